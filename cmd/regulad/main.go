@@ -0,0 +1,130 @@
+// Command regulad serves the Regula service defined in
+// proto/regula/v1/regula.proto over the Connect protocol, so other services
+// can ingest, query, analyze, and match regulations without exec'ing the
+// regula CLI.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/coolbeans/regula/pkg/service"
+	"github.com/coolbeans/regula/pkg/usage"
+)
+
+var version = "dev"
+
+func main() {
+	cmd := &cobra.Command{
+		Use:   "regulad",
+		Short: "Serve regula ingest, query, impact, and match operations",
+		Long: `regulad serves the Regula service defined in proto/regula/v1/regula.proto
+over the Connect protocol (https://connectrpc.com) with the JSON codec, so
+Connect clients generated from the proto file can call it.
+
+Each RPC is a POST of its JSON-encoded request message to the method path,
+with Content-Type application/json:
+
+  POST /regula.v1.Regula/Ingest   {"document_id": "gdpr", "text": "..."}
+  POST /regula.v1.Regula/Query    {"graph_id": "gdpr", "query": "SELECT ..."}
+  POST /regula.v1.Regula/Impact   {"graph_id": "gdpr", "provision": "Art17"}
+  POST /regula.v1.Regula/Match    {"graph_id": "gdpr", "scenario": "access_request"}
+
+Ingest returns a graph ID (the document ID) that the other methods take.
+Documents given with --preload are ingested at startup, with their file
+name (without extension) as the graph ID.
+
+With --usage-file, the provisions each request consults are counted and
+saved to that file; report them with 'regula usage hotspots --file'.
+
+Examples:
+  regulad
+  regulad --addr :9090 --preload testdata/gdpr.txt,testdata/ccpa.txt
+  regulad --preload testdata/gdpr.txt --usage-file usage.json`,
+		Version: version,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, _ := cmd.Flags().GetString("addr")
+			preload, _ := cmd.Flags().GetStringSlice("preload")
+			usagePath, _ := cmd.Flags().GetString("usage-file")
+
+			svc := service.New()
+			var tracker *usage.Tracker
+			if usagePath != "" {
+				var err error
+				tracker, err = usage.Open(usagePath)
+				if err != nil {
+					return err
+				}
+				svc.SetUsageTracker(tracker)
+			}
+			for _, sourcePath := range preload {
+				sourceText, err := os.ReadFile(sourcePath)
+				if err != nil {
+					return fmt.Errorf("failed to read %s: %w", sourcePath, err)
+				}
+				documentID := strings.TrimSuffix(filepath.Base(sourcePath), filepath.Ext(sourcePath))
+				response, err := svc.Ingest(context.Background(), &service.IngestRequest{
+					DocumentID: documentID,
+					Text:       string(sourceText),
+				})
+				if err != nil {
+					return fmt.Errorf("failed to ingest %s: %w", sourcePath, err)
+				}
+				fmt.Fprintf(os.Stderr, "Loaded %s (%d triples)\n", response.GraphID, response.Triples)
+			}
+
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", addr, err)
+			}
+			fmt.Fprintf(os.Stderr, "Serving regula.v1.Regula at http://%s\n", listener.Addr())
+
+			server := &http.Server{
+				Handler:           service.NewHandler(svc),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			if tracker == nil {
+				return server.Serve(listener)
+			}
+
+			// Flush usage periodically and once more on shutdown
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			flushDone := make(chan error, 1)
+			go func() { flushDone <- tracker.Run(ctx, 30*time.Second) }()
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				server.Shutdown(shutdownCtx)
+			}()
+			if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+				stop()
+				<-flushDone
+				return err
+			}
+			if err := <-flushDone; err != nil {
+				return fmt.Errorf("failed to save usage: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("addr", "localhost:9090", "Address to listen on")
+	cmd.Flags().StringSlice("preload", []string{}, "Source documents to ingest at startup (comma-separated)")
+	cmd.Flags().String("usage-file", "", "Record provision and template usage to this file (see 'regula usage hotspots')")
+
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -4,12 +4,10 @@ go 1.25.3
 
 require (
 	github.com/spf13/cobra v1.10.2
+	github.com/spf13/pflag v1.0.9
+	golang.org/x/sys v0.40.0
+	gopkg.in/fsnotify.v1 v1.4.7
 	gopkg.in/yaml.v3 v3.0.1
 )
 
-require (
-	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/pflag v1.0.9 // indirect
-	golang.org/x/sys v0.40.0 // indirect
-	gopkg.in/fsnotify.v1 v1.4.7 // indirect
-)
+require github.com/inconshreveable/mousetrap v1.1.0 // indirect
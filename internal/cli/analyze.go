@@ -0,0 +1,414 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/analysis/metrics"
+	"github.com/coolbeans/regula/pkg/inforce"
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+func analyzeCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Graph analytics over regulation reference graphs",
+	}
+
+	cmd.AddCommand(analyzeCentralityCmd(app))
+	cmd.AddCommand(analyzeCyclesCmd(app))
+	cmd.AddCommand(analyzeDefinitionsCmd(app))
+	cmd.AddCommand(analyzeOrphansCmd(app))
+	cmd.AddCommand(analyzeDeadlinesCmd(app))
+
+	return cmd
+}
+
+func analyzeCentralityCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "centrality",
+		Short: "Rank the structurally most important provisions",
+		Long: `Rank provisions by their position in the cross-reference graph.
+
+For each provision, the graph of reg:references links yields:
+  - PageRank: cited by provisions that are themselves heavily cited
+  - Betweenness: lies on many shortest citation paths between others
+  - In/out degree: number of provisions citing it, and it cites
+  - Community: a cluster of provisions that cite one another densely,
+    found with the Louvain method
+
+PageRank is the default ranking. Unlike a count of incoming references, it
+weighs who is citing: a definitions article cited from every chapter ranks
+above one cited many times from a single annex.
+
+Without --source, the ready documents in the library are analyzed as one
+graph, or only those listed with --documents.
+
+Examples:
+  regula analyze centrality --source gdpr.txt
+  regula analyze centrality --source gdpr.txt --by betweenness --format table
+  regula analyze centrality --documents eu-gdpr,uk-dpa2018 --limit 20 --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documents, _ := cmd.Flags().GetString("documents")
+			rankByStr, _ := cmd.Flags().GetString("by")
+			limit, _ := cmd.Flags().GetInt("limit")
+			damping, _ := cmd.Flags().GetFloat64("damping")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			rankBy, err := metrics.ParseRankBy(rankByStr)
+			if err != nil {
+				return err
+			}
+			if damping <= 0 || damping >= 1 {
+				return &usageError{err: fmt.Errorf("--damping must be between 0 and 1 (exclusive)")}
+			}
+
+			tripleStore, err := loadAnalysisStore(cmd.Context(), source, libraryPath, documents)
+			if err != nil {
+				return err
+			}
+
+			report := metrics.Analyze(tripleStore, metrics.Options{
+				RankBy:  rankBy,
+				Limit:   limit,
+				Damping: damping,
+			})
+
+			switch formatStr {
+			case "json":
+				data, err := report.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize report: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+			case "table":
+				fmt.Fprint(app.Stdout, report.FormatTable())
+			default:
+				fmt.Fprint(app.Stdout, report.String())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document path (default: the library)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("documents", "", "Comma-separated library document IDs to analyze (default: all ready documents)")
+	cmd.Flags().String("by", string(metrics.RankPageRank), "Metric to rank by (pagerank, betweenness, in-degree, out-degree)")
+	cmd.Flags().Int("limit", 20, "Maximum number of provisions to list (0 = all)")
+	cmd.Flags().Float64("damping", metrics.DefaultDamping, "PageRank damping factor")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text, table, json)")
+
+	return cmd
+}
+
+func analyzeCyclesCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cycles",
+		Short: "Find circular references and definitions",
+		Long: `Find groups of provisions and defined terms that depend on each other
+in a circle.
+
+Reference cycles are provisions that cite one another, directly (Article 6
+cites Article 9, which cites Article 6) or through others. Definition cycles
+are defined terms whose definitions use each other, so that neither can be
+understood without the other.
+
+Each cycle is reported as a shortest loop through the group. When more
+provisions reach one another than the loop shows, the group size is noted.
+
+Without --source, the ready documents in the library are analyzed as one
+graph, or only those listed with --documents. Terms are only linked to
+terms defined in the same document.
+
+The same check can run as an optional validation gate with
+  regula validate --check gates --cycle-gate
+
+Examples:
+  regula analyze cycles --source gdpr.txt
+  regula analyze cycles --documents eu-gdpr,uk-dpa2018 --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documents, _ := cmd.Flags().GetString("documents")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			tripleStore, err := loadAnalysisStore(cmd.Context(), source, libraryPath, documents)
+			if err != nil {
+				return err
+			}
+
+			report := metrics.FindCycles(tripleStore)
+
+			switch formatStr {
+			case "json":
+				data, err := report.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize report: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+			default:
+				fmt.Fprint(app.Stdout, report.String())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document path (default: the library)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("documents", "", "Comma-separated library document IDs to analyze (default: all ready documents)")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
+
+	return cmd
+}
+
+func analyzeDefinitionsCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "definitions",
+		Short: "Show which defined terms each definition depends on",
+		Long: `Show the dependency graph of defined terms: the other defined terms each
+definition is written in, and the terms definitions put in quotes without
+defining them, such as GDPR's ‘data subject’.
+
+Longer terms are matched first, so a definition using "personal data"
+does not also depend on "data". Terms are only linked to terms defined in
+the same document. Ingest records the same links as reg:definitionUses,
+which the definition-dependencies query template lists.
+
+Formats:
+  text    Most used terms, undefined terms, and each definition's dependencies
+  json    The full report
+  dot     Graphviz DOT graph (pipe to 'dot -Tsvg' for rendering)
+
+Without --source, the ready documents in the library are analyzed, or only
+those listed with --documents.
+
+Examples:
+  regula analyze definitions --source gdpr.txt
+  regula analyze definitions --source gdpr.txt --format dot | dot -Tsvg -o definitions.svg
+  regula query --source gdpr.txt --template definition-dependencies`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documents, _ := cmd.Flags().GetString("documents")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			tripleStore, err := loadAnalysisStore(cmd.Context(), source, libraryPath, documents)
+			if err != nil {
+				return err
+			}
+
+			report := metrics.FindDefinitionDependencies(tripleStore)
+
+			switch formatStr {
+			case "json":
+				data, err := report.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize report: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+			case "dot":
+				fmt.Fprint(app.Stdout, report.ToDOT())
+			case "text":
+				fmt.Fprint(app.Stdout, report.String())
+			default:
+				return fmt.Errorf("unknown format: %s (use text, json, or dot)", formatStr)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document path (default: the library)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("documents", "", "Comma-separated library document IDs to analyze (default: all ready documents)")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text, json, dot)")
+
+	return cmd
+}
+
+func analyzeOrphansCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "orphans",
+		Short: "List unreferenced provisions and dangling references",
+		Long: `List provisions that nothing refers to and internal references whose
+targets do not exist, for cleanup work.
+
+Findings are classified by severity:
+  error    an internal reference whose target is not in the document or
+           library, such as a citation of a repealed or renumbered article
+  warning  a reference that matches several targets, or a provision with
+           no references in or out
+  info     a provision that cites others but is never cited itself
+
+Without --source, the ready documents in the library are analyzed as one
+graph, so a provision cited only from another document is not an orphan.
+External references are not checked.
+
+Examples:
+  regula analyze orphans --source gdpr.txt
+  regula analyze orphans --source gdpr.txt --min-severity warning
+  regula analyze orphans --documents eu-gdpr,uk-dpa2018 --format markdown -o orphans.md`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documents, _ := cmd.Flags().GetString("documents")
+			minSeverityStr, _ := cmd.Flags().GetString("min-severity")
+			formatStr, _ := cmd.Flags().GetString("format")
+			outputPath, _ := cmd.Flags().GetString("output")
+
+			minSeverity, err := metrics.ParseSeverity(minSeverityStr)
+			if err != nil {
+				return err
+			}
+
+			tripleStore, err := loadAnalysisStore(cmd.Context(), source, libraryPath, documents)
+			if err != nil {
+				return err
+			}
+
+			report := metrics.FindOrphans(tripleStore).Filter(minSeverity)
+
+			var output string
+			switch formatStr {
+			case "json":
+				data, err := report.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize report: %w", err)
+				}
+				output = string(data) + "\n"
+			case "markdown", "md":
+				output = report.ToMarkdown()
+			default:
+				output = report.String()
+			}
+
+			if outputPath != "" {
+				if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+					return fmt.Errorf("failed to write report: %w", err)
+				}
+				fmt.Fprintf(app.messageWriter(), "Report written to %s\n", outputPath)
+				return nil
+			}
+			fmt.Fprint(app.Stdout, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document path (default: the library)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("documents", "", "Comma-separated library document IDs to analyze (default: all ready documents)")
+	cmd.Flags().String("min-severity", string(metrics.SeverityInfo), "Lowest severity to report (error, warning, info)")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text, json, markdown)")
+	cmd.Flags().StringP("output", "o", "", "Write the report to a file instead of stdout")
+
+	return cmd
+}
+
+func analyzeDeadlinesCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deadlines",
+		Short: "List upcoming statutory review and sunset deadlines",
+		Long: `List the dates regulations set for themselves: mandated reviews and
+reports, and sunset clauses.
+
+Reviews are sentences that require a review, evaluation, or report by a
+date ("By 25 May 2020 and every four years thereafter, the Commission shall
+submit a report on the evaluation and review of this Regulation"). A
+repeated review is listed at its next due date. Sunsets are clauses ending
+the force of a document, chapter, or article ("This Act shall expire on 31
+December 2030"), listed at the first day it is no longer in force.
+
+Only deadlines on or after --after are listed (default: today).
+
+Without --source, the ready documents in the library are analyzed as one
+graph, or only those listed with --documents.
+
+Examples:
+  regula analyze deadlines --source gdpr.txt --after 2025-01-01
+  regula analyze deadlines --documents eu-gdpr,uk-dpa2018 --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documents, _ := cmd.Flags().GetString("documents")
+			afterStr, _ := cmd.Flags().GetString("after")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			after := time.Now().UTC().Truncate(24 * time.Hour)
+			if afterStr != "" {
+				parsed, err := time.Parse(inforce.DateLayout, afterStr)
+				if err != nil {
+					return &usageError{err: fmt.Errorf("--after must be a date in YYYY-MM-DD form")}
+				}
+				after = parsed
+			}
+
+			tripleStore, err := loadAnalysisStore(cmd.Context(), source, libraryPath, documents)
+			if err != nil {
+				return err
+			}
+
+			report := inforce.NewReasoner(tripleStore).Deadlines(after)
+
+			switch formatStr {
+			case "json":
+				data, err := report.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize report: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+			default:
+				fmt.Fprint(app.Stdout, report.String())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document path (default: the library)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("documents", "", "Comma-separated library document IDs to analyze (default: all ready documents)")
+	cmd.Flags().String("after", "", "List deadlines on or after this date, YYYY-MM-DD (default: today)")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text, json)")
+
+	return cmd
+}
+
+// loadAnalysisStore ingests source when given, and otherwise merges the
+// listed library documents, or all ready ones, into one triple store.
+func loadAnalysisStore(ctx context.Context, source, libraryPath, documents string) (*store.TripleStore, error) {
+	if source != "" {
+		loaded, err := loadAndIngest(ctx, source)
+		if err != nil {
+			return nil, err
+		}
+		return loaded.store, nil
+	}
+
+	lib, err := library.Open(libraryPath)
+	if err != nil {
+		return nil, fmt.Errorf("library not found at %s (use --source to analyze a file): %w", libraryPath, err)
+	}
+	var documentIDs []string
+	for _, documentID := range strings.Split(documents, ",") {
+		if documentID = strings.TrimSpace(documentID); documentID != "" {
+			documentIDs = append(documentIDs, documentID)
+		}
+	}
+	if len(documentIDs) == 0 {
+		documentIDs = lib.ReadyDocumentIDs()
+	}
+	tripleStore, err := lib.LoadMergedTripleStore(documentIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load library documents: %w", err)
+	}
+	return tripleStore, nil
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/analysis/metrics"
+	"github.com/coolbeans/regula/pkg/inforce"
+)
+
+func TestAnalyzeCentralityCmd(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "analyze", "centrality", "--source", testdataPath(t, "gdpr.txt"),
+		"--by", "in-degree", "--limit", "5", "--format", "json")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+
+	var report metrics.Report
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if report.RankedBy != metrics.RankInDegree || len(report.Provisions) != 5 || report.Edges == 0 {
+		t.Fatalf("report = %+v", report)
+	}
+	for i := 1; i < len(report.Provisions); i++ {
+		if report.Provisions[i].InDegree > report.Provisions[i-1].InDegree {
+			t.Errorf("provisions not ranked by in-degree: %+v", report.Provisions)
+		}
+	}
+	if len(report.Communities) == 0 {
+		t.Error("expected communities in the GDPR reference graph")
+	}
+
+	if _, _, code := runCLI(t, "analyze", "centrality", "--source", testdataPath(t, "gdpr.txt"), "--by", "closeness"); code == 0 {
+		t.Error("expected an unknown metric to fail")
+	}
+}
+
+func TestAnalyzeCyclesCmd(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "analyze", "cycles", "--source", testdataPath(t, "gdpr.txt"), "--format", "json")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+
+	var report metrics.CycleReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if len(report.ReferenceCycles) == 0 || report.Terms == 0 {
+		t.Fatalf("report = %+v", report)
+	}
+	for _, cycle := range report.ReferenceCycles {
+		if len(cycle.Path) < 3 || cycle.Path[0] != cycle.Path[len(cycle.Path)-1] {
+			t.Errorf("cycle path does not close: %v", cycle.Path)
+		}
+	}
+
+	stdout, _, code = runCLI(t, "validate", "--source", testdataPath(t, "gdpr.txt"), "--check", "gates", "--cycle-gate")
+	if code != 0 || !strings.Contains(stdout, "Gate cycles") {
+		t.Errorf("expected the cycle gate in the gate report (exit %d):\n%s", code, stdout)
+	}
+}
+
+func TestAnalyzeDefinitionsCmd(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "analyze", "definitions", "--source", testdataPath(t, "gdpr.txt"), "--format", "json")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+
+	var report metrics.DefinitionReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if len(report.Terms) != 26 || report.Links == 0 {
+		t.Fatalf("report = %+v", report)
+	}
+	if len(report.Undefined) == 0 || report.Undefined[0].Term != "data subject" {
+		t.Errorf("undefined = %+v, want data subject", report.Undefined)
+	}
+
+	stdout, _, code = runCLI(t, "analyze", "definitions", "--source", testdataPath(t, "gdpr.txt"), "--format", "dot")
+	if code != 0 || !strings.HasPrefix(stdout, "digraph DefinitionDependencies {") {
+		t.Errorf("expected a DOT graph (exit %d):\n%s", code, stdout)
+	}
+
+	stdout, _, code = runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--template", "definition-dependencies", "--format", "csv")
+	if code != 0 || !strings.Contains(stdout, "processor,controller") {
+		t.Errorf("expected definition-dependencies rows (exit %d):\n%s", code, stdout)
+	}
+}
+
+func TestAnalyzeOrphansCmd(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "analyze", "orphans", "--source", testdataPath(t, "gdpr.txt"),
+		"--min-severity", "warning", "--format", "json")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+
+	var report metrics.OrphanReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if report.Provisions == 0 || report.References == 0 || len(report.Orphans) == 0 {
+		t.Fatalf("report = %+v", report)
+	}
+	for _, orphan := range report.Orphans {
+		if orphan.Severity == metrics.SeverityInfo {
+			t.Errorf("info finding survived --min-severity warning: %+v", orphan)
+		}
+	}
+
+	stdout, _, code = runCLI(t, "analyze", "orphans", "--source", testdataPath(t, "gdpr.txt"), "--format", "markdown")
+	if code != 0 || !strings.Contains(stdout, "## Unreferenced Provisions") {
+		t.Errorf("expected a Markdown report (exit %d):\n%s", code, stdout)
+	}
+
+	if _, _, code := runCLI(t, "analyze", "orphans", "--source", testdataPath(t, "gdpr.txt"), "--min-severity", "fatal"); code == 0 {
+		t.Error("expected an unknown severity to fail")
+	}
+}
+
+func TestAnalyzeDeadlinesCmd(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "analyze", "deadlines", "--source", testdataPath(t, "gdpr.txt"),
+		"--after", "2025-01-01", "--format", "json")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+
+	var report inforce.DeadlineReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if len(report.Deadlines) != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	// Article 97 requires a report by 25 May 2020 and every four years after.
+	review := report.Deadlines[0]
+	if review.Kind != inforce.DeadlineReview || review.Date != "2028-05-25" || review.Bearer != "Commission" {
+		t.Errorf("review = %+v", review)
+	}
+
+	if _, _, code := runCLI(t, "analyze", "deadlines", "--source", testdataPath(t, "gdpr.txt"), "--after", "May 2025"); code == 0 {
+		t.Error("expected a malformed --after date to fail")
+	}
+}
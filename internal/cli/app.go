@@ -0,0 +1,199 @@
+// Package cli implements the regula command tree. Each command group lives
+// in its own file; cmd/regula only wires an App to the process and runs
+// Execute.
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"github.com/coolbeans/regula/pkg/i18n"
+	"github.com/coolbeans/regula/pkg/textutil"
+	"github.com/coolbeans/regula/pkg/vcr"
+	"github.com/spf13/cobra"
+)
+
+// Version is the regula version reported by --version. cmd/regula
+// overrides it with the version stamped into release builds.
+var Version = "0.1.0"
+
+// App is the environment commands run in. Commands receive it when they are
+// constructed and use its streams instead of the process's, so tests can
+// run them against buffers. Commands keep no state between runs; each one
+// loads the graph it queries.
+type App struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// stdout holds Stdout while --json captures or --quiet discards it.
+	stdout io.Writer
+
+	// captured collects the output of a --json run.
+	captured *bytes.Buffer
+
+	// cassette records the run's HTTP interactions with --vcr-record.
+	cassette *vcr.Recorder
+}
+
+// NewApp returns an App on the process's standard streams.
+func NewApp() *App {
+	return &App{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}
+}
+
+// ExitError ends a command with a non-zero exit status and no further
+// message, for commands that report their outcome in their output and
+// signal it to CI through the exit code.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// exitWithCode returns an ExitError for code, silencing cobra's error and
+// usage output for it.
+func exitWithCode(cmd *cobra.Command, code int) error {
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return &ExitError{Code: code}
+}
+
+// Execute runs the regula command line with args and returns the process
+// exit status: ExitOK, ExitFailure, ExitUsage, or a command-specific
+// status.
+func Execute(app *App, args []string) int {
+	jsonOutput := jsonRequested(args)
+	if jsonOutput {
+		// Capture before building the tree so cobra's own output (help,
+		// --version) lands in the result too.
+		app.captureOutput()
+	}
+	rootCmd := NewRootCmd(app)
+	rootCmd.SetArgs(args)
+	// Keep cobra's usage text out of the result on errors, and leave the
+	// error itself to Execute so it is printed once.
+	rootCmd.SilenceUsage = jsonOutput
+	rootCmd.SilenceErrors = true
+	ctx, restoreTelemetry := withTelemetrySession(context.Background())
+	defer restoreTelemetry()
+	// --http-config and the vcr flags replace the default transport for
+	// the run; restore it so one run does not leak into the next.
+	defer func(transport http.RoundTripper) {
+		if http.DefaultTransport != transport {
+			http.DefaultTransport = transport
+		}
+	}(http.DefaultTransport)
+
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	if app.cassette != nil {
+		if closeErr := app.cassette.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		app.cassette = nil
+	}
+	code := exitCode(err)
+	var exitErr *ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		fmt.Fprintln(app.Stderr, "Error:", err)
+	}
+	commandPath := rootCmd.Name()
+	if cmd != nil {
+		commandPath = cmd.CommandPath()
+	}
+	app.finishOutput(commandPath, err, code)
+	return code
+}
+
+// statusWriter returns where progress and status messages go: stderr for
+// ndjson and xlsx output, so that stdout carries only records or the
+// workbook, and messageWriter otherwise.
+func (app *App) statusWriter(formatStr string) io.Writer {
+	if formatStr == "ndjson" || formatStr == "xlsx" {
+		return app.Stderr
+	}
+	return app.messageWriter()
+}
+
+// messageWriter returns where confirmations such as "exported to" go:
+// stderr with --json, so that the result document holds only the
+// command's data, and stdout otherwise.
+func (app *App) messageWriter() io.Writer {
+	if app.captured != nil {
+		return app.Stderr
+	}
+	return app.Stdout
+}
+
+// progressWriter returns where progress bars go: stderr, or nil under
+// --json and --quiet, which ask for the result alone.
+func (app *App) progressWriter() io.Writer {
+	if app.stdout != nil {
+		return nil
+	}
+	return app.Stderr
+}
+
+// writeWorkbook writes an .xlsx workbook to outputPath, reporting where it
+// went as what, or to stdout when outputPath is empty. A workbook is binary,
+// so it is not written to a terminal.
+func (app *App) writeWorkbook(data []byte, outputPath, what string) error {
+	if outputPath != "" {
+		if err := os.WriteFile(outputPath, data, 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+		fmt.Fprintf(app.messageWriter(), "%s exported to: %s\n", what, outputPath)
+		return nil
+	}
+	if file, ok := app.Stdout.(*os.File); ok {
+		if info, err := file.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			return fmt.Errorf("refusing to write an xlsx workbook to a terminal: use --output <file>.xlsx or redirect stdout")
+		}
+	}
+	_, err := app.Stdout.Write(data)
+	return err
+}
+
+// interruptContext returns a context derived from parent, normally
+// cmd.Context(), that is also cancelled by the first SIGINT, for
+// long-running commands that checkpoint their progress before exiting. The
+// signal handler is released on that first interrupt, so a second Ctrl+C
+// terminates the process immediately. Call stop when the command finishes.
+func interruptContext(parent context.Context) (context.Context, context.CancelFunc) {
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+// defaultLibraryPath returns the default library location.
+func defaultLibraryPath() string {
+	return ".regula"
+}
+
+// outputTranslator returns the translator for report output, chosen by the
+// --lang flag, $REGULA_LANG, or the library's config.yaml.
+func outputTranslator(cmd *cobra.Command, libraryPath string) (*i18n.Translator, error) {
+	langFlag, _ := cmd.Flags().GetString("lang")
+	language, err := i18n.ResolveLanguage(langFlag, libraryPath)
+	if err != nil {
+		return nil, err
+	}
+	return i18n.New(language)
+}
+
+func truncateString(inputStr string, maxLength int) string {
+	return textutil.Truncate(inputStr, maxLength)
+}
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// shortModeFixtureLimit is the size above which -short skips a fixture.
+// Ingesting the full GDPR text takes minutes under -race, so with -short
+// the race detector can cover the package in CI.
+const shortModeFixtureLimit = 100 << 10
+
+// testdataPath returns the path of a file in the repository's testdata.
+// Under -short it skips the test if the file is a full regulation text.
+func testdataPath(t *testing.T, name string) string {
+	t.Helper()
+	path := raceTestdataPath(t, name)
+	if info, err := os.Stat(path); err == nil && testing.Short() && !info.IsDir() && info.Size() > shortModeFixtureLimit {
+		t.Skipf("skipping %s (%d KB) in short mode", name, info.Size()>>10)
+	}
+	return path
+}
+
+// raceTestdataPath is testdataPath without the short-mode skip, for the
+// tests of concurrent runs that the race detector is there to check.
+func raceTestdataPath(t *testing.T, name string) string {
+	t.Helper()
+	path := filepath.Join("..", "..", "testdata", name)
+	if _, err := os.Stat(path); err != nil {
+		t.Skipf("test data not available: %v", err)
+	}
+	return path
+}
+
+// runCLI runs regula with args against in-memory streams and returns what
+// it wrote to stdout and stderr, and its exit status.
+func runCLI(t *testing.T, args ...string) (string, string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	app := &App{Stdin: strings.NewReader(""), Stdout: &stdout, Stderr: &stderr}
+	code := Execute(app, args)
+	return stdout.String(), stderr.String(), code
+}
+
+func TestExecute_Version(t *testing.T) {
+	stdout, _, code := runCLI(t, "--version")
+	if code != 0 || !strings.Contains(stdout, "regula version "+Version) {
+		t.Errorf("--version = %d %q", code, stdout)
+	}
+}
+
+func TestExecute_UnknownCommand(t *testing.T) {
+	_, stderr, code := runCLI(t, "frobnicate")
+	if code != ExitUsage || !strings.Contains(stderr, `unknown command "frobnicate"`) {
+		t.Errorf("unknown command = %d %q", code, stderr)
+	}
+}
+
+// A failing command prints its error once on stderr, with or without --json.
+func TestExecute_ErrorPrintedOnce(t *testing.T) {
+	for _, args := range [][]string{
+		{"query", "--source", "does-not-exist.txt", "SELECT ?s WHERE { ?s ?p ?o }"},
+		{"--json", "query", "--source", "does-not-exist.txt", "SELECT ?s WHERE { ?s ?p ?o }"},
+	} {
+		_, stderr, code := runCLI(t, args...)
+		if code != ExitFailure {
+			t.Errorf("%v: exit status = %d, want %d", args, code, ExitFailure)
+		}
+		if count := strings.Count(stderr, "does-not-exist.txt"); count != 1 {
+			t.Errorf("%v: error printed %d times on stderr:\n%s", args, count, stderr)
+		}
+	}
+}
+
+func TestExecute_ExitError(t *testing.T) {
+	scenarioPath := filepath.Join(t.TempDir(), "forbidden.yaml")
+	scenario := `name: Erasure request
+actions:
+  - type: request_erasure
+    actor: data_subject
+expect:
+  forbidden_matches: [Art17]
+`
+	if err := os.WriteFile(scenarioPath, []byte(scenario), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr, code := runCLI(t, "simulate", "--scenario", scenarioPath, "--source", testdataPath(t, "gdpr.txt"), "--assert")
+	if code != 1 {
+		t.Fatalf("exit status = %d, want 1\n%s", code, stderr)
+	}
+	if !strings.Contains(stdout, "1 failed") {
+		t.Errorf("stdout missing the failed summary:\n%s", stdout)
+	}
+	if stderr != "" {
+		t.Errorf("an exit status should not print an error, got %q", stderr)
+	}
+}
+
+// Commands share no graph state, so concurrent runs over different
+// documents do not see each other's graphs.
+func TestExecute_ConcurrentCommandsAreIsolated(t *testing.T) {
+	sources := map[string]string{
+		"GDPR": raceTestdataPath(t, "gdpr.txt"),
+		"CCPA": raceTestdataPath(t, "ccpa.txt"),
+	}
+
+	var wg sync.WaitGroup
+	outputs := make(map[string]string)
+	var mu sync.Mutex
+	for name, source := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var stdout, stderr bytes.Buffer
+			app := &App{Stdin: strings.NewReader(""), Stdout: &stdout, Stderr: &stderr}
+			Execute(app, []string{"query", "--source", source, "--format", "csv", "SELECT ?a WHERE { ?a rdf:type reg:Article } LIMIT 3"})
+			mu.Lock()
+			outputs[name] = stdout.String()
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if !strings.Contains(outputs["GDPR"], "GDPR:Art") || strings.Contains(outputs["GDPR"], "CCPA:") {
+		t.Errorf("GDPR query output:\n%s", outputs["GDPR"])
+	}
+	if strings.Contains(outputs["CCPA"], "GDPR:") {
+		t.Errorf("CCPA query saw the GDPR graph:\n%s", outputs["CCPA"])
+	}
+}
+
+// The http section of the library config applies only for the run that
+// loaded it.
+func TestExecute_HTTPConfig(t *testing.T) {
+	libraryPath := t.TempDir()
+	if _, stderr, code := runCLI(t, "library", "init", "--path", libraryPath); code != 0 {
+		t.Fatalf("library init: %s", stderr)
+	}
+	configPath := filepath.Join(libraryPath, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("http:\n  proxy: proxy.corp.example\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, stderr, code := runCLI(t, "status", "--path", libraryPath)
+	if code != 1 || !strings.Contains(stderr, "invalid http config") {
+		t.Errorf("status with an invalid http config = %d %q", code, stderr)
+	}
+
+	if err := os.WriteFile(configPath, []byte("http:\n  retry:\n    max_retries: 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	original := http.DefaultTransport
+	if _, stderr, code := runCLI(t, "status", "--path", libraryPath); code != 0 {
+		t.Fatalf("status: %s", stderr)
+	}
+	if http.DefaultTransport != original {
+		t.Error("the configured transport outlived the run")
+	}
+
+	_, stderr, code = runCLI(t, "status", "--path", libraryPath, "--http-config", filepath.Join(libraryPath, "missing.yaml"))
+	if code != 1 || !strings.Contains(stderr, "http config not found") {
+		t.Errorf("status with a missing --http-config = %d %q", code, stderr)
+	}
+}
@@ -0,0 +1,772 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/bulk"
+	"github.com/coolbeans/regula/pkg/httpcache"
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/spf13/cobra"
+)
+
+func bulkCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bulk",
+		Short: "Bulk download and ingest legislation from official sources",
+		Long: `Download and ingest legislation data in bulk from 9 sources:
+
+  uscode        US Code XML from uscode.house.gov (54 titles)
+  cfr           Code of Federal Regulations from govinfo.gov (50 titles)
+  california    California codes from leginfo.legislature.ca.gov (30 codes)
+  newyork       New York consolidated laws from legislation.nysenate.gov (API key)
+  texas         Texas statutes from statutes.capitol.texas.gov (28 codes)
+  washington    Revised Code of Washington from app.leg.wa.gov
+  municipal     City codes from Municode and American Legal (experimental crawler)
+  archive       State code archives from Internet Archive govlaw collection
+  parliamentary Congressional rules: House Rules, Senate Rules, Joint Rules
+
+Workflow:
+  1. regula bulk list <source>          List available datasets
+  2. regula bulk download <source>      Download archives to .regula/downloads/
+  3. regula bulk ingest --source <src>  Parse downloaded files and add to library
+  4. regula bulk status                 Check download/ingest progress
+  5. regula bulk stats                  Show comprehensive ingestion statistics
+  6. regula bulk verify                 Detect corrupted or truncated downloads
+  7. regula bulk sync                   Refresh changed datasets and report section changes`,
+	}
+
+	cmd.AddCommand(bulkListCmd(app))
+	cmd.AddCommand(bulkDownloadCmd(app))
+	cmd.AddCommand(bulkIngestCmd(app))
+	cmd.AddCommand(bulkStatusCmd(app))
+	cmd.AddCommand(bulkStatsCmd(app))
+	cmd.AddCommand(bulkVerifyCmd(app))
+	cmd.AddCommand(bulkSyncCmd(app))
+
+	return cmd
+}
+
+func bulkListCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list <source>",
+		Short: "List available datasets from a bulk source",
+		Long: `List all available datasets from a bulk legislation source.
+
+Sources: uscode, cfr, california, newyork, texas, washington, municipal, archive, parliamentary
+
+Examples:
+  regula bulk list uscode         List all 54 US Code titles
+  regula bulk list cfr            List all 50 CFR titles
+  regula bulk list california     List all 30 California codes
+  regula bulk list texas          List all 28 Texas codes
+  regula bulk list municipal      List the supported city codes
+  regula bulk list archive        List Internet Archive govlaw items
+  regula bulk list parliamentary  List House Rules, Senate Rules, Joint Rules
+  regula bulk list uscode --format json  List the US Code titles as JSON`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourceName := args[0]
+			yearFlag, _ := cmd.Flags().GetString("year")
+			formatFlag, _ := cmd.Flags().GetString("format")
+
+			downloadConfig := bulk.DefaultDownloadConfig()
+			if yearFlag != "" {
+				downloadConfig.CFRYear = yearFlag
+			}
+
+			source, err := bulk.ResolveSource(sourceName, downloadConfig)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(app.Stderr, "Listing datasets from %s: %s\n\n", source.Name(), source.Description())
+
+			datasets, err := source.ListDatasets()
+			if err != nil {
+				return fmt.Errorf("failed to list datasets: %w", err)
+			}
+
+			switch formatFlag {
+			case "json":
+				fmt.Fprintln(app.Stdout, bulk.FormatDatasetsJSON(datasets))
+			default:
+				fmt.Fprint(app.Stdout, bulk.FormatDatasetTable(datasets))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("year", "", "CFR edition year (default: 2024)")
+	cmd.Flags().String("format", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+func bulkDownloadCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "download <source>",
+		Short: "Download legislation archives from a bulk source",
+		Long: `Download legislation data from a bulk source to .regula/downloads/.
+
+Files are downloaded with resume support: existing files are skipped and
+interrupted downloads continue from their .part file via HTTP range
+requests. A manifest.json tracks all completed downloads with their
+SHA-256 checksums (see 'regula bulk verify').
+
+Sources: uscode, cfr, california, newyork, texas, washington, municipal, archive, parliamentary
+
+Examples:
+  regula bulk download uscode                     Download all 54 USC title ZIPs
+  regula bulk download uscode --titles 42,26      Download specific titles
+  regula bulk download cfr --year 2024            Download all CFR for 2024
+  regula bulk download california --titles CIV,PEN Download specific CA codes
+  regula bulk download newyork --ny-api-key KEY   Download NY consolidated laws
+  regula bulk download washington --titles 19     Download RCW Title 19
+  regula bulk download municipal --titles seattle Crawl the Seattle Municipal Code
+  regula bulk download parliamentary              Download all congressional rules
+  regula bulk download uscode --concurrency 4     Download 4 titles in parallel
+  regula bulk download uscode --dry-run           Show what would be downloaded`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourceName := args[0]
+			titlesFlag, _ := cmd.Flags().GetString("titles")
+			yearFlag, _ := cmd.Flags().GetString("year")
+			rateLimitFlag, _ := cmd.Flags().GetString("rate-limit")
+			dryRunFlag, _ := cmd.Flags().GetBool("dry-run")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			concurrencyFlag, _ := cmd.Flags().GetInt("concurrency")
+			httpCacheDir, _ := cmd.Flags().GetString("http-cache")
+			formatFlag, _ := cmd.Flags().GetString("format")
+
+			downloadConfig := bulk.DefaultDownloadConfig()
+			downloadConfig.DownloadDirectory = filepath.Join(libraryPath, "downloads")
+			downloadConfig.HTTPCacheDir = httpCacheDir
+			downloadConfig.DryRun = dryRunFlag
+			downloadConfig.Progress = app.progressWriter()
+			if concurrencyFlag < 1 {
+				return &usageError{err: fmt.Errorf("--concurrency must be at least 1")}
+			}
+			downloadConfig.Concurrency = concurrencyFlag
+
+			if yearFlag != "" {
+				downloadConfig.CFRYear = yearFlag
+			}
+			if rateLimitFlag != "" {
+				parsedDuration, err := time.ParseDuration(rateLimitFlag)
+				if err != nil {
+					return fmt.Errorf("invalid rate limit %q: %w", rateLimitFlag, err)
+				}
+				downloadConfig.RateLimit = parsedDuration
+			}
+			if titlesFlag != "" {
+				downloadConfig.TitleFilter = strings.Split(titlesFlag, ",")
+			}
+
+			source, err := bulk.ResolveSource(sourceName, downloadConfig)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(app.Stderr, "Source: %s — %s\n", source.Name(), source.Description())
+
+			datasets, err := source.ListDatasets()
+			if err != nil {
+				return fmt.Errorf("failed to list datasets: %w", err)
+			}
+
+			// Apply title filter
+			if len(downloadConfig.TitleFilter) > 0 {
+				var filteredDatasets []bulk.Dataset
+				for _, dataset := range datasets {
+					for _, filterTitle := range downloadConfig.TitleFilter {
+						if strings.Contains(
+							strings.ToLower(dataset.Identifier),
+							strings.ToLower(strings.TrimSpace(filterTitle))) {
+							filteredDatasets = append(filteredDatasets, dataset)
+							break
+						}
+					}
+				}
+				datasets = filteredDatasets
+			}
+
+			if len(datasets) == 0 {
+				fmt.Fprintln(app.Stderr, "No datasets match the filter.")
+				return nil
+			}
+
+			if dryRunFlag {
+				fmt.Fprintf(app.Stderr, "\nDry run: would download %d datasets\n\n", len(datasets))
+				switch formatFlag {
+				case "json":
+					fmt.Fprintln(app.Stdout, bulk.FormatDatasetsJSON(datasets))
+				default:
+					fmt.Fprint(app.Stdout, bulk.FormatDatasetTable(datasets))
+				}
+				return nil
+			}
+
+			downloader, err := bulk.NewDownloader(downloadConfig)
+			if err != nil {
+				return fmt.Errorf("failed to initialize downloader: %w", err)
+			}
+
+			ctx, stop := interruptContext(cmd.Context())
+			defer stop()
+
+			fmt.Fprintf(app.Stderr, "\nDownloading %d datasets to %s\n\n", len(datasets), downloadConfig.DownloadDirectory)
+
+			var downloadedCount, skippedCount, failedCount, completedCount int
+			downloader.DownloadDatasets(ctx, source, datasets, func(dataset bulk.Dataset, result *bulk.DownloadResult, err error) {
+				completedCount++
+				fmt.Fprintf(app.Stderr, "[%d/%d] %s\n", completedCount, len(datasets), dataset.DisplayName)
+
+				if err != nil {
+					fmt.Fprintf(app.Stderr, "  ERROR: %v\n", err)
+					failedCount++
+					return
+				}
+				if result.Skipped {
+					fmt.Fprintf(app.Stderr, "  Skipped (already downloaded: %s)\n", bulk.FormatBytes(result.BytesWritten))
+					skippedCount++
+				} else {
+					fmt.Fprintf(app.Stderr, "  Downloaded: %s\n", bulk.FormatBytes(result.BytesWritten))
+					downloadedCount++
+				}
+			})
+
+			if ctx.Err() != nil {
+				if err := downloader.SaveManifest(); err != nil {
+					return fmt.Errorf("failed to save manifest: %w", err)
+				}
+				fmt.Fprintf(app.Stderr, "\nInterrupted: %d downloaded, %d skipped, %d failed, %d not started (of %d total)\n",
+					downloadedCount, skippedCount, failedCount, len(datasets)-completedCount, len(datasets))
+				return fmt.Errorf("download interrupted; re-run to resume partial downloads")
+			}
+
+			fmt.Fprintf(app.Stderr, "\nDone: %d downloaded, %d skipped, %d failed (of %d total)\n",
+				downloadedCount, skippedCount, failedCount, len(datasets))
+			if formatFlag == "json" {
+				summary, err := json.MarshalIndent(map[string]int{
+					"downloaded": downloadedCount,
+					"skipped":    skippedCount,
+					"failed":     failedCount,
+					"total":      len(datasets),
+				}, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to serialize JSON: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(summary))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("titles", "", "Comma-separated title/code filter (e.g., '42,26' or 'CIV,PEN')")
+	cmd.Flags().String("year", "", "CFR edition year (default: 2024)")
+	cmd.Flags().String("rate-limit", "", "Minimum interval between requests per domain (default: 3s)")
+	cmd.Flags().Bool("dry-run", false, "Show what would be downloaded without fetching")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("ny-api-key", "", "NY Senate Open Legislation API key (default: $NYSENATE_API_KEY)")
+	cmd.Flags().Int("concurrency", 1, "Number of datasets to download in parallel")
+	cmd.Flags().String("http-cache", httpcache.DefaultDir, "HTTP cache directory for conditional re-fetching (empty disables)")
+	cmd.Flags().String("format", "table", "Output format (table, json); json prints the dry-run datasets or the download counts")
+
+	return cmd
+}
+
+func bulkIngestCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ingest",
+		Short: "Ingest downloaded bulk data into the library",
+		Long: `Parse downloaded bulk legislation files and add them to the library.
+
+Downloads must be completed first via 'regula bulk download'.
+Each downloaded file is parsed (XML, text, or archive) and ingested
+as a library document with extracted RDF triples.
+
+Examples:
+  regula bulk ingest --source uscode              Ingest downloaded USC files
+  regula bulk ingest --source cfr                 Ingest downloaded CFR files
+  regula bulk ingest --source california          Ingest California codes
+  regula bulk ingest --all                        Ingest all downloaded sources
+  regula bulk ingest --source uscode --titles 42  Ingest specific title
+  regula bulk ingest --dry-run --all              Show what would be ingested
+  regula bulk ingest --force --source uscode      Re-ingest even if already in library
+  regula bulk ingest --all --workers 8            Ingest on 8 workers
+
+With --workers N, files are extracted and ingested concurrently and
+committed to the library in manifest order, so the library is the same
+for any worker count. Progress is reported on stderr.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourceFilter, _ := cmd.Flags().GetString("source")
+			allSources, _ := cmd.Flags().GetBool("all")
+			titlesFlag, _ := cmd.Flags().GetString("titles")
+			forceFlag, _ := cmd.Flags().GetBool("force")
+			dryRunFlag, _ := cmd.Flags().GetBool("dry-run")
+			formatFlag, _ := cmd.Flags().GetString("format")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			workers, _ := cmd.Flags().GetInt("workers")
+
+			if sourceFilter == "" && !allSources {
+				return fmt.Errorf("specify --source <name> or --all")
+			}
+			if workers < 1 {
+				return &usageError{err: fmt.Errorf("--workers must be at least 1, got %d", workers)}
+			}
+
+			downloadDirectory := filepath.Join(libraryPath, "downloads")
+
+			ingestConfig := bulk.IngestConfig{
+				LibraryPath:       libraryPath,
+				DownloadDirectory: downloadDirectory,
+				SourceFilter:      sourceFilter,
+				Force:             forceFlag,
+				DryRun:            dryRunFlag,
+				BaseURI:           "https://regula.dev/regulations/",
+				Workers:           workers,
+				Progress: func(progress bulk.IngestProgress) {
+					fmt.Fprintf(app.Stderr, "[%d/%d] %s %s (%s)\n", progress.Completed, progress.Total,
+						progress.Entry.DocumentID, progress.Entry.Status, progress.Elapsed.Round(time.Millisecond))
+				},
+			}
+			if titlesFlag != "" {
+				ingestConfig.TitleFilter = strings.Split(titlesFlag, ",")
+			}
+
+			// Open or initialize library
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				lib, err = library.Init(libraryPath, ingestConfig.BaseURI)
+				if err != nil {
+					return fmt.Errorf("failed to open library at %s: %w", libraryPath, err)
+				}
+			}
+
+			ingester := bulk.NewBulkIngester(ingestConfig, lib)
+
+			var report *bulk.IngestReport
+
+			if allSources {
+				fmt.Fprintf(app.Stderr, "Ingesting all downloaded sources from %s\n", downloadDirectory)
+				report, err = ingester.IngestAll(downloadDirectory)
+			} else {
+				fmt.Fprintf(app.Stderr, "Ingesting source %q from %s\n", sourceFilter, downloadDirectory)
+				report, err = ingester.IngestSource(sourceFilter, downloadDirectory)
+			}
+
+			if err != nil {
+				return fmt.Errorf("ingest failed: %w", err)
+			}
+
+			switch formatFlag {
+			case "json":
+				fmt.Fprint(app.Stdout, bulk.FormatIngestReportJSON(report))
+			default:
+				fmt.Fprint(app.Stdout, bulk.FormatIngestReport(report))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("source", "", "Source to ingest (uscode, cfr, california, newyork, texas, washington, municipal, archive)")
+	cmd.Flags().Bool("all", false, "Ingest all downloaded sources")
+	cmd.Flags().String("titles", "", "Comma-separated title filter (e.g., '42,26')")
+	cmd.Flags().Bool("force", false, "Re-ingest documents even if already in library")
+	cmd.Flags().Bool("dry-run", false, "Show what would be ingested without adding to library")
+	cmd.Flags().String("format", "table", "Output format (table, json)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().Int("workers", 1, "Number of files to ingest concurrently")
+
+	return cmd
+}
+
+func bulkStatusCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show bulk download and ingest status",
+		Long: `Display the current state of bulk downloads and ingestion.
+
+Shows per-source download counts, file sizes, ingest status, and statistics.
+
+Examples:
+  regula bulk status                  Show all download/ingest status
+  regula bulk status --source uscode  Show status for USC only
+  regula bulk status --format json    Per-download statistics as JSON`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourceFilter, _ := cmd.Flags().GetString("source")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			formatFlag, _ := cmd.Flags().GetString("format")
+
+			downloadDirectory := filepath.Join(libraryPath, "downloads")
+			manifestPath := filepath.Join(downloadDirectory, "manifest.json")
+
+			manifest, err := bulk.LoadManifest(manifestPath)
+			if err != nil {
+				return fmt.Errorf("failed to load download manifest: %w", err)
+			}
+
+			// Load library document stats if available
+			var documentStats map[string]*bulk.DocumentStatsSummary
+			lib, libErr := library.Open(libraryPath)
+			if libErr == nil {
+				documentStats = make(map[string]*bulk.DocumentStatsSummary)
+				for _, doc := range lib.ListDocuments() {
+					summary := &bulk.DocumentStatsSummary{
+						Status: string(doc.Status),
+					}
+					if doc.Stats != nil {
+						summary.Triples = doc.Stats.TotalTriples
+						summary.Articles = doc.Stats.Articles
+						summary.Chapters = doc.Stats.Chapters
+					}
+					documentStats[doc.ID] = summary
+				}
+			}
+
+			switch formatFlag {
+			case "json":
+				fmt.Fprintln(app.Stdout, bulk.FormatStatsJSON(bulk.CollectStats(manifest.FilterSource(sourceFilter), documentStats)))
+			default:
+				fmt.Fprint(app.Stdout, bulk.FormatStatusReport(manifest, sourceFilter, documentStats))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("source", "", "Filter status to a specific source")
+	cmd.Flags().String("format", "table", "Output format (table, json)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+
+	return cmd
+}
+
+func bulkStatsCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show comprehensive statistics for ingested bulk data",
+		Long: `Display a detailed breakdown of ingested bulk legislation data.
+
+Shows per-title metrics (triples, articles, chapters, definitions,
+cross-references, rights, obligations), aggregate totals, and
+titles ingested vs. total.
+
+Supports table, JSON, CSV, and Excel (xlsx) output formats.
+
+Examples:
+  regula bulk stats                          Show stats as ASCII table
+  regula bulk stats --format json            Output as JSON
+  regula bulk stats --format csv             Output as CSV
+  regula bulk stats --format xlsx -o stats.xlsx  Output as an Excel workbook
+  regula bulk stats --source uscode          Filter to USC only`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourceFilter, _ := cmd.Flags().GetString("source")
+			formatFlag, _ := cmd.Flags().GetString("format")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			outputPath, _ := cmd.Flags().GetString("output")
+
+			downloadDirectory := filepath.Join(libraryPath, "downloads")
+			manifestPath := filepath.Join(downloadDirectory, "manifest.json")
+
+			manifest, err := bulk.LoadManifest(manifestPath)
+			if err != nil {
+				return fmt.Errorf("failed to load download manifest: %w", err)
+			}
+
+			// Apply source filter to manifest if specified
+			if sourceFilter != "" {
+				filteredManifest := bulk.NewDownloadManifest()
+				for _, record := range manifest.Downloads {
+					if record.SourceName == sourceFilter {
+						filteredManifest.RecordDownload(record)
+					}
+				}
+				manifest = filteredManifest
+			}
+
+			// Load library document stats
+			var documentStats map[string]*bulk.DocumentStatsSummary
+			lib, libErr := library.Open(libraryPath)
+			if libErr == nil {
+				documentStats = make(map[string]*bulk.DocumentStatsSummary)
+				for _, doc := range lib.ListDocuments() {
+					summary := &bulk.DocumentStatsSummary{
+						Status:      string(doc.Status),
+						DisplayName: doc.Name,
+						IngestedAt:  doc.IngestedAt,
+						Source:      doc.SourceInfo,
+					}
+					if doc.Stats != nil {
+						summary.Triples = doc.Stats.TotalTriples
+						summary.Articles = doc.Stats.Articles
+						summary.Chapters = doc.Stats.Chapters
+						summary.Definitions = doc.Stats.Definitions
+						summary.References = doc.Stats.References
+						summary.Rights = doc.Stats.Rights
+						summary.Obligations = doc.Stats.Obligations
+					}
+					documentStats[doc.ID] = summary
+				}
+			}
+
+			report := bulk.CollectStats(manifest, documentStats)
+
+			switch formatFlag {
+			case "json":
+				fmt.Fprintln(app.Stdout, bulk.FormatStatsJSON(report))
+			case "csv":
+				fmt.Fprint(app.Stdout, bulk.FormatStatsCSV(report))
+			case "xlsx":
+				data, err := bulk.FormatStatsXLSX(report)
+				if err != nil {
+					return fmt.Errorf("failed to build workbook: %w", err)
+				}
+				return app.writeWorkbook(data, outputPath, "Statistics")
+			default:
+				fmt.Fprint(app.Stdout, bulk.FormatStatsTable(report))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("format", "table", "Output format (table, json, csv, xlsx)")
+	cmd.Flags().StringP("output", "o", "", "Write xlsx statistics to a file instead of stdout")
+	cmd.Flags().String("source", "", "Filter statistics to a specific source")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+
+	return cmd
+}
+
+func bulkVerifyCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify downloaded archives against recorded checksums",
+		Long: `Check every download recorded in manifest.json for corruption.
+
+Each file is compared against its recorded size and SHA-256 checksum, and
+ZIP and tar.gz archives are read end to end so truncated or damaged
+entries surface. Downloads recorded before checksums were tracked are
+reported as "no-checksum" after the structural checks.
+
+Exits with an error if any download is missing, truncated, or corrupt.
+Delete the affected files and re-run 'regula bulk download' to refetch.
+
+Examples:
+  regula bulk verify                   Verify all downloads
+  regula bulk verify --source uscode   Verify USC downloads only
+  regula bulk verify --format json     Output as JSON`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourceFilter, _ := cmd.Flags().GetString("source")
+			formatFlag, _ := cmd.Flags().GetString("format")
+			libraryPath, _ := cmd.Flags().GetString("path")
+
+			manifestPath := filepath.Join(libraryPath, "downloads", "manifest.json")
+			manifest, err := bulk.LoadManifest(manifestPath)
+			if err != nil {
+				return fmt.Errorf("failed to load download manifest: %w", err)
+			}
+
+			report := bulk.VerifyManifest(manifest, sourceFilter)
+
+			switch formatFlag {
+			case "json":
+				fmt.Fprintln(app.Stdout, bulk.FormatVerifyJSON(report))
+			default:
+				fmt.Fprint(app.Stdout, bulk.FormatVerifyTable(report))
+			}
+
+			if !report.Passed() {
+				return fmt.Errorf("%d download(s) failed verification", report.Failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("source", "", "Verify only a specific source")
+	cmd.Flags().String("format", "table", "Output format (table, json)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+
+	return cmd
+}
+
+func bulkSyncCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync [source]",
+		Short: "Re-download and re-ingest datasets that changed upstream",
+		Long: `Refresh previously downloaded datasets and emit a change feed.
+
+For every dataset in manifest.json, the remote metadata is compared
+against what was recorded at download time:
+
+  - a new US Code release point (or any other change of download URL)
+  - a different ETag, or Last-Modified when no ETag is available
+  - a different size for downloads recorded before validators were stored
+
+Only changed datasets are downloaded again and re-ingested into the
+library. The change feed lists, per document, which sections were added,
+removed, or modified since the previous sync. Datasets that were never
+downloaded are not fetched; use 'regula bulk download' for those.
+
+Sync is safe to run unattended, e.g. from cron:
+  0 3 * * 1  regula bulk sync --feed /var/log/regula/changes-$(date +\%F).json
+
+Examples:
+  regula bulk sync                         Sync every downloaded source
+  regula bulk sync uscode                  Sync US Code titles only
+  regula bulk sync uscode --titles 42      Sync a single title
+  regula bulk sync --dry-run               Report what changed without fetching
+  regula bulk sync --format json           Output the change feed as JSON
+  regula bulk sync --feed changes.json     Also write the change feed to a file`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			titlesFlag, _ := cmd.Flags().GetString("titles")
+			rateLimitFlag, _ := cmd.Flags().GetString("rate-limit")
+			dryRunFlag, _ := cmd.Flags().GetBool("dry-run")
+			formatFlag, _ := cmd.Flags().GetString("format")
+			feedPath, _ := cmd.Flags().GetString("feed")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			httpCacheDir, _ := cmd.Flags().GetString("http-cache")
+
+			downloadConfig := bulk.DefaultDownloadConfig()
+			downloadConfig.DownloadDirectory = filepath.Join(libraryPath, "downloads")
+			downloadConfig.HTTPCacheDir = httpCacheDir
+			downloadConfig.Progress = app.progressWriter()
+			if rateLimitFlag != "" {
+				parsedDuration, err := time.ParseDuration(rateLimitFlag)
+				if err != nil {
+					return fmt.Errorf("invalid rate limit %q: %w", rateLimitFlag, err)
+				}
+				downloadConfig.RateLimit = parsedDuration
+			}
+			if titlesFlag != "" {
+				downloadConfig.TitleFilter = strings.Split(titlesFlag, ",")
+			}
+
+			downloader, err := bulk.NewDownloader(downloadConfig)
+			if err != nil {
+				return fmt.Errorf("failed to initialize downloader: %w", err)
+			}
+
+			sourceNames := args
+			if len(sourceNames) == 0 {
+				for _, sourceName := range bulk.AllSourceNames() {
+					if downloader.Manifest().CountBySource(sourceName) > 0 {
+						sourceNames = append(sourceNames, sourceName)
+					}
+				}
+			}
+			if len(sourceNames) == 0 {
+				fmt.Fprintln(app.Stderr, "No downloads to sync. Run 'regula bulk download <source>' first.")
+				return nil
+			}
+
+			ingestConfig := bulk.IngestConfig{
+				LibraryPath:       libraryPath,
+				DownloadDirectory: downloadConfig.DownloadDirectory,
+				DryRun:            dryRunFlag,
+				BaseURI:           "https://regula.dev/regulations/",
+			}
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				lib, err = library.Init(libraryPath, ingestConfig.BaseURI)
+				if err != nil {
+					return fmt.Errorf("failed to open library at %s: %w", libraryPath, err)
+				}
+			}
+
+			ctx, stop := interruptContext(cmd.Context())
+			defer stop()
+
+			syncer := bulk.NewSyncer(downloader, lib, ingestConfig)
+			combined := &bulk.SyncReport{StartedAt: time.Now()}
+
+			for _, sourceName := range sourceNames {
+				if ctx.Err() != nil {
+					combined.Interrupted = true
+					break
+				}
+				source, err := bulk.ResolveSource(sourceName, downloadConfig)
+				if err != nil {
+					return err
+				}
+
+				datasets, err := source.ListDatasets()
+				if err != nil {
+					return fmt.Errorf("failed to list %s datasets: %w", sourceName, err)
+				}
+				if len(downloadConfig.TitleFilter) > 0 {
+					var filteredDatasets []bulk.Dataset
+					for _, dataset := range datasets {
+						for _, filterTitle := range downloadConfig.TitleFilter {
+							if strings.Contains(
+								strings.ToLower(dataset.Identifier),
+								strings.ToLower(strings.TrimSpace(filterTitle))) {
+								filteredDatasets = append(filteredDatasets, dataset)
+								break
+							}
+						}
+					}
+					datasets = filteredDatasets
+				}
+
+				fmt.Fprintf(app.Stderr, "Checking %s (%d downloaded datasets)\n",
+					sourceName, downloader.Manifest().CountBySource(sourceName))
+				report := syncer.SyncDatasets(ctx, source, datasets)
+
+				combined.Checked += report.Checked
+				combined.Updated += report.Updated
+				combined.Pending += report.Pending
+				combined.Unchanged += report.Unchanged
+				combined.Failed += report.Failed
+				combined.SectionsAdded += report.SectionsAdded
+				combined.SectionsRemoved += report.SectionsRemoved
+				combined.SectionsModified += report.SectionsModified
+				combined.Entries = append(combined.Entries, report.Entries...)
+				combined.Interrupted = combined.Interrupted || report.Interrupted
+			}
+			combined.CompletedAt = time.Now()
+
+			if feedPath != "" {
+				if err := os.WriteFile(feedPath, []byte(bulk.FormatSyncJSON(combined)+"\n"), 0644); err != nil {
+					return fmt.Errorf("failed to write change feed: %w", err)
+				}
+				fmt.Fprintf(app.Stderr, "Change feed written to %s\n", feedPath)
+			}
+
+			switch formatFlag {
+			case "json":
+				fmt.Fprintln(app.Stdout, bulk.FormatSyncJSON(combined))
+			default:
+				fmt.Fprint(app.Stdout, bulk.FormatSyncTable(combined))
+			}
+
+			if combined.Interrupted {
+				return fmt.Errorf("sync interrupted; re-run to check the remaining datasets")
+			}
+			if combined.Failed > 0 {
+				return fmt.Errorf("%d dataset(s) failed to sync", combined.Failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("titles", "", "Comma-separated title/code filter (e.g., '42,26' or 'CIV,PEN')")
+	cmd.Flags().String("rate-limit", "", "Minimum interval between requests per domain (default: 3s)")
+	cmd.Flags().Bool("dry-run", false, "Detect changes without downloading or re-ingesting")
+	cmd.Flags().String("format", "table", "Output format (table, json)")
+	cmd.Flags().String("feed", "", "Also write the change feed as JSON to this file")
+	cmd.Flags().String("http-cache", httpcache.DefaultDir, "HTTP cache directory for conditional re-fetching (empty disables)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("ny-api-key", "", "NY Senate Open Legislation API key (default: $NYSENATE_API_KEY)")
+
+	return cmd
+}
+
+// --- Draft legislation analysis commands ---
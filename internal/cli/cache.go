@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/httpcache"
+	"github.com/spf13/cobra"
+)
+
+func cacheCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect and clear the persistent HTTP cache",
+		Long: `Inspect and clear the on-disk HTTP cache shared by crawl, validate
+--check links, ingest --fetch-refs, and bulk download/sync.
+
+Responses with an ETag or Last-Modified header are stored and revalidated
+with conditional requests, so unchanged documents are not downloaded again.
+Commands that use the cache take --http-cache to choose its directory;
+an empty value disables it.`,
+	}
+
+	cmd.AddCommand(cacheStatsCmd(app))
+	cmd.AddCommand(cacheClearCmd(app))
+
+	return cmd
+}
+
+func cacheStatsCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show HTTP cache size, hits, and domains",
+		Long: `Show the number of cached responses, their total size, how many requests
+they answered, and the cached domains.
+
+Examples:
+  regula cache stats
+  regula cache stats --format json
+  regula cache stats --dir /tmp/regula-http-cache`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cacheDir, _ := cmd.Flags().GetString("dir")
+			formatFlag, _ := cmd.Flags().GetString("format")
+
+			cache, err := httpcache.Open(cacheDir)
+			if err != nil {
+				return fmt.Errorf("failed to open HTTP cache: %w", err)
+			}
+			stats, err := cache.Stats()
+			if err != nil {
+				return fmt.Errorf("failed to read HTTP cache: %w", err)
+			}
+
+			if formatFlag == "json" {
+				data, err := json.MarshalIndent(stats, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+				return nil
+			}
+			fmt.Fprint(app.Stdout, formatHTTPCacheStats(stats))
+			return nil
+		},
+	}
+
+	cmd.Flags().String("dir", httpcache.DefaultDir, "HTTP cache directory")
+	cmd.Flags().String("format", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+func cacheClearCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Remove cached HTTP responses",
+		Long: `Remove every cached response, or only those for one domain.
+
+Examples:
+  regula cache clear
+  regula cache clear --domain eur-lex.europa.eu`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cacheDir, _ := cmd.Flags().GetString("dir")
+			domain, _ := cmd.Flags().GetString("domain")
+
+			cache, err := httpcache.Open(cacheDir)
+			if err != nil {
+				return fmt.Errorf("failed to open HTTP cache: %w", err)
+			}
+			removed, err := cache.Clear(domain)
+			if err != nil {
+				return fmt.Errorf("failed to clear HTTP cache: %w", err)
+			}
+
+			if domain != "" {
+				fmt.Fprintf(app.messageWriter(), "Removed %d cached response(s) for %s from %s\n", removed, domain, cacheDir)
+			} else {
+				fmt.Fprintf(app.messageWriter(), "Removed %d cached response(s) from %s\n", removed, cacheDir)
+			}
+			return app.writeJSONResult(map[string]int{"removed": removed})
+		},
+	}
+
+	cmd.Flags().String("dir", httpcache.DefaultDir, "HTTP cache directory")
+	cmd.Flags().String("domain", "", "Only remove responses for this domain")
+
+	return cmd
+}
+
+// formatHTTPCacheStats formats HTTP cache statistics for the terminal.
+func formatHTTPCacheStats(stats *httpcache.Stats) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("HTTP cache: %s\n\n", stats.Dir))
+	builder.WriteString(fmt.Sprintf("Entries: %d\n", stats.Entries))
+	builder.WriteString(fmt.Sprintf("Size:    %s\n", formatCacheBytes(stats.BodyBytes)))
+	builder.WriteString(fmt.Sprintf("Hits:    %d\n", stats.Hits))
+	if stats.Entries == 0 {
+		return builder.String()
+	}
+	builder.WriteString(fmt.Sprintf("Oldest:  %s\n", stats.Oldest.Local().Format("2006-01-02 15:04")))
+	builder.WriteString(fmt.Sprintf("Newest:  %s\n", stats.Newest.Local().Format("2006-01-02 15:04")))
+
+	builder.WriteString("\nDomains:\n")
+	for _, domain := range stats.SortedDomains() {
+		builder.WriteString(fmt.Sprintf("  %-40s %d\n", domain, stats.Domains[domain]))
+	}
+	return builder.String()
+}
+
+// formatCacheBytes formats a byte count with a binary unit.
+func formatCacheBytes(byteCount int64) string {
+	switch {
+	case byteCount >= 1<<30:
+		return fmt.Sprintf("%.1f GiB", float64(byteCount)/(1<<30))
+	case byteCount >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(byteCount)/(1<<20))
+	case byteCount >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(byteCount)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", byteCount)
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/httpcache"
+)
+
+func TestCacheCmd_StatsAndClear(t *testing.T) {
+	cacheDir := t.TempDir()
+	cache, err := httpcache.Open(cacheDir)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	for _, url := range []string{
+		"https://eur-lex.europa.eu/legal-content/EN/TXT/HTML/?uri=CELEX:32016R0679",
+		"https://www.legislation.gov.uk/ukpga/2018/12/data.xht",
+	} {
+		entry := &httpcache.Entry{Method: http.MethodGet, URL: url, StatusCode: http.StatusOK, ETag: `"v1"`, StoredAt: time.Now()}
+		if err := cache.Put(entry, []byte("document")); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	stdout, stderr, code := runCLI(t, "cache", "stats", "--dir", cacheDir, "--format", "json")
+	if code != 0 {
+		t.Fatalf("cache stats exit %d: %s", code, stderr)
+	}
+	var stats httpcache.Stats
+	if err := json.Unmarshal([]byte(stdout), &stats); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if stats.Entries != 2 || stats.Domains["eur-lex.europa.eu"] != 1 {
+		t.Errorf("stats = %+v, want 2 entries across 2 domains", stats)
+	}
+
+	stdout, stderr, code = runCLI(t, "cache", "clear", "--dir", cacheDir, "--domain", "eur-lex.europa.eu")
+	if code != 0 || !strings.Contains(stdout, "Removed 1 cached response(s) for eur-lex.europa.eu") {
+		t.Errorf("cache clear --domain = %d %q %q", code, stdout, stderr)
+	}
+
+	stdout, _, _ = runCLI(t, "cache", "stats", "--dir", cacheDir)
+	if !strings.Contains(stdout, "Entries: 1") || !strings.Contains(stdout, "www.legislation.gov.uk") {
+		t.Errorf("stats after clear:\n%s", stdout)
+	}
+}
@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/calendar"
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+func calendarCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "calendar",
+		Short: "Compliance calendar of recurring obligations",
+		Long: `Schedule obligations that recur (annual reports, periodic reviews,
+training). Recurrence is stored as reg:recurrence with RRULE semantics
+(FREQ, INTERVAL, BYMONTH, BYMONTHDAY). It is extracted from provision text
+("annually", "at least once a year", "by January 31 of each year") and can
+be set or overridden with a recurrence annotation file:
+
+  # gdpr.recurrence.yaml (picked up next to gdpr.txt)
+  recurrence:
+    - article: 59
+      rule: FREQ=YEARLY;BYMONTH=3;BYMONTHDAY=31
+      note: "Annual activity report"
+    - article: 35
+      obligation_type: ImpactAssessmentObligation
+      rule: FREQ=YEARLY;INTERVAL=2
+
+Series start at --anchor (default: January 1 of the current year).
+
+Examples:
+  regula calendar upcoming --source gdpr.txt
+  regula calendar upcoming --days 365 --format json
+  regula calendar export --source gdpr.txt --output obligations.ics`,
+	}
+
+	cmd.AddCommand(calendarUpcomingCmd(app))
+	cmd.AddCommand(calendarExportCmd(app))
+
+	return cmd
+}
+
+func calendarUpcomingCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upcoming",
+		Short: "List recurring obligations due soon",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			days, _ := cmd.Flags().GetInt("days")
+			fromStr, _ := cmd.Flags().GetString("from")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			from := time.Now()
+			if fromStr != "" {
+				parsed, err := time.Parse("2006-01-02", fromStr)
+				if err != nil {
+					return fmt.Errorf("invalid --from date (want YYYY-MM-DD): %w", err)
+				}
+				from = parsed
+			}
+			anchor, err := calendarAnchor(cmd, from)
+			if err != nil {
+				return err
+			}
+
+			obligations, err := loadCalendarObligations(cmd)
+			if err != nil {
+				return err
+			}
+
+			// Include obligations due on the start date itself.
+			until := from.AddDate(0, 0, days)
+			events := calendar.Upcoming(obligations, anchor, from.AddDate(0, 0, -1), until)
+
+			switch formatStr {
+			case "json":
+				fmt.Fprintln(app.Stdout, calendar.FormatUpcomingJSON(events))
+			default:
+				fmt.Fprint(app.Stdout, calendar.FormatUpcomingTable(events, from, until))
+			}
+			return nil
+		},
+	}
+
+	addCalendarSourceFlags(cmd)
+	cmd.Flags().Int("days", 90, "Number of days to look ahead")
+	cmd.Flags().String("from", "", "Start date YYYY-MM-DD (default: today)")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+func calendarExportCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export recurring obligations as an iCalendar (ICS) feed",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString("output")
+
+			now := time.Now()
+			anchor, err := calendarAnchor(cmd, now)
+			if err != nil {
+				return err
+			}
+
+			obligations, err := loadCalendarObligations(cmd)
+			if err != nil {
+				return err
+			}
+			icsOutput := calendar.FormatICS(obligations, anchor, now)
+
+			if output == "" {
+				fmt.Fprint(app.Stdout, icsOutput)
+				return nil
+			}
+			if err := os.WriteFile(output, []byte(icsOutput), 0644); err != nil {
+				return fmt.Errorf("failed to write file: %w", err)
+			}
+			fmt.Fprintf(app.messageWriter(), "iCalendar feed exported to: %s\n", output)
+			fmt.Fprintf(app.messageWriter(), "  Recurring obligations: %d\n", len(obligations))
+			return nil
+		},
+	}
+
+	addCalendarSourceFlags(cmd)
+	cmd.Flags().StringP("output", "o", "", "Output .ics file (default: stdout)")
+
+	return cmd
+}
+
+// addCalendarSourceFlags registers the graph source flags shared by the
+// calendar subcommands.
+func addCalendarSourceFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("source", "s", "", "Source document (default: all library documents)")
+	cmd.Flags().String("recurrence", "", "Recurrence annotation file for --source (default: <source>.recurrence.yaml if present)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringSlice("documents", []string{}, "Library document IDs (comma-separated, default: all)")
+	cmd.Flags().String("anchor", "", "Start of the compliance cycle YYYY-MM-DD (default: January 1 of the current year)")
+}
+
+// calendarAnchor returns the --anchor date, defaulting to January 1 of the
+// year of reference.
+func calendarAnchor(cmd *cobra.Command, reference time.Time) (time.Time, error) {
+	anchorStr, _ := cmd.Flags().GetString("anchor")
+	if anchorStr == "" {
+		return calendarYearStart(reference), nil
+	}
+	anchor, err := time.Parse("2006-01-02", anchorStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --anchor date (want YYYY-MM-DD): %w", err)
+	}
+	return anchor, nil
+}
+
+func calendarYearStart(reference time.Time) time.Time {
+	return time.Date(reference.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// loadCalendarObligations builds the graph from --source, or merges the
+// library documents, and returns its recurring obligations.
+func loadCalendarObligations(cmd *cobra.Command) ([]*calendar.RecurringObligation, error) {
+	source, _ := cmd.Flags().GetString("source")
+	recurrencePath, _ := cmd.Flags().GetString("recurrence")
+	libraryPath, _ := cmd.Flags().GetString("path")
+	documentIDs, _ := cmd.Flags().GetStringSlice("documents")
+
+	var calendarStore *store.TripleStore
+	if source != "" {
+		file, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open source: %w", err)
+		}
+		defer file.Close()
+
+		doc, err := newParserWithPatterns().Parse(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse document: %w", err)
+		}
+
+		baseURI := "https://regula.dev/regulations/"
+		semExtractor := extract.NewSemanticExtractor()
+		if _, err := applyRecurrenceAnnotations(semExtractor, source, recurrencePath); err != nil {
+			return nil, err
+		}
+		resolver := extract.NewReferenceResolver(baseURI, extractDocID(source))
+		resolver.IndexDocument(doc)
+
+		calendarStore = store.NewTripleStore()
+		builder := store.NewGraphBuilder(calendarStore, baseURI)
+		if _, err := builder.BuildComplete(doc, extract.NewDefinitionExtractor(), extract.NewReferenceExtractor(), resolver, semExtractor); err != nil {
+			return nil, fmt.Errorf("failed to build graph: %w", err)
+		}
+	} else {
+		lib, err := library.Open(libraryPath)
+		if err != nil {
+			return nil, fmt.Errorf("library not found at %s (use --source or run 'regula library init'): %w", libraryPath, err)
+		}
+		if len(documentIDs) == 0 {
+			documentIDs = lib.ReadyDocumentIDs()
+		}
+		calendarStore, err = lib.LoadMergedTripleStore(documentIDs...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	obligations, ruleErrors := calendar.CollectRecurringObligations(calendarStore)
+	for _, ruleErr := range ruleErrors {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", ruleErr)
+	}
+	return obligations, nil
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/catalog"
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/spf13/cobra"
+)
+
+func libraryCatalogCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "Show or rebuild the library metadata catalog",
+		Long: `Show the library catalog, catalog.json, a cache of document metadata
+and statistics, validation and link check history, the conflict count,
+bulk download records, and the last crawl's progress.
+
+'library list' and 'library status' read the catalog instead of loading
+each manifest; the whole catalog is read and filtered in memory. It is rebuilt automatically whenever one of its source
+files changes; --rebuild forces a rebuild, for example after editing a
+manifest by hand within the same second. Libraries in object storage are
+cataloged in memory on each use.
+
+Examples:
+  regula library catalog
+  regula library catalog --rebuild
+  regula library catalog --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			formatStr, _ := cmd.Flags().GetString("format")
+			rebuild, _ := cmd.Flags().GetBool("rebuild")
+
+			var libraryCatalog *catalog.Catalog
+			var err error
+			if rebuild && !library.IsRemotePath(libraryPath) {
+				if libraryCatalog, err = catalog.Build(libraryPath); err != nil {
+					return err
+				}
+				if err := libraryCatalog.Save(libraryPath); err != nil {
+					return err
+				}
+			} else if libraryCatalog, err = catalog.Open(libraryPath); err != nil {
+				return err
+			}
+
+			if formatStr == "json" {
+				encoder := json.NewEncoder(app.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(libraryCatalog)
+			}
+
+			fmt.Fprintf(app.Stdout, "Catalog: %s (version %s)\n", libraryPath, libraryCatalog.Version)
+			fmt.Fprintf(app.Stdout, "Built: %s\n\n", libraryCatalog.BuiltAt.Format(time.RFC3339))
+			fmt.Fprintf(app.Stdout, "Documents:    %d\n", len(libraryCatalog.Documents))
+			fmt.Fprintf(app.Stdout, "Collections:  %d\n", len(libraryCatalog.Collections))
+			fmt.Fprintf(app.Stdout, "Validations:  %d\n", len(libraryCatalog.Validations))
+			fmt.Fprintf(app.Stdout, "Link checks:  %d\n", len(libraryCatalog.LinkChecks))
+			fmt.Fprintf(app.Stdout, "Downloads:    %d\n", len(libraryCatalog.Downloads))
+			if libraryCatalog.Crawl != nil {
+				fmt.Fprintf(app.Stdout, "Last crawl:   %s\n", libraryCatalog.Crawl.Status)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+	cmd.Flags().Bool("rebuild", false, "Rebuild the catalog from the library's files")
+
+	return cmd
+}
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/simulate"
+	"github.com/spf13/cobra"
+)
+
+func checklistCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "checklist",
+		Short: "Generate a compliance checklist for a scenario",
+		Long: `Match a scenario against one or more regulations and list the
+obligations it triggers as an actionable checklist: the obligation, the
+responsible actor, any deadline stated in the provision, and the citation.
+
+Items are grouped by obligation type, so the same duty under different
+regulations (for example, breach notification under GDPR and CCPA) is
+listed together.
+
+--jurisdictions takes library document IDs or jurisdiction codes; a code
+selects every ready document of that jurisdiction. Without --jurisdictions
+or --source, all ready library documents are used.
+
+Examples:
+  regula checklist --scenario data_breach --jurisdictions eu-gdpr,us-ca-ccpa
+  regula checklist --scenario access_request --jurisdictions EU --format csv -o checklist.csv
+  regula checklist --scenario breach.yaml --source gdpr.txt`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scenarioArg, _ := cmd.Flags().GetString("scenario")
+			jurisdictions, _ := cmd.Flags().GetStringSlice("jurisdictions")
+			sources, _ := cmd.Flags().GetStringSlice("source")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			baseURI, _ := cmd.Flags().GetString("base-uri")
+			formatStr, _ := cmd.Flags().GetString("format")
+			outputPath, _ := cmd.Flags().GetString("output")
+
+			if scenarioArg == "" {
+				return &usageError{err: fmt.Errorf("--scenario flag is required")}
+			}
+			scenario, ok := simulate.PredefinedScenarios[scenarioArg]
+			if !ok {
+				loaded, err := simulate.LoadScenarioFile(scenarioArg)
+				if err != nil {
+					return fmt.Errorf("unknown scenario %q: %w", scenarioArg, err)
+				}
+				scenario = loaded
+			}
+
+			checklist := simulate.NewChecklist(scenario)
+			for _, source := range sources {
+				matcher, err := loadScenarioMatcher(source, "", libraryPath, baseURI)
+				if err != nil {
+					return fmt.Errorf("%s: %w", source, err)
+				}
+				checklist.Add(source, extractDocID(source), matcher, matcher.Match(scenario))
+			}
+
+			if len(sources) == 0 || len(jurisdictions) > 0 {
+				lib, err := library.Open(libraryPath)
+				if err != nil {
+					return fmt.Errorf("library not found at %s (use --source to check a file): %w", libraryPath, err)
+				}
+				documentIDs, err := checklistDocuments(lib, jurisdictions)
+				if err != nil {
+					return err
+				}
+				for _, documentID := range documentIDs {
+					matcher, err := loadScenarioMatcher("", documentID, libraryPath, baseURI)
+					if err != nil {
+						return fmt.Errorf("%s: %w", documentID, err)
+					}
+					entry := lib.GetDocument(documentID)
+					label := entry.ShortName
+					if label == "" {
+						label = entry.Name
+					}
+					checklist.Add(documentID, label, matcher, matcher.Match(scenario))
+				}
+			}
+
+			var output string
+			switch formatStr {
+			case "csv":
+				csvOutput, err := checklist.ToCSV()
+				if err != nil {
+					return fmt.Errorf("failed to write CSV: %w", err)
+				}
+				output = csvOutput
+			case "json":
+				data, err := checklist.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize checklist: %w", err)
+				}
+				output = string(data) + "\n"
+			case "markdown", "md":
+				output = checklist.ToMarkdown()
+			default:
+				return fmt.Errorf("unknown format %q (want markdown, csv, or json)", formatStr)
+			}
+
+			if outputPath != "" {
+				if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+					return fmt.Errorf("failed to write checklist: %w", err)
+				}
+				fmt.Fprintf(app.messageWriter(), "Checklist written to %s\n", outputPath)
+				return nil
+			}
+			fmt.Fprint(app.Stdout, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("scenario", "S", "", "Scenario file or built-in name (data_breach, access_request, etc.)")
+	cmd.Flags().StringSlice("jurisdictions", nil, "Library document IDs or jurisdiction codes (default: all ready documents)")
+	cmd.Flags().StringSliceP("source", "s", nil, "Source document to check (repeatable)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("base-uri", "https://regula.dev/regulations/", "Base URI for the graph when using --source")
+	cmd.Flags().StringP("format", "f", "markdown", "Output format (markdown, csv, json)")
+	cmd.Flags().StringP("output", "o", "", "Write the checklist to a file instead of stdout")
+
+	return cmd
+}
+
+// checklistDocuments resolves --jurisdictions values to ready library
+// document IDs. A value is a document ID or a jurisdiction code; no values
+// select every ready document.
+func checklistDocuments(lib *library.Library, selectors []string) ([]string, error) {
+	ready := lib.ReadyDocumentIDs()
+	if len(selectors) == 0 {
+		if len(ready) == 0 {
+			return nil, fmt.Errorf("library has no ready documents")
+		}
+		return ready, nil
+	}
+
+	seen := make(map[string]bool)
+	var documentIDs []string
+	add := func(documentID string) {
+		if !seen[documentID] {
+			seen[documentID] = true
+			documentIDs = append(documentIDs, documentID)
+		}
+	}
+	for _, selector := range selectors {
+		selector = strings.TrimSpace(selector)
+		if selector == "" {
+			continue
+		}
+		if entry := lib.GetDocument(selector); entry != nil {
+			if entry.Status != library.StatusReady {
+				return nil, fmt.Errorf("document %q is not ready (status: %s)", selector, entry.Status)
+			}
+			add(selector)
+			continue
+		}
+		matched := false
+		for _, documentID := range ready {
+			if strings.EqualFold(lib.GetDocument(documentID).Jurisdiction, selector) {
+				add(documentID)
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("no library document or jurisdiction matches %q", selector)
+		}
+	}
+	return documentIDs, nil
+}
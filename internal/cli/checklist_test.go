@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestChecklistCmd(t *testing.T) {
+	libraryPath := filepath.Join(t.TempDir(), "lib")
+	if _, stderr, code := runCLI(t, "library", "init", "--path", libraryPath); code != 0 {
+		t.Fatalf("library init failed: %s", stderr)
+	}
+	for _, document := range []struct{ source, id, name, jurisdiction string }{
+		{"gdpr.txt", "eu-gdpr", "GDPR", "EU"},
+		{"ccpa.txt", "us-ca-ccpa", "CCPA", "US-CA"},
+	} {
+		if _, stderr, code := runCLI(t, "library", "add", "--path", libraryPath, "--source", testdataPath(t, document.source),
+			"--id", document.id, "--name", document.name, "--jurisdiction", document.jurisdiction); code != 0 {
+			t.Fatalf("library add %s failed: %s", document.id, stderr)
+		}
+	}
+
+	stdout, stderr, code := runCLI(t, "checklist", "--scenario", "data_breach", "--path", libraryPath,
+		"--jurisdictions", "eu-gdpr,US-CA")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	for _, want := range []string{"Documents: eu-gdpr, us-ca-ccpa", "## breach notification obligation", "**GDPR Art. 33**", "without undue delay"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("checklist missing %q:\n%s", want, stdout)
+		}
+	}
+
+	stdout, stderr, code = runCLI(t, "checklist", "--scenario", "data_breach", "--source", testdataPath(t, "gdpr.txt"), "--format", "csv")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "done,obligation,actor,deadline,citation") || !strings.Contains(stdout, "GDPR Art. 34") {
+		t.Errorf("unexpected CSV:\n%s", stdout)
+	}
+
+	if _, _, code := runCLI(t, "checklist", "--scenario", "data_breach", "--path", libraryPath, "--jurisdictions", "JP"); code == 0 {
+		t.Error("expected an error for an unknown jurisdiction")
+	}
+}
@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func libraryCollectionCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "collection",
+		Short: "Manage named groups of library documents",
+		Long: `Group library documents into named collections, such as "privacy-laws"
+or "title-42-family", and scope commands to them with --collection.
+
+Every command that takes --documents also takes --collection, which loads
+the collection's documents (together with any given with --documents).
+
+Examples:
+  regula library collection create privacy-laws --description "EU and US state privacy laws"
+  regula library collection add privacy-laws eu-gdpr us-ca-ccpa us-va-vcdpa
+  regula library collection list
+  regula library query --collection privacy-laws --template rights
+  regula compare obligations --collection privacy-laws`,
+	}
+
+	cmd.AddCommand(libraryCollectionCreateCmd(app))
+	cmd.AddCommand(libraryCollectionAddCmd(app))
+	cmd.AddCommand(libraryCollectionRemoveCmd(app))
+	cmd.AddCommand(libraryCollectionListCmd(app))
+	cmd.AddCommand(libraryCollectionDeleteCmd(app))
+
+	return cmd
+}
+
+func libraryCollectionCreateCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <name> [document-id...]",
+		Short: "Create a collection",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			description, _ := cmd.Flags().GetString("description")
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+			collection, err := lib.CreateCollection(args[0], description)
+			if err != nil {
+				return fmt.Errorf("failed to create collection: %w", err)
+			}
+			if len(args) > 1 {
+				if collection, err = lib.AddToCollection(args[0], args[1:]...); err != nil {
+					return fmt.Errorf("failed to add documents: %w", err)
+				}
+			}
+
+			fmt.Fprintf(app.messageWriter(), "Created collection %s (%d document(s))\n", collection.Name, len(collection.Documents))
+			return app.writeJSONResult(collection)
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("description", "", "What the collection groups")
+
+	return cmd
+}
+
+func libraryCollectionAddCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <name> <document-id>...",
+		Short: "Add documents to a collection",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+			collection, err := lib.AddToCollection(args[0], args[1:]...)
+			if err != nil {
+				return fmt.Errorf("failed to add documents: %w", err)
+			}
+
+			fmt.Fprintf(app.messageWriter(), "Collection %s: %s\n", collection.Name, strings.Join(collection.Documents, ", "))
+			return app.writeJSONResult(collection)
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+
+	return cmd
+}
+
+func libraryCollectionRemoveCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <name> <document-id>...",
+		Short: "Remove documents from a collection",
+		Long:  "Remove documents from a collection. The documents stay in the library.",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+			collection, err := lib.RemoveFromCollection(args[0], args[1:]...)
+			if err != nil {
+				return fmt.Errorf("failed to remove documents: %w", err)
+			}
+
+			fmt.Fprintf(app.messageWriter(), "Collection %s: %s\n", collection.Name, strings.Join(collection.Documents, ", "))
+			return app.writeJSONResult(collection)
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+
+	return cmd
+}
+
+func libraryCollectionListCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list [name]",
+		Short: "List collections, or the documents of one",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+			collections := lib.ListCollections()
+			if len(args) == 1 {
+				collection := lib.GetCollection(args[0])
+				if collection == nil {
+					return fmt.Errorf("collection not found: %s", args[0])
+				}
+				collections = []*library.Collection{collection}
+			}
+
+			if formatStr == "json" {
+				encoder := json.NewEncoder(app.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(collections)
+			}
+
+			if len(collections) == 0 {
+				fmt.Fprintln(app.Stdout, "No collections. Create one with 'regula library collection create'.")
+				return nil
+			}
+			for _, collection := range collections {
+				fmt.Fprintf(app.Stdout, "%s (%d document(s))\n", collection.Name, len(collection.Documents))
+				if collection.Description != "" {
+					fmt.Fprintf(app.Stdout, "  %s\n", collection.Description)
+				}
+				for _, documentID := range collection.Documents {
+					fmt.Fprintf(app.Stdout, "  - %s\n", documentID)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+func libraryCollectionDeleteCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a collection",
+		Long:  "Delete a collection. Its documents stay in the library.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+			if err := lib.DeleteCollection(args[0]); err != nil {
+				return fmt.Errorf("failed to delete collection: %w", err)
+			}
+
+			fmt.Fprintf(app.messageWriter(), "Deleted collection %s\n", args[0])
+			return app.writeJSONResult(map[string]string{"deleted": args[0]})
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+
+	return cmd
+}
+
+// addCollectionFlags gives every command of the tree that selects library
+// documents with --documents a --collection flag, applied by
+// applyCollection.
+func addCollectionFlags(cmd *cobra.Command) {
+	if cmd.LocalFlags().Lookup("documents") != nil && cmd.LocalFlags().Lookup("path") != nil &&
+		cmd.LocalFlags().Lookup("collection") == nil {
+		cmd.Flags().String("collection", "", "Library collection whose documents to use (see 'regula library collection')")
+	}
+	for _, child := range cmd.Commands() {
+		addCollectionFlags(child)
+	}
+}
+
+// applyCollection adds the documents of --collection to --documents, so
+// commands need no collection support of their own.
+func applyCollection(cmd *cobra.Command) error {
+	collectionFlag := cmd.LocalFlags().Lookup("collection")
+	documentsFlag := cmd.LocalFlags().Lookup("documents")
+	if collectionFlag == nil || documentsFlag == nil || collectionFlag.Value.String() == "" {
+		return nil
+	}
+
+	libraryPath, _ := cmd.Flags().GetString("path")
+	lib, err := library.Open(libraryPath)
+	if err != nil {
+		return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+	}
+	collectionIDs, err := lib.CollectionDocumentIDs(collectionFlag.Value.String())
+	if err != nil {
+		return err
+	}
+
+	var documentIDs []string
+	if sliceValue, ok := documentsFlag.Value.(pflag.SliceValue); ok {
+		documentIDs = sliceValue.GetSlice()
+	} else if value := documentsFlag.Value.String(); value != "" {
+		documentIDs = strings.Split(value, ",")
+	}
+	for _, documentID := range collectionIDs {
+		if !slices.Contains(documentIDs, documentID) {
+			documentIDs = append(documentIDs, documentID)
+		}
+	}
+
+	if sliceValue, ok := documentsFlag.Value.(pflag.SliceValue); ok {
+		return sliceValue.Replace(documentIDs)
+	}
+	return documentsFlag.Value.Set(strings.Join(documentIDs, ","))
+}
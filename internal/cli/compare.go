@@ -0,0 +1,497 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/analysis"
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+func compareCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare regulation documents or House Rules versions",
+		Long: `Compare two or more regulation documents to find shared definitions,
+rights, obligations, and external reference targets.
+
+Outputs structural comparison, concept overlaps, and external reference analysis.
+
+When comparing two documents, definitions whose terms differ are aligned by
+normalization, stemming, and word overlap ("processing" ≈ "process"). A
+--synonyms crosswalk file pairs terms that share no words:
+
+  synonyms:
+    - terms: ["personal data", "personal information"]
+    - terms: ["controller", "business"]
+
+Commands:
+  rules     Compare two versions of House Rules (e.g., 118th vs 119th Congress)
+
+Example:
+  regula compare --sources testdata/gdpr.txt,testdata/ccpa.txt
+  regula compare --sources testdata/gdpr.txt,testdata/ccpa.txt --format json
+  regula compare --sources testdata/gdpr.txt,testdata/ccpa.txt --synonyms privacy-crosswalk.yaml
+  regula compare --sources testdata/gdpr.txt,testdata/ccpa.txt,testdata/eu-ai-act.txt --format dot --output comparison.dot
+  regula compare --sources testdata/gdpr.txt,testdata/ccpa.txt --format xlsx --output comparison.xlsx
+  regula compare rules --base house-rules-118th.txt --target house-rules-119th.txt`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourcesStr, _ := cmd.Flags().GetString("sources")
+			formatStr, _ := cmd.Flags().GetString("format")
+			output, _ := cmd.Flags().GetString("output")
+			synonymsPath, _ := cmd.Flags().GetString("synonyms")
+			minAlignment, _ := cmd.Flags().GetFloat64("min-alignment")
+
+			if sourcesStr == "" {
+				return &usageError{err: fmt.Errorf("--sources flag is required (comma-separated list of document paths)")}
+			}
+
+			sources := strings.Split(sourcesStr, ",")
+			if len(sources) < 2 {
+				return fmt.Errorf("at least 2 source documents are required for comparison")
+			}
+
+			// Trim whitespace from paths
+			for i := range sources {
+				sources[i] = strings.TrimSpace(sources[i])
+			}
+
+			status := app.statusWriter(formatStr)
+			fmt.Fprintf(status, "Comparing %d documents...\n\n", len(sources))
+			startTime := time.Now()
+
+			crossRefAnalyzer := analysis.NewCrossRefAnalyzer()
+			var synonyms []analysis.SynonymGroup
+			if synonymsPath != "" {
+				loaded, err := analysis.LoadSynonyms(synonymsPath)
+				if err != nil {
+					return err
+				}
+				synonyms = loaded
+			}
+			aligner := analysis.NewTermAligner(synonyms)
+			aligner.MinScore = minAlignment
+			crossRefAnalyzer.SetTermAligner(aligner)
+
+			// Ingest each document into its own store
+			for _, sourcePath := range sources {
+				if _, err := os.Stat(sourcePath); os.IsNotExist(err) {
+					return fmt.Errorf("source file not found: %s", sourcePath)
+				}
+
+				file, err := os.Open(sourcePath)
+				if err != nil {
+					return fmt.Errorf("failed to open %s: %w", sourcePath, err)
+				}
+
+				parser := newParserWithPatterns()
+				doc, err := parser.Parse(file)
+				file.Close()
+				if err != nil {
+					return fmt.Errorf("failed to parse %s: %w", sourcePath, err)
+				}
+
+				docStore := store.NewTripleStore()
+				baseURI := "https://regula.dev/regulations/"
+				builder := store.NewGraphBuilder(docStore, baseURI)
+				defExtractor := extract.NewDefinitionExtractor()
+				refExtractor := extract.NewReferenceExtractor()
+				semExtractor := extract.NewSemanticExtractor()
+
+				docID := extractDocID(sourcePath)
+				resolver := extract.NewReferenceResolver(baseURI, docID)
+				resolver.IndexDocument(doc)
+
+				_, err = builder.BuildComplete(doc, defExtractor, refExtractor, resolver, semExtractor)
+				if err != nil {
+					return fmt.Errorf("failed to build graph for %s: %w", sourcePath, err)
+				}
+
+				label := doc.Title
+				if label == "" {
+					label = docID
+				}
+				crossRefAnalyzer.AddDocument(docID, label, docStore)
+				fmt.Fprintf(status, "  Loaded %s: %d triples\n", docID, docStore.Count())
+			}
+
+			fmt.Fprintf(status, "\nAnalysis completed in %s\n\n", time.Since(startTime))
+
+			// Run analysis based on number of documents
+			if len(sources) == 2 {
+				docIDs := make([]string, 2)
+				for i, src := range sources {
+					docIDs[i] = extractDocID(src)
+				}
+				comparison := crossRefAnalyzer.CompareDocuments(docIDs[0], docIDs[1])
+
+				switch formatStr {
+				case "table":
+					fmt.Fprint(app.Stdout, comparison.String())
+				case "json":
+					jsonData, err := comparison.ToJSON()
+					if err != nil {
+						return fmt.Errorf("failed to serialize JSON: %w", err)
+					}
+					if output != "" {
+						if err := os.WriteFile(output, jsonData, 0644); err != nil {
+							return fmt.Errorf("failed to write file: %w", err)
+						}
+						fmt.Fprintf(app.messageWriter(), "Comparison exported to: %s\n", output)
+					} else {
+						fmt.Fprintln(app.Stdout, string(jsonData))
+					}
+				case "xlsx":
+					data, err := comparison.ToXLSX()
+					if err != nil {
+						return fmt.Errorf("failed to build workbook: %w", err)
+					}
+					if err := app.writeWorkbook(data, output, "Comparison"); err != nil {
+						return err
+					}
+				case "dot":
+					dotContent := comparison.ToDOT()
+					if output != "" {
+						if err := os.WriteFile(output, []byte(dotContent), 0644); err != nil {
+							return fmt.Errorf("failed to write file: %w", err)
+						}
+						fmt.Fprintf(app.messageWriter(), "DOT graph exported to: %s\n", output)
+						fmt.Fprintln(app.Stdout, "\nTo visualize with Graphviz:")
+						fmt.Fprintf(app.Stdout, "  dot -Tpng %s -o comparison.png\n", output)
+					} else {
+						fmt.Fprintln(app.Stdout, dotContent)
+					}
+				default:
+					return fmt.Errorf("unknown format: %s (use table, json, xlsx, or dot)", formatStr)
+				}
+			} else {
+				result := crossRefAnalyzer.Analyze()
+
+				switch formatStr {
+				case "table":
+					fmt.Fprint(app.Stdout, result.FormatTable())
+					fmt.Fprintln(app.Stdout)
+					fmt.Fprint(app.Stdout, result.String())
+				case "json":
+					jsonData, err := result.ToJSON()
+					if err != nil {
+						return fmt.Errorf("failed to serialize JSON: %w", err)
+					}
+					if output != "" {
+						if err := os.WriteFile(output, jsonData, 0644); err != nil {
+							return fmt.Errorf("failed to write file: %w", err)
+						}
+						fmt.Fprintf(app.messageWriter(), "Analysis exported to: %s\n", output)
+					} else {
+						fmt.Fprintln(app.Stdout, string(jsonData))
+					}
+				case "xlsx":
+					data, err := result.ToXLSX()
+					if err != nil {
+						return fmt.Errorf("failed to build workbook: %w", err)
+					}
+					if err := app.writeWorkbook(data, output, "Analysis"); err != nil {
+						return err
+					}
+				case "dot":
+					dotContent := result.ToDOT()
+					if output != "" {
+						if err := os.WriteFile(output, []byte(dotContent), 0644); err != nil {
+							return fmt.Errorf("failed to write file: %w", err)
+						}
+						fmt.Fprintf(app.messageWriter(), "DOT graph exported to: %s\n", output)
+						fmt.Fprintln(app.Stdout, "\nTo visualize with Graphviz:")
+						fmt.Fprintf(app.Stdout, "  dot -Tpng %s -o comparison.png\n", output)
+					} else {
+						fmt.Fprintln(app.Stdout, dotContent)
+					}
+				default:
+					return fmt.Errorf("unknown format: %s (use table, json, xlsx, or dot)", formatStr)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("sources", "", "Comma-separated list of source document paths")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json, xlsx, dot)")
+	cmd.Flags().StringP("output", "o", "", "Output file path")
+	cmd.Flags().String("synonyms", "", "Term crosswalk YAML file of equivalent defined terms")
+	cmd.Flags().Float64("min-alignment", analysis.DefaultMinAlignmentScore, "Lowest score at which differing defined terms are aligned")
+
+	cmd.AddCommand(compareRulesCmd(app))
+	cmd.AddCommand(compareObligationsCmd(app))
+
+	return cmd
+}
+
+func compareObligationsCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "obligations",
+		Short: "Obligation overlap matrix and gap report across documents",
+		Long: `Cluster the obligations of several documents by type and report which
+documents impose each one, for multi-jurisdiction compliance mapping.
+
+Obligations of a specific type (breach notification, record keeping, ...)
+cluster by type. Generic ones ("shall ensure", "shall implement") join the
+type whose obligations their text most resembles, when the similarity
+reaches --min-similarity. The gap report lists each obligation type with
+the documents that do not impose it.
+
+Without --sources, the ready documents in the library are compared.
+
+Examples:
+  regula compare obligations --sources testdata/gdpr.txt,testdata/ccpa.txt,testdata/vcdpa.txt
+  regula compare obligations --documents eu-gdpr,us-ca-ccpa --format markdown -o obligations.md
+  regula compare obligations --sources testdata/gdpr.txt,testdata/uk-dpa2018.txt --format csv
+  regula compare obligations --documents eu-gdpr,us-ca-ccpa --format xlsx -o obligations.xlsx`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourcesStr, _ := cmd.Flags().GetString("sources")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documents, _ := cmd.Flags().GetString("documents")
+			minSimilarity, _ := cmd.Flags().GetFloat64("min-similarity")
+			formatStr, _ := cmd.Flags().GetString("format")
+			output, _ := cmd.Flags().GetString("output")
+
+			if minSimilarity < 0 || minSimilarity > 1 {
+				return &usageError{err: fmt.Errorf("--min-similarity must be between 0 and 1, got %g", minSimilarity)}
+			}
+
+			crossRefAnalyzer, err := loadComparisonDocuments(cmd.Context(), sourcesStr, libraryPath, documents)
+			if err != nil {
+				return err
+			}
+			result := crossRefAnalyzer.AnalyzeObligationOverlap(analysis.ObligationOverlapOptions{MinSimilarity: minSimilarity})
+
+			if formatStr == "xlsx" {
+				data, err := result.ToXLSX()
+				if err != nil {
+					return fmt.Errorf("failed to build workbook: %w", err)
+				}
+				return app.writeWorkbook(data, output, "Obligation overlap")
+			}
+
+			var rendered string
+			switch formatStr {
+			case "table":
+				rendered = result.String()
+			case "markdown", "md":
+				rendered = result.ToMarkdown()
+			case "csv":
+				rendered = result.ToCSV()
+			case "json":
+				jsonData, err := result.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize JSON: %w", err)
+				}
+				rendered = string(jsonData) + "\n"
+			default:
+				return fmt.Errorf("unknown format: %s (use table, markdown, csv, json, or xlsx)", formatStr)
+			}
+
+			if output != "" {
+				if err := os.WriteFile(output, []byte(rendered), 0644); err != nil {
+					return fmt.Errorf("failed to write file: %w", err)
+				}
+				fmt.Fprintf(app.messageWriter(), "Obligation overlap exported to: %s\n", output)
+				return nil
+			}
+			fmt.Fprint(app.Stdout, rendered)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("sources", "", "Comma-separated list of source document paths (default: the library)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("documents", "", "Comma-separated library document IDs to compare (default: all ready documents)")
+	cmd.Flags().Float64("min-similarity", analysis.DefaultObligationOverlapOptions().MinSimilarity, "Lowest text similarity at which a generic obligation joins a specific type")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, markdown, csv, json, xlsx)")
+	cmd.Flags().StringP("output", "o", "", "Output file path")
+
+	return cmd
+}
+
+// loadComparisonDocuments registers each source file, or else each listed
+// library document (all ready ones by default), with a new analyzer.
+func loadComparisonDocuments(ctx context.Context, sources, libraryPath, documents string) (*analysis.CrossRefAnalyzer, error) {
+	crossRefAnalyzer := analysis.NewCrossRefAnalyzer()
+
+	if sources != "" {
+		for _, sourcePath := range strings.Split(sources, ",") {
+			sourcePath = strings.TrimSpace(sourcePath)
+			if sourcePath == "" {
+				continue
+			}
+			loaded, err := loadAndIngest(ctx, sourcePath)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", sourcePath, err)
+			}
+			docID := extractDocID(sourcePath)
+			crossRefAnalyzer.AddDocument(docID, docID, loaded.store)
+		}
+		return crossRefAnalyzer, nil
+	}
+
+	lib, err := library.Open(libraryPath)
+	if err != nil {
+		return nil, fmt.Errorf("library not found at %s (use --sources to compare files): %w", libraryPath, err)
+	}
+	var documentIDs []string
+	for _, documentID := range strings.Split(documents, ",") {
+		if documentID = strings.TrimSpace(documentID); documentID != "" {
+			documentIDs = append(documentIDs, documentID)
+		}
+	}
+	if len(documentIDs) == 0 {
+		documentIDs = lib.ReadyDocumentIDs()
+	}
+	for _, documentID := range documentIDs {
+		entry := lib.GetDocument(documentID)
+		if entry == nil {
+			return nil, fmt.Errorf("document %q not found in library", documentID)
+		}
+		tripleStore, err := lib.LoadTripleStore(documentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", documentID, err)
+		}
+		label := entry.ShortName
+		if label == "" {
+			label = entry.Name
+		}
+		crossRefAnalyzer.AddDocument(documentID, label, tripleStore)
+	}
+	return crossRefAnalyzer, nil
+}
+
+func compareRulesCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rules",
+		Short: "Compare two versions of House Rules",
+		Long: `Compare two versions of House Rules (e.g., 118th vs 119th Congress) to track
+rule changes, added/removed/modified clauses, and structural differences.
+
+The output shows:
+  - Summary of rules modified, clauses added/removed/modified
+  - Detailed changes organized by rule
+  - Similarity scores for modified clauses
+  - Change summaries (minor, moderate, substantial, major)
+
+Example:
+  regula compare rules --base house-rules-118th.txt --target house-rules-119th.txt
+  regula compare rules --base house-rules-118th.txt --target house-rules-119th.txt --format json
+  regula compare rules --base 118th.txt --target 119th.txt --threshold 80`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			basePath, _ := cmd.Flags().GetString("base")
+			targetPath, _ := cmd.Flags().GetString("target")
+			formatStr, _ := cmd.Flags().GetString("format")
+			output, _ := cmd.Flags().GetString("output")
+			threshold, _ := cmd.Flags().GetInt("threshold")
+
+			if basePath == "" || targetPath == "" {
+				return fmt.Errorf("both --base and --target flags are required")
+			}
+
+			// Read base file
+			baseContent, err := os.ReadFile(basePath)
+			if err != nil {
+				return fmt.Errorf("failed to read base file: %w", err)
+			}
+
+			// Read target file
+			targetContent, err := os.ReadFile(targetPath)
+			if err != nil {
+				return fmt.Errorf("failed to read target file: %w", err)
+			}
+
+			// Extract version labels from filenames
+			baseVersion := extractCongressLabel(basePath)
+			targetVersion := extractCongressLabel(targetPath)
+
+			// Create differ and compare
+			differ := extract.NewRulesDiffer(string(baseContent), string(targetContent))
+			report := differ.Compare(baseVersion, targetVersion)
+
+			var outputContent []byte
+
+			switch formatStr {
+			case "table", "text":
+				if threshold > 0 {
+					// Filter to significant changes only
+					significant := report.GetSignificantChanges(threshold)
+					fmt.Fprintf(app.Stdout, "Showing changes with similarity <= %d%%\n\n", threshold)
+					for _, change := range significant {
+						fmt.Fprintf(app.Stdout, "Rule %s, Clause %s: %s", change.Rule, change.Clause, change.Type)
+						if change.Summary != "" {
+							fmt.Fprintf(app.Stdout, " - %s", change.Summary)
+						}
+						if change.SimilarityScore > 0 {
+							fmt.Fprintf(app.Stdout, " (%d%% similar)", change.SimilarityScore)
+						}
+						fmt.Fprintln(app.Stdout)
+					}
+				} else {
+					fmt.Fprint(app.Stdout, report.String())
+				}
+			case "json":
+				jsonData, err := report.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize JSON: %w", err)
+				}
+				outputContent = jsonData
+				if output == "" {
+					fmt.Fprintln(app.Stdout, string(jsonData))
+				}
+			default:
+				return fmt.Errorf("unknown format: %s (use table, text, or json)", formatStr)
+			}
+
+			if output != "" && len(outputContent) > 0 {
+				if err := os.WriteFile(output, outputContent, 0644); err != nil {
+					return fmt.Errorf("failed to write output file: %w", err)
+				}
+				fmt.Fprintf(app.messageWriter(), "Report written to: %s\n", output)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("base", "", "Path to the base (older) House Rules file")
+	cmd.Flags().String("target", "", "Path to the target (newer) House Rules file")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, text, json)")
+	cmd.Flags().StringP("output", "o", "", "Output file path")
+	cmd.Flags().Int("threshold", 0, "Show only changes with similarity <= threshold (0 = show all)")
+
+	return cmd
+}
+
+// extractCongressLabel extracts a Congress label from a filename.
+func extractCongressLabel(path string) string {
+	base := filepath.Base(path)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+
+	// Try to find congress number patterns like "118th", "119th"
+	for _, suffix := range []string{"th", "st", "nd", "rd"} {
+		if idx := strings.Index(base, suffix); idx > 0 {
+			// Find the start of the number
+			start := idx
+			for start > 0 && base[start-1] >= '0' && base[start-1] <= '9' {
+				start--
+			}
+			if start < idx {
+				return base[start:idx+len(suffix)] + " Congress"
+			}
+		}
+	}
+
+	// Fall back to filename
+	return base
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/coolbeans/regula/pkg/complete"
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/spf13/cobra"
+)
+
+func completeCitationCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "complete-citation [partial-citation]",
+		Short: "Complete a partially typed citation from the library",
+		Long: `Complete a partially typed citation against the documents and provisions
+in the library, ranked best first, with their titles.
+
+Citations are matched loosely: "42 usc 13", "42 U.S.C. § 13", and
+"42 USC sec. 13" are the same query. US Code and CFR provisions complete
+in code form ("42 U.S.C. § 1320d-2"); other documents complete by short
+name ("GDPR Art. 17").
+
+With --stdio, regula runs as a minimal language server on stdin and
+stdout instead, so editors can offer citation completion as you type. It
+supports initialize, document sync, and textDocument/completion, plus a
+regula/completeCitation request taking {"query": "...", "limit": 10} for
+tools that do not track documents.
+
+Examples:
+  regula complete-citation "42 USC 13"
+  regula complete-citation "gdpr art 1" --format table
+  regula complete-citation --stdio --path .regula`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			limit, _ := cmd.Flags().GetInt("limit")
+			format, _ := cmd.Flags().GetString("format")
+			stdio, _ := cmd.Flags().GetBool("stdio")
+
+			if limit < 1 {
+				return &usageError{err: fmt.Errorf("--limit must be at least 1")}
+			}
+			if !stdio && len(args) == 0 {
+				return &usageError{err: fmt.Errorf("a partial citation is required unless --stdio is set")}
+			}
+			if format != "json" && format != "table" {
+				return fmt.Errorf("unknown format %q (use json or table)", format)
+			}
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("failed to open library at %s: %w", libraryPath, err)
+			}
+			index, err := complete.BuildIndex(lib)
+			if err != nil {
+				return fmt.Errorf("failed to build citation index: %w", err)
+			}
+
+			if stdio {
+				return complete.NewServer(index, limit, Version).Serve(app.Stdin, app.Stdout)
+			}
+
+			query := args[0]
+			completions := index.Complete(query, limit)
+			if completions == nil {
+				completions = []complete.Completion{}
+			}
+
+			if format == "json" {
+				encoder := json.NewEncoder(app.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(struct {
+					Query       string                `json:"query"`
+					Completions []complete.Completion `json:"completions"`
+				}{query, completions})
+			}
+
+			if len(completions) == 0 {
+				fmt.Fprintf(app.Stdout, "No completions for %q\n", query)
+				return nil
+			}
+			writer := tabwriter.NewWriter(app.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(writer, "CITATION\tTITLE\tDOCUMENT\tSCORE")
+			for _, completion := range completions {
+				fmt.Fprintf(writer, "%s\t%s\t%s\t%.2f\n",
+					completion.Citation, truncateString(completion.Title, 60), completion.DocumentID, completion.Score)
+			}
+			return writer.Flush()
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().Int("limit", complete.DefaultLimit, "Maximum number of completions")
+	cmd.Flags().String("format", "json", "Output format (json, table)")
+	cmd.Flags().Bool("stdio", false, "Serve completions over stdin/stdout using the Language Server Protocol")
+
+	return cmd
+}
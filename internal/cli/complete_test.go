@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/complete"
+)
+
+func TestCompleteCitationCmd(t *testing.T) {
+	sourcePath := testdataPath(t, "gdpr.txt")
+	libraryPath := filepath.Join(t.TempDir(), "lib")
+	if _, stderr, code := runCLI(t, "library", "init", "--path", libraryPath); code != 0 {
+		t.Fatalf("library init failed: %s", stderr)
+	}
+	if _, stderr, code := runCLI(t, "library", "add", "--path", libraryPath, "--source", sourcePath,
+		"--id", "eu-gdpr", "--name", "GDPR", "--jurisdiction", "EU"); code != 0 {
+		t.Fatalf("library add failed: %s", stderr)
+	}
+
+	stdout, stderr, code := runCLI(t, "complete-citation", "gdpr art 1", "--path", libraryPath, "--limit", "3")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	var result struct {
+		Query       string                `json:"query"`
+		Completions []complete.Completion `json:"completions"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if result.Query != "gdpr art 1" || len(result.Completions) != 3 {
+		t.Fatalf("result = %+v", result)
+	}
+	if first := result.Completions[0]; first.Citation != "GDPR Art. 1" || first.Title == "" || first.DocumentID != "eu-gdpr" {
+		t.Errorf("first completion = %+v", first)
+	}
+
+	stdout, _, code = runCLI(t, "complete-citation", "gdpr art 17", "--path", libraryPath, "--format", "table")
+	if code != 0 || !strings.Contains(stdout, "GDPR Art. 17") || !strings.Contains(stdout, "CITATION") {
+		t.Errorf("table output = %d %q", code, stdout)
+	}
+
+	stdout, _, code = runCLI(t, "complete-citation", "ccpa", "--path", libraryPath)
+	if code != 0 || !strings.Contains(stdout, `"completions": []`) {
+		t.Errorf("no-match output = %d %q", code, stdout)
+	}
+
+	if _, stderr, code = runCLI(t, "complete-citation", "--path", libraryPath); code != ExitUsage || !strings.Contains(stderr, "partial citation is required") {
+		t.Errorf("missing query = %d %q", code, stderr)
+	}
+}
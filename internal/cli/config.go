@@ -0,0 +1,263 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/settings"
+	"github.com/spf13/cobra"
+)
+
+func configCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "View and set default flag values",
+		Long: fmt.Sprintf(`View and set the default flag values read from the user file
+~/.regula/config.yaml and the project file .regula/config.yaml.
+
+A key is a flag name, such as base-uri, optionally scoped to a command with
+its dotted path, such as crawl.rate-limit or bulk.download.concurrency.
+Values apply to every command with the flag that is not given on the
+command line. Later sources win:
+
+  1. user defaults            (~/.regula/config.yaml, or $%s)
+  2. project defaults         (.regula/config.yaml)
+  3. the selected profile     (--config-profile, $%s, or the file's "profile" key)
+  4. environment variables    (REGULA_BASE_URI for --base-uri)
+  5. command-line flags`, settings.HomeEnv, settings.ProfileEnv),
+	}
+
+	cmd.AddCommand(configListCmd(app))
+	cmd.AddCommand(configGetCmd(app))
+	cmd.AddCommand(configSetCmd(app))
+	cmd.AddCommand(configUnsetCmd(app))
+
+	return cmd
+}
+
+// settingEntry is one effective setting as listed by config list.
+type settingEntry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+func configListCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List configured settings and where they come from",
+		Long: `List every key set in the config files with its effective value and the
+file, profile, or environment variable it comes from.
+
+Examples:
+  regula config list
+  regula config list --config-profile work
+  regula config list --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, _ := cmd.Flags().GetString("config-profile")
+			formatFlag, _ := cmd.Flags().GetString("format")
+
+			loaded, err := settings.Load(profile)
+			if err != nil {
+				return fmt.Errorf("failed to load settings: %w", err)
+			}
+			var entries []settingEntry
+			for _, key := range loaded.Keys() {
+				commandPath, flag := settings.SplitKey(key)
+				value, source, _ := loaded.Lookup(commandPath, flag)
+				entries = append(entries, settingEntry{Key: key, Value: value, Source: source})
+			}
+
+			if formatFlag == "json" {
+				data, err := json.MarshalIndent(entries, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+				return nil
+			}
+
+			fmt.Fprintf(app.Stdout, "User config:    %s\n", settings.UserPath())
+			fmt.Fprintf(app.Stdout, "Project config: %s\n", settings.ProjectPath())
+			if loaded.Profile != "" {
+				fmt.Fprintf(app.Stdout, "Profile:        %s\n", loaded.Profile)
+			}
+			fmt.Fprintln(app.Stdout)
+			if len(entries) == 0 {
+				fmt.Fprintln(app.Stdout, "No settings configured.")
+				return nil
+			}
+			for _, entry := range entries {
+				fmt.Fprintf(app.Stdout, "%-32s %-40s %s\n", entry.Key, entry.Value, entry.Source)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("format", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+func configGetCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the effective value of a setting",
+		Long: `Print the value a command would use for a flag it is not given, taking
+scoped keys, the profile, and REGULA_* environment variables into account.
+
+Examples:
+  regula config get base-uri
+  regula config get crawl.rate-limit
+  regula config get --config-profile work path
+  regula config get base-uri --format json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, _ := cmd.Flags().GetString("config-profile")
+			formatFlag, _ := cmd.Flags().GetString("format")
+
+			loaded, err := settings.Load(profile)
+			if err != nil {
+				return fmt.Errorf("failed to load settings: %w", err)
+			}
+			commandPath, flag := settings.SplitKey(args[0])
+			value, source, found := loaded.Lookup(commandPath, flag)
+			if !found {
+				return fmt.Errorf("%s is not set", args[0])
+			}
+
+			if formatFlag == "json" {
+				data, err := json.MarshalIndent(settingEntry{Key: args[0], Value: value, Source: source}, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+				return nil
+			}
+			fmt.Fprintln(app.Stdout, value)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("format", "text", "Output format (text, json)")
+
+	return cmd
+}
+
+func configSetCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a default flag value",
+		Long: `Set a default flag value in the project config, or in the user config with
+--global. With --config-profile the value is written to that profile instead of
+the defaults. The file's other sections are kept.
+
+Examples:
+  regula config set base-uri https://regula.dev/regulations/
+  regula config set crawl.rate-limit 2s
+  regula config set --global --config-profile work path /srv/regula/library`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, _ := cmd.Flags().GetString("config-profile")
+			global, _ := cmd.Flags().GetBool("global")
+
+			if err := checkSettingKey(cmd.Root(), args[0]); err != nil {
+				return err
+			}
+			path := settingsFilePath(global)
+			if err := settings.Set(path, profile, args[0], args[1]); err != nil {
+				return fmt.Errorf("failed to set %s: %w", args[0], err)
+			}
+			fmt.Fprintf(app.messageWriter(), "Set %s = %s in %s\n", args[0], args[1], settingsTarget(path, profile))
+			return app.writeJSONResult(settingEntry{Key: args[0], Value: args[1], Source: settingsTarget(path, profile)})
+		},
+	}
+
+	cmd.Flags().Bool("global", false, "Write to the user config instead of the project config")
+
+	return cmd
+}
+
+func configUnsetCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a default flag value",
+		Long: `Remove a key from the project config, or from the user config with
+--global; with --config-profile, from that profile.
+
+Examples:
+  regula config unset base-uri
+  regula config unset --global --config-profile work path`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, _ := cmd.Flags().GetString("config-profile")
+			global, _ := cmd.Flags().GetBool("global")
+
+			path := settingsFilePath(global)
+			if err := settings.Unset(path, profile, args[0]); err != nil {
+				return fmt.Errorf("failed to unset %s: %w", args[0], err)
+			}
+			fmt.Fprintf(app.messageWriter(), "Removed %s from %s\n", args[0], settingsTarget(path, profile))
+			return app.writeJSONResult(map[string]string{"removed": args[0], "source": settingsTarget(path, profile)})
+		},
+	}
+
+	cmd.Flags().Bool("global", false, "Remove from the user config instead of the project config")
+
+	return cmd
+}
+
+// settingsFilePath returns the user or project config file.
+func settingsFilePath(global bool) string {
+	if global {
+		return settings.UserPath()
+	}
+	return settings.ProjectPath()
+}
+
+// settingsTarget describes the file, and profile, a setting is written to.
+func settingsTarget(path, profile string) string {
+	if profile != "" {
+		return fmt.Sprintf("%s (profile %s)", path, profile)
+	}
+	return path
+}
+
+// checkSettingKey rejects keys no command could use: a scoped key must name
+// an existing command with the flag, and a bare key a flag of any command.
+func checkSettingKey(root *cobra.Command, key string) error {
+	commandPath, flag := settings.SplitKey(key)
+	if flag == "" || unconfigurableFlags[flag] {
+		return fmt.Errorf("%q cannot be set in config files", key)
+	}
+
+	if commandPath != "" {
+		target, remaining, err := root.Find(strings.Fields(commandPath))
+		if err != nil || len(remaining) > 0 || settingsCommandPath(target) != commandPath {
+			return fmt.Errorf("unknown command %q in key %q", commandPath, key)
+		}
+		if target.Flags().Lookup(flag) == nil && target.InheritedFlags().Lookup(flag) == nil {
+			return fmt.Errorf("regula %s has no --%s flag", commandPath, flag)
+		}
+		return nil
+	}
+
+	if commandHasFlag(root, flag) {
+		return nil
+	}
+	return fmt.Errorf("unknown setting %q: no command has a --%s flag", key, flag)
+}
+
+// commandHasFlag reports whether cmd or any of its subcommands defines flag.
+func commandHasFlag(cmd *cobra.Command, flag string) bool {
+	if cmd.Flags().Lookup(flag) != nil || cmd.PersistentFlags().Lookup(flag) != nil {
+		return true
+	}
+	for _, child := range cmd.Commands() {
+		if commandHasFlag(child, flag) {
+			return true
+		}
+	}
+	return false
+}
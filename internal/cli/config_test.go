@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/settings"
+)
+
+func TestConfigCmd_SetGetAndApply(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(settings.HomeEnv, t.TempDir())
+	t.Setenv(settings.ProfileEnv, "")
+	if _, stderr, code := runCLI(t, "library", "init", "--path", "lib"); code != 0 {
+		t.Fatalf("library init: %s", stderr)
+	}
+
+	stdout, stderr, code := runCLI(t, "config", "set", "path", "lib")
+	if code != 0 || !strings.Contains(stdout, filepath.Join(".regula", "config.yaml")) {
+		t.Fatalf("config set = %d %q %q", code, stdout, stderr)
+	}
+	if stdout, _, code := runCLI(t, "config", "get", "status.path"); code != 0 || strings.TrimSpace(stdout) != "lib" {
+		t.Errorf("config get status.path = %d %q, want lib", code, stdout)
+	}
+	if _, stderr, code := runCLI(t, "status"); code != 0 {
+		t.Errorf("status with the configured path failed: %s", stderr)
+	}
+
+	if _, stderr, code := runCLI(t, "config", "set", "--global", "--config-profile", "elsewhere", "path", "missing"); code != 0 {
+		t.Fatalf("config set --config-profile: %s", stderr)
+	}
+	if _, _, code := runCLI(t, "status", "--config-profile", "elsewhere"); code == 0 {
+		t.Error("status --config-profile elsewhere used the project path instead of the profile's")
+	}
+	if _, stderr, code := runCLI(t, "status", "--config-profile", "elsewhere", "--path", "lib"); code != 0 {
+		t.Errorf("an explicit --path did not win over the profile: %s", stderr)
+	}
+	if _, stderr, code := runCLI(t, "status", "--config-profile", "unknown"); code == 0 || !strings.Contains(stderr, "unknown profile") {
+		t.Errorf("status --config-profile unknown = %d %q", code, stderr)
+	}
+	// validate has its own --profile; the settings profile still applies
+	if _, stderr, code := runCLI(t, "validate", "--config-profile", "unknown", "--profile", "GDPR", "--source", "missing.txt"); code == 0 || !strings.Contains(stderr, "unknown profile") {
+		t.Errorf("validate --config-profile unknown = %d %q", code, stderr)
+	}
+
+	stdout, _, _ = runCLI(t, "config", "list", "--config-profile", "elsewhere")
+	if !strings.Contains(stdout, "Profile:        elsewhere") || !strings.Contains(stdout, "missing") {
+		t.Errorf("config list output:\n%s", stdout)
+	}
+	if stdout, _, _ := runCLI(t, "config", "list"); !strings.Contains(stdout, "project (") {
+		t.Errorf("config list without a profile:\n%s", stdout)
+	}
+
+	if _, stderr, code := runCLI(t, "config", "unset", "path"); code != 0 {
+		t.Fatalf("config unset: %s", stderr)
+	}
+	if _, _, code := runCLI(t, "config", "get", "path"); code == 0 {
+		t.Error("config get found path after unset")
+	}
+}
+
+func TestConfigCmd_EnvOverride(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(settings.HomeEnv, t.TempDir())
+	t.Setenv(settings.ProfileEnv, "")
+	if _, stderr, code := runCLI(t, "library", "init", "--path", "lib"); code != 0 {
+		t.Fatalf("library init: %s", stderr)
+	}
+	runCLI(t, "config", "set", "path", "missing")
+
+	t.Setenv("REGULA_PATH", "lib")
+	if stdout, _, _ := runCLI(t, "config", "get", "path"); strings.TrimSpace(stdout) != "lib" {
+		t.Errorf("config get path = %q, want the environment value", stdout)
+	}
+	if _, stderr, code := runCLI(t, "status"); code != 0 {
+		t.Errorf("status did not use $REGULA_PATH: %s", stderr)
+	}
+}
+
+func TestConfigCmd_SetRejectsUnknownKeys(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(settings.HomeEnv, t.TempDir())
+
+	for _, key := range []string{"no-such-flag", "crawl.no-such-flag", "nosuchcommand.path", "config-profile"} {
+		if _, _, code := runCLI(t, "config", "set", key, "value"); code == 0 {
+			t.Errorf("config set %s succeeded", key)
+		}
+	}
+	if _, stderr, code := runCLI(t, "config", "set", "bulk.download.concurrency", "2"); code != 0 {
+		t.Errorf("config set bulk.download.concurrency: %s", stderr)
+	}
+}
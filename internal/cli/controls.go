@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/coolbeans/regula/pkg/controls"
+	"github.com/spf13/cobra"
+)
+
+func controlsCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "controls",
+		Short: "Map obligations to compliance framework controls",
+		Long: `Link regulatory obligations to the controls of frameworks such as
+ISO 27001, NIST CSF, or SOC 2, defined in YAML control catalogs:
+
+  framework: ISO 27001
+  version: "2022"
+  controls:
+    - id: A.5.24
+      title: Information security incident management planning
+      keywords: [incident, personal data breach]
+      obligation_types: [BreachNotificationObligation]
+
+An obligation is linked to a control when its type is listed under
+obligation_types, or when keywords appear in its text, context, or article
+title (whole words, case-insensitive, plurals tolerated).
+
+See testdata/controls/iso27001-sample.yaml for a sample catalog.`,
+	}
+
+	cmd.AddCommand(controlsMapCmd(app))
+
+	return cmd
+}
+
+func controlsMapCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "map",
+		Short: "Build an obligation-to-control coverage matrix",
+		Long: `Build a coverage matrix of obligations against the controls of one or
+more catalogs, and list the obligations no control addresses and the
+controls that address no obligation.
+
+The CSV format has one row per obligation and one column per control
+(framework/id); a cell holds the link basis (obligation_type or keyword).
+
+Without --source, the ready documents in the library are mapped.
+
+Examples:
+  regula controls map --catalog iso27001.yaml --source gdpr.txt
+  regula controls map --catalog iso27001.yaml --catalog nist-csf.yaml --format csv -o matrix.csv
+  regula controls map --catalog soc2.yaml --documents eu-gdpr,us-ca-ccpa --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			catalogPaths, _ := cmd.Flags().GetStringSlice("catalog")
+			source, _ := cmd.Flags().GetString("source")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documents, _ := cmd.Flags().GetString("documents")
+			minTerms, _ := cmd.Flags().GetInt("min-terms")
+			formatStr, _ := cmd.Flags().GetString("format")
+			outputPath, _ := cmd.Flags().GetString("output")
+
+			if len(catalogPaths) == 0 {
+				return &usageError{err: fmt.Errorf("--catalog flag is required")}
+			}
+			var catalogs []*controls.Catalog
+			for _, catalogPath := range catalogPaths {
+				catalog, err := controls.LoadCatalog(catalogPath)
+				if err != nil {
+					return err
+				}
+				catalogs = append(catalogs, catalog)
+			}
+
+			tripleStore, err := loadAnalysisStore(cmd.Context(), source, libraryPath, documents)
+			if err != nil {
+				return err
+			}
+
+			mapper := controls.NewMapper(catalogs...)
+			if minTerms > 0 {
+				mapper.MinTerms = minTerms
+			}
+			mapping := mapper.Map(tripleStore)
+
+			var output string
+			switch formatStr {
+			case "csv":
+				csvOutput, err := mapping.ToCSV()
+				if err != nil {
+					return fmt.Errorf("failed to write CSV: %w", err)
+				}
+				output = csvOutput
+			case "json":
+				data, err := mapping.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize mapping: %w", err)
+				}
+				output = string(data) + "\n"
+			default:
+				output = mapping.String()
+			}
+
+			if outputPath != "" {
+				if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+					return fmt.Errorf("failed to write mapping: %w", err)
+				}
+				fmt.Fprintf(app.messageWriter(), "Mapping written to %s\n", outputPath)
+				return nil
+			}
+			fmt.Fprint(app.Stdout, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSlice("catalog", nil, "Control catalog YAML file (repeatable)")
+	cmd.Flags().StringP("source", "s", "", "Source document path (default: the library)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("documents", "", "Comma-separated library document IDs to map (default: all ready documents)")
+	cmd.Flags().Int("min-terms", 1, "Distinct keywords that must match to link by keyword")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, csv, json)")
+	cmd.Flags().StringP("output", "o", "", "Write the mapping to a file instead of stdout")
+
+	return cmd
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestControlsMapCmd(t *testing.T) {
+	source := testdataPath(t, "gdpr.txt")
+	catalog := testdataPath(t, filepath.Join("controls", "iso27001-sample.yaml"))
+
+	output, stderr, code := runCLI(t, "controls", "map", "--catalog", catalog, "--source", source)
+	if code != 0 {
+		t.Fatalf("controls map failed: %s", stderr)
+	}
+	for _, want := range []string{"Control Mapping", "ISO 27001/A.5.24", "Uncovered obligations"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestControlsMapCmd_Formats(t *testing.T) {
+	source := testdataPath(t, "gdpr.txt")
+	catalog := testdataPath(t, filepath.Join("controls", "iso27001-sample.yaml"))
+
+	csvPath := filepath.Join(t.TempDir(), "matrix.csv")
+	if _, stderr, code := runCLI(t, "controls", "map", "--catalog", catalog, "--source", source, "--format", "csv", "-o", csvPath); code != 0 {
+		t.Fatalf("controls map --format csv failed: %s", stderr)
+	}
+	data, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("read CSV: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "document,article,article_title,obligation_type,duty_bearer,ISO 27001/A.5.1") {
+		t.Errorf("unexpected CSV header: %s", strings.SplitN(string(data), "\n", 2)[0])
+	}
+
+	output, stderr, code := runCLI(t, "controls", "map", "--catalog", catalog, "--source", source, "--format", "json")
+	if code != 0 {
+		t.Fatalf("controls map --format json failed: %s", stderr)
+	}
+	var mapping struct {
+		Obligations []struct {
+			Controls []struct {
+				ID    string `json:"id"`
+				Basis string `json:"basis"`
+			} `json:"controls"`
+		} `json:"obligations"`
+		Coverage float64 `json:"coverage"`
+	}
+	if err := json.Unmarshal([]byte(output), &mapping); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(mapping.Obligations) == 0 || mapping.Coverage == 0 {
+		t.Errorf("expected mapped obligations, got %d with coverage %v", len(mapping.Obligations), mapping.Coverage)
+	}
+}
+
+func TestControlsMapCmd_RequiresCatalog(t *testing.T) {
+	if _, _, code := runCLI(t, "controls", "map", "--source", testdataPath(t, "gdpr.txt")); code == 0 {
+		t.Error("expected an error without --catalog")
+	}
+}
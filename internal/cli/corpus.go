@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/coolbeans/regula/pkg/corpus"
+	"github.com/spf13/cobra"
+)
+
+func corpusCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "corpus",
+		Short: "Regression testing of extraction output across a document corpus",
+		Long: `Ingest every document in a corpus and compare extraction output
+against stored baselines.
+
+Each baseline records key metrics (articles, definitions, references,
+rights, obligations, ...), a fingerprint of the full triple set, and a
+stable hash-based sample of triples. Any unexpected change is reported
+with metric deltas and the sampled triples that were gained or lost.`,
+	}
+
+	cmd.AddCommand(corpusRunCmd(app))
+
+	return cmd
+}
+
+func corpusRunCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run the corpus and diff extraction output against baselines",
+		Long: `Ingest every corpus document and compare the result with its baseline.
+
+Documents are taken from manifest.json in the corpus directory when present,
+otherwise every .txt file in the directory is used. Documents without a
+baseline have one created. The command exits non-zero when any document
+regresses. With the default --tolerance of 0 any change to a document's
+triple set is a regression, even one that leaves every metric and the
+sampled triples unchanged.
+
+Examples:
+  regula corpus run --corpus testdata/ --baseline baselines/
+  regula corpus run --corpus testdata/corpus/ --baseline baselines/ --update
+  regula corpus run --corpus testdata/ --baseline baselines/ --tolerance 0.05 --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			corpusDir, _ := cmd.Flags().GetString("corpus")
+			baselineDir, _ := cmd.Flags().GetString("baseline")
+			updateBaselines, _ := cmd.Flags().GetBool("update")
+			sampleSize, _ := cmd.Flags().GetInt("sample-size")
+			tolerance, _ := cmd.Flags().GetFloat64("tolerance")
+			formatFlag, _ := cmd.Flags().GetString("format")
+
+			report, err := corpus.Run(corpus.RunConfig{
+				CorpusDir:   corpusDir,
+				BaselineDir: baselineDir,
+				SampleSize:  sampleSize,
+				Tolerance:   tolerance,
+				Update:      updateBaselines,
+			})
+			if err != nil {
+				return fmt.Errorf("corpus run failed: %w", err)
+			}
+
+			switch formatFlag {
+			case "json":
+				output, err := corpus.FormatReportJSON(report)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(app.Stdout, output)
+			default:
+				fmt.Fprint(app.Stdout, corpus.FormatReportTable(report))
+			}
+
+			if !report.Passed() {
+				return fmt.Errorf("extraction output changed for %d document(s)", report.Regressions)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("corpus", "testdata", "Corpus directory containing documents to ingest")
+	cmd.Flags().String("baseline", "baselines", "Directory for stored baselines")
+	cmd.Flags().Bool("update", false, "Rewrite baselines from current extraction output")
+	cmd.Flags().Int("sample-size", corpus.DefaultSampleSize, "Number of triples sampled per document")
+	cmd.Flags().Float64("tolerance", 0, "Permitted relative metric change before failing (0.05 = 5%)")
+	cmd.Flags().String("format", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+// --- Pattern development commands ---
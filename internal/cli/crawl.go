@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/crawler"
+	"github.com/coolbeans/regula/pkg/httpcache"
+	"github.com/spf13/cobra"
+)
+
+// crawlCmd creates the crawl command for legislation discovery.
+func crawlCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "crawl",
+		Short: "Crawl and discover legislation by following cross-references",
+		Long: `Performs a BFS tree-walking crawl starting from a seed document, citation,
+or URL. The crawler follows cross-references in ingested legislation to discover
+and ingest related documents from US law sources (USC, CFR, state codes), EU
+regulations, directives, and decisions via EUR-Lex, and UK Acts and Statutory
+Instruments via legislation.gov.uk.
+
+Each discovered document is ingested into the library, its cross-references are
+extracted, and newly discovered citations are enqueued for further crawling.
+
+The crawl stops when it reaches the configured depth limit, document limit,
+or exhausts all discoverable references.
+
+The crawler identifies itself with --user-agent and honors each site's
+robots.txt: disallowed URLs are recorded as failed, and a site's Crawl-delay
+raises the per-domain rate limit. Pass --respect-robots=false only for sites
+you are permitted to crawl regardless.
+
+With --report, the discovery graph is also written to a file: each seed and
+document, and an edge for every citation that led from one to another with
+its depth and fetch status. Files ending in .dot or .gv are written as
+Graphviz DOT, others as JSON.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			seedDocID, _ := cmd.Flags().GetString("seed")
+			citationStr, _ := cmd.Flags().GetString("citation")
+			seedURL, _ := cmd.Flags().GetString("url")
+			maxDepth, _ := cmd.Flags().GetInt("max-depth")
+			maxDocuments, _ := cmd.Flags().GetInt("max-documents")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			resumeCrawl, _ := cmd.Flags().GetBool("resume")
+			allowedDomainsStr, _ := cmd.Flags().GetString("allowed-domains")
+			rateLimitStr, _ := cmd.Flags().GetString("rate-limit")
+			outputFormat, _ := cmd.Flags().GetString("format")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			respectRobots, _ := cmd.Flags().GetBool("respect-robots")
+			userAgent, _ := cmd.Flags().GetString("user-agent")
+			graphPath, _ := cmd.Flags().GetString("report")
+			httpCacheDir, _ := cmd.Flags().GetString("http-cache")
+
+			if seedDocID == "" && citationStr == "" && seedURL == "" && !resumeCrawl {
+				return fmt.Errorf("specify at least one of --seed, --citation, --url, or --resume")
+			}
+
+			// Parse rate limit
+			rateLimit := crawler.DefaultCrawlRateLimit
+			if rateLimitStr != "" {
+				parsedDuration, err := time.ParseDuration(rateLimitStr)
+				if err != nil {
+					return fmt.Errorf("invalid rate limit %q: %w", rateLimitStr, err)
+				}
+				rateLimit = parsedDuration
+			}
+
+			// Parse allowed domains
+			var allowedDomains []string
+			if allowedDomainsStr != "" {
+				allowedDomains = strings.Split(allowedDomainsStr, ",")
+				for i := range allowedDomains {
+					allowedDomains[i] = strings.TrimSpace(allowedDomains[i])
+				}
+			}
+
+			crawlConfig := crawler.CrawlConfig{
+				MaxDepth:       maxDepth,
+				MaxDocuments:   maxDocuments,
+				AllowedDomains: allowedDomains,
+				RateLimit:      rateLimit,
+				Timeout:        crawler.DefaultCrawlTimeout,
+				LibraryPath:    libraryPath,
+				BaseURI:        "https://regula.dev/regulations/",
+				CacheDir:       httpCacheDir,
+				DryRun:         dryRun,
+				Resume:         resumeCrawl,
+				StatePath:      filepath.Join(libraryPath, "crawl-state.json"),
+				UserAgent:      userAgent,
+				RespectRobots:  respectRobots,
+				DomainConfigs:  crawler.DefaultDomainConfigs(),
+				OutputFormat:   outputFormat,
+			}
+
+			crawlerInstance, err := crawler.NewCrawler(crawlConfig)
+			if err != nil {
+				return fmt.Errorf("failed to initialize crawler: %w", err)
+			}
+
+			ctx, stop := interruptContext(cmd.Context())
+			defer stop()
+
+			// Handle resume
+			if resumeCrawl {
+				statePath := crawlConfig.StatePath
+				fmt.Fprintf(app.Stderr, "Resuming crawl from %s...\n", statePath)
+				crawlReport, err := crawlerInstance.ResumeWithContext(ctx, statePath)
+				if err != nil {
+					return fmt.Errorf("failed to resume crawl: %w", err)
+				}
+				fmt.Fprint(app.Stdout, crawlReport.Format(outputFormat))
+				if err := writeCrawlGraph(app, graphPath, crawlReport); err != nil {
+					return err
+				}
+				if crawlReport.Interrupted {
+					return fmt.Errorf("crawl interrupted; state saved to %s", statePath)
+				}
+				return nil
+			}
+
+			// Build seeds
+			var seeds []crawler.CrawlSeed
+			if seedDocID != "" {
+				seeds = append(seeds, crawler.CrawlSeed{
+					Type:  crawler.SeedTypeDocumentID,
+					Value: seedDocID,
+				})
+			}
+			if citationStr != "" {
+				seeds = append(seeds, crawler.CrawlSeed{
+					Type:  crawler.SeedTypeCitation,
+					Value: citationStr,
+				})
+			}
+			if seedURL != "" {
+				seeds = append(seeds, crawler.CrawlSeed{
+					Type:  crawler.SeedTypeURL,
+					Value: seedURL,
+				})
+			}
+
+			if dryRun {
+				fmt.Fprintf(app.Stderr, "Planning crawl (dry run) with %d seed(s), max depth %d, max documents %d...\n",
+					len(seeds), maxDepth, maxDocuments)
+			} else {
+				fmt.Fprintf(app.Stderr, "Starting crawl with %d seed(s), max depth %d, max documents %d...\n",
+					len(seeds), maxDepth, maxDocuments)
+			}
+
+			crawlReport, err := crawlerInstance.CrawlWithContext(ctx, seeds)
+			if err != nil {
+				return fmt.Errorf("crawl failed: %w", err)
+			}
+
+			fmt.Fprint(app.Stdout, crawlReport.Format(outputFormat))
+			if err := writeCrawlGraph(app, graphPath, crawlReport); err != nil {
+				return err
+			}
+			if crawlReport.Interrupted {
+				return fmt.Errorf("crawl interrupted; state saved to %s", crawlConfig.StatePath)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("seed", "", "Seed from an existing library document ID")
+	cmd.Flags().String("citation", "", "Seed from a citation (e.g., '42 U.S.C. § 1320d', 'Directive 95/46/EC', 'SI 2019/419')")
+	cmd.Flags().String("url", "", "Seed from a direct URL")
+	cmd.Flags().Int("max-depth", crawler.DefaultCrawlMaxDepth, "Maximum BFS depth for following references")
+	cmd.Flags().Int("max-documents", crawler.DefaultCrawlMaxDocuments, "Maximum number of documents to ingest")
+	cmd.Flags().Bool("dry-run", false, "Plan the crawl without making network requests")
+	cmd.Flags().Bool("resume", false, "Resume a previously interrupted crawl")
+	cmd.Flags().String("allowed-domains", "", "Comma-separated list of allowed domains")
+	cmd.Flags().String("rate-limit", "3s", "Minimum interval between requests per domain")
+	cmd.Flags().String("user-agent", crawler.DefaultCrawlUserAgent, "User-Agent sent with requests and matched against robots.txt")
+	cmd.Flags().Bool("respect-robots", true, "Honor robots.txt rules and Crawl-delay")
+	cmd.Flags().String("format", "table", "Output format (table, json)")
+	cmd.Flags().String("http-cache", httpcache.DefaultDir, "HTTP cache directory for conditional re-fetching (empty disables)")
+	cmd.Flags().String("report", "", "Write the discovery graph to this file (.dot/.gv for DOT, otherwise JSON)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+
+	return cmd
+}
+
+// writeCrawlGraph writes the discovery graph of a crawl to graphPath, in the
+// format its extension selects. It does nothing when graphPath is empty.
+func writeCrawlGraph(app *App, graphPath string, crawlReport *crawler.CrawlReport) error {
+	if graphPath == "" {
+		return nil
+	}
+	graph := crawlReport.Graph()
+	output := graph.Format(crawler.GraphFormatForPath(graphPath))
+	if err := os.WriteFile(graphPath, []byte(output), 0644); err != nil {
+		return fmt.Errorf("failed to write crawl graph: %w", err)
+	}
+	fmt.Fprintf(app.Stderr, "Discovery graph written to %s (%d documents, %d edges)\n",
+		graphPath, graph.Stats.Documents, graph.Stats.Edges)
+	return nil
+}
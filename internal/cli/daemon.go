@@ -0,0 +1,342 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/daemon"
+	"github.com/spf13/cobra"
+)
+
+// daemonShutdownGrace is how long a job may take to checkpoint after the
+// daemon asks it to stop, before it is killed.
+const daemonShutdownGrace = 30 * time.Second
+
+func daemonCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Run scheduled maintenance jobs for a library",
+		Long: `Run the recurring jobs configured in the daemon section of the library's
+config.yaml until interrupted.
+
+Each job runs a regula command line on a cron schedule:
+
+  daemon:
+    jobs:
+      - name: ecfr-refresh
+        schedule: "@nightly"
+        command: [bulk, sync, cfr]
+      - name: link-check
+        schedule: "0 4 * * sun"
+        command: [validate, --source, regulations/gdpr.txt, --check, links]
+      - name: usc-update
+        schedule: "@monthly"
+        command: [bulk, sync, uscode]
+        timeout: 12h
+    notify:
+      - webhook: https://hooks.example.com/regula
+        events: [job.failed, job.skipped]
+
+Schedules use the five cron fields (minute hour day-of-month month
+day-of-week) or one of @hourly, @daily, @nightly, @weekly, @monthly,
+@yearly, and @every <duration>. Times are local.
+
+A job never overlaps itself: if it is still running when it comes due
+again, or is running in another process via 'regula daemon run', the run
+is skipped and recorded. Only one daemon may run per library.
+
+Every run is recorded in the job history ('regula daemon history').
+Webhooks receive job events as JSON; by default only job.failed.
+
+Examples:
+  regula daemon                       Run jobs until Ctrl+C
+  regula daemon jobs                  List jobs and their next run
+  regula daemon run ecfr-refresh      Run a job now
+  regula daemon history --job usc-update`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+
+			scheduler, err := newDaemon(app, libraryPath)
+			if err != nil {
+				return err
+			}
+
+			scheduled := scheduler.Schedule(time.Now())
+			fmt.Fprintf(app.Stderr, "Daemon started for %s with %d job(s)\n", libraryPath, len(scheduled))
+			for _, entry := range scheduled {
+				fmt.Fprintf(app.Stderr, "  %-20s next run %s\n", entry.Job.Name, formatNextRun(entry.NextRun))
+			}
+
+			ctx, stop := interruptContext(cmd.Context())
+			defer stop()
+			if err := scheduler.Run(ctx); err != nil {
+				return err
+			}
+			fmt.Fprintln(app.Stderr, "Daemon stopped")
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().String("path", defaultLibraryPath(), "Library directory path")
+
+	cmd.AddCommand(daemonJobsCmd(app))
+	cmd.AddCommand(daemonRunCmd(app))
+	cmd.AddCommand(daemonHistoryCmd(app))
+
+	return cmd
+}
+
+// jobStatus is one job in 'daemon jobs --format json' output.
+type jobStatus struct {
+	daemon.JobConfig
+	NextRun *time.Time        `json:"next_run,omitempty"`
+	LastRun *daemon.RunRecord `json:"last_run,omitempty"`
+}
+
+func daemonJobsCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "List configured jobs, their next run, and their last result",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			format, _ := cmd.Flags().GetString("format")
+
+			config, err := daemon.LoadConfig(libraryPath)
+			if err != nil {
+				return err
+			}
+			lastRuns, err := daemon.NewHistory(daemon.HistoryPath(libraryPath)).LastRuns()
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+
+			switch format {
+			case "json":
+				jobs := []jobStatus{}
+				for _, job := range config.Jobs {
+					status := jobStatus{JobConfig: job}
+					if job.IsEnabled() {
+						schedule, _ := daemon.ParseSchedule(job.Schedule)
+						if next := schedule.Next(now); !next.IsZero() {
+							status.NextRun = &next
+						}
+					}
+					if record, ok := lastRuns[job.Name]; ok {
+						status.LastRun = &record
+					}
+					jobs = append(jobs, status)
+				}
+				encoder := json.NewEncoder(app.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(jobs)
+			case "table":
+			default:
+				return fmt.Errorf("unknown format %q (use table or json)", format)
+			}
+
+			if len(config.Jobs) == 0 {
+				fmt.Fprintf(app.Stdout, "No jobs configured in %s\n", daemon.ConfigFileName)
+				return nil
+			}
+			writer := tabwriter.NewWriter(app.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(writer, "JOB\tSCHEDULE\tNEXT RUN\tLAST RUN\tSTATUS\tCOMMAND")
+			for _, job := range config.Jobs {
+				nextRun := "disabled"
+				if job.IsEnabled() {
+					schedule, _ := daemon.ParseSchedule(job.Schedule)
+					nextRun = formatNextRun(schedule.Next(now))
+				}
+				lastRun, status := "-", "-"
+				if record, ok := lastRuns[job.Name]; ok {
+					lastRun = record.StartedAt.Format("2006-01-02 15:04")
+					status = string(record.Status)
+				}
+				fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+					job.Name, job.Schedule, nextRun, lastRun, status, strings.Join(job.Command, " "))
+			}
+			return writer.Flush()
+		},
+	}
+
+	cmd.Flags().String("format", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+func daemonRunCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run <job>",
+		Short: "Run a configured job now",
+		Long: `Run a configured job immediately, record it in the job history, and send
+its events to the configured notifiers. The run is skipped if the job is
+already running, here or in the daemon.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			format, _ := cmd.Flags().GetString("format")
+			if format != "text" && format != "json" {
+				return fmt.Errorf("unknown format %q (use text or json)", format)
+			}
+
+			scheduler, err := newDaemon(app, libraryPath)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := interruptContext(cmd.Context())
+			defer stop()
+			record, err := scheduler.RunJob(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			if format == "json" {
+				encoder := json.NewEncoder(app.Stdout)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(record); err != nil {
+					return err
+				}
+			} else {
+				fmt.Fprintf(app.Stdout, "Job %s %s in %s\n", record.Job, record.Status, record.Duration.Round(time.Millisecond))
+			}
+			if record.Status != daemon.RunSucceeded {
+				return fmt.Errorf("job %s %s: %s", record.Job, record.Status, record.Error)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("format", "text", "Output format (text, json)")
+
+	return cmd
+}
+
+func daemonHistoryCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show recent job runs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			jobName, _ := cmd.Flags().GetString("job")
+			limit, _ := cmd.Flags().GetInt("limit")
+			format, _ := cmd.Flags().GetString("format")
+
+			records, err := daemon.NewHistory(daemon.HistoryPath(libraryPath)).Records(jobName, limit)
+			if err != nil {
+				return err
+			}
+
+			switch format {
+			case "json":
+				if records == nil {
+					records = []daemon.RunRecord{}
+				}
+				encoder := json.NewEncoder(app.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(records)
+			case "table":
+			default:
+				return fmt.Errorf("unknown format %q (use table or json)", format)
+			}
+
+			if len(records) == 0 {
+				fmt.Fprintln(app.Stdout, "No job runs recorded")
+				return nil
+			}
+			writer := tabwriter.NewWriter(app.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(writer, "STARTED\tJOB\tSTATUS\tDURATION\tTRIGGER\tERROR")
+			for _, record := range records {
+				trigger := "manual"
+				if record.Scheduled {
+					trigger = "schedule"
+				}
+				fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+					record.StartedAt.Format("2006-01-02 15:04:05"), record.Job, record.Status,
+					record.Duration.Round(time.Second), trigger, record.Error)
+			}
+			return writer.Flush()
+		},
+	}
+
+	cmd.Flags().String("job", "", "Show runs of this job only")
+	cmd.Flags().Int("limit", 20, "Number of most recent runs to show (0 for all)")
+	cmd.Flags().String("format", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+// newDaemon loads the library's daemon config and returns a daemon running
+// jobs as regula subprocesses, with their output copied to app.Stderr.
+func newDaemon(app *App, libraryPath string) (*daemon.Daemon, error) {
+	config, err := daemon.LoadConfig(libraryPath)
+	if err != nil {
+		return nil, err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate regula executable: %w", err)
+	}
+
+	return daemon.New(config, daemon.Options{
+		LibraryPath: libraryPath,
+		Runner:      commandRunner(executable, app.Stderr),
+	})
+}
+
+// commandRunner returns a daemon.Runner executing job commands with
+// executable. On cancellation the job is interrupted so it can checkpoint,
+// as on Ctrl+C, and killed if it has not exited after daemonShutdownGrace.
+func commandRunner(executable string, stderr io.Writer) daemon.Runner {
+	return func(ctx context.Context, job daemon.JobConfig, output io.Writer) error {
+		command := exec.CommandContext(ctx, executable, job.Command...)
+		combined := io.MultiWriter(output, &linePrefixWriter{prefix: "[" + job.Name + "] ", out: stderr})
+		command.Stdout = combined
+		command.Stderr = combined
+		command.Cancel = func() error {
+			return command.Process.Signal(os.Interrupt)
+		}
+		command.WaitDelay = daemonShutdownGrace
+
+		if err := command.Run(); err != nil {
+			return fmt.Errorf("regula %s: %w", strings.Join(job.Command, " "), err)
+		}
+		return nil
+	}
+}
+
+// linePrefixWriter writes each line to out with a prefix, so the output
+// of concurrent jobs can be told apart.
+type linePrefixWriter struct {
+	prefix  string
+	out     io.Writer
+	partial []byte
+}
+
+func (w *linePrefixWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		newline := bytes.IndexByte(w.partial, '\n')
+		if newline < 0 {
+			break
+		}
+		fmt.Fprintf(w.out, "%s%s", w.prefix, w.partial[:newline+1])
+		w.partial = w.partial[newline+1:]
+	}
+	return len(p), nil
+}
+
+func formatNextRun(next time.Time) string {
+	if next.IsZero() {
+		return "never"
+	}
+	return next.Format("2006-01-02 15:04")
+}
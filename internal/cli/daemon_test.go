@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/daemon"
+)
+
+func TestDaemonJobsAndHistoryCmd(t *testing.T) {
+	libraryPath := t.TempDir()
+	configYAML := `daemon:
+  jobs:
+    - name: ecfr-refresh
+      schedule: "@nightly"
+      command: [bulk, sync, cfr]
+    - name: usc-update
+      schedule: "@monthly"
+      command: [bulk, sync, uscode]
+      enabled: false
+`
+	if err := os.WriteFile(filepath.Join(libraryPath, daemon.ConfigFileName), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr, code := runCLI(t, "daemon", "history", "--path", libraryPath)
+	if code != 0 || !strings.Contains(stdout, "No job runs recorded") {
+		t.Errorf("empty history = %d %q %q", code, stdout, stderr)
+	}
+
+	history := daemon.NewHistory(daemon.HistoryPath(libraryPath))
+	startedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local)
+	history.Append(daemon.RunRecord{RunID: "1", Job: "ecfr-refresh", Status: daemon.RunFailed, Scheduled: true,
+		StartedAt: startedAt, Error: "feed returned status 503"})
+
+	stdout, stderr, code = runCLI(t, "daemon", "jobs", "--path", libraryPath)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	for _, want := range []string{"ecfr-refresh", "@nightly", "2025-03-01 00:00", "failed", "bulk sync cfr", "disabled"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("jobs output missing %q:\n%s", want, stdout)
+		}
+	}
+
+	stdout, _, code = runCLI(t, "daemon", "history", "--path", libraryPath)
+	if code != 0 || !strings.Contains(stdout, "feed returned status 503") || !strings.Contains(stdout, "schedule") {
+		t.Errorf("history = %d %q", code, stdout)
+	}
+
+	_, stderr, code = runCLI(t, "daemon", "run", "nightly-typo", "--path", libraryPath)
+	if code != 1 || !strings.Contains(stderr, "job not found") {
+		t.Errorf("unknown job = %d %q", code, stderr)
+	}
+}
+
+func TestDaemonCmd_InvalidConfig(t *testing.T) {
+	libraryPath := t.TempDir()
+	configYAML := "daemon:\n  jobs:\n    - name: broken\n      schedule: every night\n      command: [status]\n"
+	os.WriteFile(filepath.Join(libraryPath, daemon.ConfigFileName), []byte(configYAML), 0644)
+
+	_, stderr, code := runCLI(t, "daemon", "jobs", "--path", libraryPath)
+	if code != 1 || !strings.Contains(stderr, "job broken") {
+		t.Errorf("invalid config = %d %q", code, stderr)
+	}
+}
+
+func TestLinePrefixWriter(t *testing.T) {
+	var out bytes.Buffer
+	writer := &linePrefixWriter{prefix: "[job] ", out: &out}
+	writer.Write([]byte("first\nsec"))
+	writer.Write([]byte("ond\n"))
+	if got := out.String(); got != "[job] first\n[job] second\n" {
+		t.Errorf("prefixed output = %q", got)
+	}
+}
@@ -0,0 +1,244 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/spf13/cobra"
+)
+
+func libraryDedupeCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dedupe",
+		Short: "Report documents that are the same law under different IDs",
+		Long: `Find library documents that appear to be the same law, as when a bulk
+download, a crawl, and a manual add each ingest it under a different ID.
+
+Pairs are reported when their source texts are identical (ignoring case,
+punctuation, and spacing) or nearly so (--min-similarity), or when they
+share a short title ("may be cited as") or name within a jurisdiction.
+The document ingested first is suggested as the canonical one.
+
+--apply makes each unaliased duplicate an alias of its canonical document
+(see 'regula library alias'), so queries load the law once.
+
+Examples:
+  regula library dedupe
+  regula library dedupe --min-similarity 0.8 --format json
+  regula library dedupe --apply`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			formatStr, _ := cmd.Flags().GetString("format")
+			minSimilarity, _ := cmd.Flags().GetFloat64("min-similarity")
+			apply, _ := cmd.Flags().GetBool("apply")
+
+			if minSimilarity <= 0 || minSimilarity > 1 {
+				return &usageError{err: fmt.Errorf("--min-similarity must be between 0 and 1, got %g", minSimilarity)}
+			}
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+			pairs, err := lib.FindDuplicates(library.DuplicateOptions{MinSimilarity: minSimilarity})
+			if err != nil {
+				return fmt.Errorf("failed to compare documents: %w", err)
+			}
+
+			if apply {
+				for i, pair := range pairs {
+					if pair.Aliased {
+						continue
+					}
+					// An earlier alias may have made the suggested
+					// canonical document a duplicate itself.
+					canonicalID := lib.ResolveDocumentID(pair.DocumentID)
+					if canonicalID == pair.DuplicateID || lib.ResolveDocumentID(pair.DuplicateID) != pair.DuplicateID {
+						continue
+					}
+					if err := lib.AddAlias(pair.DuplicateID, canonicalID); err != nil {
+						fmt.Fprintf(app.Stderr, "Warning: %v\n", err)
+						continue
+					}
+					pairs[i].Aliased = canonicalID == pair.DocumentID
+					fmt.Fprintf(app.Stderr, "Aliased %s to %s\n", pair.DuplicateID, canonicalID)
+				}
+			}
+
+			if formatStr == "json" {
+				encoder := json.NewEncoder(app.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(pairs)
+			}
+
+			if len(pairs) == 0 {
+				fmt.Fprintln(app.Stdout, "No duplicate documents found.")
+				return nil
+			}
+
+			fmt.Fprintf(app.Stdout, "%-24s %-24s %10s  %-8s %s\n", "DOCUMENT", "DUPLICATE", "SIMILARITY", "ALIASED", "REASONS")
+			fmt.Fprintln(app.Stdout, strings.Repeat("-", 90))
+			unaliased := 0
+			for _, pair := range pairs {
+				reasons := make([]string, len(pair.Reasons))
+				for i, reason := range pair.Reasons {
+					reasons[i] = string(reason)
+				}
+				aliased := "no"
+				if pair.Aliased {
+					aliased = "yes"
+				} else {
+					unaliased++
+				}
+				fmt.Fprintf(app.Stdout, "%-24s %-24s %9.0f%%  %-8s %s\n",
+					truncateString(pair.DocumentID, 24),
+					truncateString(pair.DuplicateID, 24),
+					pair.Similarity*100,
+					aliased,
+					strings.Join(reasons, ", "),
+				)
+			}
+
+			fmt.Fprintf(app.Stdout, "\n%d duplicate pair(s)\n", len(pairs))
+			if unaliased > 0 {
+				fmt.Fprintln(app.Stdout, "Run 'regula library dedupe --apply' or 'regula library alias add <duplicate> <document>' to merge them.")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+	cmd.Flags().Float64("min-similarity", library.DefaultMinSimilarity, "Text similarity (0-1) from which documents are near-duplicates")
+	cmd.Flags().Bool("apply", false, "Alias each duplicate to its canonical document")
+
+	return cmd
+}
+
+func libraryAliasCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Manage alternative IDs for library documents",
+		Long: `Give a library document another ID. Commands that take document IDs
+accept the alias in its place, and queries merging several IDs of one law
+load it once.
+
+When the alias is the ID of a duplicate document (see 'regula library
+dedupe'), the duplicate stays in the library but is left out of
+whole-library queries.
+
+Examples:
+  regula library alias add uscode-title-15-6501 us-coppa
+  regula library alias add gdpr eu-gdpr
+  regula library alias list
+  regula library alias remove gdpr`,
+	}
+
+	cmd.AddCommand(libraryAliasAddCmd(app))
+	cmd.AddCommand(libraryAliasRemoveCmd(app))
+	cmd.AddCommand(libraryAliasListCmd(app))
+
+	return cmd
+}
+
+func libraryAliasAddCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <alias> <document-id>",
+		Short: "Make an ID an alias of a document",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+			if err := lib.AddAlias(args[0], args[1]); err != nil {
+				return fmt.Errorf("failed to add alias: %w", err)
+			}
+
+			fmt.Fprintf(app.messageWriter(), "%s is now an alias of %s\n", args[0], args[1])
+			return app.writeJSONResult(map[string]string{"alias": args[0], "document": args[1]})
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+
+	return cmd
+}
+
+func libraryAliasRemoveCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <alias>",
+		Short: "Remove an alias",
+		Long:  "Remove an alias. A duplicate document it named becomes a separate document again.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+			if err := lib.RemoveAlias(args[0]); err != nil {
+				return fmt.Errorf("failed to remove alias: %w", err)
+			}
+
+			fmt.Fprintf(app.messageWriter(), "Removed alias %s\n", args[0])
+			return app.writeJSONResult(map[string]string{"removed": args[0]})
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+
+	return cmd
+}
+
+func libraryAliasListCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List aliases",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+			aliases := lib.Aliases()
+
+			if formatStr == "json" {
+				encoder := json.NewEncoder(app.Stdout)
+				encoder.SetIndent("", "  ")
+				if aliases == nil {
+					aliases = map[string]string{}
+				}
+				return encoder.Encode(aliases)
+			}
+
+			if len(aliases) == 0 {
+				fmt.Fprintln(app.Stdout, "No aliases. Add one with 'regula library alias add'.")
+				return nil
+			}
+			for _, alias := range slices.Sorted(maps.Keys(aliases)) {
+				kind := ""
+				if entry := lib.GetDocument(alias); entry != nil && entry.ID == alias {
+					kind = " (duplicate)"
+				}
+				fmt.Fprintf(app.Stdout, "%s -> %s%s\n", alias, aliases[alias], kind)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+
+	return cmd
+}
@@ -0,0 +1,748 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/draft"
+	"github.com/coolbeans/regula/pkg/textutil"
+	"github.com/spf13/cobra"
+)
+
+func draftCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "draft",
+		Short: "Draft legislation analysis pipeline",
+		Long: `Parse draft Congressional bills and analyze their impact on existing law.
+
+Commands:
+  ingest    Parse a draft bill and display its structure and amendments
+  diff      Compute structural diff against the USC knowledge graph
+  impact    Run impact analysis against the USC knowledge graph
+  conflicts Run conflict and consistency analysis
+  simulate  Run compliance scenario simulation
+  overlay   Save the proposed-law graph for querying as if the bill passed
+  report    Generate comprehensive legislative impact report
+  compare-versions  Compare amendments and findings between two bill versions
+
+Examples:
+  regula draft ingest --bill draft-hr-1234.txt
+  regula draft ingest --bill draft-hr-1234.txt --format json
+  regula draft ingest --bill BILLS-118hr1234ih.xml
+  regula draft diff --bill draft-hr-1234.txt --path .regula
+  regula draft diff --bill draft-hr-1234.txt --format csv
+  regula draft impact --bill draft-hr-1234.txt --depth 2
+  regula draft impact --bill draft-hr-1234.txt --format dot --output impact.dot
+  regula draft conflicts --bill draft-hr-1234.txt
+  regula draft conflicts --bill draft-hr-1234.txt --severity error
+  regula draft simulate --bill draft-hr-1234.txt --scenario consent_withdrawal
+  regula draft simulate --list-scenarios
+  regula draft overlay --bill draft-hr-1234.txt --output hr1234-overlay.json
+  regula draft report --bill draft-hr-1234.txt --format markdown
+  regula draft report --bill draft-hr-1234.txt --format html --output report.html
+  regula draft compare-versions --base hr1234-ih.txt --target hr1234-rh.txt`,
+	}
+
+	cmd.AddCommand(draftIngestCmd(app))
+	cmd.AddCommand(draftDiffCmd(app))
+	cmd.AddCommand(draftImpactCmd(app))
+	cmd.AddCommand(draftConflictsCmd(app))
+	cmd.AddCommand(draftSimulateCmd(app))
+	cmd.AddCommand(draftOverlayCmd(app))
+	cmd.AddCommand(draftReportCmd(app))
+	cmd.AddCommand(draftCompareVersionsCmd(app))
+
+	return cmd
+}
+
+func draftIngestCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ingest",
+		Short: "Parse a draft bill and display its structure",
+		Long: `Parse a draft Congressional bill file and display its structural
+metadata, sections, and extracted amendments.
+
+The parser extracts bill number, title, Congress, session, and
+individual sections. The amendment recognizer then analyzes each
+section's text to identify amendment instructions (strike-and-insert,
+repeal, add new section, etc.) and their USC targets.
+
+Bills published by Congress as XML (the bill DTD used by House and Senate
+legislative counsel, or USLM) are read from their markup when the file has
+an .xml extension, so sections, headings, and quoted matter come from the
+document structure rather than from the plain-text layout.
+
+Examples:
+  regula draft ingest --bill testdata/drafts/hr1234.txt
+  regula draft ingest --bill testdata/drafts/hr1234.xml
+  regula draft ingest --bill draft-hr-1234.txt --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			billPath, _ := cmd.Flags().GetString("bill")
+			formatFlag, _ := cmd.Flags().GetString("format")
+
+			if billPath == "" {
+				return &usageError{err: fmt.Errorf("--bill flag is required: specify the path to a draft bill file")}
+			}
+
+			bill, err := parseBillWithAmendments(billPath)
+			if err != nil {
+				return err
+			}
+
+			switch formatFlag {
+			case "json":
+				data, marshalErr := json.MarshalIndent(bill, "", "  ")
+				if marshalErr != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", marshalErr)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+			case "ndjson":
+				return bill.WriteNDJSON(app.Stdout)
+			default:
+				fmt.Fprint(app.Stdout, formatIngestTable(bill))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("bill", "", "Path to draft bill file (required)")
+	cmd.Flags().String("format", "table", "Output format (table, json, ndjson)")
+
+	return cmd
+}
+
+func draftDiffCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compute structural diff against the USC knowledge graph",
+		Long: `Compute and display a structured diff between a draft bill's
+amendments and existing provisions in the USC knowledge graph.
+
+For each amendment, resolves the target provision in the library,
+counts affected triples, and identifies cross-references. Results
+are classified as added, removed, modified, or redesignated.
+
+Requires a populated library (use 'regula bulk ingest' first).
+
+Examples:
+  regula draft diff --bill testdata/drafts/hr1234.txt
+  regula draft diff --bill draft-hr-1234.txt --path .regula
+  regula draft diff --bill draft-hr-1234.txt --format json
+  regula draft diff --bill draft-hr-1234.txt --format csv`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			billPath, _ := cmd.Flags().GetString("bill")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			formatFlag, _ := cmd.Flags().GetString("format")
+
+			if billPath == "" {
+				return &usageError{err: fmt.Errorf("--bill flag is required: specify the path to a draft bill file")}
+			}
+
+			bill, err := parseBillWithAmendments(billPath)
+			if err != nil {
+				return err
+			}
+
+			diffResult, err := draft.ComputeDiff(bill, libraryPath)
+			if err != nil {
+				return fmt.Errorf("diff computation failed: %w", err)
+			}
+
+			switch formatFlag {
+			case "json":
+				data, marshalErr := json.MarshalIndent(diffResult, "", "  ")
+				if marshalErr != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", marshalErr)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+			case "ndjson":
+				return diffResult.WriteNDJSON(app.Stdout)
+			case "csv":
+				fmt.Fprint(app.Stdout, formatDiffCSV(diffResult))
+			default:
+				fmt.Fprint(app.Stdout, formatDiffTable(diffResult))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("bill", "", "Path to draft bill file (required)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("format", "table", "Output format (table, json, csv, ndjson)")
+
+	return cmd
+}
+
+// parseBillWithAmendments parses a draft bill file and runs amendment
+// recognition on each section. Files with an .xml extension are read as
+// bill DTD or USLM markup; anything else is parsed as plain text. The parser
+// alone does not extract amendments; the Recognizer must be applied
+// separately.
+func parseBillWithAmendments(billPath string) (*draft.DraftBill, error) {
+	parseBill := draft.ParseBillFromFile
+	if draft.IsBillXML(billPath) {
+		parseBill = draft.ParseBillXMLFromFile
+	}
+	bill, err := parseBill(billPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bill: %w", err)
+	}
+
+	recognizer := draft.NewRecognizer()
+	for _, section := range bill.Sections {
+		amendments, extractErr := recognizer.ExtractAmendments(section.RawText)
+		if extractErr != nil {
+			return nil, fmt.Errorf("failed to extract amendments from section %s: %w", section.Number, extractErr)
+		}
+		section.Amendments = amendments
+	}
+
+	return bill, nil
+}
+
+// collectTargetTitles returns a sorted list of unique USC title numbers
+// referenced across all amendments in the bill.
+func collectTargetTitles(bill *draft.DraftBill) []string {
+	titleSet := make(map[string]bool)
+	for _, section := range bill.Sections {
+		for _, amendment := range section.Amendments {
+			if amendment.TargetTitle != "" {
+				titleSet[amendment.TargetTitle] = true
+			}
+		}
+	}
+
+	titles := make([]string, 0, len(titleSet))
+	for title := range titleSet {
+		titles = append(titles, title)
+	}
+	sort.Strings(titles)
+	return titles
+}
+
+// formatIngestTable formats a parsed bill as a human-readable table showing
+// bill metadata, per-section structure, and amendment counts.
+func formatIngestTable(bill *draft.DraftBill) string {
+	var builder strings.Builder
+	stats := bill.Statistics()
+	targetTitles := collectTargetTitles(bill)
+
+	builder.WriteString(fmt.Sprintf("\nDraft Bill: %s\n", bill.String()))
+	builder.WriteString(strings.Repeat("═", 70) + "\n")
+
+	if bill.Congress != "" {
+		builder.WriteString(fmt.Sprintf("  Congress:    %s\n", bill.Congress))
+	}
+	if bill.Session != "" {
+		builder.WriteString(fmt.Sprintf("  Session:     %s\n", bill.Session))
+	}
+	builder.WriteString(fmt.Sprintf("  Sections:    %d\n", stats.SectionCount))
+	builder.WriteString(fmt.Sprintf("  Amendments:  %d\n", stats.AmendmentCount))
+	if len(targetTitles) > 0 {
+		builder.WriteString(fmt.Sprintf("  Targets:     %d title(s) (%s)\n", len(targetTitles), strings.Join(targetTitles, ", ")))
+	}
+	builder.WriteString(strings.Repeat("─", 70) + "\n")
+
+	// Per-section table
+	builder.WriteString(fmt.Sprintf("  %-6s %-38s %s\n", "Sec.", "Title", "Amendments"))
+	builder.WriteString(fmt.Sprintf("  %-6s %-38s %s\n", "────", "──────────────────────────────────────", "──────────"))
+	for _, section := range bill.Sections {
+		sectionTitle := textutil.Truncate(section.Title, 38)
+
+		amendmentSummary := fmt.Sprintf("%d", len(section.Amendments))
+		if len(section.Amendments) > 0 {
+			sectionTargets := make(map[string]bool)
+			for _, amendment := range section.Amendments {
+				if amendment.TargetTitle != "" {
+					sectionTargets[amendment.TargetTitle] = true
+				}
+			}
+			targetList := make([]string, 0, len(sectionTargets))
+			for title := range sectionTargets {
+				targetList = append(targetList, "Title "+title)
+			}
+			sort.Strings(targetList)
+			if len(targetList) > 0 {
+				amendmentSummary += " → " + strings.Join(targetList, ", ")
+			}
+		}
+
+		builder.WriteString(fmt.Sprintf("  %-6s %-38s %s\n", section.Number, sectionTitle, amendmentSummary))
+	}
+
+	builder.WriteString(strings.Repeat("─", 70) + "\n")
+	return builder.String()
+}
+
+// formatDiffTable formats a DraftDiff as a styled table matching the output
+// example from the issue specification.
+func formatDiffTable(diffResult *draft.DraftDiff) string {
+	var builder strings.Builder
+	bill := diffResult.Bill
+	stats := bill.Statistics()
+	targetTitles := collectTargetTitles(bill)
+
+	builder.WriteString(fmt.Sprintf("\nDraft Legislation Diff: %s\n", bill.BillNumber))
+	builder.WriteString(strings.Repeat("═", 70) + "\n")
+	builder.WriteString(fmt.Sprintf("  Bill sections: %d\n", stats.SectionCount))
+	builder.WriteString(fmt.Sprintf("  Amendments:    %d\n", stats.AmendmentCount))
+	if len(targetTitles) > 0 {
+		builder.WriteString(fmt.Sprintf("  Targets:       %d title(s) (%s)\n", len(targetTitles), strings.Join(targetTitles, ", ")))
+	}
+	builder.WriteString("\n")
+
+	// Modified entries
+	if len(diffResult.Modified) > 0 {
+		builder.WriteString(fmt.Sprintf("  MODIFIED (%d):\n", len(diffResult.Modified)))
+		for _, entry := range diffResult.Modified {
+			targetLabel := formatTargetLabel(entry)
+			incomingCount := len(entry.CrossRefsTo)
+			builder.WriteString(fmt.Sprintf("    %-30s %4d triples affected  %3d incoming refs\n",
+				targetLabel, entry.AffectedTriples, incomingCount))
+		}
+		builder.WriteString("\n")
+	}
+
+	// Removed entries
+	if len(diffResult.Removed) > 0 {
+		builder.WriteString(fmt.Sprintf("  REPEALED (%d):\n", len(diffResult.Removed)))
+		for _, entry := range diffResult.Removed {
+			targetLabel := formatTargetLabel(entry)
+			incomingCount := len(entry.CrossRefsTo)
+			highImpact := ""
+			if incomingCount >= 10 {
+				highImpact = "  !! HIGH IMPACT"
+			}
+			builder.WriteString(fmt.Sprintf("    %-30s %4d triples affected  %3d incoming refs%s\n",
+				targetLabel, entry.AffectedTriples, incomingCount, highImpact))
+		}
+		builder.WriteString("\n")
+	}
+
+	// Added entries
+	if len(diffResult.Added) > 0 {
+		builder.WriteString(fmt.Sprintf("  ADDED (%d):\n", len(diffResult.Added)))
+		for _, entry := range diffResult.Added {
+			targetLabel := formatTargetLabel(entry) + " (new)"
+			incomingCount := len(entry.CrossRefsTo)
+			builder.WriteString(fmt.Sprintf("    %-30s %4d triples affected  %3d incoming refs\n",
+				targetLabel, entry.AffectedTriples, incomingCount))
+		}
+		builder.WriteString("\n")
+	}
+
+	// Redesignated entries
+	if len(diffResult.Redesignated) > 0 {
+		builder.WriteString(fmt.Sprintf("  REDESIGNATED (%d):\n", len(diffResult.Redesignated)))
+		for _, entry := range diffResult.Redesignated {
+			targetLabel := formatTargetLabel(entry)
+			builder.WriteString(fmt.Sprintf("    %-30s %4d triples affected\n",
+				targetLabel, entry.AffectedTriples))
+		}
+		builder.WriteString("\n")
+	}
+
+	// Unresolved targets
+	builder.WriteString(fmt.Sprintf("  UNRESOLVED (%d)", len(diffResult.UnresolvedTargets)))
+	if len(diffResult.UnresolvedTargets) > 0 {
+		builder.WriteString(":\n")
+		for _, target := range diffResult.UnresolvedTargets {
+			builder.WriteString(fmt.Sprintf("    %s\n", target))
+		}
+	}
+	builder.WriteString("\n\n")
+
+	builder.WriteString(fmt.Sprintf("  Total triples affected: %d\n", diffResult.TriplesInvalidated))
+	builder.WriteString(strings.Repeat("─", 70) + "\n")
+
+	return builder.String()
+}
+
+// formatTargetLabel creates a human-readable label like "Title 15 §6502(d)"
+// from a DiffEntry's amendment target fields.
+func formatTargetLabel(entry draft.DiffEntry) string {
+	label := "Title " + entry.Amendment.TargetTitle + " §" + entry.Amendment.TargetSection
+	if entry.Amendment.TargetSubsection != "" {
+		label += "(" + entry.Amendment.TargetSubsection + ")"
+	}
+	return label
+}
+
+// formatDiffCSV formats a DraftDiff as CSV with a header row and one row
+// per diff entry across all categories.
+func formatDiffCSV(diffResult *draft.DraftDiff) string {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	header := []string{"category", "title", "section", "subsection", "type", "triples_affected", "incoming_refs", "outgoing_refs", "target_uri", "document_id"}
+	_ = writer.Write(header)
+
+	writeDiffEntries := func(category string, entries []draft.DiffEntry) {
+		for _, entry := range entries {
+			row := []string{
+				category,
+				entry.Amendment.TargetTitle,
+				entry.Amendment.TargetSection,
+				entry.Amendment.TargetSubsection,
+				string(entry.Amendment.Type),
+				fmt.Sprintf("%d", entry.AffectedTriples),
+				fmt.Sprintf("%d", len(entry.CrossRefsTo)),
+				fmt.Sprintf("%d", len(entry.CrossRefsFrom)),
+				entry.TargetURI,
+				entry.TargetDocumentID,
+			}
+			_ = writer.Write(row)
+		}
+	}
+
+	writeDiffEntries("modified", diffResult.Modified)
+	writeDiffEntries("repealed", diffResult.Removed)
+	writeDiffEntries("added", diffResult.Added)
+	writeDiffEntries("redesignated", diffResult.Redesignated)
+
+	writer.Flush()
+	return buffer.String()
+}
+
+func draftImpactCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "impact",
+		Short: "Run impact analysis for a draft bill",
+		Long: `Run transitive impact analysis for a draft bill against the USC
+knowledge graph. Identifies directly and transitively affected provisions,
+broken cross-references, and obligation/rights changes.
+
+Requires a populated library (use 'regula bulk ingest' first).
+
+Output formats:
+  table   Styled summary with per-amendment breakdown (default)
+  json    Full DraftImpactResult as indented JSON
+  ndjson  One JSON object per affected provision and broken reference
+  dot     Graphviz DOT graph (pipe to 'dot -Tpng' for rendering)
+
+Examples:
+  regula draft impact --bill draft-hr-1234.txt
+  regula draft impact --bill draft-hr-1234.txt --depth 3
+  regula draft impact --bill draft-hr-1234.txt --format json
+  regula draft impact --bill draft-hr-1234.txt --format dot --output impact.dot
+  regula draft impact --bill draft-hr-1234.txt --title-filter 15,42`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			billPath, _ := cmd.Flags().GetString("bill")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			depthFlag, _ := cmd.Flags().GetInt("depth")
+			formatFlag, _ := cmd.Flags().GetString("format")
+			outputPath, _ := cmd.Flags().GetString("output")
+			titleFilter, _ := cmd.Flags().GetString("title-filter")
+
+			if billPath == "" {
+				return &usageError{err: fmt.Errorf("--bill flag is required: specify the path to a draft bill file")}
+			}
+
+			bill, err := parseBillWithAmendments(billPath)
+			if err != nil {
+				return err
+			}
+
+			diffResult, err := draft.ComputeDiff(bill, libraryPath)
+			if err != nil {
+				return fmt.Errorf("diff computation failed: %w", err)
+			}
+
+			impactResult, err := draft.AnalyzeDraftImpact(diffResult, libraryPath, depthFlag)
+			if err != nil {
+				return fmt.Errorf("impact analysis failed: %w", err)
+			}
+
+			impactResult.SortByDepth()
+
+			// Apply title filter if specified
+			if titleFilter != "" {
+				filterTitles := parseTitleFilter(titleFilter)
+				impactResult = filterImpactByTitles(impactResult, filterTitles)
+			}
+
+			switch formatFlag {
+			case "json":
+				data, marshalErr := json.MarshalIndent(impactResult, "", "  ")
+				if marshalErr != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", marshalErr)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+			case "ndjson":
+				return impactResult.WriteNDJSON(app.Stdout)
+			case "dot":
+				dotContent, dotErr := draft.RenderImpactGraph(impactResult)
+				if dotErr != nil {
+					return fmt.Errorf("failed to render DOT graph: %w", dotErr)
+				}
+				if outputPath != "" {
+					if writeErr := os.WriteFile(outputPath, []byte(dotContent), 0644); writeErr != nil {
+						return fmt.Errorf("failed to write DOT file: %w", writeErr)
+					}
+					fmt.Fprintf(app.Stderr, "DOT graph written to %s\n", outputPath)
+					fmt.Fprintf(app.Stderr, "Render with: dot -Tpng %s -o impact.png\n", outputPath)
+				} else {
+					fmt.Fprint(app.Stdout, dotContent)
+				}
+			default:
+				fmt.Fprint(app.Stdout, formatImpactTable(impactResult))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("bill", "", "Path to draft bill file (required)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().Int("depth", 2, "Transitive dependency depth (1=direct only)")
+	cmd.Flags().String("format", "table", "Output format (table, json, ndjson, dot)")
+	cmd.Flags().String("output", "", "Output file path (useful for DOT format)")
+	cmd.Flags().String("title-filter", "", "Limit analysis to specific USC titles (comma-separated, e.g. 15,42)")
+
+	return cmd
+}
+
+// parseTitleFilter splits a comma-separated title filter string into a set
+// of title numbers for lookup.
+func parseTitleFilter(titleFilter string) map[string]bool {
+	filterSet := make(map[string]bool)
+	for _, segment := range strings.Split(titleFilter, ",") {
+		trimmed := strings.TrimSpace(segment)
+		if trimmed != "" {
+			filterSet[trimmed] = true
+		}
+	}
+	return filterSet
+}
+
+// filterImpactByTitles returns a copy of the impact result containing only
+// provisions and broken refs whose document IDs match the specified title
+// numbers. The diff is preserved unfiltered.
+func filterImpactByTitles(result *draft.DraftImpactResult, titleNumbers map[string]bool) *draft.DraftImpactResult {
+	matchesFilter := func(documentID string) bool {
+		for titleNum := range titleNumbers {
+			if strings.HasSuffix(documentID, "-"+titleNum) {
+				return true
+			}
+		}
+		return false
+	}
+
+	filtered := &draft.DraftImpactResult{
+		Bill:              result.Bill,
+		Diff:              result.Diff,
+		ObligationChanges: result.ObligationChanges,
+		RightsChanges:     result.RightsChanges,
+		MaxDepthReached:   result.MaxDepthReached,
+	}
+
+	for _, provision := range result.DirectlyAffected {
+		if matchesFilter(provision.DocumentID) {
+			filtered.DirectlyAffected = append(filtered.DirectlyAffected, provision)
+		}
+	}
+	for _, provision := range result.TransitivelyAffected {
+		if matchesFilter(provision.DocumentID) {
+			filtered.TransitivelyAffected = append(filtered.TransitivelyAffected, provision)
+		}
+	}
+	for _, brokenRef := range result.BrokenCrossRefs {
+		if matchesFilter(brokenRef.SourceDocumentID) {
+			filtered.BrokenCrossRefs = append(filtered.BrokenCrossRefs, brokenRef)
+		}
+	}
+
+	filtered.TotalProvisionsAffected = len(filtered.DirectlyAffected) + len(filtered.TransitivelyAffected)
+	return filtered
+}
+
+// formatImpactTable formats a DraftImpactResult as a styled table matching
+// the output example from issue #156.
+func formatImpactTable(result *draft.DraftImpactResult) string {
+	var builder strings.Builder
+	bill := result.Bill
+	targetTitles := collectTargetTitles(bill)
+
+	billLabel := bill.BillNumber
+	if bill.ShortTitle != "" {
+		billLabel += " — " + bill.ShortTitle
+	} else if bill.Title != "" {
+		billLabel += " — " + bill.Title
+	}
+
+	builder.WriteString(fmt.Sprintf("\nDraft Impact Analysis: %s\n", billLabel))
+	builder.WriteString(strings.Repeat("═", 70) + "\n")
+
+	stats := bill.Statistics()
+	titleSummary := ""
+	if len(targetTitles) > 0 {
+		titleSummary = fmt.Sprintf(" across %d title(s)", len(targetTitles))
+	}
+	builder.WriteString(fmt.Sprintf("  Amendments: %d%s\n", stats.AmendmentCount, titleSummary))
+	builder.WriteString(fmt.Sprintf("  Directly affected: %d provisions\n", len(result.DirectlyAffected)))
+	builder.WriteString(fmt.Sprintf("  Transitively affected: %d provisions (depth %d)\n", len(result.TransitivelyAffected), result.MaxDepthReached))
+	builder.WriteString(fmt.Sprintf("  Broken cross-references: %d\n", len(result.BrokenCrossRefs)))
+	builder.WriteString("\n")
+
+	// Per-amendment impact breakdown
+	if result.Diff != nil {
+		amendmentEntries := collectAmendmentSummaryEntries(result)
+		if len(amendmentEntries) > 0 {
+			builder.WriteString("  IMPACT BY AMENDMENT:\n")
+			builder.WriteString("  " + strings.Repeat("─", 58) + "\n")
+			for _, entry := range amendmentEntries {
+				builder.WriteString(entry)
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	// Broken cross-references section
+	if len(result.BrokenCrossRefs) > 0 {
+		builder.WriteString("  BROKEN CROSS-REFERENCES:\n")
+		builder.WriteString("  " + strings.Repeat("─", 58) + "\n")
+		for _, brokenRef := range result.BrokenCrossRefs {
+			severityTag := strings.ToUpper(brokenRef.Severity.String())
+			builder.WriteString(fmt.Sprintf("    [%s] %s → %s (%s)\n",
+				severityTag,
+				truncateImpactLabel(brokenRef.SourceLabel, 30),
+				truncateImpactLabel(brokenRef.TargetLabel, 20),
+				brokenRef.Reason))
+		}
+		builder.WriteString("\n")
+	}
+
+	// Obligation/rights changes section
+	obligationTotal := len(result.ObligationChanges.Added) +
+		len(result.ObligationChanges.Removed) +
+		len(result.ObligationChanges.Modified)
+	rightsTotal := len(result.RightsChanges.Added) +
+		len(result.RightsChanges.Removed)
+
+	if obligationTotal > 0 || rightsTotal > 0 {
+		builder.WriteString("  OBLIGATION/RIGHTS CHANGES:\n")
+		builder.WriteString("  " + strings.Repeat("─", 58) + "\n")
+		builder.WriteString(fmt.Sprintf("    Obligations added:    %d\n", len(result.ObligationChanges.Added)))
+		builder.WriteString(fmt.Sprintf("    Obligations removed:  %d\n", len(result.ObligationChanges.Removed)))
+		builder.WriteString(fmt.Sprintf("    Obligations modified: %d\n", len(result.ObligationChanges.Modified)))
+		builder.WriteString(fmt.Sprintf("    Rights added:         %d\n", len(result.RightsChanges.Added)))
+		builder.WriteString(fmt.Sprintf("    Rights removed:       %d\n", len(result.RightsChanges.Removed)))
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString(strings.Repeat("═", 70) + "\n")
+	return builder.String()
+}
+
+// collectAmendmentSummaryEntries generates per-amendment summary lines for
+// the impact table, showing direct/transitive counts and broken refs per
+// modified or repealed target.
+func collectAmendmentSummaryEntries(result *draft.DraftImpactResult) []string {
+	var entries []string
+	if result.Diff == nil {
+		return entries
+	}
+
+	writeAmendmentGroup := func(diffEntries []draft.DiffEntry, changeLabel string) {
+		for _, entry := range diffEntries {
+			targetLabel := formatTargetLabel(entry)
+			directCount := countAffectedByTarget(entry, result.DirectlyAffected)
+			transitiveCount := countAffectedByTarget(entry, result.TransitivelyAffected)
+			brokenRefCount := countBrokenRefsByTarget(entry, result.BrokenCrossRefs)
+
+			var entryBuilder strings.Builder
+			highImpact := ""
+			if directCount >= 10 {
+				highImpact = "  !! HIGH IMPACT"
+			}
+			entryBuilder.WriteString(fmt.Sprintf("  %s [%s]%s\n", targetLabel, changeLabel, highImpact))
+			entryBuilder.WriteString(fmt.Sprintf("    Direct:      %d provisions reference this section\n", directCount))
+			entryBuilder.WriteString(fmt.Sprintf("    Transitive:  %d provisions at depth 2+\n", transitiveCount))
+
+			obligationCount := countObligationsByTarget(entry, result)
+			if obligationCount > 0 {
+				entryBuilder.WriteString(fmt.Sprintf("    Obligations: %d modified\n", obligationCount))
+			}
+			if brokenRefCount > 0 {
+				entryBuilder.WriteString(fmt.Sprintf("    Broken refs: %d (severity: error)\n", brokenRefCount))
+			}
+			entryBuilder.WriteString("\n")
+			entries = append(entries, entryBuilder.String())
+		}
+	}
+
+	writeAmendmentGroup(result.Diff.Modified, "MODIFIED")
+	writeAmendmentGroup(result.Diff.Removed, "REPEALED")
+	writeAmendmentGroup(result.Diff.Added, "ADDED")
+	writeAmendmentGroup(result.Diff.Redesignated, "REDESIGNATED")
+
+	return entries
+}
+
+// countAffectedByTarget counts how many affected provisions reference the
+// given diff entry's target, by checking if the provision's Reason field
+// mentions the target URI label.
+func countAffectedByTarget(entry draft.DiffEntry, provisions []draft.AffectedProvision) int {
+	targetLabel := extractDiffTargetLabel(entry)
+	count := 0
+	for _, provision := range provisions {
+		if strings.Contains(provision.Reason, targetLabel) {
+			count++
+		}
+	}
+	return count
+}
+
+// countBrokenRefsByTarget counts broken references whose target matches
+// the given diff entry.
+func countBrokenRefsByTarget(entry draft.DiffEntry, brokenRefs []draft.BrokenReference) int {
+	count := 0
+	for _, brokenRef := range brokenRefs {
+		if brokenRef.TargetURI == entry.TargetURI {
+			count++
+		}
+	}
+	return count
+}
+
+// countObligationsByTarget counts obligation changes associated with a
+// diff entry's target URI label.
+func countObligationsByTarget(entry draft.DiffEntry, result *draft.DraftImpactResult) int {
+	targetLabel := extractDiffTargetLabel(entry)
+	count := 0
+	for _, obligation := range result.ObligationChanges.Modified {
+		if strings.Contains(obligation, targetLabel) {
+			count++
+		}
+	}
+	for _, obligation := range result.ObligationChanges.Removed {
+		if strings.Contains(obligation, targetLabel) {
+			count++
+		}
+	}
+	return count
+}
+
+// extractDiffTargetLabel extracts the last segment of a diff entry's
+// target URI for matching against reason strings.
+func extractDiffTargetLabel(entry draft.DiffEntry) string {
+	uri := entry.TargetURI
+	for i := len(uri) - 1; i >= 0; i-- {
+		if uri[i] == ':' || uri[i] == '/' || uri[i] == '#' {
+			return uri[i+1:]
+		}
+	}
+	return uri
+}
+
+// truncateImpactLabel shortens a label for table display.
+func truncateImpactLabel(label string, maxLen int) string {
+	return textutil.Truncate(label, maxLen)
+}
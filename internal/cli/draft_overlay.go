@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/draft"
+	"github.com/spf13/cobra"
+)
+
+// draftOverlayCmd creates the 'regula draft overlay' command.
+func draftOverlayCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "overlay",
+		Short: "Save the proposed-law graph of a draft bill",
+		Long: `Apply a draft bill's amendments to the library graphs of the documents it
+amends, as 'regula draft simulate' does, and save the result: each amended
+document's proposed graph and, per amendment, the triples it removed and
+added.
+
+Query the saved overlay as if the bill had passed with
+'regula library query --overlay'. The library itself is not changed.
+
+Without --output the overlay is written to stdout as JSON.
+
+Examples:
+  regula draft overlay --bill draft-hr-1234.txt --output hr1234-overlay.json
+  regula draft overlay --bill draft-hr-1234.txt --document us-usc-title-15 --output title-15.json
+  regula library query --overlay hr1234-overlay.json --template obligations`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			billPath, _ := cmd.Flags().GetString("bill")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			outputPath, _ := cmd.Flags().GetString("output")
+			documentIDs, _ := cmd.Flags().GetStringSlice("document")
+
+			if billPath == "" {
+				return &usageError{err: fmt.Errorf("--bill flag is required: specify the path to a draft bill file")}
+			}
+
+			bill, err := parseBillWithAmendments(billPath)
+			if err != nil {
+				return err
+			}
+			diffResult, err := draft.ComputeDiff(bill, libraryPath)
+			if err != nil {
+				return fmt.Errorf("diff computation failed: %w", err)
+			}
+			overlay, err := draft.ApplyDraftOverlayWithOptions(diffResult, libraryPath, draft.OverlayOptions{RecordPatches: true})
+			if err != nil {
+				return fmt.Errorf("failed to apply draft overlay: %w", err)
+			}
+
+			overlayFile := draft.NewOverlayFile(overlay, bill)
+			if len(documentIDs) > 0 {
+				if err := overlayFile.Select(documentIDs...); err != nil {
+					return err
+				}
+			}
+
+			if outputPath == "" {
+				encoder := json.NewEncoder(app.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(overlayFile)
+			}
+			if err := overlayFile.Save(outputPath); err != nil {
+				return err
+			}
+			fmt.Fprint(app.messageWriter(), formatOverlaySummary(overlayFile, outputPath))
+			return nil
+		},
+	}
+
+	cmd.Flags().String("bill", "", "Path to draft bill file (required)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringP("output", "o", "", "Write the overlay to this file instead of stdout")
+	cmd.Flags().StringSlice("document", nil, "Keep only these amended documents (comma-separated)")
+
+	return cmd
+}
+
+// formatOverlaySummary describes a saved overlay.
+func formatOverlaySummary(overlayFile *draft.OverlayFile, outputPath string) string {
+	var sb strings.Builder
+
+	patches := make(map[string]int)
+	added := make(map[string]int)
+	removed := make(map[string]int)
+	for _, patch := range overlayFile.Patches {
+		patches[patch.DocumentID]++
+		added[patch.DocumentID] += len(patch.Added)
+		removed[patch.DocumentID] += len(patch.Removed)
+	}
+
+	sb.WriteString(fmt.Sprintf("Draft overlay: %s %s\n", overlayFile.BillNumber, overlayFile.BillTitle))
+	sb.WriteString(fmt.Sprintf("Amendments: %d applied, %d skipped\n\n", len(overlayFile.Patches), len(overlayFile.Skipped)))
+	if len(overlayFile.Documents) == 0 {
+		sb.WriteString("No library documents are amended by the bill.\n")
+	}
+	for _, documentID := range overlayFile.DocumentIDs() {
+		sb.WriteString(fmt.Sprintf("  %-24s %8d triples  %d patch(es), +%d / -%d\n",
+			documentID, len(overlayFile.Documents[documentID]), patches[documentID], added[documentID], removed[documentID]))
+	}
+	for _, skipped := range overlayFile.Skipped {
+		sb.WriteString(fmt.Sprintf("  skipped %s %s: %s\n", skipped.Amendment.Type, skipped.Amendment.TargetSection, skipped.Reason))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nWrote %s\n", outputPath))
+	sb.WriteString(fmt.Sprintf("Query it with: regula library query --overlay %s <query>\n", outputPath))
+	return sb.String()
+}
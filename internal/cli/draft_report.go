@@ -0,0 +1,432 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/draft"
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/simulate"
+	"github.com/spf13/cobra"
+)
+
+func draftReportCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Generate comprehensive legislative impact report",
+		Long: `Generate a full legislative impact report for a draft bill.
+
+This command runs the complete analysis pipeline:
+  1. Parse draft bill and extract amendments
+  2. Compute structural diff against USC knowledge graph
+  3. Analyze transitive impact across provisions
+  4. Detect obligation and rights conflicts
+  5. Analyze temporal consistency
+  6. Run scenario comparisons (optional)
+  7. Generate formatted report
+
+The report aggregates all findings into a single document with:
+  - Executive summary with key metrics
+  - Risk level assessment (low/medium/high)
+  - Structural changes (modified/repealed/added provisions)
+  - Impact analysis (direct and transitive effects)
+  - Conflict findings (errors/warnings/info)
+  - Temporal analysis (gaps, contradictions, retroactive application)
+  - Scenario comparisons (baseline vs proposed)
+  - Visualization (DOT graph for Markdown/HTML)
+
+Exit codes reflect risk level:
+  0 = low risk
+  1 = medium risk
+  2 = high risk
+
+Examples:
+  # Full report to markdown (default)
+  regula draft report --bill draft-hr-1234.txt --path .regula
+
+  # HTML report to file
+  regula draft report --bill draft-hr-1234.txt --format html --output report.html
+
+  # Quick analysis without scenarios
+  regula draft report --bill draft-hr-1234.txt --skip-scenarios
+
+  # JSON for programmatic consumption
+  regula draft report --bill draft-hr-1234.txt --format json > report.json
+
+  # Deep impact analysis
+  regula draft report --bill draft-hr-1234.txt --depth 5`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			billPath, _ := cmd.Flags().GetString("bill")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			formatFlag, _ := cmd.Flags().GetString("format")
+			outputPath, _ := cmd.Flags().GetString("output")
+			depthFlag, _ := cmd.Flags().GetInt("depth")
+			scenariosFlag, _ := cmd.Flags().GetString("scenarios")
+			skipTemporal, _ := cmd.Flags().GetBool("skip-temporal")
+			skipScenarios, _ := cmd.Flags().GetBool("skip-scenarios")
+
+			// Validate required flags
+			if billPath == "" {
+				return &usageError{err: fmt.Errorf("--bill flag is required: specify the path to a draft bill file")}
+			}
+
+			// Parse the bill with amendments
+			bill, err := parseBillWithAmendments(billPath)
+			if err != nil {
+				return err
+			}
+
+			// Build report options
+			options := draft.ReportOptions{
+				IncludeDiff:          true,
+				IncludeImpact:        true,
+				ImpactDepth:          depthFlag,
+				IncludeConflicts:     true,
+				IncludeTemporal:      !skipTemporal,
+				IncludeVisualization: formatFlag != "json", // Skip DOT for JSON
+				Scenarios:            []string{},
+			}
+
+			// Parse scenarios flag
+			if !skipScenarios && scenariosFlag != "none" {
+				if scenariosFlag == "all" {
+					for scenarioID := range simulate.PredefinedScenarios {
+						options.Scenarios = append(options.Scenarios, scenarioID)
+					}
+				} else if scenariosFlag != "" {
+					options.Scenarios = strings.Split(scenariosFlag, ",")
+					for i, s := range options.Scenarios {
+						options.Scenarios[i] = strings.TrimSpace(s)
+					}
+				}
+			}
+
+			// Generate the report
+			report, err := draft.GenerateReport(bill, libraryPath, options)
+			if err != nil {
+				// GenerateReport may return partial report with error
+				if report == nil {
+					return fmt.Errorf("report generation failed: %w", err)
+				}
+				// Log warning but continue with partial report
+				fmt.Fprintf(app.Stderr, "Warning: %v\n", err)
+			}
+
+			// Run scenario comparisons if requested
+			if len(options.Scenarios) > 0 && report.Diff != nil {
+				scenarioResults, scenarioErr := runReportScenarios(report, libraryPath, options.Scenarios)
+				if scenarioErr != nil {
+					fmt.Fprintf(app.Stderr, "Warning: scenario comparison failed: %v\n", scenarioErr)
+				} else {
+					report.ScenarioResults = scenarioResults
+				}
+			}
+
+			translator, err := outputTranslator(cmd, libraryPath)
+			if err != nil {
+				return err
+			}
+
+			// Render the report in the requested format
+			var output string
+			var renderErr error
+			switch strings.ToLower(formatFlag) {
+			case "json":
+				output, renderErr = draft.RenderReportJSON(report)
+			case "html":
+				output, renderErr = draft.RenderReportHTMLLocalized(report, translator)
+			case "markdown", "md":
+				fallthrough
+			default:
+				output, renderErr = draft.RenderReportMarkdownLocalized(report, translator)
+			}
+
+			if renderErr != nil {
+				return fmt.Errorf("failed to render report: %w", renderErr)
+			}
+
+			// Write output
+			if outputPath != "" {
+				if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+					return fmt.Errorf("failed to write output file: %w", err)
+				}
+				fmt.Fprintf(app.Stderr, "Report written to %s\n", outputPath)
+			} else {
+				fmt.Fprint(app.Stdout, output)
+			}
+
+			// Exit with code based on risk level
+			switch report.RiskLevel {
+			case draft.RiskMedium:
+				return exitWithCode(cmd, 1)
+			case draft.RiskHigh:
+				return exitWithCode(cmd, 2)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("bill", "", "Path to draft bill file (required)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("format", "markdown", "Output format: markdown, json, html")
+	cmd.Flags().String("output", "", "Output file path (default: stdout)")
+	cmd.Flags().Int("depth", 2, "Transitive impact analysis depth")
+	cmd.Flags().String("scenarios", "none", "Scenarios to test (comma-separated, 'all', or 'none')")
+	cmd.Flags().Bool("skip-temporal", false, "Skip temporal consistency analysis")
+	cmd.Flags().Bool("skip-scenarios", false, "Skip scenario comparison (faster)")
+
+	return cmd
+}
+
+func draftCompareVersionsCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare-versions",
+		Short: "Compare two versions of a bill",
+		Long: `Compare two versions of the same bill, such as introduced (IH) and
+reported (RH) or engrossed (EH), and report how they differ.
+
+Amendments are matched by target provision and reported as added, removed,
+or changed. Each version is then analyzed against the USC knowledge graph
+and the affected provisions, broken cross-references, obligation
+conflicts, and temporal findings are compared: findings only in the target
+version are new, and findings only in the base version were resolved.
+
+Requires a populated library (use 'regula bulk ingest' first).
+
+Examples:
+  regula draft compare-versions --base hr1234-ih.txt --target hr1234-rh.txt
+  regula draft compare-versions --base hr1234-ih.txt --target hr1234-eh.txt --format markdown
+  regula draft compare-versions --base hr1234-ih.txt --target hr1234-rh.txt --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			basePath, _ := cmd.Flags().GetString("base")
+			targetPath, _ := cmd.Flags().GetString("target")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			depthFlag, _ := cmd.Flags().GetInt("depth")
+			skipTemporal, _ := cmd.Flags().GetBool("skip-temporal")
+			formatFlag, _ := cmd.Flags().GetString("format")
+			outputPath, _ := cmd.Flags().GetString("output")
+
+			if basePath == "" || targetPath == "" {
+				return fmt.Errorf("--base and --target flags are required: specify the two bill versions to compare")
+			}
+
+			options := draft.ReportOptions{
+				IncludeDiff:      true,
+				IncludeImpact:    true,
+				ImpactDepth:      depthFlag,
+				IncludeConflicts: true,
+				IncludeTemporal:  !skipTemporal,
+			}
+
+			reports := make([]*draft.LegislativeImpactReport, 2)
+			for i, billPath := range []string{basePath, targetPath} {
+				bill, err := parseBillWithAmendments(billPath)
+				if err != nil {
+					return err
+				}
+				report, err := draft.GenerateReport(bill, libraryPath, options)
+				if err != nil {
+					if report == nil {
+						return fmt.Errorf("analysis of %s failed: %w", billPath, err)
+					}
+					fmt.Fprintf(app.Stderr, "Warning: %s: %v\n", billPath, err)
+				}
+				reports[i] = report
+			}
+
+			comparison := draft.CompareVersions(reports[0], reports[1])
+
+			var output string
+			switch formatFlag {
+			case "json":
+				data, marshalErr := json.MarshalIndent(comparison, "", "  ")
+				if marshalErr != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", marshalErr)
+				}
+				output = string(data) + "\n"
+			case "ndjson":
+				var buffer bytes.Buffer
+				if err := comparison.WriteNDJSON(&buffer); err != nil {
+					return err
+				}
+				output = buffer.String()
+			case "markdown", "md":
+				output = draft.RenderVersionComparisonMarkdown(comparison)
+			case "table":
+				output = formatVersionComparisonTable(comparison)
+			default:
+				return fmt.Errorf("unknown format: %s (use table, json, ndjson, or markdown)", formatFlag)
+			}
+
+			if outputPath != "" {
+				if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+					return fmt.Errorf("failed to write output file: %w", err)
+				}
+				fmt.Fprintf(app.Stderr, "Comparison written to %s\n", outputPath)
+				return nil
+			}
+			fmt.Fprint(app.Stdout, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("base", "", "Path to the earlier bill version (required)")
+	cmd.Flags().String("target", "", "Path to the later bill version (required)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().Int("depth", 2, "Transitive impact analysis depth")
+	cmd.Flags().Bool("skip-temporal", false, "Skip temporal consistency analysis")
+	cmd.Flags().String("format", "table", "Output format (table, json, ndjson, markdown)")
+	cmd.Flags().String("output", "", "Output file path (default: stdout)")
+
+	return cmd
+}
+
+// formatVersionComparisonTable formats a bill version comparison as a
+// human-readable summary with per-category new and resolved findings.
+func formatVersionComparisonTable(comparison *draft.VersionComparison) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("\nBill Version Comparison: %s\n", comparison.Target.BillNumber))
+	builder.WriteString(strings.Repeat("═", 70) + "\n")
+	builder.WriteString(fmt.Sprintf("  Base:    %s\n", comparison.Base.Filename))
+	builder.WriteString(fmt.Sprintf("  Target:  %s\n", comparison.Target.Filename))
+	builder.WriteString(fmt.Sprintf("  Risk:    %s → %s\n", comparison.BaseRisk, comparison.TargetRisk))
+	builder.WriteString(fmt.Sprintf("  Amendments: %d added, %d removed, %d changed, %d unchanged\n",
+		len(comparison.AddedAmendments), len(comparison.RemovedAmendments),
+		len(comparison.ChangedAmendments), comparison.UnchangedAmendments))
+	builder.WriteString(strings.Repeat("─", 70) + "\n")
+
+	if !comparison.HasChanges() {
+		builder.WriteString("  No differences between versions.\n\n")
+		return builder.String()
+	}
+
+	amendmentLabel := func(amendment draft.Amendment) string {
+		label := "Title " + amendment.TargetTitle
+		if amendment.TargetSection != "" {
+			label += " §" + amendment.TargetSection
+		}
+		if amendment.TargetSubsection != "" {
+			label += "(" + amendment.TargetSubsection + ")"
+		}
+		return label
+	}
+	writeGroup := func(heading string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		builder.WriteString(fmt.Sprintf("  %s (%d):\n", heading, len(lines)))
+		for _, line := range lines {
+			builder.WriteString("    " + line + "\n")
+		}
+		builder.WriteString("\n")
+	}
+
+	var lines []string
+	for _, amendment := range comparison.AddedAmendments {
+		lines = append(lines, fmt.Sprintf("+ %-30s %s", amendmentLabel(amendment), amendment.Type))
+	}
+	for _, amendment := range comparison.RemovedAmendments {
+		lines = append(lines, fmt.Sprintf("- %-30s %s", amendmentLabel(amendment), amendment.Type))
+	}
+	for _, change := range comparison.ChangedAmendments {
+		detail := "text changed"
+		if change.Base.Type != change.Target.Type {
+			detail = fmt.Sprintf("%s → %s", change.Base.Type, change.Target.Type)
+		}
+		lines = append(lines, fmt.Sprintf("~ %-30s %s", amendmentLabel(change.Target), detail))
+	}
+	writeGroup("AMENDMENTS", lines)
+
+	lines = nil
+	for _, provision := range comparison.NewImpacts {
+		lines = append(lines, fmt.Sprintf("+ %-50s depth %d", truncateString(provision.Label, 50), provision.Depth))
+	}
+	for _, provision := range comparison.ResolvedImpacts {
+		lines = append(lines, fmt.Sprintf("- %-50s depth %d", truncateString(provision.Label, 50), provision.Depth))
+	}
+	writeGroup("AFFECTED PROVISIONS", lines)
+
+	lines = nil
+	for _, ref := range comparison.NewBrokenRefs {
+		lines = append(lines, fmt.Sprintf("+ %s → %s", truncateString(ref.SourceLabel, 30), truncateString(ref.TargetLabel, 30)))
+	}
+	for _, ref := range comparison.ResolvedBrokenRefs {
+		lines = append(lines, fmt.Sprintf("- %s → %s", truncateString(ref.SourceLabel, 30), truncateString(ref.TargetLabel, 30)))
+	}
+	writeGroup("BROKEN CROSS-REFERENCES", lines)
+
+	lines = nil
+	for _, conflict := range comparison.NewConflicts {
+		lines = append(lines, fmt.Sprintf("+ [%s] %s", conflict.Severity, truncateString(conflict.Description, 60)))
+	}
+	for _, conflict := range comparison.ResolvedConflicts {
+		lines = append(lines, fmt.Sprintf("- [%s] %s", conflict.Severity, truncateString(conflict.Description, 60)))
+	}
+	writeGroup("CONFLICTS", lines)
+
+	lines = nil
+	for _, finding := range comparison.NewTemporalFindings {
+		lines = append(lines, fmt.Sprintf("+ [%s] %s", finding.Severity, truncateString(finding.Description, 60)))
+	}
+	for _, finding := range comparison.ResolvedTemporalFindings {
+		lines = append(lines, fmt.Sprintf("- [%s] %s", finding.Severity, truncateString(finding.Description, 60)))
+	}
+	writeGroup("TEMPORAL FINDINGS", lines)
+
+	builder.WriteString("  + new in target   - resolved since base   ~ changed\n\n")
+	return builder.String()
+}
+
+// runReportScenarios runs scenario comparisons for the report.
+func runReportScenarios(report *draft.LegislativeImpactReport, libraryPath string, scenarioIDs []string) ([]*draft.ScenarioComparison, error) {
+	if report.Diff == nil {
+		return nil, fmt.Errorf("diff is nil")
+	}
+
+	lib, err := library.Open(libraryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open library: %w", err)
+	}
+
+	// Apply draft overlay
+	overlay, err := draft.ApplyDraftOverlay(report.Diff, libraryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply draft overlay: %w", err)
+	}
+
+	// Load base store
+	baseStore, err := loadMergedTripleStore(lib, report.Diff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load base store: %w", err)
+	}
+
+	var results []*draft.ScenarioComparison
+
+	for _, scenarioID := range scenarioIDs {
+		scenario, ok := simulate.PredefinedScenarios[scenarioID]
+		if !ok {
+			continue // Skip unknown scenarios
+		}
+
+		comparison, compareErr := draft.CompareScenarios(
+			scenarioID,
+			scenario,
+			baseStore,
+			overlay.OverlayStore,
+			lib.BaseURI(),
+			report.Bill,
+		)
+		if compareErr != nil {
+			continue // Skip failed comparisons
+		}
+
+		results = append(results, comparison)
+	}
+
+	return results, nil
+}
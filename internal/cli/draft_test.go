@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/draft"
+)
+
+func TestDraftOverlayCmd(t *testing.T) {
+	libraryPath := filepath.Join(t.TempDir(), "lib")
+	if _, stderr, code := runCLI(t, "library", "init", "--path", libraryPath); code != 0 {
+		t.Fatalf("library init failed: %s", stderr)
+	}
+	if _, stderr, code := runCLI(t, "library", "add", "--path", libraryPath, "--source", testdataPath(t, "us-coppa.txt"),
+		"--id", "us-usc-title-15"); code != 0 {
+		t.Fatalf("library add failed: %s", stderr)
+	}
+
+	overlayPath := filepath.Join(t.TempDir(), "overlay.json")
+	stdout, stderr, code := runCLI(t, "draft", "overlay", "--path", libraryPath,
+		"--bill", testdataPath(t, filepath.Join("drafts", "hr1234.txt")), "--output", overlayPath)
+	if code != 0 {
+		t.Fatalf("draft overlay failed: %s", stderr)
+	}
+	if !strings.Contains(stdout, "us-usc-title-15") || !strings.Contains(stdout, "4 applied") {
+		t.Errorf("draft overlay output:\n%s", stdout)
+	}
+	overlayFile, err := draft.LoadOverlayFile(overlayPath)
+	if err != nil {
+		t.Fatalf("LoadOverlayFile failed: %v", err)
+	}
+	if len(overlayFile.Patches) != 4 {
+		t.Errorf("patches = %d, want 4", len(overlayFile.Patches))
+	}
+
+	// Sections the bill adds exist only when querying with the overlay.
+	addedSection := `SELECT ?article WHERE { ?article reg:number "6502" . ?article reg:text ?text } LIMIT 5`
+	stdout, _, _ = runCLI(t, "library", "query", "--path", libraryPath, "--format", "csv", addedSection)
+	baseline := strings.Count(stdout, "\n")
+	stdout, stderr, code = runCLI(t, "library", "query", "--path", libraryPath, "--format", "csv",
+		"--overlay", overlayPath, addedSection)
+	if code != 0 {
+		t.Fatalf("library query --overlay failed: %s", stderr)
+	}
+	if strings.Count(stdout, "\n") <= baseline {
+		t.Errorf("overlay query found no proposed sections:\n%s", stdout)
+	}
+
+	if _, _, code := runCLI(t, "draft", "overlay", "--path", libraryPath,
+		"--bill", testdataPath(t, filepath.Join("drafts", "hr1234.txt")), "--document", "us-usc-title-42"); code == 0 {
+		t.Error("draft overlay accepted a document the bill does not amend")
+	}
+}
+
+func TestDraftIngestCmd_BillXML(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "draft", "ingest", "--format", "json",
+		"--bill", testdataPath(t, filepath.Join("drafts", "hr1234.xml")))
+	if code != 0 {
+		t.Fatalf("draft ingest failed: %s", stderr)
+	}
+
+	var bill draft.DraftBill
+	if err := json.Unmarshal([]byte(stdout), &bill); err != nil {
+		t.Fatalf("draft ingest output is not JSON: %v\n%s", err, stdout)
+	}
+	if bill.BillNumber != "H.R. 1234" || len(bill.Sections) != 5 {
+		t.Errorf("bill = %s with %d sections, want H.R. 1234 with 5", bill.BillNumber, len(bill.Sections))
+	}
+	amendments := 0
+	for _, section := range bill.Sections {
+		amendments += len(section.Amendments)
+	}
+	if amendments != 4 {
+		t.Errorf("amendments = %d, want 4", amendments)
+	}
+}
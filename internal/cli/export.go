@@ -0,0 +1,531 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/analysis"
+	"github.com/coolbeans/regula/pkg/calendar"
+	"github.com/coolbeans/regula/pkg/export"
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/policy"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+func exportCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the relationship graph for visualization",
+		Long: `Export the regulation relationship graph in various formats.
+
+Supported formats:
+  - json:    JSON graph format with nodes and edges
+  - dot:     DOT format for Graphviz visualization
+  - mermaid: Mermaid flowchart of the relationship graph, for Markdown
+  - plantuml: PlantUML diagram of the chapter/section/article hierarchy
+  - svg:     SVG image of the relationship graph, laid out without Graphviz
+  - turtle:  W3C Turtle (TTL) RDF serialization
+  - jsonld:  JSON-LD (Linked Data) format with @context
+  - rdfxml:  RDF/XML format for legacy system compatibility
+  - nquads:  W3C N-Quads, with the document's triples in a named graph
+  - trig:    W3C TriG, with the document's triples in a named graph
+  - neo4j:   Cypher script that loads the relationship graph into Neo4j
+  - neo4j-csv: node and relationship CSVs for neo4j-admin import
+  - graphml: GraphML for Cytoscape, Gephi, and other network analysis tools
+  - gexf:    GEXF 1.3, Gephi's native format
+  - ics:     iCalendar feed of recurring obligations (see 'regula calendar')
+  - rego:    Open Policy Agent rule skeletons, one rule per obligation
+  - summary: Relationship statistics and summary
+
+Use --eli to add ELI (European Legislation Identifier) vocabulary triples
+alongside reg: triples for EU documents (regulation, directive, decision).
+
+Use --identifiers to mint standard identifiers: ELI URIs for EU and UK
+legislation, ECLI for cited case law, and USLM identifiers for US Code,
+CFR, and Public Law references.
+
+Use --related to add reg:relatedTo suggestions between articles that share
+defined terms, are cited together, or have similar text (see 'regula related').
+
+Use --min-quality to export a high-precision view: triples that rest on
+extraction heuristics (resolved references, rights, obligations) carry the
+extractor's confidence as a quality score, and those scored below the
+minimum are left out. Structural triples always score 1.0.
+
+The nquads and trig formats name the graph after the document
+("https://regula.dev/regulations/gdpr/" for gdpr.txt) and record the
+source path in the default graph; use --graph to choose another IRI. To
+export several library documents as separate graphs, see
+'regula library export'.
+
+The neo4j formats map articles, defined terms, rights, and the other
+resources in the relationship graph to nodes labeled with their type (and
+Resource, keyed by uri), and contains, references, usesTerm, and the other
+relationship predicates to relationships (CONTAINS, REFERENCES,
+USES_TERM). neo4j-csv writes nodes.csv and relationships.csv into the
+--output directory.
+
+The plantuml format draws chapters and sections as packages containing
+their articles, with dotted arrows for the 40 references whose targets are
+cited most often. References from paragraphs and points are drawn from
+their article.
+
+The svg format lays out the relationship graph itself, so it needs no
+Graphviz install. --layout layered (the default) places nodes in columns
+so that most edges point right, as 'dot' does; --layout force spreads
+them by simulating forces, which suits densely cross-referenced graphs.
+Nodes are colored by type as in the dot format, and edges are labeled
+unless --edge-labels=false.
+
+The graphml and gexf formats export the relationship graph with type,
+title, and jurisdiction node attributes and relationship and predicate
+edge attributes. The jurisdiction is taken from --jurisdiction, or "EU"
+for EU regulations, directives, and decisions.
+
+The rego format writes one "violation" rule per extracted obligation, in
+package regula.<document> (regula.gdpr for gdpr.txt). Each rule checks the
+duty bearer (input.actor) and whether the obligation is met
+(input.satisfied["<rule>"]) or, for prohibitions, breached
+(input.performed["<rule>"]). The citation, actor, conditions, deadlines,
+and provision text are recorded in an OPA METADATA annotation, and
+conditions are repeated as TODO comments to translate into input checks.
+
+JSON-LD Options:
+  --expanded  Output expanded JSON-LD (full URIs, no @context) instead of compact form
+
+Example:
+  regula export --source gdpr.txt --format json --output graph.json
+  regula export --source gdpr.txt --format dot --output graph.dot
+  regula export --source gdpr.txt --format mermaid --output graph.mmd
+  regula export --source gdpr.txt --format plantuml --output structure.puml
+  regula export --source gdpr.txt --format svg --layout force --output graph.svg
+  regula export --source gdpr.txt --format turtle --output graph.ttl
+  regula export --source gdpr.txt --format turtle --eli --output graph-eli.ttl
+  regula export --source uk-dpa2018.txt --format turtle --identifiers
+  regula export --source gdpr.txt --format turtle --related
+  regula export --source gdpr.txt --format turtle --min-quality 0.75
+  regula export --source gdpr.txt --format jsonld --output graph.jsonld
+  regula export --source gdpr.txt --format jsonld --expanded --output graph-expanded.jsonld
+  regula export --source gdpr.txt --format rdfxml --output graph.rdf
+  regula export --source gdpr.txt --format nquads --output graph.nq
+  regula export --source gdpr.txt --format trig --graph https://example.org/graphs/gdpr
+  regula export --source gdpr.txt --format neo4j --output gdpr.cypher
+  regula export --source gdpr.txt --format neo4j-csv --output gdpr-neo4j
+  regula export --source gdpr.txt --format graphml --output gdpr.graphml
+  regula export --source ccpa.txt --format gexf --jurisdiction US-CA --output ccpa.gexf
+  regula export --source gdpr.txt --format ics --output obligations.ics
+  regula export --source gdpr.txt --format rego --output gdpr.rego
+  regula export --source gdpr.txt --format summary`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			formatStr, _ := cmd.Flags().GetString("format")
+			output, _ := cmd.Flags().GetString("output")
+			relationsOnly, _ := cmd.Flags().GetBool("relations-only")
+			enableELI, _ := cmd.Flags().GetBool("eli")
+			enableIdentifiers, _ := cmd.Flags().GetBool("identifiers")
+			enableRelated, _ := cmd.Flags().GetBool("related")
+			expandedJSONLD, _ := cmd.Flags().GetBool("expanded")
+			minQuality, _ := cmd.Flags().GetFloat64("min-quality")
+			graphName, _ := cmd.Flags().GetString("graph")
+			jurisdiction, _ := cmd.Flags().GetString("jurisdiction")
+			layoutName, _ := cmd.Flags().GetString("layout")
+			edgeLabels, _ := cmd.Flags().GetBool("edge-labels")
+
+			if source == "" {
+				return &usageError{err: fmt.Errorf("--source flag is required")}
+			}
+
+			graph, err := loadAndIngest(cmd.Context(), source)
+			if err != nil {
+				return err
+			}
+			graph, err = graph.withMinQuality(minQuality)
+			if err != nil {
+				return err
+			}
+			tripleStore := graph.store
+
+			// Optionally enrich with ELI vocabulary
+			if enableELI {
+				eliStats := store.EnrichWithELI(tripleStore, graph.docType)
+				if eliStats.TotalTriples > 0 {
+					fmt.Fprintf(app.Stdout, "ELI enrichment: %d triples added (%d class, %d property)\n",
+						eliStats.TotalTriples, eliStats.ClassTriples, eliStats.PropertyTriples)
+				} else if !store.IsEUDocumentType(graph.docType) {
+					fmt.Fprintln(app.Stdout, "ELI enrichment skipped: document is not an EU legislative type")
+				}
+			}
+
+			// Optionally mint standard identifiers (ELI, ECLI, USLM)
+			if enableIdentifiers {
+				identifierStats := store.EnrichWithIdentifiers(tripleStore, graph.docType, nil)
+				schemeNames := make([]string, 0, len(identifierStats.SchemeTriples))
+				for schemeName := range identifierStats.SchemeTriples {
+					schemeNames = append(schemeNames, schemeName)
+				}
+				sort.Strings(schemeNames)
+				schemeCounts := make([]string, 0, len(schemeNames))
+				for _, schemeName := range schemeNames {
+					schemeCounts = append(schemeCounts, fmt.Sprintf("%s: %d", schemeName, identifierStats.SchemeTriples[schemeName]))
+				}
+				if identifierStats.TotalTriples > 0 {
+					fmt.Fprintf(app.Stdout, "Identifier enrichment: %d triples added (%s)\n",
+						identifierStats.TotalTriples, strings.Join(schemeCounts, ", "))
+				} else {
+					fmt.Fprintln(app.Stdout, "Identifier enrichment: no identifiers minted")
+				}
+			}
+
+			// Optionally suggest related provisions (reg:relatedTo)
+			if enableRelated {
+				relatedStats := analysis.NewRelatedAnalyzer(tripleStore, analysis.DefaultRelatedOptions()).AddRelatedTriples()
+				fmt.Fprintf(app.Stdout, "Related provisions: %d suggestions, %d triples added\n",
+					relatedStats.Suggestions, relatedStats.TotalTriples)
+			}
+
+			switch formatStr {
+			case "json":
+				var export *store.GraphExport
+				if relationsOnly {
+					export = store.ExportRelationshipSubgraph(tripleStore)
+				} else {
+					export = store.ExportGraph(tripleStore)
+				}
+
+				data, err := export.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize graph: %w", err)
+				}
+
+				if output != "" {
+					if err := os.WriteFile(output, data, 0644); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "Graph exported to: %s\n", output)
+					fmt.Fprintf(app.Stdout, "  Nodes: %d\n", export.Stats.TotalNodes)
+					fmt.Fprintf(app.Stdout, "  Edges: %d\n", export.Stats.TotalEdges)
+				} else {
+					fmt.Fprintln(app.Stdout, string(data))
+				}
+
+			case "dot":
+				export := store.ExportRelationshipSubgraph(tripleStore)
+				dotContent := export.ToDOT()
+
+				if output != "" {
+					if err := os.WriteFile(output, []byte(dotContent), 0644); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "DOT graph exported to: %s\n", output)
+					fmt.Fprintln(app.Stdout, "\nTo visualize with Graphviz:")
+					fmt.Fprintf(app.Stdout, "  dot -Tpng %s -o graph.png\n", output)
+					fmt.Fprintf(app.Stdout, "  dot -Tsvg %s -o graph.svg\n", output)
+				} else {
+					fmt.Fprintln(app.Stdout, dotContent)
+				}
+
+			case "mermaid":
+				export := store.ExportRelationshipSubgraph(tripleStore)
+				mermaidContent := export.ToMermaid()
+
+				if output != "" {
+					if err := os.WriteFile(output, []byte(mermaidContent), 0644); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "Mermaid diagram exported to: %s\n", output)
+					fmt.Fprintf(app.Stdout, "  Nodes: %d\n", export.Stats.TotalNodes)
+					fmt.Fprintf(app.Stdout, "  Edges: %d\n", export.Stats.TotalEdges)
+				} else {
+					fmt.Fprint(app.Stdout, mermaidContent)
+				}
+
+			case "svg":
+				layout, err := export.ParseLayout(layoutName)
+				if err != nil {
+					return err
+				}
+				options := []export.SVGOption{export.WithLayout(layout)}
+				if !edgeLabels {
+					options = append(options, export.WithoutEdgeLabels())
+				}
+				graph := store.ExportRelationshipSubgraph(tripleStore)
+				svgContent := export.NewSVGRenderer(options...).Render(graph)
+
+				if output != "" {
+					if err := os.WriteFile(output, []byte(svgContent), 0644); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "SVG graph exported to: %s\n", output)
+					fmt.Fprintf(app.Stdout, "  Nodes: %d\n", graph.Stats.TotalNodes)
+					fmt.Fprintf(app.Stdout, "  Edges: %d\n", graph.Stats.TotalEdges)
+				} else {
+					fmt.Fprint(app.Stdout, svgContent)
+				}
+
+			case "plantuml":
+				plantUMLContent := store.NewPlantUMLSerializer().Serialize(tripleStore)
+
+				if output != "" {
+					if err := os.WriteFile(output, []byte(plantUMLContent), 0644); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "PlantUML diagram exported to: %s\n", output)
+				} else {
+					fmt.Fprint(app.Stdout, plantUMLContent)
+				}
+
+			case "turtle":
+				serializer := store.NewTurtleSerializer()
+				turtleOutput := serializer.Serialize(tripleStore)
+
+				if output != "" {
+					if err := os.WriteFile(output, []byte(turtleOutput), 0644); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "Turtle graph exported to: %s\n", output)
+					fmt.Fprintf(app.Stdout, "  Triples: %d\n", tripleStore.Count())
+				} else {
+					fmt.Fprint(app.Stdout, turtleOutput)
+				}
+
+			case "jsonld":
+				var serializer *store.JSONLDSerializer
+				if expandedJSONLD {
+					serializer = store.NewJSONLDSerializer(store.WithExpandedForm())
+				} else {
+					serializer = store.NewJSONLDSerializer(store.WithCompactForm())
+				}
+
+				jsonldOutput, err := serializer.Serialize(tripleStore)
+				if err != nil {
+					return fmt.Errorf("failed to serialize JSON-LD: %w", err)
+				}
+
+				if output != "" {
+					if err := os.WriteFile(output, jsonldOutput, 0644); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "JSON-LD graph exported to: %s\n", output)
+					fmt.Fprintf(app.Stdout, "  Triples: %d\n", tripleStore.Count())
+					if expandedJSONLD {
+						fmt.Fprintln(app.Stdout, "  Format: expanded (full URIs)")
+					} else {
+						fmt.Fprintln(app.Stdout, "  Format: compact (with @context)")
+					}
+				} else {
+					fmt.Fprint(app.Stdout, string(jsonldOutput))
+				}
+
+			case "rdfxml", "xml":
+				rdfxmlSerializer := store.NewRDFXMLSerializer()
+				rdfxmlOutput := rdfxmlSerializer.Serialize(tripleStore)
+
+				if output != "" {
+					if err := os.WriteFile(output, []byte(rdfxmlOutput), 0644); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "RDF/XML graph exported to: %s\n", output)
+					fmt.Fprintf(app.Stdout, "  Triples: %d\n", tripleStore.Count())
+				} else {
+					fmt.Fprint(app.Stdout, rdfxmlOutput)
+				}
+
+			case "nquads", "trig":
+				if graphName == "" {
+					graphName = library.DocumentBaseURI("", "", extractDocID(source))
+				}
+				graphs := []store.NamedGraph{{
+					Name:     graphName,
+					Store:    tripleStore,
+					Metadata: map[string]string{store.PrefixDC + "source": source},
+				}}
+
+				formatName := "N-Quads"
+				quadOutput := store.SerializeNQuads(graphs)
+				if formatStr == "trig" {
+					formatName = "TriG"
+					quadOutput = store.NewTurtleSerializer().SerializeTriG(graphs)
+				}
+
+				if output != "" {
+					if err := os.WriteFile(output, []byte(quadOutput), 0644); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "%s graph exported to: %s\n", formatName, output)
+					fmt.Fprintf(app.Stdout, "  Graph: %s\n", graphName)
+					fmt.Fprintf(app.Stdout, "  Triples: %d\n", tripleStore.Count())
+				} else {
+					fmt.Fprint(app.Stdout, quadOutput)
+				}
+
+			case "neo4j":
+				export := store.ExportRelationshipSubgraph(tripleStore)
+				cypherOutput := export.ToCypher()
+
+				if output != "" {
+					if err := os.WriteFile(output, []byte(cypherOutput), 0644); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "Cypher script exported to: %s\n", output)
+					fmt.Fprintf(app.Stdout, "  Nodes: %d\n", export.Stats.TotalNodes)
+					fmt.Fprintf(app.Stdout, "  Relationships: %d\n", export.Stats.TotalEdges)
+					fmt.Fprintln(app.Stdout, "\nTo load into Neo4j:")
+					fmt.Fprintf(app.Stdout, "  cypher-shell -u neo4j -f %s\n", output)
+				} else {
+					fmt.Fprint(app.Stdout, cypherOutput)
+				}
+
+			case "neo4j-csv":
+				if output == "" {
+					return &usageError{err: fmt.Errorf("--output directory is required for neo4j-csv")}
+				}
+				export := store.ExportRelationshipSubgraph(tripleStore)
+				nodesCSV, relationshipsCSV, err := export.ToNeo4jCSV()
+				if err != nil {
+					return fmt.Errorf("failed to serialize Neo4j CSV: %w", err)
+				}
+
+				if err := os.MkdirAll(output, 0755); err != nil {
+					return fmt.Errorf("failed to create output directory: %w", err)
+				}
+				nodesPath := filepath.Join(output, "nodes.csv")
+				relationshipsPath := filepath.Join(output, "relationships.csv")
+				if err := os.WriteFile(nodesPath, []byte(nodesCSV), 0644); err != nil {
+					return fmt.Errorf("failed to write file: %w", err)
+				}
+				if err := os.WriteFile(relationshipsPath, []byte(relationshipsCSV), 0644); err != nil {
+					return fmt.Errorf("failed to write file: %w", err)
+				}
+
+				fmt.Fprintf(app.messageWriter(), "Neo4j import files exported to: %s\n", output)
+				fmt.Fprintf(app.Stdout, "  Nodes: %d (%s)\n", export.Stats.TotalNodes, nodesPath)
+				fmt.Fprintf(app.Stdout, "  Relationships: %d (%s)\n", export.Stats.TotalEdges, relationshipsPath)
+				fmt.Fprintln(app.Stdout, "\nTo import into an empty Neo4j database:")
+				fmt.Fprintf(app.Stdout, "  neo4j-admin database import full --nodes=%s --relationships=%s neo4j\n",
+					nodesPath, relationshipsPath)
+
+			case "graphml", "gexf":
+				export := store.ExportRelationshipSubgraph(tripleStore)
+				if jurisdiction == "" && store.IsEUDocumentType(graph.docType) {
+					jurisdiction = "EU"
+				}
+				export.SetJurisdiction(jurisdiction)
+
+				formatName := "GraphML"
+				networkOutput := export.ToGraphML()
+				if formatStr == "gexf" {
+					formatName = "GEXF"
+					networkOutput = export.ToGEXF()
+				}
+
+				if output != "" {
+					if err := os.WriteFile(output, []byte(networkOutput), 0644); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "%s graph exported to: %s\n", formatName, output)
+					fmt.Fprintf(app.Stdout, "  Nodes: %d\n", export.Stats.TotalNodes)
+					fmt.Fprintf(app.Stdout, "  Edges: %d\n", export.Stats.TotalEdges)
+				} else {
+					fmt.Fprint(app.Stdout, networkOutput)
+				}
+
+			case "ics":
+				obligations, ruleErrors := calendar.CollectRecurringObligations(tripleStore)
+				for _, ruleErr := range ruleErrors {
+					fmt.Fprintf(app.Stderr, "Warning: %v\n", ruleErr)
+				}
+				now := time.Now()
+				icsOutput := calendar.FormatICS(obligations, calendarYearStart(now), now)
+
+				if output != "" {
+					if err := os.WriteFile(output, []byte(icsOutput), 0644); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "iCalendar feed exported to: %s\n", output)
+					fmt.Fprintf(app.Stdout, "  Recurring obligations: %d\n", len(obligations))
+				} else {
+					fmt.Fprint(app.Stdout, icsOutput)
+				}
+
+			case "rego":
+				rules := policy.CollectRules(tripleStore)
+				regoOutput := policy.FormatRego(policy.PackageName(extractDocID(source)), filepath.Base(source), rules)
+
+				if output != "" {
+					if err := os.WriteFile(output, []byte(regoOutput), 0644); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "Rego policy exported to: %s\n", output)
+					fmt.Fprintf(app.Stdout, "  Rules: %d\n", len(rules))
+					fmt.Fprintln(app.Stdout, "\nTo check an input document with OPA:")
+					fmt.Fprintf(app.Stdout, "  opa eval -d %s -i input.json 'data.%s.violation'\n", output, policy.PackageName(extractDocID(source)))
+				} else {
+					fmt.Fprint(app.Stdout, regoOutput)
+				}
+
+			case "summary":
+				summary := store.CalculateRelationshipSummary(tripleStore)
+
+				fmt.Fprintln(app.Stdout, "Relationship Graph Summary")
+				fmt.Fprintln(app.Stdout, "==========================")
+				fmt.Fprintf(app.Stdout, "\nTotal relationships: %d\n\n", summary.TotalRelationships)
+
+				fmt.Fprintln(app.Stdout, "Relationship Types:")
+				for relType, count := range summary.RelationshipCounts {
+					fmt.Fprintf(app.Stdout, "  %-25s %d\n", relType, count)
+				}
+
+				if len(summary.MostReferencedArticles) > 0 {
+					fmt.Fprintln(app.Stdout, "\nMost Referenced Articles:")
+					for _, arc := range summary.MostReferencedArticles {
+						fmt.Fprintf(app.Stdout, "  Article %d: %d incoming references\n", arc.ArticleNum, arc.Count)
+					}
+				}
+
+				if len(summary.MostReferencingArticles) > 0 {
+					fmt.Fprintln(app.Stdout, "\nArticles With Most Outgoing References:")
+					for _, arc := range summary.MostReferencingArticles {
+						fmt.Fprintf(app.Stdout, "  Article %d: %d outgoing references\n", arc.ArticleNum, arc.Count)
+					}
+				}
+
+				if summary.ExternalRefCount > 0 {
+					fmt.Fprintf(app.Stdout, "\nExternal References: %d total, %d unique targets\n",
+						summary.ExternalRefCount, len(summary.ExternalRefTargets))
+					if len(summary.TopExternalTargets) > 0 {
+						fmt.Fprintln(app.Stdout, "\nTop External Reference Targets:")
+						for _, ext := range summary.TopExternalTargets {
+							fmt.Fprintf(app.Stdout, "  %-40s %d\n", ext.Target, ext.Count)
+						}
+					}
+				}
+
+			default:
+				return fmt.Errorf("unknown format: %s (use json, dot, mermaid, plantuml, svg, turtle, jsonld, rdfxml, nquads, trig, neo4j, neo4j-csv, graphml, gexf, ics, rego, or summary)", formatStr)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document path")
+	cmd.Flags().StringP("format", "f", "summary", "Output format (json, dot, mermaid, plantuml, svg, turtle, jsonld, rdfxml, nquads, trig, neo4j, neo4j-csv, graphml, gexf, ics, rego, summary)")
+	cmd.Flags().StringP("output", "o", "", "Output file path")
+	cmd.Flags().Bool("relations-only", true, "Export only relationship edges (default: true)")
+	cmd.Flags().Bool("eli", false, "Enrich with ELI (European Legislation Identifier) vocabulary for EU documents")
+	cmd.Flags().Bool("identifiers", false, "Mint ELI, ECLI, and USLM identifiers for legislation and cited case law")
+	cmd.Flags().Bool("related", false, "Add reg:relatedTo suggestions computed from shared terms, co-citation, and text similarity")
+	cmd.Flags().Bool("expanded", false, "Output expanded JSON-LD (full URIs, no @context) instead of compact form")
+	cmd.Flags().String("graph", "", "Named graph IRI for nquads and trig output (default: derived from the source file name)")
+	cmd.Flags().String("jurisdiction", "", "Jurisdiction attribute for graphml and gexf nodes (default: EU for EU documents)")
+	cmd.Flags().String("layout", "layered", "Graph layout for svg output (layered, force)")
+	cmd.Flags().Bool("edge-labels", true, "Label edges in svg output")
+	cmd.Flags().Float64("min-quality", 0, "Export only triples with at least this quality score (0.0-1.0; 0 keeps all)")
+
+	return cmd
+}
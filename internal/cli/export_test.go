@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportCmd_JSON(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "graph.json")
+	_, stderr, code := runCLI(t, "export", "--source", testdataPath(t, "gdpr.txt"), "--format", "json", "--output", outputPath)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("export wrote no file: %v", err)
+	}
+	var graph struct {
+		Nodes []json.RawMessage `json:"nodes"`
+	}
+	if err := json.Unmarshal(data, &graph); err != nil || len(graph.Nodes) == 0 {
+		t.Errorf("export JSON has no nodes (err %v)", err)
+	}
+}
+
+func TestExportCmd_TurtleToStdout(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "export", "--source", testdataPath(t, "gdpr.txt"), "--format", "turtle")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "@prefix") {
+		t.Errorf("Turtle output missing prefixes:\n%.300s", stdout)
+	}
+}
+
+func TestExportCmd_Mermaid(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "export", "--source", testdataPath(t, "gdpr.txt"), "--format", "mermaid")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "flowchart LR\n") || !strings.Contains(stdout, "-->|references|") {
+		t.Errorf("unexpected Mermaid output:\n%.300s", stdout)
+	}
+}
+
+func TestExportCmd_PlantUML(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "export", "--source", testdataPath(t, "gdpr.txt"), "--format", "plantuml")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "@startuml\n") || !strings.HasSuffix(stdout, "@enduml\n") {
+		t.Errorf("output is not a PlantUML document:\n%.300s", stdout)
+	}
+	for _, want := range []string{`package "Chapter III: Rights of the data subject"`, `rectangle "Article 17: Right to erasure`, "..> "} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("PlantUML output missing %s", want)
+		}
+	}
+}
+
+func TestExportCmd_SVG(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "export", "--source", testdataPath(t, "gdpr.txt"), "--format", "svg", "--layout", "force")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "<svg xmlns=") || !strings.HasSuffix(stdout, "</svg>\n") {
+		t.Errorf("output is not an SVG document:\n%.300s", stdout)
+	}
+
+	_, stderr, code = runCLI(t, "export", "--source", testdataPath(t, "gdpr.txt"), "--format", "svg", "--layout", "circular")
+	if code == 0 || !strings.Contains(stderr, "unknown layout") {
+		t.Errorf("expected an unknown layout error, got status %d: %s", code, stderr)
+	}
+}
+
+func TestExportCmd_MinQuality(t *testing.T) {
+	countLines := func(args ...string) int {
+		t.Helper()
+		args = append([]string{"export", "--source", testdataPath(t, "gdpr.txt"), "--format", "turtle"}, args...)
+		stdout, stderr, code := runCLI(t, args...)
+		if code != 0 {
+			t.Fatalf("exit status %d: %s", code, stderr)
+		}
+		return strings.Count(stdout, "\n")
+	}
+	if all, precise := countLines(), countLines("--min-quality", "1"); precise >= all {
+		t.Errorf("turtle lines: %d with --min-quality 1, %d without", precise, all)
+	}
+}
+
+func TestExportCmd_NQuads(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "export", "--source", testdataPath(t, "gdpr.txt"), "--format", "nquads")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	graph := " <https://regula.dev/regulations/gdpr/> .\n"
+	if !strings.Contains(stdout, graph) {
+		t.Errorf("N-Quads output missing graph term:\n%.300s", stdout)
+	}
+
+	stdout, stderr, code = runCLI(t, "export", "--source", testdataPath(t, "gdpr.txt"), "--format", "trig",
+		"--graph", "https://example.org/graphs/gdpr")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "<https://example.org/graphs/gdpr> {\n") {
+		t.Errorf("TriG output missing graph block:\n%.300s", stdout)
+	}
+}
+
+func TestExportCmd_Neo4jCSV(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "neo4j")
+	stdout, stderr, code := runCLI(t, "export", "--source", testdataPath(t, "gdpr.txt"), "--format", "neo4j-csv", "--output", outputDir)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "neo4j-admin database import full") {
+		t.Errorf("missing import instructions:\n%s", stdout)
+	}
+	for _, name := range []string{"nodes.csv", "relationships.csv"} {
+		data, err := os.ReadFile(filepath.Join(outputDir, name))
+		if err != nil || strings.Count(string(data), "\n") < 2 {
+			t.Errorf("%s missing or empty (err %v)", name, err)
+		}
+	}
+
+	if _, _, code := runCLI(t, "export", "--source", testdataPath(t, "gdpr.txt"), "--format", "neo4j-csv"); code == 0 {
+		t.Error("expected neo4j-csv without --output to fail")
+	}
+}
+
+func TestExportCmd_GraphMLJurisdiction(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "export", "--source", testdataPath(t, "gdpr.txt"), "--format", "graphml")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, `<data key="jurisdiction">EU</data>`) {
+		t.Errorf("expected EU jurisdiction for GDPR:\n%.500s", stdout)
+	}
+
+	stdout, stderr, code = runCLI(t, "export", "--source", testdataPath(t, "gdpr.txt"), "--format", "gexf", "--jurisdiction", "EEA")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, `<attvalue for="jurisdiction" value="EEA"/>`) {
+		t.Errorf("expected --jurisdiction to be used:\n%.500s", stdout)
+	}
+}
+
+func TestExportCmd_Rego(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "export", "--source", testdataPath(t, "gdpr.txt"), "--format", "rego")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	for _, want := range []string{"package regula.gdpr\n", "# METADATA\n", "violation contains msg if {", `"GDPR Art. 33`} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Rego output missing %q:\n%.500s", want, stdout)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/coolbeans/regula/pkg/codegen"
+	"github.com/spf13/cobra"
+)
+
+func generateCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate typed code and schemas from a regulation",
+		Long: `Generate source code and schemas that describe a regulation's provisions:
+articles, defined terms, rights, and obligations.
+
+  go:         a Go package of typed provision values
+  jsonschema: a JSON Schema for the extracted provisions, with the JSON data
+  proto:      a proto3 definition of the extracted provisions`,
+	}
+
+	cmd.AddCommand(generateGoCmd(app))
+	cmd.AddCommand(generateJSONSchemaCmd(app))
+	cmd.AddCommand(generateProtoCmd(app))
+
+	return cmd
+}
+
+func generateGoCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "go",
+		Short: "Generate a Go package of typed provisions",
+		Long: `Generate a Go package declaring the regulation's provisions:
+
+  - Article values (Art17) with number, title, URI, and Citation()
+  - Term values (TermPersonalData) with the definition and defining article
+  - Right values (Art15RightOfAccess) with beneficiaries
+  - Obligation values (Art33BreachNotificationObligation) with duty bearers
+  - RightType, ObligationType, and Party constants
+  - Articles, Terms, Rights, and Obligations slices listing them all
+
+The package name defaults to the lowercased source file name.
+
+Examples:
+  regula generate go --source gdpr.txt --package gdpr -o gdpr/provisions.go
+  regula generate go --source ccpa.txt`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			packageName, _ := cmd.Flags().GetString("package")
+			output, _ := cmd.Flags().GetString("output")
+
+			if source == "" {
+				return &usageError{err: fmt.Errorf("--source flag is required")}
+			}
+			if packageName == "" {
+				packageName = codegen.PackageName(extractDocID(source))
+			}
+
+			graph, err := loadAndIngest(cmd.Context(), source)
+			if err != nil {
+				return err
+			}
+
+			code, err := codegen.GenerateGo(graph.store, codegen.GoOptions{Package: packageName, Source: filepath.Base(source)})
+			if err != nil {
+				return err
+			}
+
+			if output != "" {
+				if err := os.WriteFile(output, code, 0644); err != nil {
+					return fmt.Errorf("failed to write file: %w", err)
+				}
+				fmt.Fprintf(app.messageWriter(), "Go package %s generated: %s\n", packageName, output)
+				return nil
+			}
+			fmt.Fprint(app.Stdout, string(code))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document path")
+	cmd.Flags().String("package", "", "Go package name (default: derived from the source file name)")
+	cmd.Flags().StringP("output", "o", "", "Output file path (default: stdout)")
+
+	return cmd
+}
+
+func generateJSONSchemaCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jsonschema",
+		Short: "Generate a JSON Schema for the extracted provisions",
+		Long: `Generate a JSON Schema (draft 2020-12) describing the regulation's
+extracted provisions. Article numbers, right and obligation types, and
+parties are constrained to the values found in the document.
+
+Use --data to also write the extracted provisions as JSON that validates
+against the schema, for consumers in other languages.
+
+Examples:
+  regula generate jsonschema --source gdpr.txt -o gdpr.schema.json --data gdpr.json
+  regula generate jsonschema --source ccpa.txt --id https://example.org/ccpa.schema.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			schemaID, _ := cmd.Flags().GetString("id")
+			output, _ := cmd.Flags().GetString("output")
+			dataPath, _ := cmd.Flags().GetString("data")
+
+			if source == "" {
+				return &usageError{err: fmt.Errorf("--source flag is required")}
+			}
+			if schemaID == "" {
+				schemaID = codegen.SchemaID(extractDocID(source))
+			}
+
+			graph, err := loadAndIngest(cmd.Context(), source)
+			if err != nil {
+				return err
+			}
+			model := codegen.BuildModel(graph.store)
+
+			schema, err := codegen.GenerateJSONSchema(model, schemaID)
+			if err != nil {
+				return fmt.Errorf("failed to generate schema: %w", err)
+			}
+
+			if dataPath != "" {
+				data, err := model.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize provisions: %w", err)
+				}
+				if err := os.WriteFile(dataPath, append(data, '\n'), 0644); err != nil {
+					return fmt.Errorf("failed to write file: %w", err)
+				}
+			}
+
+			if output != "" {
+				if err := os.WriteFile(output, append(schema, '\n'), 0644); err != nil {
+					return fmt.Errorf("failed to write file: %w", err)
+				}
+				fmt.Fprintf(app.messageWriter(), "JSON Schema generated: %s\n", output)
+			} else {
+				fmt.Fprintln(app.Stdout, string(schema))
+			}
+			if dataPath != "" && output != "" {
+				fmt.Fprintf(app.messageWriter(), "Provisions written: %s (%d articles, %d terms, %d rights, %d obligations)\n",
+					dataPath, len(model.Articles), len(model.Terms), len(model.Rights), len(model.Obligations))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document path")
+	cmd.Flags().String("id", "", "Schema $id (default: https://regula.dev/schemas/<document>.schema.json)")
+	cmd.Flags().StringP("output", "o", "", "Output file path (default: stdout)")
+	cmd.Flags().String("data", "", "Also write the extracted provisions as JSON to this file")
+
+	return cmd
+}
+
+func generateProtoCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proto",
+		Short: "Generate a proto3 definition of the extracted provisions",
+		Long: `Generate a proto3 definition describing the regulation's extracted
+provisions: a Regulation message with Article, Term, Right, and Obligation
+messages, and the document's right types, obligation types, and parties as
+enums.
+
+Enum values are numbered in sorted order of the types found in the
+document; regenerating after new types are extracted can renumber them, so
+check the generated file in rather than regenerating it in a build.
+
+Examples:
+  regula generate proto --source gdpr.txt -o gdpr.proto
+  regula generate proto --source gdpr.txt --package acme.gdpr --go-package example.com/acme/gdprpb`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			packageName, _ := cmd.Flags().GetString("package")
+			goPackage, _ := cmd.Flags().GetString("go-package")
+			output, _ := cmd.Flags().GetString("output")
+
+			if source == "" {
+				return &usageError{err: fmt.Errorf("--source flag is required")}
+			}
+			if packageName == "" {
+				packageName = codegen.ProtoPackage(extractDocID(source))
+			}
+
+			graph, err := loadAndIngest(cmd.Context(), source)
+			if err != nil {
+				return err
+			}
+
+			definition, err := codegen.GenerateProto(codegen.BuildModel(graph.store), codegen.ProtoOptions{
+				Package:   packageName,
+				GoPackage: goPackage,
+				Source:    filepath.Base(source),
+			})
+			if err != nil {
+				return err
+			}
+
+			if output != "" {
+				if err := os.WriteFile(output, []byte(definition), 0644); err != nil {
+					return fmt.Errorf("failed to write file: %w", err)
+				}
+				fmt.Fprintf(app.messageWriter(), "Proto definition generated: %s\n", output)
+				return nil
+			}
+			fmt.Fprint(app.Stdout, definition)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document path")
+	cmd.Flags().String("package", "", "Protobuf package (default: regula.<document>)")
+	cmd.Flags().String("go-package", "", "Set option go_package in the generated file")
+	cmd.Flags().StringP("output", "o", "", "Output file path (default: stdout)")
+
+	return cmd
+}
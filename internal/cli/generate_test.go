@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateGoCmd(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "provisions.go")
+	stdout, stderr, code := runCLI(t, "generate", "go", "--source", testdataPath(t, "gdpr.txt"), "--package", "gdprprov", "-o", outputPath)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Go package gdprprov generated") {
+		t.Errorf("unexpected output: %s", stdout)
+	}
+
+	source, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("generate wrote no file: %v", err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), outputPath, source, 0)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v", err)
+	}
+	if file.Name.Name != "gdprprov" {
+		t.Errorf("package = %s, want gdprprov", file.Name.Name)
+	}
+	for _, want := range []string{"Art17 = Article{", "TermPersonalData = Term{", "Art15RightOfAccess = Right{"} {
+		if !strings.Contains(string(source), want) {
+			t.Errorf("generated code missing %q", want)
+		}
+	}
+}
+
+func TestGenerateGoCmd_DefaultPackage(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "generate", "go", "--source", testdataPath(t, "gdpr.txt"))
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "\npackage gdpr\n") {
+		t.Errorf("expected package gdpr:\n%.300s", stdout)
+	}
+}
+
+func TestGenerateJSONSchemaCmd(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "gdpr.schema.json")
+	dataPath := filepath.Join(dir, "gdpr.json")
+	stdout, stderr, code := runCLI(t, "generate", "jsonschema", "--source", testdataPath(t, "gdpr.txt"), "-o", schemaPath, "--data", dataPath)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Provisions written") {
+		t.Errorf("unexpected output: %s", stdout)
+	}
+
+	var schema struct {
+		ID   string                     `json:"$id"`
+		Defs map[string]json.RawMessage `json:"$defs"`
+	}
+	data, err := os.ReadFile(schemaPath)
+	if err != nil || json.Unmarshal(data, &schema) != nil {
+		t.Fatalf("invalid schema file (err %v)", err)
+	}
+	if schema.ID != "https://regula.dev/schemas/gdpr.schema.json" || schema.Defs["Obligation"] == nil {
+		t.Errorf("unexpected schema: $id %q, $defs %d", schema.ID, len(schema.Defs))
+	}
+
+	var provisions struct {
+		Document    string            `json:"document"`
+		Obligations []json.RawMessage `json:"obligations"`
+	}
+	data, err = os.ReadFile(dataPath)
+	if err != nil || json.Unmarshal(data, &provisions) != nil {
+		t.Fatalf("invalid data file (err %v)", err)
+	}
+	if provisions.Document != "GDPR" || len(provisions.Obligations) == 0 {
+		t.Errorf("data = %s with %d obligations", provisions.Document, len(provisions.Obligations))
+	}
+}
+
+func TestGenerateProtoCmd(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "generate", "proto", "--source", testdataPath(t, "gdpr.txt"))
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	for _, want := range []string{"syntax = \"proto3\";", "package regula.gdpr;", "message Obligation {", "RIGHT_TYPE_RIGHT_OF_ACCESS"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("proto output missing %q", want)
+		}
+	}
+
+	if _, _, code := runCLI(t, "generate", "proto", "--source", testdataPath(t, "gdpr.txt"), "--package", "bad-name"); code == 0 {
+		t.Error("expected an invalid package to fail")
+	}
+}
@@ -0,0 +1,335 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/store"
+
+	"github.com/coolbeans/regula/pkg/analysis"
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/spf13/cobra"
+)
+
+func impactCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "impact",
+		Short: "Analyze impact of regulatory changes",
+		Long: `Analyze the impact of changes to a provision.
+
+Performs comprehensive impact analysis including:
+  - Direct impact: provisions that reference the target
+  - Reverse impact: provisions the target references
+  - Transitive impact: configurable depth traversal
+
+Provisions may also be named by a document's popular name or short title
+from the library index (see 'regula library names'); --source is then
+optional and the document's graph is loaded from the library.
+
+With --since, the amendment history in the graph is replayed as a
+timeline: dependents that came into force (reg:validFrom,
+reg:effectiveDate), dependents that were repealed (reg:validUntil,
+reg:repealedBy), and amendments (reg:amendedBy) to the target or its
+dependents, with the size of the impact surface after each change.
+
+--granularity paragraph analyzes and reports paragraphs rather than whole
+articles: a citation of "Article 6(1)(a)" affects Art6(1), and a paragraph
+is affected by citations of it or of its points.
+
+--format html writes a self-contained report: an interactive graph of the
+impact neighborhood colored by severity (high: cites the target directly;
+medium: cited by the target, or one step removed from citing it; low: the
+rest), and sortable tables of the affected provisions. --format mermaid
+writes the same neighborhood as a Mermaid flowchart for Markdown documents
+and GitHub issues.
+
+Examples:
+  regula impact --provision "Art17" --source gdpr.txt
+  regula impact --provision "GDPR:Art17" --depth 2 --source gdpr.txt
+  regula impact --provision "Art17" --direction incoming --source gdpr.txt
+  regula impact --provision "GDPR:Art6(1)" --granularity paragraph --source gdpr.txt
+  regula impact --provision "Art17" --format json --source gdpr.txt
+  regula impact --provision "Art17" --format html --output impact.html --source gdpr.txt
+  regula impact --provision "Art17" --format mermaid --source gdpr.txt
+  regula impact --provision "COPPA §6502"
+  regula impact --provision "Art17" --since 2020-01-01 --source gdpr.txt
+  regula impact --provision "Art17" --since 2020-01-01 --format html --source gdpr.txt > art17.html`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			provision, _ := cmd.Flags().GetString("provision")
+			source, _ := cmd.Flags().GetString("source")
+			depth, _ := cmd.Flags().GetInt("depth")
+			directionStr, _ := cmd.Flags().GetString("direction")
+			formatStr, _ := cmd.Flags().GetString("format")
+			baseURI, _ := cmd.Flags().GetString("base-uri")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			sinceStr, _ := cmd.Flags().GetString("since")
+			outputPath, _ := cmd.Flags().GetString("output")
+			granularityStr, _ := cmd.Flags().GetString("granularity")
+
+			if provision == "" {
+				return &usageError{err: fmt.Errorf("--provision flag is required")}
+			}
+
+			popularMatch, lib := resolvePopularName(libraryPath, provision)
+
+			if source == "" && popularMatch == nil {
+				return &usageError{err: fmt.Errorf("--source flag is required")}
+			}
+
+			// Load graph from source, or from the library when the provision
+			// was resolved by popular name
+			var tripleStore *store.TripleStore
+			if source != "" {
+				graph, err := loadAndIngest(cmd.Context(), source)
+				if err != nil {
+					return err
+				}
+				tripleStore = graph.store
+			} else {
+				libraryStore, err := lib.LoadTripleStore(popularMatch.DocumentID)
+				if err != nil {
+					return fmt.Errorf("failed to load %s from library: %w", popularMatch.DocumentID, err)
+				}
+				tripleStore = libraryStore
+				baseURI = lib.DocumentBaseURI(popularMatch.DocumentID)
+			}
+
+			// Parse direction
+			var direction analysis.ImpactDirection
+			switch directionStr {
+			case "incoming":
+				direction = analysis.DirectionIncoming
+			case "outgoing":
+				direction = analysis.DirectionOutgoing
+			case "both":
+				direction = analysis.DirectionBoth
+			default:
+				return fmt.Errorf("invalid direction: %s (use incoming, outgoing, or both)", directionStr)
+			}
+
+			// Parse granularity
+			var granularity analysis.Granularity
+			switch granularityStr {
+			case "article":
+				granularity = analysis.GranularityArticle
+			case "paragraph":
+				granularity = analysis.GranularityParagraph
+			default:
+				return fmt.Errorf("invalid granularity: %s (use article or paragraph)", granularityStr)
+			}
+
+			// Create analyzer and run analysis
+			analyzer := analysis.NewImpactAnalyzer(tripleStore, baseURI)
+			analyzer.SetGranularity(granularity)
+			var provisionURI string
+			if popularMatch != nil {
+				provisionURI = popularMatch.ProvisionURI(tripleStore)
+				if provisionURI == "" {
+					return fmt.Errorf("provision %q not found in %s (%s)", popularMatch.Remainder, popularMatch.DocumentID, popularMatch.Name)
+				}
+			} else {
+				provisionURI = analyzer.ResolveShortID(provision)
+			}
+
+			// render writes the report in the requested format
+			var render func(out io.Writer) error
+			if cmd.Flags().Changed("since") {
+				since, err := time.Parse("2006-01-02", sinceStr)
+				if err != nil {
+					return fmt.Errorf("invalid --since date %q (use YYYY-MM-DD): %w", sinceStr, err)
+				}
+				timeline := analyzer.AnalyzeHistory(provisionURI, depth, direction, since)
+				render = func(out io.Writer) error {
+					switch formatStr {
+					case "json":
+						data, err := timeline.ToJSON()
+						if err != nil {
+							return fmt.Errorf("failed to serialize timeline: %w", err)
+						}
+						fmt.Fprintln(out, string(data))
+					case "ndjson":
+						return timeline.WriteNDJSON(out)
+					case "html":
+						fmt.Fprint(out, timeline.ToHTML())
+					default:
+						fmt.Fprintln(out, timeline.String())
+					}
+					return nil
+				}
+			} else {
+				result := analyzer.Analyze(provisionURI, depth, direction)
+				render = func(out io.Writer) error {
+					switch formatStr {
+					case "json":
+						data, err := result.ToJSON()
+						if err != nil {
+							return fmt.Errorf("failed to serialize result: %w", err)
+						}
+						fmt.Fprintln(out, string(data))
+					case "ndjson":
+						return result.WriteNDJSON(out)
+					case "table":
+						fmt.Fprintln(out, result.FormatTable())
+					case "html":
+						fmt.Fprint(out, result.ToHTML())
+					case "mermaid":
+						fmt.Fprint(out, result.ToMermaid())
+					default:
+						fmt.Fprintln(out, result.String())
+					}
+					return nil
+				}
+			}
+
+			if outputPath == "" {
+				return render(app.Stdout)
+			}
+			file, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			if err := render(file); err != nil {
+				file.Close()
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+			fmt.Fprintf(app.statusWriter(formatStr), "Report saved to: %s\n", outputPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("provision", "p", "", "Provision ID to analyze (e.g., Art17, GDPR:Art17)")
+	cmd.Flags().IntP("depth", "d", 2, "Transitive dependency depth (1=direct only)")
+	cmd.Flags().StringP("direction", "D", "both", "Direction of analysis (incoming, outgoing, both)")
+	cmd.Flags().StringP("source", "s", "", "Source document to analyze")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text, json, ndjson, table, html, mermaid; text, json, ndjson, html with --since)")
+	cmd.Flags().StringP("output", "o", "", "Write the report to a file instead of stdout")
+	cmd.Flags().String("base-uri", "https://regula.dev/regulations/", "Base URI for the graph")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path for popular-name resolution")
+	cmd.Flags().String("since", "", "Report how the impact surface changed from this date (YYYY-MM-DD) as amendments landed")
+	cmd.Flags().String("granularity", "article", "Level of provision to report (article, paragraph)")
+
+	return cmd
+}
+
+func relatedCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "related",
+		Short: "Suggest provisions related to a provision",
+		Long: `Suggest related provisions that may lack explicit cross-references.
+
+Provisions are scored by three signals:
+  - Shared terms: defined terms both provisions use
+  - Co-citation: other provisions that cite both
+  - Text similarity: TF-IDF cosine similarity of the provision text
+
+Provisions that already cite one another are left out unless
+--include-referenced is set. Use 'regula export --related' to add the
+suggestions to the graph as reg:relatedTo triples; 'regula playground
+serve' lists them in an article's details.
+
+Provisions may also be named by a document's popular name from the
+library index, as with 'regula impact'.
+
+Examples:
+  regula related --provision Art17 --source gdpr.txt
+  regula related --provision Art17 --source gdpr.txt --limit 10 --format table
+  regula related --provision "GDPR Art 17" --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			provision, _ := cmd.Flags().GetString("provision")
+			source, _ := cmd.Flags().GetString("source")
+			limit, _ := cmd.Flags().GetInt("limit")
+			minScore, _ := cmd.Flags().GetFloat64("min-score")
+			includeReferenced, _ := cmd.Flags().GetBool("include-referenced")
+			formatStr, _ := cmd.Flags().GetString("format")
+			libraryPath, _ := cmd.Flags().GetString("path")
+
+			if provision == "" {
+				return &usageError{err: fmt.Errorf("--provision flag is required")}
+			}
+
+			popularMatch, lib := resolvePopularName(libraryPath, provision)
+			if source == "" && popularMatch == nil {
+				return &usageError{err: fmt.Errorf("--source flag is required")}
+			}
+
+			var graph *store.TripleStore
+			if source != "" {
+				loaded, err := loadAndIngest(cmd.Context(), source)
+				if err != nil {
+					return err
+				}
+				graph = loaded.store
+			} else {
+				libraryStore, err := lib.LoadTripleStore(popularMatch.DocumentID)
+				if err != nil {
+					return fmt.Errorf("failed to load %s from library: %w", popularMatch.DocumentID, err)
+				}
+				graph = libraryStore
+			}
+
+			options := analysis.DefaultRelatedOptions()
+			options.Limit = limit
+			options.MinScore = minScore
+			options.IncludeReferenced = includeReferenced
+			analyzer := analysis.NewRelatedAnalyzer(graph, options)
+
+			var provisionURI string
+			if popularMatch != nil {
+				provisionURI = popularMatch.ProvisionURI(graph)
+			} else {
+				provisionURI = analyzer.Resolve(provision)
+			}
+			if provisionURI == "" {
+				return fmt.Errorf("provision %q not found", provision)
+			}
+
+			result := analyzer.Suggest(provisionURI)
+
+			switch formatStr {
+			case "json":
+				data, err := result.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize result: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+			case "table":
+				fmt.Fprint(app.Stdout, result.FormatTable())
+			default:
+				fmt.Fprint(app.Stdout, result.String())
+			}
+
+			return nil
+		},
+	}
+
+	defaults := analysis.DefaultRelatedOptions()
+	cmd.Flags().StringP("provision", "p", "", "Provision ID (e.g., Art17, GDPR:Art17)")
+	cmd.Flags().StringP("source", "s", "", "Source document to analyze")
+	cmd.Flags().IntP("limit", "n", defaults.Limit, "Maximum number of suggestions (0 = no limit)")
+	cmd.Flags().Float64("min-score", defaults.MinScore, "Minimum combined relatedness score (0.0-1.0)")
+	cmd.Flags().Bool("include-referenced", false, "Include provisions that already cross-reference the target")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text, json, table)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path for popular-name resolution")
+
+	return cmd
+}
+
+// resolvePopularName looks up a provision argument such as "COPPA §6502" in
+// the library's popular-name index. Returns nil when there is no library at
+// libraryPath or the argument does not begin with a known name.
+func resolvePopularName(libraryPath string, reference string) (*library.PopularNameMatch, *library.Library) {
+	lib, err := library.Open(libraryPath)
+	if err != nil {
+		return nil, nil
+	}
+	match, ok := lib.PopularNameIndex().Resolve(reference)
+	if !ok {
+		return nil, nil
+	}
+	return match, lib
+}
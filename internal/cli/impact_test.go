@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestImpactCmd(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "impact", "--source", testdataPath(t, "gdpr.txt"), "--provision", "Art17", "--depth", "1")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	for _, want := range []string{"URI: https://regula.dev/regulations/GDPR:Art17", "Direct Incoming", "Direct Outgoing"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("impact output missing %q:\n%.500s", want, stdout)
+		}
+	}
+}
+
+func TestImpactCmd_HTMLReport(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "impact.html")
+	stdout, stderr, code := runCLI(t, "impact", "--source", testdataPath(t, "gdpr.txt"), "--provision", "Art17",
+		"--format", "html", "--output", outputPath)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Report saved to: "+outputPath) {
+		t.Errorf("stdout = %q", stdout)
+	}
+	page, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"<!DOCTYPE html>", "Impact Analysis: Right to erasure", `id="impact-graph"`, `class="severity high"`} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("HTML report missing %q", want)
+		}
+	}
+}
+
+func TestImpactCmd_Mermaid(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "impact", "--source", testdataPath(t, "gdpr.txt"), "--provision", "Art17",
+		"--depth", "1", "--format", "mermaid")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	for _, want := range []string{"flowchart LR", `target(["Art17: Right to erasure`, "-->|references| target", "class target focus"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Mermaid output missing %q:\n%.500s", want, stdout)
+		}
+	}
+}
+
+func TestImpactCmd_RequiresSource(t *testing.T) {
+	_, stderr, code := runCLI(t, "impact", "--provision", "Art17", "--path", t.TempDir())
+	if code != ExitUsage || !strings.Contains(stderr, "--source flag is required") {
+		t.Errorf("impact without a source = %d %q", code, stderr)
+	}
+}
+
+func TestRelatedCmd(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "related", "--source", testdataPath(t, "gdpr.txt"), "--provision", "Art17")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Art") {
+		t.Errorf("related output lists no articles:\n%.500s", stdout)
+	}
+}
@@ -0,0 +1,1098 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/eurlex"
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/fetch"
+	"github.com/coolbeans/regula/pkg/httpcache"
+	"github.com/coolbeans/regula/pkg/inforce"
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/profile"
+	"github.com/coolbeans/regula/pkg/query"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/telemetry"
+	"github.com/coolbeans/regula/pkg/validate"
+	"github.com/coolbeans/regula/pkg/validate/shapes"
+	"github.com/spf13/cobra"
+)
+
+func ingestCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ingest",
+		Short: "Ingest a regulation document",
+		Long: `Ingest a regulation document and build a queryable knowledge graph.
+
+Supported formats: TXT, MD (Markdown-formatted regulations)
+
+Example:
+  regula ingest --source gdpr.txt
+  regula ingest --source gdpr.txt --output gdpr-graph.json --stats
+  regula ingest --source gdpr.txt --format json
+  regula ingest --source gdpr.txt --mappings gdpr.mappings.yaml
+  regula ingest --source gdpr.txt --reference-patterns ./reference-packs
+  regula ingest --source scraped.txt --gates --watch
+  regula ingest --source uscode-42.txt --profile --profile-output profile.folded --profile-format folded
+  regula ingest --source scraped.txt --llm-endpoint http://localhost:11434/v1/chat/completions --llm-model llama3
+
+Watch mode:
+  --watch keeps running after the first ingest and polls the source file
+  and the patterns directory. When the source changes only the edited
+  articles are re-extracted; pattern edits and changes outside the
+  articles rebuild the whole graph. Gate scores (with --gates) and graph
+  statistics are printed after every run, and --output is re-saved.
+
+Manual mappings:
+  References the resolver cannot handle can be pinned to a target in a
+  mappings.yaml file. gdpr.mappings.yaml next to gdpr.txt is picked up
+  automatically:
+
+    mappings:
+      - raw: "the Directive on privacy and electronic communications"
+        target: "http://data.europa.eu/eli/dir/2002/58/oj"
+      - raw: "paragraph 1 of this Article"
+        target: "Art17(1)"
+
+Reference patterns:
+  The reference patterns are YAML packs under patterns/references.
+  --reference-patterns loads project packs, a file or a directory, on top
+  of them: a pattern named after a built-in one replaces it, keeping its
+  capture groups, and any other pattern is added, with named groups
+  (article, paragraph, point, chapter, section, annex, doc_year,
+  doc_number) filling the reference:
+
+    name: "Project References"
+    pack_id: "project"
+    version: "1.0.0"
+    family: "eu"
+    patterns:
+      - name: "recital"
+        pattern: '\brecital\s+\((?P<paragraph>\d+)\)'
+        target: "paragraph"
+        identifier: "Recital {paragraph}"
+
+Profiling:
+  --profile prints the time and memory allocated by each pipeline stage
+  (parse, each extractor, resolution, graph build) and the time and match
+  count of every reference pattern. The reference patterns run twice, once
+  when extracting references and again inside the graph build. With
+  --profile-output the profile is also written as JSON or, with
+  --profile-format folded, as folded stacks for flamegraph tools. In watch
+  mode only the initial ingest is profiled.
+
+LLM fallback:
+  --llm-endpoint sends paragraphs the patterns missed, or matched below
+  --llm-min-confidence, to an OpenAI-compatible chat completions endpoint
+  to extract definitions, rights, and obligations. Each extraction must
+  quote its paragraph verbatim; ungrounded ones are rejected. Accepted ones
+  are marked reg:extractionMethod "llm" with a confidence of at most 0.8.
+  The API key is read from the variable named by --llm-api-key-env.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			output, _ := cmd.Flags().GetString("output")
+			showStats, _ := cmd.Flags().GetBool("stats")
+			baseURI, _ := cmd.Flags().GetString("base-uri")
+			enableGates, _ := cmd.Flags().GetBool("gates")
+			skipGates, _ := cmd.Flags().GetStringSlice("skip-gates")
+			strictMode, _ := cmd.Flags().GetBool("strict")
+			failOnWarn, _ := cmd.Flags().GetBool("fail-on-warn")
+			shapesPath, _ := cmd.Flags().GetString("shapes")
+			referencePatterns, _ := cmd.Flags().GetString("reference-patterns")
+			cycleGate, _ := cmd.Flags().GetBool("cycle-gate")
+			fetchRefs, _ := cmd.Flags().GetBool("fetch-refs")
+			maxDepth, _ := cmd.Flags().GetInt("max-depth")
+			mappingsPath, _ := cmd.Flags().GetString("mappings")
+			recurrencePath, _ := cmd.Flags().GetString("recurrence")
+			maxDocuments, _ := cmd.Flags().GetInt("max-documents")
+			allowedDomains, _ := cmd.Flags().GetStringSlice("allowed-domains")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			cacheDir, _ := cmd.Flags().GetString("cache-dir")
+			respectRobots, _ := cmd.Flags().GetBool("respect-robots")
+			httpCacheDir, _ := cmd.Flags().GetString("http-cache")
+			watchMode, _ := cmd.Flags().GetBool("watch")
+			pollInterval, _ := cmd.Flags().GetDuration("interval")
+			profiling, _ := cmd.Flags().GetBool("profile")
+			profileOutput, _ := cmd.Flags().GetString("profile-output")
+			profileFormat, _ := cmd.Flags().GetString("profile-format")
+			llmMinConfidence, _ := cmd.Flags().GetFloat64("llm-min-confidence")
+			llmMaxParagraphs, _ := cmd.Flags().GetInt("llm-max-paragraphs")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			if source == "" {
+				return &usageError{err: fmt.Errorf("--source flag is required")}
+			}
+			if profileFormat != "json" && profileFormat != "folded" {
+				return fmt.Errorf("invalid --profile-format %q (use json or folded)", profileFormat)
+			}
+			if formatStr != "text" && formatStr != "json" {
+				return fmt.Errorf("invalid --format %q (use text or json)", formatStr)
+			}
+			profiling = profiling || profileOutput != ""
+
+			// With --format json stdout carries only the result document.
+			progress := app.messageWriter()
+			if formatStr == "json" {
+				progress = app.Stderr
+			}
+
+			refExtractor, err := loadReferenceExtractor(referencePatterns)
+			if err != nil {
+				return fmt.Errorf("failed to load reference patterns: %w", err)
+			}
+
+			llmClient, err := llmClientFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			var llmExtractor *extract.LLMExtractor
+			if llmClient != nil {
+				llmExtractor = extract.NewLLMExtractor(llmClient, extract.LLMExtractorOptions{
+					MinConfidence: llmMinConfidence,
+					MaxParagraphs: llmMaxParagraphs,
+				})
+			}
+
+			// Check if file exists
+			fileInfo, err := os.Stat(source)
+			if os.IsNotExist(err) {
+				return fmt.Errorf("source file not found: %s", source)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to stat source: %w", err)
+			}
+
+			fmt.Fprintf(progress, "Ingesting regulation from: %s\n", source)
+			startTime := time.Now()
+
+			var recorder *profile.Recorder
+			if profiling {
+				recorder = profile.NewRecorder(telemetry.SetExporter(nil))
+				telemetry.SetExporter(recorder)
+				telemetry.SetMemoryTracking(true)
+				defer func() {
+					telemetry.SetMemoryTracking(false)
+					telemetry.SetExporter(recorder.Next())
+				}()
+			}
+
+			ctx := telemetry.With(cmd.Context(), slog.String(telemetry.KeyDocumentID, extractDocID(source)))
+			ctx, ingestSpan := telemetry.Start(ctx, "ingest", slog.String("source", source))
+			ingestEnded := false
+			defer func() {
+				if !ingestEnded {
+					ingestSpan.End()
+				}
+			}()
+
+			// Set up validation gates if enabled.
+			var gatePipeline *validate.GatePipeline
+			var gateContext *validate.ValidationContext
+			if enableGates {
+				shapeSet, err := loadShapeSet(shapesPath)
+				if err != nil {
+					return fmt.Errorf("failed to load shapes: %w", err)
+				}
+				gateConfig := &validate.ValidationConfig{
+					Thresholds: make(map[string]float64),
+					SkipGates:  skipGates,
+					StrictMode: strictMode,
+					FailOnWarn: failOnWarn,
+					Shapes:     shapeSet,
+				}
+				gatePipeline = validate.NewGatePipeline(gateConfig)
+				gatePipeline.RegisterDefaultGates()
+				if cycleGate {
+					gatePipeline.RegisterGate(validate.NewCycleGate())
+				}
+				gateContext = &validate.ValidationContext{
+					SourcePath: source,
+					SourceSize: fileInfo.Size(),
+					Config:     gateConfig,
+				}
+			}
+
+			// Gate V0: Schema validation (after file load, before parsing).
+			if gatePipeline != nil {
+				v0Result := gatePipeline.RunGate("V0", gateContext)
+				if v0Result != nil && !v0Result.Skipped {
+					printGateResult(progress, v0Result)
+					if !v0Result.Passed && strictMode {
+						return fmt.Errorf("pipeline halted: gate V0 (schema) failed")
+					}
+				}
+			}
+
+			// Step 1: Parse document
+			fmt.Fprint(progress, "  1. Parsing document structure... ")
+			file, err := os.Open(source)
+			if err != nil {
+				return fmt.Errorf("failed to open source: %w", err)
+			}
+			defer file.Close()
+
+			_, parseSpan := telemetry.Start(ctx, "parse")
+			parser := newParserWithPatterns()
+			doc, err := parser.Parse(file)
+			if err != nil {
+				err = fmt.Errorf("failed to parse document: %w", err)
+				parseSpan.RecordError(err)
+				parseSpan.End()
+				return err
+			}
+			parseSpan.SetAttributes(slog.Int("chapters", len(doc.Chapters)), slog.Int("articles", countArticles(doc)))
+			parseDuration := parseSpan.End()
+			fmt.Fprintf(progress, "done (%d chapters, %d articles)\n", len(doc.Chapters), countArticles(doc))
+
+			// Gate V1: Structure validation (after parsing).
+			if gatePipeline != nil {
+				gateContext.Document = doc
+				gateContext.ParseDuration = parseDuration
+				v1Result := gatePipeline.RunGate("V1", gateContext)
+				if v1Result != nil && !v1Result.Skipped {
+					printGateResult(progress, v1Result)
+					if !v1Result.Passed && strictMode {
+						return fmt.Errorf("pipeline halted: gate V1 (structure) failed")
+					}
+				}
+			}
+
+			// Step 2: Extract definitions
+			fmt.Fprint(progress, "  2. Extracting defined terms... ")
+			_, definitionsSpan := telemetry.Start(ctx, "extract_definitions")
+			defExtractor := extract.NewDefinitionExtractor()
+			definitions := defExtractor.ExtractDefinitions(doc)
+			definitionsSpan.SetAttributes(slog.Int("definitions", len(definitions)))
+			definitionsSpan.End()
+			fmt.Fprintf(progress, "done (%d definitions)\n", len(definitions))
+
+			// Step 3: Extract cross-references
+			fmt.Fprint(progress, "  3. Identifying cross-references... ")
+			_, referencesSpan := telemetry.Start(ctx, "extract_references")
+			if profiling {
+				refExtractor.EnablePatternStats()
+			}
+			references := refExtractor.ExtractFromDocument(doc)
+			referencesSpan.SetAttributes(slog.Int("references", len(references)))
+			referencesSpan.End()
+			fmt.Fprintf(progress, "done (%d references)\n", len(references))
+
+			// Step 4: Extract rights and obligations
+			fmt.Fprint(progress, "  4. Extracting rights/obligations... ")
+			semExtractor := extract.NewSemanticExtractor()
+			recurrenceFile, err := applyRecurrenceAnnotations(semExtractor, source, recurrencePath)
+			if err != nil {
+				return err
+			}
+			_, semanticsSpan := telemetry.Start(ctx, "extract_semantics")
+			semantics := semExtractor.ExtractFromDocument(doc)
+			semStats := extract.CalculateSemanticStats(semantics)
+			semanticsSpan.SetAttributes(slog.Int("rights", semStats.Rights), slog.Int("obligations", semStats.Obligations))
+			semanticsSpan.End()
+			fmt.Fprintf(progress, "done (%d rights, %d obligations, %d recurring)\n", semStats.Rights, semStats.Obligations, semStats.RecurringObligations)
+			if recurrenceFile != "" {
+				fmt.Fprintf(progress, "     Recurrence annotations: %s\n", recurrenceFile)
+			}
+
+			// Gate V2: Coverage validation (after extraction).
+			var gateResults []*validate.GateResult
+			if gatePipeline != nil {
+				gateContext.Definitions = definitions
+				gateContext.References = references
+				gateContext.Semantics = semantics
+				v2Result := gatePipeline.RunGate("V2", gateContext)
+				gateResults = append(gateResults, v2Result)
+				if v2Result != nil && !v2Result.Skipped {
+					printGateResult(progress, v2Result)
+					if !v2Result.Passed && strictMode {
+						return fmt.Errorf("pipeline halted: gate V2 (coverage) failed")
+					}
+				}
+			}
+
+			// Step 5: Resolve references
+			fmt.Fprint(progress, "  5. Resolving cross-references... ")
+			resolver := extract.NewReferenceResolver(baseURI, extractDocID(source))
+			resolver.IndexDocument(doc)
+			mappingsFile, err := applyReferenceMappings(resolver, source, mappingsPath)
+			if err != nil {
+				return err
+			}
+			_, resolveSpan := telemetry.Start(ctx, "resolve")
+			resolved := resolver.ResolveAll(references)
+			report := extract.GenerateReport(resolved)
+			resolveSpan.SetAttributes(slog.Float64("resolution_rate", report.ResolutionRate))
+			resolveSpan.End()
+			if mappingsFile != "" {
+				fmt.Fprintf(progress, "done (%.0f%% resolved, %d via %s)\n", report.ResolutionRate*100, report.ManualMappings, mappingsFile)
+			} else {
+				fmt.Fprintf(progress, "done (%.0f%% resolved)\n", report.ResolutionRate*100)
+			}
+			for _, unused := range resolver.UnusedManualMappings() {
+				fmt.Fprintf(app.Stderr, "  Warning: manual mapping %q did not match any reference\n", unused)
+			}
+
+			// Step 6: Build complete knowledge graph
+			fmt.Fprint(progress, "  6. Building knowledge graph... ")
+			_, buildSpan := telemetry.Start(ctx, "build")
+			tripleStore := store.NewTripleStore()
+			builder := store.NewGraphBuilder(tripleStore, baseURI)
+			if llmExtractor != nil {
+				builder.SetLLMExtractor(llmExtractor)
+			}
+			stats, err := builder.BuildCompleteWithContext(ctx, doc, defExtractor, refExtractor, resolver, semExtractor)
+			if err != nil {
+				err = fmt.Errorf("failed to build graph: %w", err)
+				buildSpan.RecordError(err)
+				buildSpan.End()
+				return err
+			}
+			buildSpan.SetAttributes(slog.Int("triples", stats.TotalTriples))
+			buildSpan.End()
+			fmt.Fprintf(progress, "done (%d triples)\n", stats.TotalTriples)
+			if stats.LLM != nil {
+				fmt.Fprintf(progress, "     LLM fallback: %d paragraphs, %d definitions, %d rights/obligations added, %d confirmed, %d rejected\n",
+					stats.LLM.Paragraphs, len(stats.LLM.Definitions), len(stats.LLM.Annotations), stats.LLM.Confirmed, len(stats.LLM.Rejected))
+				for _, llmError := range stats.LLM.Errors {
+					fmt.Fprintf(app.Stderr, "  Warning: LLM fallback: %s\n", llmError)
+				}
+			}
+
+			// Gate V3: Quality validation (after resolution + graph).
+			if gatePipeline != nil {
+				gateContext.ResolvedReferences = resolved
+				gateContext.TripleStore = tripleStore
+				v3Result := gatePipeline.RunGate("V3", gateContext)
+				if v3Result != nil && !v3Result.Skipped {
+					printGateResult(progress, v3Result)
+				}
+				gateResults = append(gateResults, v3Result)
+
+				// Failed gates and shape violations lower triple quality scores.
+				validate.ApplyGateQuality(tripleStore, gateResults...)
+
+				// Cycles are a property of the text rather than an extraction
+				// error, so the optional cycle gate is reported only.
+				if cycleResult := gatePipeline.RunGate("cycles", gateContext); cycleResult != nil && !cycleResult.Skipped {
+					printGateResult(progress, cycleResult)
+				}
+			}
+
+			// Step 7: Fetch external references (optional)
+			if fetchRefs {
+				fmt.Fprint(progress, "  7. Fetching external references... ")
+
+				fetchConfig := fetch.FetchConfig{
+					MaxDepth:       maxDepth,
+					MaxDocuments:   maxDocuments,
+					AllowedDomains: allowedDomains,
+					RateLimit:      fetch.DefaultFetchRateLimit,
+					Timeout:        fetch.DefaultFetchTimeout,
+					CacheDir:       cacheDir,
+					DryRun:         dryRun,
+					RespectRobots:  respectRobots,
+					UserAgent:      eurlex.DefaultUserAgent,
+				}
+
+				eurlexConfig := eurlex.DefaultConfig()
+				if httpCacheDir != "" {
+					httpCache, cacheErr := httpcache.Open(httpCacheDir)
+					if cacheErr != nil {
+						return fmt.Errorf("failed to open HTTP cache: %w", cacheErr)
+					}
+					eurlexConfig.HTTPClient = httpCache.Client(nil)
+				}
+				eurlexValidator := eurlex.NewEURLexClient(eurlexConfig)
+				recursiveFetcher, fetcherErr := fetch.NewRecursiveFetcher(fetchConfig, eurlexValidator)
+				if fetcherErr != nil {
+					return fmt.Errorf("failed to initialize recursive fetcher: %w", fetcherErr)
+				}
+
+				sourceDocURI := baseURI + "GDPR"
+				var fetchReport *fetch.FetchReport
+
+				_, fetchSpan := telemetry.Start(ctx, "fetch", slog.Bool("dry_run", dryRun))
+				if dryRun {
+					fetchReport, fetcherErr = recursiveFetcher.Plan(tripleStore, sourceDocURI)
+				} else {
+					fetchCtx, stopFetch := interruptContext(ctx)
+					fetchReport, fetcherErr = recursiveFetcher.FetchWithContext(fetchCtx, tripleStore, sourceDocURI)
+					stopFetch()
+				}
+				if fetcherErr != nil {
+					fetchSpan.RecordError(fetcherErr)
+				}
+				fetchSpan.End()
+
+				if fetcherErr != nil {
+					fmt.Fprintf(progress, "warning: %v\n", fetcherErr)
+				} else {
+					if dryRun {
+						fmt.Fprintln(progress, "done (dry-run)")
+					} else {
+						fmt.Fprintf(progress, "done (%d fetched, %d cached, %d failed, %d triples added)\n",
+							fetchReport.FetchedCount, fetchReport.CachedCount,
+							fetchReport.FailedCount, fetchReport.TriplesAdded)
+					}
+					fmt.Fprintln(progress)
+					fmt.Fprint(progress, fetchReport.String())
+				}
+			}
+
+			ingestSpan.End()
+			ingestEnded = true
+			elapsed := time.Since(startTime)
+			fmt.Fprintf(progress, "\nIngestion complete in %v\n", elapsed)
+
+			if recorder != nil {
+				ingestProfile := recorder.Build()
+				ingestProfile.Source = source
+				ingestProfile.Patterns = refExtractor.PatternStats()
+				fmt.Fprintln(progress, "\nProfile:")
+				ingestProfile.WriteTable(progress, 10)
+				if profileOutput != "" {
+					if err := writeProfile(ingestProfile, profileOutput, profileFormat); err != nil {
+						return err
+					}
+					fmt.Fprintf(progress, "Profile written to: %s\n", profileOutput)
+				}
+			}
+
+			// Show stats if requested
+			if showStats {
+				fmt.Fprintln(progress, "\nGraph Statistics:")
+				fmt.Fprintf(progress, "  Total triples:    %d\n", stats.TotalTriples)
+				fmt.Fprintf(progress, "  Articles:         %d\n", stats.Articles)
+				fmt.Fprintf(progress, "  Chapters:         %d\n", stats.Chapters)
+				fmt.Fprintf(progress, "  Sections:         %d\n", stats.Sections)
+				fmt.Fprintf(progress, "  Recitals:         %d\n", stats.Recitals)
+				fmt.Fprintf(progress, "  Definitions:      %d\n", stats.Definitions)
+				fmt.Fprintf(progress, "  References:       %d\n", stats.References)
+				fmt.Fprintf(progress, "  Rights:           %d\n", stats.Rights)
+				fmt.Fprintf(progress, "  Obligations:      %d\n", stats.Obligations)
+				fmt.Fprintf(progress, "  Term usages:      %d\n", stats.TermUsages)
+				fmt.Fprintf(progress, "  Quality < 0.5:    %d\n", tripleStore.CountBelowQuality(0.5))
+			}
+
+			// Save graph if output specified
+			if output != "" {
+				fmt.Fprintf(progress, "\nSaving graph to: %s\n", output)
+				if err := saveGraph(tripleStore, output); err != nil {
+					return fmt.Errorf("failed to save graph: %w", err)
+				}
+				fmt.Fprintln(progress, "Graph saved successfully.")
+			}
+
+			if formatStr == "json" {
+				result, err := json.MarshalIndent(ingestResult{
+					Source:            source,
+					Output:            output,
+					Stats:             library.NewDocumentStats(stats, int(fileInfo.Size())),
+					LowQualityTriples: tripleStore.CountBelowQuality(0.5),
+				}, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to serialize JSON: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(result))
+			}
+
+			if watchMode {
+				watcher := &ingestWatcher{
+					app:            app,
+					tripleStore:    tripleStore,
+					source:         source,
+					output:         output,
+					baseURI:        baseURI,
+					mappingsPath:   mappingsPath,
+					recurrencePath: recurrencePath,
+					refExtractor:   refExtractor,
+					gatePipeline:   gatePipeline,
+					gateContext:    gateContext,
+					doc:            doc,
+					stats:          library.NewDocumentStats(stats, int(fileInfo.Size())),
+				}
+				return watcher.run(ctx, pollInterval)
+			}
+
+			fmt.Fprintln(progress, "\nReady for queries. Run: regula query \"SELECT ?article WHERE { ?article rdf:type reg:Article } LIMIT 5\"")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document path")
+	cmd.Flags().StringP("output", "o", "", "Output graph file (JSON)")
+	cmd.Flags().Bool("stats", false, "Show detailed statistics")
+	cmd.Flags().String("base-uri", "https://regula.dev/regulations/", "Base URI for the graph")
+	cmd.Flags().Bool("gates", false, "Enable validation gates during ingestion")
+	cmd.Flags().StringSlice("skip-gates", []string{}, "Gates to skip (V0,V1,V2,V3)")
+	cmd.Flags().Bool("strict", false, "Halt pipeline on gate failure")
+	cmd.Flags().Bool("fail-on-warn", false, "Halt pipeline on gate warnings")
+	cmd.Flags().String("shapes", "", "Shape constraints for gate V3 (YAML or .ttl file, or \"default\" for built-in shapes)")
+	cmd.Flags().Bool("cycle-gate", false, "Also run the optional gate that reports reference and definition cycles")
+	cmd.Flags().String("mappings", "", "Manual reference mapping file (default: <source>.mappings.yaml if present)")
+	cmd.Flags().String("recurrence", "", "Obligation recurrence annotation file (default: <source>.recurrence.yaml if present)")
+	cmd.Flags().String("reference-patterns", "", "Reference pattern pack file or directory overriding and extending the built-in patterns")
+
+	// Recursive fetch flags
+	cmd.Flags().Bool("fetch-refs", false, "Fetch external referenced documents to build a federated graph")
+	cmd.Flags().Int("max-depth", fetch.DefaultMaxDepth, "Maximum recursion depth for fetching external references")
+	cmd.Flags().Int("max-documents", fetch.DefaultMaxDocuments, "Maximum number of external documents to fetch")
+	cmd.Flags().StringSlice("allowed-domains", []string{}, "Restrict fetching to these domains (empty allows all)")
+	cmd.Flags().Bool("dry-run", false, "Plan what would be fetched without making network calls")
+	cmd.Flags().String("cache-dir", "", "Directory for caching fetched document metadata")
+	cmd.Flags().String("http-cache", httpcache.DefaultDir, "HTTP cache directory for revalidating fetched references (empty disables)")
+	cmd.Flags().Bool("respect-robots", true, "Skip references disallowed by robots.txt and honor Crawl-delay")
+
+	// Watch mode flags
+	cmd.Flags().Bool("watch", false, "Watch the source and patterns and re-run the pipeline on change")
+	cmd.Flags().Duration("interval", 500*time.Millisecond, "Polling interval for --watch")
+	cmd.Flags().Bool("profile", false, "Report per-stage time and memory and per-pattern reference match statistics")
+	cmd.Flags().String("profile-output", "", "Write the profile to a file (implies --profile)")
+	cmd.Flags().String("profile-format", "json", "Profile file format: json or folded (flamegraph stacks)")
+	addLLMFlags(cmd)
+	cmd.Flags().Float64("llm-min-confidence", extract.DefaultLLMMinConfidence, "Pattern confidence below which paragraphs are sent to the LLM")
+	cmd.Flags().Int("llm-max-paragraphs", extract.DefaultLLMMaxParagraphs, "Maximum paragraphs sent to the LLM per document")
+	cmd.Flags().String("format", "text", "Output format (text, json); json prints the graph statistics, with progress on stderr")
+
+	return cmd
+}
+
+// ingestResult is the 'ingest --format json' document.
+type ingestResult struct {
+	Source            string                 `json:"source"`
+	Output            string                 `json:"output,omitempty"`
+	Stats             *library.DocumentStats `json:"stats"`
+	LowQualityTriples int                    `json:"low_quality_triples"`
+}
+
+// ingestWatcher re-runs the ingestion pipeline for "regula ingest --watch",
+// keeping the parsed document and graph between runs so that source edits
+// only re-extract the changed articles.
+type ingestWatcher struct {
+	app            *App
+	tripleStore    *store.TripleStore
+	source         string
+	output         string
+	baseURI        string
+	mappingsPath   string
+	recurrencePath string
+	refExtractor   *extract.ReferenceExtractor
+	gatePipeline   *validate.GatePipeline
+	gateContext    *validate.ValidationContext
+	doc            *extract.Document
+	stats          *library.DocumentStats
+}
+
+// run polls the source file and patterns directory until interrupted or
+// ctx is done.
+func (w *ingestWatcher) run(ctx context.Context, pollInterval time.Duration) error {
+	patternsDir := findPatternsDir()
+	if patternsDir != "" {
+		fmt.Fprintf(w.app.Stdout, "\nWatching %s and %s for changes (Ctrl+C to stop)...\n", w.source, patternsDir)
+	} else {
+		fmt.Fprintf(w.app.Stdout, "\nWatching %s for changes (Ctrl+C to stop)...\n", w.source)
+	}
+	ctx, stop := interruptContext(ctx)
+	defer stop()
+
+	sourceModTime := fileModTime(w.source)
+	patternModTimes := scanPatternModTimes(patternsDir)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(w.app.Stdout, "\nStopped watching.")
+			return nil
+		case <-ticker.C:
+			currentSource := fileModTime(w.source)
+			currentPatterns := scanPatternModTimes(patternsDir)
+			patternsChanged := patternDirChanged(patternModTimes, currentPatterns)
+			if !currentSource.After(sourceModTime) && !patternsChanged {
+				continue
+			}
+			sourceModTime = currentSource
+			patternModTimes = currentPatterns
+
+			if err := w.rerun(patternsChanged); err != nil {
+				fmt.Fprintf(w.app.Stdout, "\n[%s] %v\n", time.Now().Format("15:04:05"), err)
+			}
+		}
+	}
+}
+
+// rerun re-ingests the source, incrementally unless the patterns changed
+// or the edit cannot be applied article by article.
+func (w *ingestWatcher) rerun(patternsChanged bool) error {
+	runStart := time.Now()
+	sourceText, err := os.ReadFile(w.source)
+	if err != nil {
+		return fmt.Errorf("failed to read source: %w", err)
+	}
+	doc, err := newParserWithPatterns().Parse(bytes.NewReader(sourceText))
+	if err != nil {
+		return fmt.Errorf("failed to parse document: %w", err)
+	}
+	mappings, annotations, err := loadIngestOverrides(w.source, w.mappingsPath, w.recurrencePath)
+	if err != nil {
+		return err
+	}
+
+	opts := library.UpdateOptions{
+		Full:                  patternsChanged,
+		ReferenceMappings:     mappings,
+		RecurrenceAnnotations: annotations,
+		ReferenceExtractor:    w.refExtractor,
+	}
+	regID := extractDocID(w.source)
+	report, stats, err := library.ApplyEdit(w.tripleStore, w.doc, doc, regID, w.baseURI, w.stats, opts)
+	if err != nil {
+		return err
+	}
+	if patternsChanged {
+		report.Reason = "patterns changed"
+	}
+
+	if report.Mode == library.UpdateFull {
+		rebuilt := store.NewTripleStore()
+		buildStats, err := buildIngestGraph(rebuilt, doc, regID, w.baseURI, mappings, annotations, w.refExtractor)
+		if err != nil {
+			return err
+		}
+		report.TriplesRemoved, report.TriplesAdded = countTripleChanges(w.tripleStore, rebuilt)
+		w.tripleStore = rebuilt
+		stats = library.NewDocumentStats(buildStats, len(sourceText))
+	}
+	stats.SourceBytes = len(sourceText)
+	stats.TotalTriples = w.tripleStore.Count()
+
+	fmt.Fprintf(w.app.Stdout, "\n[%s] %s: %s", time.Now().Format("15:04:05"), filepath.Base(w.source), report.Mode)
+	if report.Reason != "" {
+		fmt.Fprintf(w.app.Stdout, " (%s)", report.Reason)
+	}
+	if changed := len(report.AddedArticles) + len(report.RemovedArticles) + len(report.ModifiedArticles); changed > 0 {
+		fmt.Fprintf(w.app.Stdout, ", %d articles changed", changed)
+	}
+	fmt.Fprintf(w.app.Stdout, ", +%d/-%d triples in %v\n", report.TriplesAdded, report.TriplesRemoved, time.Since(runStart).Round(time.Millisecond))
+	printArticleChanges(w.app.Stdout, "Added", report.AddedArticles)
+	printArticleChanges(w.app.Stdout, "Removed", report.RemovedArticles)
+	printArticleChanges(w.app.Stdout, "Modified", report.ModifiedArticles)
+
+	if w.gatePipeline != nil {
+		w.runGates(doc, regID, mappings, annotations, int64(len(sourceText)))
+	}
+	printStatsDelta(w.app.Stdout, w.stats, stats)
+
+	w.doc = doc
+	w.stats = stats
+
+	if w.output != "" {
+		if err := saveGraph(w.tripleStore, w.output); err != nil {
+			return fmt.Errorf("failed to save graph: %w", err)
+		}
+		fmt.Fprintf(w.app.Stdout, "  Saved graph to %s\n", w.output)
+	}
+	return nil
+}
+
+// runGates re-runs gates V1-V3, and the cycle gate when enabled, against
+// the edited document. Extraction is
+// repeated in full because the gates score the whole document.
+func (w *ingestWatcher) runGates(doc *extract.Document, regID string, mappings []extract.ReferenceMapping, annotations []extract.RecurrenceAnnotation, sourceSize int64) {
+	references := w.refExtractor.ExtractFromDocument(doc)
+	semExtractor := extract.NewSemanticExtractor()
+	semExtractor.SetRecurrenceAnnotations(annotations)
+	resolver := extract.NewReferenceResolver(w.baseURI, regID)
+	resolver.IndexDocument(doc)
+	resolver.SetManualMappings(mappings)
+
+	w.gateContext.SourceSize = sourceSize
+	w.gateContext.Document = doc
+	w.gateContext.Definitions = extract.NewDefinitionExtractor().ExtractDefinitions(doc)
+	w.gateContext.References = references
+	w.gateContext.Semantics = semExtractor.ExtractFromDocument(doc)
+	w.gateContext.ResolvedReferences = resolver.ResolveAll(references)
+	w.gateContext.TripleStore = w.tripleStore
+
+	for _, gate := range []string{"V1", "V2", "V3", "cycles"} {
+		if gateResult := w.gatePipeline.RunGate(gate, w.gateContext); gateResult != nil && !gateResult.Skipped {
+			printGateResult(w.app.Stdout, gateResult)
+		}
+	}
+}
+
+// buildIngestGraph runs extraction, resolution, and graph building for doc
+// into ts, as the ingest command does.
+func buildIngestGraph(ts *store.TripleStore, doc *extract.Document, regID string, baseURI string, mappings []extract.ReferenceMapping, annotations []extract.RecurrenceAnnotation, refExtractor *extract.ReferenceExtractor) (*store.BuildStats, error) {
+	semExtractor := extract.NewSemanticExtractor()
+	semExtractor.SetRecurrenceAnnotations(annotations)
+	resolver := extract.NewReferenceResolver(baseURI, regID)
+	resolver.IndexDocument(doc)
+	resolver.SetManualMappings(mappings)
+
+	builder := store.NewGraphBuilder(ts, baseURI)
+	buildStats, err := builder.BuildComplete(doc, extract.NewDefinitionExtractor(), refExtractor, resolver, semExtractor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build graph: %w", err)
+	}
+	return buildStats, nil
+}
+
+// loadIngestOverrides loads the manual reference mappings and recurrence
+// annotations for source, from the given paths or the files discovered
+// next to it.
+func loadIngestOverrides(source string, mappingsPath string, recurrencePath string) ([]extract.ReferenceMapping, []extract.RecurrenceAnnotation, error) {
+	if mappingsPath == "" {
+		mappingsPath = extract.FindReferenceMappingFile(source)
+	}
+	if recurrencePath == "" {
+		recurrencePath = extract.FindRecurrenceAnnotationFile(source)
+	}
+
+	var mappings []extract.ReferenceMapping
+	var annotations []extract.RecurrenceAnnotation
+	var err error
+	if mappingsPath != "" {
+		if mappings, err = extract.LoadReferenceMappings(mappingsPath); err != nil {
+			return nil, nil, err
+		}
+	}
+	if recurrencePath != "" {
+		if annotations, err = extract.LoadRecurrenceAnnotations(recurrencePath); err != nil {
+			return nil, nil, err
+		}
+	}
+	return mappings, annotations, nil
+}
+
+// countTripleChanges returns how many triples of before are missing from
+// after, and how many of after are new.
+func countTripleChanges(before, after *store.TripleStore) (removed int, added int) {
+	for _, triple := range before.All() {
+		if !after.Exists(triple.Subject, triple.Predicate, triple.Object) {
+			removed++
+		}
+	}
+	for _, triple := range after.All() {
+		if !before.Exists(triple.Subject, triple.Predicate, triple.Object) {
+			added++
+		}
+	}
+	return removed, added
+}
+
+func printArticleChanges(w io.Writer, label string, articles []string) {
+	if len(articles) > 0 {
+		fmt.Fprintf(w, "  %s: Art %s\n", label, strings.Join(articles, ", Art "))
+	}
+}
+
+// printStatsDelta prints graph statistics with the change since the
+// previous run.
+func printStatsDelta(w io.Writer, previous, current *library.DocumentStats) {
+	rows := []struct {
+		label    string
+		previous int
+		current  int
+	}{
+		{"Triples", previous.TotalTriples, current.TotalTriples},
+		{"Articles", previous.Articles, current.Articles},
+		{"Definitions", previous.Definitions, current.Definitions},
+		{"References", previous.References, current.References},
+		{"Rights", previous.Rights, current.Rights},
+		{"Obligations", previous.Obligations, current.Obligations},
+	}
+	for _, row := range rows {
+		if delta := row.current - row.previous; delta != 0 {
+			fmt.Fprintf(w, "  %-12s %d (%+d)\n", row.label+":", row.current, delta)
+		} else {
+			fmt.Fprintf(w, "  %-12s %d\n", row.label+":", row.current)
+		}
+	}
+}
+
+// fileModTime returns the modification time of path, or the zero time when
+// it cannot be read.
+func fileModTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// patternDirChanged reports whether any pattern file was added, removed, or
+// modified between two scans.
+func patternDirChanged(previous, current map[string]time.Time) bool {
+	if len(previous) != len(current) {
+		return true
+	}
+	for path, modTime := range current {
+		if before, ok := previous[path]; !ok || modTime.After(before) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyReferenceMappings loads manual reference mappings into the resolver
+// from mappingsPath, or from a mapping file discovered next to the source.
+// Returns the file used, or "" when there is none.
+func applyReferenceMappings(resolver *extract.ReferenceResolver, source string, mappingsPath string) (string, error) {
+	if mappingsPath == "" {
+		mappingsPath = extract.FindReferenceMappingFile(source)
+	}
+	if mappingsPath == "" {
+		return "", nil
+	}
+
+	mappings, err := extract.LoadReferenceMappings(mappingsPath)
+	if err != nil {
+		return "", err
+	}
+	resolver.SetManualMappings(mappings)
+	return mappingsPath, nil
+}
+
+// applyRecurrenceAnnotations loads obligation recurrence annotations into
+// the semantic extractor from annotationsPath, or from a recurrence file
+// discovered next to the source. Returns the file used, or "" when there is
+// none.
+func applyRecurrenceAnnotations(semExtractor *extract.SemanticExtractor, source string, annotationsPath string) (string, error) {
+	if annotationsPath == "" {
+		annotationsPath = extract.FindRecurrenceAnnotationFile(source)
+	}
+	if annotationsPath == "" {
+		return "", nil
+	}
+
+	annotations, err := extract.LoadRecurrenceAnnotations(annotationsPath)
+	if err != nil {
+		return "", err
+	}
+	semExtractor.SetRecurrenceAnnotations(annotations)
+	return annotationsPath, nil
+}
+
+// loadReferenceExtractor returns a reference extractor with the built-in
+// patterns, overridden and extended by the reference packs at packsPath (a
+// pack file or a directory of packs) when it is set.
+func loadReferenceExtractor(packsPath string) (*extract.ReferenceExtractor, error) {
+	if packsPath == "" {
+		return extract.NewReferenceExtractor(), nil
+	}
+	packs, err := extract.LoadReferencePacks(packsPath)
+	if err != nil {
+		return nil, err
+	}
+	return extract.NewReferenceExtractorWithPacks(packs...)
+}
+
+// loadShapeSet returns the shape set for gate V3: nil when shapesPath is
+// empty, the built-in shapes for "default", otherwise the shapes file.
+func loadShapeSet(shapesPath string) (*shapes.ShapeSet, error) {
+	switch shapesPath {
+	case "":
+		return nil, nil
+	case "default":
+		return shapes.DefaultShapeSet(), nil
+	}
+	return shapes.LoadShapes(shapesPath)
+}
+
+// loadedGraph is a knowledge graph ingested from a source document for a
+// single command.
+type loadedGraph struct {
+	store    *store.TripleStore
+	executor *query.Executor
+	docType  extract.DocumentType
+}
+
+// withMinQuality returns the graph restricted to triples whose quality
+// score is at least minQuality. A zero minimum keeps the whole graph.
+func (graph *loadedGraph) withMinQuality(minQuality float64) (*loadedGraph, error) {
+	filtered, err := filterMinQuality(graph.store, minQuality)
+	if err != nil {
+		return nil, err
+	}
+	if filtered == graph.store {
+		return graph, nil
+	}
+	return &loadedGraph{
+		store:    filtered,
+		executor: query.NewExecutor(filtered),
+		docType:  graph.docType,
+	}, nil
+}
+
+// filterMinQuality returns the triples of tripleStore whose quality score is
+// at least minQuality. A zero minimum returns tripleStore itself.
+func filterMinQuality(tripleStore *store.TripleStore, minQuality float64) (*store.TripleStore, error) {
+	if minQuality < 0 || minQuality > 1 {
+		return nil, &usageError{err: fmt.Errorf("--min-quality must be between 0 and 1, got %g", minQuality)}
+	}
+	if minQuality == 0 {
+		return tripleStore, nil
+	}
+	return tripleStore.FilterByQuality(minQuality), nil
+}
+
+// asOf returns the graph without the provisions that are not in force on
+// the date, given as YYYY-MM-DD. An empty date keeps the whole graph.
+func (graph *loadedGraph) asOf(date string) (*loadedGraph, error) {
+	if date == "" {
+		return graph, nil
+	}
+	asOfDate, err := time.Parse(inforce.DateLayout, date)
+	if err != nil {
+		return nil, fmt.Errorf("--as-of must be a date in YYYY-MM-DD form, got %q", date)
+	}
+	filtered := inforce.NewReasoner(graph.store).FilterAsOf(asOfDate)
+	return &loadedGraph{
+		store:    filtered,
+		executor: query.NewExecutor(filtered),
+		docType:  graph.docType,
+	}, nil
+}
+
+// writeProfile writes an ingest profile to path as JSON or folded stacks.
+func writeProfile(ingestProfile *profile.Profile, path string, format string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create profile file: %w", err)
+	}
+	defer file.Close()
+
+	if format == "folded" {
+		err = ingestProfile.WriteFolded(file)
+	} else {
+		err = ingestProfile.WriteJSON(file)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+	return nil
+}
+
+// loadAndIngest parses source and builds its knowledge graph, timing the
+// parse and build stages as telemetry spans.
+func loadAndIngest(ctx context.Context, source string) (*loadedGraph, error) {
+	file, err := os.Open(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open source: %w", err)
+	}
+	defer file.Close()
+
+	ctx = telemetry.With(ctx, slog.String(telemetry.KeyDocumentID, extractDocID(source)))
+	ctx, loadSpan := telemetry.Start(ctx, "load", slog.String("source", source))
+	defer loadSpan.End()
+
+	_, parseSpan := telemetry.Start(ctx, "parse")
+	parser := newParserWithPatterns()
+	doc, err := parser.Parse(file)
+	if err != nil {
+		err = fmt.Errorf("failed to parse document: %w", err)
+		parseSpan.RecordError(err)
+		parseSpan.End()
+		return nil, err
+	}
+	parseSpan.End()
+
+	baseURI := "https://regula.dev/regulations/"
+	tripleStore := store.NewTripleStore()
+	builder := store.NewGraphBuilder(tripleStore, baseURI)
+
+	defExtractor := extract.NewDefinitionExtractor()
+	refExtractor := extract.NewReferenceExtractor()
+	semExtractor := extract.NewSemanticExtractor()
+	if _, err := applyRecurrenceAnnotations(semExtractor, source, ""); err != nil {
+		return nil, err
+	}
+	resolver := extract.NewReferenceResolver(baseURI, extractDocID(source))
+	resolver.IndexDocument(doc)
+	if _, err := applyReferenceMappings(resolver, source, ""); err != nil {
+		return nil, err
+	}
+
+	_, buildSpan := telemetry.Start(ctx, "build")
+	stats, err := builder.BuildComplete(doc, defExtractor, refExtractor, resolver, semExtractor)
+	if err != nil {
+		err = fmt.Errorf("failed to build graph: %w", err)
+		buildSpan.RecordError(err)
+		buildSpan.End()
+		return nil, err
+	}
+	buildSpan.SetAttributes(slog.Int("triples", stats.TotalTriples))
+	buildSpan.End()
+
+	inforce.NewReasoner(tripleStore).Annotate()
+
+	return &loadedGraph{
+		store:    tripleStore,
+		executor: query.NewExecutor(tripleStore),
+		docType:  doc.Type,
+	}, nil
+}
+
+func countArticles(doc *extract.Document) int {
+	count := 0
+	for _, ch := range doc.Chapters {
+		for _, sec := range ch.Sections {
+			count += len(sec.Articles)
+		}
+		count += len(ch.Articles)
+	}
+	return count
+}
+
+func printGateResult(w io.Writer, gateResult *validate.GateResult) {
+	statusLabel := "PASS"
+	if !gateResult.Passed {
+		statusLabel = "FAIL"
+	}
+	if gateResult.Skipped {
+		statusLabel = "SKIP"
+	}
+	fmt.Fprintf(w, "  [%s] Gate %s (score: %.0f%%)\n", statusLabel, gateResult.Gate, gateResult.Score*100)
+	for _, gateError := range gateResult.Errors {
+		fmt.Fprintf(w, "    ERROR: %s\n", gateError.Message)
+	}
+	for _, gateWarning := range gateResult.Warnings {
+		fmt.Fprintf(w, "    WARN: %s\n", gateWarning.Message)
+	}
+}
+
+func saveGraph(ts *store.TripleStore, path string) error {
+	triples := ts.All()
+	data := make([]map[string]string, len(triples))
+	for i, t := range triples {
+		data[i] = map[string]string{
+			"subject":   t.Subject,
+			"predicate": t.Predicate,
+			"object":    t.Object,
+		}
+		if quality := ts.Quality(t); quality < store.DefaultQuality {
+			data[i]["quality"] = fmt.Sprintf("%.2f", quality)
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
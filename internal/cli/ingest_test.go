@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/profile"
+)
+
+func TestIngestCmd_Profile(t *testing.T) {
+	profilePath := filepath.Join(t.TempDir(), "profile.json")
+	stdout, stderr, code := runCLI(t, "ingest", "--source", testdataPath(t, "gdpr.txt"), "--profile-output", profilePath)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	for _, want := range []string{"Profile:", "extract_references", "Reference pattern"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("output missing %q:\n%s", want, stdout)
+		}
+	}
+
+	data, err := os.ReadFile(profilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ingestProfile profile.Profile
+	if err := json.Unmarshal(data, &ingestProfile); err != nil {
+		t.Fatalf("invalid profile: %v", err)
+	}
+	if len(ingestProfile.Stages) != 1 || ingestProfile.Stages[0].Name != "ingest" {
+		t.Fatalf("stages = %+v", ingestProfile.Stages)
+	}
+	root := ingestProfile.Stages[0]
+	if len(root.Children) < 6 || root.AllocBytes == 0 {
+		t.Errorf("ingest stage = %d children, %d bytes allocated", len(root.Children), root.AllocBytes)
+	}
+	if len(ingestProfile.Patterns) == 0 {
+		t.Error("profile has no pattern statistics")
+	}
+}
+
+func TestIngestCmd_ProfileFormat(t *testing.T) {
+	_, stderr, code := runCLI(t, "ingest", "--source", testdataPath(t, "gdpr.txt"), "--profile", "--profile-format", "svg")
+	if code != 1 || !strings.Contains(stderr, `invalid --profile-format "svg"`) {
+		t.Errorf("invalid format = %d %q", code, stderr)
+	}
+}
+
+func TestIngestCmd_LLMFallback(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "{\"items\": []}"}}]}`))
+	}))
+	defer server.Close()
+
+	stdout, stderr, code := runCLI(t, "ingest", "--source", testdataPath(t, "gdpr.txt"),
+		"--llm-endpoint", server.URL, "--llm-model", "test", "--llm-max-paragraphs", "3")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "LLM fallback: 3 paragraphs") {
+		t.Errorf("expected LLM fallback summary, got:\n%s", stdout)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+}
+
+func TestIngestCmd_ReferencePatterns(t *testing.T) {
+	packPath := filepath.Join(t.TempDir(), "project.yaml")
+	pack := `name: "Member State Law"
+pack_id: "member-state-law"
+version: "1.0.0"
+family: "eu_external"
+patterns:
+  - name: "memberStateLaw"
+    pattern: '\bMember\s+State\s+law\b'
+    type: "external"
+    target: "regulation"
+    identifier: "Member State law"
+`
+	if err := os.WriteFile(packPath, []byte(pack), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	referenceCount := func(stdout string) string {
+		_, rest, _ := strings.Cut(stdout, "Identifying cross-references... done (")
+		count, _, _ := strings.Cut(rest, " references)")
+		return count
+	}
+
+	stdout, stderr, code := runCLI(t, "ingest", "--source", testdataPath(t, "gdpr.txt"))
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	builtin := referenceCount(stdout)
+
+	stdout, stderr, code = runCLI(t, "ingest", "--source", testdataPath(t, "gdpr.txt"), "--reference-patterns", packPath)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if extended := referenceCount(stdout); extended == "" || extended == builtin {
+		t.Errorf("references with pack = %q, without = %q", extended, builtin)
+	}
+
+	_, stderr, code = runCLI(t, "ingest", "--source", testdataPath(t, "gdpr.txt"), "--reference-patterns", filepath.Join(t.TempDir(), "missing"))
+	if code != 1 || !strings.Contains(stderr, "failed to load reference patterns") {
+		t.Errorf("missing pack = %d %q", code, stderr)
+	}
+}
@@ -0,0 +1,1386 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/bulk"
+	"github.com/coolbeans/regula/pkg/catalog"
+	"github.com/coolbeans/regula/pkg/draft"
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/query"
+	"github.com/coolbeans/regula/pkg/querytemplate"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+func libraryCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "library",
+		Short: "Manage the legislation library",
+		Long: `Manage a persistent library of ingested legislation documents.
+
+The library stores both plain text sources and serialized RDF graphs
+on disk, enabling cross-legislation analysis without re-ingesting.
+
+--path may also name a library in object storage, s3://bucket/prefix or
+gs://bucket/prefix, for the library, playground, and draft commands.
+Credentials come from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY (HMAC
+keys for gs://), and $REGULA_S3_ENDPOINT points at an S3-compatible
+service such as MinIO. Graphs are downloaded when first used and cached
+in the user cache directory, or $REGULA_LIBRARY_CACHE.
+
+Examples:
+  regula library init
+  regula library init --path s3://compliance-team/regula
+  regula library seed --testdata-dir testdata
+  regula library list
+  regula library status
+  regula library add --source testdata/gdpr.txt --id eu-gdpr --jurisdiction EU
+  regula library update eu-gdpr --source gdpr-edited.txt
+  regula library query --template rights --documents eu-gdpr,us-ca-ccpa
+  regula library source eu-gdpr
+  regula library names "COPPA §6502"
+  regula library conflicts --documents us-va-vcdpa,us-tx-tdpsa
+  regula library link-definitions
+  regula library export --document eu-gdpr --format json
+  regula library set --document eu-gdpr --tag privacy --status in-force
+  regula library collection create privacy-laws eu-gdpr us-ca-ccpa
+  regula library remove test-doc`,
+	}
+
+	cmd.AddCommand(libraryInitCmd(app))
+	cmd.AddCommand(libraryAddCmd(app))
+	cmd.AddCommand(libraryUpdateCmd(app))
+	cmd.AddCommand(librarySeedCmd(app))
+	cmd.AddCommand(libraryListCmd(app))
+	cmd.AddCommand(libraryStatusCmd(app))
+	cmd.AddCommand(libraryQueryCmd(app))
+	cmd.AddCommand(libraryConflictsCmd(app))
+	cmd.AddCommand(libraryCoverageCmd(app))
+	cmd.AddCommand(libraryLinkDefinitionsCmd(app))
+	cmd.AddCommand(libraryMigrateURIsCmd(app))
+	cmd.AddCommand(libraryMigrateStorageCmd(app))
+	cmd.AddCommand(libraryRemoveCmd(app))
+	cmd.AddCommand(librarySetCmd(app))
+	cmd.AddCommand(libraryCollectionCmd(app))
+	cmd.AddCommand(libraryCatalogCmd(app))
+	cmd.AddCommand(libraryDedupeCmd(app))
+	cmd.AddCommand(libraryAliasCmd(app))
+	cmd.AddCommand(libraryExportCmd(app))
+	cmd.AddCommand(librarySourceCmd(app))
+	cmd.AddCommand(libraryNamesCmd(app))
+
+	return cmd
+}
+
+func libraryInitCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Initialize a new legislation library",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			baseURI, _ := cmd.Flags().GetString("base-uri")
+			storageFormatStr, _ := cmd.Flags().GetString("storage-format")
+
+			storageFormat, err := library.ParseStorageFormat(storageFormatStr)
+			if err != nil {
+				return err
+			}
+
+			lib, err := library.Init(libraryPath, baseURI)
+			if err != nil {
+				return fmt.Errorf("failed to initialize library: %w", err)
+			}
+			if storageFormat != library.DefaultStorageFormat {
+				if _, err := lib.MigrateStorageFormat(storageFormat, false); err != nil {
+					return err
+				}
+			}
+
+			out := app.messageWriter()
+			fmt.Fprintf(out, "Library initialized at: %s\n", lib.Path())
+			fmt.Fprintf(out, "Base URI: %s\n", lib.BaseURI())
+			fmt.Fprintln(out, "\nNext steps:")
+			fmt.Fprintln(out, "  regula library seed --testdata-dir testdata")
+			fmt.Fprintln(out, "  regula library add --source path/to/legislation.txt --id my-doc")
+			return app.writeJSONResult(map[string]string{"path": lib.Path(), "base_uri": lib.BaseURI()})
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("base-uri", "", "Base URI for the knowledge graph (default: https://regula.dev/regulations/)")
+	cmd.Flags().String("storage-format", string(library.DefaultStorageFormat), "Triple storage format (binary, binary+gzip, json)")
+
+	return cmd
+}
+
+func libraryAddCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a legislation document to the library",
+		Long: `Ingest a legislation document and store it in the library.
+
+The document is parsed, extracted, and its RDF graph is serialized to disk.
+
+Examples:
+  regula library add --source testdata/gdpr.txt --id eu-gdpr --jurisdiction EU
+  regula library add --source testdata/ccpa.txt --id us-ca-ccpa --name CCPA --jurisdiction US-CA
+  regula library add --source my-law.txt --force`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourcePath, _ := cmd.Flags().GetString("source")
+			documentID, _ := cmd.Flags().GetString("id")
+			documentName, _ := cmd.Flags().GetString("name")
+			jurisdiction, _ := cmd.Flags().GetString("jurisdiction")
+			format, _ := cmd.Flags().GetString("format")
+			tags, _ := cmd.Flags().GetStringSlice("tags")
+			force, _ := cmd.Flags().GetBool("force")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			recurrencePath, _ := cmd.Flags().GetString("recurrence")
+
+			if sourcePath == "" {
+				return &usageError{err: fmt.Errorf("--source flag is required")}
+			}
+
+			sourceText, err := os.ReadFile(sourcePath)
+			if err != nil {
+				return fmt.Errorf("failed to read source: %w", err)
+			}
+
+			if recurrencePath == "" {
+				recurrencePath = extract.FindRecurrenceAnnotationFile(sourcePath)
+			}
+			var recurrenceAnnotations []extract.RecurrenceAnnotation
+			if recurrencePath != "" {
+				recurrenceAnnotations, err = extract.LoadRecurrenceAnnotations(recurrencePath)
+				if err != nil {
+					return err
+				}
+			}
+
+			if documentID == "" {
+				documentID = library.DeriveDocumentID(sourcePath)
+			}
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s (run 'regula library init' first): %w", libraryPath, err)
+			}
+
+			if documentName == "" {
+				documentName = documentID
+			}
+
+			out := app.messageWriter()
+			fmt.Fprintf(out, "Adding document: %s\n", documentID)
+			fmt.Fprintf(out, "  Source: %s (%d bytes)\n", sourcePath, len(sourceText))
+
+			entry, err := lib.AddDocument(documentID, sourceText, library.AddOptions{
+				Name:         documentName,
+				ShortName:    documentName,
+				Jurisdiction: jurisdiction,
+				Format:       format,
+				Tags:         tags,
+				Force:        force,
+
+				RecurrenceAnnotations: recurrenceAnnotations,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to add document: %w", err)
+			}
+
+			if entry.Status == library.StatusReady {
+				fmt.Fprintf(out, "  Status: ready\n")
+				fmt.Fprintf(out, "  Base URI: %s\n", entry.BaseURI)
+				if entry.Stats != nil {
+					fmt.Fprintf(out, "  Triples: %d\n", entry.Stats.TotalTriples)
+					fmt.Fprintf(out, "  Articles: %d\n", entry.Stats.Articles)
+					fmt.Fprintf(out, "  Definitions: %d\n", entry.Stats.Definitions)
+					fmt.Fprintf(out, "  References: %d\n", entry.Stats.References)
+				}
+			}
+
+			return app.writeJSONResult(entry)
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document path")
+	cmd.Flags().String("id", "", "Document identifier (derived from filename if omitted)")
+	cmd.Flags().String("name", "", "Human-readable name")
+	cmd.Flags().String("jurisdiction", "", "Jurisdiction code (e.g., EU, US-CA, GB)")
+	cmd.Flags().String("format", "", "Parser format hint (eu, us, uk, generic)")
+	cmd.Flags().StringSlice("tags", []string{}, "Tags for categorization")
+	cmd.Flags().Bool("force", false, "Overwrite existing document")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("recurrence", "", "Obligation recurrence annotation file (default: <source>.recurrence.yaml if present)")
+
+	return cmd
+}
+
+func libraryUpdateCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update <document-id>",
+		Short: "Apply an edited source to a library document",
+		Long: `Update a library document from an edited source without rebuilding its
+whole graph.
+
+The new source is diffed against the cached one. Only added, removed, and
+modified articles are re-extracted, along with unchanged articles whose
+cross-references now resolve differently, and their triples are replaced
+in the stored graph. Edits to the title, preamble, chapter structure, or
+definitions affect the whole document and fall back to a full re-ingest.
+
+Examples:
+  regula library update eu-gdpr --source gdpr-edited.txt
+  regula library update eu-gdpr --source gdpr-edited.txt --dry-run
+  regula library update eu-gdpr --source gdpr-edited.txt --full --format json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourcePath, _ := cmd.Flags().GetString("source")
+			parserFormat, _ := cmd.Flags().GetString("parser-format")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			full, _ := cmd.Flags().GetBool("full")
+			formatStr, _ := cmd.Flags().GetString("format")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			recurrencePath, _ := cmd.Flags().GetString("recurrence")
+			documentID := args[0]
+
+			if sourcePath == "" {
+				return &usageError{err: fmt.Errorf("--source flag is required")}
+			}
+
+			sourceText, err := os.ReadFile(sourcePath)
+			if err != nil {
+				return fmt.Errorf("failed to read source: %w", err)
+			}
+
+			if recurrencePath == "" {
+				recurrencePath = extract.FindRecurrenceAnnotationFile(sourcePath)
+			}
+			var recurrenceAnnotations []extract.RecurrenceAnnotation
+			if recurrencePath != "" {
+				recurrenceAnnotations, err = extract.LoadRecurrenceAnnotations(recurrencePath)
+				if err != nil {
+					return err
+				}
+			}
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+
+			report, err := lib.UpdateDocument(documentID, sourceText, library.UpdateOptions{
+				Format: parserFormat,
+				DryRun: dryRun,
+				Full:   full,
+
+				RecurrenceAnnotations: recurrenceAnnotations,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update document: %w", err)
+			}
+
+			switch formatStr {
+			case "json":
+				fmt.Fprintln(app.Stdout, library.FormatUpdateReportJSON(report))
+			default:
+				fmt.Fprint(app.Stdout, library.FormatUpdateReportTable(report))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Edited source document path")
+	cmd.Flags().String("parser-format", "", "Parser format hint (eu, us, uk, generic; default: format the document was added with)")
+	cmd.Flags().Bool("dry-run", false, "Report what would change without writing")
+	cmd.Flags().Bool("full", false, "Re-ingest the whole document instead of only changed articles")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("recurrence", "", "Obligation recurrence annotation file (default: <source>.recurrence.yaml if present)")
+
+	return cmd
+}
+
+func librarySeedCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Seed the library with testdata legislation",
+		Long: `Ingest all known testdata documents into the library.
+
+Processes 18 legislation documents spanning EU, US (state and federal),
+UK, Australian, and international jurisdictions.
+
+Documents are ingested on --workers goroutines and committed to the
+library in corpus order, so the manifest is the same for any worker
+count. Progress is reported on stderr as each document is committed.
+
+Examples:
+  regula library seed --testdata-dir testdata
+  regula library seed --testdata-dir testdata --workers 8`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			testdataDir, _ := cmd.Flags().GetString("testdata-dir")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			workers, _ := cmd.Flags().GetInt("workers")
+
+			if workers < 1 {
+				return &usageError{err: fmt.Errorf("--workers must be at least 1, got %d", workers)}
+			}
+			out := app.messageWriter()
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				// Auto-init if not exists
+				lib, err = library.Init(libraryPath, "")
+				if err != nil {
+					return fmt.Errorf("failed to initialize library: %w", err)
+				}
+				fmt.Fprintf(out, "Library initialized at: %s\n\n", lib.Path())
+			}
+
+			entries := library.DefaultCorpusEntries()
+			fmt.Fprintf(out, "Seeding library with %d documents from %s\n\n", len(entries), testdataDir)
+
+			ctx, stop := interruptContext(cmd.Context())
+			defer stop()
+			seedReport, err := library.SeedFromCorpusWithOptions(ctx, lib, testdataDir, entries, library.SeedOptions{
+				Workers: workers,
+				Progress: func(progress library.SeedProgress) {
+					fmt.Fprintf(app.Stderr, "[%d/%d] %s %s (%s)\n", progress.Completed, progress.Total,
+						progress.Entry.ID, progress.Entry.Status, progress.Elapsed.Round(time.Millisecond))
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("seeding failed: %w", err)
+			}
+
+			for _, entryState := range seedReport.Entries {
+				switch entryState.Status {
+				case "ingested":
+					entry := lib.GetDocument(entryState.ID)
+					tripleCount := 0
+					if entry != nil && entry.Stats != nil {
+						tripleCount = entry.Stats.TotalTriples
+					}
+					fmt.Fprintf(out, "  [OK] %-20s %d triples\n", entryState.ID, tripleCount)
+				case "skipped":
+					fmt.Fprintf(out, "  [SKIP] %-18s already in library\n", entryState.ID)
+				case "failed":
+					fmt.Fprintf(out, "  [FAIL] %-18s %s\n", entryState.ID, entryState.Error)
+				}
+			}
+
+			fmt.Fprintf(out, "\nSeed complete: %d ingested, %d skipped, %d failed\n",
+				seedReport.Succeeded, seedReport.Skipped, seedReport.Failed)
+
+			libraryStats := lib.Stats()
+			fmt.Fprintf(out, "\nLibrary totals: %d documents, %d triples\n",
+				libraryStats.TotalDocuments, libraryStats.TotalTriples)
+
+			return app.writeJSONResult(seedReport)
+		},
+	}
+
+	cmd.Flags().String("testdata-dir", "testdata", "Path to testdata directory")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().Int("workers", 1, "Number of documents to ingest concurrently")
+
+	return cmd
+}
+
+func libraryListCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all documents in the library",
+		Long: `List library documents from the library catalog (catalog.json), which
+is rebuilt automatically whenever the library changes.
+
+Examples:
+  regula library list --jurisdiction US-VA
+  regula library list --tag privacy --legal-status in-force
+  regula library list --in-force-on 2024-01-01 --search consumer
+  regula library list --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			formatStr, _ := cmd.Flags().GetString("format")
+			jurisdiction, _ := cmd.Flags().GetString("jurisdiction")
+			tag, _ := cmd.Flags().GetString("tag")
+			collectionName, _ := cmd.Flags().GetString("collection")
+			status, _ := cmd.Flags().GetString("status")
+			legalStatus, _ := cmd.Flags().GetString("legal-status")
+			search, _ := cmd.Flags().GetString("search")
+			inForceOn, _ := cmd.Flags().GetString("in-force-on")
+			if inForceOn != "" {
+				if _, err := time.Parse("2006-01-02", inForceOn); err != nil {
+					return fmt.Errorf("invalid --in-force-on date %q: use YYYY-MM-DD", inForceOn)
+				}
+			}
+
+			libraryCatalog, err := catalog.Open(libraryPath)
+			if err != nil {
+				return err
+			}
+			if collectionName != "" && !slices.Contains(libraryCatalog.Collections, collectionName) {
+				return fmt.Errorf("collection not found: %s", collectionName)
+			}
+
+			docs := libraryCatalog.Find(catalog.Filter{
+				Jurisdiction: jurisdiction,
+				Status:       library.DocumentStatus(status),
+				LegalStatus:  library.LegalStatus(legalStatus),
+				Tag:          tag,
+				Collection:   collectionName,
+				Search:       search,
+				InForceOn:    inForceOn,
+			})
+
+			if formatStr == "json" {
+				encoder := json.NewEncoder(app.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(docs)
+			}
+
+			if len(docs) == 0 {
+				fmt.Fprintln(app.Stdout, "Library is empty. Run 'regula library seed' to add testdata documents.")
+				return nil
+			}
+
+			fmt.Fprintf(app.Stdout, "%-22s %-22s %-12s %-8s %8s %8s %8s\n",
+				"ID", "NAME", "JURISDICTION", "STATUS", "TRIPLES", "ARTICLES", "DEFS")
+			fmt.Fprintln(app.Stdout, strings.Repeat("-", 100))
+
+			for _, entry := range docs {
+				name := entry.ShortName
+				if name == "" {
+					name = entry.Name
+				}
+				if name == "" {
+					name = entry.ID
+				}
+				fmt.Fprintf(app.Stdout, "%-22s %-22s %-12s %-8s %8d %8d %8d\n",
+					truncateString(entry.ID, 22),
+					truncateString(name, 22),
+					entry.Jurisdiction,
+					entry.Status,
+					entry.Triples,
+					entry.Articles,
+					entry.Definitions,
+				)
+			}
+
+			fmt.Fprintf(app.Stdout, "\n%d document(s)\n", len(docs))
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+	cmd.Flags().String("jurisdiction", "", "Filter by jurisdiction")
+	cmd.Flags().String("tag", "", "Filter by tag")
+	cmd.Flags().String("collection", "", "Filter by collection")
+	cmd.Flags().String("status", "", "Filter by ingestion status (ready, failed, ...)")
+	cmd.Flags().String("legal-status", "", "Filter by legal status (in-force, repealed, ...)")
+	cmd.Flags().String("search", "", "Filter by text in the document ID or name")
+	cmd.Flags().String("in-force-on", "", "Only documents in force on this date (YYYY-MM-DD)")
+
+	return cmd
+}
+
+func libraryStatusCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show library statistics",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			libraryCatalog, err := catalog.Open(libraryPath)
+			if err != nil {
+				return err
+			}
+			summary := libraryCatalog.Summarize(nil)
+
+			if formatStr == "json" {
+				encoder := json.NewEncoder(app.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(summary)
+			}
+
+			fmt.Fprintf(app.Stdout, "Library: %s\n", libraryPath)
+			fmt.Fprintf(app.Stdout, "Base URI: %s\n", libraryCatalog.BaseURI)
+			fmt.Fprintf(app.Stdout, "Catalog built: %s\n\n", libraryCatalog.BuiltAt.Format(time.RFC3339))
+			fmt.Fprintf(app.Stdout, "Documents:    %d\n", summary.Documents)
+			fmt.Fprintf(app.Stdout, "Total triples: %d\n", summary.Triples)
+			fmt.Fprintf(app.Stdout, "Total articles: %d\n", summary.Articles)
+			fmt.Fprintf(app.Stdout, "Total definitions: %d\n", summary.Definitions)
+			fmt.Fprintf(app.Stdout, "Total references: %d\n", summary.References)
+			fmt.Fprintf(app.Stdout, "Total rights: %d\n", summary.Rights)
+			fmt.Fprintf(app.Stdout, "Total obligations: %d\n", summary.Obligations)
+
+			printCounts(app, "By Jurisdiction", summary.ByJurisdiction)
+			printCounts(app, "By Status", summary.ByStatus)
+			printCounts(app, "By Legal Status", summary.ByLegalStatus)
+
+			if summary.Validations > 0 || summary.LinkChecks > 0 || summary.OpenConflicts != nil {
+				fmt.Fprintln(app.Stdout, "\nHealth:")
+				fmt.Fprintf(app.Stdout, "  Validations:   %d recorded\n", summary.Validations)
+				fmt.Fprintf(app.Stdout, "  Link checks:   %d recorded\n", summary.LinkChecks)
+				if summary.OpenConflicts != nil {
+					fmt.Fprintf(app.Stdout, "  Open conflicts: %d\n", *summary.OpenConflicts)
+				}
+			}
+			if summary.Downloads > 0 {
+				fmt.Fprintf(app.Stdout, "\nDownloads: %d (%s)\n", summary.Downloads, bulk.FormatBytes(summary.DownloadBytes))
+				printCounts(app, "Downloads By Source", summary.DownloadsBySource)
+			}
+			if crawl := summary.Crawl; crawl != nil {
+				fmt.Fprintf(app.Stdout, "\nLast crawl: %s, %d visited, %d in frontier (updated %s)\n",
+					crawl.Status, crawl.Visited, crawl.Frontier, crawl.UpdatedAt.Format(time.RFC3339))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+// printCounts prints a titled block of counts sorted by key, or nothing
+// when there are none.
+func printCounts(app *App, title string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	fmt.Fprintf(app.Stdout, "\n%s:\n", title)
+	for _, key := range slices.Sorted(maps.Keys(counts)) {
+		fmt.Fprintf(app.Stdout, "  %-15s %d\n", key, counts[key])
+	}
+}
+
+func libraryQueryCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query [sparql-query]",
+		Short: "Query across library documents",
+		Long: `Execute a SPARQL query against one or more library documents.
+
+By default queries all documents. Use --documents to specify a subset.
+
+Documents that mint the same URIs (e.g., two acts without a formal
+identifier both producing Regulation:Art1) are silently merged into one
+node; a warning is printed when this happens. Use --namespace to keep
+each document's nodes under <base>/<document-id>/, and 'regula library
+conflicts' to inspect the collisions.
+
+--overlay queries the law as if a draft bill had passed: the proposed
+graphs saved by 'regula draft overlay' replace the documents it amends.
+
+Examples:
+  regula library query --template definitions
+  regula library query --template rights --documents eu-gdpr,us-ca-ccpa
+  regula library query --namespace --template rights --documents us-va-vcdpa,us-tx-tdpsa
+  regula library query --template jurisdiction-articles --param jurisdiction=US-CA
+  regula library query --overlay hr1234-overlay.json --template obligations
+  regula library query --min-quality 0.75 --template references
+  regula library query "SELECT ?article ?title WHERE { ?article rdf:type reg:Article . ?article reg:title ?title } LIMIT 10"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			templateName, _ := cmd.Flags().GetString("template")
+			formatStr, _ := cmd.Flags().GetString("format")
+			documentIDs, _ := cmd.Flags().GetStringSlice("documents")
+			showTiming, _ := cmd.Flags().GetBool("timing")
+			limit, _ := cmd.Flags().GetInt("limit")
+			namespaceDocuments, _ := cmd.Flags().GetBool("namespace")
+			paramPairs, _ := cmd.Flags().GetStringArray("param")
+			fullURI, _ := cmd.Flags().GetBool("full-uri")
+			overlayPath, _ := cmd.Flags().GetString("overlay")
+			minQuality, _ := cmd.Flags().GetFloat64("min-quality")
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+			customPrefixes, err := query.LoadPrefixes(filepath.Join(libraryPath, query.PrefixesFile))
+			if err != nil {
+				return err
+			}
+
+			paramValues, err := querytemplate.ParseValues(paramPairs)
+			if err != nil {
+				return err
+			}
+
+			// Determine query string
+			var queryStr string
+			if templateName != "" {
+				savedTemplates, err := loadSavedTemplates(filepath.Join(libraryPath, querytemplate.DirName))
+				if err != nil {
+					return err
+				}
+				tmpl := lookupTemplate(templateName, savedTemplates)
+				if tmpl == nil {
+					return fmt.Errorf("unknown template: %s\nUse 'regula query --list-templates' to see available templates", templateName)
+				}
+				queryStr, err = tmpl.Render(paramValues)
+				if err != nil {
+					return err
+				}
+				// The banner would corrupt machine-readable formats.
+				if !showTiming && query.OutputFormat(formatStr) == query.FormatTable {
+					fmt.Fprintf(app.Stdout, "Template: %s\n", templateName)
+					fmt.Fprintf(app.Stdout, "Description: %s\n\n", tmpl.Description)
+				}
+			} else if len(paramValues) > 0 {
+				return &usageError{err: fmt.Errorf("--param requires --template")}
+			} else if len(args) > 0 {
+				queryStr = args[0]
+			} else {
+				return &usageError{err: fmt.Errorf("provide a query or use --template")}
+			}
+
+			// Add LIMIT if specified and not already in query
+			if limit > 0 && !strings.Contains(strings.ToUpper(queryStr), "LIMIT") {
+				queryStr += fmt.Sprintf(" LIMIT %d", limit)
+			}
+
+			// Load triple stores
+			if len(documentIDs) == 0 {
+				documentIDs = lib.ReadyDocumentIDs()
+			}
+			mergeOptions := library.MergeOptions{NamespaceDocuments: namespaceDocuments}
+			if overlayPath != "" {
+				overlayFile, err := draft.LoadOverlayFile(overlayPath)
+				if err != nil {
+					return err
+				}
+				if mergeOptions.Replacements, err = overlayFile.DocumentStores(); err != nil {
+					return err
+				}
+			}
+			mergedStore, mergeReport, err := lib.MergeTripleStores(mergeOptions, documentIDs...)
+			if err != nil {
+				return fmt.Errorf("failed to load triple stores: %w", err)
+			}
+			if mergeReport.HasConflicts() {
+				fmt.Fprintf(app.Stderr, "Warning: %d URI collision(s) and %d conflicting value(s) between documents; "+
+					"use --namespace or see 'regula library conflicts'\n",
+					mergeReport.URICollisions, mergeReport.FunctionalConflicts)
+			}
+			if mergedStore, err = filterMinQuality(mergedStore, minQuality); err != nil {
+				return err
+			}
+
+			// Parse the SPARQL query
+			parsedQuery, parseErr := query.ParseQuery(queryStr)
+			if parseErr != nil {
+				return fmt.Errorf("query parse error: %w", parseErr)
+			}
+			outputPrefixes := query.DefaultPrefixes().With(customPrefixes).With(parsedQuery.Prefixes())
+			parsedQuery.ExpandPrefixes(query.ResourcePrefixes(mergedStore).With(customPrefixes))
+
+			queryExecutor := query.NewExecutor(mergedStore)
+
+			startTime := time.Now()
+			result, queryErr := queryExecutor.Execute(parsedQuery)
+			elapsed := time.Since(startTime)
+
+			if queryErr != nil {
+				return fmt.Errorf("query failed: %w", queryErr)
+			}
+
+			if showTiming {
+				fmt.Fprintf(app.Stdout, "Query executed in %v (%d results, %d triples searched)\n",
+					elapsed, result.Count, mergedStore.Count())
+			}
+			// Format output
+			outputFormat := query.OutputFormat(formatStr)
+			if !fullURI && !outputFormat.IsSPARQLResults() {
+				result = result.WithPrefixes(outputPrefixes)
+			}
+			output, fmtErr := result.Format(outputFormat)
+			if fmtErr != nil {
+				return fmt.Errorf("format error: %w", fmtErr)
+			}
+			fmt.Fprint(app.Stdout, output)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("template", "", "Use a built-in query template, or a saved one from the library's templates directory")
+	cmd.Flags().StringArray("param", nil, "Template parameter as name=value (repeatable)")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json, csv, srj, srx)")
+	cmd.Flags().StringSlice("documents", []string{}, "Document IDs to query (comma-separated, default: all)")
+	cmd.Flags().Bool("timing", false, "Show query execution time")
+	cmd.Flags().Int("limit", 0, "Limit number of results")
+	cmd.Flags().Bool("namespace", false, "Keep each document's URIs in a per-document namespace")
+	cmd.Flags().Bool("full-uri", false, "Display full URIs instead of compact form (custom prefixes are read from the library's prefixes.yaml)")
+	cmd.Flags().String("overlay", "", "Draft overlay file ('regula draft overlay') whose proposed graphs replace the documents it amends")
+	cmd.Flags().Float64("min-quality", 0, "Query only triples with at least this quality score (0.0-1.0; 0 keeps all)")
+
+	return cmd
+}
+
+func libraryCoverageCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "coverage",
+		Short: "Jurisdiction × topic coverage matrix across library documents",
+		Long: `Search the articles of every library document for one or more topics and
+render a matrix of jurisdictions against topics, with citations:
+
+  ✓ found      A provision uses the topic phrase, or has every topic
+               term in its title
+  ~ partial    A provision mentions every topic term, but not together
+  ✗ not found  No provision mentions every topic term
+
+Topic terms match inflected forms ("notification" matches "notify" and
+"notified"). Documents are grouped by the jurisdiction they were added with.
+
+Examples:
+  regula library coverage --topic "breach notification"
+  regula library coverage --topic "breach notification" --topic "data protection impact assessment"
+  regula library coverage --topic "right to erasure,data portability" --format markdown -o scoping.md
+  regula library coverage --topic consent --documents eu-gdpr,us-ca-ccpa --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			topics, _ := cmd.Flags().GetStringSlice("topic")
+			documentIDs, _ := cmd.Flags().GetStringSlice("documents")
+			formatStr, _ := cmd.Flags().GetString("format")
+			maxCitations, _ := cmd.Flags().GetInt("citations")
+			output, _ := cmd.Flags().GetString("output")
+
+			if len(topics) == 0 {
+				return &usageError{err: fmt.Errorf("--topic flag is required")}
+			}
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+
+			report, err := lib.Coverage(topics, library.CoverageOptions{
+				DocumentIDs:  documentIDs,
+				MaxCitations: maxCitations,
+			})
+			if err != nil {
+				return err
+			}
+
+			var rendered string
+			switch formatStr {
+			case "table":
+				rendered = library.FormatCoverageTable(report)
+			case "markdown", "md":
+				rendered = library.FormatCoverageMarkdown(report)
+			case "csv":
+				rendered = library.FormatCoverageCSV(report)
+			case "json":
+				rendered = library.FormatCoverageJSON(report) + "\n"
+			default:
+				return fmt.Errorf("unknown format: %s (use table, markdown, csv, or json)", formatStr)
+			}
+
+			if output != "" {
+				if err := os.WriteFile(output, []byte(rendered), 0644); err != nil {
+					return fmt.Errorf("failed to write file: %w", err)
+				}
+				fmt.Fprintf(app.messageWriter(), "Coverage matrix exported to: %s\n", output)
+				return nil
+			}
+			fmt.Fprint(app.Stdout, rendered)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringSlice("topic", []string{}, "Topic to search for (repeatable or comma-separated)")
+	cmd.Flags().StringSlice("documents", []string{}, "Document IDs to search (comma-separated, default: all)")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, markdown, csv, json)")
+	cmd.Flags().Int("citations", library.DefaultCoverageCitations, "Maximum citations listed per cell")
+	cmd.Flags().StringP("output", "o", "", "Write the matrix to a file")
+
+	return cmd
+}
+
+func libraryConflictsCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "conflicts",
+		Short: "Detect graph conflicts when merging library documents",
+		Long: `Merge library documents and report where their graphs conflict:
+
+  uri_collision     Two documents mint the same node URI, so their
+                    provisions would be merged into one node
+  functional_value  Documents assert different values for a single-valued
+                    predicate (reg:title, reg:text, reg:number, ...)
+
+Exits with an error when conflicts are found, so it can gate scripts.
+
+Examples:
+  regula library conflicts
+  regula library conflicts --documents us-va-vcdpa,us-tx-tdpsa
+  regula library conflicts --namespace     Confirm namespacing resolves them
+  regula library migrate-uris              Fix collisions permanently
+  regula library conflicts --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documentIDs, _ := cmd.Flags().GetStringSlice("documents")
+			formatStr, _ := cmd.Flags().GetString("format")
+			namespaceDocuments, _ := cmd.Flags().GetBool("namespace")
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+
+			if len(documentIDs) == 0 {
+				documentIDs = lib.ReadyDocumentIDs()
+			}
+
+			_, report, err := lib.MergeTripleStores(
+				library.MergeOptions{NamespaceDocuments: namespaceDocuments}, documentIDs...)
+			if err != nil {
+				return err
+			}
+
+			switch formatStr {
+			case "json":
+				fmt.Fprintln(app.Stdout, library.FormatMergeReportJSON(report))
+			default:
+				fmt.Fprint(app.Stdout, library.FormatMergeReportTable(report))
+			}
+
+			if report.HasConflicts() {
+				return fmt.Errorf("%d merge conflict(s) detected", len(report.Conflicts))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringSlice("documents", []string{}, "Document IDs to merge (comma-separated, default: all)")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+	cmd.Flags().Bool("namespace", false, "Merge with per-document URI namespacing")
+
+	return cmd
+}
+
+func libraryLinkDefinitionsCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "link-definitions",
+		Short: "Link term usages to definitions incorporated from other documents",
+		Long: `Find explicit incorporations of another library document's definitions,
+such as "personal data as defined in Article 4, point (1) of Regulation (EU)
+2016/679", and link the provisions and definitions using them to that
+definition with reg:usesExternalDefinition. Provisions using a local
+definition that incorporates a term of the same name are linked too.
+
+Cited documents are found by EU act number or popular name. Incorporations
+of documents not in the library are reported as unresolved. Rerun after
+adding documents; existing links are replaced.
+
+Examples:
+  regula library link-definitions --dry-run
+  regula library link-definitions
+  regula library query --template external-definitions`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+
+			report, err := lib.LinkExternalDefinitions(dryRun)
+			if err != nil {
+				return fmt.Errorf("failed to link definitions: %w", err)
+			}
+
+			switch formatStr {
+			case "json":
+				fmt.Fprintln(app.Stdout, library.FormatExternalDefinitionsJSON(report))
+			default:
+				fmt.Fprint(app.Stdout, library.FormatExternalDefinitionsTable(report))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().Bool("dry-run", false, "Report links without writing them")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+func libraryMigrateURIsCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-uris",
+		Short: "Re-namespace documents into per-document base URIs",
+		Long: `Rewrite documents built under the shared library base URI so that each
+document's nodes live under its own base URI, derived from its jurisdiction
+and ID:
+
+  https://regula.dev/regulations/GDPR:Art1
+    -> https://regula.dev/regulations/eu/eu-gdpr/GDPR:Art1
+
+Documents added since base URIs were scoped per document are skipped.
+
+Examples:
+  regula library migrate-uris --dry-run
+  regula library migrate-uris
+  regula library migrate-uris --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+
+			report, err := lib.MigrateDocumentBaseURIs(dryRun)
+			if err != nil {
+				return err
+			}
+
+			switch formatStr {
+			case "json":
+				fmt.Fprintln(app.Stdout, library.FormatBaseURIMigrationJSON(report))
+			default:
+				fmt.Fprint(app.Stdout, library.FormatBaseURIMigrationTable(report))
+			}
+
+			if report.Failed > 0 {
+				return fmt.Errorf("%d document(s) failed to migrate", report.Failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().Bool("dry-run", false, "Report what would change without writing")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+func libraryMigrateStorageCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-storage",
+		Short: "Convert stored document graphs to another storage format",
+		Long: `Rewrite every document's stored triples in the given format and use it for
+documents added from now on.
+
+Formats:
+  binary       Dictionary-encoded binary triples (triples.bin); the default
+               for new libraries, and the fastest to load
+  binary+gzip  Binary triples compressed with gzip; smallest on disk
+  json         A JSON array of triples (triples.json), as written by
+               libraries created before the binary format
+
+Documents already in the format are skipped. A document that fails to
+convert keeps its previous file and stays readable.
+
+Examples:
+  regula library migrate-storage --dry-run
+  regula library migrate-storage
+  regula library migrate-storage --to binary+gzip --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			formatStr, _ := cmd.Flags().GetString("format")
+			toStr, _ := cmd.Flags().GetString("to")
+
+			storageFormat, err := library.ParseStorageFormat(toStr)
+			if err != nil {
+				return err
+			}
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+
+			report, err := lib.MigrateStorageFormat(storageFormat, dryRun)
+			if err != nil {
+				return err
+			}
+
+			switch formatStr {
+			case "json":
+				fmt.Fprintln(app.Stdout, library.FormatStorageMigrationJSON(report))
+			default:
+				fmt.Fprint(app.Stdout, library.FormatStorageMigrationTable(report))
+			}
+
+			if report.Failed > 0 {
+				return fmt.Errorf("%d document(s) failed to migrate", report.Failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("to", string(library.DefaultStorageFormat), "Storage format to convert to (binary, binary+gzip, json)")
+	cmd.Flags().Bool("dry-run", false, "Report sizes without writing")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+func libraryRemoveCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <document-id>",
+		Short: "Remove a document from the library",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documentID := args[0]
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+
+			if err := lib.RemoveDocument(documentID); err != nil {
+				return fmt.Errorf("failed to remove document: %w", err)
+			}
+
+			fmt.Fprintf(app.messageWriter(), "Removed document: %s\n", documentID)
+			return app.writeJSONResult(map[string]string{"removed": documentID})
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+
+	return cmd
+}
+
+func librarySetCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Edit a document's metadata",
+		Long: `Edit the name, jurisdiction, tags, effective date, or legal status of a
+document already in the library. Only the flags given are changed.
+
+The metadata is added to the document node whenever the document is
+loaded, so it can be queried:
+
+  SELECT ?doc WHERE { ?doc reg:tag "privacy" . ?doc reg:legalStatus "in-force" }
+
+Predicates: reg:jurisdiction, reg:tag, reg:effectiveDate, reg:legalStatus.
+
+Examples:
+  regula library set --document eu-gdpr --tag privacy --effective-date 2018-05-25 --status in-force
+  regula library set --document eu-gdpr --remove-tag draft --jurisdiction EU
+  regula library set --document us-ca-ccpa --status ""`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documentID, _ := cmd.Flags().GetString("document")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			if documentID == "" {
+				return &usageError{err: fmt.Errorf("--document flag is required")}
+			}
+
+			var update library.MetadataUpdate
+			update.AddTags, _ = cmd.Flags().GetStringSlice("tag")
+			update.RemoveTags, _ = cmd.Flags().GetStringSlice("remove-tag")
+			for flag, field := range map[string]**string{
+				"name":           &update.Name,
+				"jurisdiction":   &update.Jurisdiction,
+				"effective-date": &update.EffectiveDate,
+			} {
+				if cmd.Flags().Changed(flag) {
+					value, _ := cmd.Flags().GetString(flag)
+					*field = &value
+				}
+			}
+			if cmd.Flags().Changed("status") {
+				value, _ := cmd.Flags().GetString("status")
+				status := library.LegalStatus(value)
+				update.LegalStatus = &status
+			}
+			if update.IsEmpty() {
+				return fmt.Errorf("nothing to change: give --tag, --remove-tag, --name, --jurisdiction, --effective-date, or --status")
+			}
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+			entry, err := lib.SetMetadata(documentID, update)
+			if err != nil {
+				return fmt.Errorf("failed to update metadata: %w", err)
+			}
+
+			if formatStr == "json" {
+				encoder := json.NewEncoder(app.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(entry)
+			}
+			fmt.Fprintf(app.Stdout, "Updated document: %s\n", entry.ID)
+			fmt.Fprintf(app.Stdout, "  Name:           %s\n", entry.Name)
+			fmt.Fprintf(app.Stdout, "  Jurisdiction:   %s\n", entry.Jurisdiction)
+			fmt.Fprintf(app.Stdout, "  Tags:           %s\n", strings.Join(entry.Tags, ", "))
+			fmt.Fprintf(app.Stdout, "  Effective date: %s\n", entry.EffectiveDate)
+			fmt.Fprintf(app.Stdout, "  Legal status:   %s\n", entry.LegalStatus)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("document", "", "Document identifier (required)")
+	cmd.Flags().StringSlice("tag", []string{}, "Tags to add")
+	cmd.Flags().StringSlice("remove-tag", []string{}, "Tags to remove")
+	cmd.Flags().String("name", "", "Human-readable name")
+	cmd.Flags().String("jurisdiction", "", "Jurisdiction code (e.g., EU, US-CA, GB)")
+	cmd.Flags().String("effective-date", "", "Date the document takes effect (YYYY-MM-DD; empty clears)")
+	cmd.Flags().String("status", "", "Legal status (in-force, not-yet-in-force, proposed, repealed, expired; empty clears)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+func libraryExportCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a document's RDF graph",
+		Long: `Export a document's serialized RDF graph in various formats.
+
+The nquads and trig formats put each document in its own named graph,
+described in the default graph by its identifier, title, and source, so
+federated graphs keep their document attribution when loaded into a quad
+store such as Fuseki or Blazegraph. With these formats, --document may be
+omitted to export every ready document.
+
+Examples:
+  regula library export --document eu-gdpr --format json
+  regula library export --document eu-gdpr --format summary
+  regula library export --format nquads --output library.nq
+  regula library export --document eu-gdpr,uk-dpa2018 --format trig`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documentID, _ := cmd.Flags().GetString("document")
+			formatStr, _ := cmd.Flags().GetString("format")
+			outputPath, _ := cmd.Flags().GetString("output")
+
+			quadFormat := formatStr == "nquads" || formatStr == "trig"
+			if documentID == "" && !quadFormat {
+				return &usageError{err: fmt.Errorf("--document flag is required")}
+			}
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+
+			if quadFormat {
+				var documentIDs []string
+				if documentID != "" {
+					for _, id := range strings.Split(documentID, ",") {
+						if id = strings.TrimSpace(id); id != "" {
+							documentIDs = append(documentIDs, id)
+						}
+					}
+				}
+				graphs, err := lib.LoadNamedGraphs(documentIDs...)
+				if err != nil {
+					return fmt.Errorf("failed to load documents: %w", err)
+				}
+
+				var output string
+				if formatStr == "nquads" {
+					output = store.SerializeNQuads(graphs)
+				} else {
+					output = store.NewTurtleSerializer().SerializeTriG(graphs)
+				}
+
+				if outputPath != "" {
+					if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+						return fmt.Errorf("failed to write output: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "Exported %d documents to %s\n", len(graphs), outputPath)
+				} else {
+					fmt.Fprint(app.Stdout, output)
+				}
+				return nil
+			}
+
+			tripleStore, err := lib.LoadTripleStore(documentID)
+			if err != nil {
+				return fmt.Errorf("failed to load document: %w", err)
+			}
+
+			var output string
+
+			switch formatStr {
+			case "json":
+				data, marshalErr := library.SerializeTripleStore(tripleStore)
+				if marshalErr != nil {
+					return fmt.Errorf("failed to serialize: %w", marshalErr)
+				}
+				output = string(data)
+			case "summary":
+				exportStats := tripleStore.Stats()
+				output = fmt.Sprintf("Document: %s\n", documentID)
+				output += fmt.Sprintf("Total triples: %d\n", exportStats.TotalTriples)
+				output += fmt.Sprintf("Unique subjects: %d\n", exportStats.UniqueSubjects)
+				output += fmt.Sprintf("Unique predicates: %d\n", exportStats.UniquePredicates)
+				output += fmt.Sprintf("Unique objects: %d\n", exportStats.UniqueObjects)
+				if len(exportStats.PredicateCounts) > 0 {
+					output += "\nPredicate Counts:\n"
+					for predicateKey, predicateCount := range exportStats.PredicateCounts {
+						output += fmt.Sprintf("  %-40s %d\n", predicateKey, predicateCount)
+					}
+				}
+			default:
+				// N-Triples format
+				allTriples := tripleStore.All()
+				var tripleLines []string
+				for _, triple := range allTriples {
+					tripleLines = append(tripleLines, triple.NTriples())
+				}
+				output = strings.Join(tripleLines, "\n")
+			}
+
+			if outputPath != "" {
+				if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+					return fmt.Errorf("failed to write output: %w", err)
+				}
+				fmt.Fprintf(app.messageWriter(), "Exported %s to %s\n", documentID, outputPath)
+			} else {
+				fmt.Fprint(app.Stdout, output)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("document", "", "Document ID to export (comma-separated for nquads and trig)")
+	cmd.Flags().StringP("format", "f", "ntriples", "Output format (json, summary, ntriples, nquads, trig)")
+	cmd.Flags().StringP("output", "o", "", "Output file path")
+
+	return cmd
+}
+
+func librarySourceCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "source <document-id>",
+		Short: "Display the original source text of a document",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documentID := args[0]
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+
+			sourceText, err := lib.LoadSourceText(documentID)
+			if err != nil {
+				return fmt.Errorf("failed to load source: %w", err)
+			}
+
+			fmt.Fprint(app.Stdout, string(sourceText))
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+
+	return cmd
+}
+
+func libraryNamesCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "names [reference]",
+		Short: "List or resolve popular names and short titles",
+		Long: `List the popular-name index built from document short names, full names,
+and short titles ("may be cited as ...") found during ingest, along with
+derived acronyms. With an argument, resolves it against the index.
+
+Examples:
+  regula library names
+  regula library names "COPPA §6502"
+  regula library conflicts --documents us-va-vcdpa,us-tx-tdpsa
+  regula library names --format json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+
+			index := lib.PopularNameIndex()
+
+			if len(args) == 1 {
+				match, ok := index.Resolve(args[0])
+				if !ok {
+					return fmt.Errorf("no unambiguous popular name found in %q", args[0])
+				}
+				if formatStr == "json" {
+					encoder := json.NewEncoder(app.Stdout)
+					encoder.SetIndent("", "  ")
+					return encoder.Encode(match)
+				}
+				fmt.Fprintf(app.Stdout, "Name:      %s\n", match.Name)
+				fmt.Fprintf(app.Stdout, "Document:  %s\n", match.DocumentID)
+				if match.Remainder != "" {
+					fmt.Fprintf(app.Stdout, "Provision: %s\n", match.Remainder)
+				}
+				return nil
+			}
+
+			entries := index.Entries()
+			if formatStr == "json" {
+				encoder := json.NewEncoder(app.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(entries)
+			}
+
+			if len(entries) == 0 {
+				fmt.Fprintln(app.Stdout, "No popular names indexed. Run 'regula library seed' to add documents.")
+				return nil
+			}
+
+			fmt.Fprintf(app.Stdout, "%-50s %-22s %-12s\n", "NAME", "DOCUMENT", "ORIGIN")
+			fmt.Fprintln(app.Stdout, strings.Repeat("-", 86))
+			for _, entry := range entries {
+				fmt.Fprintf(app.Stdout, "%-50s %-22s %-12s\n",
+					truncateString(entry.Name, 50),
+					truncateString(entry.DocumentID, 22),
+					entry.Origin,
+				)
+			}
+
+			fmt.Fprintf(app.Stdout, "\n%d name(s)\n", len(entries))
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+
+	return cmd
+}
@@ -0,0 +1,313 @@
+package cli
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/library"
+)
+
+func TestLibrarySetCmd(t *testing.T) {
+	libraryPath := filepath.Join(t.TempDir(), "lib")
+	if _, stderr, code := runCLI(t, "library", "init", "--path", libraryPath); code != 0 {
+		t.Fatalf("library init failed: %s", stderr)
+	}
+	for _, document := range []struct{ id, source string }{{"eu-gdpr", "gdpr.txt"}, {"us-ca-ccpa", "ccpa.txt"}} {
+		if _, stderr, code := runCLI(t, "library", "add", "--path", libraryPath, "--source", testdataPath(t, document.source),
+			"--id", document.id); code != 0 {
+			t.Fatalf("library add %s failed: %s", document.id, stderr)
+		}
+	}
+
+	stdout, stderr, code := runCLI(t, "library", "set", "--path", libraryPath, "--document", "eu-gdpr",
+		"--tag", "privacy", "--effective-date", "2018-05-25", "--status", "in-force")
+	if code != 0 {
+		t.Fatalf("library set failed: %s", stderr)
+	}
+	if !strings.Contains(stdout, "2018-05-25") || !strings.Contains(stdout, "in-force") {
+		t.Errorf("library set output:\n%s", stdout)
+	}
+
+	stdout, _, _ = runCLI(t, "library", "list", "--path", libraryPath, "--tag", "privacy")
+	if !strings.Contains(stdout, "eu-gdpr") || strings.Contains(stdout, "us-ca-ccpa") {
+		t.Errorf("library list --tag privacy:\n%s", stdout)
+	}
+
+	stdout, stderr, code = runCLI(t, "library", "query", "--path", libraryPath, "--format", "csv",
+		`SELECT ?date WHERE { ?doc reg:tag "privacy" . ?doc reg:legalStatus "in-force" . ?doc reg:effectiveDate ?date }`)
+	if code != 0 {
+		t.Fatalf("library query failed: %s", stderr)
+	}
+	if !strings.Contains(stdout, "2018-05-25") {
+		t.Errorf("metadata not queryable as triples:\n%s", stdout)
+	}
+
+	for name, args := range map[string][]string{
+		"no changes":  {"--document", "eu-gdpr"},
+		"bad status":  {"--document", "eu-gdpr", "--status", "valid"},
+		"bad date":    {"--document", "eu-gdpr", "--effective-date", "25/05/2018"},
+		"unknown id":  {"--document", "missing", "--tag", "privacy"},
+		"no document": {"--tag", "privacy"},
+	} {
+		if _, _, code := runCLI(t, append([]string{"library", "set", "--path", libraryPath}, args...)...); code == 0 {
+			t.Errorf("%s: library set succeeded", name)
+		}
+	}
+}
+
+func TestLibraryCollectionCmd(t *testing.T) {
+	libraryPath := filepath.Join(t.TempDir(), "lib")
+	if _, stderr, code := runCLI(t, "library", "init", "--path", libraryPath); code != 0 {
+		t.Fatalf("library init failed: %s", stderr)
+	}
+	for _, document := range []struct{ id, source, jurisdiction string }{
+		{"eu-gdpr", "gdpr.txt", "EU"}, {"us-ca-ccpa", "ccpa.txt", "US-CA"}, {"us-coppa", "us-coppa.txt", "US"},
+	} {
+		if _, stderr, code := runCLI(t, "library", "add", "--path", libraryPath, "--source", testdataPath(t, document.source),
+			"--id", document.id, "--jurisdiction", document.jurisdiction); code != 0 {
+			t.Fatalf("library add %s failed: %s", document.id, stderr)
+		}
+	}
+
+	if _, stderr, code := runCLI(t, "library", "collection", "create", "--path", libraryPath, "privacy-laws", "eu-gdpr",
+		"--description", "Comprehensive privacy laws"); code != 0 {
+		t.Fatalf("collection create failed: %s", stderr)
+	}
+	if _, stderr, code := runCLI(t, "library", "collection", "add", "--path", libraryPath, "privacy-laws", "us-ca-ccpa"); code != 0 {
+		t.Fatalf("collection add failed: %s", stderr)
+	}
+	stdout, _, _ := runCLI(t, "library", "collection", "list", "--path", libraryPath)
+	if !strings.Contains(stdout, "privacy-laws (2 document(s))") || !strings.Contains(stdout, "- us-ca-ccpa") {
+		t.Errorf("collection list:\n%s", stdout)
+	}
+	stdout, _, _ = runCLI(t, "library", "list", "--path", libraryPath, "--collection", "privacy-laws")
+	if !strings.Contains(stdout, "2 document(s)") || strings.Contains(stdout, "us-coppa") {
+		t.Errorf("library list --collection:\n%s", stdout)
+	}
+
+	// --collection scopes commands taking --documents, whether a string or
+	// a slice flag, and adds to documents given with --documents.
+	jurisdictionsQuery := `SELECT DISTINCT ?doc WHERE { ?doc reg:jurisdiction ?j }`
+	stdout, stderr, code := runCLI(t, "library", "query", "--path", libraryPath, "--format", "csv",
+		"--collection", "privacy-laws", jurisdictionsQuery)
+	if code != 0 {
+		t.Fatalf("library query --collection failed: %s", stderr)
+	}
+	if lines := strings.Count(strings.TrimSpace(stdout), "\n"); lines != 2 {
+		t.Errorf("library query --collection returned %d documents, want 2:\n%s", lines, stdout)
+	}
+	stdout, _, _ = runCLI(t, "library", "query", "--path", libraryPath, "--format", "csv",
+		"--collection", "privacy-laws", "--documents", "us-coppa", jurisdictionsQuery)
+	if lines := strings.Count(strings.TrimSpace(stdout), "\n"); lines != 3 {
+		t.Errorf("--collection with --documents returned %d documents, want 3:\n%s", lines, stdout)
+	}
+	stdout, stderr, code = runCLI(t, "compare", "obligations", "--path", libraryPath, "--collection", "privacy-laws", "--format", "json")
+	if code != 0 {
+		t.Fatalf("compare obligations --collection failed: %s", stderr)
+	}
+	if strings.Contains(stdout, "us-coppa") {
+		t.Errorf("compare obligations --collection included a document outside the collection")
+	}
+
+	stdout, stderr, code = runCLI(t, "match", "--scenario", "access_request", "--path", libraryPath,
+		"--collection", "privacy-laws", "--format", "json")
+	if code != 0 {
+		t.Fatalf("match --collection failed: %s", stderr)
+	}
+	if !strings.Contains(stdout, `"document": "eu-gdpr"`) || !strings.Contains(stdout, `"document": "us-ca-ccpa"`) ||
+		strings.Contains(stdout, "us-coppa") {
+		t.Errorf("match --collection did not match the collection's documents:\n%.500s", stdout)
+	}
+
+	if _, _, code := runCLI(t, "library", "query", "--path", libraryPath, "--collection", "missing", jurisdictionsQuery); code == 0 {
+		t.Error("library query with an unknown collection succeeded")
+	}
+	if _, stderr, code := runCLI(t, "library", "collection", "delete", "--path", libraryPath, "privacy-laws"); code != 0 {
+		t.Fatalf("collection delete failed: %s", stderr)
+	}
+	stdout, _, _ = runCLI(t, "library", "list", "--path", libraryPath)
+	if !strings.Contains(stdout, "3 document(s)") {
+		t.Errorf("deleting a collection changed the library:\n%s", stdout)
+	}
+}
+
+// memoryBucket is an S3-compatible service holding one bucket, "regula",
+// in memory.
+type memoryBucket struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (bucket *memoryBucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket.mu.Lock()
+	defer bucket.mu.Unlock()
+
+	key, found := strings.CutPrefix(r.URL.Path, "/regula/")
+	switch {
+	case !found:
+		w.WriteHeader(http.StatusNotFound)
+	case r.Method == http.MethodGet:
+		data, found := bucket.objects[key]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case r.Method == http.MethodPut:
+		bucket.objects[key], _ = io.ReadAll(r.Body)
+	case r.Method == http.MethodDelete:
+		delete(bucket.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestLibraryCmd_RemotePath(t *testing.T) {
+	bucket := &memoryBucket{objects: make(map[string][]byte)}
+	server := httptest.NewServer(bucket)
+	defer server.Close()
+	t.Setenv(library.S3EndpointEnv, server.URL)
+	t.Setenv(library.LibraryCacheEnv, t.TempDir())
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	sourcePath, err := filepath.Abs(testdataPath(t, "gdpr.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(t.TempDir())
+
+	const libraryPath = "s3://regula/team/library"
+	if _, stderr, code := runCLI(t, "library", "init", "--path", libraryPath); code != 0 {
+		t.Fatalf("library init failed: %s", stderr)
+	}
+	if _, stderr, code := runCLI(t, "library", "add", "--path", libraryPath, "--source", sourcePath,
+		"--id", "eu-gdpr", "--jurisdiction", "EU"); code != 0 {
+		t.Fatalf("library add failed: %s", stderr)
+	}
+	bucket.mu.Lock()
+	_, stored := bucket.objects["team/library/library.json"]
+	bucket.mu.Unlock()
+	if !stored {
+		t.Fatal("library manifest not stored in the bucket")
+	}
+
+	stdout, _, _ := runCLI(t, "library", "list", "--path", libraryPath)
+	if !strings.Contains(stdout, "eu-gdpr") {
+		t.Errorf("library list:\n%s", stdout)
+	}
+	stdout, stderr, code := runCLI(t, "playground", "query", "--path", libraryPath,
+		`SELECT ?title WHERE { ?a reg:title ?title . FILTER(CONTAINS(?title, "Right to erasure")) }`)
+	if code != 0 {
+		t.Fatalf("playground query failed: %s", stderr)
+	}
+	if !strings.Contains(stdout, "Right to erasure") {
+		t.Errorf("playground query on the remote library:\n%s", stdout)
+	}
+	if entries, _ := os.ReadDir("."); len(entries) != 0 {
+		t.Errorf("remote library commands wrote to the working directory: %v", entries)
+	}
+}
+
+func TestLibraryCatalogCmd(t *testing.T) {
+	libraryPath := filepath.Join(t.TempDir(), "lib")
+	if _, stderr, code := runCLI(t, "library", "init", "--path", libraryPath); code != 0 {
+		t.Fatalf("library init failed: %s", stderr)
+	}
+	for _, document := range []struct{ id, source, jurisdiction string }{
+		{"eu-gdpr", "gdpr.txt", "EU"}, {"us-ca-ccpa", "ccpa.txt", "US-CA"},
+	} {
+		if _, stderr, code := runCLI(t, "library", "add", "--path", libraryPath, "--source", testdataPath(t, document.source),
+			"--id", document.id, "--jurisdiction", document.jurisdiction); code != 0 {
+			t.Fatalf("library add %s failed: %s", document.id, stderr)
+		}
+	}
+	if _, stderr, code := runCLI(t, "library", "set", "--path", libraryPath, "--document", "eu-gdpr",
+		"--effective-date", "2018-05-25", "--status", "in-force"); code != 0 {
+		t.Fatalf("library set failed: %s", stderr)
+	}
+
+	// The catalog follows metadata changes made after it was built.
+	stdout, _, _ := runCLI(t, "library", "list", "--path", libraryPath, "--legal-status", "in-force")
+	if !strings.Contains(stdout, "eu-gdpr") || strings.Contains(stdout, "us-ca-ccpa") {
+		t.Errorf("library list --legal-status:\n%s", stdout)
+	}
+	stdout, _, _ = runCLI(t, "library", "list", "--path", libraryPath, "--in-force-on", "2017-01-01")
+	if strings.Contains(stdout, "eu-gdpr") || !strings.Contains(stdout, "us-ca-ccpa") {
+		t.Errorf("library list --in-force-on:\n%s", stdout)
+	}
+	if _, _, code := runCLI(t, "library", "list", "--path", libraryPath, "--in-force-on", "May 2018"); code == 0 {
+		t.Error("library list accepted an invalid --in-force-on date")
+	}
+
+	stdout, stderr, code := runCLI(t, "library", "status", "--path", libraryPath)
+	if code != 0 {
+		t.Fatalf("library status failed: %s", stderr)
+	}
+	if !strings.Contains(stdout, "Documents:    2") || !strings.Contains(stdout, "in-force") {
+		t.Errorf("library status:\n%s", stdout)
+	}
+
+	if _, err := os.Stat(filepath.Join(libraryPath, "catalog.json")); err != nil {
+		t.Fatalf("catalog not saved: %v", err)
+	}
+	stdout, stderr, code = runCLI(t, "library", "catalog", "--path", libraryPath, "--rebuild")
+	if code != 0 {
+		t.Fatalf("library catalog --rebuild failed: %s", stderr)
+	}
+	if !strings.Contains(stdout, "Documents:    2") {
+		t.Errorf("library catalog:\n%s", stdout)
+	}
+}
+
+func TestLibraryDedupeCmd(t *testing.T) {
+	libraryPath := filepath.Join(t.TempDir(), "lib")
+	if _, stderr, code := runCLI(t, "library", "init", "--path", libraryPath); code != 0 {
+		t.Fatalf("library init failed: %s", stderr)
+	}
+	for _, document := range []struct{ id, source string }{
+		{"us-ca-ccpa", "ccpa.txt"}, {"ca-civ-1798", "ccpa.txt"}, {"eu-gdpr", "gdpr.txt"},
+	} {
+		if _, stderr, code := runCLI(t, "library", "add", "--path", libraryPath, "--source", testdataPath(t, document.source),
+			"--id", document.id); code != 0 {
+			t.Fatalf("library add %s failed: %s", document.id, stderr)
+		}
+	}
+
+	stdout, stderr, code := runCLI(t, "library", "dedupe", "--path", libraryPath)
+	if code != 0 {
+		t.Fatalf("library dedupe failed: %s", stderr)
+	}
+	if !strings.Contains(stdout, "us-ca-ccpa") || !strings.Contains(stdout, "content") || !strings.Contains(stdout, "1 duplicate pair(s)") {
+		t.Errorf("library dedupe:\n%s", stdout)
+	}
+
+	if _, stderr, code := runCLI(t, "library", "dedupe", "--path", libraryPath, "--apply"); code != 0 {
+		t.Fatalf("library dedupe --apply failed: %s", stderr)
+	}
+	if _, stderr, code := runCLI(t, "library", "alias", "add", "--path", libraryPath, "gdpr", "eu-gdpr"); code != 0 {
+		t.Fatalf("library alias add failed: %s", stderr)
+	}
+	stdout, _, _ = runCLI(t, "library", "alias", "list", "--path", libraryPath)
+	if !strings.Contains(stdout, "ca-civ-1798 -> us-ca-ccpa (duplicate)") || !strings.Contains(stdout, "gdpr -> eu-gdpr") {
+		t.Errorf("library alias list:\n%s", stdout)
+	}
+
+	// Queries load an aliased law once, whichever IDs name it.
+	documentsQuery := `SELECT DISTINCT ?doc WHERE { ?doc reg:jurisdiction ?j }`
+	stdout, stderr, code = runCLI(t, "library", "query", "--path", libraryPath, "--format", "csv",
+		"--documents", "ca-civ-1798,us-ca-ccpa,gdpr", documentsQuery)
+	if code != 0 {
+		t.Fatalf("library query failed: %s", stderr)
+	}
+	if strings.Contains(stderr, "collision") {
+		t.Errorf("aliased documents merged as separate documents: %s", stderr)
+	}
+	if _, _, code := runCLI(t, "library", "alias", "add", "--path", libraryPath, "gdpr", "missing"); code == 0 {
+		t.Error("alias add accepted an unknown document")
+	}
+}
@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/coolbeans/regula/pkg/llm"
+	"github.com/spf13/cobra"
+)
+
+// addLLMFlags adds the flags that configure an OpenAI-compatible chat
+// completions endpoint.
+func addLLMFlags(cmd *cobra.Command) {
+	cmd.Flags().String("llm-endpoint", "", "OpenAI-compatible chat completions URL")
+	cmd.Flags().String("llm-model", "", "Model name sent to the LLM endpoint")
+	cmd.Flags().String("llm-api-key-env", llm.DefaultAPIKeyEnv, "Environment variable holding the LLM API key")
+}
+
+// llmClientFromFlags creates a client from the flags added by addLLMFlags,
+// or returns nil when no endpoint is set.
+func llmClientFromFlags(cmd *cobra.Command) (*llm.Client, error) {
+	endpoint, _ := cmd.Flags().GetString("llm-endpoint")
+	if endpoint == "" {
+		return nil, nil
+	}
+	model, _ := cmd.Flags().GetString("llm-model")
+	apiKeyEnv, _ := cmd.Flags().GetString("llm-api-key-env")
+	return llm.NewClient(llm.Config{
+		Endpoint: endpoint,
+		Model:    model,
+		APIKey:   os.Getenv(apiKeyEnv),
+	})
+}
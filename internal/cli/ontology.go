@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+func ontologyCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ontology",
+		Short: "Inspect, check, and export the reg: vocabulary",
+		Long: `Manage the regula vocabulary: the classes and predicates declared in
+the store schema.
+
+Subcommands:
+  dump      List classes and predicates, with usage counts for a graph
+  validate  Check that a graph only uses declared predicates and classes
+  export    Export the vocabulary as an OWL ontology (Turtle or RDF/XML)
+
+Examples:
+  regula ontology dump
+  regula ontology dump --source gdpr.txt --used
+  regula ontology validate --source gdpr.txt
+  regula ontology export --output regula.ttl`,
+	}
+
+	cmd.AddCommand(ontologyDumpCmd(app))
+	cmd.AddCommand(ontologyValidateCmd(app))
+	cmd.AddCommand(ontologyExportCmd(app))
+
+	return cmd
+}
+
+func ontologyDumpCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dump",
+		Short: "List vocabulary classes and predicates",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			kind, _ := cmd.Flags().GetString("kind")
+			prefix, _ := cmd.Flags().GetString("prefix")
+			usedOnly, _ := cmd.Flags().GetBool("used")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			switch kind {
+			case "", string(store.TermClass), string(store.TermProperty):
+			default:
+				return fmt.Errorf("invalid --kind %q (want class or property)", kind)
+			}
+			if usedOnly && source == "" {
+				return &usageError{err: fmt.Errorf("--used requires --source")}
+			}
+
+			vocabulary := store.DefaultVocabulary()
+			usageGraph := store.NewTripleStore()
+			if source != "" {
+				graph, err := loadAndIngest(cmd.Context(), source)
+				if err != nil {
+					return err
+				}
+				usageGraph = graph.store
+			}
+
+			var usage []store.TermUsageCount
+			for _, entry := range vocabulary.Usage(usageGraph) {
+				if kind != "" && entry.Kind != store.TermKind(kind) {
+					continue
+				}
+				if prefix != "" && !strings.HasPrefix(entry.Name, strings.TrimSuffix(prefix, ":")+":") {
+					continue
+				}
+				if usedOnly && entry.Count == 0 {
+					continue
+				}
+				usage = append(usage, entry)
+			}
+
+			switch formatStr {
+			case "json":
+				fmt.Fprintln(app.Stdout, store.FormatVocabularyJSON(usage))
+			default:
+				fmt.Fprint(app.Stdout, store.FormatVocabularyTable(usage, source != ""))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document to count term usage in")
+	cmd.Flags().String("kind", "", "Only list terms of this kind (class, property)")
+	cmd.Flags().String("prefix", "", "Only list terms with this prefix (e.g., reg, eli)")
+	cmd.Flags().Bool("used", false, "Only list terms used in the --source graph")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+func ontologyValidateCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check that a graph only uses declared predicates and classes",
+		Long: `Check every predicate and reg: class in a graph against the declared
+vocabulary. Predicates from well-known external vocabularies (rdf, rdfs, dc,
+eli, ...) are listed but do not fail validation.
+
+Exits with status 1 when undeclared terms are found.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			graph, err := loadAndIngest(cmd.Context(), source)
+			if err != nil {
+				return err
+			}
+			report := store.DefaultVocabulary().Validate(graph.store)
+
+			switch formatStr {
+			case "json":
+				fmt.Fprintln(app.Stdout, store.FormatVocabularyReportJSON(report))
+			default:
+				fmt.Fprint(app.Stdout, store.FormatVocabularyReportTable(report))
+			}
+
+			if !report.Conforms {
+				return exitWithCode(cmd, 1)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document to validate (required)")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+	cmd.MarkFlagRequired("source")
+
+	return cmd
+}
+
+func ontologyExportCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the vocabulary as an OWL ontology",
+		Long: `Export the reg: vocabulary as an OWL ontology so external RDF tooling
+understands regula graphs. Classes become owl:Class (right and obligation
+types are subclasses of reg:Right and reg:Obligation) and every term carries
+rdfs:label, rdfs:comment, and rdfs:isDefinedBy.
+
+Properties are typed rdf:Property unless --source is given, in which case
+predicates whose objects are all resources in that graph become
+owl:ObjectProperty and the rest owl:DatatypeProperty.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			formatStr, _ := cmd.Flags().GetString("format")
+			output, _ := cmd.Flags().GetString("output")
+
+			var usageGraph *store.TripleStore
+			if source != "" {
+				graph, err := loadAndIngest(cmd.Context(), source)
+				if err != nil {
+					return err
+				}
+				usageGraph = graph.store
+			}
+			ontology := store.DefaultVocabulary().Ontology(usageGraph)
+
+			var ontologyOutput string
+			switch formatStr {
+			case "turtle", "ttl":
+				ontologyOutput = store.FormatOntologyTurtle(ontology)
+			case "rdfxml", "xml":
+				ontologyOutput = store.NewRDFXMLSerializer(store.WithRDFXMLPrefix("owl", store.NamespaceOWL)).Serialize(ontology)
+			default:
+				return fmt.Errorf("unknown format: %s (supported: turtle, rdfxml)", formatStr)
+			}
+
+			if output == "" {
+				fmt.Fprint(app.Stdout, ontologyOutput)
+				return nil
+			}
+			if err := os.WriteFile(output, []byte(ontologyOutput), 0644); err != nil {
+				return fmt.Errorf("failed to write file: %w", err)
+			}
+			fmt.Fprintf(app.messageWriter(), "Ontology exported to: %s\n", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document used to type properties as object or datatype properties")
+	cmd.Flags().StringP("format", "f", "turtle", "Output format (turtle, rdfxml)")
+	cmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
+
+	return cmd
+}
@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Exit statuses shared by every command, so scripts can tell a failed check
+// from a mistyped command line. Statuses 2 to 63 are command-specific and
+// documented in the command's help: 'draft report' exits 1 for medium and
+// 2 for high risk.
+const (
+	// ExitOK means the command succeeded and any check it ran passed.
+	ExitOK = 0
+
+	// ExitFailure means the command failed, or a check it ran did not pass
+	// (a validation below its threshold, failing scenarios, failed run
+	// steps).
+	ExitFailure = 1
+
+	// ExitUsage means the command line was invalid: an unknown command or
+	// flag, a bad or missing flag value, conflicting flags, or the wrong
+	// number of arguments.
+	ExitUsage = 64
+)
+
+// usageError marks an invalid command line.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+
+func (e *usageError) Unwrap() error { return e.err }
+
+// markUsageErrors makes flag parsing and argument validation errors of
+// cmd and its subcommands usage errors.
+func markUsageErrors(cmd *cobra.Command) {
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &usageError{err: err}
+	})
+	if validateArgs := cmd.Args; validateArgs != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validateArgs(cmd, args); err != nil {
+				return &usageError{err: err}
+			}
+			return nil
+		}
+	}
+	for _, child := range cmd.Commands() {
+		markUsageErrors(child)
+	}
+}
+
+// exitCode returns the exit status for the error a command returned.
+func exitCode(err error) int {
+	var exitErr *ExitError
+	var usageErr *usageError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &exitErr):
+		return exitErr.Code
+	case errors.As(err, &usageErr),
+		// cobra reports these without going through a hook.
+		strings.HasPrefix(err.Error(), "unknown command"),
+		strings.HasPrefix(err.Error(), "required flag(s)"):
+		return ExitUsage
+	}
+	return ExitFailure
+}
+
+// jsonResult is the document written to stdout with --json.
+type jsonResult struct {
+	Command  string          `json:"command"`
+	OK       bool            `json:"ok"`
+	ExitCode int             `json:"exit_code"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	Output   string          `json:"output,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// jsonFormatUsage matches format flag descriptions offering json.
+var jsonFormatUsage = regexp.MustCompile(`\bjson\b`)
+
+// configureOutput applies --json, --output json, and --quiet. With --json
+// the command's standard output is captured for the result document, and a
+// format flag offering json is switched to it unless given. With --quiet it
+// is discarded.
+func (app *App) configureOutput(cmd *cobra.Command) error {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+
+	if output := cmd.Flags().Lookup("output"); output != nil {
+		switch value := output.Value.String(); {
+		case value == "json" && output.Changed:
+			jsonOutput = true
+			// A file path flag shadowing the global --output: json names
+			// the mode, not a file.
+			if !offersJSON(output) {
+				if err := output.Value.Set(output.DefValue); err != nil {
+					return err
+				}
+				output.Changed = false
+			}
+		case output == cmd.Root().PersistentFlags().Lookup("output") && value != "text":
+			return &usageError{err: fmt.Errorf("invalid --output %q (use text or json)", value)}
+		}
+	}
+
+	switch {
+	case jsonOutput && quiet:
+		return &usageError{err: fmt.Errorf("--json and --quiet are mutually exclusive")}
+	case jsonOutput:
+		for _, name := range []string{"format", "output", "export"} {
+			flag := cmd.LocalFlags().Lookup(name)
+			if flag == nil || flag.Changed || !offersJSON(flag) {
+				continue
+			}
+			if err := cmd.Flags().Set(name, "json"); err != nil {
+				return err
+			}
+		}
+		app.captureOutput()
+	case quiet:
+		app.stdout, app.Stdout = app.Stdout, io.Discard
+	}
+	return nil
+}
+
+// offersJSON reports whether flag selects an output format and json is
+// one of its values.
+func offersJSON(flag *pflag.Flag) bool {
+	if flag.Value.Type() != "string" {
+		return false
+	}
+	usage := strings.ToLower(flag.Usage)
+	return (flag.Name == "format" || strings.HasPrefix(usage, "output format")) && jsonFormatUsage.MatchString(usage)
+}
+
+// captureOutput starts capturing standard output for the --json result.
+func (app *App) captureOutput() {
+	if app.captured == nil {
+		app.captured = &bytes.Buffer{}
+		app.stdout, app.Stdout = app.Stdout, app.captured
+	}
+}
+
+// finishOutput restores standard output and, with --json, writes the
+// result document for the command that ran.
+func (app *App) finishOutput(commandPath string, err error, code int) {
+	if app.stdout == nil {
+		return
+	}
+	captured := app.captured
+	app.Stdout, app.stdout, app.captured = app.stdout, nil, nil
+	if captured == nil {
+		return
+	}
+
+	result := jsonResult{Command: commandPath, OK: code == ExitOK, ExitCode: code}
+	var exitErr *ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		result.Error = err.Error()
+	}
+	result.Data, result.Output = structuredOutput(captured.Bytes())
+
+	data, marshalErr := json.MarshalIndent(result, "", "  ")
+	if marshalErr != nil {
+		fmt.Fprintf(app.Stderr, "failed to marshal JSON result: %v\n", marshalErr)
+		return
+	}
+	fmt.Fprintln(app.Stdout, string(data))
+}
+
+// writeJSONResult writes v to standard output as the data of the --json
+// result document, for commands without a json format of their own whose
+// text output goes to messageWriter. Without --json it writes nothing.
+func (app *App) writeJSONResult(v any) error {
+	if app.captured == nil {
+		return nil
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize JSON: %w", err)
+	}
+	fmt.Fprintln(app.Stdout, string(data))
+	return nil
+}
+
+// structuredOutput returns a command's output as JSON data when it is a
+// JSON document or JSON lines (as an array), and as text otherwise.
+func structuredOutput(output []byte) (json.RawMessage, string) {
+	trimmed := bytes.TrimSpace(output)
+	if len(trimmed) == 0 {
+		return nil, ""
+	}
+	if json.Valid(trimmed) {
+		return json.RawMessage(trimmed), ""
+	}
+
+	var records [][]byte
+	for _, line := range bytes.Split(trimmed, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if !json.Valid(line) {
+			return nil, string(output)
+		}
+		records = append(records, line)
+	}
+	return json.RawMessage("[" + string(bytes.Join(records, []byte(","))) + "]"), ""
+}
+
+// jsonRequested reports whether args ask for --json or --output json, so
+// output is captured and a result written even for errors found before the
+// flags are parsed.
+func jsonRequested(args []string) bool {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		switch {
+		case arg == "--json", arg == "--json=true", arg == "--output=json":
+			return true
+		case arg == "--output" && i+1 < len(args) && args[i+1] == "json":
+			return true
+		}
+	}
+	return false
+}
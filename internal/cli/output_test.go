@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func decodeJSONResult(t *testing.T, stdout string) jsonResult {
+	t.Helper()
+	var result jsonResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("stdout is not a single JSON document: %v\n%s", err, stdout)
+	}
+	return result
+}
+
+func TestExecute_JSONOutput(t *testing.T) {
+	// cache stats defaults to a table; --json switches it to its JSON format.
+	stdout, _, code := runCLI(t, "--json", "cache", "stats", "--dir", t.TempDir())
+	result := decodeJSONResult(t, stdout)
+	if code != ExitOK || !result.OK || result.Command != "regula cache stats" {
+		t.Errorf("result = %+v (exit %d)", result, code)
+	}
+	var stats struct {
+		Entries *int `json:"entries"`
+	}
+	if err := json.Unmarshal(result.Data, &stats); err != nil || stats.Entries == nil {
+		t.Errorf("data = %s, want the cache stats", result.Data)
+	}
+
+	// Text-only output is kept as a string.
+	stdout, _, _ = runCLI(t, "--json", "--version")
+	if result := decodeJSONResult(t, stdout); !strings.Contains(result.Output, "regula version") {
+		t.Errorf("--version result = %+v", result)
+	}
+
+	// Errors are reported in the document and on stderr.
+	stdout, stderr, code := runCLI(t, "--json", "status", "--path", t.TempDir())
+	result = decodeJSONResult(t, stdout)
+	if code != ExitFailure || result.OK || !strings.Contains(result.Error, "library not found") || !strings.Contains(stderr, "library not found") {
+		t.Errorf("failed status = %+v (exit %d), stderr %q", result, code, stderr)
+	}
+
+	stdout, _, code = runCLI(t, "status", "--json", "--no-such-flag")
+	result = decodeJSONResult(t, stdout)
+	if code != ExitUsage || result.ExitCode != ExitUsage || !strings.Contains(result.Error, "unknown flag") {
+		t.Errorf("usage error = %+v (exit %d)", result, code)
+	}
+}
+
+func TestExecute_OutputJSON(t *testing.T) {
+	// --output json is --json, also where a file path --output shadows it.
+	stdout, _, code := runCLI(t, "--output", "json", "cache", "stats", "--dir", t.TempDir())
+	if result := decodeJSONResult(t, stdout); code != ExitOK || result.Data == nil {
+		t.Errorf("--output json = %+v (exit %d)", result, code)
+	}
+
+	stdout, _, code = runCLI(t, "refs", "--source", testdataPath(t, "gdpr.txt"), "--output=json")
+	result := decodeJSONResult(t, stdout)
+	var summary struct {
+		TotalRelationships int `json:"total_relationships"`
+	}
+	if err := json.Unmarshal(result.Data, &summary); err != nil || code != ExitOK || summary.TotalRelationships == 0 {
+		t.Errorf("refs --output=json = %+v (exit %d)", result, code)
+	}
+
+	if _, stderr, code := runCLI(t, "--output", "yaml", "cache", "stats"); code != ExitUsage {
+		t.Errorf("--output yaml = %d %q, want a usage error", code, stderr)
+	}
+}
+
+func TestExecute_JSONOutputHasNoText(t *testing.T) {
+	libraryPath := filepath.Join(t.TempDir(), "lib")
+	for _, args := range [][]string{
+		{"--json", "query", "--source", testdataPath(t, "gdpr.txt"), "--template", "definitions"},
+		{"--json", "library", "init", "--path", libraryPath},
+		{"--json", "bulk", "list", "parliamentary"},
+		{"--json", "status", "--path", libraryPath},
+	} {
+		stdout, stderr, code := runCLI(t, args...)
+		result := decodeJSONResult(t, stdout)
+		if code != ExitOK || result.Data == nil || result.Output != "" {
+			t.Errorf("%v = %+v (exit %d): %s", args[1:], result, code, stderr)
+		}
+	}
+}
+
+func TestExecute_QuietAndUsageErrors(t *testing.T) {
+	stdout, _, code := runCLI(t, "--quiet", "cache", "stats", "--dir", t.TempDir())
+	if code != ExitOK || stdout != "" {
+		t.Errorf("--quiet = %d %q, want no output", code, stdout)
+	}
+
+	for name, args := range map[string][]string{
+		"missing argument":  {"config", "get"},
+		"bad flag value":    {"run", "--dry-run=maybe"},
+		"json and quiet":    {"--json", "--quiet", "cache", "stats"},
+		"required flag":     {"ingest"},
+		"conflicting flags": {"status", "--badge-json", "--badge-svg"},
+	} {
+		if _, stderr, code := runCLI(t, args...); code != ExitUsage {
+			t.Errorf("%s: exit %d, want %d (%s)", name, code, ExitUsage, stderr)
+		}
+	}
+}
+
+func TestStructuredOutput(t *testing.T) {
+	data, text := structuredOutput([]byte("{\"id\":1}\n{\"id\":2}\n"))
+	if string(data) != `[{"id":1},{"id":2}]` || text != "" {
+		t.Errorf("JSON lines = %s %q, want an array", data, text)
+	}
+	data, text = structuredOutput([]byte("Articles: 99\n"))
+	if data != nil || text != "Articles: 99\n" {
+		t.Errorf("text = %s %q", data, text)
+	}
+}
+
+func TestProgressWriter(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	app := &App{Stdout: &stdout, Stderr: &stderr}
+	if app.progressWriter() != io.Writer(&stderr) {
+		t.Error("progress should go to stderr by default")
+	}
+
+	app.captureOutput()
+	if app.progressWriter() != nil {
+		t.Error("progress should be off under --json")
+	}
+}
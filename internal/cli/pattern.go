@@ -0,0 +1,261 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/pattern"
+	"github.com/spf13/cobra"
+)
+
+func patternCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pattern",
+		Short: "Develop and inspect format patterns",
+		Long:  `Tools for working on the YAML format patterns and reference pattern packs in the patterns/ directory.`,
+	}
+
+	cmd.AddCommand(patternDevCmd(app))
+	cmd.AddCommand(patternTestCmd(app))
+
+	return cmd
+}
+
+func patternDevCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Iterate on patterns against a cached parsed document",
+		Long: `Parse a source document once, then re-run only the extractors affected
+by pattern edits and print the matches gained and lost.
+
+Edits to detection or hierarchy patterns re-parse the cached source text;
+edits to definition or reference patterns re-run only that extractor
+against the cached Document. Matches come from the extractors ingest
+uses, with the format's reference patterns added as a reference pack, so
+a definition pattern edit that ingest would ignore shows no change.
+Pattern edits do not need a version bump.
+
+Examples:
+  regula pattern dev --source testdata/gdpr.txt
+  regula pattern dev --source testdata/gdpr.txt --watch
+  regula pattern dev --source testdata/ccpa.txt --patterns ./patterns --watch --interval 250ms`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			patternsDir, _ := cmd.Flags().GetString("patterns")
+			watchMode, _ := cmd.Flags().GetBool("watch")
+			pollInterval, _ := cmd.Flags().GetDuration("interval")
+
+			if source == "" {
+				return &usageError{err: fmt.Errorf("--source flag is required")}
+			}
+			if patternsDir == "" {
+				patternsDir = findPatternsDir()
+			}
+			if patternsDir == "" {
+				return fmt.Errorf("patterns directory not found; use --patterns")
+			}
+
+			sourceText, err := os.ReadFile(source)
+			if err != nil {
+				return fmt.Errorf("failed to read source: %w", err)
+			}
+
+			registry, err := pattern.NewRegistryWithDirectory(patternsDir)
+			if err != nil {
+				return fmt.Errorf("failed to load patterns: %w", err)
+			}
+
+			parseStart := time.Now()
+			session, err := extract.NewPatternDevSession(string(sourceText), registry)
+			if err != nil {
+				return err
+			}
+
+			formatID := session.FormatID()
+			if formatID == "" {
+				formatID = "(none — built-in patterns)"
+			}
+			fmt.Fprintf(app.Stdout, "Parsed %s in %v\n", source, time.Since(parseStart).Round(time.Millisecond))
+			fmt.Fprintf(app.Stdout, "  Active pattern: %s\n", formatID)
+			fmt.Fprintf(app.Stdout, "  Structure:      %d matches\n", len(session.Matches(extract.PatternExtractorStructure)))
+			fmt.Fprintf(app.Stdout, "  Definitions:    %d matches\n", len(session.Matches(extract.PatternExtractorDefinitions)))
+			fmt.Fprintf(app.Stdout, "  References:     %d matches\n", len(session.Matches(extract.PatternExtractorReferences)))
+
+			if !watchMode {
+				return nil
+			}
+
+			fmt.Fprintf(app.Stdout, "\nWatching %s for changes (Ctrl+C to stop)...\n", patternsDir)
+			ctx, stop := interruptContext(cmd.Context())
+			defer stop()
+
+			modTimes := scanPatternModTimes(patternsDir)
+			ticker := time.NewTicker(pollInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					fmt.Fprintln(app.Stdout, "\nStopped watching.")
+					return nil
+				case <-ticker.C:
+					current := scanPatternModTimes(patternsDir)
+					for path, modTime := range current {
+						if previous, ok := modTimes[path]; ok && !modTime.After(previous) {
+							continue
+						}
+						edited, parseErr := pattern.ParseFile(path)
+						if parseErr != nil {
+							fmt.Fprintf(app.Stdout, "\n[%s] %s: %v\n", time.Now().Format("15:04:05"), filepath.Base(path), parseErr)
+							continue
+						}
+						result, applyErr := session.Apply(edited)
+						if applyErr != nil {
+							fmt.Fprintf(app.Stdout, "\n[%s] %s: %v\n", time.Now().Format("15:04:05"), filepath.Base(path), applyErr)
+							continue
+						}
+						fmt.Fprintf(app.Stdout, "\n[%s] %s", time.Now().Format("15:04:05"), result.String())
+					}
+					modTimes = current
+				}
+			}
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document to parse once and cache")
+	cmd.Flags().String("patterns", "", "Pattern directory (default: auto-detect patterns/)")
+	cmd.Flags().Bool("watch", false, "Watch the pattern directory and re-run affected extractors on change")
+	cmd.Flags().Duration("interval", 500*time.Millisecond, "Polling interval for --watch")
+
+	return cmd
+}
+
+func patternTestCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Score reference patterns against annotated fixtures",
+		Long: `Run the reference patterns, with any reference packs, against annotated
+fixture files and report the precision and recall of each pattern.
+
+A fixture file lists snippets with the references and definitions expected
+in each. A reference may name the pattern that should find it; the others
+are scored against the pattern that found them:
+
+  name: "EU references"
+  format: "eu"
+  fixtures:
+    - name: "qualified point"
+      text: "Processing under point (a) of Article 6(1) is lawful."
+      references:
+        - text: "point (a) of Article 6(1)"
+          pattern: "point"
+        - "Article 6(1)"
+
+With --baseline, the report is compared with one saved earlier with
+--output, and the command fails if any pattern's precision or recall fell.
+
+Examples:
+  regula pattern test --fixtures testdata/pattern-fixtures
+  regula pattern test --fixtures testdata/pattern-fixtures --packs ./reference-packs --baseline baseline.json
+  regula pattern test --fixtures testdata/pattern-fixtures --output baseline.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fixturesPath, _ := cmd.Flags().GetString("fixtures")
+			packsPath, _ := cmd.Flags().GetString("packs")
+			baselinePath, _ := cmd.Flags().GetString("baseline")
+			outputPath, _ := cmd.Flags().GetString("output")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			if fixturesPath == "" {
+				return &usageError{err: fmt.Errorf("--fixtures flag is required")}
+			}
+			if formatStr != "table" && formatStr != "json" {
+				return fmt.Errorf("unknown format: %s (use table or json)", formatStr)
+			}
+
+			fixtures, err := extract.LoadPatternFixtures(fixturesPath)
+			if err != nil {
+				return err
+			}
+			refExtractor, err := loadReferenceExtractor(packsPath)
+			if err != nil {
+				return fmt.Errorf("failed to load reference patterns: %w", err)
+			}
+
+			report := refExtractor.TestPatterns(fixtures)
+			if baselinePath != "" {
+				baseline, err := extract.LoadPatternTestReport(baselinePath)
+				if err != nil {
+					return err
+				}
+				report.CompareBaseline(baseline)
+			}
+
+			if formatStr == "json" {
+				data, err := report.ToJSON()
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+			} else {
+				fmt.Fprint(app.Stdout, report.String())
+			}
+
+			if outputPath != "" {
+				data, err := report.ToJSON()
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(outputPath, data, 0644); err != nil {
+					return fmt.Errorf("failed to write report: %w", err)
+				}
+			}
+
+			if len(report.Regressions) > 0 {
+				return fmt.Errorf("%d pattern regressions against %s", len(report.Regressions), baselinePath)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("fixtures", "", "Fixture file or directory of fixture files")
+	cmd.Flags().String("packs", "", "Reference pattern pack file or directory to test (default: built-in patterns)")
+	cmd.Flags().String("baseline", "", "Earlier JSON report; fail if any pattern's precision or recall fell")
+	cmd.Flags().StringP("output", "o", "", "Write the report as JSON, for use as a later --baseline")
+	cmd.Flags().StringP("format", "f", "table", "Output format: table or json")
+
+	return cmd
+}
+
+// findPatternsDir returns the first pattern directory found relative to the
+// working directory, or an empty string when none exists.
+func findPatternsDir() string {
+	for _, dir := range []string{"patterns", "../../patterns", "../patterns"} {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	return ""
+}
+
+// scanPatternModTimes returns the modification time of every YAML file in dir.
+func scanPatternModTimes(dir string) map[string]time.Time {
+	modTimes := make(map[string]time.Time)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return modTimes
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || (!strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml")) {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			modTimes[filepath.Join(dir, name)] = info.ModTime()
+		}
+	}
+	return modTimes
+}
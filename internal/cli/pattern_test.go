@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPatternTestCmd(t *testing.T) {
+	fixtures := testdataPath(t, "pattern-fixtures")
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+
+	stdout, stderr, code := runCLI(t, "pattern", "test", "--fixtures", fixtures, "--output", baselinePath)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	for _, want := range []string{"Pattern Test", "articleParen", "total"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("output missing %q:\n%s", want, stdout)
+		}
+	}
+
+	// A pack narrowing a pattern regresses its recall
+	packPath := filepath.Join(t.TempDir(), "narrow.yaml")
+	pack := "name: Narrow\npack_id: narrow\nversion: \"1.0.0\"\npatterns:\n  - name: articles\n    pattern: 'Articles\\s+(\\d+)\\s+(?:and)\\s+(\\d+)'\n"
+	if err := os.WriteFile(packPath, []byte(pack), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stdout, stderr, code = runCLI(t, "pattern", "test", "--fixtures", fixtures, "--packs", packPath, "--baseline", baselinePath)
+	if code != 1 || !strings.Contains(stderr, "pattern regressions") {
+		t.Errorf("narrowed pack = %d %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "articles recall: 100.0% -> 50.0%") {
+		t.Errorf("output missing the articles regression:\n%s", stdout)
+	}
+}
@@ -0,0 +1,472 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/playground"
+	"github.com/coolbeans/regula/pkg/query"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/usage"
+	"github.com/spf13/cobra"
+)
+
+func playgroundCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "playground",
+		Short: "USC triple store analysis playground",
+		Long: `Analysis playground with pre-built query templates for exploring
+ingested legislation data in the library.
+
+Provides 10+ analysis query templates runnable via CLI, plus custom
+SPARQL query support with CSV/JSON export and timing/pagination.
+
+Commands:
+  list     List available analysis query templates
+  run      Run a template by name
+  query    Run a custom SPARQL query
+
+Examples:
+  regula playground list
+  regula playground run top-chapters-by-sections
+  regula playground run cross-ref-density --title 42
+  regula playground run definition-coverage --export json
+  regula playground run rights-enumeration --limit 50 --offset 10
+  regula playground query "SELECT ?s ?p ?o WHERE { ?s ?p ?o } LIMIT 10"
+  regula playground serve --addr localhost:8080`,
+	}
+
+	cmd.AddCommand(playgroundListCmd(app))
+	cmd.AddCommand(playgroundRunCmd(app))
+	cmd.AddCommand(playgroundQueryCmd(app))
+	cmd.AddCommand(playgroundServeCmd(app))
+
+	return cmd
+}
+
+func playgroundListCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List available analysis query templates",
+		Long: `List all pre-built analysis query templates with their categories
+and supported parameters.
+
+Examples:
+  regula playground list
+  regula playground list --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatStr, _ := cmd.Flags().GetString("format")
+			if formatStr == "json" {
+				data, err := json.MarshalIndent(playground.TemplateInfos(), "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to serialize JSON: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+				return nil
+			}
+
+			templateNames := playground.TemplateNames()
+
+			fmt.Fprintln(app.Stdout, "Available playground analysis templates:")
+			fmt.Fprintln(app.Stdout)
+
+			for _, templateName := range templateNames {
+				template, _ := playground.Get(templateName)
+				fmt.Fprintf(app.Stdout, "  %-28s [%-15s] %s\n", templateName, template.Category, template.Description)
+				for _, parameter := range template.Parameters {
+					requiredLabel := "optional"
+					if parameter.Required {
+						requiredLabel = "required"
+					}
+					fmt.Fprintf(app.Stdout, "    --%s (%s): %s\n", parameter.Name, requiredLabel, parameter.Description)
+				}
+			}
+
+			fmt.Fprintln(app.Stdout)
+			fmt.Fprintln(app.Stdout, "Usage:")
+			fmt.Fprintln(app.Stdout, "  regula playground run <template-name>")
+			fmt.Fprintln(app.Stdout, "  regula playground run <template-name> --title 42")
+			fmt.Fprintln(app.Stdout, "  regula playground run <template-name> --export csv")
+			return nil
+		},
+	}
+
+	cmd.Flags().String("format", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+func playgroundRunCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run <template-name>",
+		Short: "Run a playground analysis template",
+		Long: `Run a pre-built analysis query template against the library.
+
+Templates are parameterizable (e.g., --title 42) and support export
+to table, JSON, or CSV formats.
+
+Examples:
+  regula playground run top-chapters-by-sections
+  regula playground run cross-ref-density --title 42
+  regula playground run definition-coverage --export json
+  regula playground run rights-enumeration --limit 50 --offset 10
+  regula playground run chapter-structure --title 42 --export csv > structure.csv`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			templateName := args[0]
+			titleFilter, _ := cmd.Flags().GetString("title")
+			exportFormat, _ := cmd.Flags().GetString("export")
+			limitValue, _ := cmd.Flags().GetInt("limit")
+			offsetValue, _ := cmd.Flags().GetInt("offset")
+			showTiming, _ := cmd.Flags().GetBool("timing")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documentIDs, _ := cmd.Flags().GetStringSlice("documents")
+
+			// Look up template
+			template, exists := playground.Get(templateName)
+			if !exists {
+				return fmt.Errorf("unknown template: %s\nUse 'regula playground list' to see available templates", templateName)
+			}
+
+			// Build parameter map
+			parameterValues := make(map[string]string)
+			if titleFilter != "" {
+				parameterValues["title"] = titleFilter
+			}
+
+			// Render query with parameters
+			renderedQuery, renderErr := playground.RenderQuery(template, parameterValues)
+			if renderErr != nil {
+				return fmt.Errorf("template parameter error: %w", renderErr)
+			}
+
+			// Append LIMIT/OFFSET if not already in query
+			if limitValue > 0 && !strings.Contains(strings.ToUpper(renderedQuery), "LIMIT") {
+				renderedQuery += fmt.Sprintf(" LIMIT %d", limitValue)
+			}
+			if offsetValue > 0 && !strings.Contains(strings.ToUpper(renderedQuery), "OFFSET") {
+				renderedQuery += fmt.Sprintf(" OFFSET %d", offsetValue)
+			}
+
+			// Open library
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+
+			// Load triple stores
+			var mergedStore *store.TripleStore
+			if len(documentIDs) > 0 {
+				mergedStore, err = lib.LoadMergedTripleStore(documentIDs...)
+			} else {
+				mergedStore, err = lib.LoadAllTripleStores()
+			}
+			if err != nil {
+				return fmt.Errorf("failed to load triple stores: %w", err)
+			}
+
+			fmt.Fprintf(app.Stderr, "Template: %s\n", template.Name)
+			fmt.Fprintf(app.Stderr, "Description: %s\n", template.Description)
+			if titleFilter != "" {
+				fmt.Fprintf(app.Stderr, "Title filter: %s\n", titleFilter)
+			}
+			fmt.Fprintln(app.Stderr)
+
+			// Execute query
+			return app.executePlaygroundQuery(mergedStore, renderedQuery, exportFormat, showTiming)
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringSlice("documents", []string{}, "Document IDs to query (comma-separated, default: all)")
+	cmd.Flags().String("title", "", "Title number filter for templates that support it")
+	cmd.Flags().String("export", "table", "Output format (table, json, csv, srj, srx)")
+	cmd.Flags().Int("limit", 0, "Limit number of results")
+	cmd.Flags().Int("offset", 0, "Skip first N results")
+	cmd.Flags().Bool("timing", false, "Show query execution time")
+
+	return cmd
+}
+
+func playgroundQueryCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query [sparql-query]",
+		Short: "Run a custom SPARQL query against the library",
+		Long: `Run an arbitrary SPARQL query against all ingested library documents.
+
+Supports SELECT, CONSTRUCT, and DESCRIBE queries.
+
+Examples:
+  regula playground query "SELECT ?article ?title WHERE { ?article rdf:type reg:Article . ?article reg:title ?title } LIMIT 10"
+  regula playground query --export json "SELECT ?s ?p ?o WHERE { ?s ?p ?o } LIMIT 5"
+  regula playground query --export csv "SELECT ?term WHERE { ?term rdf:type reg:DefinedTerm }" > terms.csv`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			queryStr := args[0]
+			exportFormat, _ := cmd.Flags().GetString("export")
+			limitValue, _ := cmd.Flags().GetInt("limit")
+			offsetValue, _ := cmd.Flags().GetInt("offset")
+			showTiming, _ := cmd.Flags().GetBool("timing")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documentIDs, _ := cmd.Flags().GetStringSlice("documents")
+
+			// Append LIMIT/OFFSET if not already in query
+			if limitValue > 0 && !strings.Contains(strings.ToUpper(queryStr), "LIMIT") {
+				queryStr += fmt.Sprintf(" LIMIT %d", limitValue)
+			}
+			if offsetValue > 0 && !strings.Contains(strings.ToUpper(queryStr), "OFFSET") {
+				queryStr += fmt.Sprintf(" OFFSET %d", offsetValue)
+			}
+
+			// Open library
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+
+			// Load triple stores
+			var mergedStore *store.TripleStore
+			if len(documentIDs) > 0 {
+				mergedStore, err = lib.LoadMergedTripleStore(documentIDs...)
+			} else {
+				mergedStore, err = lib.LoadAllTripleStores()
+			}
+			if err != nil {
+				return fmt.Errorf("failed to load triple stores: %w", err)
+			}
+
+			return app.executePlaygroundQuery(mergedStore, queryStr, exportFormat, showTiming)
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringSlice("documents", []string{}, "Document IDs to query (comma-separated, default: all)")
+	cmd.Flags().String("export", "table", "Output format (table, json, csv, srj, srx)")
+	cmd.Flags().Int("limit", 0, "Limit number of results")
+	cmd.Flags().Int("offset", 0, "Skip first N results")
+	cmd.Flags().Bool("timing", false, "Show query execution time")
+
+	return cmd
+}
+
+func playgroundServeCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the playground as a local web UI",
+		Long: `Start a local web server with the playground templates, a SPARQL
+editor with a results table, and a force-directed view of the relationship
+graph around a focus provision.
+
+The graph is a source document ingested on start, or the library documents.
+Click a URI in the results to show it in the graph; double-click a node to
+focus on it. Clicking an article also lists its related provisions, as
+suggested by 'regula related'.
+
+Requests are counted per provision and template in usage.json in the
+library (see 'regula usage hotspots'); use --no-usage to turn this off.
+
+Examples:
+  regula playground serve
+  regula playground serve --documents us-usc-title-42 --addr localhost:9000
+  regula playground serve --source testdata/gdpr.txt`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, _ := cmd.Flags().GetString("addr")
+			source, _ := cmd.Flags().GetString("source")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documentIDs, _ := cmd.Flags().GetStringSlice("documents")
+			usagePath, _ := cmd.Flags().GetString("usage-file")
+			noUsage, _ := cmd.Flags().GetBool("no-usage")
+
+			var servedStore *store.TripleStore
+			var label string
+			if source != "" {
+				graph, err := loadAndIngest(cmd.Context(), source)
+				if err != nil {
+					return err
+				}
+				servedStore = graph.store
+				label = filepath.Base(source)
+			} else {
+				lib, err := library.Open(libraryPath)
+				if err != nil {
+					return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+				}
+				if len(documentIDs) > 0 {
+					servedStore, err = lib.LoadMergedTripleStore(documentIDs...)
+					label = strings.Join(documentIDs, ", ")
+				} else {
+					servedStore, err = lib.LoadAllTripleStores()
+					label = "library " + libraryPath
+				}
+				if err != nil {
+					return fmt.Errorf("failed to load triple stores: %w", err)
+				}
+			}
+
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", addr, err)
+			}
+			fmt.Fprintf(app.Stderr, "Serving %s (%d triples) at http://%s\n", label, servedStore.Count(), listener.Addr())
+			fmt.Fprintln(app.Stderr, "Press Ctrl+C to stop.")
+
+			playgroundServer := playground.NewServer(servedStore, label)
+			// Usage of a library in object storage is only recorded to an
+			// explicit --usage-file.
+			if usagePath == "" && source == "" && !noUsage && !library.IsRemotePath(libraryPath) {
+				usagePath = filepath.Join(libraryPath, usage.FileName)
+			}
+			var tracker *usage.Tracker
+			if usagePath != "" && !noUsage {
+				tracker, err = usage.Open(usagePath)
+				if err != nil {
+					return err
+				}
+				playgroundServer.SetUsageTracker(tracker)
+				fmt.Fprintf(app.Stderr, "Recording provision usage to %s\n", usagePath)
+			}
+
+			server := &http.Server{
+				Handler:           playgroundServer,
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			return serveUntilInterrupted(cmd.Context(), server, listener, tracker)
+		},
+	}
+
+	cmd.Flags().String("addr", "localhost:8080", "Address to listen on")
+	cmd.Flags().StringP("source", "s", "", "Source document to ingest and serve (default: the library)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().StringSlice("documents", []string{}, "Document IDs to serve (comma-separated, default: all)")
+	cmd.Flags().String("usage-file", "", "Record provision and template usage here (default: <path>/usage.json when serving the library)")
+	cmd.Flags().Bool("no-usage", false, "Do not record provision usage")
+
+	return cmd
+}
+
+// serveUntilInterrupted serves HTTP on listener until Ctrl+C or until ctx
+// is done, then shuts the server down and flushes the usage tracker, if
+// any. Usage is also flushed periodically while serving.
+func serveUntilInterrupted(ctx context.Context, server *http.Server, listener net.Listener, tracker *usage.Tracker) error {
+	ctx, stop := interruptContext(ctx)
+	defer stop()
+
+	flushDone := make(chan error, 1)
+	if tracker != nil {
+		go func() { flushDone <- tracker.Run(ctx, 30*time.Second) }()
+	} else {
+		flushDone <- nil
+	}
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- server.Serve(listener) }()
+
+	select {
+	case err := <-serveErr:
+		stop()
+		<-flushDone
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+	if err := <-flushDone; err != nil {
+		return fmt.Errorf("failed to save usage: %w", err)
+	}
+	return nil
+}
+
+// executePlaygroundQuery parses, executes, and formats a SPARQL query against the given store.
+func (app *App) executePlaygroundQuery(tripleStore *store.TripleStore, queryStr string, exportFormat string, showTiming bool) error {
+	parsedQuery, parseErr := query.ParseQuery(queryStr)
+	if parseErr != nil {
+		return fmt.Errorf("query parse error: %w", parseErr)
+	}
+
+	queryExecutor := query.NewExecutor(tripleStore)
+	startTime := time.Now()
+
+	// Route by query type
+	switch parsedQuery.Type {
+	case query.ConstructQueryType:
+		result, err := queryExecutor.ExecuteConstruct(parsedQuery)
+		elapsed := time.Since(startTime)
+		if err != nil {
+			return fmt.Errorf("CONSTRUCT query error: %w", err)
+		}
+
+		outputFormat := query.OutputFormat(exportFormat)
+		if outputFormat == query.FormatTable || outputFormat == query.FormatCSV {
+			outputFormat = query.FormatTurtle
+		}
+		output, fmtErr := result.Format(outputFormat)
+		if fmtErr != nil {
+			return fmt.Errorf("format error: %w", fmtErr)
+		}
+		fmt.Fprint(app.Stdout, output)
+		fmt.Fprintf(app.Stderr, "\n%d triples returned", result.Count)
+		if showTiming {
+			fmt.Fprintf(app.Stderr, " (%v)", elapsed)
+		}
+		fmt.Fprintln(app.Stderr)
+
+	case query.DescribeQueryType:
+		result, err := queryExecutor.ExecuteDescribe(parsedQuery)
+		elapsed := time.Since(startTime)
+		if err != nil {
+			return fmt.Errorf("DESCRIBE query error: %w", err)
+		}
+
+		outputFormat := query.OutputFormat(exportFormat)
+		if outputFormat == query.FormatTable || outputFormat == query.FormatCSV {
+			outputFormat = query.FormatTurtle
+		}
+		output, fmtErr := result.Format(outputFormat)
+		if fmtErr != nil {
+			return fmt.Errorf("format error: %w", fmtErr)
+		}
+		fmt.Fprint(app.Stdout, output)
+		fmt.Fprintf(app.Stderr, "\n%d triples returned", result.Count)
+		if showTiming {
+			fmt.Fprintf(app.Stderr, " (%v)", elapsed)
+		}
+		fmt.Fprintln(app.Stderr)
+
+	default:
+		// SELECT query (default)
+		result, err := queryExecutor.Execute(parsedQuery)
+		elapsed := time.Since(startTime)
+		if err != nil {
+			return fmt.Errorf("query error: %w", err)
+		}
+
+		outputFormat := query.OutputFormat(exportFormat)
+		output, fmtErr := result.Format(outputFormat)
+		if fmtErr != nil {
+			return fmt.Errorf("format error: %w", fmtErr)
+		}
+		fmt.Fprint(app.Stdout, output)
+		fmt.Fprintf(app.Stderr, "\n%d rows returned", result.Count)
+		if showTiming {
+			fmt.Fprintf(app.Stderr, " (%v)", elapsed)
+			fmt.Fprintf(app.Stderr, "\n  Parse:   %v", result.Metrics.ParseTime)
+			fmt.Fprintf(app.Stderr, "\n  Plan:    %v", result.Metrics.PlanTime)
+			fmt.Fprintf(app.Stderr, "\n  Execute: %v", result.Metrics.ExecuteTime)
+			fmt.Fprintf(app.Stderr, "\n  Triples: %d searched", tripleStore.Count())
+		}
+		fmt.Fprintln(app.Stderr)
+	}
+
+	return nil
+}
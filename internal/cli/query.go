@@ -0,0 +1,843 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/coolbeans/regula/pkg/store"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/query"
+	"github.com/coolbeans/regula/pkg/querytemplate"
+	"github.com/coolbeans/regula/pkg/repl"
+	"github.com/spf13/cobra"
+)
+
+func queryCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query [sparql-query]",
+		Short: "Query the regulation graph",
+		Long: `Execute a SPARQL query against the regulation knowledge graph.
+
+You must first ingest a regulation document using 'regula ingest'.
+
+Supports SELECT, CONSTRUCT, and DESCRIBE queries.
+
+Examples:
+  # Basic SELECT query
+  regula query "SELECT ?article ?title WHERE { ?article rdf:type reg:Article . ?article reg:title ?title } LIMIT 5"
+
+  # DESCRIBE query with direct URI
+  regula query "DESCRIBE GDPR:Art17"
+
+  # DESCRIBE query with variable
+  regula query "DESCRIBE ?article WHERE { ?article reg:title \"Right to erasure\" }"
+
+  # DESCRIBE an article with its rights, obligations, and referenced terms inlined
+  regula query "DESCRIBE GDPR:Art17 DEPTH 2"
+  regula query --depth 2 "DESCRIBE GDPR:Art17"
+
+  # CONSTRUCT query to extract subgraph
+  regula query "CONSTRUCT { ?a reg:hasTitle ?t } WHERE { ?a rdf:type reg:Article . ?a reg:title ?t }"
+
+  # CONSTRUCT with Turtle output
+  regula query --format turtle "CONSTRUCT { ?a rdf:type reg:Article } WHERE { ?a rdf:type reg:Article }"
+
+  # CONSTRUCT with N-Triples output
+  regula query --format ntriples "CONSTRUCT { ?a rdf:type reg:Article } WHERE { ?a rdf:type reg:Article }"
+
+  # Use a template
+  regula query --template definitions
+
+  # Use a template with typed parameters
+  regula query --template article-refs --param article=6
+  regula query --template term-articles --param term="Processing"
+
+  # Use a saved template from .regula/templates/my-team/obligations-by-actor.yaml
+  regula query --source gdpr.txt --template my-team/obligations-by-actor --param actor=processor
+
+  # JSON output
+  regula query --format json "SELECT ?term WHERE { ?term rdf:type reg:DefinedTerm }"
+
+  # W3C SPARQL Query Results JSON (srj) or XML (srx), for SPARQL client libraries
+  regula query --format srj "SELECT ?term WHERE { ?term rdf:type reg:DefinedTerm }"
+
+  # Excel workbook
+  regula query --format xlsx -o terms.xlsx "SELECT ?term WHERE { ?term rdf:type reg:DefinedTerm }"
+
+  # With timing
+  regula query --timing "SELECT ?a WHERE { ?a rdf:type reg:Article }"
+
+  # Show the pattern order, index used, and bindings and time per join step
+  regula query --explain --template rights
+
+  # High-precision view: only references resolved with confidence >= 0.75
+  regula query --source gdpr.txt --min-quality 0.75 --template references
+
+  # Only provisions in force on a date
+  regula query --source gdpr.txt --as-of 2020-01-01 --template articles
+
+Available templates:
+  articles     - List all articles with titles
+  definitions  - List all defined terms
+  chapters     - List all chapters
+  references   - List cross-references
+  rights       - Find provisions granting rights
+
+Templates take parameters with --param name=value, and --list-templates
+shows each template's parameters. Typed parameters are validated and
+normalized: an article ("17", "Art. 17", "Article 17") becomes Art17, a
+term is lowercased, and a jurisdiction ("US-CA") takes its URI form
+(us-ca).
+
+Provisions are dated from their document's entry-into-force, application,
+and sunset clauses ("It shall apply from 25 May 2018", "Article 8 shall
+apply from ..."), from validity and expiry triples, and from dated
+repeals, and inherit the period of the chapter or document containing
+them. The periods are recorded as reg:inForceFrom and reg:inForceUntil
+(the first day no longer in force) on documents, chapters, sections, and
+articles. --as-of YYYY-MM-DD drops the provisions not in force on that
+date, with every triple that mentions them.
+
+Saved templates are YAML files below .regula/templates (see
+--templates-dir), named by their path without the extension. Commit them
+to share named queries with a team:
+
+  description: Obligations borne by an actor
+  parameters:
+    - name: actor
+      description: Duty bearer, such as controller or processor
+      type: string
+      default: controller
+  query: |
+    SELECT ?article ?obligation WHERE {
+      ?obligation reg:dutyBearer ?bearer .
+      ?obligation reg:partOf ?article .
+      FILTER(REGEX(?bearer, "(?i)^{{actor}}$"))
+    }`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			templateName, _ := cmd.Flags().GetString("template")
+			formatStr, _ := cmd.Flags().GetString("format")
+			showTiming, _ := cmd.Flags().GetBool("timing")
+			source, _ := cmd.Flags().GetString("source")
+			listTemplates, _ := cmd.Flags().GetBool("list-templates")
+			fullURI, _ := cmd.Flags().GetBool("full-uri")
+			minQuality, _ := cmd.Flags().GetFloat64("min-quality")
+			depth, _ := cmd.Flags().GetInt("depth")
+			explain, _ := cmd.Flags().GetBool("explain")
+			templatesDir, _ := cmd.Flags().GetString("templates-dir")
+			paramPairs, _ := cmd.Flags().GetStringArray("param")
+			prefixesPath, _ := cmd.Flags().GetString("prefixes")
+			outputPath, _ := cmd.Flags().GetString("output")
+			asOf, _ := cmd.Flags().GetString("as-of")
+
+			savedTemplates, err := loadSavedTemplates(templatesDir)
+			if err != nil {
+				return err
+			}
+
+			// List templates
+			if listTemplates {
+				printTemplates(app.Stdout, savedTemplates)
+				return nil
+			}
+
+			paramValues, err := querytemplate.ParseValues(paramPairs)
+			if err != nil {
+				return err
+			}
+
+			// Get the query
+			var queryStr string
+			if templateName != "" {
+				tmpl := lookupTemplate(templateName, savedTemplates)
+				if tmpl == nil {
+					return fmt.Errorf("unknown template: %s\nUse --list-templates to see available templates", templateName)
+				}
+				queryStr, err = tmpl.Render(paramValues)
+				if err != nil {
+					return err
+				}
+				// The banner would corrupt machine-readable formats.
+				if !showTiming && query.OutputFormat(formatStr) == query.FormatTable {
+					fmt.Fprintf(app.Stdout, "Template: %s\n", templateName)
+					fmt.Fprintf(app.Stdout, "Description: %s\n\n", tmpl.Description)
+				}
+			} else if len(paramValues) > 0 {
+				return &usageError{err: fmt.Errorf("--param requires --template")}
+			} else if len(args) > 0 {
+				queryStr = args[0]
+			} else {
+				return &usageError{err: fmt.Errorf("provide a query or use --template\nUse --list-templates to see available templates")}
+			}
+
+			// Commands keep no graph between runs, so the query needs a source
+			if source == "" {
+				return fmt.Errorf("no graph loaded. Run 'regula ingest --source <file>' first, or use --source flag")
+			}
+			graph, err := loadAndIngest(cmd.Context(), source)
+			if err != nil {
+				return err
+			}
+			graph, err = graph.withMinQuality(minQuality)
+			if err != nil {
+				return err
+			}
+			graph, err = graph.asOf(asOf)
+			if err != nil {
+				return err
+			}
+			customPrefixes, err := query.LoadPrefixes(prefixesPath)
+			if err != nil {
+				return err
+			}
+
+			// Parse query to determine type
+			parsedQuery, err := query.ParseQuery(queryStr)
+			if err != nil {
+				return fmt.Errorf("query parse error: %w", err)
+			}
+
+			// Results are compacted with the query's own PREFIX declarations
+			// too; compact names like GDPR:Art17 are expanded before matching
+			outputPrefixes := query.DefaultPrefixes().With(customPrefixes).With(parsedQuery.Prefixes())
+			parsedQuery.ExpandPrefixes(query.ResourcePrefixes(graph.store).With(customPrefixes))
+
+			executor := graph.executor
+			if explain {
+				executor = query.NewExecutor(graph.store, query.WithExplain(true))
+			}
+
+			startTime := time.Now()
+
+			// Handle CONSTRUCT queries
+			if parsedQuery.Type == query.ConstructQueryType {
+				return app.executeConstructQuery(executor, parsedQuery, formatStr, showTiming, startTime)
+			}
+
+			// Handle DESCRIBE queries
+			if parsedQuery.Type == query.DescribeQueryType {
+				if cmd.Flags().Changed("depth") {
+					if depth < 1 {
+						return &usageError{err: fmt.Errorf("--depth must be at least 1")}
+					}
+					parsedQuery.Describe.Depth = depth
+				}
+				return app.executeDescribeQuery(executor, parsedQuery, formatStr, showTiming, startTime)
+			}
+
+			// Execute SELECT query
+			result, err := executor.Execute(parsedQuery)
+			queryTime := time.Since(startTime)
+
+			if err != nil {
+				return fmt.Errorf("query error: %w", err)
+			}
+
+			// Apply compact URIs by default unless --full-uri is specified;
+			// the SPARQL results formats always carry full URIs
+			format := query.OutputFormat(formatStr)
+			if !fullURI && !format.IsSPARQLResults() {
+				result = result.WithPrefixes(outputPrefixes)
+			}
+
+			// Format output
+			if format == query.FormatNDJSON {
+				if err := result.WriteNDJSON(app.Stdout); err != nil {
+					return fmt.Errorf("format error: %w", err)
+				}
+			} else if format == query.FormatXLSX {
+				data, err := result.ToXLSX()
+				if err != nil {
+					return fmt.Errorf("format error: %w", err)
+				}
+				if err := app.writeWorkbook(data, outputPath, "Results"); err != nil {
+					return err
+				}
+			} else {
+				output, err := result.Format(format)
+				if err != nil {
+					return fmt.Errorf("format error: %w", err)
+				}
+				fmt.Fprint(app.Stdout, output)
+			}
+
+			// Show timing if requested
+			if showTiming {
+				timingOut := app.statusWriter(formatStr)
+				fmt.Fprintf(timingOut, "\nQuery executed in %v\n", queryTime)
+				fmt.Fprintf(timingOut, "  Parse:   %v\n", result.Metrics.ParseTime)
+				fmt.Fprintf(timingOut, "  Plan:    %v\n", result.Metrics.PlanTime)
+				fmt.Fprintf(timingOut, "  Execute: %v\n", result.Metrics.ExecuteTime)
+			}
+			app.printQueryPlan(formatStr, result.Plan, explain)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("template", "t", "", "Use a pre-built or saved query template")
+	cmd.Flags().StringArray("param", nil, "Saved template parameter as name=value (repeatable)")
+	cmd.Flags().String("templates-dir", filepath.Join(defaultLibraryPath(), querytemplate.DirName), "Directory of saved query templates")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json, csv, ndjson, srj, srx, xlsx for SELECT; turtle, ntriples, json, ndjson for CONSTRUCT/DESCRIBE)")
+	cmd.Flags().StringP("output", "o", "", "Write xlsx results to a file instead of stdout")
+	cmd.Flags().Bool("timing", false, "Show query execution timing")
+	cmd.Flags().StringP("source", "s", "", "Source document to ingest before querying")
+	cmd.Flags().Bool("list-templates", false, "List available query templates")
+	cmd.Flags().Bool("full-uri", false, "Display full URIs instead of compact form (e.g., https://regula.dev/regulations/GDPR:Art17 instead of GDPR:Art17)")
+	cmd.Flags().String("prefixes", filepath.Join(defaultLibraryPath(), query.PrefixesFile), "YAML file of custom prefixes (name: namespace) for compact URIs")
+	cmd.Flags().Float64("min-quality", 0, "Query only triples with at least this quality score (0.0-1.0; 0 keeps all)")
+	cmd.Flags().String("as-of", "", "Query only provisions in force on this date (YYYY-MM-DD)")
+	cmd.Flags().Bool("explain", false, "Show the query plan: pattern order, index used, estimated and actual matches, and bindings and time per join step")
+	cmd.Flags().Int("depth", 1, "DESCRIBE depth: follow outgoing edges and include the triples of nodes reached (overrides DEPTH in the query)")
+
+	return cmd
+}
+
+func replCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "repl",
+		Short: "Interactive SPARQL shell",
+		Long: `Start an interactive shell for exploring a regulation graph.
+
+The shell queries a source document ingested on start, or the documents in
+the library. Queries may span several lines and run once their braces are
+balanced or they end with ';'. Tab completes SPARQL keywords, reg:
+predicates, and the URIs in the graph; Up/Down recall history, which is
+kept in ~/.regula_history between sessions.
+Custom prefixes for compact URIs are read from the library's
+prefixes.yaml.
+
+Meta-commands:
+  \templates          List query templates
+  \template <name>    Run a query template
+  \describe <uri> [n] Describe a resource to depth n (e.g. \describe GDPR:Art17 2)
+  \format <name>      Switch output format (table, json, csv, srj, srx, turtle, ntriples)
+  \timing             Toggle query timing
+  \help, \quit
+
+Examples:
+  regula repl --source gdpr.txt
+  regula repl --documents eu-gdpr,us-ca-ccpa
+  regula repl --source gdpr.txt --format json < queries.sparql`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documentIDs, _ := cmd.Flags().GetStringSlice("documents")
+			namespaceDocuments, _ := cmd.Flags().GetBool("namespace")
+			formatStr, _ := cmd.Flags().GetString("format")
+			showTiming, _ := cmd.Flags().GetBool("timing")
+			fullURI, _ := cmd.Flags().GetBool("full-uri")
+			historyPath, _ := cmd.Flags().GetString("history")
+
+			customPrefixes, err := query.LoadPrefixes(filepath.Join(libraryPath, query.PrefixesFile))
+			if err != nil {
+				return err
+			}
+
+			var tripleStore *store.TripleStore
+			var label string
+			if source != "" {
+				graph, err := loadAndIngest(cmd.Context(), source)
+				if err != nil {
+					return err
+				}
+				tripleStore = graph.store
+				label = filepath.Base(source)
+			} else {
+				lib, err := library.Open(libraryPath)
+				if err != nil {
+					return fmt.Errorf("no graph to query: use --source, or create a library at %s: %w", libraryPath, err)
+				}
+				if len(documentIDs) == 0 {
+					documentIDs = lib.ReadyDocumentIDs()
+				}
+				if len(documentIDs) == 0 {
+					return fmt.Errorf("library at %s has no ready documents", libraryPath)
+				}
+				mergedStore, mergeReport, err := lib.MergeTripleStores(
+					library.MergeOptions{NamespaceDocuments: namespaceDocuments}, documentIDs...)
+				if err != nil {
+					return fmt.Errorf("failed to load triple stores: %w", err)
+				}
+				if mergeReport.HasConflicts() {
+					fmt.Fprintf(app.Stderr, "Warning: %d URI collision(s) and %d conflicting value(s) between documents; "+
+						"use --namespace or see 'regula library conflicts'\n",
+						mergeReport.URICollisions, mergeReport.FunctionalConflicts)
+				}
+				tripleStore = mergedStore
+				label = fmt.Sprintf("library %s (%s)", libraryPath, strings.Join(documentIDs, ", "))
+			}
+
+			history := repl.NewHistory(repl.DefaultHistorySize)
+			if historyPath != "" {
+				loaded, err := repl.LoadHistory(historyPath, repl.DefaultHistorySize)
+				if err != nil {
+					fmt.Fprintf(app.Stderr, "Warning: %v\n", err)
+				} else {
+					history = loaded
+				}
+			}
+
+			templateNames := make([]string, 0, len(queryTemplates))
+			for name := range queryTemplates {
+				templateNames = append(templateNames, name)
+			}
+			sort.Strings(templateNames)
+			templates := make([]repl.Template, 0, len(templateNames))
+			for _, name := range templateNames {
+				// The shell runs templates with their default parameters
+				tmpl := queryTemplates[name]
+				queryStr, err := tmpl.template().Render(nil)
+				if err != nil {
+					continue
+				}
+				templates = append(templates, repl.Template{Name: tmpl.Name, Description: tmpl.Description, Query: queryStr})
+			}
+
+			session := repl.NewSession(repl.Config{
+				Store:     tripleStore,
+				Label:     label,
+				Templates: templates,
+				Format:    query.OutputFormat(formatStr),
+				Prefixes:  customPrefixes,
+				FullURIs:  fullURI,
+				Timing:    showTiming,
+				History:   history,
+			})
+			return session.Run(app.Stdin, app.Stdout)
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document to ingest and query")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path (used without --source)")
+	cmd.Flags().StringSlice("documents", []string{}, "Library documents to load (default: all)")
+	cmd.Flags().Bool("namespace", false, "Keep each library document's nodes under <base>/<document-id>/")
+	cmd.Flags().StringP("format", "f", "table", "Initial output format (table, json, csv, srj, srx, turtle, ntriples)")
+	cmd.Flags().Bool("timing", false, "Show query execution timing")
+	cmd.Flags().Bool("full-uri", false, "Display full URIs instead of compact form")
+	cmd.Flags().String("history", defaultReplHistoryPath(), "History file (empty to keep history in memory only)")
+
+	return cmd
+}
+
+// defaultReplHistoryPath returns ~/.regula_history, or "" when the home
+// directory is unknown.
+func defaultReplHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".regula_history")
+}
+
+// executeConstructQuery handles execution and output of CONSTRUCT queries.
+func (app *App) executeConstructQuery(executor *query.Executor, parsedQuery *query.Query, formatStr string, showTiming bool, startTime time.Time) error {
+	result, err := executor.ExecuteConstruct(parsedQuery)
+	queryTime := time.Since(startTime)
+
+	if err != nil {
+		return fmt.Errorf("CONSTRUCT query error: %w", err)
+	}
+
+	// Default format for CONSTRUCT is turtle
+	if formatStr == "table" || formatStr == "csv" {
+		formatStr = "turtle"
+	}
+
+	format := query.OutputFormat(formatStr)
+	if format == query.FormatNDJSON {
+		if err := result.WriteNDJSON(app.Stdout); err != nil {
+			return fmt.Errorf("format error: %w", err)
+		}
+	} else {
+		output, err := result.Format(format)
+		if err != nil {
+			return fmt.Errorf("format error: %w", err)
+		}
+		fmt.Fprint(app.Stdout, output)
+	}
+
+	// Show timing if requested
+	if showTiming {
+		timingOut := app.statusWriter(formatStr)
+		fmt.Fprintf(timingOut, "\nCONSTRUCT query executed in %v\n", queryTime)
+		fmt.Fprintf(timingOut, "  Parse:   %v\n", result.Metrics.ParseTime)
+		fmt.Fprintf(timingOut, "  Execute: %v\n", result.Metrics.ExecuteTime)
+		fmt.Fprintf(timingOut, "  Triples: %d\n", result.Count)
+	}
+	app.printQueryPlan(formatStr, result.Plan, executor.Explains())
+
+	return nil
+}
+
+// executeDescribeQuery handles execution and output of DESCRIBE queries.
+func (app *App) executeDescribeQuery(executor *query.Executor, parsedQuery *query.Query, formatStr string, showTiming bool, startTime time.Time) error {
+	result, err := executor.ExecuteDescribe(parsedQuery)
+	queryTime := time.Since(startTime)
+
+	if err != nil {
+		return fmt.Errorf("DESCRIBE query error: %w", err)
+	}
+
+	// Default format for DESCRIBE is turtle
+	if formatStr == "table" || formatStr == "csv" {
+		formatStr = "turtle"
+	}
+
+	format := query.OutputFormat(formatStr)
+	if format == query.FormatNDJSON {
+		if err := result.WriteNDJSON(app.Stdout); err != nil {
+			return fmt.Errorf("format error: %w", err)
+		}
+	} else {
+		output, err := result.Format(format)
+		if err != nil {
+			return fmt.Errorf("format error: %w", err)
+		}
+		fmt.Fprint(app.Stdout, output)
+	}
+
+	// Show timing if requested
+	if showTiming {
+		timingOut := app.statusWriter(formatStr)
+		fmt.Fprintf(timingOut, "\nDESCRIBE query executed in %v\n", queryTime)
+		fmt.Fprintf(timingOut, "  Parse:   %v\n", result.Metrics.ParseTime)
+		fmt.Fprintf(timingOut, "  Execute: %v\n", result.Metrics.ExecuteTime)
+		fmt.Fprintf(timingOut, "  Triples: %d\n", result.Count)
+	}
+	app.printQueryPlan(formatStr, result.Plan, executor.Explains())
+
+	return nil
+}
+
+// printQueryPlan writes a query's plan after its results when --explain is
+// set. A DESCRIBE of named resources has no WHERE clause to plan.
+func (app *App) printQueryPlan(formatStr string, plan *query.QueryPlan, explain bool) {
+	if !explain {
+		return
+	}
+	out := app.statusWriter(formatStr)
+	if plan == nil {
+		fmt.Fprintln(out, "\nQuery plan: no WHERE clause to evaluate")
+		return
+	}
+	fmt.Fprintf(out, "\n%s", plan.Format())
+}
+
+// QueryTemplate represents a pre-built query template. The query refers
+// to its parameters as {{name}}.
+type QueryTemplate struct {
+	Name        string
+	Description string
+	Query       string
+	Parameters  []querytemplate.Parameter
+}
+
+// template returns the built-in template in the form saved templates take.
+func (tmpl QueryTemplate) template() *querytemplate.Template {
+	return &querytemplate.Template{
+		Name:        tmpl.Name,
+		Description: tmpl.Description,
+		Parameters:  tmpl.Parameters,
+		Query:       tmpl.Query,
+	}
+}
+
+var queryTemplates = map[string]QueryTemplate{
+	"articles": {
+		Name:        "articles",
+		Description: "List all articles with titles",
+		Query: `SELECT ?article ?title WHERE {
+  ?article rdf:type reg:Article .
+  ?article reg:title ?title .
+} ORDER BY ?article`,
+	},
+	"definitions": {
+		Name:        "definitions",
+		Description: "List all defined terms with their full definitions and abbreviations",
+		Query: `SELECT ?termText ?abbreviation ?definition WHERE {
+  ?term rdf:type reg:DefinedTerm .
+  ?term reg:term ?termText .
+  ?term reg:definition ?definition .
+  OPTIONAL { ?abbr reg:abbreviates ?term . ?abbr reg:shortForm ?abbreviation . }
+} ORDER BY ?termText`,
+	},
+	"chapters": {
+		Name:        "chapters",
+		Description: "List all chapters with titles",
+		Query: `SELECT ?chapter ?title WHERE {
+  ?chapter rdf:type reg:Chapter .
+  ?chapter reg:title ?title .
+} ORDER BY ?chapter`,
+	},
+	"references": {
+		Name:        "references",
+		Description: "List all cross-references between articles",
+		Query: `SELECT ?from ?to WHERE {
+  ?from reg:references ?to .
+} ORDER BY ?from LIMIT 50`,
+	},
+	"rights": {
+		Name:        "rights",
+		Description: "Find articles that grant rights",
+		Query: `SELECT ?article ?title ?right ?rightType WHERE {
+  ?article rdf:type reg:Article .
+  ?article reg:title ?title .
+  ?article reg:grantsRight ?right .
+  ?right reg:rightType ?rightType .
+} ORDER BY ?article`,
+	},
+	"obligations": {
+		Name:        "obligations",
+		Description: "Find articles that impose obligations",
+		Query: `SELECT ?article ?title ?oblig ?obligType WHERE {
+  ?article rdf:type reg:Article .
+  ?article reg:title ?title .
+  ?article reg:imposesObligation ?oblig .
+  ?oblig reg:obligationType ?obligType .
+} ORDER BY ?article`,
+	},
+	"right-types": {
+		Name:        "right-types",
+		Description: "List distinct right types found",
+		Query: `SELECT DISTINCT ?rightType WHERE {
+  ?right rdf:type reg:Right .
+  ?right reg:rightType ?rightType .
+}`,
+	},
+	"obligation-types": {
+		Name:        "obligation-types",
+		Description: "List distinct obligation types found",
+		Query: `SELECT DISTINCT ?obligType WHERE {
+  ?oblig rdf:type reg:Obligation .
+  ?oblig reg:obligationType ?obligType .
+}`,
+	},
+	"recitals": {
+		Name:        "recitals",
+		Description: "List all recitals",
+		Query: `SELECT ?recital ?num WHERE {
+  ?recital rdf:type reg:Recital .
+  ?recital reg:number ?num .
+} ORDER BY ?num LIMIT 20`,
+	},
+	"article-refs": {
+		Name:        "article-refs",
+		Description: "Find what articles reference a specific article",
+		Query: `SELECT ?article ?title WHERE {
+  ?article reg:references ?target .
+  ?article reg:title ?title .
+  FILTER(STRENDS(?target, ":{{article}}"))
+}`,
+		Parameters: []querytemplate.Parameter{
+			{Name: "article", Description: "Referenced article", Type: querytemplate.TypeArticle, Default: "17"},
+		},
+	},
+	"search": {
+		Name:        "search",
+		Description: "Search for articles with a keyword in the title",
+		Query: `SELECT ?article ?title WHERE {
+  ?article rdf:type reg:Article .
+  ?article reg:title ?title .
+  FILTER(CONTAINS(?title, "{{keyword}}"))
+}`,
+		Parameters: []querytemplate.Parameter{
+			{Name: "keyword", Description: "Text to find in article titles (case-sensitive)", Default: "erasure"},
+		},
+	},
+	"term-usage": {
+		Name:        "term-usage",
+		Description: "Find which articles use defined terms",
+		Query: `SELECT ?article ?term WHERE {
+  ?article reg:usesTerm ?termUri .
+  ?termUri reg:term ?term .
+} ORDER BY ?term LIMIT 50`,
+	},
+	"term-articles": {
+		Name:        "term-articles",
+		Description: "Find articles using a specific defined term",
+		Query: `SELECT ?article ?title WHERE {
+  ?article reg:usesTerm ?termUri .
+  ?termUri reg:normalizedTerm "{{term}}" .
+  ?article reg:title ?title .
+} ORDER BY ?article`,
+		Parameters: []querytemplate.Parameter{
+			{Name: "term", Description: "Defined term", Type: querytemplate.TypeTerm, Default: "personal data"},
+		},
+	},
+	"article-terms": {
+		Name:        "article-terms",
+		Description: "Find all defined terms used in an article",
+		Query: `SELECT ?term WHERE {
+  ?article reg:usesTerm ?termUri .
+  ?termUri reg:term ?term .
+  FILTER(STRENDS(?article, ":{{article}}"))
+}`,
+		Parameters: []querytemplate.Parameter{
+			{Name: "article", Description: "Article", Type: querytemplate.TypeArticle, Default: "17"},
+		},
+	},
+	"jurisdiction-articles": {
+		Name:        "jurisdiction-articles",
+		Description: "List the articles of a jurisdiction's library documents (needs per-document base URIs)",
+		Query: `SELECT ?article ?title WHERE {
+  ?article rdf:type reg:Article .
+  ?article reg:title ?title .
+  FILTER(CONTAINS(?article, "/{{jurisdiction}}/"))
+} ORDER BY ?article`,
+		Parameters: []querytemplate.Parameter{
+			{Name: "jurisdiction", Description: "Jurisdiction code, such as EU or US-CA", Type: querytemplate.TypeJurisdiction, Required: true},
+		},
+	},
+	"hierarchy": {
+		Name:        "hierarchy",
+		Description: "Show document hierarchy (chapters contain articles)",
+		Query: `SELECT ?chapter ?chapterTitle ?article ?articleTitle WHERE {
+  ?chapter rdf:type reg:Chapter .
+  ?chapter reg:title ?chapterTitle .
+  ?chapter reg:contains ?article .
+  ?article rdf:type reg:Article .
+  ?article reg:title ?articleTitle .
+} ORDER BY ?chapter ?article LIMIT 30`,
+	},
+	"most-referenced": {
+		Name:        "most-referenced",
+		Description: "Find the most referenced articles",
+		Query: `SELECT ?target WHERE {
+  ?source reg:references ?target .
+} ORDER BY ?target`,
+	},
+	"definition-links": {
+		Name:        "definition-links",
+		Description: "Show terms and their defining articles",
+		Query: `SELECT ?term ?article WHERE {
+  ?termUri rdf:type reg:DefinedTerm .
+  ?termUri reg:term ?term .
+  ?termUri reg:definedIn ?article .
+} ORDER BY ?term`,
+	},
+	"definition-dependencies": {
+		Name:        "definition-dependencies",
+		Description: "Show the defined terms each definition is written in",
+		Query: `SELECT ?term ?usedTerm WHERE {
+  ?termUri reg:definitionUses ?usedUri .
+  ?termUri reg:term ?term .
+  ?usedUri reg:term ?usedTerm .
+} ORDER BY ?term ?usedTerm`,
+	},
+	"external-definitions": {
+		Name:        "external-definitions",
+		Description: "Show provisions using definitions incorporated from other documents",
+		Query: `SELECT ?provision ?term ?definition WHERE {
+  ?provision reg:usesExternalDefinition ?definition .
+  ?definition reg:term ?term .
+} ORDER BY ?provision ?term`,
+	},
+	"abbreviations": {
+		Name:        "abbreviations",
+		Description: "List the abbreviations the document introduces with their expansions",
+		Query: `SELECT ?shortForm ?expansion ?provision WHERE {
+  ?abbr rdf:type reg:Abbreviation .
+  ?abbr reg:shortForm ?shortForm .
+  ?abbr reg:expansion ?expansion .
+  ?abbr reg:definedIn ?provision .
+} ORDER BY ?shortForm`,
+	},
+	"bidirectional": {
+		Name:        "bidirectional",
+		Description: "Show bidirectional reference relationships",
+		Query: `SELECT ?source ?target WHERE {
+  ?source reg:references ?target .
+  ?target reg:referencedBy ?source .
+} LIMIT 20`,
+	},
+	"describe-article": {
+		Name:        "describe-article",
+		Description: "Describe Article 17 (all triples where it appears as subject or object)",
+		Query:       `DESCRIBE GDPR:Art17`,
+	},
+}
+
+func printTemplates(w io.Writer, saved []*querytemplate.Template) {
+	names := make([]string, 0, len(queryTemplates))
+	for name := range queryTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Available query templates:")
+	fmt.Fprintln(w)
+	for _, name := range names {
+		tmpl := queryTemplates[name]
+		fmt.Fprintf(w, "  %-15s %s\n", name, tmpl.Description)
+		printTemplateParameters(w, tmpl.Parameters)
+	}
+
+	if len(saved) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Saved templates:")
+		fmt.Fprintln(w)
+		for _, tmpl := range saved {
+			fmt.Fprintf(w, "  %-15s %s\n", tmpl.Name, tmpl.Description)
+			printTemplateParameters(w, tmpl.Parameters)
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Usage: regula query --template <name> [--param name=value]")
+}
+
+func printTemplateParameters(w io.Writer, parameters []querytemplate.Parameter) {
+	for _, parameter := range parameters {
+		var labels []string
+		if parameter.Type != "" && parameter.Type != querytemplate.TypeString {
+			labels = append(labels, parameter.Type)
+		}
+		if parameter.Required {
+			labels = append(labels, "required")
+		} else if parameter.Default != "" {
+			labels = append(labels, "default "+parameter.Default)
+		} else {
+			labels = append(labels, "optional")
+		}
+		fmt.Fprintf(w, "    --param %s=... (%s): %s\n", parameter.Name, strings.Join(labels, ", "), parameter.Description)
+	}
+}
+
+// loadSavedTemplates loads the saved query templates in dir. A saved
+// template may not take the name of a built-in one.
+func loadSavedTemplates(dir string) ([]*querytemplate.Template, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	saved, err := querytemplate.LoadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load saved templates: %w", err)
+	}
+	for _, tmpl := range saved {
+		if _, ok := queryTemplates[tmpl.Name]; ok {
+			return nil, fmt.Errorf("saved template %s in %s has the name of a built-in template", tmpl.Name, tmpl.Path)
+		}
+	}
+	return saved, nil
+}
+
+// lookupTemplate returns the built-in or saved template with the name, or
+// nil.
+func lookupTemplate(name string, saved []*querytemplate.Template) *querytemplate.Template {
+	if tmpl, ok := queryTemplates[name]; ok {
+		return tmpl.template()
+	}
+	for _, tmpl := range saved {
+		if tmpl.Name == name {
+			return tmpl
+		}
+	}
+	return nil
+}
@@ -0,0 +1,428 @@
+package cli
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestQueryCmd_Select(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--format", "json",
+		"SELECT ?article WHERE { ?article rdf:type reg:Article }")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	var result struct {
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if result.Count != 99 {
+		t.Errorf("count = %d, want 99", result.Count)
+	}
+}
+
+func TestQueryCmd_Construct(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--format", "ntriples",
+		"CONSTRUCT { ?a reg:title ?t } WHERE { ?a rdf:type reg:Article . ?a reg:title ?t }")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "<https://regula.dev/regulations/GDPR:Art17>") {
+		t.Errorf("N-Triples output missing Art17:\n%.300s", stdout)
+	}
+}
+
+func TestQueryCmd_Template(t *testing.T) {
+	stdout, _, code := runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--template", "definitions")
+	if code != 0 || !strings.Contains(stdout, "Template: definitions") {
+		t.Errorf("template query = %d:\n%.300s", code, stdout)
+	}
+
+	_, stderr, code := runCLI(t, "query", "--template", "nope")
+	if code != 1 || !strings.Contains(stderr, "unknown template: nope") {
+		t.Errorf("unknown template = %d %q", code, stderr)
+	}
+}
+
+func TestQueryCmd_AbbreviationsTemplate(t *testing.T) {
+	stdout, _, code := runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--template", "abbreviations")
+	if code != 0 || !strings.Contains(stdout, "European Data Protection Board") || !strings.Contains(stdout, "GDPR:Art68") {
+		t.Errorf("abbreviations query = %d:\n%s", code, stdout)
+	}
+}
+
+func TestQueryCmd_RequiresSource(t *testing.T) {
+	_, stderr, code := runCLI(t, "query", "SELECT ?a WHERE { ?a ?b ?c }")
+	if code != 1 || !strings.Contains(stderr, "no graph loaded") {
+		t.Errorf("query without a source = %d %q", code, stderr)
+	}
+}
+
+func TestQueryCmd_ListTemplates(t *testing.T) {
+	stdout, _, code := runCLI(t, "query", "--list-templates")
+	if code != 0 {
+		t.Fatalf("exit status %d", code)
+	}
+	for name := range queryTemplates {
+		if !strings.Contains(stdout, name) {
+			t.Errorf("template list missing %q", name)
+		}
+	}
+}
+
+func TestQueryCmd_MinQuality(t *testing.T) {
+	countReferences := func(args ...string) int {
+		t.Helper()
+		args = append([]string{"query", "--source", testdataPath(t, "gdpr.txt"), "--format", "json"}, args...)
+		stdout, stderr, code := runCLI(t, append(args, "SELECT ?a ?b WHERE { ?a reg:references ?b }")...)
+		if code != 0 {
+			t.Fatalf("exit status %d: %s", code, stderr)
+		}
+		var result struct {
+			Count int `json:"count"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+		}
+		return result.Count
+	}
+
+	all, precise := countReferences(), countReferences("--min-quality", "1")
+	if precise == 0 || precise >= all {
+		t.Errorf("references: %d with --min-quality 1, %d without", precise, all)
+	}
+
+	_, stderr, code := runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--min-quality", "2", "SELECT ?a WHERE { ?a ?b ?c }")
+	if code != ExitUsage || !strings.Contains(stderr, "--min-quality must be between 0 and 1") {
+		t.Errorf("out-of-range --min-quality = %d %q", code, stderr)
+	}
+}
+
+func TestQueryCmd_AsOf(t *testing.T) {
+	countArticles := func(args ...string) int {
+		t.Helper()
+		args = append([]string{"query", "--source", testdataPath(t, "gdpr.txt"), "--format", "json"}, args...)
+		stdout, stderr, code := runCLI(t, append(args, "SELECT ?a ?from WHERE { ?a rdf:type reg:Article . ?a reg:inForceFrom ?from }")...)
+		if code != 0 {
+			t.Fatalf("exit status %d: %s", code, stderr)
+		}
+		var result struct {
+			Count    int                 `json:"count"`
+			Bindings []map[string]string `json:"bindings"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+		}
+		for _, binding := range result.Bindings {
+			if binding["from"] != "2018-05-25" {
+				t.Errorf("%s in force from %s, want the GDPR application date", binding["a"], binding["from"])
+			}
+		}
+		return result.Count
+	}
+
+	if all := countArticles(); all == 0 {
+		t.Error("expected GDPR articles to be dated from 25 May 2018")
+	}
+	if applied := countArticles("--as-of", "2020-01-01"); applied != countArticles() {
+		t.Errorf("%d articles in force on 2020-01-01, want all", applied)
+	}
+	if before := countArticles("--as-of", "2017-01-01"); before != 0 {
+		t.Errorf("%d articles in force on 2017-01-01, want none", before)
+	}
+
+	_, stderr, code := runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--as-of", "01/01/2020", "SELECT ?a WHERE { ?a ?b ?c }")
+	if code != 1 || !strings.Contains(stderr, "--as-of must be a date") {
+		t.Errorf("malformed --as-of = %d %q", code, stderr)
+	}
+}
+
+func TestQueryCmd_DescribeDepth(t *testing.T) {
+	describe := "DESCRIBE <https://regula.dev/regulations/GDPR:Art17>"
+	shallow, stderr, code := runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--format", "ntriples", describe)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	deep, stderr, code := runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--format", "ntriples", "--depth", "2", describe)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if strings.Count(deep, "\n") <= strings.Count(shallow, "\n") {
+		t.Errorf("--depth 2 returned %d lines, depth 1 returned %d", strings.Count(deep, "\n"), strings.Count(shallow, "\n"))
+	}
+	if !strings.Contains(deep, "<https://regula.dev/regulations/GDPR:Art6> <reg:title>") {
+		t.Errorf("--depth 2 output missing the referenced article's title:\n%.300s", deep)
+	}
+
+	_, stderr, code = runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--depth", "0", describe)
+	if code != ExitUsage || !strings.Contains(stderr, "depth") {
+		t.Errorf("--depth 0 = %d %q", code, stderr)
+	}
+}
+
+func TestQueryCmd_Prefixes(t *testing.T) {
+	source := testdataPath(t, "gdpr.txt")
+
+	stdout, stderr, code := runCLI(t, "query", "--source", source, "--format", "ntriples", "DESCRIBE GDPR:Art17")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "<https://regula.dev/regulations/GDPR:Art17> <reg:title>") {
+		t.Errorf("DESCRIBE of a compact resource name returned:\n%.300s", stdout)
+	}
+
+	referencing := "SELECT ?a WHERE { ?a reg:references GDPR:Art17 }"
+	prefixesPath := filepath.Join(t.TempDir(), "prefixes.yaml")
+	if err := os.WriteFile(prefixesPath, []byte("gdpr: \"https://regula.dev/regulations/GDPR:\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stdout, stderr, code = runCLI(t, "query", "--source", source, "--prefixes", prefixesPath, referencing)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "gdpr:Art70") {
+		t.Errorf("results not compacted with prefixes.yaml:\n%s", stdout)
+	}
+
+	stdout, _, _ = runCLI(t, "query", "--source", source, "PREFIX g: <https://regula.dev/regulations/GDPR:> "+referencing)
+	if !strings.Contains(stdout, "g:Art70") {
+		t.Errorf("results not compacted with the query's PREFIX:\n%s", stdout)
+	}
+
+	stdout, _, _ = runCLI(t, "query", "--source", source, "--prefixes", prefixesPath, "--full-uri", referencing)
+	if !strings.Contains(stdout, "https://regula.dev/regulations/GDPR:Art70") {
+		t.Errorf("--full-uri output:\n%s", stdout)
+	}
+
+	if err := os.WriteFile(prefixesPath, []byte("reg: https://example.org/\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, stderr, code = runCLI(t, "query", "--source", source, "--prefixes", prefixesPath, referencing)
+	if code != 1 || !strings.Contains(stderr, "cannot be redefined") {
+		t.Errorf("redefining a built-in prefix = %d %q", code, stderr)
+	}
+}
+
+func TestLibraryQueryCmd_Prefixes(t *testing.T) {
+	libraryPath := filepath.Join(t.TempDir(), "lib")
+	if _, stderr, code := runCLI(t, "library", "init", "--path", libraryPath); code != 0 {
+		t.Fatalf("library init failed: %s", stderr)
+	}
+	if _, stderr, code := runCLI(t, "library", "add", "--path", libraryPath, "--source", testdataPath(t, "gdpr.txt"),
+		"--id", "eu-gdpr", "--name", "GDPR", "--jurisdiction", "EU"); code != 0 {
+		t.Fatalf("library add failed: %s", stderr)
+	}
+	// Library documents live below <base>/<jurisdiction>/<id>/
+	prefixes := "gdpr: \"https://regula.dev/regulations/eu/eu-gdpr/GDPR:\"\n"
+	if err := os.WriteFile(filepath.Join(libraryPath, "prefixes.yaml"), []byte(prefixes), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	erasure := `SELECT ?a WHERE { ?a reg:title ?t . FILTER(CONTAINS(?t, "Right to erasure")) }`
+	stdout, stderr, code := runCLI(t, "library", "query", "--path", libraryPath, erasure)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "gdpr:Art17") {
+		t.Errorf("library query not compacted with the library's prefixes.yaml:\n%s", stdout)
+	}
+
+	stdout, _, _ = runCLI(t, "library", "query", "--path", libraryPath, "--full-uri", erasure)
+	if !strings.Contains(stdout, "https://regula.dev/regulations/eu/eu-gdpr/GDPR:Art17") {
+		t.Errorf("--full-uri output:\n%s", stdout)
+	}
+
+	stdout, _, _ = runCLI(t, "library", "query", "--path", libraryPath, "SELECT ?t WHERE { gdpr:Art17 reg:title ?t }")
+	if !strings.Contains(stdout, "Right to erasure") {
+		t.Errorf("query using a prefix from prefixes.yaml:\n%s", stdout)
+	}
+}
+
+func TestQueryCmd_SPARQLResultsFormats(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--format", "srj", "--template", "articles")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	var results struct {
+		Head struct {
+			Vars []string `json:"vars"`
+		} `json:"head"`
+		Results struct {
+			Bindings []map[string]struct {
+				Type  string `json:"type"`
+				Value string `json:"value"`
+			} `json:"bindings"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("srj output is not SPARQL results JSON: %v\n%.300s", err, stdout)
+	}
+	if len(results.Results.Bindings) == 0 {
+		t.Fatal("srj output has no bindings")
+	}
+	article := results.Results.Bindings[0][results.Head.Vars[0]]
+	if article.Type != "uri" || !strings.HasPrefix(article.Value, "https://regula.dev/regulations/GDPR:Art") {
+		t.Errorf("first binding = %+v, want a full article URI", article)
+	}
+
+	stdout, stderr, code = runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--format", "srx",
+		"SELECT ?a WHERE { ?a reg:references GDPR:Art17 }")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "<?xml") || !strings.Contains(stdout, "<uri>https://regula.dev/regulations/GDPR:Art70</uri>") {
+		t.Errorf("unexpected srx output:\n%.500s", stdout)
+	}
+}
+
+func TestQueryCmd_XLSX(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "articles.xlsx")
+	stdout, stderr, code := runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--format", "xlsx",
+		"--timing", "-o", outputPath, "--template", "articles")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Results exported to: "+outputPath) {
+		t.Errorf("stdout = %q", stdout)
+	}
+	if !strings.Contains(stderr, "Query executed in") {
+		t.Errorf("timing should go to stderr with xlsx output, got %q", stderr)
+	}
+
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("output is not an .xlsx file: %v", err)
+	}
+	reader, err := archive.Open("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	sheet, _ := io.ReadAll(reader)
+	if !strings.Contains(string(sheet), ">GDPR:Art1<") {
+		t.Errorf("results sheet missing compact article URIs:\n%.500s", sheet)
+	}
+
+	// Without --output the workbook is written to stdout alone.
+	stdout, stderr, code = runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--format", "xlsx", "--template", "articles")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "PK") {
+		t.Errorf("stdout is not a zip archive: %.40q", stdout)
+	}
+}
+
+func TestQueryCmd_Explain(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--explain", "--template", "rights")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	for _, want := range []string{"Query plan: 4 pattern(s)", "INDEX", "#1 ?article rdf:type reg:Article", "1 -> 99"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("--explain output missing %q:\n%s", want, stdout)
+		}
+	}
+
+	// With NDJSON results on stdout, the plan goes to stderr.
+	stdout, stderr, code = runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--explain", "--format", "ndjson",
+		"CONSTRUCT { ?a reg:hasTitle ?t } WHERE { ?a rdf:type reg:Article . ?a reg:title ?t }")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if strings.Contains(stdout, "Query plan") || !strings.Contains(stderr, "Query plan: 2 pattern(s) in written order") {
+		t.Errorf("NDJSON --explain: stdout=%.200q stderr=%q", stdout, stderr)
+	}
+}
+
+func TestQueryCmd_SavedTemplate(t *testing.T) {
+	templatesDir := testdataPath(t, "templates")
+
+	stdout, _, code := runCLI(t, "query", "--templates-dir", templatesDir, "--list-templates")
+	if code != 0 || !strings.Contains(stdout, "my-team/obligations-by-actor") || !strings.Contains(stdout, "--param actor=... (default controller)") {
+		t.Errorf("--list-templates = %d:\n%s", code, stdout)
+	}
+	if strings.Index(stdout, "articles") > strings.Index(stdout, "definitions") {
+		t.Error("built-in templates should be listed in sorted order")
+	}
+
+	stdout, stderr, code := runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--templates-dir", templatesDir,
+		"--template", "my-team/obligations-by-actor", "--param", "actor=processor", "--format", "json")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "GDPR:Art28") {
+		t.Errorf("processor obligations missing Article 28:\n%.500s", stdout)
+	}
+
+	_, stderr, code = runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--templates-dir", templatesDir,
+		"--template", "my-team/obligations-by-actor", "--param", "bearer=processor")
+	if code != 1 || !strings.Contains(stderr, `has no parameter "bearer"`) {
+		t.Errorf("undeclared parameter = %d %q", code, stderr)
+	}
+
+	_, stderr, code = runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--template", "articles", "--param", "a=b")
+	if code != 1 || !strings.Contains(stderr, `has no parameter "a"`) {
+		t.Errorf("parameter for a built-in template = %d %q", code, stderr)
+	}
+}
+
+func TestQueryCmd_SavedTemplateShadowsBuiltIn(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "articles.yaml"), []byte("query: SELECT ?a WHERE { ?a ?b ?c }\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, stderr, code := runCLI(t, "query", "--templates-dir", dir, "--list-templates")
+	if code != 1 || !strings.Contains(stderr, "name of a built-in template") {
+		t.Errorf("shadowing saved template = %d %q", code, stderr)
+	}
+}
+
+func TestQueryCmd_TypedTemplateParameters(t *testing.T) {
+	for _, name := range []string{"articles", "article-refs", "term-articles", "jurisdiction-articles"} {
+		if err := queryTemplates[name].template().Validate(); err != nil {
+			t.Errorf("built-in template %s: %v", name, err)
+		}
+	}
+
+	stdout, stderr, code := runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--format", "json",
+		"--template", "article-refs", "--param", "article=Art. 6")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "GDPR:Art8") || strings.Contains(stdout, "GDPR:Art70") {
+		t.Errorf("articles referencing Art. 6 should include Art8 but not Art70 (which references Art17):\n%.500s", stdout)
+	}
+
+	stdout, stderr, code = runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--format", "json",
+		"--template", "term-articles", "--param", "term=  Personal   Data ")
+	if code != 0 || !strings.Contains(stdout, "GDPR:Art4") {
+		t.Errorf("term-articles with an unnormalized term = %d %s:\n%.300s", code, stderr, stdout)
+	}
+
+	_, stderr, code = runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--template", "article-terms", "--param", "article=first")
+	if code != 1 || !strings.Contains(stderr, `invalid article "first"`) {
+		t.Errorf("invalid article = %d %q", code, stderr)
+	}
+
+	_, stderr, code = runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--template", "jurisdiction-articles")
+	if code != 1 || !strings.Contains(stderr, "required parameter jurisdiction") {
+		t.Errorf("missing required parameter = %d %q", code, stderr)
+	}
+
+	stdout, _, _ = runCLI(t, "query", "--list-templates")
+	if !strings.Contains(stdout, "--param article=... (article, default 17)") {
+		t.Errorf("--list-templates should show typed parameters:\n%s", stdout)
+	}
+}
@@ -0,0 +1,383 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/analysis"
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/linkcheck"
+	"github.com/coolbeans/regula/pkg/ndjson"
+	"github.com/coolbeans/regula/pkg/pattern"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+func refsCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "refs",
+		Short: "Analyze references in a regulation document",
+		Long: `Analyze internal and external references in a regulation document.
+
+Shows clustered external references, reference frequency, and per-provision details.
+
+For House Rules, use --format matrix to generate a rule-to-rule cross-reference
+adjacency matrix showing inter-rule dependencies.
+
+Reference patterns are grouped into families (eu, eu_external, us_state,
+us_municipal, usc, us_external, house_rules), and only the families that fit
+the document's format are applied. Use --families to see the selection, its
+confidence, and the matches it dropped or found ambiguous.
+
+Example:
+  regula refs --source testdata/gdpr.txt
+  regula refs --source testdata/gdpr.txt --format json
+  regula refs --source testdata/gdpr.txt --format ndjson
+  regula refs --source testdata/eu-ai-act.txt --external-only
+  regula refs --source testdata/vcdpa.txt --families
+  regula refs --source house-rules-119th.txt --format matrix
+  regula refs --source house-rules-119th.txt --format matrix-csv
+  regula refs --source house-rules-119th.txt --format matrix-svg --output matrix.svg`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			formatStr, _ := cmd.Flags().GetString("format")
+			externalOnly, _ := cmd.Flags().GetBool("external-only")
+			output, _ := cmd.Flags().GetString("output")
+			showFamilies, _ := cmd.Flags().GetBool("families")
+
+			if source == "" {
+				return &usageError{err: fmt.Errorf("--source flag is required")}
+			}
+
+			if _, err := os.Stat(source); os.IsNotExist(err) {
+				return fmt.Errorf("source file not found: %s", source)
+			}
+
+			file, err := os.Open(source)
+			if err != nil {
+				return fmt.Errorf("failed to open source: %w", err)
+			}
+
+			parser := newParserWithPatterns()
+			doc, err := parser.Parse(file)
+			file.Close()
+			if err != nil {
+				return fmt.Errorf("failed to parse document: %w", err)
+			}
+
+			if showFamilies {
+				report := extract.NewReferenceExtractor().DetectFamilies(doc)
+				switch formatStr {
+				case "table":
+					fmt.Fprint(app.Stdout, report.String())
+				case "json":
+					jsonData, err := report.ToJSON()
+					if err != nil {
+						return fmt.Errorf("failed to serialize JSON: %w", err)
+					}
+					fmt.Fprintln(app.Stdout, string(jsonData))
+				default:
+					return fmt.Errorf("unknown format for --families: %s (use table or json)", formatStr)
+				}
+				return nil
+			}
+
+			docStore := store.NewTripleStore()
+			baseURI := "https://regula.dev/regulations/"
+			builder := store.NewGraphBuilder(docStore, baseURI)
+			defExtractor := extract.NewDefinitionExtractor()
+			refExtractor := extract.NewReferenceExtractor()
+			semExtractor := extract.NewSemanticExtractor()
+
+			docID := extractDocID(source)
+			resolver := extract.NewReferenceResolver(baseURI, docID)
+			resolver.IndexDocument(doc)
+
+			_, err = builder.BuildComplete(doc, defExtractor, refExtractor, resolver, semExtractor)
+			if err != nil {
+				return fmt.Errorf("failed to build graph: %w", err)
+			}
+
+			label := doc.Title
+			if label == "" {
+				label = docID
+			}
+
+			crossRefAnalyzer := analysis.NewCrossRefAnalyzer()
+			crossRefAnalyzer.AddDocument(docID, label, docStore)
+
+			// Handle matrix formats
+			if strings.HasPrefix(formatStr, "matrix") {
+				report := analysis.GenerateMatrixReport(docStore)
+
+				if report.Matrix.TotalRefs == 0 {
+					fmt.Fprintln(app.Stdout, "No cross-references found for matrix visualization.")
+					fmt.Fprintln(app.Stdout, "Matrix format works best with House Rules or similar documents with rule-to-rule references.")
+					return nil
+				}
+
+				var outputContent string
+				switch formatStr {
+				case "matrix":
+					// ASCII table format
+					outputContent = report.String()
+				case "matrix-csv":
+					outputContent = report.Matrix.ToCSV()
+				case "matrix-svg":
+					outputContent = report.Matrix.ToSVGHeatmap()
+				case "matrix-json":
+					jsonData, err := report.ToJSON()
+					if err != nil {
+						return fmt.Errorf("failed to serialize JSON: %w", err)
+					}
+					outputContent = string(jsonData)
+				default:
+					return fmt.Errorf("unknown matrix format: %s (use matrix, matrix-csv, matrix-svg, or matrix-json)", formatStr)
+				}
+
+				if output != "" {
+					if err := os.WriteFile(output, []byte(outputContent), 0644); err != nil {
+						return fmt.Errorf("failed to write file: %w", err)
+					}
+					fmt.Fprintf(app.messageWriter(), "Matrix report exported to: %s\n", output)
+				} else {
+					fmt.Fprint(app.Stdout, outputContent)
+				}
+				return nil
+			}
+
+			if externalOnly {
+				report := crossRefAnalyzer.AnalyzeExternalRefs(docID)
+
+				switch formatStr {
+				case "table":
+					fmt.Fprint(app.Stdout, report.String())
+				case "json":
+					jsonData, err := report.ToJSON()
+					if err != nil {
+						return fmt.Errorf("failed to serialize JSON: %w", err)
+					}
+					if output != "" {
+						if err := os.WriteFile(output, jsonData, 0644); err != nil {
+							return fmt.Errorf("failed to write file: %w", err)
+						}
+						fmt.Fprintf(app.messageWriter(), "External reference report exported to: %s\n", output)
+					} else {
+						fmt.Fprintln(app.Stdout, string(jsonData))
+					}
+				case "ndjson":
+					encoder := ndjson.NewEncoder(app.Stdout)
+					for _, cluster := range report.Clusters {
+						if err := encoder.EncodeRecord("cluster", cluster); err != nil {
+							return err
+						}
+					}
+					summary := struct {
+						*analysis.ExternalRefReport
+						Clusters []analysis.ExternalRefCluster `json:"clusters,omitempty"`
+					}{ExternalRefReport: report}
+					return encoder.EncodeRecord("summary", summary)
+				default:
+					return fmt.Errorf("unknown format: %s (use table, json, or ndjson)", formatStr)
+				}
+			} else {
+				// Full reference summary (internal + external)
+				summary := store.CalculateRelationshipSummary(docStore)
+
+				switch formatStr {
+				case "table":
+				case "json":
+					jsonData, err := json.MarshalIndent(summary, "", "  ")
+					if err != nil {
+						return fmt.Errorf("failed to serialize JSON: %w", err)
+					}
+					if output != "" {
+						if err := os.WriteFile(output, jsonData, 0644); err != nil {
+							return fmt.Errorf("failed to write file: %w", err)
+						}
+						fmt.Fprintf(app.messageWriter(), "Reference summary exported to: %s\n", output)
+					} else {
+						fmt.Fprintln(app.Stdout, string(jsonData))
+					}
+					return nil
+				case "ndjson":
+					return writeReferencesNDJSON(app.Stdout, docStore, summary)
+				default:
+					return fmt.Errorf("unknown format: %s (use table, json, or ndjson)", formatStr)
+				}
+
+				fmt.Fprintf(app.Stdout, "Reference Analysis: %s\n", label)
+				fmt.Fprintln(app.Stdout, "="+strings.Repeat("=", 50))
+				fmt.Fprintf(app.Stdout, "\nTotal relationships: %d\n", summary.TotalRelationships)
+				fmt.Fprintf(app.Stdout, "Internal references: %d\n", summary.RelationshipCounts["reg:references"])
+				fmt.Fprintf(app.Stdout, "External references: %d\n\n", summary.ExternalRefCount)
+
+				if summary.ExternalRefCount > 0 {
+					fmt.Fprintf(app.Stdout, "External Reference Targets (%d unique):\n", len(summary.ExternalRefTargets))
+					for _, ext := range summary.TopExternalTargets {
+						fmt.Fprintf(app.Stdout, "  %-45s %d\n", ext.Target, ext.Count)
+					}
+					fmt.Fprintln(app.Stdout)
+				}
+
+				if len(summary.MostReferencedArticles) > 0 {
+					fmt.Fprintln(app.Stdout, "Most Referenced Articles (internal):")
+					for _, arc := range summary.MostReferencedArticles {
+						fmt.Fprintf(app.Stdout, "  Article %d: %d incoming references\n", arc.ArticleNum, arc.Count)
+					}
+					fmt.Fprintln(app.Stdout)
+				}
+
+				if len(summary.MostReferencingArticles) > 0 {
+					fmt.Fprintln(app.Stdout, "Articles With Most Outgoing References:")
+					for _, arc := range summary.MostReferencingArticles {
+						fmt.Fprintf(app.Stdout, "  Article %d: %d outgoing references\n", arc.ArticleNum, arc.Count)
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document path")
+	cmd.Flags().StringP("format", "f", "table", "Output format (table, json, ndjson, matrix, matrix-csv, matrix-svg, matrix-json)")
+	cmd.Flags().StringP("output", "o", "", "Output file path")
+	cmd.Flags().Bool("external-only", false, "Show only external references")
+	cmd.Flags().Bool("families", false, "Show the reference pattern families applied to the document")
+
+	return cmd
+}
+
+// extractDocID extracts a document identifier from a file path.
+// newParserWithPatterns creates a parser with the pattern registry loaded from
+// the patterns directory. Falls back to a plain parser if patterns cannot be loaded.
+func newParserWithPatterns() *extract.Parser {
+	registry := pattern.NewRegistry()
+	// Try common pattern directory locations relative to the binary
+	if dir := findPatternsDir(); dir != "" {
+		if err := registry.LoadDirectory(dir); err == nil {
+			return extract.NewParserWithRegistry(registry)
+		}
+	}
+	return extract.NewParser()
+}
+
+func extractDocID(sourcePath string) string {
+	baseName := filepath.Base(sourcePath)
+	// Remove extension
+	if idx := strings.LastIndex(baseName, "."); idx != -1 {
+		baseName = baseName[:idx]
+	}
+	return strings.ToUpper(baseName)
+}
+
+// collectExternalURIs extracts external reference URIs from resolved references.
+func collectExternalURIs(resolved []*extract.ResolvedReference) []linkcheck.LinkInput {
+	seen := make(map[string]bool)
+	var links []linkcheck.LinkInput
+
+	for _, ref := range resolved {
+		// Check target URI
+		if ref.TargetURI != "" && isExternalURI(ref.TargetURI) && !seen[ref.TargetURI] {
+			seen[ref.TargetURI] = true
+			links = append(links, linkcheck.LinkInput{
+				URI:           ref.TargetURI,
+				SourceContext: formatSourceContext(ref),
+			})
+		}
+
+		// Check multiple target URIs
+		for _, uri := range ref.TargetURIs {
+			if isExternalURI(uri) && !seen[uri] {
+				seen[uri] = true
+				links = append(links, linkcheck.LinkInput{
+					URI:           uri,
+					SourceContext: formatSourceContext(ref),
+				})
+			}
+		}
+
+		// Check alternative URIs
+		for _, uri := range ref.AlternativeURIs {
+			if isExternalURI(uri) && !seen[uri] {
+				seen[uri] = true
+				links = append(links, linkcheck.LinkInput{
+					URI:           uri,
+					SourceContext: formatSourceContext(ref),
+				})
+			}
+		}
+	}
+
+	return links
+}
+
+// isExternalURI checks if a URI is an external HTTP(S) URL.
+func isExternalURI(uri string) bool {
+	return strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://")
+}
+
+// formatSourceContext creates a human-readable source context for a reference.
+func formatSourceContext(ref *extract.ResolvedReference) string {
+	if ref.Original == nil {
+		return ""
+	}
+
+	if ref.ContextArticle > 0 {
+		return fmt.Sprintf("Article %d", ref.ContextArticle)
+	}
+
+	if ref.Original.SourceArticle > 0 {
+		return fmt.Sprintf("Article %d", ref.Original.SourceArticle)
+	}
+
+	return ref.Original.RawText
+}
+
+// referenceRecord is one reg:Reference node in refs --format ndjson output.
+type referenceRecord struct {
+	URI          string `json:"uri"`
+	Source       string `json:"source"`
+	Text         string `json:"text"`
+	Identifier   string `json:"identifier,omitempty"`
+	Target       string `json:"target,omitempty"`
+	ExternalRef  string `json:"external_ref,omitempty"`
+	TemporalKind string `json:"temporal_kind,omitempty"`
+}
+
+// writeReferencesNDJSON writes a "reference" record for each reference node
+// in docStore, ordered by URI, followed by a "summary" record.
+func writeReferencesNDJSON(w io.Writer, docStore *store.TripleStore, summary *store.RelationshipSummary) error {
+	var refURIs []string
+	for _, triple := range docStore.Find("", store.RDFType, store.ClassReference) {
+		refURIs = append(refURIs, triple.Subject)
+	}
+	sort.Strings(refURIs)
+
+	encoder := ndjson.NewEncoder(w)
+	for _, refURI := range refURIs {
+		target := docStore.GetOne(refURI, store.PropResolvedTarget)
+		if target == "" {
+			target = docStore.GetOne(refURI, store.PropRefersToArticle)
+		}
+		record := referenceRecord{
+			URI:          refURI,
+			Source:       docStore.GetOne(refURI, store.PropPartOf),
+			Text:         docStore.GetOne(refURI, store.PropText),
+			Identifier:   docStore.GetOne(refURI, store.PropIdentifier),
+			Target:       target,
+			ExternalRef:  docStore.GetOne(refURI, store.PropExternalRef),
+			TemporalKind: docStore.GetOne(refURI, store.PropTemporalKind),
+		}
+		if err := encoder.EncodeRecord("reference", record); err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeRecord("summary", summary)
+}
@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/bulk"
+	"github.com/coolbeans/regula/pkg/httpconfig"
+	"github.com/coolbeans/regula/pkg/i18n"
+	"github.com/coolbeans/regula/pkg/manifest"
+	"github.com/coolbeans/regula/pkg/settings"
+	"github.com/coolbeans/regula/pkg/vcr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// NewRootCmd builds the regula command tree. Commands read input from and
+// write output to app's streams.
+func NewRootCmd(app *App) *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:   "regula",
+		Short: "Automated Regulation Mapper",
+		Long: `Regula transforms dense legal regulations into auditable,
+queryable, and simulatable programs.
+
+It ingests regulatory documents and produces:
+  - Queryable knowledge graphs via SPARQL
+  - Type-safe domain models with compile-time verification
+  - Impact analysis for regulatory changes
+  - Simulation engine for compliance scenarios
+  - Audit trails with provenance tracking
+
+Exit status:
+  0   success; any check the command ran passed
+  1   the command failed, or a check it ran did not pass
+  64  invalid command line (unknown command or flag, bad value, wrong arguments)
+Other statuses are command-specific and described in the command's help.`,
+		Version: Version,
+	}
+	rootCmd.SetIn(app.Stdin)
+	rootCmd.SetOut(app.Stdout)
+	rootCmd.SetErr(app.Stderr)
+	rootCmd.PersistentFlags().String("vcr-record", "", "Record HTTP interactions to a cassette file")
+	rootCmd.PersistentFlags().String("vcr-replay", "", "Replay HTTP interactions from a cassette file instead of the network")
+	rootCmd.PersistentFlags().String("http-config", "", "Config file with an http section (proxy, CA bundle, per-domain headers, retries); default: the library's config.yaml")
+	rootCmd.PersistentFlags().String("log-level", "warn", "Log level for pipeline diagnostics: debug, info, warn, or error (debug logs per-stage timings)")
+	rootCmd.PersistentFlags().String("log-format", "text", "Log format: text or json")
+	rootCmd.PersistentFlags().String("trace-file", "", "Write parse/extract/build/query spans to a JSON-lines file")
+	rootCmd.PersistentFlags().String("config-profile", "", fmt.Sprintf("Settings profile from config.yaml (default: $%s)", settings.ProfileEnv))
+	rootCmd.PersistentFlags().Bool("json", false, "Write a single JSON result document to stdout (command output as data, diagnostics on stderr)")
+	rootCmd.PersistentFlags().String("output", "text", "Output mode: text, or json for the same result as --json (commands with an --output file flag take json as this mode too)")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Discard standard output; the exit status reports the outcome")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := applySettings(cmd); err != nil {
+			return err
+		}
+		if err := applyCollection(cmd); err != nil {
+			return err
+		}
+		if err := app.configureOutput(cmd); err != nil {
+			return err
+		}
+		if err := app.configureTelemetry(cmd); err != nil {
+			return err
+		}
+		if err := installHTTPConfig(cmd); err != nil {
+			return err
+		}
+		return app.installCassette(cmd, args)
+	}
+	rootCmd.PersistentFlags().String("lang", "", fmt.Sprintf("Output language for reports (%s); defaults to $%s or the library config",
+		strings.Join(i18n.SupportedLanguages(), ", "), i18n.LanguageEnv))
+
+	// Add subcommands
+	rootCmd.AddCommand(initCmd(app))
+	rootCmd.AddCommand(runCmd(app))
+	rootCmd.AddCommand(ingestCmd(app))
+	rootCmd.AddCommand(queryCmd(app))
+	rootCmd.AddCommand(replCmd(app))
+	rootCmd.AddCommand(validateCmd(app))
+	rootCmd.AddCommand(impactCmd(app))
+	rootCmd.AddCommand(relatedCmd(app))
+	rootCmd.AddCommand(analyzeCmd(app))
+	rootCmd.AddCommand(matchCmd(app))
+	rootCmd.AddCommand(simulateCmd(app))
+	rootCmd.AddCommand(auditCmd(app))
+	rootCmd.AddCommand(exportCmd(app))
+	rootCmd.AddCommand(compareCmd(app))
+	rootCmd.AddCommand(refsCmd(app))
+	rootCmd.AddCommand(libraryCmd(app))
+	rootCmd.AddCommand(crawlCmd(app))
+	rootCmd.AddCommand(playgroundCmd(app))
+	rootCmd.AddCommand(bulkCmd(app))
+	rootCmd.AddCommand(cacheCmd(app))
+	rootCmd.AddCommand(configCmd(app))
+	rootCmd.AddCommand(draftCmd(app))
+	rootCmd.AddCommand(searchCmd(app))
+	rootCmd.AddCommand(navigateCmd(app))
+	rootCmd.AddCommand(corpusCmd(app))
+	rootCmd.AddCommand(patternCmd(app))
+	rootCmd.AddCommand(calendarCmd(app))
+	rootCmd.AddCommand(ontologyCmd(app))
+	rootCmd.AddCommand(statusCmd(app))
+	rootCmd.AddCommand(usageCmd(app))
+	rootCmd.AddCommand(daemonCmd(app))
+	rootCmd.AddCommand(completeCitationCmd(app))
+	rootCmd.AddCommand(summarizeCmd(app))
+	rootCmd.AddCommand(checklistCmd(app))
+	rootCmd.AddCommand(controlsCmd(app))
+	rootCmd.AddCommand(generateCmd(app))
+
+	addCollectionFlags(rootCmd)
+	markUsageErrors(rootCmd)
+
+	return rootCmd
+}
+
+// applySettings fills the flags not given on the command line from
+// REGULA_* environment variables, the selected profile, and the defaults in
+// the project and user config files. The config command is skipped so a
+// broken file can still be inspected and fixed.
+func applySettings(cmd *cobra.Command) error {
+	if strings.HasPrefix(cmd.CommandPath()+" ", cmd.Root().Name()+" config ") {
+		return nil
+	}
+	profile, _ := cmd.Root().PersistentFlags().GetString("config-profile")
+	loaded, err := settings.Load(profile)
+	if err != nil {
+		return err
+	}
+
+	commandPath := settingsCommandPath(cmd)
+	var applyErr error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if applyErr != nil || flag.Changed || unconfigurableFlags[flag.Name] {
+			return
+		}
+		value, source, found := loaded.Lookup(commandPath, flag.Name)
+		if !found {
+			return
+		}
+		if err := cmd.Flags().Set(flag.Name, value); err != nil {
+			applyErr = fmt.Errorf("invalid --%s from %s: %w", flag.Name, source, err)
+		}
+	})
+	return applyErr
+}
+
+// unconfigurableFlags are never set from config files or the environment.
+// --config-profile is read before the settings it selects are loaded.
+var unconfigurableFlags = map[string]bool{"help": true, "version": true, "config-profile": true}
+
+// settingsCommandPath returns the command path without the root name, as
+// used by scoped settings keys: "bulk download" for "regula bulk download".
+func settingsCommandPath(cmd *cobra.Command) string {
+	return strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+}
+
+// installHTTPConfig applies the http section of --http-config, or of the
+// config.yaml in the command's library, to every HTTP client, adding the
+// NY Senate API key from --ny-api-key or $NYSENATE_API_KEY. It is
+// installed before the cassette so recordings see requests without the
+// configured API keys.
+func installHTTPConfig(cmd *cobra.Command) error {
+	configPath, _ := cmd.Flags().GetString("http-config")
+	if configPath != "" {
+		if _, err := os.Stat(configPath); err != nil {
+			return fmt.Errorf("http config not found: %w", err)
+		}
+	} else {
+		libraryPath := defaultLibraryPath()
+		if pathFlag := cmd.Flags().Lookup("path"); pathFlag != nil {
+			libraryPath = pathFlag.Value.String()
+		}
+		configPath = filepath.Join(libraryPath, httpconfig.ConfigFileName)
+	}
+
+	httpConfig, err := httpconfig.LoadFile(configPath)
+	if err != nil {
+		return err
+	}
+	apiKey := os.Getenv("NYSENATE_API_KEY")
+	if keyFlag := cmd.Flags().Lookup("ny-api-key"); keyFlag != nil && keyFlag.Value.String() != "" {
+		apiKey = keyFlag.Value.String()
+	}
+	if apiKey != "" {
+		httpConfig.AddDomainQuery(bulk.NewYorkAPIHost, "key", apiKey)
+	}
+	_, err = httpconfig.Install(httpConfig)
+	return err
+}
+
+// installCassette routes all HTTP traffic through a vcr recorder when
+// --vcr-record or --vcr-replay is set, so network-dependent commands (fetch,
+// crawl, validate --check links, bulk download) can be recorded once and
+// replayed offline. A recording is written when the command finishes.
+func (app *App) installCassette(cmd *cobra.Command, args []string) error {
+	recordPath, _ := cmd.Flags().GetString("vcr-record")
+	replayPath, _ := cmd.Flags().GetString("vcr-replay")
+
+	switch {
+	case recordPath != "" && replayPath != "":
+		return &usageError{err: fmt.Errorf("--vcr-record and --vcr-replay are mutually exclusive")}
+	case recordPath != "":
+		recorder, _, err := vcr.Install(vcr.ModeRecord, recordPath)
+		if err != nil {
+			return err
+		}
+		app.cassette = recorder
+		fmt.Fprintf(app.Stderr, "Recording HTTP interactions to %s\n", recordPath)
+	case replayPath != "":
+		recorder, _, err := vcr.Install(vcr.ModeReplay, replayPath)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(app.Stderr, "Replaying %d HTTP interaction(s) from %s\n", recorder.Len(), replayPath)
+	}
+	return nil
+}
+
+func initCmd(app *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "init [project-name]",
+		Short: "Initialize a new regulation project",
+		Long: `Create a project directory with folders for regulations, graphs,
+scenarios, and reports, and a regula.yaml manifest declaring the pipeline
+'regula run' executes. An existing regula.yaml is left unchanged.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectName := "regula-project"
+			if len(args) > 0 {
+				projectName = args[0]
+			}
+
+			// Create directories
+			dirs := []string{
+				filepath.Join(projectName, "regulations"),
+				filepath.Join(projectName, "graphs"),
+				filepath.Join(projectName, "scenarios"),
+				filepath.Join(projectName, "reports"),
+			}
+
+			for _, dir := range dirs {
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					return fmt.Errorf("failed to create directory %s: %w", dir, err)
+				}
+			}
+
+			manifestPath := filepath.Join(projectName, manifest.FileName)
+			if _, err := os.Stat(manifestPath); os.IsNotExist(err) {
+				template := fmt.Sprintf(manifestTemplate, filepath.Base(projectName))
+				if err := os.WriteFile(manifestPath, []byte(template), 0644); err != nil {
+					return fmt.Errorf("failed to write %s: %w", manifestPath, err)
+				}
+			}
+
+			out := app.messageWriter()
+			fmt.Fprintf(out, "Initialized regulation project: %s\n", projectName)
+			fmt.Fprintln(out, "Created directories:")
+			for _, dir := range dirs {
+				fmt.Fprintf(out, "  - %s/\n", dir)
+			}
+			fmt.Fprintf(out, "Project manifest: %s\n", manifestPath)
+			fmt.Fprintf(out, "\nNext steps:\n")
+			fmt.Fprintf(out, "  1. Add regulation documents to %s/regulations/\n", projectName)
+			fmt.Fprintf(out, "  2. Declare them as sources in %s\n", manifestPath)
+			fmt.Fprintf(out, "  3. Run: cd %s && regula run\n", projectName)
+			return app.writeJSONResult(map[string]any{"project": projectName, "directories": dirs, "manifest": manifestPath})
+		},
+	}
+}
+
+// manifestTemplate is the regula.yaml written by 'regula init', formatted
+// with the project name.
+const manifestTemplate = `# Project manifest: the pipeline 'regula run' executes.
+name: %s
+library: .regula
+
+sources: []
+#  - id: eu-gdpr
+#    path: regulations/gdpr.txt
+#    jurisdiction: EU
+#    format: eu
+#    sha256: <sha256 of the file, to pin its content>
+
+validate: []
+#  - source: eu-gdpr
+#    profile: GDPR
+#    threshold: 0.85
+#    report: reports/gdpr-validation.html
+
+exports: []
+#  - source: eu-gdpr
+#    format: turtle
+#    output: graphs/gdpr.ttl
+`
@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/manifest"
+	"github.com/spf13/cobra"
+)
+
+// runStepResult is the outcome of one pipeline step.
+type runStepResult struct {
+	Stage    string   `json:"stage"`
+	Name     string   `json:"name"`
+	Command  []string `json:"command"`
+	ExitCode int      `json:"exit_code"`
+	Duration string   `json:"duration,omitempty"`
+	Skipped  bool     `json:"skipped,omitempty"`
+}
+
+// runSummary is the outcome of 'regula run'.
+type runSummary struct {
+	Project  string          `json:"project,omitempty"`
+	Manifest string          `json:"manifest"`
+	DryRun   bool            `json:"dry_run,omitempty"`
+	Steps    []runStepResult `json:"steps"`
+	Failed   int             `json:"failed"`
+	Duration string          `json:"duration"`
+}
+
+func runCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run the pipeline declared in regula.yaml",
+		Long: `Run the pipeline declared in the project manifest, regula.yaml: add the
+declared sources to the library, run the declared validations, and write
+the declared exports.
+
+Each step is an ordinary regula command line, printed before it runs, so a
+failing step can be reproduced by hand. Source files pinned with sha256 are
+checked before anything runs, and documents are re-added with --force, so
+repeated runs over the same sources give the same library and outputs.
+
+The run stops at the first failing step and exits non-zero, which makes it
+suitable as a CI compliance check. --keep-going runs the remaining steps
+and still exits non-zero.
+
+Example regula.yaml:
+  name: privacy-compliance
+  library: .regula
+  sources:
+    - id: eu-gdpr
+      path: regulations/gdpr.txt
+      jurisdiction: EU
+      sha256: <sha256 of the file>
+  validate:
+    - profile: GDPR
+      threshold: 0.85
+      report: reports/validation.html
+  exports:
+    - format: turtle
+      output: reports/graph.ttl
+
+Examples:
+  regula run
+  regula run --dry-run
+  regula run --manifest ci/regula.yaml --prune --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestPath, _ := cmd.Flags().GetString("manifest")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			keepGoing, _ := cmd.Flags().GetBool("keep-going")
+			prune, _ := cmd.Flags().GetBool("prune")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			project, err := manifest.Load(manifestPath)
+			if err != nil {
+				return err
+			}
+			if err := project.VerifySources(); err != nil {
+				return fmt.Errorf("failed to verify sources: %w", err)
+			}
+
+			options := manifest.PlanOptions{Prune: prune}
+			if libraryPath := project.LibraryPath(); libraryPath != "" {
+				if lib, err := library.Open(libraryPath); err == nil {
+					options.LibraryExists = true
+					for _, entry := range lib.ListDocuments() {
+						options.LibraryDocuments = append(options.LibraryDocuments, entry.ID)
+					}
+				}
+			}
+			steps := project.Plan(options)
+
+			// With JSON output the steps' own output goes to stderr, so
+			// stdout carries only the summary.
+			stepOut := app.Stdout
+			if formatStr == "json" {
+				stepOut = app.Stderr
+			}
+			stepApp := &App{Stdin: app.Stdin, Stdout: stepOut, Stderr: app.Stderr}
+
+			summary := runSummary{Project: project.Name, Manifest: manifestPath, DryRun: dryRun}
+			started := time.Now()
+			for i, step := range steps {
+				result := runStepResult{Stage: step.Stage, Name: step.Name, Command: step.Command}
+				fmt.Fprintf(stepOut, "==> [%d/%d] %s\n", i+1, len(steps), step.Name)
+				fmt.Fprintf(stepOut, "    regula %s\n", strings.Join(step.Command, " "))
+
+				switch {
+				case dryRun || summary.Failed > 0 && !keepGoing:
+					result.Skipped = true
+				default:
+					stepStarted := time.Now()
+					result.ExitCode = runStep(stepApp, step)
+					result.Duration = time.Since(stepStarted).Round(time.Millisecond).String()
+					if result.ExitCode != 0 {
+						summary.Failed++
+						fmt.Fprintf(app.Stderr, "Step %q failed with exit status %d\n", step.Name, result.ExitCode)
+					}
+				}
+				summary.Steps = append(summary.Steps, result)
+			}
+			summary.Duration = time.Since(started).Round(time.Millisecond).String()
+
+			if formatStr == "json" {
+				data, err := json.MarshalIndent(summary, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+			} else {
+				writeRunSummary(app.Stdout, &summary)
+			}
+
+			if summary.Failed > 0 {
+				return exitWithCode(cmd, 1)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("manifest", manifest.FileName, "Project manifest path")
+	cmd.Flags().Bool("dry-run", false, "Print the steps without running them")
+	cmd.Flags().Bool("keep-going", false, "Run the remaining steps after one fails")
+	cmd.Flags().Bool("prune", false, "Remove library documents the manifest does not declare")
+	cmd.Flags().String("format", "text", "Summary format (text, json)")
+
+	return cmd
+}
+
+// runStep creates the directories of the step's outputs and runs its
+// command, returning the exit status.
+func runStep(app *App, step manifest.Step) int {
+	for _, output := range step.Outputs {
+		if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+			fmt.Fprintf(app.Stderr, "failed to create directory for %s: %v\n", output, err)
+			return 1
+		}
+	}
+	return Execute(app, step.Command)
+}
+
+// writeRunSummary prints the outcome of each step and the totals.
+func writeRunSummary(w io.Writer, summary *runSummary) {
+	fmt.Fprintln(w)
+	title := "Run summary"
+	if summary.Project != "" {
+		title += ": " + summary.Project
+	}
+	fmt.Fprintln(w, title)
+	for _, step := range summary.Steps {
+		status := "ok"
+		switch {
+		case step.Skipped:
+			status = "skipped"
+		case step.ExitCode != 0:
+			status = fmt.Sprintf("FAILED (exit %d)", step.ExitCode)
+		}
+		fmt.Fprintf(w, "  %-9s %-40s %-16s %s\n", step.Stage, step.Name, status, step.Duration)
+	}
+	switch {
+	case summary.DryRun:
+		fmt.Fprintf(w, "%d step(s) planned (dry run)\n", len(summary.Steps))
+	case summary.Failed > 0:
+		fmt.Fprintf(w, "%d of %d step(s) failed in %s\n", summary.Failed, len(summary.Steps), summary.Duration)
+	default:
+		fmt.Fprintf(w, "All %d step(s) passed in %s\n", len(summary.Steps), summary.Duration)
+	}
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunCmd_Pipeline(t *testing.T) {
+	projectDir := filepath.Join(t.TempDir(), "privacy")
+	if _, stderr, code := runCLI(t, "init", projectDir); code != 0 {
+		t.Fatalf("init: %s", stderr)
+	}
+	manifestPath := filepath.Join(projectDir, "regula.yaml")
+	if _, err := os.Stat(manifestPath); err != nil {
+		t.Fatalf("init did not write regula.yaml: %v", err)
+	}
+	if _, stderr, code := runCLI(t, "run", "--manifest", manifestPath); code == 0 || !strings.Contains(stderr, "no sources declared") {
+		t.Errorf("run with the template manifest = %d %q", code, stderr)
+	}
+
+	source, err := os.ReadFile(testdataPath(t, "gdpr.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, "regulations", "gdpr.txt"), source, 0644); err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256(source)
+	manifest := fmt.Sprintf(`name: privacy
+library: .regula
+sources:
+  - id: eu-gdpr
+    path: regulations/gdpr.txt
+    jurisdiction: EU
+    sha256: %s
+validate:
+  - profile: GDPR
+    check: references
+    threshold: 0.5
+exports:
+  - format: turtle
+    output: out/gdpr.ttl
+`, hex.EncodeToString(digest[:]))
+	if err := os.WriteFile(manifestPath, []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, _, code := runCLI(t, "run", "--manifest", manifestPath, "--dry-run")
+	if code != 0 || !strings.Contains(stdout, "regula library init --path") || !strings.Contains(stdout, "4 step(s) planned") {
+		t.Errorf("dry run = %d\n%s", code, stdout)
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, ".regula")); !os.IsNotExist(err) {
+		t.Error("dry run created the library")
+	}
+
+	stdout, stderr, code := runCLI(t, "run", "--manifest", manifestPath, "--format", "json")
+	if code != 0 {
+		t.Fatalf("run = %d\n%s", code, stderr)
+	}
+	var summary runSummary
+	if err := json.Unmarshal([]byte(stdout), &summary); err != nil {
+		t.Fatalf("invalid JSON summary: %v\n%s", err, stdout)
+	}
+	if summary.Failed != 0 || len(summary.Steps) != 4 || summary.Project != "privacy" {
+		t.Errorf("summary = %+v", summary)
+	}
+	if info, err := os.Stat(filepath.Join(projectDir, "out", "gdpr.ttl")); err != nil || info.Size() == 0 {
+		t.Errorf("export not written: %v", err)
+	}
+
+	// A second run reuses the library instead of creating it again.
+	stdout, stderr, code = runCLI(t, "run", "--manifest", manifestPath)
+	if code != 0 || strings.Contains(stdout, "init library") || !strings.Contains(stdout, "All 3 step(s) passed") {
+		t.Errorf("second run = %d\n%s%s", code, stdout, stderr)
+	}
+
+	if err := os.WriteFile(filepath.Join(projectDir, "regulations", "gdpr.txt"), append(source, '\n'), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, stderr, code := runCLI(t, "run", "--manifest", manifestPath); code == 0 || !strings.Contains(stderr, "manifest pins") {
+		t.Errorf("run with a changed source = %d %q", code, stderr)
+	}
+}
+
+func TestRunCmd_StopsAtFailure(t *testing.T) {
+	projectDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectDir, "gdpr.txt"), []byte("not much of a regulation"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	manifestPath := filepath.Join(projectDir, "regula.yaml")
+	manifest := `sources:
+  - id: doc
+    path: gdpr.txt
+validate:
+  - profile: NoSuchProfile
+exports:
+  - format: summary
+    output: summary.txt
+`
+	if err := os.WriteFile(manifestPath, []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, _, code := runCLI(t, "run", "--manifest", manifestPath)
+	if code != 1 || !strings.Contains(stdout, "skipped") || !strings.Contains(stdout, "1 of 2 step(s) failed") {
+		t.Errorf("run = %d\n%s", code, stdout)
+	}
+
+	stdout, _, code = runCLI(t, "run", "--manifest", manifestPath, "--keep-going")
+	if code != 1 || strings.Contains(stdout, "skipped") {
+		t.Errorf("run --keep-going = %d\n%s", code, stdout)
+	}
+}
@@ -0,0 +1,522 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/textutil"
+	"github.com/spf13/cobra"
+)
+
+// searchCmd returns the search command for finding committee jurisdictions.
+func searchCmd(app *App) *cobra.Command {
+	var sourcePath string
+	var committeeQuery string
+	var keywordQuery string
+	var templateName string
+	var listCommittees bool
+	var listTemplates bool
+	var formatOutput string
+	var limitResults int
+
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Search for committee jurisdictions or procedural keywords in House Rules",
+		Long: `Search House Rules by committee jurisdiction or procedural keyword.
+
+COMMITTEE SEARCH (Rule X):
+Uses Rule X, clause 1 of the House Rules to find which committee
+has jurisdiction over a given subject matter.
+
+KEYWORD SEARCH (all rules):
+Searches across all House Rules for procedural concepts. Use --keyword
+for free-text search or --template for pre-built procedural queries.
+
+Examples:
+  # Find committees with cybersecurity jurisdiction
+  regula search --source house-rules-119th.txt --committee cybersecurity
+
+  # Search for "quorum" across all rules
+  regula search --source house-rules-119th.txt --keyword quorum
+
+  # Use a pre-built template for voting procedures
+  regula search --source house-rules-119th.txt --template voting
+
+  # List available templates
+  regula search --list-templates
+
+  # List all committees and their jurisdictions
+  regula search --source house-rules-119th.txt --list-committees
+
+  # Output as JSON with limit
+  regula search --source house-rules-119th.txt --keyword amendment --format json --limit 5`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle --list-templates (no source required)
+			if listTemplates {
+				return outputTemplateList(app.Stdout, formatOutput)
+			}
+
+			if sourcePath == "" {
+				return &usageError{err: fmt.Errorf("--source flag is required")}
+			}
+
+			// Read the source file
+			data, err := os.ReadFile(sourcePath)
+			if err != nil {
+				return fmt.Errorf("failed to read source file: %w", err)
+			}
+			text := string(data)
+
+			// Handle --keyword or --template search (procedural keyword navigator)
+			if keywordQuery != "" || templateName != "" {
+				searcher := extract.NewKeywordSearcher()
+				searcher.ParseHouseRules(text)
+
+				var matches []extract.KeywordMatch
+				var queryLabel string
+
+				if templateName != "" {
+					matches = searcher.SearchWithTemplate(templateName)
+					queryLabel = templateName + " (template)"
+				} else {
+					matches = searcher.Search(keywordQuery)
+					queryLabel = keywordQuery
+				}
+
+				if len(matches) == 0 {
+					fmt.Fprintf(app.Stdout, "No matches found for %q\n", queryLabel)
+					return nil
+				}
+
+				if limitResults > 0 && len(matches) > limitResults {
+					matches = matches[:limitResults]
+				}
+
+				return outputKeywordResults(app.Stdout, matches, queryLabel, formatOutput)
+			}
+
+			// Handle committee-based search (original functionality)
+
+			// Find Rule X section
+			ruleXStart := strings.Index(text, "RULE X")
+			if ruleXStart == -1 {
+				return fmt.Errorf("could not find Rule X in document")
+			}
+
+			// Find Rule XI to delimit
+			ruleXEnd := strings.Index(text[ruleXStart+6:], "RULE XI")
+			if ruleXEnd == -1 {
+				ruleXEnd = len(text) - ruleXStart
+			} else {
+				ruleXEnd += ruleXStart + 6
+			}
+
+			ruleXText := text[ruleXStart:ruleXEnd]
+
+			// Extract committees
+			extractor := extract.NewCommitteeJurisdictionExtractor()
+			committees := extractor.ExtractFromRuleX(ruleXText)
+
+			if len(committees) == 0 {
+				return fmt.Errorf("no committees found in Rule X")
+			}
+
+			// Handle --list-committees
+			if listCommittees {
+				return outputCommitteeList(app.Stdout, committees, formatOutput)
+			}
+
+			// Handle --committee search
+			if committeeQuery == "" {
+				return fmt.Errorf("use --committee, --keyword, --template, --list-committees, or --list-templates")
+			}
+
+			matches := extract.SearchCommitteeByTopic(committees, committeeQuery)
+			if len(matches) == 0 {
+				fmt.Fprintf(app.Stdout, "No committees found with jurisdiction over %q\n", committeeQuery)
+				return nil
+			}
+
+			return outputSearchResults(app.Stdout, matches, committeeQuery, formatOutput)
+		},
+	}
+
+	cmd.Flags().StringVar(&sourcePath, "source", "", "Path to House Rules source file")
+	cmd.Flags().StringVar(&committeeQuery, "committee", "", "Topic to search for in committee jurisdictions")
+	cmd.Flags().StringVar(&keywordQuery, "keyword", "", "Keyword to search across all House Rules")
+	cmd.Flags().StringVar(&templateName, "template", "", "Pre-built template (voting, quorum, amendments, debate, etc.)")
+	cmd.Flags().BoolVar(&listCommittees, "list-committees", false, "List all committees and their jurisdictions")
+	cmd.Flags().BoolVar(&listTemplates, "list-templates", false, "List available procedural keyword templates")
+	cmd.Flags().StringVar(&formatOutput, "format", "table", "Output format (table, json)")
+	cmd.Flags().IntVar(&limitResults, "limit", 0, "Limit number of results (0 for unlimited)")
+
+	return cmd
+}
+
+// outputCommitteeList prints the list of all committees.
+func outputCommitteeList(w io.Writer, committees []extract.CommitteeJurisdiction, format string) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(committees, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+
+	// Table format
+	fmt.Fprintln(w, "Congressional Committees (from Rule X)")
+	fmt.Fprintln(w, strings.Repeat("═", 70))
+
+	for _, committee := range committees {
+		fmt.Fprintf(w, "\n[%s] %s\n", committee.Letter, committee.Name)
+		fmt.Fprintf(w, "    Source: %s\n", committee.SourceClause)
+		fmt.Fprintf(w, "    Jurisdictions: %d topics\n", len(committee.Topics))
+
+		// Show first 5 topics
+		shown := 0
+		for _, topic := range committee.Topics {
+			if shown >= 5 {
+				remaining := len(committee.Topics) - 5
+				fmt.Fprintf(w, "      ... and %d more\n", remaining)
+				break
+			}
+			topicText := textutil.Truncate(topic.Text, 60)
+			fmt.Fprintf(w, "      (%s) %s\n", topic.Number, topicText)
+			shown++
+		}
+	}
+
+	fmt.Fprintf(w, "\nTotal: %d committees\n", len(committees))
+	return nil
+}
+
+// outputSearchResults prints the search results.
+func outputSearchResults(w io.Writer, matches []extract.CommitteeJurisdictionMatch, query, format string) error {
+	if format == "json" {
+		type jsonMatch struct {
+			Committee    string `json:"committee"`
+			Jurisdiction string `json:"jurisdiction"`
+			Source       string `json:"source"`
+		}
+		var jsonMatches []jsonMatch
+		for _, m := range matches {
+			jsonMatches = append(jsonMatches, jsonMatch{
+				Committee:    m.Committee.Name,
+				Jurisdiction: m.MatchedTopic.Text,
+				Source:       m.SourceRef,
+			})
+		}
+		data, err := json.MarshalIndent(jsonMatches, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+
+	// Table format
+	fmt.Fprintf(w, "Committees with jurisdiction over %q\n", query)
+	fmt.Fprintln(w, strings.Repeat("═", 70))
+
+	// Group by committee
+	byCommittee := make(map[string][]extract.CommitteeJurisdictionMatch)
+	for _, m := range matches {
+		byCommittee[m.Committee.Name] = append(byCommittee[m.Committee.Name], m)
+	}
+
+	for committeeName, committeeMatches := range byCommittee {
+		fmt.Fprintf(w, "\n%s\n", committeeName)
+		for _, m := range committeeMatches {
+			jurisdictionText := textutil.Truncate(m.MatchedTopic.Text, 60)
+			fmt.Fprintf(w, "  Jurisdiction: %q\n", jurisdictionText)
+			fmt.Fprintf(w, "  Source: %s\n", m.SourceRef)
+		}
+	}
+
+	fmt.Fprintf(w, "\nTotal: %d matches across %d committees\n", len(matches), len(byCommittee))
+	return nil
+}
+
+// outputKeywordResults prints keyword search results.
+func outputKeywordResults(w io.Writer, matches []extract.KeywordMatch, query, format string) error {
+	if format == "json" {
+		type jsonMatch struct {
+			Rule        string `json:"rule"`
+			RuleTitle   string `json:"rule_title"`
+			Clause      string `json:"clause"`
+			ClauseTitle string `json:"clause_title,omitempty"`
+			Context     string `json:"context"`
+			Score       int    `json:"score"`
+			MatchCount  int    `json:"match_count"`
+		}
+		var jsonMatches []jsonMatch
+		for _, m := range matches {
+			jsonMatches = append(jsonMatches, jsonMatch{
+				Rule:        m.Rule,
+				RuleTitle:   m.RuleTitle,
+				Clause:      m.Clause,
+				ClauseTitle: m.ClauseTitle,
+				Context:     m.Context,
+				Score:       m.Score,
+				MatchCount:  m.MatchCount,
+			})
+		}
+		data, err := json.MarshalIndent(jsonMatches, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+
+	// Table format
+	fmt.Fprintf(w, "Search results for %q\n", query)
+	fmt.Fprintln(w, strings.Repeat("═", 70))
+
+	for i, m := range matches {
+		ruleRef := fmt.Sprintf("Rule %s, clause %s", m.Rule, m.Clause)
+		if m.ClauseTitle != "" {
+			fmt.Fprintf(w, "\n[%d] %s: %s\n", i+1, ruleRef, m.ClauseTitle)
+		} else if m.RuleTitle != "" {
+			fmt.Fprintf(w, "\n[%d] %s (%s)\n", i+1, ruleRef, m.RuleTitle)
+		} else {
+			fmt.Fprintf(w, "\n[%d] %s\n", i+1, ruleRef)
+		}
+		fmt.Fprintf(w, "    Context: %q\n", m.Context)
+		fmt.Fprintf(w, "    Matches: %d (score: %d)\n", m.MatchCount, m.Score)
+	}
+
+	fmt.Fprintf(w, "\nTotal: %d matches\n", len(matches))
+	return nil
+}
+
+// outputTemplateList prints the available procedural keyword templates.
+func outputTemplateList(w io.Writer, format string) error {
+	templates := extract.GetTemplates()
+
+	if format == "json" {
+		type templateInfo struct {
+			Name     string   `json:"name"`
+			Keywords []string `json:"keywords"`
+		}
+		var templateList []templateInfo
+		for _, name := range templates {
+			templateList = append(templateList, templateInfo{
+				Name:     name,
+				Keywords: extract.ProceduralKeywords[name],
+			})
+		}
+		data, err := json.MarshalIndent(templateList, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+
+	// Table format
+	fmt.Fprintln(w, "Available Procedural Keyword Templates")
+	fmt.Fprintln(w, strings.Repeat("═", 70))
+
+	for _, name := range templates {
+		keywords := extract.ProceduralKeywords[name]
+		keywordSummary := strings.Join(keywords[:min(len(keywords), 5)], ", ")
+		if len(keywords) > 5 {
+			keywordSummary += fmt.Sprintf(", ... (%d total)", len(keywords))
+		}
+		fmt.Fprintf(w, "\n%s:\n", name)
+		fmt.Fprintf(w, "  Keywords: %s\n", keywordSummary)
+	}
+
+	fmt.Fprintf(w, "\nTotal: %d templates\n", len(templates))
+	fmt.Fprintln(w, "\nUsage: regula search --source <file> --template <template-name>")
+	return nil
+}
+
+func navigateCmd(app *App) *cobra.Command {
+	var sourcePath string
+	var action string
+	var listActions bool
+	var discover bool
+	var formatOutput string
+
+	cmd := &cobra.Command{
+		Use:   "navigate",
+		Short: "Navigate procedural paths through House Rules",
+		Long: `Generate step-by-step procedural guides for legislative actions.
+
+Given a legislative action (e.g., "introduce a bill", "propose an amendment"),
+traces the relevant rules to show the procedural steps required.
+
+Examples:
+  # Show steps for introducing a bill
+  regula navigate --source house-rules-119th.txt --action "introduce a bill"
+
+  # Use short alias for action
+  regula navigate --source house-rules-119th.txt --action vote
+
+  # Discover additional related clauses
+  regula navigate --source house-rules-119th.txt --action amend --discover
+
+  # List all available actions
+  regula navigate --list-actions
+
+  # Output as JSON
+  regula navigate --source house-rules-119th.txt --action debate --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle --list-actions (no source required)
+			if listActions {
+				return outputActionList(app.Stdout, formatOutput)
+			}
+
+			if sourcePath == "" {
+				return &usageError{err: fmt.Errorf("--source flag is required")}
+			}
+
+			if action == "" {
+				return &usageError{err: fmt.Errorf("--action flag is required (or use --list-actions)")}
+			}
+
+			// Read the source file
+			data, err := os.ReadFile(sourcePath)
+			if err != nil {
+				return fmt.Errorf("failed to read source file: %w", err)
+			}
+
+			// Parse House Rules
+			searcher := extract.NewKeywordSearcher()
+			searcher.ParseHouseRules(string(data))
+
+			// Create pathfinder
+			pathfinder := extract.NewPathfinder(searcher)
+
+			// Navigate
+			var path *extract.ProceduralPath
+			if discover {
+				path, err = pathfinder.NavigateWithDiscovery(action)
+			} else {
+				path, err = pathfinder.Navigate(action)
+			}
+			if err != nil {
+				return err
+			}
+
+			// Output
+			return outputProceduralPath(app.Stdout, path, formatOutput)
+		},
+	}
+
+	cmd.Flags().StringVar(&sourcePath, "source", "", "Path to House Rules source file")
+	cmd.Flags().StringVar(&action, "action", "", "Legislative action to navigate (e.g., 'introduce-bill', 'vote', 'amend')")
+	cmd.Flags().BoolVar(&listActions, "list-actions", false, "List all available procedural actions")
+	cmd.Flags().BoolVar(&discover, "discover", false, "Discover additional related clauses via keyword search")
+	cmd.Flags().StringVar(&formatOutput, "format", "text", "Output format (text, json)")
+
+	return cmd
+}
+
+// outputActionList prints the list of available procedural actions.
+func outputActionList(w io.Writer, format string) error {
+	pathfinder := extract.NewPathfinder(nil)
+	scenarios := pathfinder.ListScenarios()
+
+	if format == "json" {
+		type actionInfo struct {
+			Action      string   `json:"action"`
+			Title       string   `json:"title"`
+			Description string   `json:"description"`
+			Related     []string `json:"related_actions"`
+		}
+		var actionList []actionInfo
+		for _, s := range scenarios {
+			actionList = append(actionList, actionInfo{
+				Action:      s.Action,
+				Title:       s.Title,
+				Description: s.Description,
+				Related:     s.RelatedActions,
+			})
+		}
+		data, err := json.MarshalIndent(actionList, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+
+	// Text format
+	fmt.Fprintln(w, "Available Procedural Actions")
+	fmt.Fprintln(w, strings.Repeat("═", 60))
+
+	for _, s := range scenarios {
+		fmt.Fprintf(w, "\n%s (%s)\n", s.Title, s.Action)
+		fmt.Fprintf(w, "  %s\n", s.Description)
+		if len(s.RelatedActions) > 0 {
+			fmt.Fprintf(w, "  Related: %s\n", strings.Join(s.RelatedActions, ", "))
+		}
+	}
+
+	fmt.Fprintf(w, "\nTotal: %d actions\n", len(scenarios))
+	fmt.Fprintln(w, "\nUsage: regula navigate --source <file> --action <action>")
+	return nil
+}
+
+// outputProceduralPath prints the procedural path.
+func outputProceduralPath(w io.Writer, path *extract.ProceduralPath, format string) error {
+	if format == "json" {
+		type stepJSON struct {
+			StepNumber  int      `json:"step_number"`
+			Title       string   `json:"title"`
+			Rule        string   `json:"rule"`
+			Clause      string   `json:"clause"`
+			ClauseTitle string   `json:"clause_title,omitempty"`
+			Description string   `json:"description"`
+			Excerpt     string   `json:"excerpt,omitempty"`
+			References  []string `json:"references,omitempty"`
+		}
+		type pathJSON struct {
+			Action         string     `json:"action"`
+			Title          string     `json:"title"`
+			Description    string     `json:"description"`
+			Steps          []stepJSON `json:"steps"`
+			RelatedActions []string   `json:"related_actions"`
+		}
+
+		output := pathJSON{
+			Action:         path.Action,
+			Title:          path.Title,
+			Description:    path.Description,
+			RelatedActions: path.RelatedActions,
+		}
+		for _, step := range path.Steps {
+			output.Steps = append(output.Steps, stepJSON{
+				StepNumber:  step.StepNumber,
+				Title:       step.Title,
+				Rule:        step.Rule,
+				Clause:      step.Clause,
+				ClauseTitle: step.ClauseTitle,
+				Description: step.Description,
+				Excerpt:     step.Excerpt,
+				References:  step.References,
+			})
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+
+	// Text format
+	fmt.Fprint(w, path.String())
+	return nil
+}
+
+// --- Corpus regression commands ---
@@ -0,0 +1,391 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/simulate"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+func matchCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "match",
+		Short: "Match a scenario to applicable provisions",
+		Long: `Match a compliance scenario to applicable provisions in the regulation.
+
+Finds provisions that are:
+  - DIRECT: Directly applicable (grants relevant rights or imposes relevant obligations)
+  - TRIGGERED: Triggered by direct provisions (referenced by or references direct matches)
+  - RELATED: Related by keywords
+
+Built-in scenarios:
+  consent_withdrawal  - Data subject withdraws consent
+  access_request     - Data subject requests access to data
+  erasure_request    - Data subject requests erasure of data
+  data_breach        - Personal data breach handling
+
+The regulation is read from --source, or from the library documents given
+with --documents or --collection, each matched in turn.
+
+Examples:
+  regula match --scenario consent_withdrawal --source gdpr.txt
+  regula match --scenario access_request --source gdpr.txt --format json
+  regula match --scenario data_breach --source gdpr.txt --format table
+  regula match --scenario data_breach --documents eu-gdpr,us-ca-ccpa
+  regula match --scenario access_request --collection privacy-laws`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scenarioName, _ := cmd.Flags().GetString("scenario")
+			source, _ := cmd.Flags().GetString("source")
+			formatStr, _ := cmd.Flags().GetString("format")
+			baseURI, _ := cmd.Flags().GetString("base-uri")
+			listScenarios, _ := cmd.Flags().GetBool("list-scenarios")
+			documents, _ := cmd.Flags().GetString("documents")
+			libraryPath, _ := cmd.Flags().GetString("path")
+
+			// List available scenarios
+			if listScenarios {
+				fmt.Fprintln(app.Stdout, "Available scenarios:")
+				for name, s := range simulate.PredefinedScenarios {
+					fmt.Fprintf(app.Stdout, "  %-20s %s\n", name, s.Description)
+				}
+				return nil
+			}
+
+			if scenarioName == "" {
+				return &usageError{err: fmt.Errorf("--scenario flag is required\nUse --list-scenarios to see available scenarios")}
+			}
+
+			if source == "" && documents == "" {
+				return &usageError{err: fmt.Errorf("--source, --documents, or --collection is required")}
+			}
+
+			// Get scenario
+			scenario, ok := simulate.PredefinedScenarios[scenarioName]
+			if !ok {
+				return fmt.Errorf("unknown scenario: %s\nUse --list-scenarios to see available scenarios", scenarioName)
+			}
+
+			if source != "" {
+				matcher, err := loadScenarioMatcher(source, "", libraryPath, baseURI)
+				if err != nil {
+					return err
+				}
+				return writeMatchResult(app.Stdout, matcher.Match(scenario), formatStr)
+			}
+
+			var documentIDs []string
+			for _, documentID := range strings.Split(documents, ",") {
+				if documentID = strings.TrimSpace(documentID); documentID != "" {
+					documentIDs = append(documentIDs, documentID)
+				}
+			}
+			var matches []documentMatch
+			for _, documentID := range documentIDs {
+				matcher, err := loadScenarioMatcher("", documentID, libraryPath, baseURI)
+				if err != nil {
+					return err
+				}
+				matches = append(matches, documentMatch{Document: documentID, Result: matcher.Match(scenario)})
+			}
+
+			if formatStr == "json" {
+				data, err := json.MarshalIndent(matches, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to serialize result: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+				return nil
+			}
+			for i, match := range matches {
+				if i > 0 {
+					fmt.Fprintln(app.Stdout)
+				}
+				fmt.Fprintf(app.Stdout, "== %s ==\n", match.Document)
+				if err := writeMatchResult(app.Stdout, match.Result, formatStr); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("scenario", "S", "", "Scenario name (consent_withdrawal, access_request, etc.)")
+	cmd.Flags().StringP("source", "s", "", "Source document to analyze")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text, json, table)")
+	cmd.Flags().String("base-uri", "https://regula.dev/regulations/", "Base URI for the graph")
+	cmd.Flags().Bool("list-scenarios", false, "List available scenarios")
+	cmd.Flags().String("documents", "", "Comma-separated library document IDs to match instead of --source")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+
+	return cmd
+}
+
+// documentMatch is the match result for one library document.
+type documentMatch struct {
+	Document string                `json:"document"`
+	Result   *simulate.MatchResult `json:"result"`
+}
+
+// writeMatchResult writes result to w in formatStr: text, json, or table.
+func writeMatchResult(w io.Writer, result *simulate.MatchResult, formatStr string) error {
+	switch formatStr {
+	case "json":
+		data, err := result.ToJSON()
+		if err != nil {
+			return fmt.Errorf("failed to serialize result: %w", err)
+		}
+		fmt.Fprintln(w, string(data))
+	case "table":
+		fmt.Fprintln(w, result.FormatTable())
+	default:
+		fmt.Fprintln(w, result.String())
+	}
+	return nil
+}
+
+func simulateCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Simulate a compliance scenario",
+		Long: `Evaluate compliance scenarios against the regulation graph.
+
+Scenarios are YAML (or JSON) files describing the entities and actions
+involved, or the name of a built-in scenario. A directory runs every
+scenario file it contains.
+
+Scenarios may declare expected outcomes under "expect":
+
+  name: Erasure request
+  document: eu-gdpr
+  actions:
+    - type: request_erasure
+      actor: data_subject
+  expect:
+    must_match:
+      - {provision: Art17, relevance: direct}
+    required_rights: [RightToErasure]
+    required_obligations: [ResponseObligation]
+    forbidden_matches: [Art99]
+
+With --assert, each expectation is checked and the command exits with
+status 1 if any fails, so scenario suites can run as regulation unit
+tests in CI against an ingested corpus.
+
+The regulation is read from --source, or from the library document named
+by --document or the scenario's "document" field.
+
+Examples:
+  regula simulate --scenario consent-withdrawal.yaml --source gdpr.txt
+  regula simulate --scenario erasure_request --document eu-gdpr
+  regula simulate --scenario scenarios/ --assert`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scenarioArgs, _ := cmd.Flags().GetStringSlice("scenario")
+			source, _ := cmd.Flags().GetString("source")
+			documentID, _ := cmd.Flags().GetString("document")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			baseURI, _ := cmd.Flags().GetString("base-uri")
+			outputFormat, _ := cmd.Flags().GetString("output")
+			assertMode, _ := cmd.Flags().GetBool("assert")
+
+			if len(scenarioArgs) == 0 {
+				return &usageError{err: fmt.Errorf("--scenario flag is required")}
+			}
+			if outputFormat != "report" && outputFormat != "json" {
+				return fmt.Errorf("unknown output format: %s (use report or json)", outputFormat)
+			}
+
+			var scenarios []*simulate.Scenario
+			for _, scenarioArg := range scenarioArgs {
+				if predefined, ok := simulate.PredefinedScenarios[scenarioArg]; ok {
+					scenarios = append(scenarios, predefined)
+					continue
+				}
+				loaded, err := simulate.LoadScenarioSuite([]string{scenarioArg})
+				if err != nil {
+					return err
+				}
+				scenarios = append(scenarios, loaded...)
+			}
+			if len(scenarios) == 0 {
+				return fmt.Errorf("no scenarios found in %s", strings.Join(scenarioArgs, ", "))
+			}
+
+			matchers := make(map[string]*simulate.ProvisionMatcher)
+			var results []*simulate.MatchResult
+			var reports []*simulate.AssertionReport
+			for _, scenario := range scenarios {
+				target := documentID
+				if target == "" {
+					target = scenario.Document
+				}
+				if source != "" {
+					target = ""
+				}
+
+				matcher, ok := matchers[target]
+				if !ok {
+					var err error
+					matcher, err = loadScenarioMatcher(source, target, libraryPath, baseURI)
+					if err != nil {
+						return fmt.Errorf("scenario %q: %w", scenario.Name, err)
+					}
+					matchers[target] = matcher
+				}
+
+				result := matcher.Match(scenario)
+				results = append(results, result)
+				if assertMode {
+					report := result.Assert(scenario.Expect)
+					if source != "" {
+						report.Document = source
+					} else {
+						report.Document = target
+					}
+					reports = append(reports, report)
+				}
+			}
+
+			if !assertMode {
+				for i, result := range results {
+					if outputFormat == "json" {
+						data, err := result.ToJSON()
+						if err != nil {
+							return fmt.Errorf("failed to serialize result: %w", err)
+						}
+						fmt.Fprintln(app.Stdout, string(data))
+						continue
+					}
+					if i > 0 {
+						fmt.Fprintln(app.Stdout)
+					}
+					fmt.Fprint(app.Stdout, result.String())
+				}
+				return nil
+			}
+
+			suite := simulate.NewSuiteResult(reports)
+			if outputFormat == "json" {
+				data, err := suite.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize assertions: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+			} else {
+				fmt.Fprint(app.Stdout, suite.String())
+			}
+
+			// Exit code 1 if any expectation failed (useful for CI/pipeline integration)
+			if !suite.OK() {
+				return exitWithCode(cmd, 1)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceP("scenario", "s", nil, "Scenario file, directory, or built-in name (repeatable)")
+	cmd.Flags().String("source", "", "Source document to evaluate against")
+	cmd.Flags().StringP("document", "d", "", "Library document ID to evaluate against (overrides the scenario's document)")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("base-uri", "https://regula.dev/regulations/", "Base URI for the graph when using --source")
+	cmd.Flags().StringP("output", "o", "report", "Output format (report, json)")
+	cmd.Flags().Bool("assert", false, "Check scenario expectations and exit non-zero if any fail")
+
+	return cmd
+}
+
+// loadScenarioMatcher builds a provision matcher over a source file, or
+// over a library document's cached source and stored graph.
+func loadScenarioMatcher(source string, documentID string, libraryPath string, baseURI string) (*simulate.ProvisionMatcher, error) {
+	parser := newParserWithPatterns()
+	semExtractor := extract.NewSemanticExtractor()
+
+	if source == "" {
+		if documentID == "" {
+			return nil, fmt.Errorf("no regulation to evaluate: use --source, --document, or set document in the scenario")
+		}
+		lib, err := library.Open(libraryPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open library: %w", err)
+		}
+		if lib.GetDocument(documentID) == nil {
+			return nil, fmt.Errorf("document %q not found in library", documentID)
+		}
+		sourceText, err := lib.LoadSourceText(documentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load source for %s: %w", documentID, err)
+		}
+		tripleStore, err := lib.LoadTripleStore(documentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s from library: %w", documentID, err)
+		}
+		doc, err := parser.Parse(bytes.NewReader(sourceText))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse document: %w", err)
+		}
+		annotations := semExtractor.ExtractFromDocument(doc)
+		return simulate.NewProvisionMatcher(tripleStore, lib.DocumentBaseURI(documentID), annotations, doc), nil
+	}
+
+	file, err := os.Open(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open source: %w", err)
+	}
+	defer file.Close()
+
+	doc, err := parser.Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+
+	tripleStore := store.NewTripleStore()
+	builder := store.NewGraphBuilder(tripleStore, baseURI)
+	resolver := extract.NewReferenceResolver(baseURI, extractDocID(source))
+	resolver.IndexDocument(doc)
+	if _, err := builder.BuildComplete(doc, extract.NewDefinitionExtractor(), extract.NewReferenceExtractor(), resolver, semExtractor); err != nil {
+		return nil, fmt.Errorf("failed to build graph: %w", err)
+	}
+
+	annotations := semExtractor.ExtractFromDocument(doc)
+	return simulate.NewProvisionMatcher(tripleStore, baseURI, annotations, doc), nil
+}
+
+func auditCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Generate audit trail for a decision",
+		Long: `Generate an audit trail showing the reasoning chain for a decision.
+
+Example:
+  regula audit --decision "data-deletion-request-123"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			decision, _ := cmd.Flags().GetString("decision")
+
+			if decision == "" {
+				return &usageError{err: fmt.Errorf("--decision flag is required")}
+			}
+
+			fmt.Fprintf(app.Stdout, "Generating audit trail for: %s\n", decision)
+			fmt.Fprintln(app.Stdout, "\nAudit Trail:")
+			fmt.Fprintln(app.Stdout, "  - Decision ID: "+decision)
+			fmt.Fprintln(app.Stdout, "  - Timestamp: [calculating...]")
+			fmt.Fprintln(app.Stdout, "  - Applicable provisions: [calculating...]")
+			fmt.Fprintln(app.Stdout, "  - Reasoning chain: [calculating...]")
+			fmt.Fprintln(app.Stdout, "  - Proofs verified: [calculating...]")
+			fmt.Fprintln(app.Stdout, "\n[Not implemented - Phase 6]")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("decision", "d", "", "Decision ID to audit")
+	cmd.Flags().StringP("output", "o", "text", "Output format (text, json, prov)")
+
+	return cmd
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/badge"
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/spf13/cobra"
+)
+
+func statusCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show library health for dashboards and badges",
+		Long: `Summarize the health of a library: document count, the last recorded
+validation score, the broken link count from the last recorded link check,
+and open merge conflicts between documents.
+
+Validation and link check results are recorded with --record:
+  regula validate --source gdpr.txt --record
+  regula validate --source gdpr.txt --check links --record
+
+The overall status is failing when the last validation failed or a
+document failed to ingest, warning on validation warnings, broken links, or
+open conflicts, and passing otherwise.
+
+Use --badge-json (or --format json) for a compact JSON summary for
+dashboard tiles, or --badge-svg to render a status badge for one metric (health, documents,
+score, links, conflicts).
+
+Examples:
+  regula status
+  regula status --badge-json
+  regula status --badge-svg --metric score --output badges/score.svg`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			badgeJSON, _ := cmd.Flags().GetBool("badge-json")
+			badgeSVG, _ := cmd.Flags().GetBool("badge-svg")
+			metric, _ := cmd.Flags().GetString("metric")
+			outputPath, _ := cmd.Flags().GetString("output")
+			formatStr, _ := cmd.Flags().GetString("format")
+
+			if badgeJSON && badgeSVG {
+				return &usageError{err: fmt.Errorf("--badge-json and --badge-svg are mutually exclusive")}
+			}
+
+			lib, err := library.Open(libraryPath)
+			if err != nil {
+				return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+			}
+			summary, err := lib.HealthSummary()
+			if err != nil {
+				return err
+			}
+
+			var output string
+			switch {
+			case badgeJSON, formatStr == "json" && !badgeSVG:
+				data, err := json.Marshal(summary)
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", err)
+				}
+				output = string(data) + "\n"
+			case badgeSVG:
+				statusBadge, err := badge.ForHealth(summary, metric)
+				if err != nil {
+					return err
+				}
+				output = statusBadge.SVG()
+			default:
+				output = formatHealthSummary(lib.Path(), summary)
+			}
+
+			if outputPath != "" {
+				if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+					return fmt.Errorf("failed to write output file: %w", err)
+				}
+				fmt.Fprintf(app.Stderr, "Status written to %s\n", outputPath)
+				return nil
+			}
+			fmt.Fprint(app.Stdout, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().Bool("badge-json", false, "Emit a compact JSON health summary")
+	cmd.Flags().Bool("badge-svg", false, "Render an SVG status badge")
+	cmd.Flags().String("metric", "health", "Badge metric (health, documents, score, links, conflicts)")
+	cmd.Flags().String("output", "", "Output file path (default: stdout)")
+	cmd.Flags().String("format", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+// formatHealthSummary formats a library health summary for the terminal.
+func formatHealthSummary(libraryPath string, summary *library.HealthSummary) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("Library: %s\n", libraryPath))
+	builder.WriteString(fmt.Sprintf("Status:  %s\n\n", strings.ToUpper(summary.Status)))
+	builder.WriteString(fmt.Sprintf("Documents:        %d", summary.Documents))
+	if summary.FailedDocuments > 0 {
+		builder.WriteString(fmt.Sprintf(" (%d failed)", summary.FailedDocuments))
+	}
+	builder.WriteString("\n")
+
+	if summary.ValidationScore != nil {
+		builder.WriteString(fmt.Sprintf("Validation score: %.1f%% %s (%s)\n", *summary.ValidationScore*100,
+			summary.ValidationStatus, summary.ValidatedAt.Local().Format("2006-01-02 15:04")))
+	} else {
+		builder.WriteString("Validation score: not recorded\n")
+	}
+	if summary.BrokenLinks != nil {
+		builder.WriteString(fmt.Sprintf("Broken links:     %d (%s)\n", *summary.BrokenLinks,
+			summary.LinksCheckedAt.Local().Format("2006-01-02 15:04")))
+	} else {
+		builder.WriteString("Broken links:     not recorded\n")
+	}
+	builder.WriteString(fmt.Sprintf("Open conflicts:   %d\n", summary.OpenConflicts))
+
+	return builder.String()
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStatusCmd_BadgeJSON(t *testing.T) {
+	libraryPath := filepath.Join(t.TempDir(), "lib")
+	if _, stderr, code := runCLI(t, "library", "init", "--path", libraryPath); code != 0 {
+		t.Fatalf("library init: %s", stderr)
+	}
+
+	stdout, stderr, code := runCLI(t, "status", "--path", libraryPath, "--badge-json")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	var summary struct {
+		Status    string `json:"status"`
+		Documents int    `json:"documents"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &summary); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if summary.Documents != 0 || summary.Status == "" {
+		t.Errorf("summary = %+v, want an empty library", summary)
+	}
+}
+
+func TestStatusCmd_MissingLibrary(t *testing.T) {
+	_, stderr, code := runCLI(t, "status", "--path", filepath.Join(t.TempDir(), "missing"))
+	if code != 1 || !strings.Contains(stderr, "library not found") {
+		t.Errorf("status without a library = %d %q", code, stderr)
+	}
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/coolbeans/regula/pkg/summary"
+	"github.com/spf13/cobra"
+)
+
+func summarizeCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "summarize",
+		Short: "Summarize a regulation chapter by chapter",
+		Long: `Generate a structured summary of a regulation from its knowledge
+graph. For each chapter and article it lists:
+
+  obligations  obligation nodes, with duty bearer and recurrence
+  rights       right nodes, with beneficiary
+  actors       the parties bound or benefited
+  deadlines    time limits in the text ("within 72 hours", "without
+               undue delay")
+  penalties    sentences setting fines, penalties, or imprisonment
+
+Everything is derived from the graph, so the summary is reproducible.
+Articles with nothing to report are counted but not listed.
+
+With --polish and --llm-endpoint, a language model writes a short prose
+overview of each chapter from the extracted facts. Overviews are marked as
+generated; the graph-derived lists are unchanged.
+
+Examples:
+  regula summarize --source gdpr.txt
+  regula summarize --source gdpr.txt --format html -o gdpr-summary.html
+  regula summarize --document eu-gdpr --format json
+  regula summarize --source gdpr.txt --polish --llm-endpoint http://localhost:11434/v1/chat/completions --llm-model llama3`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			documentID, _ := cmd.Flags().GetString("document")
+			polish, _ := cmd.Flags().GetBool("polish")
+			formatStr, _ := cmd.Flags().GetString("format")
+			outputPath, _ := cmd.Flags().GetString("output")
+
+			if source == "" && documentID == "" {
+				return &usageError{err: fmt.Errorf("--source or --document is required")}
+			}
+			llmClient, err := llmClientFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			if polish && llmClient == nil {
+				return &usageError{err: fmt.Errorf("--polish requires --llm-endpoint")}
+			}
+
+			tripleStore, err := loadAnalysisStore(cmd.Context(), source, libraryPath, documentID)
+			if err != nil {
+				return err
+			}
+			regulationSummary, err := summary.Summarize(tripleStore, "")
+			if err != nil {
+				return err
+			}
+			if polish {
+				for _, polishErr := range regulationSummary.Polish(cmd.Context(), llmClient) {
+					fmt.Fprintf(app.Stderr, "Warning: polish failed for %v\n", polishErr)
+				}
+			}
+
+			var output string
+			switch formatStr {
+			case "json":
+				data, err := regulationSummary.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize summary: %w", err)
+				}
+				output = string(data) + "\n"
+			case "html":
+				output = regulationSummary.ToHTML()
+			case "markdown", "md":
+				output = regulationSummary.ToMarkdown()
+			default:
+				return fmt.Errorf("unknown format %q (want markdown, html, or json)", formatStr)
+			}
+
+			if outputPath != "" {
+				if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+					return fmt.Errorf("failed to write summary: %w", err)
+				}
+				fmt.Fprintf(app.messageWriter(), "Summary written to %s\n", outputPath)
+				return nil
+			}
+			fmt.Fprint(app.Stdout, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document path")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("document", "", "Library document ID to summarize")
+	cmd.Flags().Bool("polish", false, "Add an LLM-written overview to each chapter (requires --llm-endpoint)")
+	addLLMFlags(cmd)
+	cmd.Flags().StringP("format", "f", "markdown", "Output format (markdown, html, json)")
+	cmd.Flags().StringP("output", "o", "", "Write the summary to a file instead of stdout")
+
+	return cmd
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSummarizeCmd(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "summarize", "--source", testdataPath(t, "gdpr.txt"))
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	for _, want := range []string{"# Summary: GDPR", "## Chapter III: Rights of the data subject", "### Article 83", "**Deadlines:**"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("summary missing %q", want)
+		}
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "summary.json")
+	_, stderr, code = runCLI(t, "summarize", "--source", testdataPath(t, "gdpr.txt"), "--format", "json", "-o", outputPath)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("read summary: %v", err)
+	}
+	var decoded struct {
+		Totals struct {
+			Articles int `json:"articles"`
+		} `json:"totals"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded.Totals.Articles != 99 {
+		t.Errorf("articles = %d, want 99", decoded.Totals.Articles)
+	}
+}
+
+func TestSummarizeCmd_Errors(t *testing.T) {
+	if _, _, code := runCLI(t, "summarize"); code == 0 {
+		t.Error("expected an error without --source or --document")
+	}
+	if _, _, code := runCLI(t, "summarize", "--source", testdataPath(t, "gdpr.txt"), "--polish"); code == 0 {
+		t.Error("expected an error for --polish without --llm-endpoint")
+	}
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/coolbeans/regula/pkg/telemetry"
+	"github.com/spf13/cobra"
+)
+
+// telemetrySession holds what configureTelemetry changed for one Execute,
+// so the process-wide logger and span exporter are restored afterwards.
+type telemetrySession struct {
+	cleanups []func()
+}
+
+type telemetrySessionKey struct{}
+
+func (session *telemetrySession) close() {
+	for i := len(session.cleanups) - 1; i >= 0; i-- {
+		session.cleanups[i]()
+	}
+	session.cleanups = nil
+}
+
+// configureTelemetry installs a structured logger on stderr when --log-level
+// or --log-format is set, and exports pipeline spans to --trace-file.
+// Without them logging is left as is: failed stages are still reported as
+// warnings, but per-stage timings (logged at debug) are not.
+func (app *App) configureTelemetry(cmd *cobra.Command) error {
+	levelFlag := cmd.Flags().Lookup("log-level")
+	formatFlag := cmd.Flags().Lookup("log-format")
+	traceFile, _ := cmd.Flags().GetString("trace-file")
+
+	session, _ := cmd.Context().Value(telemetrySessionKey{}).(*telemetrySession)
+	if session == nil {
+		session = &telemetrySession{}
+	}
+
+	if levelFlag.Changed || formatFlag.Changed {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(levelFlag.Value.String())); err != nil {
+			return fmt.Errorf("invalid --log-level %q (use debug, info, warn, or error)", levelFlag.Value.String())
+		}
+
+		options := &slog.HandlerOptions{Level: level}
+		var handler slog.Handler
+		switch formatFlag.Value.String() {
+		case "text":
+			handler = slog.NewTextHandler(app.Stderr, options)
+		case "json":
+			handler = slog.NewJSONHandler(app.Stderr, options)
+		default:
+			return fmt.Errorf("invalid --log-format %q (use text or json)", formatFlag.Value.String())
+		}
+
+		previous := slog.Default()
+		slog.SetDefault(slog.New(handler))
+		session.cleanups = append(session.cleanups, func() { slog.SetDefault(previous) })
+	}
+
+	if traceFile != "" {
+		exporter, err := telemetry.CreateJSONExporter(traceFile)
+		if err != nil {
+			return err
+		}
+		previous := telemetry.SetExporter(exporter)
+		session.cleanups = append(session.cleanups, func() {
+			telemetry.SetExporter(previous)
+			exporter.Close()
+		})
+	}
+	return nil
+}
+
+// withTelemetrySession returns a context for running the root command in
+// and a function restoring the logger and exporter it configured.
+func withTelemetrySession(ctx context.Context) (context.Context, func()) {
+	session := &telemetrySession{}
+	return context.WithValue(ctx, telemetrySessionKey{}, session), session.close
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/telemetry"
+)
+
+func TestTraceFile_RecordsIngestStages(t *testing.T) {
+	traceFile := filepath.Join(t.TempDir(), "trace.jsonl")
+	_, stderr, code := runCLI(t, "ingest", "--source", testdataPath(t, "gdpr.txt"), "--trace-file", traceFile)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+
+	file, err := os.Open(traceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	spans := make(map[string]telemetry.SpanData)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var span telemetry.SpanData
+		if err := json.Unmarshal(scanner.Bytes(), &span); err != nil {
+			t.Fatalf("invalid span line: %v\n%s", err, scanner.Text())
+		}
+		spans[span.Name] = span
+	}
+
+	root, ok := spans["ingest"]
+	if !ok {
+		t.Fatalf("no ingest span in %v", spans)
+	}
+	for _, stage := range []string{"parse", "extract_definitions", "extract_references", "extract_semantics", "resolve", "build"} {
+		span, ok := spans[stage]
+		if !ok {
+			t.Errorf("no %s span", stage)
+			continue
+		}
+		if span.ParentID != root.SpanID || span.Attributes[telemetry.KeyDocumentID] != "GDPR" {
+			t.Errorf("%s span = %+v", stage, span)
+		}
+	}
+
+	// The exporter is released when the command finishes.
+	if previous := telemetry.SetExporter(nil); previous != nil {
+		t.Errorf("exporter still installed after Execute: %T", previous)
+	}
+}
+
+func TestLogLevel_Debug(t *testing.T) {
+	_, stderr, code := runCLI(t, "query", "--source", testdataPath(t, "gdpr.txt"), "--log-level", "debug", "--log-format", "json",
+		"SELECT ?a WHERE { ?a rdf:type reg:Article } LIMIT 1")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	for _, want := range []string{`"stage":"parse"`, `"stage":"build"`, `"stage":"query"`, `"document_id":"GDPR"`} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("debug log missing %s:\n%s", want, stderr)
+		}
+	}
+}
+
+func TestLogLevel_Invalid(t *testing.T) {
+	_, stderr, code := runCLI(t, "status", "--log-level", "loud")
+	if code != 1 || !strings.Contains(stderr, `invalid --log-level "loud"`) {
+		t.Errorf("invalid level = %d %q", code, stderr)
+	}
+}
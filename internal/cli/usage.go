@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/coolbeans/regula/pkg/usage"
+	"github.com/spf13/cobra"
+)
+
+func usageCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Report how served graphs are used",
+		Long: `Report provision and template usage recorded in server mode.
+
+'regula playground serve' records usage to usage.json in the library, and
+regulad records it to the file given with --usage-file.`,
+	}
+
+	cmd.AddCommand(usageHotspotsCmd(app))
+
+	return cmd
+}
+
+func usageHotspotsCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hotspots",
+		Short: "List the most-consulted provisions per document",
+		Long: `List the provisions consulted most often in server mode, ranked per
+document, with each provision's share of its document's accesses and the
+templates used most.
+
+A provision is consulted when a query or match result names it, when it is
+an impact analysis target, or when it is the focus of the playground graph.
+Results naming more than 50 provisions count toward templates only.
+
+Examples:
+  regula usage hotspots
+  regula usage hotspots --document GDPR --limit 20
+  regula usage hotspots --file regulad-usage.json --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			libraryPath, _ := cmd.Flags().GetString("path")
+			usagePath, _ := cmd.Flags().GetString("file")
+			document, _ := cmd.Flags().GetString("document")
+			limit, _ := cmd.Flags().GetInt("limit")
+			format, _ := cmd.Flags().GetString("format")
+
+			if usagePath == "" {
+				usagePath = filepath.Join(libraryPath, usage.FileName)
+			}
+			if _, err := os.Stat(usagePath); os.IsNotExist(err) {
+				return fmt.Errorf("no usage recorded at %s; start 'regula playground serve' or regulad --usage-file first", usagePath)
+			}
+			stats, err := usage.Load(usagePath)
+			if err != nil {
+				return err
+			}
+
+			report := usage.Hotspots(stats, document, limit)
+			switch format {
+			case "json":
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+			case "table":
+				fmt.Fprint(app.Stdout, usage.FormatHotspotsTable(report))
+			default:
+				return fmt.Errorf("unknown format: %s (use table or json)", format)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path")
+	cmd.Flags().String("file", "", "Usage file (default: <path>/usage.json)")
+	cmd.Flags().String("document", "", "Only report this document (e.g. GDPR)")
+	cmd.Flags().Int("limit", 10, "Provisions to list per document (0 for all)")
+	cmd.Flags().String("format", "table", "Output format (table, json)")
+
+	return cmd
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/usage"
+)
+
+func TestUsageHotspotsCmd(t *testing.T) {
+	usagePath := filepath.Join(t.TempDir(), usage.FileName)
+	tracker, err := usage.Open(usagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker.Record("rights-list", []string{"https://regula.dev/regulations/GDPR:Art17"})
+	if err := tracker.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr, code := runCLI(t, "usage", "hotspots", "--file", usagePath)
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+	for _, want := range []string{"GDPR (1 accesses)", "Art17", "rights-list"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("hotspots output missing %q:\n%s", want, stdout)
+		}
+	}
+
+	_, stderr, code = runCLI(t, "usage", "hotspots", "--file", usagePath, "--format", "xml")
+	if code != 1 || !strings.Contains(stderr, "unknown format") {
+		t.Errorf("bad format = %d %q", code, stderr)
+	}
+
+	_, stderr, code = runCLI(t, "usage", "hotspots", "--path", t.TempDir())
+	if code != 1 || !strings.Contains(stderr, "no usage recorded") {
+		t.Errorf("missing usage file = %d %q", code, stderr)
+	}
+}
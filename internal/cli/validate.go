@@ -0,0 +1,672 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/httpcache"
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/linkcheck"
+	"github.com/coolbeans/regula/pkg/ndjson"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/validate"
+	"github.com/spf13/cobra"
+)
+
+func validateCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate graph consistency and extraction quality",
+		Long: `Validate graph consistency and report extraction quality metrics.
+
+Checks:
+  - Reference resolution accuracy
+  - Graph connectivity (orphan provisions)
+  - Definition coverage (term usage)
+  - Semantic extraction (rights/obligations)
+  - Structure quality (completeness)
+
+Validation Profiles:
+  GDPR     - European General Data Protection Regulation
+  CCPA     - California Consumer Privacy Act
+  Generic  - Minimal criteria for unknown regulations
+
+Link Validation (--check links):
+  Validates external reference URIs with per-domain rate limiting.
+  Use --report to save results to a file (JSON or Markdown).
+  Use --since-last to keep a link history in the library, re-check only
+  links that were broken or last checked more than --link-ttl ago, and
+  report newly broken and newly fixed links since the previous run.
+
+Gold Standard Evaluation (--gold):
+  Compares extracted definitions, references, rights, and obligations with
+  a hand-labeled JSON file and reports precision, recall, and F1 for each
+  extractor and each type. A gold file may label only some articles by
+  listing them under "articles".
+
+Profile Auto-Generation:
+  --suggest-profile    Analyze document and print suggested profile
+  --generate-profile   Generate profile and save to YAML file
+  --load-profile       Load custom validation profile from YAML file
+
+Example:
+  regula validate --source gdpr.txt
+  regula validate --source gdpr.txt --threshold 0.85
+  regula validate --source gdpr.txt --format json
+  regula validate --source gdpr.txt --check references
+  regula validate --source ccpa.txt --profile CCPA
+  regula validate --source gdpr.txt --check gates
+  regula validate --source gdpr.txt --check gates --report gates.xlsx
+  regula validate --source gdpr.txt --check links
+  regula validate --source gdpr.txt --check links --report links.json
+  regula validate --source gdpr.txt --check links --since-last
+  regula validate --source gdpr.txt --gold gdpr-gold.json
+  regula validate --source gdpr.txt --suggest-profile
+  regula validate --source gdpr.txt --suggest-profile --format json
+  regula validate --source gdpr.txt --generate-profile gdpr-custom.yaml
+  regula validate --source gdpr.txt --load-profile gdpr-custom.yaml
+  regula validate --source gdpr.txt --record       Record the score for 'regula status'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, _ := cmd.Flags().GetString("source")
+			checkType, _ := cmd.Flags().GetString("check")
+			formatStr, _ := cmd.Flags().GetString("format")
+			baseURI, _ := cmd.Flags().GetString("base-uri")
+			threshold, _ := cmd.Flags().GetFloat64("threshold")
+			profileName, _ := cmd.Flags().GetString("profile")
+			skipGates, _ := cmd.Flags().GetStringSlice("skip-gates")
+			strictMode, _ := cmd.Flags().GetBool("strict")
+			failOnWarn, _ := cmd.Flags().GetBool("fail-on-warn")
+			shapesPath, _ := cmd.Flags().GetString("shapes")
+			cycleGate, _ := cmd.Flags().GetBool("cycle-gate")
+			reportPath, _ := cmd.Flags().GetString("report")
+			suggestProfile, _ := cmd.Flags().GetBool("suggest-profile")
+			generateProfilePath, _ := cmd.Flags().GetString("generate-profile")
+			loadProfilePath, _ := cmd.Flags().GetString("load-profile")
+			mappingsPath, _ := cmd.Flags().GetString("mappings")
+			recordResult, _ := cmd.Flags().GetBool("record")
+			goldPath, _ := cmd.Flags().GetString("gold")
+			libraryPath, _ := cmd.Flags().GetString("path")
+			httpCacheDir, _ := cmd.Flags().GetString("http-cache")
+			sinceLast, _ := cmd.Flags().GetBool("since-last")
+			linkTTL, _ := cmd.Flags().GetDuration("link-ttl")
+
+			if source == "" {
+				return &usageError{err: fmt.Errorf("--source flag is required")}
+			}
+
+			// Check if file exists
+			fileInfo, err := os.Stat(source)
+			if os.IsNotExist(err) {
+				return fmt.Errorf("source file not found: %s", source)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to stat source: %w", err)
+			}
+
+			// Parse document
+			file, err := os.Open(source)
+			if err != nil {
+				return fmt.Errorf("failed to open source: %w", err)
+			}
+			defer file.Close()
+
+			parser := newParserWithPatterns()
+			doc, err := parser.Parse(file)
+			if err != nil {
+				return fmt.Errorf("failed to parse document: %w", err)
+			}
+
+			// Extract definitions
+			defExtractor := extract.NewDefinitionExtractor()
+			definitions := defExtractor.ExtractDefinitions(doc)
+
+			// Extract references
+			refExtractor := extract.NewReferenceExtractor()
+			refs := refExtractor.ExtractFromDocument(doc)
+
+			// Create resolver and index document
+			resolver := extract.NewReferenceResolver(baseURI, extractDocID(source))
+			resolver.IndexDocument(doc)
+			if _, err := applyReferenceMappings(resolver, source, mappingsPath); err != nil {
+				return err
+			}
+
+			// Resolve all references
+			resolved := resolver.ResolveAll(refs)
+
+			// Extract term usages
+			usageExtractor := extract.NewTermUsageExtractor(definitions)
+			usages := usageExtractor.ExtractFromDocument(doc)
+
+			// Extract semantics
+			semExtractor := extract.NewSemanticExtractor()
+			annotations := semExtractor.ExtractFromDocument(doc)
+
+			// Handle --gold: score extraction against hand-labeled annotations
+			if goldPath != "" {
+				gold, err := validate.LoadGoldStandard(goldPath)
+				if err != nil {
+					return err
+				}
+				goldReport := validate.EvaluateGold(gold, definitions, refs, annotations)
+				if formatStr == "json" {
+					jsonData, err := goldReport.ToJSON()
+					if err != nil {
+						return fmt.Errorf("failed to serialize gold report: %w", err)
+					}
+					fmt.Fprintln(app.Stdout, string(jsonData))
+					return nil
+				}
+				fmt.Fprint(app.Stdout, goldReport.String())
+				return nil
+			}
+
+			// Build graph for connectivity check
+			ts := store.NewTripleStore()
+			builder := store.NewGraphBuilder(ts, baseURI)
+			_, err = builder.BuildComplete(doc, defExtractor, refExtractor, resolver, semExtractor)
+			if err != nil {
+				return fmt.Errorf("failed to build graph: %w", err)
+			}
+
+			// Handle --suggest-profile: analyze and output suggested profile
+			if suggestProfile {
+				profileGenerator := validate.NewProfileGenerator()
+				profileSuggestion := profileGenerator.SuggestProfile(doc, definitions, resolved, annotations, usages)
+
+				switch formatStr {
+				case "json":
+					jsonData, jsonErr := profileSuggestion.ToJSON()
+					if jsonErr != nil {
+						return fmt.Errorf("failed to serialize profile suggestion: %w", jsonErr)
+					}
+					fmt.Fprintln(app.Stdout, string(jsonData))
+				case "yaml":
+					yamlData, yamlErr := profileSuggestion.ToYAML()
+					if yamlErr != nil {
+						return fmt.Errorf("failed to serialize profile suggestion: %w", yamlErr)
+					}
+					fmt.Fprint(app.Stdout, string(yamlData))
+				default:
+					fmt.Fprint(app.Stdout, profileSuggestion.String())
+				}
+				return nil
+			}
+
+			// Handle --generate-profile: generate and save profile to YAML file
+			if generateProfilePath != "" {
+				profileGenerator := validate.NewProfileGenerator()
+				profileSuggestion := profileGenerator.SuggestProfile(doc, definitions, resolved, annotations, usages)
+
+				if err := validate.SaveProfileToFile(profileSuggestion, generateProfilePath); err != nil {
+					return fmt.Errorf("failed to save profile: %w", err)
+				}
+				fmt.Fprintf(app.messageWriter(), "Profile saved to: %s\n", generateProfilePath)
+				fmt.Fprintf(app.Stdout, "Confidence: %.0f%%\n", profileSuggestion.Confidence*100)
+				return nil
+			}
+
+			// Handle legacy check type for backwards compatibility
+			if checkType == "references" {
+				report := extract.GenerateReport(resolved)
+				if formatStr == "json" {
+					encoder := json.NewEncoder(app.Stdout)
+					encoder.SetIndent("", "  ")
+					return encoder.Encode(report)
+				}
+				if formatStr == "ndjson" {
+					encoder := ndjson.NewEncoder(app.Stdout)
+					for _, resolvedRef := range resolved {
+						if err := encoder.EncodeRecord("reference", resolvedRef); err != nil {
+							return err
+						}
+					}
+					summary := *report
+					summary.UnresolvedRefs = nil
+					return encoder.EncodeRecord("summary", summary)
+				}
+				fmt.Fprintln(app.Stdout, report.String())
+				if report.ResolutionRate >= 0.85 {
+					fmt.Fprintf(app.Stdout, "Status: PASS (resolution rate %.1f%% >= 85%%)\n", report.ResolutionRate*100)
+				} else {
+					fmt.Fprintf(app.Stdout, "Status: FAIL (resolution rate %.1f%% < 85%%)\n", report.ResolutionRate*100)
+					return fmt.Errorf("resolution rate below 85%% target")
+				}
+				return nil
+			}
+
+			// Gate-based validation
+			if checkType == "gates" {
+				shapeSet, err := loadShapeSet(shapesPath)
+				if err != nil {
+					return fmt.Errorf("failed to load shapes: %w", err)
+				}
+				gateConfig := &validate.ValidationConfig{
+					Thresholds: make(map[string]float64),
+					SkipGates:  skipGates,
+					StrictMode: strictMode,
+					FailOnWarn: failOnWarn,
+					Shapes:     shapeSet,
+				}
+				gatePipeline := validate.NewGatePipeline(gateConfig)
+				gatePipeline.RegisterDefaultGates()
+				if cycleGate {
+					gatePipeline.RegisterGate(validate.NewCycleGate())
+				}
+
+				// Stream each gate result as it finishes
+				var gateEncoder *ndjson.Encoder
+				if formatStr == "ndjson" {
+					gateEncoder = ndjson.NewEncoder(app.Stdout)
+					gatePipeline.SetResultCallback(func(gateResult *validate.GateResult) {
+						gateEncoder.EncodeRecord("gate", gateResult)
+					})
+				}
+
+				gateContext := &validate.ValidationContext{
+					SourcePath:         source,
+					SourceSize:         fileInfo.Size(),
+					Document:           doc,
+					Definitions:        definitions,
+					References:         refs,
+					Semantics:          annotations,
+					TermUsages:         usages,
+					ResolvedReferences: resolved,
+					TripleStore:        ts,
+					Config:             gateConfig,
+				}
+
+				gateReport := gatePipeline.Run(gateContext)
+
+				// Save report to file if --report flag is set
+				if reportPath != "" {
+					var reportData []byte
+					if strings.HasSuffix(reportPath, ".html") {
+						reportData = []byte(gateReport.ToHTML())
+					} else if strings.HasSuffix(reportPath, ".md") {
+						reportData = []byte(gateReport.ToMarkdown())
+					} else if strings.HasSuffix(reportPath, ".xlsx") {
+						var xlsxErr error
+						reportData, xlsxErr = gateReport.ToXLSX()
+						if xlsxErr != nil {
+							return fmt.Errorf("failed to build gate workbook: %w", xlsxErr)
+						}
+					} else {
+						var jsonErr error
+						reportData, jsonErr = gateReport.ToJSON()
+						if jsonErr != nil {
+							return fmt.Errorf("failed to serialize gate report: %w", jsonErr)
+						}
+					}
+					if err := os.WriteFile(reportPath, reportData, 0644); err != nil {
+						return fmt.Errorf("failed to write report: %w", err)
+					}
+					fmt.Fprintf(app.statusWriter(formatStr), "Report saved to: %s\n\n", reportPath)
+				}
+
+				switch formatStr {
+				case "ndjson":
+					summary := struct {
+						*validate.GateReport
+						Results []*validate.GateResult `json:"results,omitempty"`
+					}{GateReport: gateReport}
+					if err := gateEncoder.EncodeRecord("summary", summary); err != nil {
+						return err
+					}
+				case "json":
+					jsonData, err := gateReport.ToJSON()
+					if err != nil {
+						return fmt.Errorf("failed to serialize gate report: %w", err)
+					}
+					fmt.Fprintln(app.Stdout, string(jsonData))
+				case "html":
+					fmt.Fprint(app.Stdout, gateReport.ToHTML())
+				case "markdown":
+					fmt.Fprint(app.Stdout, gateReport.ToMarkdown())
+				case "xlsx":
+					data, err := gateReport.ToXLSX()
+					if err != nil {
+						return fmt.Errorf("failed to build gate workbook: %w", err)
+					}
+					if err := app.writeWorkbook(data, "", ""); err != nil {
+						return err
+					}
+				default:
+					fmt.Fprint(app.Stdout, gateReport.String())
+				}
+
+				if recordResult {
+					gateStatus := validate.StatusPass
+					if !gateReport.OverallPass {
+						gateStatus = validate.StatusFail
+					}
+					if err := app.recordValidation(libraryPath, source, gateReport.TotalScore, 0, gateStatus, formatStr); err != nil {
+						return err
+					}
+				}
+
+				if !gateReport.OverallPass {
+					return fmt.Errorf("gate validation failed: overall score %.1f%%", gateReport.TotalScore*100)
+				}
+				return nil
+			}
+
+			// Link validation - validates external reference URIs
+			if checkType == "links" {
+				// Collect external URIs from resolved references
+				externalURIs := collectExternalURIs(resolved)
+
+				if len(externalURIs) == 0 {
+					fmt.Fprintln(app.statusWriter(formatStr), "No external URIs found to validate.")
+					if recordResult {
+						return app.recordLinkCheck(libraryPath, source, 0, 0, formatStr)
+					}
+					return nil
+				}
+
+				// With --since-last, reuse recent valid results from the link
+				// history and check only stale, broken, or new links.
+				linksToCheck := externalURIs
+				var linkHistory *linkcheck.History
+				var reusedResults []*linkcheck.LinkResult
+				historyPath := filepath.Join(libraryPath, "link-history.json")
+				if sinceLast {
+					linkHistory, err = linkcheck.LoadHistory(historyPath)
+					if err != nil {
+						return err
+					}
+					linksToCheck, reusedResults = linkHistory.Due(externalURIs, linkTTL, time.Now())
+					fmt.Fprintf(app.statusWriter(formatStr), "Reusing %d link result(s) checked within %s\n", len(reusedResults), linkTTL)
+				}
+
+				fmt.Fprintf(app.statusWriter(formatStr), "Validating %d external link(s)...\n\n", len(linksToCheck))
+
+				// Configure batch validator
+				config := linkcheck.DefaultBatchConfig()
+				config.DefaultRateLimit = 1 * time.Second
+				config.DefaultTimeout = 30 * time.Second
+				config.Concurrency = 3
+				config.HTTPCacheDir = httpCacheDir
+
+				// Add domain-specific rate limits for known legal sources
+				config.WithDomainConfig(&linkcheck.DomainConfig{
+					Domain:    "eur-lex.europa.eu",
+					RateLimit: 2 * time.Second,
+					Timeout:   60 * time.Second,
+				})
+				config.WithDomainConfig(&linkcheck.DomainConfig{
+					Domain:    "data.europa.eu",
+					RateLimit: 2 * time.Second,
+					Timeout:   60 * time.Second,
+				})
+				config.WithDomainConfig(&linkcheck.DomainConfig{
+					Domain:    "uscode.house.gov",
+					RateLimit: 2 * time.Second,
+					Timeout:   60 * time.Second,
+				})
+				config.WithDomainConfig(&linkcheck.DomainConfig{
+					Domain:    "ecfr.gov",
+					RateLimit: 2 * time.Second,
+					Timeout:   60 * time.Second,
+				})
+				config.WithDomainConfig(&linkcheck.DomainConfig{
+					Domain:    "www.legislation.gov.uk",
+					RateLimit: 2 * time.Second,
+					Timeout:   60 * time.Second,
+				})
+
+				validator := linkcheck.NewBatchValidator(config)
+
+				// Stream each link result for ndjson, otherwise show progress
+				var linkEncoder *ndjson.Encoder
+				if formatStr == "ndjson" {
+					linkEncoder = ndjson.NewEncoder(app.Stdout)
+					validator.SetResultCallback(func(linkResult *linkcheck.LinkResult) {
+						linkEncoder.EncodeRecord("link", linkResult)
+					})
+				} else {
+					validator.SetProgressCallback(func(progress *linkcheck.ValidationProgress) {
+						fmt.Fprintf(app.Stdout, "\r  Progress: %d/%d (%.1f%%) - %s",
+							progress.CompletedLinks, progress.TotalLinks,
+							progress.PercentComplete(), progress.CurrentDomain)
+					})
+				}
+
+				linkCtx, stopLinks := interruptContext(cmd.Context())
+				linkReport := validator.ValidateLinksWithContext(linkCtx, linksToCheck)
+				stopLinks()
+				if linkEncoder == nil {
+					fmt.Fprintf(app.Stdout, "\r%s\n", strings.Repeat(" ", 80)) // Clear progress line
+				}
+
+				if linkHistory != nil {
+					linkReport.Diff = linkHistory.Diff(linkReport)
+					linkReport.AddReused(reusedResults)
+					linkHistory.Record(linkReport)
+					if err := linkHistory.Save(historyPath); err != nil {
+						return err
+					}
+				}
+
+				// Output report
+				if reportPath != "" {
+					var reportData []byte
+					var err error
+
+					if strings.HasSuffix(reportPath, ".md") {
+						reportData = []byte(linkReport.ToMarkdown())
+					} else {
+						reportData, err = linkReport.ToJSON()
+						if err != nil {
+							return fmt.Errorf("failed to serialize link report: %w", err)
+						}
+					}
+
+					if err := os.WriteFile(reportPath, reportData, 0644); err != nil {
+						return fmt.Errorf("failed to write report: %w", err)
+					}
+					fmt.Fprintf(app.statusWriter(formatStr), "Report saved to: %s\n\n", reportPath)
+				}
+
+				// Print summary
+				if linkEncoder != nil {
+					summary := struct {
+						*linkcheck.ValidationReport
+						Results     []*linkcheck.LinkResult `json:"results,omitempty"`
+						BrokenLinks []*linkcheck.LinkResult `json:"broken_links,omitempty"`
+					}{ValidationReport: linkReport}
+					if err := linkEncoder.EncodeRecord("summary", summary); err != nil {
+						return err
+					}
+				} else if formatStr == "json" {
+					jsonData, err := linkReport.ToJSON()
+					if err != nil {
+						return fmt.Errorf("failed to serialize link report: %w", err)
+					}
+					fmt.Fprintln(app.Stdout, string(jsonData))
+				} else {
+					fmt.Fprint(app.Stdout, linkReport.String())
+				}
+
+				if recordResult {
+					if err := app.recordLinkCheck(libraryPath, source, linkReport.TotalLinks, len(linkReport.BrokenLinks), formatStr); err != nil {
+						return err
+					}
+				}
+
+				// Return error if too many broken links
+				if linkReport.SuccessRate() < threshold*100 {
+					return fmt.Errorf("link validation failed: success rate %.1f%% below threshold %.1f%%",
+						linkReport.SuccessRate(), threshold*100)
+				}
+
+				return nil
+			}
+
+			// Full validation
+			validator := validate.NewValidator(threshold)
+
+			// Set profile: --load-profile takes priority, then --profile, then auto-detect
+			if loadProfilePath != "" {
+				customProfile, loadErr := validate.LoadProfileFromFile(loadProfilePath)
+				if loadErr != nil {
+					return fmt.Errorf("failed to load profile: %w", loadErr)
+				}
+				validator.SetProfile(customProfile)
+				fmt.Fprintf(app.statusWriter(formatStr), "Loaded custom profile: %s\n\n", customProfile.Name)
+			} else if profileName != "" {
+				regType := validate.RegulationType(profileName)
+				if profile, ok := validate.ValidationProfiles[regType]; ok {
+					validator.SetRegulationType(regType)
+					validator.SetProfile(profile)
+				} else {
+					return fmt.Errorf("unknown validation profile: %s\nAvailable profiles: GDPR, CCPA, Generic", profileName)
+				}
+			}
+
+			result := validator.Validate(doc, resolved, definitions, usages, annotations, ts)
+
+			translator, err := outputTranslator(cmd, defaultLibraryPath())
+			if err != nil {
+				return err
+			}
+
+			// Save report to file if --report flag is set
+			if reportPath != "" {
+				var reportData []byte
+				if strings.HasSuffix(reportPath, ".html") {
+					reportData = []byte(result.ToHTMLLocalized(translator))
+				} else if strings.HasSuffix(reportPath, ".md") {
+					reportData = []byte(result.ToMarkdownLocalized(translator))
+				} else if strings.HasSuffix(reportPath, ".xlsx") {
+					var xlsxErr error
+					reportData, xlsxErr = result.ToXLSX()
+					if xlsxErr != nil {
+						return fmt.Errorf("failed to build validation workbook: %w", xlsxErr)
+					}
+				} else {
+					var jsonErr error
+					reportData, jsonErr = result.ToJSON()
+					if jsonErr != nil {
+						return fmt.Errorf("failed to serialize result: %w", jsonErr)
+					}
+				}
+				if err := os.WriteFile(reportPath, reportData, 0644); err != nil {
+					return fmt.Errorf("failed to write report: %w", err)
+				}
+				fmt.Fprintf(app.statusWriter(formatStr), "Report saved to: %s\n\n", reportPath)
+			}
+
+			// Output result
+			switch formatStr {
+			case "json":
+				data, err := result.ToJSON()
+				if err != nil {
+					return fmt.Errorf("failed to serialize result: %w", err)
+				}
+				fmt.Fprintln(app.Stdout, string(data))
+			case "ndjson":
+				if err := result.WriteNDJSON(app.Stdout); err != nil {
+					return err
+				}
+			case "html":
+				fmt.Fprint(app.Stdout, result.ToHTMLLocalized(translator))
+			case "markdown":
+				fmt.Fprint(app.Stdout, result.ToMarkdownLocalized(translator))
+			case "xlsx":
+				data, err := result.ToXLSX()
+				if err != nil {
+					return fmt.Errorf("failed to build validation workbook: %w", err)
+				}
+				if err := app.writeWorkbook(data, "", ""); err != nil {
+					return err
+				}
+			default:
+				fmt.Fprintln(app.Stdout, result.StringLocalized(translator))
+			}
+
+			if recordResult {
+				if err := app.recordValidation(libraryPath, source, result.OverallScore, result.Threshold, result.Status, formatStr); err != nil {
+					return err
+				}
+			}
+
+			// Return error if validation failed
+			if result.Status == validate.StatusFail {
+				return fmt.Errorf("validation failed: overall score %.1f%% below threshold %.1f%%",
+					result.OverallScore*100, result.Threshold*100)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("source", "s", "", "Source document path")
+	cmd.Flags().String("check", "all", "What to check (all, references, gates, links)")
+	cmd.Flags().StringP("format", "f", "text", "Output format (text, json, ndjson, html, markdown, xlsx)")
+	cmd.Flags().String("base-uri", "https://regula.dev/regulations/", "Base URI for the graph")
+	cmd.Flags().Float64("threshold", 0.80, "Pass/fail threshold (0.0-1.0)")
+	cmd.Flags().String("profile", "", "Validation profile (GDPR, CCPA, Generic) - auto-detected if not specified")
+	cmd.Flags().StringSlice("skip-gates", []string{}, "Gates to skip (V0,V1,V2,V3)")
+	cmd.Flags().Bool("strict", false, "Halt pipeline on gate failure")
+	cmd.Flags().Bool("fail-on-warn", false, "Halt pipeline on gate warnings")
+	cmd.Flags().String("shapes", "", "Shape constraints for gate V3 (YAML or .ttl file, or \"default\" for built-in shapes)")
+	cmd.Flags().Bool("cycle-gate", false, "Also run the optional gate that reports reference and definition cycles (with --check gates)")
+	cmd.Flags().String("report", "", "Save validation report to file (format based on extension: .html, .md, .xlsx, .json)")
+	cmd.Flags().Bool("record", false, "Record the result in the library for 'regula status'")
+	cmd.Flags().String("path", defaultLibraryPath(), "Library directory path (with --record and --since-last)")
+	cmd.Flags().String("http-cache", httpcache.DefaultDir, "HTTP cache directory for link revalidation (empty disables)")
+	cmd.Flags().Bool("since-last", false, "Re-check only links that are stale or were broken in the last run, and report changes (with --check links)")
+	cmd.Flags().Duration("link-ttl", linkcheck.DefaultHistoryTTL, "How long a valid link result is reused with --since-last")
+	cmd.Flags().Bool("suggest-profile", false, "Analyze document and print suggested validation profile")
+	cmd.Flags().String("generate-profile", "", "Generate validation profile and save to YAML file")
+	cmd.Flags().String("load-profile", "", "Load custom validation profile from YAML file")
+	cmd.Flags().String("gold", "", "Score extraction against a hand-labeled gold standard JSON file")
+	cmd.Flags().String("mappings", "", "Manual reference mapping file (default: <source>.mappings.yaml if present)")
+
+	return cmd
+}
+
+// recordValidation stores a validation score as the library's most recent
+// validation result.
+func (app *App) recordValidation(libraryPath, source string, score, threshold float64, status validate.ValidationStatus, formatStr string) error {
+	lib, err := library.Open(libraryPath)
+	if err != nil {
+		return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+	}
+	if err := lib.RecordValidation(library.ValidationRecord{
+		Source:      source,
+		Score:       score,
+		Threshold:   threshold,
+		Status:      string(status),
+		ValidatedAt: time.Now().UTC(),
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.statusWriter(formatStr), "Validation recorded in %s\n", libraryPath)
+	return nil
+}
+
+// recordLinkCheck stores a link check outcome as the library's most recent
+// link check result.
+func (app *App) recordLinkCheck(libraryPath, source string, totalLinks, broken int, formatStr string) error {
+	lib, err := library.Open(libraryPath)
+	if err != nil {
+		return fmt.Errorf("library not found at %s: %w", libraryPath, err)
+	}
+	if err := lib.RecordLinkCheck(library.LinkCheckRecord{
+		Source:     source,
+		TotalLinks: totalLinks,
+		Broken:     broken,
+		CheckedAt:  time.Now().UTC(),
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.statusWriter(formatStr), "Link check recorded in %s\n", libraryPath)
+	return nil
+}
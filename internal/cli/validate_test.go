@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/validate"
+)
+
+func TestValidateCmd_Gold(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "validate", "--source", testdataPath(t, "gdpr.txt"),
+		"--gold", testdataPath(t, "gdpr-gold.json"), "--format", "json")
+	if code != 0 {
+		t.Fatalf("exit status %d: %s", code, stderr)
+	}
+
+	var report validate.GoldReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if report.Document != "GDPR" || len(report.Extractors) != 4 {
+		t.Fatalf("report = %+v", report)
+	}
+	for _, score := range report.Extractors {
+		if score.TruePositives == 0 {
+			t.Errorf("%s: no true positives", score.Extractor)
+		}
+		if score.Extractor == validate.GoldDefinitions && score.F1 != 1 {
+			t.Errorf("definitions F1 = %v, want 1", score.F1)
+		}
+	}
+
+	stdout, _, code = runCLI(t, "validate", "--source", testdataPath(t, "gdpr.txt"), "--gold", testdataPath(t, "gdpr-gold.json"))
+	if code != 0 || !strings.Contains(stdout, "Gold Standard Evaluation (GDPR)") {
+		t.Errorf("unexpected table output (exit %d):\n%s", code, stdout)
+	}
+
+	if _, _, code := runCLI(t, "validate", "--source", testdataPath(t, "gdpr.txt"), "--gold", "missing.json"); code == 0 {
+		t.Error("expected an error for a missing gold standard")
+	}
+}
@@ -0,0 +1,11 @@
+// Package patterns embeds the built-in reference pattern packs, so that the
+// reference extractor carries them without the source tree. The format
+// patterns alongside are loaded from disk by pattern.Registry.
+package patterns
+
+import "embed"
+
+// ReferencePacks holds the YAML reference pattern packs under references/.
+//
+//go:embed references/*.yaml
+var ReferencePacks embed.FS
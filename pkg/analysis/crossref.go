@@ -8,22 +8,32 @@ import (
 	"strings"
 
 	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // CrossRefAnalyzer performs cross-legislation analysis across multiple documents.
 type CrossRefAnalyzer struct {
-	stores map[string]*store.TripleStore
-	labels map[string]string
+	stores  map[string]*store.TripleStore
+	labels  map[string]string
+	aligner *TermAligner
 }
 
 // NewCrossRefAnalyzer creates a new cross-reference analyzer.
 func NewCrossRefAnalyzer() *CrossRefAnalyzer {
 	return &CrossRefAnalyzer{
-		stores: make(map[string]*store.TripleStore),
-		labels: make(map[string]string),
+		stores:  make(map[string]*store.TripleStore),
+		labels:  make(map[string]string),
+		aligner: NewTermAligner(nil),
 	}
 }
 
+// SetTermAligner replaces the aligner CompareDocuments uses to match
+// definitions whose terms differ, such as one loaded with a synonym
+// crosswalk.
+func (a *CrossRefAnalyzer) SetTermAligner(aligner *TermAligner) {
+	a.aligner = aligner
+}
+
 // AddDocument registers a document's triple store for analysis.
 func (a *CrossRefAnalyzer) AddDocument(documentID, label string, tripleStore *store.TripleStore) {
 	a.stores[documentID] = tripleStore
@@ -106,15 +116,19 @@ type ComparisonResult struct {
 	DocumentA         DocumentSummary  `json:"document_a"`
 	DocumentB         DocumentSummary  `json:"document_b"`
 	SharedDefinitions []ConceptOverlap `json:"shared_definitions"`
-	SharedRights      []ConceptOverlap `json:"shared_rights"`
-	SharedObligations []ConceptOverlap `json:"shared_obligations"`
-	SharedExternalRefs []string        `json:"shared_external_refs"`
-	Statistics        ComparisonStats  `json:"statistics"`
+	// AlignedDefinitions pairs definitions whose terms differ but denote
+	// the same concept, by stem, synonym, or word overlap.
+	AlignedDefinitions []TermAlignment  `json:"aligned_definitions"`
+	SharedRights       []ConceptOverlap `json:"shared_rights"`
+	SharedObligations  []ConceptOverlap `json:"shared_obligations"`
+	SharedExternalRefs []string         `json:"shared_external_refs"`
+	Statistics         ComparisonStats  `json:"statistics"`
 }
 
 // ComparisonStats holds aggregate stats for a pair-wise comparison.
 type ComparisonStats struct {
 	SharedDefinitionCount  int `json:"shared_definition_count"`
+	AlignedDefinitionCount int `json:"aligned_definition_count"`
 	SharedRightCount       int `json:"shared_right_count"`
 	SharedObligationCount  int `json:"shared_obligation_count"`
 	SharedExternalRefCount int `json:"shared_external_ref_count"`
@@ -267,6 +281,7 @@ func (a *CrossRefAnalyzer) CompareDocuments(documentAID, documentBID string) *Co
 		DocumentA:          a.buildDocumentSummary(documentAID),
 		DocumentB:          a.buildDocumentSummary(documentBID),
 		SharedDefinitions:  make([]ConceptOverlap, 0),
+		AlignedDefinitions: make([]TermAlignment, 0),
 		SharedRights:       make([]ConceptOverlap, 0),
 		SharedObligations:  make([]ConceptOverlap, 0),
 		SharedExternalRefs: make([]string, 0),
@@ -298,6 +313,13 @@ func (a *CrossRefAnalyzer) CompareDocuments(documentAID, documentBID string) *Co
 		return result.SharedDefinitions[i].Concept < result.SharedDefinitions[j].Concept
 	})
 
+	// Align the remaining definitions whose terms differ
+	for _, alignment := range a.aligner.Align(definitionsA, definitionsB) {
+		if alignment.Method != AlignExact {
+			result.AlignedDefinitions = append(result.AlignedDefinitions, alignment)
+		}
+	}
+
 	// Find overlapping rights
 	for concept, provisionsA := range rightsA {
 		if provisionsB, exists := rightsB[concept]; exists {
@@ -341,6 +363,7 @@ func (a *CrossRefAnalyzer) CompareDocuments(documentAID, documentBID string) *Co
 	// Calculate statistics
 	result.Statistics = ComparisonStats{
 		SharedDefinitionCount:  len(result.SharedDefinitions),
+		AlignedDefinitionCount: len(result.AlignedDefinitions),
 		SharedRightCount:       len(result.SharedRights),
 		SharedObligationCount:  len(result.SharedObligations),
 		SharedExternalRefCount: len(result.SharedExternalRefs),
@@ -593,10 +616,7 @@ func (r *CrossRefResult) String() string {
 	sb.WriteString("| Document             | Articles | Defs | Refs  | Rts  | Obls | ExtRefs  |\n")
 	sb.WriteString("+----------------------+----------+------+-------+------+------+----------+\n")
 	for _, doc := range r.Documents {
-		label := doc.Label
-		if len(label) > 20 {
-			label = label[:17] + "..."
-		}
+		label := textutil.Truncate(doc.Label, 20)
 		sb.WriteString(fmt.Sprintf("| %-20s | %8d | %4d | %5d | %4d | %4d | %8d |\n",
 			label, doc.Articles, doc.Definitions, doc.References, doc.Rights, doc.Obligations, doc.ExternalRefs))
 	}
@@ -703,10 +723,7 @@ func (r *CrossRefResult) FormatTable() string {
 	}
 	sb.WriteString("\n| Metric           |")
 	for _, doc := range r.Documents {
-		label := doc.ID
-		if len(label) > 8 {
-			label = label[:8]
-		}
+		label := textutil.TruncateRunes(doc.ID, 8)
 		sb.WriteString(fmt.Sprintf(" %8s |", label))
 	}
 	sb.WriteString("\n+------------------+")
@@ -764,10 +781,7 @@ func (r *ExternalRefReport) String() string {
 		sb.WriteString("| Refs | Target Document                                   |\n")
 		sb.WriteString("+------+---------------------------------------------------+\n")
 		for _, cluster := range r.Clusters {
-			target := cluster.Target
-			if len(target) > 49 {
-				target = target[:46] + "..."
-			}
+			target := textutil.Truncate(cluster.Target, 49)
 			sb.WriteString(fmt.Sprintf("| %4d | %-49s |\n", cluster.Count, target))
 		}
 		sb.WriteString("+------+---------------------------------------------------+\n\n")
@@ -807,6 +821,7 @@ func (r *ComparisonResult) String() string {
 
 	// Overlaps
 	sb.WriteString(fmt.Sprintf("Shared definitions:     %d\n", r.Statistics.SharedDefinitionCount))
+	sb.WriteString(fmt.Sprintf("Aligned definitions:    %d\n", r.Statistics.AlignedDefinitionCount))
 	sb.WriteString(fmt.Sprintf("Shared rights:          %d\n", r.Statistics.SharedRightCount))
 	sb.WriteString(fmt.Sprintf("Shared obligations:     %d\n", r.Statistics.SharedObligationCount))
 	sb.WriteString(fmt.Sprintf("Shared external refs:   %d\n\n", r.Statistics.SharedExternalRefCount))
@@ -819,6 +834,14 @@ func (r *ComparisonResult) String() string {
 		sb.WriteString("\n")
 	}
 
+	if len(r.AlignedDefinitions) > 0 {
+		sb.WriteString("Aligned Definitions:\n")
+		for _, alignment := range r.AlignedDefinitions {
+			sb.WriteString(fmt.Sprintf("  - %s ≈ %s (%.2f, %s)\n", alignment.TermA, alignment.TermB, alignment.Score, alignment.Method))
+		}
+		sb.WriteString("\n")
+	}
+
 	if len(r.SharedRights) > 0 {
 		sb.WriteString("Shared Rights:\n")
 		for _, overlap := range r.SharedRights {
@@ -847,8 +870,5 @@ func (r *ComparisonResult) String() string {
 
 // truncate shortens a string to maxLen characters.
 func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen]
+	return textutil.TruncateRunes(s, maxLen)
 }
@@ -3,6 +3,8 @@ package analysis
 import (
 	"fmt"
 	"strings"
+
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // ToDOT generates a Graphviz DOT representation of the cross-reference result.
@@ -119,10 +121,7 @@ func (r *CrossRefResult) ToDOT() string {
 		targetNodeID := "ext_" + sanitizeDOTID(cluster.Target)
 		if !externalTargetNodes[targetNodeID] {
 			externalTargetNodes[targetNodeID] = true
-			targetLabel := cluster.Target
-			if len(targetLabel) > 30 {
-				targetLabel = targetLabel[:27] + "..."
-			}
+			targetLabel := textutil.Truncate(cluster.Target, 30)
 			sb.WriteString(fmt.Sprintf("  \"%s\" [label=\"%s\" shape=hexagon style=filled fillcolor=mistyrose];\n",
 				targetNodeID, escapeDOTLabel(targetLabel)))
 		}
@@ -191,10 +190,7 @@ func (r *ComparisonResult) ToDOT() string {
 	// Shared external refs
 	for _, ref := range r.SharedExternalRefs {
 		extNode := "shared_ext_" + sanitizeDOTID(ref)
-		extLabel := ref
-		if len(extLabel) > 25 {
-			extLabel = extLabel[:22] + "..."
-		}
+		extLabel := textutil.Truncate(ref, 25)
 		sb.WriteString(fmt.Sprintf("  \"%s\" [label=\"%s\" shape=hexagon style=filled fillcolor=mistyrose];\n",
 			extNode, escapeDOTLabel(extLabel)))
 		sb.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\" [color=red style=dashed];\n", nodeA, extNode))
@@ -167,6 +167,31 @@ func TestCompareDocuments_DefinitionOverlap(t *testing.T) {
 	}
 }
 
+func TestCompareDocuments_AlignedDefinitions(t *testing.T) {
+	analyzer := NewCrossRefAnalyzer()
+	analyzer.SetTermAligner(NewTermAligner([]SynonymGroup{{Terms: []string{"controller", "business"}}}))
+	storeA := buildTestStore(1, []string{"personal data", "controller", "processing"}, nil, nil, nil)
+	storeB := buildTestStore(1, []string{"personal data", "business", "processes"}, nil, nil, nil)
+
+	analyzer.AddDocument("gdpr", "GDPR", storeA)
+	analyzer.AddDocument("ccpa", "CCPA", storeB)
+
+	result := analyzer.CompareDocuments("gdpr", "ccpa")
+
+	if result.Statistics.SharedDefinitionCount != 1 {
+		t.Errorf("expected 1 shared definition, got %d", result.Statistics.SharedDefinitionCount)
+	}
+	if result.Statistics.AlignedDefinitionCount != 2 {
+		t.Fatalf("expected 2 aligned definitions, got %+v", result.AlignedDefinitions)
+	}
+	if alignment := result.AlignedDefinitions[0]; alignment.TermA != "controller" || alignment.TermB != "business" || alignment.Method != AlignSynonym {
+		t.Errorf("unexpected alignment: %+v", alignment)
+	}
+	if !strings.Contains(result.String(), "processing ≈ processes (0.90, stem)") {
+		t.Errorf("expected the stem alignment in the report:\n%s", result.String())
+	}
+}
+
 func TestCompareDocuments_RightsOverlap(t *testing.T) {
 	analyzer := NewCrossRefAnalyzer()
 	storeA := buildTestStore(2, nil, []string{"reg:RightOfAccess", "reg:RightToErasure"}, nil, nil)
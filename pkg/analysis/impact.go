@@ -4,10 +4,13 @@ package analysis
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 
+	"github.com/coolbeans/regula/pkg/ndjson"
 	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // ImpactDirection represents the direction of impact analysis.
@@ -30,6 +33,18 @@ const (
 	ImpactTransitive ImpactType = "transitive"
 )
 
+// Granularity is the level of provision an impact analysis reports.
+type Granularity string
+
+const (
+	// GranularityArticle reports references to paragraphs and points as
+	// references to their article.
+	GranularityArticle Granularity = "article"
+	// GranularityParagraph reports references to points as references to
+	// their paragraph, and keeps references to paragraphs.
+	GranularityParagraph Granularity = "paragraph"
+)
+
 // ImpactNode represents a node in the impact graph.
 type ImpactNode struct {
 	URI       string     `json:"uri"`
@@ -53,6 +68,7 @@ type ImpactResult struct {
 	TargetURI       string            `json:"target_uri"`
 	TargetLabel     string            `json:"target_label"`
 	MaxDepth        int               `json:"max_depth"`
+	Granularity     Granularity       `json:"granularity,omitempty"`
 	DirectIncoming  []*ImpactNode     `json:"direct_incoming"`
 	DirectOutgoing  []*ImpactNode     `json:"direct_outgoing"`
 	TransitiveNodes []*ImpactNode     `json:"transitive_nodes"`
@@ -74,24 +90,33 @@ type ImpactSummary struct {
 
 // ImpactAnalyzer performs impact analysis on the knowledge graph.
 type ImpactAnalyzer struct {
-	store   *store.TripleStore
-	baseURI string
+	store       *store.TripleStore
+	baseURI     string
+	granularity Granularity
 }
 
 // NewImpactAnalyzer creates a new impact analyzer.
 func NewImpactAnalyzer(ts *store.TripleStore, baseURI string) *ImpactAnalyzer {
 	return &ImpactAnalyzer{
-		store:   ts,
-		baseURI: baseURI,
+		store:       ts,
+		baseURI:     baseURI,
+		granularity: GranularityArticle,
 	}
 }
 
+// SetGranularity sets the level of provision the analysis reports. The
+// default is GranularityArticle.
+func (a *ImpactAnalyzer) SetGranularity(granularity Granularity) {
+	a.granularity = granularity
+}
+
 // Analyze performs impact analysis for a given provision.
 func (a *ImpactAnalyzer) Analyze(provisionURI string, maxDepth int, direction ImpactDirection) *ImpactResult {
 	result := &ImpactResult{
 		TargetURI:       provisionURI,
 		TargetLabel:     a.getLabel(provisionURI),
 		MaxDepth:        maxDepth,
+		Granularity:     a.granularity,
 		DirectIncoming:  make([]*ImpactNode, 0),
 		DirectOutgoing:  make([]*ImpactNode, 0),
 		TransitiveNodes: make([]*ImpactNode, 0),
@@ -134,6 +159,12 @@ func (a *ImpactAnalyzer) AnalyzeByID(shortID string, maxDepth int, direction Imp
 	return a.Analyze(uri, maxDepth, direction)
 }
 
+// ResolveShortID converts a short ID like "Art17" or "GDPR:Art17" to the
+// provision URI AnalyzeByID would analyze.
+func (a *ImpactAnalyzer) ResolveShortID(shortID string) string {
+	return a.resolveShortID(shortID)
+}
+
 // resolveShortID converts a short ID to a full URI.
 func (a *ImpactAnalyzer) resolveShortID(shortID string) string {
 	// If it's already a URI, return as-is
@@ -158,47 +189,15 @@ func (a *ImpactAnalyzer) resolveShortID(shortID string) string {
 
 // findIncomingReferences finds provisions that reference the target.
 func (a *ImpactAnalyzer) findIncomingReferences(targetURI string, result *ImpactResult, visited map[string]bool) {
-	// Find all subjects that have a references or referencedBy predicate pointing to target
-	triples := a.store.Find("", store.PropReferences, targetURI)
-
-	for _, t := range triples {
-		if visited[t.Subject] {
-			continue
-		}
-
-		node := &ImpactNode{
-			URI:       t.Subject,
-			Label:     a.getLabel(t.Subject),
-			Type:      a.getType(t.Subject),
-			Depth:     1,
-			Impact:    ImpactDirect,
-			Direction: "incoming",
-		}
-		result.DirectIncoming = append(result.DirectIncoming, node)
-
-		edge := &ImpactEdge{
-			Source:    t.Subject,
-			Target:    targetURI,
-			Predicate: store.PropReferences,
-			Depth:     1,
-		}
-		result.Edges = append(result.Edges, edge)
-
-		visited[t.Subject] = true
-		result.ByDepth[1] = append(result.ByDepth[1], t.Subject)
-	}
-
-	// Also check referencedBy in reverse
-	triples = a.store.Find(targetURI, store.PropReferencedBy, "")
-	for _, t := range triples {
-		if visited[t.Object] {
+	for _, sourceURI := range a.referrers(targetURI) {
+		if visited[sourceURI] {
 			continue
 		}
 
 		node := &ImpactNode{
-			URI:       t.Object,
-			Label:     a.getLabel(t.Object),
-			Type:      a.getType(t.Object),
+			URI:       sourceURI,
+			Label:     a.getLabel(sourceURI),
+			Type:      a.getType(sourceURI),
 			Depth:     1,
 			Impact:    ImpactDirect,
 			Direction: "incoming",
@@ -206,32 +205,30 @@ func (a *ImpactAnalyzer) findIncomingReferences(targetURI string, result *Impact
 		result.DirectIncoming = append(result.DirectIncoming, node)
 
 		edge := &ImpactEdge{
-			Source:    t.Object,
+			Source:    sourceURI,
 			Target:    targetURI,
 			Predicate: store.PropReferences,
 			Depth:     1,
 		}
 		result.Edges = append(result.Edges, edge)
 
-		visited[t.Object] = true
-		result.ByDepth[1] = append(result.ByDepth[1], t.Object)
+		visited[sourceURI] = true
+		result.ByDepth[1] = append(result.ByDepth[1], sourceURI)
 	}
 }
 
 // findOutgoingReferences finds provisions that the target references.
 func (a *ImpactAnalyzer) findOutgoingReferences(targetURI string, result *ImpactResult, visited map[string]bool) {
-	// Find all objects that the target references
-	triples := a.store.Find(targetURI, store.PropReferences, "")
-
-	for _, t := range triples {
-		if visited[t.Object] {
+	// Find all provisions that the target references
+	for _, referencedURI := range a.referenced(targetURI) {
+		if visited[referencedURI] {
 			continue
 		}
 
 		node := &ImpactNode{
-			URI:       t.Object,
-			Label:     a.getLabel(t.Object),
-			Type:      a.getType(t.Object),
+			URI:       referencedURI,
+			Label:     a.getLabel(referencedURI),
+			Type:      a.getType(referencedURI),
 			Depth:     1,
 			Impact:    ImpactDirect,
 			Direction: "outgoing",
@@ -240,18 +237,18 @@ func (a *ImpactAnalyzer) findOutgoingReferences(targetURI string, result *Impact
 
 		edge := &ImpactEdge{
 			Source:    targetURI,
-			Target:    t.Object,
+			Target:    referencedURI,
 			Predicate: store.PropReferences,
 			Depth:     1,
 		}
 		result.Edges = append(result.Edges, edge)
 
-		visited[t.Object] = true
-		result.ByDepth[1] = append(result.ByDepth[1], t.Object)
+		visited[referencedURI] = true
+		result.ByDepth[1] = append(result.ByDepth[1], referencedURI)
 	}
 
 	// Also check resolvedTarget for resolved references
-	triples = a.store.Find(targetURI, store.PropResolvedTarget, "")
+	triples := a.store.Find(targetURI, store.PropResolvedTarget, "")
 	for _, t := range triples {
 		if visited[t.Object] {
 			continue
@@ -300,16 +297,15 @@ func (a *ImpactAnalyzer) findTransitiveImpact(result *ImpactResult, maxDepth int
 		for _, nodeURI := range currentDepthNodes {
 			// Find incoming references for this node
 			if direction == DirectionIncoming || direction == DirectionBoth {
-				triples := a.store.Find("", store.PropReferences, nodeURI)
-				for _, t := range triples {
-					if visited[t.Subject] {
+				for _, sourceURI := range a.referrers(nodeURI) {
+					if visited[sourceURI] {
 						continue
 					}
 
 					node := &ImpactNode{
-						URI:       t.Subject,
-						Label:     a.getLabel(t.Subject),
-						Type:      a.getType(t.Subject),
+						URI:       sourceURI,
+						Label:     a.getLabel(sourceURI),
+						Type:      a.getType(sourceURI),
 						Depth:     depth,
 						Impact:    ImpactTransitive,
 						Direction: "incoming",
@@ -317,31 +313,30 @@ func (a *ImpactAnalyzer) findTransitiveImpact(result *ImpactResult, maxDepth int
 					result.TransitiveNodes = append(result.TransitiveNodes, node)
 
 					edge := &ImpactEdge{
-						Source:    t.Subject,
+						Source:    sourceURI,
 						Target:    nodeURI,
 						Predicate: store.PropReferences,
 						Depth:     depth,
 					}
 					result.Edges = append(result.Edges, edge)
 
-					visited[t.Subject] = true
-					nextDepthNodes = append(nextDepthNodes, t.Subject)
-					result.ByDepth[depth] = append(result.ByDepth[depth], t.Subject)
+					visited[sourceURI] = true
+					nextDepthNodes = append(nextDepthNodes, sourceURI)
+					result.ByDepth[depth] = append(result.ByDepth[depth], sourceURI)
 				}
 			}
 
 			// Find outgoing references from this node
 			if direction == DirectionOutgoing || direction == DirectionBoth {
-				triples := a.store.Find(nodeURI, store.PropReferences, "")
-				for _, t := range triples {
-					if visited[t.Object] {
+				for _, referencedURI := range a.referenced(nodeURI) {
+					if visited[referencedURI] {
 						continue
 					}
 
 					node := &ImpactNode{
-						URI:       t.Object,
-						Label:     a.getLabel(t.Object),
-						Type:      a.getType(t.Object),
+						URI:       referencedURI,
+						Label:     a.getLabel(referencedURI),
+						Type:      a.getType(referencedURI),
 						Depth:     depth,
 						Impact:    ImpactTransitive,
 						Direction: "outgoing",
@@ -350,15 +345,15 @@ func (a *ImpactAnalyzer) findTransitiveImpact(result *ImpactResult, maxDepth int
 
 					edge := &ImpactEdge{
 						Source:    nodeURI,
-						Target:    t.Object,
+						Target:    referencedURI,
 						Predicate: store.PropReferences,
 						Depth:     depth,
 					}
 					result.Edges = append(result.Edges, edge)
 
-					visited[t.Object] = true
-					nextDepthNodes = append(nextDepthNodes, t.Object)
-					result.ByDepth[depth] = append(result.ByDepth[depth], t.Object)
+					visited[referencedURI] = true
+					nextDepthNodes = append(nextDepthNodes, referencedURI)
+					result.ByDepth[depth] = append(result.ByDepth[depth], referencedURI)
 				}
 			}
 		}
@@ -370,6 +365,116 @@ func (a *ImpactAnalyzer) findTransitiveImpact(result *ImpactResult, maxDepth int
 	}
 }
 
+// referrers returns the provisions that reference uri or any paragraph,
+// point, or sub-point it contains, in the order found.
+func (a *ImpactAnalyzer) referrers(uri string) []string {
+	var sources []string
+	seen := make(map[string]bool)
+	add := func(source string) {
+		if !seen[source] {
+			seen[source] = true
+			sources = append(sources, source)
+		}
+	}
+
+	targets := append([]string{uri}, a.subdivisions(uri)...)
+	for _, target := range targets {
+		for _, t := range a.store.Find("", store.PropReferences, target) {
+			add(t.Subject)
+		}
+		for _, t := range a.store.Find(target, store.PropReferencedBy, "") {
+			add(t.Object)
+		}
+	}
+	return sources
+}
+
+// subdivisions returns the paragraphs, points, and sub-points within uri.
+func (a *ImpactAnalyzer) subdivisions(uri string) []string {
+	var children []string
+	for _, predicate := range []string{store.PropHasParagraph, store.PropHasPoint, store.PropHasSubPoint} {
+		for _, t := range a.store.Find(uri, predicate, "") {
+			children = append(children, t.Object)
+			children = append(children, a.subdivisions(t.Object)...)
+		}
+	}
+	return children
+}
+
+// referenced returns the provisions uri references, each raised to the
+// analyzer's granularity. At paragraph granularity an article is dropped
+// when one of its paragraphs is also referenced, since a citation of a
+// paragraph links its article too.
+func (a *ImpactAnalyzer) referenced(uri string) []string {
+	var targets []string
+	seen := make(map[string]bool)
+	for _, t := range a.store.Find(uri, store.PropReferences, "") {
+		target := a.atGranularity(t.Object)
+		if !seen[target] {
+			seen[target] = true
+			targets = append(targets, target)
+		}
+	}
+
+	if a.granularity != GranularityParagraph {
+		return targets
+	}
+	covered := make(map[string]bool)
+	for _, target := range targets {
+		if a.store.Exists(target, store.RDFType, store.ClassParagraph) {
+			covered[a.store.GetOne(target, store.PropPartOf)] = true
+		}
+	}
+	filtered := targets[:0]
+	for _, target := range targets {
+		if !covered[target] {
+			filtered = append(filtered, target)
+		}
+	}
+	return filtered
+}
+
+// atGranularity returns the provision at the analyzer's granularity that
+// contains uri: its article, or at paragraph granularity its paragraph.
+// Other provisions are returned unchanged.
+func (a *ImpactAnalyzer) atGranularity(uri string) string {
+	classes := []string{store.ClassSubPoint, store.ClassPoint}
+	if a.granularity != GranularityParagraph {
+		classes = append(classes, store.ClassParagraph)
+	}
+
+	typed := false
+	for {
+		isSubdivision := false
+		for _, class := range classes {
+			if a.store.Exists(uri, store.RDFType, class) {
+				isSubdivision = true
+				break
+			}
+		}
+		if !isSubdivision {
+			break
+		}
+		parent := a.store.GetOne(uri, store.PropPartOf)
+		if parent == "" {
+			break
+		}
+		uri, typed = parent, true
+	}
+
+	// Subdivisions without nodes of their own, such as "Art6502((b)(1))",
+	// are raised to their article by URI.
+	if !typed && a.granularity != GranularityParagraph {
+		if open := strings.Index(uri, "("); open > 0 {
+			colon := strings.LastIndex(uri[:open], ":")
+			if colon >= 0 && strings.HasPrefix(uri[colon:open], ":Art") {
+				return uri[:open]
+			}
+		}
+	}
+	return uri
+}
+
 // calculateSummary calculates summary statistics.
 func (a *ImpactAnalyzer) calculateSummary(result *ImpactResult) {
 	result.Summary.DirectIncomingCount = len(result.DirectIncoming)
@@ -570,10 +675,7 @@ func (r *ImpactResult) FormatTable() string {
 	})
 
 	for _, node := range allNodes {
-		label := node.Label
-		if len(label) > 48 {
-			label = label[:45] + "..."
-		}
+		label := textutil.Truncate(node.Label, 48)
 		sb.WriteString(fmt.Sprintf("| %5d | %-48s | %-10s | %-9s |\n",
 			node.Depth, label, node.Type, node.Direction))
 	}
@@ -582,3 +684,23 @@ func (r *ImpactResult) FormatTable() string {
 
 	return sb.String()
 }
+
+// WriteNDJSON writes the result to w as newline-delimited JSON: one "node"
+// record per affected provision, in the order direct incoming, direct
+// outgoing, transitive, followed by a "summary" record.
+func (r *ImpactResult) WriteNDJSON(w io.Writer) error {
+	encoder := ndjson.NewEncoder(w)
+	for _, nodes := range [][]*ImpactNode{r.DirectIncoming, r.DirectOutgoing, r.TransitiveNodes} {
+		for _, node := range nodes {
+			if err := encoder.EncodeRecord("node", node); err != nil {
+				return err
+			}
+		}
+	}
+	return encoder.EncodeRecord("summary", struct {
+		TargetURI   string `json:"target_uri"`
+		TargetLabel string `json:"target_label"`
+		MaxDepth    int    `json:"max_depth"`
+		*ImpactSummary
+	}{r.TargetURI, r.TargetLabel, r.MaxDepth, r.Summary})
+}
@@ -0,0 +1,439 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/ndjson"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// ImpactEventKind is the kind of change an amendment made to an impact
+// surface.
+type ImpactEventKind string
+
+const (
+	// ImpactEventAdded marks a dependent provision that came into force.
+	ImpactEventAdded ImpactEventKind = "added"
+	// ImpactEventRepealed marks a dependent provision that was repealed.
+	ImpactEventRepealed ImpactEventKind = "repealed"
+	// ImpactEventAmended marks an amendment to the target or a dependent
+	// that left the surface unchanged.
+	ImpactEventAmended ImpactEventKind = "amended"
+)
+
+// historyDateLayout is the ISO date format of amendment-history triples.
+const historyDateLayout = "2006-01-02"
+
+// ImpactTimelineEvent is one change to the impact surface. SurfaceSize is
+// the size of the surface after the change, or -1 for undated changes.
+type ImpactTimelineEvent struct {
+	Date        string          `json:"date,omitempty"`
+	Kind        ImpactEventKind `json:"kind"`
+	URI         string          `json:"uri"`
+	Label       string          `json:"label"`
+	Depth       int             `json:"depth"`
+	Direction   string          `json:"direction,omitempty"`
+	Instrument  string          `json:"instrument,omitempty"`
+	SurfaceSize int             `json:"surface_size"`
+}
+
+// ImpactTimeline reports how the impact surface of a provision changed as
+// amendments landed. The surface is the set of provisions found by Analyze;
+// BaselineSize counts those in force on Since.
+type ImpactTimeline struct {
+	TargetURI    string                 `json:"target_uri"`
+	TargetLabel  string                 `json:"target_label"`
+	Since        string                 `json:"since,omitempty"`
+	MaxDepth     int                    `json:"max_depth"`
+	Direction    ImpactDirection        `json:"direction"`
+	BaselineSize int                    `json:"baseline_size"`
+	CurrentSize  int                    `json:"current_size"`
+	Added        int                    `json:"added"`
+	Repealed     int                    `json:"repealed"`
+	Amended      int                    `json:"amended"`
+	Events       []*ImpactTimelineEvent `json:"events"`
+	Undated      []*ImpactTimelineEvent `json:"undated,omitempty"`
+}
+
+// provisionHistory is the lifecycle of one provision read from
+// amendment-history triples.
+type provisionHistory struct {
+	validFrom  time.Time
+	validUntil time.Time
+	repealedBy string
+	amendments []historyAmendment
+}
+
+type historyAmendment struct {
+	date       time.Time
+	instrument string
+}
+
+// AnalyzeHistory replays the amendment history of the impact surface of
+// provisionURI from since onwards. A provision enters the surface on its
+// reg:validFrom or reg:effectiveDate and leaves it on its reg:validUntil or
+// the date of its reg:repealedBy instrument; reg:amendedBy instruments
+// become amendment events. Provisions without dates are treated as in force
+// throughout, and amendments or repeals without dates are listed as
+// undated. A zero since replays the whole history.
+func (a *ImpactAnalyzer) AnalyzeHistory(provisionURI string, maxDepth int, direction ImpactDirection, since time.Time) *ImpactTimeline {
+	surface := a.Analyze(provisionURI, maxDepth, direction)
+	timeline := &ImpactTimeline{
+		TargetURI:   surface.TargetURI,
+		TargetLabel: surface.TargetLabel,
+		MaxDepth:    maxDepth,
+		Direction:   direction,
+		Events:      make([]*ImpactTimelineEvent, 0),
+	}
+	if !since.IsZero() {
+		timeline.Since = since.Format(historyDateLayout)
+	}
+
+	nodes := []*ImpactNode{{URI: provisionURI, Label: surface.TargetLabel}}
+	nodes = append(nodes, surface.DirectIncoming...)
+	nodes = append(nodes, surface.DirectOutgoing...)
+	nodes = append(nodes, surface.TransitiveNodes...)
+
+	for i, node := range nodes {
+		isTarget := i == 0
+		history := a.provisionHistory(node.URI)
+		event := func(kind ImpactEventKind, date time.Time, instrument string) *ImpactTimelineEvent {
+			return &ImpactTimelineEvent{
+				Date:       formatHistoryDate(date),
+				Kind:       kind,
+				URI:        node.URI,
+				Label:      node.Label,
+				Depth:      node.Depth,
+				Direction:  node.Direction,
+				Instrument: instrument,
+			}
+		}
+
+		if !isTarget && inForceOn(history, since) {
+			timeline.BaselineSize++
+		}
+		if !isTarget && !history.validFrom.IsZero() && !history.validFrom.Before(since) {
+			timeline.Events = append(timeline.Events, event(ImpactEventAdded, history.validFrom, ""))
+		}
+
+		switch {
+		case !history.validUntil.IsZero():
+			if !history.validUntil.Before(since) {
+				timeline.Events = append(timeline.Events, event(ImpactEventRepealed, history.validUntil, history.repealedBy))
+			}
+		case history.repealedBy != "":
+			timeline.Undated = append(timeline.Undated, event(ImpactEventRepealed, time.Time{}, history.repealedBy))
+		}
+
+		for _, amendment := range history.amendments {
+			switch {
+			case amendment.date.IsZero():
+				timeline.Undated = append(timeline.Undated, event(ImpactEventAmended, amendment.date, amendment.instrument))
+			case !amendment.date.Before(since):
+				timeline.Events = append(timeline.Events, event(ImpactEventAmended, amendment.date, amendment.instrument))
+			}
+		}
+	}
+
+	sort.SliceStable(timeline.Events, func(i, j int) bool {
+		if timeline.Events[i].Date != timeline.Events[j].Date {
+			return timeline.Events[i].Date < timeline.Events[j].Date
+		}
+		return timeline.Events[i].Depth < timeline.Events[j].Depth
+	})
+	sort.SliceStable(timeline.Undated, func(i, j int) bool {
+		return timeline.Undated[i].Depth < timeline.Undated[j].Depth
+	})
+
+	size := timeline.BaselineSize
+	for _, event := range timeline.Events {
+		isTarget := event.URI == provisionURI
+		switch event.Kind {
+		case ImpactEventAdded:
+			size++
+			timeline.Added++
+		case ImpactEventRepealed:
+			if !isTarget {
+				size--
+				timeline.Repealed++
+			}
+		case ImpactEventAmended:
+			timeline.Amended++
+		}
+		event.SurfaceSize = size
+	}
+	for _, event := range timeline.Undated {
+		event.SurfaceSize = -1
+	}
+	timeline.CurrentSize = size
+
+	return timeline
+}
+
+// provisionHistory reads the validity and amendment triples of uri.
+func (a *ImpactAnalyzer) provisionHistory(uri string) provisionHistory {
+	history := provisionHistory{
+		validFrom:  a.firstDate(uri, store.PropValidFrom, store.PropEffectiveDate),
+		validUntil: a.firstDate(uri, store.PropValidUntil),
+	}
+
+	if triples := a.store.Find(uri, store.PropRepealedBy, ""); len(triples) > 0 {
+		instrument := triples[0].Object
+		history.repealedBy = a.instrumentLabel(instrument)
+		if history.validUntil.IsZero() {
+			history.validUntil = a.instrumentDate(instrument)
+		}
+	}
+
+	for _, triple := range a.store.Find(uri, store.PropAmendedBy, "") {
+		history.amendments = append(history.amendments, historyAmendment{
+			date:       a.instrumentDate(triple.Object),
+			instrument: a.instrumentLabel(triple.Object),
+		})
+	}
+	return history
+}
+
+// instrumentDate returns the date an amending or repealing instrument took
+// effect: the date of its temporal qualifier, or the instrument's own
+// validity or adoption date.
+func (a *ImpactAnalyzer) instrumentDate(instrument string) time.Time {
+	return a.firstDate(instrument, store.PropEffectiveDate, store.PropValidFrom, store.PropAdoptedDate)
+}
+
+// instrumentLabel describes an amending or repealing instrument.
+func (a *ImpactAnalyzer) instrumentLabel(instrument string) string {
+	for _, predicate := range []string{store.PropTemporalDescription, store.PropTitle, store.PropText} {
+		if value := a.store.GetOne(instrument, predicate); value != "" {
+			return value
+		}
+	}
+	return a.getLabel(instrument)
+}
+
+// firstDate returns the first parseable date among the predicates of uri.
+func (a *ImpactAnalyzer) firstDate(uri string, predicates ...string) time.Time {
+	for _, predicate := range predicates {
+		for _, triple := range a.store.Find(uri, predicate, "") {
+			if date, ok := parseHistoryDate(triple.Object); ok {
+				return date
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// parseHistoryDate parses an ISO date, ignoring any time of day.
+func parseHistoryDate(value string) (time.Time, bool) {
+	if len(value) > len(historyDateLayout) {
+		value = value[:len(historyDateLayout)]
+	}
+	date, err := time.Parse(historyDateLayout, value)
+	return date, err == nil
+}
+
+func formatHistoryDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(historyDateLayout)
+}
+
+// inForceOn reports whether a provision was in force on date. Every
+// provision is in force on the zero date, so an unbounded replay starts
+// from the provisions without a validFrom.
+func inForceOn(history provisionHistory, date time.Time) bool {
+	if date.IsZero() {
+		return history.validFrom.IsZero()
+	}
+	if !history.validFrom.IsZero() && history.validFrom.After(date) {
+		return false
+	}
+	return history.validUntil.IsZero() || history.validUntil.After(date)
+}
+
+// ToJSON serializes the timeline to JSON.
+func (t *ImpactTimeline) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(t, "", "  ")
+}
+
+// WriteNDJSON writes the timeline to w as newline-delimited JSON: one
+// "event" record per dated event in order, then the undated events, then a
+// "summary" record.
+func (t *ImpactTimeline) WriteNDJSON(w io.Writer) error {
+	encoder := ndjson.NewEncoder(w)
+	for _, events := range [][]*ImpactTimelineEvent{t.Events, t.Undated} {
+		for _, event := range events {
+			if err := encoder.EncodeRecord("event", event); err != nil {
+				return err
+			}
+		}
+	}
+	// The outer fields shadow the event lists so the summary omits them
+	return encoder.EncodeRecord("summary", struct {
+		*ImpactTimeline
+		Events  []*ImpactTimelineEvent `json:"events,omitempty"`
+		Undated []*ImpactTimelineEvent `json:"undated,omitempty"`
+	}{ImpactTimeline: t})
+}
+
+// String returns a human-readable timeline.
+func (t *ImpactTimeline) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Impact History for: %s\n", t.TargetLabel))
+	sb.WriteString(fmt.Sprintf("URI: %s\n", t.TargetURI))
+	if t.Since != "" {
+		sb.WriteString(fmt.Sprintf("Since: %s\n", t.Since))
+	}
+	sb.WriteString(fmt.Sprintf("Analysis Depth: %d (%s)\n", t.MaxDepth, t.Direction))
+	sb.WriteString("=" + strings.Repeat("=", 50) + "\n\n")
+
+	sb.WriteString("Summary:\n")
+	sb.WriteString(fmt.Sprintf("  Impact surface at start: %d\n", t.BaselineSize))
+	sb.WriteString(fmt.Sprintf("  Impact surface now: %d\n", t.CurrentSize))
+	sb.WriteString(fmt.Sprintf("  Dependents added: %d\n", t.Added))
+	sb.WriteString(fmt.Sprintf("  Dependents repealed: %d\n", t.Repealed))
+	sb.WriteString(fmt.Sprintf("  Amendments: %d\n\n", t.Amended))
+
+	if len(t.Events) == 0 {
+		sb.WriteString("No dated amendment history in range.\n")
+	} else {
+		sb.WriteString("Timeline:\n")
+		for _, event := range t.Events {
+			sb.WriteString(fmt.Sprintf("  %s  %-8s  %s%s  [surface %d]\n",
+				event.Date, event.Kind, event.Label, t.eventContext(event), event.SurfaceSize))
+		}
+	}
+
+	if len(t.Undated) > 0 {
+		sb.WriteString("\nUndated:\n")
+		for _, event := range t.Undated {
+			sb.WriteString(fmt.Sprintf("  %-8s  %s%s\n", event.Kind, event.Label, t.eventContext(event)))
+		}
+	}
+
+	return sb.String()
+}
+
+// eventContext describes where an event sits relative to the target and
+// the instrument that caused it.
+func (t *ImpactTimeline) eventContext(event *ImpactTimelineEvent) string {
+	position := "target"
+	if event.URI != t.TargetURI {
+		position = fmt.Sprintf("depth %d %s", event.Depth, event.Direction)
+	}
+	if event.Instrument != "" {
+		return fmt.Sprintf(" (%s: %s)", position, textutil.Truncate(event.Instrument, 60))
+	}
+	return " (" + position + ")"
+}
+
+// ToHTML renders the timeline as a standalone HTML page.
+func (t *ImpactTimeline) ToHTML() string {
+	var sb strings.Builder
+
+	title := "Impact History: " + t.TargetLabel
+	sb.WriteString(fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<title>%s</title>
+`, html.EscapeString(title)))
+	sb.WriteString(`<style>
+body {
+  font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, "Helvetica Neue", Arial, sans-serif;
+  line-height: 1.6;
+  color: #212529;
+  max-width: 960px;
+  margin: 0 auto;
+  padding: 20px;
+}
+h1 { border-bottom: 2px solid #dee2e6; padding-bottom: 0.3em; }
+.meta { color: #6c757d; }
+.summary { display: flex; gap: 15px; flex-wrap: wrap; margin: 1em 0; }
+.card { background: #f8f9fa; border: 1px solid #dee2e6; border-radius: 8px; padding: 10px 15px; }
+.card .value { font-size: 1.6em; font-weight: bold; }
+.timeline { list-style: none; border-left: 3px solid #dee2e6; margin: 1em 0; padding-left: 20px; }
+.timeline li { position: relative; margin-bottom: 1em; }
+.timeline li::before {
+  content: ""; position: absolute; left: -28px; top: 6px;
+  width: 13px; height: 13px; border-radius: 50%; background: #6c757d;
+}
+.timeline li.added::before { background: #28a745; }
+.timeline li.repealed::before { background: #dc3545; }
+.timeline li.amended::before { background: #fd7e14; }
+.date { font-weight: bold; }
+.kind { text-transform: uppercase; font-size: 0.8em; color: #6c757d; margin-left: 0.5em; }
+.bar { display: inline-block; height: 8px; background: #0d6efd; border-radius: 4px; vertical-align: middle; margin-left: 0.5em; }
+</style>
+</head>
+<body>
+`)
+	sb.WriteString(fmt.Sprintf("<h1>%s</h1>\n", html.EscapeString(title)))
+	sb.WriteString(fmt.Sprintf("<p class=\"meta\">%s", html.EscapeString(t.TargetURI)))
+	if t.Since != "" {
+		sb.WriteString(fmt.Sprintf(" &middot; since %s", html.EscapeString(t.Since)))
+	}
+	sb.WriteString(fmt.Sprintf(" &middot; depth %d (%s)</p>\n", t.MaxDepth, html.EscapeString(string(t.Direction))))
+
+	sb.WriteString("<div class=\"summary\">\n")
+	for _, card := range []struct {
+		label string
+		value int
+	}{
+		{"Surface at start", t.BaselineSize},
+		{"Surface now", t.CurrentSize},
+		{"Added", t.Added},
+		{"Repealed", t.Repealed},
+		{"Amendments", t.Amended},
+	} {
+		sb.WriteString(fmt.Sprintf("<div class=\"card\"><div>%s</div><div class=\"value\">%d</div></div>\n", card.label, card.value))
+	}
+	sb.WriteString("</div>\n")
+
+	largest := t.BaselineSize
+	for _, event := range t.Events {
+		if event.SurfaceSize > largest {
+			largest = event.SurfaceSize
+		}
+	}
+
+	sb.WriteString("<h2>Timeline</h2>\n")
+	if len(t.Events) == 0 {
+		sb.WriteString("<p>No dated amendment history in range.</p>\n")
+	} else {
+		sb.WriteString("<ul class=\"timeline\">\n")
+		for _, event := range t.Events {
+			width := 0
+			if largest > 0 {
+				width = event.SurfaceSize * 200 / largest
+			}
+			sb.WriteString(fmt.Sprintf("<li class=\"%s\"><span class=\"date\">%s</span><span class=\"kind\">%s</span><br>%s <span class=\"meta\">%s</span><br><span class=\"meta\">surface %d</span><span class=\"bar\" style=\"width: %dpx\"></span></li>\n",
+				event.Kind, html.EscapeString(event.Date), event.Kind,
+				html.EscapeString(event.Label), html.EscapeString(t.eventContext(event)),
+				event.SurfaceSize, width))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	if len(t.Undated) > 0 {
+		sb.WriteString("<h2>Undated</h2>\n<ul>\n")
+		for _, event := range t.Undated {
+			sb.WriteString(fmt.Sprintf("<li><span class=\"kind\">%s</span> %s <span class=\"meta\">%s</span></li>\n",
+				event.Kind, html.EscapeString(event.Label), html.EscapeString(t.eventContext(event))))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
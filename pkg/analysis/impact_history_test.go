@@ -0,0 +1,166 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// newHistoryTestStore builds a graph where Art5, Art6, Art7, and Art8
+// reference Art2 and Art9 references Art6. Art6 came into force in 2019,
+// Art7 in 2021, Art8 was repealed in 2022, Art2 was amended in 2023, and
+// Art9 has an undated amendment.
+func newHistoryTestStore() *store.TripleStore {
+	ts := store.NewTripleStore()
+	base := "https://regula.dev/regulations/TEST:"
+	for _, article := range []string{"Art2", "Art5", "Art6", "Art7", "Art8", "Art9"} {
+		ts.Add(base+article, store.RDFType, store.ClassArticle)
+		ts.Add(base+article, store.PropTitle, "Title "+article)
+	}
+	for _, article := range []string{"Art5", "Art6", "Art7", "Art8"} {
+		ts.Add(base+article, store.PropReferences, base+"Art2")
+	}
+	ts.Add(base+"Art9", store.PropReferences, base+"Art6")
+
+	ts.Add(base+"Art6", store.PropValidFrom, "2019-03-01")
+	ts.Add(base+"Art7", store.PropEffectiveDate, "2021-01-01")
+
+	ts.Add(base+"Art8", store.PropRepealedBy, base+"Ref:Art8:10")
+	ts.Add(base+"Ref:Art8:10", store.PropTemporalDescription, "repealed with effect from 1 June 2022")
+	ts.Add(base+"Ref:Art8:10", store.PropEffectiveDate, "2022-06-01")
+
+	ts.Add(base+"Art2", store.PropAmendedBy, "https://regula.dev/regulations/AMEND")
+	ts.Add("https://regula.dev/regulations/AMEND", store.PropTitle, "Amending Regulation")
+	ts.Add("https://regula.dev/regulations/AMEND", store.PropAdoptedDate, "2023-02-15")
+
+	ts.Add(base+"Art9", store.PropAmendedBy, base+"Ref:Art9:4")
+	ts.Add(base+"Ref:Art9:4", store.PropTemporalDescription, "as amended")
+	return ts
+}
+
+func TestAnalyzeHistory(t *testing.T) {
+	analyzer := NewImpactAnalyzer(newHistoryTestStore(), "https://regula.dev/regulations/")
+	since := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	timeline := analyzer.AnalyzeHistory(analyzer.ResolveShortID("TEST:Art2"), 2, DirectionIncoming, since)
+
+	// Art5, Art6, Art8, and Art9 are in force on the start date.
+	if timeline.BaselineSize != 4 {
+		t.Errorf("BaselineSize = %d, want 4", timeline.BaselineSize)
+	}
+	if timeline.CurrentSize != 4 || timeline.Added != 1 || timeline.Repealed != 1 || timeline.Amended != 1 {
+		t.Errorf("current %d, added %d, repealed %d, amended %d; want 4, 1, 1, 1",
+			timeline.CurrentSize, timeline.Added, timeline.Repealed, timeline.Amended)
+	}
+
+	expected := []struct {
+		date    string
+		kind    ImpactEventKind
+		label   string
+		surface int
+	}{
+		{"2021-01-01", ImpactEventAdded, "Title Art7", 5},
+		{"2022-06-01", ImpactEventRepealed, "Title Art8", 4},
+		{"2023-02-15", ImpactEventAmended, "Title Art2", 4},
+	}
+	if len(timeline.Events) != len(expected) {
+		t.Fatalf("got %d events, want %d: %+v", len(timeline.Events), len(expected), timeline.Events)
+	}
+	for i, want := range expected {
+		event := timeline.Events[i]
+		if event.Date != want.date || event.Kind != want.kind || event.Label != want.label || event.SurfaceSize != want.surface {
+			t.Errorf("event %d = %+v, want %+v", i, event, want)
+		}
+	}
+	if timeline.Events[1].Instrument != "repealed with effect from 1 June 2022" {
+		t.Errorf("repeal instrument = %q", timeline.Events[1].Instrument)
+	}
+	if timeline.Events[2].Instrument != "Amending Regulation" || timeline.Events[2].Depth != 0 {
+		t.Errorf("target amendment = %+v", timeline.Events[2])
+	}
+
+	if len(timeline.Undated) != 1 || timeline.Undated[0].Label != "Title Art9" || timeline.Undated[0].Depth != 2 {
+		t.Errorf("undated = %+v, want the Art9 amendment at depth 2", timeline.Undated)
+	}
+}
+
+func TestAnalyzeHistory_Unbounded(t *testing.T) {
+	analyzer := NewImpactAnalyzer(newHistoryTestStore(), "https://regula.dev/regulations/")
+
+	timeline := analyzer.AnalyzeHistory(analyzer.ResolveShortID("TEST:Art2"), 1, DirectionIncoming, time.Time{})
+
+	if timeline.Since != "" {
+		t.Errorf("Since = %q, want empty", timeline.Since)
+	}
+	// Art5 and Art8 have no validFrom; Art6 and Art7 are added.
+	if timeline.BaselineSize != 2 || timeline.Added != 2 || timeline.CurrentSize != 3 {
+		t.Errorf("baseline %d, added %d, current %d; want 2, 2, 3",
+			timeline.BaselineSize, timeline.Added, timeline.CurrentSize)
+	}
+	if timeline.Events[0].Date != "2019-03-01" || timeline.Events[0].SurfaceSize != 3 {
+		t.Errorf("first event = %+v", timeline.Events[0])
+	}
+}
+
+func TestImpactTimelineFormats(t *testing.T) {
+	analyzer := NewImpactAnalyzer(newHistoryTestStore(), "https://regula.dev/regulations/")
+	timeline := analyzer.AnalyzeHistory(analyzer.ResolveShortID("TEST:Art2"), 2, DirectionIncoming,
+		time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	text := timeline.String()
+	for _, want := range []string{
+		"Since: 2020-01-01",
+		"2022-06-01  repealed  Title Art8 (depth 1 incoming: repealed with effect from 1 June 2022)  [surface 4]",
+		"2023-02-15  amended   Title Art2 (target: Amending Regulation)",
+		"Undated:",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text missing %q:\n%s", want, text)
+		}
+	}
+
+	data, err := timeline.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	var decoded ImpactTimeline
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(decoded.Events) != 3 || decoded.Events[0].Kind != ImpactEventAdded {
+		t.Errorf("decoded events = %+v", decoded.Events)
+	}
+
+	page := timeline.ToHTML()
+	for _, want := range []string{"<!DOCTYPE html>", `<li class="repealed">`, "Title Art7", "<h2>Undated</h2>"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("HTML missing %q", want)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := timeline.WriteNDJSON(&buf); err != nil {
+		t.Fatalf("WriteNDJSON failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(timeline.Events)+len(timeline.Undated)+1 {
+		t.Fatalf("got %d NDJSON lines, want %d:\n%s", len(lines), len(timeline.Events)+len(timeline.Undated)+1, buf.String())
+	}
+	var first, last map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("invalid NDJSON line: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &last); err != nil {
+		t.Fatalf("invalid NDJSON line: %v", err)
+	}
+	if first["record"] != "event" || first["kind"] != string(ImpactEventAdded) {
+		t.Errorf("first record = %v", first)
+	}
+	if last["record"] != "summary" || last["events"] != nil {
+		t.Errorf("summary record = %v", last)
+	}
+}
@@ -0,0 +1,357 @@
+package analysis
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ImpactSeverity ranks how likely an affected provision is to need review
+// when the target changes.
+type ImpactSeverity string
+
+const (
+	// SeverityHigh marks provisions that cite the target directly.
+	SeverityHigh ImpactSeverity = "high"
+	// SeverityMedium marks provisions the target cites, and provisions one
+	// step removed from citing it.
+	SeverityMedium ImpactSeverity = "medium"
+	// SeverityLow marks the rest of the transitive neighborhood.
+	SeverityLow ImpactSeverity = "low"
+)
+
+// Severity ranks the node: a provision citing the target depends on its
+// wording, while one the target cites only matters to the target's reading.
+func (n *ImpactNode) Severity() ImpactSeverity {
+	switch {
+	case n.Depth <= 1 && n.Direction == "incoming":
+		return SeverityHigh
+	case n.Depth <= 1, n.Depth == 2 && n.Direction == "incoming":
+		return SeverityMedium
+	default:
+		return SeverityLow
+	}
+}
+
+// Graph layout of the HTML report: one ring per depth around the target,
+// incoming provisions on the left half and outgoing on the right.
+const (
+	impactRingGap    = 170.0
+	impactGraphPad   = 60.0
+	impactNodeRadius = 9.0
+)
+
+// impactGraphNode is a node placed in the report's graph.
+type impactGraphNode struct {
+	node *ImpactNode
+	x, y float64
+}
+
+// layoutImpactGraph places the target at the center and each affected
+// provision on the ring of its depth, spread over the half of its direction.
+func (r *ImpactResult) layoutImpactGraph(size float64) (map[string]impactGraphNode, []*ImpactNode) {
+	center := size / 2
+	positions := map[string]impactGraphNode{
+		r.TargetURI: {x: center, y: center},
+	}
+
+	nodes := r.allNodes()
+	type ringKey struct {
+		depth     int
+		direction string
+	}
+	rings := make(map[ringKey][]*ImpactNode)
+	for _, node := range nodes {
+		key := ringKey{node.Depth, node.Direction}
+		rings[key] = append(rings[key], node)
+	}
+	for key, ring := range rings {
+		radius := float64(key.depth) * impactRingGap
+		// Angles run clockwise from the top: incoming on the left half,
+		// outgoing on the right.
+		start, span := math.Pi, math.Pi
+		if key.direction == "outgoing" {
+			start = 0
+		}
+		for i, node := range ring {
+			angle := start + span*(float64(i)+0.5)/float64(len(ring))
+			positions[node.URI] = impactGraphNode{
+				node: node,
+				x:    center + radius*math.Sin(angle),
+				y:    center - radius*math.Cos(angle),
+			}
+		}
+	}
+	return positions, nodes
+}
+
+// allNodes returns the affected provisions ordered by depth, direction, and
+// label.
+func (r *ImpactResult) allNodes() []*ImpactNode {
+	nodes := make([]*ImpactNode, 0, len(r.DirectIncoming)+len(r.DirectOutgoing)+len(r.TransitiveNodes))
+	nodes = append(nodes, r.DirectIncoming...)
+	nodes = append(nodes, r.DirectOutgoing...)
+	nodes = append(nodes, r.TransitiveNodes...)
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Depth != nodes[j].Depth {
+			return nodes[i].Depth < nodes[j].Depth
+		}
+		if nodes[i].Direction != nodes[j].Direction {
+			return nodes[i].Direction < nodes[j].Direction
+		}
+		return nodes[i].Label < nodes[j].Label
+	})
+	return nodes
+}
+
+// ToHTML renders the impact analysis as a self-contained HTML page: summary
+// cards, an interactive graph of the impact neighborhood colored by
+// severity, and sortable tables of the affected provisions. The page loads
+// no external resources.
+func (r *ImpactResult) ToHTML() string {
+	var sb strings.Builder
+
+	title := "Impact Analysis: " + r.TargetLabel
+	sb.WriteString(fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<title>%s</title>
+`, html.EscapeString(title)))
+	sb.WriteString(`<style>
+body {
+  font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, "Helvetica Neue", Arial, sans-serif;
+  line-height: 1.6;
+  color: #212529;
+  max-width: 1100px;
+  margin: 0 auto;
+  padding: 20px;
+}
+h1 { border-bottom: 2px solid #dee2e6; padding-bottom: 0.3em; }
+.meta { color: #6c757d; }
+.summary { display: flex; gap: 15px; flex-wrap: wrap; margin: 1em 0; }
+.card { background: #f8f9fa; border: 1px solid #dee2e6; border-radius: 8px; padding: 10px 15px; }
+.card .value { font-size: 1.6em; font-weight: bold; }
+.legend span { margin-right: 1em; }
+.swatch { display: inline-block; width: 12px; height: 12px; border-radius: 50%; vertical-align: middle; margin-right: 4px; }
+.graph { border: 1px solid #dee2e6; border-radius: 8px; background: #fff; }
+.graph line { stroke: #adb5bd; stroke-width: 1.2; }
+.graph line.active { stroke: #212529; stroke-width: 2.5; }
+.graph circle { stroke: #fff; stroke-width: 2; cursor: pointer; }
+.graph circle.target { fill: #0d6efd; }
+.graph text { font-size: 10px; fill: #495057; pointer-events: none; }
+.graph .dim { opacity: 0.2; }
+.high { fill: #dc3545; background: #dc3545; }
+.medium { fill: #fd7e14; background: #fd7e14; }
+.low { fill: #ffc107; background: #ffc107; }
+table { border-collapse: collapse; width: 100%; margin: 1em 0; }
+th, td { border: 1px solid #dee2e6; padding: 6px 10px; text-align: left; }
+th { background: #f8f9fa; cursor: pointer; user-select: none; }
+th.asc::after { content: " \25B2"; }
+th.desc::after { content: " \25BC"; }
+tr.selected td { background: #e7f1ff; }
+td.severity { color: #fff; font-weight: bold; text-transform: uppercase; font-size: 0.8em; }
+</style>
+</head>
+<body>
+`)
+	sb.WriteString(fmt.Sprintf("<h1>%s</h1>\n", html.EscapeString(title)))
+	sb.WriteString(fmt.Sprintf("<p class=\"meta\">%s &middot; depth %d</p>\n", html.EscapeString(r.TargetURI), r.MaxDepth))
+
+	counts := make(map[ImpactSeverity]int)
+	nodes := r.allNodes()
+	for _, node := range nodes {
+		counts[node.Severity()]++
+	}
+
+	sb.WriteString("<div class=\"summary\">\n")
+	for _, card := range []struct {
+		label string
+		value int
+	}{
+		{"Total affected", r.Summary.TotalAffected},
+		{"Direct incoming", r.Summary.DirectIncomingCount},
+		{"Direct outgoing", r.Summary.DirectOutgoingCount},
+		{"Transitive", r.Summary.TransitiveCount},
+		{"High severity", counts[SeverityHigh]},
+		{"Max depth reached", r.Summary.MaxDepthReached},
+	} {
+		sb.WriteString(fmt.Sprintf("<div class=\"card\"><div>%s</div><div class=\"value\">%d</div></div>\n", card.label, card.value))
+	}
+	sb.WriteString("</div>\n")
+
+	sb.WriteString("<h2>Impact Neighborhood</h2>\n")
+	if len(nodes) == 0 {
+		sb.WriteString("<p>No provisions reference or are referenced by the target.</p>\n")
+	} else {
+		r.writeImpactGraph(&sb)
+	}
+
+	sb.WriteString("<h2>Affected Provisions</h2>\n")
+	sb.WriteString("<table class=\"sortable\" id=\"provisions\">\n<thead><tr><th>Severity</th><th>Depth</th><th>Direction</th><th>Type</th><th>Provision</th><th>URI</th></tr></thead>\n<tbody>\n")
+	for _, node := range nodes {
+		severity := node.Severity()
+		sb.WriteString(fmt.Sprintf("<tr data-uri=\"%s\"><td class=\"severity %s\" data-sort=\"%d\">%s</td><td>%d</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			html.EscapeString(node.URI), severity, severityRank(severity), severity, node.Depth,
+			html.EscapeString(node.Direction), html.EscapeString(node.Type),
+			html.EscapeString(node.Label), html.EscapeString(node.URI)))
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+
+	if len(r.Summary.AffectedByType) > 0 {
+		types := make([]string, 0, len(r.Summary.AffectedByType))
+		for nodeType := range r.Summary.AffectedByType {
+			types = append(types, nodeType)
+		}
+		sort.Strings(types)
+		sb.WriteString("<h2>Affected by Type</h2>\n")
+		sb.WriteString("<table class=\"sortable\">\n<thead><tr><th>Type</th><th>Count</th></tr></thead>\n<tbody>\n")
+		for _, nodeType := range types {
+			sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(nodeType), r.Summary.AffectedByType[nodeType]))
+		}
+		sb.WriteString("</tbody>\n</table>\n")
+	}
+
+	sb.WriteString(impactHTMLScript)
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
+
+// writeImpactGraph writes the impact neighborhood as an inline SVG.
+func (r *ImpactResult) writeImpactGraph(sb *strings.Builder) {
+	size := 2 * (float64(max(r.Summary.MaxDepthReached, 1))*impactRingGap + impactGraphPad)
+	positions, nodes := r.layoutImpactGraph(size)
+
+	sb.WriteString("<p class=\"legend\"><span><span class=\"swatch\" style=\"background:#0d6efd\"></span>target</span>")
+	for _, severity := range []ImpactSeverity{SeverityHigh, SeverityMedium, SeverityLow} {
+		sb.WriteString(fmt.Sprintf("<span><span class=\"swatch %s\"></span>%s</span>", severity, severity))
+	}
+	sb.WriteString("<span class=\"meta\">Incoming on the left, outgoing on the right. Click a provision to highlight its links; drag to move it.</span></p>\n")
+
+	sb.WriteString(fmt.Sprintf("<svg class=\"graph\" id=\"impact-graph\" viewBox=\"0 0 %.0f %.0f\" width=\"100%%\" style=\"max-height: 80vh\">\n", size, size))
+	for _, edge := range r.Edges {
+		source, sourceOK := positions[edge.Source]
+		target, targetOK := positions[edge.Target]
+		if !sourceOK || !targetOK {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("<line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\" data-source=\"%s\" data-target=\"%s\"/>\n",
+			source.x, source.y, target.x, target.y, html.EscapeString(edge.Source), html.EscapeString(edge.Target)))
+	}
+
+	center := positions[r.TargetURI]
+	sb.WriteString(fmt.Sprintf("<g data-uri=\"%s\"><circle class=\"target\" cx=\"%.1f\" cy=\"%.1f\" r=\"%.0f\"><title>%s</title></circle><text x=\"%.1f\" y=\"%.1f\">%s</text></g>\n",
+		html.EscapeString(r.TargetURI), center.x, center.y, impactNodeRadius*1.6, html.EscapeString(r.TargetLabel),
+		center.x+impactNodeRadius*2, center.y+4, html.EscapeString(extractURILabel(r.TargetURI))))
+	for _, node := range nodes {
+		placed := positions[node.URI]
+		sb.WriteString(fmt.Sprintf("<g data-uri=\"%s\"><circle class=\"%s\" cx=\"%.1f\" cy=\"%.1f\" r=\"%.0f\"><title>%s (%s, depth %d %s)</title></circle><text x=\"%.1f\" y=\"%.1f\">%s</text></g>\n",
+			html.EscapeString(node.URI), node.Severity(), placed.x, placed.y, impactNodeRadius,
+			html.EscapeString(node.Label), html.EscapeString(node.Type), node.Depth, html.EscapeString(node.Direction),
+			placed.x+impactNodeRadius+3, placed.y+4, html.EscapeString(extractURILabel(node.URI))))
+	}
+	sb.WriteString("</svg>\n")
+}
+
+// severityRank orders severities from most to least severe for sorting.
+func severityRank(severity ImpactSeverity) int {
+	switch severity {
+	case SeverityHigh:
+		return 0
+	case SeverityMedium:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// impactHTMLScript makes the report's tables sortable and its graph
+// interactive: clicking a node highlights its edges and table row, and
+// nodes can be dragged.
+const impactHTMLScript = `<script>
+(function () {
+  document.querySelectorAll("table.sortable").forEach(function (table) {
+    table.querySelectorAll("th").forEach(function (header, column) {
+      header.addEventListener("click", function () {
+        var ascending = !header.classList.contains("asc");
+        table.querySelectorAll("th").forEach(function (h) { h.classList.remove("asc", "desc"); });
+        header.classList.add(ascending ? "asc" : "desc");
+        var body = table.tBodies[0];
+        var rows = Array.prototype.slice.call(body.rows);
+        var key = function (row) {
+          var cell = row.cells[column];
+          return cell.dataset.sort !== undefined ? cell.dataset.sort : cell.textContent;
+        };
+        rows.sort(function (a, b) {
+          var x = key(a), y = key(b);
+          var order = (!isNaN(x) && !isNaN(y)) ? x - y : x.localeCompare(y);
+          return ascending ? order : -order;
+        });
+        rows.forEach(function (row) { body.appendChild(row); });
+      });
+    });
+  });
+
+  var svg = document.getElementById("impact-graph");
+  if (!svg) { return; }
+  var lines = Array.prototype.slice.call(svg.querySelectorAll("line"));
+  var groups = Array.prototype.slice.call(svg.querySelectorAll("g[data-uri]"));
+  var selected = null;
+
+  function select(uri) {
+    selected = selected === uri ? null : uri;
+    var linked = {};
+    lines.forEach(function (line) {
+      var active = selected !== null && (line.dataset.source === selected || line.dataset.target === selected);
+      line.classList.toggle("active", active);
+      line.classList.toggle("dim", selected !== null && !active);
+      if (active) { linked[line.dataset.source] = true; linked[line.dataset.target] = true; }
+    });
+    groups.forEach(function (group) {
+      group.classList.toggle("dim", selected !== null && group.dataset.uri !== selected && !linked[group.dataset.uri]);
+    });
+    document.querySelectorAll("#provisions tbody tr").forEach(function (row) {
+      var match = row.dataset.uri === selected;
+      row.classList.toggle("selected", match);
+      if (match) { row.scrollIntoView({block: "nearest"}); }
+    });
+  }
+
+  function point(event) {
+    var p = svg.createSVGPoint();
+    p.x = event.clientX; p.y = event.clientY;
+    return p.matrixTransform(svg.getScreenCTM().inverse());
+  }
+
+  groups.forEach(function (group) {
+    var circle = group.querySelector("circle");
+    var label = group.querySelector("text");
+    var uri = group.dataset.uri;
+    var dragging = false, moved = false;
+    circle.addEventListener("mousedown", function (event) { dragging = true; moved = false; event.preventDefault(); });
+    window.addEventListener("mousemove", function (event) {
+      if (!dragging) { return; }
+      moved = true;
+      var p = point(event);
+      var offset = parseFloat(label.getAttribute("x")) - parseFloat(circle.getAttribute("cx"));
+      circle.setAttribute("cx", p.x); circle.setAttribute("cy", p.y);
+      label.setAttribute("x", p.x + offset); label.setAttribute("y", p.y + 4);
+      lines.forEach(function (line) {
+        if (line.dataset.source === uri) { line.setAttribute("x1", p.x); line.setAttribute("y1", p.y); }
+        if (line.dataset.target === uri) { line.setAttribute("x2", p.x); line.setAttribute("y2", p.y); }
+      });
+    });
+    window.addEventListener("mouseup", function () {
+      if (dragging && !moved) { select(uri); }
+      dragging = false;
+    });
+  });
+  document.querySelectorAll("#provisions tbody tr").forEach(function (row) {
+    row.addEventListener("click", function () { select(row.dataset.uri); });
+  });
+})();
+</script>
+`
@@ -0,0 +1,63 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func TestImpactNode_Severity(t *testing.T) {
+	tests := []struct {
+		depth     int
+		direction string
+		want      ImpactSeverity
+	}{
+		{1, "incoming", SeverityHigh},
+		{1, "outgoing", SeverityMedium},
+		{2, "incoming", SeverityMedium},
+		{2, "outgoing", SeverityLow},
+		{3, "incoming", SeverityLow},
+	}
+	for _, tt := range tests {
+		node := &ImpactNode{Depth: tt.depth, Direction: tt.direction}
+		if got := node.Severity(); got != tt.want {
+			t.Errorf("Severity() at depth %d %s = %s, want %s", tt.depth, tt.direction, got, tt.want)
+		}
+	}
+}
+
+func TestImpactResult_ToHTML(t *testing.T) {
+	ts := store.NewTripleStore()
+	baseURI := "https://regula.dev/regulations/"
+	ts.Add(baseURI+"GDPR:Art21", store.PropTitle, "Right to <object>")
+	ts.Add(baseURI+"GDPR:Art17", store.PropTitle, "Right to erasure")
+	ts.Add(baseURI+"GDPR:Art21", store.PropReferences, baseURI+"GDPR:Art17")
+	ts.Add(baseURI+"GDPR:Art17", store.PropReferences, baseURI+"GDPR:Art6")
+	ts.Add(baseURI+"GDPR:Art6", store.PropReferences, baseURI+"GDPR:Art5")
+
+	page := NewImpactAnalyzer(ts, baseURI).AnalyzeByID("Art17", 2, DirectionBoth).ToHTML()
+
+	for _, want := range []string{
+		"<title>Impact Analysis: Right to erasure</title>",
+		`<svg class="graph" id="impact-graph"`,
+		`data-source="https://regula.dev/regulations/GDPR:Art21" data-target="https://regula.dev/regulations/GDPR:Art17"`,
+		`<td class="severity high" data-sort="0">high</td>`,
+		"Right to &lt;object&gt;",
+		`<table class="sortable" id="provisions">`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("HTML report missing %q", want)
+		}
+	}
+	if strings.Contains(page, "<script src=") || strings.Contains(page, "<link ") {
+		t.Error("HTML report should not load external resources")
+	}
+}
+
+func TestImpactResult_ToHTMLNoImpact(t *testing.T) {
+	page := NewImpactAnalyzer(store.NewTripleStore(), "https://regula.dev/regulations/").AnalyzeByID("Art99", 2, DirectionBoth).ToHTML()
+	if strings.Contains(page, "<svg") || !strings.Contains(page, "No provisions reference") {
+		t.Errorf("report without affected provisions should omit the graph")
+	}
+}
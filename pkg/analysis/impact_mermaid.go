@@ -0,0 +1,64 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// ToMermaid renders the impact neighborhood as a Mermaid flowchart for
+// pasting into Markdown documents and issues. The target is drawn as a
+// stadium and affected provisions are colored by severity, as in ToHTML.
+func (r *ImpactResult) ToMermaid() string {
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+
+	nodeIDs := map[string]string{r.TargetURI: "target"}
+	sb.WriteString(fmt.Sprintf("  target([\"%s\"])\n", store.MermaidLabel(mermaidNodeLabel(r.TargetURI, r.TargetLabel))))
+
+	members := make(map[ImpactSeverity][]string)
+	for i, node := range r.allNodes() {
+		id := fmt.Sprintf("n%d", i)
+		nodeIDs[node.URI] = id
+		sb.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", id, store.MermaidLabel(mermaidNodeLabel(node.URI, node.Label))))
+		members[node.Severity()] = append(members[node.Severity()], id)
+	}
+
+	for _, edge := range r.Edges {
+		source, sourceOK := nodeIDs[edge.Source]
+		target, targetOK := nodeIDs[edge.Target]
+		if !sourceOK || !targetOK {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  %s -->|%s| %s\n", source, store.MermaidLabel(extractURILabel(edge.Predicate)), target))
+	}
+
+	sb.WriteString("  classDef focus fill:#0d6efd,color:#fff,stroke:#333\n")
+	sb.WriteString("  class target focus\n")
+	for _, severity := range []ImpactSeverity{SeverityHigh, SeverityMedium, SeverityLow} {
+		if len(members[severity]) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  classDef %s fill:%s,stroke:#333\n", severity, severityColors[severity]))
+		sb.WriteString(fmt.Sprintf("  class %s %s\n", strings.Join(members[severity], ","), severity))
+	}
+	return sb.String()
+}
+
+// severityColors are the fill colors of each severity in diagrams.
+var severityColors = map[ImpactSeverity]string{
+	SeverityHigh:   "#dc3545",
+	SeverityMedium: "#fd7e14",
+	SeverityLow:    "#ffc107",
+}
+
+// mermaidNodeLabel labels a node with its identifier, followed by its title
+// when the title is more than the identifier.
+func mermaidNodeLabel(uri, label string) string {
+	id := extractURILabel(uri)
+	if label == "" || label == id {
+		return id
+	}
+	return id + ": " + label
+}
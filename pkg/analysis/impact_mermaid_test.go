@@ -0,0 +1,35 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func TestImpactResult_ToMermaid(t *testing.T) {
+	ts := store.NewTripleStore()
+	baseURI := "https://regula.dev/regulations/"
+	ts.Add(baseURI+"GDPR:Art17", store.PropTitle, "Right to erasure")
+	ts.Add(baseURI+"GDPR:Art21", store.PropReferences, baseURI+"GDPR:Art17")
+	ts.Add(baseURI+"GDPR:Art17", store.PropReferences, baseURI+"GDPR:Art6")
+
+	output := NewImpactAnalyzer(ts, baseURI).AnalyzeByID("Art17", 1, DirectionBoth).ToMermaid()
+	for _, want := range []string{
+		"flowchart LR\n",
+		`target(["Art17: Right to erasure"])`,
+		`n0["Art21"]`,
+		`n1["Art6"]`,
+		"n0 -->|references| target",
+		"target -->|references| n1",
+		"class n0 high",
+		"class n1 medium",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Mermaid output missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "classDef low") {
+		t.Errorf("unused severity classes should be omitted:\n%s", output)
+	}
+}
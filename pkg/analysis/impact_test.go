@@ -1,7 +1,10 @@
 package analysis
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/coolbeans/regula/pkg/extract"
@@ -89,6 +92,66 @@ func TestAnalyzeDirectImpact(t *testing.T) {
 	}
 }
 
+func TestAnalyzeGranularity(t *testing.T) {
+	ts := store.NewTripleStore()
+	baseURI := "https://regula.dev/regulations/"
+	art6 := baseURI + "GDPR:Art6"
+	para1 := art6 + "(1)"
+	para4 := art6 + "(4)"
+	pointA := para1 + "(a)"
+
+	ts.Add(art6, store.RDFType, store.ClassArticle)
+	for _, para := range []string{para1, para4} {
+		ts.Add(para, store.RDFType, store.ClassParagraph)
+		ts.Add(para, store.PropPartOf, art6)
+		ts.Add(art6, store.PropHasParagraph, para)
+	}
+	ts.Add(pointA, store.RDFType, store.ClassPoint)
+	ts.Add(pointA, store.PropPartOf, para1)
+	ts.Add(para1, store.PropHasPoint, pointA)
+
+	// Art7 cites "Article 6(1)(a)"; Art9 cites "Article 6(4)". Each citation
+	// also links the article, as the graph builder does.
+	ts.Add(baseURI+"GDPR:Art7", store.PropReferences, pointA)
+	ts.Add(baseURI+"GDPR:Art7", store.PropReferences, art6)
+	ts.Add(baseURI+"GDPR:Art9", store.PropReferences, para4)
+	ts.Add(baseURI+"GDPR:Art9", store.PropReferences, art6)
+
+	analyzer := NewImpactAnalyzer(ts, baseURI)
+
+	outgoing := func(result *ImpactResult) []string {
+		var uris []string
+		for _, node := range result.DirectOutgoing {
+			uris = append(uris, extractURILabel(node.URI))
+		}
+		return uris
+	}
+
+	// Article granularity reports the cited article once
+	result := analyzer.Analyze(baseURI+"GDPR:Art7", 1, DirectionOutgoing)
+	if got := outgoing(result); len(got) != 1 || got[0] != "Art6" {
+		t.Errorf("article granularity outgoing = %v, want [Art6]", got)
+	}
+
+	analyzer.SetGranularity(GranularityParagraph)
+
+	// Paragraph granularity raises the point to its paragraph and drops the
+	// article it belongs to
+	result = analyzer.Analyze(baseURI+"GDPR:Art7", 1, DirectionOutgoing)
+	if got := outgoing(result); len(got) != 1 || got[0] != "Art6(1)" {
+		t.Errorf("paragraph granularity outgoing = %v, want [Art6(1)]", got)
+	}
+	if result.Granularity != GranularityParagraph {
+		t.Errorf("Granularity = %q, want paragraph", result.Granularity)
+	}
+
+	// A paragraph is affected by citations of it or its points only
+	result = analyzer.Analyze(para1, 1, DirectionIncoming)
+	if len(result.DirectIncoming) != 1 || result.DirectIncoming[0].URI != baseURI+"GDPR:Art7" {
+		t.Errorf("incoming to Art6(1) = %+v, want only Art7", result.DirectIncoming)
+	}
+}
+
 func TestAnalyzeTransitiveImpact(t *testing.T) {
 	ts := store.NewTripleStore()
 	baseURI := "https://regula.dev/regulations/"
@@ -246,6 +309,47 @@ func TestImpactResultJSON(t *testing.T) {
 	}
 }
 
+func TestImpactResultNDJSON(t *testing.T) {
+	ts := store.NewTripleStore()
+	baseURI := "https://regula.dev/regulations/"
+	ts.Add(baseURI+"GDPR:Art17", store.RDFType, store.ClassArticle)
+	ts.Add(baseURI+"GDPR:Art19", store.RDFType, store.ClassArticle)
+	ts.Add(baseURI+"GDPR:Art6", store.RDFType, store.ClassArticle)
+	ts.Add(baseURI+"GDPR:Art19", store.PropReferences, baseURI+"GDPR:Art17")
+	ts.Add(baseURI+"GDPR:Art17", store.PropReferences, baseURI+"GDPR:Art6")
+
+	result := NewImpactAnalyzer(ts, baseURI).AnalyzeByID("Art17", 1, DirectionBoth)
+
+	var buf bytes.Buffer
+	if err := result.WriteNDJSON(&buf); err != nil {
+		t.Fatalf("WriteNDJSON() error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	wantNodes := len(result.DirectIncoming) + len(result.DirectOutgoing) + len(result.TransitiveNodes)
+	if wantNodes == 0 {
+		t.Fatal("expected affected nodes")
+	}
+	if len(lines) != wantNodes+1 {
+		t.Fatalf("got %d lines, want %d", len(lines), wantNodes+1)
+	}
+	for i, line := range lines {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("line %d is not JSON: %v", i, err)
+		}
+		want := "node"
+		if i == len(lines)-1 {
+			want = "summary"
+		}
+		if record["record"] != want {
+			t.Errorf("line %d record = %v, want %s", i, record["record"], want)
+		}
+	}
+	if !strings.Contains(lines[len(lines)-1], `"total_affected"`) {
+		t.Errorf("summary missing total_affected: %s", lines[len(lines)-1])
+	}
+}
+
 func TestGDPRArt17Impact(t *testing.T) {
 	// Integration test with real GDPR data
 	file, err := os.Open("../../testdata/gdpr.txt")
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // RuleMatrix represents a cross-reference adjacency matrix between rules.
@@ -145,7 +146,7 @@ func BuildRuleMatrix(tripleStore *store.TripleStore) *RuleMatrix {
 	for rule := range rulesMap {
 		rules = append(rules, rule)
 	}
-	sortRomanNumerals(rules)
+	textutil.SortRomanNumerals(rules)
 
 	// Create index map for quick lookup
 	ruleIndex := make(map[string]int)
@@ -254,34 +255,6 @@ func extractRuleFromIdentifier(identifier string) string {
 	return ruleNum
 }
 
-// sortRomanNumerals sorts Roman numerals in numeric order.
-func sortRomanNumerals(numerals []string) {
-	sort.Slice(numerals, func(i, j int) bool {
-		return romanToInt(numerals[i]) < romanToInt(numerals[j])
-	})
-}
-
-// romanToInt converts a Roman numeral to an integer.
-func romanToInt(s string) int {
-	values := map[rune]int{
-		'I': 1, 'V': 5, 'X': 10, 'L': 50,
-		'C': 100, 'D': 500, 'M': 1000,
-	}
-
-	result := 0
-	prev := 0
-	for i := len(s) - 1; i >= 0; i-- {
-		val := values[rune(s[i])]
-		if val < prev {
-			result -= val
-		} else {
-			result += val
-		}
-		prev = val
-	}
-	return result
-}
-
 // MostConnected returns the rules with the most connections (incoming + outgoing).
 func (m *RuleMatrix) MostConnected(limit int) []RuleConnection {
 	connections := make([]RuleConnection, len(m.Rules))
@@ -386,7 +359,7 @@ func (m *RuleMatrix) FindClusters() []RuleCluster {
 		}
 
 		if len(component) > 1 {
-			sortRomanNumerals(component)
+			textutil.SortRomanNumerals(component)
 			clusters = append(clusters, RuleCluster{
 				Rules: component,
 				Size:  len(component),
@@ -413,8 +386,8 @@ func (m *RuleMatrix) ToASCII() string {
 	// Calculate column widths
 	maxRuleLen := 4 // minimum width for "Rule"
 	for _, rule := range m.Rules {
-		if len(rule) > maxRuleLen {
-			maxRuleLen = len(rule)
+		if textutil.RuneLen(rule) > maxRuleLen {
+			maxRuleLen = textutil.RuneLen(rule)
 		}
 	}
 	colWidth := maxRuleLen
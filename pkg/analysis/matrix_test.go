@@ -50,46 +50,6 @@ func TestBuildRuleMatrix_SimpleRefs(t *testing.T) {
 	}
 }
 
-func TestRomanToInt(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected int
-	}{
-		{"I", 1},
-		{"II", 2},
-		{"III", 3},
-		{"IV", 4},
-		{"V", 5},
-		{"IX", 9},
-		{"X", 10},
-		{"XI", 11},
-		{"XVIII", 18},
-		{"XIX", 19},
-		{"XX", 20},
-		{"XXI", 21},
-		{"XXIX", 29},
-	}
-
-	for _, tc := range tests {
-		result := romanToInt(tc.input)
-		if result != tc.expected {
-			t.Errorf("romanToInt(%q): expected %d, got %d", tc.input, tc.expected, result)
-		}
-	}
-}
-
-func TestSortRomanNumerals(t *testing.T) {
-	numerals := []string{"X", "I", "XX", "V", "III", "IX", "XVIII"}
-	sortRomanNumerals(numerals)
-
-	expected := []string{"I", "III", "V", "IX", "X", "XVIII", "XX"}
-	for i, exp := range expected {
-		if numerals[i] != exp {
-			t.Errorf("Position %d: expected %q, got %q", i, exp, numerals[i])
-		}
-	}
-}
-
 func TestExtractRuleFromURI(t *testing.T) {
 	tests := []struct {
 		uri      string
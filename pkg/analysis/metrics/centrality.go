@@ -0,0 +1,148 @@
+package metrics
+
+import "math"
+
+// PageRank defaults.
+const (
+	DefaultDamping       = 0.85
+	DefaultMaxIterations = 100
+	pageRankTolerance    = 1e-10
+)
+
+// PageRank computes the PageRank of every node by power iteration: a
+// provision is important if important provisions cite it. Rank held by
+// provisions that cite nothing is spread evenly over all nodes. Scores sum
+// to 1 and are returned in node index order.
+func (g *Graph) PageRank(damping float64, maxIterations int) []float64 {
+	n := g.NodeCount()
+	if n == 0 {
+		return nil
+	}
+	if damping <= 0 || damping >= 1 {
+		damping = DefaultDamping
+	}
+	if maxIterations <= 0 {
+		maxIterations = DefaultMaxIterations
+	}
+
+	rank := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		dangling := 0.0
+		for i, targets := range g.out {
+			if len(targets) == 0 {
+				dangling += rank[i]
+			}
+		}
+
+		base := (1-damping)/float64(n) + damping*dangling/float64(n)
+		for i := range next {
+			next[i] = base
+		}
+		for i, targets := range g.out {
+			if len(targets) == 0 {
+				continue
+			}
+			share := damping * rank[i] / float64(len(targets))
+			for _, target := range targets {
+				next[target] += share
+			}
+		}
+
+		change := 0.0
+		for i := range rank {
+			change += math.Abs(next[i] - rank[i])
+		}
+		rank, next = next, rank
+		if change < pageRankTolerance {
+			break
+		}
+	}
+	return rank
+}
+
+// Betweenness computes the betweenness centrality of every node with
+// Brandes' algorithm: the share of shortest citation paths between other
+// provisions that pass through it. Scores are normalized by the number of
+// ordered node pairs, (n-1)(n-2), so they fall in [0, 1].
+func (g *Graph) Betweenness() []float64 {
+	n := g.NodeCount()
+	centrality := make([]float64, n)
+	if n < 3 {
+		return centrality
+	}
+
+	sigma := make([]float64, n)
+	distance := make([]int, n)
+	delta := make([]float64, n)
+	predecessors := make([][]int, n)
+	stack := make([]int, 0, n)
+	queue := make([]int, 0, n)
+
+	for source := 0; source < n; source++ {
+		stack = stack[:0]
+		queue = queue[:0]
+		for i := 0; i < n; i++ {
+			sigma[i] = 0
+			distance[i] = -1
+			delta[i] = 0
+			predecessors[i] = predecessors[i][:0]
+		}
+		sigma[source] = 1
+		distance[source] = 0
+		queue = append(queue, source)
+
+		for head := 0; head < len(queue); head++ {
+			v := queue[head]
+			stack = append(stack, v)
+			for _, w := range g.out[v] {
+				if distance[w] < 0 {
+					distance[w] = distance[v] + 1
+					queue = append(queue, w)
+				}
+				if distance[w] == distance[v]+1 {
+					sigma[w] += sigma[v]
+					predecessors[w] = append(predecessors[w], v)
+				}
+			}
+		}
+
+		for i := len(stack) - 1; i >= 0; i-- {
+			w := stack[i]
+			for _, v := range predecessors[w] {
+				delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+			}
+			if w != source {
+				centrality[w] += delta[w]
+			}
+		}
+	}
+
+	scale := 1 / float64((n-1)*(n-2))
+	for i := range centrality {
+		centrality[i] *= scale
+	}
+	return centrality
+}
+
+// InDegree returns the number of distinct provisions citing each node.
+func (g *Graph) InDegree() []int {
+	degrees := make([]int, g.NodeCount())
+	for i, sources := range g.in {
+		degrees[i] = len(sources)
+	}
+	return degrees
+}
+
+// OutDegree returns the number of distinct provisions each node cites.
+func (g *Graph) OutDegree() []int {
+	degrees := make([]int, g.NodeCount())
+	for i, targets := range g.out {
+		degrees[i] = len(targets)
+	}
+	return degrees
+}
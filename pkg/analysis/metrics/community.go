@@ -0,0 +1,219 @@
+package metrics
+
+import "sort"
+
+// modularityEpsilon is the smallest modularity gain worth a move, so that
+// floating-point noise cannot make nodes oscillate between communities.
+const modularityEpsilon = 1e-12
+
+// weightedGraph is the undirected, weighted graph the Louvain method works
+// on. adjacency[i][i] holds twice the weight of links inside an aggregated
+// node, so a node's degree is always the sum of its adjacency row.
+type weightedGraph struct {
+	adjacency []map[int]float64
+	degree    []float64
+	total     float64
+}
+
+// Communities partitions the graph with the Louvain method, treating
+// citations as undirected links (a mutual citation weighs twice as much as
+// a one-way one). It returns each node's community, numbered from 0 by
+// decreasing size, and the modularity of the partition. Provisions with
+// no references form singleton communities.
+func (g *Graph) Communities() ([]int, float64) {
+	n := g.NodeCount()
+	original := g.undirected()
+
+	membership := make([]int, n)
+	for i := range membership {
+		membership[i] = i
+	}
+
+	current := original
+	for current.total > 0 {
+		assignment, moved := current.louvainPass()
+		if !moved {
+			break
+		}
+		communityCount := renumber(assignment)
+		for i := range membership {
+			membership[i] = assignment[membership[i]]
+		}
+		current = current.aggregate(assignment, communityCount)
+	}
+
+	membership = numberBySize(membership)
+	return membership, original.modularity(membership)
+}
+
+// undirected projects the reference graph onto an undirected weighted one.
+func (g *Graph) undirected() *weightedGraph {
+	n := g.NodeCount()
+	graph := &weightedGraph{
+		adjacency: make([]map[int]float64, n),
+		degree:    make([]float64, n),
+	}
+	for i := range graph.adjacency {
+		graph.adjacency[i] = make(map[int]float64)
+	}
+	for from, targets := range g.out {
+		for _, to := range targets {
+			graph.adjacency[from][to]++
+			graph.adjacency[to][from]++
+			graph.degree[from]++
+			graph.degree[to]++
+			graph.total += 2
+		}
+	}
+	return graph
+}
+
+// louvainPass repeatedly moves each node into the neighboring community
+// with the largest modularity gain until no move helps. It reports whether
+// any node moved.
+func (w *weightedGraph) louvainPass() ([]int, bool) {
+	n := len(w.adjacency)
+	community := make([]int, n)
+	communityDegree := make([]float64, n)
+	for i := range community {
+		community[i] = i
+		communityDegree[i] = w.degree[i]
+	}
+
+	moved := false
+	for improved := true; improved; {
+		improved = false
+		for node := 0; node < n; node++ {
+			nodeDegree := w.degree[node]
+			if nodeDegree == 0 {
+				continue
+			}
+
+			links := make(map[int]float64)
+			for neighbor, weight := range w.adjacency[node] {
+				if neighbor != node {
+					links[community[neighbor]] += weight
+				}
+			}
+			candidates := make([]int, 0, len(links))
+			for candidate := range links {
+				candidates = append(candidates, candidate)
+			}
+			sort.Ints(candidates)
+
+			home := community[node]
+			communityDegree[home] -= nodeDegree
+			best := home
+			bestGain := links[home] - communityDegree[home]*nodeDegree/w.total
+			for _, candidate := range candidates {
+				gain := links[candidate] - communityDegree[candidate]*nodeDegree/w.total
+				if gain > bestGain+modularityEpsilon {
+					best, bestGain = candidate, gain
+				}
+			}
+			communityDegree[best] += nodeDegree
+
+			if best != home {
+				community[node] = best
+				improved = true
+				moved = true
+			}
+		}
+	}
+	return community, moved
+}
+
+// aggregate collapses each community into a single node.
+func (w *weightedGraph) aggregate(assignment []int, communityCount int) *weightedGraph {
+	aggregated := &weightedGraph{
+		adjacency: make([]map[int]float64, communityCount),
+		degree:    make([]float64, communityCount),
+		total:     w.total,
+	}
+	for i := range aggregated.adjacency {
+		aggregated.adjacency[i] = make(map[int]float64)
+	}
+	for node, neighbors := range w.adjacency {
+		from := assignment[node]
+		for neighbor, weight := range neighbors {
+			aggregated.adjacency[from][assignment[neighbor]] += weight
+		}
+		aggregated.degree[from] += w.degree[node]
+	}
+	return aggregated
+}
+
+// modularity scores a partition: the fraction of link weight inside
+// communities minus the fraction expected if links were placed at random.
+func (w *weightedGraph) modularity(membership []int) float64 {
+	if w.total == 0 {
+		return 0
+	}
+	internal := make(map[int]float64)
+	degree := make(map[int]float64)
+	for node, neighbors := range w.adjacency {
+		for neighbor, weight := range neighbors {
+			if membership[node] == membership[neighbor] {
+				internal[membership[node]] += weight
+			}
+		}
+		degree[membership[node]] += w.degree[node]
+	}
+
+	modularity := 0.0
+	for community, communityDegree := range degree {
+		fraction := communityDegree / w.total
+		modularity += internal[community]/w.total - fraction*fraction
+	}
+	return modularity
+}
+
+// renumber rewrites community labels in place as 0..k-1 in order of first
+// appearance and returns k.
+func renumber(assignment []int) int {
+	labels := make(map[int]int)
+	for i, community := range assignment {
+		label, ok := labels[community]
+		if !ok {
+			label = len(labels)
+			labels[community] = label
+		}
+		assignment[i] = label
+	}
+	return len(labels)
+}
+
+// numberBySize renumbers communities from 0 by decreasing size, breaking
+// ties by their lowest node index.
+func numberBySize(membership []int) []int {
+	size := make(map[int]int)
+	first := make(map[int]int)
+	for node, community := range membership {
+		if _, ok := first[community]; !ok {
+			first[community] = node
+		}
+		size[community]++
+	}
+
+	communities := make([]int, 0, len(size))
+	for community := range size {
+		communities = append(communities, community)
+	}
+	sort.Slice(communities, func(i, j int) bool {
+		a, b := communities[i], communities[j]
+		if size[a] != size[b] {
+			return size[a] > size[b]
+		}
+		return first[a] < first[b]
+	})
+
+	number := make(map[int]int, len(communities))
+	for rank, community := range communities {
+		number[community] = rank
+	}
+	numbered := make([]int, len(membership))
+	for node, community := range membership {
+		numbered[node] = number[community]
+	}
+	return numbered
+}
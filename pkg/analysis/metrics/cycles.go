@@ -0,0 +1,237 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// Cycle kinds.
+const (
+	CycleReference  = "reference"
+	CycleDefinition = "definition"
+)
+
+// Cycle is a group of nodes that all reach one another: provisions that
+// cite each other, directly or through others, or terms defined in terms
+// of each other. Path is a shortest cycle through the group, starting and
+// ending at its first member.
+type Cycle struct {
+	Kind       string   `json:"kind"`
+	Members    []string `json:"members"`
+	Path       []string `json:"path"`
+	PathLabels []string `json:"path_labels"`
+}
+
+// CycleReport lists the reference and definitional cycles in a graph.
+type CycleReport struct {
+	ReferenceCycles    []Cycle `json:"reference_cycles"`
+	DefinitionCycles   []Cycle `json:"definition_cycles"`
+	Provisions         int     `json:"provisions"`
+	ProvisionsInCycles int     `json:"provisions_in_cycles"`
+	Terms              int     `json:"terms"`
+	TermsInCycles      int     `json:"terms_in_cycles"`
+}
+
+// FindCycles finds reference cycles between provisions and definitional
+// cycles between defined terms in tripleStore.
+func FindCycles(tripleStore *store.TripleStore) *CycleReport {
+	referenceGraph := BuildReferenceGraph(tripleStore)
+	definitionGraph, termLabels := BuildDefinitionGraph(tripleStore)
+
+	report := &CycleReport{
+		ReferenceCycles:  referenceGraph.Cycles(CycleReference, provisionID),
+		DefinitionCycles: definitionGraph.Cycles(CycleDefinition, func(uri string) string { return termLabels[uri] }),
+		Provisions:       referenceGraph.NodeCount(),
+		Terms:            definitionGraph.NodeCount(),
+	}
+	for _, cycle := range report.ReferenceCycles {
+		report.ProvisionsInCycles += len(cycle.Members)
+	}
+	for _, cycle := range report.DefinitionCycles {
+		report.TermsInCycles += len(cycle.Members)
+	}
+	return report
+}
+
+// BuildDefinitionGraph builds the graph of defined terms in tripleStore,
+// linking each term to the terms of the same document its definition uses
+// (see loadDefinedTerms). It also returns each term's text by URI.
+func BuildDefinitionGraph(tripleStore *store.TripleStore) (*Graph, map[string]string) {
+	terms := loadDefinedTerms(tripleStore)
+
+	labels := make(map[string]string, len(terms))
+	uris := make([]string, len(terms))
+	for i, term := range terms {
+		uris[i] = term.uri
+		labels[term.uri] = term.term
+	}
+	graph := NewGraph(uris)
+	for _, term := range terms {
+		for _, used := range term.uses {
+			graph.AddEdge(term.uri, used)
+		}
+	}
+	return graph, labels
+}
+
+// Cycles returns the graph's cycles of the given kind, one per strongly
+// connected component of two or more nodes, labeling path nodes with label.
+func (g *Graph) Cycles(kind string, label func(uri string) string) []Cycle {
+	cycles := make([]Cycle, 0)
+	for _, component := range g.StronglyConnectedComponents() {
+		cycle := Cycle{Kind: kind}
+		for _, node := range component {
+			cycle.Members = append(cycle.Members, g.uris[node])
+		}
+		for _, node := range g.shortestCycle(component) {
+			cycle.Path = append(cycle.Path, g.uris[node])
+			cycle.PathLabels = append(cycle.PathLabels, label(g.uris[node]))
+		}
+		cycles = append(cycles, cycle)
+	}
+	return cycles
+}
+
+// StronglyConnectedComponents returns the components of two or more nodes
+// in which every node reaches every other, found with Tarjan's algorithm.
+// Each component is sorted, and components are ordered by their first node.
+func (g *Graph) StronglyConnectedComponents() [][]int {
+	n := g.NodeCount()
+	index := make([]int, n)
+	lowLink := make([]int, n)
+	onStack := make([]bool, n)
+	for i := range index {
+		index[i] = -1
+	}
+
+	var components [][]int
+	var stack []int
+	nextIndex := 0
+
+	var visit func(v int)
+	visit = func(v int) {
+		index[v] = nextIndex
+		lowLink[v] = nextIndex
+		nextIndex++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range g.out[v] {
+			if index[w] < 0 {
+				visit(w)
+				lowLink[v] = min(lowLink[v], lowLink[w])
+			} else if onStack[w] {
+				lowLink[v] = min(lowLink[v], index[w])
+			}
+		}
+
+		if lowLink[v] == index[v] {
+			var component []int
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			if len(component) > 1 {
+				sort.Ints(component)
+				components = append(components, component)
+			}
+		}
+	}
+
+	for v := 0; v < n; v++ {
+		if index[v] < 0 {
+			visit(v)
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0] < components[j][0]
+	})
+	return components
+}
+
+// shortestCycle returns a shortest cycle through the component's first
+// node, staying inside the component, as a path that ends where it starts.
+func (g *Graph) shortestCycle(component []int) []int {
+	inComponent := make(map[int]bool, len(component))
+	for _, node := range component {
+		inComponent[node] = true
+	}
+
+	start := component[0]
+	parent := map[int]int{start: -1}
+	queue := []int{start}
+	for head := 0; head < len(queue); head++ {
+		v := queue[head]
+		for _, w := range g.out[v] {
+			if w == start {
+				path := []int{start}
+				for node := v; node != start; node = parent[node] {
+					path = append(path, node)
+				}
+				path = append(path, start)
+				// The path was collected backwards from the last hop.
+				for i, j := 1, len(path)-2; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			if _, seen := parent[w]; !seen && inComponent[w] {
+				parent[w] = v
+				queue = append(queue, w)
+			}
+		}
+	}
+	return nil
+}
+
+// ToJSON serializes the report to JSON.
+func (r *CycleReport) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// String returns a human-readable list of cycles.
+func (r *CycleReport) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("Dependency cycles\n")
+	sb.WriteString(fmt.Sprintf("Reference cycles: %d (%d of %d provisions) | Definition cycles: %d (%d of %d terms)\n",
+		len(r.ReferenceCycles), r.ProvisionsInCycles, r.Provisions,
+		len(r.DefinitionCycles), r.TermsInCycles, r.Terms))
+	sb.WriteString(strings.Repeat("=", 60) + "\n")
+
+	writeCycles := func(title string, cycles []Cycle) {
+		sb.WriteString("\n" + title + ":\n")
+		if len(cycles) == 0 {
+			sb.WriteString("  None found.\n")
+			return
+		}
+		for i, cycle := range cycles {
+			sb.WriteString(fmt.Sprintf("  %d. %s\n", i+1, strings.Join(cycle.PathLabels, " → ")))
+			if len(cycle.Members) > len(cycle.Path)-1 {
+				sb.WriteString(fmt.Sprintf("     (%d %ss reach one another in this group)\n", len(cycle.Members), cycleNoun(cycle.Kind)))
+			}
+		}
+	}
+	writeCycles("Reference cycles", r.ReferenceCycles)
+	writeCycles("Definition cycles", r.DefinitionCycles)
+
+	return sb.String()
+}
+
+// cycleNoun names the nodes of a cycle kind.
+func cycleNoun(kind string) string {
+	if kind == CycleDefinition {
+		return "term"
+	}
+	return "provision"
+}
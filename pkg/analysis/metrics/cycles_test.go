@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	// a ⇄ b, and c → d → e → c, with f hanging off the second cycle.
+	graph := buildGraph(
+		[]string{"a", "b", "c", "d", "e", "f"},
+		[][2]string{{"a", "b"}, {"b", "a"}, {"c", "d"}, {"d", "e"}, {"e", "c"}, {"e", "f"}, {"b", "c"}})
+
+	components := graph.StronglyConnectedComponents()
+	if len(components) != 2 {
+		t.Fatalf("expected 2 components, got %v", components)
+	}
+	if len(components[0]) != 2 || components[0][0] != 0 || components[0][1] != 1 {
+		t.Errorf("first component: %v", components[0])
+	}
+	if len(components[1]) != 3 || components[1][0] != 2 {
+		t.Errorf("second component: %v", components[1])
+	}
+
+	if components := buildGraph([]string{"a", "b"}, [][2]string{{"a", "b"}}).StronglyConnectedComponents(); len(components) != 0 {
+		t.Errorf("acyclic graph: got %v", components)
+	}
+}
+
+func TestCycles_ShortestPath(t *testing.T) {
+	// a → b → c → d → a, with a shortcut c → a.
+	graph := buildGraph(
+		[]string{"a", "b", "c", "d"},
+		[][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "a"}, {"c", "a"}})
+
+	cycles := graph.Cycles(CycleReference, strings.ToUpper)
+	if len(cycles) != 1 {
+		t.Fatalf("expected 1 cycle, got %d", len(cycles))
+	}
+	cycle := cycles[0]
+	if len(cycle.Members) != 4 {
+		t.Errorf("members: %v", cycle.Members)
+	}
+	if got := strings.Join(cycle.PathLabels, " "); got != "A B C A" {
+		t.Errorf("path: got %q, want %q", got, "A B C A")
+	}
+}
+
+func addTerm(tripleStore *store.TripleStore, document, term, definition string) {
+	uri := "https://regula.dev/regulations/" + document + ":Term:" + strings.ReplaceAll(term, " ", "_")
+	tripleStore.Add(uri, store.RDFType, store.ClassDefinedTerm)
+	tripleStore.Add(uri, store.PropTerm, term)
+	tripleStore.Add(uri, store.PropDefinition, definition)
+	tripleStore.Add(uri, store.PropBelongsTo, "https://regula.dev/regulations/"+document)
+}
+
+func TestBuildDefinitionGraph(t *testing.T) {
+	tripleStore := store.NewTripleStore()
+	addTerm(tripleStore, "TEST", "controller", "the person who determines the purposes of processing")
+	addTerm(tripleStore, "TEST", "processing", "any operation performed by a Controller on personal data")
+	addTerm(tripleStore, "TEST", "personal data", "any information relating to a natural person")
+	addTerm(tripleStore, "TEST", "data", "facts recorded in any form")
+	addTerm(tripleStore, "OTHER", "controller", "an operator of processing systems")
+
+	graph, labels := BuildDefinitionGraph(tripleStore)
+	if graph.NodeCount() != 5 {
+		t.Fatalf("expected 5 terms, got %d", graph.NodeCount())
+	}
+
+	edges := make(map[string]bool)
+	for v, targets := range graph.out {
+		for _, w := range targets {
+			edges[provisionID(graph.uris[v])+" → "+labels[graph.uris[w]]] = true
+		}
+	}
+	for _, expected := range []string{"TEST:Term:controller → processing", "TEST:Term:processing → controller", "TEST:Term:processing → personal data"} {
+		if !edges[expected] {
+			t.Errorf("missing edge %q in %v", expected, edges)
+		}
+	}
+	if edges["TEST:Term:processing → data"] {
+		t.Error(`"data" should not match inside "personal data"`)
+	}
+	if edges["OTHER:Term:controller → processing"] {
+		t.Error("terms should not link across documents")
+	}
+}
+
+func TestFindCycles(t *testing.T) {
+	tripleStore := testReferenceStore()
+	tripleStore.Add("https://regula.dev/regulations/TEST:Art1", store.PropReferences, "https://regula.dev/regulations/TEST:Art4")
+	addTerm(tripleStore, "TEST", "controller", "the person who determines the purposes of processing")
+	addTerm(tripleStore, "TEST", "processing", "any operation performed by a controller")
+	addTerm(tripleStore, "TEST", "recipient", "a person to whom data is disclosed")
+
+	report := FindCycles(tripleStore)
+
+	if len(report.ReferenceCycles) != 1 || report.ProvisionsInCycles != 3 || report.Provisions != 5 {
+		t.Errorf("reference cycles: %+v", report)
+	}
+	if len(report.DefinitionCycles) != 1 || report.TermsInCycles != 2 || report.Terms != 3 {
+		t.Errorf("definition cycles: %+v", report)
+	}
+
+	text := report.String()
+	for _, expected := range []string{
+		"Reference cycles: 1 (3 of 5 provisions)",
+		"1. TEST:Art1 → TEST:Art4 → TEST:Art1",
+		"(3 provisions reach one another in this group)",
+		"1. controller → processing → controller",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("text report missing %q:\n%s", expected, text)
+		}
+	}
+}
+
+func TestFindCycles_None(t *testing.T) {
+	report := FindCycles(testReferenceStore())
+	if len(report.ReferenceCycles) != 0 || len(report.DefinitionCycles) != 0 {
+		t.Errorf("expected no cycles, got %+v", report)
+	}
+	if !strings.Contains(report.String(), "None found.") {
+		t.Errorf("text report:\n%s", report.String())
+	}
+	data, err := report.ToJSON()
+	if err != nil || !strings.Contains(string(data), `"reference_cycles": []`) {
+		t.Errorf("JSON report: %s, %v", data, err)
+	}
+}
@@ -0,0 +1,233 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// TermDependencies lists the defined terms one definition uses and the
+// terms whose definitions use it, by URI, and the quoted terms it uses
+// undefined.
+type TermDependencies struct {
+	URI       string   `json:"uri"`
+	Term      string   `json:"term"`
+	Uses      []string `json:"uses,omitempty"`
+	UsedBy    []string `json:"used_by,omitempty"`
+	Undefined []string `json:"undefined,omitempty"`
+}
+
+// UndefinedTerm is a term that definitions quote but no definition of the
+// same document defines. UsedIn holds the URIs of those definitions.
+type UndefinedTerm struct {
+	Term   string   `json:"term"`
+	UsedIn []string `json:"used_in"`
+}
+
+// DefinitionReport is the dependency graph of the defined terms in a graph.
+type DefinitionReport struct {
+	Terms     []TermDependencies `json:"terms"`
+	Links     int                `json:"links"`
+	Undefined []UndefinedTerm    `json:"undefined"`
+}
+
+// definedTerm is a defined term of a triple store with its dependencies.
+type definedTerm struct {
+	uri       string
+	term      string
+	uses      []string // URIs
+	undefined []string
+}
+
+// loadDefinedTerms reads the defined terms in tripleStore, sorted by URI,
+// and links each to the terms of the same document its definition uses,
+// as extract.ExtractDefinitionDependencies does at ingest time. Definitions
+// are matched from their text, so graphs built without
+// reg:definitionUses links are analyzed the same way.
+func loadDefinedTerms(tripleStore *store.TripleStore) []*definedTerm {
+	type documentTerms struct {
+		terms       []*definedTerm
+		definitions []*extract.DefinedTerm
+		uris        map[string]string // normalized term to URI
+	}
+
+	var terms []*definedTerm
+	documents := make(map[string]*documentTerms)
+	var documentOrder []string
+	subjects := tripleStore.Find("", store.RDFType, store.ClassDefinedTerm)
+	sort.Slice(subjects, func(i, j int) bool { return subjects[i].Subject < subjects[j].Subject })
+	for _, triple := range subjects {
+		term := tripleStore.GetOne(triple.Subject, store.PropTerm)
+		if strings.TrimSpace(term) == "" {
+			continue
+		}
+		document := tripleStore.GetOne(triple.Subject, store.PropBelongsTo)
+		group, ok := documents[document]
+		if !ok {
+			group = &documentTerms{uris: make(map[string]string)}
+			documents[document] = group
+			documentOrder = append(documentOrder, document)
+		}
+
+		normalized := strings.Join(strings.Fields(strings.ToLower(term)), " ")
+		if _, seen := group.uris[normalized]; !seen {
+			group.uris[normalized] = triple.Subject
+		}
+		stored := &definedTerm{uri: triple.Subject, term: term}
+		terms = append(terms, stored)
+		group.terms = append(group.terms, stored)
+		group.definitions = append(group.definitions, &extract.DefinedTerm{
+			Term:           term,
+			NormalizedTerm: normalized,
+			Definition:     tripleStore.GetOne(triple.Subject, store.PropDefinition),
+		})
+	}
+
+	for _, document := range documentOrder {
+		group := documents[document]
+		for i, dependency := range extract.ExtractDefinitionDependencies(group.definitions) {
+			for _, used := range dependency.Uses {
+				group.terms[i].uses = append(group.terms[i].uses, group.uris[used])
+			}
+			group.terms[i].undefined = dependency.Undefined
+		}
+	}
+	return terms
+}
+
+// FindDefinitionDependencies reports which defined terms in tripleStore
+// each definition uses, and the terms definitions quote without defining.
+func FindDefinitionDependencies(tripleStore *store.TripleStore) *DefinitionReport {
+	terms := loadDefinedTerms(tripleStore)
+
+	report := &DefinitionReport{
+		Terms:     make([]TermDependencies, len(terms)),
+		Undefined: make([]UndefinedTerm, 0),
+	}
+	index := make(map[string]int, len(terms))
+	for i, term := range terms {
+		index[term.uri] = i
+		report.Terms[i] = TermDependencies{URI: term.uri, Term: term.term, Uses: term.uses, Undefined: term.undefined}
+	}
+
+	undefinedIndex := make(map[string]int)
+	for _, term := range terms {
+		for _, used := range term.uses {
+			report.Terms[index[used]].UsedBy = append(report.Terms[index[used]].UsedBy, term.uri)
+			report.Links++
+		}
+		for _, undefined := range term.undefined {
+			position, ok := undefinedIndex[undefined]
+			if !ok {
+				position = len(report.Undefined)
+				undefinedIndex[undefined] = position
+				report.Undefined = append(report.Undefined, UndefinedTerm{Term: undefined})
+			}
+			report.Undefined[position].UsedIn = append(report.Undefined[position].UsedIn, term.uri)
+		}
+	}
+	sort.Slice(report.Undefined, func(i, j int) bool {
+		return report.Undefined[i].Term < report.Undefined[j].Term
+	})
+	return report
+}
+
+// ToJSON serializes the report to JSON.
+func (r *DefinitionReport) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// String returns the most used terms, the undefined terms, and each
+// definition's dependencies.
+func (r *DefinitionReport) String() string {
+	var sb strings.Builder
+	labels := make(map[string]string, len(r.Terms))
+	for _, term := range r.Terms {
+		labels[term.URI] = term.Term
+	}
+	termLabels := func(uris []string) string {
+		names := make([]string, len(uris))
+		for i, uri := range uris {
+			names[i] = labels[uri]
+		}
+		return strings.Join(names, ", ")
+	}
+
+	sb.WriteString("Definition dependencies\n")
+	sb.WriteString(fmt.Sprintf("Terms: %d | Links: %d | Undefined terms: %d\n", len(r.Terms), r.Links, len(r.Undefined)))
+	sb.WriteString(strings.Repeat("=", 60) + "\n")
+
+	mostUsed := make([]TermDependencies, 0, len(r.Terms))
+	for _, term := range r.Terms {
+		if len(term.UsedBy) > 0 {
+			mostUsed = append(mostUsed, term)
+		}
+	}
+	sort.SliceStable(mostUsed, func(i, j int) bool { return len(mostUsed[i].UsedBy) > len(mostUsed[j].UsedBy) })
+	if len(mostUsed) > 10 {
+		mostUsed = mostUsed[:10]
+	}
+	sb.WriteString("\nMost used in other definitions:\n")
+	if len(mostUsed) == 0 {
+		sb.WriteString("  None found.\n")
+	}
+	for _, term := range mostUsed {
+		sb.WriteString(fmt.Sprintf("  %-32s %d\n", term.Term, len(term.UsedBy)))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nUndefined terms (%d):\n", len(r.Undefined)))
+	if len(r.Undefined) == 0 {
+		sb.WriteString("  None found.\n")
+	}
+	for _, undefined := range r.Undefined {
+		sb.WriteString(fmt.Sprintf("  %q used in %s\n", undefined.Term, termLabels(undefined.UsedIn)))
+	}
+
+	sb.WriteString("\nDependencies:\n")
+	for _, term := range r.Terms {
+		if len(term.Uses) > 0 {
+			sb.WriteString(fmt.Sprintf("  %s → %s\n", term.Term, termLabels(term.Uses)))
+		}
+	}
+
+	return sb.String()
+}
+
+// ToDOT renders the definition dependency graph in Graphviz DOT, with an
+// edge from each term to the terms its definition uses. Undefined terms
+// are drawn dashed in red.
+func (r *DefinitionReport) ToDOT() string {
+	var sb strings.Builder
+
+	sb.WriteString("digraph DefinitionDependencies {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box style=rounded fontname=\"Helvetica\" fontsize=10];\n\n")
+
+	nodes := make(map[string]string, len(r.Terms))
+	for i, term := range r.Terms {
+		nodes[term.URI] = fmt.Sprintf("t%d", i)
+		sb.WriteString(fmt.Sprintf("  t%d [label=%q];\n", i, term.Term))
+	}
+	for i, undefined := range r.Undefined {
+		sb.WriteString(fmt.Sprintf("  u%d [label=%q style=\"rounded,dashed\" color=red];\n", i, undefined.Term))
+	}
+	sb.WriteString("\n")
+
+	for _, term := range r.Terms {
+		for _, used := range term.Uses {
+			sb.WriteString(fmt.Sprintf("  %s -> %s;\n", nodes[term.URI], nodes[used]))
+		}
+	}
+	for i, undefined := range r.Undefined {
+		for _, usedIn := range undefined.UsedIn {
+			sb.WriteString(fmt.Sprintf("  %s -> u%d [style=dashed color=red];\n", nodes[usedIn], i))
+		}
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func TestFindDefinitionDependencies(t *testing.T) {
+	tripleStore := store.NewTripleStore()
+	addTerm(tripleStore, "TEST", "controller", "the person who determines the purposes of processing")
+	addTerm(tripleStore, "TEST", "processing", "any operation performed on personal data by a ‘data user’")
+	addTerm(tripleStore, "TEST", "personal data", "any information relating to a natural person")
+	addTerm(tripleStore, "OTHER", "processor", "a body that processes data for a controller")
+
+	report := FindDefinitionDependencies(tripleStore)
+	if len(report.Terms) != 4 || report.Links != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	byTerm := make(map[string]TermDependencies)
+	for _, term := range report.Terms {
+		byTerm[term.Term] = term
+	}
+	if uses := byTerm["processing"].Uses; len(uses) != 1 || !strings.HasSuffix(uses[0], "TEST:Term:personal_data") {
+		t.Errorf("processing uses %v", uses)
+	}
+	if usedBy := byTerm["processing"].UsedBy; len(usedBy) != 1 || !strings.HasSuffix(usedBy[0], "TEST:Term:controller") {
+		t.Errorf("processing used by %v", usedBy)
+	}
+	if len(byTerm["processor"].Uses) != 0 {
+		t.Errorf("terms should not link across documents: %v", byTerm["processor"].Uses)
+	}
+	if len(report.Undefined) != 1 || report.Undefined[0].Term != "data user" {
+		t.Errorf("undefined = %+v", report.Undefined)
+	}
+
+	text := report.String()
+	for _, expected := range []string{"Terms: 4 | Links: 2 | Undefined terms: 1", `"data user" used in processing`, "controller → processing"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("text report missing %q:\n%s", expected, text)
+		}
+	}
+
+	dot := report.ToDOT()
+	for _, expected := range []string{"digraph DefinitionDependencies {", `[label="personal data"]`, "[style=dashed color=red]"} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("DOT output missing %q:\n%s", expected, dot)
+		}
+	}
+}
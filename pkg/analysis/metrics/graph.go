@@ -0,0 +1,113 @@
+// Package metrics computes graph analytics over the reference graph of one
+// or more regulations: PageRank, betweenness centrality, degree, and
+// community detection, to rank the structurally most important provisions.
+package metrics
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// Graph is a directed, unweighted reference graph between provisions. Nodes
+// are indexed in URI order, so every computation over the graph is
+// deterministic.
+type Graph struct {
+	uris     []string
+	index    map[string]int
+	out      [][]int
+	in       [][]int
+	edgeSize int
+}
+
+// BuildReferenceGraph builds the graph of reg:references links in
+// tripleStore. Its nodes are every article plus every provision that cites
+// or is cited by another, so that articles nothing refers to are still
+// ranked. Self-references, such as an article citing its own paragraphs,
+// are dropped.
+func BuildReferenceGraph(tripleStore *store.TripleStore) *Graph {
+	nodes := make(map[string]bool)
+	for _, triple := range tripleStore.Find("", store.RDFType, store.ClassArticle) {
+		nodes[triple.Subject] = true
+	}
+
+	type edge struct{ from, to string }
+	var edges []edge
+	for _, triple := range tripleStore.Find("", store.PropReferences, "") {
+		if triple.Subject == triple.Object || !isResource(triple.Object) {
+			continue
+		}
+		nodes[triple.Subject] = true
+		nodes[triple.Object] = true
+		edges = append(edges, edge{triple.Subject, triple.Object})
+	}
+
+	uris := make([]string, 0, len(nodes))
+	for uri := range nodes {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	graph := NewGraph(uris)
+	for _, e := range edges {
+		graph.AddEdge(e.from, e.to)
+	}
+	return graph
+}
+
+// NewGraph creates a graph with the given nodes and no edges.
+func NewGraph(uris []string) *Graph {
+	graph := &Graph{
+		uris:  uris,
+		index: make(map[string]int, len(uris)),
+		out:   make([][]int, len(uris)),
+		in:    make([][]int, len(uris)),
+	}
+	for i, uri := range uris {
+		graph.index[uri] = i
+	}
+	return graph
+}
+
+// AddEdge adds a link from one node to another. Links to unknown nodes,
+// self-links, and duplicates are ignored.
+func (g *Graph) AddEdge(from, to string) {
+	fromIndex, fromOK := g.index[from]
+	toIndex, toOK := g.index[to]
+	if !fromOK || !toOK || fromIndex == toIndex {
+		return
+	}
+	for _, existing := range g.out[fromIndex] {
+		if existing == toIndex {
+			return
+		}
+	}
+	g.out[fromIndex] = append(g.out[fromIndex], toIndex)
+	g.in[toIndex] = append(g.in[toIndex], fromIndex)
+	g.edgeSize++
+}
+
+// NodeCount returns the number of nodes.
+func (g *Graph) NodeCount() int {
+	return len(g.uris)
+}
+
+// EdgeCount returns the number of distinct links.
+func (g *Graph) EdgeCount() int {
+	return g.edgeSize
+}
+
+// URIs returns the node URIs in index order.
+func (g *Graph) URIs() []string {
+	return g.uris
+}
+
+// isResource reports whether a triple object names a node rather than a
+// literal value.
+func isResource(value string) bool {
+	return strings.HasPrefix(value, "http://") ||
+		strings.HasPrefix(value, "https://") ||
+		strings.HasPrefix(value, "urn:") ||
+		(strings.Contains(value, ":") && !strings.Contains(value, " "))
+}
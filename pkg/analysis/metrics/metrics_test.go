@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func buildGraph(nodes []string, edges [][2]string) *Graph {
+	graph := NewGraph(nodes)
+	for _, edge := range edges {
+		graph.AddEdge(edge[0], edge[1])
+	}
+	return graph
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestPageRank(t *testing.T) {
+	cycle := buildGraph([]string{"a", "b", "c"}, [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}})
+	for i, rank := range cycle.PageRank(DefaultDamping, DefaultMaxIterations) {
+		if !approxEqual(rank, 1.0/3) {
+			t.Errorf("cycle node %d: got %f, want 1/3", i, rank)
+		}
+	}
+
+	// a, b, and c cite d; d cites nothing, so its rank is redistributed.
+	star := buildGraph([]string{"a", "b", "c", "d"}, [][2]string{{"a", "d"}, {"b", "d"}, {"c", "d"}})
+	ranks := star.PageRank(DefaultDamping, DefaultMaxIterations)
+	sum := 0.0
+	for i, rank := range ranks {
+		sum += rank
+		if i < 3 && rank >= ranks[3] {
+			t.Errorf("leaf %d rank %f not below hub rank %f", i, rank, ranks[3])
+		}
+	}
+	if !approxEqual(sum, 1) {
+		t.Errorf("ranks sum to %f, want 1", sum)
+	}
+
+	if ranks := NewGraph(nil).PageRank(DefaultDamping, DefaultMaxIterations); ranks != nil {
+		t.Errorf("empty graph: got %v", ranks)
+	}
+}
+
+func TestBetweenness(t *testing.T) {
+	// Every path from a to c passes through b.
+	path := buildGraph([]string{"a", "b", "c"}, [][2]string{{"a", "b"}, {"b", "c"}})
+	expected := []float64{0, 0.5, 0}
+	for i, score := range path.Betweenness() {
+		if !approxEqual(score, expected[i]) {
+			t.Errorf("node %d: got %f, want %f", i, score, expected[i])
+		}
+	}
+
+	// Two equal shortest paths from a to d share the credit.
+	diamond := buildGraph([]string{"a", "b", "c", "d"}, [][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"c", "d"}})
+	scores := diamond.Betweenness()
+	if !approxEqual(scores[1], 0.5/6) || !approxEqual(scores[2], 0.5/6) || scores[0] != 0 || scores[3] != 0 {
+		t.Errorf("diamond betweenness: %v", scores)
+	}
+}
+
+func TestDegree(t *testing.T) {
+	graph := buildGraph([]string{"a", "b", "c"}, [][2]string{{"a", "b"}, {"a", "c"}, {"b", "c"}, {"a", "b"}, {"c", "c"}})
+	if graph.EdgeCount() != 3 {
+		t.Errorf("duplicate and self links should be ignored, got %d edges", graph.EdgeCount())
+	}
+	if in := graph.InDegree(); in[0] != 0 || in[1] != 1 || in[2] != 2 {
+		t.Errorf("in-degree: %v", in)
+	}
+	if out := graph.OutDegree(); out[0] != 2 || out[1] != 1 || out[2] != 0 {
+		t.Errorf("out-degree: %v", out)
+	}
+}
+
+func TestCommunities(t *testing.T) {
+	// Two triangles joined by a single link, plus an isolated node.
+	graph := buildGraph(
+		[]string{"a1", "a2", "a3", "b1", "b2", "b3", "lone"},
+		[][2]string{
+			{"a1", "a2"}, {"a2", "a3"}, {"a3", "a1"},
+			{"b1", "b2"}, {"b2", "b3"}, {"b3", "b1"},
+			{"a3", "b1"},
+		})
+
+	communities, modularity := graph.Communities()
+	if communities[0] != communities[1] || communities[1] != communities[2] {
+		t.Errorf("first triangle split: %v", communities)
+	}
+	if communities[3] != communities[4] || communities[4] != communities[5] {
+		t.Errorf("second triangle split: %v", communities)
+	}
+	if communities[0] == communities[3] {
+		t.Errorf("triangles merged: %v", communities)
+	}
+	if communities[6] != 2 {
+		t.Errorf("isolated node should be the smallest community, got %d", communities[6])
+	}
+	if modularity < 0.3 {
+		t.Errorf("modularity %f, want at least 0.3", modularity)
+	}
+
+	again, _ := graph.Communities()
+	for i := range communities {
+		if again[i] != communities[i] {
+			t.Fatal("community detection is not deterministic")
+		}
+	}
+}
+
+func TestCommunities_NoEdges(t *testing.T) {
+	communities, modularity := NewGraph([]string{"a", "b"}).Communities()
+	if communities[0] == communities[1] || modularity != 0 {
+		t.Errorf("got communities %v, modularity %f", communities, modularity)
+	}
+}
+
+func testReferenceStore() *store.TripleStore {
+	tripleStore := store.NewTripleStore()
+	for _, article := range []string{"Art1", "Art2", "Art3", "Art4", "Art5"} {
+		uri := "https://regula.dev/regulations/TEST:" + article
+		tripleStore.Add(uri, store.RDFType, store.ClassArticle)
+		tripleStore.Add(uri, store.PropTitle, "Title of "+article)
+	}
+	for _, link := range [][2]string{{"Art2", "Art1"}, {"Art3", "Art1"}, {"Art4", "Art1"}, {"Art4", "Art2"}, {"Art2", "Art2"}} {
+		tripleStore.Add("https://regula.dev/regulations/TEST:"+link[0], store.PropReferences, "https://regula.dev/regulations/TEST:"+link[1])
+	}
+	return tripleStore
+}
+
+func TestBuildReferenceGraph(t *testing.T) {
+	graph := BuildReferenceGraph(testReferenceStore())
+	if graph.NodeCount() != 5 {
+		t.Errorf("expected all 5 articles as nodes, got %d", graph.NodeCount())
+	}
+	if graph.EdgeCount() != 4 {
+		t.Errorf("expected 4 links without the self-reference, got %d", graph.EdgeCount())
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	report := Analyze(testReferenceStore(), Options{RankBy: RankInDegree, Limit: 2})
+
+	if report.Nodes != 5 || report.Edges != 4 || report.Isolated != 1 {
+		t.Errorf("report totals: nodes %d, edges %d, isolated %d", report.Nodes, report.Edges, report.Isolated)
+	}
+	if len(report.Provisions) != 2 {
+		t.Fatalf("expected 2 provisions with Limit 2, got %d", len(report.Provisions))
+	}
+	top := report.Provisions[0]
+	if !strings.HasSuffix(top.URI, ":Art1") || top.Rank != 1 || top.InDegree != 3 || top.Label != "Title of Art1" {
+		t.Errorf("top provision: %+v", top)
+	}
+	if !strings.HasSuffix(report.Provisions[1].URI, ":Art2") {
+		t.Errorf("second provision: %+v", report.Provisions[1])
+	}
+
+	text := report.String()
+	for _, expected := range []string{"ranked by in-degree", "1. TEST:Art1 — Title of Art1", "Isolated provisions (no references in or out): 1"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("text report missing %q:\n%s", expected, text)
+		}
+	}
+}
+
+func TestParseRankBy(t *testing.T) {
+	if rankBy, err := ParseRankBy("Betweenness"); err != nil || rankBy != RankBetweenness {
+		t.Errorf("ParseRankBy(Betweenness) = %q, %v", rankBy, err)
+	}
+	if _, err := ParseRankBy("closeness"); err == nil {
+		t.Error("expected an error for an unknown metric")
+	}
+}
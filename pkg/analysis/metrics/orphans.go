@@ -0,0 +1,325 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// Severity classifies a finding in an orphan report.
+type Severity string
+
+// Finding severities, from most to least urgent.
+const (
+	// SeverityError marks an internal reference whose target does not exist.
+	SeverityError Severity = "error"
+	// SeverityWarning marks a reference with several candidate targets, or a
+	// provision with no references in or out.
+	SeverityWarning Severity = "warning"
+	// SeverityInfo marks a provision that cites others but is never cited.
+	SeverityInfo Severity = "info"
+)
+
+var severityRanks = map[Severity]int{
+	SeverityError:   0,
+	SeverityWarning: 1,
+	SeverityInfo:    2,
+}
+
+// ParseSeverity parses a severity name.
+func ParseSeverity(name string) (Severity, error) {
+	severity := Severity(strings.ToLower(name))
+	if _, ok := severityRanks[severity]; !ok {
+		return "", fmt.Errorf("unknown severity %q (use error, warning, or info)", name)
+	}
+	return severity, nil
+}
+
+// AtLeast reports whether s is as urgent as min or more.
+func (s Severity) AtLeast(min Severity) bool {
+	return severityRanks[s] <= severityRanks[min]
+}
+
+// Orphan is a provision that nothing refers to.
+type Orphan struct {
+	URI       string   `json:"uri"`
+	Label     string   `json:"label"`
+	OutDegree int      `json:"out_degree"`
+	Severity  Severity `json:"severity"`
+	Reason    string   `json:"reason"`
+}
+
+// DanglingReference is an internal reference whose target is missing from
+// the graph or could not be determined.
+type DanglingReference struct {
+	Source       string   `json:"source"`
+	Text         string   `json:"text,omitempty"`
+	Target       string   `json:"target,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	Alternatives []string `json:"alternatives,omitempty"`
+	Severity     Severity `json:"severity"`
+	Reason       string   `json:"reason"`
+}
+
+// OrphanReport lists unreferenced provisions and dangling references.
+type OrphanReport struct {
+	Provisions         int                 `json:"provisions"`
+	References         int                 `json:"references"`
+	Orphans            []Orphan            `json:"orphans"`
+	DanglingReferences []DanglingReference `json:"dangling_references"`
+}
+
+// FindOrphans reports the articles in tripleStore that no other provision
+// references, and the internal references that do not lead to a provision
+// in the graph. External references and temporal markers are not checked.
+func FindOrphans(tripleStore *store.TripleStore) *OrphanReport {
+	report := &OrphanReport{
+		Orphans:            make([]Orphan, 0),
+		DanglingReferences: make([]DanglingReference, 0),
+	}
+
+	graph := BuildReferenceGraph(tripleStore)
+	inDegree := graph.InDegree()
+	outDegree := graph.OutDegree()
+	for i, uri := range graph.URIs() {
+		if !tripleStore.Exists(uri, store.RDFType, store.ClassArticle) {
+			continue
+		}
+		report.Provisions++
+		if inDegree[i] > 0 {
+			continue
+		}
+		orphan := Orphan{
+			URI:       uri,
+			Label:     provisionLabel(tripleStore, uri),
+			OutDegree: outDegree[i],
+			Severity:  SeverityInfo,
+			Reason:    fmt.Sprintf("never referenced; cites %d provision(s)", outDegree[i]),
+		}
+		if outDegree[i] == 0 {
+			orphan.Severity = SeverityWarning
+			orphan.Reason = "no references in or out"
+		}
+		report.Orphans = append(report.Orphans, orphan)
+	}
+
+	reported := make(map[string]bool)
+	for _, triple := range tripleStore.Find("", store.RDFType, store.ClassReference) {
+		reference := triple.Subject
+		// Temporal markers such as "shall enter into force" cite no provision.
+		if tripleStore.GetOne(reference, store.PropExternalRef) != "" ||
+			strings.HasPrefix(tripleStore.GetOne(reference, store.PropIdentifier), "temporal:") {
+			continue
+		}
+		report.References++
+
+		dangling := DanglingReference{
+			Source: tripleStore.GetOne(reference, store.PropPartOf),
+			Text:   tripleStore.GetOne(reference, store.PropText),
+			Status: tripleStore.GetOne(reference, store.PropResolutionStatus),
+		}
+		reason := tripleStore.GetOne(reference, store.PropResolutionReason)
+
+		switch dangling.Status {
+		case "not_found":
+			dangling.Severity = SeverityError
+			dangling.Reason = "target not found"
+		case "ambiguous":
+			for _, alternative := range tripleStore.Find(reference, store.PropAlternativeTarget, "") {
+				dangling.Alternatives = append(dangling.Alternatives, alternative.Object)
+			}
+			dangling.Severity = SeverityWarning
+			dangling.Reason = fmt.Sprintf("ambiguous between %d targets", len(dangling.Alternatives))
+		default:
+			for _, target := range tripleStore.Find(reference, store.PropResolvedTarget, "") {
+				if !isNode(tripleStore, target.Object) {
+					dangling.Target = target.Object
+					dangling.Severity = SeverityError
+					dangling.Reason = "target is not in the graph"
+					reported[dangling.Source+"|"+target.Object] = true
+					break
+				}
+			}
+		}
+		if dangling.Severity == "" {
+			continue
+		}
+		if reason != "" {
+			dangling.Reason += ": " + reason
+		}
+		report.DanglingReferences = append(report.DanglingReferences, dangling)
+	}
+
+	// Graphs built without resolution metadata still link articles directly.
+	for _, triple := range tripleStore.Find("", store.PropReferences, "") {
+		if !isResource(triple.Object) || isNode(tripleStore, triple.Object) || reported[triple.Subject+"|"+triple.Object] {
+			continue
+		}
+		reported[triple.Subject+"|"+triple.Object] = true
+		report.DanglingReferences = append(report.DanglingReferences, DanglingReference{
+			Source:   triple.Subject,
+			Target:   triple.Object,
+			Severity: SeverityError,
+			Reason:   "target is not in the graph",
+		})
+	}
+
+	sort.SliceStable(report.DanglingReferences, func(i, j int) bool {
+		a, b := report.DanglingReferences[i], report.DanglingReferences[j]
+		if a.Severity != b.Severity {
+			return severityRanks[a.Severity] < severityRanks[b.Severity]
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Target < b.Target
+	})
+	sort.SliceStable(report.Orphans, func(i, j int) bool {
+		return severityRanks[report.Orphans[i].Severity] < severityRanks[report.Orphans[j].Severity]
+	})
+
+	return report
+}
+
+// isNode reports whether uri is a typed node in tripleStore. Targets only
+// mentioned as the subject of reg:referencedBy do not count.
+func isNode(tripleStore *store.TripleStore, uri string) bool {
+	return tripleStore.GetOne(uri, store.RDFType) != ""
+}
+
+// Filter returns a copy of the report keeping only findings at least as
+// urgent as min.
+func (r *OrphanReport) Filter(min Severity) *OrphanReport {
+	filtered := &OrphanReport{
+		Provisions:         r.Provisions,
+		References:         r.References,
+		Orphans:            make([]Orphan, 0),
+		DanglingReferences: make([]DanglingReference, 0),
+	}
+	for _, orphan := range r.Orphans {
+		if orphan.Severity.AtLeast(min) {
+			filtered.Orphans = append(filtered.Orphans, orphan)
+		}
+	}
+	for _, dangling := range r.DanglingReferences {
+		if dangling.Severity.AtLeast(min) {
+			filtered.DanglingReferences = append(filtered.DanglingReferences, dangling)
+		}
+	}
+	return filtered
+}
+
+// SeverityCounts counts the findings of each severity.
+func (r *OrphanReport) SeverityCounts() map[Severity]int {
+	counts := make(map[Severity]int)
+	for _, orphan := range r.Orphans {
+		counts[orphan.Severity]++
+	}
+	for _, dangling := range r.DanglingReferences {
+		counts[dangling.Severity]++
+	}
+	return counts
+}
+
+// ToJSON serializes the report to JSON.
+func (r *OrphanReport) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// String returns a human-readable list of findings.
+func (r *OrphanReport) String() string {
+	var sb strings.Builder
+	counts := r.SeverityCounts()
+
+	sb.WriteString("Orphan and dangling reference report\n")
+	sb.WriteString(fmt.Sprintf("Provisions: %d | Internal references: %d | Errors: %d | Warnings: %d | Info: %d\n",
+		r.Provisions, r.References, counts[SeverityError], counts[SeverityWarning], counts[SeverityInfo]))
+	sb.WriteString(strings.Repeat("=", 60) + "\n")
+
+	sb.WriteString(fmt.Sprintf("\nDangling references (%d):\n", len(r.DanglingReferences)))
+	if len(r.DanglingReferences) == 0 {
+		sb.WriteString("  None found.\n")
+	}
+	for _, dangling := range r.DanglingReferences {
+		sb.WriteString(fmt.Sprintf("  [%s] %s%s — %s\n", dangling.Severity, provisionID(dangling.Source), danglingDetail(dangling), dangling.Reason))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nUnreferenced provisions (%d):\n", len(r.Orphans)))
+	if len(r.Orphans) == 0 {
+		sb.WriteString("  None found.\n")
+	}
+	for _, orphan := range r.Orphans {
+		sb.WriteString(fmt.Sprintf("  [%s] %s — %s (%s)\n", orphan.Severity, provisionID(orphan.URI), orphan.Label, orphan.Reason))
+	}
+
+	return sb.String()
+}
+
+// ToMarkdown returns the report as Markdown tables, for tracking cleanup
+// work in an issue or pull request.
+func (r *OrphanReport) ToMarkdown() string {
+	var sb strings.Builder
+	counts := r.SeverityCounts()
+
+	sb.WriteString("# Orphan and Dangling Reference Report\n\n")
+	sb.WriteString("| Metric | Value |\n|--------|-------|\n")
+	sb.WriteString(fmt.Sprintf("| Provisions | %d |\n", r.Provisions))
+	sb.WriteString(fmt.Sprintf("| Internal references | %d |\n", r.References))
+	sb.WriteString(fmt.Sprintf("| Dangling references | %d |\n", len(r.DanglingReferences)))
+	sb.WriteString(fmt.Sprintf("| Unreferenced provisions | %d |\n", len(r.Orphans)))
+	sb.WriteString(fmt.Sprintf("| Errors / Warnings / Info | %d / %d / %d |\n\n",
+		counts[SeverityError], counts[SeverityWarning], counts[SeverityInfo]))
+
+	sb.WriteString("## Dangling References\n\n")
+	if len(r.DanglingReferences) == 0 {
+		sb.WriteString("None found.\n\n")
+	} else {
+		sb.WriteString("| Severity | Source | Reference | Target | Reason |\n|----------|--------|-----------|--------|--------|\n")
+		for _, dangling := range r.DanglingReferences {
+			target := provisionID(dangling.Target)
+			if len(dangling.Alternatives) > 0 {
+				ids := make([]string, len(dangling.Alternatives))
+				for i, alternative := range dangling.Alternatives {
+					ids[i] = provisionID(alternative)
+				}
+				target = strings.Join(ids, ", ")
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", dangling.Severity, provisionID(dangling.Source),
+				markdownCell(textutil.Truncate(dangling.Text, 60)), markdownCell(target), markdownCell(dangling.Reason)))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Unreferenced Provisions\n\n")
+	if len(r.Orphans) == 0 {
+		sb.WriteString("None found.\n")
+	} else {
+		sb.WriteString("| Severity | Provision | Title | Cites |\n|----------|-----------|-------|-------|\n")
+		for _, orphan := range r.Orphans {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d |\n", orphan.Severity, provisionID(orphan.URI),
+				markdownCell(orphan.Label), orphan.OutDegree))
+		}
+	}
+
+	return sb.String()
+}
+
+// danglingDetail describes what a dangling reference says or points to.
+func danglingDetail(dangling DanglingReference) string {
+	switch {
+	case dangling.Text != "":
+		return fmt.Sprintf(" %q", dangling.Text)
+	case dangling.Target != "":
+		return " → " + provisionID(dangling.Target)
+	}
+	return ""
+}
+
+// markdownCell escapes text for a Markdown table cell.
+func markdownCell(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "|", "\\|"), "\n", " ")
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func TestFindOrphans(t *testing.T) {
+	tripleStore := testReferenceStore()
+	base := "https://regula.dev/regulations/TEST:"
+	// Art3 also cites a repealed article, and Art5 has an unresolved reference.
+	tripleStore.Add(base+"Art3", store.PropReferences, base+"Art99")
+	reference := base + "Art5:Ref:1"
+	tripleStore.Add(reference, store.RDFType, store.ClassReference)
+	tripleStore.Add(reference, store.PropPartOf, base+"Art5")
+	tripleStore.Add(reference, store.PropText, "Article 98")
+	tripleStore.Add(reference, store.PropResolutionStatus, "not_found")
+
+	report := FindOrphans(tripleStore)
+	if report.Provisions != 5 || report.References != 1 {
+		t.Errorf("provisions = %d, references = %d", report.Provisions, report.References)
+	}
+
+	severities := make(map[string]Severity)
+	for _, orphan := range report.Orphans {
+		severities[provisionID(orphan.URI)] = orphan.Severity
+	}
+	expected := map[string]Severity{"TEST:Art3": SeverityInfo, "TEST:Art4": SeverityInfo, "TEST:Art5": SeverityWarning}
+	if len(severities) != len(expected) {
+		t.Errorf("orphans: %v", severities)
+	}
+	for id, severity := range expected {
+		if severities[id] != severity {
+			t.Errorf("%s: got %q, want %q", id, severities[id], severity)
+		}
+	}
+	if report.Orphans[0].Severity != SeverityWarning {
+		t.Errorf("orphans not sorted by severity: %+v", report.Orphans)
+	}
+
+	if len(report.DanglingReferences) != 2 {
+		t.Fatalf("expected 2 dangling references, got %+v", report.DanglingReferences)
+	}
+	for _, dangling := range report.DanglingReferences {
+		if dangling.Severity != SeverityError {
+			t.Errorf("%+v: expected an error", dangling)
+		}
+	}
+
+	filtered := report.Filter(SeverityWarning)
+	if len(filtered.Orphans) != 1 || len(filtered.DanglingReferences) != 2 || filtered.Provisions != 5 {
+		t.Errorf("filtered report: %+v", filtered)
+	}
+	if markdown := filtered.ToMarkdown(); !strings.Contains(markdown, "| error | TEST:Art3 |  | TEST:Art99 |") {
+		t.Errorf("markdown missing dangling row:\n%s", markdown)
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	if severity, err := ParseSeverity("Warning"); err != nil || severity != SeverityWarning {
+		t.Errorf("got %q, %v", severity, err)
+	}
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Error("expected an error for an unknown severity")
+	}
+	if !SeverityError.AtLeast(SeverityWarning) || SeverityInfo.AtLeast(SeverityWarning) {
+		t.Error("AtLeast ordering is wrong")
+	}
+}
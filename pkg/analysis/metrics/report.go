@@ -0,0 +1,265 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// RankBy names the metric provisions are ranked by.
+type RankBy string
+
+// Ranking metrics.
+const (
+	RankPageRank    RankBy = "pagerank"
+	RankBetweenness RankBy = "betweenness"
+	RankInDegree    RankBy = "in-degree"
+	RankOutDegree   RankBy = "out-degree"
+)
+
+// ParseRankBy parses a ranking metric name.
+func ParseRankBy(name string) (RankBy, error) {
+	switch rankBy := RankBy(strings.ToLower(name)); rankBy {
+	case RankPageRank, RankBetweenness, RankInDegree, RankOutDegree:
+		return rankBy, nil
+	}
+	return "", fmt.Errorf("unknown metric %q (use pagerank, betweenness, in-degree, or out-degree)", name)
+}
+
+// Options controls a centrality analysis.
+type Options struct {
+	// RankBy is the metric provisions are ranked by (default PageRank).
+	RankBy RankBy
+
+	// Limit is the number of provisions reported (0 = all).
+	Limit int
+
+	// Damping is the PageRank damping factor (0 = DefaultDamping).
+	Damping float64
+}
+
+// ProvisionScore holds the metrics computed for one provision.
+type ProvisionScore struct {
+	Rank        int     `json:"rank"`
+	URI         string  `json:"uri"`
+	Label       string  `json:"label"`
+	PageRank    float64 `json:"pagerank"`
+	Betweenness float64 `json:"betweenness"`
+	InDegree    int     `json:"in_degree"`
+	OutDegree   int     `json:"out_degree"`
+	Community   int     `json:"community"`
+}
+
+// Community is a group of provisions more densely linked to one another
+// than to the rest of the graph. Members are ordered by PageRank.
+type Community struct {
+	ID      int      `json:"id"`
+	Size    int      `json:"size"`
+	Members []string `json:"members"`
+}
+
+// Report is the result of a centrality analysis.
+type Report struct {
+	RankedBy    RankBy            `json:"ranked_by"`
+	Nodes       int               `json:"nodes"`
+	Edges       int               `json:"edges"`
+	Modularity  float64           `json:"modularity"`
+	Provisions  []*ProvisionScore `json:"provisions"`
+	Communities []Community       `json:"communities"`
+	Isolated    int               `json:"isolated"`
+}
+
+// Analyze computes PageRank, betweenness, degree, and communities over the
+// reference graph in tripleStore and ranks provisions by options.RankBy.
+// Communities of a single provision are counted as isolated rather than
+// listed.
+func Analyze(tripleStore *store.TripleStore, options Options) *Report {
+	if options.RankBy == "" {
+		options.RankBy = RankPageRank
+	}
+
+	graph := BuildReferenceGraph(tripleStore)
+	pageRank := graph.PageRank(options.Damping, DefaultMaxIterations)
+	betweenness := graph.Betweenness()
+	inDegree := graph.InDegree()
+	outDegree := graph.OutDegree()
+	communities, modularity := graph.Communities()
+
+	scores := make([]*ProvisionScore, graph.NodeCount())
+	for i, uri := range graph.URIs() {
+		scores[i] = &ProvisionScore{
+			URI:         uri,
+			Label:       provisionLabel(tripleStore, uri),
+			PageRank:    pageRank[i],
+			Betweenness: betweenness[i],
+			InDegree:    inDegree[i],
+			OutDegree:   outDegree[i],
+			Community:   communities[i],
+		}
+	}
+
+	report := &Report{
+		RankedBy:    options.RankBy,
+		Nodes:       graph.NodeCount(),
+		Edges:       graph.EdgeCount(),
+		Modularity:  modularity,
+		Communities: groupCommunities(scores),
+		Isolated:    graph.NodeCount(),
+	}
+	for _, community := range report.Communities {
+		report.Isolated -= community.Size
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		a, b := scores[i].metric(options.RankBy), scores[j].metric(options.RankBy)
+		if a != b {
+			return a > b
+		}
+		return scores[i].PageRank > scores[j].PageRank
+	})
+	if options.Limit > 0 && len(scores) > options.Limit {
+		scores = scores[:options.Limit]
+	}
+	for i, score := range scores {
+		score.Rank = i + 1
+	}
+	report.Provisions = scores
+
+	return report
+}
+
+// metric returns the score's value for a ranking metric.
+func (s *ProvisionScore) metric(rankBy RankBy) float64 {
+	switch rankBy {
+	case RankBetweenness:
+		return s.Betweenness
+	case RankInDegree:
+		return float64(s.InDegree)
+	case RankOutDegree:
+		return float64(s.OutDegree)
+	default:
+		return s.PageRank
+	}
+}
+
+// groupCommunities lists the communities of two or more provisions, with
+// members in PageRank order.
+func groupCommunities(scores []*ProvisionScore) []Community {
+	members := make(map[int][]*ProvisionScore)
+	for _, score := range scores {
+		members[score.Community] = append(members[score.Community], score)
+	}
+
+	communities := make([]Community, 0)
+	for id := 0; id < len(members); id++ {
+		group := members[id]
+		if len(group) < 2 {
+			// Communities are numbered by decreasing size.
+			break
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].PageRank > group[j].PageRank
+		})
+		community := Community{ID: id, Size: len(group)}
+		for _, score := range group {
+			community.Members = append(community.Members, score.URI)
+		}
+		communities = append(communities, community)
+	}
+	return communities
+}
+
+// provisionLabel retrieves a display label for a provision.
+func provisionLabel(tripleStore *store.TripleStore, uri string) string {
+	for _, predicate := range []string{store.PropTitle, store.RDFSLabel} {
+		if triples := tripleStore.Find(uri, predicate, ""); len(triples) > 0 {
+			return triples[0].Object
+		}
+	}
+	return provisionID(uri)
+}
+
+// provisionID shortens a provision URI to its last path segment, such as
+// "GDPR:Art17".
+func provisionID(uri string) string {
+	if idx := strings.LastIndex(uri, "#"); idx != -1 {
+		return uri[idx+1:]
+	}
+	if idx := strings.LastIndex(uri, "/"); idx != -1 {
+		return uri[idx+1:]
+	}
+	return uri
+}
+
+// ToJSON serializes the report to JSON.
+func (r *Report) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// String returns a human-readable ranking followed by the communities.
+func (r *Report) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Provision centrality (ranked by %s)\n", r.RankedBy))
+	sb.WriteString(fmt.Sprintf("Provisions: %d | References: %d | Communities: %d | Modularity: %.3f\n",
+		r.Nodes, r.Edges, len(r.Communities), r.Modularity))
+	sb.WriteString(strings.Repeat("=", 60) + "\n\n")
+
+	if len(r.Provisions) == 0 {
+		sb.WriteString("No provisions found.\n")
+		return sb.String()
+	}
+
+	for _, score := range r.Provisions {
+		sb.WriteString(fmt.Sprintf("%d. %s — %s\n", score.Rank, provisionID(score.URI), score.Label))
+		sb.WriteString(fmt.Sprintf("   PageRank: %.4f | Betweenness: %.4f | In: %d | Out: %d | Community: %d\n",
+			score.PageRank, score.Betweenness, score.InDegree, score.OutDegree, score.Community))
+	}
+
+	if len(r.Communities) > 0 {
+		sb.WriteString("\nCommunities:\n")
+		for _, community := range r.Communities {
+			shown := community.Members
+			if len(shown) > 8 {
+				shown = shown[:8]
+			}
+			ids := make([]string, len(shown))
+			for i, uri := range shown {
+				ids[i] = provisionID(uri)
+			}
+			more := ""
+			if len(community.Members) > len(shown) {
+				more = fmt.Sprintf(", ... (+%d)", len(community.Members)-len(shown))
+			}
+			sb.WriteString(fmt.Sprintf("  %d (%d provisions): %s%s\n", community.ID, community.Size, strings.Join(ids, ", "), more))
+		}
+	}
+	if r.Isolated > 0 {
+		sb.WriteString(fmt.Sprintf("\nIsolated provisions (no references in or out): %d\n", r.Isolated))
+	}
+
+	return sb.String()
+}
+
+// FormatTable formats the ranking as a table.
+func (r *Report) FormatTable() string {
+	var sb strings.Builder
+
+	sb.WriteString("+------+--------------+----------+----------+------+------+------+------------------------------------------+\n")
+	sb.WriteString("| Rank | Provision    | PageRank | Between. | In   | Out  | Comm | Title                                    |\n")
+	sb.WriteString("+------+--------------+----------+----------+------+------+------+------------------------------------------+\n")
+
+	for _, score := range r.Provisions {
+		sb.WriteString(fmt.Sprintf("| %-4d | %-12s | %.4f   | %.4f   | %-4d | %-4d | %-4d | %-40s |\n",
+			score.Rank, textutil.Truncate(provisionID(score.URI), 12), score.PageRank, score.Betweenness,
+			score.InDegree, score.OutDegree, score.Community, textutil.Truncate(score.Label, 40)))
+	}
+
+	sb.WriteString("+------+--------------+----------+----------+------+------+------+------------------------------------------+\n")
+
+	return sb.String()
+}
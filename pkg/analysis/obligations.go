@@ -0,0 +1,482 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// ObligationOverlapOptions controls how obligations are clustered across
+// documents.
+type ObligationOverlapOptions struct {
+	// MinSimilarity is the lowest text similarity at which an obligation of
+	// a generic type ("shall ensure", "shall implement") joins the cluster
+	// of a specific obligation type.
+	MinSimilarity float64
+}
+
+// DefaultObligationOverlapOptions returns the default clustering settings.
+func DefaultObligationOverlapOptions() ObligationOverlapOptions {
+	return ObligationOverlapOptions{MinSimilarity: 0.2}
+}
+
+// genericObligationTypes are obligation types that say how a duty is
+// phrased rather than what it requires. They are clustered by text.
+var genericObligationTypes = map[string]bool{
+	"Obligation":               true,
+	"EnsureObligation":         true,
+	"ImplementationObligation": true,
+}
+
+// ObligationInstance is one extracted obligation in one document.
+type ObligationInstance struct {
+	Document    string  `json:"document"`
+	URI         string  `json:"uri"`
+	Provision   string  `json:"provision"`
+	Type        string  `json:"type"`
+	DutyBearer  string  `json:"duty_bearer,omitempty"`
+	Prohibition bool    `json:"prohibition,omitempty"`
+	Text        string  `json:"text,omitempty"`
+	Similarity  float64 `json:"similarity,omitempty"`
+
+	similarity string
+	vector     map[string]float64
+	norm       float64
+}
+
+// ObligationCluster groups similar obligations across documents.
+type ObligationCluster struct {
+	Type      string               `json:"type"`
+	Label     string               `json:"label"`
+	Documents []string             `json:"documents"`
+	Members   []ObligationInstance `json:"members"`
+	// Similarity is the mean text similarity of each member to its closest
+	// member in another document, or 0 when one document has the cluster.
+	Similarity float64 `json:"similarity"`
+}
+
+// ObligationGap is an obligation type that some documents impose and others
+// do not.
+type ObligationGap struct {
+	Type    string   `json:"type"`
+	Label   string   `json:"label"`
+	Covered []string `json:"covered"`
+	Missing []string `json:"missing"`
+}
+
+// ObligationMatrix counts obligations per type and document, and the
+// obligation types each pair of documents shares.
+type ObligationMatrix struct {
+	Documents []string `json:"documents"`
+	Types     []string `json:"types"`
+	// Counts[t][d] is the number of obligations of Types[t] in Documents[d].
+	Counts [][]int `json:"counts"`
+	// Shared[d][e] is the number of obligation types both Documents[d] and
+	// Documents[e] impose.
+	Shared [][]int `json:"shared"`
+}
+
+// ObligationOverlapResult is a multi-document obligation comparison.
+type ObligationOverlapResult struct {
+	Documents     []DocumentSummary   `json:"documents"`
+	Clusters      []ObligationCluster `json:"clusters"`
+	Matrix        ObligationMatrix    `json:"matrix"`
+	Gaps          []ObligationGap     `json:"gaps"`
+	MinSimilarity float64             `json:"min_similarity"`
+}
+
+// AnalyzeObligationOverlap clusters the obligations of all documents by
+// type, attaching generic obligations to the specific type whose text they
+// most resemble, and reports which documents impose each type.
+func (a *CrossRefAnalyzer) AnalyzeObligationOverlap(options ObligationOverlapOptions) *ObligationOverlapResult {
+	if options.MinSimilarity <= 0 {
+		options.MinSimilarity = DefaultObligationOverlapOptions().MinSimilarity
+	}
+
+	documentIDs := make([]string, 0, len(a.stores))
+	for docID := range a.stores {
+		documentIDs = append(documentIDs, docID)
+	}
+	sort.Strings(documentIDs)
+
+	result := &ObligationOverlapResult{
+		Documents:     make([]DocumentSummary, 0, len(documentIDs)),
+		Clusters:      make([]ObligationCluster, 0),
+		Gaps:          make([]ObligationGap, 0),
+		MinSimilarity: options.MinSimilarity,
+	}
+	for _, docID := range documentIDs {
+		result.Documents = append(result.Documents, a.buildDocumentSummary(docID))
+	}
+
+	instances := a.collectObligationInstances(documentIDs)
+	weighObligationText(instances)
+
+	// Specific types cluster by type; generic ones follow their text.
+	clustersByType := make(map[string]*ObligationCluster)
+	var generic []*ObligationInstance
+	for _, instance := range instances {
+		if genericObligationTypes[instance.Type] {
+			generic = append(generic, instance)
+			continue
+		}
+		addToCluster(clustersByType, instance.Type, instance)
+	}
+	specificTypes := sortedClusterTypes(clustersByType)
+	for _, instance := range generic {
+		bestType, bestSimilarity := "", 0.0
+		for _, obligationType := range specificTypes {
+			for _, member := range clustersByType[obligationType].Members {
+				if similarity := cosineSimilarity(instance, &member); similarity > bestSimilarity {
+					bestType, bestSimilarity = obligationType, similarity
+				}
+			}
+		}
+		if bestSimilarity >= options.MinSimilarity {
+			instance.Similarity = math.Round(bestSimilarity*1000) / 1000
+			addToCluster(clustersByType, bestType, instance)
+		} else {
+			addToCluster(clustersByType, instance.Type, instance)
+		}
+	}
+
+	for _, obligationType := range sortedClusterTypes(clustersByType) {
+		cluster := clustersByType[obligationType]
+		cluster.Similarity = crossDocumentSimilarity(cluster.Members)
+		result.Clusters = append(result.Clusters, *cluster)
+	}
+	sort.SliceStable(result.Clusters, func(i, j int) bool {
+		return len(result.Clusters[i].Documents) > len(result.Clusters[j].Documents)
+	})
+
+	result.Matrix = buildObligationMatrix(documentIDs, result.Clusters)
+	if len(documentIDs) > 1 {
+		for _, cluster := range result.Clusters {
+			// Unmatched generic obligations say nothing about coverage.
+			if len(cluster.Documents) == len(documentIDs) || genericObligationTypes[cluster.Type] {
+				continue
+			}
+			result.Gaps = append(result.Gaps, ObligationGap{
+				Type:    cluster.Type,
+				Label:   cluster.Label,
+				Covered: cluster.Documents,
+				Missing: missingDocuments(documentIDs, cluster.Documents),
+			})
+		}
+	}
+
+	return result
+}
+
+// collectObligationInstances gathers the obligations of each document.
+func (a *CrossRefAnalyzer) collectObligationInstances(documentIDs []string) []*ObligationInstance {
+	instances := make([]*ObligationInstance, 0)
+	for _, docID := range documentIDs {
+		tripleStore := a.stores[docID]
+		uris := make([]string, 0)
+		for _, triple := range tripleStore.Find("", store.RDFType, store.ClassObligation) {
+			uris = append(uris, triple.Subject)
+		}
+		sort.Strings(uris)
+
+		for _, uri := range uris {
+			texts := make([]string, 0)
+			for _, triple := range tripleStore.Find(uri, store.PropText, "") {
+				texts = append(texts, triple.Object)
+			}
+			sort.Strings(texts)
+			// Matched phrases are short, so compare on their surroundings
+			// and the provision title as well.
+			similarityText := make([]string, 0, len(texts)+2)
+			similarityText = append(similarityText, texts...)
+			for _, triple := range tripleStore.Find(uri, store.PropContext, "") {
+				similarityText = append(similarityText, triple.Object)
+			}
+			provision := tripleStore.GetOne(uri, store.PropPartOf)
+			similarityText = append(similarityText, tripleStore.GetOne(provision, store.PropTitle))
+
+			obligationType := tripleStore.GetOne(uri, store.PropObligationType)
+			if obligationType == "" {
+				obligationType = normalizeConceptName(uri)
+			}
+			instances = append(instances, &ObligationInstance{
+				Document:    docID,
+				URI:         uri,
+				Provision:   extractURILabel(provision),
+				Type:        obligationType,
+				DutyBearer:  tripleStore.GetOne(uri, store.PropDutyBearer),
+				Prohibition: tripleStore.GetOne(uri, store.PropIsProhibition) == "true",
+				Text:        strings.Join(texts, " … "),
+				similarity:  strings.Join(similarityText, " "),
+			})
+		}
+	}
+	return instances
+}
+
+// weighObligationText builds TF-IDF vectors of the obligations' text and
+// context.
+func weighObligationText(instances []*ObligationInstance) {
+	termCounts := make([]map[string]int, len(instances))
+	documentFrequency := make(map[string]int)
+	for i, instance := range instances {
+		counts := make(map[string]int)
+		for _, token := range similarityTokens(instance.similarity) {
+			counts[token]++
+		}
+		termCounts[i] = counts
+		for token := range counts {
+			documentFrequency[token]++
+		}
+	}
+
+	instanceCount := float64(len(instances))
+	for i, instance := range instances {
+		instance.vector = make(map[string]float64, len(termCounts[i]))
+		for token, count := range termCounts[i] {
+			weight := (1 + math.Log(float64(count))) * math.Log(1+instanceCount/float64(documentFrequency[token]))
+			instance.vector[token] = weight
+			instance.norm += weight * weight
+		}
+		instance.norm = math.Sqrt(instance.norm)
+	}
+}
+
+// cosineSimilarity compares the text vectors of two obligations.
+func cosineSimilarity(a, b *ObligationInstance) float64 {
+	if a.norm == 0 || b.norm == 0 {
+		return 0
+	}
+	dot := 0.0
+	for token, weight := range a.vector {
+		dot += weight * b.vector[token]
+	}
+	return dot / (a.norm * b.norm)
+}
+
+// crossDocumentSimilarity averages, over the members, the similarity to
+// the closest member from another document.
+func crossDocumentSimilarity(members []ObligationInstance) float64 {
+	total, counted := 0.0, 0
+	for i := range members {
+		best, found := 0.0, false
+		for j := range members {
+			if members[i].Document == members[j].Document {
+				continue
+			}
+			found = true
+			if similarity := cosineSimilarity(&members[i], &members[j]); similarity > best {
+				best = similarity
+			}
+		}
+		if found {
+			total += best
+			counted++
+		}
+	}
+	if counted == 0 {
+		return 0
+	}
+	return math.Round(total/float64(counted)*1000) / 1000
+}
+
+func addToCluster(clusters map[string]*ObligationCluster, obligationType string, instance *ObligationInstance) {
+	cluster, ok := clusters[obligationType]
+	if !ok {
+		cluster = &ObligationCluster{
+			Type:      obligationType,
+			Label:     obligationTypeLabel(obligationType),
+			Documents: make([]string, 0),
+			Members:   make([]ObligationInstance, 0),
+		}
+		clusters[obligationType] = cluster
+	}
+	cluster.Members = append(cluster.Members, *instance)
+	for _, docID := range cluster.Documents {
+		if docID == instance.Document {
+			return
+		}
+	}
+	cluster.Documents = append(cluster.Documents, instance.Document)
+	sort.Strings(cluster.Documents)
+}
+
+func sortedClusterTypes(clusters map[string]*ObligationCluster) []string {
+	types := make([]string, 0, len(clusters))
+	for obligationType := range clusters {
+		types = append(types, obligationType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// buildObligationMatrix tabulates the clusters by document.
+func buildObligationMatrix(documentIDs []string, clusters []ObligationCluster) ObligationMatrix {
+	matrix := ObligationMatrix{
+		Documents: documentIDs,
+		Types:     make([]string, len(clusters)),
+		Counts:    make([][]int, len(clusters)),
+		Shared:    make([][]int, len(documentIDs)),
+	}
+	column := make(map[string]int, len(documentIDs))
+	for d, docID := range documentIDs {
+		column[docID] = d
+		matrix.Shared[d] = make([]int, len(documentIDs))
+	}
+
+	for t, cluster := range clusters {
+		matrix.Types[t] = cluster.Type
+		matrix.Counts[t] = make([]int, len(documentIDs))
+		for _, member := range cluster.Members {
+			matrix.Counts[t][column[member.Document]]++
+		}
+		for _, docA := range cluster.Documents {
+			for _, docB := range cluster.Documents {
+				matrix.Shared[column[docA]][column[docB]]++
+			}
+		}
+	}
+	return matrix
+}
+
+func missingDocuments(documentIDs, covered []string) []string {
+	coveredSet := make(map[string]bool, len(covered))
+	for _, docID := range covered {
+		coveredSet[docID] = true
+	}
+	missing := make([]string, 0)
+	for _, docID := range documentIDs {
+		if !coveredSet[docID] {
+			missing = append(missing, docID)
+		}
+	}
+	return missing
+}
+
+// obligationTypeLabel turns "BreachNotificationObligation" into
+// "breach notification".
+func obligationTypeLabel(obligationType string) string {
+	name := strings.TrimSuffix(obligationType, "Obligation")
+	if name == "" {
+		return "general obligation"
+	}
+	var sb strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			sb.WriteRune(' ')
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+// ToJSON serializes the obligation overlap result to JSON.
+func (r *ObligationOverlapResult) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// String renders the overlap matrix and gap report as text.
+func (r *ObligationOverlapResult) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("Obligation Overlap Analysis\n")
+	sb.WriteString("===========================\n\n")
+	sb.WriteString(fmt.Sprintf("Documents: %d | Obligation types: %d | Gaps: %d\n\n",
+		len(r.Documents), len(r.Clusters), len(r.Gaps)))
+
+	labelWidth := len("Obligation type")
+	for _, cluster := range r.Clusters {
+		if width := textutil.RuneLen(cluster.Label); width > labelWidth {
+			labelWidth = width
+		}
+	}
+	columnWidths := make([]int, len(r.Matrix.Documents))
+	sb.WriteString(fmt.Sprintf("%-*s", labelWidth, "Obligation type"))
+	for d, docID := range r.Matrix.Documents {
+		columnWidths[d] = textutil.RuneLen(docID)
+		if columnWidths[d] < 3 {
+			columnWidths[d] = 3
+		}
+		sb.WriteString(fmt.Sprintf("  %*s", columnWidths[d], docID))
+	}
+	sb.WriteString("  Similarity\n")
+	for t, cluster := range r.Clusters {
+		sb.WriteString(fmt.Sprintf("%-*s", labelWidth, cluster.Label))
+		for d, count := range r.Matrix.Counts[t] {
+			cell := "-"
+			if count > 0 {
+				cell = fmt.Sprintf("%d", count)
+			}
+			sb.WriteString(fmt.Sprintf("  %*s", columnWidths[d], cell))
+		}
+		if len(cluster.Documents) > 1 {
+			sb.WriteString(fmt.Sprintf("  %.2f", cluster.Similarity))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("\nGaps (%d):\n", len(r.Gaps)))
+	if len(r.Gaps) == 0 {
+		sb.WriteString("  None found.\n")
+	}
+	for _, gap := range r.Gaps {
+		sb.WriteString(fmt.Sprintf("  %s — imposed by %s; missing from %s\n",
+			gap.Label, strings.Join(gap.Covered, ", "), strings.Join(gap.Missing, ", ")))
+	}
+
+	return sb.String()
+}
+
+// ToMarkdown renders the overlap matrix and gap report as Markdown tables.
+func (r *ObligationOverlapResult) ToMarkdown() string {
+	var sb strings.Builder
+
+	sb.WriteString("# Obligation Overlap\n\n")
+	sb.WriteString("| Obligation type | " + strings.Join(r.Matrix.Documents, " | ") + " | Similarity |\n")
+	sb.WriteString("|---" + strings.Repeat("|---", len(r.Matrix.Documents)) + "|---|\n")
+	for t, cluster := range r.Clusters {
+		cells := make([]string, len(r.Matrix.Counts[t]))
+		for d, count := range r.Matrix.Counts[t] {
+			cells[d] = "✗"
+			if count > 0 {
+				cells[d] = fmt.Sprintf("✓ %d", count)
+			}
+		}
+		similarity := ""
+		if len(cluster.Documents) > 1 {
+			similarity = fmt.Sprintf("%.2f", cluster.Similarity)
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", cluster.Label, strings.Join(cells, " | "), similarity))
+	}
+
+	sb.WriteString("\n## Gaps\n\n")
+	if len(r.Gaps) == 0 {
+		sb.WriteString("None found.\n")
+		return sb.String()
+	}
+	sb.WriteString("| Obligation type | Imposed by | Missing from |\n|---|---|---|\n")
+	for _, gap := range r.Gaps {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", gap.Label, strings.Join(gap.Covered, ", "), strings.Join(gap.Missing, ", ")))
+	}
+	return sb.String()
+}
+
+// ToCSV renders the obligation counts as CSV, one row per obligation type.
+func (r *ObligationOverlapResult) ToCSV() string {
+	var sb strings.Builder
+	sb.WriteString("type,label," + strings.Join(r.Matrix.Documents, ",") + ",similarity\n")
+	for t, cluster := range r.Clusters {
+		sb.WriteString(cluster.Type + "," + cluster.Label)
+		for _, count := range r.Matrix.Counts[t] {
+			sb.WriteString(fmt.Sprintf(",%d", count))
+		}
+		sb.WriteString(fmt.Sprintf(",%.3f\n", cluster.Similarity))
+	}
+	return sb.String()
+}
@@ -0,0 +1,88 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// addTestObligation adds an obligation of obligationType to an article
+// titled title.
+func addTestObligation(tripleStore *store.TripleStore, document, article, title, obligationType, text string) {
+	articleURI := "https://regula.dev/regulations/" + document + ":" + article
+	obligationURI := articleURI + ":Obligation:" + obligationType
+	tripleStore.Add(articleURI, store.RDFType, store.ClassArticle)
+	tripleStore.Add(articleURI, store.PropTitle, title)
+	tripleStore.Add(articleURI, store.PropImposesObligation, obligationURI)
+	tripleStore.Add(obligationURI, store.RDFType, store.ClassObligation)
+	tripleStore.Add(obligationURI, store.PropObligationType, obligationType)
+	tripleStore.Add(obligationURI, store.PropText, text)
+	tripleStore.Add(obligationURI, store.PropPartOf, articleURI)
+}
+
+func TestAnalyzeObligationOverlap(t *testing.T) {
+	gdpr := store.NewTripleStore()
+	addTestObligation(gdpr, "GDPR", "Art33", "Notification of a personal data breach to the supervisory authority",
+		"BreachNotificationObligation", "shall notify the personal data breach")
+	addTestObligation(gdpr, "GDPR", "Art30", "Records of processing activities", "RecordKeepingObligation", "shall maintain a record")
+
+	hipaa := store.NewTripleStore()
+	addTestObligation(hipaa, "HIPAA", "Sec404", "Notification of breach of unsecured health information",
+		"Obligation", "shall notify each individual of the breach")
+	addTestObligation(hipaa, "HIPAA", "Sec500", "Workforce sanctions policy", "EnsureObligation", "shall ensure sanctions")
+
+	analyzer := NewCrossRefAnalyzer()
+	analyzer.AddDocument("GDPR", "GDPR", gdpr)
+	analyzer.AddDocument("HIPAA", "HIPAA", hipaa)
+	result := analyzer.AnalyzeObligationOverlap(DefaultObligationOverlapOptions())
+
+	clusters := make(map[string]ObligationCluster)
+	for _, cluster := range result.Clusters {
+		clusters[cluster.Type] = cluster
+	}
+	breach := clusters["BreachNotificationObligation"]
+	if strings.Join(breach.Documents, ",") != "GDPR,HIPAA" || breach.Similarity == 0 {
+		t.Errorf("generic HIPAA notice should join breach notification: %+v", breach)
+	}
+	if breach.Label != "breach notification" {
+		t.Errorf("label = %q", breach.Label)
+	}
+	if result.Clusters[0].Type != "BreachNotificationObligation" {
+		t.Errorf("clusters not ranked by coverage: %+v", result.Clusters)
+	}
+	if _, ok := clusters["EnsureObligation"]; !ok {
+		t.Errorf("unrelated generic obligation should keep its own type: %+v", result.Clusters)
+	}
+
+	if len(result.Gaps) != 1 || result.Gaps[0].Type != "RecordKeepingObligation" ||
+		strings.Join(result.Gaps[0].Missing, ",") != "HIPAA" {
+		t.Errorf("gaps = %+v", result.Gaps)
+	}
+
+	if result.Matrix.Counts[0][0] != 1 || result.Matrix.Counts[0][1] != 1 {
+		t.Errorf("counts = %v", result.Matrix.Counts)
+	}
+	if result.Matrix.Shared[0][1] != 1 || result.Matrix.Shared[0][0] != 2 {
+		t.Errorf("shared = %v", result.Matrix.Shared)
+	}
+
+	if csv := result.ToCSV(); !strings.HasPrefix(csv, "type,label,GDPR,HIPAA,similarity\n") {
+		t.Errorf("csv header:\n%s", csv)
+	}
+	if markdown := result.ToMarkdown(); !strings.Contains(markdown, "| record keeping | GDPR | HIPAA |") {
+		t.Errorf("markdown gap row missing:\n%s", markdown)
+	}
+}
+
+func TestObligationTypeLabel(t *testing.T) {
+	for obligationType, expected := range map[string]string{
+		"NoticeAtCollectionObligation": "notice at collection",
+		"SecurityObligation":           "security",
+		"Obligation":                   "general obligation",
+	} {
+		if got := obligationTypeLabel(obligationType); got != expected {
+			t.Errorf("%s: got %q, want %q", obligationType, got, expected)
+		}
+	}
+}
@@ -0,0 +1,446 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// Relatedness signals. Each contributes a similarity in [0, 1].
+const (
+	BasisSharedTerms    = "shared-terms"
+	BasisCoCitation     = "co-citation"
+	BasisTextSimilarity = "text-similarity"
+)
+
+// RelatedOptions controls related-provision suggestions.
+type RelatedOptions struct {
+	// Limit is the maximum number of suggestions per provision (0 = no limit).
+	Limit int
+
+	// MinScore is the lowest combined score suggested.
+	MinScore float64
+
+	// IncludeReferenced keeps provisions that already cite one another.
+	// By default they are left out, since the aim is to surface connected
+	// rules that lack explicit cross-references.
+	IncludeReferenced bool
+
+	// Weights of the shared-term, co-citation, and text-similarity signals.
+	// Zero weights fall back to the defaults.
+	TermWeight     float64
+	CitationWeight float64
+	TextWeight     float64
+}
+
+// DefaultRelatedOptions returns the default suggestion settings.
+func DefaultRelatedOptions() RelatedOptions {
+	return RelatedOptions{
+		Limit:          5,
+		MinScore:       0.15,
+		TermWeight:     0.35,
+		CitationWeight: 0.25,
+		TextWeight:     0.40,
+	}
+}
+
+// RelatedProvision is a provision suggested as related to a target.
+type RelatedProvision struct {
+	URI            string   `json:"uri"`
+	Label          string   `json:"label"`
+	Score          float64  `json:"score"`
+	TermSimilarity float64  `json:"term_similarity"`
+	CoCitation     float64  `json:"co_citation"`
+	TextSimilarity float64  `json:"text_similarity"`
+	SharedTerms    []string `json:"shared_terms,omitempty"`
+	CoCitedBy      []string `json:"co_cited_by,omitempty"`
+	Referenced     bool     `json:"referenced,omitempty"`
+	Basis          []string `json:"basis"`
+}
+
+// RelatedResult lists the related-provision suggestions for one provision.
+type RelatedResult struct {
+	TargetURI   string              `json:"target_uri"`
+	TargetLabel string              `json:"target_label"`
+	Suggestions []*RelatedProvision `json:"suggestions"`
+}
+
+// relatedProfile holds the signals gathered for one article.
+type relatedProfile struct {
+	uri      string
+	terms    map[string]bool
+	citedBy  map[string]bool
+	cites    map[string]bool
+	vector   map[string]float64
+	norm     float64
+	position int
+}
+
+// RelatedAnalyzer suggests related provisions from shared defined terms,
+// co-citation (being cited by the same provisions), and TF-IDF cosine
+// similarity of article text.
+type RelatedAnalyzer struct {
+	store    *store.TripleStore
+	options  RelatedOptions
+	profiles map[string]*relatedProfile
+	order    []string
+}
+
+// NewRelatedAnalyzer indexes the articles in tripleStore.
+func NewRelatedAnalyzer(ts *store.TripleStore, options RelatedOptions) *RelatedAnalyzer {
+	defaults := DefaultRelatedOptions()
+	if options.TermWeight == 0 && options.CitationWeight == 0 && options.TextWeight == 0 {
+		options.TermWeight = defaults.TermWeight
+		options.CitationWeight = defaults.CitationWeight
+		options.TextWeight = defaults.TextWeight
+	}
+
+	analyzer := &RelatedAnalyzer{
+		store:    ts,
+		options:  options,
+		profiles: make(map[string]*relatedProfile),
+	}
+	analyzer.buildProfiles()
+	return analyzer
+}
+
+// buildProfiles collects terms, citations, and text vectors for each article.
+func (a *RelatedAnalyzer) buildProfiles() {
+	for _, triple := range a.store.Find("", store.RDFType, store.ClassArticle) {
+		if _, ok := a.profiles[triple.Subject]; ok {
+			continue
+		}
+		a.profiles[triple.Subject] = &relatedProfile{
+			uri:     triple.Subject,
+			terms:   make(map[string]bool),
+			citedBy: make(map[string]bool),
+			cites:   make(map[string]bool),
+		}
+		a.order = append(a.order, triple.Subject)
+	}
+	sort.Strings(a.order)
+	for position, uri := range a.order {
+		a.profiles[uri].position = position
+	}
+
+	for _, profile := range a.profiles {
+		for _, triple := range a.store.Find(profile.uri, store.PropUsesTerm, "") {
+			profile.terms[triple.Object] = true
+		}
+		for _, triple := range a.store.Find(profile.uri, store.PropReferences, "") {
+			if triple.Object == profile.uri {
+				continue
+			}
+			profile.cites[triple.Object] = true
+			if cited, ok := a.profiles[triple.Object]; ok {
+				cited.citedBy[profile.uri] = true
+			}
+		}
+	}
+
+	// Term frequencies per article, then weight by inverse document frequency
+	termCounts := make(map[string]map[string]int)
+	documentFrequency := make(map[string]int)
+	for _, uri := range a.order {
+		counts := make(map[string]int)
+		for _, triple := range a.store.Find(uri, store.PropText, "") {
+			for _, token := range similarityTokens(triple.Object) {
+				counts[token]++
+			}
+		}
+		termCounts[uri] = counts
+		for token := range counts {
+			documentFrequency[token]++
+		}
+	}
+
+	articleCount := float64(len(a.order))
+	for _, uri := range a.order {
+		profile := a.profiles[uri]
+		profile.vector = make(map[string]float64, len(termCounts[uri]))
+		for token, count := range termCounts[uri] {
+			idf := math.Log(1 + articleCount/float64(documentFrequency[token]))
+			weight := (1 + math.Log(float64(count))) * idf
+			profile.vector[token] = weight
+			profile.norm += weight * weight
+		}
+		profile.norm = math.Sqrt(profile.norm)
+	}
+}
+
+// ArticleCount returns the number of indexed articles.
+func (a *RelatedAnalyzer) ArticleCount() int {
+	return len(a.order)
+}
+
+// Resolve finds an article URI from a full URI or a short ID such as
+// "Art17" or "GDPR:Art17". Returns "" when no single article matches.
+func (a *RelatedAnalyzer) Resolve(provision string) string {
+	if _, ok := a.profiles[provision]; ok {
+		return provision
+	}
+
+	match := ""
+	for _, uri := range a.order {
+		if !strings.HasSuffix(uri, provision) || len(uri) == len(provision) {
+			continue
+		}
+		if boundary := uri[len(uri)-len(provision)-1]; boundary != ':' && boundary != '/' {
+			continue
+		}
+		if match != "" {
+			return ""
+		}
+		match = uri
+	}
+	return match
+}
+
+// Suggest returns the provisions most related to provisionURI.
+func (a *RelatedAnalyzer) Suggest(provisionURI string) *RelatedResult {
+	result := &RelatedResult{
+		TargetURI:   provisionURI,
+		TargetLabel: a.label(provisionURI),
+		Suggestions: make([]*RelatedProvision, 0),
+	}
+
+	target, ok := a.profiles[provisionURI]
+	if !ok {
+		return result
+	}
+
+	for _, uri := range a.order {
+		if uri == provisionURI {
+			continue
+		}
+		candidate := a.profiles[uri]
+		referenced := target.cites[uri] || candidate.cites[provisionURI]
+		if referenced && !a.options.IncludeReferenced {
+			continue
+		}
+
+		suggestion := a.compare(target, candidate)
+		suggestion.Referenced = referenced
+		if suggestion.Score < a.options.MinScore || suggestion.Score == 0 {
+			continue
+		}
+		suggestion.Label = a.label(uri)
+		result.Suggestions = append(result.Suggestions, suggestion)
+	}
+
+	sort.SliceStable(result.Suggestions, func(i, j int) bool {
+		if result.Suggestions[i].Score != result.Suggestions[j].Score {
+			return result.Suggestions[i].Score > result.Suggestions[j].Score
+		}
+		return a.profiles[result.Suggestions[i].URI].position < a.profiles[result.Suggestions[j].URI].position
+	})
+	if a.options.Limit > 0 && len(result.Suggestions) > a.options.Limit {
+		result.Suggestions = result.Suggestions[:a.options.Limit]
+	}
+
+	return result
+}
+
+// SuggestAll returns suggestions for every article, in URI order.
+func (a *RelatedAnalyzer) SuggestAll() []*RelatedResult {
+	results := make([]*RelatedResult, 0, len(a.order))
+	for _, uri := range a.order {
+		results = append(results, a.Suggest(uri))
+	}
+	return results
+}
+
+// compare scores the relatedness of two articles.
+func (a *RelatedAnalyzer) compare(target, candidate *relatedProfile) *RelatedProvision {
+	suggestion := &RelatedProvision{
+		URI:   candidate.uri,
+		Basis: make([]string, 0),
+	}
+
+	for term := range target.terms {
+		if candidate.terms[term] {
+			suggestion.SharedTerms = append(suggestion.SharedTerms, a.label(term))
+		}
+	}
+	sort.Strings(suggestion.SharedTerms)
+	suggestion.TermSimilarity = jaccard(len(suggestion.SharedTerms), len(target.terms), len(candidate.terms))
+
+	for citing := range target.citedBy {
+		if candidate.citedBy[citing] {
+			suggestion.CoCitedBy = append(suggestion.CoCitedBy, citing)
+		}
+	}
+	sort.Strings(suggestion.CoCitedBy)
+	suggestion.CoCitation = jaccard(len(suggestion.CoCitedBy), len(target.citedBy), len(candidate.citedBy))
+
+	if target.norm > 0 && candidate.norm > 0 {
+		dot := 0.0
+		for token, weight := range target.vector {
+			dot += weight * candidate.vector[token]
+		}
+		suggestion.TextSimilarity = dot / (target.norm * candidate.norm)
+	}
+
+	totalWeight := a.options.TermWeight + a.options.CitationWeight + a.options.TextWeight
+	suggestion.Score = (a.options.TermWeight*suggestion.TermSimilarity +
+		a.options.CitationWeight*suggestion.CoCitation +
+		a.options.TextWeight*suggestion.TextSimilarity) / totalWeight
+	suggestion.Score = math.Round(suggestion.Score*1000) / 1000
+
+	if suggestion.TermSimilarity > 0 {
+		suggestion.Basis = append(suggestion.Basis, BasisSharedTerms)
+	}
+	if suggestion.CoCitation > 0 {
+		suggestion.Basis = append(suggestion.Basis, BasisCoCitation)
+	}
+	if suggestion.TextSimilarity > 0 {
+		suggestion.Basis = append(suggestion.Basis, BasisTextSimilarity)
+	}
+
+	return suggestion
+}
+
+// jaccard returns |A∩B| / |A∪B| given the intersection and set sizes.
+func jaccard(shared, sizeA, sizeB int) float64 {
+	union := sizeA + sizeB - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// similarityTokens lowercases text and splits it into words of four or more
+// letters, dropping common legal boilerplate.
+func similarityTokens(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		if textutil.RuneLen(word) < 4 || similarityStopWords[word] {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+var similarityStopWords = map[string]bool{
+	"shall": true, "that": true, "this": true, "with": true, "which": true,
+	"where": true, "from": true, "such": true, "have": true, "been": true,
+	"their": true, "other": true, "under": true, "into": true, "within": true,
+	"article": true, "paragraph": true, "point": true, "section": true,
+	"referred": true, "accordance": true, "regulation": true, "pursuant": true,
+}
+
+// label retrieves a display label for a URI.
+func (a *RelatedAnalyzer) label(uri string) string {
+	for _, predicate := range []string{store.PropTitle, store.PropTerm, store.RDFSLabel} {
+		if triples := a.store.Find(uri, predicate, ""); len(triples) > 0 {
+			return triples[0].Object
+		}
+	}
+	return extractURILabel(uri)
+}
+
+// RelatedEnrichmentStats reports the triples added by AddRelatedTriples.
+type RelatedEnrichmentStats struct {
+	Suggestions  int `json:"suggestions"`
+	TotalTriples int `json:"total_triples"`
+}
+
+// AddRelatedTriples adds a reg:relatedTo link, plus a reg:RelatedSuggestion
+// node recording its score and basis, for each suggestion.
+func (a *RelatedAnalyzer) AddRelatedTriples() RelatedEnrichmentStats {
+	var stats RelatedEnrichmentStats
+	add := func(subject, predicate, object string) {
+		if !a.store.Exists(subject, predicate, object) {
+			a.store.Add(subject, predicate, object)
+			stats.TotalTriples++
+		}
+	}
+
+	for _, result := range a.SuggestAll() {
+		for _, suggestion := range result.Suggestions {
+			suggestionURI := result.TargetURI + ":Related:" + extractURILabel(suggestion.URI)
+			add(result.TargetURI, store.PropRelatedTo, suggestion.URI)
+			add(suggestionURI, store.RDFType, store.ClassRelatedSuggestion)
+			add(suggestionURI, store.PropSuggestionFor, result.TargetURI)
+			add(suggestionURI, store.PropSuggestedProvision, suggestion.URI)
+			add(suggestionURI, store.PropSimilarityScore, fmt.Sprintf("%.3f", suggestion.Score))
+			for _, basis := range suggestion.Basis {
+				add(suggestionURI, store.PropSuggestionBasis, basis)
+			}
+			stats.Suggestions++
+		}
+	}
+	return stats
+}
+
+// ToJSON serializes the related-provision result to JSON.
+func (r *RelatedResult) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// String returns a human-readable list of related provisions.
+func (r *RelatedResult) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Related provisions for: %s\n", r.TargetLabel))
+	sb.WriteString(fmt.Sprintf("URI: %s\n", r.TargetURI))
+	sb.WriteString(strings.Repeat("=", 60) + "\n\n")
+
+	if len(r.Suggestions) == 0 {
+		sb.WriteString("No related provisions found.\n")
+		return sb.String()
+	}
+
+	for i, suggestion := range r.Suggestions {
+		sb.WriteString(fmt.Sprintf("%d. %s — %s (score: %.2f)\n",
+			i+1, extractURILabel(suggestion.URI), suggestion.Label, suggestion.Score))
+		if len(suggestion.SharedTerms) > 0 {
+			sb.WriteString(fmt.Sprintf("   Shared terms: %s\n", strings.Join(suggestion.SharedTerms, ", ")))
+		}
+		if len(suggestion.CoCitedBy) > 0 {
+			citing := make([]string, len(suggestion.CoCitedBy))
+			for j, uri := range suggestion.CoCitedBy {
+				citing[j] = extractURILabel(uri)
+			}
+			sb.WriteString(fmt.Sprintf("   Co-cited by: %s\n", strings.Join(citing, ", ")))
+		}
+		if suggestion.TextSimilarity > 0 {
+			sb.WriteString(fmt.Sprintf("   Text similarity: %.2f\n", suggestion.TextSimilarity))
+		}
+		if suggestion.Referenced {
+			sb.WriteString("   (already cross-referenced)\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// FormatTable formats the suggestions as a table.
+func (r *RelatedResult) FormatTable() string {
+	var sb strings.Builder
+
+	sb.WriteString("+------------+-------+-------+-------+-------+------------------------------------------+\n")
+	sb.WriteString("| Provision  | Score | Terms | Cites | Text  | Title                                    |\n")
+	sb.WriteString("+------------+-------+-------+-------+-------+------------------------------------------+\n")
+
+	for _, suggestion := range r.Suggestions {
+		sb.WriteString(fmt.Sprintf("| %-10s | %.2f  | %.2f  | %.2f  | %.2f  | %-40s |\n",
+			textutil.Truncate(extractURILabel(suggestion.URI), 10), suggestion.Score,
+			suggestion.TermSimilarity, suggestion.CoCitation, suggestion.TextSimilarity,
+			textutil.Truncate(suggestion.Label, 40)))
+	}
+
+	sb.WriteString("+------------+-------+-------+-------+-------+------------------------------------------+\n")
+
+	return sb.String()
+}
@@ -0,0 +1,175 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// buildRelatedTestStore sets up four articles:
+//   - Art1 and Art2 share terms, are both cited by Art4, and have similar text
+//   - Art3 shares nothing with Art1
+//   - Art4 cites Art1 and Art2 explicitly
+func buildRelatedTestStore() *store.TripleStore {
+	ts := store.NewTripleStore()
+	base := "https://regula.dev/regulations/TEST:"
+
+	articles := map[string]string{
+		"Art1": "The controller shall erase personal data without undue delay when consent is withdrawn.",
+		"Art2": "The controller shall restrict processing of personal data when consent is withdrawn or contested.",
+		"Art3": "Member States shall establish independent supervisory authorities with adequate budgets.",
+		"Art4": "Requests about personal data under the erasure and restriction provisions shall be answered within one month.",
+	}
+	for id, text := range articles {
+		ts.Add(base+id, store.RDFType, store.ClassArticle)
+		ts.Add(base+id, store.PropTitle, "Title of "+id)
+		ts.Add(base+id, store.PropText, text)
+	}
+
+	ts.Add(base+"Term:controller", store.PropTerm, "controller")
+	ts.Add(base+"Term:personal_data", store.PropTerm, "personal data")
+	for _, id := range []string{"Art1", "Art2"} {
+		ts.Add(base+id, store.PropUsesTerm, base+"Term:controller")
+		ts.Add(base+id, store.PropUsesTerm, base+"Term:personal_data")
+	}
+
+	ts.Add(base+"Art4", store.PropReferences, base+"Art1")
+	ts.Add(base+"Art4", store.PropReferences, base+"Art2")
+	return ts
+}
+
+func TestRelatedAnalyzer_Suggest(t *testing.T) {
+	analyzer := NewRelatedAnalyzer(buildRelatedTestStore(), DefaultRelatedOptions())
+	if analyzer.ArticleCount() != 4 {
+		t.Fatalf("ArticleCount() = %d, want 4", analyzer.ArticleCount())
+	}
+
+	target := analyzer.Resolve("Art1")
+	if target != "https://regula.dev/regulations/TEST:Art1" {
+		t.Fatalf("Resolve(Art1) = %q", target)
+	}
+
+	result := analyzer.Suggest(target)
+	if len(result.Suggestions) == 0 {
+		t.Fatal("expected suggestions for Art1")
+	}
+
+	top := result.Suggestions[0]
+	if extractURILabel(top.URI) != "Art2" {
+		t.Fatalf("top suggestion = %s, want Art2", top.URI)
+	}
+	if top.TermSimilarity != 1.0 || top.CoCitation != 1.0 || top.TextSimilarity <= 0 {
+		t.Errorf("signals = terms %.2f, co-citation %.2f, text %.2f", top.TermSimilarity, top.CoCitation, top.TextSimilarity)
+	}
+	if len(top.SharedTerms) != 2 || top.SharedTerms[0] != "controller" {
+		t.Errorf("shared terms = %v, want [controller personal data]", top.SharedTerms)
+	}
+	if len(top.CoCitedBy) != 1 || extractURILabel(top.CoCitedBy[0]) != "Art4" {
+		t.Errorf("co-cited by = %v, want [Art4]", top.CoCitedBy)
+	}
+	if len(top.Basis) != 3 {
+		t.Errorf("basis = %v, want all three signals", top.Basis)
+	}
+
+	for _, suggestion := range result.Suggestions {
+		if extractURILabel(suggestion.URI) == "Art4" {
+			t.Error("Art4 cites Art1 and should be excluded by default")
+		}
+	}
+}
+
+func TestRelatedAnalyzer_IncludeReferenced(t *testing.T) {
+	options := DefaultRelatedOptions()
+	options.IncludeReferenced = true
+	options.MinScore = 0
+	analyzer := NewRelatedAnalyzer(buildRelatedTestStore(), options)
+
+	result := analyzer.Suggest(analyzer.Resolve("Art1"))
+	found := false
+	for _, suggestion := range result.Suggestions {
+		if extractURILabel(suggestion.URI) == "Art4" {
+			found = true
+			if !suggestion.Referenced {
+				t.Error("Art4 should be marked as already referenced")
+			}
+		}
+	}
+	if !found {
+		t.Error("expected Art4 with IncludeReferenced")
+	}
+}
+
+func TestRelatedAnalyzer_LimitAndMinScore(t *testing.T) {
+	options := DefaultRelatedOptions()
+	options.Limit = 1
+	options.MinScore = 0
+	analyzer := NewRelatedAnalyzer(buildRelatedTestStore(), options)
+	if result := analyzer.Suggest(analyzer.Resolve("Art1")); len(result.Suggestions) != 1 {
+		t.Errorf("got %d suggestions, want 1", len(result.Suggestions))
+	}
+
+	options.Limit = 0
+	options.MinScore = 0.99
+	analyzer = NewRelatedAnalyzer(buildRelatedTestStore(), options)
+	if result := analyzer.Suggest(analyzer.Resolve("Art1")); len(result.Suggestions) != 0 {
+		t.Errorf("got %d suggestions above 0.99, want 0", len(result.Suggestions))
+	}
+}
+
+func TestRelatedAnalyzer_Resolve(t *testing.T) {
+	analyzer := NewRelatedAnalyzer(buildRelatedTestStore(), DefaultRelatedOptions())
+
+	if uri := analyzer.Resolve("TEST:Art3"); uri != "https://regula.dev/regulations/TEST:Art3" {
+		t.Errorf("Resolve(TEST:Art3) = %q", uri)
+	}
+	if uri := analyzer.Resolve("Art99"); uri != "" {
+		t.Errorf("Resolve(Art99) = %q, want empty", uri)
+	}
+	if result := analyzer.Suggest("https://regula.dev/regulations/TEST:Art99"); len(result.Suggestions) != 0 {
+		t.Error("unknown provision should have no suggestions")
+	}
+}
+
+func TestRelatedAnalyzer_AddRelatedTriples(t *testing.T) {
+	ts := buildRelatedTestStore()
+	analyzer := NewRelatedAnalyzer(ts, DefaultRelatedOptions())
+
+	stats := analyzer.AddRelatedTriples()
+	if stats.Suggestions == 0 || stats.TotalTriples == 0 {
+		t.Fatalf("stats = %+v, want suggestions", stats)
+	}
+
+	art1 := "https://regula.dev/regulations/TEST:Art1"
+	art2 := "https://regula.dev/regulations/TEST:Art2"
+	if !ts.Exists(art1, store.PropRelatedTo, art2) || !ts.Exists(art2, store.PropRelatedTo, art1) {
+		t.Error("expected reg:relatedTo in both directions between Art1 and Art2")
+	}
+
+	suggestionURI := art1 + ":Related:Art2"
+	if !ts.Exists(suggestionURI, store.RDFType, store.ClassRelatedSuggestion) {
+		t.Error("missing suggestion node")
+	}
+	if !ts.Exists(suggestionURI, store.PropSuggestionBasis, BasisCoCitation) {
+		t.Error("missing co-citation basis")
+	}
+	if len(ts.Find(suggestionURI, store.PropSimilarityScore, "")) != 1 {
+		t.Error("missing similarity score")
+	}
+
+	if again := analyzer.AddRelatedTriples(); again.TotalTriples != 0 {
+		t.Errorf("second enrichment added %d triples, want 0", again.TotalTriples)
+	}
+}
+
+func TestSimilarityTokens(t *testing.T) {
+	tokens := similarityTokens("The controller shall erase données personnelles (Article 17).")
+	expected := []string{"controller", "erase", "données", "personnelles"}
+	if len(tokens) != len(expected) {
+		t.Fatalf("tokens = %v, want %v", tokens, expected)
+	}
+	for i := range expected {
+		if tokens[i] != expected[i] {
+			t.Errorf("tokens[%d] = %q, want %q", i, tokens[i], expected[i])
+		}
+	}
+}
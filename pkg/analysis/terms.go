@@ -0,0 +1,270 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Term alignment methods, from strongest to weakest.
+const (
+	AlignExact   = "exact"
+	AlignSynonym = "synonym"
+	AlignStem    = "stem"
+	AlignPartial = "partial"
+)
+
+// Scores given to each alignment method. Partial matches score their
+// stemmed word overlap scaled by alignPartialWeight.
+const (
+	alignSynonymScore  = 0.95
+	alignStemScore     = 0.9
+	alignPartialWeight = 0.8
+)
+
+// DefaultMinAlignmentScore is the lowest score reported as an alignment.
+const DefaultMinAlignmentScore = 0.6
+
+// SynonymGroup lists defined terms that different documents use for the
+// same concept.
+type SynonymGroup struct {
+	Terms []string `yaml:"terms" json:"terms"`
+	Note  string   `yaml:"note,omitempty" json:"note,omitempty"`
+}
+
+// SynonymFile is the on-disk format of a term crosswalk file.
+//
+//	synonyms:
+//	  - terms: ["personal data", "personal information"]
+//	    note: "GDPR Art 4(1) / CCPA 1798.140(v)"
+//	  - terms: ["controller", "business"]
+type SynonymFile struct {
+	Synonyms []SynonymGroup `yaml:"synonyms" json:"synonyms"`
+}
+
+// ParseSynonyms parses crosswalk content and validates that every group
+// names at least two terms.
+func ParseSynonyms(data []byte) ([]SynonymGroup, error) {
+	var synonymFile SynonymFile
+	if err := yaml.Unmarshal(data, &synonymFile); err != nil {
+		return nil, fmt.Errorf("failed to parse synonyms: %w", err)
+	}
+
+	for index, group := range synonymFile.Synonyms {
+		terms := 0
+		for _, term := range group.Terms {
+			if strings.TrimSpace(term) != "" {
+				terms++
+			}
+		}
+		if terms < 2 {
+			return nil, fmt.Errorf("synonym group %d: at least two terms are required", index+1)
+		}
+	}
+
+	return synonymFile.Synonyms, nil
+}
+
+// LoadSynonyms reads and parses a term crosswalk file.
+func LoadSynonyms(path string) ([]SynonymGroup, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read synonyms file: %w", err)
+	}
+	groups, err := ParseSynonyms(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return groups, nil
+}
+
+// TermAlignment pairs a defined term of one document with a conceptually
+// equivalent term of another.
+type TermAlignment struct {
+	TermA      string   `json:"term_a"`
+	TermB      string   `json:"term_b"`
+	Score      float64  `json:"score"`
+	Method     string   `json:"method"`
+	Note       string   `json:"note,omitempty"`
+	ProvisionA []string `json:"provisions_a"`
+	ProvisionB []string `json:"provisions_b"`
+}
+
+// TermAligner matches defined terms across documents after normalization,
+// stemming, and a user-supplied synonym crosswalk.
+type TermAligner struct {
+	// MinScore is the lowest score reported as an alignment.
+	MinScore float64
+
+	// synonyms maps a stemmed term to the index of its synonym group.
+	synonyms map[string]int
+	notes    []string
+}
+
+// NewTermAligner creates an aligner that treats the terms of each group as
+// equivalent.
+func NewTermAligner(groups []SynonymGroup) *TermAligner {
+	aligner := &TermAligner{
+		MinScore: DefaultMinAlignmentScore,
+		synonyms: make(map[string]int),
+		notes:    make([]string, len(groups)),
+	}
+	for index, group := range groups {
+		aligner.notes[index] = group.Note
+		for _, term := range group.Terms {
+			if key := stemTerm(term); key != "" {
+				aligner.synonyms[key] = index
+			}
+		}
+	}
+	return aligner
+}
+
+// Align pairs the terms of termsA with those of termsB, best score first,
+// using each term at most once. Both maps are keyed by term and hold the
+// provisions that define it.
+func (t *TermAligner) Align(termsA, termsB map[string][]string) []TermAlignment {
+	type candidate struct {
+		termA, termB string
+		score        float64
+		method       string
+		note         string
+	}
+
+	namesA := sortedTermNames(termsA)
+	namesB := sortedTermNames(termsB)
+	stemmedB := make([]string, len(namesB))
+	for j, termB := range namesB {
+		stemmedB[j] = stemTerm(termB)
+	}
+
+	candidates := make([]candidate, 0)
+	for _, termA := range namesA {
+		stemmedA := stemTerm(termA)
+		for j, termB := range namesB {
+			score, method, note := t.compare(termA, termB, stemmedA, stemmedB[j])
+			if score >= t.MinScore {
+				candidates = append(candidates, candidate{termA, termB, score, method, note})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	usedA := make(map[string]bool)
+	usedB := make(map[string]bool)
+	alignments := make([]TermAlignment, 0)
+	for _, c := range candidates {
+		if usedA[c.termA] || usedB[c.termB] {
+			continue
+		}
+		usedA[c.termA] = true
+		usedB[c.termB] = true
+		alignments = append(alignments, TermAlignment{
+			TermA:      c.termA,
+			TermB:      c.termB,
+			Score:      c.score,
+			Method:     c.method,
+			Note:       c.note,
+			ProvisionA: termsA[c.termA],
+			ProvisionB: termsB[c.termB],
+		})
+	}
+	sort.SliceStable(alignments, func(i, j int) bool {
+		return alignments[i].TermA < alignments[j].TermA
+	})
+	return alignments
+}
+
+// compare scores how closely two terms denote the same concept.
+func (t *TermAligner) compare(termA, termB, stemmedA, stemmedB string) (float64, string, string) {
+	if normalizeTerm(termA) == normalizeTerm(termB) {
+		return 1, AlignExact, ""
+	}
+	groupA, okA := t.synonyms[stemmedA]
+	groupB, okB := t.synonyms[stemmedB]
+	if okA && okB && groupA == groupB {
+		return alignSynonymScore, AlignSynonym, t.notes[groupA]
+	}
+	if stemmedA == stemmedB {
+		return alignStemScore, AlignStem, ""
+	}
+
+	wordsA := strings.Fields(stemmedA)
+	wordsB := make(map[string]bool)
+	for _, word := range strings.Fields(stemmedB) {
+		wordsB[word] = true
+	}
+	shared := 0
+	seen := make(map[string]bool)
+	for _, word := range wordsA {
+		if wordsB[word] && !seen[word] {
+			shared++
+		}
+		seen[word] = true
+	}
+	overlap := jaccard(shared, len(seen), len(wordsB))
+	return math.Round(overlap*alignPartialWeight*1000) / 1000, AlignPartial, ""
+}
+
+func sortedTermNames(terms map[string][]string) []string {
+	names := make([]string, 0, len(terms))
+	for name := range terms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// normalizeTerm lowercases a term, drops punctuation and a leading article,
+// and collapses whitespace.
+func normalizeTerm(term string) string {
+	words := strings.FieldsFunc(strings.ToLower(term), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > 1 && (words[0] == "the" || words[0] == "a" || words[0] == "an") {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// stemTerm normalizes a term and stems each of its words.
+func stemTerm(term string) string {
+	words := strings.Fields(normalizeTerm(term))
+	for i, word := range words {
+		words[i] = stemWord(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// stemSuffixes are tried in order; a suffix is stripped only when at least
+// three letters remain, so short words are left alone. "ies" becomes "y".
+var stemSuffixes = []string{"ations", "ation", "ments", "ment", "ities", "ity", "ies", "ing", "ers", "er", "ed", "es", "s"}
+
+// stemWord strips a common English suffix and any final "e", a light
+// stemmer that conflates "processing" with "process", "notifications" with
+// "notification", and "measures" with "measure".
+func stemWord(word string) string {
+	if strings.HasSuffix(word, "ss") {
+		return word
+	}
+	for _, suffix := range stemSuffixes {
+		if stem := strings.TrimSuffix(word, suffix); stem != word && len([]rune(stem)) >= 3 {
+			if suffix == "ies" {
+				stem += "y"
+			}
+			word = stem
+			break
+		}
+	}
+	if len([]rune(word)) > 3 {
+		word = strings.TrimSuffix(word, "e")
+	}
+	return word
+}
@@ -0,0 +1,92 @@
+package analysis
+
+import (
+	"testing"
+)
+
+func TestTermAligner_Align(t *testing.T) {
+	aligner := NewTermAligner([]SynonymGroup{
+		{Terms: []string{"personal data", "personal information"}, Note: "GDPR / CCPA"},
+	})
+	termsA := map[string][]string{
+		"personal data":        {"GDPR:Art4"},
+		"processing":           {"GDPR:Art4"},
+		"the supervisory body": {"GDPR:Art4"},
+		"restriction":          {"GDPR:Art4"},
+	}
+	termsB := map[string][]string{
+		"personal information": {"CCPA:1798.140"},
+		"processes":            {"CCPA:1798.140"},
+		"supervisory body":     {"CCPA:1798.140"},
+		"deidentified":         {"CCPA:1798.140"},
+	}
+
+	alignments := aligner.Align(termsA, termsB)
+	byTerm := make(map[string]TermAlignment)
+	for _, alignment := range alignments {
+		byTerm[alignment.TermA] = alignment
+	}
+	expected := map[string]struct {
+		termB, method string
+	}{
+		"personal data":        {"personal information", AlignSynonym},
+		"processing":           {"processes", AlignStem},
+		"the supervisory body": {"supervisory body", AlignExact},
+	}
+	if len(alignments) != len(expected) {
+		t.Errorf("alignments = %+v", alignments)
+	}
+	for termA, want := range expected {
+		got := byTerm[termA]
+		if got.TermB != want.termB || got.Method != want.method {
+			t.Errorf("%s: got %s (%s), want %s (%s)", termA, got.TermB, got.Method, want.termB, want.method)
+		}
+	}
+	if byTerm["personal data"].Note != "GDPR / CCPA" || byTerm["personal data"].Score != alignSynonymScore {
+		t.Errorf("synonym alignment: %+v", byTerm["personal data"])
+	}
+}
+
+func TestTermAligner_Partial(t *testing.T) {
+	aligner := NewTermAligner(nil)
+	termsA := map[string][]string{"data protection officer": nil}
+	termsB := map[string][]string{"data protection officers appointed": nil}
+
+	aligner.MinScore = 0.5
+	alignments := aligner.Align(termsA, termsB)
+	if len(alignments) != 1 || alignments[0].Method != AlignPartial || alignments[0].Score != 0.6 {
+		t.Fatalf("alignments = %+v", alignments)
+	}
+
+	aligner.MinScore = DefaultMinAlignmentScore + 0.01
+	if alignments := aligner.Align(termsA, termsB); len(alignments) != 0 {
+		t.Errorf("expected no alignment above the minimum score, got %+v", alignments)
+	}
+}
+
+func TestStemWord(t *testing.T) {
+	for _, pair := range [][2]string{
+		{"processing", "process"},
+		{"notifications", "notification"},
+		{"measures", "measure"},
+		{"policies", "policy"},
+		{"business", "business"},
+	} {
+		if stemWord(pair[0]) != stemWord(pair[1]) {
+			t.Errorf("%q and %q stem differently: %q, %q", pair[0], pair[1], stemWord(pair[0]), stemWord(pair[1]))
+		}
+	}
+	if stemWord("data") != "data" {
+		t.Errorf("short word stemmed: %q", stemWord("data"))
+	}
+}
+
+func TestParseSynonyms(t *testing.T) {
+	groups, err := ParseSynonyms([]byte("synonyms:\n  - terms: [controller, business]\n    note: GDPR / CCPA\n"))
+	if err != nil || len(groups) != 1 || groups[0].Note != "GDPR / CCPA" {
+		t.Fatalf("groups = %+v, err = %v", groups, err)
+	}
+	if _, err := ParseSynonyms([]byte("synonyms:\n  - terms: [controller]\n")); err == nil {
+		t.Error("expected an error for a group with one term")
+	}
+}
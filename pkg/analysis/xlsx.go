@@ -0,0 +1,119 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/xlsx"
+)
+
+// documentSummaryHeader is the header of a sheet of document summaries.
+var documentSummaryHeader = []string{"ID", "Label", "Articles", "Definitions", "References",
+	"Rights", "Obligations", "External Refs", "Triples"}
+
+// addDocumentSummaries adds a sheet with one row per document summary.
+func addDocumentSummaries(workbook *xlsx.Workbook, documents ...DocumentSummary) {
+	sheet := workbook.AddSheet("Documents", documentSummaryHeader...)
+	for _, doc := range documents {
+		sheet.AddRow(doc.ID, doc.Label, doc.Articles, doc.Definitions, doc.References,
+			doc.Rights, doc.Obligations, doc.ExternalRefs, doc.Triples)
+	}
+}
+
+// addConceptOverlaps adds a sheet with one row per concept and document,
+// listing the provisions where the document has the concept.
+func addConceptOverlaps(workbook *xlsx.Workbook, name string, overlaps []ConceptOverlap) {
+	sheet := workbook.AddSheet(name, "Concept", "Document", "Provisions")
+	for _, overlap := range overlaps {
+		documentIDs := make([]string, 0, len(overlap.Documents))
+		for documentID := range overlap.Documents {
+			documentIDs = append(documentIDs, documentID)
+		}
+		sort.Strings(documentIDs)
+		for _, documentID := range documentIDs {
+			sheet.AddRow(overlap.Concept, documentID, strings.Join(overlap.Documents[documentID], ", "))
+		}
+	}
+}
+
+// ToXLSX renders the comparison as an Excel workbook with sheets for the
+// two documents and each kind of overlap.
+func (r *ComparisonResult) ToXLSX() ([]byte, error) {
+	workbook := xlsx.NewWorkbook()
+	addDocumentSummaries(workbook, r.DocumentA, r.DocumentB)
+	addConceptOverlaps(workbook, "Shared Definitions", r.SharedDefinitions)
+
+	aligned := workbook.AddSheet("Aligned Definitions", "Term A", "Term B", "Score", "Method", "Note",
+		"Provisions A", "Provisions B")
+	for _, alignment := range r.AlignedDefinitions {
+		aligned.AddRow(alignment.TermA, alignment.TermB, alignment.Score, alignment.Method, alignment.Note,
+			strings.Join(alignment.ProvisionA, ", "), strings.Join(alignment.ProvisionB, ", "))
+	}
+
+	addConceptOverlaps(workbook, "Shared Rights", r.SharedRights)
+	addConceptOverlaps(workbook, "Shared Obligations", r.SharedObligations)
+
+	externalRefs := workbook.AddSheet("Shared External Refs", "Target")
+	for _, target := range r.SharedExternalRefs {
+		externalRefs.AddRow(target)
+	}
+	return workbook.Bytes()
+}
+
+// ToXLSX renders the cross-reference analysis as an Excel workbook with
+// sheets for the documents, shared concepts, external references, and each
+// kind of overlap.
+func (r *CrossRefResult) ToXLSX() ([]byte, error) {
+	workbook := xlsx.NewWorkbook()
+	addDocumentSummaries(workbook, r.Documents...)
+
+	concepts := workbook.AddSheet("Shared Concepts", "Concept", "Type", "Documents")
+	for _, concept := range r.SharedConcepts {
+		concepts.AddRow(concept.Concept, concept.Type, strings.Join(concept.Documents, ", "))
+	}
+
+	externalRefs := workbook.AddSheet("External Refs", "Target", "Count", "Document", "Provision", "Reference")
+	for _, cluster := range r.ExternalRefs {
+		for _, source := range cluster.Sources {
+			externalRefs.AddRow(cluster.Target, cluster.Count, source.Document, source.Provision, source.RefText)
+		}
+	}
+
+	addConceptOverlaps(workbook, "Definition Overlap", r.DefinitionOverlap)
+	addConceptOverlaps(workbook, "Rights Overlap", r.RightsOverlap)
+	addConceptOverlaps(workbook, "Obligation Overlap", r.ObligationOverlap)
+	return workbook.Bytes()
+}
+
+// ToXLSX renders the obligation comparison as an Excel workbook: the matrix
+// of counts per obligation type and document, the coverage gaps, and the
+// obligations in each cluster.
+func (r *ObligationOverlapResult) ToXLSX() ([]byte, error) {
+	workbook := xlsx.NewWorkbook()
+	addDocumentSummaries(workbook, r.Documents...)
+
+	header := append(append([]string{"Type", "Label"}, r.Matrix.Documents...), "Similarity")
+	matrix := workbook.AddSheet("Matrix", header...)
+	for t, cluster := range r.Clusters {
+		row := []any{cluster.Type, cluster.Label}
+		for _, count := range r.Matrix.Counts[t] {
+			row = append(row, count)
+		}
+		matrix.AddRow(append(row, xlsx.Percent(cluster.Similarity))...)
+	}
+
+	gaps := workbook.AddSheet("Gaps", "Type", "Label", "Covered", "Missing")
+	for _, gap := range r.Gaps {
+		gaps.AddRow(gap.Type, gap.Label, strings.Join(gap.Covered, ", "), strings.Join(gap.Missing, ", "))
+	}
+
+	obligations := workbook.AddSheet("Obligations", "Type", "Document", "Provision", "Duty Bearer",
+		"Prohibition", "Similarity", "Text")
+	for _, cluster := range r.Clusters {
+		for _, member := range cluster.Members {
+			obligations.AddRow(cluster.Type, member.Document, member.Provision, member.DutyBearer,
+				member.Prohibition, xlsx.Percent(member.Similarity), member.Text)
+		}
+	}
+	return workbook.Bytes()
+}
@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// readXLSXPart returns the content of one part of an .xlsx file.
+func readXLSXPart(t *testing.T, data []byte, name string) string {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("not an .xlsx file: %v", err)
+	}
+	reader, err := archive.Open(name)
+	if err != nil {
+		t.Fatalf("missing part %s: %v", name, err)
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestComparisonResult_ToXLSX(t *testing.T) {
+	analyzer := NewCrossRefAnalyzer()
+	analyzer.AddDocument("gdpr", "GDPR", buildTestStore(3, []string{"personal data", "consent"}, nil, nil, nil))
+	analyzer.AddDocument("ccpa", "CCPA", buildTestStore(2, []string{"personal data", "consumer"}, nil, nil, nil))
+
+	data, err := analyzer.CompareDocuments("gdpr", "ccpa").ToXLSX()
+	if err != nil {
+		t.Fatalf("ToXLSX() error = %v", err)
+	}
+	workbook := readXLSXPart(t, data, "xl/workbook.xml")
+	for _, sheet := range []string{"Documents", "Shared Definitions", "Aligned Definitions", "Shared Rights",
+		"Shared Obligations", "Shared External Refs"} {
+		if !strings.Contains(workbook, `name="`+sheet+`"`) {
+			t.Errorf("workbook missing sheet %q: %s", sheet, workbook)
+		}
+	}
+	if documents := readXLSXPart(t, data, "xl/worksheets/sheet1.xml"); !strings.Contains(documents, "CCPA") {
+		t.Errorf("Documents sheet missing CCPA:\n%s", documents)
+	}
+	if definitions := readXLSXPart(t, data, "xl/worksheets/sheet2.xml"); !strings.Contains(definitions, "personal data") {
+		t.Errorf("Shared Definitions sheet missing shared term:\n%s", definitions)
+	}
+}
+
+func TestObligationOverlapResult_ToXLSX(t *testing.T) {
+	gdpr := store.NewTripleStore()
+	addTestObligation(gdpr, "GDPR", "Art33", "Notification of a personal data breach",
+		"BreachNotificationObligation", "shall notify the personal data breach")
+	addTestObligation(gdpr, "GDPR", "Art30", "Records of processing activities", "RecordKeepingObligation", "shall maintain a record")
+	hipaa := store.NewTripleStore()
+	addTestObligation(hipaa, "HIPAA", "Sec404", "Notification of breach", "BreachNotificationObligation", "shall notify each individual")
+
+	analyzer := NewCrossRefAnalyzer()
+	analyzer.AddDocument("GDPR", "GDPR", gdpr)
+	analyzer.AddDocument("HIPAA", "HIPAA", hipaa)
+	data, err := analyzer.AnalyzeObligationOverlap(DefaultObligationOverlapOptions()).ToXLSX()
+	if err != nil {
+		t.Fatalf("ToXLSX() error = %v", err)
+	}
+
+	workbook := readXLSXPart(t, data, "xl/workbook.xml")
+	for _, sheet := range []string{"Documents", "Matrix", "Gaps", "Obligations"} {
+		if !strings.Contains(workbook, `name="`+sheet+`"`) {
+			t.Errorf("workbook missing sheet %q: %s", sheet, workbook)
+		}
+	}
+	matrix := readXLSXPart(t, data, "xl/worksheets/sheet2.xml")
+	for _, want := range []string{">HIPAA<", "BreachNotificationObligation", `<c r="C2"><v>1</v></c>`} {
+		if !strings.Contains(matrix, want) {
+			t.Errorf("Matrix sheet missing %s:\n%s", want, matrix)
+		}
+	}
+	if gaps := readXLSXPart(t, data, "xl/worksheets/sheet3.xml"); !strings.Contains(gaps, "RecordKeepingObligation") {
+		t.Errorf("Gaps sheet missing record keeping gap:\n%s", gaps)
+	}
+}
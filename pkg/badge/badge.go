@@ -0,0 +1,152 @@
+// Package badge renders flat SVG status badges, in the two-part
+// "label | message" style used by README shields, from library health
+// summaries.
+package badge
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/library"
+)
+
+// Badge colors.
+const (
+	ColorGreen  = "#4c1"
+	ColorYellow = "#dfb317"
+	ColorOrange = "#fe7d37"
+	ColorRed    = "#e05d44"
+	ColorBlue   = "#007ec6"
+	ColorGrey   = "#9f9f9f"
+	labelColor  = "#555"
+)
+
+// Metrics selectable for a health badge.
+var Metrics = []string{"health", "documents", "score", "links", "conflicts"}
+
+// Badge is a two-part status badge.
+type Badge struct {
+	Label   string `json:"label"`
+	Message string `json:"message"`
+	Color   string `json:"color"`
+}
+
+// ForHealth builds the badge for one metric of a health summary: "health"
+// (overall status), "documents", "score" (last validation score), "links"
+// (broken links), or "conflicts" (open merge conflicts).
+func ForHealth(summary *library.HealthSummary, metric string) (Badge, error) {
+	switch metric {
+	case "health", "":
+		return Badge{Label: "regula", Message: summary.Status, Color: statusColor(summary.Status)}, nil
+	case "documents":
+		return Badge{Label: "documents", Message: fmt.Sprintf("%d", summary.Documents), Color: ColorBlue}, nil
+	case "score":
+		if summary.ValidationScore == nil {
+			return Badge{Label: "validation", Message: "none", Color: ColorGrey}, nil
+		}
+		return Badge{
+			Label:   "validation",
+			Message: fmt.Sprintf("%.0f%%", *summary.ValidationScore*100),
+			Color:   scoreColor(*summary.ValidationScore),
+		}, nil
+	case "links":
+		if summary.BrokenLinks == nil {
+			return Badge{Label: "broken links", Message: "unchecked", Color: ColorGrey}, nil
+		}
+		return Badge{Label: "broken links", Message: fmt.Sprintf("%d", *summary.BrokenLinks), Color: countColor(*summary.BrokenLinks)}, nil
+	case "conflicts":
+		return Badge{Label: "conflicts", Message: fmt.Sprintf("%d", summary.OpenConflicts), Color: countColor(summary.OpenConflicts)}, nil
+	default:
+		return Badge{}, fmt.Errorf("unknown badge metric: %s (use %s)", metric, strings.Join(Metrics, ", "))
+	}
+}
+
+func statusColor(status string) string {
+	switch status {
+	case library.HealthPassing:
+		return ColorGreen
+	case library.HealthWarning:
+		return ColorYellow
+	case library.HealthFailing:
+		return ColorRed
+	default:
+		return ColorGrey
+	}
+}
+
+func scoreColor(score float64) string {
+	switch {
+	case score >= 0.9:
+		return ColorGreen
+	case score >= 0.8:
+		return ColorYellow
+	case score >= 0.6:
+		return ColorOrange
+	default:
+		return ColorRed
+	}
+}
+
+func countColor(count int) string {
+	if count == 0 {
+		return ColorGreen
+	}
+	return ColorOrange
+}
+
+// SVG renders the badge as a standalone SVG document.
+func (b Badge) SVG() string {
+	labelWidth := textWidth(b.Label) + 10
+	messageWidth := textWidth(b.Message) + 10
+	totalWidth := labelWidth + messageWidth
+	label := html.EscapeString(b.Label)
+	message := html.EscapeString(b.Message)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`,
+		totalWidth, label, message))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("  <title>%s: %s</title>\n", label, message))
+	sb.WriteString(`  <linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`)
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf(`  <clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`, totalWidth))
+	sb.WriteString("\n")
+	sb.WriteString(`  <g clip-path="url(#r)">`)
+	sb.WriteString(fmt.Sprintf(`<rect width="%d" height="20" fill="%s"/>`, labelWidth, labelColor))
+	sb.WriteString(fmt.Sprintf(`<rect x="%d" width="%d" height="20" fill="%s"/>`, labelWidth, messageWidth, html.EscapeString(b.Color)))
+	sb.WriteString(fmt.Sprintf(`<rect width="%d" height="20" fill="url(#s)"/></g>`, totalWidth))
+	sb.WriteString("\n")
+	sb.WriteString(`  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`)
+	sb.WriteString("\n")
+	writeText(&sb, labelWidth/2, label)
+	writeText(&sb, labelWidth+messageWidth/2, message)
+	sb.WriteString("  </g>\n</svg>\n")
+	return sb.String()
+}
+
+// writeText writes centered text with a drop shadow.
+func writeText(sb *strings.Builder, x int, text string) {
+	sb.WriteString(fmt.Sprintf(`    <text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text>`, x, text))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf(`    <text x="%d" y="14">%s</text>`, x, text))
+	sb.WriteString("\n")
+}
+
+// textWidth approximates the rendered width of text in 11px Verdana.
+func textWidth(text string) int {
+	width := 0.0
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune("ijlt.,:;!|' ", r):
+			width += 3.5
+		case strings.ContainsRune("mwMW%", r):
+			width += 10
+		case r >= 'A' && r <= 'Z':
+			width += 7.5
+		default:
+			width += 6.5
+		}
+	}
+	return int(width + 0.5)
+}
@@ -0,0 +1,62 @@
+package badge
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/library"
+)
+
+func TestForHealth(t *testing.T) {
+	score := 0.72
+	broken := 0
+	summary := &library.HealthSummary{
+		Status:          library.HealthWarning,
+		Documents:       12,
+		ValidationScore: &score,
+		BrokenLinks:     &broken,
+		OpenConflicts:   3,
+	}
+
+	tests := []struct {
+		metric      string
+		wantMessage string
+		wantColor   string
+	}{
+		{"health", "warning", ColorYellow},
+		{"documents", "12", ColorBlue},
+		{"score", "72%", ColorOrange},
+		{"links", "0", ColorGreen},
+		{"conflicts", "3", ColorOrange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.metric, func(t *testing.T) {
+			statusBadge, err := ForHealth(summary, tt.metric)
+			if err != nil {
+				t.Fatalf("ForHealth failed: %v", err)
+			}
+			if statusBadge.Message != tt.wantMessage || statusBadge.Color != tt.wantColor {
+				t.Errorf("got %q %s, want %q %s", statusBadge.Message, statusBadge.Color, tt.wantMessage, tt.wantColor)
+			}
+		})
+	}
+
+	if statusBadge, _ := ForHealth(&library.HealthSummary{}, "links"); statusBadge.Message != "unchecked" {
+		t.Errorf("unrecorded links message = %q, want unchecked", statusBadge.Message)
+	}
+	if _, err := ForHealth(summary, "uptime"); err == nil {
+		t.Error("expected an error for an unknown metric")
+	}
+}
+
+func TestBadgeSVG(t *testing.T) {
+	svg := Badge{Label: "regula", Message: "a<b", Color: ColorGreen}.SVG()
+
+	if err := xml.Unmarshal([]byte(svg), new(struct{})); err != nil {
+		t.Fatalf("badge is not well-formed XML: %v\n%s", err, svg)
+	}
+	if !strings.Contains(svg, "a&lt;b") || !strings.Contains(svg, ColorGreen) {
+		t.Errorf("badge missing escaped message or color:\n%s", svg)
+	}
+}
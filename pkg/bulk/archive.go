@@ -1,6 +1,7 @@
 package bulk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -83,15 +84,15 @@ func (source *InternetArchiveSource) ListDatasets() ([]Dataset, error) {
 // DownloadDataset downloads an Internet Archive item.
 // First fetches the item metadata to find downloadable files, then
 // downloads the first suitable archive file (tar.gz, zip, or xml).
-func (source *InternetArchiveSource) DownloadDataset(dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
+func (source *InternetArchiveSource) DownloadDataset(ctx context.Context, dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
 	sourceDir := downloader.SourceDirectory("archive")
 
 	// Fetch item metadata to find downloadable files
 	metadataURL := fmt.Sprintf("https://archive.org/metadata/%s", dataset.Identifier)
 
-	downloader.waitForDomain("archive.org")
+	downloader.waitForDomain(ctx, "archive.org")
 
-	metaRequest, err := http.NewRequest(http.MethodGet, metadataURL, nil)
+	metaRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create metadata request: %w", err)
 	}
@@ -123,8 +124,8 @@ func (source *InternetArchiveSource) DownloadDataset(dataset Dataset, downloader
 		dataset.Identifier, downloadFilename)
 	localPath := filepath.Join(sourceDir, dataset.Identifier, downloadFilename)
 
-	bytesWritten, skipped, err := downloader.DownloadFile(
-		downloadURL, localPath, PrintDownloadProgress)
+	bytesWritten, skipped, err := downloader.DownloadFile(ctx,
+		downloadURL, localPath, downloader.progressBar())
 	if err != nil {
 		return &DownloadResult{
 			Dataset: dataset,
@@ -133,7 +134,7 @@ func (source *InternetArchiveSource) DownloadDataset(dataset Dataset, downloader
 	}
 
 	if !skipped {
-		downloader.Manifest().RecordDownload(&DownloadRecord{
+		downloader.RecordDownload(&DownloadRecord{
 			Identifier:   dataset.Identifier,
 			SourceName:   "archive",
 			URL:          downloadURL,
@@ -142,7 +143,7 @@ func (source *InternetArchiveSource) DownloadDataset(dataset Dataset, downloader
 			DownloadedAt: time.Now(),
 		})
 		downloader.SaveManifest()
-		fmt.Println()
+		downloader.endProgressBar()
 	}
 
 	return &DownloadResult{
@@ -1,6 +1,7 @@
 package bulk
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -55,7 +56,7 @@ func (source *CaliforniaSource) ListDatasets() ([]Dataset, error) {
 
 // DownloadDataset downloads a California code by scraping the TOC and
 // fetching expanded branch text for each top-level division.
-func (source *CaliforniaSource) DownloadDataset(dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
+func (source *CaliforniaSource) DownloadDataset(ctx context.Context, dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
 	sourceDir := downloader.SourceDirectory("california")
 	codeAbbrev := strings.TrimPrefix(dataset.Identifier, "ca-")
 	codeAbbrev = strings.ToUpper(codeAbbrev)
@@ -80,9 +81,9 @@ func (source *CaliforniaSource) DownloadDataset(dataset Dataset, downloader *Dow
 		californiaBaseURL, codeAbbrev,
 		url.QueryEscape(source.codeFullName(codeAbbrev)))
 
-	downloader.waitForDomain("leginfo.legislature.ca.gov")
+	downloader.waitForDomain(ctx, "leginfo.legislature.ca.gov")
 
-	tocRequest, err := http.NewRequest(http.MethodGet, tocURL, nil)
+	tocRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, tocURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create TOC request: %w", err)
 	}
@@ -118,9 +119,9 @@ func (source *CaliforniaSource) DownloadDataset(dataset Dataset, downloader *Dow
 	allText.WriteString(fmt.Sprintf("CALIFORNIA %s\n\n", source.codeFullName(codeAbbrev)))
 
 	for _, branchURL := range branchURLs {
-		downloader.waitForDomain("leginfo.legislature.ca.gov")
+		downloader.waitForDomain(ctx, "leginfo.legislature.ca.gov")
 
-		branchRequest, err := http.NewRequest(http.MethodGet, branchURL, nil)
+		branchRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, branchURL, nil)
 		if err != nil {
 			continue
 		}
@@ -153,7 +154,7 @@ func (source *CaliforniaSource) DownloadDataset(dataset Dataset, downloader *Dow
 
 	bytesWritten := int64(len(codeText))
 
-	downloader.Manifest().RecordDownload(&DownloadRecord{
+	downloader.RecordDownload(&DownloadRecord{
 		Identifier:   dataset.Identifier,
 		SourceName:   "california",
 		URL:          tocURL,
@@ -217,6 +218,12 @@ func extractBranchURLs(tocHTML string, codeAbbrev string) []string {
 // extractCaliforniaText strips HTML tags from California legislature HTML
 // and returns plain text preserving section structure.
 func extractCaliforniaText(rawHTML []byte) string {
+	return extractHTMLText(rawHTML)
+}
+
+// extractHTMLText strips scripts, styles, and tags from legislature HTML and
+// returns plain text with one block per element. Shared by the state sources.
+func extractHTMLText(rawHTML []byte) string {
 	content := string(rawHTML)
 	content = reCAScript.ReplaceAllString(content, "")
 	content = reCAStyle.ReplaceAllString(content, "")
@@ -1,6 +1,7 @@
 package bulk
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"time"
@@ -53,12 +54,12 @@ func (source *CFRSource) ListDatasets() ([]Dataset, error) {
 }
 
 // DownloadDataset downloads a CFR title ZIP to the downloads directory.
-func (source *CFRSource) DownloadDataset(dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
+func (source *CFRSource) DownloadDataset(ctx context.Context, dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
 	sourceDir := downloader.SourceDirectory("cfr")
 	localPath := filepath.Join(sourceDir, filepath.Base(dataset.URL))
 
-	bytesWritten, skipped, err := downloader.DownloadFile(
-		dataset.URL, localPath, PrintDownloadProgress)
+	bytesWritten, skipped, err := downloader.DownloadFile(ctx,
+		dataset.URL, localPath, downloader.progressBar())
 	if err != nil {
 		return &DownloadResult{
 			Dataset: dataset,
@@ -67,7 +68,7 @@ func (source *CFRSource) DownloadDataset(dataset Dataset, downloader *Downloader
 	}
 
 	if !skipped {
-		downloader.Manifest().RecordDownload(&DownloadRecord{
+		downloader.RecordDownload(&DownloadRecord{
 			Identifier:   dataset.Identifier,
 			SourceName:   "cfr",
 			URL:          dataset.URL,
@@ -76,7 +77,7 @@ func (source *CFRSource) DownloadDataset(dataset Dataset, downloader *Downloader
 			DownloadedAt: time.Now(),
 		})
 		downloader.SaveManifest()
-		fmt.Println()
+		downloader.endProgressBar()
 	}
 
 	return &DownloadResult{
@@ -1,6 +1,7 @@
 package bulk
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -113,7 +114,7 @@ func TestCFRSourceDownloadDataset(t *testing.T) {
 		Format:     "zip",
 	}
 
-	result, err := source.DownloadDataset(dataset, downloader)
+	result, err := source.DownloadDataset(context.Background(), dataset, downloader)
 	if err != nil {
 		t.Fatalf("download failed: %v", err)
 	}
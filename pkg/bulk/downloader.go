@@ -4,15 +4,19 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"compress/gzip"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/coolbeans/regula/pkg/httpcache"
 )
 
 // Downloader provides shared download infrastructure: HTTP fetching with
@@ -52,6 +56,13 @@ func NewDownloader(config DownloadConfig) (*Downloader, error) {
 			},
 		}
 	}
+	if config.HTTPCacheDir != "" {
+		cache, err := httpcache.Open(config.HTTPCacheDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open HTTP cache: %w", err)
+		}
+		httpClient = cache.Client(httpClient)
+	}
 
 	return &Downloader{
 		config:       config,
@@ -64,8 +75,12 @@ func NewDownloader(config DownloadConfig) (*Downloader, error) {
 
 // DownloadFile fetches a URL to a local file path with progress reporting.
 // Skips the download if the file already exists with non-zero size.
-// Retries transient errors (5xx, timeouts) with exponential backoff.
-func (downloader *Downloader) DownloadFile(downloadURL string, localPath string, progressCallback ProgressCallback) (int64, bool, error) {
+// Data is streamed to a ".part" file that is renamed into place on success;
+// an existing partial file is resumed with an HTTP range request.
+// Retries transient errors (5xx, timeouts) with exponential backoff. Once
+// ctx is done, the request is aborted and retry and rate limit waits return
+// early; the partial file is kept, so a later run resumes it.
+func (downloader *Downloader) DownloadFile(ctx context.Context, downloadURL string, localPath string, progressCallback ProgressCallback) (int64, bool, error) {
 	// Check if file already exists
 	existingInfo, err := os.Stat(localPath)
 	if err == nil && existingInfo.Size() > 0 {
@@ -86,47 +101,87 @@ func (downloader *Downloader) DownloadFile(downloadURL string, localPath string,
 		retryDelay = 5 * time.Second
 	}
 
+	partialPath := localPath + partialFileSuffix
+
 	var lastErr error
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		if attempt > 0 {
 			currentDelay := retryDelay * time.Duration(1<<uint(attempt-1))
-			time.Sleep(currentDelay)
+			if err := sleepContext(ctx, currentDelay); err != nil {
+				return 0, false, err
+			}
 		}
 
-		bytesWritten, err := downloader.downloadFileAttempt(downloadURL, localPath, progressCallback)
+		bytesWritten, err := downloader.downloadFileAttempt(ctx, downloadURL, partialPath, progressCallback)
 		if err == nil {
+			if err := os.Rename(partialPath, localPath); err != nil {
+				return 0, false, fmt.Errorf("failed to finalize %s: %w", localPath, err)
+			}
 			return bytesWritten, false, nil
 		}
 
 		lastErr = err
 
-		// Only retry on transient errors (5xx, network errors)
-		if !isRetryableError(err) {
+		// Only retry on transient errors (5xx, network errors); the partial
+		// file is kept so the next attempt resumes where this one stopped
+		if ctx.Err() != nil || !isRetryableError(err) {
 			return 0, false, err
 		}
-
-		// Clean up partial file before retry
-		os.Remove(localPath)
 	}
 
 	return 0, false, fmt.Errorf("failed after %d attempts: %w", maxRetries, lastErr)
 }
 
-// downloadFileAttempt performs a single download attempt.
-func (downloader *Downloader) downloadFileAttempt(downloadURL string, localPath string, progressCallback ProgressCallback) (int64, error) {
+// progressBar returns the callback that draws download progress on
+// config.Progress, or nil when progress is not reported. Bars from
+// concurrent workers would interleave on one line, so they are drawn only
+// for sequential downloads.
+func (downloader *Downloader) progressBar() ProgressCallback {
+	if !downloader.showsProgress() {
+		return nil
+	}
+	return ProgressBar(downloader.config.Progress)
+}
+
+// endProgressBar ends the line a progress bar was drawn on.
+func (downloader *Downloader) endProgressBar() {
+	if downloader.showsProgress() {
+		fmt.Fprintln(downloader.config.Progress)
+	}
+}
+
+func (downloader *Downloader) showsProgress() bool {
+	return downloader.config.Progress != nil && downloader.config.Concurrency <= 1
+}
+
+// partialFileSuffix marks an in-progress download that can be resumed.
+const partialFileSuffix = ".part"
+
+// downloadFileAttempt performs a single download attempt into partialPath,
+// resuming from its current size when the server honors range requests.
+// Returns the total size of the partial file on success.
+func (downloader *Downloader) downloadFileAttempt(ctx context.Context, downloadURL string, partialPath string, progressCallback ProgressCallback) (int64, error) {
 	// Rate limit per domain
 	parsedURL, err := url.Parse(downloadURL)
 	if err != nil {
 		return 0, fmt.Errorf("invalid URL %s: %w", downloadURL, err)
 	}
-	downloader.waitForDomain(parsedURL.Host)
+	downloader.waitForDomain(ctx, parsedURL.Host)
+
+	var resumeOffset int64
+	if partialInfo, err := os.Stat(partialPath); err == nil {
+		resumeOffset = partialInfo.Size()
+	}
 
 	// Create HTTP request
-	request, err := http.NewRequest(http.MethodGet, downloadURL, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create request: %w", err)
 	}
 	request.Header.Set("User-Agent", downloader.config.UserAgent)
+	if resumeOffset > 0 {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%d-", resumeOffset))
+	}
 
 	response, err := downloader.httpClient.Do(request)
 	if err != nil {
@@ -134,6 +189,11 @@ func (downloader *Downloader) downloadFileAttempt(downloadURL string, localPath
 	}
 	defer response.Body.Close()
 
+	if response.StatusCode == http.StatusRequestedRangeNotSatisfiable && resumeOffset > 0 {
+		// The partial file is stale or larger than the remote; start over
+		os.Remove(partialPath)
+		return 0, &retryableHTTPError{StatusCode: response.StatusCode, URL: downloadURL}
+	}
 	if response.StatusCode >= 500 {
 		return 0, &retryableHTTPError{StatusCode: response.StatusCode, URL: downloadURL}
 	}
@@ -141,17 +201,30 @@ func (downloader *Downloader) downloadFileAttempt(downloadURL string, localPath
 		return 0, fmt.Errorf("HTTP %d for %s", response.StatusCode, downloadURL)
 	}
 
+	// Append on 206 Partial Content; any other success restarts from zero
+	openFlags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	totalBytes := response.ContentLength
+	bytesWritten := int64(0)
+	if response.StatusCode == http.StatusPartialContent && resumeOffset > 0 {
+		if rangeStart, ok := parseContentRangeStart(response.Header.Get("Content-Range")); !ok || rangeStart != resumeOffset {
+			os.Remove(partialPath)
+			return 0, &retryableHTTPError{StatusCode: response.StatusCode, URL: downloadURL}
+		}
+		openFlags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		bytesWritten = resumeOffset
+		if totalBytes >= 0 {
+			totalBytes += resumeOffset
+		}
+	}
+
 	// Create output file
-	outputFile, err := os.Create(localPath)
+	outputFile, err := os.OpenFile(partialPath, openFlags, 0644)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create file %s: %w", localPath, err)
+		return 0, fmt.Errorf("failed to create file %s: %w", partialPath, err)
 	}
 	defer outputFile.Close()
 
 	// Stream with progress reporting
-	totalBytes := response.ContentLength
-	var bytesWritten int64
-
 	buffer := make([]byte, 32*1024) // 32KB buffer
 	for {
 		bytesRead, readErr := response.Body.Read(buffer)
@@ -174,9 +247,76 @@ func (downloader *Downloader) downloadFileAttempt(downloadURL string, localPath
 		}
 	}
 
+	if totalBytes > 0 && bytesWritten < totalBytes {
+		return bytesWritten, fmt.Errorf("read error: unexpected EOF after %d of %d bytes", bytesWritten, totalBytes)
+	}
+
 	return bytesWritten, nil
 }
 
+// parseContentRangeStart extracts the first byte position from a
+// Content-Range header of the form "bytes 100-999/1000".
+func parseContentRangeStart(contentRange string) (int64, bool) {
+	rangeSpec, found := strings.CutPrefix(contentRange, "bytes ")
+	if !found {
+		return 0, false
+	}
+	startText, _, found := strings.Cut(rangeSpec, "-")
+	if !found {
+		return 0, false
+	}
+	rangeStart, err := strconv.ParseInt(strings.TrimSpace(startText), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return rangeStart, true
+}
+
+// DownloadDatasets downloads datasets from source using up to
+// config.Concurrency workers (sequentially when Concurrency <= 1).
+// onComplete is invoked once per dataset, serially, in completion order.
+// Datasets not yet started when ctx is done are skipped without invoking
+// onComplete.
+func (downloader *Downloader) DownloadDatasets(ctx context.Context, source Source, datasets []Dataset, onComplete func(dataset Dataset, result *DownloadResult, err error)) {
+	workerCount := downloader.config.Concurrency
+	if workerCount < 1 {
+		workerCount = 1
+	}
+	if workerCount > len(datasets) {
+		workerCount = len(datasets)
+	}
+
+	pending := make(chan Dataset)
+	var completeMu sync.Mutex
+	var workers sync.WaitGroup
+
+	for workerIndex := 0; workerIndex < workerCount; workerIndex++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for dataset := range pending {
+				result, err := source.DownloadDataset(ctx, dataset, downloader)
+				if onComplete != nil {
+					completeMu.Lock()
+					onComplete(dataset, result, err)
+					completeMu.Unlock()
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for _, dataset := range datasets {
+		select {
+		case pending <- dataset:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(pending)
+	workers.Wait()
+}
+
 // retryableHTTPError represents an HTTP error that should trigger a retry.
 type retryableHTTPError struct {
 	StatusCode int
@@ -335,15 +475,15 @@ func (downloader *Downloader) ExtractTarGZ(tarGzPath string, targetDirectory str
 }
 
 // CheckRemoteSize performs an HTTP HEAD request to get Content-Length.
-func (downloader *Downloader) CheckRemoteSize(downloadURL string) (int64, error) {
+func (downloader *Downloader) CheckRemoteSize(ctx context.Context, downloadURL string) (int64, error) {
 	parsedURL, err := url.Parse(downloadURL)
 	if err != nil {
 		return 0, fmt.Errorf("invalid URL: %w", err)
 	}
 
-	downloader.waitForDomain(parsedURL.Host)
+	downloader.waitForDomain(ctx, parsedURL.Host)
 
-	request, err := http.NewRequest(http.MethodHead, downloadURL, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, downloadURL, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create HEAD request: %w", err)
 	}
@@ -367,6 +507,18 @@ func (downloader *Downloader) Manifest() *DownloadManifest {
 	return downloader.manifest
 }
 
+// RecordDownload adds a completed download to the manifest. When the record
+// has no checksum and LocalPath is a regular file, its SHA-256 is computed
+// so that `bulk verify` can later detect corruption.
+func (downloader *Downloader) RecordDownload(record *DownloadRecord) {
+	if record.SHA256 == "" && record.LocalPath != "" {
+		if checksum, err := FileSHA256(record.LocalPath); err == nil {
+			record.SHA256 = checksum
+		}
+	}
+	downloader.manifest.RecordDownload(record)
+}
+
 // SaveManifest persists the download manifest to disk.
 func (downloader *Downloader) SaveManifest() error {
 	return downloader.manifest.SaveManifest(downloader.manifestPath)
@@ -377,21 +529,35 @@ func (downloader *Downloader) SourceDirectory(sourceName string) string {
 	return filepath.Join(downloader.config.DownloadDirectory, sourceName)
 }
 
-// waitForDomain enforces per-domain rate limiting.
-func (downloader *Downloader) waitForDomain(domain string) {
+// waitForDomain enforces per-domain rate limiting. Each caller reserves the
+// next free slot for the domain under the lock, so concurrent workers are
+// spaced at least RateLimit apart.
+func (downloader *Downloader) waitForDomain(ctx context.Context, domain string) {
 	downloader.timerMu.Lock()
 
-	lastRequestTime, hasLastRequest := downloader.domainTimers[domain]
-	if hasLastRequest {
-		elapsed := time.Since(lastRequestTime)
-		if elapsed < downloader.config.RateLimit {
-			waitDuration := downloader.config.RateLimit - elapsed
-			downloader.timerMu.Unlock()
-			time.Sleep(waitDuration)
-			downloader.timerMu.Lock()
+	scheduledTime := time.Now()
+	if lastRequestTime, hasLastRequest := downloader.domainTimers[domain]; hasLastRequest {
+		if nextAllowed := lastRequestTime.Add(downloader.config.RateLimit); nextAllowed.After(scheduledTime) {
+			scheduledTime = nextAllowed
 		}
 	}
-
-	downloader.domainTimers[domain] = time.Now()
+	downloader.domainTimers[domain] = scheduledTime
 	downloader.timerMu.Unlock()
+
+	if waitDuration := time.Until(scheduledTime); waitDuration > 0 {
+		sleepContext(ctx, waitDuration)
+	}
+}
+
+// sleepContext waits for duration or until ctx is done, returning ctx's
+// error in the latter case.
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -1,13 +1,18 @@
 package bulk
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/telemetry"
 )
 
 // BulkIngester reads downloaded files, parses XML/text content, and adds
@@ -33,9 +38,8 @@ func (ingester *BulkIngester) IngestSource(sourceName string, downloadDir string
 		return nil, fmt.Errorf("failed to load download manifest: %w", err)
 	}
 
-	report := &IngestReport{}
-
-	for _, record := range manifest.Downloads {
+	var records []*DownloadRecord
+	for _, record := range sortedDownloads(manifest) {
 		if record.SourceName != sourceName {
 			continue
 		}
@@ -45,15 +49,10 @@ func (ingester *BulkIngester) IngestSource(sourceName string, downloadDir string
 			continue
 		}
 
-		report.TotalAttempted++
-
-		entry := ingester.ingestDownloadedFile(record)
-		report.Entries = append(report.Entries, entry)
-
-		accumulateReportStats(report, entry)
+		records = append(records, record)
 	}
 
-	return report, nil
+	return ingester.ingestRecords(records), nil
 }
 
 // IngestAll processes all downloaded datasets from all sources.
@@ -63,22 +62,75 @@ func (ingester *BulkIngester) IngestAll(downloadDir string) (*IngestReport, erro
 		return nil, fmt.Errorf("failed to load download manifest: %w", err)
 	}
 
-	report := &IngestReport{}
-
-	for _, record := range manifest.Downloads {
-		report.TotalAttempted++
+	return ingester.ingestRecords(sortedDownloads(manifest)), nil
+}
 
-		entry := ingester.ingestDownloadedFile(record)
-		report.Entries = append(report.Entries, entry)
+// sortedDownloads returns the manifest's download records ordered by key,
+// so documents are committed in the same order on every run.
+func sortedDownloads(manifest *DownloadManifest) []*DownloadRecord {
+	keys := make([]string, 0, len(manifest.Downloads))
+	for key := range manifest.Downloads {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-		accumulateReportStats(report, entry)
+	records := make([]*DownloadRecord, len(keys))
+	for i, key := range keys {
+		records[i] = manifest.Downloads[key]
 	}
+	return records
+}
 
-	return report, nil
+// ingestRecords ingests records on up to config.Workers goroutines.
+// Extraction and triple building run concurrently; documents are committed
+// to the library, and the report built, in record order.
+func (ingester *BulkIngester) ingestRecords(records []*DownloadRecord) *IngestReport {
+	report := &IngestReport{}
+	startTime := time.Now()
+
+	library.RunOrdered(len(records), ingester.config.Workers,
+		func(index int) *pendingIngest {
+			return ingester.prepareDownloadedFile(records[index])
+		},
+		func(index int, pending *pendingIngest) {
+			entry := ingester.commitDownloadedFile(pending)
+			report.TotalAttempted++
+			report.Entries = append(report.Entries, entry)
+			accumulateReportStats(report, entry)
+
+			if ingester.config.Progress != nil {
+				ingester.config.Progress(IngestProgress{
+					Completed: len(report.Entries),
+					Total:     len(records),
+					Entry:     entry,
+					Elapsed:   time.Since(startTime),
+				})
+			}
+		})
+
+	return report
+}
+
+// pendingIngest is a downloaded file that has been extracted and ingested
+// but not yet committed to the library. When entry.Status is set, the file
+// needed no commit and entry is final.
+type pendingIngest struct {
+	entry        IngestEntry
+	prepared     *library.PreparedDocument
+	startTime    time.Time
+	documentSpan *telemetry.Span
 }
 
 // ingestDownloadedFile processes a single downloaded file based on its source.
 func (ingester *BulkIngester) ingestDownloadedFile(record *DownloadRecord) IngestEntry {
+	return ingester.commitDownloadedFile(ingester.prepareDownloadedFile(record))
+}
+
+// prepareDownloadedFile extracts plaintext from a downloaded file and
+// builds its triples without touching the library. The source extraction
+// and library ingestion are timed as telemetry spans under a "document"
+// span carrying the document ID and source, which commitDownloadedFile ends.
+func (ingester *BulkIngester) prepareDownloadedFile(record *DownloadRecord) (pending *pendingIngest) {
 	documentID := deriveDocumentID(record)
 
 	// Check if already ingested
@@ -95,22 +147,44 @@ func (ingester *BulkIngester) ingestDownloadedFile(record *DownloadRecord) Inges
 				entry.Articles = existingDoc.Stats.Articles
 				entry.Chapters = existingDoc.Stats.Chapters
 			}
-			return entry
+			return &pendingIngest{entry: entry}
 		}
 	}
 
 	if ingester.config.DryRun {
-		return IngestEntry{
+		return &pendingIngest{entry: IngestEntry{
 			Identifier: record.Identifier,
 			DocumentID: documentID,
 			Status:     "skipped",
 			Error:      "dry run",
-		}
+		}}
 	}
 
-	startTime := time.Now()
+	pending = &pendingIngest{
+		entry:     IngestEntry{Identifier: record.Identifier, DocumentID: documentID},
+		startTime: time.Now(),
+	}
+	failed := func(message string) *pendingIngest {
+		pending.entry.Status = "failed"
+		pending.entry.Error = message
+		pending.entry.Duration = time.Since(pending.startTime)
+		return pending
+	}
+
+	ctx := telemetry.With(context.Background(),
+		slog.String(telemetry.KeyDocumentID, documentID),
+		slog.String("source", record.SourceName))
+	ctx, pending.documentSpan = telemetry.Start(ctx, "document")
+
+	// A malformed download must not take down the other workers.
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			pending = failed(fmt.Sprintf("extraction panicked: %v", recovered))
+		}
+	}()
 
 	// Route to source-specific ingestion
+	_, extractSpan := telemetry.Start(ctx, "extract")
 	var plaintext string
 	var ingestErr error
 
@@ -121,53 +195,64 @@ func (ingester *BulkIngester) ingestDownloadedFile(record *DownloadRecord) Inges
 		plaintext, ingestErr = ingester.ingestCFR(record)
 	case "california":
 		plaintext, ingestErr = ingester.ingestCalifornia(record)
+	case "newyork", "washington", "municipal":
+		plaintext, ingestErr = ingester.ingestStateText(record)
+	case "texas":
+		plaintext, ingestErr = ingester.ingestTexas(record)
 	case "archive":
 		plaintext, ingestErr = ingester.ingestArchive(record)
 	default:
 		ingestErr = fmt.Errorf("unknown source: %s", record.SourceName)
 	}
+	if ingestErr != nil {
+		extractSpan.RecordError(ingestErr)
+	}
+	extractSpan.SetAttributes(slog.Int("source_bytes", len(plaintext)))
+	extractSpan.End()
 
 	if ingestErr != nil {
-		return IngestEntry{
-			Identifier: record.Identifier,
-			DocumentID: documentID,
-			Status:     "failed",
-			Error:      ingestErr.Error(),
-			Duration:   time.Since(startTime),
-		}
+		return failed(ingestErr.Error())
 	}
 
 	if plaintext == "" {
-		return IngestEntry{
-			Identifier: record.Identifier,
-			DocumentID: documentID,
-			Status:     "failed",
-			Error:      "no content extracted",
-			Duration:   time.Since(startTime),
-		}
+		return failed("no content extracted")
 	}
 
-	// Add to library
 	addOptions := deriveAddOptions(record, documentID)
-	docEntry, err := ingester.lib.AddDocument(documentID, []byte(plaintext), addOptions)
-	if err != nil {
-		return IngestEntry{
-			Identifier: record.Identifier,
-			DocumentID: documentID,
-			Status:     "failed",
-			Error:      err.Error(),
-			Duration:   time.Since(startTime),
-		}
+	addOptions.Force = ingester.config.Force
+	pending.prepared = ingester.lib.PrepareDocument(ctx, documentID, []byte(plaintext), addOptions)
+	pending.entry.SourceBytes = len(plaintext)
+	return pending
+}
+
+// commitDownloadedFile adds a prepared file to the library and returns its
+// report entry.
+func (ingester *BulkIngester) commitDownloadedFile(pending *pendingIngest) (entry IngestEntry) {
+	entry = pending.entry
+	if pending.documentSpan != nil {
+		defer func() {
+			if entry.Status == "failed" {
+				pending.documentSpan.RecordError(errors.New(entry.Error))
+			}
+			pending.documentSpan.SetAttributes(slog.String("status", entry.Status), slog.Int("triples", entry.Triples))
+			pending.documentSpan.End()
+		}()
+	}
+	if pending.prepared == nil {
+		return entry
 	}
 
-	entry := IngestEntry{
-		Identifier:  record.Identifier,
-		DocumentID:  documentID,
-		Status:      "ingested",
-		Duration:    time.Since(startTime),
-		SourceBytes: len(plaintext),
+	// Add to library
+	docEntry, err := ingester.lib.CommitDocument(pending.prepared)
+	entry.Duration = time.Since(pending.startTime)
+	if err != nil {
+		entry.Status = "failed"
+		entry.Error = err.Error()
+		entry.SourceBytes = 0
+		return entry
 	}
 
+	entry.Status = "ingested"
 	if docEntry.Stats != nil {
 		entry.Triples = docEntry.Stats.TotalTriples
 		entry.Articles = docEntry.Stats.Articles
@@ -285,6 +370,57 @@ func (ingester *BulkIngester) ingestCalifornia(record *DownloadRecord) (string,
 	return string(data), nil
 }
 
+// ingestStateText reads a state or municipal code already flattened to
+// plain text at download time (New York, Washington, municipal codes).
+func (ingester *BulkIngester) ingestStateText(record *DownloadRecord) (string, error) {
+	data, err := os.ReadFile(record.LocalPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", record.LocalPath, err)
+	}
+	return string(data), nil
+}
+
+// ingestTexas extracts a Texas code ZIP and converts its HTML chapters to text.
+func (ingester *BulkIngester) ingestTexas(record *DownloadRecord) (string, error) {
+	localPath := record.LocalPath
+	if !strings.HasSuffix(localPath, ".zip") {
+		return "", fmt.Errorf("expected ZIP file, got: %s", localPath)
+	}
+
+	extractDir := strings.TrimSuffix(strings.TrimSuffix(localPath, ".zip"), ".htm")
+	downloader, err := NewDownloader(DefaultDownloadConfig())
+	if err != nil {
+		return "", err
+	}
+
+	extractedFiles, err := downloader.ExtractZIP(localPath, extractDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to extract ZIP: %w", err)
+	}
+	sort.Strings(extractedFiles)
+
+	var allText strings.Builder
+	codeAbbrev := strings.ToUpper(strings.TrimPrefix(record.Identifier, "tx-"))
+	allText.WriteString(fmt.Sprintf("TEXAS %s\n\n", texasCodeFullName(codeAbbrev)))
+
+	for _, filePath := range extractedFiles {
+		ext := strings.ToLower(filepath.Ext(filePath))
+		if ext != ".htm" && ext != ".html" {
+			continue
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			continue
+		}
+		if chapterText := extractHTMLText(data); chapterText != "" {
+			allText.WriteString(chapterText)
+			allText.WriteString("\n\n")
+		}
+	}
+
+	return allText.String(), nil
+}
+
 // ingestArchive extracts and reads an Internet Archive download.
 func (ingester *BulkIngester) ingestArchive(record *DownloadRecord) (string, error) {
 	localPath := record.LocalPath
@@ -363,8 +499,9 @@ func deriveDocumentID(record *DownloadRecord) string {
 	case "cfr":
 		// "cfr-2024-title-42" → "us-cfr-2024-title-42"
 		return "us-" + record.Identifier
-	case "california":
-		// "ca-civ" → "us-ca-civ"
+	case "california", "newyork", "texas", "washington", "municipal":
+		// "ca-civ" → "us-ca-civ", "ny-gbs" → "us-ny-gbs", "wa-rcw-19" → "us-wa-rcw-19",
+		// "muni-wa-seattle" → "us-muni-wa-seattle"
 		return "us-" + record.Identifier
 	case "archive":
 		// "govlawca" → "archive-govlawca"
@@ -382,6 +519,14 @@ func deriveAddOptions(record *DownloadRecord, documentID string) library.AddOpti
 	switch record.SourceName {
 	case "california":
 		jurisdiction = "US-CA"
+	case "newyork":
+		jurisdiction = "US-NY"
+	case "texas":
+		jurisdiction = "US-TX"
+	case "washington":
+		jurisdiction = "US-WA"
+	case "municipal":
+		jurisdiction = municipalJurisdiction(record.Identifier)
 	case "archive":
 		format = "generic"
 	}
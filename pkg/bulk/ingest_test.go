@@ -3,7 +3,10 @@ package bulk
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/coolbeans/regula/pkg/library"
 )
 
 func TestDeriveDocumentID(t *testing.T) {
@@ -27,6 +30,21 @@ func TestDeriveDocumentID(t *testing.T) {
 			record:     &DownloadRecord{Identifier: "ca-civ", SourceName: "california"},
 			expectedID: "us-ca-civ",
 		},
+		{
+			name:       "new york law",
+			record:     &DownloadRecord{Identifier: "ny-gbs", SourceName: "newyork"},
+			expectedID: "us-ny-gbs",
+		},
+		{
+			name:       "washington title",
+			record:     &DownloadRecord{Identifier: "wa-rcw-19", SourceName: "washington"},
+			expectedID: "us-wa-rcw-19",
+		},
+		{
+			name:       "municipal code",
+			record:     &DownloadRecord{Identifier: "muni-wa-seattle", SourceName: "municipal"},
+			expectedID: "us-muni-wa-seattle",
+		},
 		{
 			name:       "archive item",
 			record:     &DownloadRecord{Identifier: "govlawca", SourceName: "archive"},
@@ -78,6 +96,13 @@ func TestDeriveAddOptions(t *testing.T) {
 			expectedJurisdiction: "US-CA",
 			expectedFormat:       "us",
 		},
+		{
+			name:                 "municipal jurisdiction",
+			record:               &DownloadRecord{Identifier: "muni-wa-seattle", SourceName: "municipal", URL: "https://example.com"},
+			documentID:           "us-muni-wa-seattle",
+			expectedJurisdiction: "US-WA-SEATTLE",
+			expectedFormat:       "us",
+		},
 		{
 			name:                 "archive generic format",
 			record:               &DownloadRecord{Identifier: "govlawca", SourceName: "archive", URL: "https://example.com"},
@@ -339,3 +364,51 @@ func TestAccumulateReportStats(t *testing.T) {
 		t.Errorf("expected 30000 total triples after failed, got %d", report.TotalTriples)
 	}
 }
+
+func TestIngestRecordsParallelKeepsOrder(t *testing.T) {
+	temporaryDir := t.TempDir()
+	lib, err := library.Init(filepath.Join(temporaryDir, "lib"), "")
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	var records []*DownloadRecord
+	for _, code := range []string{"CIV", "PEN", "MISSING", "GOV", "BPC"} {
+		textFile := filepath.Join(temporaryDir, code+".txt")
+		if code != "MISSING" {
+			codeText := "CALIFORNIA " + code + " Code\n\nDIVISION 1. GENERAL\nSection 1. This code applies to all persons.\n"
+			os.WriteFile(textFile, []byte(codeText), 0644)
+		}
+		records = append(records, &DownloadRecord{
+			Identifier: "ca-" + strings.ToLower(code),
+			SourceName: "california",
+			LocalPath:  textFile,
+		})
+	}
+
+	var progress []IngestProgress
+	ingester := NewBulkIngester(IngestConfig{
+		Workers:  3,
+		Progress: func(p IngestProgress) { progress = append(progress, p) },
+	}, lib)
+	report := ingester.ingestRecords(records)
+
+	if report.TotalAttempted != 5 || report.Succeeded != 4 || report.Failed != 1 {
+		t.Errorf("expected 5 attempted, 4 succeeded, 1 failed; got %d, %d, %d",
+			report.TotalAttempted, report.Succeeded, report.Failed)
+	}
+	for i, entry := range report.Entries {
+		if entry.Identifier != records[i].Identifier {
+			t.Errorf("entry %d: got %s, want %s", i, entry.Identifier, records[i].Identifier)
+		}
+		if wantFailed := i == 2; (entry.Status == "failed") != wantFailed {
+			t.Errorf("%s: unexpected status %s (%s)", entry.Identifier, entry.Status, entry.Error)
+		}
+	}
+	if len(progress) != 5 || progress[4].Completed != 5 || progress[4].Total != 5 {
+		t.Errorf("unexpected progress reports: %+v", progress)
+	}
+	if docs := lib.ListDocuments(); len(docs) != 4 {
+		t.Errorf("expected 4 library documents, got %d", len(docs))
+	}
+}
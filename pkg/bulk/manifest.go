@@ -1,18 +1,28 @@
 package bulk
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 )
 
 // DownloadManifest tracks which datasets have been downloaded for resumability.
+// Methods are safe for concurrent use by download workers.
 type DownloadManifest struct {
 	Version   string                     `json:"version"`
 	UpdatedAt time.Time                  `json:"updated_at"`
 	Downloads map[string]*DownloadRecord `json:"downloads"`
+
+	// LastSyncAt is when `bulk sync` last checked the downloads for updates.
+	LastSyncAt time.Time `json:"last_sync_at,omitzero"`
+
+	mu sync.Mutex
 }
 
 // DownloadRecord tracks a single completed download.
@@ -22,6 +32,10 @@ type DownloadRecord struct {
 	URL          string    `json:"url"`
 	LocalPath    string    `json:"local_path"`
 	SizeBytes    int64     `json:"size_bytes"`
+	SHA256       string    `json:"sha256,omitempty"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	ReleasePoint string    `json:"release_point,omitempty"`
 	DownloadedAt time.Time `json:"downloaded_at"`
 }
 
@@ -60,6 +74,9 @@ func LoadManifest(manifestPath string) (*DownloadManifest, error) {
 
 // SaveManifest writes the manifest to disk.
 func (manifest *DownloadManifest) SaveManifest(manifestPath string) error {
+	manifest.mu.Lock()
+	defer manifest.mu.Unlock()
+
 	manifest.UpdatedAt = time.Now()
 
 	if err := os.MkdirAll(filepath.Dir(manifestPath), 0755); err != nil {
@@ -80,22 +97,31 @@ func (manifest *DownloadManifest) SaveManifest(manifestPath string) error {
 
 // RecordDownload adds a completed download to the manifest.
 func (manifest *DownloadManifest) RecordDownload(record *DownloadRecord) {
+	manifest.mu.Lock()
+	defer manifest.mu.Unlock()
 	manifest.Downloads[record.Identifier] = record
 }
 
 // IsDownloaded checks if a dataset has already been downloaded.
 func (manifest *DownloadManifest) IsDownloaded(identifier string) bool {
+	manifest.mu.Lock()
+	defer manifest.mu.Unlock()
 	_, exists := manifest.Downloads[identifier]
 	return exists
 }
 
 // GetRecord returns the download record for an identifier, or nil.
 func (manifest *DownloadManifest) GetRecord(identifier string) *DownloadRecord {
+	manifest.mu.Lock()
+	defer manifest.mu.Unlock()
 	return manifest.Downloads[identifier]
 }
 
 // CountBySource returns the number of downloads for a given source name.
 func (manifest *DownloadManifest) CountBySource(sourceName string) int {
+	manifest.mu.Lock()
+	defer manifest.mu.Unlock()
+
 	count := 0
 	for _, record := range manifest.Downloads {
 		if record.SourceName == sourceName {
@@ -107,6 +133,9 @@ func (manifest *DownloadManifest) CountBySource(sourceName string) int {
 
 // TotalSizeBySource returns total bytes downloaded for a given source.
 func (manifest *DownloadManifest) TotalSizeBySource(sourceName string) int64 {
+	manifest.mu.Lock()
+	defer manifest.mu.Unlock()
+
 	var totalBytes int64
 	for _, record := range manifest.Downloads {
 		if record.SourceName == sourceName {
@@ -115,3 +144,49 @@ func (manifest *DownloadManifest) TotalSizeBySource(sourceName string) int64 {
 	}
 	return totalBytes
 }
+
+// FilterSource returns a manifest holding only the downloads for a given
+// source, or the manifest itself when sourceName is empty.
+func (manifest *DownloadManifest) FilterSource(sourceName string) *DownloadManifest {
+	if sourceName == "" {
+		return manifest
+	}
+	manifest.mu.Lock()
+	defer manifest.mu.Unlock()
+
+	filtered := &DownloadManifest{
+		Version:    manifest.Version,
+		UpdatedAt:  manifest.UpdatedAt,
+		Downloads:  make(map[string]*DownloadRecord),
+		LastSyncAt: manifest.LastSyncAt,
+	}
+	for identifier, record := range manifest.Downloads {
+		if record.SourceName == sourceName {
+			filtered.Downloads[identifier] = record
+		}
+	}
+	return filtered
+}
+
+// FileSHA256 returns the hex-encoded SHA-256 digest of a regular file.
+func FileSHA256(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+	if !fileInfo.Mode().IsRegular() {
+		return "", fmt.Errorf("%s is not a regular file", filePath)
+	}
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
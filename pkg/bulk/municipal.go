@@ -0,0 +1,266 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/crawler"
+)
+
+// MunicipalSource crawls municipal codes from the two platforms most US
+// cities publish on: the Municode Library (library.municode.com) and the
+// American Legal Publishing code library (codelibrary.amlegal.com).
+//
+// Neither platform offers bulk downloads, so the source is experimental: it
+// starts at a code's landing page, follows links that stay within the code,
+// and flattens every page to text. Pages are fetched with the crawler's
+// fetcher, which honors each platform's robots.txt rules and Crawl-delay.
+// A crawl that misses pages, and codes a platform renders only in the
+// browser, fail the download rather than writing an incomplete file.
+type MunicipalSource struct {
+	config   DownloadConfig
+	fetcher  *crawler.ContentFetcher
+	baseURLs map[string]string
+	maxPages int
+}
+
+// NewMunicipalSource creates a MunicipalSource with the given config.
+func NewMunicipalSource(config DownloadConfig) *MunicipalSource {
+	return &MunicipalSource{
+		config: config,
+		fetcher: crawler.NewContentFetcher(crawler.CrawlConfig{
+			RateLimit:     config.RateLimit,
+			Timeout:       config.Timeout,
+			CacheDir:      config.HTTPCacheDir,
+			UserAgent:     config.UserAgent,
+			RespectRobots: true,
+		}),
+		baseURLs: map[string]string{
+			"municode": municodeBaseURL,
+			"amlegal":  americanLegalBaseURL,
+		},
+		maxPages: municipalMaxPages,
+	}
+}
+
+func (source *MunicipalSource) Name() string { return "municipal" }
+
+func (source *MunicipalSource) Description() string {
+	return "Municipal codes from Municode and American Legal Publishing (experimental crawler)"
+}
+
+// ListDatasets returns the supported municipal codes.
+func (source *MunicipalSource) ListDatasets() ([]Dataset, error) {
+	var datasets []Dataset
+
+	for _, codeEntry := range municipalCodeEntries {
+		datasets = append(datasets, Dataset{
+			SourceName:   "municipal",
+			Identifier:   municipalIdentifier(codeEntry.State, codeEntry.City),
+			DisplayName:  fmt.Sprintf("%s (%s)", codeEntry.Name, codeEntry.Platform),
+			URL:          source.baseURLs[codeEntry.Platform] + codeEntry.Path,
+			Format:       "html",
+			Jurisdiction: municipalJurisdiction(municipalIdentifier(codeEntry.State, codeEntry.City)),
+		})
+	}
+
+	return datasets, nil
+}
+
+// DownloadDataset crawls a municipal code from its landing page and stores
+// the text of every page as one plain-text file.
+func (source *MunicipalSource) DownloadDataset(ctx context.Context, dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
+	sourceDir := downloader.SourceDirectory("municipal")
+	localPath := filepath.Join(sourceDir, dataset.Identifier+".txt")
+
+	if existingInfo, err := os.Stat(localPath); err == nil && existingInfo.Size() > 0 {
+		return &DownloadResult{
+			Dataset:      dataset,
+			LocalPath:    localPath,
+			BytesWritten: existingInfo.Size(),
+			Skipped:      true,
+			DownloadedAt: time.Now(),
+		}, nil
+	}
+
+	os.MkdirAll(sourceDir, 0755)
+
+	codeEntry, ok := municipalCodeEntry(dataset.Identifier)
+	if !ok {
+		return nil, fmt.Errorf("unknown municipal code: %s", dataset.Identifier)
+	}
+	startURL, err := url.Parse(dataset.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid code URL: %w", err)
+	}
+	scope := municipalCrawlScope(codeEntry.Platform, startURL.Path)
+
+	var allText strings.Builder
+	allText.WriteString(strings.ToUpper(codeEntry.Name) + "\n\n")
+
+	// visited holds every page queued so far; a code that links to more
+	// than maxPages pages fails rather than being cut short.
+	pagesWithText := 0
+	queue := []*url.URL{startURL}
+	visited := map[string]bool{startURL.String(): true}
+	for len(queue) > 0 {
+		pageURL := queue[0]
+		queue = queue[1:]
+
+		content, err := source.fetcher.FetchWithContext(ctx, pageURL.String())
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// Nothing is written or recorded, so the next download crawls
+			// the code again instead of skipping an incomplete file.
+			return nil, fmt.Errorf("failed to crawl %s: %w", codeEntry.Name, err)
+		}
+		pageBody := content.RawHTML
+
+		for _, linkURL := range extractMunicipalLinks(pageBody, pageURL, scope) {
+			key := linkURL.String()
+			if visited[key] {
+				continue
+			}
+			if len(visited) >= source.maxPages {
+				return nil, fmt.Errorf("failed to crawl %s: more than %d pages", codeEntry.Name, source.maxPages)
+			}
+			visited[key] = true
+			queue = append(queue, linkURL)
+		}
+
+		if pageText := extractHTMLText(pageBody); pageText != "" {
+			allText.WriteString(pageText)
+			allText.WriteString("\n\n")
+			pagesWithText++
+		}
+	}
+
+	if pagesWithText == 0 {
+		return nil, fmt.Errorf("no code text found at %s (the platform may render this code in the browser only)", dataset.URL)
+	}
+
+	codeText := allText.String()
+	if err := os.WriteFile(localPath, []byte(codeText), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", localPath, err)
+	}
+
+	bytesWritten := int64(len(codeText))
+
+	downloader.RecordDownload(&DownloadRecord{
+		Identifier:   dataset.Identifier,
+		SourceName:   "municipal",
+		URL:          dataset.URL,
+		LocalPath:    localPath,
+		SizeBytes:    bytesWritten,
+		DownloadedAt: time.Now(),
+	})
+	downloader.SaveManifest()
+
+	return &DownloadResult{
+		Dataset:      dataset,
+		LocalPath:    localPath,
+		BytesWritten: bytesWritten,
+		Skipped:      false,
+		DownloadedAt: time.Now(),
+	}, nil
+}
+
+var reMunicipalHref = regexp.MustCompile(`(?i)href\s*=\s*["']([^"'#]+)`)
+
+// extractMunicipalLinks returns the links of a page that stay on its host
+// and under the code's path scope, in page order.
+func extractMunicipalLinks(pageHTML []byte, pageURL *url.URL, scope string) []*url.URL {
+	var links []*url.URL
+	seen := make(map[string]bool)
+
+	for _, match := range reMunicipalHref.FindAllSubmatch(pageHTML, -1) {
+		href := strings.ReplaceAll(string(match[1]), "&amp;", "&")
+		linkURL, err := pageURL.Parse(href)
+		if err != nil || linkURL.Host != pageURL.Host || !strings.HasPrefix(linkURL.Path, scope) {
+			continue
+		}
+		linkURL.Fragment = ""
+		if key := linkURL.String(); !seen[key] {
+			seen[key] = true
+			links = append(links, linkURL)
+		}
+	}
+
+	return links
+}
+
+// municipalCrawlScope returns the path prefix that pages of a code share.
+// Municode addresses the nodes of a code with a query parameter on the
+// code's own path; American Legal gives each node a path below the code's
+// directory.
+func municipalCrawlScope(platform, codePath string) string {
+	if platform == "amlegal" {
+		return path.Dir(codePath) + "/"
+	}
+	return codePath
+}
+
+// municipalIdentifier returns the dataset identifier of a municipal code,
+// e.g. "muni-wa-seattle".
+func municipalIdentifier(state, city string) string {
+	return fmt.Sprintf("muni-%s-%s", strings.ToLower(state), city)
+}
+
+// municipalJurisdiction returns the jurisdiction code of a municipal code
+// identifier: "muni-wa-seattle" → "US-WA-SEATTLE".
+func municipalJurisdiction(identifier string) string {
+	parts := strings.SplitN(strings.TrimPrefix(identifier, "muni-"), "-", 2)
+	if len(parts) != 2 {
+		return "US"
+	}
+	return "US-" + strings.ToUpper(parts[0]) + "-" + strings.ToUpper(parts[1])
+}
+
+// municipalCodeEntry looks up a municipal code by dataset identifier.
+func municipalCodeEntry(identifier string) (municipalCode, bool) {
+	for _, codeEntry := range municipalCodeEntries {
+		if municipalIdentifier(codeEntry.State, codeEntry.City) == identifier {
+			return codeEntry, true
+		}
+	}
+	return municipalCode{}, false
+}
+
+const (
+	municodeBaseURL      = "https://library.municode.com"
+	americanLegalBaseURL = "https://codelibrary.amlegal.com"
+
+	// municipalMaxPages bounds the crawl of a single code.
+	municipalMaxPages = 2000
+)
+
+// municipalCode is a municipal code on one of the supported platforms.
+type municipalCode struct {
+	Platform string // "municode" or "amlegal"
+	State    string
+	City     string
+	Name     string
+	Path     string // landing page path on the platform
+}
+
+// municipalCodeEntries contains the codes of large US cities.
+var municipalCodeEntries = []municipalCode{
+	{"municode", "WA", "seattle", "Seattle Municipal Code", "/wa/seattle/codes/municipal_code"},
+	{"municode", "TX", "austin", "Austin City Code", "/tx/austin/codes/code_of_ordinances"},
+	{"municode", "GA", "atlanta", "Atlanta Code of Ordinances", "/ga/atlanta/codes/code_of_ordinances"},
+	{"municode", "FL", "miami", "Miami Code of Ordinances", "/fl/miami/codes/code_of_ordinances"},
+	{"municode", "CO", "denver", "Denver Revised Municipal Code", "/co/denver/codes/code_of_ordinances"},
+	{"amlegal", "NY", "newyorkcity", "New York City Administrative Code", "/codes/newyorkcity/latest/overview"},
+	{"amlegal", "CA", "sanfrancisco", "San Francisco Municipal Codes", "/codes/san_francisco/latest/overview"},
+	{"amlegal", "IL", "chicago", "Chicago Municipal Code", "/codes/chicago/latest/overview"},
+	{"amlegal", "CA", "losangeles", "Los Angeles Municipal Code", "/codes/los_angeles/latest/overview"},
+}
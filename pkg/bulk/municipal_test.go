@@ -0,0 +1,257 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/fetch"
+)
+
+func TestMunicipalSourceListDatasets(t *testing.T) {
+	source := NewMunicipalSource(DefaultDownloadConfig())
+
+	if source.Name() != "municipal" {
+		t.Errorf("expected name 'municipal', got %q", source.Name())
+	}
+
+	datasets, err := source.ListDatasets()
+	if err != nil {
+		t.Fatalf("ListDatasets failed: %v", err)
+	}
+	platforms := make(map[string]bool)
+	for _, dataset := range datasets {
+		if !strings.HasPrefix(dataset.Identifier, "muni-") {
+			t.Errorf("expected muni- identifier, got %q", dataset.Identifier)
+		}
+		switch {
+		case strings.HasPrefix(dataset.URL, municodeBaseURL):
+			platforms["municode"] = true
+		case strings.HasPrefix(dataset.URL, americanLegalBaseURL):
+			platforms["amlegal"] = true
+		}
+		if dataset.Identifier == "muni-wa-seattle" && dataset.Jurisdiction != "US-WA-SEATTLE" {
+			t.Errorf("expected jurisdiction 'US-WA-SEATTLE', got %q", dataset.Jurisdiction)
+		}
+	}
+	if !platforms["municode"] || !platforms["amlegal"] {
+		t.Errorf("expected datasets on both platforms, got %v", platforms)
+	}
+}
+
+func TestMunicipalSourceDownloadDataset(t *testing.T) {
+	pages := map[string]string{
+		"/wa/seattle/codes/municipal_code": `<html><body>
+			<a href="/wa/seattle/codes/municipal_code?nodeId=TIT8">Title 8</a>
+			<a href="/wa/seattle/codes/zoning">Zoning</a>
+			<a href="https://example.com/wa/seattle/codes/municipal_code?nodeId=OFFSITE">Elsewhere</a>
+		</body></html>`,
+	}
+	nodes := map[string]string{
+		"TIT8": `<html><body><h2>Title 8 - Licenses</h2>
+			<a href="?nodeId=TIT8CH8.04">Chapter 8.04</a>
+			<a href="/wa/seattle/codes/municipal_code?nodeId=TIT8#top">Top</a>
+		</body></html>`,
+		"TIT8CH8.04": `<html><body><h3>Sec. 8.04.020 - Definitions.</h3>
+			<p>As used in this chapter, &quot;license&quot; has the meaning in City Code &sect; 8.04.010.</p>
+		</body></html>`,
+	}
+	requested := make(map[string]int)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		requested[request.URL.RequestURI()]++
+		if node := request.URL.Query().Get("nodeId"); node != "" {
+			if body, ok := nodes[node]; ok {
+				responseWriter.Write([]byte(body))
+				return
+			}
+		} else if body, ok := pages[request.URL.Path]; ok {
+			responseWriter.Write([]byte(body))
+			return
+		}
+		responseWriter.WriteHeader(http.StatusNotFound)
+	}))
+	defer testServer.Close()
+
+	config := DownloadConfig{
+		DownloadDirectory: t.TempDir(),
+		RateLimit:         1 * time.Millisecond,
+		Timeout:           10 * time.Second,
+		UserAgent:         "regula-test/1.0",
+		HTTPClient:        testServer.Client(),
+	}
+	downloader, err := NewDownloader(config)
+	if err != nil {
+		t.Fatalf("NewDownloader failed: %v", err)
+	}
+
+	source := NewMunicipalSource(config)
+	source.baseURLs["municode"] = testServer.URL
+	datasets, _ := source.ListDatasets()
+
+	var codeDataset Dataset
+	for _, dataset := range datasets {
+		if dataset.Identifier == "muni-wa-seattle" {
+			codeDataset = dataset
+		}
+	}
+
+	result, err := source.DownloadDataset(context.Background(), codeDataset, downloader)
+	if err != nil {
+		t.Fatalf("DownloadDataset failed: %v", err)
+	}
+
+	content, err := os.ReadFile(result.LocalPath)
+	if err != nil {
+		t.Fatalf("failed to read downloaded file: %v", err)
+	}
+	text := string(content)
+	if !strings.HasPrefix(text, "SEATTLE MUNICIPAL CODE") {
+		t.Errorf("expected code heading, got:\n%s", text)
+	}
+	if !strings.Contains(text, "Sec. 8.04.020 - Definitions.") || strings.Contains(text, "<h3>") {
+		t.Errorf("expected stripped chapter text, got:\n%s", text)
+	}
+	if requested["/wa/seattle/codes/zoning"] != 0 {
+		t.Error("crawled a page outside the code")
+	}
+	if requested["/wa/seattle/codes/municipal_code?nodeId=TIT8"] != 1 {
+		t.Errorf("expected Title 8 to be fetched once, got %d", requested["/wa/seattle/codes/municipal_code?nodeId=TIT8"])
+	}
+
+	record := downloader.Manifest().Downloads["muni-wa-seattle"]
+	if record == nil || record.SourceName != "municipal" {
+		t.Errorf("expected a manifest record for muni-wa-seattle, got %+v", record)
+	}
+}
+
+func TestMunicipalSourceDownloadDatasetWithoutText(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		responseWriter.Write([]byte(`<html><head><script>renderApp()</script></head><body></body></html>`))
+	}))
+	defer testServer.Close()
+
+	config := DownloadConfig{
+		DownloadDirectory: t.TempDir(),
+		RateLimit:         1 * time.Millisecond,
+		Timeout:           10 * time.Second,
+		HTTPClient:        testServer.Client(),
+	}
+	downloader, err := NewDownloader(config)
+	if err != nil {
+		t.Fatalf("NewDownloader failed: %v", err)
+	}
+
+	source := NewMunicipalSource(config)
+	source.baseURLs["amlegal"] = testServer.URL
+	datasets, _ := source.ListDatasets()
+	for _, dataset := range datasets {
+		if dataset.Identifier != "muni-il-chicago" {
+			continue
+		}
+		if _, err := source.DownloadDataset(context.Background(), dataset, downloader); err == nil || !strings.Contains(err.Error(), "no code text") {
+			t.Errorf("expected a no-text error, got %v", err)
+		}
+	}
+}
+
+func TestMunicipalSourceDownloadDatasetIncomplete(t *testing.T) {
+	landingPage := `<html><body><h1>Title 1</h1>
+		<a href="/wa/seattle/codes/municipal_code?nodeId=TIT2">Title 2</a>
+	</body></html>`
+	tests := map[string]struct {
+		robots  string
+		missing bool
+		cancel  bool
+		wantErr error
+	}{
+		"missing page":         {missing: true},
+		"disallowed by robots": {robots: "User-agent: *\nDisallow: /wa/seattle/codes/municipal_code?nodeId=\n", wantErr: fetch.ErrDisallowedByRobots},
+		"cancelled":            {cancel: true, wantErr: context.Canceled},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			testServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+				switch {
+				case request.URL.Path == "/robots.txt" && test.robots != "":
+					responseWriter.Write([]byte(test.robots))
+				case request.URL.Path == "/robots.txt", test.missing && request.URL.Query().Get("nodeId") != "":
+					responseWriter.WriteHeader(http.StatusNotFound)
+				default:
+					responseWriter.Write([]byte(landingPage))
+				}
+			}))
+			defer testServer.Close()
+
+			config := DownloadConfig{
+				DownloadDirectory: t.TempDir(),
+				RateLimit:         1 * time.Millisecond,
+				Timeout:           10 * time.Second,
+			}
+			downloader, err := NewDownloader(config)
+			if err != nil {
+				t.Fatalf("NewDownloader failed: %v", err)
+			}
+			source := NewMunicipalSource(config)
+			source.baseURLs["municode"] = testServer.URL
+			datasets, _ := source.ListDatasets()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if test.cancel {
+				cancel()
+			}
+			for _, dataset := range datasets {
+				if dataset.Identifier != "muni-wa-seattle" {
+					continue
+				}
+				_, err := source.DownloadDataset(ctx, dataset, downloader)
+				if err == nil || (test.wantErr != nil && !errors.Is(err, test.wantErr)) {
+					t.Errorf("DownloadDataset error = %v, want %v", err, test.wantErr)
+				}
+			}
+			if len(downloader.Manifest().Downloads) != 0 {
+				t.Errorf("recorded an incomplete crawl: %v", downloader.Manifest().Downloads)
+			}
+		})
+	}
+}
+
+func TestExtractMunicipalLinks(t *testing.T) {
+	pageURL, _ := url.Parse("https://codelibrary.amlegal.com/codes/chicago/latest/overview")
+	pageHTML := []byte(`
+		<a href="/codes/chicago/latest/chicago_il/0-0-0-1">Title 1</a>
+		<a href='../latest/chicago_il/0-0-0-2#section'>Title 2</a>
+		<a href="/codes/chicago/latest/chicago_il/0-0-0-1">Title 1 again</a>
+		<a href="/codes/evanston/latest/overview">Evanston</a>
+		<a href="https://example.com/codes/chicago/latest/x">Offsite</a>`)
+
+	links := extractMunicipalLinks(pageHTML, pageURL, municipalCrawlScope("amlegal", pageURL.Path))
+	var got []string
+	for _, link := range links {
+		got = append(got, link.Path)
+	}
+	want := []string{"/codes/chicago/latest/chicago_il/0-0-0-1", "/codes/chicago/latest/chicago_il/0-0-0-2"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("links = %v, want %v", got, want)
+	}
+}
+
+func TestMunicipalJurisdiction(t *testing.T) {
+	tests := map[string]string{
+		"muni-wa-seattle":     "US-WA-SEATTLE",
+		"muni-ny-newyorkcity": "US-NY-NEWYORKCITY",
+		"seattle":             "US",
+	}
+	for identifier, want := range tests {
+		if got := municipalJurisdiction(identifier); got != want {
+			t.Errorf("municipalJurisdiction(%q) = %q, want %q", identifier, got, want)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// NewYorkAPIHost is the host of the NY Senate Open Legislation API.
+const NewYorkAPIHost = "legislation.nysenate.gov"
+
+// NewYorkSource downloads New York consolidated laws from the NY Senate
+// Open Legislation API (legislation.nysenate.gov). The API requires a free
+// key, passed as the "key" query parameter. The source does not add it
+// itself: it is injected by the transport, as a per-domain query parameter
+// configured with pkg/httpconfig, so request URLs (and vcr recordings of
+// them) never carry it.
+type NewYorkSource struct {
+	config     DownloadConfig
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewNewYorkSource creates a NewYorkSource with the given config.
+func NewNewYorkSource(config DownloadConfig) *NewYorkSource {
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: config.Timeout}
+	}
+	return &NewYorkSource{config: config, httpClient: httpClient, baseURL: newYorkBaseURL}
+}
+
+func (source *NewYorkSource) Name() string { return "newyork" }
+
+func (source *NewYorkSource) Description() string {
+	return "New York consolidated laws from the NY Senate Open Legislation API"
+}
+
+// ListDatasets returns the supported New York consolidated laws.
+func (source *NewYorkSource) ListDatasets() ([]Dataset, error) {
+	var datasets []Dataset
+
+	for _, lawEntry := range newYorkLawEntries {
+		datasets = append(datasets, Dataset{
+			SourceName:   "newyork",
+			Identifier:   fmt.Sprintf("ny-%s", strings.ToLower(lawEntry.Abbreviation)),
+			DisplayName:  fmt.Sprintf("%s (%s)", lawEntry.FullName, lawEntry.Abbreviation),
+			URL:          fmt.Sprintf("%s/%s?full=true", source.baseURL, lawEntry.Abbreviation),
+			Format:       "json",
+			Jurisdiction: "US-NY",
+		})
+	}
+
+	return datasets, nil
+}
+
+// DownloadDataset fetches the full law tree from the Open Legislation API and
+// flattens it into plain text, one section per block.
+func (source *NewYorkSource) DownloadDataset(ctx context.Context, dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
+	sourceDir := downloader.SourceDirectory("newyork")
+	lawID := strings.ToUpper(strings.TrimPrefix(dataset.Identifier, "ny-"))
+	localPath := filepath.Join(sourceDir, lawID+".txt")
+
+	if existingInfo, err := os.Stat(localPath); err == nil && existingInfo.Size() > 0 {
+		return &DownloadResult{
+			Dataset:      dataset,
+			LocalPath:    localPath,
+			BytesWritten: existingInfo.Size(),
+			Skipped:      true,
+			DownloadedAt: time.Now(),
+		}, nil
+	}
+
+	os.MkdirAll(sourceDir, 0755)
+
+	lawURL := fmt.Sprintf("%s/%s?full=true", source.baseURL, lawID)
+	parsedURL, err := url.Parse(lawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid law URL: %w", err)
+	}
+	downloader.waitForDomain(ctx, parsedURL.Host)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, lawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	request.Header.Set("User-Agent", downloader.config.UserAgent)
+
+	response, err := source.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", lawID, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("HTTP %d fetching %s: New York Open Legislation API key required (set NYSENATE_API_KEY or --ny-api-key)", response.StatusCode, lawID)
+	}
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("HTTP %d fetching %s", response.StatusCode, lawID)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	lawText, err := flattenNewYorkLaw(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", lawID, err)
+	}
+
+	if err := os.WriteFile(localPath, []byte(lawText), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", localPath, err)
+	}
+
+	bytesWritten := int64(len(lawText))
+
+	downloader.RecordDownload(&DownloadRecord{
+		Identifier:   dataset.Identifier,
+		SourceName:   "newyork",
+		URL:          dataset.URL,
+		LocalPath:    localPath,
+		SizeBytes:    bytesWritten,
+		DownloadedAt: time.Now(),
+	})
+	downloader.SaveManifest()
+
+	return &DownloadResult{
+		Dataset:      dataset,
+		LocalPath:    localPath,
+		BytesWritten: bytesWritten,
+		Skipped:      false,
+		DownloadedAt: time.Now(),
+	}, nil
+}
+
+// nyLawResponse is the Open Legislation API envelope for a law tree.
+type nyLawResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Result  struct {
+		Info struct {
+			LawID string `json:"lawId"`
+			Name  string `json:"name"`
+		} `json:"info"`
+		Documents nyLawDocument `json:"documents"`
+	} `json:"result"`
+}
+
+// nyLawDocument is a node in the Open Legislation law tree.
+type nyLawDocument struct {
+	DocType    string `json:"docType"`
+	DocLevelID string `json:"docLevelId"`
+	Title      string `json:"title"`
+	Text       string `json:"text"`
+	Documents  struct {
+		Items []nyLawDocument `json:"items"`
+	} `json:"documents"`
+}
+
+// flattenNewYorkLaw converts an Open Legislation law tree into plain text
+// with ARTICLE headings and "Section N. Title" blocks.
+func flattenNewYorkLaw(body []byte) (string, error) {
+	var lawResponse nyLawResponse
+	if err := json.Unmarshal(body, &lawResponse); err != nil {
+		return "", err
+	}
+	if !lawResponse.Success {
+		return "", fmt.Errorf("API error: %s", lawResponse.Message)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("NEW YORK %s\n\n", strings.ToUpper(lawResponse.Result.Info.Name)))
+	writeNewYorkDocument(&builder, lawResponse.Result.Documents)
+
+	return strings.TrimSpace(builder.String()) + "\n", nil
+}
+
+func writeNewYorkDocument(builder *strings.Builder, document nyLawDocument) {
+	switch document.DocType {
+	case "ARTICLE", "TITLE", "PART", "SUBPART":
+		builder.WriteString(fmt.Sprintf("%s %s\n%s\n\n", document.DocType, document.DocLevelID, document.Title))
+	case "SECTION":
+		builder.WriteString(fmt.Sprintf("Section %s. %s\n", document.DocLevelID, document.Title))
+		if text := strings.TrimSpace(document.Text); text != "" {
+			builder.WriteString(text)
+			builder.WriteString("\n")
+		}
+		builder.WriteString("\n")
+	}
+
+	for _, child := range document.Documents.Items {
+		writeNewYorkDocument(builder, child)
+	}
+}
+
+const newYorkBaseURL = "https://legislation.nysenate.gov/api/3/laws"
+
+// newYorkLawEntries contains commonly used New York consolidated laws.
+var newYorkLawEntries = []struct {
+	Abbreviation string
+	FullName     string
+}{
+	{"ABC", "Alcoholic Beverage Control Law"},
+	{"BSC", "Business Corporation Law"},
+	{"CVP", "Civil Practice Law and Rules"},
+	{"CVR", "Civil Rights Law"},
+	{"CPL", "Criminal Procedure Law"},
+	{"DOM", "Domestic Relations Law"},
+	{"EDN", "Education Law"},
+	{"ELN", "Election Law"},
+	{"EXC", "Executive Law"},
+	{"GBS", "General Business Law"},
+	{"GMU", "General Municipal Law"},
+	{"INS", "Insurance Law"},
+	{"LAB", "Labor Law"},
+	{"PEN", "Penal Law"},
+	{"PBH", "Public Health Law"},
+	{"RPP", "Real Property Law"},
+	{"SOS", "Social Services Law"},
+	{"STT", "State Technology Law"},
+	{"TAX", "Tax Law"},
+	{"VAT", "Vehicle and Traffic Law"},
+}
@@ -0,0 +1,169 @@
+package bulk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/httpconfig"
+)
+
+const newYorkLawJSON = `{
+  "success": true,
+  "message": "",
+  "result": {
+    "info": {"lawId": "GBS", "name": "General Business"},
+    "documents": {
+      "docType": "CHAPTER", "docLevelId": "20", "title": "General Business",
+      "documents": {"items": [
+        {"docType": "ARTICLE", "docLevelId": "39-F", "title": "Notification of Security Breach",
+         "documents": {"items": [
+           {"docType": "SECTION", "docLevelId": "899-AA", "title": "Notification; person without valid authorization",
+            "text": "  1. As used in this section, the following terms shall have the following meanings.",
+            "documents": {"items": []}}
+         ]}}
+      ]}
+    }
+  }
+}`
+
+func TestNewYorkSourceListDatasets(t *testing.T) {
+	source := NewNewYorkSource(DefaultDownloadConfig())
+
+	if source.Name() != "newyork" {
+		t.Errorf("expected name 'newyork', got %q", source.Name())
+	}
+
+	datasets, err := source.ListDatasets()
+	if err != nil {
+		t.Fatalf("ListDatasets failed: %v", err)
+	}
+	if len(datasets) != len(newYorkLawEntries) {
+		t.Fatalf("expected %d datasets, got %d", len(newYorkLawEntries), len(datasets))
+	}
+	for _, dataset := range datasets {
+		if dataset.Jurisdiction != "US-NY" {
+			t.Errorf("expected jurisdiction 'US-NY', got %q for %s", dataset.Jurisdiction, dataset.Identifier)
+		}
+		if !strings.HasPrefix(dataset.Identifier, "ny-") {
+			t.Errorf("expected identifier to start with 'ny-', got %q", dataset.Identifier)
+		}
+	}
+}
+
+func TestFlattenNewYorkLaw(t *testing.T) {
+	lawText, err := flattenNewYorkLaw([]byte(newYorkLawJSON))
+	if err != nil {
+		t.Fatalf("flattenNewYorkLaw failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"NEW YORK GENERAL BUSINESS",
+		"ARTICLE 39-F\nNotification of Security Breach",
+		"Section 899-AA. Notification; person without valid authorization",
+		"1. As used in this section",
+	} {
+		if !strings.Contains(lawText, expected) {
+			t.Errorf("expected flattened text to contain %q, got:\n%s", expected, lawText)
+		}
+	}
+
+	if _, err := flattenNewYorkLaw([]byte(`{"success": false, "message": "Invalid key"}`)); err == nil {
+		t.Error("expected error for unsuccessful API response")
+	}
+}
+
+func TestNewYorkSourceDownloadDataset(t *testing.T) {
+	var requestedKey string
+	testServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		requestedKey = request.URL.Query().Get("key")
+		responseWriter.Write([]byte(newYorkLawJSON))
+	}))
+	defer testServer.Close()
+
+	// The key is injected by the transport, not written into the URL.
+	serverURL, _ := url.Parse(testServer.URL)
+	httpConfig := &httpconfig.Config{}
+	httpConfig.AddDomainQuery(serverURL.Hostname(), "key", "test-key")
+	transport, err := httpConfig.Transport(nil)
+	if err != nil {
+		t.Fatalf("Transport failed: %v", err)
+	}
+
+	config := DownloadConfig{
+		DownloadDirectory: t.TempDir(),
+		RateLimit:         1 * time.Millisecond,
+		Timeout:           10 * time.Second,
+		UserAgent:         "regula-test/1.0",
+		HTTPClient:        &http.Client{Transport: transport},
+	}
+	downloader, err := NewDownloader(config)
+	if err != nil {
+		t.Fatalf("NewDownloader failed: %v", err)
+	}
+
+	source := NewNewYorkSource(config)
+	source.baseURL = testServer.URL
+
+	dataset := Dataset{SourceName: "newyork", Identifier: "ny-gbs", URL: testServer.URL + "/GBS"}
+	result, err := source.DownloadDataset(context.Background(), dataset, downloader)
+	if err != nil {
+		t.Fatalf("DownloadDataset failed: %v", err)
+	}
+	if requestedKey != "test-key" {
+		t.Errorf("expected API key to be sent, got %q", requestedKey)
+	}
+
+	content, err := os.ReadFile(result.LocalPath)
+	if err != nil {
+		t.Fatalf("failed to read downloaded file: %v", err)
+	}
+	if !strings.Contains(string(content), "Section 899-AA.") {
+		t.Errorf("expected section heading in downloaded text, got:\n%s", content)
+	}
+	if !downloader.Manifest().IsDownloaded("ny-gbs") {
+		t.Error("expected download to be recorded in manifest")
+	}
+
+	// Second download is skipped.
+	second, err := source.DownloadDataset(context.Background(), dataset, downloader)
+	if err != nil {
+		t.Fatalf("second DownloadDataset failed: %v", err)
+	}
+	if !second.Skipped {
+		t.Error("expected second download to be skipped")
+	}
+}
+
+func TestNewYorkSourceRequiresAPIKey(t *testing.T) {
+	var requestedURL string
+	testServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		requestedURL = request.URL.String()
+		responseWriter.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer testServer.Close()
+
+	config := DefaultDownloadConfig()
+	config.DownloadDirectory = t.TempDir()
+	config.RateLimit = time.Millisecond
+	config.HTTPClient = testServer.Client()
+	downloader, err := NewDownloader(config)
+	if err != nil {
+		t.Fatalf("NewDownloader failed: %v", err)
+	}
+
+	source := NewNewYorkSource(config)
+	source.baseURL = testServer.URL
+	_, err = source.DownloadDataset(context.Background(), Dataset{SourceName: "newyork", Identifier: "ny-gbs"}, downloader)
+	if err == nil || !strings.Contains(err.Error(), "API key") {
+		t.Errorf("expected API key error, got %v", err)
+	}
+	if strings.Contains(requestedURL, "key=") {
+		t.Errorf("source should not add the key itself, requested %s", requestedURL)
+	}
+}
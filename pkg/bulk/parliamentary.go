@@ -1,9 +1,12 @@
 package bulk
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"time"
+
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // ParliamentarySource downloads Congressional rules documents from official sources.
@@ -42,7 +45,7 @@ func (source *ParliamentarySource) ListDatasets() ([]Dataset, error) {
 }
 
 // DownloadDataset downloads a parliamentary rules document.
-func (source *ParliamentarySource) DownloadDataset(dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
+func (source *ParliamentarySource) DownloadDataset(ctx context.Context, dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
 	sourceDir := downloader.SourceDirectory("parliamentary")
 
 	// Determine file extension from format
@@ -55,8 +58,8 @@ func (source *ParliamentarySource) DownloadDataset(dataset Dataset, downloader *
 
 	localPath := filepath.Join(sourceDir, dataset.Identifier+ext)
 
-	bytesWritten, skipped, err := downloader.DownloadFile(
-		dataset.URL, localPath, PrintDownloadProgress)
+	bytesWritten, skipped, err := downloader.DownloadFile(ctx,
+		dataset.URL, localPath, downloader.progressBar())
 	if err != nil {
 		return &DownloadResult{
 			Dataset: dataset,
@@ -66,7 +69,7 @@ func (source *ParliamentarySource) DownloadDataset(dataset Dataset, downloader *
 
 	// Record in manifest
 	if !skipped {
-		downloader.Manifest().RecordDownload(&DownloadRecord{
+		downloader.RecordDownload(&DownloadRecord{
 			Identifier:   dataset.Identifier,
 			SourceName:   "parliamentary",
 			URL:          dataset.URL,
@@ -75,7 +78,7 @@ func (source *ParliamentarySource) DownloadDataset(dataset Dataset, downloader *
 			DownloadedAt: time.Now(),
 		})
 		downloader.SaveManifest()
-		fmt.Println() // newline after progress bar
+		downloader.endProgressBar()
 	}
 
 	return &DownloadResult{
@@ -203,10 +206,7 @@ func FormatParliamentarySummary(summary *ParliamentarySummary) string {
 
 // truncateString truncates a string to the specified length with ellipsis.
 func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
+	return textutil.Truncate(s, maxLen)
 }
 
 // GetParliamentaryDocumentsByChamber returns documents filtered by chamber.
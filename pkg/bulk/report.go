@@ -5,26 +5,33 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/coolbeans/regula/pkg/textutil"
+	"github.com/coolbeans/regula/pkg/xlsx"
 )
 
-// PrintDownloadProgress is a ProgressCallback that prints a progress bar.
-func PrintDownloadProgress(bytesDownloaded int64, totalBytes int64) {
-	if totalBytes > 0 {
-		percentage := float64(bytesDownloaded) / float64(totalBytes) * 100
-		barLength := int(percentage / 2)
-		if barLength > 50 {
-			barLength = 50
+// ProgressBar returns a ProgressCallback that draws a progress bar on w,
+// redrawing it in place as the download advances.
+func ProgressBar(w io.Writer) ProgressCallback {
+	return func(bytesDownloaded int64, totalBytes int64) {
+		if totalBytes > 0 {
+			percentage := float64(bytesDownloaded) / float64(totalBytes) * 100
+			barLength := int(percentage / 2)
+			if barLength > 50 {
+				barLength = 50
+			}
+			fmt.Fprintf(w, "\r  [%-50s] %.1f%% (%s / %s)",
+				strings.Repeat("=", barLength)+strings.Repeat(" ", 50-barLength),
+				percentage,
+				FormatBytes(bytesDownloaded),
+				FormatBytes(totalBytes))
+		} else {
+			fmt.Fprintf(w, "\r  Downloaded: %s", FormatBytes(bytesDownloaded))
 		}
-		fmt.Printf("\r  [%-50s] %.1f%% (%s / %s)",
-			strings.Repeat("=", barLength)+strings.Repeat(" ", 50-barLength),
-			percentage,
-			FormatBytes(bytesDownloaded),
-			FormatBytes(totalBytes))
-	} else {
-		fmt.Printf("\r  Downloaded: %s", FormatBytes(bytesDownloaded))
 	}
 }
 
@@ -51,10 +58,7 @@ func FormatDatasetTable(datasets []Dataset) string {
 	builder.WriteString(strings.Repeat("─", 90) + "\n")
 
 	for _, dataset := range datasets {
-		displayName := dataset.DisplayName
-		if len(displayName) > 40 {
-			displayName = displayName[:37] + "..."
-		}
+		displayName := textutil.Truncate(dataset.DisplayName, 40)
 		builder.WriteString(fmt.Sprintf("%-30s %-12s %-8s %s\n",
 			dataset.Identifier, dataset.Jurisdiction, dataset.Format, displayName))
 	}
@@ -64,6 +68,18 @@ func FormatDatasetTable(datasets []Dataset) string {
 	return builder.String()
 }
 
+// FormatDatasetsJSON formats a list of datasets as indented JSON.
+func FormatDatasetsJSON(datasets []Dataset) string {
+	if datasets == nil {
+		datasets = []Dataset{}
+	}
+	data, err := json.MarshalIndent(datasets, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(data)
+}
+
 // FormatIngestReport formats an IngestReport for terminal output.
 func FormatIngestReport(report *IngestReport) string {
 	var builder strings.Builder
@@ -316,9 +332,7 @@ func FormatStatsTable(report *StatsReport) string {
 		if entry.DisplayName != "" {
 			titleLabel = entry.DisplayName
 		}
-		if len(titleLabel) > 28 {
-			titleLabel = titleLabel[:25] + "..."
-		}
+		titleLabel = textutil.Truncate(titleLabel, 28)
 
 		if entry.Status == "pending" || entry.Triples == 0 {
 			builder.WriteString(fmt.Sprintf("  %-28s %10s %10s %8s %8s %8s %8s %8s  %-8s\n",
@@ -403,6 +417,35 @@ func FormatStatsCSV(report *StatsReport) string {
 	return buffer.String()
 }
 
+// FormatStatsXLSX formats a StatsReport as an Excel workbook with a summary
+// sheet of totals and a sheet with one row per title.
+func FormatStatsXLSX(report *StatsReport) ([]byte, error) {
+	workbook := xlsx.NewWorkbook()
+
+	summary := workbook.AddSheet("Summary", "Metric", "Value")
+	summary.AddRow("Titles ingested", report.TitlesIngested)
+	summary.AddRow("Titles total", report.TitlesTotal)
+	summary.AddRow("Triples", report.TotalTriples)
+	summary.AddRow("Articles", report.TotalArticles)
+	summary.AddRow("Chapters", report.TotalChapters)
+	summary.AddRow("Definitions", report.TotalDefinitions)
+	summary.AddRow("References", report.TotalReferences)
+	summary.AddRow("Rights", report.TotalRights)
+	summary.AddRow("Obligations", report.TotalObligations)
+
+	titles := workbook.AddSheet("Titles",
+		"Identifier", "Document ID", "Display Name", "Source",
+		"Triples", "Articles", "Chapters", "Definitions",
+		"References", "Rights", "Obligations", "Status", "Ingested At")
+	for _, entry := range report.Entries {
+		titles.AddRow(entry.Identifier, entry.DocumentID, entry.DisplayName, entry.Source,
+			entry.Triples, entry.Articles, entry.Chapters, entry.Definitions,
+			entry.References, entry.Rights, entry.Obligations, entry.Status, entry.IngestedAt)
+	}
+
+	return workbook.Bytes()
+}
+
 // formatNumber returns a comma-separated number string (e.g., 25100 → "25,100").
 func formatNumber(value int) string {
 	if value == 0 {
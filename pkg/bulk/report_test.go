@@ -1,8 +1,11 @@
 package bulk
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/csv"
 	"encoding/json"
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -34,6 +37,22 @@ func TestFormatBytes(t *testing.T) {
 	}
 }
 
+func TestProgressBar(t *testing.T) {
+	var output bytes.Buffer
+	progress := ProgressBar(&output)
+
+	progress(512, 1024)
+	if !strings.Contains(output.String(), "50.0% (512 B / 1.0 KB)") {
+		t.Errorf("expected a half-full bar, got %q", output.String())
+	}
+
+	output.Reset()
+	progress(2048, 0)
+	if output.String() != "\r  Downloaded: 2.0 KB" {
+		t.Errorf("expected a byte count for an unknown size, got %q", output.String())
+	}
+}
+
 func TestFormatDatasetTable(t *testing.T) {
 	datasets := []Dataset{
 		{
@@ -601,6 +620,48 @@ func TestFormatStatsCSV(t *testing.T) {
 	}
 }
 
+func TestFormatStatsXLSX(t *testing.T) {
+	report := &StatsReport{
+		TitlesIngested: 1,
+		TitlesTotal:    2,
+		TotalTriples:   25100,
+		Entries: []StatsEntry{
+			{Identifier: "usc-title-42", DisplayName: "Title 42", Source: "uscode", Triples: 25100, Status: "ready",
+				IngestedAt: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)},
+			{Identifier: "usc-title-04", Source: "uscode", Status: "pending"},
+		},
+	}
+
+	data, err := FormatStatsXLSX(report)
+	if err != nil {
+		t.Fatalf("FormatStatsXLSX() error = %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("FormatStatsXLSX produced an invalid archive: %v", err)
+	}
+	readPart := func(name string) string {
+		reader, err := archive.Open(name)
+		if err != nil {
+			t.Fatalf("missing part %s: %v", name, err)
+		}
+		defer reader.Close()
+		content, _ := io.ReadAll(reader)
+		return string(content)
+	}
+
+	workbook := readPart("xl/workbook.xml")
+	if !strings.Contains(workbook, `name="Summary"`) || !strings.Contains(workbook, `name="Titles"`) {
+		t.Errorf("workbook sheets = %s", workbook)
+	}
+	titles := readPart("xl/worksheets/sheet2.xml")
+	for _, want := range []string{"usc-title-42", `<c r="E2"><v>25100</v></c>`, "2025-01-15T10:00:00Z", "pending"} {
+		if !strings.Contains(titles, want) {
+			t.Errorf("Titles sheet missing %s:\n%s", want, titles)
+		}
+	}
+}
+
 func TestFormatNumber(t *testing.T) {
 	testCases := []struct {
 		name     string
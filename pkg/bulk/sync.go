@@ -0,0 +1,506 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// SyncStatus classifies the outcome of syncing one dataset.
+type SyncStatus string
+
+const (
+	// SyncUnchanged indicates the remote dataset matches the manifest.
+	SyncUnchanged SyncStatus = "unchanged"
+
+	// SyncUpdated indicates the dataset changed and was re-downloaded and re-ingested.
+	SyncUpdated SyncStatus = "updated"
+
+	// SyncPending indicates a change was detected but not applied (dry run).
+	SyncPending SyncStatus = "pending"
+
+	// SyncFailed indicates the change check, download, or re-ingest failed.
+	SyncFailed SyncStatus = "failed"
+)
+
+// SectionChangeType classifies a section-level difference between two
+// ingested versions of a document.
+type SectionChangeType string
+
+const (
+	SectionAdded    SectionChangeType = "added"
+	SectionRemoved  SectionChangeType = "removed"
+	SectionModified SectionChangeType = "modified"
+)
+
+// RemoteMetadata holds the HTTP validators reported for a remote dataset.
+type RemoteMetadata struct {
+	ETag          string `json:"etag,omitempty"`
+	LastModified  string `json:"last_modified,omitempty"`
+	ContentLength int64  `json:"content_length,omitempty"`
+}
+
+// SectionChange records one added, removed, or modified section in the
+// change feed.
+type SectionChange struct {
+	DocumentID string            `json:"document_id"`
+	Section    string            `json:"section"`
+	Title      string            `json:"title,omitempty"`
+	ChangeType SectionChangeType `json:"change_type"`
+}
+
+// SyncEntry records the sync outcome for a single dataset.
+type SyncEntry struct {
+	Identifier           string          `json:"identifier"`
+	SourceName           string          `json:"source_name"`
+	DocumentID           string          `json:"document_id"`
+	Status               SyncStatus      `json:"status"`
+	Reason               string          `json:"reason,omitempty"`
+	PreviousReleasePoint string          `json:"previous_release_point,omitempty"`
+	ReleasePoint         string          `json:"release_point,omitempty"`
+	Error                string          `json:"error,omitempty"`
+	Changes              []SectionChange `json:"changes,omitempty"`
+}
+
+// SyncReport is the change feed produced by a sync run.
+type SyncReport struct {
+	StartedAt        time.Time   `json:"started_at"`
+	CompletedAt      time.Time   `json:"completed_at"`
+	Checked          int         `json:"checked"`
+	Updated          int         `json:"updated"`
+	Pending          int         `json:"pending"`
+	Unchanged        int         `json:"unchanged"`
+	Failed           int         `json:"failed"`
+	Interrupted      bool        `json:"interrupted,omitempty"`
+	SectionsAdded    int         `json:"sections_added"`
+	SectionsRemoved  int         `json:"sections_removed"`
+	SectionsModified int         `json:"sections_modified"`
+	Entries          []SyncEntry `json:"entries"`
+}
+
+// Syncer refreshes previously downloaded datasets: it compares remote
+// metadata against the download manifest, re-downloads only the datasets
+// that changed, re-ingests them, and diffs their sections.
+type Syncer struct {
+	downloader *Downloader
+	lib        *library.Library
+	ingester   *BulkIngester
+	dryRun     bool
+}
+
+// NewSyncer creates a Syncer. Re-ingestion always overwrites the existing
+// library document; config.DryRun limits the run to change detection.
+func NewSyncer(downloader *Downloader, lib *library.Library, config IngestConfig) *Syncer {
+	config.Force = true
+	return &Syncer{
+		downloader: downloader,
+		lib:        lib,
+		ingester:   NewBulkIngester(config, lib),
+		dryRun:     config.DryRun,
+	}
+}
+
+// SyncDatasets syncs the given datasets from source. Datasets that were
+// never downloaded are ignored, so a sync only refreshes what the user
+// already has. When ctx is done the run stops early, saves the manifest,
+// and marks the report Interrupted.
+func (syncer *Syncer) SyncDatasets(ctx context.Context, source Source, datasets []Dataset) *SyncReport {
+	report := &SyncReport{StartedAt: time.Now()}
+	manifest := syncer.downloader.Manifest()
+
+	for _, dataset := range datasets {
+		if ctx.Err() != nil {
+			report.Interrupted = true
+			break
+		}
+		record := manifest.GetRecord(dataset.Identifier)
+		if record == nil {
+			continue
+		}
+
+		entry := syncer.syncDataset(ctx, source, dataset, record)
+		report.Checked++
+		switch entry.Status {
+		case SyncUpdated:
+			report.Updated++
+		case SyncPending:
+			report.Pending++
+		case SyncUnchanged:
+			report.Unchanged++
+		case SyncFailed:
+			report.Failed++
+		}
+		for _, change := range entry.Changes {
+			switch change.ChangeType {
+			case SectionAdded:
+				report.SectionsAdded++
+			case SectionRemoved:
+				report.SectionsRemoved++
+			case SectionModified:
+				report.SectionsModified++
+			}
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+
+	manifest.mu.Lock()
+	manifest.LastSyncAt = time.Now()
+	manifest.mu.Unlock()
+	syncer.downloader.SaveManifest()
+
+	report.CompletedAt = time.Now()
+	return report
+}
+
+// syncDataset checks one dataset and applies the update when it changed.
+func (syncer *Syncer) syncDataset(ctx context.Context, source Source, dataset Dataset, record *DownloadRecord) SyncEntry {
+	entry := SyncEntry{
+		Identifier:           dataset.Identifier,
+		SourceName:           dataset.SourceName,
+		DocumentID:           deriveDocumentID(record),
+		PreviousReleasePoint: recordReleasePoint(record),
+		ReleasePoint:         ReleasePointFromURL(dataset.URL),
+	}
+
+	remote, err := syncer.downloader.FetchRemoteMetadata(ctx, dataset.URL)
+	if err != nil && record.URL == dataset.URL {
+		entry.Status = SyncFailed
+		entry.Error = err.Error()
+		return entry
+	}
+
+	changed, reason := DetectDatasetChange(record, dataset, remote)
+	if !changed {
+		// Backfill validators so the next sync can compare them
+		if remote != nil && (record.ETag == "" && record.LastModified == "") {
+			syncer.stampRecord(dataset, remote)
+		}
+		entry.Status = SyncUnchanged
+		return entry
+	}
+	entry.Reason = reason
+
+	if syncer.dryRun {
+		entry.Status = SyncPending
+		return entry
+	}
+
+	var before *store.TripleStore
+	if syncer.lib.GetDocument(entry.DocumentID) != nil {
+		before, _ = syncer.lib.LoadTripleStore(entry.DocumentID)
+	}
+
+	if err := syncer.redownload(ctx, source, dataset, record); err != nil {
+		entry.Status = SyncFailed
+		entry.Error = err.Error()
+		return entry
+	}
+	if remote != nil {
+		syncer.stampRecord(dataset, remote)
+	}
+
+	updatedRecord := syncer.downloader.Manifest().GetRecord(dataset.Identifier)
+	ingestEntry := syncer.ingester.ingestDownloadedFile(updatedRecord)
+	if ingestEntry.Status == "failed" {
+		entry.Status = SyncFailed
+		entry.Error = "re-ingest failed: " + ingestEntry.Error
+		return entry
+	}
+
+	after, err := syncer.lib.LoadTripleStore(entry.DocumentID)
+	if err != nil {
+		entry.Status = SyncFailed
+		entry.Error = err.Error()
+		return entry
+	}
+
+	entry.Changes = DiffSections(entry.DocumentID, before, after)
+	entry.Status = SyncUpdated
+	return entry
+}
+
+// redownload moves the current download aside, fetches the dataset again,
+// and restores the previous file if the download fails.
+func (syncer *Syncer) redownload(ctx context.Context, source Source, dataset Dataset, record *DownloadRecord) error {
+	previousPath := record.LocalPath + ".prev"
+	os.RemoveAll(previousPath)
+
+	movedAside := false
+	if _, err := os.Stat(record.LocalPath); err == nil {
+		if err := os.Rename(record.LocalPath, previousPath); err != nil {
+			return fmt.Errorf("failed to move aside %s: %w", record.LocalPath, err)
+		}
+		movedAside = true
+	}
+
+	_, err := source.DownloadDataset(ctx, dataset, syncer.downloader)
+	if err != nil {
+		if movedAside {
+			os.Rename(previousPath, record.LocalPath)
+		}
+		return fmt.Errorf("download failed: %w", err)
+	}
+
+	if movedAside {
+		os.RemoveAll(previousPath)
+	}
+	return nil
+}
+
+// stampRecord stores the remote validators and release point on the
+// dataset's manifest record.
+func (syncer *Syncer) stampRecord(dataset Dataset, remote *RemoteMetadata) {
+	manifest := syncer.downloader.Manifest()
+	manifest.mu.Lock()
+	defer manifest.mu.Unlock()
+
+	record := manifest.Downloads[dataset.Identifier]
+	if record == nil {
+		return
+	}
+	record.ETag = remote.ETag
+	record.LastModified = remote.LastModified
+	record.ReleasePoint = ReleasePointFromURL(dataset.URL)
+}
+
+// FetchRemoteMetadata issues an HTTP HEAD request and returns the
+// validators (ETag, Last-Modified, Content-Length) for a remote dataset.
+func (downloader *Downloader) FetchRemoteMetadata(ctx context.Context, downloadURL string) (*RemoteMetadata, error) {
+	parsedURL, err := url.Parse(downloadURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+
+	downloader.waitForDomain(ctx, parsedURL.Host)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, downloadURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HEAD request: %w", err)
+	}
+	request.Header.Set("User-Agent", downloader.config.UserAgent)
+
+	response, err := downloader.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("HTTP %d for %s", response.StatusCode, downloadURL)
+	}
+
+	return &RemoteMetadata{
+		ETag:          response.Header.Get("ETag"),
+		LastModified:  response.Header.Get("Last-Modified"),
+		ContentLength: response.ContentLength,
+	}, nil
+}
+
+// DetectDatasetChange decides whether a downloaded dataset is stale.
+// A changed download URL (a new USC release point or CFR edition) always
+// counts as a change; otherwise the ETag is compared, then Last-Modified,
+// and finally the size when the record predates stored validators.
+func DetectDatasetChange(record *DownloadRecord, dataset Dataset, remote *RemoteMetadata) (bool, string) {
+	if record.URL != dataset.URL {
+		previousRelease := recordReleasePoint(record)
+		currentRelease := ReleasePointFromURL(dataset.URL)
+		if previousRelease != "" && currentRelease != "" && previousRelease != currentRelease {
+			return true, fmt.Sprintf("release point %s -> %s", previousRelease, currentRelease)
+		}
+		return true, "download URL changed"
+	}
+
+	if remote == nil {
+		return false, ""
+	}
+
+	if record.ETag != "" && remote.ETag != "" {
+		if record.ETag != remote.ETag {
+			return true, "ETag changed"
+		}
+		return false, ""
+	}
+	if record.LastModified != "" && remote.LastModified != "" {
+		if record.LastModified != remote.LastModified {
+			return true, fmt.Sprintf("modified %s", remote.LastModified)
+		}
+		return false, ""
+	}
+	if record.ETag == "" && record.LastModified == "" &&
+		remote.ContentLength > 0 && record.SizeBytes > 0 && remote.ContentLength != record.SizeBytes {
+		return true, fmt.Sprintf("size %s -> %s", FormatBytes(record.SizeBytes), FormatBytes(remote.ContentLength))
+	}
+
+	return false, ""
+}
+
+// ReleasePointFromURL extracts the release point tag embedded in a US Code
+// download URL ("xml_usc42@119-73not60.zip" -> "119-73not60"). Returns ""
+// for URLs without one.
+func ReleasePointFromURL(downloadURL string) string {
+	atIndex := strings.LastIndex(downloadURL, "@")
+	if atIndex < 0 {
+		return ""
+	}
+	tag := downloadURL[atIndex+1:]
+	if dotIndex := strings.Index(tag, "."); dotIndex >= 0 {
+		tag = tag[:dotIndex]
+	}
+	return tag
+}
+
+// recordReleasePoint returns the stored release point of a record, falling
+// back to the one embedded in its URL.
+func recordReleasePoint(record *DownloadRecord) string {
+	if record.ReleasePoint != "" {
+		return record.ReleasePoint
+	}
+	return ReleasePointFromURL(record.URL)
+}
+
+// sectionSnapshot captures the title and text of a section for diffing.
+type sectionSnapshot struct {
+	title string
+	text  string
+}
+
+// DiffSections compares the articles/sections of two ingested versions of a
+// document. A nil before store treats every section as added.
+func DiffSections(documentID string, before, after *store.TripleStore) []SectionChange {
+	beforeSections := snapshotSections(before)
+	afterSections := snapshotSections(after)
+
+	var changes []SectionChange
+	for uri, afterSection := range afterSections {
+		beforeSection, existed := beforeSections[uri]
+		switch {
+		case !existed:
+			changes = append(changes, SectionChange{
+				DocumentID: documentID, Section: sectionLabel(uri), Title: afterSection.title, ChangeType: SectionAdded,
+			})
+		case beforeSection != afterSection:
+			changes = append(changes, SectionChange{
+				DocumentID: documentID, Section: sectionLabel(uri), Title: afterSection.title, ChangeType: SectionModified,
+			})
+		}
+	}
+	for uri, beforeSection := range beforeSections {
+		if _, stillExists := afterSections[uri]; !stillExists {
+			changes = append(changes, SectionChange{
+				DocumentID: documentID, Section: sectionLabel(uri), Title: beforeSection.title, ChangeType: SectionRemoved,
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Section != changes[j].Section {
+			return changes[i].Section < changes[j].Section
+		}
+		return changes[i].ChangeType < changes[j].ChangeType
+	})
+	return changes
+}
+
+// snapshotSections indexes every article in a triple store by URI.
+func snapshotSections(tripleStore *store.TripleStore) map[string]sectionSnapshot {
+	sections := make(map[string]sectionSnapshot)
+	if tripleStore == nil {
+		return sections
+	}
+
+	for _, triple := range tripleStore.Find("", store.RDFType, store.ClassArticle) {
+		snapshot := sectionSnapshot{}
+		if titles := tripleStore.Find(triple.Subject, store.PropTitle, ""); len(titles) > 0 {
+			snapshot.title = titles[0].Object
+		}
+		var textParts []string
+		for _, textTriple := range tripleStore.Find(triple.Subject, store.PropText, "") {
+			textParts = append(textParts, textTriple.Object)
+		}
+		sort.Strings(textParts)
+		snapshot.text = strings.Join(textParts, "\n")
+		sections[triple.Subject] = snapshot
+	}
+
+	return sections
+}
+
+// sectionLabel returns the provision part of a section URI
+// (".../us-usc-title-42:Art42.1983" -> "Art42.1983").
+func sectionLabel(uri string) string {
+	if colonIndex := strings.LastIndex(uri, ":"); colonIndex >= 0 {
+		return uri[colonIndex+1:]
+	}
+	return uri
+}
+
+// FormatSyncTable formats a sync report and its change feed for terminal output.
+func FormatSyncTable(report *SyncReport) string {
+	var builder strings.Builder
+
+	builder.WriteString("\nBulk Sync\n")
+	builder.WriteString(strings.Repeat("═", 90) + "\n")
+	builder.WriteString(fmt.Sprintf("  %-28s  %-10s  %-8s  %-8s  %-8s  %s\n",
+		"IDENTIFIER", "STATUS", "ADDED", "REMOVED", "MODIFIED", "REASON"))
+	builder.WriteString("  " + strings.Repeat("─", 88) + "\n")
+
+	for _, entry := range report.Entries {
+		var added, removed, modified int
+		for _, change := range entry.Changes {
+			switch change.ChangeType {
+			case SectionAdded:
+				added++
+			case SectionRemoved:
+				removed++
+			case SectionModified:
+				modified++
+			}
+		}
+		detail := entry.Reason
+		if entry.Error != "" {
+			detail = entry.Error
+		}
+		builder.WriteString(fmt.Sprintf("  %-28s  %-10s  %-8d  %-8d  %-8d  %s\n",
+			entry.Identifier, entry.Status, added, removed, modified, detail))
+	}
+
+	builder.WriteString(strings.Repeat("─", 90) + "\n")
+	builder.WriteString(fmt.Sprintf("  Checked: %d | Updated: %d | Pending: %d | Unchanged: %d | Failed: %d\n",
+		report.Checked, report.Updated, report.Pending, report.Unchanged, report.Failed))
+	builder.WriteString(fmt.Sprintf("  Sections: +%d added, -%d removed, ~%d modified\n",
+		report.SectionsAdded, report.SectionsRemoved, report.SectionsModified))
+	if report.Interrupted {
+		builder.WriteString("  Interrupted: remaining datasets were not checked\n")
+	}
+
+	for _, entry := range report.Entries {
+		if len(entry.Changes) == 0 {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("\n  %s\n", entry.DocumentID))
+		for _, change := range entry.Changes {
+			marker := map[SectionChangeType]string{SectionAdded: "+", SectionRemoved: "-", SectionModified: "~"}[change.ChangeType]
+			builder.WriteString(fmt.Sprintf("    %s %-20s %s\n", marker, change.Section, change.Title))
+		}
+	}
+
+	return builder.String()
+}
+
+// FormatSyncJSON formats a sync report as indented JSON.
+func FormatSyncJSON(report *SyncReport) string {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(data)
+}
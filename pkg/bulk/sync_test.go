@@ -0,0 +1,268 @@
+package bulk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// syncTestSource serves a single plain-text California code from a test server.
+type syncTestSource struct{}
+
+func (source *syncTestSource) Name() string        { return "california" }
+func (source *syncTestSource) Description() string { return "sync test source" }
+func (source *syncTestSource) ListDatasets() ([]Dataset, error) {
+	return nil, nil
+}
+
+func (source *syncTestSource) DownloadDataset(ctx context.Context, dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
+	localPath := filepath.Join(downloader.SourceDirectory("california"), dataset.Identifier+".txt")
+	bytesWritten, skipped, err := downloader.DownloadFile(ctx, dataset.URL, localPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !skipped {
+		downloader.RecordDownload(&DownloadRecord{
+			Identifier:   dataset.Identifier,
+			SourceName:   "california",
+			URL:          dataset.URL,
+			LocalPath:    localPath,
+			SizeBytes:    bytesWritten,
+			DownloadedAt: time.Now(),
+		})
+	}
+	return &DownloadResult{Dataset: dataset, LocalPath: localPath, BytesWritten: bytesWritten, Skipped: skipped}, nil
+}
+
+const syncCodeV1 = `CALIFORNIA CIVIL CODE
+
+CHAPTER 1
+General Provisions
+
+Section 1
+Freedom
+
+(a) All people are by nature free and independent.
+
+Section 2
+Rights
+
+(a) Every person has certain inalienable rights.
+
+Section 3
+Records
+
+(a) The controller shall keep records.
+`
+
+const syncCodeV2 = `CALIFORNIA CIVIL CODE
+
+CHAPTER 1
+General Provisions
+
+Section 1
+Freedom
+
+(a) All people are by nature free and independent.
+
+Section 2
+Rights
+
+(a) Every person has certain inalienable rights, including privacy.
+
+Section 4
+Disclosure
+
+(a) A business shall disclose its data practices.
+`
+
+func TestDetectDatasetChange(t *testing.T) {
+	dataset := Dataset{URL: "https://uscode.house.gov/download/xml_usc42@119-73not60.zip"}
+
+	tests := []struct {
+		name    string
+		record  DownloadRecord
+		remote  *RemoteMetadata
+		changed bool
+		reason  string
+	}{
+		{
+			name:    "new release point",
+			record:  DownloadRecord{URL: "https://uscode.house.gov/download/xml_usc42@119-60.zip"},
+			changed: true,
+			reason:  "release point 119-60 -> 119-73not60",
+		},
+		{
+			name:    "etag changed",
+			record:  DownloadRecord{URL: dataset.URL, ETag: `"a"`},
+			remote:  &RemoteMetadata{ETag: `"b"`},
+			changed: true,
+			reason:  "ETag changed",
+		},
+		{
+			name:   "etag unchanged despite new last-modified",
+			record: DownloadRecord{URL: dataset.URL, ETag: `"a"`, LastModified: "Mon, 01 Jan 2024 00:00:00 GMT"},
+			remote: &RemoteMetadata{ETag: `"a"`, LastModified: "Tue, 02 Jan 2024 00:00:00 GMT"},
+		},
+		{
+			name:    "last-modified changed",
+			record:  DownloadRecord{URL: dataset.URL, LastModified: "Mon, 01 Jan 2024 00:00:00 GMT"},
+			remote:  &RemoteMetadata{LastModified: "Tue, 02 Jan 2024 00:00:00 GMT"},
+			changed: true,
+			reason:  "modified Tue, 02 Jan 2024 00:00:00 GMT",
+		},
+		{
+			name:    "legacy record size changed",
+			record:  DownloadRecord{URL: dataset.URL, SizeBytes: 100},
+			remote:  &RemoteMetadata{ContentLength: 200},
+			changed: true,
+			reason:  "size 100 B -> 200 B",
+		},
+		{
+			name:   "legacy record same size",
+			record: DownloadRecord{URL: dataset.URL, SizeBytes: 100},
+			remote: &RemoteMetadata{ContentLength: 100},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			changed, reason := DetectDatasetChange(&tc.record, dataset, tc.remote)
+			if changed != tc.changed || reason != tc.reason {
+				t.Errorf("DetectDatasetChange() = (%v, %q), want (%v, %q)", changed, reason, tc.changed, tc.reason)
+			}
+		})
+	}
+}
+
+func TestReleasePointFromURL(t *testing.T) {
+	tests := map[string]string{
+		"https://uscode.house.gov/download/releasepoints/us/pl/119/73not60/xml_usc42@119-73not60.zip": "119-73not60",
+		"https://www.govinfo.gov/bulkdata/CFR/2024/title-42/CFR-2024-title42.zip":                     "",
+	}
+	for downloadURL, expected := range tests {
+		if got := ReleasePointFromURL(downloadURL); got != expected {
+			t.Errorf("ReleasePointFromURL(%q) = %q, want %q", downloadURL, got, expected)
+		}
+	}
+}
+
+func TestDiffSections(t *testing.T) {
+	before := store.NewTripleStore()
+	before.Add("doc:Art1", store.RDFType, store.ClassArticle)
+	before.Add("doc:Art1", store.PropText, "unchanged")
+	before.Add("doc:Art2", store.RDFType, store.ClassArticle)
+	before.Add("doc:Art2", store.PropText, "old")
+	before.Add("doc:Art3", store.RDFType, store.ClassArticle)
+
+	after := store.NewTripleStore()
+	after.Add("doc:Art1", store.RDFType, store.ClassArticle)
+	after.Add("doc:Art1", store.PropText, "unchanged")
+	after.Add("doc:Art2", store.RDFType, store.ClassArticle)
+	after.Add("doc:Art2", store.PropText, "new")
+	after.Add("doc:Art4", store.RDFType, store.ClassArticle)
+
+	changes := DiffSections("doc", before, after)
+	expected := []SectionChange{
+		{DocumentID: "doc", Section: "Art2", ChangeType: SectionModified},
+		{DocumentID: "doc", Section: "Art3", ChangeType: SectionRemoved},
+		{DocumentID: "doc", Section: "Art4", ChangeType: SectionAdded},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %d: %+v", len(expected), len(changes), changes)
+	}
+	for index, change := range changes {
+		if change != expected[index] {
+			t.Errorf("change %d = %+v, want %+v", index, change, expected[index])
+		}
+	}
+}
+
+func TestSyncDatasets(t *testing.T) {
+	var mu sync.Mutex
+	content, etag := syncCodeV1, `"v1"`
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		responseWriter.Header().Set("ETag", etag)
+		if request.Method == http.MethodHead {
+			return
+		}
+		responseWriter.Write([]byte(content))
+	}))
+	defer testServer.Close()
+
+	downloader, temporaryDir := setupTestDownloader(t)
+	lib, err := library.Init(filepath.Join(temporaryDir, "lib"), "https://regula.dev/regulations/")
+	if err != nil {
+		t.Fatalf("failed to init library: %v", err)
+	}
+
+	source := &syncTestSource{}
+	datasets := []Dataset{
+		{SourceName: "california", Identifier: "ca-civ", URL: testServer.URL + "/CIV.txt"},
+		{SourceName: "california", Identifier: "ca-pen", URL: testServer.URL + "/PEN.txt"},
+	}
+
+	// Initial download and ingest of one code only.
+	if _, err := source.DownloadDataset(context.Background(), datasets[0], downloader); err != nil {
+		t.Fatalf("initial download failed: %v", err)
+	}
+	ingester := NewBulkIngester(IngestConfig{}, lib)
+	if entry := ingester.ingestDownloadedFile(downloader.Manifest().GetRecord("ca-civ")); entry.Status != "ingested" {
+		t.Fatalf("initial ingest failed: %+v", entry)
+	}
+
+	syncer := NewSyncer(downloader, lib, IngestConfig{})
+
+	// First sync backfills validators; nothing changed remotely.
+	report := syncer.SyncDatasets(context.Background(), source, datasets)
+	if report.Checked != 1 || report.Unchanged != 1 {
+		t.Fatalf("expected 1 unchanged dataset (never-downloaded ignored), got %+v", report)
+	}
+	if record := downloader.Manifest().GetRecord("ca-civ"); record.ETag != `"v1"` {
+		t.Errorf("expected ETag to be backfilled, got %q", record.ETag)
+	}
+
+	// Publish a new version.
+	mu.Lock()
+	content, etag = syncCodeV2, `"v2"`
+	mu.Unlock()
+
+	dryRunSyncer := NewSyncer(downloader, lib, IngestConfig{DryRun: true})
+	report = dryRunSyncer.SyncDatasets(context.Background(), source, datasets)
+	if report.Pending != 1 || report.Updated != 0 {
+		t.Fatalf("expected dry run to report 1 pending update, got %+v", report)
+	}
+
+	report = syncer.SyncDatasets(context.Background(), source, datasets)
+	if report.Updated != 1 {
+		t.Fatalf("expected 1 updated dataset, got %+v", report)
+	}
+	if report.SectionsAdded != 1 || report.SectionsRemoved != 1 || report.SectionsModified != 1 {
+		t.Errorf("expected +1/-1/~1 section changes, got +%d/-%d/~%d: %+v",
+			report.SectionsAdded, report.SectionsRemoved, report.SectionsModified, report.Entries[0].Changes)
+	}
+	if record := downloader.Manifest().GetRecord("ca-civ"); record.ETag != `"v2"` {
+		t.Errorf("expected manifest ETag to be updated, got %q", record.ETag)
+	}
+	if downloader.Manifest().LastSyncAt.IsZero() {
+		t.Error("expected LastSyncAt to be recorded")
+	}
+
+	sourceText, err := lib.LoadSourceText("us-ca-civ")
+	if err != nil {
+		t.Fatalf("failed to load re-ingested source: %v", err)
+	}
+	if string(sourceText) != syncCodeV2 {
+		t.Error("expected library document to hold the new version")
+	}
+}
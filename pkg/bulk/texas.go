@@ -0,0 +1,129 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TexasSource downloads Texas statutes from statutes.capitol.texas.gov, which
+// publishes each code as a ZIP archive of HTML chapter files.
+type TexasSource struct {
+	config  DownloadConfig
+	baseURL string
+}
+
+// NewTexasSource creates a TexasSource with the given config.
+func NewTexasSource(config DownloadConfig) *TexasSource {
+	return &TexasSource{config: config, baseURL: texasBaseURL}
+}
+
+func (source *TexasSource) Name() string { return "texas" }
+
+func (source *TexasSource) Description() string {
+	return "Texas statutes from statutes.capitol.texas.gov (27 codes + Constitution)"
+}
+
+// ListDatasets returns all Texas codes as downloadable HTML ZIP archives.
+func (source *TexasSource) ListDatasets() ([]Dataset, error) {
+	var datasets []Dataset
+
+	for _, codeEntry := range texasCodeEntries {
+		datasets = append(datasets, Dataset{
+			SourceName:   "texas",
+			Identifier:   fmt.Sprintf("tx-%s", strings.ToLower(codeEntry.Abbreviation)),
+			DisplayName:  fmt.Sprintf("%s (%s)", codeEntry.FullName, codeEntry.Abbreviation),
+			URL:          fmt.Sprintf("%s/%s.htm.zip", source.baseURL, codeEntry.Abbreviation),
+			Format:       "zip",
+			Jurisdiction: "US-TX",
+		})
+	}
+
+	return datasets, nil
+}
+
+// DownloadDataset downloads a Texas code ZIP archive.
+func (source *TexasSource) DownloadDataset(ctx context.Context, dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
+	sourceDir := downloader.SourceDirectory("texas")
+	codeAbbrev := strings.ToUpper(strings.TrimPrefix(dataset.Identifier, "tx-"))
+	localPath := filepath.Join(sourceDir, codeAbbrev+".htm.zip")
+
+	bytesWritten, skipped, err := downloader.DownloadFile(ctx,
+		dataset.URL, localPath, downloader.progressBar())
+	if err != nil {
+		return &DownloadResult{
+			Dataset: dataset,
+			Error:   err.Error(),
+		}, err
+	}
+
+	if !skipped {
+		downloader.RecordDownload(&DownloadRecord{
+			Identifier:   dataset.Identifier,
+			SourceName:   "texas",
+			URL:          dataset.URL,
+			LocalPath:    localPath,
+			SizeBytes:    bytesWritten,
+			DownloadedAt: time.Now(),
+		})
+		downloader.SaveManifest()
+		downloader.endProgressBar()
+	}
+
+	return &DownloadResult{
+		Dataset:      dataset,
+		LocalPath:    localPath,
+		BytesWritten: bytesWritten,
+		Skipped:      skipped,
+		DownloadedAt: time.Now(),
+	}, nil
+}
+
+const texasBaseURL = "https://statutes.capitol.texas.gov/Docs/Zips"
+
+// texasCodeEntries contains all Texas codes and the Constitution.
+var texasCodeEntries = []struct {
+	Abbreviation string
+	FullName     string
+}{
+	{"CN", "Texas Constitution"},
+	{"AG", "Agriculture Code"},
+	{"AL", "Alcoholic Beverage Code"},
+	{"BC", "Business and Commerce Code"},
+	{"BO", "Business Organizations Code"},
+	{"CP", "Civil Practice and Remedies Code"},
+	{"CR", "Code of Criminal Procedure"},
+	{"ED", "Education Code"},
+	{"EL", "Election Code"},
+	{"ES", "Estates Code"},
+	{"FA", "Family Code"},
+	{"FI", "Finance Code"},
+	{"GV", "Government Code"},
+	{"HS", "Health and Safety Code"},
+	{"HR", "Human Resources Code"},
+	{"IN", "Insurance Code"},
+	{"LA", "Labor Code"},
+	{"LG", "Local Government Code"},
+	{"NR", "Natural Resources Code"},
+	{"OC", "Occupations Code"},
+	{"PW", "Parks and Wildlife Code"},
+	{"PE", "Penal Code"},
+	{"PR", "Property Code"},
+	{"SD", "Special District Local Laws Code"},
+	{"TX", "Tax Code"},
+	{"TN", "Transportation Code"},
+	{"UT", "Utilities Code"},
+	{"WA", "Water Code"},
+}
+
+// texasCodeFullName returns the full name of a Texas code abbreviation.
+func texasCodeFullName(abbreviation string) string {
+	for _, entry := range texasCodeEntries {
+		if entry.Abbreviation == abbreviation {
+			return strings.ToUpper(entry.FullName)
+		}
+	}
+	return abbreviation
+}
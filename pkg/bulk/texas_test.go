@@ -0,0 +1,78 @@
+package bulk
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTexasSourceListDatasets(t *testing.T) {
+	source := NewTexasSource(DefaultDownloadConfig())
+
+	if source.Name() != "texas" {
+		t.Errorf("expected name 'texas', got %q", source.Name())
+	}
+
+	datasets, err := source.ListDatasets()
+	if err != nil {
+		t.Fatalf("ListDatasets failed: %v", err)
+	}
+	if len(datasets) != 28 {
+		t.Fatalf("expected 28 Texas datasets, got %d", len(datasets))
+	}
+	for _, dataset := range datasets {
+		if dataset.Jurisdiction != "US-TX" {
+			t.Errorf("expected jurisdiction 'US-TX', got %q", dataset.Jurisdiction)
+		}
+		if !strings.HasSuffix(dataset.URL, ".htm.zip") {
+			t.Errorf("expected HTML ZIP URL, got %q", dataset.URL)
+		}
+	}
+}
+
+func TestIngestTexas(t *testing.T) {
+	temporaryDir := t.TempDir()
+	zipPath := filepath.Join(temporaryDir, "BC.htm.zip")
+
+	zipFile, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatalf("failed to create zip: %v", err)
+	}
+	zipWriter := zip.NewWriter(zipFile)
+	chapters := map[string]string{
+		"BC.1.htm":   "<html><body><p>CHAPTER 1. GENERAL PROVISIONS</p><p>Sec. 1.001. SHORT TITLE.</p></body></html>",
+		"BC.521.htm": "<html><body><p>CHAPTER 521. UNAUTHORIZED USE OF IDENTIFYING INFORMATION</p></body></html>",
+		"notes.txt":  "ignored",
+	}
+	for name, content := range chapters {
+		entryWriter, _ := zipWriter.Create(name)
+		entryWriter.Write([]byte(content))
+	}
+	zipWriter.Close()
+	zipFile.Close()
+
+	ingester := &BulkIngester{}
+	plaintext, err := ingester.ingestTexas(&DownloadRecord{
+		Identifier: "tx-bc",
+		SourceName: "texas",
+		LocalPath:  zipPath,
+	})
+	if err != nil {
+		t.Fatalf("ingestTexas failed: %v", err)
+	}
+
+	if !strings.HasPrefix(plaintext, "TEXAS BUSINESS AND COMMERCE CODE") {
+		t.Errorf("expected code heading, got:\n%s", plaintext)
+	}
+	if strings.Contains(plaintext, "<p>") {
+		t.Error("expected HTML tags to be stripped")
+	}
+	if strings.Index(plaintext, "CHAPTER 1.") > strings.Index(plaintext, "CHAPTER 521.") {
+		t.Error("expected chapters in file order")
+	}
+	if strings.Contains(plaintext, "ignored") {
+		t.Error("expected non-HTML files to be skipped")
+	}
+}
@@ -1,8 +1,11 @@
 package bulk
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -21,7 +24,8 @@ type Source interface {
 	// DownloadDataset downloads a single dataset to the target directory.
 	// Returns the local file path of the downloaded file.
 	// Supports resumability: skips if file already exists with matching size.
-	DownloadDataset(dataset Dataset, downloader *Downloader) (*DownloadResult, error)
+	// Requests are made with ctx, so cancelling it aborts the download.
+	DownloadDataset(ctx context.Context, dataset Dataset, downloader *Downloader) (*DownloadResult, error)
 }
 
 // Dataset represents a single downloadable unit from a bulk source.
@@ -78,6 +82,12 @@ type DownloadConfig struct {
 	// HTTPClient allows injection of a custom HTTP client (for testing).
 	HTTPClient *http.Client
 
+	// HTTPCacheDir is the persistent HTTP cache directory (see pkg/httpcache).
+	// Responses up to the cache's body limit are revalidated with
+	// conditional requests; archives and resumed downloads bypass it.
+	// Empty disables caching.
+	HTTPCacheDir string
+
 	// DryRun when true, lists what would be downloaded without fetching.
 	DryRun bool
 
@@ -92,6 +102,14 @@ type DownloadConfig struct {
 
 	// RetryBaseDelay is the initial delay between retries (doubles each attempt).
 	RetryBaseDelay time.Duration
+
+	// Concurrency is the number of datasets downloaded in parallel (default 1).
+	// Per-domain rate limiting still applies across workers.
+	Concurrency int
+
+	// Progress receives a progress bar for each file downloaded. Nil, the
+	// default, reports no progress.
+	Progress io.Writer
 }
 
 // DefaultDownloadConfig returns a DownloadConfig with sensible defaults.
@@ -104,6 +122,7 @@ func DefaultDownloadConfig() DownloadConfig {
 		CFRYear:           "2024",
 		MaxRetries:        3,
 		RetryBaseDelay:    5 * time.Second,
+		Concurrency:       1,
 	}
 }
 
@@ -129,6 +148,23 @@ type IngestConfig struct {
 
 	// BaseURI is the base URI for the library.
 	BaseURI string
+
+	// Workers is the number of files extracted and ingested concurrently.
+	// Values below 2 ingest serially. Files are committed to the library in
+	// manifest order whatever the worker count.
+	Workers int
+
+	// Progress, if set, is called once per file as it is committed,
+	// serially and in manifest order.
+	Progress func(IngestProgress)
+}
+
+// IngestProgress reports bulk ingest progress after each file.
+type IngestProgress struct {
+	Completed int
+	Total     int
+	Entry     IngestEntry
+	Elapsed   time.Duration
 }
 
 // IngestReport summarizes the results of a bulk ingest operation.
@@ -205,16 +241,24 @@ func ResolveSource(sourceName string, config DownloadConfig) (Source, error) {
 		return NewCFRSource(config), nil
 	case "california":
 		return NewCaliforniaSource(config), nil
+	case "newyork":
+		return NewNewYorkSource(config), nil
+	case "texas":
+		return NewTexasSource(config), nil
+	case "washington":
+		return NewWashingtonSource(config), nil
+	case "municipal":
+		return NewMunicipalSource(config), nil
 	case "archive":
 		return NewInternetArchiveSource(config), nil
 	case "parliamentary":
 		return NewParliamentarySource(config), nil
 	default:
-		return nil, fmt.Errorf("unknown source: %s (available: uscode, cfr, california, archive, parliamentary)", sourceName)
+		return nil, fmt.Errorf("unknown source: %s (available: %s)", sourceName, strings.Join(AllSourceNames(), ", "))
 	}
 }
 
 // AllSourceNames returns the list of registered source names.
 func AllSourceNames() []string {
-	return []string{"uscode", "cfr", "california", "archive", "parliamentary"}
+	return []string{"uscode", "cfr", "california", "newyork", "texas", "washington", "municipal", "archive", "parliamentary"}
 }
@@ -48,6 +48,26 @@ func TestResolveSource(t *testing.T) {
 			sourceName:   "california",
 			expectedName: "california",
 		},
+		{
+			name:         "newyork source",
+			sourceName:   "newyork",
+			expectedName: "newyork",
+		},
+		{
+			name:         "texas source",
+			sourceName:   "texas",
+			expectedName: "texas",
+		},
+		{
+			name:         "washington source",
+			sourceName:   "washington",
+			expectedName: "washington",
+		},
+		{
+			name:         "municipal source",
+			sourceName:   "municipal",
+			expectedName: "municipal",
+		},
 		{
 			name:         "archive source",
 			sourceName:   "archive",
@@ -87,14 +107,18 @@ func TestResolveSource(t *testing.T) {
 func TestAllSourceNames(t *testing.T) {
 	sourceNames := AllSourceNames()
 
-	if len(sourceNames) != 5 {
-		t.Fatalf("expected 5 source names, got %d", len(sourceNames))
+	if len(sourceNames) != 9 {
+		t.Fatalf("expected 9 source names, got %d", len(sourceNames))
 	}
 
 	expectedNames := map[string]bool{
 		"uscode":       false,
 		"cfr":          false,
 		"california":   false,
+		"newyork":      false,
+		"texas":        false,
+		"washington":   false,
+		"municipal":    false,
 		"archive":      false,
 		"parliamentary": false,
 	}
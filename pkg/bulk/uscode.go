@@ -1,6 +1,7 @@
 package bulk
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"time"
@@ -48,13 +49,13 @@ func (source *USCodeSource) ListDatasets() ([]Dataset, error) {
 }
 
 // DownloadDataset downloads a USC title ZIP to the downloads directory.
-func (source *USCodeSource) DownloadDataset(dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
+func (source *USCodeSource) DownloadDataset(ctx context.Context, dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
 	sourceDir := downloader.SourceDirectory("uscode")
 	// Use identifier-based filename since the URL contains @ which complicates filepath.Base
 	localPath := filepath.Join(sourceDir, dataset.Identifier+".zip")
 
-	bytesWritten, skipped, err := downloader.DownloadFile(
-		dataset.URL, localPath, PrintDownloadProgress)
+	bytesWritten, skipped, err := downloader.DownloadFile(ctx,
+		dataset.URL, localPath, downloader.progressBar())
 	if err != nil {
 		return &DownloadResult{
 			Dataset: dataset,
@@ -64,7 +65,7 @@ func (source *USCodeSource) DownloadDataset(dataset Dataset, downloader *Downloa
 
 	// Record in manifest
 	if !skipped {
-		downloader.Manifest().RecordDownload(&DownloadRecord{
+		downloader.RecordDownload(&DownloadRecord{
 			Identifier:   dataset.Identifier,
 			SourceName:   "uscode",
 			URL:          dataset.URL,
@@ -73,7 +74,7 @@ func (source *USCodeSource) DownloadDataset(dataset Dataset, downloader *Downloa
 			DownloadedAt: time.Now(),
 		})
 		downloader.SaveManifest()
-		fmt.Println() // newline after progress bar
+		downloader.endProgressBar()
 	}
 
 	return &DownloadResult{
@@ -1,6 +1,7 @@
 package bulk
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
@@ -97,7 +98,7 @@ func TestUSCodeSourceDownloadDataset(t *testing.T) {
 		Format:     "zip",
 	}
 
-	result, err := source.DownloadDataset(dataset, downloader)
+	result, err := source.DownloadDataset(context.Background(), dataset, downloader)
 	if err != nil {
 		t.Fatalf("download failed: %v", err)
 	}
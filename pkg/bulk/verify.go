@@ -0,0 +1,263 @@
+package bulk
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// VerifyStatus classifies the integrity of a downloaded file.
+type VerifyStatus string
+
+const (
+	// VerifyOK indicates size, checksum, and archive structure all check out.
+	VerifyOK VerifyStatus = "ok"
+
+	// VerifyNoChecksum indicates the file looks intact but the manifest
+	// predates checksum recording, so its contents could not be confirmed.
+	VerifyNoChecksum VerifyStatus = "no-checksum"
+
+	// VerifyMissing indicates the file recorded in the manifest is gone.
+	VerifyMissing VerifyStatus = "missing"
+
+	// VerifyTruncated indicates the file is smaller than the recorded size.
+	VerifyTruncated VerifyStatus = "truncated"
+
+	// VerifySizeMismatch indicates the file is larger than the recorded size.
+	VerifySizeMismatch VerifyStatus = "size-mismatch"
+
+	// VerifyChecksumMismatch indicates the SHA-256 digest differs from the manifest.
+	VerifyChecksumMismatch VerifyStatus = "checksum-mismatch"
+
+	// VerifyCorrupt indicates the archive could not be read end to end.
+	VerifyCorrupt VerifyStatus = "corrupt"
+)
+
+// Failed reports whether the status indicates a damaged download.
+func (status VerifyStatus) Failed() bool {
+	return status != VerifyOK && status != VerifyNoChecksum
+}
+
+// VerifyEntry records the verification outcome for one manifest record.
+type VerifyEntry struct {
+	Identifier     string       `json:"identifier"`
+	SourceName     string       `json:"source_name"`
+	LocalPath      string       `json:"local_path"`
+	Status         VerifyStatus `json:"status"`
+	ExpectedBytes  int64        `json:"expected_bytes"`
+	ActualBytes    int64        `json:"actual_bytes"`
+	ExpectedSHA256 string       `json:"expected_sha256,omitempty"`
+	ActualSHA256   string       `json:"actual_sha256,omitempty"`
+	Detail         string       `json:"detail,omitempty"`
+}
+
+// VerifyReport summarizes verification across the download manifest.
+type VerifyReport struct {
+	Total      int           `json:"total"`
+	OK         int           `json:"ok"`
+	NoChecksum int           `json:"no_checksum"`
+	Failed     int           `json:"failed"`
+	Entries    []VerifyEntry `json:"entries"`
+}
+
+// Passed reports whether no downloads failed verification.
+func (report *VerifyReport) Passed() bool {
+	return report.Failed == 0
+}
+
+// VerifyManifest checks every download in the manifest, optionally limited
+// to one source, against its recorded size and SHA-256 digest, and reads
+// ZIP and tar.gz archives end to end to detect corruption.
+func VerifyManifest(manifest *DownloadManifest, sourceFilter string) *VerifyReport {
+	manifest.mu.Lock()
+	var records []*DownloadRecord
+	for _, record := range manifest.Downloads {
+		if sourceFilter == "" || record.SourceName == sourceFilter {
+			records = append(records, record)
+		}
+	}
+	manifest.mu.Unlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Identifier < records[j].Identifier
+	})
+
+	report := &VerifyReport{Entries: make([]VerifyEntry, 0, len(records))}
+	for _, record := range records {
+		entry := VerifyRecord(record)
+		report.Total++
+		switch {
+		case entry.Status.Failed():
+			report.Failed++
+		case entry.Status == VerifyNoChecksum:
+			report.NoChecksum++
+		default:
+			report.OK++
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+
+	return report
+}
+
+// VerifyRecord checks a single downloaded file.
+func VerifyRecord(record *DownloadRecord) VerifyEntry {
+	entry := VerifyEntry{
+		Identifier:     record.Identifier,
+		SourceName:     record.SourceName,
+		LocalPath:      record.LocalPath,
+		ExpectedBytes:  record.SizeBytes,
+		ExpectedSHA256: record.SHA256,
+	}
+
+	fileInfo, err := os.Stat(record.LocalPath)
+	if err != nil {
+		entry.Status = VerifyMissing
+		entry.Detail = err.Error()
+		return entry
+	}
+	entry.ActualBytes = fileInfo.Size()
+
+	// Directory downloads (extracted archives) only get an existence check
+	if fileInfo.IsDir() {
+		entry.Status = VerifyNoChecksum
+		return entry
+	}
+
+	if record.SizeBytes > 0 && entry.ActualBytes < record.SizeBytes {
+		entry.Status = VerifyTruncated
+		entry.Detail = fmt.Sprintf("%s of %s", FormatBytes(entry.ActualBytes), FormatBytes(record.SizeBytes))
+		return entry
+	}
+	if record.SizeBytes > 0 && entry.ActualBytes > record.SizeBytes {
+		entry.Status = VerifySizeMismatch
+		entry.Detail = fmt.Sprintf("%s, expected %s", FormatBytes(entry.ActualBytes), FormatBytes(record.SizeBytes))
+		return entry
+	}
+
+	if record.SHA256 != "" {
+		actualChecksum, err := FileSHA256(record.LocalPath)
+		if err != nil {
+			entry.Status = VerifyCorrupt
+			entry.Detail = err.Error()
+			return entry
+		}
+		entry.ActualSHA256 = actualChecksum
+		if !strings.EqualFold(actualChecksum, record.SHA256) {
+			entry.Status = VerifyChecksumMismatch
+			return entry
+		}
+	}
+
+	if err := verifyArchive(record.LocalPath); err != nil {
+		entry.Status = VerifyCorrupt
+		entry.Detail = err.Error()
+		return entry
+	}
+
+	entry.Status = VerifyOK
+	if record.SHA256 == "" {
+		entry.Status = VerifyNoChecksum
+	}
+	return entry
+}
+
+// verifyArchive reads every entry of a ZIP or tar.gz archive so that CRC
+// errors and truncated streams surface. Other file types pass unchanged.
+func verifyArchive(filePath string) error {
+	lowerPath := strings.ToLower(filePath)
+
+	switch {
+	case strings.HasSuffix(lowerPath, ".zip"):
+		zipReader, err := zip.OpenReader(filePath)
+		if err != nil {
+			return fmt.Errorf("invalid ZIP: %w", err)
+		}
+		defer zipReader.Close()
+
+		for _, zipEntry := range zipReader.File {
+			if zipEntry.FileInfo().IsDir() {
+				continue
+			}
+			entryReader, err := zipEntry.Open()
+			if err != nil {
+				return fmt.Errorf("ZIP entry %s: %w", zipEntry.Name, err)
+			}
+			_, err = io.Copy(io.Discard, entryReader)
+			entryReader.Close()
+			if err != nil {
+				return fmt.Errorf("ZIP entry %s: %w", zipEntry.Name, err)
+			}
+		}
+
+	case strings.HasSuffix(lowerPath, ".tar.gz") || strings.HasSuffix(lowerPath, ".tgz"):
+		archiveFile, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer archiveFile.Close()
+
+		gzipReader, err := gzip.NewReader(archiveFile)
+		if err != nil {
+			return fmt.Errorf("invalid gzip: %w", err)
+		}
+		defer gzipReader.Close()
+
+		tarReader := tar.NewReader(gzipReader)
+		for {
+			_, err := tarReader.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("tar read error: %w", err)
+			}
+			if _, err := io.Copy(io.Discard, tarReader); err != nil {
+				return fmt.Errorf("tar read error: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// FormatVerifyTable formats a verification report for terminal output.
+func FormatVerifyTable(report *VerifyReport) string {
+	var builder strings.Builder
+
+	builder.WriteString("\nBulk Download Verification\n")
+	builder.WriteString(strings.Repeat("═", 90) + "\n")
+	builder.WriteString(fmt.Sprintf("  %-30s  %-14s  %-18s  %-10s  %s\n",
+		"IDENTIFIER", "SOURCE", "STATUS", "SIZE", "DETAIL"))
+	builder.WriteString("  " + strings.Repeat("─", 88) + "\n")
+
+	for _, entry := range report.Entries {
+		builder.WriteString(fmt.Sprintf("  %-30s  %-14s  %-18s  %-10s  %s\n",
+			entry.Identifier,
+			entry.SourceName,
+			entry.Status,
+			FormatBytes(entry.ActualBytes),
+			entry.Detail))
+	}
+
+	builder.WriteString(strings.Repeat("─", 90) + "\n")
+	builder.WriteString(fmt.Sprintf("  Verified: %d | OK: %d | No checksum: %d | Failed: %d\n",
+		report.Total, report.OK, report.NoChecksum, report.Failed))
+
+	return builder.String()
+}
+
+// FormatVerifyJSON formats a verification report as indented JSON.
+func FormatVerifyJSON(report *VerifyReport) string {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(data)
+}
@@ -0,0 +1,163 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// WashingtonSource downloads Revised Code of Washington (RCW) titles from
+// app.leg.wa.gov. Each title is fetched as a single full-text HTML page.
+type WashingtonSource struct {
+	config     DownloadConfig
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewWashingtonSource creates a WashingtonSource with the given config.
+func NewWashingtonSource(config DownloadConfig) *WashingtonSource {
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: config.Timeout}
+	}
+	return &WashingtonSource{config: config, httpClient: httpClient, baseURL: washingtonBaseURL}
+}
+
+func (source *WashingtonSource) Name() string { return "washington" }
+
+func (source *WashingtonSource) Description() string {
+	return "Revised Code of Washington titles from app.leg.wa.gov"
+}
+
+// ListDatasets returns the supported RCW titles.
+func (source *WashingtonSource) ListDatasets() ([]Dataset, error) {
+	var datasets []Dataset
+
+	for _, titleEntry := range washingtonTitleEntries {
+		datasets = append(datasets, Dataset{
+			SourceName:   "washington",
+			Identifier:   fmt.Sprintf("wa-rcw-%s", strings.ToLower(titleEntry.Number)),
+			DisplayName:  fmt.Sprintf("RCW Title %s: %s", titleEntry.Number, titleEntry.FullName),
+			URL:          fmt.Sprintf("%s?cite=%s&full=true", source.baseURL, titleEntry.Number),
+			Format:       "html",
+			Jurisdiction: "US-WA",
+		})
+	}
+
+	return datasets, nil
+}
+
+// DownloadDataset fetches the full text of an RCW title and stores it as
+// plain text.
+func (source *WashingtonSource) DownloadDataset(ctx context.Context, dataset Dataset, downloader *Downloader) (*DownloadResult, error) {
+	sourceDir := downloader.SourceDirectory("washington")
+	titleNumber := strings.ToUpper(strings.TrimPrefix(dataset.Identifier, "wa-rcw-"))
+	localPath := filepath.Join(sourceDir, "RCW-"+titleNumber+".txt")
+
+	if existingInfo, err := os.Stat(localPath); err == nil && existingInfo.Size() > 0 {
+		return &DownloadResult{
+			Dataset:      dataset,
+			LocalPath:    localPath,
+			BytesWritten: existingInfo.Size(),
+			Skipped:      true,
+			DownloadedAt: time.Now(),
+		}, nil
+	}
+
+	os.MkdirAll(sourceDir, 0755)
+
+	parsedURL, err := url.Parse(dataset.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid title URL: %w", err)
+	}
+	downloader.waitForDomain(ctx, parsedURL.Host)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, dataset.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	request.Header.Set("User-Agent", downloader.config.UserAgent)
+
+	response, err := source.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch RCW title %s: %w", titleNumber, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("HTTP %d fetching RCW title %s", response.StatusCode, titleNumber)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read RCW title body: %w", err)
+	}
+
+	titleText := fmt.Sprintf("REVISED CODE OF WASHINGTON TITLE %s\n\n%s\n", titleNumber, extractHTMLText(body))
+	if err := os.WriteFile(localPath, []byte(titleText), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", localPath, err)
+	}
+
+	bytesWritten := int64(len(titleText))
+
+	downloader.RecordDownload(&DownloadRecord{
+		Identifier:   dataset.Identifier,
+		SourceName:   "washington",
+		URL:          dataset.URL,
+		LocalPath:    localPath,
+		SizeBytes:    bytesWritten,
+		DownloadedAt: time.Now(),
+	})
+	downloader.SaveManifest()
+
+	return &DownloadResult{
+		Dataset:      dataset,
+		LocalPath:    localPath,
+		BytesWritten: bytesWritten,
+		Skipped:      false,
+		DownloadedAt: time.Now(),
+	}, nil
+}
+
+const washingtonBaseURL = "https://app.leg.wa.gov/RCW/default.aspx"
+
+// washingtonTitleEntries contains commonly used RCW titles.
+var washingtonTitleEntries = []struct {
+	Number   string
+	FullName string
+}{
+	{"1", "General Provisions"},
+	{"2", "Courts of Record"},
+	{"4", "Civil Procedure"},
+	{"5", "Evidence"},
+	{"9", "Crimes and Punishments"},
+	{"9A", "Washington Criminal Code"},
+	{"10", "Criminal Procedure"},
+	{"11", "Probate and Trust Law"},
+	{"18", "Businesses and Professions"},
+	{"19", "Business Regulations—Miscellaneous"},
+	{"23B", "Business Corporations"},
+	{"26", "Domestic Relations"},
+	{"28A", "Common School Provisions"},
+	{"34", "Administrative Law"},
+	{"42", "Public Officers and Agencies"},
+	{"43", "State Government—Executive"},
+	{"46", "Motor Vehicles"},
+	{"48", "Insurance"},
+	{"49", "Labor Regulations"},
+	{"59", "Landlord and Tenant"},
+	{"62A", "Uniform Commercial Code"},
+	{"64", "Real Property and Conveyances"},
+	{"69", "Food, Drugs, Cosmetics, and Poisons"},
+	{"70", "Public Health and Safety"},
+	{"74", "Public Assistance"},
+	{"82", "Excise Taxes"},
+	{"84", "Property Taxes"},
+	{"90", "Water Rights—Environment"},
+}
@@ -0,0 +1,95 @@
+package bulk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWashingtonSourceListDatasets(t *testing.T) {
+	source := NewWashingtonSource(DefaultDownloadConfig())
+
+	if source.Name() != "washington" {
+		t.Errorf("expected name 'washington', got %q", source.Name())
+	}
+
+	datasets, err := source.ListDatasets()
+	if err != nil {
+		t.Fatalf("ListDatasets failed: %v", err)
+	}
+	found := false
+	for _, dataset := range datasets {
+		if dataset.Jurisdiction != "US-WA" {
+			t.Errorf("expected jurisdiction 'US-WA', got %q", dataset.Jurisdiction)
+		}
+		if dataset.Identifier == "wa-rcw-19" {
+			found = true
+			if !strings.Contains(dataset.URL, "cite=19&full=true") {
+				t.Errorf("unexpected URL for title 19: %q", dataset.URL)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected to find RCW title 19")
+	}
+}
+
+func TestWashingtonSourceDownloadDataset(t *testing.T) {
+	titleHTML := `<html><body>
+		<h1>Title 19 RCW</h1>
+		<div><h3>RCW 19.255.010</h3><p>Disclosure, notice &mdash; Definitions &amp; rights.</p></div>
+	</body></html>`
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		if request.URL.Query().Get("cite") != "19" {
+			responseWriter.WriteHeader(http.StatusNotFound)
+			return
+		}
+		responseWriter.Write([]byte(titleHTML))
+	}))
+	defer testServer.Close()
+
+	config := DownloadConfig{
+		DownloadDirectory: t.TempDir(),
+		RateLimit:         1 * time.Millisecond,
+		Timeout:           10 * time.Second,
+		UserAgent:         "regula-test/1.0",
+		HTTPClient:        testServer.Client(),
+	}
+	downloader, err := NewDownloader(config)
+	if err != nil {
+		t.Fatalf("NewDownloader failed: %v", err)
+	}
+
+	source := NewWashingtonSource(config)
+	source.baseURL = testServer.URL
+	datasets, _ := source.ListDatasets()
+
+	var titleDataset Dataset
+	for _, dataset := range datasets {
+		if dataset.Identifier == "wa-rcw-19" {
+			titleDataset = dataset
+		}
+	}
+
+	result, err := source.DownloadDataset(context.Background(), titleDataset, downloader)
+	if err != nil {
+		t.Fatalf("DownloadDataset failed: %v", err)
+	}
+
+	content, err := os.ReadFile(result.LocalPath)
+	if err != nil {
+		t.Fatalf("failed to read downloaded file: %v", err)
+	}
+	text := string(content)
+	if !strings.HasPrefix(text, "REVISED CODE OF WASHINGTON TITLE 19") {
+		t.Errorf("expected title heading, got:\n%s", text)
+	}
+	if !strings.Contains(text, "RCW 19.255.010") || strings.Contains(text, "<h3>") {
+		t.Errorf("expected stripped section text, got:\n%s", text)
+	}
+}
@@ -0,0 +1,217 @@
+// Package calendar schedules recurring regulatory obligations: it reads
+// reg:recurrence rules from a knowledge graph, lists upcoming due dates, and
+// exports them as an iCalendar (ICS) feed.
+package calendar
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// RecurringObligation is an obligation node with a recurrence rule.
+type RecurringObligation struct {
+	URI            string              `json:"uri"`
+	ObligationType string              `json:"obligation_type"`
+	ArticleURI     string              `json:"article_uri,omitempty"`
+	RegulationURI  string              `json:"regulation_uri,omitempty"`
+	DutyBearer     string              `json:"duty_bearer,omitempty"`
+	Text           string              `json:"text,omitempty"`
+	Rule           string              `json:"rule"`
+	Source         string              `json:"source,omitempty"`
+	Recurrence     *extract.Recurrence `json:"-"`
+}
+
+// Label returns a short name such as "GDPR:Art30 RecordKeepingObligation".
+func (obligation *RecurringObligation) Label() string {
+	return strings.TrimSpace(shortName(obligation.ArticleURI) + " " + obligation.ObligationType)
+}
+
+// Event is a single due date of a recurring obligation.
+type Event struct {
+	Date       time.Time            `json:"date"`
+	Obligation *RecurringObligation `json:"obligation"`
+}
+
+// CollectRecurringObligations returns every obligation in the store that
+// has a valid reg:recurrence rule, sorted by URI. Rules that fail to parse
+// are returned as errors alongside the valid obligations.
+func CollectRecurringObligations(tripleStore *store.TripleStore) ([]*RecurringObligation, []error) {
+	var obligations []*RecurringObligation
+	var ruleErrors []error
+
+	for _, triple := range tripleStore.Find("", store.PropRecurrence, "") {
+		recurrence, err := extract.ParseRecurrenceRule(triple.Object)
+		if err != nil {
+			ruleErrors = append(ruleErrors, fmt.Errorf("%s: %w", triple.Subject, err))
+			continue
+		}
+		recurrence.Source = tripleStore.GetOne(triple.Subject, store.PropRecurrenceSource)
+
+		obligations = append(obligations, &RecurringObligation{
+			URI:            triple.Subject,
+			ObligationType: tripleStore.GetOne(triple.Subject, store.PropObligationType),
+			ArticleURI:     tripleStore.GetOne(triple.Subject, store.PropPartOf),
+			RegulationURI:  tripleStore.GetOne(triple.Subject, store.PropBelongsTo),
+			DutyBearer:     tripleStore.GetOne(triple.Subject, store.PropDutyBearer),
+			Text:           tripleStore.GetOne(triple.Subject, store.PropText),
+			Rule:           recurrence.Rule(),
+			Source:         recurrence.Source,
+			Recurrence:     recurrence,
+		})
+	}
+
+	sort.Slice(obligations, func(i, j int) bool {
+		return obligations[i].URI < obligations[j].URI
+	})
+	return obligations, ruleErrors
+}
+
+// Upcoming returns the due dates in (from, until] of every obligation, with
+// each series starting at anchor, sorted by date.
+func Upcoming(obligations []*RecurringObligation, anchor time.Time, from time.Time, until time.Time) []Event {
+	var events []Event
+	for _, obligation := range obligations {
+		for _, occurrence := range obligation.Recurrence.Occurrences(anchor, from, until) {
+			events = append(events, Event{Date: occurrence, Obligation: obligation})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Date.Equal(events[j].Date) {
+			return events[i].Date.Before(events[j].Date)
+		}
+		return events[i].Obligation.URI < events[j].Obligation.URI
+	})
+	return events
+}
+
+// FormatUpcomingTable formats upcoming events for terminal output.
+func FormatUpcomingTable(events []Event, from time.Time, until time.Time) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("Upcoming obligations %s to %s: %d\n\n",
+		from.Format("2006-01-02"), until.Format("2006-01-02"), len(events)))
+	if len(events) == 0 {
+		builder.WriteString("No recurring obligations due in this period.\n")
+		return builder.String()
+	}
+
+	builder.WriteString(fmt.Sprintf("  %-10s  %-50s  %-26s  %s\n", "Due", "Obligation", "Recurrence", "Source"))
+	builder.WriteString(fmt.Sprintf("  %s  %s  %s  %s\n",
+		strings.Repeat("-", 10), strings.Repeat("-", 50), strings.Repeat("-", 26), strings.Repeat("-", 10)))
+	for _, event := range events {
+		builder.WriteString(fmt.Sprintf("  %-10s  %-50s  %-26s  %s\n",
+			event.Date.Format("2006-01-02"),
+			truncate(event.Obligation.Label(), 50),
+			truncate(event.Obligation.Recurrence.Describe(), 26),
+			event.Obligation.Source))
+	}
+	return builder.String()
+}
+
+// FormatUpcomingJSON formats upcoming events as indented JSON.
+func FormatUpcomingJSON(events []Event) string {
+	if events == nil {
+		events = []Event{}
+	}
+	data, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(data)
+}
+
+// FormatICS renders the obligations as an iCalendar feed with one all-day
+// recurring VEVENT per obligation, starting at its first occurrence on or
+// after anchor. generatedAt is used for DTSTAMP.
+func FormatICS(obligations []*RecurringObligation, anchor time.Time, generatedAt time.Time) string {
+	var builder strings.Builder
+	writeLine := func(line string) {
+		builder.WriteString(foldICSLine(line))
+		builder.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//regula//Compliance Calendar//EN")
+	writeLine("CALSCALE:GREGORIAN")
+	writeLine("X-WR-CALNAME:Regulatory obligations")
+
+	dtstamp := generatedAt.UTC().Format("20060102T150405Z")
+	for _, obligation := range obligations {
+		start := obligation.Recurrence.Next(anchor, anchor.AddDate(0, 0, -1))
+		uidHash := sha256.Sum256([]byte(obligation.URI))
+
+		writeLine("BEGIN:VEVENT")
+		writeLine(fmt.Sprintf("UID:%x@regula", uidHash[:12]))
+		writeLine("DTSTAMP:" + dtstamp)
+		writeLine("DTSTART;VALUE=DATE:" + start.Format("20060102"))
+		writeLine("RRULE:" + obligation.Rule)
+		writeLine("SUMMARY:" + escapeICSText(obligation.Label()))
+
+		description := obligation.Recurrence.Describe()
+		if obligation.Text != "" {
+			description += "\n" + obligation.Text
+		}
+		if obligation.DutyBearer != "" {
+			description += "\nDuty bearer: " + obligation.DutyBearer
+		}
+		writeLine("DESCRIPTION:" + escapeICSText(description))
+		if strings.HasPrefix(obligation.ArticleURI, "http") {
+			writeLine("URL:" + obligation.ArticleURI)
+		}
+		writeLine("CATEGORIES:" + escapeICSText(obligation.ObligationType))
+		writeLine("END:VEVENT")
+	}
+
+	writeLine("END:VCALENDAR")
+	return builder.String()
+}
+
+// escapeICSText escapes a TEXT value per RFC 5545 section 3.3.11.
+func escapeICSText(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(text)
+}
+
+// foldICSLine folds a content line longer than 75 octets per RFC 5545
+// section 3.1, without splitting UTF-8 sequences.
+func foldICSLine(line string) string {
+	const maxOctets = 75
+	if len(line) <= maxOctets {
+		return line
+	}
+
+	var builder strings.Builder
+	lineLength := 0
+	for _, r := range line {
+		runeLength := len(string(r))
+		if lineLength+runeLength > maxOctets {
+			builder.WriteString("\r\n ")
+			lineLength = 1
+		}
+		builder.WriteRune(r)
+		lineLength += runeLength
+	}
+	return builder.String()
+}
+
+// shortName returns the last path segment of a URI.
+func shortName(uri string) string {
+	if index := strings.LastIndex(uri, "/"); index >= 0 {
+		return uri[index+1:]
+	}
+	return uri
+}
+
+func truncate(value string, maxLength int) string {
+	return textutil.Truncate(value, maxLength)
+}
@@ -0,0 +1,131 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+const testBase = "https://regula.dev/regulations/"
+
+func buildCalendarTestStore() *store.TripleStore {
+	tripleStore := store.NewTripleStore()
+
+	annual := testBase + "GDPR:Obligation:30:RecordKeepingObligation"
+	tripleStore.Add(annual, store.RDFType, store.ClassObligation)
+	tripleStore.Add(annual, "reg:obligationType", "RecordKeepingObligation")
+	tripleStore.Add(annual, store.PropPartOf, testBase+"GDPR:Art30")
+	tripleStore.Add(annual, "reg:dutyBearer", "Controller")
+	tripleStore.Add(annual, store.PropRecurrence, "FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=31")
+	tripleStore.Add(annual, store.PropRecurrenceSource, "annotation")
+
+	quarterly := testBase + "GDPR:Obligation:32:SecurityObligation"
+	tripleStore.Add(quarterly, store.RDFType, store.ClassObligation)
+	tripleStore.Add(quarterly, "reg:obligationType", "SecurityObligation")
+	tripleStore.Add(quarterly, store.PropPartOf, testBase+"GDPR:Art32")
+	tripleStore.Add(quarterly, store.PropRecurrence, "FREQ=MONTHLY;INTERVAL=3")
+	tripleStore.Add(quarterly, store.PropRecurrenceSource, "text")
+
+	oneOff := testBase + "GDPR:Obligation:33:BreachNotificationObligation"
+	tripleStore.Add(oneOff, store.RDFType, store.ClassObligation)
+	tripleStore.Add(oneOff, "reg:obligationType", "BreachNotificationObligation")
+
+	invalid := testBase + "GDPR:Obligation:35:ImpactAssessmentObligation"
+	tripleStore.Add(invalid, store.RDFType, store.ClassObligation)
+	tripleStore.Add(invalid, store.PropRecurrence, "FREQ=SOMETIMES")
+
+	return tripleStore
+}
+
+func TestCollectRecurringObligations(t *testing.T) {
+	obligations, ruleErrors := CollectRecurringObligations(buildCalendarTestStore())
+
+	if len(obligations) != 2 {
+		t.Fatalf("got %d obligations, want 2", len(obligations))
+	}
+	if len(ruleErrors) != 1 || !strings.Contains(ruleErrors[0].Error(), "Obligation:35") {
+		t.Errorf("expected one rule error naming the obligation, got %v", ruleErrors)
+	}
+
+	first := obligations[0]
+	if first.Label() != "GDPR:Art30 RecordKeepingObligation" {
+		t.Errorf("Label: got %q", first.Label())
+	}
+	if first.Source != "annotation" || first.DutyBearer != "Controller" {
+		t.Errorf("unexpected obligation: %+v", first)
+	}
+}
+
+func TestUpcoming(t *testing.T) {
+	obligations, _ := CollectRecurringObligations(buildCalendarTestStore())
+	anchor := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	events := Upcoming(obligations, anchor, from, until)
+
+	var got []string
+	for _, event := range events {
+		got = append(got, event.Date.Format("2006-01-02")+" "+shortName(event.Obligation.ArticleURI))
+	}
+	want := []string{"2026-01-31 GDPR:Art30", "2026-04-01 GDPR:Art32", "2026-07-01 GDPR:Art32"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	table := FormatUpcomingTable(events, from, until)
+	if !strings.Contains(table, "annually on Jan 31") || !strings.Contains(table, "quarterly") {
+		t.Errorf("table missing recurrence descriptions:\n%s", table)
+	}
+	if json := FormatUpcomingJSON(nil); json != "[]" {
+		t.Errorf("empty JSON: got %q", json)
+	}
+}
+
+func TestFormatICS(t *testing.T) {
+	obligations, _ := CollectRecurringObligations(buildCalendarTestStore())
+	anchor := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	generatedAt := time.Date(2026, time.March, 2, 10, 30, 0, 0, time.UTC)
+
+	ics := FormatICS(obligations, anchor, generatedAt)
+
+	for _, expected := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTAMP:20260302T103000Z\r\n",
+		"DTSTART;VALUE=DATE:20260131\r\n",
+		"RRULE:FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=31\r\n",
+		"DTSTART;VALUE=DATE:20260101\r\n",
+		"RRULE:FREQ=MONTHLY;INTERVAL=3\r\n",
+		"URL:" + testBase + "GDPR:Art30\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, expected) {
+			t.Errorf("ICS missing %q:\n%s", expected, ics)
+		}
+	}
+	if count := strings.Count(ics, "BEGIN:VEVENT"); count != 2 {
+		t.Errorf("got %d events, want 2", count)
+	}
+	if ics != FormatICS(obligations, anchor, generatedAt) {
+		t.Error("ICS output is not deterministic")
+	}
+}
+
+func TestICSTextHelpers(t *testing.T) {
+	if got := escapeICSText("a;b,c\\d\ne"); got != `a\;b\,c\\d\ne` {
+		t.Errorf("escapeICSText: got %q", got)
+	}
+
+	line := "DESCRIPTION:" + strings.Repeat("é", 60)
+	folded := foldICSLine(line)
+	for _, physical := range strings.Split(folded, "\r\n") {
+		if len(physical) > 75 {
+			t.Errorf("folded line is %d octets: %q", len(physical), physical)
+		}
+	}
+	if strings.ReplaceAll(folded, "\r\n ", "") != line {
+		t.Error("unfolding did not restore the original line")
+	}
+}
@@ -0,0 +1,461 @@
+// Package catalog caches a library's metadata in one file so listing,
+// filtering, and status need neither document graphs nor the separate
+// manifests they are drawn from.
+//
+// The catalog, catalog.json in the library directory, holds a row per
+// document with its statistics, tags, and collections; the validation and
+// link check history and cached conflict count from health.json; the
+// records of bulk downloads (downloads/manifest.json); and the progress of
+// the last crawl (crawl-state.json). It is plain JSON, so regula stays free
+// of cgo.
+//
+// The catalog is a cache, not an index on disk: every use reads and
+// decodes the whole file and filters the documents in memory, and checking
+// whether it is stale stats each source file. That is one small read in
+// place of the manifests, graphs, and conflict recount it replaces, but
+// the cost still grows with the number of documents.
+//
+// Open rebuilds the catalog when any of those files has changed since it
+// was built, so it never needs to be maintained by hand.
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/bulk"
+	"github.com/coolbeans/regula/pkg/crawler"
+	"github.com/coolbeans/regula/pkg/library"
+)
+
+// FileName is the catalog file name within a library directory.
+const FileName = "catalog.json"
+
+const catalogVersion = "1.0.0"
+
+// sourceFiles are the library files the catalog is built from, relative to
+// the library directory.
+var sourceFiles = []string{
+	"library.json",
+	"health.json",
+	filepath.Join("downloads", "manifest.json"),
+	"crawl-state.json",
+}
+
+// Document is the catalog row of a library document.
+type Document struct {
+	ID            string                 `json:"id"`
+	Name          string                 `json:"name"`
+	ShortName     string                 `json:"short_name,omitempty"`
+	Jurisdiction  string                 `json:"jurisdiction,omitempty"`
+	Format        string                 `json:"format,omitempty"`
+	Status        library.DocumentStatus `json:"status"`
+	LegalStatus   library.LegalStatus    `json:"legal_status,omitempty"`
+	EffectiveDate string                 `json:"effective_date,omitempty"`
+	Tags          []string               `json:"tags,omitempty"`
+	Collections   []string               `json:"collections,omitempty"`
+	AliasOf       string                 `json:"alias_of,omitempty"`
+	Triples       int                    `json:"triples"`
+	Articles      int                    `json:"articles"`
+	Definitions   int                    `json:"definitions"`
+	References    int                    `json:"references"`
+	Rights        int                    `json:"rights"`
+	Obligations   int                    `json:"obligations"`
+	IngestedAt    time.Time              `json:"ingested_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
+
+// Crawl summarizes the state of the last crawl.
+type Crawl struct {
+	Status     crawler.CrawlStatus     `json:"status"`
+	Seeds      int                     `json:"seeds"`
+	Frontier   int                     `json:"frontier"`
+	Visited    int                     `json:"visited"`
+	Statistics crawler.CrawlStateStats `json:"statistics"`
+	StartedAt  time.Time               `json:"started_at"`
+	UpdatedAt  time.Time               `json:"updated_at"`
+}
+
+// Catalog is the cached metadata of a library.
+type Catalog struct {
+	Version string    `json:"version"`
+	BuiltAt time.Time `json:"built_at"`
+	BaseURI string    `json:"base_uri"`
+
+	// Sources records the size and modification time of each source file
+	// ("" when missing), to tell when the catalog is stale.
+	Sources map[string]string `json:"sources"`
+
+	Documents   []Document                 `json:"documents"`
+	Collections []string                   `json:"collections,omitempty"`
+	Validations []library.ValidationRecord `json:"validations,omitempty"`
+	LinkChecks  []library.LinkCheckRecord  `json:"link_checks,omitempty"`
+	Conflicts   *library.ConflictCheck     `json:"conflicts,omitempty"`
+	Downloads   []*bulk.DownloadRecord     `json:"downloads,omitempty"`
+	Crawl       *Crawl                     `json:"crawl,omitempty"`
+
+	// byJurisdiction, byStatus, and byTag map values to positions in
+	// Documents; they are built in memory on load.
+	byJurisdiction map[string][]int
+	byStatus       map[string][]int
+	byTag          map[string][]int
+}
+
+// Open returns the catalog of the library at libraryPath, rebuilding and
+// saving it when it is missing or stale. The catalog of a library in
+// object storage is built in memory each time.
+func Open(libraryPath string) (*Catalog, error) {
+	if library.IsRemotePath(libraryPath) {
+		return Build(libraryPath)
+	}
+
+	catalog, err := Load(libraryPath)
+	if err == nil && !catalog.Stale(libraryPath) {
+		return catalog, nil
+	}
+	if catalog, err = Build(libraryPath); err != nil {
+		return nil, err
+	}
+	// A library that cannot be written to is cataloged on every use.
+	catalog.Save(libraryPath)
+	return catalog, nil
+}
+
+// Load reads a saved catalog without checking whether it is stale.
+func Load(libraryPath string) (*Catalog, error) {
+	data, err := os.ReadFile(filepath.Join(libraryPath, FileName))
+	if err != nil {
+		return nil, err
+	}
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse catalog: %w", err)
+	}
+	if catalog.Version != catalogVersion {
+		return nil, fmt.Errorf("catalog version %s is not %s", catalog.Version, catalogVersion)
+	}
+	catalog.index()
+	return &catalog, nil
+}
+
+// Build catalogs the library at libraryPath from its source files.
+func Build(libraryPath string) (*Catalog, error) {
+	sources := sourceStamps(libraryPath)
+	lib, err := library.Open(libraryPath)
+	if err != nil {
+		return nil, fmt.Errorf("library not found at %s: %w", libraryPath, err)
+	}
+
+	catalog := &Catalog{Version: catalogVersion, BuiltAt: time.Now().UTC(), BaseURI: lib.BaseURI(), Sources: sources, Documents: []Document{}}
+	memberships := make(map[string][]string)
+	for _, collection := range lib.ListCollections() {
+		catalog.Collections = append(catalog.Collections, collection.Name)
+		for _, documentID := range collection.Documents {
+			memberships[documentID] = append(memberships[documentID], collection.Name)
+		}
+	}
+	aliases := lib.Aliases()
+	for _, entry := range lib.ListDocuments() {
+		document := documentRow(entry, memberships[entry.ID])
+		document.AliasOf = aliases[entry.ID]
+		catalog.Documents = append(catalog.Documents, document)
+	}
+
+	record, err := lib.LoadHealth()
+	if err != nil {
+		return nil, err
+	}
+	catalog.Validations, catalog.LinkChecks = record.Validations, record.LinkChecks
+	if conflicts := record.Conflicts; conflicts != nil && conflicts.Fingerprint == lib.ReadyFingerprint() {
+		catalog.Conflicts = conflicts
+	}
+	// Results recorded before the history was kept are its only entries.
+	if len(catalog.Validations) == 0 && record.LastValidation != nil {
+		catalog.Validations = []library.ValidationRecord{*record.LastValidation}
+	}
+	if len(catalog.LinkChecks) == 0 && record.LastLinkCheck != nil {
+		catalog.LinkChecks = []library.LinkCheckRecord{*record.LastLinkCheck}
+	}
+
+	if !library.IsRemotePath(libraryPath) {
+		if err := catalog.addDownloads(filepath.Join(libraryPath, "downloads", "manifest.json")); err != nil {
+			return nil, err
+		}
+		if err := catalog.addCrawl(filepath.Join(libraryPath, "crawl-state.json")); err != nil {
+			return nil, err
+		}
+	}
+
+	catalog.index()
+	return catalog, nil
+}
+
+func documentRow(entry *library.DocumentEntry, collections []string) Document {
+	document := Document{
+		ID:            entry.ID,
+		Name:          entry.Name,
+		ShortName:     entry.ShortName,
+		Jurisdiction:  entry.Jurisdiction,
+		Format:        entry.Format,
+		Status:        entry.Status,
+		LegalStatus:   entry.LegalStatus,
+		EffectiveDate: entry.EffectiveDate,
+		Tags:          entry.Tags,
+		Collections:   collections,
+		IngestedAt:    entry.IngestedAt,
+		UpdatedAt:     entry.UpdatedAt,
+	}
+	if stats := entry.Stats; stats != nil {
+		document.Triples, document.Articles, document.Definitions = stats.TotalTriples, stats.Articles, stats.Definitions
+		document.References, document.Rights, document.Obligations = stats.References, stats.Rights, stats.Obligations
+	}
+	return document
+}
+
+func (catalog *Catalog) addDownloads(manifestPath string) error {
+	if _, err := os.Stat(manifestPath); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	manifest, err := bulk.LoadManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	for _, record := range manifest.Downloads {
+		catalog.Downloads = append(catalog.Downloads, record)
+	}
+	sort.Slice(catalog.Downloads, func(i, j int) bool {
+		return catalog.Downloads[i].Identifier < catalog.Downloads[j].Identifier
+	})
+	return nil
+}
+
+func (catalog *Catalog) addCrawl(statePath string) error {
+	if _, err := os.Stat(statePath); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	state, err := crawler.LoadState(statePath)
+	if err != nil {
+		return err
+	}
+	catalog.Crawl = &Crawl{
+		Status:     state.Status,
+		Seeds:      len(state.Seeds),
+		Frontier:   len(state.Frontier),
+		Visited:    len(state.Visited),
+		Statistics: state.Statistics,
+		StartedAt:  state.StartedAt,
+		UpdatedAt:  state.UpdatedAt,
+	}
+	return nil
+}
+
+// Save writes the catalog to the library directory.
+func (catalog *Catalog) Save(libraryPath string) error {
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal catalog: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(libraryPath, FileName), data, 0644); err != nil {
+		return fmt.Errorf("failed to write catalog: %w", err)
+	}
+	return nil
+}
+
+// Stale reports whether a source file has changed since the catalog was
+// built.
+func (catalog *Catalog) Stale(libraryPath string) bool {
+	current := sourceStamps(libraryPath)
+	for _, file := range sourceFiles {
+		if catalog.Sources[file] != current[file] {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceStamps identifies the current version of each source file by its
+// size and modification time.
+func sourceStamps(libraryPath string) map[string]string {
+	stamps := make(map[string]string, len(sourceFiles))
+	for _, file := range sourceFiles {
+		info, err := os.Stat(filepath.Join(libraryPath, file))
+		if err != nil {
+			stamps[file] = ""
+			continue
+		}
+		stamps[file] = fmt.Sprintf("%d@%d", info.Size(), info.ModTime().UnixNano())
+	}
+	return stamps
+}
+
+// index builds the in-memory lookup tables over Documents.
+func (catalog *Catalog) index() {
+	catalog.byJurisdiction = make(map[string][]int)
+	catalog.byStatus = make(map[string][]int)
+	catalog.byTag = make(map[string][]int)
+	for i, document := range catalog.Documents {
+		catalog.byJurisdiction[document.Jurisdiction] = append(catalog.byJurisdiction[document.Jurisdiction], i)
+		catalog.byStatus[string(document.Status)] = append(catalog.byStatus[string(document.Status)], i)
+		for _, tag := range document.Tags {
+			catalog.byTag[tag] = append(catalog.byTag[tag], i)
+		}
+	}
+}
+
+// Filter selects catalog documents. Empty fields match every document.
+type Filter struct {
+	Jurisdiction string
+	Status       library.DocumentStatus
+	LegalStatus  library.LegalStatus
+	Tag          string
+	Collection   string
+	Format       string
+
+	// Search matches the ID, name, or short name, case-insensitively.
+	Search string
+
+	// InForceOn (YYYY-MM-DD) matches documents in force on that day: not
+	// repealed, expired, or proposed, and effective by then when an
+	// effective date is recorded.
+	InForceOn string
+}
+
+// Find returns the documents matching filter, sorted by ID.
+func (catalog *Catalog) Find(filter Filter) []Document {
+	candidates := catalog.candidates(filter)
+	matches := make([]Document, 0, len(candidates))
+	search := strings.ToLower(filter.Search)
+	for _, i := range candidates {
+		document := catalog.Documents[i]
+		switch {
+		case filter.Jurisdiction != "" && document.Jurisdiction != filter.Jurisdiction,
+			filter.Status != "" && document.Status != filter.Status,
+			filter.LegalStatus != "" && document.LegalStatus != filter.LegalStatus,
+			filter.Tag != "" && !slices.Contains(document.Tags, filter.Tag),
+			filter.Collection != "" && !slices.Contains(document.Collections, filter.Collection),
+			filter.Format != "" && document.Format != filter.Format,
+			search != "" && !document.matches(search),
+			filter.InForceOn != "" && !document.inForceOn(filter.InForceOn):
+			continue
+		}
+		matches = append(matches, document)
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+	return matches
+}
+
+// candidates narrows the documents to scan with the most selective lookup
+// table the filter allows.
+func (catalog *Catalog) candidates(filter Filter) []int {
+	var lists [][]int
+	if filter.Jurisdiction != "" {
+		lists = append(lists, catalog.byJurisdiction[filter.Jurisdiction])
+	}
+	if filter.Status != "" {
+		lists = append(lists, catalog.byStatus[string(filter.Status)])
+	}
+	if filter.Tag != "" {
+		lists = append(lists, catalog.byTag[filter.Tag])
+	}
+	if len(lists) == 0 {
+		all := make([]int, len(catalog.Documents))
+		for i := range all {
+			all[i] = i
+		}
+		return all
+	}
+	return slices.MinFunc(lists, func(a, b []int) int { return len(a) - len(b) })
+}
+
+// matches reports whether the lowercase search text occurs in the
+// document's ID or names.
+func (document Document) matches(search string) bool {
+	for _, text := range []string{document.ID, document.Name, document.ShortName} {
+		if strings.Contains(strings.ToLower(text), search) {
+			return true
+		}
+	}
+	return false
+}
+
+// inForceOn reports whether the document applies on day (YYYY-MM-DD).
+// Effective dates share the layout, so they compare as strings.
+func (document Document) inForceOn(day string) bool {
+	switch document.LegalStatus {
+	case library.LegalStatusRepealed, library.LegalStatusExpired, library.LegalStatusProposed:
+		return false
+	}
+	return document.EffectiveDate == "" || document.EffectiveDate <= day
+}
+
+// Summary aggregates the catalog.
+type Summary struct {
+	Documents         int            `json:"documents"`
+	Triples           int            `json:"triples"`
+	Articles          int            `json:"articles"`
+	Definitions       int            `json:"definitions"`
+	References        int            `json:"references"`
+	Rights            int            `json:"rights"`
+	Obligations       int            `json:"obligations"`
+	ByJurisdiction    map[string]int `json:"by_jurisdiction"`
+	ByStatus          map[string]int `json:"by_status"`
+	ByLegalStatus     map[string]int `json:"by_legal_status,omitempty"`
+	Downloads         int            `json:"downloads"`
+	DownloadBytes     int64          `json:"download_bytes"`
+	DownloadsBySource map[string]int `json:"downloads_by_source,omitempty"`
+	Validations       int            `json:"validations"`
+	LinkChecks        int            `json:"link_checks"`
+	OpenConflicts     *int           `json:"open_conflicts,omitempty"`
+	Crawl             *Crawl         `json:"crawl,omitempty"`
+}
+
+// Summarize aggregates the given documents, or every document when
+// documents is nil, with the library-wide downloads, history, and crawl.
+func (catalog *Catalog) Summarize(documents []Document) *Summary {
+	if documents == nil {
+		documents = catalog.Documents
+	}
+	summary := &Summary{
+		ByJurisdiction:    make(map[string]int),
+		ByStatus:          make(map[string]int),
+		ByLegalStatus:     make(map[string]int),
+		DownloadsBySource: make(map[string]int),
+		Validations:       len(catalog.Validations),
+		LinkChecks:        len(catalog.LinkChecks),
+		Crawl:             catalog.Crawl,
+	}
+	for _, document := range documents {
+		summary.Documents++
+		summary.Triples += document.Triples
+		summary.Articles += document.Articles
+		summary.Definitions += document.Definitions
+		summary.References += document.References
+		summary.Rights += document.Rights
+		summary.Obligations += document.Obligations
+		summary.ByStatus[string(document.Status)]++
+		if document.Jurisdiction != "" {
+			summary.ByJurisdiction[document.Jurisdiction]++
+		}
+		if document.LegalStatus != "" {
+			summary.ByLegalStatus[string(document.LegalStatus)]++
+		}
+	}
+	for _, download := range catalog.Downloads {
+		summary.Downloads++
+		summary.DownloadBytes += download.SizeBytes
+		summary.DownloadsBySource[download.SourceName]++
+	}
+	if catalog.Conflicts != nil {
+		count := catalog.Conflicts.Count
+		summary.OpenConflicts = &count
+	}
+	return summary
+}
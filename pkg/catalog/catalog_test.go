@@ -0,0 +1,134 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/bulk"
+	"github.com/coolbeans/regula/pkg/library"
+)
+
+const testDocument = `STATE CONSUMER PRIVACY ACT
+
+CHAPTER 1
+General Provisions
+
+Article 1
+Scope
+
+Section 1
+Scope
+
+(a) This Act applies to controllers established in the State.
+`
+
+func setupLibrary(t *testing.T) (*library.Library, string) {
+	t.Helper()
+	libraryPath := filepath.Join(t.TempDir(), "lib")
+	lib, err := library.Init(libraryPath, "")
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	for _, document := range []struct{ id, jurisdiction string }{
+		{"us-va-vcdpa", "US-VA"}, {"us-co-cpa", "US-CO"}, {"us-va-draft", "US-VA"},
+	} {
+		if _, err := lib.AddDocument(document.id, []byte(testDocument), library.AddOptions{Format: "us", Jurisdiction: document.jurisdiction}); err != nil {
+			t.Fatalf("AddDocument(%s) failed: %v", document.id, err)
+		}
+	}
+
+	inForce, proposed, effective := library.LegalStatusInForce, library.LegalStatusProposed, "2023-01-01"
+	lib.SetMetadata("us-va-vcdpa", library.MetadataUpdate{LegalStatus: &inForce, EffectiveDate: &effective, AddTags: []string{"privacy"}})
+	lib.SetMetadata("us-co-cpa", library.MetadataUpdate{AddTags: []string{"privacy"}})
+	lib.SetMetadata("us-va-draft", library.MetadataUpdate{LegalStatus: &proposed})
+	if _, err := lib.CreateCollection("state-privacy", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lib.AddToCollection("state-privacy", "us-va-vcdpa", "us-co-cpa"); err != nil {
+		t.Fatal(err)
+	}
+	return lib, libraryPath
+}
+
+func TestFind(t *testing.T) {
+	_, libraryPath := setupLibrary(t)
+	catalog, err := Open(libraryPath)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	for name, test := range map[string]struct {
+		filter Filter
+		want   []string
+	}{
+		"all":          {Filter{}, []string{"us-co-cpa", "us-va-draft", "us-va-vcdpa"}},
+		"jurisdiction": {Filter{Jurisdiction: "US-VA"}, []string{"us-va-draft", "us-va-vcdpa"}},
+		"tag":          {Filter{Tag: "privacy", Jurisdiction: "US-VA"}, []string{"us-va-vcdpa"}},
+		"collection":   {Filter{Collection: "state-privacy"}, []string{"us-co-cpa", "us-va-vcdpa"}},
+		"legal status": {Filter{LegalStatus: library.LegalStatusProposed}, []string{"us-va-draft"}},
+		"search":       {Filter{Search: "VA-"}, []string{"us-va-draft", "us-va-vcdpa"}},
+		"in force":     {Filter{InForceOn: "2022-06-01"}, []string{"us-co-cpa"}},
+		"no match":     {Filter{Tag: "health"}, nil},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			for _, document := range catalog.Find(test.filter) {
+				got = append(got, document.ID)
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("Find = %v, want %v", got, test.want)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Errorf("Find = %v, want %v", got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenRebuildsWhenStale(t *testing.T) {
+	lib, libraryPath := setupLibrary(t)
+	if _, err := Open(libraryPath); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(libraryPath, FileName)); err != nil {
+		t.Fatalf("catalog not saved: %v", err)
+	}
+
+	// Sources changed after the catalog was saved are picked up.
+	if err := lib.RecordValidation(library.ValidationRecord{Score: 0.9, Status: "PASS", ValidatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := lib.RemoveDocument("us-va-draft"); err != nil {
+		t.Fatal(err)
+	}
+	manifest := bulk.NewDownloadManifest()
+	manifest.RecordDownload(&bulk.DownloadRecord{Identifier: "uscode-title-42", SourceName: "uscode", SizeBytes: 2048})
+	if err := os.MkdirAll(filepath.Join(libraryPath, "downloads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := manifest.SaveManifest(filepath.Join(libraryPath, "downloads", "manifest.json")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lib.HealthSummary(); err != nil {
+		t.Fatal(err)
+	}
+
+	catalog, err := Open(libraryPath)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	summary := catalog.Summarize(nil)
+	if summary.Documents != 2 || summary.Validations != 1 || summary.Downloads != 1 || summary.DownloadsBySource["uscode"] != 1 {
+		t.Errorf("summary = %+v", summary)
+	}
+	if summary.OpenConflicts == nil {
+		t.Error("cached conflict count missing from the summary")
+	}
+	if summary.ByJurisdiction["US-VA"] != 1 || summary.ByLegalStatus["in-force"] != 1 {
+		t.Errorf("by jurisdiction %v, by legal status %v", summary.ByJurisdiction, summary.ByLegalStatus)
+	}
+}
@@ -90,7 +90,7 @@ func mapReferenceTargetToCitationType(target extract.ReferenceTarget) CitationTy
 	case extract.TargetTreaty:
 		return CitationTypeTreaty
 	case extract.TargetArticle, extract.TargetParagraph, extract.TargetPoint,
-		extract.TargetChapter, extract.TargetSection:
+		extract.TargetChapter, extract.TargetSection, extract.TargetAnnex:
 		return CitationTypeStatute
 	default:
 		return CitationTypeUnknown
@@ -0,0 +1,335 @@
+// Package codegen generates source code from a regulation knowledge graph,
+// so application code can reference provisions with compile-time checks
+// instead of string identifiers.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// maxCommentLength bounds the provision text quoted in a doc comment.
+const maxCommentLength = 100
+
+// GoOptions configures Go code generation.
+type GoOptions struct {
+	// Package is the name of the generated package.
+	Package string
+	// Source names the document the code was generated from, for the
+	// generated-code header.
+	Source string
+}
+
+// GenerateGo returns a gofmt-formatted Go source file declaring the
+// articles, defined terms, rights, and obligations in the store as typed
+// values, with their URIs and citations.
+func GenerateGo(tripleStore *store.TripleStore, options GoOptions) ([]byte, error) {
+	if !token.IsIdentifier(options.Package) || token.IsKeyword(options.Package) {
+		return nil, fmt.Errorf("invalid package name %q", options.Package)
+	}
+
+	model := BuildModel(tripleStore)
+	names := newNameSet("Document", "DocumentURI", "Article", "Term", "Right", "Obligation",
+		"RightType", "ObligationType", "Party", "Articles", "Terms", "Rights", "Obligations")
+
+	// Identifiers, claimed in declaration order so collisions resolve the
+	// same way on every run.
+	articleNames := make(map[*Article]string)
+	for _, article := range model.Articles {
+		articleNames[article] = names.claim(identifier("Art "+article.Number, "Art"))
+	}
+	termNames := make([]string, len(model.Terms))
+	for i, term := range model.Terms {
+		termNames[i] = names.claim(identifier("Term "+term.Term, "Term"))
+	}
+	rightNames := make([]string, len(model.Rights))
+	for i, right := range model.Rights {
+		rightNames[i] = names.claim(identifier(articleNames[right.article]+" "+right.Type, "Right"))
+	}
+	obligationNames := make([]string, len(model.Obligations))
+	for i, obligation := range model.Obligations {
+		obligationNames[i] = names.claim(identifier(articleNames[obligation.article]+" "+obligation.Type, "Obligation"))
+	}
+	rightTypes := constantNames(model.RightTypes(), "Right", "GenericRight", names)
+	obligationTypes := constantNames(model.ObligationTypes(), "Obligation", "GenericObligation", names)
+	parties := make(map[string]string)
+	for _, party := range model.Parties() {
+		parties[party] = names.claim(identifier(party, "Party"))
+	}
+	label := model.Document
+
+	var b bytes.Buffer
+	header := "// Code generated by regula"
+	if options.Source != "" {
+		header += " from " + options.Source
+	}
+	fmt.Fprintf(&b, "%s. DO NOT EDIT.\n\n", header)
+	fmt.Fprintf(&b, "// Package %s references the provisions of %s.\n", options.Package, orDefault(label, "the regulation"))
+	fmt.Fprintf(&b, "package %s\n\n", options.Package)
+
+	fmt.Fprintf(&b, "// Document is the short name of the regulation.\nconst Document = %s\n\n", strconv.Quote(label))
+	fmt.Fprintf(&b, "// DocumentURI is the regulation's URI in the knowledge graph.\nconst DocumentURI = %s\n\n", strconv.Quote(model.URI))
+
+	b.WriteString(`// Article is an article of the regulation.
+type Article struct {
+	Number string
+	Title  string
+	URI    string
+}
+
+// Citation returns the article's citation, such as "GDPR Art. 17".
+func (a Article) Citation() string {
+	return Document + " Art. " + a.Number
+}
+
+// Term is a defined term and the article that defines it.
+type Term struct {
+	Term       string
+	Definition string
+	Article    Article
+	URI        string
+}
+
+// RightType is a kind of right.
+type RightType string
+
+// ObligationType is a kind of obligation.
+type ObligationType string
+
+// Party is a beneficiary of a right or a bearer of an obligation.
+type Party string
+
+// Right is a right granted by an article.
+type Right struct {
+	Type          RightType
+	Beneficiaries []Party
+	Article       Article
+	URI           string
+}
+
+// Obligation is an obligation imposed by an article. Prohibition is set
+// for duties phrased as "shall not".
+type Obligation struct {
+	Type        ObligationType
+	DutyBearers []Party
+	Prohibition bool
+	Article     Article
+	URI         string
+}
+`)
+
+	writeConstants(&b, "RightType", "Right types.", rightTypes)
+	writeConstants(&b, "ObligationType", "Obligation types.", obligationTypes)
+	writeConstants(&b, "Party", "Parties.", parties)
+
+	if len(model.Articles) > 0 {
+		b.WriteString("\n// Articles.\nvar (\n")
+		for _, article := range model.Articles {
+			comment := "Article " + article.Number
+			if article.Title != "" {
+				comment += ": " + article.Title
+			}
+			fmt.Fprintf(&b, "\t// %s is %s.\n", articleNames[article], commentText(comment))
+			fmt.Fprintf(&b, "\t%s = Article{Number: %s, Title: %s, URI: %s}\n",
+				articleNames[article], strconv.Quote(article.Number), strconv.Quote(article.Title), strconv.Quote(article.URI))
+		}
+		b.WriteString(")\n")
+	}
+
+	if len(model.Terms) > 0 {
+		b.WriteString("\n// Defined terms.\nvar (\n")
+		for i, term := range model.Terms {
+			fmt.Fprintf(&b, "\t// %s is %s.\n", termNames[i], commentText(fmt.Sprintf("%q, defined in Article %s", term.Term, term.Article)))
+			fmt.Fprintf(&b, "\t%s = Term{Term: %s, Definition: %s, Article: %s, URI: %s}\n",
+				termNames[i], strconv.Quote(term.Term), strconv.Quote(term.Definition), articleNames[term.article], strconv.Quote(term.URI))
+		}
+		b.WriteString(")\n")
+	}
+
+	if len(model.Rights) > 0 {
+		b.WriteString("\n// Rights.\nvar (\n")
+		for i, right := range model.Rights {
+			writeProvisionComment(&b, rightNames[i], right.Type, "granted", right.Article, right.Text, false)
+			fmt.Fprintf(&b, "\t%s = Right{Type: %s, Beneficiaries: %s, Article: %s, URI: %s}\n",
+				rightNames[i], rightTypes[right.Type], partyList(right.Beneficiaries, parties), articleNames[right.article], strconv.Quote(right.URI))
+		}
+		b.WriteString(")\n")
+	}
+
+	if len(model.Obligations) > 0 {
+		b.WriteString("\n// Obligations.\nvar (\n")
+		for i, obligation := range model.Obligations {
+			writeProvisionComment(&b, obligationNames[i], obligation.Type, "imposed", obligation.Article, obligation.Text, obligation.Prohibition)
+			fmt.Fprintf(&b, "\t%s = Obligation{Type: %s, DutyBearers: %s, Prohibition: %t, Article: %s, URI: %s}\n",
+				obligationNames[i], obligationTypes[obligation.Type], partyList(obligation.DutyBearers, parties),
+				obligation.Prohibition, articleNames[obligation.article], strconv.Quote(obligation.URI))
+		}
+		b.WriteString(")\n")
+	}
+
+	articleList := make([]string, len(model.Articles))
+	for i, article := range model.Articles {
+		articleList[i] = articleNames[article]
+	}
+	writeList(&b, "Articles", "Article", "Articles lists the articles in order.", articleList)
+	writeList(&b, "Terms", "Term", "Terms lists the defined terms.", termNames)
+	writeList(&b, "Rights", "Right", "Rights lists the rights in article order.", rightNames)
+	writeList(&b, "Obligations", "Obligation", "Obligations lists the obligations in article order.", obligationNames)
+
+	formatted, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+// PackageName derives a Go package name from a document identifier, such
+// as "ukdpa2018" from "UK-DPA2018".
+func PackageName(documentID string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(documentID) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		}
+	}
+	name := sb.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) || token.IsKeyword(name) {
+		name = "reg" + name
+	}
+	return name
+}
+
+// constantNames names a constant for each provision type; the generic
+// type, which would collide with a struct name, is renamed.
+func constantNames(types []string, generic, genericName string, names *nameSet) map[string]string {
+	constants := make(map[string]string)
+	for _, value := range types {
+		if value == generic {
+			constants[value] = names.claim(genericName)
+		} else {
+			constants[value] = names.claim(identifier(value, generic))
+		}
+	}
+	return constants
+}
+
+func writeConstants(b *bytes.Buffer, typeName, comment string, constants map[string]string) {
+	if len(constants) == 0 {
+		return
+	}
+	values := make([]string, 0, len(constants))
+	for value := range constants {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	fmt.Fprintf(b, "\n// %s\nconst (\n", comment)
+	for _, value := range values {
+		fmt.Fprintf(b, "\t%s %s = %s\n", constants[value], typeName, strconv.Quote(value))
+	}
+	b.WriteString(")\n")
+}
+
+func writeProvisionComment(b *bytes.Buffer, name, provisionType, verb, article, text string, prohibition bool) {
+	comment := fmt.Sprintf("%s is the %s %s by Article %s", name,
+		strings.ToLower(textutil.Humanize(provisionType)), verb, article)
+	if prohibition {
+		comment += " (a prohibition)"
+	}
+	fmt.Fprintf(b, "\t// %s.\n", commentText(comment))
+	if text != "" {
+		fmt.Fprintf(b, "\t// Matched text: %q.\n", textutil.Truncate(text, maxCommentLength))
+	}
+}
+
+func writeList(b *bytes.Buffer, name, elementType, comment string, elements []string) {
+	fmt.Fprintf(b, "\n// %s\nvar %s = []%s{", comment, name, elementType)
+	if len(elements) > 0 {
+		b.WriteString("\n")
+		for _, element := range elements {
+			fmt.Fprintf(b, "\t%s,\n", element)
+		}
+	}
+	b.WriteString("}\n")
+}
+
+func partyList(parties []string, constants map[string]string) string {
+	if len(parties) == 0 {
+		return "nil"
+	}
+	names := make([]string, len(parties))
+	for i, party := range parties {
+		names[i] = constants[party]
+	}
+	return "[]Party{" + strings.Join(names, ", ") + "}"
+}
+
+// commentText flattens text for a single-line comment.
+func commentText(text string) string {
+	return textutil.Truncate(strings.Join(strings.Fields(text), " "), maxCommentLength*2)
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// identifier converts text to an exported Go identifier by capitalizing
+// its alphanumeric runs, as in "personal data" to "PersonalData". Runs
+// after the first that start with a digit are joined with an underscore,
+// as in "Art 1798.100" to "Art1798_100". prefix is used when the text
+// yields an identifier that does not start with a letter.
+func identifier(text, prefix string) string {
+	var words []string
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	}) {
+		words = append(words, strings.ToUpper(word[:1])+word[1:])
+	}
+
+	var sb strings.Builder
+	for i, word := range words {
+		if i > 0 && unicode.IsDigit(rune(word[0])) && unicode.IsDigit(rune(words[i-1][len(words[i-1])-1])) {
+			sb.WriteString("_")
+		}
+		sb.WriteString(word)
+	}
+	name := sb.String()
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = prefix + name
+	}
+	return name
+}
+
+// nameSet hands out unique identifiers, suffixing a number on collision.
+type nameSet struct {
+	used map[string]bool
+}
+
+func newNameSet(reserved ...string) *nameSet {
+	set := &nameSet{used: make(map[string]bool)}
+	for _, name := range reserved {
+		set.used[name] = true
+	}
+	return set
+}
+
+func (set *nameSet) claim(name string) string {
+	candidate := name
+	for suffix := 2; set.used[candidate]; suffix++ {
+		candidate = fmt.Sprintf("%s%d", name, suffix)
+	}
+	set.used[candidate] = true
+	return candidate
+}
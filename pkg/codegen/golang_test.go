@@ -0,0 +1,161 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func codegenTestStore() *store.TripleStore {
+	ts := store.NewTripleStore()
+	ts.Add("reg:TEST", store.RDFSLabel, "TEST")
+
+	for _, article := range []struct{ uri, number, title string }{
+		{"reg:TEST:Art4", "4", "Definitions"},
+		{"reg:TEST:Art15", "15", "Right of access"},
+		{"reg:TEST:Art33", "33", "Notification of a “breach”"},
+	} {
+		ts.Add(article.uri, store.RDFType, store.ClassArticle)
+		ts.Add(article.uri, store.PropNumber, article.number)
+		ts.Add(article.uri, store.PropTitle, article.title)
+		ts.Add(article.uri, store.PropBelongsTo, "reg:TEST")
+	}
+
+	for _, term := range []string{"personal data", "Personal-Data"} {
+		uri := "reg:TEST:Term:" + term
+		ts.Add(uri, store.RDFType, store.ClassDefinedTerm)
+		ts.Add(uri, store.PropTerm, term)
+		ts.Add(uri, store.PropDefinition, "any information relating to a \"natural person\"")
+		ts.Add(uri, store.PropDefinedIn, "reg:TEST:Art4")
+	}
+
+	ts.Add("reg:TEST:Right:15:RightOfAccess", store.RDFType, store.ClassRight)
+	ts.Add("reg:TEST:Right:15:RightOfAccess", store.PropRightType, "RightOfAccess")
+	ts.Add("reg:TEST:Right:15:RightOfAccess", store.PropBeneficiary, "DataSubject")
+	ts.Add("reg:TEST:Right:15:RightOfAccess", store.PropPartOf, "reg:TEST:Art15")
+
+	ts.Add("reg:TEST:Obligation:33:BreachNotificationObligation", store.RDFType, store.ClassObligation)
+	ts.Add("reg:TEST:Obligation:33:BreachNotificationObligation", store.PropObligationType, "BreachNotificationObligation")
+	ts.Add("reg:TEST:Obligation:33:BreachNotificationObligation", store.PropDutyBearer, "Controller")
+	ts.Add("reg:TEST:Obligation:33:BreachNotificationObligation", store.PropPartOf, "reg:TEST:Art33")
+	ts.Add("reg:TEST:Obligation:33:Obligation", store.RDFType, store.ClassObligation)
+	ts.Add("reg:TEST:Obligation:33:Obligation", store.PropObligationType, "Obligation")
+	ts.Add("reg:TEST:Obligation:33:Obligation", store.PropIsProhibition, "true")
+	ts.Add("reg:TEST:Obligation:33:Obligation", store.PropPartOf, "reg:TEST:Art33")
+	return ts
+}
+
+// typeCheck parses and type-checks generated source, returning the
+// package scope.
+func typeCheck(t *testing.T, source []byte) *types.Scope {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "generated.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, source)
+	}
+	config := types.Config{Importer: importer.Default()}
+	pkg, err := config.Check("test", fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatalf("generated code does not type-check: %v\n%s", err, source)
+	}
+	return pkg.Scope()
+}
+
+func TestGenerateGo(t *testing.T) {
+	source, err := GenerateGo(codegenTestStore(), GoOptions{Package: "test", Source: "test.txt"})
+	if err != nil {
+		t.Fatalf("GenerateGo: %v", err)
+	}
+	if !strings.HasPrefix(string(source), "// Code generated by regula from test.txt. DO NOT EDIT.\n") {
+		t.Errorf("missing generated-code header:\n%.200s", source)
+	}
+
+	scope := typeCheck(t, source)
+	for name, want := range map[string]string{
+		"Art4":                              "test.Article",
+		"Art33":                             "test.Article",
+		"TermPersonalData":                  "test.Term",
+		"TermPersonalData2":                 "test.Term",
+		"Art15RightOfAccess":                "test.Right",
+		"Art33BreachNotificationObligation": "test.Obligation",
+		"Art33Obligation":                   "test.Obligation",
+		"RightOfAccess":                     "test.RightType",
+		"GenericObligation":                 "test.ObligationType",
+		"Controller":                        "test.Party",
+		"Obligations":                       "[]test.Obligation",
+	} {
+		object := scope.Lookup(name)
+		if object == nil {
+			t.Errorf("%s not declared", name)
+			continue
+		}
+		if got := object.Type().String(); got != want {
+			t.Errorf("%s has type %s, want %s", name, got, want)
+		}
+	}
+
+	for _, want := range []string{
+		`Art33Obligation = Obligation{Type: GenericObligation, DutyBearers: nil, Prohibition: true, Article: Art33,`,
+		`Beneficiaries: []Party{DataSubject}, Article: Art15,`,
+		`// Art33 is Article 33: Notification of a “breach”.`,
+		"Articles = []Article{\n\tArt4,\n\tArt15,\n\tArt33,\n}",
+	} {
+		if !strings.Contains(string(source), want) {
+			t.Errorf("generated code missing %q:\n%s", want, source)
+		}
+	}
+}
+
+func TestGenerateGo_Empty(t *testing.T) {
+	source, err := GenerateGo(store.NewTripleStore(), GoOptions{Package: "empty"})
+	if err != nil {
+		t.Fatalf("GenerateGo: %v", err)
+	}
+	typeCheck(t, source)
+}
+
+func TestGenerateGo_InvalidPackage(t *testing.T) {
+	for _, name := range []string{"", "func", "uk-dpa"} {
+		if _, err := GenerateGo(codegenTestStore(), GoOptions{Package: name}); err == nil {
+			t.Errorf("expected an error for package %q", name)
+		}
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"personal data":        "PersonalData",
+		"Art 1798.100":         "Art1798_100",
+		"Art5 RightOfAccess":   "Art5RightOfAccess",
+		"3rd party":            "X3rdParty",
+		"‘controller’":         "Controller",
+		"data-protection act2": "DataProtectionAct2",
+	}
+	for text, want := range tests {
+		if got := identifier(text, "X"); got != want {
+			t.Errorf("identifier(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	tests := map[string]string{
+		"GDPR":       "gdpr",
+		"UK-DPA2018": "ukdpa2018",
+		"2018-ACT":   "reg2018act",
+		"TYPE":       "regtype",
+		"":           "reg",
+	}
+	for id, want := range tests {
+		if got := PackageName(id); got != want {
+			t.Errorf("PackageName(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package codegen
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect of generated schemas.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// GenerateJSONSchema returns a JSON Schema for the model's JSON form (see
+// Model.ToJSON). Article numbers, right and obligation types, and parties
+// are constrained to the values found in the document, so extractions from
+// another document, or a later run that finds new types, fail validation.
+// id is the schema's $id and may be empty.
+func GenerateJSONSchema(model *Model, id string) ([]byte, error) {
+	title := model.Document
+	if title == "" {
+		title = "Regulation"
+	}
+
+	schema := map[string]any{
+		"$schema":     JSONSchemaDraft,
+		"title":       title,
+		"description": "Provisions of " + title + " extracted by regula: articles, defined terms, rights, and obligations.",
+		"type":        "object",
+		"required":    []string{"document", "uri", "articles", "terms", "rights", "obligations"},
+		"properties": map[string]any{
+			"document":    constString(model.Document),
+			"uri":         constString(model.URI),
+			"articles":    arrayOf("Article"),
+			"terms":       arrayOf("Term"),
+			"rights":      arrayOf("Right"),
+			"obligations": arrayOf("Obligation"),
+		},
+		"additionalProperties": false,
+		"$defs": map[string]any{
+			"ArticleNumber":  enumString("An article number of "+title+".", model.ArticleNumbers()),
+			"RightType":      enumString("A kind of right.", model.RightTypes()),
+			"ObligationType": enumString("A kind of obligation.", model.ObligationTypes()),
+			"Party":          enumString("A beneficiary of a right or a bearer of an obligation.", model.Parties()),
+			"Article": object("An article of the regulation.", []string{"number", "uri"}, map[string]any{
+				"number": ref("ArticleNumber"),
+				"title":  plainString(),
+				"uri":    uriString(),
+			}),
+			"Term": object("A defined term and the article that defines it.", []string{"term", "article", "uri"}, map[string]any{
+				"term":       plainString(),
+				"definition": plainString(),
+				"article":    ref("ArticleNumber"),
+				"uri":        uriString(),
+			}),
+			"Right": object("A right granted by an article.", []string{"type", "article", "uri"}, map[string]any{
+				"type":          ref("RightType"),
+				"beneficiaries": arrayOf("Party"),
+				"article":       ref("ArticleNumber"),
+				"text":          plainString(),
+				"uri":           uriString(),
+			}),
+			"Obligation": object("An obligation imposed by an article; prohibition is set for duties phrased as \"shall not\".", []string{"type", "prohibition", "article", "uri"}, map[string]any{
+				"type":         ref("ObligationType"),
+				"duty_bearers": arrayOf("Party"),
+				"prohibition":  map[string]any{"type": "boolean"},
+				"article":      ref("ArticleNumber"),
+				"text":         plainString(),
+				"uri":          uriString(),
+			}),
+		},
+	}
+	if id != "" {
+		schema["$id"] = id
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// SchemaID returns a default $id for a document's schema, such as
+// "https://regula.dev/schemas/gdpr.schema.json".
+func SchemaID(documentID string) string {
+	return "https://regula.dev/schemas/" + strings.ToLower(documentID) + ".schema.json"
+}
+
+func object(description string, required []string, properties map[string]any) map[string]any {
+	return map[string]any{
+		"description":          description,
+		"type":                 "object",
+		"required":             required,
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/$defs/" + name}
+}
+
+func arrayOf(name string) map[string]any {
+	return map[string]any{"type": "array", "items": ref(name)}
+}
+
+func plainString() map[string]any {
+	return map[string]any{"type": "string"}
+}
+
+func uriString() map[string]any {
+	return map[string]any{"type": "string", "format": "uri"}
+}
+
+func constString(value string) map[string]any {
+	return map[string]any{"type": "string", "const": value}
+}
+
+// enumString constrains a string to values, or accepts any string when the
+// document has none (an empty enum would reject everything).
+func enumString(description string, values []string) map[string]any {
+	schema := map[string]any{"description": description, "type": "string"}
+	if len(values) > 0 {
+		schema["enum"] = values
+	}
+	return schema
+}
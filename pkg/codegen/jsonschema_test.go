@@ -0,0 +1,129 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// validate checks value against the subset of JSON Schema the generator
+// emits: $ref, type, const, enum, required, properties,
+// additionalProperties, and items.
+func validate(root, schema map[string]any, value any, path string) error {
+	if reference, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(reference, "#/$defs/")
+		return validate(root, root["$defs"].(map[string]any)[name].(map[string]any), value, path)
+	}
+	if want, ok := schema["const"]; ok && value != want {
+		return fmt.Errorf("%s: %v is not %v", path, value, want)
+	}
+	if values, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, allowed := range values {
+			found = found || allowed == value
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not in the enum", path, value)
+		}
+	}
+	switch schema["type"] {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: %v is not a string", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: %v is not a boolean", path, value)
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: %v is not an array", path, value)
+		}
+		for i, item := range items {
+			if err := validate(root, schema["items"].(map[string]any), item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: %v is not an object", path, value)
+		}
+		for _, name := range schema["required"].([]any) {
+			if _, ok := object[name.(string)]; !ok {
+				return fmt.Errorf("%s: missing %s", path, name)
+			}
+		}
+		properties := schema["properties"].(map[string]any)
+		for name, property := range object {
+			propertySchema, ok := properties[name].(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s: unexpected property %s", path, name)
+			}
+			if err := validate(root, propertySchema, property, path+"."+name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func decode(t *testing.T, data []byte) any {
+	t.Helper()
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	return value
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	model := BuildModel(codegenTestStore())
+	schemaJSON, err := GenerateJSONSchema(model, SchemaID("TEST"))
+	if err != nil {
+		t.Fatalf("GenerateJSONSchema: %v", err)
+	}
+	schema := decode(t, schemaJSON).(map[string]any)
+	if schema["$schema"] != JSONSchemaDraft || schema["$id"] != "https://regula.dev/schemas/test.schema.json" {
+		t.Errorf("$schema = %v, $id = %v", schema["$schema"], schema["$id"])
+	}
+
+	defs := schema["$defs"].(map[string]any)
+	numbers := defs["ArticleNumber"].(map[string]any)["enum"].([]any)
+	if len(numbers) != 3 || numbers[0] != "4" || numbers[2] != "33" {
+		t.Errorf("ArticleNumber enum = %v", numbers)
+	}
+	if _, ok := defs["RightType"].(map[string]any)["enum"]; !ok {
+		t.Error("RightType has no enum")
+	}
+
+	data, err := model.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+	if err := validate(schema, schema, decode(t, data), "$"); err != nil {
+		t.Errorf("model does not validate against its schema: %v", err)
+	}
+
+	// An obligation type the document does not have is rejected.
+	invalid := strings.Replace(string(data), `"BreachNotificationObligation"`, `"MadeUpObligation"`, 1)
+	if err := validate(schema, schema, decode(t, []byte(invalid)), "$"); err == nil {
+		t.Error("expected an unknown obligation type to fail validation")
+	}
+}
+
+func TestGenerateJSONSchema_EmptyEnums(t *testing.T) {
+	schemaJSON, err := GenerateJSONSchema(BuildModel(codegenStoreWithoutProvisions()), "")
+	if err != nil {
+		t.Fatalf("GenerateJSONSchema: %v", err)
+	}
+	schema := decode(t, schemaJSON).(map[string]any)
+	if _, ok := schema["$id"]; ok {
+		t.Error("expected no $id")
+	}
+	if _, ok := schema["$defs"].(map[string]any)["Party"].(map[string]any)["enum"]; ok {
+		t.Error("expected no enum for a document without parties")
+	}
+}
@@ -0,0 +1,249 @@
+package codegen
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// Model is the regulation's provisions as the generators describe them:
+// its articles, defined terms, rights, and obligations. Its JSON form is
+// the instance document the generated JSON Schema validates.
+type Model struct {
+	Document    string        `json:"document"`
+	URI         string        `json:"uri"`
+	Articles    []*Article    `json:"articles"`
+	Terms       []*Term       `json:"terms"`
+	Rights      []*Right      `json:"rights"`
+	Obligations []*Obligation `json:"obligations"`
+}
+
+// Article is an article of the regulation.
+type Article struct {
+	Number string `json:"number"`
+	Title  string `json:"title,omitempty"`
+	URI    string `json:"uri"`
+	order  int
+}
+
+// Term is a defined term; Article is the number of the defining article.
+type Term struct {
+	Term       string `json:"term"`
+	Definition string `json:"definition,omitempty"`
+	Article    string `json:"article"`
+	URI        string `json:"uri"`
+	article    *Article
+}
+
+// Right is a right granted by an article.
+type Right struct {
+	Type          string   `json:"type"`
+	Beneficiaries []string `json:"beneficiaries,omitempty"`
+	Article       string   `json:"article"`
+	Text          string   `json:"text,omitempty"`
+	URI           string   `json:"uri"`
+	article       *Article
+}
+
+// Obligation is an obligation imposed by an article.
+type Obligation struct {
+	Type        string   `json:"type"`
+	DutyBearers []string `json:"duty_bearers,omitempty"`
+	Prohibition bool     `json:"prohibition"`
+	Article     string   `json:"article"`
+	Text        string   `json:"text,omitempty"`
+	URI         string   `json:"uri"`
+	article     *Article
+}
+
+// BuildModel reads the provisions of the regulation in the store. Articles
+// are in article order, terms by defining article and name, and rights and
+// obligations by article and type.
+func BuildModel(tripleStore *store.TripleStore) *Model {
+	model := &Model{
+		Articles:    make([]*Article, 0),
+		Terms:       make([]*Term, 0),
+		Rights:      make([]*Right, 0),
+		Obligations: make([]*Obligation, 0),
+	}
+
+	articles := make(map[string]*Article)
+	for _, triple := range tripleStore.Find("", store.RDFType, store.ClassArticle) {
+		if model.URI == "" {
+			if uri := tripleStore.GetOne(triple.Subject, store.PropBelongsTo); uri != "" {
+				model.URI = uri
+				model.Document = tripleStore.GetOne(uri, store.RDFSLabel)
+			}
+		}
+		number := tripleStore.GetOne(triple.Subject, store.PropNumber)
+		article := &Article{
+			Number: number,
+			Title:  flatten(tripleStore.GetOne(triple.Subject, store.PropTitle)),
+			URI:    triple.Subject,
+			order:  textutil.ArticleOrder(number),
+		}
+		articles[triple.Subject] = article
+		model.Articles = append(model.Articles, article)
+	}
+	sort.Slice(model.Articles, func(i, j int) bool {
+		return articleLess(model.Articles[i], model.Articles[j])
+	})
+
+	for _, triple := range tripleStore.Find("", store.RDFType, store.ClassDefinedTerm) {
+		article := articles[tripleStore.GetOne(triple.Subject, store.PropDefinedIn)]
+		if article == nil {
+			continue
+		}
+		model.Terms = append(model.Terms, &Term{
+			Term:       tripleStore.GetOne(triple.Subject, store.PropTerm),
+			Definition: flatten(tripleStore.GetOne(triple.Subject, store.PropDefinition)),
+			Article:    article.Number,
+			URI:        triple.Subject,
+			article:    article,
+		})
+	}
+	sort.Slice(model.Terms, func(i, j int) bool {
+		a, b := model.Terms[i], model.Terms[j]
+		if a.article != b.article {
+			return articleLess(a.article, b.article)
+		}
+		return strings.ToLower(a.Term) < strings.ToLower(b.Term)
+	})
+
+	for _, triple := range tripleStore.Find("", store.RDFType, store.ClassRight) {
+		article := articles[tripleStore.GetOne(triple.Subject, store.PropPartOf)]
+		if article == nil {
+			continue
+		}
+		model.Rights = append(model.Rights, &Right{
+			Type:          tripleStore.GetOne(triple.Subject, store.PropRightType),
+			Beneficiaries: objects(tripleStore, triple.Subject, store.PropBeneficiary),
+			Article:       article.Number,
+			Text:          firstText(tripleStore, triple.Subject),
+			URI:           triple.Subject,
+			article:       article,
+		})
+	}
+	sort.Slice(model.Rights, func(i, j int) bool {
+		a, b := model.Rights[i], model.Rights[j]
+		if a.article != b.article {
+			return articleLess(a.article, b.article)
+		}
+		return a.Type < b.Type
+	})
+
+	for _, triple := range tripleStore.Find("", store.RDFType, store.ClassObligation) {
+		article := articles[tripleStore.GetOne(triple.Subject, store.PropPartOf)]
+		if article == nil {
+			continue
+		}
+		model.Obligations = append(model.Obligations, &Obligation{
+			Type:        tripleStore.GetOne(triple.Subject, store.PropObligationType),
+			DutyBearers: objects(tripleStore, triple.Subject, store.PropDutyBearer),
+			Prohibition: tripleStore.GetOne(triple.Subject, store.PropIsProhibition) == "true",
+			Article:     article.Number,
+			Text:        firstText(tripleStore, triple.Subject),
+			URI:         triple.Subject,
+			article:     article,
+		})
+	}
+	sort.Slice(model.Obligations, func(i, j int) bool {
+		a, b := model.Obligations[i], model.Obligations[j]
+		if a.article != b.article {
+			return articleLess(a.article, b.article)
+		}
+		return a.Type < b.Type
+	})
+
+	return model
+}
+
+// ToJSON serializes the model to JSON.
+func (model *Model) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(model, "", "  ")
+}
+
+// RightTypes returns the distinct right types, sorted.
+func (model *Model) RightTypes() []string {
+	var values []string
+	for _, right := range model.Rights {
+		values = append(values, right.Type)
+	}
+	return distinct(values)
+}
+
+// ObligationTypes returns the distinct obligation types, sorted.
+func (model *Model) ObligationTypes() []string {
+	var values []string
+	for _, obligation := range model.Obligations {
+		values = append(values, obligation.Type)
+	}
+	return distinct(values)
+}
+
+// Parties returns the distinct beneficiaries and duty bearers, sorted.
+func (model *Model) Parties() []string {
+	var values []string
+	for _, right := range model.Rights {
+		values = append(values, right.Beneficiaries...)
+	}
+	for _, obligation := range model.Obligations {
+		values = append(values, obligation.DutyBearers...)
+	}
+	return distinct(values)
+}
+
+// ArticleNumbers returns the article numbers in article order.
+func (model *Model) ArticleNumbers() []string {
+	numbers := make([]string, len(model.Articles))
+	for i, article := range model.Articles {
+		numbers[i] = article.Number
+	}
+	return numbers
+}
+
+func articleLess(a, b *Article) bool {
+	if a.order != b.order {
+		return a.order < b.order
+	}
+	return a.Number < b.Number
+}
+
+// objects returns the sorted objects of a subject's predicate. Right and
+// obligation nodes gather the parties of every annotation that produced
+// them.
+func objects(tripleStore *store.TripleStore, subject, predicate string) []string {
+	var values []string
+	for _, triple := range tripleStore.Find(subject, predicate, "") {
+		values = append(values, triple.Object)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// firstText returns the first of a node's matched texts in sorted order, so
+// the choice is stable across runs.
+func firstText(tripleStore *store.TripleStore, subject string) string {
+	if texts := objects(tripleStore, subject, store.PropText); len(texts) > 0 {
+		return flatten(texts[0])
+	}
+	return ""
+}
+
+func distinct(values []string) []string {
+	sort.Strings(values)
+	var unique []string
+	for i, value := range values {
+		if value != "" && (i == 0 || value != values[i-1]) {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+func flatten(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
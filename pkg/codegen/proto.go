@@ -0,0 +1,167 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ProtoOptions configures .proto generation.
+type ProtoOptions struct {
+	// Package is the protobuf package, such as "regula.gdpr".
+	Package string
+	// GoPackage sets option go_package when not empty.
+	GoPackage string
+	// Source names the document the definition was generated from, for the
+	// generated-code header.
+	Source string
+}
+
+// GenerateProto returns a proto3 definition of the model: a Regulation
+// message holding Article, Term, Right, and Obligation messages, with the
+// document's right types, obligation types, and parties as enums.
+//
+// Enum values are numbered in sorted order of the types found in the
+// document, so regenerating after the extraction finds a new type can
+// renumber values; pin a generated file for wire compatibility.
+func GenerateProto(model *Model, options ProtoOptions) (string, error) {
+	for _, segment := range strings.Split(options.Package, ".") {
+		if !isProtoIdentifier(segment) {
+			return "", fmt.Errorf("invalid proto package %q", options.Package)
+		}
+	}
+
+	title := model.Document
+	if title == "" {
+		title = "the regulation"
+	}
+
+	var sb strings.Builder
+	header := "// Code generated by regula"
+	if options.Source != "" {
+		header += " from " + options.Source
+	}
+	sb.WriteString(header + ". DO NOT EDIT.\n\n")
+	sb.WriteString("syntax = \"proto3\";\n\n")
+	sb.WriteString("package " + options.Package + ";\n")
+	if options.GoPackage != "" {
+		sb.WriteString(fmt.Sprintf("\noption go_package = %q;\n", options.GoPackage))
+	}
+
+	sb.WriteString(fmt.Sprintf(`
+// Regulation holds the provisions of %s extracted by regula.
+message Regulation {
+  string document = 1;
+  string uri = 2;
+  repeated Article articles = 3;
+  repeated Term terms = 4;
+  repeated Right rights = 5;
+  repeated Obligation obligations = 6;
+}
+
+// Article is an article of the regulation.
+message Article {
+  string number = 1;
+  string title = 2;
+  string uri = 3;
+}
+
+// Term is a defined term; article is the number of the defining article.
+message Term {
+  string term = 1;
+  string definition = 2;
+  string article = 3;
+  string uri = 4;
+}
+
+// Right is a right granted by an article.
+message Right {
+  RightType type = 1;
+  repeated Party beneficiaries = 2;
+  string article = 3;
+  string text = 4;
+  string uri = 5;
+}
+
+// Obligation is an obligation imposed by an article. prohibition is set for
+// duties phrased as "shall not".
+message Obligation {
+  ObligationType type = 1;
+  repeated Party duty_bearers = 2;
+  bool prohibition = 3;
+  string article = 4;
+  string text = 5;
+  string uri = 6;
+}
+`, title))
+
+	writeProtoEnum(&sb, "RightType", "RightType is a kind of right.", model.RightTypes())
+	writeProtoEnum(&sb, "ObligationType", "ObligationType is a kind of obligation.", model.ObligationTypes())
+	writeProtoEnum(&sb, "Party", "Party is a beneficiary of a right or a bearer of an obligation.", model.Parties())
+
+	return sb.String(), nil
+}
+
+// ProtoPackage returns a protobuf package for a document identifier, such
+// as "regula.ukdpa2018" for "UK-DPA2018".
+func ProtoPackage(documentID string) string {
+	return "regula." + PackageName(documentID)
+}
+
+// writeProtoEnum writes an enum whose values are prefixed with the enum
+// name, with the zero value reserved for "unspecified" as proto3 requires.
+// Each value is commented with the type name regula uses.
+func writeProtoEnum(sb *strings.Builder, name, comment string, values []string) {
+	prefix := upperSnake(name)
+	sb.WriteString(fmt.Sprintf("\n// %s\nenum %s {\n", comment, name))
+	sb.WriteString(fmt.Sprintf("  %s_UNSPECIFIED = 0;\n", prefix))
+	used := map[string]bool{prefix + "_UNSPECIFIED": true}
+	for i, value := range values {
+		constant := prefix + "_" + upperSnake(value)
+		for suffix := 2; used[constant]; suffix++ {
+			constant = fmt.Sprintf("%s_%s_%d", prefix, upperSnake(value), suffix)
+		}
+		used[constant] = true
+		sb.WriteString(fmt.Sprintf("  %s = %d; // %s\n", constant, i+1, value))
+	}
+	sb.WriteString("}\n")
+}
+
+// upperSnake converts a CamelCase identifier to UPPER_SNAKE_CASE, as in
+// "RightOfAccess" to "RIGHT_OF_ACCESS".
+func upperSnake(text string) string {
+	var sb strings.Builder
+	runes := []rune(text)
+	for i, r := range runes {
+		if !(r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))) {
+			if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "_") {
+				sb.WriteRune('_')
+			}
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) && sb.Len() > 0 && !strings.HasSuffix(sb.String(), "_") {
+			previous := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextLower) {
+				sb.WriteRune('_')
+			}
+		}
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+	return strings.TrimSuffix(sb.String(), "_")
+}
+
+func isProtoIdentifier(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for i, r := range segment {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func codegenStoreWithoutProvisions() *store.TripleStore {
+	ts := store.NewTripleStore()
+	ts.Add("reg:EMPTY:Art1", store.RDFType, store.ClassArticle)
+	ts.Add("reg:EMPTY:Art1", store.PropNumber, "1")
+	return ts
+}
+
+func TestGenerateProto(t *testing.T) {
+	definition, err := GenerateProto(BuildModel(codegenTestStore()), ProtoOptions{
+		Package:   ProtoPackage("TEST"),
+		GoPackage: "example.com/testpb",
+		Source:    "test.txt",
+	})
+	if err != nil {
+		t.Fatalf("GenerateProto: %v", err)
+	}
+
+	for _, want := range []string{
+		"// Code generated by regula from test.txt. DO NOT EDIT.\n",
+		"syntax = \"proto3\";\n",
+		"package regula.test;\n",
+		"option go_package = \"example.com/testpb\";\n",
+		"// Regulation holds the provisions of TEST extracted by regula.\n",
+		"  repeated Party duty_bearers = 2;\n",
+		"  RIGHT_TYPE_UNSPECIFIED = 0;\n  RIGHT_TYPE_RIGHT_OF_ACCESS = 1; // RightOfAccess\n",
+		"  OBLIGATION_TYPE_BREACH_NOTIFICATION_OBLIGATION = 1; // BreachNotificationObligation\n  OBLIGATION_TYPE_OBLIGATION = 2; // Obligation\n",
+		"  PARTY_CONTROLLER = 1; // Controller\n  PARTY_DATA_SUBJECT = 2; // DataSubject\n",
+	} {
+		if !strings.Contains(definition, want) {
+			t.Errorf("definition missing %q:\n%s", want, definition)
+		}
+	}
+}
+
+func TestGenerateProto_Empty(t *testing.T) {
+	definition, err := GenerateProto(BuildModel(codegenStoreWithoutProvisions()), ProtoOptions{Package: "empty"})
+	if err != nil {
+		t.Fatalf("GenerateProto: %v", err)
+	}
+	if !strings.Contains(definition, "enum Party {\n  PARTY_UNSPECIFIED = 0;\n}\n") {
+		t.Errorf("expected an enum with only the zero value:\n%s", definition)
+	}
+	if strings.Contains(definition, "go_package") {
+		t.Error("expected no go_package option")
+	}
+}
+
+func TestGenerateProto_InvalidPackage(t *testing.T) {
+	for _, name := range []string{"", "regula.", "regula.2018", "regula-gdpr"} {
+		if _, err := GenerateProto(&Model{}, ProtoOptions{Package: name}); err == nil {
+			t.Errorf("expected an error for package %q", name)
+		}
+	}
+}
+
+func TestUpperSnake(t *testing.T) {
+	tests := map[string]string{
+		"RightOfAccess":         "RIGHT_OF_ACCESS",
+		"DataProtectionOfficer": "DATA_PROTECTION_OFFICER",
+		"GDPRRight":             "GDPR_RIGHT",
+		"Right2Know":            "RIGHT2_KNOW",
+		"opt-out link":          "OPT_OUT_LINK",
+	}
+	for text, want := range tests {
+		if got := upperSnake(text); got != want {
+			t.Errorf("upperSnake(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,408 @@
+// Package complete provides citation autocompletion over the documents in
+// a library, for editors and drafting tools. An Index holds every ready
+// document and its provisions under the citation forms people type, such as
+// "42 U.S.C. § 1320d-2", "45 CFR 164.502", or "GDPR Art. 17", and ranks
+// completions for a partially typed citation.
+package complete
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// Entry kinds.
+const (
+	KindDocument  = "document"
+	KindProvision = "provision"
+)
+
+// DefaultLimit is the number of completions returned when no limit is given.
+const DefaultLimit = 10
+
+// Entry is one citation that can be completed.
+type Entry struct {
+	// Citation is the citation as it should be inserted.
+	Citation string `json:"citation"`
+
+	// Title is the provision heading or the document's full name.
+	Title string `json:"title,omitempty"`
+
+	DocumentID string `json:"document_id"`
+	URI        string `json:"uri,omitempty"`
+	Kind       string `json:"kind"`
+
+	// keys are the normalized forms the entry is matched against.
+	keys []string
+}
+
+// Completion is a ranked match for a query.
+type Completion struct {
+	Entry
+	Score float64 `json:"score"`
+}
+
+// Index is a citation autocomplete index.
+type Index struct {
+	entries []*Entry
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{}
+}
+
+// Add indexes entry under its citation and the given alternative forms.
+func (idx *Index) Add(entry Entry, forms ...string) {
+	seen := make(map[string]bool)
+	for _, form := range append([]string{entry.Citation}, forms...) {
+		key := normalizeCitation(form)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		entry.keys = append(entry.keys, key)
+	}
+	if len(entry.keys) > 0 {
+		idx.entries = append(idx.entries, &entry)
+	}
+}
+
+// Len returns the number of indexed entries.
+func (idx *Index) Len() int {
+	return len(idx.entries)
+}
+
+// Complete returns up to limit entries matching a partially typed
+// citation, best first. An entry matches when one of its forms starts with
+// the query, or when every word of the query starts a word of the form.
+// Ties are broken by document before provision, then by citation in
+// natural order, so "42 USC 13" lists § 1320d before § 1320d-1.
+func (idx *Index) Complete(query string, limit int) []Completion {
+	normalizedQuery := normalizeCitation(query)
+	if normalizedQuery == "" {
+		return nil
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	var completions []Completion
+	for _, entry := range idx.entries {
+		best := 0.0
+		for _, key := range entry.keys {
+			if score := matchScore(normalizedQuery, key); score > best {
+				best = score
+			}
+		}
+		if best > 0 {
+			completions = append(completions, Completion{Entry: *entry, Score: best})
+		}
+	}
+
+	sort.Slice(completions, func(i, j int) bool {
+		a, b := completions[i], completions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Kind != b.Kind {
+			return a.Kind == KindDocument
+		}
+		if a.Citation != b.Citation {
+			return naturalLess(a.Citation, b.Citation)
+		}
+		return a.DocumentID < b.DocumentID
+	})
+
+	if len(completions) > limit {
+		completions = completions[:limit]
+	}
+	return completions
+}
+
+// matchScore scores key against query: 1 for an exact match, 0.5 to 0.9
+// for a prefix match depending on how much of the key the query covers,
+// up to 0.4 when every query word starts a key word, and 0 otherwise.
+func matchScore(query, key string) float64 {
+	coverage := float64(len(query)) / float64(len(key))
+	switch {
+	case key == query:
+		return 1
+	case strings.HasPrefix(key, query):
+		return 0.5 + 0.4*coverage
+	case wordsPrefixMatch(strings.Fields(query), strings.Fields(key)):
+		return 0.4 * min(coverage, 1)
+	}
+	return 0
+}
+
+// wordsPrefixMatch reports whether each query word is a prefix of a
+// different key word.
+func wordsPrefixMatch(queryWords, keyWords []string) bool {
+	used := make([]bool, len(keyWords))
+	for _, queryWord := range queryWords {
+		found := false
+		for i, keyWord := range keyWords {
+			if !used[i] && strings.HasPrefix(keyWord, queryWord) {
+				used[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// citationNoiseWords are dropped when normalizing, so "§ 6502",
+// "Section 6502", "s. 6502", and "6502" match alike.
+var citationNoiseWords = map[string]bool{
+	"sec": true, "secs": true, "section": true, "sections": true,
+	"art": true, "arts": true, "article": true, "articles": true,
+	"s": true, "ss": true,
+}
+
+// normalizeCitation lowercases a citation and reduces it to words: section
+// signs and "Art."/"Section" are dropped, dots are removed from
+// abbreviations ("U.S.C." -> "usc") but kept inside numbers ("164.502").
+func normalizeCitation(text string) string {
+	text = strings.ToLower(text)
+	text = strings.NewReplacer("§", " ", "’", "", "'", "").Replace(text)
+
+	var words []string
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(",;:()[]\"", r)
+	}) {
+		compact := strings.ReplaceAll(word, ".", "")
+		if compact == "" || citationNoiseWords[compact] {
+			continue
+		}
+		if strings.IndexFunc(compact, unicode.IsDigit) < 0 {
+			word = compact
+		} else {
+			word = strings.Trim(word, ".")
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, " ")
+}
+
+// naturalLess orders strings with embedded numbers numerically, so
+// "1320d-2" sorts before "1320d-10".
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		aDigits, bDigits := leadingDigits(a), leadingDigits(b)
+		if aDigits != "" && bDigits != "" {
+			aNumber, _ := strconv.Atoi(aDigits)
+			bNumber, _ := strconv.Atoi(bDigits)
+			if aNumber != bNumber {
+				return aNumber < bNumber
+			}
+			a, b = a[len(aDigits):], b[len(bDigits):]
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+func leadingDigits(s string) string {
+	end := 0
+	for end < len(s) && end < 9 && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	return s[:end]
+}
+
+var (
+	// codeCitationPattern finds a title of the US Code or the CFR in a
+	// document name, e.g. "42 USC 1320d" or "15 U.S.C. 6501-6506".
+	codeCitationPattern = regexp.MustCompile(`(?i)\b(\d+)\s*(U\.?\s?S\.?\s?C\.?|C\.?\s?F\.?\s?R\.?)(?:\s|$|\)|,)`)
+
+	// bulkCodeIDPattern matches the IDs bulk ingest gives code titles.
+	bulkCodeIDPattern = regexp.MustCompile(`^us-(usc|uscode|cfr)(?:-\d{4})?-title-(\d+)$`)
+
+	trailingParenthetical = regexp.MustCompile(`\s*\([^)]*\)\s*$`)
+)
+
+// citationStyle is one way of citing a document's provisions.
+type citationStyle struct {
+	// prefix is prepended to a provision number for display.
+	prefix string
+
+	// title is the code title number, stripped from title-qualified
+	// provision numbers ("15.6502" -> "6502").
+	title string
+}
+
+// documentStyles returns the ways a document's provisions are cited: by
+// code title for US Code and CFR documents, and by short name otherwise.
+func documentStyles(entry *library.DocumentEntry) []citationStyle {
+	var styles []citationStyle
+	seen := make(map[string]bool)
+	addCode := func(title, code string) {
+		compact := strings.ToUpper(strings.NewReplacer(".", "", " ", "").Replace(code))
+		var prefix string
+		switch compact {
+		case "USC", "USCODE":
+			prefix = fmt.Sprintf("%s U.S.C. § ", title)
+		case "CFR":
+			prefix = fmt.Sprintf("%s C.F.R. § ", title)
+		default:
+			return
+		}
+		if !seen[prefix] {
+			seen[prefix] = true
+			styles = append(styles, citationStyle{prefix: prefix, title: title})
+		}
+	}
+
+	if match := bulkCodeIDPattern.FindStringSubmatch(entry.ID); match != nil {
+		addCode(match[2], match[1])
+	}
+	for _, name := range []string{entry.ShortName, entry.FullName} {
+		for _, match := range codeCitationPattern.FindAllStringSubmatch(name, -1) {
+			addCode(match[1], match[2])
+		}
+	}
+	if len(styles) > 0 {
+		return styles
+	}
+
+	if entry.ShortName != "" && entry.ShortName != entry.ID {
+		styles = append(styles, citationStyle{prefix: entry.ShortName + provisionSeparator(entry)})
+	}
+	return styles
+}
+
+// provisionSeparator returns what goes between a document's short name and
+// a provision number: "Art." for EU and international instruments, "s."
+// for UK legislation, and "§" otherwise. Documents added without a format
+// hint are judged by jurisdiction.
+func provisionSeparator(entry *library.DocumentEntry) string {
+	format := entry.Format
+	if format == "" {
+		switch jurisdiction := strings.ToUpper(entry.Jurisdiction); {
+		case jurisdiction == "EU" || jurisdiction == "INTL":
+			format = "eu"
+		case jurisdiction == "GB" || jurisdiction == "UK" || strings.HasPrefix(jurisdiction, "GB-"):
+			format = "uk"
+		}
+	}
+	switch format {
+	case "eu", "generic":
+		return " Art. "
+	case "uk":
+		return " s. "
+	}
+	return " § "
+}
+
+// documentForms returns the names a document is completed from.
+func documentForms(entry *library.DocumentEntry, styles []citationStyle) []string {
+	forms := []string{entry.ShortName, trailingParenthetical.ReplaceAllString(entry.FullName, ""), entry.FullName}
+	forms = append(forms, entry.ShortTitles...)
+	for _, title := range entry.ShortTitles {
+		if acronym := library.PopularNameAcronym(title); acronym != "" {
+			forms = append(forms, acronym)
+		}
+	}
+	for _, style := range styles {
+		forms = append(forms, strings.TrimSuffix(style.prefix, " § "))
+	}
+	return forms
+}
+
+// BuildIndex indexes every ready document in lib and its articles or
+// sections.
+func BuildIndex(lib *library.Library) (*Index, error) {
+	index := NewIndex()
+	for _, entry := range lib.ListDocuments() {
+		if entry.Status != library.StatusReady {
+			continue
+		}
+
+		styles := documentStyles(entry)
+		citation := entry.ShortName
+		if citation == "" {
+			citation = entry.ID
+		}
+		index.Add(Entry{
+			Citation:   citation,
+			Title:      entry.FullName,
+			DocumentID: entry.ID,
+			Kind:       KindDocument,
+		}, documentForms(entry, styles)...)
+
+		if len(styles) == 0 {
+			continue
+		}
+		tripleStore, err := lib.LoadTripleStore(entry.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", entry.ID, err)
+		}
+		addProvisions(index, entry, tripleStore, styles)
+	}
+	return index, nil
+}
+
+// addProvisions indexes the articles in tripleStore under each style, and
+// under the document's short name so "COPPA 6502" finds 15 U.S.C. § 6502.
+func addProvisions(index *Index, document *library.DocumentEntry, tripleStore *store.TripleStore, styles []citationStyle) {
+	articles := tripleStore.Find("", store.RDFType, store.ClassArticle)
+	sort.Slice(articles, func(i, j int) bool { return articles[i].Subject < articles[j].Subject })
+
+	seen := make(map[string]bool)
+	for _, article := range articles {
+		uri := article.Subject
+		if seen[uri] {
+			continue
+		}
+		seen[uri] = true
+
+		number := firstObject(tripleStore, uri, store.PropNumber)
+		if number == "" {
+			continue
+		}
+		title := firstObject(tripleStore, uri, store.PropTitle)
+
+		for _, style := range styles {
+			provision := number
+			if style.title != "" {
+				provision = strings.TrimPrefix(number, style.title+".")
+			}
+			index.Add(Entry{
+				Citation:   style.prefix + provision,
+				Title:      title,
+				DocumentID: document.ID,
+				URI:        uri,
+				Kind:       KindProvision,
+			}, document.ShortName+" "+provision)
+		}
+	}
+}
+
+// firstObject returns the lexically first object of subject's predicate,
+// so repeated titles resolve the same way every time.
+func firstObject(tripleStore *store.TripleStore, subject, predicate string) string {
+	var objects []string
+	for _, triple := range tripleStore.Find(subject, predicate, "") {
+		objects = append(objects, triple.Object)
+	}
+	if len(objects) == 0 {
+		return ""
+	}
+	sort.Strings(objects)
+	return objects[0]
+}
@@ -0,0 +1,122 @@
+package complete
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/library"
+)
+
+func TestNormalizeCitation(t *testing.T) {
+	tests := map[string]string{
+		"42 U.S.C. § 1320d-2":   "42 usc 1320d-2",
+		"42 USC Sec. 1320d-2":   "42 usc 1320d-2",
+		"45 C.F.R. 164.502":     "45 cfr 164.502",
+		"GDPR Art. 17":          "gdpr 17",
+		"GDPR, Article 17(1)":   "gdpr 17 1",
+		"DPA 2018 s. 3":         "dpa 2018 3",
+		"Children's Online Act": "childrens online act",
+		"  §§  ":                "",
+	}
+	for input, want := range tests {
+		if got := normalizeCitation(input); got != want {
+			t.Errorf("normalizeCitation(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestNaturalLess(t *testing.T) {
+	ordered := []string{"GDPR Art. 2", "GDPR Art. 10", "42 U.S.C. § 1320d", "42 U.S.C. § 1320d-2", "42 U.S.C. § 1320d-10"}
+	pairs := [][2]string{
+		{ordered[0], ordered[1]},
+		{ordered[2], ordered[3]},
+		{ordered[3], ordered[4]},
+	}
+	for _, pair := range pairs {
+		if !naturalLess(pair[0], pair[1]) || naturalLess(pair[1], pair[0]) {
+			t.Errorf("expected %q < %q", pair[0], pair[1])
+		}
+	}
+}
+
+func TestIndexCompleteRanking(t *testing.T) {
+	index := NewIndex()
+	index.Add(Entry{Citation: "GDPR", Title: "General Data Protection Regulation", DocumentID: "eu-gdpr", Kind: KindDocument})
+	for _, number := range []string{"1", "10", "2", "17"} {
+		index.Add(Entry{Citation: "GDPR Art. " + number, DocumentID: "eu-gdpr", Kind: KindProvision})
+	}
+	index.Add(Entry{Citation: "GDPR SI 2019", DocumentID: "gb-si", Kind: KindDocument})
+
+	completions := index.Complete("gdpr art 1", 10)
+	var citations []string
+	for _, completion := range completions {
+		citations = append(citations, completion.Citation)
+	}
+	want := []string{"GDPR Art. 1", "GDPR Art. 10", "GDPR Art. 17"}
+	if len(citations) != len(want) {
+		t.Fatalf("Complete = %v, want %v", citations, want)
+	}
+	for i := range want {
+		if citations[i] != want[i] {
+			t.Fatalf("Complete = %v, want %v", citations, want)
+		}
+	}
+	if completions[0].Score != 1 {
+		t.Errorf("exact match score = %v, want 1", completions[0].Score)
+	}
+
+	if got := index.Complete("gdpr", 2); len(got) != 2 || got[0].Citation != "GDPR" {
+		t.Errorf("Complete(gdpr, 2) = %v, want the document first", got)
+	}
+	if got := index.Complete("   ", 10); got != nil {
+		t.Errorf("Complete of an empty query = %v, want nil", got)
+	}
+	if got := index.Complete("ccpa", 10); len(got) != 0 {
+		t.Errorf("Complete(ccpa) = %v, want none", got)
+	}
+}
+
+func TestBuildIndex(t *testing.T) {
+	lib, err := library.Init(filepath.Join(t.TempDir(), "lib"), "")
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	entries := []library.CorpusEntry{
+		{ID: "us-hipaa", Jurisdiction: "US-Federal", ShortName: "HIPAA", FullName: "Health Insurance Portability and Accountability Act excerpt (42 USC 1320d)", Format: "us", SourcePath: "corpus/us-hipaa/source.txt"},
+		{ID: "us-va-vcdpa", Jurisdiction: "US-VA", ShortName: "VCDPA", FullName: "Virginia Consumer Data Protection Act", Format: "us", SourcePath: "vcdpa.txt"},
+	}
+	report, err := library.SeedFromCorpus(lib, filepath.Join("..", "..", "testdata"), entries)
+	if err != nil {
+		t.Fatalf("SeedFromCorpus failed: %v", err)
+	}
+	if report.Succeeded != len(entries) {
+		t.Skipf("test corpus not available: %+v", report.Entries)
+	}
+
+	index, err := BuildIndex(lib)
+	if err != nil {
+		t.Fatalf("BuildIndex failed: %v", err)
+	}
+
+	completions := index.Complete("42 USC 13", 5)
+	if len(completions) == 0 {
+		t.Fatal("expected completions for 42 USC 13")
+	}
+	first := completions[0]
+	if first.Citation != "42 U.S.C. § 1320d" || first.DocumentID != "us-hipaa" || first.Kind != KindProvision {
+		t.Errorf("first completion = %+v, want 42 U.S.C. § 1320d from us-hipaa", first)
+	}
+	if first.Title == "" || first.URI == "" {
+		t.Errorf("first completion missing title or URI: %+v", first)
+	}
+
+	if got := index.Complete("hipaa 1320d-2", 1); len(got) != 1 || got[0].Citation != "42 U.S.C. § 1320d-2" {
+		t.Errorf("Complete(hipaa 1320d-2) = %+v, want 42 U.S.C. § 1320d-2", got)
+	}
+	if got := index.Complete("virginia consumer", 1); len(got) != 1 || got[0].Citation != "VCDPA" {
+		t.Errorf("Complete(virginia consumer) = %+v, want the VCDPA document", got)
+	}
+	if got := index.Complete("vcdpa 59.1-57", 10); len(got) == 0 {
+		t.Error("expected VCDPA provisions by short name")
+	}
+}
@@ -0,0 +1,332 @@
+package complete
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Server answers completion requests over stdio with the Language Server
+// Protocol's framing and JSON-RPC messages. It implements the subset an
+// editor needs for citation completion:
+//
+//   - initialize, shutdown, and exit
+//   - textDocument/didOpen, didChange (full sync), and didClose
+//   - textDocument/completion, completing the citation before the cursor
+//   - regula/completeCitation, taking a query string directly, for tools
+//     that do not track documents
+//
+// Other requests get a method-not-found error; other notifications are
+// ignored.
+type Server struct {
+	index     *Index
+	limit     int
+	version   string
+	documents map[string][]string // URI -> lines
+	shutdown  bool
+}
+
+// maxCitationWords bounds how far back from the cursor a citation may
+// start, e.g. "Children's Online Privacy Protection Act § 65".
+const maxCitationWords = 8
+
+// JSON-RPC error codes.
+const (
+	errorParse          = -32700
+	errorInvalidRequest = -32600
+	errorMethodNotFound = -32601
+	errorInvalidParams  = -32602
+)
+
+// LSP completion item kind for references.
+const completionKindReference = 18
+
+// NewServer returns a server completing from index, returning up to limit
+// items per request. version is reported to the client.
+func NewServer(index *Index, limit int, version string) *Server {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	return &Server{
+		index:     index,
+		limit:     limit,
+		version:   version,
+		documents: make(map[string][]string),
+	}
+}
+
+type rpcMessage struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  any              `json:"result,omitempty"`
+	Error   *rpcError        `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type textRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+type textDocumentItem struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+// Serve reads messages from r and writes responses to w until the client
+// sends exit or closes r.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	reader := bufio.NewReader(r)
+	for {
+		body, err := readMessage(reader)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var request rpcMessage
+		if err := json.Unmarshal(body, &request); err != nil {
+			nullID := json.RawMessage("null")
+			if err := writeMessage(w, rpcMessage{ID: &nullID, Error: &rpcError{Code: errorParse, Message: err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+		if request.Method == "exit" {
+			return nil
+		}
+
+		result, rpcErr := s.handle(request.Method, request.Params)
+		if request.ID == nil {
+			continue
+		}
+		response := rpcMessage{ID: request.ID, Result: result, Error: rpcErr}
+		if rpcErr == nil && result == nil {
+			// Results such as shutdown's are an explicit null.
+			response.Result = json.RawMessage("null")
+		}
+		if err := writeMessage(w, response); err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches one request or notification.
+func (s *Server) handle(method string, params json.RawMessage) (any, *rpcError) {
+	if s.shutdown && method != "exit" {
+		return nil, &rpcError{Code: errorInvalidRequest, Message: "server is shut down"}
+	}
+
+	switch method {
+	case "initialize":
+		return map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync": 1,
+				"completionProvider": map[string]any{
+					"triggerCharacters": []string{" ", "§", "."},
+				},
+			},
+			"serverInfo": map[string]string{"name": "regula", "version": s.version},
+		}, nil
+
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var p struct {
+			TextDocument textDocumentItem `json:"textDocument"`
+		}
+		if err := json.Unmarshal(params, &p); err == nil {
+			s.documents[p.TextDocument.URI] = strings.Split(p.TextDocument.Text, "\n")
+		}
+		return nil, nil
+
+	case "textDocument/didChange":
+		var p struct {
+			TextDocument   textDocumentItem `json:"textDocument"`
+			ContentChanges []struct {
+				Text string `json:"text"`
+			} `json:"contentChanges"`
+		}
+		if err := json.Unmarshal(params, &p); err == nil && len(p.ContentChanges) > 0 {
+			last := p.ContentChanges[len(p.ContentChanges)-1]
+			s.documents[p.TextDocument.URI] = strings.Split(last.Text, "\n")
+		}
+		return nil, nil
+
+	case "textDocument/didClose":
+		var p struct {
+			TextDocument textDocumentItem `json:"textDocument"`
+		}
+		if err := json.Unmarshal(params, &p); err == nil {
+			delete(s.documents, p.TextDocument.URI)
+		}
+		return nil, nil
+
+	case "textDocument/completion":
+		var p struct {
+			TextDocument textDocumentItem `json:"textDocument"`
+			Position     position         `json:"position"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &rpcError{Code: errorInvalidParams, Message: err.Error()}
+		}
+		return s.completeAt(p.TextDocument.URI, p.Position), nil
+
+	case "regula/completeCitation":
+		var p struct {
+			Query string `json:"query"`
+			Limit int    `json:"limit"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &rpcError{Code: errorInvalidParams, Message: err.Error()}
+		}
+		limit := p.Limit
+		if limit <= 0 {
+			limit = s.limit
+		}
+		completions := s.index.Complete(p.Query, limit)
+		if completions == nil {
+			completions = []Completion{}
+		}
+		return map[string]any{"query": p.Query, "completions": completions}, nil
+	}
+
+	if strings.HasPrefix(method, "$/") || method == "initialized" {
+		return nil, nil
+	}
+	return nil, &rpcError{Code: errorMethodNotFound, Message: fmt.Sprintf("method not found: %s", method)}
+}
+
+// completeAt completes the citation ending at pos in an open document.
+func (s *Server) completeAt(uri string, pos position) map[string]any {
+	items := []map[string]any{}
+	lines := s.documents[uri]
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return map[string]any{"isIncomplete": true, "items": items}
+	}
+
+	line := lines[pos.Line]
+	prefix := line[:byteOffset(line, pos.Character)]
+	query, start := citationBeforeCursor(s.index, prefix, s.limit)
+	if query == "" {
+		return map[string]any{"isIncomplete": true, "items": items}
+	}
+
+	replace := textRange{
+		Start: position{Line: pos.Line, Character: utf16Length(prefix[:start])},
+		End:   pos,
+	}
+	for i, completion := range s.index.Complete(query, s.limit) {
+		items = append(items, map[string]any{
+			"label":      completion.Citation,
+			"kind":       completionKindReference,
+			"detail":     completion.Title,
+			"sortText":   fmt.Sprintf("%04d", i),
+			"filterText": query,
+			"textEdit":   map[string]any{"range": replace, "newText": completion.Citation},
+		})
+	}
+	// Results depend on every character typed, so the client must ask again.
+	return map[string]any{"isIncomplete": true, "items": items}
+}
+
+// citationBeforeCursor finds the longest run of words at the end of text,
+// up to maxCitationWords, that has completions, and returns it with its
+// byte offset in text.
+func citationBeforeCursor(index *Index, text string, limit int) (string, int) {
+	var starts []int
+	inWord := false
+	for i, r := range text {
+		isSpace := r == ' ' || r == '\t'
+		if !isSpace && !inWord {
+			starts = append(starts, i)
+		}
+		inWord = !isSpace
+	}
+	if len(starts) == 0 || !inWord && !strings.HasSuffix(text, " ") {
+		return "", 0
+	}
+
+	first := max(0, len(starts)-maxCitationWords)
+	for _, start := range starts[first:] {
+		query := text[start:]
+		if len(index.Complete(query, limit)) > 0 {
+			return query, start
+		}
+	}
+	return "", 0
+}
+
+// byteOffset converts an LSP character offset (UTF-16 code units) in line
+// to a byte offset, clamped to the line length.
+func byteOffset(line string, character int) int {
+	units := 0
+	for i, r := range line {
+		if units >= character {
+			return i
+		}
+		units += max(utf16.RuneLen(r), 1)
+	}
+	return len(line)
+}
+
+func utf16Length(text string) int {
+	units := 0
+	for _, r := range text {
+		units += max(utf16.RuneLen(r), 1)
+	}
+	return units
+}
+
+// readMessage reads one Content-Length framed message.
+func readMessage(reader *bufio.Reader) ([]byte, error) {
+	headers, err := textproto.NewReader(reader).ReadMIMEHeader()
+	if err != nil {
+		if err == io.EOF || len(headers) == 0 && strings.Contains(err.Error(), "EOF") {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read message header: %w", err)
+	}
+	length, err := strconv.Atoi(headers.Get("Content-Length"))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid Content-Length %q", headers.Get("Content-Length"))
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return nil, fmt.Errorf("failed to read message body: %w", err)
+	}
+	return body, nil
+}
+
+// writeMessage writes one Content-Length framed message.
+func writeMessage(w io.Writer, message rpcMessage) error {
+	message.JSONRPC = "2.0"
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package complete
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func testServerIndex() *Index {
+	index := NewIndex()
+	index.Add(Entry{Citation: "HIPAA", Title: "Health Insurance Portability and Accountability Act", DocumentID: "us-hipaa", Kind: KindDocument})
+	for _, number := range []string{"1320d", "1320d-1", "1320d-2"} {
+		index.Add(Entry{Citation: "42 U.S.C. § " + number, Title: "Section " + number, DocumentID: "us-hipaa", Kind: KindProvision}, "HIPAA "+number)
+	}
+	return index
+}
+
+// runServer sends messages to a server and returns its responses by ID.
+func runServer(t *testing.T, messages ...map[string]any) map[string]map[string]any {
+	t.Helper()
+	var input bytes.Buffer
+	for _, message := range messages {
+		message["jsonrpc"] = "2.0"
+		body, _ := json.Marshal(message)
+		fmt.Fprintf(&input, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	}
+
+	var output bytes.Buffer
+	if err := NewServer(testServerIndex(), 5, "test").Serve(&input, &output); err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+
+	responses := make(map[string]map[string]any)
+	reader := bufio.NewReader(&output)
+	for {
+		body, err := readMessage(reader)
+		if err != nil {
+			break
+		}
+		var response map[string]any
+		if err := json.Unmarshal(body, &response); err != nil {
+			t.Fatalf("invalid response %s: %v", body, err)
+		}
+		responses[fmt.Sprint(response["id"])] = response
+	}
+	return responses
+}
+
+func TestServerCompletion(t *testing.T) {
+	line := "Covered entities must comply with 42 USC 1320d-"
+	responses := runServer(t,
+		map[string]any{"id": 1, "method": "initialize", "params": map[string]any{}},
+		map[string]any{"method": "initialized", "params": map[string]any{}},
+		map[string]any{"method": "textDocument/didOpen", "params": map[string]any{
+			"textDocument": map[string]any{"uri": "file:///draft.md", "text": "# Draft\n" + line},
+		}},
+		map[string]any{"id": 2, "method": "textDocument/completion", "params": map[string]any{
+			"textDocument": map[string]any{"uri": "file:///draft.md"},
+			"position":     map[string]any{"line": 1, "character": len(line)},
+		}},
+		map[string]any{"id": 3, "method": "shutdown"},
+		map[string]any{"method": "exit"},
+		map[string]any{"id": 4, "method": "shutdown"},
+	)
+
+	capabilities := responses["1"]["result"].(map[string]any)["capabilities"].(map[string]any)
+	if capabilities["completionProvider"] == nil {
+		t.Errorf("initialize did not advertise completion: %v", capabilities)
+	}
+
+	items := responses["2"]["result"].(map[string]any)["items"].([]any)
+	if len(items) != 2 {
+		t.Fatalf("expected 2 completion items, got %v", items)
+	}
+	item := items[0].(map[string]any)
+	if item["label"] != "42 U.S.C. § 1320d-1" || item["detail"] != "Section 1320d-1" {
+		t.Errorf("first item = %v", item)
+	}
+	start := item["textEdit"].(map[string]any)["range"].(map[string]any)["start"].(map[string]any)
+	if int(start["character"].(float64)) != strings.Index(line, "42") {
+		t.Errorf("edit starts at %v, want the start of the citation", start)
+	}
+
+	if _, ok := responses["3"]; !ok {
+		t.Error("expected a shutdown response")
+	}
+	if _, ok := responses["4"]; ok {
+		t.Error("server kept serving after exit")
+	}
+}
+
+func TestServerCompleteCitationRequest(t *testing.T) {
+	responses := runServer(t,
+		map[string]any{"id": 1, "method": "regula/completeCitation", "params": map[string]any{"query": "hipaa", "limit": 1}},
+		map[string]any{"id": 2, "method": "textDocument/hover", "params": map[string]any{}},
+	)
+
+	completions := responses["1"]["result"].(map[string]any)["completions"].([]any)
+	if len(completions) != 1 || completions[0].(map[string]any)["citation"] != "HIPAA" {
+		t.Errorf("completions = %v, want the HIPAA document", completions)
+	}
+	if code := responses["2"]["error"].(map[string]any)["code"]; code != float64(errorMethodNotFound) {
+		t.Errorf("unknown method error code = %v", code)
+	}
+}
+
+func TestCitationBeforeCursor(t *testing.T) {
+	index := testServerIndex()
+	tests := []struct {
+		text      string
+		wantQuery string
+	}{
+		{"see 42 USC 1320d", "42 USC 1320d"},
+		{"as required by HIPAA § 13", "HIPAA § 13"},
+		{"nothing to complete", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		query, start := citationBeforeCursor(index, tt.text, 5)
+		if query != tt.wantQuery {
+			t.Errorf("citationBeforeCursor(%q) = %q, want %q", tt.text, query, tt.wantQuery)
+		}
+		if query != "" && tt.text[start:] != query {
+			t.Errorf("citationBeforeCursor(%q) start %d does not match query", tt.text, start)
+		}
+	}
+}
+
+func TestByteOffsetUTF16(t *testing.T) {
+	line := "a § 𝔘 b"
+	// "a", " ", "§", " " are one UTF-16 unit each; "𝔘" is two.
+	if got := byteOffset(line, 4); line[got:] != "𝔘 b" {
+		t.Errorf("byteOffset(4) = %d", got)
+	}
+	if got := byteOffset(line, 6); line[got:] != " b" {
+		t.Errorf("byteOffset(6) = %d", got)
+	}
+	if got := byteOffset(line, 100); got != len(line) {
+		t.Errorf("byteOffset past end = %d, want %d", got, len(line))
+	}
+	if got := utf16Length("a § 𝔘"); got != 6 {
+		t.Errorf("utf16Length = %d, want 6", got)
+	}
+}
@@ -0,0 +1,355 @@
+// Package controls maps regulatory obligations to the controls of
+// compliance frameworks such as ISO 27001, NIST CSF, or SOC 2. Control
+// catalogs are user-defined YAML files; obligations are linked to controls
+// by obligation type and by keyword matches in the obligation's text.
+package controls
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+	"gopkg.in/yaml.v3"
+)
+
+// Link bases, from strongest to weakest.
+const (
+	BasisObligationType = "obligation_type"
+	BasisKeyword        = "keyword"
+)
+
+// Control is one control of a framework.
+type Control struct {
+	ID          string `yaml:"id" json:"id"`
+	Title       string `yaml:"title" json:"title"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Keywords are terms that indicate an obligation is addressed by the
+	// control. Matching is case-insensitive on whole words, and a trailing
+	// "s" or "es" is tolerated.
+	Keywords []string `yaml:"keywords,omitempty" json:"keywords,omitempty"`
+	// ObligationTypes link every obligation of these types to the control,
+	// such as "SecurityObligation" or "BreachNotificationObligation".
+	ObligationTypes []string `yaml:"obligation_types,omitempty" json:"obligation_types,omitempty"`
+}
+
+// Catalog is a framework's list of controls.
+//
+//	framework: ISO 27001
+//	version: "2022"
+//	controls:
+//	  - id: A.5.24
+//	    title: Information security incident management planning
+//	    keywords: [incident, breach]
+//	    obligation_types: [BreachNotificationObligation]
+type Catalog struct {
+	Framework string    `yaml:"framework" json:"framework"`
+	Version   string    `yaml:"version,omitempty" json:"version,omitempty"`
+	Controls  []Control `yaml:"controls" json:"controls"`
+}
+
+// ParseCatalog parses catalog content and validates that the framework is
+// named, control IDs are unique, and every control has a keyword or an
+// obligation type to match on.
+func ParseCatalog(data []byte) (*Catalog, error) {
+	var catalog Catalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse catalog: %w", err)
+	}
+	if strings.TrimSpace(catalog.Framework) == "" {
+		return nil, fmt.Errorf("catalog framework is required")
+	}
+	if len(catalog.Controls) == 0 {
+		return nil, fmt.Errorf("catalog %s has no controls", catalog.Framework)
+	}
+
+	seen := make(map[string]bool)
+	for index, control := range catalog.Controls {
+		if strings.TrimSpace(control.ID) == "" {
+			return nil, fmt.Errorf("control %d: id is required", index+1)
+		}
+		if seen[control.ID] {
+			return nil, fmt.Errorf("control %s: duplicate id", control.ID)
+		}
+		seen[control.ID] = true
+		if len(control.Keywords) == 0 && len(control.ObligationTypes) == 0 {
+			return nil, fmt.Errorf("control %s: at least one keyword or obligation type is required", control.ID)
+		}
+	}
+	return &catalog, nil
+}
+
+// LoadCatalog reads and parses a catalog file.
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog: %w", err)
+	}
+	catalog, err := ParseCatalog(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return catalog, nil
+}
+
+// ControlRef identifies a control of a framework.
+type ControlRef struct {
+	Framework string `json:"framework"`
+	ID        string `json:"id"`
+	Title     string `json:"title,omitempty"`
+}
+
+// Key returns "framework/id", the column name used in the CSV matrix.
+func (ref ControlRef) Key() string {
+	return ref.Framework + "/" + ref.ID
+}
+
+// ControlLink records why an obligation is linked to a control.
+type ControlLink struct {
+	ControlRef
+	Basis        string   `json:"basis"`
+	MatchedTerms []string `json:"matched_terms,omitempty"`
+}
+
+// MappedObligation is an obligation of the graph with the controls that
+// address it.
+type MappedObligation struct {
+	URI            string        `json:"uri"`
+	Document       string        `json:"document"`
+	Article        string        `json:"article"`
+	ArticleTitle   string        `json:"article_title,omitempty"`
+	ObligationType string        `json:"obligation_type"`
+	DutyBearer     string        `json:"duty_bearer,omitempty"`
+	Controls       []ControlLink `json:"controls"`
+}
+
+// Mapping is the coverage matrix of obligations against controls.
+type Mapping struct {
+	Controls    []ControlRef        `json:"controls"`
+	Obligations []*MappedObligation `json:"obligations"`
+	// Uncovered lists obligations no control addresses.
+	Uncovered []string `json:"uncovered"`
+	// UnusedControls lists controls that address no obligation.
+	UnusedControls []string `json:"unused_controls"`
+	Coverage       float64  `json:"coverage"`
+}
+
+// Mapper links obligations to the controls of one or more catalogs.
+type Mapper struct {
+	// MinTerms is the number of distinct keywords that must match for a
+	// keyword link. Obligation type links are always made.
+	MinTerms int
+
+	catalogs []*Catalog
+	patterns map[string]*regexp.Regexp
+}
+
+// NewMapper creates a mapper over the given catalogs.
+func NewMapper(catalogs ...*Catalog) *Mapper {
+	mapper := &Mapper{
+		MinTerms: 1,
+		catalogs: catalogs,
+		patterns: make(map[string]*regexp.Regexp),
+	}
+	for _, catalog := range catalogs {
+		for _, control := range catalog.Controls {
+			for _, keyword := range control.Keywords {
+				key := strings.ToLower(strings.TrimSpace(keyword))
+				if key == "" || mapper.patterns[key] != nil {
+					continue
+				}
+				words := strings.Fields(regexp.QuoteMeta(key))
+				mapper.patterns[key] = regexp.MustCompile(`(?i)\b` + strings.Join(words, `\s+`) + `(?:e?s)?\b`)
+			}
+		}
+	}
+	return mapper
+}
+
+// Map links every obligation node in the store to the controls that
+// address it.
+func (m *Mapper) Map(tripleStore *store.TripleStore) *Mapping {
+	mapping := &Mapping{
+		Controls:       make([]ControlRef, 0),
+		Obligations:    make([]*MappedObligation, 0),
+		Uncovered:      make([]string, 0),
+		UnusedControls: make([]string, 0),
+	}
+	used := make(map[string]bool)
+	for _, catalog := range m.catalogs {
+		for _, control := range catalog.Controls {
+			mapping.Controls = append(mapping.Controls, ControlRef{Framework: catalog.Framework, ID: control.ID, Title: control.Title})
+		}
+	}
+
+	for _, triple := range tripleStore.Find("", store.RDFType, store.ClassObligation) {
+		obligation := describeObligation(tripleStore, triple.Subject)
+		text := obligationText(tripleStore, triple.Subject)
+
+		for _, catalog := range m.catalogs {
+			for _, control := range catalog.Controls {
+				link, ok := m.link(control, obligation.ObligationType, text)
+				if !ok {
+					continue
+				}
+				link.ControlRef = ControlRef{Framework: catalog.Framework, ID: control.ID, Title: control.Title}
+				obligation.Controls = append(obligation.Controls, link)
+				used[link.Key()] = true
+			}
+		}
+
+		mapping.Obligations = append(mapping.Obligations, obligation)
+	}
+
+	sort.Slice(mapping.Obligations, func(i, j int) bool {
+		a, b := mapping.Obligations[i], mapping.Obligations[j]
+		if a.Document != b.Document {
+			return a.Document < b.Document
+		}
+		if orderA, orderB := textutil.ArticleOrder(a.Article), textutil.ArticleOrder(b.Article); orderA != orderB {
+			return orderA < orderB
+		}
+		return a.URI < b.URI
+	})
+	for _, obligation := range mapping.Obligations {
+		if len(obligation.Controls) == 0 {
+			mapping.Uncovered = append(mapping.Uncovered, obligation.URI)
+		}
+	}
+	for _, control := range mapping.Controls {
+		if !used[control.Key()] {
+			mapping.UnusedControls = append(mapping.UnusedControls, control.Key())
+		}
+	}
+	if total := len(mapping.Obligations); total > 0 {
+		mapping.Coverage = float64(total-len(mapping.Uncovered)) / float64(total)
+	}
+	return mapping
+}
+
+// link decides whether control addresses an obligation of the given type
+// and text.
+func (m *Mapper) link(control Control, obligationType, text string) (ControlLink, bool) {
+	for _, linkedType := range control.ObligationTypes {
+		if linkedType == obligationType {
+			return ControlLink{Basis: BasisObligationType}, true
+		}
+	}
+
+	var matched []string
+	for _, keyword := range control.Keywords {
+		key := strings.ToLower(strings.TrimSpace(keyword))
+		if pattern := m.patterns[key]; pattern != nil && pattern.MatchString(text) {
+			matched = append(matched, key)
+		}
+	}
+	if len(matched) == 0 || len(matched) < m.MinTerms {
+		return ControlLink{}, false
+	}
+	return ControlLink{Basis: BasisKeyword, MatchedTerms: matched}, true
+}
+
+func describeObligation(tripleStore *store.TripleStore, uri string) *MappedObligation {
+	articleURI := tripleStore.GetOne(uri, store.PropPartOf)
+	regulationURI := tripleStore.GetOne(uri, store.PropBelongsTo)
+	document := tripleStore.GetOne(regulationURI, store.RDFSLabel)
+	if document == "" {
+		document = regulationURI
+	}
+	return &MappedObligation{
+		URI:            uri,
+		Document:       document,
+		Article:        tripleStore.GetOne(articleURI, store.PropNumber),
+		ArticleTitle:   tripleStore.GetOne(articleURI, store.PropTitle),
+		ObligationType: tripleStore.GetOne(uri, store.PropObligationType),
+		DutyBearer:     tripleStore.GetOne(uri, store.PropDutyBearer),
+		Controls:       make([]ControlLink, 0),
+	}
+}
+
+// obligationText is the text keywords are matched against: the obligation's
+// matched text and context and its article's title.
+func obligationText(tripleStore *store.TripleStore, uri string) string {
+	parts := []string{
+		tripleStore.GetOne(uri, store.PropText),
+		tripleStore.GetOne(uri, store.PropContext),
+		tripleStore.GetOne(tripleStore.GetOne(uri, store.PropPartOf), store.PropTitle),
+	}
+	return strings.Join(parts, "\n")
+}
+
+// ToCSV renders the matrix with one row per obligation and one column per
+// control; a cell holds the link basis, or is empty.
+func (mapping *Mapping) ToCSV() (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	header := []string{"document", "article", "article_title", "obligation_type", "duty_bearer"}
+	for _, control := range mapping.Controls {
+		header = append(header, control.Key())
+	}
+	if err := writer.Write(header); err != nil {
+		return "", err
+	}
+
+	for _, obligation := range mapping.Obligations {
+		bases := make(map[string]string)
+		for _, link := range obligation.Controls {
+			bases[link.Key()] = link.Basis
+		}
+		row := []string{obligation.Document, obligation.Article, obligation.ArticleTitle, obligation.ObligationType, obligation.DutyBearer}
+		for _, control := range mapping.Controls {
+			row = append(row, bases[control.Key()])
+		}
+		if err := writer.Write(row); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	return buf.String(), writer.Error()
+}
+
+// ToJSON serializes the mapping to JSON.
+func (mapping *Mapping) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(mapping, "", "  ")
+}
+
+// String returns a summary of coverage by control and the uncovered
+// obligations.
+func (mapping *Mapping) String() string {
+	var sb strings.Builder
+
+	covered := len(mapping.Obligations) - len(mapping.Uncovered)
+	sb.WriteString("Control Mapping\n")
+	sb.WriteString("===============\n\n")
+	sb.WriteString(fmt.Sprintf("Obligations: %d, covered: %d (%.0f%%)\n", len(mapping.Obligations), covered, mapping.Coverage*100))
+	sb.WriteString(fmt.Sprintf("Controls:    %d, unused: %d\n\n", len(mapping.Controls), len(mapping.UnusedControls)))
+
+	counts := make(map[string]int)
+	for _, obligation := range mapping.Obligations {
+		for _, link := range obligation.Controls {
+			counts[link.Key()]++
+		}
+	}
+	sb.WriteString(fmt.Sprintf("  %-28s  %-11s  %s\n", "Control", "Obligations", "Title"))
+	sb.WriteString(fmt.Sprintf("  %s  %s  %s\n", strings.Repeat("-", 28), strings.Repeat("-", 11), strings.Repeat("-", 30)))
+	for _, control := range mapping.Controls {
+		sb.WriteString(fmt.Sprintf("  %-28s  %11d  %s\n", control.Key(), counts[control.Key()], control.Title))
+	}
+
+	if len(mapping.Uncovered) > 0 {
+		sb.WriteString(fmt.Sprintf("\nUncovered obligations (%d):\n", len(mapping.Uncovered)))
+		for _, obligation := range mapping.Obligations {
+			if len(obligation.Controls) == 0 {
+				sb.WriteString(fmt.Sprintf("  %s Art %s  %s\n", obligation.Document, obligation.Article, obligation.ObligationType))
+			}
+		}
+	}
+	return sb.String()
+}
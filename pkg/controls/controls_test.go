@@ -0,0 +1,156 @@
+package controls
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+const testCatalog = `
+framework: ISO 27001
+version: "2022"
+controls:
+  - id: A.5.24
+    title: Incident management
+    keywords: [incident]
+    obligation_types: [BreachNotificationObligation]
+  - id: A.8.24
+    title: Use of cryptography
+    keywords: [encryption, pseudonymisation]
+  - id: A.6.3
+    title: Awareness and training
+    keywords: [training]
+`
+
+func TestParseCatalog(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(testCatalog))
+	if err != nil {
+		t.Fatalf("ParseCatalog: %v", err)
+	}
+	if catalog.Framework != "ISO 27001" || len(catalog.Controls) != 3 {
+		t.Errorf("catalog = %s with %d controls", catalog.Framework, len(catalog.Controls))
+	}
+	if got := catalog.Controls[0].ObligationTypes; len(got) != 1 || got[0] != "BreachNotificationObligation" {
+		t.Errorf("ObligationTypes = %v", got)
+	}
+}
+
+func TestParseCatalog_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"no framework", "controls:\n  - id: A\n    keywords: [x]\n"},
+		{"no controls", "framework: X\n"},
+		{"missing id", "framework: X\ncontrols:\n  - keywords: [x]\n"},
+		{"duplicate id", "framework: X\ncontrols:\n  - id: A\n    keywords: [x]\n  - id: A\n    keywords: [y]\n"},
+		{"nothing to match", "framework: X\ncontrols:\n  - id: A\n    title: Empty\n"},
+		{"malformed", "framework: [\n"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseCatalog([]byte(tt.content)); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func mappingTestStore() *store.TripleStore {
+	ts := store.NewTripleStore()
+	ts.Add("reg:TEST", store.RDFSLabel, "TEST")
+
+	obligations := []struct {
+		uri, article, title, obligationType, text string
+	}{
+		{"reg:TEST:Obligation:33:BreachNotificationObligation", "33", "Notification of a personal data breach", "BreachNotificationObligation", "shall notify the supervisory authority"},
+		{"reg:TEST:Obligation:32:SecurityObligation", "32", "Security of processing", "SecurityObligation", "shall implement measures such as pseudonymisation and encryption"},
+		{"reg:TEST:Obligation:5:Obligation", "5", "Principles", "Obligation", "shall be processed lawfully"},
+	}
+	for _, o := range obligations {
+		articleURI := "reg:TEST:Art" + o.article
+		ts.Add(articleURI, store.RDFType, store.ClassArticle)
+		ts.Add(articleURI, store.PropNumber, o.article)
+		ts.Add(articleURI, store.PropTitle, o.title)
+		ts.Add(o.uri, store.RDFType, store.ClassObligation)
+		ts.Add(o.uri, store.PropObligationType, o.obligationType)
+		ts.Add(o.uri, store.PropText, o.text)
+		ts.Add(o.uri, store.PropPartOf, articleURI)
+		ts.Add(o.uri, store.PropBelongsTo, "reg:TEST")
+	}
+	return ts
+}
+
+func TestMapper_Map(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(testCatalog))
+	if err != nil {
+		t.Fatalf("ParseCatalog: %v", err)
+	}
+	mapping := NewMapper(catalog).Map(mappingTestStore())
+
+	if len(mapping.Obligations) != 3 {
+		t.Fatalf("Obligations = %d, want 3", len(mapping.Obligations))
+	}
+	// Obligations are ordered by article number.
+	principles, security, breach := mapping.Obligations[0], mapping.Obligations[1], mapping.Obligations[2]
+	if principles.Article != "5" || security.Article != "32" || breach.Article != "33" {
+		t.Errorf("order = %s, %s, %s", principles.Article, security.Article, breach.Article)
+	}
+
+	if len(breach.Controls) != 1 || breach.Controls[0].ID != "A.5.24" || breach.Controls[0].Basis != BasisObligationType {
+		t.Errorf("breach controls = %+v, want A.5.24 by obligation type", breach.Controls)
+	}
+	if len(security.Controls) != 1 || security.Controls[0].ID != "A.8.24" || security.Controls[0].Basis != BasisKeyword {
+		t.Fatalf("security controls = %+v, want A.8.24 by keyword", security.Controls)
+	}
+	if terms := security.Controls[0].MatchedTerms; len(terms) != 2 {
+		t.Errorf("MatchedTerms = %v, want encryption and pseudonymisation", terms)
+	}
+
+	if len(mapping.Uncovered) != 1 || mapping.Uncovered[0] != principles.URI {
+		t.Errorf("Uncovered = %v", mapping.Uncovered)
+	}
+	if len(mapping.UnusedControls) != 1 || mapping.UnusedControls[0] != "ISO 27001/A.6.3" {
+		t.Errorf("UnusedControls = %v", mapping.UnusedControls)
+	}
+	if mapping.Coverage < 0.66 || mapping.Coverage > 0.67 {
+		t.Errorf("Coverage = %v, want 2/3", mapping.Coverage)
+	}
+}
+
+func TestMapper_MinTerms(t *testing.T) {
+	catalog, _ := ParseCatalog([]byte(testCatalog))
+	mapper := NewMapper(catalog)
+	mapper.MinTerms = 3
+	mapping := mapper.Map(mappingTestStore())
+
+	for _, obligation := range mapping.Obligations {
+		for _, link := range obligation.Controls {
+			if link.Basis == BasisKeyword {
+				t.Errorf("%s linked to %s by keyword with MinTerms 3", obligation.URI, link.Key())
+			}
+		}
+	}
+}
+
+func TestMapping_ToCSV(t *testing.T) {
+	catalog, _ := ParseCatalog([]byte(testCatalog))
+	output, err := NewMapper(catalog).Map(mappingTestStore()).ToCSV()
+	if err != nil {
+		t.Fatalf("ToCSV: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		t.Fatalf("parse CSV: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("rows = %d, want header and 3 obligations", len(records))
+	}
+	header := strings.Join(records[0], ",")
+	if header != "document,article,article_title,obligation_type,duty_bearer,ISO 27001/A.5.24,ISO 27001/A.8.24,ISO 27001/A.6.3" {
+		t.Errorf("header = %s", header)
+	}
+	if got := records[2][6]; got != BasisKeyword {
+		t.Errorf("Art 32 / A.8.24 cell = %q, want %q", got, BasisKeyword)
+	}
+}
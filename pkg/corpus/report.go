@@ -0,0 +1,82 @@
+package corpus
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// maxTriplesShown limits how many changed triples are listed per document.
+const maxTriplesShown = 10
+
+// FormatReportTable formats a RunReport for terminal output.
+func FormatReportTable(report *RunReport) string {
+	var builder strings.Builder
+
+	builder.WriteString("\nCorpus Regression Report\n")
+	builder.WriteString(strings.Repeat("═", 80) + "\n")
+	builder.WriteString(fmt.Sprintf("Corpus:    %s\n", report.CorpusDir))
+	builder.WriteString(fmt.Sprintf("Baselines: %s\n", report.BaselineDir))
+	builder.WriteString(strings.Repeat("─", 80) + "\n")
+
+	for _, result := range report.Results {
+		marker := "✓"
+		if result.IsRegression() {
+			marker = "✗"
+		} else if result.Status == StatusChanged {
+			marker = "~"
+		}
+		builder.WriteString(fmt.Sprintf("  %s %-30s %-10s %v\n",
+			marker, result.DocumentID, result.Status, result.Duration.Round(1e6)))
+
+		if result.Error != "" {
+			builder.WriteString(fmt.Sprintf("      error: %s\n", result.Error))
+		}
+		for _, delta := range result.MetricDeltas {
+			flag := ""
+			if delta.Exceeded {
+				flag = " (exceeds tolerance)"
+			}
+			builder.WriteString(fmt.Sprintf("      %-14s %6d → %-6d %+.1f%%%s\n",
+				delta.Metric, delta.Baseline, delta.Current, delta.Change*100, flag))
+		}
+		if result.FingerprintChanged && len(result.MetricDeltas) == 0 &&
+			len(result.TriplesAdded) == 0 && len(result.TriplesRemoved) == 0 {
+			builder.WriteString("      triple set changed outside the sampled range\n")
+		}
+		writeTriples(&builder, "-", result.TriplesRemoved)
+		writeTriples(&builder, "+", result.TriplesAdded)
+	}
+
+	builder.WriteString(strings.Repeat("─", 80) + "\n")
+	builder.WriteString(fmt.Sprintf("Unchanged: %d | Changed: %d | New: %d | Updated: %d | Errors: %d\n",
+		report.Unchanged, report.Changed, report.New, report.Updated, report.Errors))
+	if report.Passed() {
+		builder.WriteString(fmt.Sprintf("Result: PASS (%v)\n", report.Duration.Round(1e6)))
+	} else {
+		builder.WriteString(fmt.Sprintf("Result: FAIL — %d regression(s) (%v)\n",
+			report.Regressions, report.Duration.Round(1e6)))
+	}
+
+	return builder.String()
+}
+
+// FormatReportJSON formats a RunReport as indented JSON.
+func FormatReportJSON(report *RunReport) (string, error) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return string(data), nil
+}
+
+func writeTriples(builder *strings.Builder, prefix string, triples []SampledTriple) {
+	for i, triple := range triples {
+		if i == maxTriplesShown {
+			builder.WriteString(fmt.Sprintf("      %s ... %d more\n", prefix, len(triples)-maxTriplesShown))
+			break
+		}
+		builder.WriteString(fmt.Sprintf("      %s %s %s %s\n",
+			prefix, triple.Subject, triple.Predicate, triple.Object))
+	}
+}
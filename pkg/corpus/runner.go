@@ -0,0 +1,421 @@
+package corpus
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+const defaultBaseURI = "https://regula.dev/regulations/"
+
+// manifestFile mirrors the entries of a testdata corpus manifest.json.
+type manifestFile struct {
+	Entries []struct {
+		ID         string `json:"id"`
+		Format     string `json:"format"`
+		SourcePath string `json:"source_path"`
+	} `json:"entries"`
+}
+
+// DiscoverDocuments lists the corpus documents in corpusDir. When the
+// directory contains a manifest.json its entries are used, otherwise every
+// .txt file in the directory is treated as a corpus document.
+func DiscoverDocuments(corpusDir string) ([]Document, error) {
+	info, err := os.Stat(corpusDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat corpus directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("corpus path is not a directory: %s", corpusDir)
+	}
+
+	manifestPath := filepath.Join(corpusDir, "manifest.json")
+	if manifestData, err := os.ReadFile(manifestPath); err == nil {
+		var manifest manifestFile
+		if err := json.Unmarshal(manifestData, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse corpus manifest: %w", err)
+		}
+		documents := make([]Document, 0, len(manifest.Entries))
+		for _, entry := range manifest.Entries {
+			documents = append(documents, Document{
+				ID:         entry.ID,
+				SourcePath: filepath.Clean(filepath.Join(corpusDir, entry.SourcePath)),
+				Format:     entry.Format,
+			})
+		}
+		return documents, nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(corpusDir, "*.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to glob corpus directory: %w", err)
+	}
+	sort.Strings(matches)
+
+	documents := make([]Document, 0, len(matches))
+	for _, sourcePath := range matches {
+		documents = append(documents, Document{
+			ID:         library.DeriveDocumentID(sourcePath),
+			SourcePath: sourcePath,
+		})
+	}
+	return documents, nil
+}
+
+// Capture ingests a single corpus document and returns a baseline snapshot
+// of its extraction output.
+func Capture(document Document, baseURI string, sampleSize int) (*Baseline, error) {
+	baseline, _, err := capture(document, baseURI, sampleSize)
+	return baseline, err
+}
+
+// capture ingests a document and returns its snapshot together with the
+// full triple set, which Compare needs to confirm removals.
+func capture(document Document, baseURI string, sampleSize int) (*Baseline, []store.Triple, error) {
+	if baseURI == "" {
+		baseURI = defaultBaseURI
+	}
+	if sampleSize <= 0 {
+		sampleSize = DefaultSampleSize
+	}
+
+	sourceText, err := os.ReadFile(document.SourcePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read source: %w", err)
+	}
+
+	result, err := library.IngestFromText(sourceText, document.ID, baseURI, document.Format)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	triples := result.TripleStore.All()
+	return &Baseline{
+		Version:     BaselineVersion,
+		DocumentID:  document.ID,
+		SourcePath:  document.SourcePath,
+		Format:      document.Format,
+		GeneratedAt: time.Now().UTC(),
+		Metrics:     result.Stats,
+		Fingerprint: Fingerprint(triples),
+		Sample:      SampleTriples(triples, sampleSize),
+	}, triples, nil
+}
+
+// Fingerprint returns a SHA-256 digest of the sorted triple set, which
+// changes whenever any triple is added, removed, or altered.
+func Fingerprint(triples []store.Triple) string {
+	lines := make([]string, 0, len(triples))
+	for _, triple := range triples {
+		lines = append(lines, triple.NTriples())
+	}
+	sort.Strings(lines)
+
+	hasher := sha256.New()
+	for _, line := range lines {
+		hasher.Write([]byte(line))
+		hasher.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// SampleTriples selects the sampleSize triples with the lowest stable hash.
+// Hash-based selection keeps the sample stable when unrelated triples are
+// added or removed, so diffs only surface genuine changes.
+func SampleTriples(triples []store.Triple, sampleSize int) []SampledTriple {
+	sampled := make([]SampledTriple, 0, len(triples))
+	for _, triple := range triples {
+		sampled = append(sampled, SampledTriple{
+			Subject:   triple.Subject,
+			Predicate: triple.Predicate,
+			Object:    triple.Object,
+			Hash:      tripleHash(triple.Subject, triple.Predicate, triple.Object),
+		})
+	}
+	sort.Slice(sampled, func(i, j int) bool {
+		if sampled[i].Hash != sampled[j].Hash {
+			return sampled[i].Hash < sampled[j].Hash
+		}
+		return sampledKey(sampled[i]) < sampledKey(sampled[j])
+	})
+	if len(sampled) > sampleSize {
+		sampled = sampled[:sampleSize]
+	}
+	return sampled
+}
+
+// Compare diffs a current snapshot against a stored baseline. tolerance is
+// the permitted relative change in any metric; at 0 the triple sets must
+// match exactly.
+func Compare(baseline, current *Baseline, currentTriples []store.Triple, tolerance float64) *DocumentResult {
+	result := &DocumentResult{
+		DocumentID:         current.DocumentID,
+		SourcePath:         current.SourcePath,
+		Status:             StatusUnchanged,
+		FingerprintChanged: baseline.Fingerprint != current.Fingerprint,
+		Exact:              tolerance == 0,
+	}
+
+	result.MetricDeltas = compareMetrics(baseline.Metrics, current.Metrics, tolerance)
+
+	if result.FingerprintChanged {
+		result.TriplesAdded, result.TriplesRemoved = compareSamples(baseline.Sample, currentTriples)
+	}
+
+	if result.FingerprintChanged || len(result.MetricDeltas) > 0 {
+		result.Status = StatusChanged
+	}
+	return result
+}
+
+// Run executes a regression run over every document in the corpus.
+func Run(config RunConfig) (*RunReport, error) {
+	if config.BaselineDir == "" {
+		return nil, fmt.Errorf("baseline directory is required")
+	}
+	if config.SampleSize <= 0 {
+		config.SampleSize = DefaultSampleSize
+	}
+	if config.BaseURI == "" {
+		config.BaseURI = defaultBaseURI
+	}
+
+	documents, err := DiscoverDocuments(config.CorpusDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(config.BaselineDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create baseline directory: %w", err)
+	}
+
+	runStart := time.Now()
+	report := &RunReport{
+		CorpusDir:   config.CorpusDir,
+		BaselineDir: config.BaselineDir,
+		Results:     make([]*DocumentResult, 0, len(documents)),
+	}
+
+	for _, document := range documents {
+		documentResult := runDocument(document, config)
+		report.Results = append(report.Results, documentResult)
+
+		switch documentResult.Status {
+		case StatusUnchanged:
+			report.Unchanged++
+		case StatusChanged:
+			report.Changed++
+		case StatusNew:
+			report.New++
+		case StatusUpdated:
+			report.Updated++
+		case StatusError:
+			report.Errors++
+		}
+		if documentResult.IsRegression() {
+			report.Regressions++
+		}
+	}
+
+	report.Duration = time.Since(runStart)
+	return report, nil
+}
+
+// runDocument captures and compares a single corpus document.
+func runDocument(document Document, config RunConfig) *DocumentResult {
+	documentStart := time.Now()
+	baselinePath := BaselinePath(config.BaselineDir, document.ID)
+
+	current, currentTriples, err := capture(document, config.BaseURI, config.SampleSize)
+	if err != nil {
+		return &DocumentResult{
+			DocumentID: document.ID,
+			SourcePath: document.SourcePath,
+			Status:     StatusError,
+			Error:      err.Error(),
+			Duration:   time.Since(documentStart),
+		}
+	}
+
+	existing, loadErr := LoadBaseline(baselinePath)
+
+	if config.Update || os.IsNotExist(loadErr) {
+		status := StatusUpdated
+		if os.IsNotExist(loadErr) {
+			status = StatusNew
+		}
+		if err := SaveBaseline(baselinePath, current); err != nil {
+			return &DocumentResult{
+				DocumentID: document.ID,
+				SourcePath: document.SourcePath,
+				Status:     StatusError,
+				Error:      err.Error(),
+				Duration:   time.Since(documentStart),
+			}
+		}
+		return &DocumentResult{
+			DocumentID: document.ID,
+			SourcePath: document.SourcePath,
+			Status:     status,
+			Duration:   time.Since(documentStart),
+		}
+	}
+	if loadErr != nil {
+		return &DocumentResult{
+			DocumentID: document.ID,
+			SourcePath: document.SourcePath,
+			Status:     StatusError,
+			Error:      loadErr.Error(),
+			Duration:   time.Since(documentStart),
+		}
+	}
+
+	documentResult := Compare(existing, current, currentTriples, config.Tolerance)
+	documentResult.Duration = time.Since(documentStart)
+	return documentResult
+}
+
+// BaselinePath returns the baseline file path for a document ID.
+func BaselinePath(baselineDir, documentID string) string {
+	return filepath.Join(baselineDir, documentID+".json")
+}
+
+// LoadBaseline reads a baseline from disk. A missing file is reported with
+// an error satisfying os.IsNotExist.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	return &baseline, nil
+}
+
+// SaveBaseline writes a baseline to disk as indented JSON.
+func SaveBaseline(path string, baseline *Baseline) error {
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write baseline %s: %w", path, err)
+	}
+	return nil
+}
+
+// compareMetrics returns a delta for every metric that differs.
+func compareMetrics(baseline, current *library.DocumentStats, tolerance float64) []MetricDelta {
+	if baseline == nil || current == nil {
+		return nil
+	}
+
+	pairs := []struct {
+		name     string
+		baseline int
+		current  int
+	}{
+		{"total_triples", baseline.TotalTriples, current.TotalTriples},
+		{"articles", baseline.Articles, current.Articles},
+		{"chapters", baseline.Chapters, current.Chapters},
+		{"sections", baseline.Sections, current.Sections},
+		{"recitals", baseline.Recitals, current.Recitals},
+		{"definitions", baseline.Definitions, current.Definitions},
+		{"references", baseline.References, current.References},
+		{"rights", baseline.Rights, current.Rights},
+		{"obligations", baseline.Obligations, current.Obligations},
+		{"term_usages", baseline.TermUsages, current.TermUsages},
+		{"source_bytes", baseline.SourceBytes, current.SourceBytes},
+	}
+
+	var deltas []MetricDelta
+	for _, pair := range pairs {
+		if pair.baseline == pair.current {
+			continue
+		}
+		change := 1.0
+		if pair.baseline != 0 {
+			change = float64(pair.current-pair.baseline) / float64(pair.baseline)
+		}
+		deltas = append(deltas, MetricDelta{
+			Metric:   pair.name,
+			Baseline: pair.baseline,
+			Current:  pair.current,
+			Change:   change,
+			Exceeded: math.Abs(change) > tolerance,
+		})
+	}
+	return deltas
+}
+
+// compareSamples detects sampled triples that disappeared and new triples
+// that fall inside the baseline's sampled hash range.
+func compareSamples(baselineSample []SampledTriple, currentTriples []store.Triple) (added, removed []SampledTriple) {
+	if len(baselineSample) == 0 {
+		return nil, nil
+	}
+
+	currentKeys := make(map[string]bool, len(currentTriples))
+	for _, triple := range currentTriples {
+		currentKeys[tripleKey(triple.Subject, triple.Predicate, triple.Object)] = true
+	}
+
+	baselineKeys := make(map[string]bool, len(baselineSample))
+	var hashCeiling uint64
+	for _, sampled := range baselineSample {
+		baselineKeys[sampledKey(sampled)] = true
+		if sampled.Hash > hashCeiling {
+			hashCeiling = sampled.Hash
+		}
+		if !currentKeys[sampledKey(sampled)] {
+			removed = append(removed, sampled)
+		}
+	}
+
+	for _, triple := range currentTriples {
+		hash := tripleHash(triple.Subject, triple.Predicate, triple.Object)
+		if hash > hashCeiling {
+			continue
+		}
+		key := tripleKey(triple.Subject, triple.Predicate, triple.Object)
+		if baselineKeys[key] {
+			continue
+		}
+		added = append(added, SampledTriple{
+			Subject:   triple.Subject,
+			Predicate: triple.Predicate,
+			Object:    triple.Object,
+			Hash:      hash,
+		})
+	}
+	sort.Slice(added, func(i, j int) bool { return added[i].Hash < added[j].Hash })
+
+	return added, removed
+}
+
+func tripleKey(subject, predicate, object string) string {
+	return strings.Join([]string{subject, predicate, object}, "\x00")
+}
+
+func sampledKey(sampled SampledTriple) string {
+	return tripleKey(sampled.Subject, sampled.Predicate, sampled.Object)
+}
+
+func tripleHash(subject, predicate, object string) uint64 {
+	hasher := fnv.New64a()
+	hasher.Write([]byte(tripleKey(subject, predicate, object)))
+	return hasher.Sum64()
+}
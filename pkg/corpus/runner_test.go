@@ -0,0 +1,219 @@
+package corpus
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// writeCorpus copies a testdata document into a temporary corpus directory.
+func writeCorpus(t *testing.T, sourceName string) string {
+	t.Helper()
+	corpusDir := t.TempDir()
+	sourceText, err := os.ReadFile(filepath.Join("..", "..", "testdata", sourceName))
+	if err != nil {
+		t.Fatalf("failed to read testdata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(corpusDir, sourceName), sourceText, 0644); err != nil {
+		t.Fatalf("failed to write corpus document: %v", err)
+	}
+	return corpusDir
+}
+
+func TestDiscoverDocuments(t *testing.T) {
+	t.Run("text files", func(t *testing.T) {
+		corpusDir := writeCorpus(t, "vcdpa.txt")
+		documents, err := DiscoverDocuments(corpusDir)
+		if err != nil {
+			t.Fatalf("DiscoverDocuments failed: %v", err)
+		}
+		if len(documents) != 1 || documents[0].ID != "vcdpa" {
+			t.Errorf("unexpected documents: %+v", documents)
+		}
+	})
+
+	t.Run("manifest", func(t *testing.T) {
+		documents, err := DiscoverDocuments(filepath.Join("..", "..", "testdata", "corpus"))
+		if err != nil {
+			t.Fatalf("DiscoverDocuments failed: %v", err)
+		}
+		if len(documents) < 10 {
+			t.Fatalf("expected manifest entries, got %d", len(documents))
+		}
+		for _, document := range documents {
+			if _, err := os.Stat(document.SourcePath); err != nil {
+				t.Errorf("%s: source not found at %s", document.ID, document.SourcePath)
+			}
+		}
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		if _, err := DiscoverDocuments(filepath.Join(t.TempDir(), "missing")); err == nil {
+			t.Error("expected error for missing directory")
+		}
+	})
+}
+
+func TestSampleTriplesStable(t *testing.T) {
+	triples := []store.Triple{
+		store.NewTriple("a", "p", "1"),
+		store.NewTriple("b", "p", "2"),
+		store.NewTriple("c", "p", "3"),
+		store.NewTriple("d", "p", "4"),
+	}
+	first := SampleTriples(triples, 2)
+	reversed := []store.Triple{triples[3], triples[2], triples[1], triples[0]}
+	second := SampleTriples(reversed, 2)
+
+	if len(first) != 2 {
+		t.Fatalf("expected 2 sampled triples, got %d", len(first))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("sample differs with input order: %+v vs %+v", first[i], second[i])
+		}
+	}
+	if Fingerprint(triples) != Fingerprint(reversed) {
+		t.Error("fingerprint should not depend on triple order")
+	}
+}
+
+func TestCompareDetectsChanges(t *testing.T) {
+	baselineTriples := []store.Triple{
+		store.NewTriple("a", "p", "1"),
+		store.NewTriple("b", "p", "2"),
+	}
+	currentTriples := []store.Triple{
+		store.NewTriple("a", "p", "1"),
+		store.NewTriple("c", "p", "3"),
+	}
+	baseline := &Baseline{
+		DocumentID:  "doc",
+		Metrics:     &library.DocumentStats{TotalTriples: 2, Articles: 10},
+		Fingerprint: Fingerprint(baselineTriples),
+		Sample:      SampleTriples(baselineTriples, 10),
+	}
+	baseline.Sample[len(baseline.Sample)-1].Hash = ^uint64(0) // widen sampled range
+	current := &Baseline{
+		DocumentID:  "doc",
+		Metrics:     &library.DocumentStats{TotalTriples: 2, Articles: 9},
+		Fingerprint: Fingerprint(currentTriples),
+	}
+
+	result := Compare(baseline, current, currentTriples, 0.2)
+	if result.Status != StatusChanged {
+		t.Fatalf("expected changed status, got %s", result.Status)
+	}
+	if len(result.MetricDeltas) != 1 || result.MetricDeltas[0].Exceeded {
+		t.Errorf("expected one tolerated metric delta, got %+v", result.MetricDeltas)
+	}
+	if len(result.TriplesRemoved) != 1 || result.TriplesRemoved[0].Subject != "b" {
+		t.Errorf("expected triple b removed, got %+v", result.TriplesRemoved)
+	}
+	if len(result.TriplesAdded) != 1 || result.TriplesAdded[0].Subject != "c" {
+		t.Errorf("expected triple c added, got %+v", result.TriplesAdded)
+	}
+	if !result.IsRegression() {
+		t.Error("triple changes should be a regression")
+	}
+}
+
+func TestCompareUnsampledTripleChange(t *testing.T) {
+	baselineTriples := []store.Triple{
+		store.NewTriple("a", "p", "1"),
+		store.NewTriple("b", "p", "2"),
+	}
+	currentTriples := []store.Triple{
+		store.NewTriple("a", "p", "1"),
+		store.NewTriple("c", "p", "3"),
+	}
+	metrics := &library.DocumentStats{TotalTriples: 2, Articles: 10}
+	baseline := &Baseline{
+		DocumentID:  "doc",
+		Metrics:     metrics,
+		Fingerprint: Fingerprint(baselineTriples),
+	}
+	current := &Baseline{
+		DocumentID:  "doc",
+		Metrics:     metrics,
+		Fingerprint: Fingerprint(currentTriples),
+	}
+
+	result := Compare(baseline, current, currentTriples, 0)
+	if len(result.MetricDeltas) != 0 || len(result.TriplesAdded) != 0 || len(result.TriplesRemoved) != 0 {
+		t.Fatalf("expected no metric or sampled changes, got %+v", result)
+	}
+	if !result.IsRegression() {
+		t.Error("a changed triple set should be a regression at tolerance 0")
+	}
+
+	if Compare(baseline, current, currentTriples, 0.05).IsRegression() {
+		t.Error("an unsampled change within tolerance should not be a regression")
+	}
+}
+
+func TestRunCreatesAndMatchesBaselines(t *testing.T) {
+	corpusDir := writeCorpus(t, "vcdpa.txt")
+	baselineDir := filepath.Join(t.TempDir(), "baselines")
+	config := RunConfig{CorpusDir: corpusDir, BaselineDir: baselineDir}
+
+	firstReport, err := Run(config)
+	if err != nil {
+		t.Fatalf("first Run failed: %v", err)
+	}
+	if firstReport.New != 1 || !firstReport.Passed() {
+		t.Fatalf("expected one new baseline, got %+v", firstReport)
+	}
+	if _, err := os.Stat(BaselinePath(baselineDir, "vcdpa")); err != nil {
+		t.Fatalf("baseline not written: %v", err)
+	}
+
+	secondReport, err := Run(config)
+	if err != nil {
+		t.Fatalf("second Run failed: %v", err)
+	}
+	if secondReport.Unchanged != 1 || !secondReport.Passed() {
+		t.Errorf("expected unchanged result, got:\n%s", FormatReportTable(secondReport))
+	}
+}
+
+func TestRunReportsRegression(t *testing.T) {
+	corpusDir := writeCorpus(t, "vcdpa.txt")
+	baselineDir := filepath.Join(t.TempDir(), "baselines")
+	config := RunConfig{CorpusDir: corpusDir, BaselineDir: baselineDir}
+
+	if _, err := Run(config); err != nil {
+		t.Fatalf("initial Run failed: %v", err)
+	}
+
+	// Truncate the document so extraction output shrinks.
+	sourcePath := filepath.Join(corpusDir, "vcdpa.txt")
+	sourceText, _ := os.ReadFile(sourcePath)
+	if err := os.WriteFile(sourcePath, sourceText[:len(sourceText)/2], 0644); err != nil {
+		t.Fatalf("failed to truncate source: %v", err)
+	}
+
+	report, err := Run(config)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if report.Passed() {
+		t.Fatalf("expected regression, got:\n%s", FormatReportTable(report))
+	}
+	if !strings.Contains(FormatReportTable(report), "FAIL") {
+		t.Error("table report should indicate failure")
+	}
+
+	config.Update = true
+	updated, err := Run(config)
+	if err != nil {
+		t.Fatalf("update Run failed: %v", err)
+	}
+	if updated.Updated != 1 || !updated.Passed() {
+		t.Errorf("expected updated baseline, got %+v", updated)
+	}
+}
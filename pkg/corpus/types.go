@@ -0,0 +1,141 @@
+// Package corpus provides a regression runner that ingests a corpus of
+// regulation documents, captures extraction metrics and a stable sample of
+// triples, and compares them against stored baselines.
+package corpus
+
+import (
+	"time"
+
+	"github.com/coolbeans/regula/pkg/library"
+)
+
+// DefaultSampleSize is the number of triples captured per document baseline.
+const DefaultSampleSize = 50
+
+// BaselineVersion identifies the on-disk baseline format.
+const BaselineVersion = "1.0"
+
+// Document describes a single corpus document to be ingested.
+type Document struct {
+	ID         string `json:"id"`
+	SourcePath string `json:"source_path"`
+	Format     string `json:"format,omitempty"`
+}
+
+// SampledTriple is a triple captured in a baseline along with its stable hash.
+type SampledTriple struct {
+	Subject   string `json:"subject"`
+	Predicate string `json:"predicate"`
+	Object    string `json:"object"`
+	Hash      uint64 `json:"hash"`
+}
+
+// Baseline is the stored extraction snapshot for one corpus document.
+type Baseline struct {
+	Version     string                 `json:"version"`
+	DocumentID  string                 `json:"document_id"`
+	SourcePath  string                 `json:"source_path"`
+	Format      string                 `json:"format,omitempty"`
+	GeneratedAt time.Time              `json:"generated_at"`
+	Metrics     *library.DocumentStats `json:"metrics"`
+	Fingerprint string                 `json:"fingerprint"`
+	Sample      []SampledTriple        `json:"sample"`
+}
+
+// RunConfig controls a corpus regression run.
+type RunConfig struct {
+	// CorpusDir is the directory containing corpus documents.
+	CorpusDir string
+	// BaselineDir is the directory where baselines are read and written.
+	BaselineDir string
+	// SampleSize is the number of triples to sample per document.
+	SampleSize int
+	// Tolerance is the permitted relative change (0.05 = 5%) in any metric
+	// before it is reported as a regression. At 0, any change to the triple
+	// set is a regression, including one the metrics and sample miss.
+	Tolerance float64
+	// Update rewrites baselines from the current extraction output.
+	Update bool
+	// BaseURI is used when building graphs.
+	BaseURI string
+}
+
+// DocumentStatus is the outcome of comparing one document against its baseline.
+type DocumentStatus string
+
+const (
+	// StatusUnchanged indicates extraction output matches the baseline.
+	StatusUnchanged DocumentStatus = "unchanged"
+	// StatusChanged indicates extraction output differs from the baseline.
+	StatusChanged DocumentStatus = "changed"
+	// StatusNew indicates no baseline existed for the document.
+	StatusNew DocumentStatus = "new"
+	// StatusUpdated indicates the baseline was rewritten.
+	StatusUpdated DocumentStatus = "updated"
+	// StatusError indicates the document could not be ingested.
+	StatusError DocumentStatus = "error"
+)
+
+// MetricDelta records a change in a single extraction metric.
+type MetricDelta struct {
+	Metric   string  `json:"metric"`
+	Baseline int     `json:"baseline"`
+	Current  int     `json:"current"`
+	Change   float64 `json:"change"`
+	Exceeded bool    `json:"exceeded"`
+}
+
+// DocumentResult is the comparison outcome for a single corpus document.
+type DocumentResult struct {
+	DocumentID         string          `json:"document_id"`
+	SourcePath         string          `json:"source_path"`
+	Status             DocumentStatus  `json:"status"`
+	FingerprintChanged bool            `json:"fingerprint_changed"`
+	MetricDeltas       []MetricDelta   `json:"metric_deltas,omitempty"`
+	TriplesAdded       []SampledTriple `json:"triples_added,omitempty"`
+	TriplesRemoved     []SampledTriple `json:"triples_removed,omitempty"`
+	Error              string          `json:"error,omitempty"`
+	Duration           time.Duration   `json:"duration_ns"`
+
+	// Exact is set when the comparison permitted no change (tolerance 0),
+	// making a changed fingerprint a regression on its own.
+	Exact bool `json:"exact,omitempty"`
+}
+
+// IsRegression reports whether the result should fail a regression run.
+func (r *DocumentResult) IsRegression() bool {
+	if r.Status == StatusError {
+		return true
+	}
+	if r.Status != StatusChanged {
+		return false
+	}
+	if r.Exact && r.FingerprintChanged {
+		return true
+	}
+	for _, delta := range r.MetricDeltas {
+		if delta.Exceeded {
+			return true
+		}
+	}
+	return len(r.TriplesAdded) > 0 || len(r.TriplesRemoved) > 0
+}
+
+// RunReport aggregates the results of a corpus regression run.
+type RunReport struct {
+	CorpusDir   string            `json:"corpus_dir"`
+	BaselineDir string            `json:"baseline_dir"`
+	Results     []*DocumentResult `json:"results"`
+	Unchanged   int               `json:"unchanged"`
+	Changed     int               `json:"changed"`
+	New         int               `json:"new"`
+	Updated     int               `json:"updated"`
+	Errors      int               `json:"errors"`
+	Regressions int               `json:"regressions"`
+	Duration    time.Duration     `json:"duration_ns"`
+}
+
+// Passed reports whether the run completed without regressions.
+func (r *RunReport) Passed() bool {
+	return r.Regressions == 0
+}
@@ -0,0 +1,271 @@
+package crawler
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/citation"
+	"github.com/coolbeans/regula/pkg/eurlex"
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/ukleg"
+)
+
+// Connector resolves the references of one legislation source to fetchable
+// URLs and extracts the legislation text from the pages that source serves.
+// Connectors registered with a SourceResolver are tried after its built-in
+// US patterns.
+type Connector interface {
+	// Name identifies the connector. Crawl items record it so that resumed
+	// crawls parse their content with the same connector.
+	Name() string
+
+	// Resolve resolves a citation, URN, or URL, returning an error when the
+	// reference does not belong to this source.
+	Resolve(reference string) (*ResolvedSource, error)
+
+	// Parse extracts the legislation text from fetched content.
+	Parse(content *FetchedContent) (*ParsedSource, error)
+}
+
+// ParsedSource is legislation text extracted by a connector, with the
+// metadata it is ingested into the library with.
+type ParsedSource struct {
+	// Text is the plain legislation text.
+	Text []byte
+
+	// Title is the document title, if the page states one.
+	Title string
+
+	// Format is the parser format hint (eu, uk).
+	Format string
+
+	// Jurisdiction is the jurisdiction code (EU, GB).
+	Jurisdiction string
+}
+
+// htmlTitlePattern captures the contents of an HTML title element.
+var htmlTitlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// extractMainContent returns the plain text of the HTML from the first of
+// the given content markers on, or of the whole page if none is present.
+func extractMainContent(rawHTML []byte, markers []string) []byte {
+	content := string(rawHTML)
+	for _, marker := range markers {
+		index := strings.Index(content, marker)
+		if index < 0 {
+			continue
+		}
+		if tagStart := strings.LastIndex(content[:index], "<"); tagStart >= 0 {
+			index = tagStart
+		}
+		return ExtractTextFromHTML([]byte(content[index:]))
+	}
+	return ExtractTextFromHTML(rawHTML)
+}
+
+// extractHTMLTitle returns the text of the HTML title element.
+func extractHTMLTitle(rawHTML []byte) string {
+	match := htmlTitlePattern.FindSubmatch(rawHTML)
+	if match == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(string(ExtractTextFromHTML(match[1]))), " ")
+}
+
+// EURLexConnector resolves EU regulations, directives, and decisions to
+// their EUR-Lex HTML text. It accepts citations such as "Regulation (EU)
+// 2016/679" and "Directive 95/46/EC", urn:eu URNs, CELEX URLs, and ELI URIs.
+type EURLexConnector struct {
+	citationParser *citation.EUCitationParser
+}
+
+// EURLexTextURL is the EUR-Lex URL prefix for the HTML text of a document
+// by CELEX number.
+const EURLexTextURL = "https://eur-lex.europa.eu/legal-content/EN/TXT/HTML/?uri=CELEX:"
+
+var (
+	euURNPattern   = regexp.MustCompile(`^urn:eu:(regulation|directive|decision):(\d{2,4})/(\d+)$`)
+	euCELEXPattern = regexp.MustCompile(`(?i)CELEX(?::|%3A)3(\d{4})([RLD])0*(\d+)`)
+	euELIPattern   = regexp.MustCompile(`(?i)/eli/(reg|dir|dec)/(\d{4})/(\d+)`)
+)
+
+// euActTypes maps URN, CELEX, and ELI act type codes to citation types.
+var euActTypes = map[string]citation.CitationType{
+	"regulation": citation.CitationTypeRegulation,
+	"directive":  citation.CitationTypeDirective,
+	"decision":   citation.CitationTypeDecision,
+	"r":          citation.CitationTypeRegulation,
+	"l":          citation.CitationTypeDirective,
+	"d":          citation.CitationTypeDecision,
+	"reg":        citation.CitationTypeRegulation,
+	"dir":        citation.CitationTypeDirective,
+	"dec":        citation.CitationTypeDecision,
+}
+
+// euDocumentIDSlugs maps citation types to document ID slugs.
+var euDocumentIDSlugs = map[citation.CitationType]string{
+	citation.CitationTypeRegulation: "reg",
+	citation.CitationTypeDirective:  "dir",
+	citation.CitationTypeDecision:   "dec",
+}
+
+// NewEURLexConnector creates an EUR-Lex connector.
+func NewEURLexConnector() *EURLexConnector {
+	return &EURLexConnector{citationParser: citation.NewEUCitationParser()}
+}
+
+// Name returns "eurlex".
+func (connector *EURLexConnector) Name() string {
+	return "eurlex"
+}
+
+// Resolve resolves an EU act reference to its EUR-Lex HTML text.
+func (connector *EURLexConnector) Resolve(reference string) (*ResolvedSource, error) {
+	citationRef := connector.parseReference(strings.TrimSpace(reference))
+	if citationRef == nil {
+		return nil, fmt.Errorf("not an EU act reference: %s", reference)
+	}
+
+	celexNumber, err := eurlex.GenerateCELEX(citationRef)
+	if err != nil {
+		return nil, err
+	}
+	celex := celexNumber.String()
+
+	return &ResolvedSource{
+		URL:        EURLexTextURL + celex,
+		DocumentID: fmt.Sprintf("eu-%s-%s-%s", euDocumentIDSlugs[citationRef.Type], celex[1:5], citationRef.Components.DocNumber),
+		Domain:     "eur-lex.europa.eu",
+		SourceName: "EUR-Lex",
+	}, nil
+}
+
+// parseReference returns the act a reference identifies, or nil.
+func (connector *EURLexConnector) parseReference(reference string) *citation.Citation {
+	newCitation := func(actType, year, number string) *citation.Citation {
+		return &citation.Citation{
+			Type:       euActTypes[strings.ToLower(actType)],
+			Components: citation.CitationComponents{DocYear: year, DocNumber: number},
+		}
+	}
+
+	if match := euURNPattern.FindStringSubmatch(reference); match != nil {
+		return newCitation(match[1], match[2], match[3])
+	}
+	if match := euCELEXPattern.FindStringSubmatch(reference); match != nil {
+		return newCitation(match[2], match[1], match[3])
+	}
+	if match := euELIPattern.FindStringSubmatch(reference); match != nil {
+		return newCitation(match[1], match[2], match[3])
+	}
+
+	citations, err := connector.citationParser.Parse(reference)
+	if err != nil {
+		return nil
+	}
+	for _, parsed := range citations {
+		if euDocumentIDSlugs[parsed.Type] != "" && parsed.Components.DocYear != "" && parsed.Components.DocNumber != "" {
+			return parsed
+		}
+	}
+	return nil
+}
+
+// Parse extracts the document text from an EUR-Lex HTML page, skipping the
+// site navigation around it.
+func (connector *EURLexConnector) Parse(content *FetchedContent) (*ParsedSource, error) {
+	text := extractMainContent(content.RawHTML, []string{`id="TexteOnly"`, `class="eli-container"`, `id="document1"`})
+	if len(text) == 0 {
+		return nil, fmt.Errorf("no document text in %s", content.URL)
+	}
+	return &ParsedSource{
+		Text:         text,
+		Title:        extractHTMLTitle(content.RawHTML),
+		Format:       string(extract.FormatEU),
+		Jurisdiction: "EU",
+	}, nil
+}
+
+// UKLegislationConnector resolves UK Public General Acts and Statutory
+// Instruments to their legislation.gov.uk text. It accepts chapter citations
+// such as "2018 c. 12", SI citations such as "SI 2019/419", urn:uk:act and
+// urn:uk:si URNs with a number, and legislation.gov.uk URLs.
+type UKLegislationConnector struct {
+	citationParser *citation.OSCOLAParser
+}
+
+var (
+	ukURNPattern     = regexp.MustCompile(`^urn:uk:(act|si):(\d{4})/(\d+)$`)
+	ukURLPattern     = regexp.MustCompile(`(?i)legislation\.gov\.uk/(?:id/)?(ukpga|uksi)/(\d{4})/(\d+)`)
+	ukChapterPattern = regexp.MustCompile(`(?i)\b(\d{4})\s+c\.?\s*(\d+)\b`)
+)
+
+// NewUKLegislationConnector creates a legislation.gov.uk connector.
+func NewUKLegislationConnector() *UKLegislationConnector {
+	return &UKLegislationConnector{citationParser: citation.NewOSCOLAParser()}
+}
+
+// Name returns "ukleg".
+func (connector *UKLegislationConnector) Name() string {
+	return "ukleg"
+}
+
+// Resolve resolves a UK legislation reference to the legislation.gov.uk
+// XHTML of the whole document.
+func (connector *UKLegislationConnector) Resolve(reference string) (*ResolvedSource, error) {
+	legislationURI, ok := connector.parseReference(strings.TrimSpace(reference))
+	if !ok {
+		return nil, fmt.Errorf("not a UK legislation reference: %s", reference)
+	}
+
+	return &ResolvedSource{
+		URL:        legislationURI.String() + "/data.xht",
+		DocumentID: fmt.Sprintf("gb-%s-%s-%s", legislationURI.LegislationType, legislationURI.Year, legislationURI.Number),
+		Domain:     "www.legislation.gov.uk",
+		SourceName: "legislation.gov.uk",
+	}, nil
+}
+
+// parseReference returns the legislation a reference identifies.
+func (connector *UKLegislationConnector) parseReference(reference string) (ukleg.LegislationURI, bool) {
+	if match := ukURNPattern.FindStringSubmatch(reference); match != nil {
+		legislationType := ukleg.LegislationTypeUKPGA
+		if match[1] == "si" {
+			legislationType = ukleg.LegislationTypeUKSI
+		}
+		return ukleg.LegislationURI{LegislationType: legislationType, Year: match[2], Number: match[3]}, true
+	}
+	if match := ukURLPattern.FindStringSubmatch(reference); match != nil {
+		return ukleg.LegislationURI{LegislationType: ukleg.LegislationType(strings.ToLower(match[1])), Year: match[2], Number: match[3]}, true
+	}
+	if match := ukChapterPattern.FindStringSubmatch(reference); match != nil {
+		return ukleg.LegislationURI{LegislationType: ukleg.LegislationTypeUKPGA, Year: match[1], Number: match[2]}, true
+	}
+
+	citations, err := connector.citationParser.Parse(reference)
+	if err != nil {
+		return ukleg.LegislationURI{}, false
+	}
+	for _, parsed := range citations {
+		if legislationURI, err := ukleg.GenerateLegislationURI(parsed); err == nil {
+			legislationURI.Section = ""
+			return legislationURI, true
+		}
+	}
+	return ukleg.LegislationURI{}, false
+}
+
+// Parse extracts the legislation text from a legislation.gov.uk page.
+func (connector *UKLegislationConnector) Parse(content *FetchedContent) (*ParsedSource, error) {
+	text := extractMainContent(content.RawHTML, []string{`id="viewLegSnippet"`, `class="LegSnippet"`, `id="viewLegContents"`})
+	if len(text) == 0 {
+		return nil, fmt.Errorf("no legislation text in %s", content.URL)
+	}
+	return &ParsedSource{
+		Text:         text,
+		Title:        extractHTMLTitle(content.RawHTML),
+		Format:       string(extract.FormatUK),
+		Jurisdiction: "GB",
+	}, nil
+}
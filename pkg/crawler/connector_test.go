@@ -0,0 +1,188 @@
+package crawler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveEUReferences(t *testing.T) {
+	resolver := NewSourceResolver()
+
+	testCases := []struct {
+		name        string
+		reference   string
+		expectDocID string
+		expectCELEX string
+	}{
+		{"regulation citation", "Regulation (EU) 2016/679", "eu-reg-2016-679", "32016R0679"},
+		{"regulation No citation", "Regulation (EU) No 45/2001", "eu-reg-2001-45", "32001R0045"},
+		{"directive with two-digit year", "Directive 95/46/EC", "eu-dir-1995-46", "31995L0046"},
+		{"decision citation", "Decision 2010/87/EU", "eu-dec-2010-87", "32010D0087"},
+		{"regulation URN", "urn:eu:regulation:2016/679", "eu-reg-2016-679", "32016R0679"},
+		{"directive URN", "urn:eu:directive:2002/58", "eu-dir-2002-58", "32002L0058"},
+		{"CELEX URL", "https://eur-lex.europa.eu/legal-content/EN/TXT/?uri=CELEX%3A32022R2065", "eu-reg-2022-2065", "32022R2065"},
+		{"ELI URI", "http://data.europa.eu/eli/reg/2024/1689/oj", "eu-reg-2024-1689", "32024R1689"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resolved, err := resolver.ResolveURN(testCase.reference)
+			if err != nil {
+				resolved, err = resolver.Resolve(testCase.reference)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error resolving %q: %v", testCase.reference, err)
+			}
+			if resolved.DocumentID != testCase.expectDocID {
+				t.Errorf("document ID = %q, want %q", resolved.DocumentID, testCase.expectDocID)
+			}
+			if resolved.URL != EURLexTextURL+testCase.expectCELEX {
+				t.Errorf("URL = %q, want CELEX %s", resolved.URL, testCase.expectCELEX)
+			}
+			if resolved.Domain != "eur-lex.europa.eu" || resolved.Connector != "eurlex" {
+				t.Errorf("domain, connector = %q, %q, want eur-lex.europa.eu, eurlex", resolved.Domain, resolved.Connector)
+			}
+		})
+	}
+}
+
+func TestResolveUKReferences(t *testing.T) {
+	resolver := NewSourceResolver()
+
+	testCases := []struct {
+		name        string
+		reference   string
+		expectDocID string
+		expectURL   string
+	}{
+		{"act chapter citation", "Data Protection Act 2018 (2018 c. 12)", "gb-ukpga-2018-12", "https://www.legislation.gov.uk/ukpga/2018/12/data.xht"},
+		{"SI citation", "SI 2019/419", "gb-uksi-2019-419", "https://www.legislation.gov.uk/uksi/2019/419/data.xht"},
+		{"act URN", "urn:uk:act:2018/12", "gb-ukpga-2018-12", "https://www.legislation.gov.uk/ukpga/2018/12/data.xht"},
+		{"SI URN", "urn:uk:si:2019/419", "gb-uksi-2019-419", "https://www.legislation.gov.uk/uksi/2019/419/data.xht"},
+		{"section URL", "https://www.legislation.gov.uk/ukpga/2018/12/section/3", "gb-ukpga-2018-12", "https://www.legislation.gov.uk/ukpga/2018/12/data.xht"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resolved, err := resolver.ResolveURN(testCase.reference)
+			if err != nil {
+				resolved, err = resolver.Resolve(testCase.reference)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error resolving %q: %v", testCase.reference, err)
+			}
+			if resolved.DocumentID != testCase.expectDocID {
+				t.Errorf("document ID = %q, want %q", resolved.DocumentID, testCase.expectDocID)
+			}
+			if resolved.URL != testCase.expectURL {
+				t.Errorf("URL = %q, want %q", resolved.URL, testCase.expectURL)
+			}
+			if resolved.Connector != "ukleg" {
+				t.Errorf("connector = %q, want ukleg", resolved.Connector)
+			}
+		})
+	}
+}
+
+func TestResolveUnresolvableReferences(t *testing.T) {
+	resolver := NewSourceResolver()
+
+	for _, reference := range []string{
+		"TEU",
+		"urn:eu:treaty:TEU",
+		"urn:uk:act:2018/data-protection-act",
+		"Data Protection Act 2018",
+	} {
+		if resolved, err := resolver.ResolveURN(reference); err == nil {
+			t.Errorf("ResolveURN(%q) = %+v, want error", reference, resolved)
+		}
+		if resolved, err := resolver.Resolve(reference); err == nil {
+			t.Errorf("Resolve(%q) = %+v, want error", reference, resolved)
+		}
+	}
+}
+
+func TestResolveUSBeforeConnectors(t *testing.T) {
+	resolver := NewSourceResolver()
+
+	resolved, err := resolver.Resolve("45 C.F.R. Part 164")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved.Connector != "" || resolved.DocumentID != "us-cfr-45-164" {
+		t.Errorf("resolved = %+v, want the built-in CFR pattern", resolved)
+	}
+}
+
+func TestEURLexConnectorParse(t *testing.T) {
+	content := &FetchedContent{
+		URL: EURLexTextURL + "32016R0679",
+		RawHTML: []byte(`<html><head><title>Regulation (EU) 2016/679 - EN - EUR-Lex</title></head><body>
+<div class="header"><a href="/">EUR-Lex home</a> Access to European Union law</div>
+<div id="document1"><div class="tabContent">
+<p class="oj-doc-ti">REGULATION (EU) 2016/679 OF THE EUROPEAN PARLIAMENT AND OF THE COUNCIL</p>
+<p class="oj-ti-art">Article 1</p>
+<p class="oj-sti-art">Subject-matter and objectives</p>
+<p class="oj-normal">1. This Regulation lays down rules relating to the protection of natural persons.</p>
+</div></div></body></html>`),
+	}
+
+	parsed, err := NewEURLexConnector().Parse(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := string(parsed.Text)
+	if strings.Contains(text, "EUR-Lex home") {
+		t.Errorf("text includes site navigation: %q", text)
+	}
+	if !strings.HasPrefix(text, "REGULATION (EU) 2016/679") || !strings.Contains(text, "Article 1\n\nSubject-matter and objectives") {
+		t.Errorf("text = %q, want the document from its title", text)
+	}
+	if parsed.Title != "Regulation (EU) 2016/679 - EN - EUR-Lex" {
+		t.Errorf("title = %q", parsed.Title)
+	}
+	if parsed.Format != "eu" || parsed.Jurisdiction != "EU" {
+		t.Errorf("format, jurisdiction = %q, %q, want eu, EU", parsed.Format, parsed.Jurisdiction)
+	}
+}
+
+func TestUKLegislationConnectorParse(t *testing.T) {
+	content := &FetchedContent{
+		URL: "https://www.legislation.gov.uk/ukpga/2018/12/data.xht",
+		RawHTML: []byte(`<html><head><title>Data Protection Act 2018</title></head><body>
+<div id="layout2"><a href="/browse">Browse legislation</a></div>
+<div id="viewLegSnippet">
+<h1 class="LegTitle">Data Protection Act 2018</h1>
+<h2 class="LegPartTitle">PART 1 Preliminary</h2>
+<p class="LegText">1 Overview</p>
+</div></body></html>`),
+	}
+
+	parsed, err := NewUKLegislationConnector().Parse(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := string(parsed.Text)
+	if strings.Contains(text, "Browse legislation") || !strings.Contains(text, "PART 1 Preliminary") {
+		t.Errorf("text = %q, want the legislation without navigation", text)
+	}
+	if parsed.Title != "Data Protection Act 2018" || parsed.Format != "uk" || parsed.Jurisdiction != "GB" {
+		t.Errorf("parsed = %q, %q, %q", parsed.Title, parsed.Format, parsed.Jurisdiction)
+	}
+}
+
+func TestRegisterConnectorReplacesByName(t *testing.T) {
+	resolver := NewSourceResolver()
+	replacement := NewEURLexConnector()
+	resolver.RegisterConnector(replacement)
+
+	if len(resolver.connectors) != 2 {
+		t.Errorf("connectors = %d, want 2", len(resolver.connectors))
+	}
+	if resolver.Connector("eurlex") != replacement {
+		t.Error("Connector(eurlex) did not return the replacement")
+	}
+	if resolver.Connector("missing") != nil {
+		t.Error("Connector(missing) should be nil")
+	}
+}
@@ -1,6 +1,7 @@
 package crawler
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -10,7 +11,7 @@ import (
 )
 
 // Crawler is a BFS tree-walking legislation crawler that discovers and ingests
-// US legislation by following cross-references between documents.
+// US, EU, and UK legislation by following cross-references between documents.
 type Crawler struct {
 	config     CrawlConfig
 	lib        *library.Library
@@ -69,6 +70,13 @@ func (crawler *Crawler) SetFetcher(fetcher *ContentFetcher) {
 // Crawl performs a BFS crawl starting from the given seeds. Each seed can be
 // a document ID (from the library), a citation string, or a direct URL.
 func (crawler *Crawler) Crawl(seeds []CrawlSeed) (*CrawlReport, error) {
+	return crawler.CrawlWithContext(context.Background(), seeds)
+}
+
+// CrawlWithContext performs a crawl with cancellation support. When ctx is
+// done the crawl stops after the current item, saves its state as paused to
+// config.StatePath, and returns the partial report with Interrupted set.
+func (crawler *Crawler) CrawlWithContext(ctx context.Context, seeds []CrawlSeed) (*CrawlReport, error) {
 	crawlState := NewCrawlState(seeds, crawler.config)
 	report := NewCrawlReport(crawler.config.DryRun, seeds)
 
@@ -91,7 +99,7 @@ func (crawler *Crawler) Crawl(seeds []CrawlSeed) (*CrawlReport, error) {
 	}
 
 	// BFS loop
-	for crawlState.FrontierSize() > 0 && crawlState.WithinLimits() {
+	for crawlState.FrontierSize() > 0 && crawlState.WithinLimits() && ctx.Err() == nil {
 		currentItem := crawlState.Dequeue()
 		if currentItem == nil {
 			break
@@ -154,6 +162,7 @@ func (crawler *Crawler) Crawl(seeds []CrawlSeed) (*CrawlReport, error) {
 			}
 			currentItem.URL = resolved.URL
 			currentItem.Domain = resolved.Domain
+			currentItem.Connector = resolved.Connector
 			if currentItem.DocumentID == "" {
 				currentItem.DocumentID = resolved.DocumentID
 			}
@@ -161,7 +170,11 @@ func (crawler *Crawler) Crawl(seeds []CrawlSeed) (*CrawlReport, error) {
 
 		// Fetch content
 		currentItem.Status = CrawlItemFetching
-		fetchedContent, err := crawler.fetcher.Fetch(currentItem.URL)
+		fetchedContent, err := crawler.fetcher.FetchWithContext(ctx, currentItem.URL)
+		if err != nil && ctx.Err() != nil {
+			crawlState.Requeue(currentItem)
+			break
+		}
 		if err != nil {
 			currentItem.Status = CrawlItemFailed
 			currentItem.Error = fmt.Sprintf("fetch failed: %v", err)
@@ -172,23 +185,25 @@ func (crawler *Crawler) Crawl(seeds []CrawlSeed) (*CrawlReport, error) {
 		}
 
 		// Ingest into library
-		if len(fetchedContent.PlainText) == 0 {
+		documentText, addOptions, err := crawler.prepareIngest(currentItem, fetchedContent)
+		if err != nil {
 			currentItem.Status = CrawlItemFailed
-			currentItem.Error = "empty content after extraction"
+			currentItem.Error = fmt.Sprintf("parse failed: %v", err)
 			crawlState.RecordProcessed(currentItem)
 			report.RecordItem(currentItem)
 			crawler.provenance.RecordFailure(currentItem.Citation, currentItem.URL, currentItem.Error)
 			continue
 		}
-
-		addOptions := library.AddOptions{
-			Name:      currentItem.DocumentID,
-			ShortName: currentItem.DocumentID,
-			FullName:  currentItem.Citation,
-			Force:     false,
+		if len(documentText) == 0 {
+			currentItem.Status = CrawlItemFailed
+			currentItem.Error = "empty content after extraction"
+			crawlState.RecordProcessed(currentItem)
+			report.RecordItem(currentItem)
+			crawler.provenance.RecordFailure(currentItem.Citation, currentItem.URL, currentItem.Error)
+			continue
 		}
 
-		_, err = crawler.lib.AddDocument(currentItem.DocumentID, fetchedContent.PlainText, addOptions)
+		_, err = crawler.lib.AddDocument(currentItem.DocumentID, documentText, addOptions)
 		if err != nil {
 			currentItem.Status = CrawlItemFailed
 			currentItem.Error = fmt.Sprintf("ingestion failed: %v", err)
@@ -220,6 +235,10 @@ func (crawler *Crawler) Crawl(seeds []CrawlSeed) (*CrawlReport, error) {
 
 	// Final state save
 	crawlState.Status = CrawlStatusCompleted
+	if ctx.Err() != nil {
+		crawlState.Status = CrawlStatusPaused
+		report.Interrupted = true
+	}
 	if crawler.config.StatePath != "" {
 		_ = crawlState.SaveState(crawler.config.StatePath)
 	}
@@ -255,6 +274,13 @@ func (crawler *Crawler) Plan(seeds []CrawlSeed) (*CrawlReport, error) {
 
 // Resume continues a previously interrupted crawl from saved state.
 func (crawler *Crawler) Resume(statePath string) (*CrawlReport, error) {
+	return crawler.ResumeWithContext(context.Background(), statePath)
+}
+
+// ResumeWithContext resumes a crawl with cancellation support. When ctx is
+// done the state is saved as paused again, so the crawl can be resumed any
+// number of times.
+func (crawler *Crawler) ResumeWithContext(ctx context.Context, statePath string) (*CrawlReport, error) {
 	crawlState, err := LoadState(statePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load crawl state: %w", err)
@@ -269,7 +295,7 @@ func (crawler *Crawler) Resume(statePath string) (*CrawlReport, error) {
 	}
 
 	// Continue BFS from frontier
-	for crawlState.FrontierSize() > 0 && crawlState.WithinLimits() {
+	for crawlState.FrontierSize() > 0 && crawlState.WithinLimits() && ctx.Err() == nil {
 		currentItem := crawlState.Dequeue()
 		if currentItem == nil {
 			break
@@ -304,10 +330,15 @@ func (crawler *Crawler) Resume(statePath string) (*CrawlReport, error) {
 			}
 			currentItem.URL = resolved.URL
 			currentItem.Domain = resolved.Domain
+			currentItem.Connector = resolved.Connector
 		}
 
 		// Fetch and ingest
-		fetchedContent, err := crawler.fetcher.Fetch(currentItem.URL)
+		fetchedContent, err := crawler.fetcher.FetchWithContext(ctx, currentItem.URL)
+		if err != nil && ctx.Err() != nil {
+			crawlState.Requeue(currentItem)
+			break
+		}
 		if err != nil {
 			currentItem.Status = CrawlItemFailed
 			currentItem.Error = fmt.Sprintf("fetch failed: %v", err)
@@ -316,22 +347,23 @@ func (crawler *Crawler) Resume(statePath string) (*CrawlReport, error) {
 			continue
 		}
 
-		if len(fetchedContent.PlainText) == 0 {
+		documentText, addOptions, err := crawler.prepareIngest(currentItem, fetchedContent)
+		if err != nil {
 			currentItem.Status = CrawlItemFailed
-			currentItem.Error = "empty content"
+			currentItem.Error = fmt.Sprintf("parse failed: %v", err)
 			crawlState.RecordProcessed(currentItem)
 			report.RecordItem(currentItem)
 			continue
 		}
-
-		addOptions := library.AddOptions{
-			Name:      currentItem.DocumentID,
-			ShortName: currentItem.DocumentID,
-			FullName:  currentItem.Citation,
-			Force:     false,
+		if len(documentText) == 0 {
+			currentItem.Status = CrawlItemFailed
+			currentItem.Error = "empty content"
+			crawlState.RecordProcessed(currentItem)
+			report.RecordItem(currentItem)
+			continue
 		}
 
-		_, err = crawler.lib.AddDocument(currentItem.DocumentID, fetchedContent.PlainText, addOptions)
+		_, err = crawler.lib.AddDocument(currentItem.DocumentID, documentText, addOptions)
 		if err != nil {
 			currentItem.Status = CrawlItemFailed
 			currentItem.Error = fmt.Sprintf("ingestion failed: %v", err)
@@ -353,6 +385,10 @@ func (crawler *Crawler) Resume(statePath string) (*CrawlReport, error) {
 	}
 
 	crawlState.Status = CrawlStatusCompleted
+	if ctx.Err() != nil {
+		crawlState.Status = CrawlStatusPaused
+		report.Interrupted = true
+	}
 	if statePath != "" {
 		_ = crawlState.SaveState(statePath)
 	}
@@ -360,6 +396,37 @@ func (crawler *Crawler) Resume(statePath string) (*CrawlReport, error) {
 	return report, nil
 }
 
+// prepareIngest returns the text and library options to ingest fetched
+// content with. Content resolved by a connector is parsed by it, which also
+// supplies the format and jurisdiction; other content is ingested as the
+// fetcher's plain text.
+func (crawler *Crawler) prepareIngest(item *CrawlItem, content *FetchedContent) ([]byte, library.AddOptions, error) {
+	addOptions := library.AddOptions{
+		Name:      item.DocumentID,
+		ShortName: item.DocumentID,
+		FullName:  item.Citation,
+		Force:     false,
+	}
+	if item.Connector == "" {
+		return content.PlainText, addOptions, nil
+	}
+
+	connector := crawler.resolver.Connector(item.Connector)
+	if connector == nil {
+		return nil, addOptions, fmt.Errorf("unknown connector %q", item.Connector)
+	}
+	parsed, err := connector.Parse(content)
+	if err != nil {
+		return nil, addOptions, err
+	}
+	if parsed.Title != "" {
+		addOptions.FullName = parsed.Title
+	}
+	addOptions.Format = parsed.Format
+	addOptions.Jurisdiction = parsed.Jurisdiction
+	return parsed.Text, addOptions, nil
+}
+
 // Provenance returns the provenance tracker for inspecting discovery chains.
 func (crawler *Crawler) Provenance() *ProvenanceTracker {
 	return crawler.provenance
@@ -409,11 +476,26 @@ func (crawler *Crawler) seedFromCitation(citation string) ([]*CrawlItem, error)
 		Depth:      0,
 		Status:     CrawlItemPending,
 		Domain:     resolved.Domain,
+		Connector:  resolved.Connector,
 	}}, nil
 }
 
-// seedFromURL creates a crawl item from a direct URL.
+// seedFromURL creates a crawl item from a direct URL. URLs of a connector's
+// source, such as EUR-Lex or legislation.gov.uk pages, are resolved by it so
+// that the document ID and parsing match those of the cited document.
 func (crawler *Crawler) seedFromURL(targetURL string) ([]*CrawlItem, error) {
+	if resolved, ok := crawler.resolver.resolveWithConnectors(targetURL); ok {
+		return []*CrawlItem{{
+			Citation:   targetURL,
+			URL:        resolved.URL,
+			DocumentID: resolved.DocumentID,
+			Depth:      0,
+			Status:     CrawlItemPending,
+			Domain:     resolved.Domain,
+			Connector:  resolved.Connector,
+		}}, nil
+	}
+
 	domain := ExtractDomainFromURL(targetURL)
 	documentID := deriveDocIDFromURL(targetURL)
 
@@ -474,6 +556,7 @@ func (crawler *Crawler) extractExternalRefs(tripleStore *store.TripleStore, sour
 			DiscoveredBy: sourceDocumentID,
 			Status:       CrawlItemPending,
 			Domain:       resolved.Domain,
+			Connector:    resolved.Connector,
 		})
 	}
 
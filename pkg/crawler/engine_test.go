@@ -1,9 +1,12 @@
 package crawler
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -351,3 +354,147 @@ func TestCrawlerFromDocumentNotFound(t *testing.T) {
 		t.Error("expected at least one failed/skipped item for nonexistent document")
 	}
 }
+
+func TestCrawlerInterruptAndResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The second request is cancelled in flight, as SIGINT would mid-run
+	testServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/doc2" && ctx.Err() == nil {
+			cancel()
+			<-request.Context().Done()
+			return
+		}
+		responseWriter.Header().Set("Content-Type", "text/plain")
+		responseWriter.WriteHeader(http.StatusOK)
+		responseWriter.Write([]byte("Some legislation content."))
+	}))
+	defer testServer.Close()
+
+	testLib := setupTestLibrary(t)
+	statePath := filepath.Join(t.TempDir(), "crawl-state.json")
+
+	config := CrawlConfig{
+		MaxDepth:      1,
+		MaxDocuments:  5,
+		RateLimit:     10 * time.Millisecond,
+		Timeout:       5 * time.Second,
+		BaseURI:       "https://regula.dev/regulations/",
+		StatePath:     statePath,
+		DomainConfigs: make(map[string]*DomainConfig),
+	}
+
+	crawlerInstance := NewCrawlerWithLibrary(config, testLib)
+	seeds := []CrawlSeed{
+		{Type: SeedTypeURL, Value: testServer.URL + "/doc1"},
+		{Type: SeedTypeURL, Value: testServer.URL + "/doc2"},
+		{Type: SeedTypeURL, Value: testServer.URL + "/doc3"},
+	}
+
+	report, err := crawlerInstance.CrawlWithContext(ctx, seeds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.Interrupted {
+		t.Error("report.Interrupted = false, want true")
+	}
+	if report.TotalIngested != 1 {
+		t.Errorf("total ingested = %d, want 1 before interruption", report.TotalIngested)
+	}
+
+	savedState, err := LoadState(statePath)
+	if err != nil {
+		t.Fatalf("failed to load saved state: %v", err)
+	}
+	if savedState.Status != CrawlStatusPaused {
+		t.Errorf("saved status = %s, want %s", savedState.Status, CrawlStatusPaused)
+	}
+	if savedState.FrontierSize() != 2 {
+		t.Errorf("saved frontier = %d items, want 2", savedState.FrontierSize())
+	}
+
+	resumed, err := crawlerInstance.ResumeWithContext(context.Background(), statePath)
+	if err != nil {
+		t.Fatalf("unexpected resume error: %v", err)
+	}
+	if resumed.Interrupted || resumed.TotalIngested != 3 {
+		t.Errorf("resumed: interrupted %v, ingested %d, want false and 3", resumed.Interrupted, resumed.TotalIngested)
+	}
+}
+
+func TestContentFetcherCancelledContext(t *testing.T) {
+	testServer := setupTestServer()
+	defer testServer.Close()
+
+	fetcher := NewContentFetcher(CrawlConfig{Timeout: 5 * time.Second})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := fetcher.FetchWithContext(ctx, testServer.URL+"/doc1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+}
+
+// testEUConnector resolves EU references like the EUR-Lex connector but
+// fetches from a test server.
+type testEUConnector struct {
+	*EURLexConnector
+	serverURL string
+}
+
+func (connector *testEUConnector) Resolve(reference string) (*ResolvedSource, error) {
+	resolved, err := connector.EURLexConnector.Resolve(reference)
+	if err != nil {
+		return nil, err
+	}
+	resolved.URL = connector.serverURL + "/" + resolved.DocumentID
+	return resolved, nil
+}
+
+func TestCrawlerConnectorIngest(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/eu-reg-2016-679" {
+			responseWriter.WriteHeader(http.StatusNotFound)
+			return
+		}
+		responseWriter.Header().Set("Content-Type", "text/html")
+		responseWriter.Write([]byte(`<html><head><title>Regulation (EU) 2016/679</title></head><body>
+<div class="header">EUR-Lex navigation</div>
+<div id="document1">
+<p>REGULATION (EU) 2016/679 OF THE EUROPEAN PARLIAMENT AND OF THE COUNCIL</p>
+<p>CHAPTER I</p><p>General provisions</p>
+<p>Article 1</p><p>Subject-matter and objectives</p>
+<p>1. This Regulation lays down rules relating to the protection of natural persons.</p>
+</div></body></html>`))
+	}))
+	defer testServer.Close()
+
+	testLib := setupTestLibrary(t)
+	config := CrawlConfig{
+		MaxDepth:      1,
+		MaxDocuments:  5,
+		RateLimit:     10 * time.Millisecond,
+		Timeout:       5 * time.Second,
+		BaseURI:       "https://regula.dev/regulations/",
+		DomainConfigs: make(map[string]*DomainConfig),
+	}
+	crawlerInstance := NewCrawlerWithLibrary(config, testLib)
+	crawlerInstance.resolver.RegisterConnector(&testEUConnector{EURLexConnector: NewEURLexConnector(), serverURL: testServer.URL})
+
+	report, err := crawlerInstance.CrawlFromCitation("Regulation (EU) 2016/679")
+	if err != nil {
+		t.Fatalf("unexpected crawl error: %v", err)
+	}
+	if report.TotalIngested != 1 {
+		t.Fatalf("total ingested = %d, want 1", report.TotalIngested)
+	}
+
+	entry := testLib.GetDocument("eu-reg-2016-679")
+	if entry == nil {
+		t.Fatal("eu-reg-2016-679 not in library")
+	}
+	if entry.Format != "eu" || entry.Jurisdiction != "EU" || entry.FullName != "Regulation (EU) 2016/679" {
+		t.Errorf("entry format, jurisdiction, name = %q, %q, %q", entry.Format, entry.Jurisdiction, entry.FullName)
+	}
+}
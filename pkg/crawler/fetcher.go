@@ -1,6 +1,7 @@
 package crawler
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,16 +10,20 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/coolbeans/regula/pkg/fetch"
+	"github.com/coolbeans/regula/pkg/httpcache"
 )
 
 // ContentFetcher fetches web content with per-domain rate limiting,
-// HTML-to-text conversion, and caching support.
+// robots.txt compliance, HTML-to-text conversion, and caching support.
 type ContentFetcher struct {
 	httpClient   *http.Client
 	domainTimers map[string]time.Time
 	timerMu      sync.Mutex
 	config       CrawlConfig
 	maxBodyBytes int64
+	robots       *fetch.RobotsChecker // nil unless config.RespectRobots
 }
 
 // NewContentFetcher creates a ContentFetcher with the given configuration.
@@ -33,17 +38,33 @@ func NewContentFetcher(config CrawlConfig) *ContentFetcher {
 		},
 	}
 
-	return &ContentFetcher{
+	if config.CacheDir != "" {
+		if cache, err := httpcache.Open(config.CacheDir); err == nil {
+			httpClient = cache.Client(httpClient)
+		}
+	}
+
+	contentFetcher := &ContentFetcher{
 		httpClient:   httpClient,
 		domainTimers: make(map[string]time.Time),
 		config:       config,
 		maxBodyBytes: 10 * 1024 * 1024, // 10MB max
 	}
+	if config.RespectRobots {
+		contentFetcher.robots = fetch.NewRobotsChecker(config.Timeout)
+	}
+	return contentFetcher
 }
 
 // Fetch retrieves content from the given URL, respecting rate limits.
 // Returns extracted plain text suitable for the ingestion pipeline.
 func (fetcher *ContentFetcher) Fetch(targetURL string) (*FetchedContent, error) {
+	return fetcher.FetchWithContext(context.Background(), targetURL)
+}
+
+// FetchWithContext retrieves content with cancellation support. Both the
+// rate limit wait and the request itself end early when ctx is done.
+func (fetcher *ContentFetcher) FetchWithContext(ctx context.Context, targetURL string) (*FetchedContent, error) {
 	if targetURL == "" {
 		return nil, fmt.Errorf("empty URL")
 	}
@@ -53,9 +74,6 @@ func (fetcher *ContentFetcher) Fetch(targetURL string) (*FetchedContent, error)
 		return nil, fmt.Errorf("invalid URL %s: %w", targetURL, err)
 	}
 
-	// Rate limit per domain
-	fetcher.waitForDomain(parsedURL.Host)
-
 	userAgent := fetcher.config.UserAgent
 	if userAgent == "" {
 		userAgent = DefaultCrawlUserAgent
@@ -66,7 +84,25 @@ func (fetcher *ContentFetcher) Fetch(targetURL string) (*FetchedContent, error)
 		userAgent = domainConfig.UserAgent
 	}
 
-	request, err := http.NewRequest(http.MethodGet, targetURL, nil)
+	// Honor robots.txt
+	var crawlDelay time.Duration
+	if fetcher.robots != nil {
+		var allowed bool
+		allowed, crawlDelay, err = fetcher.robots.Check(ctx, targetURL, userAgent)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, fmt.Errorf("%w: %s", fetch.ErrDisallowedByRobots, targetURL)
+		}
+	}
+
+	// Rate limit per domain
+	if err := fetcher.waitForDomain(ctx, parsedURL.Host, crawlDelay); err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request for %s: %w", targetURL, err)
 	}
@@ -116,14 +152,19 @@ func (fetcher *ContentFetcher) Fetch(targetURL string) (*FetchedContent, error)
 	}, nil
 }
 
-// waitForDomain enforces per-domain rate limiting.
-func (fetcher *ContentFetcher) waitForDomain(domain string) {
+// waitForDomain enforces per-domain rate limiting, returning ctx's error if
+// it is done before the wait ends. A site's Crawl-delay raises the interval
+// when it is longer than the configured rate limit.
+func (fetcher *ContentFetcher) waitForDomain(ctx context.Context, domain string, crawlDelay time.Duration) error {
 	fetcher.timerMu.Lock()
 
 	rateLimit := fetcher.config.RateLimit
 	if domainConfig, hasDomainConfig := fetcher.config.DomainConfigs[domain]; hasDomainConfig && domainConfig.RateLimit > 0 {
 		rateLimit = domainConfig.RateLimit
 	}
+	if crawlDelay > rateLimit {
+		rateLimit = crawlDelay
+	}
 
 	lastRequestTime, hasLastRequest := fetcher.domainTimers[domain]
 	if hasLastRequest {
@@ -131,13 +172,20 @@ func (fetcher *ContentFetcher) waitForDomain(domain string) {
 		if elapsed < rateLimit {
 			waitDuration := rateLimit - elapsed
 			fetcher.timerMu.Unlock()
-			time.Sleep(waitDuration)
+			timer := time.NewTimer(waitDuration)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
 			fetcher.timerMu.Lock()
 		}
 	}
 
 	fetcher.domainTimers[domain] = time.Now()
 	fetcher.timerMu.Unlock()
+	return nil
 }
 
 // Pre-compiled regex patterns for HTML-to-text conversion.
@@ -1,11 +1,14 @@
 package crawler
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/coolbeans/regula/pkg/fetch"
 )
 
 func TestExtractTextFromHTMLBasic(t *testing.T) {
@@ -193,3 +196,45 @@ func TestFetchPlainText(t *testing.T) {
 		t.Errorf("plain text content missing: %s", plainText)
 	}
 }
+
+func TestFetchRespectsRobots(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/robots.txt" {
+			responseWriter.Write([]byte("User-agent: test-crawler\nDisallow: /private\nCrawl-delay: 0.2\n"))
+			return
+		}
+		responseWriter.Header().Set("Content-Type", "text/html")
+		responseWriter.Write([]byte(`<html><body><p>Section 1.</p></body></html>`))
+	}))
+	defer testServer.Close()
+
+	config := CrawlConfig{
+		RateLimit:     10 * time.Millisecond,
+		Timeout:       5 * time.Second,
+		UserAgent:     "test-crawler/1.0",
+		RespectRobots: true,
+	}
+	fetcher := NewContentFetcher(config)
+
+	_, err := fetcher.Fetch(testServer.URL + "/private/doc")
+	if !errors.Is(err, fetch.ErrDisallowedByRobots) {
+		t.Fatalf("error = %v, want ErrDisallowedByRobots", err)
+	}
+
+	// The Crawl-delay of 200ms overrides the 10ms rate limit
+	start := time.Now()
+	for range 2 {
+		if _, err := fetcher.Fetch(testServer.URL + "/doc"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("two fetches took %v, want at least the 200ms crawl delay", elapsed)
+	}
+
+	// Disabled, robots.txt is not consulted
+	config.RespectRobots = false
+	if _, err := NewContentFetcher(config).Fetch(testServer.URL + "/private/doc"); err != nil {
+		t.Errorf("unexpected error with robots disabled: %v", err)
+	}
+}
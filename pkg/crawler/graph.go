@@ -0,0 +1,235 @@
+package crawler
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CrawlGraph is the discovery graph of a crawl: which seed or document led
+// to which document, through which citation, at what depth, and with what
+// fetch outcome.
+type CrawlGraph struct {
+	Seeds []CrawlSeed       `json:"seeds"`
+	Nodes []*CrawlGraphNode `json:"nodes"`
+	Edges []*CrawlGraphEdge `json:"edges"`
+	Stats CrawlGraphStats   `json:"stats"`
+}
+
+// CrawlGraphNode is a seed or a document in the discovery graph.
+type CrawlGraphNode struct {
+	// ID is the document ID, or "seed:" and the seed value for citation
+	// and URL seeds.
+	ID string `json:"id"`
+
+	// Kind is "seed" or "document".
+	Kind string `json:"kind"`
+
+	// Status is the outcome for the document; empty for citation and URL
+	// seeds.
+	Status CrawlItemStatus `json:"status,omitempty"`
+
+	Depth     int       `json:"depth"`
+	URL       string    `json:"url,omitempty"`
+	Domain    string    `json:"domain,omitempty"`
+	FetchedAt time.Time `json:"fetched_at,omitempty"`
+}
+
+// CrawlGraphEdge records the discovery of a document from a seed or another
+// document.
+type CrawlGraphEdge struct {
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	Citation string          `json:"citation"`
+	Depth    int             `json:"depth"`
+	Status   CrawlItemStatus `json:"status"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// CrawlGraphStats counts the nodes and edges of a discovery graph.
+type CrawlGraphStats struct {
+	Seeds     int `json:"seeds"`
+	Documents int `json:"documents"`
+	Edges     int `json:"edges"`
+}
+
+// crawlStatusRank orders item statuses by how much they say about a
+// document, so a document discovered several times shows its real outcome
+// rather than "already visited".
+var crawlStatusRank = map[CrawlItemStatus]int{
+	CrawlItemSkipped:  1,
+	CrawlItemPending:  2,
+	CrawlItemFetching: 2,
+	CrawlItemFailed:   3,
+	CrawlItemIngested: 4,
+}
+
+// Graph builds the discovery graph of the report's items. Items found in a
+// document hang off that document; depth 0 items hang off the citation or
+// URL seed they came from.
+func (report *CrawlReport) Graph() *CrawlGraph {
+	graph := &CrawlGraph{
+		Seeds: report.Seeds,
+		Nodes: make([]*CrawlGraphNode, 0),
+		Edges: make([]*CrawlGraphEdge, 0),
+	}
+
+	nodes := make(map[string]*CrawlGraphNode)
+	addNode := func(node *CrawlGraphNode) *CrawlGraphNode {
+		if existing, ok := nodes[node.ID]; ok {
+			return existing
+		}
+		nodes[node.ID] = node
+		graph.Nodes = append(graph.Nodes, node)
+		return node
+	}
+
+	seedNodes := make(map[string]string) // seed value to node ID
+	for _, seed := range report.Seeds {
+		nodeID := "seed:" + seed.Value
+		if seed.Type == SeedTypeDocumentID {
+			nodeID = seed.Value
+		}
+		seedNodes[seed.Value] = nodeID
+		addNode(&CrawlGraphNode{ID: nodeID, Kind: "seed"})
+	}
+
+	edges := make(map[string]*CrawlGraphEdge)
+	for _, item := range report.Items {
+		from := item.DiscoveredBy
+		if from == "" {
+			from = seedNodes[item.Citation]
+		}
+		if item.DocumentID == "" || item.DocumentID == from {
+			continue
+		}
+		if from != "" {
+			addNode(&CrawlGraphNode{ID: from, Kind: "document"})
+		}
+
+		node := addNode(&CrawlGraphNode{ID: item.DocumentID, Kind: "document", Depth: item.Depth})
+		if crawlStatusRank[item.Status] > crawlStatusRank[node.Status] {
+			node.Status = item.Status
+			node.Depth = item.Depth
+			node.URL = item.URL
+			node.Domain = item.Domain
+			node.FetchedAt = item.FetchedAt
+		}
+
+		if from == "" {
+			continue
+		}
+		key := from + "\x00" + item.DocumentID
+		if edge, ok := edges[key]; ok {
+			if crawlStatusRank[item.Status] > crawlStatusRank[edge.Status] {
+				edge.Status, edge.Error = item.Status, item.Error
+			}
+			continue
+		}
+		edge := &CrawlGraphEdge{
+			From:     from,
+			To:       item.DocumentID,
+			Citation: item.Citation,
+			Depth:    item.Depth,
+			Status:   item.Status,
+			Error:    item.Error,
+		}
+		edges[key] = edge
+		graph.Edges = append(graph.Edges, edge)
+	}
+
+	for _, node := range graph.Nodes {
+		if node.Kind == "seed" {
+			graph.Stats.Seeds++
+		} else {
+			graph.Stats.Documents++
+		}
+	}
+	graph.Stats.Edges = len(graph.Edges)
+	return graph
+}
+
+// ToJSON renders the graph as indented JSON.
+func (graph *CrawlGraph) ToJSON() string {
+	graphJSON, err := json.MarshalIndent(graph, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(graphJSON)
+}
+
+// crawlStatusColors colors documents and edges by outcome.
+var crawlStatusColors = map[CrawlItemStatus]string{
+	CrawlItemIngested: "palegreen",
+	CrawlItemFailed:   "lightcoral",
+	CrawlItemSkipped:  "lightgrey",
+	CrawlItemPending:  "lightyellow",
+	CrawlItemFetching: "lightyellow",
+}
+
+// ToDOT renders the graph in Graphviz DOT. Seeds are boxes, documents are
+// ellipses filled by status, and edges are labeled with their depth and
+// status and dashed unless the document was ingested.
+func (graph *CrawlGraph) ToDOT() string {
+	var builder strings.Builder
+
+	builder.WriteString("digraph CrawlDiscovery {\n")
+	builder.WriteString("  rankdir=LR;\n")
+	builder.WriteString("  node [fontname=\"Helvetica\" fontsize=10 style=filled];\n")
+	builder.WriteString("  edge [fontname=\"Helvetica\" fontsize=8];\n\n")
+
+	for _, node := range graph.Nodes {
+		label := escapeCrawlDOT(strings.TrimPrefix(node.ID, "seed:"))
+		if node.Kind == "seed" {
+			builder.WriteString(fmt.Sprintf("  \"%s\" [label=\"%s\" shape=box fillcolor=lightblue];\n",
+				escapeCrawlDOT(node.ID), label))
+			continue
+		}
+		color := crawlStatusColors[node.Status]
+		if color == "" {
+			color = "white"
+		}
+		builder.WriteString(fmt.Sprintf("  \"%s\" [label=\"%s\\n%s\" fillcolor=%s];\n",
+			escapeCrawlDOT(node.ID), label, node.Status, color))
+	}
+	builder.WriteString("\n")
+
+	for _, edge := range graph.Edges {
+		style := "dashed"
+		if edge.Status == CrawlItemIngested {
+			style = "solid"
+		}
+		builder.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\" [label=\"d%d %s\" style=%s];\n",
+			escapeCrawlDOT(edge.From), escapeCrawlDOT(edge.To), edge.Depth, edge.Status, style))
+	}
+
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// Format renders the graph as "dot" or "json".
+func (graph *CrawlGraph) Format(outputFormat string) string {
+	if strings.ToLower(outputFormat) == "dot" {
+		return graph.ToDOT()
+	}
+	return graph.ToJSON()
+}
+
+// GraphFormatForPath returns the graph format for an output file: "dot" for
+// .dot and .gv files, "json" otherwise.
+func GraphFormatForPath(outputPath string) string {
+	switch strings.ToLower(filepath.Ext(outputPath)) {
+	case ".dot", ".gv":
+		return "dot"
+	default:
+		return "json"
+	}
+}
+
+// escapeCrawlDOT escapes a string for a quoted DOT ID or label.
+func escapeCrawlDOT(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	return strings.ReplaceAll(value, "\"", "\\\"")
+}
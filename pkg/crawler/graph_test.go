@@ -0,0 +1,84 @@
+package crawler
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func testGraphReport() *CrawlReport {
+	report := NewCrawlReport(false, []CrawlSeed{
+		{Type: SeedTypeCitation, Value: "42 U.S.C. § 1320d"},
+		{Type: SeedTypeDocumentID, Value: "us-hipaa"},
+	})
+	for _, item := range []*CrawlItem{
+		{Citation: "42 U.S.C. § 1320d", DocumentID: "us-usc-42-1320d", Depth: 0, Status: CrawlItemIngested, URL: "https://uscode.house.gov/1320d", Domain: "uscode.house.gov"},
+		{Citation: "urn:us:cfr:45/164", DocumentID: "us-cfr-45-164", Depth: 1, DiscoveredBy: "us-hipaa", Status: CrawlItemIngested},
+		{Citation: "urn:us:usc:15/6501", DocumentID: "us-usc-15-6501", Depth: 1, DiscoveredBy: "us-usc-42-1320d", Status: CrawlItemFailed, Error: "fetch failed: HTTP 404"},
+		{Citation: "45 CFR Part 164", DocumentID: "us-cfr-45-164", Depth: 1, DiscoveredBy: "us-usc-42-1320d", Status: CrawlItemSkipped, Error: "already visited"},
+	} {
+		report.RecordItem(item)
+	}
+	return report
+}
+
+func TestCrawlReportGraph(t *testing.T) {
+	graph := testGraphReport().Graph()
+
+	if graph.Stats != (CrawlGraphStats{Seeds: 2, Documents: 3, Edges: 4}) {
+		t.Errorf("stats = %+v, want 2 seeds, 3 documents, 4 edges", graph.Stats)
+	}
+
+	edges := make(map[string]*CrawlGraphEdge)
+	for _, edge := range graph.Edges {
+		edges[edge.From+" -> "+edge.To] = edge
+	}
+	if edge := edges["seed:42 U.S.C. § 1320d -> us-usc-42-1320d"]; edge == nil || edge.Depth != 0 || edge.Status != CrawlItemIngested {
+		t.Errorf("seed edge = %+v, want depth 0 ingested", edge)
+	}
+	if edge := edges["us-usc-42-1320d -> us-usc-15-6501"]; edge == nil || edge.Status != CrawlItemFailed || edge.Error == "" {
+		t.Errorf("failed edge = %+v, want failed with error", edge)
+	}
+	if edge := edges["us-usc-42-1320d -> us-cfr-45-164"]; edge == nil || edge.Citation != "45 CFR Part 164" || edge.Status != CrawlItemSkipped {
+		t.Errorf("rediscovery edge = %+v, want the skipped citation", edge)
+	}
+	if edge := edges["us-hipaa -> us-cfr-45-164"]; edge == nil || edge.Depth != 1 {
+		t.Errorf("document seed edge = %+v, want depth 1", edge)
+	}
+
+	for _, node := range graph.Nodes {
+		if node.ID == "us-cfr-45-164" && node.Status != CrawlItemIngested {
+			t.Errorf("us-cfr-45-164 status = %q, want ingested despite the later skip", node.Status)
+		}
+	}
+}
+
+func TestCrawlGraphFormats(t *testing.T) {
+	graph := testGraphReport().Graph()
+
+	var decoded CrawlGraph
+	if err := json.Unmarshal([]byte(graph.Format("json")), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(decoded.Edges) != 4 || len(decoded.Nodes) != 5 {
+		t.Errorf("decoded %d nodes, %d edges, want 5 and 4", len(decoded.Nodes), len(decoded.Edges))
+	}
+
+	dot := graph.Format("dot")
+	for _, want := range []string{
+		"digraph CrawlDiscovery {",
+		`"seed:42 U.S.C. § 1320d" [label="42 U.S.C. § 1320d" shape=box`,
+		`"us-usc-42-1320d" -> "us-usc-15-6501" [label="d1 failed" style=dashed];`,
+		`"us-hipaa" -> "us-cfr-45-164" [label="d1 ingested" style=solid];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT missing %q:\n%s", want, dot)
+		}
+	}
+
+	for path, want := range map[string]string{"crawl.dot": "dot", "crawl.GV": "dot", "crawl-graph.json": "json", "graph": "json"} {
+		if got := GraphFormatForPath(path); got != want {
+			t.Errorf("GraphFormatForPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // CrawlReport contains the results and statistics of a completed crawl.
@@ -38,6 +40,10 @@ type CrawlReport struct {
 
 	// Seeds contains the original seeds that started the crawl.
 	Seeds []CrawlSeed `json:"seeds"`
+
+	// Interrupted indicates the crawl was cancelled and its state saved as
+	// paused for a later resume.
+	Interrupted bool `json:"interrupted,omitempty"`
 }
 
 // CrawlDepthStats holds statistics for a specific BFS depth level.
@@ -138,6 +144,9 @@ func (report *CrawlReport) formatTable() string {
 	builder.WriteString(fmt.Sprintf("  Failed:     %d\n", report.TotalFailed))
 	builder.WriteString(fmt.Sprintf("  Skipped:    %d\n", report.TotalSkipped))
 	builder.WriteString(fmt.Sprintf("  Max Depth:  %d\n", report.MaxDepthReached))
+	if report.Interrupted {
+		builder.WriteString("  Interrupted: state saved; continue with --resume\n")
+	}
 	builder.WriteString("\n")
 
 	// Depth breakdown
@@ -187,10 +196,7 @@ func (report *CrawlReport) formatTable() string {
 		builder.WriteString(fmt.Sprintf("  %-30s %-10s %-7s %-25s %s\n", "-----------", "------", "-----", "------", "--------"))
 
 		for _, item := range report.Items {
-			citation := item.Citation
-			if len(citation) > 40 {
-				citation = citation[:37] + "..."
-			}
+			citation := textutil.Truncate(item.Citation, 40)
 			builder.WriteString(fmt.Sprintf("  %-30s %-10s %-7d %-25s %s\n",
 				truncateReportString(item.DocumentID, 30),
 				item.Status,
@@ -214,11 +220,8 @@ func (report *CrawlReport) formatJSON() string {
 
 // truncateReportString truncates a string to maxLen characters.
 func truncateReportString(inputStr string, maxLen int) string {
-	if len(inputStr) <= maxLen {
-		return inputStr
-	}
 	if maxLen <= 3 {
-		return inputStr[:maxLen]
+		return textutil.TruncateRunes(inputStr, maxLen)
 	}
-	return inputStr[:maxLen-3] + "..."
+	return textutil.Truncate(inputStr, maxLen)
 }
@@ -11,10 +11,15 @@ import (
 
 // SourceResolver maps citations and URNs to fetchable URLs and document IDs.
 // It knows about US law sources: USC (uscode.house.gov), CFR (ecfr.gov),
-// state codes, and LII (law.cornell.edu) as a fallback.
+// state codes, and LII (law.cornell.edu) as a fallback. Other sources are
+// added as connectors; EUR-Lex and legislation.gov.uk are registered by
+// default.
 type SourceResolver struct {
 	// citationPatterns maps regex patterns to resolution functions.
 	citationPatterns []*citationPattern
+
+	// connectors are tried in registration order after citationPatterns.
+	connectors []Connector
 }
 
 // citationPattern pairs a regex with a resolution function.
@@ -40,17 +45,61 @@ type ResolvedSource struct {
 
 	// Citation is the original citation text.
 	Citation string
+
+	// Connector is the name of the connector that resolved the citation,
+	// empty for the built-in US patterns.
+	Connector string
 }
 
-// NewSourceResolver creates a SourceResolver with pre-registered US law patterns.
+// NewSourceResolver creates a SourceResolver with pre-registered US law
+// patterns and the EUR-Lex and legislation.gov.uk connectors.
 func NewSourceResolver() *SourceResolver {
 	resolver := &SourceResolver{}
 	resolver.registerUSPatterns()
+	resolver.RegisterConnector(NewEURLexConnector())
+	resolver.RegisterConnector(NewUKLegislationConnector())
 	return resolver
 }
 
+// RegisterConnector adds a connector, replacing any registered under the
+// same name.
+func (resolver *SourceResolver) RegisterConnector(connector Connector) {
+	for index, registered := range resolver.connectors {
+		if registered.Name() == connector.Name() {
+			resolver.connectors[index] = connector
+			return
+		}
+	}
+	resolver.connectors = append(resolver.connectors, connector)
+}
+
+// Connector returns the connector registered under name, or nil.
+func (resolver *SourceResolver) Connector(name string) Connector {
+	for _, connector := range resolver.connectors {
+		if connector.Name() == name {
+			return connector
+		}
+	}
+	return nil
+}
+
+// resolveWithConnectors returns the first connector resolution of reference.
+func (resolver *SourceResolver) resolveWithConnectors(reference string) (*ResolvedSource, bool) {
+	for _, connector := range resolver.connectors {
+		resolved, err := connector.Resolve(reference)
+		if err != nil {
+			continue
+		}
+		resolved.Citation = reference
+		resolved.Connector = connector.Name()
+		return resolved, true
+	}
+	return nil, false
+}
+
 // Resolve attempts to resolve a citation string to a fetchable URL and document ID.
-// It tries all registered patterns in order and returns the first match.
+// It tries all registered patterns in order, then the connectors, and returns
+// the first match.
 func (resolver *SourceResolver) Resolve(citation string) (*ResolvedSource, error) {
 	normalizedCitation := strings.TrimSpace(citation)
 	if normalizedCitation == "" {
@@ -69,10 +118,15 @@ func (resolver *SourceResolver) Resolve(citation string) (*ResolvedSource, error
 		}
 	}
 
+	if resolved, ok := resolver.resolveWithConnectors(normalizedCitation); ok {
+		return resolved, nil
+	}
+
 	return nil, fmt.Errorf("unrecognized citation format: %s", normalizedCitation)
 }
 
 // ResolveURN resolves a URN-style identifier (from the triple store) to a fetchable URL.
+// URNs outside the US schemes, such as urn:eu and urn:uk, go to the connectors.
 func (resolver *SourceResolver) ResolveURN(urn string) (*ResolvedSource, error) {
 	if urn == "" {
 		return nil, fmt.Errorf("empty URN")
@@ -86,6 +140,9 @@ func (resolver *SourceResolver) ResolveURN(urn string) (*ResolvedSource, error)
 	case strings.HasPrefix(urn, "urn:us:state:"):
 		return resolver.resolveStateURN(urn)
 	default:
+		if resolved, ok := resolver.resolveWithConnectors(urn); ok {
+			return resolved, nil
+		}
 		return nil, fmt.Errorf("unsupported URN scheme: %s", urn)
 	}
 }
@@ -245,9 +245,9 @@ func TestResolveStateURN(t *testing.T) {
 func TestResolveUnsupportedURN(t *testing.T) {
 	resolver := NewSourceResolver()
 
-	_, err := resolver.ResolveURN("urn:eu:regulation:2016/679")
+	_, err := resolver.ResolveURN("urn:au:act:1988/119")
 	if err == nil {
-		t.Fatal("expected error for EU URN, got nil")
+		t.Fatal("expected error for Australian URN, got nil")
 	}
 }
 
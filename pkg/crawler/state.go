@@ -99,6 +99,15 @@ func (state *CrawlState) Dequeue() *CrawlItem {
 	return nextItem
 }
 
+// Requeue returns an item whose processing was interrupted to the front of
+// the frontier and clears its visited mark, so a resumed crawl retries it.
+func (state *CrawlState) Requeue(item *CrawlItem) {
+	delete(state.Visited, item.DocumentID)
+	item.Status = CrawlItemPending
+	item.Error = ""
+	state.Frontier = append([]*CrawlItem{item}, state.Frontier...)
+}
+
 // MarkVisited records a document ID as visited.
 func (state *CrawlState) MarkVisited(documentID string) {
 	state.Visited[documentID] = true
@@ -1,5 +1,7 @@
 // Package crawler provides a BFS tree-walking legislation crawler that
-// automatically discovers and ingests US legislation by following cross-references.
+// automatically discovers and ingests legislation by following cross-references.
+// US sources are resolved by built-in citation patterns; EU and UK sources by
+// the EUR-Lex and legislation.gov.uk connectors.
 package crawler
 
 import (
@@ -50,7 +52,9 @@ type CrawlConfig struct {
 	// BaseURI is the base URI for RDF triples.
 	BaseURI string
 
-	// CacheDir is the directory for persistent content caching.
+	// CacheDir is the HTTP cache directory (see pkg/httpcache). Fetched
+	// pages are revalidated with conditional requests instead of being
+	// downloaded again. Empty disables caching.
 	CacheDir string
 
 	// DryRun when true, plans the crawl without making network requests.
@@ -62,9 +66,14 @@ type CrawlConfig struct {
 	// StatePath is the path for saving/loading crawl state.
 	StatePath string
 
-	// UserAgent is the User-Agent header sent with requests.
+	// UserAgent is the User-Agent header sent with requests. Its product
+	// token, as "regula-crawler", is what robots.txt rules are matched against.
 	UserAgent string
 
+	// RespectRobots when true, skips URLs disallowed by their site's
+	// robots.txt and spaces requests by at least the site's Crawl-delay.
+	RespectRobots bool
+
 	// DomainConfigs holds per-domain configuration overrides.
 	DomainConfigs map[string]*DomainConfig
 
@@ -75,14 +84,15 @@ type CrawlConfig struct {
 // DefaultCrawlConfig returns a CrawlConfig with sensible defaults.
 func DefaultCrawlConfig() CrawlConfig {
 	return CrawlConfig{
-		MaxDepth:     DefaultCrawlMaxDepth,
-		MaxDocuments: DefaultCrawlMaxDocuments,
-		RateLimit:    DefaultCrawlRateLimit,
-		Timeout:      DefaultCrawlTimeout,
-		LibraryPath:  ".regula",
-		BaseURI:      "https://regula.dev/regulations/",
-		UserAgent:    DefaultCrawlUserAgent,
-		OutputFormat: "table",
+		MaxDepth:      DefaultCrawlMaxDepth,
+		MaxDocuments:  DefaultCrawlMaxDocuments,
+		RateLimit:     DefaultCrawlRateLimit,
+		Timeout:       DefaultCrawlTimeout,
+		LibraryPath:   ".regula",
+		BaseURI:       "https://regula.dev/regulations/",
+		UserAgent:     DefaultCrawlUserAgent,
+		RespectRobots: true,
+		OutputFormat:  "table",
 	}
 }
 
@@ -98,7 +108,7 @@ type DomainConfig struct {
 	ContentSelector string
 }
 
-// DefaultDomainConfigs returns pre-configured domain settings for known US law sources.
+// DefaultDomainConfigs returns pre-configured domain settings for known law sources.
 func DefaultDomainConfigs() map[string]*DomainConfig {
 	return map[string]*DomainConfig{
 		"uscode.house.gov": {
@@ -113,6 +123,12 @@ func DefaultDomainConfigs() map[string]*DomainConfig {
 		"leginfo.legislature.ca.gov": {
 			RateLimit: 3 * time.Second,
 		},
+		"eur-lex.europa.eu": {
+			RateLimit: 3 * time.Second,
+		},
+		"www.legislation.gov.uk": {
+			RateLimit: 2 * time.Second,
+		},
 	}
 }
 
@@ -185,6 +201,10 @@ type CrawlItem struct {
 	// Domain is the source domain of the URL.
 	Domain string `json:"domain,omitempty"`
 
+	// Connector is the name of the connector that resolved the item, empty
+	// for US sources, whose content is ingested as fetched.
+	Connector string `json:"connector,omitempty"`
+
 	// FetchedAt is the timestamp when the item was fetched.
 	FetchedAt time.Time `json:"fetched_at,omitempty"`
 }
@@ -0,0 +1,153 @@
+// Package daemon runs recurring maintenance jobs for a library, such as
+// refreshing bulk downloads or checking links, on cron-like schedules read
+// from the library's config.yaml.
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName is the library configuration file holding the daemon
+// section. It is the same file that sets the report language.
+const ConfigFileName = "config.yaml"
+
+// DefaultJobTimeout bounds a job run when the job sets no timeout.
+const DefaultJobTimeout = 6 * time.Hour
+
+// Config is the "daemon" section of a library's config.yaml:
+//
+//	daemon:
+//	  jobs:
+//	    - name: ecfr-refresh
+//	      schedule: "@nightly"
+//	      command: [bulk, sync, cfr]
+//	  notify:
+//	    - webhook: https://hooks.example.com/regula
+//	      events: [job.failed]
+type Config struct {
+	// Jobs are the recurring jobs to run.
+	Jobs []JobConfig `yaml:"jobs" json:"jobs"`
+
+	// Notify lists where job events are sent.
+	Notify []NotifyConfig `yaml:"notify,omitempty" json:"notify,omitempty"`
+}
+
+// JobConfig configures one recurring job.
+type JobConfig struct {
+	// Name identifies the job in history and notifications.
+	Name string `yaml:"name" json:"name"`
+
+	// Schedule is a cron expression; see ParseSchedule.
+	Schedule string `yaml:"schedule" json:"schedule"`
+
+	// Command is the regula command line to run, without the program
+	// name, e.g. [bulk, sync, uscode].
+	Command []string `yaml:"command" json:"command"`
+
+	// Timeout bounds a run (e.g. "2h"); DefaultJobTimeout if empty.
+	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+
+	// Enabled indicates if this job is scheduled (default true).
+	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+}
+
+// NotifyConfig configures one notification target.
+type NotifyConfig struct {
+	// Webhook is a URL that receives each event as a JSON POST.
+	Webhook string `yaml:"webhook" json:"webhook"`
+
+	// Events lists the event types sent; job.failed if empty.
+	Events []EventType `yaml:"events,omitempty" json:"events,omitempty"`
+}
+
+// IsEnabled reports whether the job is scheduled.
+func (j JobConfig) IsEnabled() bool {
+	return j.Enabled == nil || *j.Enabled
+}
+
+// TimeoutDuration returns the job's timeout.
+func (j JobConfig) TimeoutDuration() time.Duration {
+	if j.Timeout == "" {
+		return DefaultJobTimeout
+	}
+	timeout, err := time.ParseDuration(j.Timeout)
+	if err != nil {
+		return DefaultJobTimeout
+	}
+	return timeout
+}
+
+// Job returns the configured job with the given name, or nil.
+func (c *Config) Job(name string) *JobConfig {
+	for i := range c.Jobs {
+		if c.Jobs[i].Name == name {
+			return &c.Jobs[i]
+		}
+	}
+	return nil
+}
+
+// LoadConfig reads the daemon section of config.yaml in libraryPath. A
+// missing file or section yields an empty configuration.
+func LoadConfig(libraryPath string) (*Config, error) {
+	configPath := filepath.Join(libraryPath, ConfigFileName)
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", configPath, err)
+	}
+
+	var libraryConfig struct {
+		Daemon Config `yaml:"daemon"`
+	}
+	if err := yaml.Unmarshal(data, &libraryConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+	if err := libraryConfig.Daemon.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid daemon config in %s: %w", configPath, err)
+	}
+	return &libraryConfig.Daemon, nil
+}
+
+// Validate checks that every job is named uniquely, has a command, and
+// has a parseable schedule and timeout.
+func (c *Config) Validate() error {
+	names := make(map[string]bool)
+	for i, job := range c.Jobs {
+		if job.Name == "" {
+			return fmt.Errorf("job %d: name is required", i)
+		}
+		if names[job.Name] {
+			return fmt.Errorf("job %s: duplicate name", job.Name)
+		}
+		names[job.Name] = true
+
+		if len(job.Command) == 0 {
+			return fmt.Errorf("job %s: command is required", job.Name)
+		}
+		if job.Schedule == "" {
+			return fmt.Errorf("job %s: schedule is required", job.Name)
+		}
+		if _, err := ParseSchedule(job.Schedule); err != nil {
+			return fmt.Errorf("job %s: %w", job.Name, err)
+		}
+		if job.Timeout != "" {
+			if _, err := time.ParseDuration(job.Timeout); err != nil {
+				return fmt.Errorf("job %s: invalid timeout %q: %w", job.Name, job.Timeout, err)
+			}
+		}
+	}
+	for i, target := range c.Notify {
+		if target.Webhook == "" {
+			return fmt.Errorf("notify %d: webhook is required", i)
+		}
+	}
+	return nil
+}
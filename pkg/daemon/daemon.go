@@ -0,0 +1,342 @@
+package daemon
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Runner executes a job's command, writing its combined output to output.
+// It must return when ctx is cancelled.
+type Runner func(ctx context.Context, job JobConfig, output io.Writer) error
+
+// Options configures a Daemon.
+type Options struct {
+	// LibraryPath is the library the jobs maintain. History and locks are
+	// kept in its daemon directory.
+	LibraryPath string
+
+	// Runner executes job commands.
+	Runner Runner
+
+	// Notifiers receive every job event, in addition to those configured
+	// in Config.Notify.
+	Notifiers []Notifier
+}
+
+// Daemon runs a library's configured jobs on their schedules.
+//
+// A job never overlaps itself: a run that comes due while the previous run
+// of the same job is still going, in this daemon or in another process
+// such as 'regula daemon run', is skipped and recorded as such. Only one
+// daemon may run per library.
+type Daemon struct {
+	config    *Config
+	options   Options
+	history   *History
+	notifiers []Notifier
+	schedules map[string]*Schedule
+
+	mu      sync.Mutex
+	next    map[string]time.Time
+	running map[string]bool
+	active  sync.WaitGroup
+}
+
+// ScheduledJob is a job and the time it next runs.
+type ScheduledJob struct {
+	Job     JobConfig
+	NextRun time.Time
+}
+
+// New returns a daemon for config.
+func New(config *Config, options Options) (*Daemon, error) {
+	if options.Runner == nil {
+		return nil, fmt.Errorf("daemon requires a runner")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	schedules := make(map[string]*Schedule, len(config.Jobs))
+	for _, job := range config.Jobs {
+		schedule, err := ParseSchedule(job.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", job.Name, err)
+		}
+		schedules[job.Name] = schedule
+	}
+
+	return &Daemon{
+		config:    config,
+		options:   options,
+		history:   NewHistory(HistoryPath(options.LibraryPath)),
+		notifiers: append(config.Notifiers(), options.Notifiers...),
+		schedules: schedules,
+		next:      make(map[string]time.Time),
+		running:   make(map[string]bool),
+	}, nil
+}
+
+// History returns the daemon's job history.
+func (d *Daemon) History() *History {
+	return d.history
+}
+
+// Schedule returns the enabled jobs with the time each next runs after t,
+// soonest first.
+func (d *Daemon) Schedule(t time.Time) []ScheduledJob {
+	var scheduled []ScheduledJob
+	for _, job := range d.config.Jobs {
+		if !job.IsEnabled() {
+			continue
+		}
+		scheduled = append(scheduled, ScheduledJob{Job: job, NextRun: d.schedules[job.Name].Next(t)})
+	}
+	sort.SliceStable(scheduled, func(i, j int) bool {
+		return scheduled[i].NextRun.Before(scheduled[j].NextRun)
+	})
+	return scheduled
+}
+
+// Run schedules jobs until ctx is cancelled, then waits for running jobs,
+// which see the cancellation, to finish. It returns ErrLocked if another
+// daemon is running for the library.
+func (d *Daemon) Run(ctx context.Context) error {
+	lock, err := acquireLock(daemonLockPath(d.options.LibraryPath))
+	if errors.Is(err, ErrLocked) {
+		return fmt.Errorf("another daemon is running for %s: %w", d.options.LibraryPath, err)
+	}
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	d.start(time.Now())
+	for {
+		wake, ok := d.nextWake()
+		if !ok {
+			slog.Warn("daemon has no enabled jobs")
+			<-ctx.Done()
+			break
+		}
+
+		timer := time.NewTimer(time.Until(wake))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case now := <-timer.C:
+			d.Tick(ctx, now)
+			continue
+		}
+		break
+	}
+
+	d.Wait()
+	return nil
+}
+
+// start schedules each enabled job's first run after t.
+func (d *Daemon) start(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, job := range d.config.Jobs {
+		if job.IsEnabled() {
+			d.next[job.Name] = d.schedules[job.Name].Next(t)
+		}
+	}
+}
+
+// nextWake returns the earliest time a job is due.
+func (d *Daemon) nextWake() (time.Time, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var earliest time.Time
+	for _, next := range d.next {
+		if next.IsZero() {
+			continue
+		}
+		if earliest.IsZero() || next.Before(earliest) {
+			earliest = next
+		}
+	}
+	return earliest, !earliest.IsZero()
+}
+
+// Tick starts every job due at now in the background and schedules its
+// next run. Jobs not yet scheduled are scheduled from now without running.
+// It returns the names of the jobs started or skipped.
+func (d *Daemon) Tick(ctx context.Context, now time.Time) []string {
+	d.mu.Lock()
+	var due []JobConfig
+	for _, job := range d.config.Jobs {
+		if !job.IsEnabled() {
+			continue
+		}
+		next, scheduled := d.next[job.Name]
+		if !scheduled {
+			d.next[job.Name] = d.schedules[job.Name].Next(now)
+			continue
+		}
+		if next.IsZero() || next.After(now) {
+			continue
+		}
+		d.next[job.Name] = d.schedules[job.Name].Next(now)
+		due = append(due, job)
+	}
+	d.mu.Unlock()
+
+	names := make([]string, len(due))
+	for i, job := range due {
+		names[i] = job.Name
+		d.active.Add(1)
+		go func() {
+			defer d.active.Done()
+			d.execute(ctx, job, true)
+		}()
+	}
+	return names
+}
+
+// Wait blocks until all jobs started by Tick have finished.
+func (d *Daemon) Wait() {
+	d.active.Wait()
+}
+
+// RunJob runs the named job immediately and waits for it. The run is
+// skipped if the job is already running.
+func (d *Daemon) RunJob(ctx context.Context, name string) (RunRecord, error) {
+	job := d.config.Job(name)
+	if job == nil {
+		return RunRecord{}, fmt.Errorf("job not found: %s", name)
+	}
+	return d.execute(ctx, *job, false), nil
+}
+
+// execute runs a job unless it is already running, then records the run
+// and emits its events.
+func (d *Daemon) execute(ctx context.Context, job JobConfig, scheduled bool) RunRecord {
+	record := RunRecord{
+		RunID:     newRunID(),
+		Job:       job.Name,
+		Command:   job.Command,
+		Scheduled: scheduled,
+		StartedAt: time.Now(),
+	}
+	logger := slog.With(slog.String("job", job.Name), slog.String("run_id", record.RunID))
+
+	release, err := d.claim(job.Name)
+	if err != nil {
+		record.Status = RunSkipped
+		record.Error = err.Error()
+		record.FinishedAt = record.StartedAt
+		logger.Warn("job skipped", slog.String("reason", record.Error))
+		d.finish(ctx, record, EventJobSkipped)
+		return record
+	}
+	defer release()
+
+	logger.Info("job started", slog.Any("command", job.Command))
+	d.emit(ctx, Event{Type: EventJobStarted, Job: job.Name, RunID: record.RunID, Time: record.StartedAt})
+
+	timeout := job.TimeoutDuration()
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	output := &tailBuffer{}
+	runErr := d.options.Runner(runCtx, job, output)
+	if runErr != nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		runErr = fmt.Errorf("timed out after %s: %w", timeout, runErr)
+	}
+
+	record.FinishedAt = time.Now()
+	record.Duration = record.FinishedAt.Sub(record.StartedAt)
+	record.Output = output.String()
+
+	eventType := EventJobSucceeded
+	record.Status = RunSucceeded
+	if runErr != nil {
+		eventType = EventJobFailed
+		record.Status = RunFailed
+		record.Error = runErr.Error()
+		logger.Error("job failed", slog.Duration("duration", record.Duration), slog.String("error", record.Error))
+	} else {
+		logger.Info("job succeeded", slog.Duration("duration", record.Duration))
+	}
+	d.finish(ctx, record, eventType)
+	return record
+}
+
+// claim marks a job as running, both in this process and in the job's lock
+// file, and returns a function releasing both.
+func (d *Daemon) claim(job string) (func(), error) {
+	d.mu.Lock()
+	if d.running[job] {
+		d.mu.Unlock()
+		return nil, fmt.Errorf("previous run still in progress")
+	}
+	d.running[job] = true
+	d.mu.Unlock()
+
+	unmark := func() {
+		d.mu.Lock()
+		delete(d.running, job)
+		d.mu.Unlock()
+	}
+
+	lock, err := acquireLock(jobLockPath(d.options.LibraryPath, job))
+	if err != nil {
+		unmark()
+		if errors.Is(err, ErrLocked) {
+			return nil, fmt.Errorf("job is running in another process")
+		}
+		return nil, err
+	}
+	return func() {
+		lock.Release()
+		unmark()
+	}, nil
+}
+
+// finish appends the run to the history and emits its final event.
+func (d *Daemon) finish(ctx context.Context, record RunRecord, eventType EventType) {
+	if err := d.history.Append(record); err != nil {
+		slog.Error("failed to record job run", slog.String("job", record.Job), slog.String("error", err.Error()))
+	}
+	d.emit(ctx, Event{
+		Type:     eventType,
+		Job:      record.Job,
+		RunID:    record.RunID,
+		Time:     record.FinishedAt,
+		Duration: record.Duration,
+		Error:    record.Error,
+	})
+}
+
+// emit sends an event to every notifier. Delivery failures are logged and
+// never fail the job. Notifications are still delivered while the daemon
+// shuts down, so a job failing because of the shutdown is reported.
+func (d *Daemon) emit(ctx context.Context, event Event) {
+	notifyCtx := context.WithoutCancel(ctx)
+	for _, notifier := range d.notifiers {
+		if err := notifier.Notify(notifyCtx, event); err != nil {
+			slog.Error("failed to deliver job event",
+				slog.String("job", event.Job), slog.String("event", string(event.Type)), slog.String("error", err.Error()))
+		}
+	}
+}
+
+func newRunID() string {
+	buf := make([]byte, 6)
+	rand.Read(buf)
+	return time.Now().UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(buf)
+}
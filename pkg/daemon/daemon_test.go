@@ -0,0 +1,389 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventRecorder is a Notifier collecting events.
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *eventRecorder) Notify(ctx context.Context, event Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *eventRecorder) types() []EventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var types []EventType
+	for _, event := range r.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func newTestDaemon(t *testing.T, jobs []JobConfig, runner Runner) (*Daemon, *eventRecorder) {
+	t.Helper()
+	recorder := &eventRecorder{}
+	d, err := New(&Config{Jobs: jobs}, Options{
+		LibraryPath: t.TempDir(),
+		Runner:      runner,
+		Notifiers:   []Notifier{recorder},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return d, recorder
+}
+
+func TestLoadConfig(t *testing.T) {
+	libraryPath := t.TempDir()
+
+	config, err := LoadConfig(libraryPath)
+	if err != nil {
+		t.Fatalf("LoadConfig without config.yaml failed: %v", err)
+	}
+	if len(config.Jobs) != 0 {
+		t.Errorf("expected no jobs, got %d", len(config.Jobs))
+	}
+
+	configYAML := `language: de
+daemon:
+  jobs:
+    - name: ecfr-refresh
+      schedule: "@nightly"
+      command: [bulk, sync, cfr]
+    - name: usc-update
+      schedule: "@monthly"
+      command: [bulk, sync, uscode]
+      timeout: 12h
+      enabled: false
+  notify:
+    - webhook: https://hooks.example.com/regula
+`
+	os.WriteFile(filepath.Join(libraryPath, ConfigFileName), []byte(configYAML), 0644)
+
+	config, err = LoadConfig(libraryPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if len(config.Jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(config.Jobs))
+	}
+	usc := config.Job("usc-update")
+	if usc == nil || usc.IsEnabled() || usc.TimeoutDuration() != 12*time.Hour {
+		t.Errorf("unexpected usc-update job: %+v", usc)
+	}
+	if ecfr := config.Job("ecfr-refresh"); ecfr == nil || !ecfr.IsEnabled() || ecfr.TimeoutDuration() != DefaultJobTimeout {
+		t.Errorf("unexpected ecfr-refresh job: %+v", ecfr)
+	}
+	if len(config.Notifiers()) != 1 {
+		t.Errorf("expected 1 notifier, got %d", len(config.Notifiers()))
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{"missing name", Config{Jobs: []JobConfig{{Schedule: "@daily", Command: []string{"status"}}}}, "name is required"},
+		{"duplicate", Config{Jobs: []JobConfig{
+			{Name: "a", Schedule: "@daily", Command: []string{"status"}},
+			{Name: "a", Schedule: "@daily", Command: []string{"status"}},
+		}}, "duplicate name"},
+		{"missing command", Config{Jobs: []JobConfig{{Name: "a", Schedule: "@daily"}}}, "command is required"},
+		{"bad schedule", Config{Jobs: []JobConfig{{Name: "a", Schedule: "daily", Command: []string{"status"}}}}, "expected 5 fields"},
+		{"bad timeout", Config{Jobs: []JobConfig{{Name: "a", Schedule: "@daily", Command: []string{"status"}, Timeout: "soon"}}}, "invalid timeout"},
+		{"missing webhook", Config{Notify: []NotifyConfig{{}}}, "webhook is required"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestDaemon_TickRunsDueJobsAndRecordsHistory(t *testing.T) {
+	var mu sync.Mutex
+	var ran []string
+	runner := func(ctx context.Context, job JobConfig, output io.Writer) error {
+		mu.Lock()
+		ran = append(ran, job.Name)
+		mu.Unlock()
+		fmt.Fprintf(output, "ran %s\n", strings.Join(job.Command, " "))
+		return nil
+	}
+	disabled := false
+	d, recorder := newTestDaemon(t, []JobConfig{
+		{Name: "hourly", Schedule: "@hourly", Command: []string{"bulk", "sync", "cfr"}},
+		{Name: "daily", Schedule: "@daily", Command: []string{"bulk", "sync", "uscode"}},
+		{Name: "off", Schedule: "@hourly", Command: []string{"status"}, Enabled: &disabled},
+	}, runner)
+
+	start := time.Date(2025, 1, 15, 10, 30, 0, 0, time.Local)
+	d.start(start)
+
+	if due := d.Tick(context.Background(), start.Add(10*time.Minute)); len(due) != 0 {
+		t.Errorf("expected no jobs due at 10:40, got %v", due)
+	}
+	if due := d.Tick(context.Background(), start.Add(30*time.Minute)); len(due) != 1 || due[0] != "hourly" {
+		t.Errorf("expected hourly due at 11:00, got %v", due)
+	}
+	d.Wait()
+	// The hourly job was rescheduled for 12:00, so 11:30 runs nothing.
+	if due := d.Tick(context.Background(), start.Add(time.Hour)); len(due) != 0 {
+		t.Errorf("expected no jobs due at 11:30, got %v", due)
+	}
+
+	records, err := d.History().Records("", 0)
+	if err != nil {
+		t.Fatalf("Records failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 history record, got %d", len(records))
+	}
+	record := records[0]
+	if record.Job != "hourly" || record.Status != RunSucceeded || !record.Scheduled {
+		t.Errorf("unexpected record: %+v", record)
+	}
+	if record.Output != "ran bulk sync cfr\n" {
+		t.Errorf("unexpected output %q", record.Output)
+	}
+	if got := recorder.types(); len(got) != 2 || got[0] != EventJobStarted || got[1] != EventJobSucceeded {
+		t.Errorf("unexpected events %v", got)
+	}
+
+	scheduled := d.Schedule(start)
+	if len(scheduled) != 2 || scheduled[0].Job.Name != "hourly" || scheduled[1].Job.Name != "daily" {
+		t.Errorf("unexpected schedule %+v", scheduled)
+	}
+}
+
+func TestDaemon_SkipsOverlappingRun(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	runner := func(ctx context.Context, job JobConfig, output io.Writer) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+	d, recorder := newTestDaemon(t, []JobConfig{
+		{Name: "slow", Schedule: "@every 1m", Command: []string{"bulk", "sync"}},
+	}, runner)
+
+	start := time.Now()
+	d.start(start)
+	d.Tick(context.Background(), start.Add(time.Minute))
+	<-started
+
+	// A manual run while the scheduled run is going is skipped.
+	record, err := d.RunJob(context.Background(), "slow")
+	if err != nil {
+		t.Fatalf("RunJob failed: %v", err)
+	}
+	if record.Status != RunSkipped || !strings.Contains(record.Error, "in progress") {
+		t.Errorf("expected overlapping manual run to be skipped, got %+v", record)
+	}
+
+	close(release)
+	d.Wait()
+
+	records, _ := d.History().Records("slow", 0)
+	if len(records) != 2 || records[0].Status != RunSkipped || records[1].Status != RunSucceeded {
+		t.Errorf("unexpected history %+v", records)
+	}
+	if got := recorder.types(); len(got) != 3 || got[0] != EventJobStarted || got[1] != EventJobSkipped || got[2] != EventJobSucceeded {
+		t.Errorf("unexpected events %v", got)
+	}
+}
+
+func TestDaemon_SkipsJobLockedByAnotherProcess(t *testing.T) {
+	runs := 0
+	d, _ := newTestDaemon(t, []JobConfig{
+		{Name: "refresh", Schedule: "@daily", Command: []string{"bulk", "sync"}},
+	}, func(ctx context.Context, job JobConfig, output io.Writer) error {
+		runs++
+		return nil
+	})
+
+	lock, err := acquireLock(jobLockPath(d.options.LibraryPath, "refresh"))
+	if err != nil {
+		t.Fatalf("acquireLock failed: %v", err)
+	}
+	record, err := d.RunJob(context.Background(), "refresh")
+	if err != nil {
+		t.Fatalf("RunJob failed: %v", err)
+	}
+	if record.Status != RunSkipped || !strings.Contains(record.Error, "another process") || runs != 0 {
+		t.Errorf("expected skipped run while locked, got %+v after %d runs", record, runs)
+	}
+
+	lock.Release()
+	record, _ = d.RunJob(context.Background(), "refresh")
+	if record.Status != RunSucceeded || runs != 1 {
+		t.Errorf("expected run after lock release, got %+v after %d runs", record, runs)
+	}
+}
+
+func TestDaemon_RunRefusesSecondDaemon(t *testing.T) {
+	d, _ := newTestDaemon(t, nil, func(ctx context.Context, job JobConfig, output io.Writer) error { return nil })
+
+	lock, err := acquireLock(daemonLockPath(d.options.LibraryPath))
+	if err != nil {
+		t.Fatalf("acquireLock failed: %v", err)
+	}
+	defer lock.Release()
+
+	if err := d.Run(context.Background()); !errors.Is(err, ErrLocked) {
+		t.Errorf("Run with daemon lock held = %v, want ErrLocked", err)
+	}
+}
+
+func TestDaemon_RunStopsOnCancel(t *testing.T) {
+	d, _ := newTestDaemon(t, []JobConfig{
+		{Name: "weekly", Schedule: "@weekly", Command: []string{"status"}},
+	}, func(ctx context.Context, job JobConfig, output io.Writer) error { return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- d.Run(ctx) }()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancellation")
+	}
+}
+
+func TestDaemon_TimeoutFailsRun(t *testing.T) {
+	d, recorder := newTestDaemon(t, []JobConfig{
+		{Name: "hang", Schedule: "@daily", Command: []string{"crawl"}, Timeout: "20ms"},
+	}, func(ctx context.Context, job JobConfig, output io.Writer) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	record, err := d.RunJob(context.Background(), "hang")
+	if err != nil {
+		t.Fatalf("RunJob failed: %v", err)
+	}
+	if record.Status != RunFailed || !strings.Contains(record.Error, "timed out after 20ms") {
+		t.Errorf("expected timeout failure, got %+v", record)
+	}
+	if got := recorder.types(); len(got) != 2 || got[1] != EventJobFailed {
+		t.Errorf("unexpected events %v", got)
+	}
+}
+
+func TestDaemon_FailureNotifiesWebhook(t *testing.T) {
+	var mu sync.Mutex
+	var received []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("failed to decode webhook body: %v", err)
+		}
+		mu.Lock()
+		received = append(received, event)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	config := &Config{
+		Jobs: []JobConfig{
+			{Name: "broken", Schedule: "@daily", Command: []string{"bulk", "sync", "nowhere"}},
+			{Name: "fine", Schedule: "@daily", Command: []string{"status"}},
+		},
+		Notify: []NotifyConfig{{Webhook: server.URL}},
+	}
+	d, err := New(config, Options{
+		LibraryPath: t.TempDir(),
+		Runner: func(ctx context.Context, job JobConfig, output io.Writer) error {
+			if job.Name == "broken" {
+				return errors.New("unknown source: nowhere")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	d.RunJob(context.Background(), "fine")
+	d.RunJob(context.Background(), "broken")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 1 {
+		t.Fatalf("expected 1 webhook delivery (job.failed only), got %d: %+v", len(received), received)
+	}
+	if received[0].Type != EventJobFailed || received[0].Job != "broken" || received[0].Error != "unknown source: nowhere" {
+		t.Errorf("unexpected event %+v", received[0])
+	}
+}
+
+func TestHistory_RecordsLimitAndFilter(t *testing.T) {
+	history := NewHistory(filepath.Join(t.TempDir(), "daemon", "history.jsonl"))
+
+	records, err := history.Records("", 0)
+	if err != nil || len(records) != 0 {
+		t.Fatalf("expected empty history, got %v, %v", records, err)
+	}
+
+	for i := 0; i < 5; i++ {
+		job := "a"
+		if i%2 == 1 {
+			job = "b"
+		}
+		history.Append(RunRecord{RunID: fmt.Sprint(i), Job: job, Status: RunSucceeded})
+	}
+
+	records, _ = history.Records("", 2)
+	if len(records) != 2 || records[0].RunID != "3" || records[1].RunID != "4" {
+		t.Errorf("unexpected limited records %+v", records)
+	}
+	records, _ = history.Records("b", 0)
+	if len(records) != 2 || records[0].RunID != "1" || records[1].RunID != "3" {
+		t.Errorf("unexpected filtered records %+v", records)
+	}
+	last, _ := history.LastRuns()
+	if last["a"].RunID != "4" || last["b"].RunID != "3" {
+		t.Errorf("unexpected last runs %+v", last)
+	}
+}
+
+func TestTailBuffer_KeepsEnd(t *testing.T) {
+	buffer := &tailBuffer{}
+	buffer.Write([]byte(strings.Repeat("x", MaxRecordedOutput)))
+	buffer.Write([]byte("end"))
+	output := buffer.String()
+	if len(output) != MaxRecordedOutput || !strings.HasSuffix(output, "end") {
+		t.Errorf("unexpected tail: %d bytes ending %q", len(output), output[len(output)-5:])
+	}
+}
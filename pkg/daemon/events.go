@@ -0,0 +1,127 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// EventType identifies what happened to a job run.
+type EventType string
+
+const (
+	// EventJobStarted is emitted when a run begins.
+	EventJobStarted EventType = "job.started"
+
+	// EventJobSucceeded is emitted when a run exits successfully.
+	EventJobSucceeded EventType = "job.succeeded"
+
+	// EventJobFailed is emitted when a run fails or times out.
+	EventJobFailed EventType = "job.failed"
+
+	// EventJobSkipped is emitted when a run is skipped because the previous
+	// run of the same job is still in progress.
+	EventJobSkipped EventType = "job.skipped"
+)
+
+// Event describes a change in a job run's state.
+type Event struct {
+	Type     EventType     `json:"type"`
+	Job      string        `json:"job"`
+	RunID    string        `json:"run_id"`
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Notifier receives job events. Notify is called from the goroutine running
+// the job, so implementations must be safe for concurrent use.
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// NotifierFunc adapts a function to the Notifier interface.
+type NotifierFunc func(ctx context.Context, event Event) error
+
+// Notify implements Notifier.
+func (f NotifierFunc) Notify(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// DefaultWebhookTimeout bounds a webhook delivery.
+const DefaultWebhookTimeout = 10 * time.Second
+
+// WebhookNotifier posts events as JSON to a URL.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookNotifier returns a notifier posting to url.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL:    url,
+		Client: &http.Client{Timeout: DefaultWebhookTimeout},
+	}
+}
+
+// Notify implements Notifier.
+func (w *WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// filteredNotifier forwards only the listed event types.
+type filteredNotifier struct {
+	notifier Notifier
+	types    []EventType
+}
+
+// FilterEvents returns a notifier forwarding only events of the given
+// types to notifier.
+func FilterEvents(notifier Notifier, types ...EventType) Notifier {
+	return &filteredNotifier{notifier: notifier, types: types}
+}
+
+// Notify implements Notifier.
+func (f *filteredNotifier) Notify(ctx context.Context, event Event) error {
+	if !slices.Contains(f.types, event.Type) {
+		return nil
+	}
+	return f.notifier.Notify(ctx, event)
+}
+
+// Notifiers builds the notifiers configured in c.Notify.
+func (c *Config) Notifiers() []Notifier {
+	notifiers := make([]Notifier, 0, len(c.Notify))
+	for _, target := range c.Notify {
+		types := target.Events
+		if len(types) == 0 {
+			types = []EventType{EventJobFailed}
+		}
+		notifiers = append(notifiers, FilterEvents(NewWebhookNotifier(target.Webhook), types...))
+	}
+	return notifiers
+}
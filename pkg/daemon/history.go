@@ -0,0 +1,159 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RunStatus is the outcome of a job run.
+type RunStatus string
+
+const (
+	// RunSucceeded means the job's command exited successfully.
+	RunSucceeded RunStatus = "succeeded"
+
+	// RunFailed means the command failed, timed out, or could not start.
+	RunFailed RunStatus = "failed"
+
+	// RunSkipped means the run was not started because the previous run of
+	// the job was still in progress.
+	RunSkipped RunStatus = "skipped"
+)
+
+// RunRecord is one entry in the job history.
+type RunRecord struct {
+	RunID      string        `json:"run_id"`
+	Job        string        `json:"job"`
+	Command    []string      `json:"command"`
+	Status     RunStatus     `json:"status"`
+	Scheduled  bool          `json:"scheduled"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at"`
+	Duration   time.Duration `json:"duration"`
+	Error      string        `json:"error,omitempty"`
+
+	// Output holds the end of the command's combined output.
+	Output string `json:"output,omitempty"`
+}
+
+// MaxRecordedOutput is the number of trailing output bytes kept per run.
+const MaxRecordedOutput = 4096
+
+// History is an append-only JSON-lines log of job runs.
+type History struct {
+	path string
+	mu   sync.Mutex
+}
+
+// HistoryPath returns the history file location for a library.
+func HistoryPath(libraryPath string) string {
+	return filepath.Join(libraryPath, "daemon", "history.jsonl")
+}
+
+// NewHistory returns the history stored at path.
+func NewHistory(path string) *History {
+	return &History{path: path}
+}
+
+// Append adds a record to the history.
+func (h *History) Append(record RunRecord) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	file, err := os.OpenFile(h.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	defer file.Close()
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode run record: %w", err)
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
+
+// Records returns the most recent runs, oldest first, optionally limited
+// to one job. A limit of zero or less returns every matching run.
+func (h *History) Records(job string, limit int) ([]RunRecord, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	file, err := os.Open(h.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer file.Close()
+
+	var records []RunRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		var record RunRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("failed to parse history line %d: %w", lineNumber, err)
+		}
+		if job != "" && record.Job != job {
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	if limit > 0 && len(records) > limit {
+		records = records[len(records)-limit:]
+	}
+	return records, nil
+}
+
+// LastRuns returns the most recent run of each job that has run.
+func (h *History) LastRuns() (map[string]RunRecord, error) {
+	records, err := h.Records("", 0)
+	if err != nil {
+		return nil, err
+	}
+	last := make(map[string]RunRecord)
+	for _, record := range records {
+		last[record.Job] = record
+	}
+	return last, nil
+}
+
+// tailBuffer keeps the last MaxRecordedOutput bytes written to it.
+type tailBuffer struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.data = append(t.data, p...)
+	if len(t.data) > MaxRecordedOutput {
+		t.data = t.data[len(t.data)-MaxRecordedOutput:]
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return string(t.data)
+}
@@ -0,0 +1,50 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// ErrLocked is returned when a lock is held by another run or process.
+var ErrLocked = errors.New("lock is held by another process")
+
+// fileLock is an exclusive lock on a file in the library, held while a
+// daemon runs or a job executes so that two processes never overlap.
+type fileLock struct {
+	path string
+	file *os.File
+}
+
+// acquireLock takes the lock at path without blocking, returning
+// ErrLocked if it is already held. The holder's PID is written to the
+// file for diagnostics.
+func acquireLock(path string) (*fileLock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	file, err := lockFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file.Truncate(0)
+	file.WriteString(strconv.Itoa(os.Getpid()) + "\n")
+	return &fileLock{path: path, file: file}, nil
+}
+
+// Release releases the lock.
+func (l *fileLock) Release() error {
+	return unlockFile(l.path, l.file)
+}
+
+// daemonLockPath is the lock held by a running daemon.
+func daemonLockPath(libraryPath string) string {
+	return filepath.Join(libraryPath, "daemon", "daemon.lock")
+}
+
+// jobLockPath is the lock held while a job runs.
+func jobLockPath(libraryPath, job string) string {
+	return filepath.Join(libraryPath, "daemon", "locks", job+".lock")
+}
@@ -0,0 +1,26 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package daemon
+
+import (
+	"fmt"
+	"os"
+)
+
+// lockFile creates path exclusively. Without flock the lock file outlives
+// a crashed process and must be removed by hand.
+func lockFile(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0644)
+	if os.IsExist(err) {
+		return nil, ErrLocked
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lock file: %w", err)
+	}
+	return file, nil
+}
+
+func unlockFile(path string, file *os.File) error {
+	file.Close()
+	return os.Remove(path)
+}
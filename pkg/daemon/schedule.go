@@ -0,0 +1,202 @@
+package daemon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression. It accepts the five standard fields
+// (minute, hour, day of month, month, day of week) with *, lists, ranges,
+// and steps; month and weekday names (jan, mon); and the macros @hourly,
+// @daily (or @midnight, @nightly), @weekly, @monthly, @yearly (or
+// @annually), and @every <duration>.
+type Schedule struct {
+	expression string
+
+	// every is set for @every schedules, which ignore the fields.
+	every time.Duration
+
+	minutes, hours, days, months, weekdays uint64
+
+	// daysRestricted and weekdaysRestricted follow cron's rule that when
+	// both day fields are restricted a time matches either of them.
+	daysRestricted, weekdaysRestricted bool
+}
+
+var scheduleMacros = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@nightly":  "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var weekdayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// ParseSchedule parses a cron expression.
+func ParseSchedule(expression string) (*Schedule, error) {
+	trimmed := strings.TrimSpace(expression)
+	schedule := &Schedule{expression: trimmed}
+
+	if rest, ok := strings.CutPrefix(trimmed, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", expression, err)
+		}
+		if interval < time.Minute {
+			return nil, fmt.Errorf("invalid schedule %q: interval must be at least 1m", expression)
+		}
+		schedule.every = interval
+		return schedule, nil
+	}
+	if macro, ok := scheduleMacros[strings.ToLower(trimmed)]; ok {
+		trimmed = macro
+	}
+
+	fields := strings.Fields(trimmed)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields, got %d", expression, len(fields))
+	}
+
+	var err error
+	if schedule.minutes, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: minute: %w", expression, err)
+	}
+	if schedule.hours, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: hour: %w", expression, err)
+	}
+	if schedule.days, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: day of month: %w", expression, err)
+	}
+	if schedule.months, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: month: %w", expression, err)
+	}
+	if schedule.weekdays, err = parseField(fields[4], 0, 7, weekdayNames); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: day of week: %w", expression, err)
+	}
+	// 7 is an alias for Sunday.
+	if schedule.weekdays&(1<<7) != 0 {
+		schedule.weekdays |= 1
+	}
+	schedule.daysRestricted = !strings.HasPrefix(fields[2], "*")
+	schedule.weekdaysRestricted = !strings.HasPrefix(fields[4], "*")
+
+	return schedule, nil
+}
+
+// parseField parses one cron field into a bit set of the values it allows.
+func parseField(field string, low, high int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			parsedStep, err := strconv.Atoi(stepPart)
+			if err != nil || parsedStep <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = parsedStep
+		}
+
+		start, end := low, high
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			startText, endText, _ := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseValue(startText, low, high, names); err != nil {
+				return 0, err
+			}
+			if end, err = parseValue(endText, low, high, names); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			value, err := parseValue(rangePart, low, high, names)
+			if err != nil {
+				return 0, err
+			}
+			start = value
+			if !hasStep {
+				end = value
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(text string, low, high int, names map[string]int) (int, error) {
+	if value, ok := names[strings.ToLower(text)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", text)
+	}
+	if value < low || value > high {
+		return 0, fmt.Errorf("value %d out of range %d-%d", value, low, high)
+	}
+	return value, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.expression
+}
+
+// Next returns the first time after t that the schedule fires, in t's
+// location. It returns the zero time if the schedule never fires within
+// five years, as with "0 0 31 2 *".
+func (s *Schedule) Next(t time.Time) time.Time {
+	if s.every > 0 {
+		return t.Add(s.every)
+	}
+
+	location := t.Location()
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := next.AddDate(5, 0, 0)
+
+	for next.Before(limit) {
+		year, month, day := next.Date()
+		switch {
+		case s.months&(1<<uint(month)) == 0:
+			next = time.Date(year, month+1, 1, 0, 0, 0, 0, location)
+		case !s.dayMatches(next):
+			next = time.Date(year, month, day+1, 0, 0, 0, 0, location)
+		case s.hours&(1<<uint(next.Hour())) == 0:
+			next = time.Date(year, month, day, next.Hour()+1, 0, 0, 0, location)
+		case s.minutes&(1<<uint(next.Minute())) == 0:
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dayMatch := s.days&(1<<uint(t.Day())) != 0
+	weekdayMatch := s.weekdays&(1<<uint(t.Weekday())) != 0
+	if s.daysRestricted && s.weekdaysRestricted {
+		return dayMatch || weekdayMatch
+	}
+	return dayMatch && weekdayMatch
+}
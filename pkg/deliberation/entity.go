@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // StakeholderType classifies the type of stakeholder.
@@ -481,14 +482,7 @@ func (e *EntityExtractor) extractNamedSpeakers(text string, context EntityContex
 				}
 
 				// Get context for disambiguation
-				contextStart := match[0] - 50
-				if contextStart < 0 {
-					contextStart = 0
-				}
-				contextEnd := match[1] + 50
-				if contextEnd > len(text) {
-					contextEnd = len(text)
-				}
+				contextStart, contextEnd := textutil.ContextBounds(text, match[0], match[1], 50)
 				mention.Context = text[contextStart:contextEnd]
 
 				// Try to resolve
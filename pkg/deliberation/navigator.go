@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // NodeType indicates the type of node in the deliberation graph.
@@ -774,10 +775,7 @@ func (n *GraphNavigator) RenderTUI() string {
 	sb.WriteString("│                                                             │\n")
 
 	// Focus node info
-	focusLine := fmt.Sprintf("  Focus: [%s] %s", focusNode.Type.Symbol(), focusNode.Label)
-	if len(focusLine) > 57 {
-		focusLine = focusLine[:54] + "..."
-	}
+	focusLine := textutil.Truncate(fmt.Sprintf("  Focus: [%s] %s", focusNode.Type.Symbol(), focusNode.Label), 57)
 	sb.WriteString(fmt.Sprintf("│%-61s│\n", focusLine))
 	sb.WriteString(fmt.Sprintf("│  %s│\n", strings.Repeat("═", 59)))
 	sb.WriteString("│                                                             │\n")
@@ -1144,8 +1142,5 @@ func (n *GraphNavigator) nodeColor(nodeType NodeType) string {
 
 // truncate truncates a string to a maximum length.
 func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
+	return textutil.Truncate(s, maxLen)
 }
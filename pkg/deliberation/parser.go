@@ -8,6 +8,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // MinutesParser extracts structured deliberation data from meeting minutes text.
@@ -657,14 +659,7 @@ func (p *MinutesParser) extractVoteFromContext(text, decisionText string) *VoteR
 	}
 
 	// Look for vote pattern nearby
-	contextStart := idx - 200
-	if contextStart < 0 {
-		contextStart = 0
-	}
-	contextEnd := idx + len(decisionText) + 200
-	if contextEnd > len(text) {
-		contextEnd = len(text)
-	}
+	contextStart, contextEnd := textutil.ContextBounds(text, idx, idx+len(decisionText), 200)
 	context := text[contextStart:contextEnd]
 
 	match := p.patterns.votePattern.FindStringSubmatch(context)
@@ -804,8 +799,5 @@ func sanitizeForURI(s string) string {
 }
 
 func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
+	return textutil.Truncate(s, maxLen)
 }
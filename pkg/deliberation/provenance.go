@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // ProvenanceEventType classifies the type of event in a provenance chain.
@@ -311,10 +312,7 @@ func (b *ProvenanceBuilder) findProvisionEvents(provisionURI string) []Provenanc
 			// Get amendment text for description
 			textTriples := b.store.Find(versionURI, store.PropText, "")
 			if len(textTriples) > 0 {
-				text := textTriples[0].Object
-				if len(text) > 100 {
-					text = text[:100] + "..."
-				}
+				text := textutil.Truncate(textTriples[0].Object, 103)
 				event.Description = fmt.Sprintf("Amendment: %s", text)
 			}
 
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // TimelineEventType indicates the type of event on a timeline.
@@ -878,19 +879,13 @@ func (t *Timeline) RenderASCII() string {
 		symbol := event.EventType.Symbol()
 		dateStr := event.Timestamp.Format("Jan 02")
 
-		label := event.Label
-		if len(label) > 40 {
-			label = label[:37] + "..."
-		}
+		label := textutil.Truncate(event.Label, 40)
 
 		sb.WriteString(fmt.Sprintf("%-11s %s  [%s] %s\n",
 			dateStr, symbol, event.EventType.String(), label))
 
 		if event.Description != "" {
-			desc := event.Description
-			if len(desc) > 50 {
-				desc = desc[:47] + "..."
-			}
+			desc := textutil.Truncate(event.Description, 50)
 			sb.WriteString(fmt.Sprintf("            │   %s\n", desc))
 		}
 		sb.WriteString("            │\n")
@@ -1005,20 +1000,14 @@ func (t *Timeline) RenderSVG() string {
 		sb.WriteString("\n")
 
 		// Event label
-		label := event.Label
-		if len(label) > 50 {
-			label = label[:47] + "..."
-		}
+		label := textutil.Truncate(event.Label, 50)
 		sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="event-label">[%s] %s</text>`,
 			lineX+20, y+5, event.EventType.String(), html.EscapeString(label)))
 		sb.WriteString("\n")
 
 		// Description
 		if event.Description != "" {
-			desc := event.Description
-			if len(desc) > 60 {
-				desc = desc[:57] + "..."
-			}
+			desc := textutil.Truncate(event.Description, 60)
 			sb.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="event-desc">%s</text>`,
 				lineX+20, y+22, html.EscapeString(desc)))
 			sb.WriteString("\n")
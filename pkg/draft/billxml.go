@@ -0,0 +1,512 @@
+package draft
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Congress publishes bills as XML in two schemas: the bill DTD
+// (<bill><form>...</form><legis-body>...</legis-body></bill>) and USLM
+// (<bill xmlns="http://schemas.gpo.gov/xml/uslm"><meta>...<main>...</main>).
+// Both mark up the same things — numbered provisions with enumerators and
+// headers, quoted text, quoted blocks of inserted law, and citations — so
+// the parser reads elements by local name and handles the two together.
+//
+// Bill text is mixed content (text interleaved with <quote>, <external-xref>,
+// and similar elements), which struct decoding flattens, so the document is
+// read into a generic element tree.
+
+// billXMLNode is an element of a bill XML document. Children holds the
+// element's content in document order; text runs are nodes with an empty
+// Name.
+type billXMLNode struct {
+	Name     string
+	Attrs    map[string]string
+	Text     string
+	Children []*billXMLNode
+}
+
+// Elements that start a provision on its own line.
+var billXMLProvisionElements = map[string]bool{
+	"section": true, "subsection": true, "paragraph": true, "subparagraph": true,
+	"clause": true, "subclause": true, "item": true, "subitem": true,
+	"title": true, "subtitle": true, "chapter": true, "subchapter": true,
+	"part": true, "subpart": true, "division": true, "level": true,
+}
+
+// billXMLSkippedElements hold no bill text.
+var billXMLSkippedElements = map[string]bool{
+	"metadata": true, "meta": true, "form": true, "preface": true,
+	"longTitle": true, "enactingFormula": true, "endMarker": true,
+	"attestation": true, "endorsement": true, "note": true, "footnote": true,
+}
+
+var (
+	billXMLOrdinalPattern = regexp.MustCompile(`(?i)^(\d{1,3})(st|nd|rd|th)?\b`)
+	billXMLUSLMRefPattern = regexp.MustCompile(`^/us/usc/t(\d+)/s([0-9A-Za-z-]+)((?:/[0-9A-Za-z-]+)*)`)
+	billXMLDTDCitePattern = regexp.MustCompile(`^usc/(\d+)/([0-9A-Za-z-]+)((?:/[0-9A-Za-z-]+)*)`)
+	billXMLSectionPrefix  = regexp.MustCompile(`(?i)^(?:SECTION|SEC\.|§)\s*`)
+	billXMLWhitespace     = regexp.MustCompile(`\s+`)
+)
+
+// IsBillXML reports whether a bill file is XML, by its extension.
+func IsBillXML(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".xml")
+}
+
+// ParseBillXML reads a bill in the bill DTD or USLM XML schema. Metadata,
+// sections, and section headers come from the markup. Each section's
+// RawText is rendered from its provisions one per line, quoted text and
+// quoted blocks in quotation marks, and United States Code citations in
+// "(15 U.S.C. 6502)" form, so that the Recognizer reads amendment
+// instructions from markup boundaries instead of line layout.
+//
+// Like Parse, it leaves each section's Amendments empty for the Recognizer
+// to populate.
+func ParseBillXML(reader io.Reader) (*DraftBill, error) {
+	root, err := readBillXMLTree(reader)
+	if err != nil {
+		return nil, err
+	}
+	if root.Name != "bill" && root.Name != "resolution" {
+		return nil, fmt.Errorf("not a bill XML document: root element is <%s>", root.Name)
+	}
+
+	bill := &DraftBill{Sections: []*DraftSection{}}
+	parseBillXMLMetadata(root, bill)
+	if bill.BillNumber == "" {
+		return nil, fmt.Errorf("no bill number found (expected <legis-num> or <docNumber>)")
+	}
+
+	body := root.find("legis-body", "resolution-body", "main")
+	if body == nil {
+		return nil, fmt.Errorf("no bill body found (expected <legis-body> or <main>)")
+	}
+
+	var rawText strings.Builder
+	for _, sectionNode := range body.sections() {
+		section := &DraftSection{
+			Number:     sectionDesignation(sectionNode),
+			Title:      strings.TrimRight(strings.ToUpper(sectionNode.heading()), "."),
+			Amendments: []Amendment{},
+		}
+
+		writer := &billTextWriter{}
+		for _, child := range sectionNode.Children {
+			if child.Name == "enum" || child.Name == "num" || child.Name == "header" || child.Name == "heading" {
+				continue
+			}
+			writer.render(child)
+		}
+		section.RawText = writer.String()
+		bill.Sections = append(bill.Sections, section)
+
+		fmt.Fprintf(&rawText, "SEC. %s. %s.\n%s\n\n", section.Number, section.Title, section.RawText)
+	}
+	bill.RawText = strings.TrimSpace(rawText.String())
+
+	if bill.ShortTitle == "" && len(bill.Sections) > 0 {
+		bill.ShortTitle = NewParser().extractShortTitle(bill.Sections[0].RawText)
+	}
+	return bill, nil
+}
+
+// ParseBillXMLFromFile reads a bill XML file.
+func ParseBillXMLFromFile(path string) (*DraftBill, error) {
+	file, openErr := os.Open(path)
+	if openErr != nil {
+		return nil, fmt.Errorf("opening file %s: %w", path, openErr)
+	}
+	defer file.Close()
+
+	bill, parseErr := ParseBillXML(file)
+	if parseErr != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, parseErr)
+	}
+	bill.Filename = path
+	return bill, nil
+}
+
+// readBillXMLTree decodes a document into a billXMLNode tree, dropping
+// namespaces.
+func readBillXMLTree(reader io.Reader) (*billXMLNode, error) {
+	decoder := xml.NewDecoder(reader)
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	var stack []*billXMLNode
+	var root *billXMLNode
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading bill XML: %w", err)
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			node := &billXMLNode{Name: element.Name.Local, Attrs: make(map[string]string, len(element.Attr))}
+			for _, attr := range element.Attr {
+				node.Attrs[attr.Name.Local] = attr.Value
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, node)
+			} else if root == nil {
+				root = node
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, &billXMLNode{Text: string(element)})
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("empty input: no XML elements")
+	}
+	return root, nil
+}
+
+// parseBillXMLMetadata fills the bill number, Congress, session, and titles
+// from <form> (bill DTD) or <meta> and <preface> (USLM).
+func parseBillXMLMetadata(root *billXMLNode, bill *DraftBill) {
+	if form := root.find("form"); form != nil {
+		if legisNum := form.find("legis-num"); legisNum != nil {
+			bill.BillNumber = normalizeBillNumber(legisNum.text())
+		}
+		bill.Congress = ordinalPrefix(form.find("congress"))
+		bill.Session = ordinalPrefix(form.find("session"))
+		if title := form.find("official-title"); title != nil {
+			bill.Title = title.text()
+		}
+	}
+
+	if preface := root.find("preface"); preface != nil {
+		if bill.BillNumber == "" {
+			if docNumber := preface.find("docNumber"); docNumber != nil {
+				designation := ""
+				if docType := preface.find("type"); docType != nil {
+					designation = docType.text()
+				}
+				bill.BillNumber = normalizeBillNumber(designation + " " + docNumber.text())
+			}
+		}
+		if bill.Congress == "" {
+			bill.Congress = ordinalPrefix(preface.find("congress"))
+		}
+		if bill.Session == "" {
+			bill.Session = ordinalPrefix(preface.find("session"))
+		}
+		if title := preface.find("officialTitle"); title != nil && bill.Title == "" {
+			bill.Title = title.text()
+		}
+	}
+
+	if meta := root.find("meta"); meta != nil {
+		if bill.BillNumber == "" {
+			if docNumber := meta.find("docNumber"); docNumber != nil {
+				designation := "H.R."
+				if docType := meta.find("type"); docType != nil && strings.Contains(strings.ToLower(docType.text()), "senate") {
+					designation = "S."
+				}
+				bill.BillNumber = normalizeBillNumber(designation + docNumber.text())
+			}
+		}
+		if bill.Congress == "" {
+			bill.Congress = ordinalPrefix(meta.find("congress"))
+		}
+		if bill.Session == "" {
+			bill.Session = ordinalPrefix(meta.find("session"))
+		}
+	}
+
+	if bill.Title == "" {
+		if title := root.find("officialTitle", "official-title"); title != nil {
+			bill.Title = title.text()
+		}
+	}
+	if shortTitle := root.find("short-title", "shortTitle"); shortTitle != nil {
+		bill.ShortTitle = shortTitle.text()
+	}
+}
+
+// ordinalPrefix reads "118th CONGRESS" or "118" as "118th".
+func ordinalPrefix(node *billXMLNode) string {
+	if node == nil {
+		return ""
+	}
+	match := billXMLOrdinalPattern.FindStringSubmatch(node.text())
+	if match == nil {
+		return ""
+	}
+	if match[2] != "" {
+		return match[1] + strings.ToLower(match[2])
+	}
+	return match[1] + ordinalSuffix(match[1])
+}
+
+// ordinalSuffix returns "st", "nd", "rd", or "th" for a number.
+func ordinalSuffix(number string) string {
+	if len(number) >= 2 && number[len(number)-2] == '1' {
+		return "th"
+	}
+	switch number[len(number)-1] {
+	case '1':
+		return "st"
+	case '2':
+		return "nd"
+	case '3':
+		return "rd"
+	}
+	return "th"
+}
+
+// find returns the first descendant with one of the given names, searching
+// depth first.
+func (node *billXMLNode) find(names ...string) *billXMLNode {
+	for _, child := range node.Children {
+		for _, name := range names {
+			if child.Name == name {
+				return child
+			}
+		}
+		if found := child.find(names...); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// text returns the element's text content with whitespace collapsed.
+func (node *billXMLNode) text() string {
+	var builder strings.Builder
+	node.appendText(&builder)
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
+
+func (node *billXMLNode) appendText(builder *strings.Builder) {
+	if node.Name == "" {
+		builder.WriteString(node.Text)
+		return
+	}
+	for _, child := range node.Children {
+		child.appendText(builder)
+	}
+}
+
+// sections returns the bill's own sections, looking through titles and
+// divisions but not into quoted blocks or nested sections.
+func (node *billXMLNode) sections() []*billXMLNode {
+	var sections []*billXMLNode
+	for _, child := range node.Children {
+		switch {
+		case child.Name == "section":
+			sections = append(sections, child)
+		case child.Name == "quoted-block" || child.Name == "quotedContent" || billXMLSkippedElements[child.Name]:
+		case child.Name != "":
+			sections = append(sections, child.sections()...)
+		}
+	}
+	return sections
+}
+
+// designation returns the provision's enumerator: "(a)", "(1)", "5.".
+func (node *billXMLNode) designation() string {
+	for _, child := range node.Children {
+		if child.Name == "enum" || child.Name == "num" {
+			return child.text()
+		}
+	}
+	return ""
+}
+
+// heading returns the provision's header.
+func (node *billXMLNode) heading() string {
+	for _, child := range node.Children {
+		if child.Name == "header" || child.Name == "heading" {
+			return child.text()
+		}
+	}
+	return ""
+}
+
+// sectionDesignation returns a section's number without "SEC." or the
+// trailing period: "SEC. 45b." and <num value="45b"> both give "45b".
+func sectionDesignation(section *billXMLNode) string {
+	for _, child := range section.Children {
+		if child.Name == "num" && child.Attrs["value"] != "" {
+			return child.Attrs["value"]
+		}
+	}
+	return strings.TrimRight(billXMLSectionPrefix.ReplaceAllString(section.designation(), ""), ". ")
+}
+
+// uscCitation returns the "(15 U.S.C. 6502)" form of a citation element's
+// parsable-cite (bill DTD) or href (USLM), or "" when it does not cite a
+// section of the Code.
+func uscCitation(citation *billXMLNode) string {
+	match := billXMLDTDCitePattern.FindStringSubmatch(citation.Attrs["parsable-cite"])
+	if match == nil {
+		match = billXMLUSLMRefPattern.FindStringSubmatch(citation.Attrs["href"])
+	}
+	if match == nil {
+		return ""
+	}
+
+	cite := match[1] + " U.S.C. " + match[2]
+	for _, designation := range strings.Split(strings.Trim(match[3], "/"), "/") {
+		if designation != "" {
+			cite += "(" + designation + ")"
+		}
+	}
+	return "(" + cite + ")"
+}
+
+// billTextWriter renders bill markup as text lines in the layout the
+// Recognizer reads: one provision per line, inserted law in quotation
+// marks.
+type billTextWriter struct {
+	lines      []string
+	current    strings.Builder
+	quoteDepth int
+}
+
+// String returns the rendered lines.
+func (writer *billTextWriter) String() string {
+	writer.newLine()
+	return strings.TrimSpace(strings.Join(writer.lines, "\n"))
+}
+
+// write appends inline text, opening the line with a quotation mark inside
+// quoted blocks.
+func (writer *billTextWriter) write(text string) {
+	if writer.current.Len() == 0 {
+		text = strings.TrimLeft(text, " ")
+		if text == "" {
+			return
+		}
+		if writer.quoteDepth > 0 {
+			writer.current.WriteString(`"`)
+		}
+	}
+	writer.current.WriteString(text)
+}
+
+// newLine ends the current line.
+func (writer *billTextWriter) newLine() {
+	if writer.current.Len() == 0 {
+		return
+	}
+	writer.lines = append(writer.lines, strings.TrimRight(writer.current.String(), " "))
+	writer.current.Reset()
+}
+
+// reopen continues the last line, so that text following a quoted block
+// ("; and") joins its closing quotation mark.
+func (writer *billTextWriter) reopen() {
+	if writer.current.Len() > 0 || len(writer.lines) == 0 {
+		return
+	}
+	writer.current.WriteString(writer.lines[len(writer.lines)-1])
+	writer.lines = writer.lines[:len(writer.lines)-1]
+}
+
+// render writes a node and its content.
+func (writer *billTextWriter) render(node *billXMLNode) {
+	switch {
+	case node.Name == "":
+		writer.write(billXMLWhitespace.ReplaceAllString(node.Text, " "))
+	case billXMLSkippedElements[node.Name]:
+	case billXMLProvisionElements[node.Name]:
+		writer.renderProvision(node)
+	case node.Name == "quoted-block" || node.Name == "quotedContent":
+		writer.renderQuotedBlock(node)
+	case node.Name == "quote" || node.Name == "quotedText":
+		writer.write(`"` + node.text() + `"`)
+	case node.Name == "external-xref" || node.Name == "ref":
+		text := node.text()
+		if citation := uscCitation(node); citation != "" && !strings.Contains(text, "U.S.C.") {
+			text += " " + citation
+		}
+		writer.write(text)
+	case node.Name == "toc-entry":
+		writer.newLine()
+		writer.write(node.text())
+		writer.newLine()
+	case node.Name == "continuation" || node.Name == "proviso":
+		writer.newLine()
+		writer.renderChildren(node)
+	default:
+		writer.renderChildren(node)
+	}
+}
+
+func (writer *billTextWriter) renderChildren(node *billXMLNode) {
+	for _, child := range node.Children {
+		writer.render(child)
+	}
+}
+
+// renderProvision writes "(a) HEADER.—text" on a new line, or
+// "SEC. 5. HEADER." for a section inside a quoted block, then its
+// subordinate provisions.
+func (writer *billTextWriter) renderProvision(node *billXMLNode) {
+	writer.newLine()
+	heading := strings.ToUpper(strings.TrimRight(node.heading(), "."))
+
+	if node.Name == "section" {
+		writer.write("SEC. " + sectionDesignation(node) + ".")
+		if heading != "" {
+			writer.write(" " + heading + ".")
+		}
+		writer.newLine()
+	} else {
+		if designation := node.designation(); designation != "" {
+			writer.write(designation + " ")
+		}
+		if heading != "" {
+			writer.write(heading + ".—")
+		}
+	}
+
+	for _, child := range node.Children {
+		if child.Name == "enum" || child.Name == "num" || child.Name == "header" || child.Name == "heading" {
+			continue
+		}
+		writer.render(child)
+	}
+}
+
+// renderQuotedBlock writes inserted law in quotation marks, closing the
+// last line with a quotation mark and the <after-quoted-block> text.
+func (writer *billTextWriter) renderQuotedBlock(node *billXMLNode) {
+	writer.newLine()
+	writer.quoteDepth++
+	afterText := ""
+	for _, child := range node.Children {
+		if child.Name == "after-quoted-block" {
+			afterText = child.text()
+			continue
+		}
+		writer.render(child)
+	}
+	writer.newLine()
+	writer.quoteDepth--
+
+	writer.reopen()
+	writer.current.WriteString(`"` + afterText)
+}
@@ -0,0 +1,254 @@
+package draft
+
+import (
+	"strings"
+	"testing"
+)
+
+const uslmBillSample = `<?xml version="1.0" encoding="UTF-8"?>
+<bill xmlns="http://schemas.gpo.gov/xml/uslm" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<meta>
+<dc:title>118 S 456 IS</dc:title>
+<docNumber>456</docNumber>
+<congress>118</congress>
+<session>2</session>
+</meta>
+<preface>
+<congress>118th CONGRESS</congress>
+<session>2d Session</session>
+<dc:type>S.</dc:type> <docNumber>456</docNumber>
+<officialTitle>To amend the Clean Air Act to update emission reporting.</officialTitle>
+</preface>
+<main>
+<longTitle><docTitle>A BILL</docTitle></longTitle>
+<section identifier="/us/bill/118/s/456/s1"><num value="1">SECTION 1. </num><heading>Short title</heading><content>This Act may be cited as the <quotedText><shortTitle>Emission Reporting Update Act</shortTitle></quotedText>.</content></section>
+<section identifier="/us/bill/118/s/456/s2"><num value="2">SEC. 2. </num><heading>Reporting threshold</heading><content>Section 114(a) of the Clean Air Act (<ref href="/us/usc/t42/s7414/a">42 U.S.C. 7414(a)</ref>) is amended by striking <quotedText>25,000</quotedText> and inserting <quotedText>10,000</quotedText>.</content></section>
+<section identifier="/us/bill/118/s/456/s3"><num value="3">SEC. 3. </num><heading>Monitoring</heading><chapeau>Section 114 of the Clean Air Act (<ref href="/us/usc/t42/s7414">42 U.S.C. 7414</ref>) is amended by adding at the end the following:</chapeau>
+<quotedContent><subsection><num value="d">(d)</num><heading>Continuous monitoring</heading><content>The Administrator shall require continuous monitoring of covered sources.</content></subsection></quotedContent><inline>.</inline></section>
+</main>
+</bill>`
+
+func TestIsBillXML(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"hr1234.xml", true},
+		{"BILLS-118hr1234ih.XML", true},
+		{"hr1234.txt", false},
+		{"hr1234", false},
+	}
+	for _, tt := range tests {
+		if got := IsBillXML(tt.path); got != tt.want {
+			t.Errorf("IsBillXML(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParseBillXMLMatchesPlainText(t *testing.T) {
+	xmlBill, err := ParseBillXMLFromFile(testdataPath("hr1234.xml"))
+	if err != nil {
+		t.Fatalf("ParseBillXMLFromFile failed: %v", err)
+	}
+	textBill, err := ParseBillFromFile(testdataPath("hr1234.txt"))
+	if err != nil {
+		t.Fatalf("ParseBillFromFile failed: %v", err)
+	}
+
+	if xmlBill.BillNumber != textBill.BillNumber {
+		t.Errorf("BillNumber = %q, want %q", xmlBill.BillNumber, textBill.BillNumber)
+	}
+	if xmlBill.Congress != textBill.Congress {
+		t.Errorf("Congress = %q, want %q", xmlBill.Congress, textBill.Congress)
+	}
+	if xmlBill.Session != textBill.Session {
+		t.Errorf("Session = %q, want %q", xmlBill.Session, textBill.Session)
+	}
+	if xmlBill.ShortTitle != textBill.ShortTitle {
+		t.Errorf("ShortTitle = %q, want %q", xmlBill.ShortTitle, textBill.ShortTitle)
+	}
+	if !strings.Contains(xmlBill.Title, "amend title 42") {
+		t.Errorf("Title should contain 'amend title 42', got %q", xmlBill.Title)
+	}
+	if xmlBill.Filename != testdataPath("hr1234.xml") {
+		t.Errorf("Filename = %q, want %q", xmlBill.Filename, testdataPath("hr1234.xml"))
+	}
+
+	if len(xmlBill.Sections) != len(textBill.Sections) {
+		t.Fatalf("Sections = %d, want %d", len(xmlBill.Sections), len(textBill.Sections))
+	}
+	for i, section := range xmlBill.Sections {
+		want := textBill.Sections[i]
+		if section.Number != want.Number {
+			t.Errorf("Section[%d].Number = %q, want %q", i, section.Number, want.Number)
+		}
+		if section.Title != want.Title {
+			t.Errorf("Section[%d].Title = %q, want %q", i, section.Title, want.Title)
+		}
+		if section.Amendments == nil || len(section.Amendments) != 0 {
+			t.Errorf("Section[%d].Amendments should be initialized empty", i)
+		}
+	}
+}
+
+func TestParseBillXMLAmendments(t *testing.T) {
+	bill, err := ParseBillXMLFromFile(testdataPath("hr1234.xml"))
+	if err != nil {
+		t.Fatalf("ParseBillXMLFromFile failed: %v", err)
+	}
+
+	recognizer := NewRecognizer()
+	var amendments []Amendment
+	for _, section := range bill.Sections {
+		found, extractErr := recognizer.ExtractAmendments(section.RawText)
+		if extractErr != nil {
+			t.Fatalf("ExtractAmendments(section %s) failed: %v", section.Number, extractErr)
+		}
+		amendments = append(amendments, found...)
+	}
+
+	want := []struct {
+		amendmentType AmendmentType
+		section       string
+		subsection    string
+		strike        string
+		insert        string
+	}{
+		{AmendStrikeInsert, "6502", "(b)(1)", "13", "16"},
+		{AmendAddAtEnd, "6502", "(b)(2)", "", "(C) PROHIBITION ON TARGETED ADVERTISING"},
+		{AmendAddAtEnd, "6502", "", "", "(e) DATA MINIMIZATION"},
+		{AmendStrikeInsert, "6505", "(d)", "$50,000", "$100,000"},
+	}
+	if len(amendments) != len(want) {
+		t.Fatalf("amendments = %d, want %d", len(amendments), len(want))
+	}
+	for i, w := range want {
+		got := amendments[i]
+		if got.Type != w.amendmentType {
+			t.Errorf("amendment[%d].Type = %v, want %v", i, got.Type, w.amendmentType)
+		}
+		if got.TargetTitle != "15" {
+			t.Errorf("amendment[%d].TargetTitle = %q, want %q", i, got.TargetTitle, "15")
+		}
+		if got.TargetSection != w.section {
+			t.Errorf("amendment[%d].TargetSection = %q, want %q", i, got.TargetSection, w.section)
+		}
+		if got.TargetSubsection != w.subsection {
+			t.Errorf("amendment[%d].TargetSubsection = %q, want %q", i, got.TargetSubsection, w.subsection)
+		}
+		if got.StrikeText != w.strike {
+			t.Errorf("amendment[%d].StrikeText = %q, want %q", i, got.StrikeText, w.strike)
+		}
+		if !strings.HasPrefix(got.InsertText, w.insert) {
+			t.Errorf("amendment[%d].InsertText = %q, want prefix %q", i, got.InsertText, w.insert)
+		}
+	}
+}
+
+func TestParseBillXMLRendersQuotedBlocks(t *testing.T) {
+	bill, err := ParseBillXMLFromFile(testdataPath("hr1234.xml"))
+	if err != nil {
+		t.Fatalf("ParseBillXMLFromFile failed: %v", err)
+	}
+
+	rawText := bill.Sections[2].RawText
+	for _, line := range []string{
+		`(A) in paragraph (1), by striking "13" and inserting "16";`,
+		`"(e) DATA MINIMIZATION.—An operator shall—`,
+		`"(2) delete personal information collected from a child after a reasonable period not to exceed 2 years.".`,
+		`(15 U.S.C. 6502) is amended—`,
+	} {
+		if !strings.Contains(rawText, line) {
+			t.Errorf("section 3 RawText missing %q:\n%s", line, rawText)
+		}
+	}
+	if !strings.Contains(bill.RawText, "SEC. 3. ENHANCED PROTECTIONS FOR CHILDREN'S ONLINE PRIVACY.") {
+		t.Error("bill RawText should contain the section 3 heading")
+	}
+}
+
+func TestParseBillXMLUSLM(t *testing.T) {
+	bill, err := ParseBillXML(strings.NewReader(uslmBillSample))
+	if err != nil {
+		t.Fatalf("ParseBillXML failed: %v", err)
+	}
+
+	if bill.BillNumber != "S. 456" {
+		t.Errorf("BillNumber = %q, want %q", bill.BillNumber, "S. 456")
+	}
+	if bill.Congress != "118th" {
+		t.Errorf("Congress = %q, want %q", bill.Congress, "118th")
+	}
+	if bill.Session != "2nd" {
+		t.Errorf("Session = %q, want %q", bill.Session, "2nd")
+	}
+	if bill.ShortTitle != "Emission Reporting Update Act" {
+		t.Errorf("ShortTitle = %q, want %q", bill.ShortTitle, "Emission Reporting Update Act")
+	}
+	if len(bill.Sections) != 3 {
+		t.Fatalf("Sections = %d, want 3", len(bill.Sections))
+	}
+	if bill.Sections[1].Number != "2" || bill.Sections[1].Title != "REPORTING THRESHOLD" {
+		t.Errorf("Section[1] = %s %q, want 2 %q", bill.Sections[1].Number, bill.Sections[1].Title, "REPORTING THRESHOLD")
+	}
+
+	recognizer := NewRecognizer()
+	strike, err := recognizer.ExtractAmendments(bill.Sections[1].RawText)
+	if err != nil {
+		t.Fatalf("ExtractAmendments failed: %v", err)
+	}
+	if len(strike) != 1 {
+		t.Fatalf("section 2 amendments = %d, want 1:\n%s", len(strike), bill.Sections[1].RawText)
+	}
+	if strike[0].Type != AmendStrikeInsert || strike[0].TargetTitle != "42" || strike[0].TargetSection != "7414" {
+		t.Errorf("section 2 amendment = %v 42 U.S.C. %s, want strike_insert 42 U.S.C. 7414", strike[0].Type, strike[0].TargetSection)
+	}
+	if strike[0].StrikeText != "25,000" || strike[0].InsertText != "10,000" {
+		t.Errorf("section 2 strike/insert = %q/%q, want %q/%q", strike[0].StrikeText, strike[0].InsertText, "25,000", "10,000")
+	}
+
+	added, err := recognizer.ExtractAmendments(bill.Sections[2].RawText)
+	if err != nil {
+		t.Fatalf("ExtractAmendments failed: %v", err)
+	}
+	if len(added) != 1 || added[0].Type != AmendAddAtEnd {
+		t.Fatalf("section 3 amendments = %v, want one add_at_end:\n%s", added, bill.Sections[2].RawText)
+	}
+	if !strings.HasPrefix(added[0].InsertText, "(d) CONTINUOUS MONITORING") {
+		t.Errorf("section 3 InsertText = %q, want prefix %q", added[0].InsertText, "(d) CONTINUOUS MONITORING")
+	}
+}
+
+func TestParseBillXMLErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"empty", "", "no XML elements"},
+		{"not a bill", `<lawDoc><main/></lawDoc>`, "root element is <lawDoc>"},
+		{"no number", `<bill><form><congress>118th CONGRESS</congress></form><legis-body/></bill>`, "no bill number"},
+		{"no body", `<bill><form><legis-num>H. R. 1</legis-num></form></bill>`, "no bill body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBillXML(strings.NewReader(tt.input))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %q, want it to contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseBillXMLFromFileMissing(t *testing.T) {
+	_, err := ParseBillXMLFromFile(testdataPath("nonexistent.xml"))
+	if err == nil {
+		t.Fatal("expected error for missing file")
+	}
+	if !strings.Contains(err.Error(), "opening file") {
+		t.Errorf("error = %q, want it to contain %q", err.Error(), "opening file")
+	}
+}
@@ -158,7 +158,7 @@ func ResolveAmendmentTarget(amendment Amendment, lib *library.Library) (targetUR
 		return "", "", fmt.Errorf("document %s not found in library", documentID)
 	}
 
-	baseURI := lib.BaseURI()
+	baseURI := lib.DocumentBaseURI(documentID)
 	if baseURI == "" {
 		baseURI = defaultBaseURI
 	}
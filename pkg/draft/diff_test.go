@@ -35,12 +35,14 @@ func testLibrary(t *testing.T, documentID string, triples []store.Triple) (*libr
 		t.Fatalf("failed to serialize triples: %v", err)
 	}
 
-	// Add document entry via the library's normal flow with minimal source text
+	// Add document entry via the library's normal flow with minimal source text.
+	// The test triples are minted under the shared library base URI.
 	sourceText := []byte("placeholder source text for test")
 	_, err = lib.AddDocument(documentID, sourceText, library.AddOptions{
 		Name:         documentID,
 		Jurisdiction: "US",
 		Format:       "us",
+		BaseURI:      baseURI,
 		Force:        true,
 	})
 	if err != nil {
@@ -53,7 +55,7 @@ func testLibrary(t *testing.T, documentID string, triples []store.Triple) (*libr
 		t.Fatalf("document entry not found after add")
 	}
 
-	triplesPath := filepath.Join(libraryPath, "documents", entry.StorageHash, "triples.json")
+	triplesPath := filepath.Join(libraryPath, "documents", entry.StorageHash, entry.StorageFormat.FileName())
 	if err := os.WriteFile(triplesPath, triplesData, 0644); err != nil {
 		t.Fatalf("failed to write triples: %v", err)
 	}
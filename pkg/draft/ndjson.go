@@ -0,0 +1,183 @@
+package draft
+
+import (
+	"io"
+
+	"github.com/coolbeans/regula/pkg/ndjson"
+)
+
+// WriteNDJSON writes the bill to w as newline-delimited JSON: one "section"
+// record per section, followed by a "summary" record with the bill metadata
+// and statistics.
+func (bill *DraftBill) WriteNDJSON(w io.Writer) error {
+	encoder := ndjson.NewEncoder(w)
+	for _, section := range bill.Sections {
+		if err := encoder.EncodeRecord("section", section); err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeRecord("summary", struct {
+		Filename   string         `json:"filename,omitempty"`
+		Title      string         `json:"title"`
+		ShortTitle string         `json:"short_title,omitempty"`
+		BillNumber string         `json:"bill_number"`
+		Congress   string         `json:"congress,omitempty"`
+		Session    string         `json:"session,omitempty"`
+		Statistics BillStatistics `json:"statistics"`
+	}{bill.Filename, bill.Title, bill.ShortTitle, bill.BillNumber, bill.Congress, bill.Session, bill.Statistics()})
+}
+
+// WriteNDJSON writes the diff to w as newline-delimited JSON: one record per
+// entry, kind "added", "removed", "modified", or "redesignated", one
+// "unresolved" record per unresolved target, then a "summary" record.
+func (d *DraftDiff) WriteNDJSON(w io.Writer) error {
+	encoder := ndjson.NewEncoder(w)
+	groups := []struct {
+		kind    string
+		entries []DiffEntry
+	}{
+		{"added", d.Added},
+		{"removed", d.Removed},
+		{"modified", d.Modified},
+		{"redesignated", d.Redesignated},
+	}
+	for _, group := range groups {
+		for _, entry := range group.entries {
+			if err := encoder.EncodeRecord(group.kind, entry); err != nil {
+				return err
+			}
+		}
+	}
+	for _, target := range d.UnresolvedTargets {
+		if err := encoder.EncodeRecord("unresolved", struct {
+			Target string `json:"target"`
+		}{target}); err != nil {
+			return err
+		}
+	}
+
+	var billNumber string
+	if d.Bill != nil {
+		billNumber = d.Bill.BillNumber
+	}
+	return encoder.EncodeRecord("summary", struct {
+		BillNumber         string `json:"bill_number"`
+		Added              int    `json:"added"`
+		Removed            int    `json:"removed"`
+		Modified           int    `json:"modified"`
+		Redesignated       int    `json:"redesignated"`
+		Unresolved         int    `json:"unresolved"`
+		TriplesInvalidated int    `json:"triples_invalidated"`
+	}{billNumber, len(d.Added), len(d.Removed), len(d.Modified), len(d.Redesignated), len(d.UnresolvedTargets), d.TriplesInvalidated})
+}
+
+// WriteNDJSON writes the impact result to w as newline-delimited JSON: one
+// "affected" record per directly then transitively affected provision, one
+// "broken_reference" record per broken cross-reference, then a "summary"
+// record with the obligation and rights changes.
+func (r *DraftImpactResult) WriteNDJSON(w io.Writer) error {
+	encoder := ndjson.NewEncoder(w)
+	for _, provisions := range [][]AffectedProvision{r.DirectlyAffected, r.TransitivelyAffected} {
+		for _, provision := range provisions {
+			if err := encoder.EncodeRecord("affected", provision); err != nil {
+				return err
+			}
+		}
+	}
+	for _, broken := range r.BrokenCrossRefs {
+		if err := encoder.EncodeRecord("broken_reference", broken); err != nil {
+			return err
+		}
+	}
+
+	var billNumber string
+	if r.Bill != nil {
+		billNumber = r.Bill.BillNumber
+	}
+	return encoder.EncodeRecord("summary", struct {
+		BillNumber              string          `json:"bill_number"`
+		DirectlyAffected        int             `json:"directly_affected"`
+		TransitivelyAffected    int             `json:"transitively_affected"`
+		BrokenCrossRefs         int             `json:"broken_cross_refs"`
+		ObligationChanges       ObligationDelta `json:"obligation_changes"`
+		RightsChanges           RightsDelta     `json:"rights_changes"`
+		TotalProvisionsAffected int             `json:"total_provisions_affected"`
+		MaxDepthReached         int             `json:"max_depth_reached"`
+	}{billNumber, len(r.DirectlyAffected), len(r.TransitivelyAffected), len(r.BrokenCrossRefs),
+		r.ObligationChanges, r.RightsChanges, r.TotalProvisionsAffected, r.MaxDepthReached})
+}
+
+// WriteNDJSON writes the comparison to w as newline-delimited JSON: one
+// record per provision difference, kind "newly_applicable",
+// "no_longer_applicable", or "changed_relevance", then a "summary" record.
+func (c *ScenarioComparison) WriteNDJSON(w io.Writer) error {
+	encoder := ndjson.NewEncoder(w)
+	groups := []struct {
+		kind  string
+		diffs []ProvisionDiff
+	}{
+		{"newly_applicable", c.NewlyApplicable},
+		{"no_longer_applicable", c.NoLongerApplicable},
+		{"changed_relevance", c.ChangedRelevance},
+	}
+	for _, group := range groups {
+		for _, diff := range group.diffs {
+			if err := encoder.EncodeRecord(group.kind, diff); err != nil {
+				return err
+			}
+		}
+	}
+	return encoder.EncodeRecord("summary", struct {
+		Scenario string `json:"scenario"`
+		ComparisonSummary
+	}{c.Scenario, c.GetSummary()})
+}
+
+// WriteNDJSON writes the comparison to w as newline-delimited JSON: one
+// record per difference, kind "amendment_added", "amendment_removed",
+// "amendment_changed", "impact_new", "impact_resolved", "broken_ref_new",
+// "broken_ref_resolved", "conflict_new", "conflict_resolved",
+// "temporal_new", or "temporal_resolved", then a "summary" record.
+func (c *VersionComparison) WriteNDJSON(w io.Writer) error {
+	encoder := ndjson.NewEncoder(w)
+	groups := []struct {
+		kind  string
+		count int
+		item  func(int) any
+	}{
+		{"amendment_added", len(c.AddedAmendments), func(i int) any { return c.AddedAmendments[i] }},
+		{"amendment_removed", len(c.RemovedAmendments), func(i int) any { return c.RemovedAmendments[i] }},
+		{"amendment_changed", len(c.ChangedAmendments), func(i int) any { return c.ChangedAmendments[i] }},
+		{"impact_new", len(c.NewImpacts), func(i int) any { return c.NewImpacts[i] }},
+		{"impact_resolved", len(c.ResolvedImpacts), func(i int) any { return c.ResolvedImpacts[i] }},
+		{"broken_ref_new", len(c.NewBrokenRefs), func(i int) any { return c.NewBrokenRefs[i] }},
+		{"broken_ref_resolved", len(c.ResolvedBrokenRefs), func(i int) any { return c.ResolvedBrokenRefs[i] }},
+		{"conflict_new", len(c.NewConflicts), func(i int) any { return c.NewConflicts[i] }},
+		{"conflict_resolved", len(c.ResolvedConflicts), func(i int) any { return c.ResolvedConflicts[i] }},
+		{"temporal_new", len(c.NewTemporalFindings), func(i int) any { return c.NewTemporalFindings[i] }},
+		{"temporal_resolved", len(c.ResolvedTemporalFindings), func(i int) any { return c.ResolvedTemporalFindings[i] }},
+	}
+	for _, group := range groups {
+		for i := 0; i < group.count; i++ {
+			if err := encoder.EncodeRecord(group.kind, group.item(i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	var baseNumber, targetNumber string
+	if c.Base != nil {
+		baseNumber = c.Base.BillNumber
+	}
+	if c.Target != nil {
+		targetNumber = c.Target.BillNumber
+	}
+	return encoder.EncodeRecord("summary", struct {
+		Base                string    `json:"base"`
+		Target              string    `json:"target"`
+		BaseRisk            RiskLevel `json:"base_risk"`
+		TargetRisk          RiskLevel `json:"target_risk"`
+		UnchangedAmendments int       `json:"unchanged_amendments"`
+		HasChanges          bool      `json:"has_changes"`
+	}{baseNumber, targetNumber, c.BaseRisk, c.TargetRisk, c.UnchangedAmendments, c.HasChanges()})
+}
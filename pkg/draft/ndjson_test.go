@@ -0,0 +1,126 @@
+package draft
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// decodeNDJSON splits buf into lines and decodes each as a JSON object.
+func decodeNDJSON(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for i, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("line %d is not JSON: %v\n%s", i, err, line)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func recordKinds(records []map[string]any) []string {
+	kinds := make([]string, len(records))
+	for i, record := range records {
+		kinds[i], _ = record["record"].(string)
+	}
+	return kinds
+}
+
+func TestDraftBill_WriteNDJSON(t *testing.T) {
+	bill := &DraftBill{
+		Title:      "Consumer Data Act",
+		BillNumber: "H.R. 1234",
+		Sections: []*DraftSection{
+			{Number: "1", Title: "Short title"},
+			{Number: "2", Title: "Amendments", Amendments: []Amendment{{Type: AmendStrikeInsert, TargetTitle: "15"}}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := bill.WriteNDJSON(&buf); err != nil {
+		t.Fatalf("WriteNDJSON failed: %v", err)
+	}
+	records := decodeNDJSON(t, &buf)
+	if got := strings.Join(recordKinds(records), ","); got != "section,section,summary" {
+		t.Fatalf("record kinds = %s", got)
+	}
+	if records[1]["number"] != "2" {
+		t.Errorf("second section = %v", records[1])
+	}
+	statistics, _ := records[2]["statistics"].(map[string]any)
+	if records[2]["bill_number"] != "H.R. 1234" || statistics["amendment_count"] != float64(1) {
+		t.Errorf("summary = %v", records[2])
+	}
+}
+
+func TestDraftDiff_WriteNDJSON(t *testing.T) {
+	diff := &DraftDiff{
+		Bill:              &DraftBill{BillNumber: "H.R. 1234"},
+		Added:             []DiffEntry{{TargetURI: "urn:a"}},
+		Modified:          []DiffEntry{{TargetURI: "urn:m1"}, {TargetURI: "urn:m2"}},
+		UnresolvedTargets: []string{"15 U.S.C. 9999"},
+	}
+
+	var buf bytes.Buffer
+	if err := diff.WriteNDJSON(&buf); err != nil {
+		t.Fatalf("WriteNDJSON failed: %v", err)
+	}
+	records := decodeNDJSON(t, &buf)
+	if got := strings.Join(recordKinds(records), ","); got != "added,modified,modified,unresolved,summary" {
+		t.Fatalf("record kinds = %s", got)
+	}
+	if records[3]["target"] != "15 U.S.C. 9999" {
+		t.Errorf("unresolved record = %v", records[3])
+	}
+	if records[4]["modified"] != float64(2) || records[4]["unresolved"] != float64(1) {
+		t.Errorf("summary = %v", records[4])
+	}
+}
+
+func TestDraftImpactResult_WriteNDJSON(t *testing.T) {
+	impact := &DraftImpactResult{
+		DirectlyAffected:        []AffectedProvision{{URI: "urn:d", Depth: 1}},
+		TransitivelyAffected:    []AffectedProvision{{URI: "urn:t", Depth: 2}},
+		BrokenCrossRefs:         []BrokenReference{{SourceURI: "urn:s", TargetURI: "urn:d"}},
+		TotalProvisionsAffected: 2,
+		MaxDepthReached:         2,
+	}
+
+	var buf bytes.Buffer
+	if err := impact.WriteNDJSON(&buf); err != nil {
+		t.Fatalf("WriteNDJSON failed: %v", err)
+	}
+	records := decodeNDJSON(t, &buf)
+	if got := strings.Join(recordKinds(records), ","); got != "affected,affected,broken_reference,summary" {
+		t.Fatalf("record kinds = %s", got)
+	}
+	if records[1]["depth"] != float64(2) {
+		t.Errorf("transitive record = %v", records[1])
+	}
+	if records[3]["total_provisions_affected"] != float64(2) {
+		t.Errorf("summary = %v", records[3])
+	}
+}
+
+func TestScenarioComparison_WriteNDJSON(t *testing.T) {
+	comparison := &ScenarioComparison{
+		Scenario:           "consent_withdrawal",
+		NewlyApplicable:    []ProvisionDiff{{URI: "urn:new"}},
+		NoLongerApplicable: []ProvisionDiff{{URI: "urn:old"}},
+	}
+
+	var buf bytes.Buffer
+	if err := comparison.WriteNDJSON(&buf); err != nil {
+		t.Fatalf("WriteNDJSON failed: %v", err)
+	}
+	records := decodeNDJSON(t, &buf)
+	if got := strings.Join(recordKinds(records), ","); got != "newly_applicable,no_longer_applicable,summary" {
+		t.Fatalf("record kinds = %s", got)
+	}
+	if records[2]["scenario"] != "consent_withdrawal" || records[2]["has_differences"] != true {
+		t.Errorf("summary = %v", records[2])
+	}
+}
@@ -0,0 +1,161 @@
+package draft
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// OverlayFileVersion is the version of the overlay file format.
+const OverlayFileVersion = "1.0.0"
+
+// OverlayFile is a draft overlay saved to disk: the proposed-law graph of
+// each document a bill amends, and the patch each amendment made to it.
+// Loaded later, its graphs stand in for the library's to query the law as
+// if the bill had passed.
+type OverlayFile struct {
+	Version     string       `json:"version"`
+	BillNumber  string       `json:"bill_number,omitempty"`
+	BillTitle   string       `json:"bill_title,omitempty"`
+	LibraryPath string       `json:"library_path"`
+	CreatedAt   time.Time    `json:"created_at"`
+	Stats       OverlayStats `json:"stats"`
+
+	// Documents maps each amended document's ID to its proposed graph.
+	Documents map[string][]library.SerializedTriple `json:"documents"`
+
+	Patches []AmendmentPatch   `json:"patches"`
+	Skipped []SkippedAmendment `json:"skipped,omitempty"`
+}
+
+// NewOverlayFile captures an overlay for saving. Patches are included when
+// the overlay was applied with OverlayOptions.RecordPatches.
+func NewOverlayFile(overlay *ScenarioOverlay, bill *DraftBill) *OverlayFile {
+	file := &OverlayFile{
+		Version:     OverlayFileVersion,
+		LibraryPath: overlay.BaseLibraryPath,
+		CreatedAt:   time.Now().UTC(),
+		Stats:       overlay.Stats,
+		Documents:   make(map[string][]library.SerializedTriple, len(overlay.DocumentStores)),
+		Patches:     overlay.Patches,
+		Skipped:     overlay.SkippedAmendments,
+	}
+	if bill != nil {
+		file.BillNumber, file.BillTitle = bill.BillNumber, bill.Title
+	}
+	if file.Patches == nil {
+		file.Patches = []AmendmentPatch{}
+	}
+	for documentID, tripleStore := range overlay.DocumentStores {
+		triples := make([]library.SerializedTriple, 0, tripleStore.Count())
+		for _, triple := range tripleStore.All() {
+			triples = append(triples, library.FromStoreTriple(triple))
+		}
+		sortSerializedTriples(triples)
+		file.Documents[documentID] = triples
+	}
+	return file
+}
+
+// LoadOverlayFile reads an overlay saved with Save.
+func LoadOverlayFile(path string) (*OverlayFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overlay: %w", err)
+	}
+	var file OverlayFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse overlay %s: %w", path, err)
+	}
+	if file.Version != OverlayFileVersion {
+		return nil, fmt.Errorf("overlay %s has version %q, want %s", path, file.Version, OverlayFileVersion)
+	}
+	return &file, nil
+}
+
+// Save writes the overlay as JSON.
+func (file *OverlayFile) Save(path string) error {
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal overlay: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write overlay: %w", err)
+	}
+	return nil
+}
+
+// DocumentIDs returns the IDs of the amended documents, sorted.
+func (file *OverlayFile) DocumentIDs() []string {
+	documentIDs := make([]string, 0, len(file.Documents))
+	for documentID := range file.Documents {
+		documentIDs = append(documentIDs, documentID)
+	}
+	sort.Strings(documentIDs)
+	return documentIDs
+}
+
+// Select keeps only the given documents and their patches.
+func (file *OverlayFile) Select(documentIDs ...string) error {
+	for _, documentID := range documentIDs {
+		if _, found := file.Documents[documentID]; !found {
+			return fmt.Errorf("document %s is not amended by the overlay (amended: %v)", documentID, file.DocumentIDs())
+		}
+	}
+	for documentID := range file.Documents {
+		if !slices.Contains(documentIDs, documentID) {
+			delete(file.Documents, documentID)
+		}
+	}
+	file.Patches = slices.DeleteFunc(file.Patches, func(patch AmendmentPatch) bool {
+		return !slices.Contains(documentIDs, patch.DocumentID)
+	})
+	return nil
+}
+
+// DocumentStore returns the proposed graph of one amended document.
+func (file *OverlayFile) DocumentStore(documentID string) (*store.TripleStore, error) {
+	triples, found := file.Documents[documentID]
+	if !found {
+		return nil, fmt.Errorf("document %s is not amended by the overlay", documentID)
+	}
+	tripleStore := store.NewTripleStore()
+	for _, triple := range triples {
+		if err := tripleStore.AddTriple(triple.ToStoreTriple()); err != nil {
+			return nil, fmt.Errorf("failed to load overlay graph of %s: %w", documentID, err)
+		}
+	}
+	return tripleStore, nil
+}
+
+// DocumentStores returns the proposed graph of every amended document,
+// keyed by document ID, as library.MergeOptions.Replacements expects.
+func (file *OverlayFile) DocumentStores() (map[string]*store.TripleStore, error) {
+	stores := make(map[string]*store.TripleStore, len(file.Documents))
+	for documentID := range file.Documents {
+		tripleStore, err := file.DocumentStore(documentID)
+		if err != nil {
+			return nil, err
+		}
+		stores[documentID] = tripleStore
+	}
+	return stores, nil
+}
+
+func sortSerializedTriples(triples []library.SerializedTriple) {
+	sort.Slice(triples, func(i, j int) bool {
+		if triples[i].Subject != triples[j].Subject {
+			return triples[i].Subject < triples[j].Subject
+		}
+		if triples[i].Predicate != triples[j].Predicate {
+			return triples[i].Predicate < triples[j].Predicate
+		}
+		return triples[i].Object < triples[j].Object
+	})
+}
@@ -0,0 +1,80 @@
+package draft
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/library"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func TestOverlayFileRoundTrip(t *testing.T) {
+	lib, libraryPath := testLibrary(t, "us-usc-title-15", buildScenarioTriples())
+	art6502URI := "https://regula.dev/regulations/US-USC-TITLE-15:Art6502"
+	art6503URI := "https://regula.dev/regulations/US-USC-TITLE-15:Art6503"
+	bill := &DraftBill{BillNumber: "H.R. 1234", Title: "Test Act"}
+	diff := &DraftDiff{
+		Bill: bill,
+		Removed: []DiffEntry{{
+			Amendment:        Amendment{Type: AmendRepeal, TargetTitle: "15", TargetSection: "6502"},
+			TargetURI:        art6502URI,
+			TargetDocumentID: "us-usc-title-15",
+		}},
+		Redesignated: []DiffEntry{{
+			Amendment:        Amendment{Type: AmendRedesignate, TargetTitle: "15", TargetSection: "6503", InsertText: "6504"},
+			TargetURI:        art6503URI,
+			TargetDocumentID: "us-usc-title-15",
+		}},
+	}
+
+	overlay, err := ApplyDraftOverlayWithOptions(diff, libraryPath, OverlayOptions{RecordPatches: true})
+	if err != nil {
+		t.Fatalf("ApplyDraftOverlayWithOptions failed: %v", err)
+	}
+	if len(overlay.Patches) != 2 {
+		t.Fatalf("patches = %d, want one per applied amendment", len(overlay.Patches))
+	}
+	repeal, redesignation := overlay.Patches[0], overlay.Patches[1]
+	if len(repeal.Removed) == 0 || len(repeal.Added) != 0 {
+		t.Errorf("repeal patch removed %d, added %d", len(repeal.Removed), len(repeal.Added))
+	}
+	if len(redesignation.Removed) != 1 || len(redesignation.Added) != 1 || redesignation.Added[0].Object != "6504" {
+		t.Errorf("redesignation patch = %+v", redesignation)
+	}
+
+	overlayPath := filepath.Join(t.TempDir(), "overlay.json")
+	if err := NewOverlayFile(overlay, bill).Save(overlayPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := LoadOverlayFile(overlayPath)
+	if err != nil {
+		t.Fatalf("LoadOverlayFile failed: %v", err)
+	}
+	if loaded.BillNumber != "H.R. 1234" || len(loaded.Patches) != 2 || len(loaded.DocumentIDs()) != 1 {
+		t.Errorf("loaded overlay = %+v", loaded)
+	}
+	proposed, err := loaded.DocumentStore("us-usc-title-15")
+	if err != nil {
+		t.Fatalf("DocumentStore failed: %v", err)
+	}
+	if proposed.Count() != overlay.OverlayStore.Count() || len(proposed.Find(art6502URI, "", "")) != 0 {
+		t.Errorf("proposed graph has %d triples, want %d without section 6502", proposed.Count(), overlay.OverlayStore.Count())
+	}
+
+	// Merged in place of the stored graph, the overlay queries as passed law.
+	replacements, err := loaded.DocumentStores()
+	if err != nil {
+		t.Fatalf("DocumentStores failed: %v", err)
+	}
+	merged, _, err := lib.MergeTripleStores(library.MergeOptions{Replacements: replacements}, "us-usc-title-15")
+	if err != nil {
+		t.Fatalf("MergeTripleStores failed: %v", err)
+	}
+	if len(merged.Find(art6502URI, "", "")) != 0 || merged.GetOne(art6503URI, store.PropNumber) != "6504" {
+		t.Error("merge did not use the proposed graph")
+	}
+
+	if err := loaded.Select("us-usc-title-42"); err == nil {
+		t.Error("Select accepted a document the overlay does not amend")
+	}
+}
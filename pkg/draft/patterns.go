@@ -3,7 +3,11 @@ package draft
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
+
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // Recognizer extracts structured amendment instructions from draft bill
@@ -18,23 +22,40 @@ type Recognizer struct {
 
 	// Amendment action patterns
 	strikeInsertPattern    *regexp.Regexp
+	insertTextPattern      *regexp.Regexp
 	repealPattern          *regexp.Regexp
 	addNewSectionPattern   *regexp.Regexp
 	addAtEndPattern        *regexp.Regexp
 	redesignatePattern     *regexp.Regexp
 	tableOfContentsPattern *regexp.Regexp
+	tableItemPattern       *regexp.Regexp
+	effectiveDatePattern   *regexp.Regexp
 
 	// Structural patterns
-	isAmendedPattern      *regexp.Regexp
-	numberedClausePattern *regexp.Regexp
-	letteredClausePattern *regexp.Regexp
-	inSubsectionPattern   *regexp.Regexp
-	paragraphRefPattern   *regexp.Regexp
+	isAmendedPattern         *regexp.Regexp
+	numberedClausePattern    *regexp.Regexp
+	letteredClausePattern    *regexp.Regexp
+	inSubsectionPattern      *regexp.Regexp
+	paragraphRefPattern      *regexp.Regexp
+	subsectionHeadingPattern *regexp.Regexp
 
 	// Text extraction
 	quotedTextPattern *regexp.Regexp
 }
 
+// provisionLevelPattern matches a provision level word, capturing it in
+// the singular ("paragraphs" captures "paragraph").
+const provisionLevelPattern = `(sub(?:section|paragraph|clause|item)|section|paragraph|clause|item)s?`
+
+// designationPattern matches a provision designation: "(3)", "(b)(2)",
+// "(a-1)", or a bare section number such as "45a".
+const designationPattern = `(?:\([a-zA-Z0-9-]+\))+|\d+[a-zA-Z]*(?:\([a-zA-Z0-9-]+\))*`
+
+// designationListPattern matches one or more designations joined by
+// commas, "and", or "through": "(c) through (e)", "(1), (2), and (3)".
+const designationListPattern = `(?:` + designationPattern + `)` +
+	`(?:(?:\s*,\s*(?:and\s+)?|\s+and\s+|\s+through\s+)(?:` + designationPattern + `))*`
+
 // numberedClause represents a numbered sub-item within an amendment block,
 // such as "(1) by striking..." or "(2) by adding at the end...".
 type numberedClause struct {
@@ -67,30 +88,53 @@ func NewRecognizer() *Recognizer {
 				`\s+and\s+inserting\s+` +
 				`["\x{201c}]([^"\x{201d}]+)["\x{201d}]`,
 		),
+		// "by inserting "X" after "Y"" or "inserting "X" before "Y""
+		insertTextPattern: regexp.MustCompile(
+			`(?i)(?:by\s+)?inserting\s+` +
+				`["\x{201c}]([^"\x{201d}]+)["\x{201d}]` +
+				`\s+(after|before)\s+` +
+				`["\x{201c}]([^"\x{201d}]+)["\x{201d}]`,
+		),
 		// "is repealed" or "is hereby repealed"
 		repealPattern: regexp.MustCompile(
 			`(?i)is\s+(?:hereby\s+)?repealed`,
 		),
-		// "inserting after section X the following new section/subsection"
+		// "inserting after paragraph (3) the following" or "inserting before
+		// section 5 the following new section"; "(as so redesignated)" may
+		// follow the anchor
 		addNewSectionPattern: regexp.MustCompile(
-			`(?i)(?:by\s+)?inserting\s+after\s+(?:section|subsection)\s+` +
-				`(\([a-zA-Z0-9]+\)|\d+[a-zA-Z]?)\s+the\s+following\s+new\s+(?:section|subsection)`,
+			`(?i)(?:by\s+)?inserting\s+(after|before)\s+` + provisionLevelPattern + `\s+` +
+				`(` + designationPattern + `)(?:\s+\(as\s+so\s+redesignated\))?\s+the\s+following`,
 		),
-		// "adding at the end the following"
+		// "adding at the end the following" or "adding at the end of
+		// subsection (c) the following"
 		addAtEndPattern: regexp.MustCompile(
-			`(?i)(?:by\s+)?adding\s+at\s+the\s+end\s+the\s+following`,
+			`(?i)(?:by\s+)?adding\s+at\s+the\s+end` +
+				`(?:\s+of\s+(?:such\s+)?` + provisionLevelPattern + `\s+(` + designationPattern + `))?` +
+				`(?:\s+thereof)?\s+the\s+following`,
 		),
-		// "redesignating paragraph (X) as paragraph (Y)"
+		// "redesignating paragraph (X) as paragraph (Y)" or "redesignating
+		// subsections (c) through (e) as subsections (d) through (f)"
 		redesignatePattern: regexp.MustCompile(
 			`(?i)(?:by\s+)?redesignating\s+` +
-				`(?:paragraph|subsection|section|subparagraph|clause)\s+` +
-				`\(([a-zA-Z0-9]+)\)\s+as\s+` +
-				`(?:paragraph|subsection|section|subparagraph|clause)\s+` +
-				`\(([a-zA-Z0-9]+)\)`,
+				provisionLevelPattern + `\s+(` + designationListPattern + `)\s+as\s+` +
+				provisionLevelPattern + `\s+(` + designationListPattern + `)`,
 		),
-		// "table of contents ... is amended"
+		// "table of contents ... is amended", also tables of sections and
+		// chapters
 		tableOfContentsPattern: regexp.MustCompile(
-			`(?i)table\s+of\s+contents\s+.{0,120}is\s+amended`,
+			`(?i)table\s+of\s+(?:contents|sections|chapters)\b.{0,160}?\bis\s+amended`,
+		),
+		// "striking the items relating to sections 312 and 313" or "after
+		// the item relating to section 523"
+		tableItemPattern: regexp.MustCompile(
+			`(?i)(striking|after|before)\s+the\s+items?\s+relating\s+to\s+` +
+				`((?:sections?|chapters?|subchapters?|parts?|subparts?|titles?)\s+` +
+				`[0-9A-Za-z]+(?:(?:\s*,\s*(?:and\s+)?|\s+and\s+|\s+through\s+)[0-9A-Za-z]+)*)`,
+		),
+		// "(c) EFFECTIVE DATE.--" subsection heading
+		effectiveDatePattern: regexp.MustCompile(
+			`(?m)^[ \t]*\([a-z]\)[ \t]+EFFECTIVE[ \t]+DATES?\b`,
 		),
 
 		// "is amended--" (em dash, double hyphen) or "is amended by"
@@ -98,13 +142,13 @@ func NewRecognizer() *Recognizer {
 		isAmendedPattern: regexp.MustCompile(
 			`(?i)is\s+amended\s*[\x{2014}\-]{1,2}|is\s+amended\s+by\b`,
 		),
-		// "(1) ", "(2) " etc. at clause boundaries (with leading whitespace)
+		// "(1) ", "(2) " etc. at clause boundaries, indented or flush left
 		numberedClausePattern: regexp.MustCompile(
-			`(?m)^\s+\((\d+)\)\s`,
+			`(?m)^[ \t]*\((\d+)\)\s`,
 		),
-		// "(A) ", "(B) " etc. at lettered sub-item boundaries (with leading whitespace)
+		// "(A) ", "(B) " etc. at lettered sub-item boundaries, indented or flush left
 		letteredClausePattern: regexp.MustCompile(
-			`(?m)^\s+\(([A-Z])\)\s`,
+			`(?m)^[ \t]*\(([A-Z])\)\s`,
 		),
 		// "in subsection (b)--" or "intes in subsection (b)--"
 		inSubsectionPattern: regexp.MustCompile(
@@ -114,6 +158,10 @@ func NewRecognizer() *Recognizer {
 		paragraphRefPattern: regexp.MustCompile(
 			`(?i)(?:in\s+)?paragraph\s+\((\d+[A-Za-z]*)\)`,
 		),
+		// "(c) REGULATIONS.--" or "(b) EFFECTIVE DATE.--" subsection heading
+		subsectionHeadingPattern: regexp.MustCompile(
+			`(?m)^[ \t]*\([a-z]\)[ \t]+[A-Z][A-Z ,'-]*\.`,
+		),
 
 		// Quoted text between straight or curly quotes
 		quotedTextPattern: regexp.MustCompile(
@@ -132,6 +180,9 @@ func (recognizer *Recognizer) ClassifyAmendmentType(text string) AmendmentType {
 	if recognizer.strikeInsertPattern.MatchString(normalizedText) {
 		return AmendStrikeInsert
 	}
+	if recognizer.insertTextPattern.MatchString(normalizedText) {
+		return AmendStrikeInsert
+	}
 	if recognizer.redesignatePattern.MatchString(normalizedText) {
 		return AmendRedesignate
 	}
@@ -201,21 +252,19 @@ func (recognizer *Recognizer) ParseTargetReference(text string) (title, section,
 func (recognizer *Recognizer) ExtractAmendments(sectionText string) ([]Amendment, error) {
 	var amendments []Amendment
 
+	// An effective-date subsection governs the section's amendments
+	// rather than making one of its own
+	sectionText, effectiveDate := recognizer.splitEffectiveDate(sectionText)
+
 	// Check for direct repeal pattern (no "is amended" anchor)
 	repealAmendments := recognizer.extractRepealAmendments(sectionText)
 	amendments = append(amendments, repealAmendments...)
 
 	// Find "is amended" anchors on the original text
 	anchorLocations := recognizer.isAmendedPattern.FindAllStringIndex(sectionText, -1)
-	if len(anchorLocations) == 0 {
-		if amendments == nil {
-			amendments = []Amendment{}
-		}
-		return amendments, nil
-	}
 
 	for anchorIndex, anchorLocation := range anchorLocations {
-		preambleText := sectionText[:anchorLocation[0]]
+		preambleStart := findPreambleStart(sectionText, anchorLocation[0])
 
 		// Determine the end of this amendment block
 		var blockEnd int
@@ -235,9 +284,20 @@ func (recognizer *Recognizer) ExtractAmendments(sectionText string) ([]Amendment
 
 		afterAnchorText := sectionText[anchorLocation[1]:blockEnd]
 
-		// Parse target reference from preamble
-		targetTitle, targetSection, targetSubsection, targetErr := recognizer.ParseTargetReference(preambleText)
+		// Parse target reference from this anchor's preamble, falling back
+		// to the section text before it when the preamble names no title
+		targetTitle, targetSection, targetSubsection, targetErr := recognizer.ParseTargetReference(sectionText[preambleStart:anchorLocation[0]])
 		if targetErr != nil {
+			targetTitle, targetSection, targetSubsection, targetErr = recognizer.ParseTargetReference(sectionText[:anchorLocation[0]])
+		}
+		if targetErr != nil {
+			continue
+		}
+
+		// "The table of sections for chapter 5 is amended by striking the
+		// item relating to section 312"
+		if recognizer.tableOfContentsPattern.MatchString(normalizeAmendmentText(sectionText[preambleStart:anchorLocation[1]])) {
+			amendments = append(amendments, recognizer.extractTableOfContentsAmendments(afterAnchorText, targetTitle, targetSection, targetSubsection)...)
 			continue
 		}
 
@@ -278,9 +338,37 @@ func (recognizer *Recognizer) ExtractAmendments(sectionText string) ([]Amendment
 	if amendments == nil {
 		amendments = []Amendment{}
 	}
+	for i := range amendments {
+		amendments[i].EffectiveDate = effectiveDate
+	}
 	return amendments, nil
 }
 
+// splitEffectiveDate removes an "EFFECTIVE DATE" subsection from section
+// text, returning the remaining text and the effective date the subsection
+// gives. When no known date form matches, the date carries only the
+// subsection's text.
+func (recognizer *Recognizer) splitEffectiveDate(sectionText string) (string, *EffectiveDateInfo) {
+	headingLocation := recognizer.effectiveDatePattern.FindStringIndex(sectionText)
+	if headingLocation == nil {
+		return sectionText, nil
+	}
+
+	subsectionEnd := len(sectionText)
+	if nextHeading := recognizer.subsectionHeadingPattern.FindStringIndex(sectionText[headingLocation[1]:]); nextHeading != nil {
+		subsectionEnd = headingLocation[1] + nextHeading[0]
+	}
+	subsectionText := sectionText[headingLocation[1]:subsectionEnd]
+
+	effectiveDate := ParseEffectiveDate(subsectionText)
+	if effectiveDate == nil {
+		effectiveDate = &EffectiveDateInfo{
+			RawText: strings.TrimLeft(normalizeAmendmentText(subsectionText), ".-\u2014 "),
+		}
+	}
+	return sectionText[:headingLocation[0]] + sectionText[subsectionEnd:], effectiveDate
+}
+
 // extractRepealAmendments finds "is repealed" or "is hereby repealed"
 // patterns that don't use the standard "is amended" anchor.
 func (recognizer *Recognizer) extractRepealAmendments(sectionText string) []Amendment {
@@ -316,6 +404,11 @@ func findPreambleStart(text string, nextAnchorStart int) int {
 	for pos > 0 && text[pos-1] != '\n' {
 		pos--
 	}
+	// An anchor line that opens with its own marker, as in "(b) Section
+	// 541(a) ... is amended", is the whole preamble
+	if strings.HasPrefix(strings.TrimSpace(text[pos:nextAnchorStart]), "(") {
+		return pos
+	}
 	// Continue backward through continuation lines (lines that start with
 	// non-whitespace or are part of the same paragraph)
 	for pos > 0 {
@@ -464,12 +557,20 @@ func (recognizer *Recognizer) buildAmendment(clauseText, targetTitle, targetSect
 		Description:      truncateDescription(clauseText),
 	}
 
+	normalizedClause := normalizeAmendmentText(clauseText)
+
 	// Extract strike/insert text for strike-insert amendments
 	if amendmentType == AmendStrikeInsert {
-		normalizedClause := normalizeAmendmentText(clauseText)
 		if strikeMatch := recognizer.strikeInsertPattern.FindStringSubmatch(normalizedClause); strikeMatch != nil {
 			amendment.StrikeText = strikeMatch[1]
 			amendment.InsertText = strikeMatch[2]
+		} else if insertMatch := recognizer.insertTextPattern.FindStringSubmatch(normalizedClause); insertMatch != nil {
+			// Inserting text beside an anchor replaces the anchor with
+			// the two joined
+			relation := PositionRelation(strings.ToLower(insertMatch[2]))
+			amendment.StrikeText = insertMatch[3]
+			amendment.InsertText = joinInsertedText(insertMatch[1], insertMatch[3], relation)
+			amendment.Position = &AmendmentPosition{Relation: relation, Text: insertMatch[3]}
 		}
 	}
 
@@ -477,19 +578,269 @@ func (recognizer *Recognizer) buildAmendment(clauseText, targetTitle, targetSect
 	if amendmentType == AmendAddAtEnd || amendmentType == AmendAddNewSection {
 		amendment.InsertText = extractQuotedInsertText(clauseText)
 	}
+	if amendmentType == AmendAddAtEnd {
+		amendment.Position = &AmendmentPosition{Relation: PositionEnd}
+		if endMatch := recognizer.addAtEndPattern.FindStringSubmatch(normalizedClause); endMatch != nil && endMatch[1] != "" {
+			amendment.Position.Level = strings.ToLower(endMatch[1])
+			amendment.Position.Designation = endMatch[2]
+		}
+	}
+	if amendmentType == AmendAddNewSection {
+		if insertMatch := recognizer.addNewSectionPattern.FindStringSubmatch(normalizedClause); insertMatch != nil {
+			amendment.Position = &AmendmentPosition{
+				Relation:    PositionRelation(strings.ToLower(insertMatch[1])),
+				Level:       strings.ToLower(insertMatch[2]),
+				Designation: insertMatch[3],
+			}
+		}
+	}
 
 	// Extract redesignation details
 	if amendmentType == AmendRedesignate {
-		normalizedClause := normalizeAmendmentText(clauseText)
 		if redesignateMatch := recognizer.redesignatePattern.FindStringSubmatch(normalizedClause); redesignateMatch != nil {
-			amendment.StrikeText = "(" + redesignateMatch[1] + ")"
-			amendment.InsertText = "(" + redesignateMatch[2] + ")"
+			amendment.Redesignations = buildRedesignations(
+				strings.ToLower(redesignateMatch[1]), redesignateMatch[2],
+				strings.ToLower(redesignateMatch[3]), redesignateMatch[4],
+			)
+			if len(amendment.Redesignations) > 0 {
+				amendment.StrikeText = amendment.Redesignations[0].From
+				amendment.InsertText = amendment.Redesignations[0].To
+			} else {
+				amendment.StrikeText = firstDesignation(redesignateMatch[2])
+				amendment.InsertText = firstDesignation(redesignateMatch[4])
+			}
 		}
 	}
 
 	return amendment
 }
 
+// extractTableOfContentsAmendments builds an AmendTableOfContents
+// amendment for each clause of a block amending a table of contents,
+// sections, or chapters.
+func (recognizer *Recognizer) extractTableOfContentsAmendments(blockText, targetTitle, targetSection, targetSubsection string) []Amendment {
+	clauseTexts := []string{blockText}
+	if clauses := recognizer.splitNumberedClauses(blockText); len(clauses) > 0 {
+		clauseTexts = clauseTexts[:0]
+		for _, clause := range clauses {
+			clauseTexts = append(clauseTexts, clause.text)
+		}
+	}
+
+	var amendments []Amendment
+	for _, clauseText := range clauseTexts {
+		amendment := recognizer.buildTableOfContentsAmendment(clauseText, targetTitle, targetSection, targetSubsection)
+		if amendment.Type != "" {
+			amendments = append(amendments, amendment)
+		}
+	}
+	return amendments
+}
+
+// buildTableOfContentsAmendment reads one table-of-contents instruction.
+// StrikeText names the struck items ("sections 312 and 313"), InsertText
+// holds the inserted items, and Position anchors them to an existing item
+// ("after the item relating to section 523"). It returns a zero Amendment
+// when the clause changes no items.
+func (recognizer *Recognizer) buildTableOfContentsAmendment(clauseText, targetTitle, targetSection, targetSubsection string) Amendment {
+	normalizedClause := normalizeAmendmentText(clauseText)
+	amendment := Amendment{
+		Type:             AmendTableOfContents,
+		TargetTitle:      targetTitle,
+		TargetSection:    targetSection,
+		TargetSubsection: targetSubsection,
+		Description:      truncateDescription(clauseText),
+	}
+
+	if strikeMatch := recognizer.strikeInsertPattern.FindStringSubmatch(normalizedClause); strikeMatch != nil {
+		amendment.StrikeText = strikeMatch[1]
+		amendment.InsertText = strikeMatch[2]
+		return amendment
+	}
+
+	for _, itemMatch := range recognizer.tableItemPattern.FindAllStringSubmatch(normalizedClause, -1) {
+		action := strings.ToLower(itemMatch[1])
+		if action == "striking" {
+			amendment.StrikeText = itemMatch[2]
+			continue
+		}
+		amendment.Position = &AmendmentPosition{
+			Relation:    PositionRelation(action),
+			Level:       "item",
+			Designation: itemMatch[2],
+		}
+	}
+	if amendment.Position == nil && recognizer.addAtEndPattern.MatchString(normalizedClause) {
+		amendment.Position = &AmendmentPosition{Relation: PositionEnd}
+	}
+	if amendment.Position != nil || strings.Contains(strings.ToLower(normalizedClause), "inserting") {
+		amendment.InsertText = extractQuotedInsertText(clauseText)
+	}
+
+	if amendment.StrikeText == "" && amendment.InsertText == "" && amendment.Position == nil {
+		return Amendment{}
+	}
+	return amendment
+}
+
+// joinInsertedText places inserted text beside its anchor, with a space
+// between them unless the inserted text starts (after) or ends (before)
+// with punctuation that attaches to the anchor.
+func joinInsertedText(insertedText, anchorText string, relation PositionRelation) string {
+	if relation == PositionBefore {
+		if strings.HasSuffix(insertedText, " ") || strings.HasSuffix(insertedText, "(") {
+			return insertedText + anchorText
+		}
+		return insertedText + " " + anchorText
+	}
+	if strings.HasPrefix(insertedText, " ") || strings.ContainsAny(insertedText[:1], ",.;:)") {
+		return anchorText + insertedText
+	}
+	return anchorText + " " + insertedText
+}
+
+// buildRedesignations pairs the designations of a redesignation
+// instruction, expanding lists and ranges: "subsections (c) through (e) as
+// subsections (d) through (f)" gives (c) to (d), (d) to (e), and (e) to
+// (f). It returns nil when the two sides do not pair up.
+func buildRedesignations(fromLevel, fromList, toLevel, toList string) []Redesignation {
+	fromDesignations := expandDesignationList(fromList, fromLevel)
+	toDesignations := expandDesignationList(toList, toLevel)
+	if len(fromDesignations) == 0 || len(fromDesignations) != len(toDesignations) {
+		return nil
+	}
+
+	redesignations := make([]Redesignation, len(fromDesignations))
+	for i := range fromDesignations {
+		redesignations[i] = Redesignation{Level: fromLevel, From: fromDesignations[i], To: toDesignations[i]}
+		if toLevel != fromLevel {
+			redesignations[i].ToLevel = toLevel
+		}
+	}
+	return redesignations
+}
+
+// maxDesignationRange bounds how many designations a "through" range may
+// expand to.
+const maxDesignationRange = 100
+
+var (
+	designationSeparatorPattern = regexp.MustCompile(`\s*,\s*(?:and\s+)?|\s+and\s+`)
+	designationRangePattern     = regexp.MustCompile(`^(\S+)\s+through\s+(\S+)$`)
+	romanNumeralPattern         = regexp.MustCompile(`^(?:[ivxlc]+|[IVXLC]+)$`)
+)
+
+// expandDesignationList splits a list of designations ("(1), (2), and
+// (3)", "(c) through (e)") into single designations. It returns nil when a
+// range cannot be expanded.
+func expandDesignationList(list, level string) []string {
+	var designations []string
+	for _, item := range designationSeparatorPattern.Split(strings.TrimSpace(list), -1) {
+		rangeMatch := designationRangePattern.FindStringSubmatch(item)
+		if rangeMatch == nil {
+			designations = append(designations, item)
+			continue
+		}
+		expanded := expandDesignationRange(rangeMatch[1], rangeMatch[2], level)
+		if expanded == nil {
+			return nil
+		}
+		designations = append(designations, expanded...)
+	}
+	return designations
+}
+
+// expandDesignationRange lists the designations from first through last:
+// numbers, single letters, or, for clauses and subclauses (or when either
+// end is longer than a letter), roman numerals.
+func expandDesignationRange(first, last, level string) []string {
+	prefix, suffix := "", ""
+	if strings.HasPrefix(first, "(") && strings.HasSuffix(first, ")") {
+		if !strings.HasPrefix(last, "(") || !strings.HasSuffix(last, ")") {
+			return nil
+		}
+		prefix, suffix = "(", ")"
+		first, last = first[1:len(first)-1], last[1:len(last)-1]
+	}
+
+	var values []string
+	firstNumber, firstErr := strconv.Atoi(first)
+	lastNumber, lastErr := strconv.Atoi(last)
+	switch {
+	case firstErr == nil && lastErr == nil:
+		for number := firstNumber; number <= lastNumber && len(values) <= maxDesignationRange; number++ {
+			values = append(values, strconv.Itoa(number))
+		}
+	case romanNumeralPattern.MatchString(first) && romanNumeralPattern.MatchString(last) &&
+		(strings.HasSuffix(level, "clause") || len(first) > 1 || len(last) > 1):
+		upper := first == strings.ToUpper(first)
+		for number := romanValue(first); number > 0 && number <= romanValue(last) && len(values) <= maxDesignationRange; number++ {
+			numeral := romanNumeral(number)
+			if upper {
+				numeral = strings.ToUpper(numeral)
+			}
+			values = append(values, numeral)
+		}
+	case len(first) == 1 && len(last) == 1 && unicode.IsLetter(rune(first[0])) && unicode.IsLetter(rune(last[0])) &&
+		unicode.IsUpper(rune(first[0])) == unicode.IsUpper(rune(last[0])):
+		for letter := first[0]; letter <= last[0]; letter++ {
+			values = append(values, string(letter))
+		}
+	}
+	if len(values) == 0 || len(values) > maxDesignationRange {
+		return nil
+	}
+
+	for i, value := range values {
+		values[i] = prefix + value + suffix
+	}
+	return values
+}
+
+// firstDesignation returns the first designation of a list.
+func firstDesignation(list string) string {
+	return strings.TrimRight(strings.Fields(list)[0], ",")
+}
+
+// romanValue returns the value of a roman numeral, or 0 when it is not a
+// well-formed one.
+func romanValue(numeral string) int {
+	digits := map[rune]int{'i': 1, 'v': 5, 'x': 10, 'l': 50, 'c': 100}
+	lower := strings.ToLower(numeral)
+	value := 0
+	for i, digit := range lower {
+		digitValue := digits[digit]
+		if i+1 < len(lower) && digits[rune(lower[i+1])] > digitValue {
+			value -= digitValue
+		} else {
+			value += digitValue
+		}
+	}
+	if romanNumeral(value) != lower {
+		return 0
+	}
+	return value
+}
+
+// romanNumeral writes a positive number as a lowercase roman numeral.
+func romanNumeral(number int) string {
+	numerals := []struct {
+		value   int
+		numeral string
+	}{
+		{100, "c"}, {90, "xc"}, {50, "l"}, {40, "xl"},
+		{10, "x"}, {9, "ix"}, {5, "v"}, {4, "iv"}, {1, "i"},
+	}
+	var builder strings.Builder
+	for _, entry := range numerals {
+		for number >= entry.value {
+			builder.WriteString(entry.numeral)
+			number -= entry.value
+		}
+	}
+	return builder.String()
+}
+
 // normalizeAmendmentText collapses runs of whitespace (including newlines)
 // into single spaces for pattern matching.
 func normalizeAmendmentText(text string) string {
@@ -519,9 +870,5 @@ func extractQuotedInsertText(text string) string {
 // truncateDescription creates a short description from the clause text,
 // trimming to a reasonable length.
 func truncateDescription(text string) string {
-	normalized := normalizeAmendmentText(text)
-	if len(normalized) > 200 {
-		return normalized[:200] + "..."
-	}
-	return normalized
+	return textutil.Truncate(normalizeAmendmentText(text), 203)
 }
@@ -53,6 +53,18 @@ func TestNewRecognizer(t *testing.T) {
 	if recognizer.quotedTextPattern == nil {
 		t.Error("quotedTextPattern is nil")
 	}
+	if recognizer.insertTextPattern == nil {
+		t.Error("insertTextPattern is nil")
+	}
+	if recognizer.tableItemPattern == nil {
+		t.Error("tableItemPattern is nil")
+	}
+	if recognizer.effectiveDatePattern == nil {
+		t.Error("effectiveDatePattern is nil")
+	}
+	if recognizer.subsectionHeadingPattern == nil {
+		t.Error("subsectionHeadingPattern is nil")
+	}
 }
 
 func TestClassifyAmendmentType(t *testing.T) {
@@ -113,6 +125,36 @@ func TestClassifyAmendmentType(t *testing.T) {
 			text:     "The table of contents of such Act is amended",
 			wantType: AmendTableOfContents,
 		},
+		{
+			name:     "insert after paragraph",
+			text:     "by inserting after paragraph (3) the following",
+			wantType: AmendAddNewSection,
+		},
+		{
+			name:     "insert before subsection as so redesignated",
+			text:     "by inserting before subsection (d) (as so redesignated) the following new subsection",
+			wantType: AmendAddNewSection,
+		},
+		{
+			name:     "insert text after text",
+			text:     `by inserting ", including any successor" after "operator"`,
+			wantType: AmendStrikeInsert,
+		},
+		{
+			name:     "add at end of subsection",
+			text:     "by adding at the end of subsection (c) the following new paragraph",
+			wantType: AmendAddAtEnd,
+		},
+		{
+			name:     "redesignate range",
+			text:     "by redesignating subsections (c) through (e) as subsections (d) through (f), respectively",
+			wantType: AmendRedesignate,
+		},
+		{
+			name:     "table of sections",
+			text:     "The table of sections for chapter 5 of title 11, United States Code, is amended",
+			wantType: AmendTableOfContents,
+		},
 		{
 			name:     "no match",
 			text:     "The Secretary shall conduct a study",
@@ -351,6 +393,316 @@ func TestExtractAmendments_Redesignate(t *testing.T) {
 	}
 }
 
+func TestExtractAmendments_InsertionPosition(t *testing.T) {
+	recognizer := NewRecognizer()
+
+	testCases := []struct {
+		name         string
+		sectionText  string
+		wantType     AmendmentType
+		wantPosition AmendmentPosition
+		wantStrike   string
+		wantInsert   string
+	}{
+		{
+			name: "after paragraph",
+			sectionText: `Section 1396a(a) of title 42, United States Code, is amended by inserting after paragraph (3) the following:
+"(4) provide for electronic reporting.".`,
+			wantType:     AmendAddNewSection,
+			wantPosition: AmendmentPosition{Relation: PositionAfter, Level: "paragraph", Designation: "(3)"},
+			wantInsert:   "(4) provide for electronic reporting.",
+		},
+		{
+			name: "before subsection as so redesignated",
+			sectionText: `Section 6502 of title 15, United States Code, is amended by inserting before subsection (d) (as so redesignated) the following new subsection:
+"(c) NOTICE.--An operator shall give notice.".`,
+			wantType:     AmendAddNewSection,
+			wantPosition: AmendmentPosition{Relation: PositionBefore, Level: "subsection", Designation: "(d)"},
+			wantInsert:   "(c) NOTICE.--An operator shall give notice.",
+		},
+		{
+			name: "at end of subsection",
+			sectionText: `Section 36B of title 26, United States Code, is amended by adding at the end of subsection (c) the following new paragraph:
+"(6) ESSENTIAL WORKERS.--Such term includes essential workers.".`,
+			wantType:     AmendAddAtEnd,
+			wantPosition: AmendmentPosition{Relation: PositionEnd, Level: "subsection", Designation: "(c)"},
+			wantInsert:   "(6) ESSENTIAL WORKERS.--Such term includes essential workers.",
+		},
+		{
+			name:         "text after text",
+			sectionText:  `Section 6502(a) of title 15, United States Code, is amended by inserting ", including any successor" after "operator".`,
+			wantType:     AmendStrikeInsert,
+			wantPosition: AmendmentPosition{Relation: PositionAfter, Text: "operator"},
+			wantStrike:   "operator",
+			wantInsert:   "operator, including any successor",
+		},
+		{
+			name:         "text before text",
+			sectionText:  `Section 6502(a) of title 15, United States Code, is amended by inserting "knowingly" before "collect".`,
+			wantType:     AmendStrikeInsert,
+			wantPosition: AmendmentPosition{Relation: PositionBefore, Text: "collect"},
+			wantStrike:   "collect",
+			wantInsert:   "knowingly collect",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			amendments, extractErr := recognizer.ExtractAmendments(testCase.sectionText)
+			if extractErr != nil {
+				t.Fatalf("unexpected error: %v", extractErr)
+			}
+			if len(amendments) != 1 {
+				t.Fatalf("got %d amendments, want 1", len(amendments))
+			}
+			amendment := amendments[0]
+			if amendment.Type != testCase.wantType {
+				t.Errorf("Type = %q, want %q", amendment.Type, testCase.wantType)
+			}
+			if amendment.Position == nil {
+				t.Fatal("Position is nil")
+			}
+			if *amendment.Position != testCase.wantPosition {
+				t.Errorf("Position = %+v, want %+v", *amendment.Position, testCase.wantPosition)
+			}
+			if amendment.StrikeText != testCase.wantStrike {
+				t.Errorf("StrikeText = %q, want %q", amendment.StrikeText, testCase.wantStrike)
+			}
+			if amendment.InsertText != testCase.wantInsert {
+				t.Errorf("InsertText = %q, want %q", amendment.InsertText, testCase.wantInsert)
+			}
+		})
+	}
+}
+
+func TestExtractAmendments_MultiTargetRedesignate(t *testing.T) {
+	recognizer := NewRecognizer()
+
+	testCases := []struct {
+		name               string
+		instruction        string
+		wantRedesignations []Redesignation
+	}{
+		{
+			name:        "single",
+			instruction: "by redesignating paragraph (3) as paragraph (4)",
+			wantRedesignations: []Redesignation{
+				{Level: "paragraph", From: "(3)", To: "(4)"},
+			},
+		},
+		{
+			name:        "lettered range",
+			instruction: "by redesignating subsections (c) through (e) as subsections (d) through (f), respectively",
+			wantRedesignations: []Redesignation{
+				{Level: "subsection", From: "(c)", To: "(d)"},
+				{Level: "subsection", From: "(d)", To: "(e)"},
+				{Level: "subsection", From: "(e)", To: "(f)"},
+			},
+		},
+		{
+			name:        "list with level change",
+			instruction: "by redesignating paragraphs (1), (2), and (3) as subparagraphs (A), (B), and (C), respectively",
+			wantRedesignations: []Redesignation{
+				{Level: "paragraph", From: "(1)", ToLevel: "subparagraph", To: "(A)"},
+				{Level: "paragraph", From: "(2)", ToLevel: "subparagraph", To: "(B)"},
+				{Level: "paragraph", From: "(3)", ToLevel: "subparagraph", To: "(C)"},
+			},
+		},
+		{
+			name:        "roman numeral clauses",
+			instruction: "by redesignating clauses (ii) through (iv) as clauses (iii) through (v), respectively",
+			wantRedesignations: []Redesignation{
+				{Level: "clause", From: "(ii)", To: "(iii)"},
+				{Level: "clause", From: "(iii)", To: "(iv)"},
+				{Level: "clause", From: "(iv)", To: "(v)"},
+			},
+		},
+		{
+			name:        "numbered range and pair",
+			instruction: "by redesignating paragraphs (8) through (10) and (12) as paragraphs (9) through (11) and (13), respectively",
+			wantRedesignations: []Redesignation{
+				{Level: "paragraph", From: "(8)", To: "(9)"},
+				{Level: "paragraph", From: "(9)", To: "(10)"},
+				{Level: "paragraph", From: "(10)", To: "(11)"},
+				{Level: "paragraph", From: "(12)", To: "(13)"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			sectionText := "Section 6502 of title 15, United States Code, is amended " + testCase.instruction + "."
+			amendments, extractErr := recognizer.ExtractAmendments(sectionText)
+			if extractErr != nil {
+				t.Fatalf("unexpected error: %v", extractErr)
+			}
+			if len(amendments) != 1 {
+				t.Fatalf("got %d amendments, want 1", len(amendments))
+			}
+			amendment := amendments[0]
+			if amendment.Type != AmendRedesignate {
+				t.Errorf("Type = %q, want %q", amendment.Type, AmendRedesignate)
+			}
+			if len(amendment.Redesignations) != len(testCase.wantRedesignations) {
+				t.Fatalf("got %d redesignations %+v, want %d", len(amendment.Redesignations), amendment.Redesignations, len(testCase.wantRedesignations))
+			}
+			for i, want := range testCase.wantRedesignations {
+				if amendment.Redesignations[i] != want {
+					t.Errorf("Redesignations[%d] = %+v, want %+v", i, amendment.Redesignations[i], want)
+				}
+			}
+			if amendment.StrikeText != testCase.wantRedesignations[0].From || amendment.InsertText != testCase.wantRedesignations[0].To {
+				t.Errorf("StrikeText, InsertText = %q, %q, want the first redesignation", amendment.StrikeText, amendment.InsertText)
+			}
+		})
+	}
+}
+
+func TestExtractAmendments_TableOfContents(t *testing.T) {
+	recognizer := NewRecognizer()
+
+	sectionText := `(a) TABLE OF SECTIONS.—The table of sections for chapter 5 of title 11, United States Code, is amended—
+(1) by striking the items relating to sections 312 and 313; and
+(2) by inserting after the item relating to section 523 the following:
+"523A. Digital asset protections.".
+(b) CROSS-REFERENCE.—Section 307(c) of title 47, United States Code, is amended by striking "and section 312" and inserting "and section 311".`
+
+	amendments, extractErr := recognizer.ExtractAmendments(sectionText)
+	if extractErr != nil {
+		t.Fatalf("unexpected error: %v", extractErr)
+	}
+	if len(amendments) != 3 {
+		t.Fatalf("got %d amendments, want 3: %+v", len(amendments), amendments)
+	}
+
+	strike := amendments[0]
+	if strike.Type != AmendTableOfContents {
+		t.Errorf("amendment[0].Type = %q, want %q", strike.Type, AmendTableOfContents)
+	}
+	if strike.TargetTitle != "11" {
+		t.Errorf("amendment[0].TargetTitle = %q, want %q", strike.TargetTitle, "11")
+	}
+	if strike.StrikeText != "sections 312 and 313" {
+		t.Errorf("amendment[0].StrikeText = %q, want %q", strike.StrikeText, "sections 312 and 313")
+	}
+
+	insert := amendments[1]
+	if insert.Type != AmendTableOfContents {
+		t.Errorf("amendment[1].Type = %q, want %q", insert.Type, AmendTableOfContents)
+	}
+	wantPosition := AmendmentPosition{Relation: PositionAfter, Level: "item", Designation: "section 523"}
+	if insert.Position == nil || *insert.Position != wantPosition {
+		t.Errorf("amendment[1].Position = %+v, want %+v", insert.Position, wantPosition)
+	}
+	if insert.InsertText != "523A. Digital asset protections." {
+		t.Errorf("amendment[1].InsertText = %q, want %q", insert.InsertText, "523A. Digital asset protections.")
+	}
+
+	// The next subsection names its own target
+	crossReference := amendments[2]
+	if crossReference.Type != AmendStrikeInsert {
+		t.Errorf("amendment[2].Type = %q, want %q", crossReference.Type, AmendStrikeInsert)
+	}
+	if crossReference.TargetTitle != "47" || crossReference.TargetSection != "307" || crossReference.TargetSubsection != "(c)" {
+		t.Errorf("amendment[2] target = %s %s%s, want 47 307(c)", crossReference.TargetTitle, crossReference.TargetSection, crossReference.TargetSubsection)
+	}
+}
+
+func TestExtractAmendments_SubsectionTargets(t *testing.T) {
+	recognizer := NewRecognizer()
+
+	// Flush-left subsections, each amending a different section
+	sectionText := `(a) Section 522(d) of title 11, United States Code, is amended by adding at the end the following:
+"(13) The debtor's interest in digital assets.".
+(b) Section 541(a) of title 11, United States Code, is amended—
+(1) by redesignating paragraph (7) as paragraph (8); and
+(2) by inserting after paragraph (6) the following:
+"(7) Digital assets.".`
+
+	amendments, extractErr := recognizer.ExtractAmendments(sectionText)
+	if extractErr != nil {
+		t.Fatalf("unexpected error: %v", extractErr)
+	}
+
+	want := []struct {
+		amendmentType AmendmentType
+		target        string
+	}{
+		{AmendAddAtEnd, "522(d)"},
+		{AmendRedesignate, "541(a)"},
+		{AmendAddNewSection, "541(a)"},
+	}
+	if len(amendments) != len(want) {
+		t.Fatalf("got %d amendments, want %d: %+v", len(amendments), len(want), amendments)
+	}
+	for i, wantAmendment := range want {
+		if amendments[i].Type != wantAmendment.amendmentType {
+			t.Errorf("amendment[%d].Type = %q, want %q", i, amendments[i].Type, wantAmendment.amendmentType)
+		}
+		if target := amendments[i].TargetSection + amendments[i].TargetSubsection; target != wantAmendment.target {
+			t.Errorf("amendment[%d] target = %q, want %q", i, target, wantAmendment.target)
+		}
+	}
+	if amendments[0].InsertText != "(13) The debtor's interest in digital assets." {
+		t.Errorf("amendment[0].InsertText = %q", amendments[0].InsertText)
+	}
+}
+
+func TestExtractAmendments_EffectiveDateSubsection(t *testing.T) {
+	recognizer := NewRecognizer()
+
+	testCases := []struct {
+		name        string
+		sectionText string
+		wantDays    int
+		wantRawText string
+	}{
+		{
+			name: "days after enactment",
+			sectionText: `(a) IN GENERAL.--Section 1303 of the Children's Online Privacy
+Protection Act of 1998 (15 U.S.C. 6502) is amended by striking "13" and inserting "16".
+(b) EFFECTIVE DATE.--The amendment made by subsection (a) shall take effect 180 days after the date of enactment of this Act.`,
+			wantDays:    180,
+			wantRawText: "take effect 180 days after the date of enactment",
+		},
+		{
+			name: "unrecognized date form before another subsection",
+			sectionText: `(a) IN GENERAL.--Section 6505 of title 15, United States Code, is amended by striking "$50,000" and inserting "$100,000".
+(b) EFFECTIVE DATE.--The amendment made by subsection (a) shall apply to violations occurring after the first full calendar year.
+(c) RULE OF CONSTRUCTION.--Nothing in this section limits the authority of a State.`,
+			wantRawText: "The amendment made by subsection (a) shall apply to violations occurring after the first full calendar year.",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			amendments, extractErr := recognizer.ExtractAmendments(testCase.sectionText)
+			if extractErr != nil {
+				t.Fatalf("unexpected error: %v", extractErr)
+			}
+			if len(amendments) != 1 {
+				t.Fatalf("got %d amendments, want 1", len(amendments))
+			}
+			amendment := amendments[0]
+			if amendment.Type != AmendStrikeInsert {
+				t.Errorf("Type = %q, want %q", amendment.Type, AmendStrikeInsert)
+			}
+			if strings.Contains(amendment.Description, "EFFECTIVE DATE") {
+				t.Errorf("Description includes the effective-date subsection: %q", amendment.Description)
+			}
+			if amendment.EffectiveDate == nil {
+				t.Fatal("EffectiveDate is nil")
+			}
+			if amendment.EffectiveDate.DaysAfterEnactment != testCase.wantDays {
+				t.Errorf("DaysAfterEnactment = %d, want %d", amendment.EffectiveDate.DaysAfterEnactment, testCase.wantDays)
+			}
+			if amendment.EffectiveDate.RawText != testCase.wantRawText {
+				t.Errorf("RawText = %q, want %q", amendment.EffectiveDate.RawText, testCase.wantRawText)
+			}
+		})
+	}
+}
+
 func TestExtractAmendments_MultipleInOneSection(t *testing.T) {
 	recognizer := NewRecognizer()
 
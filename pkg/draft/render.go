@@ -6,11 +6,21 @@ import (
 	"html"
 	"strings"
 	"time"
+
+	"github.com/coolbeans/regula/pkg/i18n"
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // RenderReportMarkdown converts a LegislativeImpactReport into a GitHub-flavored
 // Markdown document suitable for rendering on GitHub, GitLab, or similar platforms.
 func RenderReportMarkdown(report *LegislativeImpactReport) (string, error) {
+	return RenderReportMarkdownLocalized(report, nil)
+}
+
+// RenderReportMarkdownLocalized renders the Markdown report with headings,
+// labels, and fixed phrases translated by translator. A nil translator
+// renders English.
+func RenderReportMarkdownLocalized(report *LegislativeImpactReport, translator *i18n.Translator) (string, error) {
 	if report == nil {
 		return "", fmt.Errorf("report is nil")
 	}
@@ -23,15 +33,15 @@ func RenderReportMarkdown(report *LegislativeImpactReport) (string, error) {
 		billTitle = report.Bill.BillNumber
 	}
 	if billTitle == "" {
-		billTitle = "Draft Legislation"
+		billTitle = translator.T("Draft Legislation")
 	}
 
-	sb.WriteString(fmt.Sprintf("# Legislative Impact Report: %s\n\n", billTitle))
+	sb.WriteString(fmt.Sprintf("# %s: %s\n\n", translator.T("Legislative Impact Report"), billTitle))
 
 	// Bill number if different from title
 	billNumber := report.ExecutiveSummary.BillNumber
 	if billNumber != "" && billNumber != billTitle {
-		sb.WriteString(fmt.Sprintf("**Bill Number:** %s\n\n", billNumber))
+		sb.WriteString(fmt.Sprintf("**%s:** %s\n\n", translator.T("Bill Number"), billNumber))
 	}
 
 	// Risk level with emoji
@@ -40,29 +50,31 @@ func RenderReportMarkdown(report *LegislativeImpactReport) (string, error) {
 	switch report.RiskLevel {
 	case RiskHigh:
 		riskEmoji = "🔴"
-		riskLabel = "HIGH"
+		riskLabel = translator.T("HIGH")
 	case RiskMedium:
 		riskEmoji = "🟠"
-		riskLabel = "MEDIUM"
+		riskLabel = translator.T("MEDIUM")
 	default:
 		riskEmoji = "🟢"
-		riskLabel = "LOW"
+		riskLabel = translator.T("LOW")
 	}
-	sb.WriteString(fmt.Sprintf("**Risk Level: %s** %s\n\n", riskLabel, riskEmoji))
+	sb.WriteString(fmt.Sprintf("**%s: %s** %s\n\n", translator.T("Risk Level"), riskLabel, riskEmoji))
 
 	// Executive Summary
-	sb.WriteString("## Executive Summary\n\n")
+	sb.WriteString("## " + translator.T("Executive Summary") + "\n\n")
 	summary := report.ExecutiveSummary
 
 	titlesStr := formatTitlesForMarkdown(summary.TitlesAffected)
-	sb.WriteString(fmt.Sprintf("- **Amendments:** %d", summary.AmendmentCount))
+	sb.WriteString(fmt.Sprintf("- **%s:** %d", translator.T("Amendments"), summary.AmendmentCount))
 	if titlesStr != "" {
-		sb.WriteString(fmt.Sprintf(" across titles %s", titlesStr))
+		sb.WriteString(" " + translator.Sprintf("across titles %s", titlesStr))
 	}
 	sb.WriteString("\n")
 
-	sb.WriteString(fmt.Sprintf("- **Provisions modified:** %d | **Repealed:** %d | **Added:** %d\n",
-		summary.ProvisionsModified, summary.ProvisionsRepealed, summary.ProvisionsAdded))
+	sb.WriteString(fmt.Sprintf("- **%s:** %d | **%s:** %d | **%s:** %d\n",
+		translator.T("Provisions modified"), summary.ProvisionsModified,
+		translator.T("Repealed"), summary.ProvisionsRepealed,
+		translator.T("Added"), summary.ProvisionsAdded))
 
 	directCount := 0
 	transitiveCount := 0
@@ -70,59 +82,58 @@ func RenderReportMarkdown(report *LegislativeImpactReport) (string, error) {
 		directCount = len(report.Impact.DirectlyAffected)
 		transitiveCount = len(report.Impact.TransitivelyAffected)
 	}
-	sb.WriteString(fmt.Sprintf("- **Total provisions affected:** %d (%d direct, %d transitive)\n",
-		summary.TotalProvisionsAffected, directCount, transitiveCount))
+	sb.WriteString(fmt.Sprintf("- **%s:** %d %s\n", translator.T("Total provisions affected"),
+		summary.TotalProvisionsAffected, translator.Sprintf("(%d direct, %d transitive)", directCount, transitiveCount)))
 
-	sb.WriteString(fmt.Sprintf("- **Broken cross-references:** %d\n", summary.BrokenCrossRefs))
+	sb.WriteString(fmt.Sprintf("- **%s:** %d\n", translator.T("Broken cross-references"), summary.BrokenCrossRefs))
 
 	conflictInfoCount := 0
 	if report.Conflicts != nil {
 		conflictInfoCount = report.Conflicts.Summary.Infos
 	}
-	sb.WriteString(fmt.Sprintf("- **Conflicts:** %d errors, %d warnings, %d info\n",
-		summary.ConflictErrors, summary.ConflictWarnings, conflictInfoCount))
+	sb.WriteString(fmt.Sprintf("- **%s:** %s\n", translator.T("Conflicts"),
+		translator.Sprintf("%d errors, %d warnings, %d info", summary.ConflictErrors, summary.ConflictWarnings, conflictInfoCount)))
 
-	sb.WriteString(fmt.Sprintf("- **New obligations:** %d | **Removed:** %d\n",
-		summary.ObligationsAdded, summary.ObligationsRemoved))
-	sb.WriteString(fmt.Sprintf("- **New rights:** %d | **Removed:** %d\n\n",
-		summary.RightsAdded, summary.RightsRemoved))
+	sb.WriteString(fmt.Sprintf("- **%s:** %d | **%s:** %d\n",
+		translator.T("New obligations"), summary.ObligationsAdded, translator.T("Removed"), summary.ObligationsRemoved))
+	sb.WriteString(fmt.Sprintf("- **%s:** %d | **%s:** %d\n\n",
+		translator.T("New rights"), summary.RightsAdded, translator.T("Removed"), summary.RightsRemoved))
 
 	// Structural Changes (Diff)
 	if report.Diff != nil && hasDiffEntries(report.Diff) {
-		sb.WriteString("## Structural Changes\n\n")
-		sb.WriteString("| Type | Target | Description |\n")
-		sb.WriteString("|------|--------|-------------|\n")
+		sb.WriteString("## " + translator.T("Structural Changes") + "\n\n")
+		sb.WriteString(translator.MarkdownTableHeader("Type", "Target", "Description"))
 
 		for _, entry := range report.Diff.Modified {
 			desc := entry.Amendment.Description
 			if desc == "" {
-				desc = "Strike and insert"
+				desc = translator.T("Strike and insert")
 			}
-			sb.WriteString(fmt.Sprintf("| Modified | %s | %s |\n",
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", translator.T("Modified"),
 				formatTargetForMarkdown(entry.Amendment), truncateMarkdown(desc, 50)))
 		}
 		for _, entry := range report.Diff.Removed {
 			desc := entry.Amendment.Description
 			if desc == "" {
-				desc = "Repeal"
+				desc = translator.T("Repeal")
 			}
-			sb.WriteString(fmt.Sprintf("| Repealed | %s | %s |\n",
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", translator.T("Repealed"),
 				formatTargetForMarkdown(entry.Amendment), truncateMarkdown(desc, 50)))
 		}
 		for _, entry := range report.Diff.Added {
 			desc := entry.Amendment.Description
 			if desc == "" {
-				desc = "Add new section"
+				desc = translator.T("Add new section")
 			}
-			sb.WriteString(fmt.Sprintf("| Added | %s | %s |\n",
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", translator.T("Added"),
 				formatTargetForMarkdown(entry.Amendment), truncateMarkdown(desc, 50)))
 		}
 		for _, entry := range report.Diff.Redesignated {
 			desc := entry.Amendment.Description
 			if desc == "" {
-				desc = "Redesignate"
+				desc = translator.T("Redesignate")
 			}
-			sb.WriteString(fmt.Sprintf("| Redesignated | %s | %s |\n",
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", translator.T("Redesignated"),
 				formatTargetForMarkdown(entry.Amendment), truncateMarkdown(desc, 50)))
 		}
 		sb.WriteString("\n")
@@ -130,12 +141,11 @@ func RenderReportMarkdown(report *LegislativeImpactReport) (string, error) {
 
 	// Impact Analysis
 	if report.Impact != nil && (len(report.Impact.DirectlyAffected) > 0 || len(report.Impact.TransitivelyAffected) > 0) {
-		sb.WriteString("## Impact Analysis\n\n")
+		sb.WriteString("## " + translator.T("Impact Analysis") + "\n\n")
 
 		if len(report.Impact.DirectlyAffected) > 0 {
-			sb.WriteString("### Directly Affected Provisions\n\n")
-			sb.WriteString("| Provision | Reason |\n")
-			sb.WriteString("|-----------|--------|\n")
+			sb.WriteString("### " + translator.T("Directly Affected Provisions") + "\n\n")
+			sb.WriteString(translator.MarkdownTableHeader("Provision", "Reason"))
 			for _, prov := range report.Impact.DirectlyAffected {
 				label := prov.Label
 				if label == "" {
@@ -147,9 +157,8 @@ func RenderReportMarkdown(report *LegislativeImpactReport) (string, error) {
 		}
 
 		if len(report.Impact.TransitivelyAffected) > 0 {
-			sb.WriteString("### Transitively Affected Provisions\n\n")
-			sb.WriteString("| Provision | Depth | Reason |\n")
-			sb.WriteString("|-----------|-------|--------|\n")
+			sb.WriteString("### " + translator.T("Transitively Affected Provisions") + "\n\n")
+			sb.WriteString(translator.MarkdownTableHeader("Provision", "Depth", "Reason"))
 			for _, prov := range report.Impact.TransitivelyAffected {
 				label := prov.Label
 				if label == "" {
@@ -163,17 +172,16 @@ func RenderReportMarkdown(report *LegislativeImpactReport) (string, error) {
 
 	// Conflict Findings
 	if report.Conflicts != nil && len(report.Conflicts.Conflicts) > 0 {
-		sb.WriteString("## Conflict Findings\n\n")
-		sb.WriteString("| Severity | Type | Description |\n")
-		sb.WriteString("|----------|------|-------------|\n")
+		sb.WriteString("## " + translator.T("Conflict Findings") + "\n\n")
+		sb.WriteString(translator.MarkdownTableHeader("Severity", "Type", "Description"))
 
 		for _, conflict := range report.Conflicts.Conflicts {
-			severityLabel := "ℹ️ Info"
+			severityLabel := "ℹ️ " + translator.T("Info")
 			switch conflict.Severity {
 			case ConflictError:
-				severityLabel = "🔴 Error"
+				severityLabel = "🔴 " + translator.T("Error")
 			case ConflictWarning:
-				severityLabel = "🟠 Warning"
+				severityLabel = "🟠 " + translator.T("Warning")
 			}
 			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
 				severityLabel, conflict.Type, truncateMarkdown(conflict.Description, 60)))
@@ -183,17 +191,16 @@ func RenderReportMarkdown(report *LegislativeImpactReport) (string, error) {
 
 	// Temporal Analysis
 	if len(report.TemporalFindings) > 0 {
-		sb.WriteString("## Temporal Analysis\n\n")
-		sb.WriteString("| Severity | Type | Finding |\n")
-		sb.WriteString("|----------|------|--------|\n")
+		sb.WriteString("## " + translator.T("Temporal Analysis") + "\n\n")
+		sb.WriteString(translator.MarkdownTableHeader("Severity", "Type", "Finding"))
 
 		for _, finding := range report.TemporalFindings {
-			severityLabel := "ℹ️ Info"
+			severityLabel := "ℹ️ " + translator.T("Info")
 			switch finding.Severity {
 			case ConflictError:
-				severityLabel = "🔴 Error"
+				severityLabel = "🔴 " + translator.T("Error")
 			case ConflictWarning:
-				severityLabel = "🟠 Warning"
+				severityLabel = "🟠 " + translator.T("Warning")
 			}
 			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
 				severityLabel, finding.Type, truncateMarkdown(finding.Description, 60)))
@@ -203,17 +210,16 @@ func RenderReportMarkdown(report *LegislativeImpactReport) (string, error) {
 
 	// Broken Cross-References
 	if report.Impact != nil && len(report.Impact.BrokenCrossRefs) > 0 {
-		sb.WriteString("## Broken Cross-References\n\n")
-		sb.WriteString("| Severity | Source | Target | Reason |\n")
-		sb.WriteString("|----------|--------|--------|--------|\n")
+		sb.WriteString("## " + translator.T("Broken Cross-References") + "\n\n")
+		sb.WriteString(translator.MarkdownTableHeader("Severity", "Source", "Target", "Reason"))
 
 		for _, ref := range report.Impact.BrokenCrossRefs {
-			severityLabel := "ℹ️ Info"
+			severityLabel := "ℹ️ " + translator.T("Info")
 			switch ref.Severity {
 			case SeverityError:
-				severityLabel = "🔴 Error"
+				severityLabel = "🔴 " + translator.T("Error")
 			case SeverityWarning:
-				severityLabel = "🟠 Warning"
+				severityLabel = "🟠 " + translator.T("Warning")
 			}
 			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
 				severityLabel, ref.SourceLabel, ref.TargetLabel, truncateMarkdown(ref.Reason, 40)))
@@ -223,31 +229,30 @@ func RenderReportMarkdown(report *LegislativeImpactReport) (string, error) {
 
 	// Scenario Comparisons
 	if len(report.ScenarioResults) > 0 {
-		sb.WriteString("## Scenario Comparisons\n\n")
+		sb.WriteString("## " + translator.T("Scenario Comparisons") + "\n\n")
 
 		for _, comparison := range report.ScenarioResults {
 			sb.WriteString(fmt.Sprintf("### %s\n\n", comparison.Scenario))
 			sum := comparison.GetSummary()
 
 			if !sum.HasDifferences {
-				sb.WriteString("No differences detected between baseline and proposed.\n\n")
+				sb.WriteString(translator.T("No differences detected between baseline and proposed.") + "\n\n")
 				continue
 			}
 
-			sb.WriteString("| Metric | Count |\n")
-			sb.WriteString("|--------|-------|\n")
-			sb.WriteString(fmt.Sprintf("| Newly Applicable | %d |\n", sum.NewlyApplicable))
-			sb.WriteString(fmt.Sprintf("| No Longer Applicable | %d |\n", sum.NoLongerApplicable))
-			sb.WriteString(fmt.Sprintf("| Changed Relevance | %d |\n", sum.ChangedRelevance))
-			sb.WriteString(fmt.Sprintf("| Obligations Added | %d |\n", sum.ObligationsAdded))
-			sb.WriteString(fmt.Sprintf("| Obligations Removed | %d |\n", sum.ObligationsRemoved))
+			sb.WriteString(translator.MarkdownTableHeader("Metric", "Count"))
+			sb.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Newly Applicable"), sum.NewlyApplicable))
+			sb.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("No Longer Applicable"), sum.NoLongerApplicable))
+			sb.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Changed Relevance"), sum.ChangedRelevance))
+			sb.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Obligations Added"), sum.ObligationsAdded))
+			sb.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Obligations Removed"), sum.ObligationsRemoved))
 			sb.WriteString("\n")
 		}
 	}
 
 	// Visualization (as code block)
 	if report.Visualization != "" {
-		sb.WriteString("## Impact Visualization\n\n")
+		sb.WriteString("## " + translator.T("Impact Visualization") + "\n\n")
 		sb.WriteString("```dot\n")
 		sb.WriteString(report.Visualization)
 		if !strings.HasSuffix(report.Visualization, "\n") {
@@ -258,7 +263,7 @@ func RenderReportMarkdown(report *LegislativeImpactReport) (string, error) {
 
 	// Footer
 	sb.WriteString("---\n")
-	sb.WriteString(fmt.Sprintf("*Generated by regula on %s*\n", report.GeneratedAt.Format("2006-01-02")))
+	sb.WriteString("*" + translator.Sprintf("Generated by regula on %s", report.GeneratedAt.Format("2006-01-02")) + "*\n")
 
 	return sb.String(), nil
 }
@@ -281,6 +286,13 @@ func RenderReportJSON(report *LegislativeImpactReport) (string, error) {
 // RenderReportHTML converts a LegislativeImpactReport into a self-contained HTML
 // document with inline CSS styling. No external dependencies are required.
 func RenderReportHTML(report *LegislativeImpactReport) (string, error) {
+	return RenderReportHTMLLocalized(report, nil)
+}
+
+// RenderReportHTMLLocalized renders the HTML report in the translator's
+// language and sets the document's lang attribute to match. A nil
+// translator renders English.
+func RenderReportHTMLLocalized(report *LegislativeImpactReport, translator *i18n.Translator) (string, error) {
 	if report == nil {
 		return "", fmt.Errorf("report is nil")
 	}
@@ -288,13 +300,14 @@ func RenderReportHTML(report *LegislativeImpactReport) (string, error) {
 	var sb strings.Builder
 
 	// HTML header with inline CSS
-	sb.WriteString(`<!DOCTYPE html>
-<html lang="en">
+	sb.WriteString(fmt.Sprintf(`<!DOCTYPE html>
+<html lang="%s">
 <head>
 <meta charset="UTF-8">
 <meta name="viewport" content="width=device-width, initial-scale=1.0">
-<title>Legislative Impact Report</title>
-<style>
+<title>%s</title>
+`, translator.Language(), html.EscapeString(translator.T("Legislative Impact Report"))))
+	sb.WriteString(`<style>
 :root {
   --risk-high: #dc3545;
   --risk-medium: #fd7e14;
@@ -410,84 +423,85 @@ pre {
 		billTitle = report.Bill.BillNumber
 	}
 	if billTitle == "" {
-		billTitle = "Draft Legislation"
+		billTitle = translator.T("Draft Legislation")
 	}
-	sb.WriteString(fmt.Sprintf("<h1>Legislative Impact Report: %s</h1>\n", html.EscapeString(billTitle)))
+	sb.WriteString(fmt.Sprintf("<h1>%s: %s</h1>\n",
+		html.EscapeString(translator.T("Legislative Impact Report")), html.EscapeString(billTitle)))
 
 	// Risk level header
 	riskClass := "risk-low"
-	riskLabel := "LOW RISK"
+	riskLabel := translator.T("LOW RISK")
 	switch report.RiskLevel {
 	case RiskHigh:
 		riskClass = "risk-high"
-		riskLabel = "HIGH RISK"
+		riskLabel = translator.T("HIGH RISK")
 	case RiskMedium:
 		riskClass = "risk-medium"
-		riskLabel = "MEDIUM RISK"
+		riskLabel = translator.T("MEDIUM RISK")
 	}
-	sb.WriteString(fmt.Sprintf("<div class=\"risk-header %s\">%s</div>\n", riskClass, riskLabel))
+	sb.WriteString(fmt.Sprintf("<div class=\"risk-header %s\">%s</div>\n", riskClass, html.EscapeString(riskLabel)))
 
 	if report.ExecutiveSummary.RiskJustification != "" {
 		sb.WriteString(fmt.Sprintf("<p><em>%s</em></p>\n", html.EscapeString(report.ExecutiveSummary.RiskJustification)))
 	}
 
 	// Executive Summary as cards
-	sb.WriteString("<h2>Executive Summary</h2>\n")
+	sb.WriteString(htmlHeading(translator, "h2", "Executive Summary"))
 	sb.WriteString("<div class=\"summary-grid\">\n")
 
 	summary := report.ExecutiveSummary
-	writeHTMLSummaryCard(&sb, "Amendments", fmt.Sprintf("%d", summary.AmendmentCount))
-	writeHTMLSummaryCard(&sb, "Modified", fmt.Sprintf("%d", summary.ProvisionsModified))
-	writeHTMLSummaryCard(&sb, "Repealed", fmt.Sprintf("%d", summary.ProvisionsRepealed))
-	writeHTMLSummaryCard(&sb, "Added", fmt.Sprintf("%d", summary.ProvisionsAdded))
-	writeHTMLSummaryCard(&sb, "Total Affected", fmt.Sprintf("%d", summary.TotalProvisionsAffected))
-	writeHTMLSummaryCard(&sb, "Broken Refs", fmt.Sprintf("%d", summary.BrokenCrossRefs))
-	writeHTMLSummaryCard(&sb, "Conflict Errors", fmt.Sprintf("%d", summary.ConflictErrors))
-	writeHTMLSummaryCard(&sb, "Conflict Warnings", fmt.Sprintf("%d", summary.ConflictWarnings))
+	writeHTMLSummaryCard(&sb, translator.T("Amendments"), fmt.Sprintf("%d", summary.AmendmentCount))
+	writeHTMLSummaryCard(&sb, translator.T("Modified"), fmt.Sprintf("%d", summary.ProvisionsModified))
+	writeHTMLSummaryCard(&sb, translator.T("Repealed"), fmt.Sprintf("%d", summary.ProvisionsRepealed))
+	writeHTMLSummaryCard(&sb, translator.T("Added"), fmt.Sprintf("%d", summary.ProvisionsAdded))
+	writeHTMLSummaryCard(&sb, translator.T("Total Affected"), fmt.Sprintf("%d", summary.TotalProvisionsAffected))
+	writeHTMLSummaryCard(&sb, translator.T("Broken Refs"), fmt.Sprintf("%d", summary.BrokenCrossRefs))
+	writeHTMLSummaryCard(&sb, translator.T("Conflict Errors"), fmt.Sprintf("%d", summary.ConflictErrors))
+	writeHTMLSummaryCard(&sb, translator.T("Conflict Warnings"), fmt.Sprintf("%d", summary.ConflictWarnings))
 
 	sb.WriteString("</div>\n")
 
 	// Obligation and Rights changes
 	if summary.ObligationsAdded > 0 || summary.ObligationsRemoved > 0 || summary.RightsAdded > 0 || summary.RightsRemoved > 0 {
-		sb.WriteString("<h3>Obligation &amp; Rights Changes</h3>\n")
+		sb.WriteString(htmlHeading(translator, "h3", "Obligation & Rights Changes"))
 		sb.WriteString("<div class=\"summary-grid\">\n")
-		writeHTMLSummaryCard(&sb, "Obligations Added", fmt.Sprintf("%d", summary.ObligationsAdded))
-		writeHTMLSummaryCard(&sb, "Obligations Removed", fmt.Sprintf("%d", summary.ObligationsRemoved))
-		writeHTMLSummaryCard(&sb, "Rights Added", fmt.Sprintf("%d", summary.RightsAdded))
-		writeHTMLSummaryCard(&sb, "Rights Removed", fmt.Sprintf("%d", summary.RightsRemoved))
+		writeHTMLSummaryCard(&sb, translator.T("Obligations Added"), fmt.Sprintf("%d", summary.ObligationsAdded))
+		writeHTMLSummaryCard(&sb, translator.T("Obligations Removed"), fmt.Sprintf("%d", summary.ObligationsRemoved))
+		writeHTMLSummaryCard(&sb, translator.T("Rights Added"), fmt.Sprintf("%d", summary.RightsAdded))
+		writeHTMLSummaryCard(&sb, translator.T("Rights Removed"), fmt.Sprintf("%d", summary.RightsRemoved))
 		sb.WriteString("</div>\n")
 	}
 
 	// Structural Changes
 	if report.Diff != nil && hasDiffEntries(report.Diff) {
-		sb.WriteString("<h2>Structural Changes</h2>\n")
+		sb.WriteString(htmlHeading(translator, "h2", "Structural Changes"))
 		sb.WriteString("<table>\n")
-		sb.WriteString("<tr><th>Type</th><th>Target</th><th>Description</th></tr>\n")
+		sb.WriteString(htmlTableHeader(translator, "Type", "Target", "Description"))
 
-		writeDiffRowsHTML(&sb, report.Diff.Modified, "Modified")
-		writeDiffRowsHTML(&sb, report.Diff.Removed, "Repealed")
-		writeDiffRowsHTML(&sb, report.Diff.Added, "Added")
-		writeDiffRowsHTML(&sb, report.Diff.Redesignated, "Redesignated")
+		writeDiffRowsHTML(&sb, translator, report.Diff.Modified, "Modified")
+		writeDiffRowsHTML(&sb, translator, report.Diff.Removed, "Repealed")
+		writeDiffRowsHTML(&sb, translator, report.Diff.Added, "Added")
+		writeDiffRowsHTML(&sb, translator, report.Diff.Redesignated, "Redesignated")
 
 		sb.WriteString("</table>\n")
 	}
 
 	// Conflict Findings
 	if report.Conflicts != nil && len(report.Conflicts.Conflicts) > 0 {
-		sb.WriteString("<h2>Conflict Findings</h2>\n")
+		sb.WriteString(htmlHeading(translator, "h2", "Conflict Findings"))
 		sb.WriteString("<table>\n")
-		sb.WriteString("<tr><th>Severity</th><th>Type</th><th>Description</th></tr>\n")
+		sb.WriteString(htmlTableHeader(translator, "Severity", "Type", "Description"))
 
 		for _, conflict := range report.Conflicts.Conflicts {
 			severityClass := "severity-info"
-			severityLabel := "Info"
+			severityLabel := translator.T("Info")
 			switch conflict.Severity {
 			case ConflictError:
 				severityClass = "severity-error"
-				severityLabel = "Error"
+				severityLabel = translator.T("Error")
 			case ConflictWarning:
 				severityClass = "severity-warning"
-				severityLabel = "Warning"
+				severityLabel = translator.T("Warning")
 			}
 			sb.WriteString(fmt.Sprintf("<tr><td class=\"%s\">%s</td><td>%s</td><td>%s</td></tr>\n",
 				severityClass, severityLabel,
@@ -500,20 +514,20 @@ pre {
 
 	// Temporal Findings
 	if len(report.TemporalFindings) > 0 {
-		sb.WriteString("<h2>Temporal Analysis</h2>\n")
+		sb.WriteString(htmlHeading(translator, "h2", "Temporal Analysis"))
 		sb.WriteString("<table>\n")
-		sb.WriteString("<tr><th>Severity</th><th>Type</th><th>Finding</th></tr>\n")
+		sb.WriteString(htmlTableHeader(translator, "Severity", "Type", "Finding"))
 
 		for _, finding := range report.TemporalFindings {
 			severityClass := "severity-info"
-			severityLabel := "Info"
+			severityLabel := translator.T("Info")
 			switch finding.Severity {
 			case ConflictError:
 				severityClass = "severity-error"
-				severityLabel = "Error"
+				severityLabel = translator.T("Error")
 			case ConflictWarning:
 				severityClass = "severity-warning"
-				severityLabel = "Warning"
+				severityLabel = translator.T("Warning")
 			}
 			sb.WriteString(fmt.Sprintf("<tr><td class=\"%s\">%s</td><td>%s</td><td>%s</td></tr>\n",
 				severityClass, severityLabel,
@@ -526,20 +540,20 @@ pre {
 
 	// Broken Cross-References
 	if report.Impact != nil && len(report.Impact.BrokenCrossRefs) > 0 {
-		sb.WriteString("<h2>Broken Cross-References</h2>\n")
+		sb.WriteString(htmlHeading(translator, "h2", "Broken Cross-References"))
 		sb.WriteString("<table>\n")
-		sb.WriteString("<tr><th>Severity</th><th>Source</th><th>Target</th><th>Reason</th></tr>\n")
+		sb.WriteString(htmlTableHeader(translator, "Severity", "Source", "Target", "Reason"))
 
 		for _, ref := range report.Impact.BrokenCrossRefs {
 			severityClass := "severity-info"
-			severityLabel := "Info"
+			severityLabel := translator.T("Info")
 			switch ref.Severity {
 			case SeverityError:
 				severityClass = "severity-error"
-				severityLabel = "Error"
+				severityLabel = translator.T("Error")
 			case SeverityWarning:
 				severityClass = "severity-warning"
-				severityLabel = "Warning"
+				severityLabel = translator.T("Warning")
 			}
 			sb.WriteString(fmt.Sprintf("<tr><td class=\"%s\">%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
 				severityClass, severityLabel,
@@ -553,31 +567,31 @@ pre {
 
 	// Scenario Comparisons
 	if len(report.ScenarioResults) > 0 {
-		sb.WriteString("<h2>Scenario Comparisons</h2>\n")
+		sb.WriteString(htmlHeading(translator, "h2", "Scenario Comparisons"))
 
 		for _, comparison := range report.ScenarioResults {
 			sb.WriteString(fmt.Sprintf("<h3>%s</h3>\n", html.EscapeString(comparison.Scenario)))
 			sum := comparison.GetSummary()
 
 			if !sum.HasDifferences {
-				sb.WriteString("<p>No differences detected between baseline and proposed.</p>\n")
+				sb.WriteString("<p>" + html.EscapeString(translator.T("No differences detected between baseline and proposed.")) + "</p>\n")
 				continue
 			}
 
 			sb.WriteString("<table>\n")
-			sb.WriteString("<tr><th>Metric</th><th>Count</th></tr>\n")
-			sb.WriteString(fmt.Sprintf("<tr><td>Newly Applicable</td><td>%d</td></tr>\n", sum.NewlyApplicable))
-			sb.WriteString(fmt.Sprintf("<tr><td>No Longer Applicable</td><td>%d</td></tr>\n", sum.NoLongerApplicable))
-			sb.WriteString(fmt.Sprintf("<tr><td>Changed Relevance</td><td>%d</td></tr>\n", sum.ChangedRelevance))
+			sb.WriteString(htmlTableHeader(translator, "Metric", "Count"))
+			sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Newly Applicable")), sum.NewlyApplicable))
+			sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("No Longer Applicable")), sum.NoLongerApplicable))
+			sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Changed Relevance")), sum.ChangedRelevance))
 			sb.WriteString("</table>\n")
 		}
 	}
 
 	// Visualization
 	if report.Visualization != "" {
-		sb.WriteString("<h2>Impact Visualization</h2>\n")
+		sb.WriteString(htmlHeading(translator, "h2", "Impact Visualization"))
 		sb.WriteString("<details>\n")
-		sb.WriteString("<summary>View DOT Graph Source</summary>\n")
+		sb.WriteString("<summary>" + html.EscapeString(translator.T("View DOT Graph Source")) + "</summary>\n")
 		sb.WriteString("<pre>\n")
 		sb.WriteString(html.EscapeString(report.Visualization))
 		sb.WriteString("</pre>\n")
@@ -586,7 +600,7 @@ pre {
 
 	// Footer
 	sb.WriteString("<div class=\"footer\">\n")
-	sb.WriteString(fmt.Sprintf("Generated by regula on %s\n", report.GeneratedAt.Format(time.RFC3339)))
+	sb.WriteString(html.EscapeString(translator.Sprintf("Generated by regula on %s", report.GeneratedAt.Format(time.RFC3339))) + "\n")
 	sb.WriteString("</div>\n")
 
 	// Collapsible sections script
@@ -640,10 +654,8 @@ func truncateMarkdown(text string, maxLen int) string {
 	text = strings.ReplaceAll(text, "\r", "")
 	text = strings.TrimSpace(text)
 
-	if len(text) <= maxLen {
-		return text
-	}
-	return text[:maxLen-3] + "..."
+	// Isolate right-to-left text so it cannot reorder the table cell
+	return textutil.IsolateBidi(textutil.Truncate(text, maxLen))
 }
 
 // hasDiffEntries checks if a diff has any entries.
@@ -660,8 +672,25 @@ func writeHTMLSummaryCard(sb *strings.Builder, title, value string) {
 	sb.WriteString("</div>\n")
 }
 
-// writeDiffRowsHTML writes table rows for diff entries.
-func writeDiffRowsHTML(sb *strings.Builder, entries []DiffEntry, changeType string) {
+// htmlHeading returns a translated heading element.
+func htmlHeading(translator *i18n.Translator, tag, text string) string {
+	return fmt.Sprintf("<%s>%s</%s>\n", tag, html.EscapeString(translator.T(text)), tag)
+}
+
+// htmlTableHeader returns a translated table header row.
+func htmlTableHeader(translator *i18n.Translator, columns ...string) string {
+	var sb strings.Builder
+	sb.WriteString("<tr>")
+	for _, column := range columns {
+		sb.WriteString("<th>" + html.EscapeString(translator.T(column)) + "</th>")
+	}
+	sb.WriteString("</tr>\n")
+	return sb.String()
+}
+
+// writeDiffRowsHTML writes table rows for diff entries. changeType is the
+// English change label and is translated for display.
+func writeDiffRowsHTML(sb *strings.Builder, translator *i18n.Translator, entries []DiffEntry, changeType string) {
 	for _, entry := range entries {
 		desc := entry.Amendment.Description
 		if desc == "" {
@@ -675,10 +704,11 @@ func writeDiffRowsHTML(sb *strings.Builder, entries []DiffEntry, changeType stri
 			case "Redesignated":
 				desc = "Redesignate"
 			}
+			desc = translator.T(desc)
 		}
 		target := formatTargetForMarkdown(entry.Amendment)
 		sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%s</td></tr>\n",
-			html.EscapeString(changeType),
+			html.EscapeString(translator.T(changeType)),
 			html.EscapeString(target),
 			html.EscapeString(truncateMarkdown(desc, 80))))
 	}
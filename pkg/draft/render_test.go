@@ -5,6 +5,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/coolbeans/regula/pkg/i18n"
 )
 
 func TestRenderReportMarkdown(t *testing.T) {
@@ -563,3 +565,48 @@ func createTestReport() *LegislativeImpactReport {
 		},
 	}
 }
+
+func TestRenderReportLocalized(t *testing.T) {
+	report := createTestReport()
+	translator, err := i18n.New("de")
+	if err != nil {
+		t.Fatalf("i18n.New failed: %v", err)
+	}
+
+	md, err := RenderReportMarkdownLocalized(report, translator)
+	if err != nil {
+		t.Fatalf("RenderReportMarkdownLocalized failed: %v", err)
+	}
+	for _, expected := range []string{
+		"# Bericht zur Gesetzesfolgenabschätzung:",
+		"## Zusammenfassung",
+		"| Typ | Ziel | Beschreibung |",
+		"*Erstellt von regula am",
+	} {
+		if !strings.Contains(md, expected) {
+			t.Errorf("localized Markdown missing %q", expected)
+		}
+	}
+
+	htmlOutput, err := RenderReportHTMLLocalized(report, translator)
+	if err != nil {
+		t.Fatalf("RenderReportHTMLLocalized failed: %v", err)
+	}
+	for _, expected := range []string{
+		`<html lang="de">`,
+		"<h2>Zusammenfassung</h2>",
+		"<th>Schweregrad</th>",
+	} {
+		if !strings.Contains(htmlOutput, expected) {
+			t.Errorf("localized HTML missing %q", expected)
+		}
+	}
+
+	english, err := RenderReportHTML(report)
+	if err != nil {
+		t.Fatalf("RenderReportHTML failed: %v", err)
+	}
+	if !strings.Contains(english, `<html lang="en">`) {
+		t.Error("English HTML should declare lang=\"en\"")
+	}
+}
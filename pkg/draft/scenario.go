@@ -24,22 +24,50 @@ type ScenarioOverlay struct {
 	// SkippedAmendments tracks amendments that couldn't be applied
 	SkippedAmendments []SkippedAmendment
 
+	// DocumentStores holds the overlay graph of each amended document,
+	// keyed by document ID; OverlayStore merges them
+	DocumentStores map[string]*store.TripleStore
+
+	// Patches records the triples each applied amendment removed and
+	// added, when requested with OverlayOptions.RecordPatches
+	Patches []AmendmentPatch
+
 	// Stats provides summary statistics
 	Stats OverlayStats
+
+	recordPatches bool
 }
 
 // SkippedAmendment records an amendment that couldn't be applied and why.
 type SkippedAmendment struct {
-	Amendment Amendment
-	Reason    string
+	Amendment Amendment `json:"amendment"`
+	Reason    string    `json:"reason"`
 }
 
 // OverlayStats provides summary statistics about the overlay application.
 type OverlayStats struct {
-	TriplesRemoved int
-	TriplesAdded   int
-	BaseTriples    int
-	OverlayTriples int
+	TriplesRemoved int `json:"triples_removed"`
+	TriplesAdded   int `json:"triples_added"`
+	BaseTriples    int `json:"base_triples"`
+	OverlayTriples int `json:"overlay_triples"`
+}
+
+// OverlayOptions configures ApplyDraftOverlayWithOptions.
+type OverlayOptions struct {
+	// RecordPatches records the change each amendment makes in
+	// ScenarioOverlay.Patches. It snapshots the document graph before
+	// every amendment, so it is off unless the patches are wanted.
+	RecordPatches bool
+}
+
+// AmendmentPatch is the change one applied amendment made to its target
+// document's graph.
+type AmendmentPatch struct {
+	Amendment  Amendment                  `json:"amendment"`
+	DocumentID string                     `json:"document_id"`
+	TargetURI  string                     `json:"target_uri"`
+	Removed    []library.SerializedTriple `json:"removed"`
+	Added      []library.SerializedTriple `json:"added"`
 }
 
 // ApplyDraftOverlay creates a non-destructive overlay of the base triple store
@@ -49,6 +77,12 @@ type OverlayStats struct {
 // For modified sections: removes old triples for the target, inserts new from draft text
 // For added sections: inserts new triples from draft text
 func ApplyDraftOverlay(diff *DraftDiff, libraryPath string) (*ScenarioOverlay, error) {
+	return ApplyDraftOverlayWithOptions(diff, libraryPath, OverlayOptions{})
+}
+
+// ApplyDraftOverlayWithOptions is ApplyDraftOverlay with options, such as
+// recording each amendment's patch.
+func ApplyDraftOverlayWithOptions(diff *DraftDiff, libraryPath string, opts OverlayOptions) (*ScenarioOverlay, error) {
 	if diff == nil {
 		return nil, fmt.Errorf("diff is nil")
 	}
@@ -62,6 +96,7 @@ func ApplyDraftOverlay(diff *DraftDiff, libraryPath string) (*ScenarioOverlay, e
 		BaseLibraryPath:   libraryPath,
 		AppliedAmendments: []Amendment{},
 		SkippedAmendments: []SkippedAmendment{},
+		recordPatches:     opts.RecordPatches,
 	}
 
 	// Cache triple stores by document ID, cloned for modification
@@ -101,13 +136,25 @@ func ApplyDraftOverlay(diff *DraftDiff, libraryPath string) (*ScenarioOverlay, e
 			continue
 		}
 
+		before := overlay.snapshot(cloned)
 		removed := applyRepeal(entry.TargetURI, cloned)
 		overlay.Stats.TriplesRemoved += removed
 		overlay.AppliedAmendments = append(overlay.AppliedAmendments, entry.Amendment)
+		overlay.recordPatch(entry, before, cloned)
 	}
 
 	// Process modified sections
 	for _, entry := range diff.Modified {
+		// Tables of contents are not part of the graph; applying one as a
+		// modification would replace the provision it names
+		if entry.Amendment.Type == AmendTableOfContents {
+			overlay.SkippedAmendments = append(overlay.SkippedAmendments, SkippedAmendment{
+				Amendment: entry.Amendment,
+				Reason:    "table of contents changes are not represented in the graph",
+			})
+			continue
+		}
+
 		cloned, err := getOrCloneStore(entry.TargetDocumentID)
 		if err != nil {
 			overlay.SkippedAmendments = append(overlay.SkippedAmendments, SkippedAmendment{
@@ -117,7 +164,8 @@ func ApplyDraftOverlay(diff *DraftDiff, libraryPath string) (*ScenarioOverlay, e
 			continue
 		}
 
-		removed, added, applyErr := applyModification(entry, cloned, lib.BaseURI())
+		before := overlay.snapshot(cloned)
+		removed, added, applyErr := applyModification(entry, cloned, lib.DocumentBaseURI(entry.TargetDocumentID))
 		if applyErr != nil {
 			overlay.SkippedAmendments = append(overlay.SkippedAmendments, SkippedAmendment{
 				Amendment: entry.Amendment,
@@ -129,6 +177,7 @@ func ApplyDraftOverlay(diff *DraftDiff, libraryPath string) (*ScenarioOverlay, e
 		overlay.Stats.TriplesRemoved += removed
 		overlay.Stats.TriplesAdded += added
 		overlay.AppliedAmendments = append(overlay.AppliedAmendments, entry.Amendment)
+		overlay.recordPatch(entry, before, cloned)
 	}
 
 	// Process added sections
@@ -142,7 +191,8 @@ func ApplyDraftOverlay(diff *DraftDiff, libraryPath string) (*ScenarioOverlay, e
 			continue
 		}
 
-		added, applyErr := applyAddition(entry, cloned, lib.BaseURI())
+		before := overlay.snapshot(cloned)
+		added, applyErr := applyAddition(entry, cloned, lib.DocumentBaseURI(entry.TargetDocumentID))
 		if applyErr != nil {
 			overlay.SkippedAmendments = append(overlay.SkippedAmendments, SkippedAmendment{
 				Amendment: entry.Amendment,
@@ -153,6 +203,7 @@ func ApplyDraftOverlay(diff *DraftDiff, libraryPath string) (*ScenarioOverlay, e
 
 		overlay.Stats.TriplesAdded += added
 		overlay.AppliedAmendments = append(overlay.AppliedAmendments, entry.Amendment)
+		overlay.recordPatch(entry, before, cloned)
 	}
 
 	// Process redesignated sections (similar to modifications)
@@ -166,6 +217,7 @@ func ApplyDraftOverlay(diff *DraftDiff, libraryPath string) (*ScenarioOverlay, e
 			continue
 		}
 
+		before := overlay.snapshot(cloned)
 		added, applyErr := applyRedesignation(entry, cloned)
 		if applyErr != nil {
 			overlay.SkippedAmendments = append(overlay.SkippedAmendments, SkippedAmendment{
@@ -177,9 +229,11 @@ func ApplyDraftOverlay(diff *DraftDiff, libraryPath string) (*ScenarioOverlay, e
 
 		overlay.Stats.TriplesAdded += added
 		overlay.AppliedAmendments = append(overlay.AppliedAmendments, entry.Amendment)
+		overlay.recordPatch(entry, before, cloned)
 	}
 
 	// Merge all cloned stores into a single overlay store
+	overlay.DocumentStores = clonedStores
 	overlay.OverlayStore = store.NewTripleStore()
 	for _, cloned := range clonedStores {
 		overlay.OverlayStore.MergeFrom(cloned)
@@ -189,6 +243,47 @@ func ApplyDraftOverlay(diff *DraftDiff, libraryPath string) (*ScenarioOverlay, e
 	return overlay, nil
 }
 
+// snapshot returns the triples of a document graph before an amendment is
+// applied, or nil when patches are not recorded.
+func (overlay *ScenarioOverlay) snapshot(tripleStore *store.TripleStore) map[store.Triple]bool {
+	if !overlay.recordPatches {
+		return nil
+	}
+	triples := make(map[store.Triple]bool, tripleStore.Count())
+	for _, triple := range tripleStore.All() {
+		triples[triple] = true
+	}
+	return triples
+}
+
+// recordPatch records the difference between a snapshot and the graph
+// after the amendment.
+func (overlay *ScenarioOverlay) recordPatch(entry DiffEntry, before map[store.Triple]bool, tripleStore *store.TripleStore) {
+	if before == nil {
+		return
+	}
+	patch := AmendmentPatch{
+		Amendment:  entry.Amendment,
+		DocumentID: entry.TargetDocumentID,
+		TargetURI:  entry.TargetURI,
+		Removed:    []library.SerializedTriple{},
+		Added:      []library.SerializedTriple{},
+	}
+	for _, triple := range tripleStore.All() {
+		if before[triple] {
+			delete(before, triple)
+			continue
+		}
+		patch.Added = append(patch.Added, library.FromStoreTriple(triple))
+	}
+	for triple := range before {
+		patch.Removed = append(patch.Removed, library.FromStoreTriple(triple))
+	}
+	sortSerializedTriples(patch.Added)
+	sortSerializedTriples(patch.Removed)
+	overlay.Patches = append(overlay.Patches, patch)
+}
+
 // applyRepeal removes all triples where the targetURI is the subject.
 // Returns the number of triples removed.
 func applyRepeal(targetURI string, tripleStore *store.TripleStore) int {
@@ -189,6 +189,39 @@ func TestApplyDraftOverlay_Modification(t *testing.T) {
 	}
 }
 
+func TestApplyDraftOverlay_TableOfContentsSkipped(t *testing.T) {
+	_, libraryPath := testLibrary(t, "us-usc-title-15", buildScenarioTriples())
+
+	art6502URI := "https://regula.dev/regulations/US-USC-TITLE-15:Art6502"
+	diff := &DraftDiff{
+		Bill: &DraftBill{BillNumber: "HR-TEST-TOC", Title: "Test Table Act"},
+		Modified: []DiffEntry{
+			{
+				Amendment: Amendment{
+					Type:          AmendTableOfContents,
+					TargetTitle:   "15",
+					TargetSection: "6502",
+					StrikeText:    "section 6502",
+				},
+				TargetURI:        art6502URI,
+				TargetDocumentID: "us-usc-title-15",
+			},
+		},
+	}
+
+	overlay, err := ApplyDraftOverlay(diff, libraryPath)
+	if err != nil {
+		t.Fatalf("ApplyDraftOverlay failed: %v", err)
+	}
+	if len(overlay.SkippedAmendments) != 1 || len(overlay.AppliedAmendments) != 0 {
+		t.Fatalf("applied %d, skipped %d amendments, want the table of contents amendment skipped",
+			len(overlay.AppliedAmendments), len(overlay.SkippedAmendments))
+	}
+	if overlay.Stats.TriplesRemoved != 0 {
+		t.Errorf("TriplesRemoved = %d, want 0", overlay.Stats.TriplesRemoved)
+	}
+}
+
 func TestApplyDraftOverlay_Addition(t *testing.T) {
 	// Setup
 	_, libraryPath := testLibrary(t, "us-usc-title-15", buildScenarioTriples())
@@ -9,6 +9,7 @@ import (
 
 	"github.com/coolbeans/regula/pkg/library"
 	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // TemporalIssueType classifies the kind of temporal consistency issue detected
@@ -449,8 +450,5 @@ func findRelatedProvisionsWithTemporalConflict(targetURI string, tripleStore *st
 
 // truncateText truncates text to maxLen characters, adding "..." if truncated.
 func truncateText(text string, maxLen int) string {
-	if len(text) <= maxLen {
-		return text
-	}
-	return text[:maxLen-3] + "..."
+	return textutil.Truncate(text, maxLen)
 }
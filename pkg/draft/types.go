@@ -16,7 +16,8 @@ const (
 	AmendStrikeInsert AmendmentType = "strike_insert"
 	// AmendRepeal removes an existing provision entirely.
 	AmendRepeal AmendmentType = "repeal"
-	// AmendAddNewSection inserts a new section into existing law.
+	// AmendAddNewSection inserts a new provision (section, subsection,
+	// paragraph, ...) into existing law at the amendment's Position.
 	AmendAddNewSection AmendmentType = "add_new_section"
 	// AmendAddAtEnd appends content to the end of an existing section.
 	AmendAddAtEnd AmendmentType = "add_at_end"
@@ -37,6 +38,51 @@ type Amendment struct {
 	StrikeText       string        `json:"strike_text,omitempty"`
 	InsertText       string        `json:"insert_text,omitempty"`
 	Description      string        `json:"description,omitempty"`
+
+	// Position locates inserted text for add and insert amendments:
+	// "after paragraph (3)", "before "operator"", "at the end of
+	// subsection (c)".
+	Position *AmendmentPosition `json:"position,omitempty"`
+	// Redesignations lists every provision a redesignation renumbers.
+	// StrikeText and InsertText hold the first of them.
+	Redesignations []Redesignation `json:"redesignations,omitempty"`
+	// EffectiveDate is set from an "EFFECTIVE DATE" subsection of the
+	// amending section.
+	EffectiveDate *EffectiveDateInfo `json:"effective_date,omitempty"`
+}
+
+// PositionRelation says where an amendment places new text relative to the
+// anchor of its Position.
+type PositionRelation string
+
+const (
+	// PositionAfter inserts immediately after the anchor.
+	PositionAfter PositionRelation = "after"
+	// PositionBefore inserts immediately before the anchor.
+	PositionBefore PositionRelation = "before"
+	// PositionEnd appends at the end of the anchor, or of the target
+	// provision when there is no anchor.
+	PositionEnd PositionRelation = "end"
+)
+
+// AmendmentPosition locates inserted text within the target provision. The
+// anchor is either a provision, named by Level and Designation ("paragraph"
+// and "(3)"), or quoted Text.
+type AmendmentPosition struct {
+	Relation    PositionRelation `json:"relation"`
+	Level       string           `json:"level,omitempty"`
+	Designation string           `json:"designation,omitempty"`
+	Text        string           `json:"text,omitempty"`
+}
+
+// Redesignation is one provision renumbered or relettered by an
+// AmendRedesignate amendment. ToLevel is set when the provision also
+// changes level, as when paragraphs become subparagraphs.
+type Redesignation struct {
+	Level   string `json:"level"`
+	From    string `json:"from"`
+	ToLevel string `json:"to_level,omitempty"`
+	To      string `json:"to"`
 }
 
 // DraftSection represents a numbered section within a draft bill. Sections
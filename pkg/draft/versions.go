@@ -0,0 +1,292 @@
+package draft
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AmendmentChange pairs an amendment in the base version of a bill with the
+// amendment to the same target in the target version when their type or
+// text differs.
+type AmendmentChange struct {
+	Base   Amendment `json:"base"`
+	Target Amendment `json:"target"`
+}
+
+// VersionComparison reports how the amendments and analysis findings of a
+// bill changed between two versions, such as introduced (IH) and reported
+// (RH). "New" findings appear only in the target version; "resolved"
+// findings appear only in the base version.
+type VersionComparison struct {
+	Base       *DraftBill `json:"base"`
+	Target     *DraftBill `json:"target"`
+	BaseRisk   RiskLevel  `json:"base_risk"`
+	TargetRisk RiskLevel  `json:"target_risk"`
+
+	AddedAmendments     []Amendment       `json:"added_amendments"`
+	RemovedAmendments   []Amendment       `json:"removed_amendments"`
+	ChangedAmendments   []AmendmentChange `json:"changed_amendments"`
+	UnchangedAmendments int               `json:"unchanged_amendments"`
+
+	NewImpacts               []AffectedProvision `json:"new_impacts"`
+	ResolvedImpacts          []AffectedProvision `json:"resolved_impacts"`
+	NewBrokenRefs            []BrokenReference   `json:"new_broken_refs"`
+	ResolvedBrokenRefs       []BrokenReference   `json:"resolved_broken_refs"`
+	NewConflicts             []Conflict          `json:"new_conflicts"`
+	ResolvedConflicts        []Conflict          `json:"resolved_conflicts"`
+	NewTemporalFindings      []TemporalFinding   `json:"new_temporal_findings"`
+	ResolvedTemporalFindings []TemporalFinding   `json:"resolved_temporal_findings"`
+}
+
+// HasChanges reports whether the versions differ in amendments or findings.
+func (c *VersionComparison) HasChanges() bool {
+	return len(c.AddedAmendments) > 0 || len(c.RemovedAmendments) > 0 || len(c.ChangedAmendments) > 0 ||
+		len(c.NewImpacts) > 0 || len(c.ResolvedImpacts) > 0 ||
+		len(c.NewBrokenRefs) > 0 || len(c.ResolvedBrokenRefs) > 0 ||
+		len(c.NewConflicts) > 0 || len(c.ResolvedConflicts) > 0 ||
+		len(c.NewTemporalFindings) > 0 || len(c.ResolvedTemporalFindings) > 0
+}
+
+// CompareVersions compares the reports generated for two versions of the
+// same bill. Either report may lack impact, conflict, or temporal results,
+// in which case that category is treated as empty.
+func CompareVersions(base, target *LegislativeImpactReport) *VersionComparison {
+	comparison := &VersionComparison{
+		Base:       base.Bill,
+		Target:     target.Bill,
+		BaseRisk:   base.RiskLevel,
+		TargetRisk: target.RiskLevel,
+	}
+
+	comparison.AddedAmendments, comparison.RemovedAmendments, comparison.ChangedAmendments,
+		comparison.UnchangedAmendments = CompareAmendments(base.Bill, target.Bill)
+
+	comparison.NewImpacts, comparison.ResolvedImpacts = diffByKey(
+		affectedProvisions(base.Impact), affectedProvisions(target.Impact),
+		func(provision AffectedProvision) string { return provision.URI })
+
+	comparison.NewBrokenRefs, comparison.ResolvedBrokenRefs = diffByKey(
+		brokenReferences(base.Impact), brokenReferences(target.Impact),
+		func(ref BrokenReference) string { return ref.SourceURI + "|" + ref.Predicate + "|" + ref.TargetURI })
+
+	comparison.NewConflicts, comparison.ResolvedConflicts = diffByKey(
+		reportConflicts(base.Conflicts), reportConflicts(target.Conflicts),
+		func(conflict Conflict) string {
+			return conflict.Type.String() + "|" + conflict.ExistingProvision + "|" + amendmentTargetKey(conflict.SourceAmendment)
+		})
+
+	comparison.NewTemporalFindings, comparison.ResolvedTemporalFindings = diffByKey(
+		base.TemporalFindings, target.TemporalFindings,
+		func(finding TemporalFinding) string {
+			return finding.Type.String() + "|" + strings.Join(finding.Provisions, ",") + "|" + finding.Description
+		})
+
+	return comparison
+}
+
+// CompareAmendments matches the amendments of two bill versions by target
+// provision. Amendments to the same target are paired in the order they
+// appear; a pair whose type or text differs is a change, and unpaired
+// amendments are added (target only) or removed (base only).
+func CompareAmendments(base, target *DraftBill) (added, removed []Amendment, changed []AmendmentChange, unchanged int) {
+	baseByKey := make(map[string][]Amendment)
+	for _, amendment := range billAmendments(base) {
+		key := amendmentTargetKey(amendment)
+		baseByKey[key] = append(baseByKey[key], amendment)
+	}
+
+	for _, amendment := range billAmendments(target) {
+		key := amendmentTargetKey(amendment)
+		candidates := baseByKey[key]
+		if len(candidates) == 0 {
+			added = append(added, amendment)
+			continue
+		}
+		match := candidates[0]
+		baseByKey[key] = candidates[1:]
+		if match.Type == amendment.Type && match.StrikeText == amendment.StrikeText && match.InsertText == amendment.InsertText {
+			unchanged++
+		} else {
+			changed = append(changed, AmendmentChange{Base: match, Target: amendment})
+		}
+	}
+
+	// Remaining base amendments, in bill order
+	for _, amendment := range billAmendments(base) {
+		key := amendmentTargetKey(amendment)
+		if len(baseByKey[key]) > 0 {
+			removed = append(removed, baseByKey[key][0])
+			baseByKey[key] = baseByKey[key][1:]
+		}
+	}
+	return added, removed, changed, unchanged
+}
+
+// amendmentTargetKey identifies the provision an amendment targets.
+func amendmentTargetKey(amendment Amendment) string {
+	return amendment.TargetTitle + "|" + amendment.TargetSection + "|" + amendment.TargetSubsection
+}
+
+func billAmendments(bill *DraftBill) []Amendment {
+	if bill == nil {
+		return nil
+	}
+	var amendments []Amendment
+	for _, section := range bill.Sections {
+		amendments = append(amendments, section.Amendments...)
+	}
+	return amendments
+}
+
+func affectedProvisions(impact *DraftImpactResult) []AffectedProvision {
+	if impact == nil {
+		return nil
+	}
+	provisions := append([]AffectedProvision{}, impact.DirectlyAffected...)
+	return append(provisions, impact.TransitivelyAffected...)
+}
+
+func brokenReferences(impact *DraftImpactResult) []BrokenReference {
+	if impact == nil {
+		return nil
+	}
+	return impact.BrokenCrossRefs
+}
+
+func reportConflicts(conflicts *ConflictReport) []Conflict {
+	if conflicts == nil {
+		return nil
+	}
+	return conflicts.Conflicts
+}
+
+// diffByKey returns the items of target whose key is not in base, and the
+// items of base whose key is not in target, each in their original order.
+func diffByKey[T any](base, target []T, key func(T) string) (onlyTarget, onlyBase []T) {
+	baseKeys := make(map[string]bool, len(base))
+	for _, item := range base {
+		baseKeys[key(item)] = true
+	}
+	targetKeys := make(map[string]bool, len(target))
+	for _, item := range target {
+		k := key(item)
+		targetKeys[k] = true
+		if !baseKeys[k] {
+			onlyTarget = append(onlyTarget, item)
+		}
+	}
+	for _, item := range base {
+		if !targetKeys[key(item)] {
+			onlyBase = append(onlyBase, item)
+		}
+	}
+	return onlyTarget, onlyBase
+}
+
+// RenderVersionComparisonMarkdown renders a version comparison as a
+// Markdown document.
+func RenderVersionComparisonMarkdown(c *VersionComparison) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Bill Version Comparison\n\n")
+	sb.WriteString(fmt.Sprintf("**Base:** %s  \n", versionLabel(c.Base)))
+	sb.WriteString(fmt.Sprintf("**Target:** %s  \n", versionLabel(c.Target)))
+	sb.WriteString(fmt.Sprintf("**Risk:** %s → %s\n\n", c.BaseRisk, c.TargetRisk))
+
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString("| Category | New | Resolved |\n")
+	sb.WriteString("|----------|-----|----------|\n")
+	sb.WriteString(fmt.Sprintf("| Amendments | %d | %d |\n", len(c.AddedAmendments), len(c.RemovedAmendments)))
+	sb.WriteString(fmt.Sprintf("| Affected provisions | %d | %d |\n", len(c.NewImpacts), len(c.ResolvedImpacts)))
+	sb.WriteString(fmt.Sprintf("| Broken cross-references | %d | %d |\n", len(c.NewBrokenRefs), len(c.ResolvedBrokenRefs)))
+	sb.WriteString(fmt.Sprintf("| Conflicts | %d | %d |\n", len(c.NewConflicts), len(c.ResolvedConflicts)))
+	sb.WriteString(fmt.Sprintf("| Temporal findings | %d | %d |\n\n", len(c.NewTemporalFindings), len(c.ResolvedTemporalFindings)))
+	sb.WriteString(fmt.Sprintf("%d amendment(s) changed, %d unchanged.\n\n", len(c.ChangedAmendments), c.UnchangedAmendments))
+
+	if len(c.AddedAmendments)+len(c.RemovedAmendments)+len(c.ChangedAmendments) > 0 {
+		sb.WriteString("## Amendments\n\n")
+		sb.WriteString("| Change | Target | Type | Description |\n")
+		sb.WriteString("|--------|--------|------|-------------|\n")
+		for _, amendment := range c.AddedAmendments {
+			sb.WriteString(fmt.Sprintf("| Added | %s | %s | %s |\n", formatTargetForMarkdown(amendment),
+				amendment.Type, truncateMarkdown(amendment.Description, 80)))
+		}
+		for _, amendment := range c.RemovedAmendments {
+			sb.WriteString(fmt.Sprintf("| Removed | %s | %s | %s |\n", formatTargetForMarkdown(amendment),
+				amendment.Type, truncateMarkdown(amendment.Description, 80)))
+		}
+		for _, change := range c.ChangedAmendments {
+			changeType := string(change.Target.Type)
+			if change.Base.Type != change.Target.Type {
+				changeType = fmt.Sprintf("%s → %s", change.Base.Type, change.Target.Type)
+			}
+			sb.WriteString(fmt.Sprintf("| Changed | %s | %s | %s |\n", formatTargetForMarkdown(change.Target),
+				changeType, truncateMarkdown(change.Target.Description, 80)))
+		}
+		sb.WriteString("\n")
+	}
+
+	writeFindingList := func(heading string, newItems, resolvedItems []string) {
+		if len(newItems)+len(resolvedItems) == 0 {
+			return
+		}
+		sb.WriteString(fmt.Sprintf("## %s\n\n", heading))
+		for _, item := range newItems {
+			sb.WriteString(fmt.Sprintf("- **New:** %s\n", item))
+		}
+		for _, item := range resolvedItems {
+			sb.WriteString(fmt.Sprintf("- **Resolved:** %s\n", item))
+		}
+		sb.WriteString("\n")
+	}
+
+	writeFindingList("Affected Provisions", impactLines(c.NewImpacts), impactLines(c.ResolvedImpacts))
+	writeFindingList("Broken Cross-References", brokenRefLines(c.NewBrokenRefs), brokenRefLines(c.ResolvedBrokenRefs))
+	writeFindingList("Conflicts", conflictLines(c.NewConflicts), conflictLines(c.ResolvedConflicts))
+	writeFindingList("Temporal Findings", temporalLines(c.NewTemporalFindings), temporalLines(c.ResolvedTemporalFindings))
+
+	return sb.String()
+}
+
+// versionLabel names a bill version by number and file.
+func versionLabel(bill *DraftBill) string {
+	if bill == nil {
+		return "(none)"
+	}
+	if bill.Filename != "" {
+		return fmt.Sprintf("%s (%s)", bill.BillNumber, bill.Filename)
+	}
+	return bill.BillNumber
+}
+
+func impactLines(provisions []AffectedProvision) []string {
+	lines := make([]string, len(provisions))
+	for i, provision := range provisions {
+		lines[i] = fmt.Sprintf("%s (depth %d)", truncateMarkdown(provision.Label, 80), provision.Depth)
+	}
+	return lines
+}
+
+func brokenRefLines(refs []BrokenReference) []string {
+	lines := make([]string, len(refs))
+	for i, ref := range refs {
+		lines[i] = fmt.Sprintf("%s → %s (%s)", truncateMarkdown(ref.SourceLabel, 60), truncateMarkdown(ref.TargetLabel, 60), ref.Reason)
+	}
+	return lines
+}
+
+func conflictLines(conflicts []Conflict) []string {
+	lines := make([]string, len(conflicts))
+	for i, conflict := range conflicts {
+		lines[i] = fmt.Sprintf("[%s] %s: %s", conflict.Severity, conflict.Type, truncateMarkdown(conflict.Description, 120))
+	}
+	return lines
+}
+
+func temporalLines(findings []TemporalFinding) []string {
+	lines := make([]string, len(findings))
+	for i, finding := range findings {
+		lines[i] = fmt.Sprintf("[%s] %s: %s", finding.Severity, finding.Type, truncateMarkdown(finding.Description, 120))
+	}
+	return lines
+}
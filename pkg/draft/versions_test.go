@@ -0,0 +1,144 @@
+package draft
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func versionTestBill(number string, amendments ...Amendment) *DraftBill {
+	return &DraftBill{
+		BillNumber: number,
+		Sections:   []*DraftSection{{Number: "1", Amendments: amendments}},
+	}
+}
+
+func TestCompareAmendments(t *testing.T) {
+	base := versionTestBill("H.R. 1234",
+		Amendment{Type: AmendStrikeInsert, TargetTitle: "15", TargetSection: "6502", StrikeText: "13", InsertText: "16"},
+		Amendment{Type: AmendRepeal, TargetTitle: "15", TargetSection: "6505"},
+		Amendment{Type: AmendAddAtEnd, TargetTitle: "15", TargetSection: "6501", InsertText: "(13) new definition"},
+	)
+	target := versionTestBill("H.R. 1234",
+		Amendment{Type: AmendStrikeInsert, TargetTitle: "15", TargetSection: "6502", StrikeText: "13", InsertText: "17"},
+		Amendment{Type: AmendAddAtEnd, TargetTitle: "15", TargetSection: "6501", InsertText: "(13) new definition"},
+		Amendment{Type: AmendAddNewSection, TargetTitle: "15", TargetSection: "6507"},
+	)
+
+	added, removed, changed, unchanged := CompareAmendments(base, target)
+
+	if len(added) != 1 || added[0].TargetSection != "6507" {
+		t.Errorf("added = %+v, want §6507", added)
+	}
+	if len(removed) != 1 || removed[0].TargetSection != "6505" {
+		t.Errorf("removed = %+v, want §6505", removed)
+	}
+	if len(changed) != 1 || changed[0].Base.InsertText != "16" || changed[0].Target.InsertText != "17" {
+		t.Errorf("changed = %+v, want §6502 16 → 17", changed)
+	}
+	if unchanged != 1 {
+		t.Errorf("unchanged = %d, want 1", unchanged)
+	}
+}
+
+func TestCompareAmendments_RepeatedTarget(t *testing.T) {
+	amendment := Amendment{Type: AmendStrikeInsert, TargetTitle: "15", TargetSection: "6502", StrikeText: "a", InsertText: "b"}
+	base := versionTestBill("H.R. 1", amendment)
+	target := versionTestBill("H.R. 1", amendment, amendment)
+
+	added, removed, changed, unchanged := CompareAmendments(base, target)
+	if len(added) != 1 || len(removed) != 0 || len(changed) != 0 || unchanged != 1 {
+		t.Errorf("got %d added, %d removed, %d changed, %d unchanged; want 1, 0, 0, 1",
+			len(added), len(removed), len(changed), unchanged)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	repeal := Amendment{Type: AmendRepeal, TargetTitle: "15", TargetSection: "6505"}
+	base := &LegislativeImpactReport{
+		Bill:      versionTestBill("H.R. 1234", repeal),
+		RiskLevel: RiskHigh,
+		Impact: &DraftImpactResult{
+			DirectlyAffected: []AffectedProvision{{URI: "urn:6505", Label: "15 USC 6505", Depth: 0}},
+			BrokenCrossRefs: []BrokenReference{
+				{SourceURI: "urn:6506", Predicate: "references", TargetURI: "urn:6505", SourceLabel: "15 USC 6506", TargetLabel: "15 USC 6505"},
+			},
+		},
+		Conflicts: &ConflictReport{Conflicts: []Conflict{
+			{Type: ConflictObligationContradiction, Severity: ConflictError, SourceAmendment: repeal, ExistingProvision: "urn:6506", Description: "repeal contradicts 6506"},
+		}},
+		TemporalFindings: []TemporalFinding{
+			{Type: TemporalGap, Severity: ConflictWarning, Description: "gap before enactment", Provisions: []string{"urn:6505"}},
+		},
+	}
+	target := &LegislativeImpactReport{
+		Bill:      versionTestBill("H.R. 1234"),
+		RiskLevel: RiskLow,
+		Impact: &DraftImpactResult{
+			TransitivelyAffected: []AffectedProvision{{URI: "urn:6502", Label: "15 USC 6502", Depth: 1}},
+		},
+		TemporalFindings: []TemporalFinding{
+			{Type: TemporalGap, Severity: ConflictWarning, Description: "gap before enactment", Provisions: []string{"urn:6505"}},
+		},
+	}
+
+	comparison := CompareVersions(base, target)
+
+	if !comparison.HasChanges() {
+		t.Fatal("expected changes between versions")
+	}
+	if comparison.BaseRisk != RiskHigh || comparison.TargetRisk != RiskLow {
+		t.Errorf("risk = %s → %s, want high → low", comparison.BaseRisk, comparison.TargetRisk)
+	}
+	if len(comparison.RemovedAmendments) != 1 || len(comparison.AddedAmendments) != 0 {
+		t.Errorf("amendments: %d added, %d removed; want 0, 1", len(comparison.AddedAmendments), len(comparison.RemovedAmendments))
+	}
+	if len(comparison.NewImpacts) != 1 || comparison.NewImpacts[0].URI != "urn:6502" {
+		t.Errorf("new impacts = %+v, want urn:6502", comparison.NewImpacts)
+	}
+	if len(comparison.ResolvedImpacts) != 1 || comparison.ResolvedImpacts[0].URI != "urn:6505" {
+		t.Errorf("resolved impacts = %+v, want urn:6505", comparison.ResolvedImpacts)
+	}
+	if len(comparison.ResolvedBrokenRefs) != 1 || len(comparison.NewBrokenRefs) != 0 {
+		t.Errorf("broken refs: %d new, %d resolved; want 0, 1", len(comparison.NewBrokenRefs), len(comparison.ResolvedBrokenRefs))
+	}
+	if len(comparison.ResolvedConflicts) != 1 || len(comparison.NewConflicts) != 0 {
+		t.Errorf("conflicts: %d new, %d resolved; want 0, 1", len(comparison.NewConflicts), len(comparison.ResolvedConflicts))
+	}
+	if len(comparison.NewTemporalFindings) != 0 || len(comparison.ResolvedTemporalFindings) != 0 {
+		t.Errorf("temporal findings should match across versions, got %d new, %d resolved",
+			len(comparison.NewTemporalFindings), len(comparison.ResolvedTemporalFindings))
+	}
+
+	markdown := RenderVersionComparisonMarkdown(comparison)
+	for _, want := range []string{"# Bill Version Comparison", "| Removed |", "**New:** 15 USC 6502", "**Resolved:** [error]"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown missing %q", want)
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := comparison.WriteNDJSON(&buffer); err != nil {
+		t.Fatalf("WriteNDJSON failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("got %d NDJSON records, want 6:\n%s", len(lines), buffer.String())
+	}
+	if !strings.Contains(lines[5], `"record":"summary"`) || !strings.Contains(lines[5], `"has_changes":true`) {
+		t.Errorf("last record = %s, want summary with changes", lines[5])
+	}
+}
+
+func TestCompareVersions_Identical(t *testing.T) {
+	bill := versionTestBill("H.R. 1", Amendment{Type: AmendRepeal, TargetTitle: "15", TargetSection: "6505"})
+	report := &LegislativeImpactReport{Bill: bill}
+
+	comparison := CompareVersions(report, report)
+	if comparison.HasChanges() {
+		t.Errorf("identical versions reported changes: %+v", comparison)
+	}
+	if comparison.UnchangedAmendments != 1 {
+		t.Errorf("unchanged = %d, want 1", comparison.UnchangedAmendments)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // RenderImpactGraph generates a Graphviz DOT string representing the impact
@@ -335,10 +337,7 @@ func nodeLabel(label, uri string) string {
 
 // truncateLabel shortens a label to maxLen characters, appending "..." if truncated.
 func truncateLabel(label string, maxLen int) string {
-	if len(label) <= maxLen {
-		return label
-	}
-	return label[:maxLen-3] + "..."
+	return textutil.Truncate(label, maxLen)
 }
 
 // sanitizeDOTNodeID converts a URI into a valid DOT node identifier by
@@ -1,6 +1,7 @@
 package eurlex
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -77,12 +78,18 @@ func NewEURLexClient(config EURLexClientConfig) *EURLexClient {
 // A status code < 400 is considered valid (includes 200, 301, 302 redirects).
 // Network errors and status codes >= 400 are considered invalid.
 func (eurlexClient *EURLexClient) ValidateURI(uri string) (*ValidationResult, error) {
+	return eurlexClient.ValidateURIWithContext(context.Background(), uri)
+}
+
+// ValidateURIWithContext validates a URI with cancellation support. When ctx
+// is done the context error is returned and nothing is cached.
+func (eurlexClient *EURLexClient) ValidateURIWithContext(ctx context.Context, uri string) (*ValidationResult, error) {
 	// Check cache first.
 	if cachedResult, found := eurlexClient.cache.Get(uri); found {
 		return &cachedResult, nil
 	}
 
-	request, err := http.NewRequest(http.MethodHead, uri, nil)
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request for %s: %w", uri, err)
 	}
@@ -90,6 +97,9 @@ func (eurlexClient *EURLexClient) ValidateURI(uri string) (*ValidationResult, er
 
 	response, err := eurlexClient.httpClient.Do(request)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		// Network error — return as a validation result (not a Go error),
 		// since the failure is expected in normal operation.
 		networkErrorResult := ValidationResult{
@@ -37,10 +37,16 @@ func NewRateLimitedHTTPClient(underlying HTTPClient, requestInterval time.Durati
 }
 
 // Do executes an HTTP request, waiting for the rate limiter before sending.
+// The wait ends early if the request's context is done.
 func (rateLimitedClient *RateLimitedHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	rateLimitedClient.mu.Lock()
 	if !rateLimitedClient.closed {
-		<-rateLimitedClient.ticker.C
+		select {
+		case <-rateLimitedClient.ticker.C:
+		case <-req.Context().Done():
+			rateLimitedClient.mu.Unlock()
+			return nil, req.Context().Err()
+		}
 	}
 	rateLimitedClient.mu.Unlock()
 
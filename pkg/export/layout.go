@@ -0,0 +1,334 @@
+// Package export draws regulation graphs without external tools such as
+// Graphviz: it lays out a store.GraphExport itself, in layers or by
+// simulating forces, and renders the result as SVG.
+package export
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// Layout names a graph layout algorithm.
+type Layout string
+
+const (
+	// LayoutLayered places nodes in columns so that most edges point to the
+	// right, as Graphviz dot does with rankdir=LR.
+	LayoutLayered Layout = "layered"
+
+	// LayoutForce places nodes by simulating repulsion between every pair of
+	// nodes and attraction along edges (Fruchterman-Reingold).
+	LayoutForce Layout = "force"
+)
+
+// ParseLayout returns the layout with the given name.
+func ParseLayout(name string) (Layout, error) {
+	switch layout := Layout(strings.ToLower(name)); layout {
+	case LayoutLayered, LayoutForce:
+		return layout, nil
+	default:
+		return "", fmt.Errorf("unknown layout: %s (use layered or force)", name)
+	}
+}
+
+// Node geometry and spacing, in pixels.
+const (
+	nodeLabelLength = 30
+	charWidth       = 7.0
+	nodePadding     = 16.0
+	nodeHeight      = 28.0
+	layerGap        = 80.0
+	nodeGap         = 16.0
+	idealEdgeLength = 90.0
+)
+
+// Iteration counts of the layout heuristics.
+const (
+	barycenterSweeps = 8
+	forceIterations  = 150
+)
+
+// Box is the placement of a node: the center of its rectangle and its size.
+type Box struct {
+	X, Y          float64
+	Width, Height float64
+}
+
+// Arrange lays out the nodes of the graph and returns their boxes by node
+// ID. The boxes fit in the quadrant with the origin at the top left, and the
+// same graph always gets the same layout.
+func Arrange(graph *store.GraphExport, layout Layout) map[string]Box {
+	g := newLayoutGraph(graph)
+
+	var boxes []Box
+	if layout == LayoutForce {
+		boxes = g.force()
+	} else {
+		boxes = g.layered()
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	for _, box := range boxes {
+		minX = math.Min(minX, box.X-box.Width/2)
+		minY = math.Min(minY, box.Y-box.Height/2)
+	}
+	arranged := make(map[string]Box, len(boxes))
+	for i, box := range boxes {
+		box.X -= minX
+		box.Y -= minY
+		arranged[g.ids[i]] = box
+	}
+	return arranged
+}
+
+// nodeLabel returns the text drawn in a node.
+func nodeLabel(node store.GraphNode) string {
+	label := node.Label
+	if label == "" {
+		label = node.ID
+	}
+	return textutil.Truncate(strings.Join(strings.Fields(label), " "), nodeLabelLength)
+}
+
+// nodeWidth returns the width of a node box that fits its label.
+func nodeWidth(label string) float64 {
+	return float64(textutil.RuneLen(label))*charWidth + nodePadding
+}
+
+// layoutGraph is a graph with nodes numbered in ID order, for layout.
+type layoutGraph struct {
+	ids    []string
+	widths []float64
+	// edges are the distinct directed edges between different nodes.
+	edges [][2]int
+}
+
+func newLayoutGraph(graph *store.GraphExport) *layoutGraph {
+	nodes := append([]store.GraphNode(nil), graph.Nodes...)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+
+	g := &layoutGraph{}
+	index := make(map[string]int, len(nodes))
+	for _, node := range nodes {
+		if _, ok := index[node.ID]; ok {
+			continue
+		}
+		index[node.ID] = len(g.ids)
+		g.ids = append(g.ids, node.ID)
+		g.widths = append(g.widths, nodeWidth(nodeLabel(node)))
+	}
+
+	seen := make(map[[2]int]bool)
+	for _, edge := range graph.Edges {
+		source, sourceOK := index[edge.Source]
+		target, targetOK := index[edge.Target]
+		pair := [2]int{source, target}
+		if !sourceOK || !targetOK || source == target || seen[pair] {
+			continue
+		}
+		seen[pair] = true
+		g.edges = append(g.edges, pair)
+	}
+	sort.Slice(g.edges, func(i, j int) bool {
+		if g.edges[i][0] != g.edges[j][0] {
+			return g.edges[i][0] < g.edges[j][0]
+		}
+		return g.edges[i][1] < g.edges[j][1]
+	})
+	return g
+}
+
+// layered assigns nodes to columns by longest path after reversing the
+// edges that close cycles, orders each column by the barycenter of its
+// neighbors to reduce crossings, and centers the columns vertically.
+func (g *layoutGraph) layered() []Box {
+	n := len(g.ids)
+	successors := make([][]int, n)
+	for _, edge := range g.edges {
+		successors[edge[0]] = append(successors[edge[0]], edge[1])
+	}
+
+	// A depth-first search finds the edges that close cycles; its reverse
+	// postorder is a topological order of the graph without them.
+	const (
+		unvisited = iota
+		active
+		finished
+	)
+	state := make([]int, n)
+	predecessors := make([][]int, n)
+	postorder := make([]int, 0, n)
+	var visit func(node int)
+	visit = func(node int) {
+		state[node] = active
+		for _, next := range successors[node] {
+			switch state[next] {
+			case unvisited:
+				predecessors[next] = append(predecessors[next], node)
+				visit(next)
+			case active:
+				predecessors[node] = append(predecessors[node], next)
+			default:
+				predecessors[next] = append(predecessors[next], node)
+			}
+		}
+		state[node] = finished
+		postorder = append(postorder, node)
+	}
+	for node := 0; node < n; node++ {
+		if state[node] == unvisited {
+			visit(node)
+		}
+	}
+
+	layerOf := make([]int, n)
+	layerCount := 0
+	for i := len(postorder) - 1; i >= 0; i-- {
+		node := postorder[i]
+		for _, previous := range predecessors[node] {
+			if layerOf[previous]+1 > layerOf[node] {
+				layerOf[node] = layerOf[previous] + 1
+			}
+		}
+		if layerOf[node]+1 > layerCount {
+			layerCount = layerOf[node] + 1
+		}
+	}
+
+	layers := make([][]int, layerCount)
+	for node := 0; node < n; node++ {
+		layers[layerOf[node]] = append(layers[layerOf[node]], node)
+	}
+	neighbors := make([][]int, n)
+	for _, edge := range g.edges {
+		neighbors[edge[0]] = append(neighbors[edge[0]], edge[1])
+		neighbors[edge[1]] = append(neighbors[edge[1]], edge[0])
+	}
+
+	// rank is a node's position within its column, scaled to [0, 1] so that
+	// columns of different heights can be compared.
+	rank := make([]float64, n)
+	updateRanks := func(layer []int) {
+		for i, node := range layer {
+			rank[node] = float64(i+1) / float64(len(layer)+1)
+		}
+	}
+	for _, layer := range layers {
+		updateRanks(layer)
+	}
+	for sweep := 0; sweep < barycenterSweeps; sweep++ {
+		downward := sweep%2 == 0
+		for step := 1; step < layerCount; step++ {
+			l := step
+			if !downward {
+				l = layerCount - 1 - step
+			}
+			key := make(map[int]float64, len(layers[l]))
+			for _, node := range layers[l] {
+				sum, count := 0.0, 0
+				for _, neighbor := range neighbors[node] {
+					if (downward && layerOf[neighbor] < l) || (!downward && layerOf[neighbor] > l) {
+						sum += rank[neighbor]
+						count++
+					}
+				}
+				key[node] = rank[node]
+				if count > 0 {
+					key[node] = sum / float64(count)
+				}
+			}
+			sort.SliceStable(layers[l], func(i, j int) bool { return key[layers[l][i]] < key[layers[l][j]] })
+			updateRanks(layers[l])
+		}
+	}
+
+	tallest := 0
+	for _, layer := range layers {
+		if len(layer) > tallest {
+			tallest = len(layer)
+		}
+	}
+	boxes := make([]Box, n)
+	x := 0.0
+	for _, layer := range layers {
+		columnWidth := 0.0
+		for _, node := range layer {
+			columnWidth = math.Max(columnWidth, g.widths[node])
+		}
+		offset := float64(tallest-len(layer)) * (nodeHeight + nodeGap) / 2
+		for i, node := range layer {
+			boxes[node] = Box{
+				X:      x + columnWidth/2,
+				Y:      offset + float64(i)*(nodeHeight+nodeGap) + nodeHeight/2,
+				Width:  g.widths[node],
+				Height: nodeHeight,
+			}
+		}
+		x += columnWidth + layerGap
+	}
+	return boxes
+}
+
+// force runs a Fruchterman-Reingold simulation from a sunflower spiral,
+// with weak gravity so that disconnected parts stay close.
+func (g *layoutGraph) force() []Box {
+	n := len(g.ids)
+	const goldenAngle = 2.399963229728653
+	x, y := make([]float64, n), make([]float64, n)
+	for i := range x {
+		radius := idealEdgeLength * math.Sqrt(float64(i))
+		x[i] = radius * math.Cos(float64(i)*goldenAngle)
+		y[i] = radius * math.Sin(float64(i)*goldenAngle)
+	}
+
+	k := idealEdgeLength
+	startTemperature := k * (1 + math.Sqrt(float64(n))/4)
+	dx, dy := make([]float64, n), make([]float64, n)
+	for iteration := 0; iteration < forceIterations; iteration++ {
+		for i := range dx {
+			dx[i], dy[i] = -x[i]*0.01, -y[i]*0.01
+		}
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				ddx, ddy := x[i]-x[j], y[i]-y[j]
+				distance := math.Max(math.Hypot(ddx, ddy), 1)
+				push := k * k / (distance * distance)
+				dx[i] += ddx * push
+				dy[i] += ddy * push
+				dx[j] -= ddx * push
+				dy[j] -= ddy * push
+			}
+		}
+		for _, edge := range g.edges {
+			i, j := edge[0], edge[1]
+			ddx, ddy := x[i]-x[j], y[i]-y[j]
+			pull := math.Hypot(ddx, ddy) / k
+			dx[i] -= ddx * pull
+			dy[i] -= ddy * pull
+			dx[j] += ddx * pull
+			dy[j] += ddy * pull
+		}
+
+		temperature := startTemperature * (1 - float64(iteration)/forceIterations)
+		for i := range x {
+			length := math.Hypot(dx[i], dy[i])
+			if length == 0 {
+				continue
+			}
+			step := math.Min(length, temperature)
+			x[i] += dx[i] / length * step
+			y[i] += dy[i] / length * step
+		}
+	}
+
+	boxes := make([]Box, n)
+	for i := range boxes {
+		boxes[i] = Box{X: x[i], Y: y[i], Width: g.widths[i], Height: nodeHeight}
+	}
+	return boxes
+}
@@ -0,0 +1,84 @@
+package export
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// testGraph returns a chain of articles a -> b -> c with a cycle back from
+// c to a, and a term used by b.
+func testGraph() *store.GraphExport {
+	return &store.GraphExport{
+		Nodes: []store.GraphNode{
+			{ID: "c", Label: "Article 3", Type: "Article"},
+			{ID: "a", Label: "Article 1", Type: "Article"},
+			{ID: "b", Label: "Article 2", Type: "Article"},
+			{ID: "term", Label: "personal <data>", Type: "DefinedTerm"},
+		},
+		Edges: []store.GraphEdge{
+			{Source: "a", Target: "b", Label: "references"},
+			{Source: "b", Target: "c", Label: "references"},
+			{Source: "c", Target: "a", Label: "references"},
+			{Source: "b", Target: "term", Label: "usesTerm"},
+			{Source: "b", Target: "b", Label: "references"},
+		},
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+	for _, name := range []string{"layered", "Force"} {
+		if _, err := ParseLayout(name); err != nil {
+			t.Errorf("ParseLayout(%q) error = %v", name, err)
+		}
+	}
+	if _, err := ParseLayout("circular"); err == nil {
+		t.Error("Expected an error for an unknown layout")
+	}
+}
+
+func TestArrange_Layered(t *testing.T) {
+	boxes := Arrange(testGraph(), LayoutLayered)
+	if len(boxes) != 4 {
+		t.Fatalf("Expected 4 boxes, got %d", len(boxes))
+	}
+	if !(boxes["a"].X < boxes["b"].X && boxes["b"].X < boxes["c"].X) {
+		t.Errorf("Expected the chain to run left to right, got a=%v b=%v c=%v", boxes["a"], boxes["b"], boxes["c"])
+	}
+	if boxes["c"].X != boxes["term"].X || boxes["c"].Y == boxes["term"].Y {
+		t.Errorf("Expected c and term to share a column without overlapping, got c=%v term=%v", boxes["c"], boxes["term"])
+	}
+	assertInQuadrant(t, boxes)
+}
+
+func TestArrange_Force(t *testing.T) {
+	boxes := Arrange(testGraph(), LayoutForce)
+	if len(boxes) != 4 {
+		t.Fatalf("Expected 4 boxes, got %d", len(boxes))
+	}
+	for id, box := range boxes {
+		for otherID, other := range boxes {
+			if id != otherID && box.X == other.X && box.Y == other.Y {
+				t.Errorf("Expected %s and %s at different positions, both at (%v, %v)", id, otherID, box.X, box.Y)
+			}
+		}
+	}
+	assertInQuadrant(t, boxes)
+
+	again := Arrange(testGraph(), LayoutForce)
+	for id, box := range boxes {
+		if again[id] != box {
+			t.Errorf("Expected a stable layout for %s, got %v then %v", id, box, again[id])
+		}
+	}
+}
+
+func assertInQuadrant(t *testing.T, boxes map[string]Box) {
+	t.Helper()
+	for id, box := range boxes {
+		if box.X-box.Width/2 < -1e-9 || box.Y-box.Height/2 < -1e-9 {
+			t.Errorf("Expected %s inside the quadrant, got %s", id, fmt.Sprint(box))
+		}
+	}
+}
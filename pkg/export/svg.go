@@ -0,0 +1,233 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// SVG canvas spacing, in pixels.
+const (
+	svgMargin        = 20.0
+	legendHeight     = 28.0
+	legendSwatch     = 12.0
+	legendEntryGap   = 20.0
+	defaultEdgeColor = "black"
+)
+
+// edgeColors are the stroke colors of edges by label, matching ToDOT.
+var edgeColors = map[string]string{
+	"partOf":            "blue",
+	"contains":          "blue",
+	"references":        "red",
+	"referencedBy":      "red",
+	"defines":           "green",
+	"definedIn":         "green",
+	"usesTerm":          "purple",
+	"definitionUses":    "purple",
+	"grantsRight":       "orange",
+	"imposesObligation": "brown",
+}
+
+// SVGRenderer draws a GraphExport as a standalone SVG image, with nodes
+// colored by type, a legend of the node types, and labeled edges.
+type SVGRenderer struct {
+	layout     Layout
+	edgeLabels bool
+}
+
+// SVGOption is a functional option for configuring the SVGRenderer.
+type SVGOption func(*SVGRenderer)
+
+// NewSVGRenderer creates an SVGRenderer that uses the layered layout and
+// labels edges.
+func NewSVGRenderer(options ...SVGOption) *SVGRenderer {
+	renderer := &SVGRenderer{
+		layout:     LayoutLayered,
+		edgeLabels: true,
+	}
+
+	for _, option := range options {
+		option(renderer)
+	}
+
+	return renderer
+}
+
+// WithLayout sets the layout algorithm.
+func WithLayout(layout Layout) SVGOption {
+	return func(renderer *SVGRenderer) {
+		renderer.layout = layout
+	}
+}
+
+// WithoutEdgeLabels draws edges without their labels, which keeps dense
+// graphs legible.
+func WithoutEdgeLabels() SVGOption {
+	return func(renderer *SVGRenderer) {
+		renderer.edgeLabels = false
+	}
+}
+
+// Render lays out the graph and returns it as an SVG document.
+func (renderer *SVGRenderer) Render(graph *store.GraphExport) string {
+	boxes := Arrange(graph, renderer.layout)
+
+	nodes := append([]store.GraphNode(nil), graph.Nodes...)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	edges := append([]store.GraphEdge(nil), graph.Edges...)
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Source != edges[j].Source {
+			return edges[i].Source < edges[j].Source
+		}
+		if edges[i].Target != edges[j].Target {
+			return edges[i].Target < edges[j].Target
+		}
+		return edges[i].Label < edges[j].Label
+	})
+
+	typeSet := make(map[string]bool)
+	for _, node := range nodes {
+		typeSet[node.Type] = true
+	}
+	nodeTypes := make([]string, 0, len(typeSet))
+	for nodeType := range typeSet {
+		nodeTypes = append(nodeTypes, nodeType)
+	}
+	sort.Strings(nodeTypes)
+
+	legendWidth := 0.0
+	for _, nodeType := range nodeTypes {
+		legendWidth += legendEntryWidth(nodeType)
+	}
+	graphWidth, graphHeight := 0.0, 0.0
+	for _, box := range boxes {
+		graphWidth = math.Max(graphWidth, box.X+box.Width/2)
+		graphHeight = math.Max(graphHeight, box.Y+box.Height/2)
+	}
+	width := math.Max(graphWidth, legendWidth) + 2*svgMargin
+	height := graphHeight + legendHeight + 2*svgMargin
+	offsetX, offsetY := svgMargin, svgMargin+legendHeight
+
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s">`+"\n",
+		coordinate(width), coordinate(height), coordinate(width), coordinate(height)))
+	sb.WriteString("<style>text { font-family: Helvetica, Arial, sans-serif; font-size: 12px; } " +
+		".edge text { font-size: 9px; fill: #555; }</style>\n")
+
+	colorSet := make(map[string]bool)
+	for _, edge := range edges {
+		colorSet[edgeColor(edge.Label)] = true
+	}
+	colors := make([]string, 0, len(colorSet))
+	for color := range colorSet {
+		colors = append(colors, color)
+	}
+	sort.Strings(colors)
+	sb.WriteString("<defs>\n")
+	for _, color := range colors {
+		sb.WriteString(fmt.Sprintf(`<marker id="arrow-%s" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto"><path d="M0,0 L10,5 L0,10 z" fill="%s"/></marker>`+"\n",
+			color, color))
+	}
+	sb.WriteString("</defs>\n")
+	sb.WriteString(`<rect width="100%" height="100%" fill="white"/>` + "\n")
+
+	sb.WriteString(`<g class="legend">` + "\n")
+	x := svgMargin
+	for _, nodeType := range nodeTypes {
+		sb.WriteString(fmt.Sprintf(`<rect x="%s" y="%s" width="%s" height="%s" fill="%s" stroke="#333"/>`,
+			coordinate(x), coordinate(svgMargin), coordinate(legendSwatch), coordinate(legendSwatch), nodeFill(nodeType)))
+		sb.WriteString(fmt.Sprintf(`<text x="%s" y="%s">%s</text>`+"\n",
+			coordinate(x+legendSwatch+4), coordinate(svgMargin+legendSwatch-2), html.EscapeString(nodeType)))
+		x += legendEntryWidth(nodeType)
+	}
+	sb.WriteString("</g>\n")
+
+	for _, edge := range edges {
+		source, sourceOK := boxes[edge.Source]
+		target, targetOK := boxes[edge.Target]
+		if !sourceOK || !targetOK || edge.Source == edge.Target {
+			continue
+		}
+		x1, y1 := borderPoint(source, target.X, target.Y)
+		x2, y2 := borderPoint(target, source.X, source.Y)
+		x1, y1, x2, y2 = x1+offsetX, y1+offsetY, x2+offsetX, y2+offsetY
+		color := edgeColor(edge.Label)
+		sb.WriteString(`<g class="edge">`)
+		sb.WriteString(fmt.Sprintf("<title>%s</title>", html.EscapeString(edge.Source+" "+edge.Label+" "+edge.Target)))
+		sb.WriteString(fmt.Sprintf(`<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-opacity="0.6" marker-end="url(#arrow-%s)"/>`,
+			coordinate(x1), coordinate(y1), coordinate(x2), coordinate(y2), color, color))
+		if renderer.edgeLabels && edge.Label != "" {
+			sb.WriteString(fmt.Sprintf(`<text x="%s" y="%s" text-anchor="middle">%s</text>`,
+				coordinate((x1+x2)/2), coordinate((y1+y2)/2-2), html.EscapeString(edge.Label)))
+		}
+		sb.WriteString("</g>\n")
+	}
+
+	for _, node := range nodes {
+		box, ok := boxes[node.ID]
+		if !ok {
+			continue
+		}
+		centerX, centerY := box.X+offsetX, box.Y+offsetY
+		sb.WriteString(fmt.Sprintf(`<g class="node" data-type="%s">`, html.EscapeString(node.Type)))
+		sb.WriteString(fmt.Sprintf("<title>%s</title>", html.EscapeString(node.ID)))
+		sb.WriteString(fmt.Sprintf(`<rect x="%s" y="%s" width="%s" height="%s" rx="4" fill="%s" stroke="#333"/>`,
+			coordinate(centerX-box.Width/2), coordinate(centerY-box.Height/2), coordinate(box.Width), coordinate(box.Height),
+			nodeFill(node.Type)))
+		sb.WriteString(fmt.Sprintf(`<text x="%s" y="%s" text-anchor="middle" dominant-baseline="central">%s</text>`,
+			coordinate(centerX), coordinate(centerY), html.EscapeString(nodeLabel(node))))
+		sb.WriteString("</g>\n")
+	}
+
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// legendEntryWidth returns the width of a node type's swatch and name in
+// the legend, with the gap that follows.
+func legendEntryWidth(nodeType string) float64 {
+	return legendSwatch + 4 + float64(textutil.RuneLen(nodeType))*charWidth + legendEntryGap
+}
+
+// nodeFill returns the fill color of a node type, white for types without
+// one.
+func nodeFill(nodeType string) string {
+	if color, ok := store.NodeTypeColors[nodeType]; ok {
+		return color
+	}
+	return "white"
+}
+
+// edgeColor returns the stroke color of an edge label.
+func edgeColor(label string) string {
+	if color, ok := edgeColors[label]; ok {
+		return color
+	}
+	return defaultEdgeColor
+}
+
+// borderPoint returns where the line from the center of box toward the
+// point (x, y) leaves the box, so that arrowheads stop at the border.
+func borderPoint(box Box, x, y float64) (float64, float64) {
+	dx, dy := x-box.X, y-box.Y
+	scale := 1.0
+	if dx != 0 {
+		scale = math.Min(scale, box.Width/2/math.Abs(dx))
+	}
+	if dy != 0 {
+		scale = math.Min(scale, box.Height/2/math.Abs(dy))
+	}
+	return box.X + dx*scale, box.Y + dy*scale
+}
+
+// coordinate formats a length for an SVG attribute.
+func coordinate(value float64) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", value), ".0")
+}
@@ -0,0 +1,58 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSVGRenderer_Render(t *testing.T) {
+	for _, layout := range []Layout{LayoutLayered, LayoutForce} {
+		output := NewSVGRenderer(WithLayout(layout)).Render(testGraph())
+
+		decoder := xml.NewDecoder(strings.NewReader(output))
+		for {
+			if _, err := decoder.Token(); err != nil {
+				if err != io.EOF {
+					t.Fatalf("%s: output is not well-formed XML: %v\n%s", layout, err, output)
+				}
+				break
+			}
+		}
+
+		for _, expected := range []string{
+			`<svg xmlns="http://www.w3.org/2000/svg"`,
+			`fill="#add8e6"`,
+			`fill="#ffb6c1"`,
+			">personal &lt;data&gt;</text>",
+			">references</text>",
+			`marker-end="url(#arrow-purple)"`,
+			">DefinedTerm</text>",
+		} {
+			if !strings.Contains(output, expected) {
+				t.Errorf("%s: expected SVG to contain %q", layout, expected)
+			}
+		}
+		if got := strings.Count(output, `<g class="edge">`); got != 4 {
+			t.Errorf("%s: expected 4 edges without the self-loop, got %d", layout, got)
+		}
+	}
+}
+
+func TestSVGRenderer_WithoutEdgeLabels(t *testing.T) {
+	output := NewSVGRenderer(WithoutEdgeLabels()).Render(testGraph())
+	if strings.Contains(output, ">references</text>") {
+		t.Errorf("Expected no edge labels, got:\n%s", output)
+	}
+}
+
+func TestBorderPoint(t *testing.T) {
+	box := Box{X: 0, Y: 0, Width: 40, Height: 20}
+	if x, y := borderPoint(box, 100, 0); x != 20 || y != 0 {
+		t.Errorf("borderPoint() toward the right = (%v, %v), want (20, 0)", x, y)
+	}
+	if x, y := borderPoint(box, 0, -100); x != 0 || y != -10 {
+		t.Errorf("borderPoint() upward = (%v, %v), want (0, -10)", x, y)
+	}
+}
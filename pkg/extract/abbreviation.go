@@ -0,0 +1,203 @@
+package extract
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Abbreviation is a short form a document introduces for a longer name, as
+// in "the European Data Protection Board (the ‘Board’)" or "Treaty on the
+// Functioning of the European Union (TFEU)".
+type Abbreviation struct {
+	ShortForm string `json:"short_form"`
+	Expansion string `json:"expansion"`
+
+	// ArticleNum or RecitalNum is the provision that introduces it.
+	ArticleNum int `json:"article_num,omitempty"`
+	RecitalNum int `json:"recital_num,omitempty"`
+}
+
+// abbreviationPattern matches a parenthesized short form: an acronym, or a
+// quoted name after an optional "hereinafter (referred to as)" and "the".
+var abbreviationPattern = regexp.MustCompile(`\((?:hereinafter(?:\s+referred\s+to\s+as)?\s+)?(?:(?:the\s+)?[‘'"“]((?:the\s+)?[\p{Lu}][\p{L}\-]*(?:\s+[\p{L}\-]+){0,3})[’'"”]|([\p{Lu}][\p{Lu}0-9&]*[\p{Lu}0-9]))\)`)
+
+// abbreviationConnectors are the lowercase words allowed inside a name,
+// which do not count toward an acronym.
+var abbreviationConnectors = map[string]bool{
+	"of": true, "the": true, "and": true, "for": true, "on": true, "in": true, "to": true,
+}
+
+// maxExpansionWords bounds how far back a name is looked for.
+const maxExpansionWords = 12
+
+// AbbreviationExtractor finds the abbreviations a document introduces.
+type AbbreviationExtractor struct{}
+
+// NewAbbreviationExtractor creates a new abbreviation extractor.
+func NewAbbreviationExtractor() *AbbreviationExtractor {
+	return &AbbreviationExtractor{}
+}
+
+// ExtractFromDocument returns the abbreviations introduced in the recitals
+// and articles of doc, each at its first introduction.
+func (e *AbbreviationExtractor) ExtractFromDocument(doc *Document) []*Abbreviation {
+	if doc == nil {
+		return nil
+	}
+
+	var abbreviations []*Abbreviation
+	seen := make(map[string]bool)
+	add := func(found []*Abbreviation) {
+		for _, abbreviation := range found {
+			if !seen[abbreviation.ShortForm] {
+				seen[abbreviation.ShortForm] = true
+				abbreviations = append(abbreviations, abbreviation)
+			}
+		}
+	}
+
+	if doc.Preamble != nil {
+		for _, recital := range doc.Preamble.Recitals {
+			found := e.ExtractFromText(recital.Text)
+			for _, abbreviation := range found {
+				abbreviation.RecitalNum = recital.Number
+			}
+			add(found)
+		}
+	}
+	for _, article := range doc.AllArticles() {
+		found := e.ExtractFromText(article.Text)
+		for _, abbreviation := range found {
+			abbreviation.ArticleNum = article.Number
+		}
+		add(found)
+	}
+	return abbreviations
+}
+
+// ExtractFromText returns the abbreviations introduced in text. An acronym
+// is expanded to the preceding words whose initials spell it; a quoted
+// name to the preceding capitalized name that contains it.
+func (e *AbbreviationExtractor) ExtractFromText(text string) []*Abbreviation {
+	var abbreviations []*Abbreviation
+	for _, match := range abbreviationPattern.FindAllStringSubmatchIndex(text, -1) {
+		words := precedingWords(text[:match[0]])
+
+		var abbreviation *Abbreviation
+		if match[2] >= 0 {
+			shortForm := strings.Join(strings.Fields(text[match[2]:match[3]]), " ")
+			shortForm = strings.TrimPrefix(shortForm, "the ")
+			if expansion := expandName(words, shortForm); expansion != "" {
+				abbreviation = &Abbreviation{ShortForm: shortForm, Expansion: expansion}
+			}
+		} else {
+			shortForm := text[match[4]:match[5]]
+			if expansion := expandAcronym(words, shortForm); expansion != "" {
+				abbreviation = &Abbreviation{ShortForm: shortForm, Expansion: expansion}
+			}
+		}
+		if abbreviation != nil {
+			abbreviations = append(abbreviations, abbreviation)
+		}
+	}
+	return abbreviations
+}
+
+// precedingWords returns up to maxExpansionWords words before a short
+// form, stopping at the punctuation that ends a clause.
+func precedingWords(text string) []string {
+	if index := strings.LastIndexAny(text, ".;:()"); index >= 0 {
+		text = text[index+1:]
+	}
+	words := strings.Fields(strings.TrimRight(text, " ,\n"))
+	if len(words) > maxExpansionWords {
+		words = words[len(words)-maxExpansionWords:]
+	}
+	return words
+}
+
+// expandAcronym returns the shortest run of words ending before the
+// acronym whose initials spell it, skipping connectors, as in "Treaty on
+// the Functioning of the European Union (TFEU)". When no run matches,
+// the letters may also come from inside the words, as in "deoxyribonucleic
+// acid (DNA)", provided the first and last words start with the first and
+// last letters.
+func expandAcronym(words []string, acronym string) string {
+	letters := []rune(strings.ToLower(acronym))
+	var initials []rune
+	for start := len(words) - 1; start >= 0; start-- {
+		word := []rune(words[start])
+		if abbreviationConnectors[strings.ToLower(words[start])] {
+			continue
+		}
+		initials = append([]rune{unicode.ToLower(word[0])}, initials...)
+		if len(initials) > len(letters) {
+			break
+		}
+		if string(initials) == string(letters) {
+			return strings.Join(words[start:], " ")
+		}
+	}
+
+	if len(words) == 0 || unicode.ToLower([]rune(words[len(words)-1])[0]) != letters[len(letters)-1] {
+		return ""
+	}
+	for start := len(words) - 2; start >= 0 && len(words)-start < len(letters); start-- {
+		candidate := []rune(strings.ToLower(strings.Join(words[start:], " ")))
+		if candidate[0] == letters[0] && containsInOrder(string(candidate[1:]), string(letters[1:])) {
+			return strings.Join(words[start:], " ")
+		}
+	}
+	return ""
+}
+
+// containsInOrder reports whether the letters appear in text in order.
+func containsInOrder(text, letters string) bool {
+	for _, letter := range letters {
+		index := strings.IndexRune(text, letter)
+		if index < 0 {
+			return false
+		}
+		text = text[index+len(string(letter)):]
+	}
+	return true
+}
+
+// expandName returns the capitalized name before a quoted short form that
+// repeats its words. The name starts at those words when a connector
+// follows it, as in "the Charter of Fundamental Rights of the European
+// Union (the ‘Charter’)", and otherwise spans the whole name, as in "The
+// European Data Protection Board (the ‘Board’)".
+func expandName(words []string, shortForm string) string {
+	start := len(words)
+	for start > 0 {
+		word := words[start-1]
+		first := []rune(word)[0]
+		if !unicode.IsUpper(first) && !unicode.IsDigit(first) && !abbreviationConnectors[word] {
+			break
+		}
+		start--
+	}
+	name := words[start:]
+
+	shortWords := strings.Fields(strings.ToLower(shortForm))
+	found := false
+	for i := 0; i+len(shortWords) <= len(name); i++ {
+		if strings.ToLower(strings.Join(name[i:i+len(shortWords)], " ")) != strings.Join(shortWords, " ") {
+			continue
+		}
+		found = true
+		if next := i + len(shortWords); next < len(name) && abbreviationConnectors[name[next]] {
+			name = name[i:]
+		}
+		break
+	}
+	for len(name) > 0 && abbreviationConnectors[strings.ToLower(name[0])] {
+		name = name[1:]
+	}
+	if !found || len(name) < 2 {
+		return ""
+	}
+	return strings.Join(name, " ")
+}
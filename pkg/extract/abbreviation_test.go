@@ -0,0 +1,115 @@
+package extract
+
+import "testing"
+
+func TestAbbreviationExtractor_ExtractFromText(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		shortForm string
+		expansion string
+	}{
+		{
+			name:      "acronym",
+			text:      "as enshrined in Article 16(1) of the Treaty on the Functioning of the European Union (TFEU).",
+			shortForm: "TFEU",
+			expansion: "Treaty on the Functioning of the European Union",
+		},
+		{
+			name:      "acronym from inside words",
+			text:      "the analysis of deoxyribonucleic acid (DNA) or ribonucleic acid",
+			shortForm: "DNA",
+			expansion: "deoxyribonucleic acid",
+		},
+		{
+			name:      "quoted name starting the expansion",
+			text:      "recognised by the Charter of Fundamental Rights of the European Union (the ‘Charter’), in particular",
+			shortForm: "Charter",
+			expansion: "Charter of Fundamental Rights of the European Union",
+		},
+		{
+			name:      "quoted name ending the expansion",
+			text:      "The European Data Protection Board (the ‘Board’) is hereby established.",
+			shortForm: "Board",
+			expansion: "European Data Protection Board",
+		},
+		{
+			name:      "hereinafter",
+			text:      "the California Privacy Protection Agency (hereinafter referred to as \"Agency\") shall",
+			shortForm: "Agency",
+			expansion: "California Privacy Protection Agency",
+		},
+	}
+
+	extractor := NewAbbreviationExtractor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			abbreviations := extractor.ExtractFromText(tt.text)
+			if len(abbreviations) != 1 {
+				t.Fatalf("got %d abbreviations, want 1: %+v", len(abbreviations), abbreviations)
+			}
+			if abbreviations[0].ShortForm != tt.shortForm || abbreviations[0].Expansion != tt.expansion {
+				t.Errorf("got %q = %q, want %q = %q", abbreviations[0].ShortForm, abbreviations[0].Expansion, tt.shortForm, tt.expansion)
+			}
+		})
+	}
+}
+
+func TestAbbreviationExtractor_NoExpansion(t *testing.T) {
+	extractor := NewAbbreviationExtractor()
+	for _, text := range []string{
+		"Regulation (EU) 2016/679 of the European Parliament",
+		"as referred to in point (a) (GDPR)",
+		"in Chapter (II) of this Regulation",
+	} {
+		if abbreviations := extractor.ExtractFromText(text); len(abbreviations) != 0 {
+			t.Errorf("%q: got %+v, want none", text, abbreviations)
+		}
+	}
+}
+
+func TestAbbreviationExtractor_GDPR(t *testing.T) {
+	f := loadGDPRText(t)
+	defer f.Close()
+
+	doc, err := NewParser().Parse(f)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	abbreviations := NewAbbreviationExtractor().ExtractFromDocument(doc)
+
+	found := make(map[string]*Abbreviation)
+	for _, abbreviation := range abbreviations {
+		if found[abbreviation.ShortForm] != nil {
+			t.Errorf("%s introduced twice", abbreviation.ShortForm)
+		}
+		found[abbreviation.ShortForm] = abbreviation
+	}
+
+	if tfeu := found["TFEU"]; tfeu == nil || tfeu.RecitalNum != 1 {
+		t.Errorf("TFEU = %+v, want its introduction in Recital 1", tfeu)
+	}
+	if board := found["Board"]; board == nil || board.ArticleNum != 68 || board.Expansion != "European Data Protection Board" {
+		t.Errorf("Board = %+v, want the European Data Protection Board in Article 68", board)
+	}
+}
+
+func TestTermUsageExtractor_SetAbbreviations(t *testing.T) {
+	definitions := []*DefinedTerm{
+		{Number: 1, Term: "personal information", NormalizedTerm: "personal information", ArticleRef: 4},
+	}
+	extractor := NewTermUsageExtractor(definitions)
+	extractor.SetAbbreviations([]*Abbreviation{
+		{ShortForm: "PI", Expansion: "Personal Information"},
+		{ShortForm: "EU", Expansion: "European Union"},
+	})
+
+	usages := extractor.findTermsInText("A business shall not sell PI. Personal information is", 5, 0, "")
+	if len(usages) != 1 || usages[0].NormalizedTerm != "personal information" || usages[0].Count != 2 {
+		t.Fatalf("usages = %+v, want personal information used twice", usages)
+	}
+
+	if usages := extractor.findTermsInText("the pi of a circle", 5, 0, ""); len(usages) != 0 {
+		t.Errorf("usages = %+v, want the short form matched case-sensitively", usages)
+	}
+}
@@ -0,0 +1,27 @@
+package extract
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// deadlinePattern finds time limits such as "within 72 hours", "no later
+// than one month", and "without undue delay".
+var deadlinePattern = regexp.MustCompile(`(?i)\b(?:without\s+undue\s+delay|(?:within|no\s+later\s+than|not\s+later\s+than|not\s+exceeding)\s+(?:\d+|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve|fifteen|thirty|forty-five|sixty|ninety)\s+(?:calendar\s+|working\s+|business\s+)?(?:hours?|days?|weeks?|months?|years?))\b`)
+
+// FindDeadlines returns the distinct time limits stated in text, lowercased
+// with whitespace collapsed and sorted.
+func FindDeadlines(text string) []string {
+	seen := make(map[string]bool)
+	var deadlines []string
+	for _, match := range deadlinePattern.FindAllString(text, -1) {
+		deadline := strings.ToLower(strings.Join(strings.Fields(match), " "))
+		if !seen[deadline] {
+			seen[deadline] = true
+			deadlines = append(deadlines, deadline)
+		}
+	}
+	sort.Strings(deadlines)
+	return deadlines
+}
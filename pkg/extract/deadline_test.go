@@ -0,0 +1,20 @@
+package extract
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindDeadlines(t *testing.T) {
+	text := "The controller shall notify the breach without undue delay and, where feasible, " +
+		"not later than 72 hours after becoming aware of it. Within one  Month of receipt, " +
+		"and again WITHOUT UNDUE\nDELAY, the controller shall reply. The period may be extended by two further months."
+
+	want := []string{"not later than 72 hours", "within one month", "without undue delay"}
+	if got := FindDeadlines(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindDeadlines = %q, want %q", got, want)
+	}
+	if got := FindDeadlines("No time limits here."); got != nil {
+		t.Errorf("expected no deadlines, got %q", got)
+	}
+}
@@ -16,6 +16,11 @@ type DefinedTerm struct {
 	ArticleRef     int                   `json:"article_ref"`
 	SubPoints      []*DefinitionSubPoint `json:"sub_points,omitempty"`
 	References     []string              `json:"references,omitempty"`
+
+	// ExtractionMethod and Confidence are set for definitions that did not
+	// come from the pattern extractor (see ExtractionMethodLLM).
+	ExtractionMethod string  `json:"extraction_method,omitempty"`
+	Confidence       float64 `json:"confidence,omitempty"`
 }
 
 // DefinitionSubPoint represents a sub-point within a definition (e.g., (a), (b)).
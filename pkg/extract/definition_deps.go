@@ -0,0 +1,124 @@
+package extract
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefinitionDependency lists the defined terms a definition is written in,
+// and the terms it puts in quotes without defining them.
+type DefinitionDependency struct {
+	Term           string `json:"term"`
+	NormalizedTerm string `json:"normalized_term"`
+	ArticleRef     int    `json:"article_ref"`
+
+	// Uses holds the normalized terms of the other definitions used, in
+	// order of length.
+	Uses []string `json:"uses,omitempty"`
+
+	// Undefined holds quoted terms, such as ‘data subject’, that no
+	// definition defines.
+	Undefined []string `json:"undefined,omitempty"`
+}
+
+// quotedTermPattern matches a short phrase in straight, typographic, or
+// double quotes. The opening quote must not follow a letter, so that
+// possessives such as "provider's" are not read as quotes.
+var quotedTermPattern = regexp.MustCompile(`(?:^|[^\p{L}])['‘"“]([\p{L}][\p{L}\- ]{0,48}[\p{L}])['’"”]`)
+
+// ExtractDefinitionDependencies links each definition to the other
+// definitions its text and sub-points use. Longer terms are matched first,
+// so a definition mentioning "personal data" does not also use "data".
+// Terms are matched as the TermUsageExtractor matches them: whole words,
+// plurals, any case.
+func ExtractDefinitionDependencies(definitions []*DefinedTerm) []*DefinitionDependency {
+	type termPattern struct {
+		normalized string
+		lower      string
+		pattern    *regexp.Regexp
+	}
+
+	defined := make(map[string]bool, len(definitions))
+	var patterns []termPattern
+	for _, def := range definitions {
+		if strings.TrimSpace(def.Term) == "" || defined[def.NormalizedTerm] {
+			continue
+		}
+		defined[def.NormalizedTerm] = true
+		patterns = append(patterns, termPattern{
+			normalized: def.NormalizedTerm,
+			lower:      strings.ToLower(def.Term),
+			pattern:    regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(def.Term) + `(?:s|'s)?\b`),
+		})
+	}
+	sort.SliceStable(patterns, func(i, j int) bool {
+		if len(patterns[i].lower) != len(patterns[j].lower) {
+			return len(patterns[i].lower) > len(patterns[j].lower)
+		}
+		return patterns[i].normalized < patterns[j].normalized
+	})
+
+	dependencies := make([]*DefinitionDependency, 0, len(definitions))
+	for _, def := range definitions {
+		dependency := &DefinitionDependency{
+			Term:           def.Term,
+			NormalizedTerm: def.NormalizedTerm,
+			ArticleRef:     def.ArticleRef,
+		}
+
+		texts := []string{def.Definition}
+		for _, subPoint := range def.SubPoints {
+			texts = append(texts, subPoint.Text)
+		}
+		text := strings.Join(texts, "\n")
+
+		// An undefined quoted term is masked, so that "data" is not
+		// matched inside ‘data subject’
+		undefined := make(map[string]bool)
+		var undefinedSpans [][]int
+		for _, match := range quotedTermPattern.FindAllStringSubmatchIndex(text, -1) {
+			quoted := normalizeTerm(text[match[2]:match[3]])
+			if defined[quoted] || quoted == def.NormalizedTerm {
+				continue
+			}
+			undefinedSpans = append(undefinedSpans, match[2:4])
+			if !undefined[quoted] {
+				undefined[quoted] = true
+				dependency.Undefined = append(dependency.Undefined, quoted)
+			}
+		}
+		text = maskSpans(text, undefinedSpans)
+
+		lower := strings.ToLower(text)
+		for _, candidate := range patterns {
+			if !strings.Contains(lower, candidate.lower) {
+				continue
+			}
+			matches := candidate.pattern.FindAllStringIndex(text, -1)
+			if len(matches) == 0 {
+				continue
+			}
+			if candidate.normalized != def.NormalizedTerm {
+				dependency.Uses = append(dependency.Uses, candidate.normalized)
+			}
+			text = maskSpans(text, matches)
+			lower = strings.ToLower(text)
+		}
+
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies
+}
+
+// maskSpans blanks out matched spans so shorter terms inside them are not
+// matched again.
+func maskSpans(text string, spans [][]int) string {
+	masked := []byte(text)
+	for _, span := range spans {
+		for i := span[0]; i < span[1]; i++ {
+			masked[i] = ' '
+		}
+	}
+	return string(masked)
+}
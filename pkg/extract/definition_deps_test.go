@@ -0,0 +1,48 @@
+package extract
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractDefinitionDependencies(t *testing.T) {
+	definitions := []*DefinedTerm{
+		{Term: "personal data", NormalizedTerm: "personal data", Definition: "any information relating to a natural person (‘data subject’)"},
+		{Term: "data", NormalizedTerm: "data", Definition: "facts recorded in any form"},
+		{Term: "processing", NormalizedTerm: "processing", Definition: "any operation performed on Personal Data or on data, and further processing"},
+		{Term: "Controller", NormalizedTerm: "controller", Definition: "the person who determines the purposes",
+			SubPoints: []*DefinitionSubPoint{{Letter: "a", Text: "of the processing of personal data, including a 'joint controller'"}}},
+		{Term: "Business purpose", NormalizedTerm: "business purpose", Definition: "the use of information for the business's or a service provider's purposes"},
+	}
+
+	dependencies := ExtractDefinitionDependencies(definitions)
+	if len(dependencies) != len(definitions) {
+		t.Fatalf("got %d dependencies, want %d", len(dependencies), len(definitions))
+	}
+
+	tests := []struct {
+		term      string
+		uses      []string
+		undefined []string
+	}{
+		{"personal data", nil, []string{"data subject"}},
+		{"data", nil, nil},
+		// "data" inside "Personal Data" is not counted, the standalone one is
+		{"processing", []string{"personal data", "data"}, nil},
+		{"controller", []string{"personal data", "processing"}, []string{"joint controller"}},
+		// Possessives are not quotes
+		{"business purpose", nil, nil},
+	}
+	for i, tt := range tests {
+		dependency := dependencies[i]
+		if dependency.NormalizedTerm != tt.term {
+			t.Fatalf("dependency %d is %q, want %q", i, dependency.NormalizedTerm, tt.term)
+		}
+		if !reflect.DeepEqual(dependency.Uses, tt.uses) {
+			t.Errorf("%s uses %v, want %v", tt.term, dependency.Uses, tt.uses)
+		}
+		if !reflect.DeepEqual(dependency.Undefined, tt.undefined) {
+			t.Errorf("%s undefined %q, want %q", tt.term, dependency.Undefined, tt.undefined)
+		}
+	}
+}
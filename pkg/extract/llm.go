@@ -0,0 +1,401 @@
+package extract
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ExtractionMethodLLM marks definitions, rights, and obligations extracted
+// by a language model rather than by the pattern extractors.
+const ExtractionMethodLLM = "llm"
+
+// LLM extraction defaults.
+const (
+	// DefaultLLMMinConfidence is the pattern confidence below which a
+	// paragraph's rights and obligations are re-extracted by the model.
+	DefaultLLMMinConfidence = 0.6
+
+	// DefaultLLMMaxConfidence caps the confidence of model extractions, so
+	// they never outrank a confident pattern match.
+	DefaultLLMMaxConfidence = 0.8
+
+	// DefaultLLMMaxParagraphs limits the paragraphs sent per document.
+	DefaultLLMMaxParagraphs = 50
+)
+
+// LLMCompleter sends a prompt to a language model and returns its reply.
+type LLMCompleter interface {
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// LLMExtractorOptions controls which paragraphs are sent to the model and
+// how its extractions are scored.
+type LLMExtractorOptions struct {
+	MinConfidence float64
+	MaxConfidence float64
+	MaxParagraphs int
+}
+
+// DefaultLLMExtractorOptions returns the default fallback settings.
+func DefaultLLMExtractorOptions() LLMExtractorOptions {
+	return LLMExtractorOptions{
+		MinConfidence: DefaultLLMMinConfidence,
+		MaxConfidence: DefaultLLMMaxConfidence,
+		MaxParagraphs: DefaultLLMMaxParagraphs,
+	}
+}
+
+// LLMExtractor is an optional fallback that asks a language model to
+// extract definitions, rights, and obligations from paragraphs the pattern
+// extractors missed or matched with low confidence. Every extraction must
+// quote the paragraph verbatim; ungrounded ones are rejected.
+type LLMExtractor struct {
+	client  LLMCompleter
+	options LLMExtractorOptions
+}
+
+// NewLLMExtractor creates a fallback extractor. Zero options fall back to
+// the defaults.
+func NewLLMExtractor(client LLMCompleter, options LLMExtractorOptions) *LLMExtractor {
+	defaults := DefaultLLMExtractorOptions()
+	if options.MinConfidence <= 0 {
+		options.MinConfidence = defaults.MinConfidence
+	}
+	if options.MaxConfidence <= 0 {
+		options.MaxConfidence = defaults.MaxConfidence
+	}
+	if options.MaxParagraphs <= 0 {
+		options.MaxParagraphs = defaults.MaxParagraphs
+	}
+	return &LLMExtractor{client: client, options: options}
+}
+
+// LLMRejection is a model extraction that failed verification.
+type LLMRejection struct {
+	ArticleNum   int    `json:"article_num"`
+	ParagraphNum int    `json:"paragraph_num,omitempty"`
+	Kind         string `json:"kind"`
+	Text         string `json:"text"`
+	Reason       string `json:"reason"`
+}
+
+// LLMResult holds the verified extractions of one document.
+type LLMResult struct {
+	// Paragraphs is the number of paragraphs sent to the model.
+	Paragraphs  int                   `json:"paragraphs"`
+	Definitions []*DefinedTerm        `json:"definitions"`
+	Annotations []*SemanticAnnotation `json:"annotations"`
+	// Confirmed counts grounded extractions the patterns had already found.
+	Confirmed int            `json:"confirmed"`
+	Rejected  []LLMRejection `json:"rejected"`
+	// Errors lists failed requests. A failed paragraph is skipped; the
+	// build carries on with the pattern extractions.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// llmCuePattern finds paragraphs likely to define a term or state a right
+// or duty, so plain narrative text is not sent to the model.
+var llmCuePattern = regexp.MustCompile(`(?i)\b(shall|must|may not|is required to|are required to|has the right|have the right|entitled to|means|refers to)\b`)
+
+// llmDefinitionCuePattern finds paragraphs that look like definitions.
+var llmDefinitionCuePattern = regexp.MustCompile(`(?i)\b(means|refers to)\b`)
+
+// llmUnit is a paragraph (or unnumbered article text) sent to the model.
+type llmUnit struct {
+	articleNum   int
+	paragraphNum int
+	text         string
+}
+
+// llmItem is one extraction in the model's reply.
+type llmItem struct {
+	Kind       string  `json:"kind"`
+	Category   string  `json:"category"`
+	Party      string  `json:"party"`
+	Term       string  `json:"term"`
+	Text       string  `json:"text"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Extract sends the paragraphs of doc that the pattern extractors missed,
+// or matched only below MinConfidence, to the model and returns the
+// extractions whose quoted text is found in the paragraph. Extractions
+// that repeat a pattern result are counted as confirmed and dropped.
+func (e *LLMExtractor) Extract(ctx context.Context, doc *Document, definitions []*DefinedTerm, annotations []*SemanticAnnotation) *LLMResult {
+	result := &LLMResult{
+		Definitions: make([]*DefinedTerm, 0),
+		Annotations: make([]*SemanticAnnotation, 0),
+		Rejected:    make([]LLMRejection, 0),
+	}
+
+	definedTerms := make(map[string]bool)
+	definingArticles := make(map[int]bool)
+	for _, def := range definitions {
+		definedTerms[def.NormalizedTerm] = true
+		definingArticles[def.ArticleRef] = true
+	}
+	bestConfidence := make(map[[2]int]float64)
+	annotated := make(map[string]bool)
+	for _, ann := range annotations {
+		key := [2]int{ann.ArticleNum, ann.ParagraphNum}
+		if ann.Confidence > bestConfidence[key] {
+			bestConfidence[key] = ann.Confidence
+		}
+		annotated[annotationKey(ann)] = true
+	}
+
+	for _, unit := range llmUnits(doc) {
+		if result.Paragraphs >= e.options.MaxParagraphs {
+			break
+		}
+		if !llmCuePattern.MatchString(unit.text) {
+			continue
+		}
+		// Skip paragraphs the patterns covered confidently, unless they
+		// look like a definition the definition extractor missed.
+		missedDefinition := llmDefinitionCuePattern.MatchString(unit.text) && !definingArticles[unit.articleNum]
+		if bestConfidence[[2]int{unit.articleNum, unit.paragraphNum}] >= e.options.MinConfidence && !missedDefinition {
+			continue
+		}
+		result.Paragraphs++
+
+		reply, err := e.client.Complete(ctx, llmPrompt(unit.text))
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("article %d paragraph %d: %v", unit.articleNum, unit.paragraphNum, err))
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		items, err := parseLLMReply(reply)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("article %d paragraph %d: %v", unit.articleNum, unit.paragraphNum, err))
+			continue
+		}
+		for _, item := range items {
+			e.verify(unit, item, result, definedTerms, annotated)
+		}
+	}
+
+	return result
+}
+
+// verify grounds one model extraction in its paragraph and adds it to
+// result, or records why it was rejected.
+func (e *LLMExtractor) verify(unit llmUnit, item llmItem, result *LLMResult, definedTerms, annotated map[string]bool) {
+	reject := func(reason string) {
+		result.Rejected = append(result.Rejected, LLMRejection{
+			ArticleNum:   unit.articleNum,
+			ParagraphNum: unit.paragraphNum,
+			Kind:         item.Kind,
+			Text:         item.Text,
+			Reason:       reason,
+		})
+	}
+
+	grounding := groundSpan(unit.text, item.Text)
+	if grounding == 0 {
+		reject("quoted text not found in the source paragraph")
+		return
+	}
+	confidence := item.Confidence
+	if confidence <= 0 || confidence > 1 {
+		confidence = 0.5
+	}
+	confidence = math.Round(math.Min(confidence, e.options.MaxConfidence)*grounding*100) / 100
+
+	switch strings.ToLower(item.Kind) {
+	case "definition":
+		if item.Term == "" || groundSpan(item.Text, item.Term) == 0 {
+			reject("defined term not found in the quoted text")
+			return
+		}
+		normalized := normalizeTerm(item.Term)
+		if definedTerms[normalized] {
+			result.Confirmed++
+			return
+		}
+		definedTerms[normalized] = true
+		result.Definitions = append(result.Definitions, &DefinedTerm{
+			Number:           len(definedTerms),
+			Term:             item.Term,
+			NormalizedTerm:   normalized,
+			Definition:       strings.TrimSpace(item.Text),
+			ArticleRef:       unit.articleNum,
+			ExtractionMethod: ExtractionMethodLLM,
+			Confidence:       confidence,
+		})
+
+	case "right", "obligation", "prohibition":
+		ann := &SemanticAnnotation{
+			Type:             SemanticType(strings.ToLower(item.Kind)),
+			ArticleNum:       unit.articleNum,
+			ParagraphNum:     unit.paragraphNum,
+			MatchedText:      strings.TrimSpace(item.Text),
+			MatchedPattern:   ExtractionMethodLLM,
+			Confidence:       confidence,
+			ExtractionMethod: ExtractionMethodLLM,
+		}
+		party := EntityType(item.Party)
+		if !llmEntities[party] {
+			party = EntityUnspecified
+		}
+		if ann.Type == SemanticRight {
+			ann.RightType = RightType(item.Category)
+			if !llmRightTypes[ann.RightType] {
+				ann.RightType = RightGeneric
+			}
+			ann.Beneficiary = party
+		} else {
+			ann.ObligationType = ObligationType(item.Category)
+			if !llmObligationTypes[ann.ObligationType] {
+				ann.ObligationType = ObligationGeneric
+			}
+			ann.DutyBearer = party
+		}
+		if annotated[annotationKey(ann)] {
+			result.Confirmed++
+			return
+		}
+		annotated[annotationKey(ann)] = true
+		result.Annotations = append(result.Annotations, ann)
+
+	default:
+		reject(fmt.Sprintf("unknown kind %q", item.Kind))
+	}
+}
+
+// annotationKey identifies the graph node an annotation becomes: one right
+// or obligation node per article and type.
+func annotationKey(ann *SemanticAnnotation) string {
+	if ann.Type == SemanticRight {
+		return fmt.Sprintf("%d|right|%s", ann.ArticleNum, ann.RightType)
+	}
+	return fmt.Sprintf("%d|obligation|%s", ann.ArticleNum, ann.ObligationType)
+}
+
+// llmUnits lists the paragraphs of doc, or the article text when an article
+// has no numbered paragraphs.
+func llmUnits(doc *Document) []llmUnit {
+	var units []llmUnit
+	for _, article := range doc.AllArticles() {
+		if len(article.Paragraphs) == 0 {
+			if strings.TrimSpace(article.Text) != "" {
+				units = append(units, llmUnit{articleNum: article.Number, text: article.Text})
+			}
+			continue
+		}
+		for _, para := range article.Paragraphs {
+			text := para.Text
+			for _, point := range para.Points {
+				text += "\n(" + point.Letter + ") " + point.Text
+			}
+			if strings.TrimSpace(text) != "" {
+				units = append(units, llmUnit{articleNum: article.Number, paragraphNum: para.Number, text: text})
+			}
+		}
+	}
+	return units
+}
+
+// llmPrompt asks for the definitions, rights, and obligations of a
+// paragraph as JSON, each with a verbatim quote.
+func llmPrompt(paragraph string) string {
+	var sb strings.Builder
+	sb.WriteString("Extract the legal definitions, rights, obligations, and prohibitions stated in the paragraph below.\n")
+	sb.WriteString("Reply with JSON only, in the form:\n")
+	sb.WriteString(`{"items": [{"kind": "definition|right|obligation|prohibition", "category": "...", "party": "...", "term": "...", "text": "...", "confidence": 0.0}]}` + "\n")
+	sb.WriteString("- text: the exact words of the paragraph that state the item, copied verbatim\n")
+	sb.WriteString("- term: for definitions, the term being defined\n")
+	sb.WriteString("- category: for rights, one of " + strings.Join(sortedKeys(llmRightTypes), ", ") + "\n")
+	sb.WriteString("  for obligations and prohibitions, one of " + strings.Join(sortedKeys(llmObligationTypes), ", ") + "\n")
+	sb.WriteString("- party: who holds the right or bears the duty, one of " + strings.Join(sortedKeys(llmEntities), ", ") + "\n")
+	sb.WriteString("- confidence: your confidence from 0 to 1\n")
+	sb.WriteString("Reply {\"items\": []} if the paragraph states none.\n\n")
+	sb.WriteString("Paragraph:\n")
+	sb.WriteString(paragraph)
+	return sb.String()
+}
+
+// parseLLMReply decodes the items of a reply, tolerating text or code
+// fences around the JSON object.
+func parseLLMReply(reply string) ([]llmItem, error) {
+	start := strings.Index(reply, "{")
+	end := strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("reply contains no JSON object")
+	}
+	var decoded struct {
+		Items []llmItem `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &decoded); err != nil {
+		return nil, fmt.Errorf("failed to parse reply: %w", err)
+	}
+	return decoded.Items, nil
+}
+
+// groundSpan reports how well quote is grounded in source: 1 when it
+// appears verbatim, 0.9 when it appears after case, whitespace, and quote
+// normalization, and 0 otherwise. Quotes shorter than three characters are
+// never grounded.
+func groundSpan(source, quote string) float64 {
+	quote = strings.TrimSpace(quote)
+	if len([]rune(quote)) < 3 {
+		return 0
+	}
+	if strings.Contains(source, quote) {
+		return 1
+	}
+	if strings.Contains(normalizeSpan(source), normalizeSpan(quote)) {
+		return 0.9
+	}
+	return 0
+}
+
+var spanQuoteReplacer = strings.NewReplacer("‘", "'", "’", "'", "“", "\"", "”", "\"")
+
+func normalizeSpan(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(spanQuoteReplacer.Replace(text))), " ")
+}
+
+func sortedKeys[K ~string](set map[K]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Categories the model may assign; anything else becomes generic.
+var (
+	llmRightTypes = map[RightType]bool{
+		RightAccess: true, RightRectification: true, RightErasure: true, RightRestriction: true,
+		RightPortability: true, RightObject: true, RightNotAutomated: true, RightWithdrawConsent: true,
+		RightLodgeComplaint: true, RightEffectiveRemedy: true, RightCompensation: true,
+		RightInformation: true, RightNotification: true, RightToKnow: true, RightToKnowAboutSales: true,
+		RightToDelete: true, RightToOptOut: true, RightToNonDiscrimination: true, RightToCorrect: true,
+		RightToLimit: true, RightGeneric: true,
+	}
+	llmObligationTypes = map[ObligationType]bool{
+		ObligationLawfulProcessing: true, ObligationConsent: true, ObligationTransparency: true,
+		ObligationNotifyBreach: true, ObligationNotifySubject: true, ObligationSecure: true,
+		ObligationRecord: true, ObligationImpactAssessment: true, ObligationCooperate: true,
+		ObligationAppoint: true, ObligationProvideInformation: true, ObligationRespond: true,
+		ObligationVerify: true, ObligationNoticeAtCollection: true, ObligationPrivacyPolicy: true,
+		ObligationOptOutLink: true, ObligationServiceProvider: true, ObligationNonDiscrimination: true,
+		ObligationVerifyRequest: true, ObligationTrainPersonnel: true, ObligationDataMinimization: true,
+		ObligationGeneric: true,
+	}
+	llmEntities = map[EntityType]bool{
+		EntityDataSubject: true, EntityController: true, EntityProcessor: true, EntitySupervisoryAuth: true,
+		EntityMemberState: true, EntityThirdParty: true, EntityRecipient: true, EntityRepresentative: true,
+		EntityDataProtectionOff: true, EntityConsumer: true, EntityBusiness: true,
+		EntityServiceProvider: true, EntityAttorneyGeneral: true,
+	}
+)
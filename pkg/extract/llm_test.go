@@ -0,0 +1,180 @@
+package extract
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeCompleter replies with a canned response per paragraph, keyed by a
+// substring of the paragraph text.
+type fakeCompleter struct {
+	replies map[string]string
+	prompts []string
+}
+
+func (f *fakeCompleter) Complete(ctx context.Context, prompt string) (string, error) {
+	f.prompts = append(f.prompts, prompt)
+	for key, reply := range f.replies {
+		if strings.Contains(prompt, key) {
+			return reply, nil
+		}
+	}
+	return "", fmt.Errorf("no reply for prompt")
+}
+
+func llmTestDocument() *Document {
+	return &Document{
+		Title: "Test Act",
+		Chapters: []*Chapter{{
+			Number: "I",
+			Articles: []*Article{
+				{Number: 1, Title: "Scope", Paragraphs: []*Paragraph{
+					{Number: 1, Text: "This Act applies to the processing of records."},
+				}},
+				{Number: 2, Title: "Definitions", Paragraphs: []*Paragraph{
+					{Number: 1, Text: "“Custodian” means any person holding records on behalf of another."},
+				}},
+				{Number: 3, Title: "Duties", Paragraphs: []*Paragraph{
+					{Number: 1, Text: "A custodian shall keep records for five years."},
+					{Number: 2, Text: "The controller shall implement appropriate technical measures."},
+				}},
+			},
+		}},
+	}
+}
+
+func TestLLMExtractor_Extract(t *testing.T) {
+	doc := llmTestDocument()
+	client := &fakeCompleter{replies: map[string]string{
+		"Custodian": "```json\n" + `{"items": [
+			{"kind": "definition", "term": "Custodian", "text": "“Custodian” means any person holding records on behalf of another.", "confidence": 0.9},
+			{"kind": "definition", "term": "Archive", "text": "Archive means a store of records.", "confidence": 0.9}
+		]}` + "\n```",
+		"keep records": `{"items": [
+			{"kind": "obligation", "category": "RecordKeepingObligation", "party": "Nobody", "text": "shall  KEEP records for five years", "confidence": 0.7},
+			{"kind": "right", "category": "made_up", "party": "DataSubject", "text": "records", "confidence": 2},
+			{"kind": "opinion", "text": "A custodian shall keep records"}
+		]}`,
+	}}
+
+	// The controller paragraph is already covered confidently by a pattern.
+	patternAnnotations := []*SemanticAnnotation{{
+		Type:           SemanticObligation,
+		ObligationType: ObligationSecure,
+		ArticleNum:     3,
+		ParagraphNum:   2,
+		Confidence:     0.9,
+	}}
+
+	result := NewLLMExtractor(client, LLMExtractorOptions{}).Extract(context.Background(), doc, nil, patternAnnotations)
+
+	if result.Paragraphs != 2 {
+		t.Errorf("Paragraphs = %d, want 2 (scope has no cue, controller paragraph is covered)", result.Paragraphs)
+	}
+	if len(client.prompts) != 2 {
+		t.Errorf("sent %d prompts, want 2", len(client.prompts))
+	}
+
+	if len(result.Definitions) != 1 {
+		t.Fatalf("Definitions = %d, want 1", len(result.Definitions))
+	}
+	def := result.Definitions[0]
+	if def.NormalizedTerm != "custodian" || def.ArticleRef != 2 {
+		t.Errorf("definition = %q in article %d, want custodian in article 2", def.NormalizedTerm, def.ArticleRef)
+	}
+	if def.ExtractionMethod != ExtractionMethodLLM {
+		t.Errorf("ExtractionMethod = %q, want %q", def.ExtractionMethod, ExtractionMethodLLM)
+	}
+	if def.Confidence != DefaultLLMMaxConfidence {
+		t.Errorf("definition Confidence = %v, want capped at %v", def.Confidence, DefaultLLMMaxConfidence)
+	}
+
+	if len(result.Annotations) != 2 {
+		t.Fatalf("Annotations = %d, want 2", len(result.Annotations))
+	}
+	obligation := result.Annotations[0]
+	if obligation.ObligationType != ObligationRecord || obligation.DutyBearer != EntityUnspecified {
+		t.Errorf("obligation = %s borne by %s, want RecordKeepingObligation borne by Unspecified", obligation.ObligationType, obligation.DutyBearer)
+	}
+	// Normalized grounding scales the model confidence.
+	if obligation.Confidence != 0.63 {
+		t.Errorf("obligation Confidence = %v, want 0.63", obligation.Confidence)
+	}
+	right := result.Annotations[1]
+	if right.RightType != RightGeneric || right.Beneficiary != EntityDataSubject {
+		t.Errorf("right = %s for %s, want Right for DataSubject", right.RightType, right.Beneficiary)
+	}
+	if right.Confidence != 0.5 {
+		t.Errorf("out-of-range confidence should default to 0.5, got %v", right.Confidence)
+	}
+
+	reasons := make(map[string]bool)
+	for _, rejection := range result.Rejected {
+		reasons[rejection.Kind] = true
+	}
+	if len(result.Rejected) != 2 || !reasons["definition"] || !reasons["opinion"] {
+		t.Errorf("Rejected = %+v, want the ungrounded definition and the unknown kind", result.Rejected)
+	}
+}
+
+func TestLLMExtractor_ConfirmedAndErrors(t *testing.T) {
+	doc := llmTestDocument()
+	client := &fakeCompleter{replies: map[string]string{
+		"Custodian": `{"items": [{"kind": "definition", "term": "Custodian", "text": "Custodian” means any person", "confidence": 0.9}]}`,
+	}}
+	definitions := []*DefinedTerm{{Term: "custodian", NormalizedTerm: "custodian", ArticleRef: 5}}
+
+	result := NewLLMExtractor(client, LLMExtractorOptions{MaxParagraphs: 10}).Extract(context.Background(), doc, definitions, nil)
+
+	if result.Confirmed != 1 {
+		t.Errorf("Confirmed = %d, want 1", result.Confirmed)
+	}
+	if len(result.Definitions) != 0 {
+		t.Errorf("Definitions = %d, want 0 for an already defined term", len(result.Definitions))
+	}
+	// Both article 3 paragraphs have no fake reply.
+	if len(result.Errors) != 2 {
+		t.Errorf("Errors = %v, want 2", result.Errors)
+	}
+}
+
+func TestLLMExtractor_MaxParagraphs(t *testing.T) {
+	client := &fakeCompleter{replies: map[string]string{"shall": `{"items": []}`, "means": `{"items": []}`}}
+	result := NewLLMExtractor(client, LLMExtractorOptions{MaxParagraphs: 1}).Extract(context.Background(), llmTestDocument(), nil, nil)
+	if result.Paragraphs != 1 || len(client.prompts) != 1 {
+		t.Errorf("Paragraphs = %d, prompts = %d, want 1", result.Paragraphs, len(client.prompts))
+	}
+}
+
+func TestGroundSpan(t *testing.T) {
+	source := "The controller shall notify the ‘supervisory authority’ without delay."
+	tests := []struct {
+		quote string
+		want  float64
+	}{
+		{"shall notify the", 1},
+		{"SHALL   notify the 'supervisory authority'", 0.9},
+		{"shall inform the", 0},
+		{"ab", 0},
+	}
+	for _, tt := range tests {
+		if got := groundSpan(source, tt.quote); got != tt.want {
+			t.Errorf("groundSpan(%q) = %v, want %v", tt.quote, got, tt.want)
+		}
+	}
+}
+
+func TestParseLLMReply(t *testing.T) {
+	items, err := parseLLMReply("Here you go:\n```json\n{\"items\": [{\"kind\": \"right\", \"text\": \"x\"}]}\n```")
+	if err != nil {
+		t.Fatalf("parseLLMReply: %v", err)
+	}
+	if len(items) != 1 || items[0].Kind != "right" {
+		t.Errorf("items = %+v", items)
+	}
+	if _, err := parseLLMReply("no json here"); err == nil {
+		t.Error("expected an error for a reply without JSON")
+	}
+}
@@ -0,0 +1,95 @@
+package extract
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReferenceMappingFileName is the per-document manual mapping file looked up
+// alongside a source document.
+const ReferenceMappingFileName = "mappings.yaml"
+
+// ReferenceMapping maps a raw reference string, as it appears in the source
+// text, to a target URI. Targets without a scheme are relative to the
+// document being resolved (e.g., "Art17" or "Art6(1)(a)").
+type ReferenceMapping struct {
+	Raw    string `yaml:"raw" json:"raw"`
+	Target string `yaml:"target" json:"target"`
+	Note   string `yaml:"note,omitempty" json:"note,omitempty"`
+}
+
+// ReferenceMappingFile is the on-disk format of a mappings.yaml file.
+//
+//	mappings:
+//	  - raw: "the Directive on privacy and electronic communications"
+//	    target: "http://data.europa.eu/eli/dir/2002/58/oj"
+//	  - raw: "paragraph 1 of this Article"
+//	    target: "Art17(1)"
+type ReferenceMappingFile struct {
+	Mappings []ReferenceMapping `yaml:"mappings" json:"mappings"`
+}
+
+// ParseReferenceMappings parses mappings.yaml content and validates that
+// every entry has both a raw string and a target.
+func ParseReferenceMappings(data []byte) ([]ReferenceMapping, error) {
+	var mappingFile ReferenceMappingFile
+	if err := yaml.Unmarshal(data, &mappingFile); err != nil {
+		return nil, fmt.Errorf("failed to parse mappings: %w", err)
+	}
+
+	for index, mapping := range mappingFile.Mappings {
+		if strings.TrimSpace(mapping.Raw) == "" {
+			return nil, fmt.Errorf("mapping %d: raw reference is required", index+1)
+		}
+		if strings.TrimSpace(mapping.Target) == "" {
+			return nil, fmt.Errorf("mapping %d (%q): target is required", index+1, mapping.Raw)
+		}
+	}
+
+	return mappingFile.Mappings, nil
+}
+
+// LoadReferenceMappings reads and parses a mappings.yaml file.
+func LoadReferenceMappings(path string) ([]ReferenceMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mappings file: %w", err)
+	}
+	mappings, err := ParseReferenceMappings(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return mappings, nil
+}
+
+// FindReferenceMappingFile returns the manual mapping file for a source
+// document, or "" if none exists. "<name>.mappings.yaml" next to the source
+// takes precedence; a plain mappings.yaml is used only for per-document
+// directories where the source is named source.txt (the corpus layout).
+func FindReferenceMappingFile(sourcePath string) string {
+	sourceDir := filepath.Dir(sourcePath)
+	baseName := filepath.Base(sourcePath)
+	stem := strings.TrimSuffix(baseName, filepath.Ext(baseName))
+
+	candidates := []string{filepath.Join(sourceDir, stem+"."+ReferenceMappingFileName)}
+	if baseName == "source.txt" {
+		candidates = append(candidates, filepath.Join(sourceDir, ReferenceMappingFileName))
+	}
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// normalizeRawReference makes manual mapping lookups insensitive to case and
+// whitespace differences introduced by line wrapping.
+func normalizeRawReference(raw string) string {
+	return strings.ToLower(strings.Join(strings.Fields(raw), " "))
+}
@@ -0,0 +1,110 @@
+package extract
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseReferenceMappings(t *testing.T) {
+	data := []byte(`
+mappings:
+  - raw: "the Directive on privacy and electronic communications"
+    target: "http://data.europa.eu/eli/dir/2002/58/oj"
+    note: ePrivacy Directive
+  - raw: "Article 999"
+    target: "Art99"
+`)
+
+	mappings, err := ParseReferenceMappings(data)
+	if err != nil {
+		t.Fatalf("ParseReferenceMappings failed: %v", err)
+	}
+	if len(mappings) != 2 {
+		t.Fatalf("expected 2 mappings, got %d", len(mappings))
+	}
+	if mappings[0].Note != "ePrivacy Directive" {
+		t.Errorf("Note = %q, want %q", mappings[0].Note, "ePrivacy Directive")
+	}
+
+	if _, err := ParseReferenceMappings([]byte("mappings:\n  - raw: \"Article 5\"\n")); err == nil {
+		t.Error("expected error for mapping without target")
+	}
+}
+
+func TestFindReferenceMappingFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	flatSource := filepath.Join(tempDir, "gdpr.txt")
+	os.WriteFile(flatSource, []byte("text"), 0644)
+	os.WriteFile(filepath.Join(tempDir, ReferenceMappingFileName), []byte("mappings: []"), 0644)
+
+	// A shared directory's mappings.yaml does not apply to every document in it.
+	if found := FindReferenceMappingFile(flatSource); found != "" {
+		t.Errorf("expected no mapping file for flat source, got %q", found)
+	}
+
+	namedMappings := filepath.Join(tempDir, "gdpr.mappings.yaml")
+	os.WriteFile(namedMappings, []byte("mappings: []"), 0644)
+	if found := FindReferenceMappingFile(flatSource); found != namedMappings {
+		t.Errorf("FindReferenceMappingFile() = %q, want %q", found, namedMappings)
+	}
+
+	documentDir := filepath.Join(tempDir, "eu-gdpr")
+	os.MkdirAll(documentDir, 0755)
+	corpusSource := filepath.Join(documentDir, "source.txt")
+	os.WriteFile(corpusSource, []byte("text"), 0644)
+	corpusMappings := filepath.Join(documentDir, ReferenceMappingFileName)
+	os.WriteFile(corpusMappings, []byte("mappings: []"), 0644)
+	if found := FindReferenceMappingFile(corpusSource); found != corpusMappings {
+		t.Errorf("FindReferenceMappingFile() = %q, want %q", found, corpusMappings)
+	}
+}
+
+func TestReferenceResolver_ManualMappings(t *testing.T) {
+	resolver := NewReferenceResolver("https://regula.dev/", "GDPR")
+	resolver.articles[17] = true
+	resolver.SetManualMappings([]ReferenceMapping{
+		{Raw: "Article 999", Target: "Art99"},
+		{Raw: "Article 17", Target: "https://example.com/override"},
+		{Raw: "the  Data Protection\nDirective", Target: "http://data.europa.eu/eli/dir/1995/46/oj", Note: "repealed"},
+		{Raw: "never referenced", Target: "Art1"},
+	})
+
+	refs := []*Reference{
+		{Type: ReferenceTypeInternal, Target: TargetArticle, RawText: "Article 999", ArticleNum: 999},
+		{Type: ReferenceTypeInternal, Target: TargetArticle, RawText: "Article 17", ArticleNum: 17},
+		{Type: ReferenceTypeExternal, Target: TargetArticle, RawText: "the Data Protection Directive", ExternalDoc: "Directive 95/46/EC"},
+		{Type: ReferenceTypeInternal, Target: TargetArticle, RawText: "Article 500", ArticleNum: 500},
+	}
+
+	resolved := resolver.ResolveAll(refs)
+
+	expected := []struct {
+		status ResolutionStatus
+		uri    string
+		manual bool
+	}{
+		{ResolutionResolved, "https://regula.dev/GDPR:Art99", true},
+		{ResolutionResolved, "https://example.com/override", true},
+		{ResolutionResolved, "http://data.europa.eu/eli/dir/1995/46/oj", true},
+		{ResolutionNotFound, "", false},
+	}
+	for i, want := range expected {
+		got := resolved[i]
+		if got.Status != want.status || got.TargetURI != want.uri || got.Manual != want.manual {
+			t.Errorf("ref %q: got (%s, %q, manual=%v), want (%s, %q, manual=%v)",
+				refs[i].RawText, got.Status, got.TargetURI, got.Manual, want.status, want.uri, want.manual)
+		}
+	}
+
+	report := GenerateReport(resolved)
+	if report.ManualMappings != 3 {
+		t.Errorf("ManualMappings = %d, want 3", report.ManualMappings)
+	}
+
+	unused := resolver.UnusedManualMappings()
+	if len(unused) != 1 || unused[0] != "never referenced" {
+		t.Errorf("UnusedManualMappings() = %v, want [never referenced]", unused)
+	}
+}
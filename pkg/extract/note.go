@@ -0,0 +1,197 @@
+package extract
+
+import (
+	"regexp"
+	"strings"
+)
+
+// NoteKind classifies an editorial note attached to a provision.
+type NoteKind string
+
+const (
+	// NoteKindSourceCredit is the enacting history in parentheses after a
+	// USC section: "(Pub. L. 105–277, div. C, title XIII, §1302, ...)".
+	NoteKindSourceCredit NoteKind = "source_credit"
+
+	// NoteKindEditorial is a note under "Editorial Notes", such as
+	// "References in Text" or "Codification".
+	NoteKindEditorial NoteKind = "editorial"
+
+	// NoteKindStatutory is a note under "Statutory Notes and Related
+	// Subsidiaries", such as "Effective Date" or "Short Title".
+	NoteKindStatutory NoteKind = "statutory"
+
+	// NoteKindAmendment is one dated entry of an "Amendments" note.
+	NoteKindAmendment NoteKind = "amendment"
+
+	// NoteKindFootnote is a numbered footnote ("1 So in original.").
+	NoteKindFootnote NoteKind = "footnote"
+)
+
+// EditorialNote is text printed with a provision that is not part of the
+// law itself: source credits, editorial and statutory notes, amendment
+// history, and footnotes.
+type EditorialNote struct {
+	Kind    NoteKind `json:"kind"`
+	Heading string   `json:"heading,omitempty"`
+	Number  string   `json:"number,omitempty"` // Footnote number
+	Year    int      `json:"year,omitempty"`   // Year of an amendment entry
+	Text    string   `json:"text"`
+}
+
+// NoteExtractor separates editorial notes from the text of provisions.
+type NoteExtractor struct {
+	sourceCreditPattern *regexp.Regexp
+	groupPattern        *regexp.Regexp
+	headingPattern      *regexp.Regexp
+	amendmentPattern    *regexp.Regexp
+	footnotePattern     *regexp.Regexp
+	footnoteMarker      *regexp.Regexp
+}
+
+// NewNoteExtractor creates a new NoteExtractor with default patterns.
+func NewNoteExtractor() *NoteExtractor {
+	return &NoteExtractor{
+		// "(Pub. L. 95–91, title I, §101, Aug. 4, 1977, 91 Stat. 567.)" or "(R.S. §1977.)"
+		sourceCreditPattern: regexp.MustCompile(`^\((?:Pub\.\s*L\.|R\.S\.|Aug\.|July|June|Mar\.|Feb\.|Jan\.|Apr\.|May|Sept?\.|Oct\.|Nov\.|Dec\.)[^)]*(?:\d{4}|Stat\.)[^)]*\)$`),
+		// "Editorial Notes", "Statutory Notes and Related Subsidiaries"
+		groupPattern: regexp.MustCompile(`^(Editorial Notes|Statutory Notes and Related Subsidiaries|Executive Documents|Historical and Revision Notes)$`),
+		// "References in Text", "Effective Date of 2005 Amendment": short,
+		// capitalized, and without closing punctuation
+		headingPattern: regexp.MustCompile(`^[A-Z][A-Za-z0-9'’,\-]*(?:\s+[A-Za-z0-9'’,\-§.]+){0,9}$`),
+		// "2005—Subsec. (a). Pub. L. 109–58 substituted ..." or "2005--..."
+		amendmentPattern: regexp.MustCompile(`^(\d{4})(?:—|--|-)\s*(.*)$`),
+		// "1 So in original. The comma probably should not appear."
+		footnotePattern: regexp.MustCompile(`^([0-9]{1,2}|[¹²³⁴⁵⁶⁷⁸⁹])\s+((?:So in original|See |Probably should|Should be ).*)$`),
+		// Superscript footnote markers left in the text of the law
+		footnoteMarker: regexp.MustCompile(`[¹²³⁴⁵⁶⁷⁸⁹]+`),
+	}
+}
+
+// Separate splits text into the text of the law and the editorial notes
+// printed with it. Notes begin at the source credit or the first note
+// group heading; footnotes are taken from anywhere in the text.
+func (e *NoteExtractor) Separate(text string) (string, []*EditorialNote) {
+	var body []string
+	var notes []*EditorialNote
+	var current *EditorialNote
+	inNotes := false
+	group := NoteKindEditorial
+	heading := ""
+
+	flush := func() {
+		if current != nil {
+			current.Text = strings.TrimSpace(current.Text)
+			if current.Text != "" {
+				notes = append(notes, current)
+			}
+			current = nil
+		}
+	}
+	appendText := func(line string) {
+		if current.Text != "" {
+			current.Text += "\n"
+		}
+		current.Text += line
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if m := e.footnotePattern.FindStringSubmatch(trimmed); m != nil {
+			notes = append(notes, &EditorialNote{Kind: NoteKindFootnote, Number: footnoteNumber(m[1]), Text: m[2]})
+			continue
+		}
+
+		if e.sourceCreditPattern.MatchString(trimmed) {
+			flush()
+			notes = append(notes, &EditorialNote{Kind: NoteKindSourceCredit, Text: trimmed})
+			inNotes = true
+			continue
+		}
+
+		if m := e.groupPattern.FindStringSubmatch(trimmed); m != nil {
+			flush()
+			inNotes = true
+			group = NoteKindEditorial
+			if m[1] != "Editorial Notes" && m[1] != "Historical and Revision Notes" {
+				group = NoteKindStatutory
+			}
+			heading = ""
+			continue
+		}
+
+		if !inNotes {
+			body = append(body, line)
+			continue
+		}
+
+		if heading == "Amendments" {
+			if m := e.amendmentPattern.FindStringSubmatch(trimmed); m != nil {
+				flush()
+				current = &EditorialNote{Kind: NoteKindAmendment, Heading: heading, Year: mustAtoi(m[1]), Text: m[2]}
+				continue
+			}
+		}
+
+		if trimmed != "" && e.isHeading(trimmed) {
+			flush()
+			heading = trimmed
+			if heading != "Amendments" {
+				current = &EditorialNote{Kind: group, Heading: heading}
+			}
+			continue
+		}
+
+		if current == nil {
+			current = &EditorialNote{Kind: group, Heading: heading}
+		}
+		appendText(trimmed)
+	}
+	flush()
+
+	lawText := strings.TrimSpace(strings.Join(body, "\n"))
+	if len(notes) > 0 {
+		lawText = e.footnoteMarker.ReplaceAllString(lawText, "")
+	}
+	return lawText, notes
+}
+
+// isHeading reports whether a line within notes is a note heading rather
+// than note text.
+func (e *NoteExtractor) isHeading(line string) bool {
+	if strings.HasSuffix(line, ".") || strings.HasSuffix(line, ":") || strings.HasSuffix(line, ";") {
+		return false
+	}
+	return e.headingPattern.MatchString(line)
+}
+
+// ExtractNotes separates the notes of an article from its text. It modifies
+// the article in place, populating the Notes field.
+func (e *NoteExtractor) ExtractNotes(article *Article) {
+	if article == nil || article.Text == "" {
+		return
+	}
+	text, notes := e.Separate(article.Text)
+	if len(notes) == 0 {
+		return
+	}
+	article.Text = text
+	article.Notes = notes
+}
+
+// ExtractAllNotes separates notes from all articles in a document and
+// returns how many it found.
+func (e *NoteExtractor) ExtractAllNotes(doc *Document) int {
+	count := 0
+	for _, article := range doc.AllArticles() {
+		e.ExtractNotes(article)
+		count += len(article.Notes)
+	}
+	return count
+}
+
+// footnoteNumber converts a superscript footnote number to plain digits.
+func footnoteNumber(marker string) string {
+	return strings.NewReplacer("¹", "1", "²", "2", "³", "3", "⁴", "4", "⁵", "5", "⁶", "6", "⁷", "7", "⁸", "8", "⁹", "9").Replace(marker)
+}
@@ -0,0 +1,102 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+)
+
+const uscSectionWithNotes = `(a) In general
+It shall be unlawful for an operator of a website to collect personal information from a child.¹
+(b) Regulations
+The Commission shall promulgate regulations under this section.
+(Pub. L. 105–277, div. C, title XIII, §1303, Oct. 21, 1998, 112 Stat. 2681–730.)
+1 So in original. Probably should be followed by a comma.
+Editorial Notes
+References in Text
+This title, referred to in subsec. (a), is title XIII of div. C of Pub. L. 105–277.
+Amendments
+2010—Subsec. (b). Pub. L. 111–203 substituted "Bureau" for "Commission".
+2005—Subsec. (a). Pub. L. 109–58 inserted "or online service".
+The amendment took effect on enactment.
+Statutory Notes and Related Subsidiaries
+Effective Date
+Section effective 18 months after Oct. 21, 1998, see section 1308 of Pub. L. 105–277.`
+
+func TestNoteExtractor_Separate(t *testing.T) {
+	text, notes := NewNoteExtractor().Separate(uscSectionWithNotes)
+
+	wantText := "(a) In general\nIt shall be unlawful for an operator of a website to collect personal information from a child.\n(b) Regulations\nThe Commission shall promulgate regulations under this section."
+	if text != wantText {
+		t.Errorf("law text = %q, want %q", text, wantText)
+	}
+
+	type want struct {
+		kind    NoteKind
+		heading string
+		prefix  string
+	}
+	wants := []want{
+		{NoteKindSourceCredit, "", "(Pub. L. 105–277"},
+		{NoteKindFootnote, "", "So in original"},
+		{NoteKindEditorial, "References in Text", "This title, referred to"},
+		{NoteKindAmendment, "Amendments", "Subsec. (b). Pub. L. 111–203"},
+		{NoteKindAmendment, "Amendments", "Subsec. (a). Pub. L. 109–58"},
+		{NoteKindStatutory, "Effective Date", "Section effective 18 months"},
+	}
+	if len(notes) != len(wants) {
+		t.Fatalf("got %d notes, want %d: %+v", len(notes), len(wants), notes)
+	}
+	for i, w := range wants {
+		note := notes[i]
+		if note.Kind != w.kind || note.Heading != w.heading || !strings.HasPrefix(note.Text, w.prefix) {
+			t.Errorf("note %d = %+v, want kind %s heading %q text starting %q", i, note, w.kind, w.heading, w.prefix)
+		}
+	}
+
+	if notes[1].Number != "1" {
+		t.Errorf("footnote number = %q, want 1", notes[1].Number)
+	}
+	if notes[3].Year != 2010 || notes[4].Year != 2005 {
+		t.Errorf("amendment years = %d, %d, want 2010, 2005", notes[3].Year, notes[4].Year)
+	}
+	if !strings.HasSuffix(notes[4].Text, "The amendment took effect on enactment.") {
+		t.Errorf("amendment continuation lost: %q", notes[4].Text)
+	}
+}
+
+func TestNoteExtractor_NoNotes(t *testing.T) {
+	text := "(a) Amendments\nThe Legislature may amend this title by a majority vote."
+	got, notes := NewNoteExtractor().Separate(text)
+	if len(notes) != 0 {
+		t.Errorf("expected no notes, got %+v", notes)
+	}
+	if got != text {
+		t.Errorf("text changed to %q", got)
+	}
+}
+
+func TestParseUSC_SeparatesNotes(t *testing.T) {
+	content := "TITLE 15\nCOMMERCE AND TRADE\n\nCHAPTER 91\nCHILDREN'S ONLINE PRIVACY PROTECTION\n\nSection 6502 Regulation of unfair and deceptive acts\n\n" + uscSectionWithNotes + "\n"
+
+	parser := NewParser()
+	parser.SetFormatHint(FormatUS)
+	doc, err := parser.Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	articles := doc.AllArticles()
+	if len(articles) != 1 {
+		t.Fatalf("expected 1 section, got %d", len(articles))
+	}
+	article := articles[0]
+	if strings.Contains(article.Text, "Pub. L.") || strings.Contains(article.Text, "Editorial Notes") {
+		t.Errorf("section text still contains notes: %q", article.Text)
+	}
+	if len(article.Notes) != 6 {
+		t.Errorf("expected 6 notes, got %d", len(article.Notes))
+	}
+	if got := doc.Statistics().Notes; got != 6 {
+		t.Errorf("Statistics().Notes = %d, want 6", got)
+	}
+}
@@ -37,12 +37,14 @@ const (
 
 // Document represents a parsed regulatory document.
 type Document struct {
-	Title       string        `json:"title"`
-	Type        DocumentType  `json:"type"`
-	Identifier  string        `json:"identifier"`
-	Preamble    *Preamble     `json:"preamble,omitempty"`
-	Chapters    []*Chapter    `json:"chapters"`
-	Definitions []*Definition `json:"definitions,omitempty"`
+	Title       string         `json:"title"`
+	Type        DocumentType   `json:"type"`
+	Format      DocumentFormat `json:"format,omitempty"`
+	Identifier  string         `json:"identifier"`
+	Preamble    *Preamble      `json:"preamble,omitempty"`
+	Chapters    []*Chapter     `json:"chapters"`
+	Annexes     []*Annex       `json:"annexes,omitempty"`
+	Definitions []*Definition  `json:"definitions,omitempty"`
 }
 
 // Preamble represents the preamble section of a regulation.
@@ -80,6 +82,10 @@ type Article struct {
 	Title      string       `json:"title"`
 	Paragraphs []*Paragraph `json:"paragraphs,omitempty"`
 	Text       string       `json:"text,omitempty"`
+
+	// Notes are the source credits, editorial notes, and footnotes printed
+	// with the article, kept out of Text.
+	Notes []*EditorialNote `json:"notes,omitempty"`
 }
 
 // Paragraph represents a numbered paragraph within an article.
@@ -96,6 +102,25 @@ type Point struct {
 	SubPoints []*SubPoint `json:"sub_points,omitempty"`
 }
 
+// AnnexKind distinguishes EU annexes from UK schedules.
+type AnnexKind string
+
+const (
+	AnnexKindAnnex    AnnexKind = "annex"    // EU-style: ANNEX II
+	AnnexKindSchedule AnnexKind = "schedule" // UK-style: SCHEDULE 1
+)
+
+// Annex represents an annex or schedule following the main body. Lines of
+// tabular content (cells separated by "|" or tabs) are also kept as rows of
+// cells, the first row of a table being its header.
+type Annex struct {
+	Kind   AnnexKind  `json:"kind"`
+	Number string     `json:"number"`
+	Title  string     `json:"title"`
+	Text   string     `json:"text,omitempty"`
+	Rows   [][]string `json:"rows,omitempty"`
+}
+
 // Definition represents a defined term from Article 4 or similar.
 type Definition struct {
 	Number int    `json:"number"`
@@ -109,6 +134,7 @@ type Parser struct {
 	euChapterPattern *regexp.Regexp
 	euSectionPattern *regexp.Regexp
 	euArticlePattern *regexp.Regexp
+	euAnnexPattern   *regexp.Regexp
 
 	// US-style patterns (California Civil Code style)
 	usChapterPattern    *regexp.Regexp
@@ -166,6 +192,7 @@ func NewParser() *Parser {
 		euChapterPattern: regexp.MustCompile(`^CHAPTER\s+([IVX]+)$`),
 		euSectionPattern: regexp.MustCompile(`^Section\s+(\d+)$`),
 		euArticlePattern: regexp.MustCompile(`^Article\s+(\d+)$`),
+		euAnnexPattern:   regexp.MustCompile(`^ANNEX(?:\s+([IVXLC]+))?\s*$`),
 
 		// US-style patterns (CCPA, California Civil Code, US Code, etc.)
 		usChapterPattern:    regexp.MustCompile(`^CHAPTER\s+(\d+\w*)$`),
@@ -242,6 +269,9 @@ func (p *Parser) applyPatternBridge(bridge *pattern.PatternBridge) {
 	if articlePattern := bridge.HierarchyPattern("article"); articlePattern != nil {
 		p.euArticlePattern = articlePattern
 	}
+	if annexPattern := bridge.HierarchyPattern("annex"); annexPattern != nil {
+		p.euAnnexPattern = annexPattern
+	}
 
 	// Override definition pattern from pattern library
 	if defPattern := bridge.DefinitionPattern(); defPattern != nil {
@@ -370,6 +400,7 @@ func (p *Parser) Parse(r io.Reader) (*Document, error) {
 	} else {
 		p.format = p.detectFormat(lines)
 	}
+	doc.Format = p.format
 
 	// Apply preprocessing for House Rules format (removes PDF artifacts)
 	if p.isHouseRulesFormat {
@@ -402,9 +433,25 @@ func (p *Parser) Parse(r io.Reader) (*Document, error) {
 		p.parseEUDocument(doc, lines)
 	}
 
+	// Model numbered paragraphs, points, and sub-points as child provisions
+	p.extractProvisions(doc)
+
 	return doc, nil
 }
 
+// extractProvisions splits article text into paragraphs, points, and
+// sub-points. Articles without numbered paragraphs are left as plain text
+// rather than given a single implicit paragraph.
+func (p *Parser) extractProvisions(doc *Document) {
+	extractor := NewProvisionExtractor()
+	for _, article := range doc.AllArticles() {
+		extractor.ExtractProvisions(article)
+		if len(article.Paragraphs) == 1 && article.Paragraphs[0].Number == 0 {
+			article.Paragraphs = nil
+		}
+	}
+}
+
 // detectFormat analyzes the document to determine its structural format.
 // When a pattern registry is available, it uses the registry's confidence-based
 // detection. Otherwise, it falls back to the hardcoded indicator counting.
@@ -931,6 +978,9 @@ func (p *Parser) parseUSDocument(doc *Document, lines []string) {
 		p.addArticle(currentChapter, nil, currentSection)
 	}
 
+	// Separate source credits, notes, and footnotes from the law text
+	NewNoteExtractor().ExtractAllNotes(doc)
+
 	// Extract definitions
 	doc.Definitions = p.extractUSDefinitions(doc)
 }
@@ -1171,33 +1221,10 @@ func (p *Parser) parseUKDocument(doc *Document, lines []string) {
 			continue
 		}
 
-		// Check for SCHEDULE (also maps to Chapter)
-		if m := p.ukSchedulePattern.FindStringSubmatch(trimmedLine); m != nil {
-			// Save previous article
-			if currentArticle != nil {
-				currentArticle.Text = strings.TrimSpace(articleText.String())
-				p.addArticle(currentChapter, nil, currentArticle)
-				currentArticle = nil
-				articleText.Reset()
-			}
-
-			// Get schedule title (next non-empty line)
-			title := ""
-			for j := i + 1; j < len(lines) && j < i+5; j++ {
-				if strings.TrimSpace(lines[j]) != "" {
-					title = strings.TrimSpace(lines[j])
-					break
-				}
-			}
-
-			currentChapter = &Chapter{
-				Number:   "S" + m[1], // Prefix with S to distinguish from Parts
-				Title:    title,
-				Sections: make([]*Section, 0),
-				Articles: make([]*Article, 0),
-			}
-			doc.Chapters = append(doc.Chapters, currentChapter)
-			continue
+		// Schedules follow the main body and run to the end of the document
+		if p.ukSchedulePattern.MatchString(trimmedLine) {
+			doc.Annexes = p.parseAnnexes(lines[i:], p.ukSchedulePattern, AnnexKindSchedule)
+			break
 		}
 
 		// Check for numbered section (UK Acts: "1 Overview" or "1.—(1) Citation")
@@ -1396,6 +1423,12 @@ func (p *Parser) parseMainBody(doc *Document, lines []string) {
 	for i := 0; i < len(lines); i++ {
 		line := lines[i]
 
+		// Annexes follow the main body and run to the end of the document
+		if p.euAnnexPattern.MatchString(strings.TrimSpace(line)) {
+			doc.Annexes = p.parseAnnexes(lines[i:], p.euAnnexPattern, AnnexKindAnnex)
+			break
+		}
+
 		// Check for chapter
 		if m := p.euChapterPattern.FindStringSubmatch(line); m != nil {
 			// Save previous article
@@ -1527,6 +1560,97 @@ func (p *Parser) parseMainBody(doc *Document, lines []string) {
 	}
 }
 
+// parseAnnexes splits lines, which start with an annex heading matching
+// headingPattern, into annexes. The first non-empty line after a heading is
+// the annex title; the lines up to the next heading are its text.
+func (p *Parser) parseAnnexes(lines []string, headingPattern *regexp.Regexp, kind AnnexKind) []*Annex {
+	var annexes []*Annex
+	var current *Annex
+	var body []string
+
+	finish := func() {
+		if current == nil {
+			return
+		}
+		current.Text, current.Rows = annexContent(body)
+		annexes = append(annexes, current)
+	}
+
+	for _, line := range lines {
+		trimmedLine := strings.TrimSpace(line)
+		if m := headingPattern.FindStringSubmatch(trimmedLine); m != nil {
+			finish()
+			current = &Annex{Kind: kind}
+			if len(m) > 1 {
+				current.Number = m[1]
+			}
+			body = nil
+			continue
+		}
+		if current == nil || trimmedLine == "" {
+			continue
+		}
+		if current.Title == "" && len(body) == 0 && !isTableRow(trimmedLine) {
+			current.Title = trimmedLine
+			continue
+		}
+		body = append(body, trimmedLine)
+	}
+	finish()
+
+	return annexes
+}
+
+// tableSeparatorPattern matches the rule under a table header, such as
+// "|---|:---:|".
+var tableSeparatorPattern = regexp.MustCompile(`^\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?$`)
+
+// isTableRow reports whether a line holds table cells separated by "|" or
+// tabs.
+func isTableRow(line string) bool {
+	return len(tableCells(line)) >= 2 || tableSeparatorPattern.MatchString(line)
+}
+
+// tableCells splits a table row into its trimmed cells, or returns nil for a
+// line that is not a table row.
+func tableCells(line string) []string {
+	var cells []string
+	switch {
+	case strings.Contains(line, "|"):
+		cells = strings.Split(strings.Trim(strings.TrimSpace(line), "|"), "|")
+	case strings.Contains(line, "\t"):
+		cells = strings.Split(line, "\t")
+	default:
+		return nil
+	}
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+	if len(cells) < 2 {
+		return nil
+	}
+	return cells
+}
+
+// annexContent returns the text of an annex body and the cells of its
+// table rows. Cells are joined by " | " in the text so that it reads as
+// one line per row.
+func annexContent(body []string) (string, [][]string) {
+	var rows [][]string
+	text := make([]string, 0, len(body))
+	for _, line := range body {
+		if tableSeparatorPattern.MatchString(line) {
+			continue
+		}
+		if cells := tableCells(line); cells != nil {
+			rows = append(rows, cells)
+			line = strings.Join(cells, " | ")
+		}
+		text = append(text, line)
+	}
+	return strings.Join(text, "\n"), rows
+}
+
 // addArticle adds an article to the appropriate container (section or chapter).
 func (p *Parser) addArticle(chapter *Chapter, section *Section, article *Article) {
 	if chapter == nil {
@@ -1704,6 +1828,8 @@ type Statistics struct {
 	Articles    int `json:"articles"`
 	Definitions int `json:"definitions"`
 	Recitals    int `json:"recitals"`
+	Annexes     int `json:"annexes,omitempty"`
+	Notes       int `json:"notes,omitempty"`
 }
 
 // Statistics returns statistics about the parsed document.
@@ -1726,6 +1852,10 @@ func (d *Document) Statistics() Statistics {
 	}
 
 	stats.Definitions = len(d.Definitions)
+	stats.Annexes = len(d.Annexes)
+	for _, article := range d.AllArticles() {
+		stats.Notes += len(article.Notes)
+	}
 
 	return stats
 }
@@ -1759,6 +1889,16 @@ func (d *Document) GetChapter(number string) *Chapter {
 	return nil
 }
 
+// GetAnnex returns an annex or schedule by number, or nil if not found.
+func (d *Document) GetAnnex(number string) *Annex {
+	for _, annex := range d.Annexes {
+		if annex.Number == number {
+			return annex
+		}
+	}
+	return nil
+}
+
 // AllArticles returns all articles in document order.
 func (d *Document) AllArticles() []*Article {
 	var articles []*Article
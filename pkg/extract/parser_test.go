@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -409,3 +410,72 @@ func TestParser_GetChapter(t *testing.T) {
 		t.Error("Chapter XII should not exist")
 	}
 }
+
+func TestParser_Annexes(t *testing.T) {
+	content := `Regulation (EU) 2024/001 of the European Parliament and of the Council
+of 1 January 2024
+on testing annexes
+
+HAVE ADOPTED THIS REGULATION:
+
+CHAPTER I
+General provisions
+
+Article 1
+Scope
+This Regulation applies to the systems listed in Annex II.
+
+ANNEX I
+Union harmonisation legislation
+1. Directive 2006/42/EC on machinery.
+
+ANNEX II
+High-risk systems
+Area | System | Threshold
+---|---|---
+Biometrics | Remote identification | 1
+Education | Admission scoring | 2
+`
+
+	doc, err := NewParser().Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(doc.Annexes) != 2 {
+		t.Fatalf("Expected 2 annexes, got %d", len(doc.Annexes))
+	}
+	if got := doc.Statistics().Annexes; got != 2 {
+		t.Errorf("Statistics().Annexes = %d, want 2", got)
+	}
+
+	first := doc.GetAnnex("I")
+	if first == nil || first.Kind != AnnexKindAnnex || first.Title != "Union harmonisation legislation" {
+		t.Fatalf("Annex I = %+v", first)
+	}
+	if !strings.Contains(first.Text, "Directive 2006/42/EC") || len(first.Rows) != 0 {
+		t.Errorf("Annex I text = %q, rows = %v", first.Text, first.Rows)
+	}
+
+	second := doc.GetAnnex("II")
+	if second == nil || second.Title != "High-risk systems" {
+		t.Fatalf("Annex II = %+v", second)
+	}
+	wantRows := [][]string{
+		{"Area", "System", "Threshold"},
+		{"Biometrics", "Remote identification", "1"},
+		{"Education", "Admission scoring", "2"},
+	}
+	if !reflect.DeepEqual(second.Rows, wantRows) {
+		t.Errorf("Annex II rows = %v, want %v", second.Rows, wantRows)
+	}
+
+	// Annex text no longer runs on into the last article.
+	article := doc.GetArticle(1)
+	if article == nil {
+		t.Fatal("Article 1 not found")
+	}
+	if strings.Contains(article.Text, "harmonisation") || strings.Contains(article.Text, "Biometrics") {
+		t.Errorf("Article 1 text absorbed annex content: %q", article.Text)
+	}
+}
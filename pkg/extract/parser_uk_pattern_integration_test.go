@@ -69,7 +69,7 @@ func TestUKPatternRegistryDPA2018Parse(t *testing.T) {
 		t.Errorf("Expected identifier '2018 c. 12', got %q", doc.Identifier)
 	}
 
-	// DPA 2018 test data has 7 Parts + 2 Schedules = 9 chapters
+	// DPA 2018 test data has 7 Parts; its 2 Schedules are annexes
 	if stats.Chapters < 7 {
 		t.Errorf("Expected at least 7 chapters (Parts), got %d", stats.Chapters)
 	}
@@ -492,16 +492,22 @@ func TestUKPatternRegistryDPA2018Structure(t *testing.T) {
 		t.Error("Expected Part 1 with title 'Preliminary'")
 	}
 
-	// Verify schedules were parsed
-	scheduleCount := 0
+	// Verify schedules were parsed as annexes, not chapters
+	if len(doc.Annexes) < 2 {
+		t.Fatalf("Expected at least 2 schedules, got %d", len(doc.Annexes))
+	}
+	schedule := doc.GetAnnex("1")
+	if schedule == nil || schedule.Kind != AnnexKindSchedule || schedule.Title != "Conditions for sensitive processing" {
+		t.Errorf("Schedule 1 = %+v", schedule)
+	}
+	if schedule != nil && !strings.Contains(schedule.Text, "substantial public interest") {
+		t.Errorf("Schedule 1 text = %q", schedule.Text)
+	}
 	for _, ch := range doc.Chapters {
 		if strings.HasPrefix(ch.Number, "S") {
-			scheduleCount++
+			t.Errorf("Schedule parsed as chapter %s", ch.Number)
 		}
 	}
-	if scheduleCount < 2 {
-		t.Errorf("Expected at least 2 schedules, got %d", scheduleCount)
-	}
 }
 
 func TestUKPatternRegistryLegacyDetection(t *testing.T) {
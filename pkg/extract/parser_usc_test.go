@@ -208,7 +208,7 @@ func TestUSCAlphanumericReferenceExtraction(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			refs := extractor.extractUSCSectionRefs(tc.text, 42, nil)
+			refs := extractor.extractUSCSectionRefs(extractor.scan(tc.text), 42, nil)
 			if len(refs) == 0 {
 				t.Fatalf("expected at least 1 reference for %q, got 0", tc.text)
 			}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // ProceduralStep represents a single step in a legislative procedure.
@@ -486,10 +488,10 @@ func extractExcerpt(text string, maxLen int) string {
 		}
 	}
 
-	if len(text) > maxLen {
+	if textutil.RuneLen(text) > maxLen {
 		// Find a good break point
-		text = text[:maxLen]
-		if idx := strings.LastIndex(text, " "); idx > maxLen/2 {
+		text = textutil.TruncateRunes(text, maxLen)
+		if idx := strings.LastIndex(text, " "); idx > len(text)/2 {
 			text = text[:idx]
 		}
 		return text + "..."
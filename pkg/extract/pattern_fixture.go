@@ -0,0 +1,400 @@
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PatternFixtureFile is a file of annotated snippets used to test reference
+// patterns and definitions:
+//
+//	name: "EU references"
+//	format: "eu"
+//	fixtures:
+//	  - name: "qualified point"
+//	    text: "Processing under point (a) of Article 6(1) is lawful."
+//	    references:
+//	      - "point (a) of Article 6(1)"
+//	      - text: "Article 9"
+//	        pattern: "article"
+//	        identifier: "Article 9"
+//	    definitions:
+//	      - "personal data"
+//
+// Format restricts the pattern families to those of documents of that
+// format, without the confidence check of DetectFamilies, which a single
+// snippet is too short for; without it every family applies.
+type PatternFixtureFile struct {
+	Name     string           `yaml:"name" json:"name"`
+	Format   DocumentFormat   `yaml:"format,omitempty" json:"format,omitempty"`
+	Fixtures []PatternFixture `yaml:"fixtures" json:"fixtures"`
+
+	// Path is the file the fixtures were loaded from.
+	Path string `yaml:"-" json:"path,omitempty"`
+}
+
+// PatternFixture is one snippet and the references and definitions that
+// should be extracted from it. Each snippet is extracted as the text of a
+// "Definitions" article, so definition lists are recognized.
+type PatternFixture struct {
+	Name        string              `yaml:"name" json:"name"`
+	Text        string              `yaml:"text" json:"text"`
+	References  []ExpectedReference `yaml:"references,omitempty" json:"references,omitempty"`
+	Definitions []string            `yaml:"definitions,omitempty" json:"definitions,omitempty"`
+}
+
+// ExpectedReference is a reference a fixture expects, by its raw text. It
+// may be written as a plain string.
+type ExpectedReference struct {
+	Text string `yaml:"text" json:"text"`
+
+	// Pattern names the pattern that should find the reference, so that a
+	// miss counts against that pattern's recall.
+	Pattern string `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+
+	// Identifier, when set, must equal the extracted identifier.
+	Identifier string `yaml:"identifier,omitempty" json:"identifier,omitempty"`
+}
+
+// UnmarshalYAML accepts an expected reference as a plain string.
+func (r *ExpectedReference) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		r.Text = node.Value
+		return nil
+	}
+	type plain ExpectedReference
+	return node.Decode((*plain)(r))
+}
+
+// Names under which matches are scored that no reference pattern owns.
+const (
+	// PatternScoreDefinitions scores the definition extractor.
+	PatternScoreDefinitions = "definitions"
+
+	// PatternScoreUnattributed scores expected references without a
+	// pattern that were not found.
+	PatternScoreUnattributed = "(unattributed)"
+)
+
+// PatternScore counts the matches of one pattern over a fixture run.
+type PatternScore struct {
+	Pattern        string  `json:"pattern"`
+	TruePositives  int     `json:"true_positives"`
+	FalsePositives int     `json:"false_positives"`
+	FalseNegatives int     `json:"false_negatives"`
+	Precision      float64 `json:"precision"`
+	Recall         float64 `json:"recall"`
+}
+
+// compute sets precision and recall from the counts. Either is 1 when
+// nothing was extracted, or nothing expected, respectively.
+func (s *PatternScore) compute() {
+	s.Precision, s.Recall = 1, 1
+	if found := s.TruePositives + s.FalsePositives; found > 0 {
+		s.Precision = float64(s.TruePositives) / float64(found)
+	}
+	if expected := s.TruePositives + s.FalseNegatives; expected > 0 {
+		s.Recall = float64(s.TruePositives) / float64(expected)
+	}
+}
+
+// PatternTestFailure is an expected match that was not found, or a match
+// that was not expected.
+type PatternTestFailure struct {
+	File    string `json:"file"`
+	Fixture string `json:"fixture"`
+	Pattern string `json:"pattern"`
+	Text    string `json:"text"`
+
+	// Kind is "missing" or "unexpected".
+	Kind   string `json:"kind"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// PatternRegression is a pattern whose precision or recall fell below the
+// baseline.
+type PatternRegression struct {
+	Pattern string  `json:"pattern"`
+	Metric  string  `json:"metric"`
+	Before  float64 `json:"before"`
+	After   float64 `json:"after"`
+}
+
+// PatternTestReport is the result of running fixtures against a set of
+// patterns.
+type PatternTestReport struct {
+	Fixtures    int                  `json:"fixtures"`
+	Scores      []PatternScore       `json:"scores"`
+	Total       PatternScore         `json:"total"`
+	Failures    []PatternTestFailure `json:"failures,omitempty"`
+	Regressions []PatternRegression  `json:"regressions,omitempty"`
+}
+
+// ParsePatternFixtures parses a fixture file and validates that every
+// fixture has text.
+func ParsePatternFixtures(data []byte) (*PatternFixtureFile, error) {
+	fixtureFile := &PatternFixtureFile{}
+	if err := yaml.Unmarshal(data, fixtureFile); err != nil {
+		return nil, fmt.Errorf("failed to parse fixtures: %w", err)
+	}
+	for index, fixture := range fixtureFile.Fixtures {
+		if strings.TrimSpace(fixture.Text) == "" {
+			return nil, fmt.Errorf("fixture %d (%q): text is required", index+1, fixture.Name)
+		}
+		if fixture.Name == "" {
+			fixtureFile.Fixtures[index].Name = fmt.Sprintf("fixture %d", index+1)
+		}
+	}
+	return fixtureFile, nil
+}
+
+// LoadPatternFixtures loads a fixture file, or every YAML fixture file in a
+// directory in file name order.
+func LoadPatternFixtures(path string) ([]*PatternFixtureFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+	paths := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixtures: %w", err)
+		}
+		paths = nil
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.IsDir() && (strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")) {
+				paths = append(paths, filepath.Join(path, name))
+			}
+		}
+	}
+
+	var files []*PatternFixtureFile
+	for _, fixturePath := range paths {
+		data, err := os.ReadFile(fixturePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixtures: %w", err)
+		}
+		fixtureFile, err := ParsePatternFixtures(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fixturePath, err)
+		}
+		fixtureFile.Path = fixturePath
+		files = append(files, fixtureFile)
+	}
+	return files, nil
+}
+
+// TestPatterns runs the fixtures against the extractor's patterns and the
+// default definition extractor, scoring each extracted reference against
+// the pattern that found it.
+func (e *ReferenceExtractor) TestPatterns(files []*PatternFixtureFile) *PatternTestReport {
+	report := &PatternTestReport{}
+	scores := make(map[string]*PatternScore)
+	score := func(pattern string) *PatternScore {
+		if scores[pattern] == nil {
+			scores[pattern] = &PatternScore{Pattern: pattern}
+		}
+		return scores[pattern]
+	}
+	definitionExtractor := NewDefinitionExtractor()
+
+	for _, file := range files {
+		for _, fixture := range file.Fixtures {
+			report.Fixtures++
+			fail := func(pattern, text, kind, detail string) {
+				report.Failures = append(report.Failures, PatternTestFailure{
+					File: file.Path, Fixture: fixture.Name, Pattern: pattern, Text: text, Kind: kind, Detail: detail,
+				})
+			}
+
+			article := &Article{Number: 1, Title: "Definitions", Text: fixture.Text}
+			doc := &Document{Format: file.Format, Chapters: []*Chapter{{Number: "I", Articles: []*Article{article}}}}
+
+			// References
+			extractor := e
+			if families := familiesForFormat(file.Format); e.families == nil && families != nil {
+				extractor = e.withFamilies(families)
+			}
+			scan := extractor.scan(fixture.Text)
+			scan.recording = true
+			refs, _ := extractor.extractScan(scan, article.Number)
+
+			matched := make([]bool, len(refs))
+			for _, expected := range fixture.References {
+				found := -1
+				for i, ref := range refs {
+					if !matched[i] && ref.RawText == expected.Text {
+						found = i
+						break
+					}
+				}
+				if found < 0 {
+					pattern := expected.Pattern
+					if pattern == "" {
+						pattern = PatternScoreUnattributed
+					}
+					score(pattern).FalseNegatives++
+					fail(pattern, expected.Text, "missing", "")
+					continue
+				}
+				matched[found] = true
+				ref := refs[found]
+				pattern := scan.attribute(ref)
+				if expected.Identifier != "" && ref.Identifier != expected.Identifier {
+					score(pattern).FalsePositives++
+					score(pattern).FalseNegatives++
+					fail(pattern, expected.Text, "missing", fmt.Sprintf("identifier %q, want %q", ref.Identifier, expected.Identifier))
+					continue
+				}
+				if expected.Pattern != "" && pattern != expected.Pattern {
+					score(expected.Pattern).FalseNegatives++
+					score(pattern).FalsePositives++
+					fail(expected.Pattern, expected.Text, "missing", fmt.Sprintf("found by %s", pattern))
+					continue
+				}
+				score(pattern).TruePositives++
+			}
+			for i, ref := range refs {
+				if !matched[i] {
+					pattern := scan.attribute(ref)
+					score(pattern).FalsePositives++
+					fail(pattern, ref.RawText, "unexpected", ref.Identifier)
+				}
+			}
+
+			// Definitions
+			definitions := definitionExtractor.ExtractDefinitions(doc)
+			definitionScore := score(PatternScoreDefinitions)
+			matchedTerms := make([]bool, len(definitions))
+			for _, term := range fixture.Definitions {
+				found := false
+				for i, definition := range definitions {
+					if !matchedTerms[i] && definition.NormalizedTerm == normalizeTerm(term) {
+						matchedTerms[i], found = true, true
+						break
+					}
+				}
+				if found {
+					definitionScore.TruePositives++
+				} else {
+					definitionScore.FalseNegatives++
+					fail(PatternScoreDefinitions, term, "missing", "")
+				}
+			}
+			for i, definition := range definitions {
+				if !matchedTerms[i] {
+					definitionScore.FalsePositives++
+					fail(PatternScoreDefinitions, definition.Term, "unexpected", "")
+				}
+			}
+		}
+	}
+
+	// Definitions are only scored when fixtures expect or find any
+	if s := scores[PatternScoreDefinitions]; s != nil && s.TruePositives+s.FalsePositives+s.FalseNegatives == 0 {
+		delete(scores, PatternScoreDefinitions)
+	}
+
+	report.Total.Pattern = "total"
+	for _, s := range scores {
+		s.compute()
+		report.Scores = append(report.Scores, *s)
+		report.Total.TruePositives += s.TruePositives
+		report.Total.FalsePositives += s.FalsePositives
+		report.Total.FalseNegatives += s.FalseNegatives
+	}
+	report.Total.compute()
+	sort.Slice(report.Scores, func(i, j int) bool { return report.Scores[i].Pattern < report.Scores[j].Pattern })
+	return report
+}
+
+// CompareBaseline records in the report the patterns whose precision or
+// recall is lower than in a baseline report, and returns them.
+func (r *PatternTestReport) CompareBaseline(baseline *PatternTestReport) []PatternRegression {
+	current := make(map[string]PatternScore, len(r.Scores))
+	for _, s := range r.Scores {
+		current[s.Pattern] = s
+	}
+
+	r.Regressions = nil
+	for _, before := range baseline.Scores {
+		after, ok := current[before.Pattern]
+		if !ok {
+			// Scored before but neither expected nor found now
+			if before.TruePositives > 0 {
+				r.Regressions = append(r.Regressions, PatternRegression{Pattern: before.Pattern, Metric: "recall", Before: before.Recall})
+			}
+			continue
+		}
+		if after.Precision < before.Precision {
+			r.Regressions = append(r.Regressions, PatternRegression{Pattern: before.Pattern, Metric: "precision", Before: before.Precision, After: after.Precision})
+		}
+		if after.Recall < before.Recall {
+			r.Regressions = append(r.Regressions, PatternRegression{Pattern: before.Pattern, Metric: "recall", Before: before.Recall, After: after.Recall})
+		}
+	}
+	return r.Regressions
+}
+
+// LoadPatternTestReport reads a report saved as JSON, for use as a baseline.
+func LoadPatternTestReport(path string) (*PatternTestReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	report := &PatternTestReport{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("%s: failed to parse baseline: %w", path, err)
+	}
+	return report, nil
+}
+
+// ToJSON serializes the report to JSON.
+func (r *PatternTestReport) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// String returns a human-readable summary of the report.
+func (r *PatternTestReport) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Pattern Test (%d fixtures)\n", r.Fixtures))
+	sb.WriteString(strings.Repeat("=", 50) + "\n")
+	sb.WriteString(fmt.Sprintf("  %-24s %5s %5s %5s %9s %7s\n", "Pattern", "TP", "FP", "FN", "Precision", "Recall"))
+	writeScore := func(s PatternScore) {
+		sb.WriteString(fmt.Sprintf("  %-24s %5d %5d %5d %8.1f%% %6.1f%%\n",
+			s.Pattern, s.TruePositives, s.FalsePositives, s.FalseNegatives, s.Precision*100, s.Recall*100))
+	}
+	for _, s := range r.Scores {
+		writeScore(s)
+	}
+	writeScore(r.Total)
+
+	if len(r.Failures) > 0 {
+		sb.WriteString(fmt.Sprintf("\nFailures (%d):\n", len(r.Failures)))
+		for _, failure := range r.Failures {
+			sb.WriteString(fmt.Sprintf("  %s: %s %s %q (%s)", filepath.Base(failure.File), failure.Fixture, failure.Kind, failure.Text, failure.Pattern))
+			if failure.Detail != "" {
+				sb.WriteString(" - " + failure.Detail)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(r.Regressions) > 0 {
+		sb.WriteString(fmt.Sprintf("\nRegressions (%d):\n", len(r.Regressions)))
+		for _, regression := range r.Regressions {
+			sb.WriteString(fmt.Sprintf("  %s %s: %.1f%% -> %.1f%%\n", regression.Pattern, regression.Metric, regression.Before*100, regression.After*100))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,94 @@
+package extract
+
+import "testing"
+
+func TestTestPatterns_Fixtures(t *testing.T) {
+	files, err := LoadPatternFixtures("../../testdata/pattern-fixtures")
+	if err != nil {
+		t.Fatalf("LoadPatternFixtures: %v", err)
+	}
+
+	report := NewReferenceExtractor().TestPatterns(files)
+	for _, failure := range report.Failures {
+		t.Errorf("%s: %s %s %q (%s) %s", failure.File, failure.Fixture, failure.Kind, failure.Text, failure.Pattern, failure.Detail)
+	}
+	if report.Total.TruePositives == 0 {
+		t.Error("fixtures scored no references")
+	}
+}
+
+func TestTestPatterns_Scores(t *testing.T) {
+	fixtures, err := ParsePatternFixtures([]byte(`
+name: "scores"
+format: "eu"
+fixtures:
+  - name: "chapters"
+    text: "Chapter III applies, as does Chapter 4 of the Annex."
+    references:
+      - text: "Chapter III"
+        pattern: "chapter"
+      - text: "Chapter 4"
+        pattern: "chapter"
+  - name: "unattributed"
+    text: "See recital 12."
+    references:
+      - "recital 12"
+`))
+	if err != nil {
+		t.Fatalf("ParsePatternFixtures: %v", err)
+	}
+
+	report := NewReferenceExtractor().TestPatterns([]*PatternFixtureFile{fixtures})
+	scores := make(map[string]PatternScore)
+	for _, score := range report.Scores {
+		scores[score.Pattern] = score
+	}
+
+	chapter := scores["chapter"]
+	if chapter.TruePositives != 1 || chapter.FalseNegatives != 1 || chapter.Recall != 0.5 || chapter.Precision != 1 {
+		t.Errorf("chapter score = %+v", chapter)
+	}
+	if unattributed := scores[PatternScoreUnattributed]; unattributed.FalseNegatives != 1 {
+		t.Errorf("unattributed score = %+v", unattributed)
+	}
+	if len(report.Failures) != 2 {
+		t.Errorf("failures = %+v, want 2", report.Failures)
+	}
+}
+
+func TestPatternTestReport_CompareBaseline(t *testing.T) {
+	fixtures, err := ParsePatternFixtures([]byte(`
+name: "baseline"
+fixtures:
+  - text: "Chapter III and Chapter IV apply."
+    references:
+      - text: "Chapter III"
+        pattern: "chapter"
+      - text: "Chapter IV"
+        pattern: "chapter"
+`))
+	if err != nil {
+		t.Fatalf("ParsePatternFixtures: %v", err)
+	}
+	files := []*PatternFixtureFile{fixtures}
+	baseline := NewReferenceExtractor().TestPatterns(files)
+
+	pack, err := ParseReferencePack([]byte("name: x\npack_id: x\nversion: \"1\"\npatterns:\n  - name: chapter\n    pattern: 'Chapter\\s+(III)'\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	narrowed, err := NewReferenceExtractorWithPacks(pack)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := narrowed.TestPatterns(files)
+	regressions := report.CompareBaseline(baseline)
+	if len(regressions) != 1 || regressions[0].Pattern != "chapter" || regressions[0].Metric != "recall" || regressions[0].After != 0.5 {
+		t.Errorf("regressions = %+v, want chapter recall 1 -> 0.5", regressions)
+	}
+
+	if regressions := baseline.CompareBaseline(baseline); len(regressions) != 0 {
+		t.Errorf("baseline regressed against itself: %+v", regressions)
+	}
+}
@@ -0,0 +1,103 @@
+package extract
+
+import (
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PatternStat records how one ReferenceExtractor pattern performed over the
+// texts it was run against.
+type PatternStat struct {
+	// Name identifies the pattern, e.g. "articleParen" or "uscSectionOfAct".
+	Name string `json:"name"`
+
+	// Regexp is the pattern's source.
+	Regexp string `json:"regexp"`
+
+	// Calls is the number of texts the pattern was applied to.
+	Calls int `json:"calls"`
+
+	// Skipped is how many of those texts the keyword pre-filter ruled out
+	// without running the pattern.
+	Skipped int `json:"skipped"`
+
+	// Matches is the total number of matches found.
+	Matches int `json:"matches"`
+
+	// Duration is the total time spent matching.
+	Duration time.Duration `json:"duration_ns"`
+}
+
+type patternStatsRecorder struct {
+	mu    sync.Mutex
+	stats map[string]*PatternStat
+}
+
+// EnablePatternStats makes the extractor record match counts and time for
+// each of its patterns, for profiling slow patterns on large corpora.
+// Recording adds a timer read per pattern per article.
+func (e *ReferenceExtractor) EnablePatternStats() {
+	if e.patternStats == nil {
+		e.patternStats = &patternStatsRecorder{stats: make(map[string]*PatternStat)}
+	}
+}
+
+// PatternStats returns the recorded statistics, slowest pattern first, or
+// nil if EnablePatternStats was not called.
+func (e *ReferenceExtractor) PatternStats() []PatternStat {
+	if e.patternStats == nil {
+		return nil
+	}
+	e.patternStats.mu.Lock()
+	defer e.patternStats.mu.Unlock()
+
+	stats := make([]PatternStat, 0, len(e.patternStats.stats))
+	for _, stat := range e.patternStats.stats {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Duration != stats[j].Duration {
+			return stats[i].Duration > stats[j].Duration
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
+
+// findAll returns the submatch indexes of every match of pattern in the
+// scanned text, recording them under name when pattern statistics are
+// enabled. Patterns overridden by a reference pack run over the whole text,
+// since their keywords are unknown.
+func (e *ReferenceExtractor) findAll(scan *textScan, name string, pattern *regexp.Regexp) [][]int {
+	keywordName := name
+	if e.overridden[name] {
+		keywordName = ""
+	}
+	if e.patternStats == nil {
+		matches, _ := scan.matchAll(keywordName, pattern)
+		scan.record(name, matches)
+		return matches
+	}
+
+	start := time.Now()
+	matches, ran := scan.matchAll(keywordName, pattern)
+	elapsed := time.Since(start)
+	scan.record(name, matches)
+
+	e.patternStats.mu.Lock()
+	defer e.patternStats.mu.Unlock()
+	stat, ok := e.patternStats.stats[name]
+	if !ok {
+		stat = &PatternStat{Name: name, Regexp: pattern.String()}
+		e.patternStats.stats[name] = stat
+	}
+	stat.Calls++
+	if !ran {
+		stat.Skipped++
+	}
+	stat.Matches += len(matches)
+	stat.Duration += elapsed
+	return matches
+}
@@ -0,0 +1,38 @@
+package extract
+
+import "testing"
+
+func TestReferenceExtractor_PatternStats(t *testing.T) {
+	extractor := NewReferenceExtractor()
+	article := &Article{Number: 1, Text: "As referred to in Article 6(1) and Article 9, see Directive 95/46/EC."}
+
+	extractor.ExtractFromArticle(article)
+	if stats := extractor.PatternStats(); stats != nil {
+		t.Fatalf("stats recorded without EnablePatternStats: %v", stats)
+	}
+
+	extractor.EnablePatternStats()
+	extractor.ExtractFromArticle(article)
+	extractor.ExtractFromArticle(article)
+
+	byName := make(map[string]PatternStat)
+	for _, stat := range extractor.PatternStats() {
+		byName[stat.Name] = stat
+	}
+	articleStat, ok := byName["article"]
+	if !ok {
+		t.Fatalf("no stats for the article pattern: %v", byName)
+	}
+	if articleStat.Calls != 2 || articleStat.Matches != 4 {
+		t.Errorf("article pattern: %d calls, %d matches; want 2, 4", articleStat.Calls, articleStat.Matches)
+	}
+	if articleStat.Regexp == "" {
+		t.Error("article pattern has no regexp source")
+	}
+	if hinds := byName["hinds"]; hinds.Calls != 2 || hinds.Skipped != 2 {
+		t.Errorf("hinds pattern: %d calls, %d skipped; want both 2", hinds.Calls, hinds.Skipped)
+	}
+	if byName["directive"].Matches != 2 {
+		t.Errorf("directive pattern matches = %d, want 2", byName["directive"].Matches)
+	}
+}
@@ -0,0 +1,460 @@
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/pattern"
+)
+
+// PatternExtractor identifies a pattern-driven extraction stage that can be
+// re-run independently during pattern development.
+type PatternExtractor string
+
+const (
+	// PatternExtractorStructure covers hierarchy parsing (chapters, sections, articles).
+	PatternExtractorStructure PatternExtractor = "structure"
+	// PatternExtractorDefinitions covers the definition pattern.
+	PatternExtractorDefinitions PatternExtractor = "definitions"
+	// PatternExtractorReferences covers internal and external reference patterns.
+	PatternExtractorReferences PatternExtractor = "references"
+)
+
+// allPatternExtractors lists extractors in pipeline order.
+var allPatternExtractors = []PatternExtractor{
+	PatternExtractorStructure,
+	PatternExtractorDefinitions,
+	PatternExtractorReferences,
+}
+
+// PatternMatch is a single match produced by a pattern-driven extractor.
+type PatternMatch struct {
+	Extractor PatternExtractor `json:"extractor"`
+	Location  string           `json:"location"`
+	Text      string           `json:"text"`
+}
+
+// key returns a string that uniquely identifies the match for diffing.
+func (m PatternMatch) key() string {
+	return string(m.Extractor) + "\x00" + m.Location + "\x00" + m.Text
+}
+
+// PatternMatchDiff lists matches gained and lost by one extractor.
+type PatternMatchDiff struct {
+	Extractor PatternExtractor `json:"extractor"`
+	Before    int              `json:"before"`
+	After     int              `json:"after"`
+	Gained    []PatternMatch   `json:"gained,omitempty"`
+	Lost      []PatternMatch   `json:"lost,omitempty"`
+}
+
+// PatternDevResult describes the effect of applying an edited pattern.
+type PatternDevResult struct {
+	FormatID   string             `json:"format_id"`
+	Rerun      []PatternExtractor `json:"rerun"`
+	Diffs      []PatternMatchDiff `json:"diffs"`
+	Duration   time.Duration      `json:"duration_ns"`
+	Inactive   bool               `json:"inactive,omitempty"`
+	Structural bool               `json:"structural,omitempty"`
+}
+
+// PatternDevSession caches a parsed document so that pattern edits can be
+// evaluated by re-running only the extractors whose patterns changed.
+type PatternDevSession struct {
+	sourceText string
+	registry   *pattern.DefaultRegistry
+	active     *pattern.FormatPattern
+	document   *Document
+	matches    map[PatternExtractor][]PatternMatch
+}
+
+// NewPatternDevSession parses sourceText once using the registry and records
+// the initial matches of every pattern-driven extractor.
+func NewPatternDevSession(sourceText string, registry *pattern.DefaultRegistry) (*PatternDevSession, error) {
+	if registry == nil {
+		return nil, fmt.Errorf("pattern registry is required")
+	}
+
+	session := &PatternDevSession{
+		sourceText: sourceText,
+		registry:   registry,
+		matches:    make(map[PatternExtractor][]PatternMatch),
+	}
+
+	if bridge := pattern.DetectAndBridge(registry, sourceText, 0.3); bridge != nil {
+		session.active, _ = registry.Get(bridge.FormatID())
+	}
+
+	if err := session.reparse(); err != nil {
+		return nil, err
+	}
+	for _, extractor := range allPatternExtractors {
+		matches, err := session.run(extractor)
+		if err != nil {
+			return nil, err
+		}
+		session.matches[extractor] = matches
+	}
+
+	return session, nil
+}
+
+// FormatID returns the format ID of the pattern driving the document, or an
+// empty string when no registry pattern matched.
+func (s *PatternDevSession) FormatID() string {
+	if s.active == nil {
+		return ""
+	}
+	return s.active.FormatID
+}
+
+// Document returns the cached parsed document.
+func (s *PatternDevSession) Document() *Document {
+	return s.document
+}
+
+// Matches returns the current matches for an extractor.
+func (s *PatternDevSession) Matches(extractor PatternExtractor) []PatternMatch {
+	return s.matches[extractor]
+}
+
+// Apply registers an edited pattern and re-runs only the extractors affected
+// by the edit against the cached document. Edits to a pattern other than the
+// one driving the document are reported as inactive.
+func (s *PatternDevSession) Apply(updated *pattern.FormatPattern) (*PatternDevResult, error) {
+	if updated == nil {
+		return nil, fmt.Errorf("pattern cannot be nil")
+	}
+
+	startTime := time.Now()
+	previous, _ := s.registry.Get(updated.FormatID)
+	if err := s.registry.Replace(updated); err != nil {
+		return nil, err
+	}
+
+	result := &PatternDevResult{FormatID: updated.FormatID}
+	if s.active == nil || s.active.FormatID != updated.FormatID {
+		result.Inactive = true
+		result.Duration = time.Since(startTime)
+		return result, nil
+	}
+
+	result.Rerun = AffectedPatternExtractors(previous, updated)
+	s.active = updated
+
+	for _, extractor := range result.Rerun {
+		if extractor == PatternExtractorStructure {
+			result.Structural = true
+			if err := s.reparse(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, extractor := range result.Rerun {
+		before := s.matches[extractor]
+		after, err := s.run(extractor)
+		if err != nil {
+			return nil, err
+		}
+		s.matches[extractor] = after
+		result.Diffs = append(result.Diffs, DiffPatternMatches(extractor, before, after))
+	}
+
+	result.Duration = time.Since(startTime)
+	return result, nil
+}
+
+// AffectedPatternExtractors compares two versions of a pattern and returns
+// the extractors whose configuration changed. A structural change re-parses
+// the document, so every downstream extractor is re-run as well.
+func AffectedPatternExtractors(previous, updated *pattern.FormatPattern) []PatternExtractor {
+	if previous == nil || updated == nil {
+		return allPatternExtractors
+	}
+
+	if !sameConfig(previous.Detection, updated.Detection) || !sameConfig(previous.Structure, updated.Structure) {
+		return allPatternExtractors
+	}
+
+	var affected []PatternExtractor
+	if !sameConfig(previous.Definitions, updated.Definitions) {
+		affected = append(affected, PatternExtractorDefinitions)
+	}
+	if !sameConfig(previous.References, updated.References) {
+		affected = append(affected, PatternExtractorReferences)
+	}
+	return affected
+}
+
+// DiffPatternMatches computes the matches gained and lost between two runs.
+func DiffPatternMatches(extractor PatternExtractor, before, after []PatternMatch) PatternMatchDiff {
+	diff := PatternMatchDiff{Extractor: extractor, Before: len(before), After: len(after)}
+
+	beforeKeys := make(map[string]bool, len(before))
+	for _, match := range before {
+		beforeKeys[match.key()] = true
+	}
+	afterKeys := make(map[string]bool, len(after))
+	for _, match := range after {
+		afterKeys[match.key()] = true
+		if !beforeKeys[match.key()] {
+			diff.Gained = append(diff.Gained, match)
+		}
+	}
+	for _, match := range before {
+		if !afterKeys[match.key()] {
+			diff.Lost = append(diff.Lost, match)
+		}
+	}
+	return diff
+}
+
+// String formats the result as a live diff for terminal output.
+func (r *PatternDevResult) String() string {
+	var sb strings.Builder
+
+	if r.Inactive {
+		sb.WriteString(fmt.Sprintf("Pattern %s changed but does not drive this document; nothing to re-run\n", r.FormatID))
+		return sb.String()
+	}
+	if len(r.Rerun) == 0 {
+		sb.WriteString(fmt.Sprintf("Pattern %s changed without affecting extraction patterns\n", r.FormatID))
+		return sb.String()
+	}
+
+	rerunNames := make([]string, len(r.Rerun))
+	for i, extractor := range r.Rerun {
+		rerunNames[i] = string(extractor)
+	}
+	sb.WriteString(fmt.Sprintf("Pattern %s: re-ran %s in %v\n",
+		r.FormatID, strings.Join(rerunNames, ", "), r.Duration.Round(time.Microsecond)))
+
+	for _, diff := range r.Diffs {
+		sb.WriteString(fmt.Sprintf("  %-12s %d → %d (+%d/-%d)\n",
+			diff.Extractor, diff.Before, diff.After, len(diff.Gained), len(diff.Lost)))
+		for _, match := range diff.Gained {
+			sb.WriteString(fmt.Sprintf("    + [%s] %s\n", match.Location, match.Text))
+		}
+		for _, match := range diff.Lost {
+			sb.WriteString(fmt.Sprintf("    - [%s] %s\n", match.Location, match.Text))
+		}
+	}
+
+	return sb.String()
+}
+
+// reparse parses the source text with the session registry.
+func (s *PatternDevSession) reparse() error {
+	parser := NewParserWithRegistry(s.registry)
+	doc, err := parser.Parse(strings.NewReader(s.sourceText))
+	if err != nil {
+		return fmt.Errorf("failed to parse document: %w", err)
+	}
+	s.document = doc
+	return nil
+}
+
+// run executes a single pattern-driven extractor against the cached
+// document. Definitions and references come from the same extractors
+// ingest uses, so the matches are the terms and references ingest would
+// add to the graph.
+func (s *PatternDevSession) run(extractor PatternExtractor) ([]PatternMatch, error) {
+	switch extractor {
+	case PatternExtractorStructure:
+		return structureMatches(s.document), nil
+	case PatternExtractorDefinitions:
+		return definitionMatches(s.document), nil
+	case PatternExtractorReferences:
+		return referenceMatches(s.document, s.active)
+	}
+	return nil, nil
+}
+
+// structureMatches lists the structural elements recognized in the document.
+func structureMatches(doc *Document) []PatternMatch {
+	var matches []PatternMatch
+	for _, chapter := range doc.Chapters {
+		matches = append(matches, PatternMatch{
+			Extractor: PatternExtractorStructure,
+			Location:  "Chapter " + chapter.Number,
+			Text:      chapter.Title,
+		})
+		for _, section := range chapter.Sections {
+			matches = append(matches, PatternMatch{
+				Extractor: PatternExtractorStructure,
+				Location:  fmt.Sprintf("Chapter %s Section %d", chapter.Number, section.Number),
+				Text:      section.Title,
+			})
+		}
+	}
+	for _, article := range doc.AllArticles() {
+		matches = append(matches, PatternMatch{
+			Extractor: PatternExtractorStructure,
+			Location:  articleLocation(article),
+			Text:      article.Title,
+		})
+	}
+	return matches
+}
+
+// definitionMatches lists the terms the DefinitionExtractor finds in the
+// document.
+func definitionMatches(doc *Document) []PatternMatch {
+	locations := articleLocations(doc)
+
+	var matches []PatternMatch
+	for _, term := range NewDefinitionExtractor().ExtractDefinitions(doc) {
+		matches = append(matches, PatternMatch{
+			Extractor: PatternExtractorDefinitions,
+			Location:  locations.of(term.ArticleRef),
+			Text:      term.Term,
+		})
+	}
+	return matches
+}
+
+// referenceMatches lists the references the ReferenceExtractor finds in the
+// document, with the format pattern's reference patterns added as a
+// reference pack.
+func referenceMatches(doc *Document, formatPattern *pattern.FormatPattern) ([]PatternMatch, error) {
+	refExtractor, err := NewReferenceExtractorWithPacks(formatReferencePack(formatPattern))
+	if err != nil {
+		return nil, err
+	}
+	locations := articleLocations(doc)
+
+	var matches []PatternMatch
+	for _, ref := range refExtractor.ExtractFromDocument(doc) {
+		matches = append(matches, PatternMatch{
+			Extractor: PatternExtractorReferences,
+			Location:  locations.of(ref.SourceArticle),
+			Text:      string(ref.Target) + ": " + ref.RawText,
+		})
+	}
+	return matches, nil
+}
+
+// formatReferencePack converts the internal and external reference
+// patterns of a format pattern to custom patterns of a reference pack.
+// Capture groups are named from the pattern's group mappings where a
+// Reference field matches ("number" fills the target's field, or the
+// document number of an external reference); other groups are unnamed.
+// A nil format pattern gives an empty pack.
+func formatReferencePack(formatPattern *pattern.FormatPattern) *ReferencePack {
+	pack := &ReferencePack{Name: "format patterns"}
+	if formatPattern == nil {
+		return pack
+	}
+	pack.Name = formatPattern.Name
+	pack.PackID = formatPattern.FormatID
+
+	for i, internalPattern := range formatPattern.References.Internal {
+		if internalPattern.Target == "" {
+			continue
+		}
+		groups := make(map[int]string)
+		for name, index := range internalPattern.Groups {
+			if name == "number" {
+				name = internalPattern.Target
+			}
+			groups[index] = name
+		}
+		pack.Patterns = append(pack.Patterns, PackPattern{
+			Name:    fmt.Sprintf("%s.internal.%d", formatPattern.FormatID, i),
+			Pattern: nameCaptureGroups(internalPattern.Pattern, groups),
+			Type:    ReferenceTypeInternal,
+			Target:  ReferenceTarget(internalPattern.Target),
+		})
+	}
+	for i, externalPattern := range formatPattern.References.External {
+		if externalPattern.Type == "" {
+			continue
+		}
+		groups := make(map[int]string)
+		for name, index := range externalPattern.Groups {
+			switch name {
+			case "year":
+				name = "doc_year"
+			case "number":
+				name = "doc_number"
+			}
+			groups[index] = name
+		}
+		pack.Patterns = append(pack.Patterns, PackPattern{
+			Name:    fmt.Sprintf("%s.external.%d", formatPattern.FormatID, i),
+			Pattern: nameCaptureGroups(externalPattern.Pattern, groups),
+			Type:    ReferenceTypeExternal,
+			Target:  ReferenceTarget(externalPattern.Type),
+		})
+	}
+	return pack
+}
+
+// nameCaptureGroups names the capture groups of expr by index, keeping a
+// name only when it is one of customGroups and not already taken. An expr
+// that does not parse is returned unchanged, for the pack to report.
+func nameCaptureGroups(expr string, names map[int]string) string {
+	parsed, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return expr
+	}
+	used := make(map[string]bool)
+	var walk func(*syntax.Regexp)
+	walk = func(node *syntax.Regexp) {
+		if node.Op == syntax.OpCapture {
+			node.Name = ""
+			if name := names[node.Cap]; customGroups[name] && !used[name] {
+				node.Name = name
+				used[name] = true
+			}
+		}
+		for _, sub := range node.Sub {
+			walk(sub)
+		}
+	}
+	walk(parsed)
+	return parsed.String()
+}
+
+// articleLocationIndex maps article numbers to their locations.
+type articleLocationIndex map[int]string
+
+func articleLocations(doc *Document) articleLocationIndex {
+	locations := make(articleLocationIndex)
+	for _, article := range doc.AllArticles() {
+		if _, ok := locations[article.Number]; !ok {
+			locations[article.Number] = articleLocation(article)
+		}
+	}
+	return locations
+}
+
+// of returns the location of the article numbered number.
+func (l articleLocationIndex) of(number int) string {
+	if location, ok := l[number]; ok {
+		return location
+	}
+	return fmt.Sprintf("Article %d", number)
+}
+
+func articleLocation(article *Article) string {
+	if article.SectionID != "" {
+		return "Section " + article.SectionID
+	}
+	return fmt.Sprintf("Article %d", article.Number)
+}
+
+// sameConfig compares two pattern configuration blocks by their serialized
+// form, ignoring compiled regex state.
+func sameConfig(a, b interface{}) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return string(aJSON) == string(bJSON)
+}
@@ -0,0 +1,201 @@
+package extract
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/pattern"
+)
+
+func newGDPRDevSession(t *testing.T) (*PatternDevSession, string) {
+	t.Helper()
+	projectRoot := getProjectRootDir(t)
+	patternsDir := filepath.Join(projectRoot, "patterns")
+
+	registry, err := pattern.NewRegistryWithDirectory(patternsDir)
+	if err != nil {
+		t.Fatalf("Failed to load pattern registry: %v", err)
+	}
+
+	sourceText, err := os.ReadFile(filepath.Join(projectRoot, "testdata", "gdpr.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read gdpr.txt: %v", err)
+	}
+
+	session, err := NewPatternDevSession(string(sourceText), registry)
+	if err != nil {
+		t.Fatalf("NewPatternDevSession failed: %v", err)
+	}
+	return session, filepath.Join(patternsDir, "eu-regulation.yaml")
+}
+
+func TestPatternDevSessionInitialMatches(t *testing.T) {
+	session, _ := newGDPRDevSession(t)
+
+	if session.FormatID() != "eu-regulation" {
+		t.Fatalf("FormatID = %q, want eu-regulation", session.FormatID())
+	}
+	if len(session.Matches(PatternExtractorStructure)) == 0 {
+		t.Error("expected structure matches")
+	}
+	if len(session.Matches(PatternExtractorDefinitions)) == 0 {
+		t.Error("expected definition matches")
+	}
+}
+
+func TestPatternDevSessionDefinitionEdit(t *testing.T) {
+	session, patternPath := newGDPRDevSession(t)
+	structureBefore := len(session.Matches(PatternExtractorStructure))
+	definitionsBefore := len(session.Matches(PatternExtractorDefinitions))
+
+	edited, err := pattern.ParseFile(patternPath)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	edited.Definitions.Pattern = `^NEVER_MATCHES_ANYTHING$`
+	if err := edited.Compile(); err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	result, err := session.Apply(edited)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	if len(result.Rerun) != 1 || result.Rerun[0] != PatternExtractorDefinitions {
+		t.Fatalf("Rerun = %v, want only definitions", result.Rerun)
+	}
+	if result.Structural {
+		t.Error("definition edit should not re-parse structure")
+	}
+	// Ingest takes terms from the DefinitionExtractor, not the format's
+	// definition pattern, so the terms are unchanged.
+	if len(result.Diffs) != 1 || result.Diffs[0].After != definitionsBefore || len(result.Diffs[0].Lost) != 0 {
+		t.Errorf("expected the %d definition matches unchanged, got %+v", definitionsBefore, result.Diffs)
+	}
+	if got := len(session.Matches(PatternExtractorStructure)); got != structureBefore {
+		t.Errorf("structure matches changed from %d to %d", structureBefore, got)
+	}
+}
+
+func TestPatternDevSessionReferenceEdit(t *testing.T) {
+	session, patternPath := newGDPRDevSession(t)
+	referencesBefore := len(session.Matches(PatternExtractorReferences))
+	if referencesBefore == 0 {
+		t.Fatal("expected reference matches")
+	}
+
+	edited, err := pattern.ParseFile(patternPath)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	edited.References.Internal = append(edited.References.Internal, pattern.ReferencePattern{
+		Pattern: `\bsupervisory authorit(?:y|ies)\b`,
+		Target:  "authority",
+	})
+	if err := edited.Compile(); err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	result, err := session.Apply(edited)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	if len(result.Diffs) != 1 || result.Diffs[0].Extractor != PatternExtractorReferences {
+		t.Fatalf("Diffs = %+v, want only references", result.Diffs)
+	}
+	diff := result.Diffs[0]
+	if len(diff.Gained) == 0 || len(diff.Lost) != 0 {
+		t.Fatalf("expected only gained references, got +%d/-%d", len(diff.Gained), len(diff.Lost))
+	}
+	for _, match := range diff.Gained {
+		if !strings.HasPrefix(match.Text, "authority: ") {
+			t.Errorf("gained match %+v is not from the new pattern", match)
+		}
+	}
+	if !strings.Contains(result.String(), "+ [Article ") {
+		t.Errorf("diff output missing gained references:\n%s", result.String())
+	}
+}
+
+func TestPatternDevSessionInactivePattern(t *testing.T) {
+	session, patternPath := newGDPRDevSession(t)
+
+	other, err := pattern.ParseFile(filepath.Join(filepath.Dir(patternPath), "us-california.yaml"))
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	result, err := session.Apply(other)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if !result.Inactive || len(result.Rerun) != 0 {
+		t.Errorf("expected inactive result, got %+v", result)
+	}
+}
+
+func TestAffectedPatternExtractors(t *testing.T) {
+	base := &pattern.FormatPattern{FormatID: "x"}
+	base.References.Internal = []pattern.ReferencePattern{{Pattern: "a", Target: "article"}}
+
+	tests := []struct {
+		name   string
+		mutate func(*pattern.FormatPattern)
+		want   []PatternExtractor
+	}{
+		{"unchanged", func(*pattern.FormatPattern) {}, nil},
+		{"references", func(fp *pattern.FormatPattern) {
+			fp.References.Internal = []pattern.ReferencePattern{{Pattern: "b", Target: "article"}}
+		}, []PatternExtractor{PatternExtractorReferences}},
+		{"structure", func(fp *pattern.FormatPattern) {
+			fp.Structure.Hierarchy = []pattern.HierarchyLevel{{Type: "article", Pattern: "x"}}
+		}, allPatternExtractors},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			updated := *base
+			tc.mutate(&updated)
+			got := AffectedPatternExtractors(base, &updated)
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("got %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatReferencePack(t *testing.T) {
+	formatPattern := &pattern.FormatPattern{Name: "Test", FormatID: "test"}
+	formatPattern.References.Internal = []pattern.ReferencePattern{
+		{Pattern: `Art\. (\d+)\((\d+)\)`, Target: "article", Groups: map[string]int{"number": 1, "paragraph": 2}},
+	}
+	formatPattern.References.External = []pattern.ExternalReferencePattern{
+		{Pattern: `Directive (\d{4})/(\d+)`, Type: "directive", Groups: map[string]int{"year": 1, "number": 2, "code": 3}},
+	}
+
+	pack := formatReferencePack(formatPattern)
+	want := []string{
+		`Art\. (?P<article>[0-9]+)\((?P<paragraph>[0-9]+)\)`,
+		`Directive (?P<doc_year>[0-9]{4})/(?P<doc_number>[0-9]+)`,
+	}
+	if len(pack.Patterns) != len(want) {
+		t.Fatalf("got %d patterns, want %d", len(pack.Patterns), len(want))
+	}
+	for i, packPattern := range pack.Patterns {
+		if packPattern.Pattern != want[i] {
+			t.Errorf("pattern %d = %q, want %q", i, packPattern.Pattern, want[i])
+		}
+	}
+	if _, err := NewReferenceExtractorWithPacks(pack); err != nil {
+		t.Errorf("pack does not load: %v", err)
+	}
+}
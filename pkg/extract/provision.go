@@ -42,6 +42,7 @@ func (e *ProvisionExtractor) ExtractProvisions(article *Article) {
 
 	var currentParagraph *Paragraph
 	var currentPoint *Point
+	var currentSubPoint *SubPoint
 	var textBuffer strings.Builder
 
 	flushText := func() string {
@@ -52,12 +53,16 @@ func (e *ProvisionExtractor) ExtractProvisions(article *Article) {
 
 	saveCurrentPoint := func() {
 		if currentPoint != nil && currentParagraph != nil {
-			currentPoint.Text = flushText()
-			if currentPoint.Text != "" {
+			// Text already taken when the point's first sub-point began
+			if len(currentPoint.SubPoints) == 0 || textBuffer.Len() > 0 {
+				currentPoint.Text = flushText()
+			}
+			if currentPoint.Text != "" || len(currentPoint.SubPoints) > 0 {
 				currentParagraph.Points = append(currentParagraph.Points, currentPoint)
 			}
-			currentPoint = nil
 		}
+		currentPoint = nil
+		currentSubPoint = nil
 	}
 
 	saveCurrentParagraph := func() {
@@ -85,8 +90,9 @@ func (e *ProvisionExtractor) ExtractProvisions(article *Article) {
 			continue
 		}
 
-		// Check for new point
-		if m := e.pointPattern.FindStringSubmatch(line); m != nil {
+		// Check for new point. "(i)", "(v)", and "(x)" are sub-points unless
+		// they follow the point lettered just before them.
+		if m := e.pointPattern.FindStringSubmatch(line); m != nil && !e.isSubPoint(line, currentPoint) {
 			// Skip if this looks like a reference (e.g., "(a) of Article 9(2)")
 			if isPointReference(m[2]) {
 				// Treat as continuation text
@@ -120,10 +126,17 @@ func (e *ProvisionExtractor) ExtractProvisions(article *Article) {
 					Text:    m[2],
 				}
 				currentPoint.SubPoints = append(currentPoint.SubPoints, subPoint)
+				currentSubPoint = subPoint
 			}
 			continue
 		}
 
+		// Continuation of a sub-point
+		if currentSubPoint != nil && line != "" {
+			currentSubPoint.Text += " " + line
+			continue
+		}
+
 		// Continuation line - append to current buffer
 		if line != "" {
 			if textBuffer.Len() > 0 {
@@ -145,6 +158,19 @@ func (e *ProvisionExtractor) ExtractProvisions(article *Article) {
 	}
 }
 
+// isSubPoint reports whether line opens a roman-numeral sub-point of point
+// rather than the next lettered point.
+func (e *ProvisionExtractor) isSubPoint(line string, point *Point) bool {
+	m := e.subPointPattern.FindStringSubmatch(line)
+	if m == nil || point == nil {
+		return false
+	}
+	if len(m[1]) == 1 && len(point.Letter) == 1 && point.Letter[0]+1 == m[1][0] {
+		return false
+	}
+	return true
+}
+
 // ExtractAllProvisions extracts provisions from all articles in a document.
 func (e *ProvisionExtractor) ExtractAllProvisions(doc *Document) {
 	for _, article := range doc.AllArticles() {
@@ -203,6 +203,75 @@ func TestProvisionExtraction_SubPoints(t *testing.T) {
 	// This test verifies the extraction code runs without errors.
 }
 
+func TestProvisionExtraction_RomanSubPoints(t *testing.T) {
+	article := &Article{
+		Number: 10,
+		Text: strings.Join([]string{
+			"1.   The controller shall inform the authority of:",
+			"(h) the categories of data;",
+			"(i) the recipients, including:",
+			"(i) recipients in third countries",
+			"or international organisations;",
+			"(ii) processors;",
+			"(j) the retention period.",
+		}, "\n"),
+	}
+	NewProvisionExtractor().ExtractProvisions(article)
+
+	if len(article.Paragraphs) != 1 {
+		t.Fatalf("Expected 1 paragraph, got %d", len(article.Paragraphs))
+	}
+	points := article.Paragraphs[0].Points
+	var letters []string
+	for _, point := range points {
+		letters = append(letters, point.Letter)
+	}
+	if strings.Join(letters, ",") != "h,i,j" {
+		t.Fatalf("Point letters = %v, want h,i,j", letters)
+	}
+
+	pointI := points[1]
+	if pointI.Text != "the recipients, including:" {
+		t.Errorf("Point (i) text = %q", pointI.Text)
+	}
+	if len(pointI.SubPoints) != 2 {
+		t.Fatalf("Expected 2 sub-points in point (i), got %d", len(pointI.SubPoints))
+	}
+	if got := pointI.SubPoints[0].Text; got != "recipients in third countries or international organisations;" {
+		t.Errorf("Sub-point (i) text = %q", got)
+	}
+	if pointI.SubPoints[1].Numeral != "ii" {
+		t.Errorf("Second sub-point numeral = %q, want ii", pointI.SubPoints[1].Numeral)
+	}
+}
+
+func TestParse_PopulatesProvisions(t *testing.T) {
+	f := loadGDPRText(t)
+	defer f.Close()
+
+	doc, err := NewParser().Parse(f)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	article := doc.GetArticle(6)
+	if article == nil || len(article.Paragraphs) < 4 {
+		t.Fatalf("Article 6 should be parsed into paragraphs, got %+v", article)
+	}
+	if points := article.Paragraphs[0].Points; len(points) != 6 || points[0].Letter != "a" {
+		t.Errorf("Article 6(1) should have points (a) to (f), got %d", len(points))
+	}
+
+	// Articles without numbered paragraphs keep only their text
+	for _, article := range doc.AllArticles() {
+		for _, para := range article.Paragraphs {
+			if para.Number == 0 {
+				t.Errorf("Article %d has an implicit paragraph", article.Number)
+			}
+		}
+	}
+}
+
 func TestProvisionExtraction_ParagraphNumbersPreserved(t *testing.T) {
 	f := loadGDPRText(t)
 	defer f.Close()
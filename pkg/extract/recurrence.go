@@ -0,0 +1,503 @@
+package extract
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RecurrenceFrequency is the FREQ part of a recurrence rule.
+type RecurrenceFrequency string
+
+const (
+	FrequencyDaily   RecurrenceFrequency = "DAILY"
+	FrequencyWeekly  RecurrenceFrequency = "WEEKLY"
+	FrequencyMonthly RecurrenceFrequency = "MONTHLY"
+	FrequencyYearly  RecurrenceFrequency = "YEARLY"
+)
+
+// Recurrence sources.
+const (
+	RecurrenceSourceText       = "text"
+	RecurrenceSourceAnnotation = "annotation"
+)
+
+// Recurrence describes how often an obligation recurs, using a subset of
+// iCalendar RRULE semantics: FREQ, INTERVAL, BYMONTH, and BYMONTHDAY.
+type Recurrence struct {
+	Frequency  RecurrenceFrequency `json:"frequency"`
+	Interval   int                 `json:"interval,omitempty"`
+	ByMonth    int                 `json:"by_month,omitempty"`
+	ByMonthDay int                 `json:"by_month_day,omitempty"`
+
+	// Source is "text" when extracted from the provision or "annotation"
+	// when set by a recurrence annotation file.
+	Source      string `json:"source,omitempty"`
+	MatchedText string `json:"matched_text,omitempty"`
+}
+
+// Rule returns the recurrence as an RRULE value, e.g.
+// "FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=31".
+func (recurrence *Recurrence) Rule() string {
+	parts := []string{"FREQ=" + string(recurrence.Frequency)}
+	if recurrence.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", recurrence.Interval))
+	}
+	if recurrence.ByMonth > 0 {
+		parts = append(parts, fmt.Sprintf("BYMONTH=%d", recurrence.ByMonth))
+	}
+	if recurrence.ByMonthDay > 0 {
+		parts = append(parts, fmt.Sprintf("BYMONTHDAY=%d", recurrence.ByMonthDay))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Describe returns a human-readable summary such as "every 2 years" or
+// "annually on Jan 31".
+func (recurrence *Recurrence) Describe() string {
+	interval := recurrence.interval()
+	var description string
+	switch {
+	case recurrence.Frequency == FrequencyYearly && interval == 1:
+		description = "annually"
+	case recurrence.Frequency == FrequencyMonthly && interval == 3:
+		description = "quarterly"
+	case recurrence.Frequency == FrequencyMonthly && interval == 6:
+		description = "semi-annually"
+	case interval == 1:
+		description = strings.ToLower(string(recurrence.Frequency))
+	default:
+		units := map[RecurrenceFrequency]string{
+			FrequencyDaily: "days", FrequencyWeekly: "weeks", FrequencyMonthly: "months", FrequencyYearly: "years",
+		}
+		description = fmt.Sprintf("every %d %s", interval, units[recurrence.Frequency])
+	}
+	if recurrence.ByMonth > 0 && recurrence.ByMonthDay > 0 {
+		description += fmt.Sprintf(" on %s %d", time.Month(recurrence.ByMonth).String()[:3], recurrence.ByMonthDay)
+	}
+	return description
+}
+
+func (recurrence *Recurrence) interval() int {
+	if recurrence.Interval < 1 {
+		return 1
+	}
+	return recurrence.Interval
+}
+
+// Next returns the first occurrence strictly after the given time. The
+// series starts at anchor (the start of the compliance cycle); BYMONTH and
+// BYMONTHDAY, when set, pin each occurrence to that calendar date.
+func (recurrence *Recurrence) Next(anchor time.Time, after time.Time) time.Time {
+	occurrence := recurrence.first(anchor)
+	for step := 1; !occurrence.After(after); step++ {
+		occurrence = recurrence.nth(anchor, step)
+	}
+	return occurrence
+}
+
+// Occurrences returns every occurrence in (from, until].
+func (recurrence *Recurrence) Occurrences(anchor time.Time, from time.Time, until time.Time) []time.Time {
+	var occurrences []time.Time
+	for occurrence := recurrence.Next(anchor, from); !occurrence.After(until); occurrence = recurrence.Next(anchor, occurrence) {
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences
+}
+
+// first returns the first occurrence on or after anchor.
+func (recurrence *Recurrence) first(anchor time.Time) time.Time {
+	anchor = truncateToDay(anchor)
+	if recurrence.ByMonth > 0 && recurrence.ByMonthDay > 0 {
+		pinned := pinnedDate(anchor.Year(), recurrence.ByMonth, recurrence.ByMonthDay, anchor.Location())
+		if pinned.Before(anchor) {
+			pinned = pinnedDate(anchor.Year()+1, recurrence.ByMonth, recurrence.ByMonthDay, anchor.Location())
+		}
+		return pinned
+	}
+	return anchor
+}
+
+// nth returns the occurrence step intervals after the first one.
+func (recurrence *Recurrence) nth(anchor time.Time, step int) time.Time {
+	first := recurrence.first(anchor)
+	count := step * recurrence.interval()
+	switch recurrence.Frequency {
+	case FrequencyDaily:
+		return first.AddDate(0, 0, count)
+	case FrequencyWeekly:
+		return first.AddDate(0, 0, 7*count)
+	case FrequencyMonthly:
+		return addMonthsClamped(first, count)
+	default:
+		if recurrence.ByMonth > 0 && recurrence.ByMonthDay > 0 {
+			return pinnedDate(first.Year()+count, recurrence.ByMonth, recurrence.ByMonthDay, first.Location())
+		}
+		return addMonthsClamped(first, 12*count)
+	}
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// pinnedDate returns year-month-day, clamping the day to the month length
+// (BYMONTHDAY=31 in a 30-day month falls on the 30th).
+func pinnedDate(year int, month int, day int, location *time.Location) time.Time {
+	lastDay := time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, location).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, location)
+}
+
+// addMonthsClamped adds months without overflowing into the next month
+// (Jan 31 + 1 month is Feb 28/29, not Mar 3).
+func addMonthsClamped(t time.Time, months int) time.Time {
+	target := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	return pinnedDate(target.Year(), int(target.Month()), t.Day(), t.Location())
+}
+
+// ParseRecurrenceRule parses an RRULE value ("FREQ=YEARLY;INTERVAL=2"). An
+// optional "RRULE:" prefix is accepted; unsupported parts are rejected.
+func ParseRecurrenceRule(rule string) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return nil, fmt.Errorf("recurrence rule is empty")
+	}
+
+	recurrence := &Recurrence{}
+	for _, part := range strings.Split(rule, ";") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			return nil, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+		key = strings.ToUpper(key)
+
+		if key == "FREQ" {
+			switch frequency := RecurrenceFrequency(strings.ToUpper(value)); frequency {
+			case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+				recurrence.Frequency = frequency
+			default:
+				return nil, fmt.Errorf("unsupported FREQ %q", value)
+			}
+			continue
+		}
+
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an integer, got %q", key, value)
+		}
+		switch key {
+		case "INTERVAL":
+			if number < 1 {
+				return nil, fmt.Errorf("INTERVAL must be positive, got %d", number)
+			}
+			recurrence.Interval = number
+		case "BYMONTH":
+			if number < 1 || number > 12 {
+				return nil, fmt.Errorf("BYMONTH must be 1-12, got %d", number)
+			}
+			recurrence.ByMonth = number
+		case "BYMONTHDAY":
+			if number < 1 || number > 31 {
+				return nil, fmt.Errorf("BYMONTHDAY must be 1-31, got %d", number)
+			}
+			recurrence.ByMonthDay = number
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part %q", key)
+		}
+	}
+
+	if recurrence.Frequency == "" {
+		return nil, fmt.Errorf("recurrence rule %q has no FREQ", rule)
+	}
+	if (recurrence.ByMonth > 0) != (recurrence.ByMonthDay > 0) {
+		return nil, fmt.Errorf("BYMONTH and BYMONTHDAY must be used together")
+	}
+	if recurrence.ByMonth > 0 && recurrence.Frequency != FrequencyYearly {
+		return nil, fmt.Errorf("BYMONTH and BYMONTHDAY require FREQ=YEARLY")
+	}
+	return recurrence, nil
+}
+
+// recurrencePattern maps a phrase in provision text to a recurrence.
+type recurrencePattern struct {
+	pattern   *regexp.Regexp
+	frequency RecurrenceFrequency
+	interval  int // 0 reads the interval from the first capture group
+}
+
+var recurrencePatterns = []recurrencePattern{
+	{regexp.MustCompile(`(?i)\bevery\s+(two|three|four|five|2|3|4|5)\s+years\b`), FrequencyYearly, 0},
+	{regexp.MustCompile(`(?i)\bbiennial(?:ly)?\b`), FrequencyYearly, 2},
+	{regexp.MustCompile(`(?i)\b(?:semi-?annual(?:ly)?|twice\s+(?:a|per|each)\s+year|every\s+six\s+months)\b`), FrequencyMonthly, 6},
+	{regexp.MustCompile(`(?i)\b(?:quarterly|every\s+(?:three|3)\s+months)\b`), FrequencyMonthly, 3},
+	{regexp.MustCompile(`(?i)\b(?:annually|annual\s+(?:report|review|audit|assessment|training|filing|return|statement|certification|update|renewal)s?|(?:at\s+least\s+)?once\s+(?:a|per|each|every)\s+year|every\s+year|each\s+(?:calendar\s+)?year|per\s+annum|yearly)\b`), FrequencyYearly, 1},
+	{regexp.MustCompile(`(?i)\b(?:monthly|every\s+month|each\s+month)\b`), FrequencyMonthly, 1},
+	{regexp.MustCompile(`(?i)\b(?:weekly|every\s+week|each\s+week)\b`), FrequencyWeekly, 1},
+}
+
+// annualDatePattern matches fixed yearly deadlines such as "by January 31 of
+// each year" or "no later than 1 March each year".
+var annualDatePattern = regexp.MustCompile(`(?i)\b(?:by|before|on\s+or\s+before|no\s+later\s+than|not\s+later\s+than)\s+` +
+	`(?:(January|February|March|April|May|June|July|August|September|October|November|December)\s+(\d{1,2})|` +
+	`(\d{1,2})\s+(January|February|March|April|May|June|July|August|September|October|November|December))` +
+	`,?\s+(?:of\s+)?(?:each|every)\s+(?:calendar\s+)?year\b`)
+
+var numberWords = map[string]int{"two": 2, "three": 3, "four": 4, "five": 5}
+
+// monthNumber returns 1-12 for an English month name, or 0.
+func monthNumber(name string) int {
+	for month := time.January; month <= time.December; month++ {
+		if strings.EqualFold(month.String(), name) {
+			return int(month)
+		}
+	}
+	return 0
+}
+
+// ExtractRecurrence detects recurrence language in provision text, returning
+// nil when the text does not describe a recurring duty. Fixed annual
+// deadlines ("by January 31 of each year") take precedence over frequency
+// words.
+func ExtractRecurrence(text string) *Recurrence {
+	if match := annualDatePattern.FindStringSubmatch(text); match != nil {
+		monthName, dayText := match[1], match[2]
+		if monthName == "" {
+			monthName, dayText = match[4], match[3]
+		}
+		day, _ := strconv.Atoi(dayText)
+		month := monthNumber(monthName)
+		if month > 0 && day >= 1 && day <= 31 {
+			return &Recurrence{
+				Frequency:   FrequencyYearly,
+				ByMonth:     month,
+				ByMonthDay:  day,
+				Source:      RecurrenceSourceText,
+				MatchedText: match[0],
+			}
+		}
+	}
+
+	for _, candidate := range recurrencePatterns {
+		match := candidate.pattern.FindStringSubmatch(text)
+		if match == nil {
+			continue
+		}
+		interval := candidate.interval
+		if interval == 0 {
+			interval = numberWords[strings.ToLower(match[1])]
+			if interval == 0 {
+				interval, _ = strconv.Atoi(match[1])
+			}
+		}
+		return &Recurrence{
+			Frequency:   candidate.frequency,
+			Interval:    interval,
+			Source:      RecurrenceSourceText,
+			MatchedText: match[0],
+		}
+	}
+	return nil
+}
+
+// dutyVerbPattern marks a sentence that imposes a duty.
+var dutyVerbPattern = regexp.MustCompile(`(?i)\b(?:shall|must)\b`)
+
+// measureFollowerPattern marks a recurrence phrase that qualifies a measure,
+// as in "monthly active recipients" or "per annum rate", rather than a duty.
+var measureFollowerPattern = regexp.MustCompile(`^\s+(?:active|rate|pay|salary)\b`)
+
+// findRecurringDuty finds the first sentence of text that imposes a duty
+// and says how often it recurs, such as "Each supervisory authority shall
+// draw up an annual report" or "They shall carry out the risk assessments
+// ... at least once a year thereafter". It returns the bounds of the
+// recurrence phrase and the recurrence, or nil when there is none.
+func findRecurringDuty(text string) ([]int, *Recurrence) {
+	locations := annualDatePattern.FindAllStringIndex(text, -1)
+	for _, candidate := range recurrencePatterns {
+		locations = append(locations, candidate.pattern.FindAllStringIndex(text, -1)...)
+	}
+	sort.Slice(locations, func(i, j int) bool { return locations[i][0] < locations[j][0] })
+
+	for _, location := range locations {
+		if measureFollowerPattern.MatchString(text[location[1]:]) {
+			continue
+		}
+		sentence := sentenceAround(text, location[0], location[1])
+		if !dutyVerbPattern.MatchString(sentence) {
+			continue
+		}
+		if recurrence := ExtractRecurrence(sentence); recurrence != nil {
+			return location, recurrence
+		}
+	}
+	return nil, nil
+}
+
+// sentenceSeparators end a sentence or an enumerated point.
+var sentenceSeparators = []string{". ", "; ", "\n("}
+
+// sentenceAround returns the sentence (or enumerated point) of text
+// containing [start, end), so recurrence is only read from the sentence that
+// imposes an obligation.
+func sentenceAround(text string, start, end int) string {
+	sentenceStart := 0
+	for _, separator := range sentenceSeparators {
+		if index := strings.LastIndex(text[:start], separator); index >= 0 && index+len(separator) > sentenceStart {
+			sentenceStart = index + len(separator)
+		}
+	}
+	sentenceEnd := len(text)
+	for _, separator := range sentenceSeparators {
+		if index := strings.Index(text[end:], separator); index >= 0 && end+index+1 < sentenceEnd {
+			sentenceEnd = end + index + 1
+		}
+	}
+	return text[sentenceStart:sentenceEnd]
+}
+
+// RecurrenceAnnotationFileName is the per-document recurrence annotation
+// file looked up alongside a source document.
+const RecurrenceAnnotationFileName = "recurrence.yaml"
+
+// RecurrenceAnnotation sets the recurrence of the obligations imposed by an
+// article, overriding anything extracted from the text. ObligationType
+// narrows the annotation to one obligation; Rule "none" clears recurrence.
+// When the article has no matching extracted obligation, one is added.
+type RecurrenceAnnotation struct {
+	Article        int    `yaml:"article" json:"article"`
+	ObligationType string `yaml:"obligation_type,omitempty" json:"obligation_type,omitempty"`
+	Rule           string `yaml:"rule" json:"rule"`
+	Note           string `yaml:"note,omitempty" json:"note,omitempty"`
+
+	recurrence *Recurrence
+}
+
+// RecurrenceAnnotationFile is the on-disk format of a recurrence.yaml file.
+//
+//	recurrence:
+//	  - article: 30
+//	    obligation_type: RecordKeepingObligation
+//	    rule: FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=31
+//	    note: "Internal policy: review records each January"
+type RecurrenceAnnotationFile struct {
+	Recurrence []RecurrenceAnnotation `yaml:"recurrence" json:"recurrence"`
+}
+
+// ParseRecurrenceAnnotations parses recurrence.yaml content and validates
+// every rule.
+func ParseRecurrenceAnnotations(data []byte) ([]RecurrenceAnnotation, error) {
+	var annotationFile RecurrenceAnnotationFile
+	if err := yaml.Unmarshal(data, &annotationFile); err != nil {
+		return nil, fmt.Errorf("failed to parse recurrence annotations: %w", err)
+	}
+
+	for index := range annotationFile.Recurrence {
+		annotation := &annotationFile.Recurrence[index]
+		if annotation.Article <= 0 {
+			return nil, fmt.Errorf("annotation %d: article is required", index+1)
+		}
+		if strings.EqualFold(strings.TrimSpace(annotation.Rule), "none") {
+			continue
+		}
+		recurrence, err := ParseRecurrenceRule(annotation.Rule)
+		if err != nil {
+			return nil, fmt.Errorf("annotation %d (article %d): %w", index+1, annotation.Article, err)
+		}
+		recurrence.Source = RecurrenceSourceAnnotation
+		annotation.recurrence = recurrence
+	}
+
+	return annotationFile.Recurrence, nil
+}
+
+// LoadRecurrenceAnnotations reads and parses a recurrence.yaml file.
+func LoadRecurrenceAnnotations(path string) ([]RecurrenceAnnotation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recurrence annotations: %w", err)
+	}
+	annotations, err := ParseRecurrenceAnnotations(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return annotations, nil
+}
+
+// FindRecurrenceAnnotationFile returns the recurrence annotation file for a
+// source document, or "" if none exists, following the same lookup rules as
+// FindReferenceMappingFile.
+func FindRecurrenceAnnotationFile(sourcePath string) string {
+	sourceDir := filepath.Dir(sourcePath)
+	baseName := filepath.Base(sourcePath)
+	stem := strings.TrimSuffix(baseName, filepath.Ext(baseName))
+
+	candidates := []string{filepath.Join(sourceDir, stem+"."+RecurrenceAnnotationFileName)}
+	if baseName == "source.txt" {
+		candidates = append(candidates, filepath.Join(sourceDir, RecurrenceAnnotationFileName))
+	}
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// applyRecurrenceAnnotations overrides the recurrence of matching
+// obligation annotations. An annotation with a rule that matches no
+// extracted obligation adds one to that article (when it is one of
+// articleNumbers), so recurring duties the patterns miss (e.g., "shall draw
+// up an annual report") can still be scheduled.
+func applyRecurrenceAnnotations(annotations []*SemanticAnnotation, overrides []RecurrenceAnnotation, articleNumbers map[int]bool) []*SemanticAnnotation {
+	for _, override := range overrides {
+		matched := false
+		for _, annotation := range annotations {
+			if annotation.Type != SemanticObligation || annotation.ArticleNum != override.Article {
+				continue
+			}
+			if override.ObligationType != "" && override.ObligationType != string(annotation.ObligationType) {
+				continue
+			}
+			annotation.Recurrence = override.copyRecurrence()
+			matched = true
+		}
+		if matched || override.recurrence == nil || !articleNumbers[override.Article] {
+			continue
+		}
+
+		obligationType := ObligationType(override.ObligationType)
+		if obligationType == "" {
+			obligationType = ObligationGeneric
+		}
+		annotations = append(annotations, &SemanticAnnotation{
+			Type:           SemanticObligation,
+			ArticleNum:     override.Article,
+			ObligationType: obligationType,
+			DutyBearer:     EntityUnspecified,
+			Recurrence:     override.copyRecurrence(),
+			MatchedText:    override.Note,
+			MatchedPattern: "recurrence annotation",
+			Confidence:     1.0,
+		})
+	}
+	return annotations
+}
+
+func (annotation RecurrenceAnnotation) copyRecurrence() *Recurrence {
+	if annotation.recurrence == nil {
+		return nil
+	}
+	recurrence := *annotation.recurrence
+	return &recurrence
+}
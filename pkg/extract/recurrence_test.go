@@ -0,0 +1,310 @@
+package extract
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExtractRecurrence(t *testing.T) {
+	tests := []struct {
+		text string
+		rule string
+	}{
+		{"The controller shall review the policy annually.", "FREQ=YEARLY"},
+		{"The provider shall submit an annual report to the authority.", "FREQ=YEARLY"},
+		{"Staff shall be trained at least once a year.", "FREQ=YEARLY"},
+		{"The assessment shall be repeated every two years.", "FREQ=YEARLY;INTERVAL=2"},
+		{"A biennial audit shall be carried out.", "FREQ=YEARLY;INTERVAL=2"},
+		{"Figures shall be published quarterly.", "FREQ=MONTHLY;INTERVAL=3"},
+		{"The board shall meet semi-annually.", "FREQ=MONTHLY;INTERVAL=6"},
+		{"Logs shall be checked weekly.", "FREQ=WEEKLY"},
+		{"The business shall file a return by January 31 of each year.", "FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=31"},
+		{"Reports are due no later than 1 March each year.", "FREQ=YEARLY;BYMONTH=3;BYMONTHDAY=1"},
+		{"Each supervisory authority shall draw up an annual report on its\nactivities.", "FREQ=YEARLY"},
+		{"They shall carry out the risk assessments by the date of application and at\nleast once a year thereafter.", "FREQ=YEARLY"},
+	}
+
+	for _, test := range tests {
+		recurrence := ExtractRecurrence(test.text)
+		if recurrence == nil {
+			t.Errorf("%q: expected recurrence %s, got nil", test.text, test.rule)
+			continue
+		}
+		if recurrence.Rule() != test.rule {
+			t.Errorf("%q: got %s, want %s", test.text, recurrence.Rule(), test.rule)
+		}
+		if recurrence.Source != RecurrenceSourceText {
+			t.Errorf("%q: source %q, want text", test.text, recurrence.Source)
+		}
+	}
+}
+
+func TestExtractRecurrence_NoMatch(t *testing.T) {
+	for _, text := range []string{
+		"The controller shall implement appropriate measures.",
+		"Fines of up to 4 % of the total worldwide annual turnover.",
+		"Notification shall be made within 72 hours.",
+	} {
+		if recurrence := ExtractRecurrence(text); recurrence != nil {
+			t.Errorf("%q: expected no recurrence, got %s", text, recurrence.Rule())
+		}
+	}
+}
+
+func TestFindRecurringDuty(t *testing.T) {
+	text := "Member States shall provide for the annual budget. The Board shall draw up an annual report regarding its activities."
+	location, recurrence := findRecurringDuty(text)
+	if recurrence == nil {
+		t.Fatal("expected a recurring duty")
+	}
+	if got := text[location[0]:location[1]]; got != "annual report" {
+		t.Errorf("matched %q, want %q", got, "annual report")
+	}
+
+	for _, text := range []string{
+		"The annual report was published in May.",
+		"The Commission shall adjust the average number of monthly active recipients.",
+	} {
+		if _, recurrence := findRecurringDuty(text); recurrence != nil {
+			t.Errorf("findRecurringDuty(%q) = %s, want none", text, recurrence.Rule())
+		}
+	}
+}
+
+// TestExtractRecurrence_Fixtures checks recurring duties in the test data:
+// the annual reports of GDPR Articles 59 and 71 and the yearly systemic
+// risk assessment of DSA Article 34.
+func TestExtractRecurrence_Fixtures(t *testing.T) {
+	projectRoot := getProjectRootDir(t)
+	tests := []struct {
+		file     string
+		article  int
+		wantRule string
+	}{
+		{"gdpr.txt", 59, "FREQ=YEARLY"},
+		{"gdpr.txt", 71, "FREQ=YEARLY"},
+		{"eu-dsa.txt", 34, "FREQ=YEARLY"},
+	}
+
+	documents := make(map[string][]*SemanticAnnotation)
+	for _, test := range tests {
+		annotations, ok := documents[test.file]
+		if !ok {
+			sourceText, err := os.ReadFile(filepath.Join(projectRoot, "testdata", test.file))
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", test.file, err)
+			}
+			doc, err := NewParser().Parse(strings.NewReader(string(sourceText)))
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", test.file, err)
+			}
+			annotations = NewSemanticExtractor().ExtractFromDocument(doc)
+			documents[test.file] = annotations
+		}
+
+		found := false
+		for _, annotation := range annotations {
+			if annotation.Type == SemanticObligation && annotation.ArticleNum == test.article &&
+				annotation.Recurrence != nil && annotation.Recurrence.Rule() == test.wantRule {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("%s Article %d: no obligation recurring %s", test.file, test.article, test.wantRule)
+		}
+	}
+}
+
+func TestParseRecurrenceRule(t *testing.T) {
+	recurrence, err := ParseRecurrenceRule("RRULE:FREQ=yearly;INTERVAL=2")
+	if err != nil {
+		t.Fatalf("ParseRecurrenceRule failed: %v", err)
+	}
+	if recurrence.Frequency != FrequencyYearly || recurrence.Interval != 2 {
+		t.Errorf("unexpected recurrence: %+v", recurrence)
+	}
+	if recurrence.Describe() != "every 2 years" {
+		t.Errorf("Describe: got %q", recurrence.Describe())
+	}
+
+	for _, rule := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=YEARLY;INTERVAL=0",
+		"FREQ=YEARLY;BYMONTH=13;BYMONTHDAY=1",
+		"FREQ=YEARLY;BYMONTH=3",
+		"FREQ=MONTHLY;BYMONTH=3;BYMONTHDAY=1",
+		"FREQ=YEARLY;BYDAY=MO",
+		"FREQ",
+	} {
+		if _, err := ParseRecurrenceRule(rule); err == nil {
+			t.Errorf("%q: expected error", rule)
+		}
+	}
+}
+
+func TestRecurrence_Occurrences(t *testing.T) {
+	anchor := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	day := func(year int, month time.Month, dayOfMonth int) time.Time {
+		return time.Date(year, month, dayOfMonth, 0, 0, 0, 0, time.UTC)
+	}
+
+	pinned := &Recurrence{Frequency: FrequencyYearly, ByMonth: 3, ByMonthDay: 31}
+	got := pinned.Occurrences(anchor, day(2026, 6, 1), day(2028, 12, 31))
+	want := []time.Time{day(2027, 3, 31), day(2028, 3, 31)}
+	assertDates(t, "pinned yearly", got, want)
+
+	quarterly := &Recurrence{Frequency: FrequencyMonthly, Interval: 3}
+	got = quarterly.Occurrences(anchor, anchor, day(2026, 12, 31))
+	want = []time.Time{day(2026, 4, 1), day(2026, 7, 1), day(2026, 10, 1)}
+	assertDates(t, "quarterly", got, want)
+
+	// Month ends are clamped rather than overflowing into the next month.
+	monthEnd := &Recurrence{Frequency: FrequencyMonthly}
+	got = monthEnd.Occurrences(day(2026, 1, 31), day(2026, 1, 31), day(2026, 4, 30))
+	want = []time.Time{day(2026, 2, 28), day(2026, 3, 31), day(2026, 4, 30)}
+	assertDates(t, "month end", got, want)
+
+	leapDay := &Recurrence{Frequency: FrequencyYearly, ByMonth: 2, ByMonthDay: 29}
+	if next := leapDay.Next(anchor, anchor); !next.Equal(day(2026, 2, 28)) {
+		t.Errorf("leap day in common year: got %s", next.Format("2006-01-02"))
+	}
+}
+
+func assertDates(t *testing.T, name string, got []time.Time, want []time.Time) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d occurrences %v, want %v", name, len(got), got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("%s[%d]: got %s, want %s", name, i, got[i].Format("2006-01-02"), want[i].Format("2006-01-02"))
+		}
+	}
+}
+
+func TestSentenceAround(t *testing.T) {
+	text := "The business shall disclose its practices. Annual turnover is not relevant; the report shall be updated annually. Other text."
+	start := len("The business shall disclose its practices. Annual turnover is not relevant; the report ")
+	sentence := sentenceAround(text, start, start+len("shall"))
+	if sentence != "the report shall be updated annually." {
+		t.Errorf("got %q", sentence)
+	}
+}
+
+func TestParseRecurrenceAnnotations(t *testing.T) {
+	annotations, err := ParseRecurrenceAnnotations([]byte(`
+recurrence:
+  - article: 30
+    obligation_type: RecordKeepingObligation
+    rule: FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=31
+  - article: 12
+    rule: none
+`))
+	if err != nil {
+		t.Fatalf("ParseRecurrenceAnnotations failed: %v", err)
+	}
+	if len(annotations) != 2 {
+		t.Fatalf("got %d annotations, want 2", len(annotations))
+	}
+	if annotations[0].recurrence == nil || annotations[0].recurrence.Source != RecurrenceSourceAnnotation {
+		t.Errorf("rule was not parsed: %+v", annotations[0])
+	}
+	if annotations[1].recurrence != nil {
+		t.Errorf("rule none should clear recurrence, got %+v", annotations[1].recurrence)
+	}
+
+	for _, input := range []string{
+		"recurrence:\n  - rule: FREQ=YEARLY\n",
+		"recurrence:\n  - article: 3\n    rule: FREQ=SOMETIMES\n",
+		"recurrence: [",
+	} {
+		if _, err := ParseRecurrenceAnnotations([]byte(input)); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+}
+
+func TestApplyRecurrenceAnnotations(t *testing.T) {
+	overrides, err := ParseRecurrenceAnnotations([]byte(`
+recurrence:
+  - article: 30
+    obligation_type: RecordKeepingObligation
+    rule: FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=31
+  - article: 32
+    rule: none
+  - article: 59
+    rule: FREQ=YEARLY
+    note: Annual activity report
+  - article: 200
+    rule: FREQ=YEARLY
+`))
+	if err != nil {
+		t.Fatalf("ParseRecurrenceAnnotations failed: %v", err)
+	}
+
+	annotations := []*SemanticAnnotation{
+		{Type: SemanticObligation, ArticleNum: 30, ObligationType: ObligationRecord},
+		{Type: SemanticObligation, ArticleNum: 30, ObligationType: ObligationSecure},
+		{Type: SemanticObligation, ArticleNum: 32, ObligationType: ObligationSecure,
+			Recurrence: &Recurrence{Frequency: FrequencyYearly, Source: RecurrenceSourceText}},
+	}
+	articleNumbers := map[int]bool{30: true, 32: true, 59: true}
+
+	annotations = applyRecurrenceAnnotations(annotations, overrides, articleNumbers)
+
+	if annotations[0].Recurrence == nil || annotations[0].Recurrence.Rule() != "FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=31" {
+		t.Errorf("record keeping obligation not overridden: %+v", annotations[0].Recurrence)
+	}
+	if annotations[1].Recurrence != nil {
+		t.Error("obligation type filter was ignored")
+	}
+	if annotations[2].Recurrence != nil {
+		t.Error("rule none did not clear extracted recurrence")
+	}
+
+	// Article 59 had no obligation and gains one; article 200 does not exist.
+	if len(annotations) != 4 {
+		t.Fatalf("got %d annotations, want 4", len(annotations))
+	}
+	added := annotations[3]
+	if added.ArticleNum != 59 || added.ObligationType != ObligationGeneric || added.Recurrence == nil {
+		t.Errorf("unexpected added obligation: %+v", added)
+	}
+	if added.MatchedText != "Annual activity report" {
+		t.Errorf("note not used as matched text: %q", added.MatchedText)
+	}
+}
+
+func TestFindRecurrenceAnnotationFile(t *testing.T) {
+	tempDir := t.TempDir()
+	sourcePath := filepath.Join(tempDir, "gdpr.txt")
+	if FindRecurrenceAnnotationFile(sourcePath) != "" {
+		t.Error("expected no annotation file")
+	}
+
+	annotationPath := filepath.Join(tempDir, "gdpr."+RecurrenceAnnotationFileName)
+	if err := os.WriteFile(annotationPath, []byte("recurrence: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindRecurrenceAnnotationFile(sourcePath); got != annotationPath {
+		t.Errorf("got %q, want %q", got, annotationPath)
+	}
+
+	libraryDir := filepath.Join(tempDir, "doc")
+	if err := os.MkdirAll(libraryDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	plainPath := filepath.Join(libraryDir, RecurrenceAnnotationFileName)
+	if err := os.WriteFile(plainPath, []byte("recurrence: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindRecurrenceAnnotationFile(filepath.Join(libraryDir, "source.txt")); got != plainPath {
+		t.Errorf("got %q, want %q", got, plainPath)
+	}
+}
@@ -1,10 +1,12 @@
 package extract
 
 import (
+	"fmt"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // ReferenceType indicates whether a reference is internal or external.
@@ -24,6 +26,7 @@ const (
 	TargetPoint      ReferenceTarget = "point"
 	TargetChapter     ReferenceTarget = "chapter"
 	TargetSection     ReferenceTarget = "section"
+	TargetAnnex       ReferenceTarget = "annex"
 	TargetSubsection  ReferenceTarget = "subsection"
 	TargetSubchapter  ReferenceTarget = "subchapter"
 	TargetDirective   ReferenceTarget = "directive"
@@ -57,6 +60,7 @@ type Reference struct {
 	ChapterNum   string `json:"chapter_num,omitempty"`
 	SectionNum   int    `json:"section_num,omitempty"`
 	SectionStr   string `json:"section_str,omitempty"` // Full alphanumeric section ID (e.g., "1396a", "300aa-25")
+	AnnexNum     string `json:"annex_num,omitempty"`   // Annex or schedule number (e.g., "II", "1")
 
 	// For external references
 	ExternalDoc string `json:"external_doc,omitempty"`
@@ -80,6 +84,13 @@ type ReferenceExtractor struct {
 	pointsRangePattern  *regexp.Regexp
 	chapterPattern      *regexp.Regexp
 	sectionPattern      *regexp.Regexp
+	annexPattern        *regexp.Regexp
+	schedulePattern     *regexp.Regexp
+
+	// Relative references (EU-style)
+	relativePattern           *regexp.Regexp // this Article, this Chapter
+	provisionQualifierPattern *regexp.Regexp // of paragraph 2, of Article 6(1), of this Article
+	otherActPattern           *regexp.Regexp // of Directive 95/46/EC
 
 	// Internal reference patterns (US-style)
 	usSectionPattern          *regexp.Regexp // Section 1798.100
@@ -88,6 +99,11 @@ type ReferenceExtractor struct {
 	usParagraphSubdivPattern  *regexp.Regexp // paragraph (1) of subdivision (a) of Section 1798.100
 	usSectionsRangePattern    *regexp.Regexp // Sections 1798.100 to 1798.199
 
+	// Internal reference patterns (US municipal codes)
+	municipalCodeSectionPattern *regexp.Regexp // City Code § 8.04.020
+	municipalSectionPattern     *regexp.Regexp // Sec. 8.04.020(a)
+	municipalChapterPattern     *regexp.Regexp // Chapter 8.04
+
 	// Internal reference patterns (USC-style)
 	uscSectionOfTitlePattern      *regexp.Regexp // section 1396a of this title
 	uscSectionOfOtherTitlePattern *regexp.Regexp // section 552a of title 5
@@ -138,140 +154,133 @@ type ReferenceExtractor struct {
 	consolidatedVersionPattern *regexp.Regexp // consolidated version (of)?
 	repealedByPattern          *regexp.Regexp // repealed by {document}
 	repealedWithEffectPattern  *regexp.Regexp // repealed with effect from {date}
+
+	// patternStats counts matches per pattern when enabled for profiling.
+	patternStats *patternStatsRecorder
+
+	// disablePrefilter runs every pattern over the whole text, bypassing
+	// the keyword scan; tests use it as the reference behaviour.
+	disablePrefilter bool
+
+	// families restricts extraction to these pattern families; nil applies
+	// every family to articles and detects the families of a document.
+	families map[ReferenceFamily]bool
+
+	// overridden names the built-in patterns replaced by a reference pack,
+	// which the keyword pre-filter does not apply to.
+	overridden map[string]bool
+
+	// custom holds the patterns reference packs added.
+	custom []*customPattern
 }
 
+// referencePatterns compiles the default patterns once per process; every
+// extractor shares the compiled expressions, which are safe for concurrent
+// use.
+var referencePatterns = sync.OnceValue(compileReferencePatterns)
+
 // NewReferenceExtractor creates a new ReferenceExtractor with default patterns.
 func NewReferenceExtractor() *ReferenceExtractor {
-	return &ReferenceExtractor{
-		// Internal references (EU-style)
-		// Simple "Article 6" - overlap with parenthetical is handled in extractArticleRefs
-		articlePattern:      regexp.MustCompile(`Article\s+(\d+)`),
-		articleParenPattern: regexp.MustCompile(`Article\s+(\d+)\((\d+)\)(?:\(([a-z])\))?`),
-		// "Articles 13 and 14" or "Articles 15 to 22"
-		articlesPattern: regexp.MustCompile(`Articles\s+(\d+)\s+(?:and|to)\s+(\d+)`),
-		// "paragraph 1" or "paragraph 2"
-		paragraphPattern: regexp.MustCompile(`paragraph\s+(\d+)`),
-		// "point (a)" or "point (f)"
-		pointPattern: regexp.MustCompile(`point\s+\(([a-z])\)`),
-		// "points (a) to (f)" or "points (a) and (b)"
-		pointsRangePattern: regexp.MustCompile(`points\s+\(([a-z])\)\s+(?:to|and)\s+\(([a-z])\)`),
-		// "Chapter III" or "Chapter VIII"
-		chapterPattern: regexp.MustCompile(`Chapter\s+([IVX]+)`),
-		// "Section 1" or "Section 2" (EU-style, simple section numbers)
-		// Note: We handle overlap with US-style in extractSectionRefs
-		sectionPattern: regexp.MustCompile(`Section\s+(\d+)`),
-
-		// Internal references (US-style California Civil Code)
-		// "Section 1798.100" or "Section 1798.185" (simple, no subdivision)
-		// Note: We handle overlap with subdivision pattern in extractUSSectionRefs
-		usSectionPattern: regexp.MustCompile(`Section\s+(\d+)\.(\d+)`),
-		// "Section 1798.100(a)" or "Section 1798.185(a)(1)"
-		usSectionSubdivPattern: regexp.MustCompile(`Section\s+(\d+)\.(\d+)\(([a-z])\)(?:\((\d+)\))?`),
-		// "subdivision (a) of Section 1798.100"
-		usSubdivOfSectionPattern: regexp.MustCompile(`subdivision\s+\(([a-z])\)\s+of\s+Section\s+(\d+)\.(\d+)`),
-		// "paragraph (1) of subdivision (a) of Section 1798.185"
-		usParagraphSubdivPattern: regexp.MustCompile(`paragraph\s+\((\d+)\)\s+of\s+subdivision\s+\(([a-z])\)\s+of\s+Section\s+(\d+)\.(\d+)`),
-		// "Sections 1798.100 to 1798.199" or "Sections 1798.100 through 1798.199"
-		usSectionsRangePattern: regexp.MustCompile(`Sections\s+(\d+)\.(\d+)\s+(?:to|through)\s+(\d+)\.(\d+)`),
-
-		// Internal references (USC-style)
-		// "section 1396a of this title" or "section 300aa-25(a)(10) of this title"
-		uscSectionOfTitlePattern: regexp.MustCompile(`(?i)section\s+(\d+[a-z]*(?:-\d+[a-z]*)?)\s*(\([^)]*\)(?:\(\d+\))?)?\s+of\s+this\s+title`),
-		// "section 552a of title 5"
-		uscSectionOfOtherTitlePattern: regexp.MustCompile(`(?i)section\s+(\d+[a-z]*(?:-\d+[a-z]*)?)\s*(\([^)]*\)(?:\(\d+\))?)?\s+of\s+title\s+(\d+)`),
-		// "section 1396a(a)" or "section 300aa-25(a)(10)" (with parentheticals, no "of" context)
-		uscSectionSubsecPattern: regexp.MustCompile(`(?i)\bsection\s+(\d+[a-z]*(?:-\d+[a-z]*)?)\(([a-z])\)(?:\((\d+)\))?`),
-		// "section 1396a" or "section 300aa-25" (bare section with letter suffix, avoids matching "Section 1")
-		uscSectionBarePattern: regexp.MustCompile(`(?i)\bsection\s+(\d+[a-z]+(?:-\d+[a-z]*)?)\b`),
-		// "subsection (a)" or "subsection (b)(1)"
-		uscSubsectionPattern: regexp.MustCompile(`(?i)subsection\s+\(([a-z])\)(?:\((\d+)\))?`),
-		// "paragraph (2) of subsection (a)"
-		uscParagraphOfSubsecPattern: regexp.MustCompile(`(?i)paragraph\s+\((\d+)\)\s+of\s+subsection\s+\(([a-z])\)`),
-		// "subchapter II of chapter 7"
-		uscSubchapterPattern: regexp.MustCompile(`(?i)subchapter\s+([IVXivx]+)\s+of\s+chapter\s+(\d+)`),
-		// "chapter 7" (Arabic numerals, not Roman — avoids overlap with EU chapterPattern)
-		uscChapterArabicPattern: regexp.MustCompile(`(?i)\bchapter\s+(\d+)\b`),
-		// "section 306 of the Public Health Service Act"
-		uscSectionOfActPattern: regexp.MustCompile(`(?i)section\s+(\d+[a-z]*(?:-\d+[a-z]*)?)\s*(\([^)]*\)(?:\(\d+\))?)?\s+of\s+the\s+([A-Z][^,;.]+?)\s+Act`),
-
-		// Internal references (House Rules-style)
-		// "clause 5 of rule XX" or "clause 1(a)(1) of rule X"
-		houseClauseOfRulePattern: regexp.MustCompile(`(?i)clause\s+(\d+)(?:\(([a-z])\))?(?:\((\d+)\))?\s+of\s+rule\s+([IVXLCDM]+)`),
-		// "rule XX" (standalone rule reference)
-		houseRuleRefPattern: regexp.MustCompile(`(?i)\brule\s+([IVXLCDM]+)\b`),
-		// "clause 5" (standalone clause reference)
-		houseClauseRefPattern: regexp.MustCompile(`(?i)\bclause\s+(\d+)\b`),
-
-		// External references (Parliamentary authorities)
-		// "Jefferson's Manual" with optional section: "Jefferson's Manual, sec. 53" or "section 53 of Jefferson's Manual"
-		jeffersonsManualPattern: regexp.MustCompile(`(?i)(?:(?:sec(?:tion)?\.?\s*(\d+)\s+of\s+)?Jefferson'?s\s+Manual(?:,?\s+sec(?:tion)?\.?\s*(\d+))?)`),
-		// "Jefferson's Manual" standalone (for general references)
-		jeffersonsManualShortPattern: regexp.MustCompile(`(?i)Jefferson'?s\s+Manual`),
-		// "Cannon's Precedents" with volume and section: "Cannon's Precedents, vol. 8, sec. 3449" or "8 Cannon's Precedents § 3449"
-		cannonsPattern: regexp.MustCompile(`(?i)(?:(\d+)\s+)?Cannon'?s\s+Precedents(?:,?\s*(?:vol(?:ume)?\.?\s*(\d+)))?(?:,?\s*(?:sec(?:tion)?\.?|§)\s*(\d+))?`),
-		// Short citation: "8 Cannon § 3449"
-		cannonsCitePattern: regexp.MustCompile(`(?i)(\d+)\s+Cannon\s+(?:§|sec\.?)\s*(\d+)`),
-		// "Deschler's Precedents" with chapter and section: "Deschler's Precedents, ch. 21, § 18"
-		deschlerPattern: regexp.MustCompile(`(?i)Deschler'?s\s+Precedents(?:,?\s*ch(?:apter)?\.?\s*(\d+))?(?:,?\s*(?:§|sec(?:tion)?\.?)\s*(\d+))?`),
-		// "Deschler-Brown Precedent(s)" with optional chapter
-		deschlerBrownPattern: regexp.MustCompile(`(?i)Deschler-Brown\s+Precedents?(?:,?\s*ch(?:apter)?\.?\s*(\d+))?`),
-		// "Precedents of the House" (generic reference to House precedents)
-		precedentsOfHousePattern: regexp.MustCompile(`(?i)Precedents\s+of\s+the\s+House`),
-		// "Hinds' Precedents" (older 5-volume set)
-		hindsPattern: regexp.MustCompile(`(?i)(?:(\d+)\s+)?Hinds'?\s+Precedents(?:,?\s*(?:§|sec\.?)\s*(\d+))?`),
-
-		// External references (EU-style)
-		// "Directive 95/46/EC" or "Directive (EU) 2016/680"
-		directivePattern: regexp.MustCompile(`Directive\s+(?:\(E[CU]\)\s+)?(\d+)/(\d+)(?:/EC|/EU)?`),
-		// "Regulation (EU) 2016/679"
-		regulationPattern: regexp.MustCompile(`Regulation\s+\(E[CU]\)\s+(\d+)/(\d+)`),
-		// "Regulation (EC) No 45/2001"
-		regulationNoPattern: regexp.MustCompile(`Regulation\s+\(E[CU]\)\s+No\s+(\d+)/(\d+)`),
-		// "Treaty on the Functioning of the European Union" or "TFEU"
-		treatyPattern: regexp.MustCompile(`(?:Treaty\s+on\s+the\s+Functioning\s+of\s+the\s+European\s+Union|TFEU|TEU)`),
-		// "Decision 2010/87/EU"
-		decisionPattern: regexp.MustCompile(`Decision\s+(\d+)/(\d+)/E[CU]`),
-
-		// External references (US-style)
-		// "15 U.S.C. Section 1681" or "15 U.S.C. § 1681" or "15 U.S.C. Sec. 1681" or "42 U.S.C. Sec. 1320d"
-		usCodePattern: regexp.MustCompile(`(\d+)\s+U\.?S\.?C\.?\s+(?:Section|Sec\.?|§)\s*(\d+[a-z]?)`),
-		// "45 C.F.R. Part 164" or "45 CFR 164"
-		cfrPattern: regexp.MustCompile(`(\d+)\s+C\.?F\.?R\.?\s+(?:Part\s+)?(\d+)`),
-		// "Section 17014 of Title 18" (California codes)
-		caTitlePattern: regexp.MustCompile(`Section\s+(\d+)\s+of\s+Title\s+(\d+)`),
-		// "Public Law 104-191"
-		publicLawPattern: regexp.MustCompile(`Public\s+Law\s+(\d+)-(\d+)`),
-
-		// Temporal reference patterns
-		// "as amended by Regulation (EU) 2018/1725" or "as amended by this Regulation"
-		asAmendedByPattern: regexp.MustCompile(`(?i)as\s+amended\s+by\s+(.+?)(?:\.|,|;|$)`),
-		// "as amended" or "as amended accordingly" (standalone, no "by")
-		asAmendedPattern: regexp.MustCompile(`(?i)(?:,\s*)?as\s+amended(?:\s+accordingly)?(?:\s|,|\.|;|$)`),
-		// "as in force on 24 May 2016"
-		asInForceOnPattern: regexp.MustCompile(`(?i)as\s+in\s+force\s+on\s+(\d{1,2}\s+\w+\s+\d{4})`),
-		// "in force on 25 May 2018" or "in force from 25 May 2018"
-		inForceOnPattern: regexp.MustCompile(`(?i)in\s+force\s+(?:on|from)\s+(\d{1,2}\s+\w+\s+\d{4})`),
-		// "enter into force" or "enters into force on 25 May 2018" or "entered into force"
-		enterIntoForcePattern: regexp.MustCompile(`(?i)enter(?:s|ed)?\s+into\s+force(?:\s+on\s+(\d{1,2}\s+\w+\s+\d{4}))?`),
-		// "as originally enacted"
-		asOriginallyEnactedPattern: regexp.MustCompile(`(?i)as\s+originally\s+enacted`),
-		// "as it stood on 1 January 2020"
-		asItStoodOnPattern: regexp.MustCompile(`(?i)as\s+it\s+stood\s+on\s+(\d{1,2}\s+\w+\s+\d{4})`),
-		// "consolidated version" or "consolidated version of"
-		consolidatedVersionPattern: regexp.MustCompile(`(?i)consolidated\s+version(?:\s+of)?`),
-		// "repealed by this Regulation" or "repealed by Regulation (EU) 2016/679"
-		repealedByPattern: regexp.MustCompile(`(?i)repealed\s+by\s+(.+?)(?:\.|,|;|$)`),
-		// "repealed with effect from 25 May 2018"
-		repealedWithEffectPattern: regexp.MustCompile(`(?i)repealed\s+with\s+effect\s+from\s+(\d{1,2}\s+\w+\s+\d{4})`),
+	extractor := *referencePatterns()
+	return &extractor
+}
+
+// compileReferencePatterns builds the default patterns from the built-in
+// reference packs.
+func compileReferencePatterns() *ReferenceExtractor {
+	e := &ReferenceExtractor{}
+	for _, pack := range DefaultReferencePacks() {
+		if err := e.applyPack(pack, true); err != nil {
+			panic(fmt.Sprintf("invalid built-in reference pack: %v", err))
+		}
+	}
+	for name, field := range e.patternFields() {
+		if *field == nil {
+			panic(fmt.Sprintf("built-in reference pattern %q is missing from the reference packs", name))
+		}
 	}
+	return e
 }
 
-// ExtractFromDocument extracts all references from a parsed document.
+// patternFields maps the names of the built-in patterns, as used in
+// reference packs and with findAll, to the extractor's fields.
+func (e *ReferenceExtractor) patternFields() map[string]**regexp.Regexp {
+	return map[string]**regexp.Regexp{
+		"article":                &e.articlePattern,
+		"articleParen":           &e.articleParenPattern,
+		"articles":               &e.articlesPattern,
+		"paragraph":              &e.paragraphPattern,
+		"point":                  &e.pointPattern,
+		"pointsRange":            &e.pointsRangePattern,
+		"chapter":                &e.chapterPattern,
+		"section":                &e.sectionPattern,
+		"annex":                  &e.annexPattern,
+		"schedule":               &e.schedulePattern,
+		"relative":               &e.relativePattern,
+		"provisionQualifier":     &e.provisionQualifierPattern,
+		"otherAct":               &e.otherActPattern,
+		"usSection":              &e.usSectionPattern,
+		"usSectionSubdiv":        &e.usSectionSubdivPattern,
+		"usSubdivOfSection":      &e.usSubdivOfSectionPattern,
+		"usParagraphSubdiv":      &e.usParagraphSubdivPattern,
+		"usSectionsRange":        &e.usSectionsRangePattern,
+		"municipalCodeSection":   &e.municipalCodeSectionPattern,
+		"municipalSection":       &e.municipalSectionPattern,
+		"municipalChapter":       &e.municipalChapterPattern,
+		"uscSectionOfTitle":      &e.uscSectionOfTitlePattern,
+		"uscSectionOfOtherTitle": &e.uscSectionOfOtherTitlePattern,
+		"uscSectionSubsec":       &e.uscSectionSubsecPattern,
+		"uscSectionBare":         &e.uscSectionBarePattern,
+		"uscSubsection":          &e.uscSubsectionPattern,
+		"uscParagraphOfSubsec":   &e.uscParagraphOfSubsecPattern,
+		"uscSubchapter":          &e.uscSubchapterPattern,
+		"uscChapterArabic":       &e.uscChapterArabicPattern,
+		"uscSectionOfAct":        &e.uscSectionOfActPattern,
+		"houseClauseOfRule":      &e.houseClauseOfRulePattern,
+		"houseRuleRef":           &e.houseRuleRefPattern,
+		"houseClauseRef":         &e.houseClauseRefPattern,
+		"jeffersonsManual":       &e.jeffersonsManualPattern,
+		"jeffersonsManualShort":  &e.jeffersonsManualShortPattern,
+		"cannons":                &e.cannonsPattern,
+		"cannonsCite":            &e.cannonsCitePattern,
+		"deschler":               &e.deschlerPattern,
+		"deschlerBrown":          &e.deschlerBrownPattern,
+		"precedentsOfHouse":      &e.precedentsOfHousePattern,
+		"hinds":                  &e.hindsPattern,
+		"directive":              &e.directivePattern,
+		"regulation":             &e.regulationPattern,
+		"regulationNo":           &e.regulationNoPattern,
+		"treaty":                 &e.treatyPattern,
+		"decision":               &e.decisionPattern,
+		"usCode":                 &e.usCodePattern,
+		"cfr":                    &e.cfrPattern,
+		"caTitle":                &e.caTitlePattern,
+		"publicLaw":              &e.publicLawPattern,
+		"asAmendedBy":            &e.asAmendedByPattern,
+		"asAmended":              &e.asAmendedPattern,
+		"asInForceOn":            &e.asInForceOnPattern,
+		"inForceOn":              &e.inForceOnPattern,
+		"enterIntoForce":         &e.enterIntoForcePattern,
+		"asOriginallyEnacted":    &e.asOriginallyEnactedPattern,
+		"asItStoodOn":            &e.asItStoodOnPattern,
+		"consolidatedVersion":    &e.consolidatedVersionPattern,
+		"repealedBy":             &e.repealedByPattern,
+		"repealedWithEffect":     &e.repealedWithEffectPattern,
+	}
+}
+
+// ExtractFromDocument extracts all references from a parsed document. Unless
+// families were set with SetFamilies, only the pattern families that apply
+// to the document's format are used (see DetectFamilies).
 func (e *ReferenceExtractor) ExtractFromDocument(doc *Document) []*Reference {
-	var refs []*Reference
+	extractor := e
+	if e.families == nil {
+		extractor = e.withFamilies(e.DetectFamilies(doc).Applied())
+	}
 
+	var refs []*Reference
 	for _, article := range doc.AllArticles() {
-		articleRefs := e.ExtractFromArticle(article)
+		articleRefs := extractor.ExtractFromArticle(article)
 		refs = append(refs, articleRefs...)
 	}
 
@@ -286,55 +295,88 @@ func (e *ReferenceExtractor) ExtractFromDocument(doc *Document) []*Reference {
 	return refs
 }
 
-// ExtractFromArticle extracts all references from a single article.
+// ExtractFromArticle extracts all references from a single article, using
+// the families set with SetFamilies or, by default, every family.
 func (e *ReferenceExtractor) ExtractFromArticle(article *Article) []*Reference {
+	refs, _ := e.extractFamilies(article)
+	return refs
+}
+
+// extractFamilies extracts the references of an article along with the
+// family whose patterns found each one ("" for annex and temporal
+// references, which every family shares).
+func (e *ReferenceExtractor) extractFamilies(article *Article) ([]*Reference, []ReferenceFamily) {
 	if article == nil || article.Text == "" {
-		return nil
+		return nil, nil
 	}
+	return e.extractScan(e.scan(article.Text), article.Number)
+}
 
+// extractScan runs every applicable pattern family over scanned text.
+func (e *ReferenceExtractor) extractScan(scan *textScan, n int) ([]*Reference, []ReferenceFamily) {
 	var refs []*Reference
-	text := article.Text
+	var families []ReferenceFamily
+	add := func(family ReferenceFamily, extract func() []*Reference) {
+		if family != "" && e.families != nil && !e.families[family] {
+			return
+		}
+		for _, ref := range extract() {
+			refs = append(refs, ref)
+			families = append(families, family)
+		}
+	}
 
 	// Extract internal references (EU-style)
-	refs = append(refs, e.extractArticleRefs(text, article.Number)...)
-	refs = append(refs, e.extractParagraphRefs(text, article.Number)...)
-	refs = append(refs, e.extractPointRefs(text, article.Number)...)
-	refs = append(refs, e.extractChapterRefs(text, article.Number)...)
-	refs = append(refs, e.extractSectionRefs(text, article.Number)...)
+	add(FamilyEU, func() []*Reference { return e.extractArticleRefs(scan, n) })
+	add(FamilyEU, func() []*Reference { return e.extractParagraphRefs(scan, n) })
+	add(FamilyEU, func() []*Reference { return e.extractPointRefs(scan, n) })
+	add(FamilyEU, func() []*Reference { return e.extractChapterRefs(scan, n) })
+	add(FamilyEU, func() []*Reference { return e.extractSectionRefs(scan, n) })
+	add("", func() []*Reference { return e.extractAnnexRefs(scan, n) })
+	add(FamilyEU, func() []*Reference { return e.extractRelativeRefs(scan, n, refs) })
 
 	// Extract internal references (US-style California Civil Code)
-	refs = append(refs, e.extractUSSectionRefs(text, article.Number)...)
+	add(FamilyUSState, func() []*Reference { return e.extractUSSectionRefs(scan, n) })
+
+	// Extract internal references (US municipal codes)
+	add(FamilyUSMunicipal, func() []*Reference { return e.extractMunicipalRefs(scan, n) })
 
 	// Extract internal references (USC-style)
-	refs = append(refs, e.extractUSCSectionRefs(text, article.Number, refs)...)
+	add(FamilyUSC, func() []*Reference { return e.extractUSCSectionRefs(scan, n, refs) })
 
 	// Extract external references (EU-style)
-	refs = append(refs, e.extractDirectiveRefs(text, article.Number)...)
-	refs = append(refs, e.extractRegulationRefs(text, article.Number)...)
-	refs = append(refs, e.extractTreatyRefs(text, article.Number)...)
-	refs = append(refs, e.extractDecisionRefs(text, article.Number)...)
+	add(FamilyEUExternal, func() []*Reference { return e.extractDirectiveRefs(scan, n) })
+	add(FamilyEUExternal, func() []*Reference { return e.extractRegulationRefs(scan, n) })
+	add(FamilyEUExternal, func() []*Reference { return e.extractTreatyRefs(scan, n) })
+	add(FamilyEUExternal, func() []*Reference { return e.extractDecisionRefs(scan, n) })
 
 	// Extract internal references (House Rules-style)
-	refs = append(refs, e.extractHouseRuleRefs(text, article.Number)...)
+	add(FamilyHouseRules, func() []*Reference { return e.extractHouseRuleRefs(scan, n) })
 
 	// Extract external references (US-style)
-	refs = append(refs, e.extractUSExternalRefs(text, article.Number)...)
+	add(FamilyUSExternal, func() []*Reference { return e.extractUSExternalRefs(scan, n) })
 
 	// Extract external references (Parliamentary authorities)
-	refs = append(refs, e.extractParliamentaryAuthorityRefs(text, article.Number)...)
+	add(FamilyHouseRules, func() []*Reference { return e.extractParliamentaryAuthorityRefs(scan, n) })
+
+	// Extract references matched by the custom patterns of reference packs
+	for _, custom := range e.custom {
+		add(custom.family, func() []*Reference { return e.extractCustomRefs(scan, n, custom, refs) })
+	}
 
 	// Extract temporal references
-	refs = append(refs, e.extractTemporalRefs(text, article.Number)...)
+	add("", func() []*Reference { return e.extractTemporalRefs(scan, n) })
 
-	return refs
+	return refs, families
 }
 
 // extractArticleRefs extracts Article references.
-func (e *ReferenceExtractor) extractArticleRefs(text string, sourceArticle int) []*Reference {
+func (e *ReferenceExtractor) extractArticleRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
 	// Article with parenthetical reference: "Article 6(1)" or "Article 6(1)(a)"
-	matches := e.articleParenPattern.FindAllStringSubmatchIndex(text, -1)
+	matches := e.findAll(scan, "articleParen", e.articleParenPattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 		articleNum := mustAtoi(text[match[2]:match[3]])
@@ -362,7 +404,7 @@ func (e *ReferenceExtractor) extractArticleRefs(text string, sourceArticle int)
 	}
 
 	// Simple Article reference: "Article 6"
-	matches = e.articlePattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "article", e.articlePattern)
 	for _, match := range matches {
 		// Skip if this overlaps with an articleParenPattern match
 		if e.isOverlapping(match[0], match[1], refs) {
@@ -385,7 +427,7 @@ func (e *ReferenceExtractor) extractArticleRefs(text string, sourceArticle int)
 	}
 
 	// Multiple articles: "Articles 13 and 14"
-	matches = e.articlesPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "articles", e.articlesPattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 		startArticle := mustAtoi(text[match[2]:match[3]])
@@ -408,73 +450,168 @@ func (e *ReferenceExtractor) extractArticleRefs(text string, sourceArticle int)
 }
 
 // extractParagraphRefs extracts paragraph references.
-func (e *ReferenceExtractor) extractParagraphRefs(text string, sourceArticle int) []*Reference {
+func (e *ReferenceExtractor) extractParagraphRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
-	matches := e.paragraphPattern.FindAllStringSubmatchIndex(text, -1)
+	matches := e.findAll(scan, "paragraph", e.paragraphPattern)
 	for _, match := range matches {
-		rawText := text[match[0]:match[1]]
 		paragraphNum := mustAtoi(text[match[2]:match[3]])
+		qualifier := e.qualifyProvision(text, match[1])
+		if qualifier.otherAct {
+			continue
+		}
 
-		refs = append(refs, &Reference{
+		ref := &Reference{
 			Type:          ReferenceTypeInternal,
 			Target:        TargetParagraph,
-			RawText:       rawText,
+			RawText:       text[match[0]:qualifier.end],
 			Identifier:    "paragraph " + text[match[2]:match[3]],
 			SourceArticle: sourceArticle,
 			TextOffset:    match[0],
-			TextLength:    match[1] - match[0],
+			TextLength:    qualifier.end - match[0],
+			ArticleNum:    qualifier.articleNum,
 			ParagraphNum:  paragraphNum,
-		})
+		}
+		if ref.ArticleNum > 0 {
+			ref.Identifier = buildArticleIdentifier(ref.ArticleNum, paragraphNum, "")
+		}
+		refs = append(refs, ref)
 	}
 
 	return refs
 }
 
 // extractPointRefs extracts point references.
-func (e *ReferenceExtractor) extractPointRefs(text string, sourceArticle int) []*Reference {
+func (e *ReferenceExtractor) extractPointRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
 	// Points range: "points (a) to (f)"
-	matches := e.pointsRangePattern.FindAllStringSubmatchIndex(text, -1)
+	matches := e.findAll(scan, "pointsRange", e.pointsRangePattern)
 	for _, match := range matches {
-		rawText := text[match[0]:match[1]]
 		startLetter := text[match[2]:match[3]]
 		endLetter := text[match[4]:match[5]]
+		qualifier := e.qualifyProvision(text, match[1])
+		if qualifier.otherAct {
+			continue
+		}
 
 		refs = append(refs, &Reference{
 			Type:          ReferenceTypeInternal,
 			Target:        TargetPoint,
-			RawText:       rawText,
+			RawText:       text[match[0]:qualifier.end],
 			Identifier:    "points (" + startLetter + ") to (" + endLetter + ")",
 			SubRef:        "range",
 			SourceArticle: sourceArticle,
 			TextOffset:    match[0],
-			TextLength:    match[1] - match[0],
+			TextLength:    qualifier.end - match[0],
+			ArticleNum:    qualifier.articleNum,
+			ParagraphNum:  qualifier.paragraphNum,
 			PointLetter:   startLetter,
 		})
 	}
 
 	// Single point: "point (a)"
-	matches = e.pointPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "point", e.pointPattern)
 	for _, match := range matches {
 		// Skip if overlapping with range
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
 		}
 
-		rawText := text[match[0]:match[1]]
 		letter := text[match[2]:match[3]]
+		qualifier := e.qualifyProvision(text, match[1])
+		if qualifier.otherAct {
+			continue
+		}
 
-		refs = append(refs, &Reference{
+		ref := &Reference{
 			Type:          ReferenceTypeInternal,
 			Target:        TargetPoint,
-			RawText:       rawText,
+			RawText:       text[match[0]:qualifier.end],
 			Identifier:    "point (" + letter + ")",
 			SourceArticle: sourceArticle,
 			TextOffset:    match[0],
-			TextLength:    match[1] - match[0],
+			TextLength:    qualifier.end - match[0],
+			ArticleNum:    qualifier.articleNum,
+			ParagraphNum:  qualifier.paragraphNum,
 			PointLetter:   letter,
+		}
+		if ref.ArticleNum > 0 {
+			ref.Identifier = buildArticleIdentifier(ref.ArticleNum, ref.ParagraphNum, letter)
+		}
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
+
+// provisionQualifier is the context named after a paragraph or point
+// reference.
+type provisionQualifier struct {
+	articleNum   int  // Article named by "of Article 6"; 0 for this article
+	paragraphNum int  // Paragraph named by "of paragraph 2" or "of Article 6(2)"
+	end          int  // Offset just past the qualifier
+	otherAct     bool // The qualifier continues "of Directive ...": not an internal reference
+}
+
+// qualifyProvision reads the qualifiers following a paragraph or point
+// reference that ends at offset end: "point (a) of paragraph 2 of
+// Article 6", "paragraph 1 of this Article", "point (f) of Article 6(1)".
+func (e *ReferenceExtractor) qualifyProvision(text string, end int) provisionQualifier {
+	qualifier := provisionQualifier{end: end}
+	for {
+		match := e.provisionQualifierPattern.FindStringSubmatchIndex(text[qualifier.end:])
+		if match == nil {
+			break
+		}
+		rest := text[qualifier.end:]
+		qualifier.end += match[1]
+
+		if match[4] != -1 {
+			qualifier.paragraphNum = mustAtoi(rest[match[4]:match[5]])
+			continue
+		}
+		if match[6] != -1 {
+			qualifier.articleNum = mustAtoi(rest[match[6]:match[7]])
+			if match[8] != -1 {
+				qualifier.paragraphNum = mustAtoi(rest[match[8]:match[9]])
+			}
+			qualifier.otherAct = e.otherActPattern.MatchString(text[qualifier.end:])
+		}
+		// An article, or this Article, ends the qualifier
+		break
+	}
+	return qualifier
+}
+
+// extractRelativeRefs extracts "this Article" and "this Chapter", which the
+// resolver anchors to the article or chapter containing the reference.
+// Occurrences that qualify a paragraph or point reference already in refs
+// ("paragraph 1 of this Article") are skipped.
+func (e *ReferenceExtractor) extractRelativeRefs(scan *textScan, sourceArticle int, existingRefs []*Reference) []*Reference {
+	text := scan.text
+	var refs []*Reference
+
+	for _, match := range e.findAll(scan, "relative", e.relativePattern) {
+		if isOverlappingWithSlice(match[0], match[1], existingRefs) {
+			continue
+		}
+
+		target := TargetArticle
+		if text[match[2]:match[3]] == "Chapter" {
+			target = TargetChapter
+		}
+		refs = append(refs, &Reference{
+			Type:          ReferenceTypeInternal,
+			Target:        target,
+			RawText:       text[match[0]:match[1]],
+			Identifier:    "this " + text[match[2]:match[3]],
+			SubRef:        "this",
+			SourceArticle: sourceArticle,
+			TextOffset:    match[0],
+			TextLength:    match[1] - match[0],
 		})
 	}
 
@@ -482,10 +619,11 @@ func (e *ReferenceExtractor) extractPointRefs(text string, sourceArticle int) []
 }
 
 // extractChapterRefs extracts chapter references.
-func (e *ReferenceExtractor) extractChapterRefs(text string, sourceArticle int) []*Reference {
+func (e *ReferenceExtractor) extractChapterRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
-	matches := e.chapterPattern.FindAllStringSubmatchIndex(text, -1)
+	matches := e.findAll(scan, "chapter", e.chapterPattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 		chapterNum := text[match[2]:match[3]]
@@ -505,11 +643,45 @@ func (e *ReferenceExtractor) extractChapterRefs(text string, sourceArticle int)
 	return refs
 }
 
+// extractAnnexRefs extracts annex ("Annex II") and schedule ("Schedule 1")
+// references.
+func (e *ReferenceExtractor) extractAnnexRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
+	var refs []*Reference
+
+	for _, kind := range []struct {
+		name    string
+		label   string
+		pattern *regexp.Regexp
+	}{
+		{"annex", "Annex", e.annexPattern},
+		{"schedule", "Schedule", e.schedulePattern},
+	} {
+		for _, match := range e.findAll(scan, kind.name, kind.pattern) {
+			annexNum := text[match[2]:match[3]]
+
+			refs = append(refs, &Reference{
+				Type:          ReferenceTypeInternal,
+				Target:        TargetAnnex,
+				RawText:       text[match[0]:match[1]],
+				Identifier:    kind.label + " " + annexNum,
+				SourceArticle: sourceArticle,
+				TextOffset:    match[0],
+				TextLength:    match[1] - match[0],
+				AnnexNum:      annexNum,
+			})
+		}
+	}
+
+	return refs
+}
+
 // extractSectionRefs extracts section references (EU-style simple numbers).
-func (e *ReferenceExtractor) extractSectionRefs(text string, sourceArticle int) []*Reference {
+func (e *ReferenceExtractor) extractSectionRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
-	matches := e.sectionPattern.FindAllStringSubmatchIndex(text, -1)
+	matches := e.findAll(scan, "section", e.sectionPattern)
 	for _, match := range matches {
 		// Skip if this is a US-style section (followed by a decimal point)
 		endPos := match[1]
@@ -536,17 +708,19 @@ func (e *ReferenceExtractor) extractSectionRefs(text string, sourceArticle int)
 }
 
 // extractUSSectionRefs extracts US-style California Civil Code section references.
-func (e *ReferenceExtractor) extractUSSectionRefs(text string, sourceArticle int) []*Reference {
+func (e *ReferenceExtractor) extractUSSectionRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
 	// Paragraph of subdivision of section: "paragraph (1) of subdivision (a) of Section 1798.185"
-	matches := e.usParagraphSubdivPattern.FindAllStringSubmatchIndex(text, -1)
+	matches := e.findAll(scan, "usParagraphSubdiv", e.usParagraphSubdivPattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 		paragraphNum := text[match[2]:match[3]]
 		subdivLetter := text[match[4]:match[5]]
 		codePrefix := mustAtoi(text[match[6]:match[7]])
-		sectionNum := mustAtoi(text[match[8]:match[9]])
+		sectionStr := text[match[8]:match[9]]
+		sectionNum := usSectionNumber(sectionStr)
 
 		// For California Civil Code 1798.xxx, map to Article xxx
 		articleNum := sectionNum
@@ -558,7 +732,7 @@ func (e *ReferenceExtractor) extractUSSectionRefs(text string, sourceArticle int
 			Type:          ReferenceTypeInternal,
 			Target:        TargetSection,
 			RawText:       rawText,
-			Identifier:    buildUSSectionIdentifier(codePrefix, sectionNum, subdivLetter, paragraphNum),
+			Identifier:    buildUSSectionIdentifier(codePrefix, sectionStr, subdivLetter, paragraphNum),
 			SubRef:        "paragraph",
 			SourceArticle: sourceArticle,
 			TextOffset:    match[0],
@@ -571,7 +745,7 @@ func (e *ReferenceExtractor) extractUSSectionRefs(text string, sourceArticle int
 	}
 
 	// Subdivision of section: "subdivision (a) of Section 1798.100"
-	matches = e.usSubdivOfSectionPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "usSubdivOfSection", e.usSubdivOfSectionPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -580,7 +754,8 @@ func (e *ReferenceExtractor) extractUSSectionRefs(text string, sourceArticle int
 		rawText := text[match[0]:match[1]]
 		subdivLetter := text[match[2]:match[3]]
 		codePrefix := mustAtoi(text[match[4]:match[5]])
-		sectionNum := mustAtoi(text[match[6]:match[7]])
+		sectionStr := text[match[6]:match[7]]
+		sectionNum := usSectionNumber(sectionStr)
 
 		articleNum := sectionNum
 		if codePrefix == 1798 {
@@ -591,7 +766,7 @@ func (e *ReferenceExtractor) extractUSSectionRefs(text string, sourceArticle int
 			Type:          ReferenceTypeInternal,
 			Target:        TargetSection,
 			RawText:       rawText,
-			Identifier:    buildUSSectionIdentifier(codePrefix, sectionNum, subdivLetter, ""),
+			Identifier:    buildUSSectionIdentifier(codePrefix, sectionStr, subdivLetter, ""),
 			SubRef:        "subdivision",
 			SourceArticle: sourceArticle,
 			TextOffset:    match[0],
@@ -603,7 +778,7 @@ func (e *ReferenceExtractor) extractUSSectionRefs(text string, sourceArticle int
 	}
 
 	// Section with subdivision: "Section 1798.100(a)" or "Section 1798.185(a)(1)"
-	matches = e.usSectionSubdivPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "usSectionSubdiv", e.usSectionSubdivPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -611,7 +786,8 @@ func (e *ReferenceExtractor) extractUSSectionRefs(text string, sourceArticle int
 
 		rawText := text[match[0]:match[1]]
 		codePrefix := mustAtoi(text[match[2]:match[3]])
-		sectionNum := mustAtoi(text[match[4]:match[5]])
+		sectionStr := text[match[4]:match[5]]
+		sectionNum := usSectionNumber(sectionStr)
 		subdivLetter := text[match[6]:match[7]]
 
 		var paragraphNum string
@@ -628,7 +804,7 @@ func (e *ReferenceExtractor) extractUSSectionRefs(text string, sourceArticle int
 			Type:          ReferenceTypeInternal,
 			Target:        TargetSection,
 			RawText:       rawText,
-			Identifier:    buildUSSectionIdentifier(codePrefix, sectionNum, subdivLetter, paragraphNum),
+			Identifier:    buildUSSectionIdentifier(codePrefix, sectionStr, subdivLetter, paragraphNum),
 			SourceArticle: sourceArticle,
 			TextOffset:    match[0],
 			TextLength:    match[1] - match[0],
@@ -648,13 +824,13 @@ func (e *ReferenceExtractor) extractUSSectionRefs(text string, sourceArticle int
 	}
 
 	// Sections range: "Sections 1798.100 to 1798.199"
-	matches = e.usSectionsRangePattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "usSectionsRange", e.usSectionsRangePattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 		startPrefix := mustAtoi(text[match[2]:match[3]])
-		startSection := mustAtoi(text[match[4]:match[5]])
+		startSection := text[match[4]:match[5]]
 		endPrefix := mustAtoi(text[match[6]:match[7]])
-		endSection := mustAtoi(text[match[8]:match[9]])
+		endSection := text[match[8]:match[9]]
 
 		refs = append(refs, &Reference{
 			Type:          ReferenceTypeInternal,
@@ -665,13 +841,13 @@ func (e *ReferenceExtractor) extractUSSectionRefs(text string, sourceArticle int
 			SourceArticle: sourceArticle,
 			TextOffset:    match[0],
 			TextLength:    match[1] - match[0],
-			ArticleNum:    startSection,
-			SectionNum:    startPrefix*1000 + startSection,
+			ArticleNum:    usSectionNumber(startSection),
+			SectionNum:    startPrefix*1000 + usSectionNumber(startSection),
 		})
 	}
 
 	// Simple section: "Section 1798.100"
-	matches = e.usSectionPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "usSection", e.usSectionPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -679,7 +855,8 @@ func (e *ReferenceExtractor) extractUSSectionRefs(text string, sourceArticle int
 
 		rawText := text[match[0]:match[1]]
 		codePrefix := mustAtoi(text[match[2]:match[3]])
-		sectionNum := mustAtoi(text[match[4]:match[5]])
+		sectionStr := text[match[4]:match[5]]
+		sectionNum := usSectionNumber(sectionStr)
 
 		articleNum := sectionNum
 		if codePrefix == 1798 {
@@ -690,7 +867,7 @@ func (e *ReferenceExtractor) extractUSSectionRefs(text string, sourceArticle int
 			Type:          ReferenceTypeInternal,
 			Target:        TargetSection,
 			RawText:       rawText,
-			Identifier:    buildUSSectionIdentifier(codePrefix, sectionNum, "", ""),
+			Identifier:    buildUSSectionIdentifier(codePrefix, sectionStr, "", ""),
 			SourceArticle: sourceArticle,
 			TextOffset:    match[0],
 			TextLength:    match[1] - match[0],
@@ -702,13 +879,104 @@ func (e *ReferenceExtractor) extractUSSectionRefs(text string, sourceArticle int
 	return refs
 }
 
+// usSectionNumber returns the section of a state code section number
+// without its dotted extensions: 81 for the 81.5 of Section 1798.81.5.
+func usSectionNumber(section string) int {
+	section, _, _ = strings.Cut(section, ".")
+	return mustAtoi(section)
+}
+
+// continuesDottedNumber reports whether the number ending at end goes on
+// with another dotted component, as in the municipal section 8.04.020,
+// which the chapter pattern would otherwise read as Chapter 8.04.
+func continuesDottedNumber(text string, end int) bool {
+	return end+1 < len(text) && text[end] == '.' && text[end+1] >= '0' && text[end+1] <= '9'
+}
+
+// extractMunicipalRefs extracts municipal code references: "City Code §
+// 8.04.020", "Sec. 8.04.020(a)", "Chapter 8.04". Sections are numbered
+// title.chapter.section, so ChapterNum holds the title.chapter prefix.
+func (e *ReferenceExtractor) extractMunicipalRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
+	var refs []*Reference
+
+	// Code-qualified section: "City Code § 8.04.020(b)"
+	matches := e.findAll(scan, "municipalCodeSection", e.municipalCodeSectionPattern)
+	for _, match := range matches {
+		codeName := text[match[2]:match[3]] + " Code"
+		refs = append(refs, buildMunicipalSectionRef(text, match, match[4:8], codeName, sourceArticle))
+	}
+
+	// Section: "Sec. 8.04.020" or "§ 8.04.020(a)"
+	matches = e.findAll(scan, "municipalSection", e.municipalSectionPattern)
+	for _, match := range matches {
+		if e.isOverlapping(match[0], match[1], refs) {
+			continue
+		}
+		refs = append(refs, buildMunicipalSectionRef(text, match, match[2:6], "", sourceArticle))
+	}
+
+	// Chapter: "Chapter 8.04"
+	matches = e.findAll(scan, "municipalChapter", e.municipalChapterPattern)
+	for _, match := range matches {
+		if continuesDottedNumber(text, match[1]) || e.isOverlapping(match[0], match[1], refs) {
+			continue
+		}
+		chapterNum := text[match[2]:match[3]]
+		refs = append(refs, &Reference{
+			Type:          ReferenceTypeInternal,
+			Target:        TargetChapter,
+			RawText:       text[match[0]:match[1]],
+			Identifier:    "Chapter " + chapterNum,
+			SourceArticle: sourceArticle,
+			TextOffset:    match[0],
+			TextLength:    match[1] - match[0],
+			ChapterNum:    chapterNum,
+		})
+	}
+
+	return refs
+}
+
+// buildMunicipalSectionRef builds a municipal section reference from a
+// match; groups holds the offsets of the section number and the optional
+// subsection letter.
+func buildMunicipalSectionRef(text string, match []int, groups []int, codeName string, sourceArticle int) *Reference {
+	sectionNum := text[groups[0]:groups[1]]
+	identifier := "§ " + sectionNum
+	if codeName != "" {
+		identifier = codeName + " " + identifier
+	}
+
+	ref := &Reference{
+		Type:          ReferenceTypeInternal,
+		Target:        TargetSection,
+		RawText:       text[match[0]:match[1]],
+		SourceArticle: sourceArticle,
+		TextOffset:    match[0],
+		TextLength:    match[1] - match[0],
+		SectionStr:    sectionNum,
+	}
+	if lastDot := strings.LastIndex(sectionNum, "."); strings.Count(sectionNum, ".") == 2 {
+		ref.ChapterNum = sectionNum[:lastDot]
+	}
+	if groups[2] != -1 {
+		ref.PointLetter = text[groups[2]:groups[3]]
+		ref.SubRef = "subsection"
+		identifier += "(" + ref.PointLetter + ")"
+	}
+	ref.Identifier = identifier
+	return ref
+}
+
 // extractHouseRuleRefs extracts House Rules-style internal references:
 // "clause N of rule X", "rule X", "clause N".
-func (e *ReferenceExtractor) extractHouseRuleRefs(text string, sourceArticle int) []*Reference {
+func (e *ReferenceExtractor) extractHouseRuleRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
 	// "clause 5 of rule XX" or "clause 1(a)(1) of rule X"
-	clauseOfRuleMatches := e.houseClauseOfRulePattern.FindAllStringSubmatchIndex(text, -1)
+	clauseOfRuleMatches := e.findAll(scan, "houseClauseOfRule", e.houseClauseOfRulePattern)
 	for _, match := range clauseOfRuleMatches {
 		rawText := text[match[0]:match[1]]
 		clauseNum := text[match[2]:match[3]]
@@ -730,7 +998,7 @@ func (e *ReferenceExtractor) extractHouseRuleRefs(text string, sourceArticle int
 	}
 
 	// "rule XX" (standalone, but skip if already part of "clause N of rule X")
-	ruleMatches := e.houseRuleRefPattern.FindAllStringSubmatchIndex(text, -1)
+	ruleMatches := e.findAll(scan, "houseRuleRef", e.houseRuleRefPattern)
 	for _, match := range ruleMatches {
 		// Skip if this match is part of a "clause of rule" match
 		alreadyCovered := false
@@ -763,11 +1031,12 @@ func (e *ReferenceExtractor) extractHouseRuleRefs(text string, sourceArticle int
 }
 
 // extractUSExternalRefs extracts US-style external references (USC, CFR, etc.).
-func (e *ReferenceExtractor) extractUSExternalRefs(text string, sourceArticle int) []*Reference {
+func (e *ReferenceExtractor) extractUSExternalRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
 	// US Code: "15 U.S.C. Section 1681"
-	matches := e.usCodePattern.FindAllStringSubmatchIndex(text, -1)
+	matches := e.findAll(scan, "usCode", e.usCodePattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 		title := text[match[2]:match[3]]
@@ -788,7 +1057,7 @@ func (e *ReferenceExtractor) extractUSExternalRefs(text string, sourceArticle in
 	}
 
 	// CFR: "45 C.F.R. Part 164"
-	matches = e.cfrPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "cfr", e.cfrPattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 		title := text[match[2]:match[3]]
@@ -809,7 +1078,7 @@ func (e *ReferenceExtractor) extractUSExternalRefs(text string, sourceArticle in
 	}
 
 	// California Title references: "Section 17014 of Title 18"
-	matches = e.caTitlePattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "caTitle", e.caTitlePattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 		section := text[match[2]:match[3]]
@@ -830,7 +1099,7 @@ func (e *ReferenceExtractor) extractUSExternalRefs(text string, sourceArticle in
 	}
 
 	// Public Law: "Public Law 104-191"
-	matches = e.publicLawPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "publicLaw", e.publicLawPattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 		congress := text[match[2]:match[3]]
@@ -856,11 +1125,12 @@ func (e *ReferenceExtractor) extractUSExternalRefs(text string, sourceArticle in
 // extractParliamentaryAuthorityRefs extracts references to parliamentary authorities:
 // Jefferson's Manual, Cannon's Precedents, Deschler's Precedents, Deschler-Brown Precedents,
 // Hinds' Precedents, and general House precedents.
-func (e *ReferenceExtractor) extractParliamentaryAuthorityRefs(text string, sourceArticle int) []*Reference {
+func (e *ReferenceExtractor) extractParliamentaryAuthorityRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
 	// Jefferson's Manual with section: "Jefferson's Manual, sec. 53" or "section 53 of Jefferson's Manual"
-	matches := e.jeffersonsManualPattern.FindAllStringSubmatchIndex(text, -1)
+	matches := e.findAll(scan, "jeffersonsManual", e.jeffersonsManualPattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 
@@ -891,7 +1161,7 @@ func (e *ReferenceExtractor) extractParliamentaryAuthorityRefs(text string, sour
 	}
 
 	// Cannon's Precedents: "Cannon's Precedents, vol. 8, sec. 3449" or "8 Cannon's Precedents § 3449"
-	matches = e.cannonsPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "cannons", e.cannonsPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -935,7 +1205,7 @@ func (e *ReferenceExtractor) extractParliamentaryAuthorityRefs(text string, sour
 	}
 
 	// Short Cannon citation: "8 Cannon § 3449"
-	matches = e.cannonsCitePattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "cannonsCite", e.cannonsCitePattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -962,7 +1232,7 @@ func (e *ReferenceExtractor) extractParliamentaryAuthorityRefs(text string, sour
 	}
 
 	// Deschler's Precedents: "Deschler's Precedents, ch. 21, § 18"
-	matches = e.deschlerPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "deschler", e.deschlerPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1001,7 +1271,7 @@ func (e *ReferenceExtractor) extractParliamentaryAuthorityRefs(text string, sour
 	}
 
 	// Deschler-Brown Precedents: "Deschler-Brown Precedent ch. 29"
-	matches = e.deschlerBrownPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "deschlerBrown", e.deschlerBrownPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1033,7 +1303,7 @@ func (e *ReferenceExtractor) extractParliamentaryAuthorityRefs(text string, sour
 	}
 
 	// Hinds' Precedents: "5 Hinds' Precedents § 5445" or "Hinds' Precedents"
-	matches = e.hindsPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "hinds", e.hindsPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1072,7 +1342,7 @@ func (e *ReferenceExtractor) extractParliamentaryAuthorityRefs(text string, sour
 	}
 
 	// Generic "Precedents of the House"
-	matches = e.precedentsOfHousePattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "precedentsOfHouse", e.precedentsOfHousePattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1096,8 +1366,8 @@ func (e *ReferenceExtractor) extractParliamentaryAuthorityRefs(text string, sour
 }
 
 // buildUSSectionIdentifier creates a standardized US section identifier.
-func buildUSSectionIdentifier(codePrefix, sectionNum int, subdivLetter, paragraphNum string) string {
-	id := "Section " + itoa(codePrefix) + "." + itoa(sectionNum)
+func buildUSSectionIdentifier(codePrefix int, section, subdivLetter, paragraphNum string) string {
+	id := "Section " + itoa(codePrefix) + "." + section
 	if subdivLetter != "" {
 		id += "(" + subdivLetter + ")"
 	}
@@ -1108,18 +1378,19 @@ func buildUSSectionIdentifier(codePrefix, sectionNum int, subdivLetter, paragrap
 }
 
 // buildUSSectionsRangeIdentifier creates an identifier for US section ranges.
-func buildUSSectionsRangeIdentifier(startPrefix, startSection, endPrefix, endSection int) string {
-	return "Sections " + itoa(startPrefix) + "." + itoa(startSection) + "-" + itoa(endPrefix) + "." + itoa(endSection)
+func buildUSSectionsRangeIdentifier(startPrefix int, startSection string, endPrefix int, endSection string) string {
+	return "Sections " + itoa(startPrefix) + "." + startSection + "-" + itoa(endPrefix) + "." + endSection
 }
 
 // extractUSCSectionRefs extracts USC-style internal cross-references.
 // USC uses lowercase "section", no dot separators, letter suffixes (1396a), dash extensions (1320d-1),
 // and context phrases like "of this title" (internal) or "of title 5" (cross-title external).
-func (e *ReferenceExtractor) extractUSCSectionRefs(text string, sourceArticle int, existingRefs []*Reference) []*Reference {
+func (e *ReferenceExtractor) extractUSCSectionRefs(scan *textScan, sourceArticle int, existingRefs []*Reference) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
 	// 1. Cross-title: "section 552a of title 5" (external)
-	matches := e.uscSectionOfOtherTitlePattern.FindAllStringSubmatchIndex(text, -1)
+	matches := e.findAll(scan, "uscSectionOfOtherTitle", e.uscSectionOfOtherTitlePattern)
 	for _, match := range matches {
 		if isOverlappingWithSlice(match[0], match[1], existingRefs) || e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1149,7 +1420,7 @@ func (e *ReferenceExtractor) extractUSCSectionRefs(text string, sourceArticle in
 	}
 
 	// 2. Same-title: "section 1396a of this title" (internal)
-	matches = e.uscSectionOfTitlePattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "uscSectionOfTitle", e.uscSectionOfTitlePattern)
 	for _, match := range matches {
 		if isOverlappingWithSlice(match[0], match[1], existingRefs) || e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1180,7 +1451,7 @@ func (e *ReferenceExtractor) extractUSCSectionRefs(text string, sourceArticle in
 	}
 
 	// 3. Section of Act: "section 306 of the Public Health Service Act" (external)
-	matches = e.uscSectionOfActPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "uscSectionOfAct", e.uscSectionOfActPattern)
 	for _, match := range matches {
 		if isOverlappingWithSlice(match[0], match[1], existingRefs) || e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1210,7 +1481,7 @@ func (e *ReferenceExtractor) extractUSCSectionRefs(text string, sourceArticle in
 	}
 
 	// 4. Paragraph of subsection: "paragraph (2) of subsection (a)"
-	matches = e.uscParagraphOfSubsecPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "uscParagraphOfSubsec", e.uscParagraphOfSubsecPattern)
 	for _, match := range matches {
 		if isOverlappingWithSlice(match[0], match[1], existingRefs) || e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1236,7 +1507,7 @@ func (e *ReferenceExtractor) extractUSCSectionRefs(text string, sourceArticle in
 	}
 
 	// 5. Section with subsection: "section 1396a(a)" or "section 1396a(a)(10)"
-	matches = e.uscSectionSubsecPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "uscSectionSubsec", e.uscSectionSubsecPattern)
 	for _, match := range matches {
 		if isOverlappingWithSlice(match[0], match[1], existingRefs) || e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1276,7 +1547,7 @@ func (e *ReferenceExtractor) extractUSCSectionRefs(text string, sourceArticle in
 	}
 
 	// 6. Subsection: "subsection (a)" or "subsection (b)(1)"
-	matches = e.uscSubsectionPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "uscSubsection", e.uscSubsectionPattern)
 	for _, match := range matches {
 		if isOverlappingWithSlice(match[0], match[1], existingRefs) || e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1309,7 +1580,7 @@ func (e *ReferenceExtractor) extractUSCSectionRefs(text string, sourceArticle in
 	}
 
 	// 7. Subchapter: "subchapter II of chapter 7"
-	matches = e.uscSubchapterPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "uscSubchapter", e.uscSubchapterPattern)
 	for _, match := range matches {
 		if isOverlappingWithSlice(match[0], match[1], existingRefs) || e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1332,7 +1603,7 @@ func (e *ReferenceExtractor) extractUSCSectionRefs(text string, sourceArticle in
 	}
 
 	// 8. Chapter (Arabic numerals): "chapter 7"
-	matches = e.uscChapterArabicPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "uscChapterArabic", e.uscChapterArabicPattern)
 	for _, match := range matches {
 		if isOverlappingWithSlice(match[0], match[1], existingRefs) || e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1354,7 +1625,7 @@ func (e *ReferenceExtractor) extractUSCSectionRefs(text string, sourceArticle in
 	}
 
 	// 9. Bare section with letter suffix: "section 1396a" or "section 1320d-1"
-	matches = e.uscSectionBarePattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "uscSectionBare", e.uscSectionBarePattern)
 	for _, match := range matches {
 		if isOverlappingWithSlice(match[0], match[1], existingRefs) || e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1424,10 +1695,11 @@ func isOverlappingWithSlice(start, end int, refs []*Reference) bool {
 }
 
 // extractDirectiveRefs extracts Directive references.
-func (e *ReferenceExtractor) extractDirectiveRefs(text string, sourceArticle int) []*Reference {
+func (e *ReferenceExtractor) extractDirectiveRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
-	matches := e.directivePattern.FindAllStringSubmatchIndex(text, -1)
+	matches := e.findAll(scan, "directive", e.directivePattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 		year := text[match[2]:match[3]]
@@ -1451,11 +1723,12 @@ func (e *ReferenceExtractor) extractDirectiveRefs(text string, sourceArticle int
 }
 
 // extractRegulationRefs extracts Regulation references.
-func (e *ReferenceExtractor) extractRegulationRefs(text string, sourceArticle int) []*Reference {
+func (e *ReferenceExtractor) extractRegulationRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
 	// "Regulation (EU) No 45/2001"
-	matches := e.regulationNoPattern.FindAllStringSubmatchIndex(text, -1)
+	matches := e.findAll(scan, "regulationNo", e.regulationNoPattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 		number := text[match[2]:match[3]]
@@ -1476,7 +1749,7 @@ func (e *ReferenceExtractor) extractRegulationRefs(text string, sourceArticle in
 	}
 
 	// "Regulation (EU) 2016/679"
-	matches = e.regulationPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "regulation", e.regulationPattern)
 	for _, match := range matches {
 		// Skip if overlapping with No pattern
 		if e.isOverlapping(match[0], match[1], refs) {
@@ -1505,10 +1778,11 @@ func (e *ReferenceExtractor) extractRegulationRefs(text string, sourceArticle in
 }
 
 // extractTreatyRefs extracts Treaty references.
-func (e *ReferenceExtractor) extractTreatyRefs(text string, sourceArticle int) []*Reference {
+func (e *ReferenceExtractor) extractTreatyRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
-	matches := e.treatyPattern.FindAllStringSubmatchIndex(text, -1)
+	matches := e.findAll(scan, "treaty", e.treatyPattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 
@@ -1537,10 +1811,11 @@ func (e *ReferenceExtractor) extractTreatyRefs(text string, sourceArticle int) [
 }
 
 // extractDecisionRefs extracts Decision references.
-func (e *ReferenceExtractor) extractDecisionRefs(text string, sourceArticle int) []*Reference {
+func (e *ReferenceExtractor) extractDecisionRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
-	matches := e.decisionPattern.FindAllStringSubmatchIndex(text, -1)
+	matches := e.findAll(scan, "decision", e.decisionPattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 		year := text[match[2]:match[3]]
@@ -1565,11 +1840,12 @@ func (e *ReferenceExtractor) extractDecisionRefs(text string, sourceArticle int)
 
 // extractTemporalRefs extracts temporal qualifiers from the text.
 // These capture patterns like "as amended by", "as in force on", "repealed by", etc.
-func (e *ReferenceExtractor) extractTemporalRefs(text string, sourceArticle int) []*Reference {
+func (e *ReferenceExtractor) extractTemporalRefs(scan *textScan, sourceArticle int) []*Reference {
+	text := scan.text
 	var refs []*Reference
 
 	// "repealed with effect from 25 May 2018" (most specific repeal pattern first)
-	matches := e.repealedWithEffectPattern.FindAllStringSubmatchIndex(text, -1)
+	matches := e.findAll(scan, "repealedWithEffect", e.repealedWithEffectPattern)
 	for _, match := range matches {
 		rawText := text[match[0]:match[1]]
 		dateStr := text[match[2]:match[3]]
@@ -1590,7 +1866,7 @@ func (e *ReferenceExtractor) extractTemporalRefs(text string, sourceArticle int)
 	}
 
 	// "repealed by {document}" (skip if overlapping with repealedWithEffect)
-	matches = e.repealedByPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "repealedBy", e.repealedByPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1612,7 +1888,7 @@ func (e *ReferenceExtractor) extractTemporalRefs(text string, sourceArticle int)
 	}
 
 	// "as amended by {document}" (most specific amendment pattern)
-	matches = e.asAmendedByPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "asAmendedBy", e.asAmendedByPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1634,7 +1910,7 @@ func (e *ReferenceExtractor) extractTemporalRefs(text string, sourceArticle int)
 	}
 
 	// "as amended" / "as amended accordingly" (standalone, no "by")
-	matches = e.asAmendedPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "asAmended", e.asAmendedPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1655,7 +1931,7 @@ func (e *ReferenceExtractor) extractTemporalRefs(text string, sourceArticle int)
 	}
 
 	// "as in force on {date}"
-	matches = e.asInForceOnPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "asInForceOn", e.asInForceOnPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1679,7 +1955,7 @@ func (e *ReferenceExtractor) extractTemporalRefs(text string, sourceArticle int)
 	}
 
 	// "in force on/from {date}" (skip if overlapping with asInForceOn)
-	matches = e.inForceOnPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "inForceOn", e.inForceOnPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1703,7 +1979,7 @@ func (e *ReferenceExtractor) extractTemporalRefs(text string, sourceArticle int)
 	}
 
 	// "enter(s/ed) into force (on {date})?"
-	matches = e.enterIntoForcePattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "enterIntoForce", e.enterIntoForcePattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1729,7 +2005,7 @@ func (e *ReferenceExtractor) extractTemporalRefs(text string, sourceArticle int)
 	}
 
 	// "as originally enacted"
-	matches = e.asOriginallyEnactedPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "asOriginallyEnacted", e.asOriginallyEnactedPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1750,7 +2026,7 @@ func (e *ReferenceExtractor) extractTemporalRefs(text string, sourceArticle int)
 	}
 
 	// "as it stood on {date}"
-	matches = e.asItStoodOnPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "asItStoodOn", e.asItStoodOnPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -1774,7 +2050,7 @@ func (e *ReferenceExtractor) extractTemporalRefs(text string, sourceArticle int)
 	}
 
 	// "consolidated version (of)?"
-	matches = e.consolidatedVersionPattern.FindAllStringSubmatchIndex(text, -1)
+	matches = e.findAll(scan, "consolidatedVersion", e.consolidatedVersionPattern)
 	for _, match := range matches {
 		if e.isOverlapping(match[0], match[1], refs) {
 			continue
@@ -0,0 +1,289 @@
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ReferenceFamily groups the reference patterns of one drafting tradition.
+// Applying every family to every document produces cross-jurisdiction false
+// positives, such as "Section 2" of an EU regulation read as a US section.
+type ReferenceFamily string
+
+const (
+	// FamilyEU covers Article 6(1)(a), paragraph 2, Chapter III, Section 1,
+	// and "this Article".
+	FamilyEU ReferenceFamily = "eu"
+
+	// FamilyEUExternal covers citations of EU acts: Directive 95/46/EC,
+	// Regulation (EU) 2016/679, Decision 2010/87/EU, and the Treaties.
+	FamilyEUExternal ReferenceFamily = "eu_external"
+
+	// FamilyUSState covers state code sections: Section 1798.100(a),
+	// subdivision (a) of Section 1798.100.
+	FamilyUSState ReferenceFamily = "us_state"
+
+	// FamilyUSMunicipal covers city and county code sections: City Code §
+	// 8.04.020, Sec. 8.04.020(a), Chapter 8.04. It applies only to
+	// documents detected as municipal codes.
+	FamilyUSMunicipal ReferenceFamily = "us_municipal"
+
+	// FamilyUSC covers United States Code sections: section 1396a(a)(10) of
+	// this title, subsection (b), subchapter II of chapter 7.
+	FamilyUSC ReferenceFamily = "usc"
+
+	// FamilyUSExternal covers US citations of other acts: 15 U.S.C. 1681,
+	// 45 C.F.R. Part 164, Public Law 104-191.
+	FamilyUSExternal ReferenceFamily = "us_external"
+
+	// FamilyHouseRules covers clause 5 of rule XX and parliamentary
+	// authorities such as Jefferson's Manual and Deschler's Precedents.
+	FamilyHouseRules ReferenceFamily = "house_rules"
+)
+
+// ReferenceFamilies lists every pattern family.
+var ReferenceFamilies = []ReferenceFamily{FamilyEU, FamilyEUExternal, FamilyUSState, FamilyUSMunicipal, FamilyUSC, FamilyUSExternal, FamilyHouseRules}
+
+// minFamilyConfidence is the share of matches the families of a document's
+// format must account for. Below it the format is taken to be misdetected
+// and every family is applied.
+const minFamilyConfidence = 0.5
+
+// familiesForFormat returns the pattern families that apply to documents of
+// a format, or nil when any family may apply. Citations of other acts name
+// their jurisdiction and apply to every format.
+func familiesForFormat(format DocumentFormat) []ReferenceFamily {
+	switch format {
+	case FormatEU:
+		return []ReferenceFamily{FamilyEU, FamilyEUExternal, FamilyUSExternal}
+	case FormatUS:
+		return []ReferenceFamily{FamilyUSState, FamilyUSC, FamilyHouseRules, FamilyEUExternal, FamilyUSExternal}
+	default:
+		// UK and generic documents mix conventions
+		return nil
+	}
+}
+
+// municipalCodeTitlePattern matches the titles of city and county codes:
+// "Seattle Municipal Code", "New York City Administrative Code", "Atlanta
+// Code of Ordinances".
+var municipalCodeTitlePattern = regexp.MustCompile(`(?i)\b(?:municipal|city|county|town|village|township)\s+(?:\w+\s+)?codes?\b|\bcode\s+of\s+ordinances\b`)
+
+// isMunicipalCode reports whether a document is a city or county code,
+// judged by its title.
+func isMunicipalCode(doc *Document) bool {
+	return municipalCodeTitlePattern.MatchString(doc.Title)
+}
+
+// familiesForDocument returns the pattern families that apply to a
+// document, or nil when any family but the municipal one may apply. A
+// municipal code takes the municipal family in place of the state family,
+// whose patterns would read its section 8.04.020 as Section 8.04.020 of a
+// state code.
+func familiesForDocument(doc *Document) []ReferenceFamily {
+	families := familiesForFormat(doc.Format)
+	if isMunicipalCode(doc) {
+		for i, family := range families {
+			if family == FamilyUSState {
+				families[i] = FamilyUSMunicipal
+			}
+		}
+	}
+	return families
+}
+
+// SetFamilies restricts extraction to the given families, overriding
+// detection. Annex and temporal references are extracted regardless.
+// Calling it with no families restores detection.
+func (e *ReferenceExtractor) SetFamilies(families ...ReferenceFamily) {
+	e.families = nil
+	if len(families) > 0 {
+		e.families = make(map[ReferenceFamily]bool, len(families))
+		for _, family := range families {
+			e.families[family] = true
+		}
+	}
+}
+
+// withFamilies returns a copy of the extractor restricted to families.
+func (e *ReferenceExtractor) withFamilies(families []ReferenceFamily) *ReferenceExtractor {
+	restricted := *e
+	restricted.SetFamilies(families...)
+	return &restricted
+}
+
+// FamilyMatches counts the references one family found in a document.
+type FamilyMatches struct {
+	Family  ReferenceFamily `json:"family"`
+	Matches int             `json:"matches"`
+	Applied bool            `json:"applied"`
+}
+
+// ReferenceConflict is a match that was dropped or contested during family
+// selection.
+type ReferenceConflict struct {
+	SourceArticle int             `json:"source_article"`
+	RawText       string          `json:"raw_text"`
+	Family        ReferenceFamily `json:"family"`
+
+	// Other is the family of an overlapping match of the same text.
+	Other  ReferenceFamily `json:"other,omitempty"`
+	Reason string          `json:"reason"`
+}
+
+// FamilyReport records which pattern families apply to a document and how
+// confident the choice is.
+type FamilyReport struct {
+	Format   DocumentFormat  `json:"format"`
+	Families []FamilyMatches `json:"families"`
+
+	// Restricted is set when only some families are applied.
+	Restricted bool `json:"restricted"`
+
+	// Confidence is the share of family matches found by the applied
+	// families: 1 when every match fits the document's format.
+	Confidence float64 `json:"confidence"`
+
+	Conflicts []ReferenceConflict `json:"conflicts,omitempty"`
+}
+
+// DetectFamilies selects the pattern families that apply to a document from
+// its parsed format, and reports the matches of the other families and the
+// text matched by more than one family. When the selected families account
+// for less than half of all matches, the format is not trusted and every
+// family is applied, except the municipal family outside municipal codes.
+func (e *ReferenceExtractor) DetectFamilies(doc *Document) *FamilyReport {
+	report := &FamilyReport{Format: doc.Format, Confidence: 1}
+
+	selected := make(map[ReferenceFamily]bool)
+	for _, family := range familiesForDocument(doc) {
+		selected[family] = true
+	}
+	municipal := isMunicipalCode(doc)
+
+	all := e.withFamilies(ReferenceFamilies)
+	counts := make(map[ReferenceFamily]int)
+	total, applied := 0, 0
+	var matches [][]*Reference
+	var matchFamilies [][]ReferenceFamily
+	for _, article := range doc.AllArticles() {
+		refs, families := all.extractFamilies(article)
+		for _, family := range families {
+			if family == "" {
+				continue
+			}
+			counts[family]++
+			total++
+			if len(selected) == 0 || selected[family] {
+				applied++
+			}
+		}
+		matches = append(matches, refs)
+		matchFamilies = append(matchFamilies, families)
+	}
+
+	if len(selected) > 0 && total > 0 {
+		report.Confidence = float64(applied) / float64(total)
+		report.Restricted = report.Confidence >= minFamilyConfidence
+	}
+
+	applies := make(map[ReferenceFamily]bool)
+	for _, family := range ReferenceFamilies {
+		applies[family] = selected[family] || !report.Restricted && (family != FamilyUSMunicipal || municipal)
+		report.Families = append(report.Families, FamilyMatches{
+			Family:  family,
+			Matches: counts[family],
+			Applied: applies[family],
+		})
+	}
+
+	for i, refs := range matches {
+		report.Conflicts = append(report.Conflicts, familyConflicts(refs, matchFamilies[i], applies, doc.Format)...)
+	}
+	return report
+}
+
+// familyConflicts lists the matches in one article that family selection
+// dropped, and those overlapping a match of another family.
+func familyConflicts(refs []*Reference, families []ReferenceFamily, applies map[ReferenceFamily]bool, format DocumentFormat) []ReferenceConflict {
+	var conflicts []ReferenceConflict
+	for i, ref := range refs {
+		family := families[i]
+		if family == "" {
+			continue
+		}
+		if !applies[family] {
+			conflicts = append(conflicts, ReferenceConflict{
+				SourceArticle: ref.SourceArticle,
+				RawText:       ref.RawText,
+				Family:        family,
+				Reason:        fmt.Sprintf("%s patterns do not apply to %s documents", family, format),
+			})
+		}
+		for j, other := range refs[:i] {
+			if families[j] == "" || families[j] == family {
+				continue
+			}
+			if ref.TextOffset < other.TextOffset+other.TextLength && other.TextOffset < ref.TextOffset+ref.TextLength {
+				conflicts = append(conflicts, ReferenceConflict{
+					SourceArticle: ref.SourceArticle,
+					RawText:       ref.RawText,
+					Family:        family,
+					Other:         families[j],
+					Reason:        fmt.Sprintf("overlaps %q", other.RawText),
+				})
+			}
+		}
+	}
+	return conflicts
+}
+
+// Applied returns the families applied to the document.
+func (r *FamilyReport) Applied() []ReferenceFamily {
+	var families []ReferenceFamily
+	for _, family := range r.Families {
+		if family.Applied {
+			families = append(families, family.Family)
+		}
+	}
+	return families
+}
+
+// ToJSON serializes the report to JSON.
+func (r *FamilyReport) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// String returns a human-readable summary of the report.
+func (r *FamilyReport) String() string {
+	var sb strings.Builder
+
+	format := string(r.Format)
+	if format == "" {
+		format = string(FormatUnknown)
+	}
+	sb.WriteString(fmt.Sprintf("Reference Families (%s document, confidence %.1f%%)\n", format, r.Confidence*100))
+	sb.WriteString(strings.Repeat("=", 50) + "\n")
+	for _, family := range r.Families {
+		status := "skipped"
+		if family.Applied {
+			status = "applied"
+		}
+		sb.WriteString(fmt.Sprintf("  %-12s %-8s %d matches\n", family.Family, status, family.Matches))
+	}
+
+	if len(r.Conflicts) > 0 {
+		sb.WriteString(fmt.Sprintf("\nConflicts (%d):\n", len(r.Conflicts)))
+		for i, conflict := range r.Conflicts {
+			if i == 10 {
+				sb.WriteString(fmt.Sprintf("  ... and %d more\n", len(r.Conflicts)-10))
+				break
+			}
+			sb.WriteString(fmt.Sprintf("  Art %d: %q (%s) - %s\n", conflict.SourceArticle, conflict.RawText, conflict.Family, conflict.Reason))
+		}
+	}
+
+	return sb.String()
+}
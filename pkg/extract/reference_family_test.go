@@ -0,0 +1,160 @@
+package extract
+
+import "testing"
+
+func familyTestDocument(format DocumentFormat, texts ...string) *Document {
+	chapter := &Chapter{Number: "I"}
+	for i, text := range texts {
+		chapter.Articles = append(chapter.Articles, &Article{Number: i + 1, Text: text})
+	}
+	return &Document{Format: format, Chapters: []*Chapter{chapter}}
+}
+
+func TestReferenceExtractor_FamiliesByFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     DocumentFormat
+		title      string
+		text       string
+		want       []string
+		restricted bool
+	}{
+		{
+			name:       "EU regulation drops US sections",
+			format:     FormatEU,
+			text:       "Subject to Article 6 and Section 1798.100, Directive 95/46/EC is repealed.",
+			want:       []string{"Article 6", "Directive 95/46/EC"},
+			restricted: true,
+		},
+		{
+			name:       "US statute drops EU sections but keeps EU acts",
+			format:     FormatUS,
+			text:       "Pursuant to Section 1798.100 and Section 17014, processing under Regulation (EU) 2016/679 is exempt.",
+			want:       []string{"Section 1798.100", "Regulation (EU) 2016/679"},
+			restricted: true,
+		},
+		{
+			name:       "US state code keeps dotted sections",
+			format:     FormatUS,
+			text:       "A breach of subdivision (h) of Section 1798.81.5 is actionable under Section 1798.150.",
+			want:       []string{"subdivision (h) of Section 1798.81.5", "Section 1798.150"},
+			restricted: true,
+		},
+		{
+			name:       "US municipal code keeps code sections and chapters",
+			format:     FormatUS,
+			title:      "SEATTLE MUNICIPAL CODE",
+			text:       "A license under Chapter 8.04 is subject to City Code § 8.04.020 and Section 8.04.030(b).",
+			want:       []string{"Chapter 8.04", "City Code § 8.04.020", "Section 8.04.030(b)"},
+			restricted: true,
+		},
+		{
+			name:   "unknown format applies every family",
+			format: FormatUnknown,
+			text:   "See Article 6 and Section 1798.100.",
+			want:   []string{"Article 6", "Section 1798.100"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := familyTestDocument(tt.format, tt.text)
+			doc.Title = tt.title
+			extractor := NewReferenceExtractor()
+
+			report := extractor.DetectFamilies(doc)
+			if report.Restricted != tt.restricted {
+				t.Errorf("Restricted = %v, want %v", report.Restricted, tt.restricted)
+			}
+
+			var got []string
+			for _, ref := range extractor.ExtractFromDocument(doc) {
+				got = append(got, ref.RawText)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("references = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("references = %q, want %q", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestReferenceExtractor_DetectFamiliesReport(t *testing.T) {
+	doc := familyTestDocument(FormatUS, "Information described in Section 17014 of Title 18 is exempt.")
+	report := NewReferenceExtractor().DetectFamilies(doc)
+
+	if report.Confidence != 0.5 {
+		t.Errorf("Confidence = %v, want 0.5", report.Confidence)
+	}
+	var dropped, overlap bool
+	for _, conflict := range report.Conflicts {
+		if conflict.Family == FamilyEU && conflict.RawText == "Section 17014" && conflict.Other == "" {
+			dropped = true
+		}
+		if conflict.Family == FamilyUSExternal && conflict.Other == FamilyEU {
+			overlap = true
+		}
+	}
+	if !dropped || !overlap {
+		t.Errorf("expected a dropped EU match and an overlap, got %+v", report.Conflicts)
+	}
+}
+
+func TestReferenceExtractor_LowConfidenceAppliesEveryFamily(t *testing.T) {
+	// A document parsed as EU whose references are all US-style
+	doc := familyTestDocument(FormatEU, "As provided in Section 1798.100 and Section 1798.105.")
+	extractor := NewReferenceExtractor()
+
+	report := extractor.DetectFamilies(doc)
+	if report.Restricted {
+		t.Errorf("expected every family to apply at confidence %v", report.Confidence)
+	}
+	if refs := extractor.ExtractFromDocument(doc); len(refs) != 2 {
+		t.Errorf("got %d references, want 2", len(refs))
+	}
+}
+
+func TestReferenceExtractor_SetFamilies(t *testing.T) {
+	doc := familyTestDocument(FormatEU, "Article 6 and Section 1798.100 apply.")
+	extractor := NewReferenceExtractor()
+	extractor.SetFamilies(FamilyUSState)
+
+	refs := extractor.ExtractFromDocument(doc)
+	if len(refs) != 1 || refs[0].RawText != "Section 1798.100" {
+		t.Errorf("references = %+v, want only Section 1798.100", refs)
+	}
+
+	extractor.SetFamilies()
+	if refs := extractor.ExtractFromDocument(doc); len(refs) != 1 || refs[0].RawText != "Article 6" {
+		t.Errorf("after reset, references = %+v, want only Article 6", refs)
+	}
+}
+
+func TestReferenceExtractor_MunicipalFamilyOnlyForMunicipalCodes(t *testing.T) {
+	text := "As provided in City Code § 8.04.020 and Section 8.04.030."
+	for _, title := range []string{"", "CALIFORNIA CONSUMER PRIVACY ACT OF 2018"} {
+		// An untrusted format applies every family but the municipal one
+		doc := familyTestDocument(FormatUnknown, text)
+		doc.Title = title
+
+		report := NewReferenceExtractor().DetectFamilies(doc)
+		for _, family := range report.Families {
+			if family.Family == FamilyUSMunicipal && family.Applied {
+				t.Errorf("title %q: municipal family applied", title)
+			}
+		}
+	}
+
+	for _, title := range []string{"SEATTLE MUNICIPAL CODE", "NEW YORK CITY ADMINISTRATIVE CODE", "ATLANTA CODE OF ORDINANCES"} {
+		doc := familyTestDocument(FormatUnknown, text)
+		doc.Title = title
+		if !isMunicipalCode(doc) {
+			t.Errorf("title %q: not detected as a municipal code", title)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package extract
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/coolbeans/regula/patterns"
+)
+
+// ReferencePack is a versioned set of reference patterns in YAML. The
+// built-in packs in patterns/references hold the patterns of the
+// ReferenceExtractor; a project pack overrides built-in patterns by name
+// or adds patterns of its own.
+//
+//	name: "Project References"
+//	pack_id: "project"
+//	version: "1.0.0"
+//	family: "eu"
+//	patterns:
+//	  # Replaces the built-in pattern of the same name
+//	  - name: "chapter"
+//	    pattern: '\bChapter\s+([IVXLC]+|\d+)\b'
+//	  # A custom pattern; named groups fill the fields of its references
+//	  - name: "recital"
+//	    pattern: '\brecital\s+\((?P<paragraph>\d+)\)'
+//	    target: "paragraph"
+//	    identifier: "Recital {paragraph}"
+type ReferencePack struct {
+	Name    string          `yaml:"name" json:"name"`
+	PackID  string          `yaml:"pack_id" json:"pack_id"`
+	Version string          `yaml:"version" json:"version"`
+	Family  ReferenceFamily `yaml:"family,omitempty" json:"family,omitempty"`
+
+	Patterns []PackPattern `yaml:"patterns" json:"patterns"`
+}
+
+// PackPattern is one pattern of a reference pack. A pattern named after a
+// built-in pattern replaces it and must have the same number of capture
+// groups. Any other name adds a custom pattern, whose named groups map to
+// Reference fields (see customGroups).
+type PackPattern struct {
+	Name    string `yaml:"name" json:"name"`
+	Pattern string `yaml:"pattern" json:"pattern"`
+
+	// Custom patterns only
+	Type        ReferenceType   `yaml:"type,omitempty" json:"type,omitempty"`
+	Target      ReferenceTarget `yaml:"target,omitempty" json:"target,omitempty"`
+	ExternalDoc string          `yaml:"external_doc,omitempty" json:"external_doc,omitempty"`
+
+	// Identifier is a template for the reference identifier in which
+	// {group} is replaced by the text of a named group. The raw text is
+	// used when it is empty.
+	Identifier string `yaml:"identifier,omitempty" json:"identifier,omitempty"`
+}
+
+// customGroups lists the named groups a custom pattern may use, each filling
+// the Reference field of the same name.
+var customGroups = map[string]bool{
+	"article":    true, // ArticleNum
+	"paragraph":  true, // ParagraphNum
+	"point":      true, // PointLetter
+	"chapter":    true, // ChapterNum
+	"section":    true, // SectionStr, and SectionNum when numeric
+	"annex":      true, // AnnexNum
+	"doc_year":   true, // DocYear
+	"doc_number": true, // DocNumber
+}
+
+// customPattern is a compiled custom pattern of a pack.
+type customPattern struct {
+	PackPattern
+	family  ReferenceFamily
+	pattern *regexp.Regexp
+}
+
+// ParseReferencePack parses a reference pack and validates its metadata and
+// patterns.
+func ParseReferencePack(data []byte) (*ReferencePack, error) {
+	pack := &ReferencePack{}
+	if err := yaml.Unmarshal(data, pack); err != nil {
+		return nil, fmt.Errorf("failed to parse reference pack: %w", err)
+	}
+
+	if pack.Name == "" {
+		return nil, fmt.Errorf("reference pack name is required")
+	}
+	if pack.PackID == "" {
+		return nil, fmt.Errorf("reference pack %q: pack_id is required", pack.Name)
+	}
+	if pack.Version == "" {
+		return nil, fmt.Errorf("reference pack %q: version is required", pack.PackID)
+	}
+	if pack.Family != "" && !isReferenceFamily(pack.Family) {
+		return nil, fmt.Errorf("reference pack %q: unknown family %q", pack.PackID, pack.Family)
+	}
+
+	seen := make(map[string]bool)
+	for index, packPattern := range pack.Patterns {
+		if packPattern.Name == "" {
+			return nil, fmt.Errorf("reference pack %q: pattern %d: name is required", pack.PackID, index+1)
+		}
+		if seen[packPattern.Name] {
+			return nil, fmt.Errorf("reference pack %q: duplicate pattern %q", pack.PackID, packPattern.Name)
+		}
+		seen[packPattern.Name] = true
+		if _, err := regexp.Compile(packPattern.Pattern); err != nil {
+			return nil, fmt.Errorf("reference pack %q: pattern %q: %w", pack.PackID, packPattern.Name, err)
+		}
+	}
+
+	return pack, nil
+}
+
+// LoadReferencePack reads and parses a reference pack file.
+func LoadReferencePack(path string) (*ReferencePack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reference pack: %w", err)
+	}
+	pack, err := ParseReferencePack(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return pack, nil
+}
+
+// LoadReferencePacks loads a reference pack file, or every YAML pack in a
+// directory in file name order.
+func LoadReferencePacks(path string) ([]*ReferencePack, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reference packs: %w", err)
+	}
+	if !info.IsDir() {
+		pack, err := LoadReferencePack(path)
+		if err != nil {
+			return nil, err
+		}
+		return []*ReferencePack{pack}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reference packs: %w", err)
+	}
+	var packs []*ReferencePack
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || (!strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml")) {
+			continue
+		}
+		pack, err := LoadReferencePack(filepath.Join(path, name))
+		if err != nil {
+			return nil, err
+		}
+		packs = append(packs, pack)
+	}
+	return packs, nil
+}
+
+// DefaultReferencePacks returns the built-in reference packs, which define
+// every pattern of the ReferenceExtractor.
+func DefaultReferencePacks() []*ReferencePack {
+	entries, err := fs.Glob(patterns.ReferencePacks, "references/*.yaml")
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in reference packs: %v", err))
+	}
+	sort.Strings(entries)
+
+	var packs []*ReferencePack
+	for _, name := range entries {
+		data, err := patterns.ReferencePacks.ReadFile(name)
+		if err != nil {
+			panic(fmt.Sprintf("invalid built-in reference pack %s: %v", name, err))
+		}
+		pack, err := ParseReferencePack(data)
+		if err != nil {
+			panic(fmt.Sprintf("invalid built-in reference pack %s: %v", name, err))
+		}
+		packs = append(packs, pack)
+	}
+	return packs
+}
+
+// NewReferenceExtractorWithPacks creates a ReferenceExtractor with the
+// default patterns and then applies packs in order, so that a later pack
+// overrides an earlier one.
+func NewReferenceExtractorWithPacks(packs ...*ReferencePack) (*ReferenceExtractor, error) {
+	extractor := NewReferenceExtractor()
+	for _, pack := range packs {
+		if err := extractor.applyPack(pack, false); err != nil {
+			return nil, err
+		}
+	}
+	return extractor, nil
+}
+
+// applyPack sets the extractor's patterns from a pack. Built-in packs may
+// only name built-in patterns.
+func (e *ReferenceExtractor) applyPack(pack *ReferencePack, builtin bool) error {
+	fields := e.patternFields()
+	for _, packPattern := range pack.Patterns {
+		compiled, err := regexp.Compile(packPattern.Pattern)
+		if err != nil {
+			return fmt.Errorf("reference pack %q: pattern %q: %w", pack.PackID, packPattern.Name, err)
+		}
+
+		if field, ok := fields[packPattern.Name]; ok {
+			if builtin {
+				*field = compiled
+				continue
+			}
+			if want := (*field).NumSubexp(); compiled.NumSubexp() != want {
+				return fmt.Errorf("reference pack %q: pattern %q has %d capture groups, the built-in pattern has %d",
+					pack.PackID, packPattern.Name, compiled.NumSubexp(), want)
+			}
+			*field = compiled
+			overridden := make(map[string]bool, len(e.overridden)+1)
+			for name := range e.overridden {
+				overridden[name] = true
+			}
+			overridden[packPattern.Name] = true
+			e.overridden = overridden
+			continue
+		}
+
+		if builtin {
+			return fmt.Errorf("reference pack %q: unknown built-in pattern %q", pack.PackID, packPattern.Name)
+		}
+		custom, err := newCustomPattern(pack, packPattern, compiled)
+		if err != nil {
+			return err
+		}
+		e.custom = append(e.custom[:len(e.custom):len(e.custom)], custom)
+	}
+	return nil
+}
+
+// newCustomPattern validates a custom pattern of a pack.
+func newCustomPattern(pack *ReferencePack, packPattern PackPattern, compiled *regexp.Regexp) (*customPattern, error) {
+	if packPattern.Target == "" {
+		return nil, fmt.Errorf("reference pack %q: custom pattern %q: target is required", pack.PackID, packPattern.Name)
+	}
+	if packPattern.Type == "" {
+		packPattern.Type = ReferenceTypeInternal
+	}
+	if packPattern.Type != ReferenceTypeInternal && packPattern.Type != ReferenceTypeExternal {
+		return nil, fmt.Errorf("reference pack %q: custom pattern %q: unknown type %q", pack.PackID, packPattern.Name, packPattern.Type)
+	}
+	for _, group := range compiled.SubexpNames() {
+		if group != "" && !customGroups[group] {
+			return nil, fmt.Errorf("reference pack %q: custom pattern %q: unknown group %q", pack.PackID, packPattern.Name, group)
+		}
+	}
+	return &customPattern{PackPattern: packPattern, family: pack.Family, pattern: compiled}, nil
+}
+
+// extractCustomRefs extracts the references matched by a custom pattern,
+// skipping text a built-in pattern already matched.
+func (e *ReferenceExtractor) extractCustomRefs(scan *textScan, sourceArticle int, custom *customPattern, existingRefs []*Reference) []*Reference {
+	text := scan.text
+	var refs []*Reference
+
+	for _, match := range e.findAll(scan, custom.Name, custom.pattern) {
+		if isOverlappingWithSlice(match[0], match[1], existingRefs) || e.isOverlapping(match[0], match[1], refs) {
+			continue
+		}
+
+		ref := &Reference{
+			Type:          custom.Type,
+			Target:        custom.Target,
+			RawText:       text[match[0]:match[1]],
+			Identifier:    custom.Identifier,
+			SourceArticle: sourceArticle,
+			TextOffset:    match[0],
+			TextLength:    match[1] - match[0],
+			ExternalDoc:   custom.ExternalDoc,
+		}
+		for i, group := range custom.pattern.SubexpNames() {
+			if group == "" || match[2*i] < 0 {
+				continue
+			}
+			value := text[match[2*i]:match[2*i+1]]
+			setCustomGroup(ref, group, value)
+			ref.Identifier = strings.ReplaceAll(ref.Identifier, "{"+group+"}", value)
+		}
+		if ref.Identifier == "" {
+			ref.Identifier = ref.RawText
+		}
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
+
+// setCustomGroup sets the Reference field named by a custom pattern group.
+func setCustomGroup(ref *Reference, group, value string) {
+	switch group {
+	case "article":
+		ref.ArticleNum, _ = strconv.Atoi(value)
+	case "paragraph":
+		ref.ParagraphNum, _ = strconv.Atoi(value)
+	case "point":
+		ref.PointLetter = value
+	case "chapter":
+		ref.ChapterNum = value
+	case "section":
+		ref.SectionStr = value
+		ref.SectionNum, _ = strconv.Atoi(value)
+	case "annex":
+		ref.AnnexNum = value
+	case "doc_year":
+		ref.DocYear = value
+	case "doc_number":
+		ref.DocNumber = value
+	}
+}
+
+// isReferenceFamily reports whether family is one of ReferenceFamilies.
+func isReferenceFamily(family ReferenceFamily) bool {
+	for _, known := range ReferenceFamilies {
+		if family == known {
+			return true
+		}
+	}
+	return false
+}
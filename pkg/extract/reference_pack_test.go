@@ -0,0 +1,184 @@
+package extract
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultReferencePacks(t *testing.T) {
+	packs := DefaultReferencePacks()
+	if len(packs) == 0 {
+		t.Fatal("expected built-in reference packs")
+	}
+
+	builtin := NewReferenceExtractor().patternFields()
+	seen := make(map[string]string)
+	for _, pack := range packs {
+		for _, packPattern := range pack.Patterns {
+			if _, ok := builtin[packPattern.Name]; !ok {
+				t.Errorf("pack %s: %q is not a built-in pattern", pack.PackID, packPattern.Name)
+			}
+			if other, ok := seen[packPattern.Name]; ok {
+				t.Errorf("pattern %q defined in packs %s and %s", packPattern.Name, other, pack.PackID)
+			}
+			seen[packPattern.Name] = pack.PackID
+		}
+	}
+	if len(seen) != len(builtin) {
+		t.Errorf("packs define %d patterns, the extractor has %d", len(seen), len(builtin))
+	}
+}
+
+func TestReferenceExtractorWithPacks_Override(t *testing.T) {
+	pack, err := ParseReferencePack([]byte(`
+name: "Arabic Chapters"
+pack_id: "arabic-chapters"
+version: "1.0.0"
+patterns:
+  - name: "chapter"
+    pattern: 'Chapter\s+([IVX]+|\d+)\b'
+`))
+	if err != nil {
+		t.Fatalf("ParseReferencePack: %v", err)
+	}
+	article := &Article{Number: 1, Text: "The requirements of Chapter 3 and Chapter IV apply."}
+	defaults := NewReferenceExtractor()
+	defaults.SetFamilies(FamilyEU)
+
+	if refs := defaults.ExtractFromArticle(article); len(refs) != 1 {
+		t.Fatalf("default patterns found %d references, want 1", len(refs))
+	}
+
+	extractor, err := NewReferenceExtractorWithPacks(pack)
+	if err != nil {
+		t.Fatalf("NewReferenceExtractorWithPacks: %v", err)
+	}
+	extractor.SetFamilies(FamilyEU)
+	refs := extractor.ExtractFromArticle(article)
+	if len(refs) != 2 || refs[0].ChapterNum != "3" || refs[0].Identifier != "Chapter 3" {
+		t.Errorf("references = %+v, want Chapter 3 and Chapter IV", refs)
+	}
+
+	// The override does not leak into other extractors
+	if refs := defaults.ExtractFromArticle(article); len(refs) != 1 {
+		t.Errorf("default extractor changed: %d references", len(refs))
+	}
+}
+
+func TestReferenceExtractorWithPacks_Custom(t *testing.T) {
+	pack, err := ParseReferencePack([]byte(`
+name: "Recitals"
+pack_id: "recitals"
+version: "1.0.0"
+family: "eu"
+patterns:
+  - name: "recital"
+    pattern: '\b[Rr]ecital\s+\((?P<paragraph>\d+)\)'
+    target: "paragraph"
+    identifier: "Recital {paragraph}"
+`))
+	if err != nil {
+		t.Fatalf("ParseReferencePack: %v", err)
+	}
+	extractor, err := NewReferenceExtractorWithPacks(pack)
+	if err != nil {
+		t.Fatalf("NewReferenceExtractorWithPacks: %v", err)
+	}
+
+	refs := extractor.ExtractFromArticle(&Article{Number: 4, Text: "As explained in recital (39), Article 6 applies."})
+	var recital *Reference
+	for _, ref := range refs {
+		if ref.Identifier == "Recital 39" {
+			recital = ref
+		}
+	}
+	if recital == nil {
+		t.Fatalf("custom pattern found nothing in %+v", refs)
+	}
+	if recital.Type != ReferenceTypeInternal || recital.Target != TargetParagraph || recital.ParagraphNum != 39 || recital.RawText != "recital (39)" {
+		t.Errorf("recital reference = %+v", recital)
+	}
+
+	extractor.SetFamilies(FamilyUSC)
+	for _, ref := range extractor.ExtractFromArticle(&Article{Number: 4, Text: "See recital (39)."}) {
+		if ref.Identifier == "Recital 39" {
+			t.Error("custom pattern applied outside its family")
+		}
+	}
+}
+
+func TestReferencePack_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{
+			name: "missing version",
+			yaml: "name: x\npack_id: x\npatterns: []\n",
+			want: "version is required",
+		},
+		{
+			name: "unknown family",
+			yaml: "name: x\npack_id: x\nversion: \"1\"\nfamily: uk\n",
+			want: "unknown family",
+		},
+		{
+			name: "invalid regexp",
+			yaml: "name: x\npack_id: x\nversion: \"1\"\npatterns:\n  - name: article\n    pattern: 'Article\\s+(\\d+'\n",
+			want: "missing closing )",
+		},
+		{
+			name: "override changes capture groups",
+			yaml: "name: x\npack_id: x\nversion: \"1\"\npatterns:\n  - name: article\n    pattern: 'Article\\s+\\d+'\n",
+			want: "has 0 capture groups, the built-in pattern has 1",
+		},
+		{
+			name: "custom pattern without target",
+			yaml: "name: x\npack_id: x\nversion: \"1\"\npatterns:\n  - name: recital\n    pattern: 'recital\\s+(?P<paragraph>\\d+)'\n",
+			want: "target is required",
+		},
+		{
+			name: "custom pattern with unknown group",
+			yaml: "name: x\npack_id: x\nversion: \"1\"\npatterns:\n  - name: recital\n    pattern: 'recital\\s+(?P<recital>\\d+)'\n    target: paragraph\n",
+			want: "unknown group \"recital\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pack, err := ParseReferencePack([]byte(tt.yaml))
+			if err == nil {
+				_, err = NewReferenceExtractorWithPacks(pack)
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadReferencePacks(t *testing.T) {
+	dir := t.TempDir()
+	pack := "name: Project\npack_id: project\nversion: \"1.0.0\"\npatterns:\n  - name: chapter\n    pattern: 'Chapter\\s+(\\d+)'\n"
+	if err := os.WriteFile(filepath.Join(dir, "project.yaml"), []byte(pack), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a pack"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	packs, err := LoadReferencePacks(dir)
+	if err != nil {
+		t.Fatalf("LoadReferencePacks: %v", err)
+	}
+	if len(packs) != 1 || packs[0].PackID != "project" {
+		t.Errorf("packs = %+v, want the project pack", packs)
+	}
+
+	if _, err := LoadReferencePacks(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected an error for a missing path")
+	}
+}
@@ -0,0 +1,364 @@
+package extract
+
+import (
+	"regexp"
+	"sync"
+)
+
+// Most reference patterns can only match where a fixed keyword occurs:
+// "Article 6" needs "article", "section 552a of title 5" needs "section" and
+// "title". Before running the patterns on an article, the extractor finds
+// every keyword in one Aho-Corasick pass over the text. A pattern whose
+// keywords are absent is skipped, and a pattern whose matches always begin
+// with a keyword is tried only at that keyword's occurrences instead of
+// being scanned across the whole text. Both shortcuts return exactly the
+// matches FindAllStringSubmatchIndex would.
+//
+// Keywords are matched against ASCII-lowercased text, which is sufficient
+// for case-insensitive patterns except where Unicode case folding maps a
+// non-ASCII rune onto a keyword letter (U+017F "ſ" folds to "s", U+212A
+// Kelvin sign to "k"). Texts containing either rune are matched without the
+// pre-filter.
+
+// patternKeywords describes where a pattern can match.
+type patternKeywords struct {
+	// lead is a lowercase keyword every match starts with, or "" if
+	// matches can start elsewhere.
+	lead string
+
+	// leadBoundary is set when the pattern begins with \b, so an
+	// occurrence of lead only counts at the start of a word.
+	leadBoundary bool
+
+	// requires lists keyword groups; every match contains all keywords of
+	// at least one group. lead is implied.
+	requires [][]string
+
+	anchorOnce sync.Once
+	anchored   *regexp.Regexp
+}
+
+// anchoredPattern returns pattern anchored to the start of its input, for
+// matching at a lead occurrence.
+func (k *patternKeywords) anchoredPattern(pattern *regexp.Regexp) *regexp.Regexp {
+	k.anchorOnce.Do(func() {
+		k.anchored = regexp.MustCompile(`^(?:` + pattern.String() + `)`)
+	})
+	return k.anchored
+}
+
+func allOf(keywords ...string) [][]string { return [][]string{keywords} }
+
+// referenceKeywords maps the pattern names used with findAll to their
+// keywords. Patterns without an entry always run over the whole text.
+var referenceKeywords = map[string]*patternKeywords{
+	"article":      {lead: "article"},
+	"articleParen": {lead: "article"},
+	"articles":     {lead: "articles"},
+	"paragraph":    {lead: "paragraph"},
+	"point":        {lead: "point"},
+	"pointsRange":  {lead: "points"},
+	"chapter":      {lead: "chapter"},
+	"section":      {lead: "section"},
+	"annex":        {lead: "annex"},
+	"schedule":     {lead: "schedule"},
+	"relative":     {lead: "this", leadBoundary: true},
+
+	"usSection":         {lead: "section"},
+	"usSectionSubdiv":   {lead: "section"},
+	"usSubdivOfSection": {lead: "subdivision", requires: allOf("section")},
+	"usParagraphSubdiv": {lead: "paragraph", requires: allOf("subdivision", "section")},
+	"usSectionsRange":   {lead: "sections"},
+
+	"municipalCodeSection": {requires: allOf("code")},
+	"municipalSection":     {requires: [][]string{{"sec"}, {"§"}}},
+	"municipalChapter":     {lead: "chapter", leadBoundary: true},
+
+	"uscSectionOfTitle":      {lead: "section", requires: allOf("title")},
+	"uscSectionOfOtherTitle": {lead: "section", requires: allOf("title")},
+	"uscSectionSubsec":       {lead: "section", leadBoundary: true},
+	"uscSectionBare":         {lead: "section", leadBoundary: true},
+	"uscSubsection":          {lead: "subsection"},
+	"uscParagraphOfSubsec":   {lead: "paragraph", requires: allOf("subsection")},
+	"uscSubchapter":          {lead: "subchapter"},
+	"uscChapterArabic":       {lead: "chapter", leadBoundary: true},
+	"uscSectionOfAct":        {lead: "section", requires: allOf("act")},
+
+	"directive":    {lead: "directive"},
+	"regulation":   {lead: "regulation"},
+	"regulationNo": {lead: "regulation"},
+	"treaty":       {requires: [][]string{{"treaty"}, {"tfeu"}, {"teu"}}},
+	"decision":     {lead: "decision"},
+
+	"houseClauseOfRule": {lead: "clause", requires: allOf("rule")},
+	"houseRuleRef":      {lead: "rule", leadBoundary: true},
+
+	"usCode":    {requires: [][]string{{"sec"}, {"§"}}},
+	"cfr":       {requires: [][]string{{"cfr"}, {"c.fr"}, {"cf.r"}, {"c.f.r"}}},
+	"caTitle":   {lead: "section", requires: allOf("title")},
+	"publicLaw": {lead: "public", requires: allOf("law")},
+
+	"jeffersonsManual":  {requires: allOf("jefferson", "manual")},
+	"cannons":           {requires: allOf("cannon", "precedents")},
+	"cannonsCite":       {requires: allOf("cannon")},
+	"deschler":          {lead: "deschler", requires: allOf("precedents")},
+	"deschlerBrown":     {lead: "deschler-brown"},
+	"precedentsOfHouse": {lead: "precedents", requires: allOf("house")},
+	"hinds":             {requires: allOf("hinds", "precedents")},
+
+	"repealedWithEffect":  {lead: "repealed", requires: allOf("effect")},
+	"repealedBy":          {lead: "repealed"},
+	"asAmendedBy":         {requires: allOf("amended")},
+	"asAmended":           {requires: allOf("amended")},
+	"asInForceOn":         {requires: allOf("force")},
+	"inForceOn":           {requires: allOf("force")},
+	"enterIntoForce":      {lead: "enter", requires: allOf("force")},
+	"asOriginallyEnacted": {requires: allOf("enacted")},
+	"asItStoodOn":         {requires: allOf("stood")},
+	"consolidatedVersion": {lead: "consolidated"},
+}
+
+// foldSpecials are the non-ASCII runes that case-insensitive patterns
+// match as ASCII keyword letters.
+var foldSpecials = []string{"ſ", "K"}
+
+// keywordMatcher is an Aho-Corasick automaton over every keyword in
+// referenceKeywords plus foldSpecials.
+type keywordMatcher struct {
+	ids      map[string]int // keyword -> id
+	lengths  []int          // id -> keyword length in bytes
+	next     []int32        // state*256 + byte -> state
+	outputs  [][]int        // state -> ids of keywords ending there
+	specials []int          // ids of foldSpecials
+}
+
+var referenceKeywordMatcher = sync.OnceValue(func() *keywordMatcher {
+	var keywords []string
+	for _, k := range referenceKeywords {
+		if k.lead != "" {
+			keywords = append(keywords, k.lead)
+		}
+		for _, group := range k.requires {
+			keywords = append(keywords, group...)
+		}
+	}
+	return newKeywordMatcher(keywords, foldSpecials)
+})
+
+func newKeywordMatcher(keywords, specials []string) *keywordMatcher {
+	m := &keywordMatcher{ids: make(map[string]int)}
+	goto_ := []map[byte]int32{{}}
+	m.outputs = [][]int{nil}
+
+	add := func(keyword string) int {
+		if id, ok := m.ids[keyword]; ok {
+			return id
+		}
+		id := len(m.lengths)
+		m.ids[keyword] = id
+		m.lengths = append(m.lengths, len(keyword))
+		state := int32(0)
+		for i := 0; i < len(keyword); i++ {
+			next, ok := goto_[state][keyword[i]]
+			if !ok {
+				next = int32(len(goto_))
+				goto_ = append(goto_, map[byte]int32{})
+				m.outputs = append(m.outputs, nil)
+				goto_[state][keyword[i]] = next
+			}
+			state = next
+		}
+		m.outputs[state] = append(m.outputs[state], id)
+		return id
+	}
+	for _, keyword := range keywords {
+		add(keyword)
+	}
+	for _, special := range specials {
+		m.specials = append(m.specials, add(special))
+	}
+
+	// Breadth-first construction of the full transition table, folding
+	// failure links into it so matching is one lookup per byte.
+	m.next = make([]int32, len(goto_)*256)
+	fail := make([]int32, len(goto_))
+	var queue []int32
+	for c := 0; c < 256; c++ {
+		if next, ok := goto_[0][byte(c)]; ok {
+			m.next[c] = next
+			queue = append(queue, next)
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		m.outputs[state] = append(m.outputs[state], m.outputs[fail[state]]...)
+		for c := 0; c < 256; c++ {
+			if next, ok := goto_[state][byte(c)]; ok {
+				fail[next] = m.next[int(fail[state])*256+c]
+				m.next[int(state)*256+c] = next
+				queue = append(queue, next)
+			} else {
+				m.next[int(state)*256+c] = m.next[int(fail[state])*256+c]
+			}
+		}
+	}
+	return m
+}
+
+// textScan is the text of one article with the keyword occurrences found
+// in it. It is built once per article and read by every pattern.
+type textScan struct {
+	text string
+
+	// filtered is false when the pre-filter must not be used, either
+	// because it is disabled or because the text contains foldSpecials.
+	filtered bool
+
+	// occurrences holds the start offsets of each keyword, by id.
+	occurrences [][]int
+
+	// recording makes findAll keep every match in spans, so that
+	// references can be attributed to the pattern that found them.
+	recording bool
+	spans     []patternSpan
+}
+
+// patternSpan is the text matched by a named pattern.
+type patternSpan struct {
+	name       string
+	start, end int
+}
+
+// scan prepares text for extraction.
+func (e *ReferenceExtractor) scan(text string) *textScan {
+	if e.disablePrefilter {
+		return &textScan{text: text}
+	}
+	return referenceKeywordMatcher().scan(text)
+}
+
+func (m *keywordMatcher) scan(text string) *textScan {
+	s := &textScan{text: text, filtered: true, occurrences: make([][]int, len(m.lengths))}
+	state := int32(0)
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		state = m.next[int(state)*256+int(c)]
+		for _, id := range m.outputs[state] {
+			s.occurrences[id] = append(s.occurrences[id], i+1-m.lengths[id])
+		}
+	}
+	for _, id := range m.specials {
+		if len(s.occurrences[id]) > 0 {
+			s.filtered = false
+		}
+	}
+	return s
+}
+
+// record keeps the matches of a named pattern when recording.
+func (s *textScan) record(name string, matches [][]int) {
+	if !s.recording {
+		return
+	}
+	for _, match := range matches {
+		s.spans = append(s.spans, patternSpan{name: name, start: match[0], end: match[1]})
+	}
+}
+
+// attribute returns the name of the pattern that found ref: the recorded
+// match starting where ref starts, or else containing its start, whose end
+// is closest to ref's end. Earlier matches win ties.
+func (s *textScan) attribute(ref *Reference) string {
+	best, bestStart, bestDistance := "", false, 0
+	refEnd := ref.TextOffset + ref.TextLength
+	for _, span := range s.spans {
+		if span.start > ref.TextOffset || span.end <= ref.TextOffset {
+			continue
+		}
+		exactStart := span.start == ref.TextOffset
+		distance := span.end - refEnd
+		if distance < 0 {
+			distance = -distance
+		}
+		if best == "" || (exactStart && !bestStart) || (exactStart == bestStart && distance < bestDistance) {
+			best, bestStart, bestDistance = span.name, exactStart, distance
+		}
+	}
+	return best
+}
+
+// present reports whether keyword occurs in the scanned text.
+func (s *textScan) present(m *keywordMatcher, keyword string) bool {
+	return len(s.occurrences[m.ids[keyword]]) > 0
+}
+
+// matchAll returns the submatch indexes of every match of pattern, as
+// pattern.FindAllStringSubmatchIndex(s.text, -1) would. The second result
+// is false when the keywords ruled out a match without running pattern.
+func (s *textScan) matchAll(name string, pattern *regexp.Regexp) ([][]int, bool) {
+	keywords, ok := referenceKeywords[name]
+	if !s.filtered || !ok {
+		return pattern.FindAllStringSubmatchIndex(s.text, -1), true
+	}
+
+	m := referenceKeywordMatcher()
+	if keywords.lead != "" && !s.present(m, keywords.lead) {
+		return nil, false
+	}
+	if len(keywords.requires) > 0 {
+		satisfied := false
+		for _, group := range keywords.requires {
+			satisfied = true
+			for _, keyword := range group {
+				if !s.present(m, keyword) {
+					satisfied = false
+					break
+				}
+			}
+			if satisfied {
+				break
+			}
+		}
+		if !satisfied {
+			return nil, false
+		}
+	}
+	if keywords.lead == "" {
+		return pattern.FindAllStringSubmatchIndex(s.text, -1), true
+	}
+
+	// Try the anchored pattern at each lead occurrence in order, resuming
+	// after each match, which is how FindAll walks the text.
+	anchored := keywords.anchoredPattern(pattern)
+	var matches [][]int
+	end := 0
+	for _, start := range s.occurrences[m.ids[keywords.lead]] {
+		if start < end {
+			continue
+		}
+		if keywords.leadBoundary && start > 0 && isASCIIWordByte(s.text[start-1]) {
+			continue
+		}
+		match := anchored.FindStringSubmatchIndex(s.text[start:])
+		if match == nil {
+			continue
+		}
+		for i := range match {
+			if match[i] >= 0 {
+				match[i] += start
+			}
+		}
+		matches = append(matches, match)
+		end = match[1]
+	}
+	return matches, true
+}
+
+// isASCIIWordByte reports whether c is in \w, the class \b tests against.
+func isASCIIWordByte(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -164,6 +164,79 @@ func TestCrossReferenceDetection_ChapterRefs(t *testing.T) {
 	}
 }
 
+func TestCrossReferenceDetection_AnnexRefs(t *testing.T) {
+	extractor := NewReferenceExtractor()
+	refs := extractor.ExtractFromArticle(&Article{
+		Number: 6,
+		Text:   "The systems listed in Annex III and the conditions in Schedule 1 apply.",
+	})
+	lookup := NewReferenceLookup(refs)
+
+	annexRefs := lookup.GetByTarget(TargetAnnex)
+	if len(annexRefs) != 2 {
+		t.Fatalf("Expected 2 annex references, got %d", len(annexRefs))
+	}
+	want := map[string]string{"Annex III": "III", "Schedule 1": "1"}
+	for _, ref := range annexRefs {
+		if want[ref.Identifier] != ref.AnnexNum {
+			t.Errorf("Unexpected annex reference %q (number %q)", ref.Identifier, ref.AnnexNum)
+		}
+	}
+}
+
+func TestCrossReferenceDetection_QualifiedRefs(t *testing.T) {
+	extractor := NewReferenceExtractor()
+	refs := extractor.ExtractFromArticle(&Article{
+		Number: 13,
+		Text: "Where processing is based on point (a) of Article 6(1), points (b) and (c) of paragraph 2, " +
+			"or paragraph 1 of this Article, the obligations of this Chapter and this Article apply. " +
+			"The rules in paragraph 4 of Article 6 of Directive 95/46/EC no longer apply.",
+	})
+
+	type want struct {
+		target    ReferenceTarget
+		raw       string
+		article   int
+		paragraph int
+	}
+	wants := []want{
+		{TargetPoint, "point (a) of Article 6(1)", 6, 1},
+		{TargetPoint, "points (b) and (c) of paragraph 2", 0, 2},
+		{TargetParagraph, "paragraph 1 of this Article", 0, 1},
+		{TargetChapter, "this Chapter", 0, 0},
+		{TargetArticle, "this Article", 0, 0},
+	}
+	for _, w := range wants {
+		found := false
+		for _, ref := range refs {
+			if ref.Target == w.target && ref.RawText == w.raw {
+				found = true
+				if ref.ArticleNum != w.article || ref.ParagraphNum != w.paragraph {
+					t.Errorf("%q: article %d paragraph %d, want %d and %d", w.raw, ref.ArticleNum, ref.ParagraphNum, w.article, w.paragraph)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("missing reference %q", w.raw)
+		}
+	}
+
+	relative := 0
+	for _, ref := range refs {
+		if ref.SubRef == "this" {
+			relative++
+		}
+		if ref.Target == TargetParagraph && ref.ParagraphNum == 4 {
+			t.Errorf("paragraph of another act extracted as internal: %q", ref.RawText)
+		}
+	}
+	// "of this Article" qualifies paragraph 1; only the two standalone
+	// occurrences are references of their own
+	if relative != 2 {
+		t.Errorf("got %d relative references, want 2", relative)
+	}
+}
+
 func TestCrossReferenceDetection_ExternalDirectives(t *testing.T) {
 	f := loadGDPRText(t)
 	defer f.Close()
@@ -106,7 +106,7 @@ func TestExtractUSSectionRefs(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			refs := extractor.extractUSSectionRefs(tc.text, 1)
+			refs := extractor.extractUSSectionRefs(extractor.scan(tc.text), 1)
 
 			if len(refs) != len(tc.expected) {
 				t.Errorf("expected %d refs, got %d", len(tc.expected), len(refs))
@@ -205,7 +205,7 @@ func TestExtractUSExternalRefs(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			refs := extractor.extractUSExternalRefs(tc.text, 1)
+			refs := extractor.extractUSExternalRefs(extractor.scan(tc.text), 1)
 
 			if len(refs) != len(tc.expected) {
 				t.Errorf("expected %d refs, got %d", len(tc.expected), len(refs))
@@ -238,7 +238,7 @@ func TestUSSectionPatternDoesNotMatchSimpleSections(t *testing.T) {
 
 	// EU-style "Section 1" should NOT match US-style pattern
 	text := "Section 1 provides definitions"
-	refs := extractor.extractUSSectionRefs(text, 1)
+	refs := extractor.extractUSSectionRefs(extractor.scan(text), 1)
 
 	if len(refs) != 0 {
 		t.Errorf("US section pattern should not match simple 'Section 1', got %d refs", len(refs))
@@ -248,12 +248,87 @@ func TestUSSectionPatternDoesNotMatchSimpleSections(t *testing.T) {
 	}
 
 	// But EU-style should still work
-	refs = extractor.extractSectionRefs(text, 1)
+	refs = extractor.extractSectionRefs(extractor.scan(text), 1)
 	if len(refs) != 1 {
 		t.Errorf("EU section pattern should match 'Section 1', got %d refs", len(refs))
 	}
 }
 
+func TestExtractMunicipalRefs(t *testing.T) {
+	extractor := NewReferenceExtractor()
+
+	testCases := []struct {
+		name       string
+		text       string
+		rawText    string
+		identifier string
+		target     ReferenceTarget
+		chapterNum string
+		subsection string
+	}{
+		{"city code section", "as defined in City Code § 8.04.020", "City Code § 8.04.020", "City Code § 8.04.020", TargetSection, "8.04", ""},
+		{"municipal code with subsection", "under Municipal Code Section 23.47A.004(b)", "Municipal Code Section 23.47A.004(b)", "Municipal Code § 23.47A.004(b)", TargetSection, "23.47A", "b"},
+		{"county code without marker", "County Code 2.12.030 applies", "County Code 2.12.030", "County Code § 2.12.030", TargetSection, "2.12", ""},
+		{"abbreviated section", "See Sec. 8.04.020(a).", "Sec. 8.04.020(a)", "§ 8.04.020(a)", TargetSection, "8.04", "a"},
+		{"section symbol", "pursuant to § 12.03.010", "§ 12.03.010", "§ 12.03.010", TargetSection, "12.03", ""},
+		{"chapter", "the licenses in Chapter 8.04 expire", "Chapter 8.04", "Chapter 8.04", TargetChapter, "8.04", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			refs := extractor.extractMunicipalRefs(extractor.scan(tc.text), 1)
+			if len(refs) != 1 {
+				t.Fatalf("expected 1 reference, got %d: %+v", len(refs), refs)
+			}
+			ref := refs[0]
+			if ref.RawText != tc.rawText {
+				t.Errorf("RawText = %q, want %q", ref.RawText, tc.rawText)
+			}
+			if ref.Identifier != tc.identifier {
+				t.Errorf("Identifier = %q, want %q", ref.Identifier, tc.identifier)
+			}
+			if ref.Target != tc.target {
+				t.Errorf("Target = %q, want %q", ref.Target, tc.target)
+			}
+			if ref.ChapterNum != tc.chapterNum {
+				t.Errorf("ChapterNum = %q, want %q", ref.ChapterNum, tc.chapterNum)
+			}
+			if ref.PointLetter != tc.subsection {
+				t.Errorf("PointLetter = %q, want %q", ref.PointLetter, tc.subsection)
+			}
+		})
+	}
+}
+
+func TestUSSectionRefsKeepDottedSections(t *testing.T) {
+	extractor := NewReferenceExtractor()
+
+	text := "Section 1798.81.5, subdivision (h) of Section 1798.81.5, and Sections 1798.81.5 to 1798.82 apply, as does Section 1798.100."
+	refs := extractor.extractUSSectionRefs(extractor.scan(text), 1)
+	want := map[string]string{
+		"Section 1798.81.5":                   "Section 1798.81.5",
+		"subdivision (h) of Section 1798.81.5":"Section 1798.81.5(h)",
+		"Sections 1798.81.5 to 1798.82":       "Sections 1798.81.5-1798.82",
+		"Section 1798.100":                    "Section 1798.100",
+	}
+	if len(refs) != len(want) {
+		t.Fatalf("got %d references, want %d: %+v", len(refs), len(want), refs)
+	}
+	for _, ref := range refs {
+		if want[ref.RawText] != ref.Identifier {
+			t.Errorf("%q: Identifier = %q, want %q", ref.RawText, ref.Identifier, want[ref.RawText])
+		}
+		if ref.RawText != "Section 1798.100" && ref.SectionNum != 1798081 {
+			t.Errorf("%q: SectionNum = %d, want 1798081", ref.RawText, ref.SectionNum)
+		}
+	}
+
+	refs = extractor.extractMunicipalRefs(extractor.scan("Section 8.04.020 and subdivision (a) of Section 8.04.030 apply."), 1)
+	if len(refs) != 2 || refs[0].SectionStr != "8.04.020" || refs[1].SectionStr != "8.04.030" {
+		t.Errorf("expected municipal sections 8.04.020 and 8.04.030, got %+v", refs)
+	}
+}
+
 func TestCCPADocumentReferences(t *testing.T) {
 	// Test with actual CCPA-style text
 	text := `The consumer has the right to request that a business that collects
@@ -769,7 +844,7 @@ func TestExtractUSCSectionRefs(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Source article 42 for relative references
-			refs := extractor.extractUSCSectionRefs(tc.text, 42, nil)
+			refs := extractor.extractUSCSectionRefs(extractor.scan(tc.text), 42, nil)
 
 			if len(refs) != len(tc.expected) {
 				t.Errorf("expected %d refs, got %d", len(tc.expected), len(refs))
@@ -813,7 +888,7 @@ func TestUSCPatternDoesNotMatchCaliforniaStyle(t *testing.T) {
 
 	// California-style "Section 1798.100" should NOT be matched by USC patterns
 	text := "Section 1798.100 and Section 1798.140(o) apply"
-	uscRefs := extractor.extractUSCSectionRefs(text, 1, nil)
+	uscRefs := extractor.extractUSCSectionRefs(extractor.scan(text), 1, nil)
 
 	// USC bare pattern requires letter suffix — dotted numbers should not match
 	for _, ref := range uscRefs {
@@ -823,7 +898,7 @@ func TestUSCPatternDoesNotMatchCaliforniaStyle(t *testing.T) {
 	}
 
 	// California pattern should still work
-	calRefs := extractor.extractUSSectionRefs(text, 1)
+	calRefs := extractor.extractUSSectionRefs(extractor.scan(text), 1)
 	if len(calRefs) < 2 {
 		t.Errorf("California pattern should match 'Section 1798.100' and 'Section 1798.140(o)', got %d refs", len(calRefs))
 	}
@@ -937,7 +1012,7 @@ func TestUSCPatternCaseInsensitive(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			refs := extractor.extractUSCSectionRefs(tc.text, 1, nil)
+			refs := extractor.extractUSCSectionRefs(extractor.scan(tc.text), 1, nil)
 			if len(refs) == 0 {
 				t.Errorf("expected at least 1 reference for %q, got 0", tc.text)
 			}
@@ -954,7 +1029,7 @@ Article 25 and Article 32 of this Regulation. References to Chapter III and Chap
 shall be interpreted consistently with the principles set forth in Section 1.`
 
 	extractor := NewReferenceExtractor()
-	uscRefs := extractor.extractUSCSectionRefs(gdprText, 5, nil)
+	uscRefs := extractor.extractUSCSectionRefs(extractor.scan(gdprText), 5, nil)
 
 	// "Section 1" should not match bare USC pattern (no letter suffix)
 	for _, ref := range uscRefs {
@@ -49,6 +49,9 @@ type ResolvedReference struct {
 	// Context used for resolution
 	ContextArticle  int    `json:"context_article,omitempty"`
 	ContextChapter  string `json:"context_chapter,omitempty"`
+
+	// Manual is true when the target came from a user-supplied mapping.
+	Manual bool `json:"manual,omitempty"`
 }
 
 // ReferenceResolver resolves detected references to provision URIs.
@@ -62,6 +65,7 @@ type ReferenceResolver struct {
 	sections   map[string]bool      // Section identifiers that exist
 	paragraphs map[string]bool      // Paragraph identifiers (Art:Para) that exist
 	points     map[string]bool      // Point identifiers (Art:Para:Point) that exist
+	annexes    map[string]bool      // Annex and schedule numbers that exist
 
 	// Article to chapter mapping for context resolution
 	articleChapter map[int]string
@@ -70,6 +74,10 @@ type ReferenceResolver struct {
 	articlesByID       map[string]bool
 	articleChapterByID map[string]string
 	sectionsByID       map[string]bool // key: "chapterNum:sectionID"
+
+	// Manual overrides from mappings.yaml, keyed by normalized raw text
+	manualMappings map[string]ReferenceMapping
+	manualHits     map[string]int
 }
 
 // NewReferenceResolver creates a new resolver.
@@ -85,11 +93,68 @@ func NewReferenceResolver(baseURI, regID string) *ReferenceResolver {
 		sections:           make(map[string]bool),
 		paragraphs:         make(map[string]bool),
 		points:             make(map[string]bool),
+		annexes:            make(map[string]bool),
 		articleChapter:     make(map[int]string),
 		articlesByID:       make(map[string]bool),
 		articleChapterByID: make(map[string]string),
 		sectionsByID:       make(map[string]bool),
+		manualMappings:     make(map[string]ReferenceMapping),
+		manualHits:         make(map[string]int),
+	}
+}
+
+// SetManualMappings installs user-supplied overrides that map raw reference
+// strings to target URIs. Overrides take priority over all other resolution.
+// Relative targets are expanded against the resolver's base URI and
+// regulation ID.
+func (r *ReferenceResolver) SetManualMappings(mappings []ReferenceMapping) {
+	r.manualMappings = make(map[string]ReferenceMapping, len(mappings))
+	r.manualHits = make(map[string]int, len(mappings))
+	for _, mapping := range mappings {
+		r.manualMappings[normalizeRawReference(mapping.Raw)] = mapping
+	}
+}
+
+// UnusedManualMappings returns the raw strings of manual mappings that did
+// not match any reference, which usually indicates a typo in mappings.yaml.
+func (r *ReferenceResolver) UnusedManualMappings() []string {
+	var unused []string
+	for key, mapping := range r.manualMappings {
+		if r.manualHits[key] == 0 {
+			unused = append(unused, mapping.Raw)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
+
+// resolveManualMapping applies a manual override, if one matches the raw text.
+func (r *ReferenceResolver) resolveManualMapping(ref *Reference, result *ResolvedReference) (*ResolvedReference, bool) {
+	if len(r.manualMappings) == 0 {
+		return nil, false
+	}
+
+	key := normalizeRawReference(ref.RawText)
+	mapping, ok := r.manualMappings[key]
+	if !ok {
+		return nil, false
+	}
+	r.manualHits[key]++
+
+	target := strings.TrimSpace(mapping.Target)
+	if !strings.Contains(target, "://") {
+		target = r.baseURI + r.regID + ":" + target
+	}
+
+	result.Status = ResolutionResolved
+	result.Confidence = ConfidenceHigh
+	result.TargetURI = target
+	result.Manual = true
+	result.Reason = "Manual mapping"
+	if mapping.Note != "" {
+		result.Reason += ": " + mapping.Note
 	}
+	return result, true
 }
 
 // IndexDocument indexes all provisions in a document for resolution.
@@ -121,6 +186,10 @@ func (r *ReferenceResolver) IndexDocument(doc *Document) {
 			r.indexArticle(article, chapter.Number)
 		}
 	}
+
+	for _, annex := range doc.Annexes {
+		r.annexes[annex.Number] = true
+	}
 }
 
 // indexArticle indexes an article and its sub-elements.
@@ -166,6 +235,11 @@ func (r *ReferenceResolver) Resolve(ref *Reference) *ResolvedReference {
 		result.ContextChapter = chap
 	}
 
+	// Manual mappings take priority over every other strategy
+	if manual, ok := r.resolveManualMapping(ref, result); ok {
+		return manual
+	}
+
 	// Handle external references
 	if ref.Type == ReferenceTypeExternal {
 		result.Status = ResolutionExternal
@@ -183,6 +257,11 @@ func (r *ReferenceResolver) Resolve(ref *Reference) *ResolvedReference {
 		}
 	}
 
+	// "this Article" and "this Chapter"
+	if ref.SubRef == "this" {
+		return r.resolveContainingReference(ref, result)
+	}
+
 	// Handle by target type
 	switch ref.Target {
 	case TargetArticle:
@@ -195,6 +274,8 @@ func (r *ReferenceResolver) Resolve(ref *Reference) *ResolvedReference {
 		return r.resolveChapterReference(ref, result)
 	case TargetSection:
 		return r.resolveSectionReference(ref, result)
+	case TargetAnnex:
+		return r.resolveAnnexReference(ref, result)
 	default:
 		result.Status = ResolutionNotFound
 		result.Confidence = ConfidenceNone
@@ -452,6 +533,21 @@ func (r *ReferenceResolver) resolveChapterReference(ref *Reference, result *Reso
 	return result
 }
 
+// resolveAnnexReference resolves an annex or schedule reference.
+func (r *ReferenceResolver) resolveAnnexReference(ref *Reference, result *ResolvedReference) *ResolvedReference {
+	if r.annexes[ref.AnnexNum] {
+		result.Status = ResolutionResolved
+		result.Confidence = ConfidenceHigh
+		result.TargetURI = r.annexURI(ref.AnnexNum)
+		result.Reason = "Resolved to annex"
+	} else {
+		result.Status = ResolutionNotFound
+		result.Confidence = ConfidenceNone
+		result.Reason = fmt.Sprintf("%s does not exist", ref.Identifier)
+	}
+	return result
+}
+
 // resolveSectionReference resolves a section reference.
 func (r *ReferenceResolver) resolveSectionReference(ref *Reference, result *ResolvedReference) *ResolvedReference {
 	// US-style section references (e.g., Section 1798.100) or USC-style (e.g., section 1396a)
@@ -669,6 +765,28 @@ func (r *ReferenceResolver) resolveRelativeReference(ref *Reference, result *Res
 	}
 }
 
+// resolveContainingReference resolves "this Article" and "this Chapter" to
+// the article or chapter containing the reference.
+func (r *ReferenceResolver) resolveContainingReference(ref *Reference, result *ResolvedReference) *ResolvedReference {
+	switch {
+	case ref.Target == TargetArticle && r.articles[ref.SourceArticle]:
+		result.Status = ResolutionSelfRef
+		result.Confidence = ConfidenceHigh
+		result.TargetURI = r.articleURI(ref.SourceArticle)
+		result.Reason = "Resolved to containing article"
+	case ref.Target == TargetChapter && r.chapters[result.ContextChapter]:
+		result.Status = ResolutionResolved
+		result.Confidence = ConfidenceHigh
+		result.TargetURI = r.chapterURI(result.ContextChapter)
+		result.Reason = "Resolved to containing chapter"
+	default:
+		result.Status = ResolutionNotFound
+		result.Confidence = ConfidenceNone
+		result.Reason = fmt.Sprintf("No containing provision for %q in article %d", ref.RawText, ref.SourceArticle)
+	}
+	return result
+}
+
 // URI builders (must match GraphBuilder)
 
 func (r *ReferenceResolver) articleURI(number int) string {
@@ -687,6 +805,10 @@ func (r *ReferenceResolver) chapterURI(number string) string {
 	return r.baseURI + r.regID + ":Chapter" + number
 }
 
+func (r *ReferenceResolver) annexURI(number string) string {
+	return r.baseURI + r.regID + ":Annex" + number
+}
+
 func (r *ReferenceResolver) sectionURI(chapterNum string, sectionNum int) string {
 	return r.baseURI + r.regID + ":Chapter" + chapterNum + ":Section" + itoa(sectionNum)
 }
@@ -763,13 +885,16 @@ type ResolutionReport struct {
 	SelfRef    int `json:"self_ref"`
 	RangeRef   int `json:"range_ref"`
 
+	// Resolutions that came from mappings.yaml overrides (counted in Resolved)
+	ManualMappings int `json:"manual_mappings"`
+
 	// Confidence distribution
 	HighConfidence   int `json:"high_confidence"`
 	MediumConfidence int `json:"medium_confidence"`
 	LowConfidence    int `json:"low_confidence"`
 
 	// Calculated metrics
-	ResolutionRate   float64 `json:"resolution_rate"`   // (Resolved + Partial + RangeRef) / (Total - External)
+	ResolutionRate   float64 `json:"resolution_rate"`   // (Resolved + Partial + SelfRef + RangeRef) / (Total - External)
 	ConfidenceRate   float64 `json:"confidence_rate"`   // High / Total
 
 	// Details for reporting
@@ -805,6 +930,10 @@ func GenerateReport(resolved []*ResolvedReference) *ResolutionReport {
 			report.RangeRef++
 		}
 
+		if ref.Manual {
+			report.ManualMappings++
+		}
+
 		// Count confidence
 		switch {
 		case ref.Confidence >= ConfidenceHigh:
@@ -823,7 +952,7 @@ func GenerateReport(resolved []*ResolvedReference) *ResolutionReport {
 
 	// Calculate rates
 	if internalCount > 0 {
-		successfulResolutions := report.Resolved + report.Partial + report.RangeRef
+		successfulResolutions := report.Resolved + report.Partial + report.SelfRef + report.RangeRef
 		report.ResolutionRate = float64(successfulResolutions) / float64(internalCount)
 	}
 	if report.TotalReferences > 0 {
@@ -855,6 +984,10 @@ func (r *ResolutionReport) String() string {
 	sb.WriteString(fmt.Sprintf("  Not found:  %d\n", r.NotFound))
 	sb.WriteString(fmt.Sprintf("  External:   %d\n\n", r.External))
 
+	if r.ManualMappings > 0 {
+		sb.WriteString(fmt.Sprintf("Manual mappings applied: %d\n\n", r.ManualMappings))
+	}
+
 	sb.WriteString("Confidence Distribution:\n")
 	sb.WriteString(fmt.Sprintf("  High:   %d\n", r.HighConfidence))
 	sb.WriteString(fmt.Sprintf("  Medium: %d\n", r.MediumConfidence))
@@ -270,6 +270,67 @@ func TestReferenceResolver_ResolveChapter(t *testing.T) {
 	}
 }
 
+func TestReferenceResolver_ResolveAnnex(t *testing.T) {
+	resolver := NewReferenceResolver("https://regula.dev/", "AIA")
+	resolver.IndexDocument(&Document{
+		Annexes: []*Annex{{Kind: AnnexKindAnnex, Number: "III", Title: "High-risk AI systems"}},
+	})
+
+	ref := &Reference{
+		Type:          ReferenceTypeInternal,
+		Target:        TargetAnnex,
+		RawText:       "Annex III",
+		Identifier:    "Annex III",
+		AnnexNum:      "III",
+		SourceArticle: 6,
+	}
+	result := resolver.Resolve(ref)
+	if result.Status != ResolutionResolved {
+		t.Errorf("Status = %v, want %v", result.Status, ResolutionResolved)
+	}
+	expectedURI := "https://regula.dev/AIA:AnnexIII"
+	if result.TargetURI != expectedURI {
+		t.Errorf("TargetURI = %q, want %q", result.TargetURI, expectedURI)
+	}
+
+	ref.AnnexNum, ref.Identifier = "IX", "Annex IX"
+	if result := resolver.Resolve(ref); result.Status != ResolutionNotFound {
+		t.Errorf("Status for missing annex = %v, want %v", result.Status, ResolutionNotFound)
+	}
+}
+
+func TestReferenceResolver_ResolveContainingProvision(t *testing.T) {
+	resolver := NewReferenceResolver("https://regula.dev/", "GDPR")
+	resolver.IndexDocument(&Document{
+		Chapters: []*Chapter{{Number: "III", Articles: []*Article{{Number: 12}, {Number: 13}}}},
+	})
+
+	tests := []struct {
+		name   string
+		ref    *Reference
+		status ResolutionStatus
+		uri    string
+	}{
+		{"this Article", &Reference{Target: TargetArticle, SubRef: "this", RawText: "this Article", SourceArticle: 13}, ResolutionSelfRef, "https://regula.dev/GDPR:Art13"},
+		{"this Chapter", &Reference{Target: TargetChapter, SubRef: "this", RawText: "this Chapter", SourceArticle: 13}, ResolutionResolved, "https://regula.dev/GDPR:ChapterIII"},
+		{"outside any chapter", &Reference{Target: TargetChapter, SubRef: "this", RawText: "this Chapter", SourceArticle: 99}, ResolutionNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.ref.Type = ReferenceTypeInternal
+			result := resolver.Resolve(tt.ref)
+			if result.Status != tt.status || result.TargetURI != tt.uri {
+				t.Errorf("got %v %q, want %v %q", result.Status, result.TargetURI, tt.status, tt.uri)
+			}
+		})
+	}
+
+	report := GenerateReport(resolver.ResolveAll([]*Reference{tests[0].ref, tests[1].ref}))
+	if report.ResolutionRate != 1 {
+		t.Errorf("ResolutionRate = %v, want 1", report.ResolutionRate)
+	}
+}
+
 func TestReferenceResolver_ResolveExternalReference(t *testing.T) {
 	resolver := NewReferenceResolver("https://regula.dev/", "GDPR")
 
@@ -456,6 +517,35 @@ func TestGDPRReferenceResolution(t *testing.T) {
 	}
 }
 
+func TestGDPRReferenceResolution_PointNodes(t *testing.T) {
+	f := loadGDPRText(t)
+	defer f.Close()
+
+	doc, err := NewParser().Parse(f)
+	if err != nil {
+		t.Fatalf("Failed to parse GDPR: %v", err)
+	}
+
+	resolver := NewReferenceResolver("https://regula.dev/regulations/", "GDPR")
+	resolver.IndexDocument(doc)
+
+	result := resolver.Resolve(&Reference{
+		Type:          ReferenceTypeInternal,
+		Target:        TargetArticle,
+		RawText:       "Article 6(1)(a)",
+		ArticleNum:    6,
+		ParagraphNum:  1,
+		PointLetter:   "a",
+		SourceArticle: 7,
+	})
+	if result.Status != ResolutionResolved {
+		t.Errorf("Status = %v (%s), want %v", result.Status, result.Reason, ResolutionResolved)
+	}
+	if want := "https://regula.dev/regulations/GDPR:Art6(1)(a)"; result.TargetURI != want {
+		t.Errorf("TargetURI = %q, want %q", result.TargetURI, want)
+	}
+}
+
 func TestReferenceResolver_ResolveSection(t *testing.T) {
 	resolver := NewReferenceResolver("https://regula.dev/", "GDPR")
 	resolver.chapters["III"] = true
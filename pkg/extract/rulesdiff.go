@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // ChangeType represents the type of change between two versions.
@@ -168,7 +170,7 @@ func (d *RulesDiffer) Compare(baseVersion, targetVersion string) *RulesDiffRepor
 	for rule := range allRules {
 		rules = append(rules, rule)
 	}
-	sortRomanNumerals(rules)
+	textutil.SortRomanNumerals(rules)
 
 	// Compare each rule
 	for _, rule := range rules {
@@ -419,34 +421,6 @@ func compareClauseNumbers(a, b string) bool {
 	return a < b
 }
 
-// sortRomanNumerals sorts Roman numerals in numeric order.
-func sortRomanNumerals(numerals []string) {
-	sort.Slice(numerals, func(i, j int) bool {
-		return romanToInt(numerals[i]) < romanToInt(numerals[j])
-	})
-}
-
-// romanToInt converts a Roman numeral to an integer.
-func romanToInt(s string) int {
-	values := map[rune]int{
-		'I': 1, 'V': 5, 'X': 10, 'L': 50,
-		'C': 100, 'D': 500, 'M': 1000,
-	}
-
-	result := 0
-	prev := 0
-	for i := len(s) - 1; i >= 0; i-- {
-		val := values[rune(s[i])]
-		if val < prev {
-			result -= val
-		} else {
-			result += val
-		}
-		prev = val
-	}
-	return result
-}
-
 // String returns a formatted string representation of the diff report.
 func (r *RulesDiffReport) String() string {
 	var sb strings.Builder
@@ -504,10 +478,7 @@ func (r *RulesDiffReport) String() string {
 
 // truncate shortens text to the specified length.
 func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
+	return textutil.Truncate(s, maxLen)
 }
 
 // ToJSON returns the report as JSON.
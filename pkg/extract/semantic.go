@@ -3,6 +3,8 @@ package extract
 import (
 	"regexp"
 	"strings"
+
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // SemanticType indicates the type of semantic annotation.
@@ -122,12 +124,17 @@ type SemanticAnnotation struct {
 	// For obligations
 	ObligationType ObligationType `json:"obligation_type,omitempty"`
 	DutyBearer     EntityType     `json:"duty_bearer,omitempty"`
+	Recurrence     *Recurrence    `json:"recurrence,omitempty"`
 
 	// Common fields
 	MatchedText    string  `json:"matched_text"`
 	MatchedPattern string  `json:"matched_pattern"`
 	Confidence     float64 `json:"confidence"`
 	Context        string  `json:"context,omitempty"` // Surrounding text
+
+	// ExtractionMethod is set for annotations that did not come from the
+	// pattern extractor (see ExtractionMethodLLM).
+	ExtractionMethod string `json:"extraction_method,omitempty"`
 }
 
 // SemanticExtractor extracts rights and obligations from regulatory text.
@@ -140,6 +147,9 @@ type SemanticExtractor struct {
 
 	// Entity detection patterns
 	entityPatterns map[EntityType]*regexp.Regexp
+
+	// Recurrence overrides from a recurrence annotation file
+	recurrenceAnnotations []RecurrenceAnnotation
 }
 
 // semanticPattern represents a pattern for detecting semantic content.
@@ -749,13 +759,25 @@ func (e *SemanticExtractor) initEntityPatterns() {
 	}
 }
 
+// SetRecurrenceAnnotations registers recurrence overrides that
+// ExtractFromDocument applies to matching obligations.
+func (e *SemanticExtractor) SetRecurrenceAnnotations(annotations []RecurrenceAnnotation) {
+	e.recurrenceAnnotations = annotations
+}
+
 // ExtractFromDocument extracts all semantic annotations from a document.
 func (e *SemanticExtractor) ExtractFromDocument(doc *Document) []*SemanticAnnotation {
 	var annotations []*SemanticAnnotation
+	articleNumbers := make(map[int]bool)
 
 	for _, article := range doc.AllArticles() {
 		articleAnnotations := e.ExtractFromArticle(article)
 		annotations = append(annotations, articleAnnotations...)
+		articleNumbers[article.Number] = true
+	}
+
+	if len(e.recurrenceAnnotations) > 0 {
+		annotations = applyRecurrenceAnnotations(annotations, e.recurrenceAnnotations, articleNumbers)
 	}
 
 	return annotations
@@ -775,8 +797,8 @@ func (e *SemanticExtractor) ExtractFromArticle(article *Article) []*SemanticAnno
 		annotations = append(annotations, titleAnnotations...)
 	}
 
-	// Extract from article text
-	if article.Text != "" {
+	// Extract from article text, unless it is split into paragraphs below
+	if article.Text != "" && len(article.Paragraphs) == 0 {
 		articleAnnotations := e.extractFromText(article.Text, article.Number, 0, "")
 		annotations = append(annotations, articleAnnotations...)
 	}
@@ -940,13 +962,49 @@ func (e *SemanticExtractor) extractFromText(text string, articleNum, paraNum int
 				annotation.DutyBearer = e.identifyEntity(text)
 			}
 
+			if annotation.Type == SemanticObligation {
+				annotation.Recurrence = ExtractRecurrence(sentenceAround(text, loc[0], loc[1]))
+			}
+
 			annotations = append(annotations, annotation)
 		}
 	}
 
+	// A recurring duty the obligation patterns missed, such as "shall draw
+	// up an annual report", is added as a generic obligation so it can be
+	// scheduled.
+	if !hasRecurringObligation(annotations) {
+		if loc, recurrence := findRecurringDuty(text); recurrence != nil {
+			annotations = append(annotations, &SemanticAnnotation{
+				Type:           SemanticObligation,
+				ArticleNum:     articleNum,
+				ParagraphNum:   paraNum,
+				PointLetter:    pointLetter,
+				ObligationType: ObligationGeneric,
+				DutyBearer:     e.identifyEntity(sentenceAround(text, loc[0], loc[1])),
+				Recurrence:     recurrence,
+				MatchedText:    text[loc[0]:loc[1]],
+				MatchedPattern: "recurring duty",
+				Confidence:     0.7,
+				Context:        extractContext(text, loc[0], loc[1], 50),
+			})
+		}
+	}
+
 	return annotations
 }
 
+// hasRecurringObligation reports whether any of annotations is an
+// obligation with a recurrence.
+func hasRecurringObligation(annotations []*SemanticAnnotation) bool {
+	for _, annotation := range annotations {
+		if annotation.Type == SemanticObligation && annotation.Recurrence != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // identifyEntity tries to identify the primary entity mentioned in text.
 func (e *SemanticExtractor) identifyEntity(text string) EntityType {
 	// Check for each entity type in order of specificity
@@ -982,14 +1040,7 @@ func (e *SemanticExtractor) identifyEntity(text string) EntityType {
 
 // extractContext extracts surrounding context for a match.
 func extractContext(text string, start, end, contextLen int) string {
-	contextStart := start - contextLen
-	if contextStart < 0 {
-		contextStart = 0
-	}
-	contextEnd := end + contextLen
-	if contextEnd > len(text) {
-		contextEnd = len(text)
-	}
+	contextStart, contextEnd := textutil.ContextBounds(text, start, end, contextLen)
 
 	context := text[contextStart:contextEnd]
 
@@ -1015,6 +1066,7 @@ type SemanticStats struct {
 	Obligations             int                    `json:"obligations"`
 	Prohibitions            int                    `json:"prohibitions"`
 	Permissions             int                    `json:"permissions"`
+	RecurringObligations    int                    `json:"recurring_obligations"`
 	ByRightType             map[RightType]int      `json:"by_right_type"`
 	ByObligationType        map[ObligationType]int `json:"by_obligation_type"`
 	ByBeneficiary           map[EntityType]int     `json:"by_beneficiary"`
@@ -1051,6 +1103,9 @@ func CalculateSemanticStats(annotations []*SemanticAnnotation) *SemanticStats {
 			stats.ByObligationType[ann.ObligationType]++
 			stats.ByDutyBearer[ann.DutyBearer]++
 			articlesWithObligations[ann.ArticleNum] = true
+			if ann.Recurrence != nil {
+				stats.RecurringObligations++
+			}
 		case SemanticProhibition:
 			stats.Prohibitions++
 			stats.ByObligationType[ann.ObligationType]++
@@ -68,6 +68,22 @@ func (e *TermUsageExtractor) buildTermPattern(term string) *regexp.Regexp {
 	return re
 }
 
+// SetAbbreviations makes the short form of each abbreviation that expands
+// to a defined term count as a use of that term. Short forms are matched
+// case-sensitively, so "Board" does not match "board".
+func (e *TermUsageExtractor) SetAbbreviations(abbreviations []*Abbreviation) {
+	for _, abbreviation := range abbreviations {
+		def := e.definitions.GetByNormalizedTerm(abbreviation.Expansion)
+		if def == nil || e.patterns[def.NormalizedTerm] == nil {
+			continue
+		}
+		pattern := `(?:` + e.patterns[def.NormalizedTerm].String() + `)|\b` + regexp.QuoteMeta(abbreviation.ShortForm) + `\b`
+		if re, err := regexp.Compile(pattern); err == nil {
+			e.patterns[def.NormalizedTerm] = re
+		}
+	}
+}
+
 // ExtractFromDocument finds all term usages in a document.
 func (e *TermUsageExtractor) ExtractFromDocument(doc *Document) []*TermUsage {
 	if doc == nil {
@@ -18,6 +18,9 @@ const DefaultFetchRateLimit = 2 * time.Second
 // DefaultFetchTimeout is the default per-request timeout.
 const DefaultFetchTimeout = 30 * time.Second
 
+// DefaultFetchUserAgent is the default user agent matched against robots.txt rules.
+const DefaultFetchUserAgent = "regula-fetch/1.0 (+https://regula.dev)"
+
 // DefaultCacheTTL is the default time-to-live for cached fetch results.
 const DefaultCacheTTL = 24 * time.Hour
 
@@ -44,6 +47,13 @@ type FetchConfig struct {
 
 	// DryRun when true, plans what would be fetched without making network calls.
 	DryRun bool
+
+	// RespectRobots when true, skips references whose site's robots.txt
+	// disallows UserAgent and waits out the site's Crawl-delay between requests.
+	RespectRobots bool
+
+	// UserAgent identifies the fetcher to robots.txt rules.
+	UserAgent string
 }
 
 // DefaultFetchConfig returns a FetchConfig with sensible defaults.
@@ -53,6 +63,7 @@ func DefaultFetchConfig() FetchConfig {
 		MaxDocuments: DefaultMaxDocuments,
 		RateLimit:    DefaultFetchRateLimit,
 		Timeout:      DefaultFetchTimeout,
+		UserAgent:    DefaultFetchUserAgent,
 	}
 }
 
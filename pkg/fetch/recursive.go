@@ -1,6 +1,7 @@
 package fetch
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -16,6 +17,14 @@ type URIValidator interface {
 	ValidateURI(uri string) (*eurlex.ValidationResult, error)
 }
 
+// ContextURIValidator is a URIValidator that supports cancellation. When the
+// fetcher's validator implements it, in-flight validations are aborted as
+// soon as the fetch context is done.
+type ContextURIValidator interface {
+	URIValidator
+	ValidateURIWithContext(ctx context.Context, uri string) (*eurlex.ValidationResult, error)
+}
+
 // RecursiveFetcher coordinates breadth-first fetching of external references
 // found in a triple store, resolving URNs to fetchable URLs, validating them,
 // and adding cross-document triples to the federated graph.
@@ -24,6 +33,11 @@ type RecursiveFetcher struct {
 	urnMapper *URNMapper
 	validator URIValidator
 	cache     *DiskCache
+
+	// robots is set when config.RespectRobots is; lastRequest holds the
+	// time of the last request to each host, for Crawl-delay.
+	robots      *RobotsChecker
+	lastRequest map[string]time.Time
 }
 
 // NewRecursiveFetcher creates a new recursive fetcher with the given configuration.
@@ -38,12 +52,17 @@ func NewRecursiveFetcher(config FetchConfig, validator URIValidator) (*Recursive
 		}
 	}
 
-	return &RecursiveFetcher{
-		config:    config,
-		urnMapper: NewURNMapper(),
-		validator: validator,
-		cache:     diskCache,
-	}, nil
+	fetcher := &RecursiveFetcher{
+		config:      config,
+		urnMapper:   NewURNMapper(),
+		validator:   validator,
+		cache:       diskCache,
+		lastRequest: make(map[string]time.Time),
+	}
+	if config.RespectRobots {
+		fetcher.robots = NewRobotsChecker(config.Timeout)
+	}
+	return fetcher, nil
 }
 
 // Fetch performs BFS over external references in the triple store, validates/fetches
@@ -52,7 +71,18 @@ func (fetcher *RecursiveFetcher) Fetch(
 	tripleStore *store.TripleStore,
 	sourceDocURI string,
 ) (*FetchReport, error) {
-	return fetcher.execute(tripleStore, sourceDocURI, false)
+	return fetcher.FetchWithContext(context.Background(), tripleStore, sourceDocURI)
+}
+
+// FetchWithContext is Fetch with cancellation support. When ctx is done the
+// fetch stops before the next reference, counts the remaining references as
+// skipped, and returns the partial report with Interrupted set.
+func (fetcher *RecursiveFetcher) FetchWithContext(
+	ctx context.Context,
+	tripleStore *store.TripleStore,
+	sourceDocURI string,
+) (*FetchReport, error) {
+	return fetcher.execute(ctx, tripleStore, sourceDocURI, false)
 }
 
 // Plan performs a dry-run: maps URNs and checks the cache, but makes no network calls.
@@ -61,11 +91,12 @@ func (fetcher *RecursiveFetcher) Plan(
 	tripleStore *store.TripleStore,
 	sourceDocURI string,
 ) (*FetchReport, error) {
-	return fetcher.execute(tripleStore, sourceDocURI, true)
+	return fetcher.execute(context.Background(), tripleStore, sourceDocURI, true)
 }
 
 // execute is the shared implementation for Fetch and Plan.
 func (fetcher *RecursiveFetcher) execute(
+	ctx context.Context,
 	tripleStore *store.TripleStore,
 	sourceDocURI string,
 	dryRun bool,
@@ -130,7 +161,13 @@ func (fetcher *RecursiveFetcher) execute(
 	}
 
 	// Process each fetchable reference.
-	for _, fetchableRef := range fetchableRefs {
+	for refIndex, fetchableRef := range fetchableRefs {
+		if ctx.Err() != nil {
+			report.Interrupted = true
+			report.SkippedCount += len(fetchableRefs) - refIndex
+			break
+		}
+
 		if dryRun {
 			// In dry-run mode, check cache only.
 			if fetcher.cache != nil {
@@ -166,8 +203,33 @@ func (fetcher *RecursiveFetcher) execute(
 			}
 		}
 
+		// Honor robots.txt before any request to the site.
+		if fetcher.robots != nil {
+			if err := fetcher.waitForRobots(ctx, fetchableRef.URL); err != nil {
+				if ctx.Err() != nil {
+					report.Interrupted = true
+					report.SkippedCount += len(fetchableRefs) - refIndex
+					break
+				}
+				report.SkippedCount++
+				report.Results = append(report.Results, FetchResult{
+					Reference: fetchableRef,
+					Success:   false,
+					Error:     err.Error(),
+				})
+				continue
+			}
+		}
+
 		// Validate via HEAD request.
-		fetchResult := fetcher.validateReference(fetchableRef)
+		fetchResult := fetcher.validateReference(ctx, fetchableRef)
+		if ctx.Err() != nil {
+			// The validation was cut short; leave it uncached and unreported
+			// so a later run retries it.
+			report.Interrupted = true
+			report.SkippedCount += len(fetchableRefs) - refIndex
+			break
+		}
 		report.Results = append(report.Results, fetchResult)
 
 		if fetchResult.Success {
@@ -214,8 +276,14 @@ func (fetcher *RecursiveFetcher) collectExternalRefs(tripleStore *store.TripleSt
 }
 
 // validateReference performs an HTTP HEAD validation of the fetchable URL.
-func (fetcher *RecursiveFetcher) validateReference(fetchableRef FetchableReference) FetchResult {
-	validationResult, err := fetcher.validator.ValidateURI(fetchableRef.URL)
+func (fetcher *RecursiveFetcher) validateReference(ctx context.Context, fetchableRef FetchableReference) FetchResult {
+	var validationResult *eurlex.ValidationResult
+	var err error
+	if contextValidator, ok := fetcher.validator.(ContextURIValidator); ok {
+		validationResult, err = contextValidator.ValidateURIWithContext(ctx, fetchableRef.URL)
+	} else {
+		validationResult, err = fetcher.validator.ValidateURI(fetchableRef.URL)
+	}
 	if err != nil {
 		return FetchResult{
 			Reference: fetchableRef,
@@ -241,6 +309,41 @@ func (fetcher *RecursiveFetcher) validateReference(fetchableRef FetchableReferen
 	}
 }
 
+// waitForRobots returns ErrDisallowedByRobots if the site's robots.txt
+// disallows the URL, and otherwise waits until the site's Crawl-delay has
+// passed since the last request to it.
+func (fetcher *RecursiveFetcher) waitForRobots(ctx context.Context, fetchableURL string) error {
+	userAgent := fetcher.config.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultFetchUserAgent
+	}
+	allowed, crawlDelay, err := fetcher.robots.Check(ctx, fetchableURL, userAgent)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrDisallowedByRobots
+	}
+
+	parsedURL, err := url.Parse(fetchableURL)
+	if err != nil {
+		return err
+	}
+	if lastRequest, ok := fetcher.lastRequest[parsedURL.Host]; ok {
+		if wait := crawlDelay - time.Since(lastRequest); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+	}
+	fetcher.lastRequest[parsedURL.Host] = time.Now()
+	return nil
+}
+
 // addFederationTriples adds cross-document RDF triples linking the source document
 // to the fetched external document.
 func (fetcher *RecursiveFetcher) addFederationTriples(
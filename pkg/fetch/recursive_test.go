@@ -1,6 +1,7 @@
 package fetch
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -152,6 +153,55 @@ func TestRecursiveFetcher_Fetch_Success(t *testing.T) {
 	}
 }
 
+// cancellingValidator is a ContextURIValidator whose second validation is
+// interrupted, as a SIGINT mid-fetch would be.
+type cancellingValidator struct {
+	*mockValidator
+	cancel       context.CancelFunc
+	contextCalls int
+}
+
+func (cancelVal *cancellingValidator) ValidateURIWithContext(ctx context.Context, uri string) (*eurlex.ValidationResult, error) {
+	cancelVal.contextCalls++
+	if cancelVal.contextCalls > 1 {
+		cancelVal.cancel()
+		return nil, ctx.Err()
+	}
+	return &eurlex.ValidationResult{URI: uri, Valid: true, StatusCode: 200, CheckedAt: time.Now()}, nil
+}
+
+func TestRecursiveFetcher_FetchWithContext_Cancelled(t *testing.T) {
+	tripleStore := buildTestStore()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	validator := &cancellingValidator{mockValidator: newMockValidator(), cancel: cancel}
+
+	fetcher, err := NewRecursiveFetcher(DefaultFetchConfig(), validator)
+	if err != nil {
+		t.Fatalf("NewRecursiveFetcher failed: %v", err)
+	}
+
+	report, err := fetcher.FetchWithContext(ctx, tripleStore, "https://regula.dev/regulations/GDPR")
+	if err != nil {
+		t.Fatalf("FetchWithContext failed: %v", err)
+	}
+
+	if !report.Interrupted {
+		t.Error("Interrupted should be true")
+	}
+	if report.FetchedCount != 1 {
+		t.Errorf("FetchedCount: got %d, want 1", report.FetchedCount)
+	}
+	// The treaty is unmappable; the two references after the interrupt are
+	// skipped rather than failed.
+	if report.SkippedCount != 3 || report.FailedCount != 0 {
+		t.Errorf("SkippedCount/FailedCount: got %d/%d, want 3/0", report.SkippedCount, report.FailedCount)
+	}
+	if validator.callCount != 0 {
+		t.Errorf("ValidateURI should not be used when ValidateURIWithContext exists, got %d calls", validator.callCount)
+	}
+}
+
 func TestRecursiveFetcher_Fetch_MaxDepth(t *testing.T) {
 	tripleStore := buildTestStore()
 	validator := newMockValidator()
@@ -33,6 +33,10 @@ type FetchReport struct {
 
 	// DryRun indicates this was a plan-only operation with no network calls.
 	DryRun bool `json:"dry_run"`
+
+	// Interrupted indicates the fetch was cancelled before every reference
+	// was processed.
+	Interrupted bool `json:"interrupted,omitempty"`
 }
 
 // String returns a CLI-friendly summary of the fetch report.
@@ -60,6 +64,10 @@ func (report *FetchReport) String() string {
 		summaryBuilder.WriteString(fmt.Sprintf("  Triples added:             %d\n", report.TriplesAdded))
 	}
 
+	if report.Interrupted {
+		summaryBuilder.WriteString("  Interrupted before all references were processed\n")
+	}
+
 	if len(report.Results) > 0 {
 		summaryBuilder.WriteString("\n  References:\n")
 		for _, result := range report.Results {
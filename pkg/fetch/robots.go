@@ -0,0 +1,274 @@
+package fetch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrDisallowedByRobots is returned when a site's robots.txt disallows a URL
+// for the fetching user agent.
+var ErrDisallowedByRobots = errors.New("disallowed by robots.txt")
+
+// maxRobotsBytes bounds the size of a robots.txt file that is parsed, as
+// RFC 9309 allows crawlers to ignore content beyond 500 KiB.
+const maxRobotsBytes = 500 * 1024
+
+// RobotsFile is a parsed robots.txt file.
+type RobotsFile struct {
+	groups []*robotsGroup
+}
+
+// robotsGroup is a group of rules for one or more user agents.
+type robotsGroup struct {
+	userAgents []string
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+// robotsRule is an Allow or Disallow line.
+type robotsRule struct {
+	allow   bool
+	length  int
+	pattern *regexp.Regexp
+}
+
+// allowAllRobots and disallowAllRobots stand in for a robots.txt that is
+// missing and one that cannot be fetched.
+var (
+	allowAllRobots    = &RobotsFile{}
+	disallowAllRobots = ParseRobots([]byte("User-agent: *\nDisallow: /\n"))
+)
+
+// ParseRobots parses a robots.txt file following RFC 9309. The non-standard
+// Crawl-delay directive is read in seconds; unknown lines are ignored.
+func ParseRobots(data []byte) *RobotsFile {
+	file := &RobotsFile{}
+	var current *robotsGroup
+	inRules := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if index := strings.Index(line, "#"); index >= 0 {
+			line = line[:index]
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if current == nil || inRules {
+				current = &robotsGroup{}
+				file.groups = append(file.groups, current)
+				inRules = false
+			}
+			current.userAgents = append(current.userAgents, strings.ToLower(robotsProductToken(value)))
+		case "allow", "disallow":
+			if current == nil {
+				continue
+			}
+			inRules = true
+			if value == "" {
+				continue
+			}
+			current.rules = append(current.rules, robotsRule{
+				allow:   key == "allow",
+				length:  len(value),
+				pattern: compileRobotsPattern(value),
+			})
+		case "crawl-delay":
+			if current == nil {
+				continue
+			}
+			inRules = true
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+				current.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+		}
+	}
+	return file
+}
+
+// compileRobotsPattern converts a robots.txt path pattern, in which "*"
+// matches any characters and a trailing "$" anchors the end, to a regexp.
+func compileRobotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+	for index, part := range parts {
+		parts[index] = regexp.QuoteMeta(part)
+	}
+	expression := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expression += "$"
+	}
+	return regexp.MustCompile(expression)
+}
+
+// robotsProductToken returns the product token of a user agent, as in
+// "regula-crawler" for "regula-crawler/1.0 (+https://regula.dev)".
+func robotsProductToken(userAgent string) string {
+	if index := strings.IndexAny(userAgent, "/ "); index >= 0 {
+		return userAgent[:index]
+	}
+	return userAgent
+}
+
+// groupsFor returns the groups that apply to a user agent: those naming its
+// product token, or the "*" groups when none does.
+func (file *RobotsFile) groupsFor(userAgent string) []*robotsGroup {
+	token := strings.ToLower(robotsProductToken(userAgent))
+	var matched, wildcard []*robotsGroup
+	for _, group := range file.groups {
+		for _, groupAgent := range group.userAgents {
+			if groupAgent == "*" {
+				wildcard = append(wildcard, group)
+				break
+			}
+			if token != "" && groupAgent == token {
+				matched = append(matched, group)
+				break
+			}
+		}
+	}
+	if len(matched) > 0 {
+		return matched
+	}
+	return wildcard
+}
+
+// Allowed reports whether userAgent may fetch path, which includes any query
+// string. The longest matching rule decides, and Allow wins a tie.
+func (file *RobotsFile) Allowed(userAgent, path string) bool {
+	if path == "/robots.txt" {
+		return true
+	}
+	allowed, longest := true, -1
+	for _, group := range file.groupsFor(userAgent) {
+		for _, rule := range group.rules {
+			if !rule.pattern.MatchString(path) {
+				continue
+			}
+			if rule.length > longest || rule.length == longest && rule.allow {
+				allowed, longest = rule.allow, rule.length
+			}
+		}
+	}
+	return allowed
+}
+
+// CrawlDelay returns the Crawl-delay that applies to userAgent, or zero.
+func (file *RobotsFile) CrawlDelay(userAgent string) time.Duration {
+	var delay time.Duration
+	for _, group := range file.groupsFor(userAgent) {
+		if group.crawlDelay > delay {
+			delay = group.crawlDelay
+		}
+	}
+	return delay
+}
+
+// RobotsChecker fetches and caches the robots.txt file of each site a
+// fetcher visits. A missing robots.txt (4xx) allows everything; one that
+// cannot be fetched (5xx or a network error) disallows everything, as RFC
+// 9309 requires.
+type RobotsChecker struct {
+	httpClient *http.Client
+	mu         sync.Mutex
+	files      map[string]*RobotsFile // by scheme and host
+}
+
+// NewRobotsChecker creates a RobotsChecker whose robots.txt requests time
+// out after timeout.
+func NewRobotsChecker(timeout time.Duration) *RobotsChecker {
+	return &RobotsChecker{
+		httpClient: &http.Client{Timeout: timeout},
+		files:      make(map[string]*RobotsFile),
+	}
+}
+
+// Check returns whether userAgent may fetch rawURL, and the crawl delay the
+// site asks of it.
+func (checker *RobotsChecker) Check(ctx context.Context, rawURL, userAgent string) (bool, time.Duration, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return false, 0, fmt.Errorf("invalid URL %s: %w", rawURL, err)
+	}
+	file, err := checker.robotsFile(ctx, parsedURL, userAgent)
+	if err != nil {
+		return false, 0, err
+	}
+	return file.Allowed(userAgent, parsedURL.RequestURI()), file.CrawlDelay(userAgent), nil
+}
+
+// robotsFile returns the robots.txt of a URL's site, fetching it on first use.
+func (checker *RobotsChecker) robotsFile(ctx context.Context, parsedURL *url.URL, userAgent string) (*RobotsFile, error) {
+	site := parsedURL.Scheme + "://" + parsedURL.Host
+
+	checker.mu.Lock()
+	file, cached := checker.files[site]
+	checker.mu.Unlock()
+	if cached {
+		return file, nil
+	}
+
+	file, err := checker.fetchRobots(ctx, site, userAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	checker.mu.Lock()
+	checker.files[site] = file
+	checker.mu.Unlock()
+	return file, nil
+}
+
+// fetchRobots fetches and parses a site's robots.txt. Only a cancelled
+// context is returned as an error, so the site can be checked again later.
+func (checker *RobotsChecker) fetchRobots(ctx context.Context, site, userAgent string) (*RobotsFile, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, site+"/robots.txt", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create robots.txt request for %s: %w", site, err)
+	}
+	request.Header.Set("User-Agent", userAgent)
+
+	response, err := checker.httpClient.Do(request)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return disallowAllRobots, nil
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode >= 500:
+		return disallowAllRobots, nil
+	case response.StatusCode >= 400:
+		return allowAllRobots, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxRobotsBytes))
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return disallowAllRobots, nil
+	}
+	return ParseRobots(data), nil
+}
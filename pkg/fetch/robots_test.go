@@ -0,0 +1,123 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testRobots = `# Example robots.txt
+User-agent: *
+Disallow: /private/
+Disallow: /*.pdf$
+Allow: /private/public/
+Crawl-delay: 2
+
+User-agent: regula-crawler
+User-agent: other-bot
+Disallow: /search
+Crawl-delay: 0.5
+`
+
+func TestRobotsFile_Allowed(t *testing.T) {
+	robots := ParseRobots([]byte(testRobots))
+	const genericAgent = "generic-bot/2.0"
+
+	testCases := []struct {
+		name      string
+		userAgent string
+		path      string
+		allowed   bool
+	}{
+		{"unrestricted path", genericAgent, "/eli/reg/2016/679/oj", true},
+		{"disallowed prefix", genericAgent, "/private/notes", false},
+		{"longer allow wins", genericAgent, "/private/public/page", true},
+		{"anchored wildcard", genericAgent, "/docs/act.pdf", false},
+		{"anchored wildcard with suffix", genericAgent, "/docs/act.pdf?download=1", true},
+		{"robots.txt always allowed", genericAgent, "/robots.txt", true},
+		{"specific group replaces wildcard", "regula-crawler/1.0 (+https://regula.dev)", "/private/notes", true},
+		{"specific group rule", "Regula-Crawler/1.0", "/search?q=gdpr", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if allowed := robots.Allowed(testCase.userAgent, testCase.path); allowed != testCase.allowed {
+				t.Errorf("Allowed(%q, %q) = %v, want %v", testCase.userAgent, testCase.path, allowed, testCase.allowed)
+			}
+		})
+	}
+}
+
+func TestRobotsFile_CrawlDelay(t *testing.T) {
+	robots := ParseRobots([]byte(testRobots))
+
+	if delay := robots.CrawlDelay("generic-bot"); delay != 2*time.Second {
+		t.Errorf("generic crawl delay = %v, want 2s", delay)
+	}
+	if delay := robots.CrawlDelay("regula-crawler/1.0"); delay != 500*time.Millisecond {
+		t.Errorf("regula-crawler crawl delay = %v, want 500ms", delay)
+	}
+	if delay := ParseRobots(nil).CrawlDelay("generic-bot"); delay != 0 {
+		t.Errorf("empty robots.txt crawl delay = %v, want 0", delay)
+	}
+}
+
+func TestRobotsChecker_Check(t *testing.T) {
+	var robotsRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			robotsRequests.Add(1)
+			w.Write([]byte(testRobots))
+		}
+	}))
+	defer server.Close()
+
+	checker := NewRobotsChecker(5 * time.Second)
+	ctx := context.Background()
+
+	allowed, crawlDelay, err := checker.Check(ctx, server.URL+"/private/notes", "generic-bot/2.0")
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if allowed || crawlDelay != 2*time.Second {
+		t.Errorf("Check = %v, %v, want disallowed with 2s delay", allowed, crawlDelay)
+	}
+
+	if allowed, _, _ := checker.Check(ctx, server.URL+"/eli/reg/2016/679", "generic-bot/2.0"); !allowed {
+		t.Error("expected /eli/reg/2016/679 to be allowed")
+	}
+	if got := robotsRequests.Load(); got != 1 {
+		t.Errorf("robots.txt fetched %d times, want 1", got)
+	}
+}
+
+func TestRobotsChecker_UnavailableRobots(t *testing.T) {
+	testCases := []struct {
+		name    string
+		status  int
+		allowed bool
+	}{
+		{"missing robots.txt allows all", http.StatusNotFound, true},
+		{"server error disallows all", http.StatusServiceUnavailable, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(testCase.status)
+			}))
+			defer server.Close()
+
+			allowed, _, err := NewRobotsChecker(5*time.Second).Check(context.Background(), server.URL+"/doc", "regula-crawler")
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+			if allowed != testCase.allowed {
+				t.Errorf("allowed = %v, want %v", allowed, testCase.allowed)
+			}
+		})
+	}
+}
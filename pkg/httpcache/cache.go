@@ -0,0 +1,286 @@
+// Package httpcache provides a persistent on-disk HTTP cache shared by the
+// fetch, linkcheck, crawler, and bulk packages.
+//
+// Responses that carry an ETag or Last-Modified validator are stored on disk
+// and revalidated with If-None-Match and If-Modified-Since on the next
+// request for the same URL. A 304 Not Modified answer is served from the
+// stored copy, so repeated validation and crawling of the same URIs does not
+// transfer the documents again.
+package httpcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDir is the default cache directory, inside the default library.
+var DefaultDir = filepath.Join(".regula", "http-cache")
+
+// DefaultMaxBodyBytes is the largest response body that is stored. Larger
+// responses, such as bulk archives, pass through uncached.
+const DefaultMaxBodyBytes = 10 * 1024 * 1024
+
+const (
+	metaExtension = ".json"
+	bodyExtension = ".body"
+)
+
+// Cache is a directory of cached HTTP responses. Each entry is a metadata
+// JSON file and a body file, keyed by a SHA-256 hash of the method and URL.
+// The directory is created on the first store.
+type Cache struct {
+	dir string
+
+	// MaxBodyBytes is the largest response body that is stored.
+	MaxBodyBytes int64
+
+	mu sync.Mutex
+}
+
+// Entry is the metadata of a cached response.
+type Entry struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	BodyBytes    int64       `json:"body_bytes"`
+	StoredAt     time.Time   `json:"stored_at"`
+	ValidatedAt  time.Time   `json:"validated_at"`
+
+	// ExpiresAt is when the response stops being fresh under its
+	// Cache-Control max-age. Until then it is served without a request.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+
+	// Hits counts the requests answered from this entry.
+	Hits int `json:"hits"`
+}
+
+// Stats summarizes the contents of a cache.
+type Stats struct {
+	Dir       string         `json:"dir"`
+	Entries   int            `json:"entries"`
+	BodyBytes int64          `json:"body_bytes"`
+	Hits      int            `json:"hits"`
+	Oldest    time.Time      `json:"oldest,omitempty"`
+	Newest    time.Time      `json:"newest,omitempty"`
+	Domains   map[string]int `json:"domains"`
+}
+
+// Open returns the cache in dir. The directory does not need to exist yet.
+func Open(dir string) (*Cache, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("empty cache directory")
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		return nil, fmt.Errorf("cache path %s is not a directory", dir)
+	}
+	return &Cache{dir: dir, MaxBodyBytes: DefaultMaxBodyBytes}, nil
+}
+
+// Dir returns the cache directory.
+func (cache *Cache) Dir() string {
+	return cache.dir
+}
+
+// Client returns a copy of client whose transport goes through the cache.
+// A nil client stands for http.DefaultClient.
+func (cache *Cache) Client(client *http.Client) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	cachedClient := *client
+	cachedClient.Transport = NewTransport(cache, client.Transport)
+	return &cachedClient
+}
+
+// Get returns the entry and body stored for a method and URL.
+func (cache *Cache) Get(method, rawURL string) (*Entry, []byte, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	key := cache.keyFor(method, rawURL)
+	entry, err := cache.readEntry(key)
+	if err != nil {
+		return nil, nil, false
+	}
+	body, err := os.ReadFile(cache.pathFor(key, bodyExtension))
+	if err != nil {
+		return nil, nil, false
+	}
+	return entry, body, true
+}
+
+// Put stores an entry and its body, replacing any previous entry for the
+// same method and URL.
+func (cache *Cache) Put(entry *Entry, body []byte) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if err := os.MkdirAll(cache.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory %s: %w", cache.dir, err)
+	}
+	key := cache.keyFor(entry.Method, entry.URL)
+	entry.BodyBytes = int64(len(body))
+	if err := os.WriteFile(cache.pathFor(key, bodyExtension), body, 0o644); err != nil {
+		return fmt.Errorf("failed to write cache body for %s: %w", entry.URL, err)
+	}
+	return cache.writeEntry(key, entry)
+}
+
+// touch records a hit on an entry, and a revalidation when validated is set.
+func (cache *Cache) touch(method, rawURL string, validated bool, expiresAt time.Time) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	key := cache.keyFor(method, rawURL)
+	entry, err := cache.readEntry(key)
+	if err != nil {
+		return
+	}
+	entry.Hits++
+	if validated {
+		entry.ValidatedAt = time.Now()
+		entry.ExpiresAt = expiresAt
+	}
+	_ = cache.writeEntry(key, entry)
+}
+
+// Stats scans the cache directory. A missing directory is an empty cache.
+func (cache *Cache) Stats() (*Stats, error) {
+	stats := &Stats{Dir: cache.dir, Domains: make(map[string]int)}
+	err := cache.eachEntry(func(key string, entry *Entry) error {
+		stats.Entries++
+		stats.BodyBytes += entry.BodyBytes
+		stats.Hits += entry.Hits
+		if stats.Oldest.IsZero() || entry.StoredAt.Before(stats.Oldest) {
+			stats.Oldest = entry.StoredAt
+		}
+		if entry.StoredAt.After(stats.Newest) {
+			stats.Newest = entry.StoredAt
+		}
+		stats.Domains[entryDomain(entry)]++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// Clear removes the cached entries for domain, or every entry when domain
+// is empty, and returns how many were removed.
+func (cache *Cache) Clear(domain string) (int, error) {
+	removed := 0
+	err := cache.eachEntry(func(key string, entry *Entry) error {
+		if domain != "" && !strings.EqualFold(entryDomain(entry), domain) {
+			return nil
+		}
+		for _, extension := range []string{bodyExtension, metaExtension} {
+			if err := os.Remove(cache.pathFor(key, extension)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to remove cache entry for %s: %w", entry.URL, err)
+			}
+		}
+		removed++
+		return nil
+	})
+	return removed, err
+}
+
+// SortedDomains returns the domains in stats, most cached first.
+func (stats *Stats) SortedDomains() []string {
+	domains := make([]string, 0, len(stats.Domains))
+	for domain := range stats.Domains {
+		domains = append(domains, domain)
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		if stats.Domains[domains[i]] != stats.Domains[domains[j]] {
+			return stats.Domains[domains[i]] > stats.Domains[domains[j]]
+		}
+		return domains[i] < domains[j]
+	})
+	return domains
+}
+
+// eachEntry calls visit for every readable entry, holding the cache lock.
+func (cache *Cache) eachEntry(visit func(key string, entry *Entry) error) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	dirEntries, err := os.ReadDir(cache.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read cache directory %s: %w", cache.dir, err)
+	}
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if dirEntry.IsDir() || !strings.HasSuffix(name, metaExtension) {
+			continue
+		}
+		key := strings.TrimSuffix(name, metaExtension)
+		entry, err := cache.readEntry(key)
+		if err != nil {
+			continue
+		}
+		if err := visit(key, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cache *Cache) readEntry(key string) (*Entry, error) {
+	data, err := os.ReadFile(cache.pathFor(key, metaExtension))
+	if err != nil {
+		return nil, err
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (cache *Cache) writeEntry(key string, entry *Entry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+	if err := os.WriteFile(cache.pathFor(key, metaExtension), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write cache entry for %s: %w", entry.URL, err)
+	}
+	return nil
+}
+
+// keyFor returns the hex-encoded SHA-256 hash of a method and URL.
+func (cache *Cache) keyFor(method, rawURL string) string {
+	hash := sha256.Sum256([]byte(method + " " + rawURL))
+	return hex.EncodeToString(hash[:])
+}
+
+func (cache *Cache) pathFor(key, extension string) string {
+	return filepath.Join(cache.dir, key+extension)
+}
+
+// entryDomain returns the host of an entry's URL.
+func entryDomain(entry *Entry) string {
+	parsedURL, err := url.Parse(entry.URL)
+	if err != nil || parsedURL.Host == "" {
+		return "unknown"
+	}
+	return strings.ToLower(parsedURL.Hostname())
+}
@@ -0,0 +1,181 @@
+package httpcache
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheStatusHeader is set on responses served by a Transport: "hit" for a
+// fresh entry served without a request, "revalidated" for an entry confirmed
+// by a 304 Not Modified, and "miss" for a response fetched in full.
+const CacheStatusHeader = "X-Regula-Cache"
+
+// Transport is an http.RoundTripper that answers GET and HEAD requests from
+// a Cache. Stored responses are revalidated with conditional requests unless
+// their Cache-Control max-age has not yet passed. Range requests, requests
+// that already carry their own validators, and responses marked no-store
+// bypass the cache.
+type Transport struct {
+	cache *Cache
+	base  http.RoundTripper
+}
+
+// NewTransport wraps base with cache. A nil base stands for
+// http.DefaultTransport.
+func NewTransport(cache *Cache, base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{cache: cache, base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (transport *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if !cacheable(request) {
+		return transport.base.RoundTrip(request)
+	}
+
+	rawURL := request.URL.String()
+	entry, body, found := transport.cache.Get(request.Method, rawURL)
+	if found && time.Now().Before(entry.ExpiresAt) {
+		transport.cache.touch(request.Method, rawURL, false, time.Time{})
+		return entry.response(request, body, "hit"), nil
+	}
+
+	outgoing := request
+	if found {
+		outgoing = request.Clone(request.Context())
+		if entry.ETag != "" {
+			outgoing.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			outgoing.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	response, err := transport.base.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+
+	if found && response.StatusCode == http.StatusNotModified {
+		response.Body.Close()
+		transport.cache.touch(request.Method, rawURL, true, freshUntil(response.Header))
+		return entry.response(request, body, "revalidated"), nil
+	}
+
+	if response.StatusCode != http.StatusOK || noStore(response.Header) {
+		return response, nil
+	}
+	etag, lastModified := response.Header.Get("ETag"), response.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return response, nil
+	}
+
+	// Read up to one byte past the limit; a larger body is handed on
+	// unstored, starting with the bytes already read.
+	limit := transport.cache.MaxBodyBytes
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, limit+1))
+	if err != nil {
+		response.Body.Close()
+		return nil, err
+	}
+	if int64(len(responseBody)) > limit {
+		response.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(responseBody), response.Body), response.Body}
+		return response, nil
+	}
+	response.Body.Close()
+
+	now := time.Now()
+	_ = transport.cache.Put(&Entry{
+		Method:       request.Method,
+		URL:          rawURL,
+		StatusCode:   response.StatusCode,
+		Header:       response.Header.Clone(),
+		ETag:         etag,
+		LastModified: lastModified,
+		StoredAt:     now,
+		ValidatedAt:  now,
+		ExpiresAt:    freshUntil(response.Header),
+	}, responseBody)
+
+	response.Header.Set(CacheStatusHeader, "miss")
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+	return response, nil
+}
+
+// response rebuilds a stored response for request.
+func (entry *Entry) response(request *http.Request, body []byte, cacheStatus string) *http.Response {
+	header := entry.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set(CacheStatusHeader, cacheStatus)
+	return &http.Response{
+		Status:        strconv.Itoa(entry.StatusCode) + " " + http.StatusText(entry.StatusCode),
+		StatusCode:    entry.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}
+}
+
+// cacheable reports whether a request may be answered from the cache.
+func cacheable(request *http.Request) bool {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		return false
+	}
+	for _, name := range []string{"Range", "If-None-Match", "If-Modified-Since"} {
+		if request.Header.Get(name) != "" {
+			return false
+		}
+	}
+	return !noStore(request.Header)
+}
+
+// noStore reports whether Cache-Control forbids storing a message.
+func noStore(header http.Header) bool {
+	return cacheControl(header, "no-store") != ""
+}
+
+// freshUntil returns when a response stops being fresh under its
+// Cache-Control max-age, or the zero time if it must always be revalidated.
+func freshUntil(header http.Header) time.Time {
+	if cacheControl(header, "no-cache") != "" {
+		return time.Time{}
+	}
+	seconds, err := strconv.Atoi(cacheControl(header, "max-age"))
+	if err != nil || seconds <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(time.Duration(seconds) * time.Second)
+}
+
+// cacheControl returns the value of a Cache-Control directive, "true" for a
+// directive without a value, or "" when the directive is absent.
+func cacheControl(header http.Header, directive string) string {
+	for _, value := range header.Values("Cache-Control") {
+		for _, part := range strings.Split(value, ",") {
+			name, argument, hasArgument := strings.Cut(strings.TrimSpace(part), "=")
+			if !strings.EqualFold(name, directive) {
+				continue
+			}
+			if !hasArgument {
+				return "true"
+			}
+			return strings.Trim(argument, `"`)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,160 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const testETag = `"gdpr-v1"`
+
+// newValidatorServer serves a fixed body with an ETag and answers matching
+// If-None-Match requests with 304, counting full and conditional responses.
+func newValidatorServer(t *testing.T, cacheControl string) (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+	t.Helper()
+	var fullResponses, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", testETag)
+		if cacheControl != "" {
+			w.Header().Set("Cache-Control", cacheControl)
+		}
+		if r.Header.Get("If-None-Match") == testETag {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fullResponses.Add(1)
+		w.Write([]byte("Article 1 Subject-matter and objectives"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &fullResponses, &notModified
+}
+
+func getBody(t *testing.T, client *http.Client, url string) (string, string) {
+	t.Helper()
+	response, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("reading body failed: %v", err)
+	}
+	return string(body), response.Header.Get(CacheStatusHeader)
+}
+
+func TestTransportRevalidatesWithETag(t *testing.T) {
+	server, fullResponses, notModified := newValidatorServer(t, "")
+	cache, err := Open(filepath.Join(t.TempDir(), "http-cache"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	client := cache.Client(nil)
+
+	first, firstStatus := getBody(t, client, server.URL+"/eli/reg/2016/679")
+	second, secondStatus := getBody(t, client, server.URL+"/eli/reg/2016/679")
+
+	if first != second || !strings.HasPrefix(second, "Article 1") {
+		t.Errorf("bodies = %q, %q, want the same document twice", first, second)
+	}
+	if firstStatus != "miss" || secondStatus != "revalidated" {
+		t.Errorf("cache statuses = %q, %q, want miss, revalidated", firstStatus, secondStatus)
+	}
+	if fullResponses.Load() != 1 || notModified.Load() != 1 {
+		t.Errorf("server sent %d full and %d not-modified responses, want 1 and 1", fullResponses.Load(), notModified.Load())
+	}
+
+	stats, err := cache.Stats()
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.Entries != 1 || stats.Hits != 1 || stats.BodyBytes != int64(len(first)) {
+		t.Errorf("stats = %+v, want 1 entry with 1 hit", stats)
+	}
+}
+
+func TestTransportServesFreshEntries(t *testing.T) {
+	server, fullResponses, notModified := newValidatorServer(t, "max-age=3600")
+	cache, _ := Open(t.TempDir())
+	client := cache.Client(nil)
+
+	getBody(t, client, server.URL+"/doc")
+	if _, status := getBody(t, client, server.URL+"/doc"); status != "hit" {
+		t.Errorf("cache status = %q, want hit", status)
+	}
+	if fullResponses.Load() != 1 || notModified.Load() != 0 {
+		t.Errorf("server sent %d full and %d not-modified responses, want 1 and 0", fullResponses.Load(), notModified.Load())
+	}
+}
+
+func TestTransportBypass(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/no-validator" {
+			w.Header().Set("ETag", testETag)
+		}
+		if r.URL.Path == "/no-store" {
+			w.Header().Set("Cache-Control", "no-store")
+		}
+		w.Write([]byte(strings.Repeat("x", 64)))
+	}))
+	defer server.Close()
+
+	cache, _ := Open(t.TempDir())
+	cache.MaxBodyBytes = 32
+	client := cache.Client(nil)
+
+	for _, path := range []string{"/no-validator", "/no-store", "/too-large"} {
+		body, _ := getBody(t, client, server.URL+path)
+		if len(body) != 64 {
+			t.Errorf("%s body length = %d, want 64", path, len(body))
+		}
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL+"/range", nil)
+	request.Header.Set("Range", "bytes=10-")
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatalf("range request failed: %v", err)
+	}
+	response.Body.Close()
+
+	if stats, _ := cache.Stats(); stats.Entries != 0 {
+		t.Errorf("stored %d entries, want none", stats.Entries)
+	}
+}
+
+func TestCacheClear(t *testing.T) {
+	server, _, _ := newValidatorServer(t, "")
+	cache, _ := Open(t.TempDir())
+	client := cache.Client(nil)
+	getBody(t, client, server.URL+"/a")
+	getBody(t, client, server.URL+"/b")
+
+	if removed, err := cache.Clear("other.example"); err != nil || removed != 0 {
+		t.Errorf("Clear(other.example) = %d, %v, want 0", removed, err)
+	}
+	if removed, err := cache.Clear(""); err != nil || removed != 2 {
+		t.Errorf("Clear() = %d, %v, want 2", removed, err)
+	}
+	if stats, _ := cache.Stats(); stats.Entries != 0 {
+		t.Errorf("entries after clear = %d, want 0", stats.Entries)
+	}
+}
+
+func TestStatsOnMissingDirectory(t *testing.T) {
+	cache, err := Open(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	stats, err := cache.Stats()
+	if err != nil || stats.Entries != 0 {
+		t.Errorf("Stats() = %+v, %v, want an empty cache", stats, err)
+	}
+}
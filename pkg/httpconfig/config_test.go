@@ -0,0 +1,227 @@
+package httpconfig
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	libraryPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(libraryPath, ConfigFileName), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return libraryPath
+}
+
+func TestLoadConfig(t *testing.T) {
+	libraryPath := writeConfig(t, `language: de
+http:
+  proxy: http://proxy.corp.example:3128
+  no_proxy: [localhost]
+  retry:
+    max_retries: 2
+    base_delay: 10ms
+  domains:
+    api.govinfo.gov:
+      headers:
+        X-Api-Key: ${GOVINFO_API_KEY}
+`)
+
+	config, err := LoadConfig(libraryPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if config.Proxy != "http://proxy.corp.example:3128" || config.Retry.MaxRetries != 2 || config.IsZero() {
+		t.Errorf("config = %+v", config)
+	}
+	if config.Domains["api.govinfo.gov"].Headers["X-Api-Key"] != "${GOVINFO_API_KEY}" {
+		t.Errorf("domains = %+v", config.Domains)
+	}
+
+	missing, err := LoadConfig(t.TempDir())
+	if err != nil || !missing.IsZero() {
+		t.Errorf("LoadConfig(no file) = %+v, %v, want an empty config", missing, err)
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"proxy without scheme": "http:\n  proxy: proxy.corp.example\n",
+		"bad delay":            "http:\n  retry:\n    base_delay: soon\n",
+		"bad status":           "http:\n  retry:\n    retry_on: [42]\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := LoadConfig(writeConfig(t, content)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestTransportDomainHeadersAndQuery(t *testing.T) {
+	t.Setenv("TEST_API_KEY", "secret-key")
+	var gotHeader, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader, gotQuery = r.Header.Get("X-Api-Key"), r.URL.RawQuery
+	}))
+	defer server.Close()
+
+	config := &Config{Domains: map[string]DomainConfig{
+		"127.0.0.1": {
+			Headers: map[string]string{"X-Api-Key": "${TEST_API_KEY}"},
+			Query:   map[string]string{"key": "$TEST_API_KEY", "view": "full"},
+		},
+	}}
+	transport, err := config.Transport(nil)
+	if err != nil {
+		t.Fatalf("Transport failed: %v", err)
+	}
+	response, err := (&http.Client{Transport: transport}).Get(server.URL + "/laws?view=summary")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	response.Body.Close()
+
+	if gotHeader != "secret-key" {
+		t.Errorf("X-Api-Key = %q, want the expanded environment variable", gotHeader)
+	}
+	if !strings.Contains(gotQuery, "key=secret-key") || !strings.Contains(gotQuery, "view=summary") {
+		t.Errorf("query = %q, want the key added and view kept", gotQuery)
+	}
+}
+
+func TestTransportRetry(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	config := &Config{Retry: RetryPolicy{MaxRetries: 3, BaseDelay: "1ms"}}
+	transport, _ := config.Transport(nil)
+	client := &http.Client{Transport: transport}
+
+	response, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK || attempts.Load() != 3 {
+		t.Errorf("status %d after %d attempts, want 200 after 3", response.StatusCode, attempts.Load())
+	}
+
+	attempts.Store(0)
+	postResponse, err := client.Post(server.URL, "text/plain", strings.NewReader("body"))
+	if err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	postResponse.Body.Close()
+	if attempts.Load() != 1 {
+		t.Errorf("POST attempts = %d, want 1 (not retried)", attempts.Load())
+	}
+}
+
+func TestTransportProxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		w.Write([]byte("via proxy"))
+	}))
+	defer proxy.Close()
+
+	config := &Config{Proxy: proxy.URL, NoProxy: []string{"internal.example"}}
+	transport, err := config.Transport(nil)
+	if err != nil {
+		t.Fatalf("Transport failed: %v", err)
+	}
+	response, err := (&http.Client{Transport: transport}).Get("http://www.legislation.gov.uk/ukpga/2018/12")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	response.Body.Close()
+	if proxiedHost != "www.legislation.gov.uk" {
+		t.Errorf("proxy saw host %q, want www.legislation.gov.uk", proxiedHost)
+	}
+
+	httpTransport := transport.(*http.Transport)
+	request, _ := http.NewRequest(http.MethodGet, "http://docs.internal.example/", nil)
+	if proxyURL, _ := httpTransport.Proxy(request); proxyURL != nil {
+		t.Errorf("no_proxy host proxied through %v", proxyURL)
+	}
+}
+
+func TestTransportCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	untrusted, _ := (&Config{}).Transport(nil)
+	if _, err := (&http.Client{Transport: untrusted}).Get(server.URL); err == nil {
+		t.Fatal("expected a certificate error without the CA bundle")
+	}
+
+	bundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundlePath, bundle, 0644); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+	trusted, err := (&Config{CABundle: bundlePath}).Transport(nil)
+	if err != nil {
+		t.Fatalf("Transport failed: %v", err)
+	}
+	response, err := (&http.Client{Transport: trusted}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with CA bundle failed: %v", err)
+	}
+	response.Body.Close()
+}
+
+func TestInstall(t *testing.T) {
+	original := http.DefaultTransport
+
+	restore, err := Install(&Config{})
+	if err != nil || http.DefaultTransport != original {
+		t.Fatalf("Install(empty) changed the default transport: %v", err)
+	}
+	restore()
+
+	restore, err = Install(&Config{Retry: RetryPolicy{MaxRetries: 1}})
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	if http.DefaultTransport == original {
+		t.Error("Install did not replace the default transport")
+	}
+	restore()
+	if http.DefaultTransport != original {
+		t.Error("restore did not put back the default transport")
+	}
+}
+
+func TestAddDomainQuery(t *testing.T) {
+	config := &Config{Domains: map[string]DomainConfig{
+		"example.gov": {Query: map[string]string{"key": "from-file"}},
+	}}
+	config.AddDomainQuery("example.gov", "key", "from-flag")
+	config.AddDomainQuery("api.example.org", "key", "from-flag")
+
+	if got := config.Domains["example.gov"].Query["key"]; got != "from-file" {
+		t.Errorf("configured key = %q, want the file's value kept", got)
+	}
+	if got := config.Domains["api.example.org"].Query["key"]; got != "from-flag" {
+		t.Errorf("added key = %q, want from-flag", got)
+	}
+}
@@ -0,0 +1,166 @@
+package httpconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Transport builds the round tripper for the configuration: a clone of
+// base with the proxy and CA bundle applied, wrapped to add per-domain
+// headers and query parameters and to retry failed GET and HEAD requests.
+func (c *Config) Transport(base *http.Transport) (http.RoundTripper, error) {
+	if base == nil {
+		base = &http.Transport{Proxy: http.ProxyFromEnvironment}
+	}
+	transport := base.Clone()
+
+	if c.Proxy != "" {
+		proxyURL, err := url.Parse(c.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", c.Proxy, err)
+		}
+		noProxy := c.NoProxy
+		transport.Proxy = func(request *http.Request) (*url.URL, error) {
+			for _, domain := range noProxy {
+				if matchesDomain(request.URL.Hostname(), domain) {
+					return nil, nil
+				}
+			}
+			return proxyURL, nil
+		}
+	}
+
+	if c.CABundle != "" {
+		pemData, err := os.ReadFile(c.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle %s: %w", c.CABundle, err)
+		}
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(pemData) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", c.CABundle)
+		}
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+
+	var roundTripper http.RoundTripper = transport
+	if len(c.Domains) > 0 {
+		roundTripper = &domainTransport{config: c, base: roundTripper}
+	}
+	if c.Retry.MaxRetries > 0 {
+		roundTripper = &retryTransport{
+			base:       roundTripper,
+			maxRetries: c.Retry.MaxRetries,
+			baseDelay:  c.Retry.baseDelay(),
+			maxDelay:   c.Retry.maxDelay(),
+			retryOn:    c.Retry.retryStatuses(),
+		}
+	}
+	return roundTripper, nil
+}
+
+// Install replaces http.DefaultTransport with the configured transport and
+// returns a function that restores the previous one. An empty configuration
+// installs nothing.
+func Install(config *Config) (func(), error) {
+	previous := http.DefaultTransport
+	if config == nil || config.IsZero() {
+		return func() {}, nil
+	}
+	base, _ := previous.(*http.Transport)
+	transport, err := config.Transport(base)
+	if err != nil {
+		return nil, err
+	}
+	http.DefaultTransport = transport
+	return func() { http.DefaultTransport = previous }, nil
+}
+
+// domainTransport adds the configured headers and query parameters to
+// requests for matching domains.
+type domainTransport struct {
+	config *Config
+	base   http.RoundTripper
+}
+
+func (transport *domainTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	domainConfig, found := transport.config.domainFor(request.URL.Hostname())
+	if !found {
+		return transport.base.RoundTrip(request)
+	}
+
+	outgoing := request.Clone(request.Context())
+	for name, value := range domainConfig.Headers {
+		outgoing.Header.Set(name, os.ExpandEnv(value))
+	}
+	if len(domainConfig.Query) > 0 {
+		query := outgoing.URL.Query()
+		for name, value := range domainConfig.Query {
+			if !query.Has(name) {
+				query.Set(name, os.ExpandEnv(value))
+			}
+		}
+		outgoing.URL.RawQuery = query.Encode()
+	}
+	return transport.base.RoundTrip(outgoing)
+}
+
+// retryTransport retries GET and HEAD requests that fail with a network
+// error or a retryable status, doubling the delay each time and honoring a
+// Retry-After header in seconds.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	retryOn    map[int]bool
+}
+
+func (transport *retryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		return transport.base.RoundTrip(request)
+	}
+
+	delay := transport.baseDelay
+	for attempt := 0; ; attempt++ {
+		response, err := transport.base.RoundTrip(request)
+		ctx := request.Context()
+		retryable := err != nil && ctx.Err() == nil || err == nil && transport.retryOn[response.StatusCode]
+		if !retryable || attempt >= transport.maxRetries {
+			return response, err
+		}
+
+		wait := delay
+		if response != nil {
+			if seconds, parseErr := strconv.Atoi(response.Header.Get("Retry-After")); parseErr == nil && seconds >= 0 {
+				wait = time.Duration(seconds) * time.Second
+			}
+			io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+			response.Body.Close()
+		}
+		if wait > transport.maxDelay {
+			wait = transport.maxDelay
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
@@ -0,0 +1,159 @@
+// Package i18n localizes report and CLI output. Message catalogs map English
+// source strings to translations, so English needs no catalog and any
+// message missing from a catalog falls back to English.
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLanguage is the language of the source strings.
+const DefaultLanguage = "en"
+
+// LanguageEnv is the environment variable that selects the output language
+// when --lang is not given.
+const LanguageEnv = "REGULA_LANG"
+
+// ConfigFileName is the optional configuration file in the library
+// directory; its "language" key selects the output language.
+const ConfigFileName = "config.yaml"
+
+//go:embed catalogs/*.yaml
+var catalogFiles embed.FS
+
+// Translator translates messages into one language. A nil Translator
+// returns messages unchanged.
+type Translator struct {
+	language string
+	messages map[string]string
+}
+
+// New returns a Translator for the given language code ("de", "fr-FR",
+// "es_ES.UTF-8"). An empty code selects English.
+func New(language string) (*Translator, error) {
+	language = NormalizeLanguage(language)
+	if language == "" || language == DefaultLanguage {
+		return &Translator{language: DefaultLanguage}, nil
+	}
+
+	data, err := catalogFiles.ReadFile("catalogs/" + language + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("unsupported language %q (supported: %s)", language, strings.Join(SupportedLanguages(), ", "))
+	}
+	messages, err := ParseCatalog(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s catalog: %w", language, err)
+	}
+	return &Translator{language: language, messages: messages}, nil
+}
+
+// ParseCatalog parses a YAML message catalog of English source strings to
+// translations.
+func ParseCatalog(data []byte) (map[string]string, error) {
+	messages := make(map[string]string)
+	if err := yaml.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("failed to parse catalog: %w", err)
+	}
+	return messages, nil
+}
+
+// Language returns the translator's language code.
+func (translator *Translator) Language() string {
+	if translator == nil {
+		return DefaultLanguage
+	}
+	return translator.language
+}
+
+// T returns the translation of message, or message itself when the catalog
+// has no translation.
+func (translator *Translator) T(message string) string {
+	if translator == nil {
+		return message
+	}
+	if translated, ok := translator.messages[message]; ok && translated != "" {
+		return translated
+	}
+	return message
+}
+
+// Sprintf translates format and formats it with args.
+func (translator *Translator) Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(translator.T(format), args...)
+}
+
+// MarkdownTableHeader returns the translated header and separator rows of
+// a Markdown table with the given columns.
+func (translator *Translator) MarkdownTableHeader(columns ...string) string {
+	header := "|"
+	separator := "|"
+	for _, column := range columns {
+		label := translator.T(column)
+		header += " " + label + " |"
+		separator += strings.Repeat("-", len([]rune(label))+2) + "|"
+	}
+	return header + "\n" + separator + "\n"
+}
+
+// SupportedLanguages returns the available language codes, English first.
+func SupportedLanguages() []string {
+	languages := []string{DefaultLanguage}
+	entries, _ := catalogFiles.ReadDir("catalogs")
+	var translated []string
+	for _, entry := range entries {
+		translated = append(translated, strings.TrimSuffix(entry.Name(), ".yaml"))
+	}
+	sort.Strings(translated)
+	return append(languages, translated...)
+}
+
+// NormalizeLanguage reduces a locale such as "de_DE.UTF-8" or "fr-FR" to
+// its lowercase language code.
+func NormalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if index := strings.IndexAny(language, "_-.@"); index >= 0 {
+		language = language[:index]
+	}
+	return language
+}
+
+// config is the subset of the library configuration file read here.
+type config struct {
+	Language string `yaml:"language"`
+}
+
+// ResolveLanguage picks the output language: the explicit flag value, then
+// $REGULA_LANG, then the "language" key of config.yaml in libraryPath, and
+// finally English.
+func ResolveLanguage(flagValue string, libraryPath string) (string, error) {
+	if flagValue != "" {
+		return NormalizeLanguage(flagValue), nil
+	}
+	if envValue := os.Getenv(LanguageEnv); envValue != "" {
+		return NormalizeLanguage(envValue), nil
+	}
+
+	configPath := filepath.Join(libraryPath, ConfigFileName)
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return DefaultLanguage, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", configPath, err)
+	}
+	var libraryConfig config
+	if err := yaml.Unmarshal(data, &libraryConfig); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+	if libraryConfig.Language == "" {
+		return DefaultLanguage, nil
+	}
+	return NormalizeLanguage(libraryConfig.Language), nil
+}
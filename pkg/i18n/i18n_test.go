@@ -0,0 +1,204 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+var formatVerbPattern = regexp.MustCompile(`%[-+# 0]*[0-9]*(?:\.[0-9]+)?[a-zA-Z%]`)
+
+func TestNew_English(t *testing.T) {
+	for _, language := range []string{"", "en", "en_US.UTF-8"} {
+		translator, err := New(language)
+		if err != nil {
+			t.Fatalf("New(%q) failed: %v", language, err)
+		}
+		if translator.Language() != "en" {
+			t.Errorf("New(%q).Language() = %q, want en", language, translator.Language())
+		}
+		if got := translator.T("Validation Report"); got != "Validation Report" {
+			t.Errorf("English T() = %q, want source string", got)
+		}
+	}
+}
+
+func TestNew_Translates(t *testing.T) {
+	tests := []struct {
+		language string
+		want     string
+	}{
+		{"de", "Validierungsbericht"},
+		{"fr-FR", "Rapport de validation"},
+		{"ES", "Informe de validación"},
+	}
+
+	for _, tt := range tests {
+		translator, err := New(tt.language)
+		if err != nil {
+			t.Fatalf("New(%q) failed: %v", tt.language, err)
+		}
+		if got := translator.T("Validation Report"); got != tt.want {
+			t.Errorf("New(%q).T() = %q, want %q", tt.language, got, tt.want)
+		}
+	}
+}
+
+func TestNew_Unsupported(t *testing.T) {
+	if _, err := New("xx"); err == nil {
+		t.Error("expected error for unsupported language")
+	}
+}
+
+func TestTranslator_Fallback(t *testing.T) {
+	translator, err := New("de")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if got := translator.T("No such message"); got != "No such message" {
+		t.Errorf("missing message = %q, want English fallback", got)
+	}
+
+	var nilTranslator *Translator
+	if got := nilTranslator.Sprintf("Generated by regula on %s", "2026-01-01"); got != "Generated by regula on 2026-01-01" {
+		t.Errorf("nil translator Sprintf = %q", got)
+	}
+	if nilTranslator.Language() != "en" {
+		t.Errorf("nil translator Language = %q, want en", nilTranslator.Language())
+	}
+}
+
+func TestTranslator_Sprintf(t *testing.T) {
+	translator, err := New("fr")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if got := translator.Sprintf("Generated by regula on %s", "2026-01-01"); got != "Généré par regula le 2026-01-01" {
+		t.Errorf("Sprintf = %q", got)
+	}
+}
+
+func TestTranslator_MarkdownTableHeader(t *testing.T) {
+	var translator *Translator
+	if got := translator.MarkdownTableHeader("Term", "Count"); got != "| Term | Count |\n|------|-------|\n" {
+		t.Errorf("MarkdownTableHeader = %q", got)
+	}
+}
+
+func TestSupportedLanguages(t *testing.T) {
+	languages := SupportedLanguages()
+	want := []string{"en", "de", "es", "fr"}
+	if len(languages) != len(want) {
+		t.Fatalf("SupportedLanguages() = %v, want %v", languages, want)
+	}
+	for i := range want {
+		if languages[i] != want[i] {
+			t.Errorf("SupportedLanguages()[%d] = %q, want %q", i, languages[i], want[i])
+		}
+	}
+}
+
+// TestCatalogs_Consistent checks that every catalog translates the same
+// messages and that translations keep the format verbs of their source.
+func TestCatalogs_Consistent(t *testing.T) {
+	var referenceKeys map[string]string
+	for _, language := range SupportedLanguages()[1:] {
+		data, err := catalogFiles.ReadFile("catalogs/" + language + ".yaml")
+		if err != nil {
+			t.Fatalf("failed to read %s catalog: %v", language, err)
+		}
+		messages, err := ParseCatalog(data)
+		if err != nil {
+			t.Fatalf("failed to parse %s catalog: %v", language, err)
+		}
+
+		for source, translated := range messages {
+			if translated == "" {
+				t.Errorf("%s: empty translation for %q", language, source)
+			}
+			sourceVerbs := formatVerbPattern.FindAllString(source, -1)
+			translatedVerbs := formatVerbPattern.FindAllString(translated, -1)
+			if len(sourceVerbs) != len(translatedVerbs) {
+				t.Errorf("%s: %q has verbs %v, translation %q has %v", language, source, sourceVerbs, translated, translatedVerbs)
+				continue
+			}
+			for i := range sourceVerbs {
+				if sourceVerbs[i] != translatedVerbs[i] {
+					t.Errorf("%s: verb %d of %q is %s, translation uses %s", language, i, source, sourceVerbs[i], translatedVerbs[i])
+				}
+			}
+		}
+
+		if referenceKeys == nil {
+			referenceKeys = messages
+			continue
+		}
+		for source := range referenceKeys {
+			if _, ok := messages[source]; !ok {
+				t.Errorf("%s: missing translation for %q", language, source)
+			}
+		}
+		for source := range messages {
+			if _, ok := referenceKeys[source]; !ok {
+				t.Errorf("%s: unexpected message %q", language, source)
+			}
+		}
+	}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := map[string]string{
+		"de":          "de",
+		"DE":          "de",
+		"de_DE.UTF-8": "de",
+		"fr-CA":       "fr",
+		" es ":        "es",
+		"":            "",
+	}
+	for input, want := range tests {
+		if got := NormalizeLanguage(input); got != want {
+			t.Errorf("NormalizeLanguage(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestResolveLanguage(t *testing.T) {
+	libraryPath := t.TempDir()
+	t.Setenv(LanguageEnv, "")
+
+	language, err := ResolveLanguage("", libraryPath)
+	if err != nil || language != "en" {
+		t.Fatalf("no config: got %q, %v; want en", language, err)
+	}
+
+	configPath := filepath.Join(libraryPath, ConfigFileName)
+	if err := os.WriteFile(configPath, []byte("language: fr\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if language, _ := ResolveLanguage("", libraryPath); language != "fr" {
+		t.Errorf("config: got %q, want fr", language)
+	}
+
+	t.Setenv(LanguageEnv, "es_ES.UTF-8")
+	if language, _ := ResolveLanguage("", libraryPath); language != "es" {
+		t.Errorf("env: got %q, want es", language)
+	}
+
+	if language, _ := ResolveLanguage("de", libraryPath); language != "de" {
+		t.Errorf("flag: got %q, want de", language)
+	}
+}
+
+func TestResolveLanguage_InvalidConfig(t *testing.T) {
+	libraryPath := t.TempDir()
+	t.Setenv(LanguageEnv, "")
+
+	configPath := filepath.Join(libraryPath, ConfigFileName)
+	if err := os.WriteFile(configPath, []byte("language: [unclosed\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := ResolveLanguage("", libraryPath); err == nil {
+		t.Error("expected error for malformed config")
+	}
+}
@@ -0,0 +1,219 @@
+package inforce
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ClauseKind classifies a temporal clause of a legal text.
+type ClauseKind string
+
+const (
+	// ClauseEntryIntoForce is a clause fixing the date a text enters into
+	// force ("This Regulation shall enter into force on 1 January 2020").
+	ClauseEntryIntoForce ClauseKind = "entry_into_force"
+
+	// ClauseApplication is a clause fixing the date a text or some of its
+	// articles apply from ("It shall apply from 25 May 2018").
+	ClauseApplication ClauseKind = "application"
+
+	// ClauseSunset is a clause ending the force of a text or some of its
+	// articles ("This Act shall expire on 31 December 2030").
+	ClauseSunset ClauseKind = "sunset"
+)
+
+// Clause is a temporal clause found in the text of a provision.
+type Clause struct {
+	// Kind is what the clause fixes.
+	Kind ClauseKind `json:"kind"`
+
+	// Date is the date of the clause. For a sunset it is the first day the
+	// text is no longer in force, so "shall expire on 31 December 2030"
+	// has the date 1 January 2031.
+	Date time.Time `json:"date"`
+
+	// Articles and Chapters are the numbers of the articles and chapters
+	// the clause is limited to. Both are empty when the clause concerns the
+	// whole document.
+	Articles []string `json:"articles,omitempty"`
+	Chapters []string `json:"chapters,omitempty"`
+
+	// Text is the matched clause.
+	Text string `json:"text"`
+}
+
+// dateExpression matches "25 May 2018", "1st January 2020",
+// "May 25, 2018", and "2018-05-25".
+const dateExpression = `(\d{1,2}(?:st|nd|rd|th)?\s+(?:january|february|march|april|may|june|july|august|september|october|november|december)\s+\d{4}` +
+	`|(?:january|february|march|april|may|june|july|august|september|october|november|december)\s+\d{1,2}(?:st|nd|rd|th)?,?\s+\d{4}` +
+	`|\d{4}-\d{2}-\d{2})`
+
+// clauseSubject matches the document ("This Regulation", "It") or a list
+// of its articles ("Articles 8, 10 to 12 and 15") or chapters ("Chapters
+// I and II") as the subject of a clause. An article of another text
+// ("Article 45 of Directive 95/46/EC") does not match because the list
+// must be followed by "shall".
+const clauseSubject = `\b(this\s+(?:regulation|directive|decision|act|law|code|title)|it` +
+	`|articles?\s+(\d+[a-z]?(?:\s*(?:,|and|to)\s*\d+[a-z]?)*)` +
+	`|chapters?\s+([ivxlc]+|\d+)((?:\s*(?:,|and)\s*(?:[ivxlc]+|\d+)\b)*))\s+shall\s+`
+
+var (
+	entryIntoForcePattern = regexp.MustCompile(`(?i)` + clauseSubject +
+		`(?:enter\s+into\s+force|come\s+into\s+force|take\s+effect|come\s+into\s+operation|become\s+(?:effective|operative))\s+on\s+(?:the\s+)?` + dateExpression)
+	applicationPattern = regexp.MustCompile(`(?i)` + clauseSubject +
+		`(?:apply|be\s+applicable)\s+(?:from|as\s+from|as\s+of|with\s+effect\s+from)\s+` + dateExpression)
+	// sunsetInclusivePattern names the last day in force.
+	sunsetInclusivePattern = regexp.MustCompile(`(?i)` + clauseSubject +
+		`(?:expire|remain\s+in\s+force\s+until|apply\s+until)\s+(?:on\s+)?` + dateExpression)
+	// sunsetExclusivePattern names the first day no longer in force.
+	sunsetExclusivePattern = regexp.MustCompile(`(?i)` + clauseSubject +
+		`(?:cease\s+to\s+apply|cease\s+to\s+have\s+effect|cease\s+to\s+be\s+in\s+force)\s+(?:on|from|as\s+from)\s+` + dateExpression)
+)
+
+// ExtractClauses returns the entry-into-force, application, and sunset
+// clauses in text, in the order they appear.
+func ExtractClauses(text string) []Clause {
+	normalized := strings.Join(strings.Fields(text), " ")
+
+	type match struct {
+		start  int
+		clause Clause
+	}
+	var matches []match
+	for _, rule := range []struct {
+		kind      ClauseKind
+		pattern   *regexp.Regexp
+		inclusive bool
+	}{
+		{ClauseEntryIntoForce, entryIntoForcePattern, false},
+		{ClauseApplication, applicationPattern, false},
+		{ClauseSunset, sunsetInclusivePattern, true},
+		{ClauseSunset, sunsetExclusivePattern, false},
+	} {
+		for _, indexes := range rule.pattern.FindAllStringSubmatchIndex(normalized, -1) {
+			date, ok := ParseDate(normalized[indexes[10]:indexes[11]])
+			if !ok {
+				continue
+			}
+			if rule.inclusive {
+				date = date.AddDate(0, 0, 1)
+			}
+			clause := Clause{
+				Kind: rule.kind,
+				Date: date,
+				Text: normalized[indexes[0]:indexes[1]],
+			}
+			if indexes[4] >= 0 {
+				clause.Articles = parseNumberList(normalized[indexes[4]:indexes[5]])
+			}
+			if indexes[6] >= 0 {
+				clause.Chapters = parseNumberList(normalized[indexes[6]:indexes[9]])
+			}
+			// Parts of a document taking effect on a date apply from it.
+			if clause.Kind == ClauseEntryIntoForce && (len(clause.Articles) > 0 || len(clause.Chapters) > 0) {
+				clause.Kind = ClauseApplication
+			}
+			matches = append(matches, match{start: indexes[0], clause: clause})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+	clauses := make([]Clause, len(matches))
+	for i, m := range matches {
+		clauses[i] = m.clause
+	}
+	return clauses
+}
+
+// maxArticleRange is the longest article range expanded ("Articles 1 to
+// 99"); longer ranges are more likely misreadings than real clauses.
+const maxArticleRange = 500
+
+var articleListSeparator = regexp.MustCompile(`\s*(,|\band\b|\bto\b)\s*`)
+
+// parseNumberList expands a list of article or chapter numbers such as
+// "8, 10 to 12 and 15" into the numbers. Roman chapter numbers are
+// returned in upper case, as the builder records them.
+func parseNumberList(list string) []string {
+	items := articleListSeparator.Split(list, -1)
+	separators := articleListSeparator.FindAllStringSubmatch(list, -1)
+
+	var numbers []string
+	for i, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if isRomanNumeral(item) {
+			item = strings.ToUpper(item)
+		} else {
+			item = strings.ToLower(item)
+		}
+		if i > 0 && strings.TrimSpace(separators[i-1][1]) == "to" && len(numbers) > 0 {
+			from, fromErr := strconv.Atoi(numbers[len(numbers)-1])
+			to, toErr := strconv.Atoi(item)
+			if fromErr == nil && toErr == nil && to > from && to-from <= maxArticleRange {
+				for number := from + 1; number <= to; number++ {
+					numbers = append(numbers, strconv.Itoa(number))
+				}
+				continue
+			}
+		}
+		numbers = append(numbers, item)
+	}
+	return numbers
+}
+
+func isRomanNumeral(s string) bool {
+	return strings.Trim(strings.ToLower(s), "ivxlc") == ""
+}
+
+var monthNames = map[string]time.Month{
+	"january": time.January, "february": time.February, "march": time.March,
+	"april": time.April, "may": time.May, "june": time.June,
+	"july": time.July, "august": time.August, "september": time.September,
+	"october": time.October, "november": time.November, "december": time.December,
+}
+
+var dateFieldPattern = regexp.MustCompile(`[a-z]+|\d+`)
+
+// ParseDate parses an ISO date ("2018-05-25", optionally followed by a
+// time of day) or a date written out in English ("25 May 2018", "1st
+// January 2020", "May 25, 2018").
+func ParseDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) >= len(DateLayout) {
+		if date, err := time.Parse(DateLayout, value[:len(DateLayout)]); err == nil {
+			return date, true
+		}
+	}
+
+	var day, year int
+	var month time.Month
+	for _, field := range dateFieldPattern.FindAllString(strings.ToLower(value), -1) {
+		if m, ok := monthNames[field]; ok {
+			month = m
+			continue
+		}
+		number, err := strconv.Atoi(field)
+		if err != nil {
+			continue
+		}
+		if number > 31 {
+			year = number
+		} else if day == 0 {
+			day = number
+		}
+	}
+	if day == 0 || month == 0 || year == 0 {
+		return time.Time{}, false
+	}
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day {
+		return time.Time{}, false
+	}
+	return date, true
+}
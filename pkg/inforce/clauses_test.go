@@ -0,0 +1,90 @@
+package inforce
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestExtractClauses(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []Clause
+	}{
+		{
+			name: "GDPR final provisions",
+			text: "1.   This Regulation shall enter into force on the twentieth day\nfollowing that of its publication.\n2.   It shall apply from 25 May 2018.",
+			expected: []Clause{
+				{Kind: ClauseApplication, Date: date(2018, time.May, 25), Text: "It shall apply from 25 May 2018"},
+			},
+		},
+		{
+			name: "dated entry into force and sunset",
+			text: "This Regulation shall enter into force on 1st January 2020. This Regulation shall expire on 31 December 2030.",
+			expected: []Clause{
+				{Kind: ClauseEntryIntoForce, Date: date(2020, time.January, 1), Text: "This Regulation shall enter into force on 1st January 2020"},
+				{Kind: ClauseSunset, Date: date(2031, time.January, 1), Text: "This Regulation shall expire on 31 December 2030"},
+			},
+		},
+		{
+			name: "article and chapter application",
+			text: "Articles 8, 10 to 12 and 15a shall apply from 2 August 2026. Chapters I and II shall apply from 2 February 2025.",
+			expected: []Clause{
+				{Kind: ClauseApplication, Date: date(2026, time.August, 2), Articles: []string{"8", "10", "11", "12", "15a"},
+					Text: "Articles 8, 10 to 12 and 15a shall apply from 2 August 2026"},
+				{Kind: ClauseApplication, Date: date(2025, time.February, 2), Chapters: []string{"I", "II"},
+					Text: "Chapters I and II shall apply from 2 February 2025"},
+			},
+		},
+		{
+			name: "US style dates",
+			text: "(a) This title shall become operative on January 1, 2020. Section 5 shall cease to have effect on July 1, 2025.",
+			expected: []Clause{
+				{Kind: ClauseEntryIntoForce, Date: date(2020, time.January, 1), Text: "This title shall become operative on January 1, 2020"},
+			},
+		},
+		{
+			name: "article of another act",
+			text: "Article 45 of Directive 95/46/EC shall apply from 1 January 2020.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractClauses(tt.text)
+			if len(got) == 0 && len(tt.expected) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ExtractClauses() =\n%+v\nwant:\n%+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Time
+		ok       bool
+	}{
+		{"2018-05-25", date(2018, time.May, 25), true},
+		{"2018-05-25T00:00:00Z", date(2018, time.May, 25), true},
+		{"25 May 2018", date(2018, time.May, 25), true},
+		{"May 25, 2018", date(2018, time.May, 25), true},
+		{"3rd March 2021", date(2021, time.March, 3), true},
+		{"31 February 2021", time.Time{}, false},
+		{"within 30 days", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseDate(tt.input)
+		if ok != tt.ok || !got.Equal(tt.expected) {
+			t.Errorf("ParseDate(%q) = %v, %v, want %v, %v", tt.input, got, ok, tt.expected, tt.ok)
+		}
+	}
+}
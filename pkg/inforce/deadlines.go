@@ -0,0 +1,142 @@
+package inforce
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// DeadlineKind classifies a statutory deadline.
+type DeadlineKind string
+
+const (
+	// DeadlineReview is a review or report a text requires by a date.
+	DeadlineReview DeadlineKind = "review"
+
+	// DeadlineSunset is the day a text or some of its provisions cease to
+	// be in force.
+	DeadlineSunset DeadlineKind = "sunset"
+)
+
+// Deadline is a date fixed by the text of a provision.
+type Deadline struct {
+	Kind DeadlineKind `json:"kind"`
+	Date string       `json:"date"`
+
+	// Provision is the node the deadline concerns: the article imposing a
+	// review, or the document, chapter, or article that expires.
+	Provision string `json:"provision"`
+	Label     string `json:"label"`
+	Document  string `json:"document"`
+
+	// Bearer and Recurrence describe a review: who carries it out and how
+	// often it is repeated.
+	Bearer     string `json:"bearer,omitempty"`
+	Recurrence string `json:"recurrence,omitempty"`
+
+	// Text is the clause that sets the deadline.
+	Text string `json:"text"`
+}
+
+// DeadlineReport lists the statutory deadlines of a graph due on or after
+// a date, soonest first.
+type DeadlineReport struct {
+	After     string     `json:"after,omitempty"`
+	Deadlines []Deadline `json:"deadlines"`
+}
+
+// Deadlines returns the review and sunset deadlines due on or after after,
+// soonest first. A repeated review is listed once, at its next due date. A
+// zero after lists every deadline.
+func (r *Reasoner) Deadlines(after time.Time) *DeadlineReport {
+	report := &DeadlineReport{Deadlines: make([]Deadline, 0)}
+	if !after.IsZero() {
+		report.After = after.Format(DateLayout)
+	}
+	add := func(deadline Deadline, date time.Time) {
+		if after.IsZero() || !date.Before(after) {
+			deadline.Date = date.Format(DateLayout)
+			deadline.Label = r.label(deadline.Provision)
+			deadline.Document = r.label(r.documentOf(deadline.Provision))
+			report.Deadlines = append(report.Deadlines, deadline)
+		}
+	}
+
+	for _, review := range r.reviews {
+		deadline := Deadline{
+			Kind:      DeadlineReview,
+			Provision: review.article,
+			Bearer:    review.Bearer,
+			Text:      review.Text,
+		}
+		if review.Recurrence != nil {
+			deadline.Recurrence = review.Recurrence.Describe()
+		}
+		add(deadline, review.NextDue(after))
+	}
+
+	for uri, clauses := range r.documents {
+		if clauses.sunset != nil {
+			add(Deadline{Kind: DeadlineSunset, Provision: uri, Text: clauses.sunset.Text}, clauses.sunset.Date)
+		}
+	}
+	for uri, clauses := range r.parts {
+		if clauses.sunset != nil {
+			add(Deadline{Kind: DeadlineSunset, Provision: uri, Text: clauses.sunset.Text}, clauses.sunset.Date)
+		}
+	}
+
+	sort.Slice(report.Deadlines, func(i, j int) bool {
+		a, b := report.Deadlines[i], report.Deadlines[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.Provision != b.Provision {
+			return a.Provision < b.Provision
+		}
+		return a.Kind < b.Kind
+	})
+	return report
+}
+
+// ToJSON serializes the report to JSON.
+func (report *DeadlineReport) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// String returns the deadlines as a human-readable list.
+func (report *DeadlineReport) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("Statutory deadlines")
+	if report.After != "" {
+		sb.WriteString(" on or after " + report.After)
+	}
+	sb.WriteString(fmt.Sprintf(" (%d)\n", len(report.Deadlines)))
+	sb.WriteString(strings.Repeat("=", 60) + "\n")
+
+	if len(report.Deadlines) == 0 {
+		sb.WriteString("  None found.\n")
+	}
+	for _, deadline := range report.Deadlines {
+		name := deadline.Document
+		if deadline.Label != deadline.Document {
+			name += ", " + deadline.Label
+		}
+		sb.WriteString(fmt.Sprintf("  %s  %-6s  %s", deadline.Date, deadline.Kind, name))
+		if deadline.Bearer != "" {
+			sb.WriteString(" — " + deadline.Bearer)
+		}
+		if deadline.Recurrence != "" {
+			sb.WriteString(" (" + deadline.Recurrence + ")")
+		}
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("              %s\n", textutil.Truncate(deadline.Text, 100)))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,465 @@
+// Package inforce reasons about when the provisions of a regulation are in
+// force. It combines the entry-into-force, application, and sunset clauses
+// in a document's text with the validity, expiry, repeal, and amendment
+// triples in its graph, and lets provisions inherit the period of the
+// chapter or document that contains them.
+package inforce
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// DateLayout is the layout of the dates read and written by this package.
+const DateLayout = "2006-01-02"
+
+// maxPartOfDepth bounds the walk up reg:partOf links, in case of cycles.
+const maxPartOfDepth = 16
+
+// provisionClasses are the classes whose own validity triples are read.
+// Other nodes, such as references, carry dates that describe something
+// else (the version of a cited act) and only inherit a period.
+var provisionClasses = map[string]bool{
+	store.ClassRegulation: true,
+	store.ClassDirective:  true,
+	store.ClassDecision:   true,
+	store.ClassChapter:    true,
+	store.ClassSection:    true,
+	store.ClassArticle:    true,
+	store.ClassParagraph:  true,
+	store.ClassPoint:      true,
+	store.ClassSubPoint:   true,
+}
+
+// annotatedClasses are the classes given reg:inForceFrom and
+// reg:inForceUntil triples by Annotate; paragraphs and points share the
+// period of their article unless their own triples say otherwise.
+var annotatedClasses = []string{
+	store.ClassRegulation,
+	store.ClassDirective,
+	store.ClassDecision,
+	store.ClassChapter,
+	store.ClassSection,
+	store.ClassArticle,
+}
+
+// Interval is the period a provision is in force.
+type Interval struct {
+	// From is the first day in force, or zero if in force from the start.
+	From time.Time `json:"from,omitempty"`
+
+	// Until is the first day no longer in force, or zero if still in force.
+	Until time.Time `json:"until,omitempty"`
+
+	// FromSource and UntilSource describe where the dates come from.
+	FromSource  string `json:"from_source,omitempty"`
+	UntilSource string `json:"until_source,omitempty"`
+
+	// Amendments are the dated amendments of the provision, oldest first.
+	// Amendments change a provision's text, not the period it is in force.
+	Amendments []Amendment `json:"amendments,omitempty"`
+}
+
+// Amendment is an amendment of a provision that took effect on a date.
+type Amendment struct {
+	Date       time.Time `json:"date"`
+	Instrument string    `json:"instrument"`
+}
+
+// InForceOn reports whether the interval includes date.
+func (iv Interval) InForceOn(date time.Time) bool {
+	if !iv.From.IsZero() && iv.From.After(date) {
+		return false
+	}
+	return iv.Until.IsZero() || iv.Until.After(date)
+}
+
+// String describes the interval, e.g. "2018-05-25 to 2030-01-01".
+func (iv Interval) String() string {
+	from, until := "start", "open"
+	if !iv.From.IsZero() {
+		from = iv.From.Format(DateLayout)
+	}
+	if !iv.Until.IsZero() {
+		until = iv.Until.Format(DateLayout)
+	}
+	return fmt.Sprintf("%s to %s", from, until)
+}
+
+// restrict narrows the interval to start no earlier than from and end no
+// later than until, recording the sources of the dates that narrow it.
+func (iv *Interval) restrict(from time.Time, fromSource string, until time.Time, untilSource string) {
+	if !from.IsZero() && (iv.From.IsZero() || from.After(iv.From)) {
+		iv.From, iv.FromSource = from, fromSource
+	}
+	if !until.IsZero() && (iv.Until.IsZero() || until.Before(iv.Until)) {
+		iv.Until, iv.UntilSource = until, untilSource
+	}
+}
+
+// documentClauses are the document-wide clauses of a document.
+type documentClauses struct {
+	entryIntoForce, application, sunset *sourcedClause
+}
+
+// partClauses are the clauses limited to one article or chapter.
+type partClauses struct {
+	document            string
+	application, sunset *sourcedClause
+}
+
+// sourcedClause is a clause and the article it was found in.
+type sourcedClause struct {
+	Clause
+	article string
+}
+
+func (c *sourcedClause) source() string {
+	return fmt.Sprintf("%s (%s)", c.Kind, c.article)
+}
+
+// sourcedReview is a review clause and the URI of the article it was found
+// in.
+type sourcedReview struct {
+	ReviewClause
+	article string
+}
+
+// Reasoner computes the periods in force of the nodes of a graph.
+type Reasoner struct {
+	store     *store.TripleStore
+	documents map[string]*documentClauses
+	parts     map[string]*partClauses
+	reviews   []sourcedReview
+	intervals map[string]Interval
+}
+
+// NewReasoner creates a Reasoner for the graph in ts, reading the temporal
+// and review clauses in the text of its articles.
+func NewReasoner(ts *store.TripleStore) *Reasoner {
+	reasoner := &Reasoner{
+		store:     ts,
+		documents: make(map[string]*documentClauses),
+		parts:     make(map[string]*partClauses),
+		intervals: make(map[string]Interval),
+	}
+	reasoner.readClauses()
+	return reasoner
+}
+
+// readClauses reads the clauses of every article. The first clause of
+// each kind wins, with articles taken in URI order.
+func (r *Reasoner) readClauses() {
+	articleURIs := r.subjectsOfType(store.ClassArticle)
+
+	// Article and chapter numbers are resolved within the document of the
+	// clause.
+	articlesByNumber := r.indexByNumber(articleURIs)
+	chaptersByNumber := r.indexByNumber(r.subjectsOfType(store.ClassChapter))
+
+	for _, uri := range articleURIs {
+		text := r.store.GetOne(uri, store.PropText)
+		if text == "" {
+			continue
+		}
+		for _, review := range ExtractReviews(text) {
+			r.reviews = append(r.reviews, sourcedReview{ReviewClause: review, article: uri})
+		}
+		document := r.documentOf(uri)
+		for _, clause := range ExtractClauses(text) {
+			found := &sourcedClause{Clause: clause, article: r.label(uri)}
+			if len(clause.Articles) == 0 && len(clause.Chapters) == 0 {
+				clauses := r.documents[document]
+				if clauses == nil {
+					clauses = &documentClauses{}
+					r.documents[document] = clauses
+				}
+				setFirst(clauseSlot(clauses, clause.Kind), found)
+				continue
+			}
+
+			var targets []string
+			for _, number := range clause.Articles {
+				if target, ok := articlesByNumber[document][number]; ok {
+					targets = append(targets, target)
+				}
+			}
+			for _, number := range clause.Chapters {
+				if target, ok := chaptersByNumber[document][number]; ok {
+					targets = append(targets, target)
+				}
+			}
+			for _, target := range targets {
+				clauses := r.parts[target]
+				if clauses == nil {
+					clauses = &partClauses{document: document}
+					r.parts[target] = clauses
+				}
+				switch clause.Kind {
+				case ClauseApplication:
+					setFirst(&clauses.application, found)
+				case ClauseSunset:
+					setFirst(&clauses.sunset, found)
+				}
+			}
+		}
+	}
+}
+
+// subjectsOfType returns the nodes of a class in URI order.
+func (r *Reasoner) subjectsOfType(class string) []string {
+	var uris []string
+	for _, triple := range r.store.Find("", store.RDFType, class) {
+		uris = append(uris, triple.Subject)
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// indexByNumber maps each document to the nodes in uris by their number.
+func (r *Reasoner) indexByNumber(uris []string) map[string]map[string]string {
+	index := make(map[string]map[string]string)
+	for _, uri := range uris {
+		document := r.documentOf(uri)
+		if index[document] == nil {
+			index[document] = make(map[string]string)
+		}
+		if number := r.store.GetOne(uri, store.PropNumber); number != "" {
+			index[document][number] = uri
+		}
+	}
+	return index
+}
+
+func clauseSlot(clauses *documentClauses, kind ClauseKind) **sourcedClause {
+	switch kind {
+	case ClauseEntryIntoForce:
+		return &clauses.entryIntoForce
+	case ClauseApplication:
+		return &clauses.application
+	default:
+		return &clauses.sunset
+	}
+}
+
+func setFirst(slot **sourcedClause, clause *sourcedClause) {
+	if *slot == nil {
+		*slot = clause
+	}
+}
+
+// Interval returns the period uri is in force: the period of the node
+// containing it, narrowed by the clauses and triples about uri itself.
+// Nodes that nothing dates are always in force.
+func (r *Reasoner) Interval(uri string) Interval {
+	return r.interval(uri, 0)
+}
+
+// InForceOn reports whether uri is in force on date.
+func (r *Reasoner) InForceOn(uri string, date time.Time) bool {
+	return r.Interval(uri).InForceOn(date)
+}
+
+func (r *Reasoner) interval(uri string, depth int) Interval {
+	if iv, ok := r.intervals[uri]; ok {
+		return iv
+	}
+
+	var iv Interval
+	if parent := r.store.GetOne(uri, store.PropPartOf); parent != "" && parent != uri && depth < maxPartOfDepth {
+		iv = r.interval(parent, depth+1)
+		iv.Amendments = nil
+	}
+
+	if clauses, ok := r.documents[uri]; ok {
+		// A document applies once it is both in force and applicable.
+		for _, clause := range []*sourcedClause{clauses.entryIntoForce, clauses.application} {
+			if clause != nil {
+				iv.restrict(clause.Date, clause.source(), time.Time{}, "")
+			}
+		}
+		if clauses.sunset != nil {
+			iv.restrict(time.Time{}, "", clauses.sunset.Date, clauses.sunset.source())
+		}
+	}
+
+	if clauses, ok := r.parts[uri]; ok {
+		// An article's or chapter's own application date replaces the
+		// document's, which may be later, but it still needs the document
+		// in force.
+		if clauses.application != nil {
+			iv.From, iv.FromSource = clauses.application.Date, clauses.application.source()
+			if document := r.documents[clauses.document]; document != nil && document.entryIntoForce != nil {
+				iv.restrict(document.entryIntoForce.Date, document.entryIntoForce.source(), time.Time{}, "")
+			}
+		}
+		if clauses.sunset != nil {
+			iv.restrict(time.Time{}, "", clauses.sunset.Date, clauses.sunset.source())
+		}
+	}
+
+	if r.isProvision(uri) {
+		r.applyTriples(uri, &iv)
+	}
+
+	r.intervals[uri] = iv
+	return iv
+}
+
+// applyTriples narrows iv by the validity, expiry, and repeal triples of
+// uri and records its dated amendments.
+func (r *Reasoner) applyTriples(uri string, iv *Interval) {
+	for _, predicate := range []string{store.PropValidFrom, store.PropEffectiveDate} {
+		if date, ok := r.firstDate(uri, predicate); ok {
+			iv.restrict(date, predicate, time.Time{}, "")
+		}
+	}
+	for _, predicate := range []string{store.PropValidUntil, store.PropExpiryDate} {
+		if date, ok := r.firstDate(uri, predicate); ok {
+			iv.restrict(time.Time{}, "", date, predicate)
+		}
+	}
+
+	for _, triple := range r.store.Find(uri, store.PropRepealedBy, "") {
+		if date, ok := r.instrumentDate(triple.Object); ok {
+			iv.restrict(time.Time{}, "", date, "repealed by "+r.label(triple.Object))
+		}
+	}
+	for _, triple := range r.store.Find(uri, store.PropAmendedBy, "") {
+		if date, ok := r.instrumentDate(triple.Object); ok {
+			iv.Amendments = append(iv.Amendments, Amendment{Date: date, Instrument: r.label(triple.Object)})
+		}
+	}
+	sort.Slice(iv.Amendments, func(i, j int) bool {
+		if !iv.Amendments[i].Date.Equal(iv.Amendments[j].Date) {
+			return iv.Amendments[i].Date.Before(iv.Amendments[j].Date)
+		}
+		return iv.Amendments[i].Instrument < iv.Amendments[j].Instrument
+	})
+}
+
+// instrumentDate returns the date an amending or repealing instrument took
+// effect. The builder also links articles to the references through which
+// they cite an amended or repealed act; those describe the cited act, not
+// the article, and are skipped.
+func (r *Reasoner) instrumentDate(instrument string) (time.Time, bool) {
+	if r.store.Exists(instrument, store.RDFType, store.ClassReference) {
+		return time.Time{}, false
+	}
+	for _, predicate := range []string{store.PropEffectiveDate, store.PropValidFrom, store.PropAdoptedDate} {
+		if date, ok := r.firstDate(instrument, predicate); ok {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// firstDate returns the earliest parseable date among the objects of
+// predicate on uri.
+func (r *Reasoner) firstDate(uri, predicate string) (time.Time, bool) {
+	var first time.Time
+	for _, triple := range r.store.Find(uri, predicate, "") {
+		if date, ok := ParseDate(triple.Object); ok && (first.IsZero() || date.Before(first)) {
+			first = date
+		}
+	}
+	return first, !first.IsZero()
+}
+
+func (r *Reasoner) isProvision(uri string) bool {
+	for _, triple := range r.store.Find(uri, store.RDFType, "") {
+		if provisionClasses[triple.Object] {
+			return true
+		}
+	}
+	return false
+}
+
+// documentOf returns the outermost node containing uri.
+func (r *Reasoner) documentOf(uri string) string {
+	for depth := 0; depth < maxPartOfDepth; depth++ {
+		parent := r.store.GetOne(uri, store.PropPartOf)
+		if parent == "" || parent == uri {
+			break
+		}
+		uri = parent
+	}
+	return uri
+}
+
+// label names a node in interval sources.
+func (r *Reasoner) label(uri string) string {
+	if label := r.store.GetOne(uri, store.RDFSLabel); label != "" {
+		return label
+	}
+	for i := len(uri) - 1; i >= 0; i-- {
+		if uri[i] == '/' || uri[i] == '#' {
+			return uri[i+1:]
+		}
+	}
+	return uri
+}
+
+// Annotate adds reg:inForceFrom and reg:inForceUntil triples to the
+// regulation, chapters, sections, and articles of the graph whose period
+// is bounded, and a reg:ReviewObligation node for each review clause, and
+// returns the number of triples added.
+func (r *Reasoner) Annotate() int {
+	added := 0
+	for _, class := range annotatedClasses {
+		for _, triple := range r.store.Find("", store.RDFType, class) {
+			iv := r.Interval(triple.Subject)
+			if !iv.From.IsZero() && !r.store.Exists(triple.Subject, store.PropInForceFrom, iv.From.Format(DateLayout)) {
+				r.store.Add(triple.Subject, store.PropInForceFrom, iv.From.Format(DateLayout))
+				added++
+			}
+			if !iv.Until.IsZero() && !r.store.Exists(triple.Subject, store.PropInForceUntil, iv.Until.Format(DateLayout)) {
+				r.store.Add(triple.Subject, store.PropInForceUntil, iv.Until.Format(DateLayout))
+				added++
+			}
+		}
+	}
+
+	reviewCounts := make(map[string]int)
+	for _, review := range r.reviews {
+		reviewCounts[review.article]++
+		reviewURI := fmt.Sprintf("%s:Review:%d", review.article, reviewCounts[review.article])
+		if r.store.Exists(reviewURI, store.RDFType, store.ClassReviewObligation) {
+			continue
+		}
+		r.store.Add(reviewURI, store.RDFType, store.ClassReviewObligation)
+		r.store.Add(reviewURI, store.PropDueDate, review.Date.Format(DateLayout))
+		r.store.Add(reviewURI, store.PropText, review.Text)
+		r.store.Add(reviewURI, store.PropPartOf, review.article)
+		added += 4
+		if review.Bearer != "" {
+			r.store.Add(reviewURI, store.PropDutyBearer, review.Bearer)
+			added++
+		}
+		if review.Recurrence != nil {
+			r.store.Add(reviewURI, store.PropRecurrence, review.Recurrence.Rule())
+			added++
+		}
+	}
+	return added
+}
+
+// FilterAsOf returns a new graph without the nodes that are not in force
+// on date, or any triple mentioning them.
+func (r *Reasoner) FilterAsOf(date time.Time) *store.TripleStore {
+	excluded := make(map[string]bool)
+	isExcluded := func(node string) bool {
+		value, ok := excluded[node]
+		if !ok {
+			value = !r.InForceOn(node, date)
+			excluded[node] = value
+		}
+		return value
+	}
+	return r.store.Filter(func(triple store.Triple) bool {
+		return !isExcluded(triple.Subject) && !isExcluded(triple.Object)
+	})
+}
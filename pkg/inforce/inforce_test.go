@@ -0,0 +1,141 @@
+package inforce
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+const testBase = "https://regula.dev/regulations/TEST"
+
+// testStore builds a regulation with two chapters. Article 10 in chapter
+// II carries the final provisions: the regulation applies from 1 March
+// 2020, Article 2 from 1 January 2019, chapter II until 2030, and Article
+// 3 is repealed by a dated instrument.
+func testStore() *store.TripleStore {
+	ts := store.NewTripleStore()
+	ts.Add(testBase, store.RDFType, store.ClassRegulation)
+	for _, chapter := range []struct{ id, number string }{{"ChapterI", "I"}, {"ChapterII", "II"}} {
+		uri := testBase + ":" + chapter.id
+		ts.Add(uri, store.RDFType, store.ClassChapter)
+		ts.Add(uri, store.PropNumber, chapter.number)
+		ts.Add(uri, store.PropPartOf, testBase)
+	}
+	for _, article := range []struct{ number, chapter string }{
+		{"1", "ChapterI"}, {"2", "ChapterI"}, {"3", "ChapterI"}, {"10", "ChapterII"},
+	} {
+		uri := testBase + ":Art" + article.number
+		ts.Add(uri, store.RDFType, store.ClassArticle)
+		ts.Add(uri, store.PropNumber, article.number)
+		ts.Add(uri, store.PropPartOf, testBase+":"+article.chapter)
+	}
+	ts.Add(testBase+":Art10", store.PropText, "This Regulation shall enter into force on 1 January 2019. "+
+		"It shall apply from 1 March 2020. However, Article 2 shall apply from 1 January 2019. "+
+		"Chapter II shall cease to apply on 1 January 2030.")
+	ts.Add(testBase+":Art1:Para1", store.RDFType, store.ClassParagraph)
+	ts.Add(testBase+":Art1:Para1", store.PropPartOf, testBase+":Art1")
+
+	repeal := "https://regula.dev/regulations/REPEAL"
+	ts.Add(testBase+":Art3", store.PropRepealedBy, repeal)
+	ts.Add(repeal, store.PropAdoptedDate, "2024-06-30")
+
+	// Citing a repealed act does not repeal the citing article.
+	ts.Add(testBase+":Art1", store.PropRepealedBy, testBase+":Ref:1")
+	ts.Add(testBase+":Ref:1", store.RDFType, store.ClassReference)
+	ts.Add(testBase+":Ref:1", store.PropEffectiveDate, "2021-01-01")
+	return ts
+}
+
+func TestReasoner_Interval(t *testing.T) {
+	reasoner := NewReasoner(testStore())
+
+	tests := []struct {
+		uri              string
+		from, until      string
+		fromSource       string
+		inForceOn, notOn string
+	}{
+		{testBase, "2020-03-01", "", "application (TEST:Art10)", "2020-03-01", "2020-02-29"},
+		{testBase + ":Art1", "2020-03-01", "", "application (TEST:Art10)", "2025-01-01", "2019-06-01"},
+		{testBase + ":Art1:Para1", "2020-03-01", "", "application (TEST:Art10)", "2025-01-01", "2019-06-01"},
+		{testBase + ":Art2", "2019-01-01", "", "application (TEST:Art10)", "2019-06-01", "2018-12-31"},
+		{testBase + ":Art3", "2020-03-01", "2024-06-30", "application (TEST:Art10)", "2024-06-29", "2024-06-30"},
+		{testBase + ":Art10", "2020-03-01", "2030-01-01", "application (TEST:Art10)", "2029-12-31", "2030-01-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			iv := reasoner.Interval(tt.uri)
+			if got := formatDate(iv.From); got != tt.from {
+				t.Errorf("From = %q, want %q", got, tt.from)
+			}
+			if got := formatDate(iv.Until); got != tt.until {
+				t.Errorf("Until = %q, want %q", got, tt.until)
+			}
+			if iv.FromSource != tt.fromSource {
+				t.Errorf("FromSource = %q, want %q", iv.FromSource, tt.fromSource)
+			}
+			if on := mustParse(t, tt.inForceOn); !reasoner.InForceOn(tt.uri, on) {
+				t.Errorf("Expected in force on %s, interval %s", tt.inForceOn, iv)
+			}
+			if off := mustParse(t, tt.notOn); reasoner.InForceOn(tt.uri, off) {
+				t.Errorf("Expected not in force on %s, interval %s", tt.notOn, iv)
+			}
+		})
+	}
+
+	if until := reasoner.Interval(testBase + ":Art3").UntilSource; until != "repealed by REPEAL" {
+		t.Errorf("UntilSource = %q, want repealed by REPEAL", until)
+	}
+}
+
+func TestReasoner_Annotate(t *testing.T) {
+	ts := testStore()
+	reasoner := NewReasoner(ts)
+
+	added := reasoner.Annotate()
+	if added == 0 {
+		t.Fatal("Expected in-force triples to be added")
+	}
+	if got := ts.GetOne(testBase+":Art2", store.PropInForceFrom); got != "2019-01-01" {
+		t.Errorf("Art2 inForceFrom = %q, want 2019-01-01", got)
+	}
+	if got := ts.GetOne(testBase+":ChapterII", store.PropInForceUntil); got != "2030-01-01" {
+		t.Errorf("ChapterII inForceUntil = %q, want 2030-01-01", got)
+	}
+	if ts.Exists(testBase+":Art1:Para1", store.PropInForceFrom, "2020-03-01") {
+		t.Error("Expected paragraphs to share their article's triples rather than get their own")
+	}
+	if again := reasoner.Annotate(); again != 0 {
+		t.Errorf("Expected a second Annotate to add nothing, added %d", again)
+	}
+}
+
+func TestReasoner_FilterAsOf(t *testing.T) {
+	filtered := NewReasoner(testStore()).FilterAsOf(mustParse(t, "2019-06-01"))
+
+	if !filtered.Exists(testBase+":Art2", store.RDFType, store.ClassArticle) {
+		t.Error("Expected Art2, which applies early, to be kept")
+	}
+	for _, uri := range []string{testBase + ":Art1", testBase + ":Art1:Para1", testBase + ":Art10"} {
+		if len(filtered.Find(uri, "", "")) > 0 || len(filtered.Find("", "", uri)) > 0 {
+			t.Errorf("Expected every triple mentioning %s to be dropped", uri)
+		}
+	}
+}
+
+func formatDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(DateLayout)
+}
+
+func mustParse(t *testing.T, value string) time.Time {
+	t.Helper()
+	date, err := time.Parse(DateLayout, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return date
+}
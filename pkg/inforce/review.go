@@ -0,0 +1,79 @@
+package inforce
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/extract"
+)
+
+// ReviewClause is a mandated review of, or report on, a text due by a date
+// ("By 25 May 2020 and every four years thereafter, the Commission shall
+// submit a report on the evaluation and review of this Regulation").
+type ReviewClause struct {
+	// Date is the date the first review is due.
+	Date time.Time `json:"date"`
+
+	// Bearer is who must carry out the review ("Commission"), when named.
+	Bearer string `json:"bearer,omitempty"`
+
+	// Recurrence is how often the review is repeated after Date, or nil for
+	// a single review.
+	Recurrence *extract.Recurrence `json:"recurrence,omitempty"`
+
+	// Text is the sentence of the clause.
+	Text string `json:"text"`
+}
+
+var (
+	// reviewDutyPattern matches a duty to review, evaluate, or report.
+	reviewDutyPattern = regexp.MustCompile(`(?i)\bshall\b.*\b(?:review|evaluat(?:e|ion)|report|assess(?:ment)?)\b`)
+	// reviewDeadlinePattern matches the date a review is due.
+	reviewDeadlinePattern = regexp.MustCompile(`(?i)\b(?:by|before|on\s+or\s+before|no\s+later\s+than|not\s+later\s+than)\s+(?:the\s+)?` + dateExpression)
+	// reviewBearerPattern matches the capitalized name before "shall", such
+	// as "the Commission" or "The Secretary of Commerce".
+	reviewBearerPattern = regexp.MustCompile(`((?:[A-Z][\w-]*\s+(?:of\s+(?:the\s+)?)?){0,3}[A-Z][\w-]*)\s+shall\b`)
+	sentenceEndPattern  = regexp.MustCompile(`[.;]\s+`)
+)
+
+// ExtractReviews returns the review clauses in text, one per sentence that
+// both imposes a review or report and dates it, in the order they appear.
+func ExtractReviews(text string) []ReviewClause {
+	normalized := strings.Join(strings.Fields(text), " ")
+
+	var reviews []ReviewClause
+	for _, sentence := range sentenceEndPattern.Split(normalized, -1) {
+		if !reviewDutyPattern.MatchString(sentence) {
+			continue
+		}
+		match := reviewDeadlinePattern.FindStringSubmatch(sentence)
+		if match == nil {
+			continue
+		}
+		date, ok := ParseDate(match[1])
+		if !ok {
+			continue
+		}
+		review := ReviewClause{
+			Date:       date,
+			Recurrence: extract.ExtractRecurrence(sentence),
+			Text:       strings.TrimSpace(sentence),
+		}
+		if bearer := reviewBearerPattern.FindStringSubmatch(sentence); bearer != nil {
+			review.Bearer = strings.TrimPrefix(bearer[1], "The ")
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews
+}
+
+// NextDue returns the first date on or after after that the review is due:
+// Date for a single review, or the next recurrence of a repeated one. A
+// zero after returns Date.
+func (review ReviewClause) NextDue(after time.Time) time.Time {
+	if review.Recurrence == nil || after.IsZero() || !review.Date.Before(after) {
+		return review.Date
+	}
+	return review.Recurrence.Next(review.Date, after.AddDate(0, 0, -1))
+}
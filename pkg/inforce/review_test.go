@@ -0,0 +1,111 @@
+package inforce
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func TestExtractReviews(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		want       []time.Time
+		bearer     string
+		recurrence string
+	}{
+		{
+			name:       "recurring report",
+			text:       "1. By 25 May 2020 and every four years thereafter, the Commission shall submit a report on the evaluation and review of this Regulation to the European Parliament and to the Council. The reports shall be made public.",
+			want:       []time.Time{date(2020, time.May, 25)},
+			bearer:     "Commission",
+			recurrence: "FREQ=YEARLY;INTERVAL=4",
+		},
+		{
+			name:   "deadline after the duty",
+			text:   "The Secretary of Commerce shall review the operation of this Act not later than January 1, 2027; the review shall be published.",
+			want:   []time.Time{date(2027, time.January, 1)},
+			bearer: "Secretary of Commerce",
+		},
+		{
+			name: "review without a date",
+			text: "The Commission shall review this Regulation periodically.",
+		},
+		{
+			name: "date without a review",
+			text: "Member States shall notify those provisions to the Commission by 25 May 2018.",
+		},
+		{
+			name: "one clause per sentence",
+			text: "The Board shall evaluate Article 5 by 1 June 2026. The Board shall report on Article 6 no later than 1 June 2027.",
+			want: []time.Time{date(2026, time.June, 1), date(2027, time.June, 1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviews := ExtractReviews(tt.text)
+			if len(reviews) != len(tt.want) {
+				t.Fatalf("got %d reviews, want %d: %+v", len(reviews), len(tt.want), reviews)
+			}
+			for i, review := range reviews {
+				if !review.Date.Equal(tt.want[i]) {
+					t.Errorf("review %d due %s, want %s", i, formatDate(review.Date), formatDate(tt.want[i]))
+				}
+			}
+			if len(reviews) == 0 {
+				return
+			}
+			if tt.bearer != "" && reviews[0].Bearer != tt.bearer {
+				t.Errorf("bearer = %q, want %q", reviews[0].Bearer, tt.bearer)
+			}
+			rule := ""
+			if reviews[0].Recurrence != nil {
+				rule = reviews[0].Recurrence.Rule()
+			}
+			if rule != tt.recurrence {
+				t.Errorf("recurrence = %q, want %q", rule, tt.recurrence)
+			}
+		})
+	}
+}
+
+func TestReasoner_Deadlines(t *testing.T) {
+	ts := testStore()
+	ts.Add(testBase+":Art3", store.PropText, "By 1 March 2021 and every two years thereafter, the Commission shall report on the application of this Regulation.")
+	reasoner := NewReasoner(ts)
+
+	report := reasoner.Deadlines(mustParse(t, "2025-01-01"))
+	var got []string
+	for _, deadline := range report.Deadlines {
+		got = append(got, string(deadline.Kind)+" "+deadline.Date+" "+deadline.Label)
+	}
+	want := []string{"review 2025-03-01 TEST:Art3", "sunset 2030-01-01 TEST:ChapterII"}
+	if strings.Join(got, "; ") != strings.Join(want, "; ") {
+		t.Errorf("deadlines = %v, want %v", got, want)
+	}
+	if report.Deadlines[0].Bearer != "Commission" || report.Deadlines[0].Recurrence != "every 2 years" {
+		t.Errorf("review = %+v", report.Deadlines[0])
+	}
+
+	if later := reasoner.Deadlines(mustParse(t, "2030-01-02")); len(later.Deadlines) != 1 || later.Deadlines[0].Date != "2031-03-01" {
+		t.Errorf("deadlines after the sunset = %+v, want only the next review", later.Deadlines)
+	}
+	if !strings.Contains(report.String(), "2030-01-01  sunset  TEST, TEST:ChapterII") {
+		t.Errorf("unexpected text report:\n%s", report.String())
+	}
+
+	reasoner.Annotate()
+	reviewURI := testBase + ":Art3:Review:1"
+	if !ts.Exists(reviewURI, store.RDFType, store.ClassReviewObligation) {
+		t.Fatal("expected a review obligation node")
+	}
+	if due := ts.GetOne(reviewURI, store.PropDueDate); due != "2021-03-01" {
+		t.Errorf("due date = %q, want 2021-03-01", due)
+	}
+	if rule := ts.GetOne(reviewURI, store.PropRecurrence); rule != "FREQ=YEARLY;INTERVAL=2" {
+		t.Errorf("recurrence = %q", rule)
+	}
+}
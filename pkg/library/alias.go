@@ -0,0 +1,125 @@
+package library
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// AddAlias makes alias another ID for the canonical document. The alias may
+// be a new name, or the ID of a duplicate document (see FindDuplicates): the
+// duplicate then stays in the library but is left out of ReadyDocumentIDs,
+// and merges load the canonical document in its place, so queries see the
+// law once.
+func (lib *Library) AddAlias(alias, canonicalID string) error {
+	if !collectionNamePattern.MatchString(alias) {
+		return fmt.Errorf("invalid alias %q: use lowercase letters, digits, '.', '-', and '_'", alias)
+	}
+	if alias == canonicalID {
+		return fmt.Errorf("%s cannot be an alias of itself", alias)
+	}
+
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	if lib.findDocumentUnsafe(canonicalID) == nil {
+		return fmt.Errorf("document not found: %s", canonicalID)
+	}
+	if target, found := lib.manifest.Aliases[canonicalID]; found {
+		return fmt.Errorf("%s is itself an alias of %s", canonicalID, target)
+	}
+	if target, found := lib.manifest.Aliases[alias]; found && target != canonicalID {
+		return fmt.Errorf("%s is already an alias of %s", alias, target)
+	}
+	for other, target := range lib.manifest.Aliases {
+		if target == alias {
+			return fmt.Errorf("%s has aliases of its own (%s)", alias, other)
+		}
+	}
+
+	if lib.manifest.Aliases == nil {
+		lib.manifest.Aliases = make(map[string]string)
+	}
+	lib.manifest.Aliases[alias] = canonicalID
+	return lib.saveAliasesUnsafe()
+}
+
+// RemoveAlias removes an alias. A duplicate document it named becomes a
+// separate document again.
+func (lib *Library) RemoveAlias(alias string) error {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	if _, found := lib.manifest.Aliases[alias]; !found {
+		return fmt.Errorf("alias not found: %s", alias)
+	}
+	delete(lib.manifest.Aliases, alias)
+	return lib.saveAliasesUnsafe()
+}
+
+// Aliases returns a copy of the alias map, alias to canonical document ID.
+func (lib *Library) Aliases() map[string]string {
+	lib.mu.RLock()
+	defer lib.mu.RUnlock()
+	return maps.Clone(lib.manifest.Aliases)
+}
+
+// ResolveDocumentID returns the canonical document ID for an alias, or
+// documentID unchanged when it is not one.
+func (lib *Library) ResolveDocumentID(documentID string) string {
+	lib.mu.RLock()
+	defer lib.mu.RUnlock()
+	return lib.resolveDocumentIDUnsafe(documentID)
+}
+
+func (lib *Library) resolveDocumentIDUnsafe(documentID string) string {
+	if canonicalID, found := lib.manifest.Aliases[documentID]; found {
+		return canonicalID
+	}
+	return documentID
+}
+
+// lookupDocumentUnsafe finds a document by ID, falling back to the
+// canonical document when documentID is an alias without a document of its
+// own.
+func (lib *Library) lookupDocumentUnsafe(documentID string) *DocumentEntry {
+	if entry := lib.findDocumentUnsafe(documentID); entry != nil {
+		return entry
+	}
+	if canonicalID, found := lib.manifest.Aliases[documentID]; found {
+		return lib.findDocumentUnsafe(canonicalID)
+	}
+	return nil
+}
+
+// resolveDocumentIDs maps aliases to their canonical documents and drops
+// the repeats that leaves, keeping the first occurrence of each.
+func (lib *Library) resolveDocumentIDs(documentIDs []string) []string {
+	lib.mu.RLock()
+	defer lib.mu.RUnlock()
+
+	resolved := make([]string, 0, len(documentIDs))
+	for _, documentID := range documentIDs {
+		canonicalID := lib.resolveDocumentIDUnsafe(documentID)
+		if !slices.Contains(resolved, canonicalID) {
+			resolved = append(resolved, canonicalID)
+		}
+	}
+	return resolved
+}
+
+// removeAliasesOfUnsafe removes the aliases of a document being removed.
+func (lib *Library) removeAliasesOfUnsafe(documentID string) {
+	maps.DeleteFunc(lib.manifest.Aliases, func(alias, canonicalID string) bool {
+		return canonicalID == documentID
+	})
+}
+
+func (lib *Library) saveAliasesUnsafe() error {
+	lib.manifest.UpdatedAt = time.Now().UTC()
+	if err := lib.saveManifest(); err != nil {
+		return fmt.Errorf("failed to save manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package library
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAliases(t *testing.T) {
+	lib := setupMergeTestLibrary(t)
+	if _, err := lib.AddDocument("doc-a-copy", []byte(mergeTestDocumentA), AddOptions{Format: "us", BaseURI: lib.BaseURI()}); err != nil {
+		t.Fatalf("AddDocument failed: %v", err)
+	}
+
+	if err := lib.AddAlias("doc-a-copy", "doc-a"); err != nil {
+		t.Fatalf("AddAlias(doc-a-copy) failed: %v", err)
+	}
+	if err := lib.AddAlias("state-privacy", "doc-a"); err != nil {
+		t.Fatalf("AddAlias(state-privacy) failed: %v", err)
+	}
+
+	// The aliased duplicate drops out of the ready documents, and merges
+	// load the canonical document once for all of its IDs.
+	if got := lib.ReadyDocumentIDs(); slices.Contains(got, "doc-a-copy") || len(got) != 2 {
+		t.Errorf("ReadyDocumentIDs = %v", got)
+	}
+	_, report, err := lib.MergeTripleStores(MergeOptions{}, "state-privacy", "doc-a-copy", "doc-a")
+	if err != nil {
+		t.Fatalf("MergeTripleStores failed: %v", err)
+	}
+	if !slices.Equal(report.DocumentIDs, []string{"doc-a"}) || report.HasConflicts() {
+		t.Errorf("merged %v with %d conflict(s), want doc-a alone", report.DocumentIDs, len(report.Conflicts))
+	}
+	if entry := lib.GetDocument("state-privacy"); entry == nil || entry.ID != "doc-a" {
+		t.Errorf("GetDocument(state-privacy) = %+v", entry)
+	}
+	if entry := lib.GetDocument("doc-a-copy"); entry == nil || entry.ID != "doc-a-copy" {
+		t.Errorf("GetDocument(doc-a-copy) = %+v, want the duplicate itself", entry)
+	}
+
+	for name, test := range map[string]struct{ alias, canonical string }{
+		"unknown document": {"alias-x", "missing"},
+		"self":             {"doc-a", "doc-a"},
+		"chain":            {"alias-y", "state-privacy"},
+		"has aliases":      {"doc-a", "doc-b"},
+		"invalid":          {"Doc A", "doc-a"},
+		"retarget":         {"doc-a-copy", "doc-b"},
+	} {
+		if err := lib.AddAlias(test.alias, test.canonical); err == nil {
+			t.Errorf("%s: AddAlias(%s, %s) succeeded", name, test.alias, test.canonical)
+		}
+	}
+
+	if err := lib.RemoveAlias("doc-a-copy"); err != nil {
+		t.Fatalf("RemoveAlias failed: %v", err)
+	}
+	if err := lib.RemoveDocument("doc-a"); err != nil {
+		t.Fatalf("RemoveDocument failed: %v", err)
+	}
+	reopened, err := Open(lib.Path())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if aliases := reopened.Aliases(); len(aliases) != 0 {
+		t.Errorf("aliases after removing their document = %v", aliases)
+	}
+	if !slices.Contains(reopened.ReadyDocumentIDs(), "doc-a-copy") {
+		t.Error("unaliased duplicate missing from ReadyDocumentIDs")
+	}
+}
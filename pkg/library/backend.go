@@ -0,0 +1,103 @@
+package library
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Backend stores a library's files. Keys are slash-separated paths relative
+// to the library root, such as "library.json" or
+// "documents/<hash>/triples.bin".
+type Backend interface {
+	// Read returns the contents of key. A missing key is an error wrapping
+	// fs.ErrNotExist.
+	Read(key string) ([]byte, error)
+
+	// Write stores data at key, replacing any previous contents.
+	Write(key string, data []byte) error
+
+	// Delete removes key. A missing key is not an error.
+	Delete(key string) error
+
+	// DeletePrefix removes every key under the directory prefix.
+	DeletePrefix(prefix string) error
+}
+
+// Remote library locations are object storage URLs.
+const (
+	s3Scheme  = "s3://"
+	gcsScheme = "gs://"
+)
+
+// IsRemotePath reports whether libraryPath names a library in object
+// storage (s3://bucket/prefix or gs://bucket/prefix) rather than a local
+// directory.
+func IsRemotePath(libraryPath string) bool {
+	return strings.HasPrefix(libraryPath, s3Scheme) || strings.HasPrefix(libraryPath, gcsScheme)
+}
+
+// OpenBackend returns the backend for a library location: a local
+// directory, or an S3-compatible bucket for s3:// and gs:// URLs. Remote
+// files are cached locally (see RemoteOptionsFromEnv).
+func OpenBackend(libraryPath string) (Backend, error) {
+	if !IsRemotePath(libraryPath) {
+		return &localBackend{root: libraryPath}, nil
+	}
+	options, err := RemoteOptionsFromEnv(libraryPath)
+	if err != nil {
+		return nil, err
+	}
+	return newS3Backend(options)
+}
+
+// localBackend stores files in a directory.
+type localBackend struct {
+	root string
+}
+
+func (backend *localBackend) file(key string) string {
+	return filepath.Join(backend.root, filepath.FromSlash(key))
+}
+
+func (backend *localBackend) Read(key string) ([]byte, error) {
+	return os.ReadFile(backend.file(key))
+}
+
+func (backend *localBackend) Write(key string, data []byte) error {
+	filePath := backend.file(key)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0644)
+}
+
+func (backend *localBackend) Delete(key string) error {
+	if err := os.Remove(backend.file(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (backend *localBackend) DeletePrefix(prefix string) error {
+	return os.RemoveAll(backend.file(prefix))
+}
+
+// documentKey returns the key of one of a document's files.
+func documentKey(storageHash, fileName string) string {
+	return path.Join(documentsDir, storageHash, fileName)
+}
+
+// splitRemotePath splits s3://bucket/prefix into the bucket and the prefix.
+func splitRemotePath(libraryPath string) (bucket, prefix string, err error) {
+	rest := libraryPath[strings.Index(libraryPath, "://")+3:]
+	bucket, prefix, _ = strings.Cut(rest, "/")
+	if bucket == "" {
+		return "", "", fmt.Errorf("invalid library location %q: no bucket", libraryPath)
+	}
+	return bucket, strings.Trim(prefix, "/"), nil
+}
@@ -0,0 +1,204 @@
+package library
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// DocumentBaseURI derives the base URI scoping a document's nodes from the
+// library base URI, its jurisdiction, and its ID:
+// "https://regula.dev/regulations/" + "EU" + "eu-gdpr" becomes
+// "https://regula.dev/regulations/eu/eu-gdpr/". The jurisdiction segment is
+// omitted when unknown.
+func DocumentBaseURI(libraryBaseURI string, jurisdiction string, documentID string) string {
+	if libraryBaseURI == "" {
+		libraryBaseURI = defaultBaseURI
+	}
+	if !strings.HasSuffix(libraryBaseURI, "/") && !strings.HasSuffix(libraryBaseURI, "#") {
+		libraryBaseURI += "/"
+	}
+
+	scoped := libraryBaseURI
+	if jurisdiction = uriSegment(jurisdiction); jurisdiction != "" {
+		scoped += jurisdiction + "/"
+	}
+	return scoped + uriSegment(documentID) + "/"
+}
+
+// uriSegment lowercases value and replaces characters that are unsafe in a
+// URI path segment with hyphens.
+func uriSegment(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '-'
+	}, value)
+}
+
+// DocumentBaseURI returns the base URI a document's graph was built with.
+// Documents ingested before base URIs were scoped per document report the
+// library base URI.
+func (lib *Library) DocumentBaseURI(documentID string) string {
+	lib.mu.RLock()
+	defer lib.mu.RUnlock()
+
+	if entry := lib.findDocumentUnsafe(documentID); entry != nil && entry.BaseURI != "" {
+		return entry.BaseURI
+	}
+	return lib.manifest.BaseURI
+}
+
+// RebaseTripleStore returns a copy of tripleStore with every URI under
+// fromBaseURI moved under toBaseURI. URIs already under toBaseURI are left
+// unchanged, so rebasing is idempotent. Quality scores carry over to the
+// rebased triples.
+func RebaseTripleStore(tripleStore *store.TripleStore, fromBaseURI string, toBaseURI string) *store.TripleStore {
+	rebased := store.NewTripleStore()
+
+	rewrite := func(term string) string {
+		if fromBaseURI != "" && strings.HasPrefix(term, fromBaseURI) && !strings.HasPrefix(term, toBaseURI) {
+			return toBaseURI + strings.TrimPrefix(term, fromBaseURI)
+		}
+		return term
+	}
+
+	for _, triple := range tripleStore.All() {
+		rebasedTriple := store.NewTriple(rewrite(triple.Subject), triple.Predicate, rewrite(triple.Object))
+		rebased.Add(rebasedTriple.Subject, rebasedTriple.Predicate, rebasedTriple.Object)
+		if quality := tripleStore.Quality(triple); quality < store.DefaultQuality {
+			rebased.SetQuality(rebasedTriple, quality)
+		}
+	}
+	return rebased
+}
+
+// BaseURIMigration records the re-namespacing of a single document.
+type BaseURIMigration struct {
+	DocumentID     string `json:"document_id"`
+	FromBaseURI    string `json:"from_base_uri"`
+	ToBaseURI      string `json:"to_base_uri"`
+	RewrittenTerms int    `json:"rewritten_terms"`
+	Error          string `json:"error,omitempty"`
+}
+
+// BaseURIMigrationReport summarizes a MigrateDocumentBaseURIs run.
+type BaseURIMigrationReport struct {
+	DryRun     bool               `json:"dry_run"`
+	Migrated   int                `json:"migrated"`
+	Skipped    int                `json:"skipped"`
+	Failed     int                `json:"failed"`
+	Migrations []BaseURIMigration `json:"migrations"`
+}
+
+// MigrateDocumentBaseURIs re-namespaces documents built under the shared
+// library base URI into their own DocumentBaseURI, rewriting the stored
+// triples and recording the new base URI on each entry. Documents that are
+// already scoped, or not ready, are skipped. With dryRun, nothing is written.
+func (lib *Library) MigrateDocumentBaseURIs(dryRun bool) (*BaseURIMigrationReport, error) {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	report := &BaseURIMigrationReport{DryRun: dryRun, Migrations: make([]BaseURIMigration, 0)}
+	libraryBaseURI := lib.manifest.BaseURI
+
+	for _, entry := range lib.manifest.Documents {
+		scoped := entry.BaseURI != "" && entry.BaseURI != libraryBaseURI
+		if scoped || entry.Status != StatusReady {
+			report.Skipped++
+			continue
+		}
+
+		migration := BaseURIMigration{
+			DocumentID:  entry.ID,
+			FromBaseURI: libraryBaseURI,
+			ToBaseURI:   DocumentBaseURI(libraryBaseURI, entry.Jurisdiction, entry.ID),
+		}
+
+		rebased, rewrittenTerms, err := lib.rebaseDocumentUnsafe(entry, migration.FromBaseURI, migration.ToBaseURI)
+		if err != nil {
+			migration.Error = err.Error()
+			report.Failed++
+			report.Migrations = append(report.Migrations, migration)
+			continue
+		}
+		migration.RewrittenTerms = rewrittenTerms
+
+		if !dryRun {
+			if err := lib.writeTriples(entry.StorageHash, rebased, entry.StorageFormat); err != nil {
+				return nil, fmt.Errorf("%s: %w", entry.ID, err)
+			}
+			entry.BaseURI = migration.ToBaseURI
+		}
+
+		report.Migrated++
+		report.Migrations = append(report.Migrations, migration)
+	}
+
+	if !dryRun && report.Migrated > 0 {
+		if err := lib.saveManifest(); err != nil {
+			return nil, fmt.Errorf("failed to save manifest: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// rebaseDocumentUnsafe loads a document's triples and rebases them, returning
+// the number of subject and object terms that changed.
+func (lib *Library) rebaseDocumentUnsafe(entry *DocumentEntry, fromBaseURI string, toBaseURI string) (*store.TripleStore, int, error) {
+	tripleStore, err := lib.readTriples(entry)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rewrittenTerms := 0
+	for _, triple := range tripleStore.All() {
+		for _, term := range []string{triple.Subject, triple.Object} {
+			if strings.HasPrefix(term, fromBaseURI) && !strings.HasPrefix(term, toBaseURI) {
+				rewrittenTerms++
+			}
+		}
+	}
+	return RebaseTripleStore(tripleStore, fromBaseURI, toBaseURI), rewrittenTerms, nil
+}
+
+// FormatBaseURIMigrationTable formats a migration report for terminal output.
+func FormatBaseURIMigrationTable(report *BaseURIMigrationReport) string {
+	var builder strings.Builder
+
+	if report.DryRun {
+		builder.WriteString("Dry run: no changes written.\n")
+	}
+	builder.WriteString(fmt.Sprintf("Migrated: %d | Skipped: %d | Failed: %d\n",
+		report.Migrated, report.Skipped, report.Failed))
+
+	if len(report.Migrations) == 0 {
+		builder.WriteString("\nAll documents already use scoped base URIs.\n")
+		return builder.String()
+	}
+
+	builder.WriteString("\n")
+	for _, migration := range report.Migrations {
+		if migration.Error != "" {
+			builder.WriteString(fmt.Sprintf("  FAILED  %s: %s\n", migration.DocumentID, migration.Error))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("  %-30s %s (%d terms)\n",
+			migration.DocumentID, migration.ToBaseURI, migration.RewrittenTerms))
+	}
+	return builder.String()
+}
+
+// FormatBaseURIMigrationJSON formats a migration report as indented JSON.
+func FormatBaseURIMigrationJSON(report *BaseURIMigrationReport) string {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(data)
+}
@@ -0,0 +1,148 @@
+package library
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func TestDocumentBaseURI(t *testing.T) {
+	cases := []struct {
+		base, jurisdiction, documentID, expected string
+	}{
+		{"https://regula.dev/regulations/", "EU", "eu-gdpr", "https://regula.dev/regulations/eu/eu-gdpr/"},
+		{"https://regula.dev/regulations", "US-CA", "us-ca-ccpa", "https://regula.dev/regulations/us-ca/us-ca-ccpa/"},
+		{"https://regula.dev/regulations/", "", "doc-a", "https://regula.dev/regulations/doc-a/"},
+		{"", "UK", "Data Protection Act", defaultBaseURI + "uk/data-protection-act/"},
+	}
+	for _, testCase := range cases {
+		got := DocumentBaseURI(testCase.base, testCase.jurisdiction, testCase.documentID)
+		if got != testCase.expected {
+			t.Errorf("DocumentBaseURI(%q, %q, %q) = %q, want %q",
+				testCase.base, testCase.jurisdiction, testCase.documentID, got, testCase.expected)
+		}
+	}
+}
+
+func TestAddDocumentScopesBaseURI(t *testing.T) {
+	lib, err := Init(filepath.Join(t.TempDir(), "lib"), "")
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if _, err := lib.AddDocument("doc-a", []byte(mergeTestDocumentA), AddOptions{Format: "us", Jurisdiction: "US-CA"}); err != nil {
+		t.Fatalf("AddDocument (doc-a) failed: %v", err)
+	}
+	if _, err := lib.AddDocument("doc-b", []byte(mergeTestDocumentB), AddOptions{Format: "us"}); err != nil {
+		t.Fatalf("AddDocument (doc-b) failed: %v", err)
+	}
+
+	expectedBase := lib.BaseURI() + "us-ca/doc-a/"
+	if got := lib.DocumentBaseURI("doc-a"); got != expectedBase {
+		t.Errorf("DocumentBaseURI: got %q, want %q", got, expectedBase)
+	}
+
+	// The base URI survives a reopen.
+	reopened, err := Open(lib.Path())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if got := reopened.GetDocument("doc-a").BaseURI; got != expectedBase {
+		t.Errorf("persisted BaseURI: got %q, want %q", got, expectedBase)
+	}
+
+	tripleStore, err := lib.LoadTripleStore("doc-a")
+	if err != nil {
+		t.Fatalf("LoadTripleStore failed: %v", err)
+	}
+	for _, triple := range tripleStore.Find("", store.RDFType, store.ClassArticle) {
+		if !strings.HasPrefix(triple.Subject, expectedBase) {
+			t.Errorf("article %s not under %s", triple.Subject, expectedBase)
+		}
+	}
+
+	_, report, err := lib.MergeTripleStores(MergeOptions{}, "doc-a", "doc-b")
+	if err != nil {
+		t.Fatalf("MergeTripleStores failed: %v", err)
+	}
+	if report.HasConflicts() {
+		t.Errorf("expected scoped documents not to conflict, got %+v", report.Conflicts)
+	}
+}
+
+func TestMigrateDocumentBaseURIs(t *testing.T) {
+	lib := setupMergeTestLibrary(t)
+
+	dryRun, err := lib.MigrateDocumentBaseURIs(true)
+	if err != nil {
+		t.Fatalf("MigrateDocumentBaseURIs (dry run) failed: %v", err)
+	}
+	if dryRun.Migrated != 2 || dryRun.Migrations[0].RewrittenTerms == 0 {
+		t.Fatalf("unexpected dry run report: %+v", dryRun)
+	}
+	if got := lib.DocumentBaseURI("doc-a"); got != lib.BaseURI() {
+		t.Fatalf("dry run changed base URI to %q", got)
+	}
+
+	report, err := lib.MigrateDocumentBaseURIs(false)
+	if err != nil {
+		t.Fatalf("MigrateDocumentBaseURIs failed: %v", err)
+	}
+	if report.Migrated != 2 || report.Failed != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	_, mergeReport, err := lib.MergeTripleStores(MergeOptions{}, "doc-a", "doc-b")
+	if err != nil {
+		t.Fatalf("MergeTripleStores failed: %v", err)
+	}
+	if mergeReport.HasConflicts() {
+		t.Errorf("expected no conflicts after migration, got %+v", mergeReport.Conflicts)
+	}
+
+	tripleStore, err := lib.LoadTripleStore("doc-a")
+	if err != nil {
+		t.Fatalf("LoadTripleStore failed: %v", err)
+	}
+	documentBase := lib.DocumentBaseURI("doc-a")
+	if documentBase != lib.BaseURI()+"doc-a/" {
+		t.Errorf("migrated base URI: got %q", documentBase)
+	}
+	if len(tripleStore.Find("", store.RDFType, store.ClassArticle)) == 0 {
+		t.Fatal("expected articles after migration")
+	}
+	for _, triple := range tripleStore.Find("", store.RDFType, store.ClassArticle) {
+		if !strings.HasPrefix(triple.Subject, documentBase) {
+			t.Errorf("article %s not migrated under %s", triple.Subject, documentBase)
+		}
+	}
+
+	// A second run has nothing left to do.
+	again, err := lib.MigrateDocumentBaseURIs(false)
+	if err != nil {
+		t.Fatalf("second MigrateDocumentBaseURIs failed: %v", err)
+	}
+	if again.Migrated != 0 || again.Skipped != 2 {
+		t.Errorf("expected migration to be idempotent, got %+v", again)
+	}
+}
+
+func TestRebaseTripleStore(t *testing.T) {
+	base := "https://regula.dev/regulations/"
+	tripleStore := store.NewTripleStore()
+	tripleStore.Add(base+"GDPR:Art1", store.PropTitle, "Scope")
+	tripleStore.Add(base+"GDPR:Art1", store.PropReferences, base+"GDPR:Art2")
+	tripleStore.Add(base+"eu/gdpr/GDPR:Art3", store.PropReferences, "https://example.org/other")
+
+	rebased := RebaseTripleStore(tripleStore, base, base+"eu/gdpr/")
+	if !rebased.Exists(base+"eu/gdpr/GDPR:Art1", store.PropReferences, base+"eu/gdpr/GDPR:Art2") {
+		t.Error("expected subject and object to be rebased")
+	}
+	if !rebased.Exists(base+"eu/gdpr/GDPR:Art3", store.PropReferences, "https://example.org/other") {
+		t.Error("expected already-scoped and external URIs to be unchanged")
+	}
+	if rebased.Count() != tripleStore.Count() {
+		t.Errorf("Count: got %d, want %d", rebased.Count(), tripleStore.Count())
+	}
+}
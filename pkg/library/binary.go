@@ -0,0 +1,263 @@
+package library
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// The binary triple format is:
+//
+//	magic        "RGTB"
+//	version      1 byte (binaryFormatVersion)
+//	compression  1 byte (compressionNone or compressionGzip)
+//	payload      possibly compressed:
+//	  term count    uvarint
+//	  terms         uvarint length + UTF-8 bytes, each
+//	  triple count  uvarint
+//	  triples       subject, predicate, object term indexes as uvarints
+//	  score count   uvarint (version 2)
+//	  scores        triple index as uvarint + quality as little-endian
+//	                float64 bits, each (version 2)
+//
+// Each distinct subject, predicate, and object string is stored once in the
+// term dictionary, so repeated URIs cost a few bytes per triple and share
+// one string in memory when loaded. Only triples scored below
+// store.DefaultQuality have an entry in the score section. Version 1 data,
+// written before quality scores were stored, is still read.
+const (
+	binaryMagic         = "RGTB"
+	binaryFormatVersion = 2
+
+	// binaryFormatVersionUnscored is the version without a score section.
+	binaryFormatVersionUnscored = 1
+
+	compressionNone byte = 0
+	compressionGzip byte = 1
+
+	binaryHeaderSize = len(binaryMagic) + 2
+)
+
+// IsBinaryTripleData reports whether data is in the binary triple format.
+func IsBinaryTripleData(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(binaryMagic))
+}
+
+// EncodeTripleStoreBinary encodes all triples in tripleStore in the binary
+// format, gzip-compressed if compress is set.
+func EncodeTripleStoreBinary(tripleStore *store.TripleStore, compress bool) ([]byte, error) {
+	if tripleStore == nil {
+		return nil, fmt.Errorf("triple store is nil")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(binaryMagic)
+	buf.WriteByte(binaryFormatVersion)
+	if !compress {
+		buf.WriteByte(compressionNone)
+		writeBinaryPayload(&buf, tripleStore)
+		return buf.Bytes(), nil
+	}
+
+	buf.WriteByte(compressionGzip)
+	gzipWriter := gzip.NewWriter(&buf)
+	payload := bufio.NewWriter(gzipWriter)
+	writeBinaryPayload(payload, tripleStore)
+	if err := payload.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to compress triples: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress triples: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// binaryWriter is the subset of bytes.Buffer and bufio.Writer the encoder
+// uses; neither returns errors until flushed.
+type binaryWriter interface {
+	io.Writer
+	io.StringWriter
+}
+
+func writeBinaryPayload(w binaryWriter, tripleStore *store.TripleStore) {
+	triples := tripleStore.All()
+	termIndex := make(map[string]uint64)
+	var terms []string
+	intern := func(term string) uint64 {
+		if index, ok := termIndex[term]; ok {
+			return index
+		}
+		index := uint64(len(terms))
+		termIndex[term] = index
+		terms = append(terms, term)
+		return index
+	}
+
+	encoded := make([]uint64, 0, len(triples)*3)
+	var scoredIndexes []int
+	for i, triple := range triples {
+		encoded = append(encoded, intern(triple.Subject), intern(triple.Predicate), intern(triple.Object))
+		if tripleStore.Quality(triple) < store.DefaultQuality {
+			scoredIndexes = append(scoredIndexes, i)
+		}
+	}
+
+	var scratch [binary.MaxVarintLen64]byte
+	writeUvarint := func(value uint64) {
+		w.Write(scratch[:binary.PutUvarint(scratch[:], value)])
+	}
+
+	writeUvarint(uint64(len(terms)))
+	for _, term := range terms {
+		writeUvarint(uint64(len(term)))
+		w.WriteString(term)
+	}
+	writeUvarint(uint64(len(triples)))
+	for _, index := range encoded {
+		writeUvarint(index)
+	}
+	writeUvarint(uint64(len(scoredIndexes)))
+	for _, index := range scoredIndexes {
+		writeUvarint(uint64(index))
+		w.Write(binary.LittleEndian.AppendUint64(scratch[:0], math.Float64bits(tripleStore.Quality(triples[index]))))
+	}
+}
+
+// DecodeTripleStoreBinary creates a TripleStore from binary triple data.
+func DecodeTripleStoreBinary(data []byte) (*store.TripleStore, error) {
+	if !IsBinaryTripleData(data) || len(data) < binaryHeaderSize {
+		return nil, fmt.Errorf("not binary triple data")
+	}
+	version := data[len(binaryMagic)]
+	if version != binaryFormatVersion && version != binaryFormatVersionUnscored {
+		return nil, fmt.Errorf("unsupported binary triple format version %d (this build reads versions %d and %d)",
+			version, binaryFormatVersionUnscored, binaryFormatVersion)
+	}
+
+	payload := data[binaryHeaderSize:]
+	switch compression := data[len(binaryMagic)+1]; compression {
+	case compressionNone:
+	case compressionGzip:
+		gzipReader, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress triples: %w", err)
+		}
+		payload, err = io.ReadAll(gzipReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress triples: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported binary triple compression %d", compression)
+	}
+
+	storeTriples, scores, err := readBinaryPayload(payload, version >= binaryFormatVersion)
+	if err != nil {
+		return nil, fmt.Errorf("corrupt binary triples: %w", err)
+	}
+
+	tripleStore := store.NewTripleStore()
+	if err := tripleStore.BulkAdd(storeTriples); err != nil {
+		return nil, fmt.Errorf("failed to bulk add triples: %w", err)
+	}
+	for index, score := range scores {
+		tripleStore.SetQuality(storeTriples[index], score)
+	}
+	return tripleStore, nil
+}
+
+// readBinaryPayload decodes the triples of a payload and, when scored is
+// set, the quality scores keyed by triple index.
+func readBinaryPayload(payload []byte, scored bool) ([]store.Triple, map[int]float64, error) {
+	offset := 0
+	readUvarint := func() (uint64, error) {
+		value, n := binary.Uvarint(payload[offset:])
+		if n <= 0 {
+			return 0, fmt.Errorf("truncated varint at offset %d", offset)
+		}
+		offset += n
+		return value, nil
+	}
+
+	termCount, err := readUvarint()
+	if err != nil {
+		return nil, nil, err
+	}
+	// Every term takes at least one byte, which bounds the allocation for
+	// corrupt counts.
+	if termCount > uint64(len(payload)) {
+		return nil, nil, fmt.Errorf("term count %d exceeds data size", termCount)
+	}
+	terms := make([]string, termCount)
+	for i := range terms {
+		length, err := readUvarint()
+		if err != nil {
+			return nil, nil, err
+		}
+		if length > uint64(len(payload)-offset) {
+			return nil, nil, fmt.Errorf("term %d overruns data", i)
+		}
+		terms[i] = string(payload[offset : offset+int(length)])
+		offset += int(length)
+	}
+
+	tripleCount, err := readUvarint()
+	if err != nil {
+		return nil, nil, err
+	}
+	if tripleCount > uint64(len(payload)-offset)/3 {
+		return nil, nil, fmt.Errorf("triple count %d exceeds data size", tripleCount)
+	}
+	triples := make([]store.Triple, tripleCount)
+	for i := range triples {
+		var parts [3]string
+		for j := range parts {
+			index, err := readUvarint()
+			if err != nil {
+				return nil, nil, err
+			}
+			if index >= termCount {
+				return nil, nil, fmt.Errorf("triple %d references unknown term %d", i, index)
+			}
+			parts[j] = terms[index]
+		}
+		triples[i] = store.NewTriple(parts[0], parts[1], parts[2])
+	}
+
+	var scores map[int]float64
+	if scored {
+		scoreCount, err := readUvarint()
+		if err != nil {
+			return nil, nil, err
+		}
+		// Every score takes at least nine bytes
+		if scoreCount > uint64(len(payload)-offset)/9 {
+			return nil, nil, fmt.Errorf("score count %d exceeds data size", scoreCount)
+		}
+		scores = make(map[int]float64, scoreCount)
+		for range scoreCount {
+			index, err := readUvarint()
+			if err != nil {
+				return nil, nil, err
+			}
+			if index >= tripleCount {
+				return nil, nil, fmt.Errorf("score references unknown triple %d", index)
+			}
+			if len(payload)-offset < 8 {
+				return nil, nil, fmt.Errorf("truncated score at offset %d", offset)
+			}
+			scores[int(index)] = math.Float64frombits(binary.LittleEndian.Uint64(payload[offset:]))
+			offset += 8
+		}
+	}
+
+	if offset != len(payload) {
+		return nil, nil, fmt.Errorf("%d trailing bytes", len(payload)-offset)
+	}
+	return triples, scores, nil
+}
@@ -0,0 +1,183 @@
+package library
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func binaryTestStore() *store.TripleStore {
+	tripleStore := store.NewTripleStore()
+	tripleStore.Add("http://example.org/art1", "rdf:type", "reg:Article")
+	tripleStore.Add("http://example.org/art1", "reg:title", "Right to erasure (‘right to be forgotten’)")
+	tripleStore.Add("http://example.org/art2", "rdf:type", "reg:Article")
+	tripleStore.Add("http://example.org/art2", "reg:references", "http://example.org/art1")
+	return tripleStore
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	original := binaryTestStore()
+	for _, compress := range []bool{false, true} {
+		data, err := EncodeTripleStoreBinary(original, compress)
+		if err != nil {
+			t.Fatalf("EncodeTripleStoreBinary(compress=%v) failed: %v", compress, err)
+		}
+		if !IsBinaryTripleData(data) {
+			t.Fatalf("encoded data does not start with the binary magic")
+		}
+
+		// DeserializeTripleStore recognizes the binary format.
+		restored, err := DeserializeTripleStore(data)
+		if err != nil {
+			t.Fatalf("DeserializeTripleStore(compress=%v) failed: %v", compress, err)
+		}
+		if restored.Count() != original.Count() {
+			t.Errorf("compress=%v: triple count %d, want %d", compress, restored.Count(), original.Count())
+		}
+		for _, triple := range original.All() {
+			if !restored.Exists(triple.Subject, triple.Predicate, triple.Object) {
+				t.Errorf("compress=%v: missing triple %v", compress, triple)
+			}
+		}
+	}
+}
+
+func TestBinaryRoundTripKeepsQuality(t *testing.T) {
+	original := binaryTestStore()
+	reference := store.NewTriple("http://example.org/art2", "reg:references", "http://example.org/art1")
+	original.SetQuality(reference, 0.7)
+
+	for _, compress := range []bool{false, true} {
+		data, err := EncodeTripleStoreBinary(original, compress)
+		if err != nil {
+			t.Fatal(err)
+		}
+		restored, err := DecodeTripleStoreBinary(data)
+		if err != nil {
+			t.Fatalf("DecodeTripleStoreBinary(compress=%v) failed: %v", compress, err)
+		}
+		if quality := restored.Quality(reference); quality != 0.7 {
+			t.Errorf("compress=%v: reference quality %g, want 0.7", compress, quality)
+		}
+		if filtered := restored.FilterByQuality(0.75); filtered.Count() != original.Count()-1 {
+			t.Errorf("compress=%v: FilterByQuality kept %d triples, want %d", compress, filtered.Count(), original.Count()-1)
+		}
+	}
+}
+
+func TestDecodeTripleStoreBinaryReadsUnscoredVersion(t *testing.T) {
+	data, err := EncodeTripleStoreBinary(binaryTestStore(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Version 1 data is the version 2 encoding without the (empty) score
+	// section.
+	unscored := append([]byte(nil), data[:len(data)-1]...)
+	unscored[len(binaryMagic)] = binaryFormatVersionUnscored
+
+	restored, err := DecodeTripleStoreBinary(unscored)
+	if err != nil {
+		t.Fatalf("DecodeTripleStoreBinary(version 1) failed: %v", err)
+	}
+	if restored.Count() != binaryTestStore().Count() {
+		t.Errorf("triple count %d, want %d", restored.Count(), binaryTestStore().Count())
+	}
+}
+
+func TestBinaryIsSmallerThanJSON(t *testing.T) {
+	tripleStore := benchmarkTripleStore(500)
+	jsonData, err := SerializeTripleStore(tripleStore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	binaryData, err := EncodeTripleStoreBinary(tripleStore, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gzipData, err := EncodeTripleStoreBinary(tripleStore, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(binaryData) >= len(jsonData)/2 || len(gzipData) >= len(binaryData) {
+		t.Errorf("sizes: json %d, binary %d, binary+gzip %d", len(jsonData), len(binaryData), len(gzipData))
+	}
+}
+
+func TestDecodeTripleStoreBinaryRejectsBadData(t *testing.T) {
+	valid, err := EncodeTripleStoreBinary(binaryTestStore(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newerVersion := append([]byte(nil), valid...)
+	newerVersion[len(binaryMagic)] = binaryFormatVersion + 1
+
+	unknownCompression := append([]byte(nil), valid...)
+	unknownCompression[len(binaryMagic)+1] = 9
+
+	tests := map[string]struct {
+		data []byte
+		want string
+	}{
+		"header only":         {[]byte(binaryMagic), "not binary triple data"},
+		"newer version":       {newerVersion, "unsupported binary triple format version"},
+		"unknown compression": {unknownCompression, "unsupported binary triple compression"},
+		"truncated":           {valid[:len(valid)-2], "corrupt binary triples"},
+		"trailing bytes":      {append(append([]byte(nil), valid...), 0), "trailing bytes"},
+		"huge term count":     {append([]byte(binaryMagic+"\x01\x00"), 0xff, 0xff, 0xff, 0xff, 0x0f), "exceeds data size"},
+	}
+	for name, tt := range tests {
+		_, err := DecodeTripleStoreBinary(tt.data)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error %v, want %q", name, err, tt.want)
+		}
+	}
+}
+
+func TestParseStorageFormat(t *testing.T) {
+	for _, name := range []string{"json", "binary", "binary+gzip"} {
+		if format, err := ParseStorageFormat(name); err != nil || string(format) != name {
+			t.Errorf("ParseStorageFormat(%q) = %q, %v", name, format, err)
+		}
+	}
+	if _, err := ParseStorageFormat("zip"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+// benchmarkTripleStore builds a store shaped like an ingested code title:
+// size sections, each with a handful of properties and references.
+func benchmarkTripleStore(size int) *store.TripleStore {
+	tripleStore := store.NewTripleStore()
+	base := "https://regula.dev/regulations/us-federal/us-usc-title-42/US-USC-TITLE-42:Art"
+	for i := 0; i < size; i++ {
+		uri := fmt.Sprintf("%s%d", base, i)
+		tripleStore.Add(uri, store.RDFType, store.ClassArticle)
+		tripleStore.Add(uri, store.PropNumber, fmt.Sprint(i))
+		tripleStore.Add(uri, store.PropTitle, fmt.Sprintf("Section %d heading", i))
+		tripleStore.Add(uri, store.PropText, strings.Repeat(fmt.Sprintf("Text of section %d. ", i), 8))
+		tripleStore.Add(uri, store.PropPartOf, base+"Chapter7")
+		tripleStore.Add(uri, store.PropReferences, fmt.Sprintf("%s%d", base, (i*7+3)%size))
+	}
+	return tripleStore
+}
+
+func BenchmarkDeserializeTripleStore(b *testing.B) {
+	tripleStore := benchmarkTripleStore(5000)
+	for _, format := range []StorageFormat{StorageJSON, StorageBinary, StorageBinaryGzip} {
+		data, err := encodeTriples(tripleStore, format)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(string(format), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				if _, err := DeserializeTripleStore(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package library
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Collection is a named group of library documents, such as
+// "privacy-laws", that commands can be scoped to with --collection.
+type Collection struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Documents   []string  `json:"documents"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// collectionNamePattern matches valid collection names.
+var collectionNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// CreateCollection adds an empty collection.
+func (lib *Library) CreateCollection(name, description string) (*Collection, error) {
+	if !collectionNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid collection name %q: use lowercase letters, digits, '.', '-', and '_'", name)
+	}
+
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	if lib.findCollectionUnsafe(name) != nil {
+		return nil, fmt.Errorf("collection already exists: %s", name)
+	}
+	now := time.Now().UTC()
+	collection := &Collection{Name: name, Description: description, Documents: []string{}, CreatedAt: now, UpdatedAt: now}
+	lib.manifest.Collections = append(lib.manifest.Collections, collection)
+	sort.Slice(lib.manifest.Collections, func(i, j int) bool {
+		return lib.manifest.Collections[i].Name < lib.manifest.Collections[j].Name
+	})
+
+	if err := lib.saveCollectionsUnsafe(); err != nil {
+		return nil, err
+	}
+	return cloneCollection(collection), nil
+}
+
+// DeleteCollection removes a collection. Its documents stay in the library.
+func (lib *Library) DeleteCollection(name string) error {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	if lib.findCollectionUnsafe(name) == nil {
+		return fmt.Errorf("collection not found: %s", name)
+	}
+	lib.manifest.Collections = slices.DeleteFunc(lib.manifest.Collections, func(collection *Collection) bool {
+		return collection.Name == name
+	})
+	return lib.saveCollectionsUnsafe()
+}
+
+// AddToCollection adds library documents to a collection. Documents already
+// in it are skipped.
+func (lib *Library) AddToCollection(name string, documentIDs ...string) (*Collection, error) {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	collection := lib.findCollectionUnsafe(name)
+	if collection == nil {
+		return nil, fmt.Errorf("collection not found: %s", name)
+	}
+	for _, documentID := range documentIDs {
+		if lib.findDocumentUnsafe(documentID) == nil {
+			return nil, fmt.Errorf("document not found: %s", documentID)
+		}
+	}
+	for _, documentID := range documentIDs {
+		if !slices.Contains(collection.Documents, documentID) {
+			collection.Documents = append(collection.Documents, documentID)
+		}
+	}
+	sort.Strings(collection.Documents)
+	collection.UpdatedAt = time.Now().UTC()
+
+	if err := lib.saveCollectionsUnsafe(); err != nil {
+		return nil, err
+	}
+	return cloneCollection(collection), nil
+}
+
+// RemoveFromCollection removes documents from a collection. The documents
+// stay in the library.
+func (lib *Library) RemoveFromCollection(name string, documentIDs ...string) (*Collection, error) {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	collection := lib.findCollectionUnsafe(name)
+	if collection == nil {
+		return nil, fmt.Errorf("collection not found: %s", name)
+	}
+	for _, documentID := range documentIDs {
+		if !slices.Contains(collection.Documents, documentID) {
+			return nil, fmt.Errorf("document %s is not in collection %s", documentID, name)
+		}
+	}
+	collection.Documents = slices.DeleteFunc(collection.Documents, func(id string) bool {
+		return slices.Contains(documentIDs, id)
+	})
+	collection.UpdatedAt = time.Now().UTC()
+
+	if err := lib.saveCollectionsUnsafe(); err != nil {
+		return nil, err
+	}
+	return cloneCollection(collection), nil
+}
+
+// GetCollection returns a collection, or nil if there is none by that name.
+func (lib *Library) GetCollection(name string) *Collection {
+	lib.mu.RLock()
+	defer lib.mu.RUnlock()
+
+	collection := lib.findCollectionUnsafe(name)
+	if collection == nil {
+		return nil
+	}
+	return cloneCollection(collection)
+}
+
+// ListCollections returns all collections, sorted by name.
+func (lib *Library) ListCollections() []*Collection {
+	lib.mu.RLock()
+	defer lib.mu.RUnlock()
+
+	result := make([]*Collection, len(lib.manifest.Collections))
+	for i, collection := range lib.manifest.Collections {
+		result[i] = cloneCollection(collection)
+	}
+	return result
+}
+
+// CollectionDocumentIDs returns the IDs of a collection's documents. An
+// empty collection is an error, since commands treat an empty document list
+// as the whole library.
+func (lib *Library) CollectionDocumentIDs(name string) ([]string, error) {
+	collection := lib.GetCollection(name)
+	if collection == nil {
+		return nil, fmt.Errorf("collection not found: %s", name)
+	}
+	if len(collection.Documents) == 0 {
+		return nil, fmt.Errorf("collection %s has no documents", name)
+	}
+	return collection.Documents, nil
+}
+
+func (lib *Library) findCollectionUnsafe(name string) *Collection {
+	for _, collection := range lib.manifest.Collections {
+		if collection.Name == name {
+			return collection
+		}
+	}
+	return nil
+}
+
+func cloneCollection(collection *Collection) *Collection {
+	copied := *collection
+	copied.Documents = slices.Clone(collection.Documents)
+	return &copied
+}
+
+func (lib *Library) saveCollectionsUnsafe() error {
+	lib.manifest.UpdatedAt = time.Now().UTC()
+	if err := lib.saveManifest(); err != nil {
+		return fmt.Errorf("failed to save manifest: %w", err)
+	}
+	return nil
+}
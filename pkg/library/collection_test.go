@@ -0,0 +1,68 @@
+package library
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCollections(t *testing.T) {
+	lib := setupMergeTestLibrary(t)
+
+	if _, err := lib.CreateCollection("privacy-laws", "State and EU privacy laws"); err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+	if _, err := lib.CollectionDocumentIDs("privacy-laws"); err == nil {
+		t.Error("expected an error for an empty collection")
+	}
+	collection, err := lib.AddToCollection("privacy-laws", "doc-b", "doc-a", "doc-b")
+	if err != nil {
+		t.Fatalf("AddToCollection failed: %v", err)
+	}
+	if !slices.Equal(collection.Documents, []string{"doc-a", "doc-b"}) {
+		t.Errorf("documents = %v, want [doc-a doc-b]", collection.Documents)
+	}
+
+	if err := lib.RemoveDocument("doc-b"); err != nil {
+		t.Fatalf("RemoveDocument failed: %v", err)
+	}
+	reopened, err := Open(lib.Path())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	documentIDs, err := reopened.CollectionDocumentIDs("privacy-laws")
+	if err != nil || !slices.Equal(documentIDs, []string{"doc-a"}) {
+		t.Errorf("CollectionDocumentIDs = %v, %v, want [doc-a] after removing doc-b", documentIDs, err)
+	}
+
+	if _, err := reopened.RemoveFromCollection("privacy-laws", "doc-a"); err != nil {
+		t.Fatalf("RemoveFromCollection failed: %v", err)
+	}
+	if err := reopened.DeleteCollection("privacy-laws"); err != nil {
+		t.Fatalf("DeleteCollection failed: %v", err)
+	}
+	if collections := reopened.ListCollections(); len(collections) != 0 {
+		t.Errorf("collections after delete = %v", collections)
+	}
+}
+
+func TestCollectionsInvalid(t *testing.T) {
+	lib := setupMergeTestLibrary(t)
+	if _, err := lib.CreateCollection("Privacy Laws", ""); err == nil {
+		t.Error("expected an error for an invalid name")
+	}
+	if _, err := lib.CreateCollection("privacy", ""); err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+	if _, err := lib.CreateCollection("privacy", ""); err == nil {
+		t.Error("expected an error for a duplicate collection")
+	}
+	if _, err := lib.AddToCollection("privacy", "missing"); err == nil {
+		t.Error("expected an error adding an unknown document")
+	}
+	if _, err := lib.AddToCollection("missing", "doc-a"); err == nil {
+		t.Error("expected an error adding to an unknown collection")
+	}
+	if _, err := lib.RemoveFromCollection("privacy", "doc-a"); err == nil {
+		t.Error("expected an error removing a document not in the collection")
+	}
+}
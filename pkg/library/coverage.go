@@ -0,0 +1,503 @@
+package library
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// CoverageStatus describes how well a jurisdiction's documents address a
+// topic.
+type CoverageStatus string
+
+const (
+	// CoverageFound means a provision addresses the topic: the topic
+	// phrase appears in its title or text, or every topic term appears in
+	// its title.
+	CoverageFound CoverageStatus = "found"
+
+	// CoveragePartial means a provision mentions every topic term, but
+	// not together as the topic phrase.
+	CoveragePartial CoverageStatus = "partial"
+
+	// CoverageNotFound means no provision mentions every topic term.
+	CoverageNotFound CoverageStatus = "not_found"
+)
+
+// DefaultCoverageCitations is the number of citations kept per cell.
+const DefaultCoverageCitations = 5
+
+// unspecifiedJurisdiction labels documents added without a jurisdiction.
+const unspecifiedJurisdiction = "(unspecified)"
+
+// coverageStopWords are ignored when splitting a topic into terms.
+var coverageStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "by": true, "for": true, "in": true,
+	"of": true, "on": true, "or": true, "the": true, "to": true, "with": true,
+}
+
+// CoverageOptions controls a coverage search.
+type CoverageOptions struct {
+	// DocumentIDs limits the search to these documents (default: all
+	// ready documents).
+	DocumentIDs []string
+
+	// MaxCitations caps the citations kept per cell (default
+	// DefaultCoverageCitations). Matches beyond the cap are still counted.
+	MaxCitations int
+}
+
+// CoverageCitation is a provision that matches a topic.
+type CoverageCitation struct {
+	DocumentID   string         `json:"document_id"`
+	Document     string         `json:"document"`
+	Provision    string         `json:"provision"`
+	Title        string         `json:"title,omitempty"`
+	URI          string         `json:"uri"`
+	Status       CoverageStatus `json:"status"`
+	MatchedTerms []string       `json:"matched_terms"`
+	Snippet      string         `json:"snippet,omitempty"`
+}
+
+// CoverageCell is the coverage of one topic in one jurisdiction.
+type CoverageCell struct {
+	Topic     string             `json:"topic"`
+	Status    CoverageStatus     `json:"status"`
+	Matches   int                `json:"matches"`
+	Citations []CoverageCitation `json:"citations,omitempty"`
+}
+
+// CoverageRow is one jurisdiction of the matrix.
+type CoverageRow struct {
+	Jurisdiction string         `json:"jurisdiction"`
+	DocumentIDs  []string       `json:"document_ids"`
+	Cells        []CoverageCell `json:"cells"`
+}
+
+// CoverageReport is a jurisdiction × topic coverage matrix.
+type CoverageReport struct {
+	Topics []string      `json:"topics"`
+	Rows   []CoverageRow `json:"rows"`
+}
+
+// Cell returns the cell for a jurisdiction and topic, or nil.
+func (report *CoverageReport) Cell(jurisdiction string, topic string) *CoverageCell {
+	for i := range report.Rows {
+		if report.Rows[i].Jurisdiction != jurisdiction {
+			continue
+		}
+		for j := range report.Rows[i].Cells {
+			if report.Rows[i].Cells[j].Topic == topic {
+				return &report.Rows[i].Cells[j]
+			}
+		}
+	}
+	return nil
+}
+
+// Coverage searches the articles of library documents for each topic and
+// groups the results by jurisdiction. Topic terms match inflected forms
+// ("notification" matches "notify" and "notified") by comparing their
+// first five letters.
+func (lib *Library) Coverage(topics []string, opts CoverageOptions) (*CoverageReport, error) {
+	matchers := make([]*topicMatcher, 0, len(topics))
+	report := &CoverageReport{}
+	for _, topic := range topics {
+		topic = strings.TrimSpace(topic)
+		if topic == "" {
+			continue
+		}
+		matcher := newTopicMatcher(topic)
+		if len(matcher.stems) == 0 {
+			return nil, fmt.Errorf("topic %q has no searchable terms", topic)
+		}
+		matchers = append(matchers, matcher)
+		report.Topics = append(report.Topics, topic)
+	}
+	if len(matchers) == 0 {
+		return nil, fmt.Errorf("at least one topic is required")
+	}
+
+	maxCitations := opts.MaxCitations
+	if maxCitations <= 0 {
+		maxCitations = DefaultCoverageCitations
+	}
+	documentIDs := opts.DocumentIDs
+	if len(documentIDs) == 0 {
+		documentIDs = lib.ReadyDocumentIDs()
+	}
+
+	rowsByJurisdiction := make(map[string]*CoverageRow)
+	citationsByCell := make(map[string][][]CoverageCitation)
+	for _, documentID := range documentIDs {
+		entry := lib.GetDocument(documentID)
+		if entry == nil {
+			return nil, fmt.Errorf("document not found: %s", documentID)
+		}
+		tripleStore, err := lib.LoadTripleStore(documentID)
+		if err != nil {
+			return nil, err
+		}
+
+		jurisdiction := entry.Jurisdiction
+		if jurisdiction == "" {
+			jurisdiction = unspecifiedJurisdiction
+		}
+		row, ok := rowsByJurisdiction[jurisdiction]
+		if !ok {
+			row = &CoverageRow{Jurisdiction: jurisdiction}
+			rowsByJurisdiction[jurisdiction] = row
+			citationsByCell[jurisdiction] = make([][]CoverageCitation, len(matchers))
+		}
+		row.DocumentIDs = append(row.DocumentIDs, documentID)
+
+		documentLabel := entry.ShortName
+		if documentLabel == "" {
+			documentLabel = documentID
+		}
+		for _, provision := range coverageProvisions(tripleStore) {
+			for i, matcher := range matchers {
+				if citation, ok := matcher.match(provision); ok {
+					citation.DocumentID = documentID
+					citation.Document = documentLabel
+					citationsByCell[jurisdiction][i] = append(citationsByCell[jurisdiction][i], citation)
+				}
+			}
+		}
+	}
+
+	jurisdictions := make([]string, 0, len(rowsByJurisdiction))
+	for jurisdiction := range rowsByJurisdiction {
+		jurisdictions = append(jurisdictions, jurisdiction)
+	}
+	sort.Strings(jurisdictions)
+
+	for _, jurisdiction := range jurisdictions {
+		row := rowsByJurisdiction[jurisdiction]
+		for i, topic := range report.Topics {
+			citations := citationsByCell[jurisdiction][i]
+			sortCoverageCitations(citations)
+
+			cell := CoverageCell{Topic: topic, Status: CoverageNotFound, Matches: len(citations)}
+			if len(citations) > 0 {
+				cell.Status = citations[0].Status
+			}
+			if len(citations) > maxCitations {
+				citations = citations[:maxCitations]
+			}
+			cell.Citations = citations
+			row.Cells = append(row.Cells, cell)
+		}
+		report.Rows = append(report.Rows, *row)
+	}
+
+	return report, nil
+}
+
+// coverageProvision is the searchable text of one article.
+type coverageProvision struct {
+	uri    string
+	number string
+	title  string
+	text   string
+}
+
+func coverageProvisions(tripleStore *store.TripleStore) []coverageProvision {
+	var provisions []coverageProvision
+	for _, triple := range tripleStore.Find("", store.RDFType, store.ClassArticle) {
+		provisions = append(provisions, coverageProvision{
+			uri:    triple.Subject,
+			number: tripleStore.GetOne(triple.Subject, store.PropNumber),
+			title:  tripleStore.GetOne(triple.Subject, store.PropTitle),
+			text:   tripleStore.GetOne(triple.Subject, store.PropText),
+		})
+	}
+	return provisions
+}
+
+// topicMatcher matches provisions against one topic.
+type topicMatcher struct {
+	phrase string
+	terms  []string
+	stems  []string
+}
+
+func newTopicMatcher(topic string) *topicMatcher {
+	matcher := &topicMatcher{phrase: normalizeCoverageText(topic)}
+	seen := make(map[string]bool)
+	for _, word := range coverageWords(topic) {
+		if coverageStopWords[word] || seen[coverageStem(word)] {
+			continue
+		}
+		seen[coverageStem(word)] = true
+		matcher.terms = append(matcher.terms, word)
+		matcher.stems = append(matcher.stems, coverageStem(word))
+	}
+	return matcher
+}
+
+// match reports whether provision mentions every topic term, with the
+// resulting citation.
+func (m *topicMatcher) match(provision coverageProvision) (CoverageCitation, bool) {
+	titleStems := coverageStemSet(provision.title)
+	textStems := coverageStemSet(provision.text)
+
+	allInTitle := true
+	for _, stem := range m.stems {
+		if !titleStems[stem] {
+			allInTitle = false
+			if !textStems[stem] {
+				return CoverageCitation{}, false
+			}
+		}
+	}
+
+	citation := CoverageCitation{
+		Provision:    "Art " + provision.number,
+		Title:        provision.title,
+		URI:          provision.uri,
+		Status:       CoveragePartial,
+		MatchedTerms: m.terms,
+		Snippet:      m.snippet(provision.text),
+	}
+	if provision.number == "" {
+		citation.Provision = provision.uri[strings.LastIndexAny(provision.uri, ":/")+1:]
+	}
+	normalizedTitle := normalizeCoverageText(provision.title)
+	normalizedText := normalizeCoverageText(provision.text)
+	if allInTitle || strings.Contains(normalizedTitle, m.phrase) || strings.Contains(normalizedText, m.phrase) {
+		citation.Status = CoverageFound
+	}
+	return citation, true
+}
+
+// snippet returns the text around the topic phrase, or around the first
+// topic term when the phrase does not occur.
+func (m *topicMatcher) snippet(text string) string {
+	normalized := normalizeCoverageText(text)
+	start := strings.Index(normalized, m.phrase)
+	end := start + len(m.phrase)
+	if start < 0 {
+		start = strings.Index(normalized, m.stems[0])
+		end = start + len(m.stems[0])
+	}
+	if start < 0 {
+		return ""
+	}
+	contextStart, contextEnd := textutil.ContextBounds(normalized, start, end, 60)
+	snippet := normalized[contextStart:contextEnd]
+	if contextStart > 0 {
+		snippet = "…" + snippet
+	}
+	if contextEnd < len(normalized) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// normalizeCoverageText lower-cases text and collapses whitespace, so that
+// phrases broken across lines still match.
+func normalizeCoverageText(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
+
+func coverageWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// coverageStem truncates a word to its first five letters, a crude
+// stemmer that conflates "notify", "notified", and "notification".
+func coverageStem(word string) string {
+	runes := []rune(word)
+	if len(runes) > 5 {
+		runes = runes[:5]
+	}
+	return string(runes)
+}
+
+func coverageStemSet(text string) map[string]bool {
+	stems := make(map[string]bool)
+	for _, word := range coverageWords(text) {
+		stems[coverageStem(word)] = true
+	}
+	return stems
+}
+
+// sortCoverageCitations orders found before partial, then by document and
+// provision number.
+func sortCoverageCitations(citations []CoverageCitation) {
+	sort.SliceStable(citations, func(i, j int) bool {
+		a, b := citations[i], citations[j]
+		if a.Status != b.Status {
+			return a.Status == CoverageFound
+		}
+		if a.DocumentID != b.DocumentID {
+			return a.DocumentID < b.DocumentID
+		}
+		numberA, errA := strconv.Atoi(strings.TrimPrefix(a.Provision, "Art "))
+		numberB, errB := strconv.Atoi(strings.TrimPrefix(b.Provision, "Art "))
+		if errA == nil && errB == nil {
+			return numberA < numberB
+		}
+		return a.Provision < b.Provision
+	})
+}
+
+// coverageSymbol returns the short marker used in matrix cells.
+func coverageSymbol(status CoverageStatus) string {
+	switch status {
+	case CoverageFound:
+		return "✓"
+	case CoveragePartial:
+		return "~"
+	}
+	return "✗"
+}
+
+// coverageCellSummary renders a cell as its marker and leading citations.
+func coverageCellSummary(cell CoverageCell, maxCitations int) string {
+	summary := coverageSymbol(cell.Status)
+	if cell.Matches == 0 {
+		return summary
+	}
+	var labels []string
+	for i, citation := range cell.Citations {
+		if i == maxCitations {
+			break
+		}
+		labels = append(labels, citation.Document+" "+citation.Provision)
+	}
+	summary += " " + strings.Join(labels, ", ")
+	if cell.Matches > len(labels) {
+		summary += fmt.Sprintf(" (+%d)", cell.Matches-len(labels))
+	}
+	return summary
+}
+
+// FormatCoverageTable renders the matrix as a text table followed by the
+// citations for each cell.
+func FormatCoverageTable(report *CoverageReport) string {
+	var builder strings.Builder
+
+	headers := append([]string{"Jurisdiction"}, report.Topics...)
+	rows := make([][]string, len(report.Rows))
+	for i, row := range report.Rows {
+		rows[i] = []string{row.Jurisdiction}
+		for _, cell := range row.Cells {
+			rows[i] = append(rows[i], coverageCellSummary(cell, 2))
+		}
+	}
+
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = textutil.RuneLen(header)
+	}
+	for _, row := range rows {
+		for i, value := range row {
+			if length := textutil.RuneLen(value); length > widths[i] {
+				widths[i] = length
+			}
+		}
+	}
+	writeRow := func(values []string) {
+		for i, value := range values {
+			builder.WriteString(value)
+			if i < len(values)-1 {
+				builder.WriteString(strings.Repeat(" ", widths[i]-textutil.RuneLen(value)+2))
+			}
+		}
+		builder.WriteString("\n")
+	}
+
+	writeRow(headers)
+	separators := make([]string, len(headers))
+	for i, width := range widths {
+		separators[i] = strings.Repeat("-", width)
+	}
+	writeRow(separators)
+	for _, row := range rows {
+		writeRow(row)
+	}
+	builder.WriteString("\n✓ found   ~ partial (all terms, not as a phrase)   ✗ not found\n")
+
+	for _, row := range report.Rows {
+		for _, cell := range row.Cells {
+			if len(cell.Citations) == 0 {
+				continue
+			}
+			matches := "provisions"
+			if cell.Matches == 1 {
+				matches = "provision"
+			}
+			builder.WriteString(fmt.Sprintf("\n%s: %s (%s, %d %s)\n", row.Jurisdiction, cell.Topic, cell.Status, cell.Matches, matches))
+			for _, citation := range cell.Citations {
+				builder.WriteString(fmt.Sprintf("  %s %s %s", coverageSymbol(citation.Status), citation.Document, citation.Provision))
+				if citation.Title != "" {
+					builder.WriteString(" - " + citation.Title)
+				}
+				builder.WriteString("\n")
+				if citation.Snippet != "" {
+					builder.WriteString("      " + citation.Snippet + "\n")
+				}
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// FormatCoverageMarkdown renders the matrix as a Markdown table, for
+// pasting into scoping memos.
+func FormatCoverageMarkdown(report *CoverageReport) string {
+	var builder strings.Builder
+
+	builder.WriteString("| Jurisdiction | " + strings.Join(report.Topics, " | ") + " |\n")
+	builder.WriteString("|---" + strings.Repeat("|---", len(report.Topics)) + "|\n")
+	for _, row := range report.Rows {
+		builder.WriteString("| " + row.Jurisdiction)
+		for _, cell := range row.Cells {
+			builder.WriteString(" | " + strings.ReplaceAll(coverageCellSummary(cell, DefaultCoverageCitations), "|", "\\|"))
+		}
+		builder.WriteString(" |\n")
+	}
+	builder.WriteString("\n✓ found, ~ partial (all terms, not as a phrase), ✗ not found\n")
+
+	return builder.String()
+}
+
+// FormatCoverageCSV renders one line per jurisdiction and topic.
+func FormatCoverageCSV(report *CoverageReport) string {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Write([]string{"jurisdiction", "topic", "status", "matches", "citations"})
+	for _, row := range report.Rows {
+		for _, cell := range row.Cells {
+			var citations []string
+			for _, citation := range cell.Citations {
+				citations = append(citations, citation.Document+" "+citation.Provision)
+			}
+			writer.Write([]string{row.Jurisdiction, cell.Topic, string(cell.Status), strconv.Itoa(cell.Matches), strings.Join(citations, "; ")})
+		}
+	}
+	writer.Flush()
+	return buffer.String()
+}
+
+// FormatCoverageJSON renders the report as indented JSON.
+func FormatCoverageJSON(report *CoverageReport) string {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(data)
+}
@@ -0,0 +1,177 @@
+package library
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const coverageTestEU = `DATA PROTECTION REGULATION
+
+CHAPTER I
+General provisions
+
+Article 1
+Notification of a personal data breach
+
+1. In the case of a personal data breach, the controller shall notify the
+supervisory authority without undue delay.
+
+Article 2
+Records
+
+1. The controller shall keep records of processing, including any breach
+and the steps taken. The authority may request notification of the records.
+`
+
+const coverageTestState = `CONSUMER PRIVACY REGULATION
+
+CHAPTER I
+General provisions
+
+Article 1
+Security
+
+1. A controller shall maintain reasonable security practices and, after a
+breach of security, shall inform affected consumers by notifying them in writing.
+`
+
+const coverageTestOther = `TAX REGULATION
+
+CHAPTER I
+General provisions
+
+Article 1
+Tax rates
+
+1. The rate of tax is five percent.
+`
+
+func setupCoverageTestLibrary(t *testing.T) *Library {
+	t.Helper()
+	lib, err := Init(filepath.Join(t.TempDir(), "lib"), "")
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	documents := []struct {
+		id, shortName, jurisdiction, text string
+	}{
+		{"eu-dpr", "DPR", "EU", coverageTestEU},
+		{"us-xx-cpa", "CPA", "US-XX", coverageTestState},
+		{"us-xx-tax", "TAX", "US-XX", coverageTestOther},
+		{"misc", "MISC", "", coverageTestOther},
+	}
+	for _, document := range documents {
+		if _, err := lib.AddDocument(document.id, []byte(document.text), AddOptions{
+			ShortName:    document.shortName,
+			Jurisdiction: document.jurisdiction,
+		}); err != nil {
+			t.Fatalf("AddDocument(%s) failed: %v", document.id, err)
+		}
+	}
+	return lib
+}
+
+func TestCoverage_Matrix(t *testing.T) {
+	lib := setupCoverageTestLibrary(t)
+
+	report, err := lib.Coverage([]string{"breach notification", "tax rate", "data portability"}, CoverageOptions{})
+	if err != nil {
+		t.Fatalf("Coverage failed: %v", err)
+	}
+
+	var jurisdictions []string
+	for _, row := range report.Rows {
+		jurisdictions = append(jurisdictions, row.Jurisdiction)
+	}
+	if strings.Join(jurisdictions, ",") != "(unspecified),EU,US-XX" {
+		t.Fatalf("jurisdictions = %v", jurisdictions)
+	}
+
+	eu := report.Cell("EU", "breach notification")
+	if eu.Status != CoverageFound || eu.Matches != 2 {
+		t.Fatalf("EU breach notification = %+v, want found with 2 matches", eu)
+	}
+	if eu.Citations[0].Provision != "Art 1" || eu.Citations[0].Status != CoverageFound || eu.Citations[0].Document != "DPR" {
+		t.Errorf("first EU citation = %+v, want DPR Art 1 found", eu.Citations[0])
+	}
+	if eu.Citations[1].Provision != "Art 2" || eu.Citations[1].Status != CoveragePartial {
+		t.Errorf("second EU citation = %+v, want Art 2 partial", eu.Citations[1])
+	}
+	if !strings.Contains(eu.Citations[0].Snippet, "personal data breach") {
+		t.Errorf("snippet = %q", eu.Citations[0].Snippet)
+	}
+
+	state := report.Cell("US-XX", "breach notification")
+	if state.Status != CoveragePartial || len(state.Citations) != 1 || state.Citations[0].DocumentID != "us-xx-cpa" {
+		t.Errorf("US-XX breach notification = %+v, want partial via inflected terms in CPA", state)
+	}
+	if cell := report.Cell("US-XX", "tax rate"); cell.Status != CoverageFound {
+		t.Errorf("US-XX tax rate = %+v, want found in the title", cell)
+	}
+	if cell := report.Cell("EU", "data portability"); cell.Status != CoverageNotFound || cell.Matches != 0 {
+		t.Errorf("EU data portability = %+v, want not found", cell)
+	}
+	if row := report.Rows[2]; len(row.DocumentIDs) != 2 {
+		t.Errorf("US-XX documents = %v, want both state documents", row.DocumentIDs)
+	}
+}
+
+func TestCoverage_Options(t *testing.T) {
+	lib := setupCoverageTestLibrary(t)
+
+	report, err := lib.Coverage([]string{"breach notification"}, CoverageOptions{DocumentIDs: []string{"eu-dpr"}, MaxCitations: 1})
+	if err != nil {
+		t.Fatalf("Coverage failed: %v", err)
+	}
+	if len(report.Rows) != 1 {
+		t.Fatalf("rows = %d, want 1", len(report.Rows))
+	}
+	if cell := report.Rows[0].Cells[0]; cell.Matches != 2 || len(cell.Citations) != 1 {
+		t.Errorf("cell = %d matches, %d citations; want 2, 1", cell.Matches, len(cell.Citations))
+	}
+
+	if _, err := lib.Coverage([]string{" "}, CoverageOptions{}); err == nil {
+		t.Error("expected error without topics")
+	}
+	if _, err := lib.Coverage([]string{"of the"}, CoverageOptions{}); err == nil {
+		t.Error("expected error for a topic of stop words")
+	}
+	if _, err := lib.Coverage([]string{"breach"}, CoverageOptions{DocumentIDs: []string{"missing"}}); err == nil {
+		t.Error("expected error for unknown document")
+	}
+}
+
+func TestCoverage_Formats(t *testing.T) {
+	lib := setupCoverageTestLibrary(t)
+	report, err := lib.Coverage([]string{"breach notification", "tax rate"}, CoverageOptions{})
+	if err != nil {
+		t.Fatalf("Coverage failed: %v", err)
+	}
+
+	table := FormatCoverageTable(report)
+	for _, expected := range []string{"Jurisdiction", "✓ DPR Art 1, DPR Art 2", "~ CPA Art 1", "EU: breach notification (found, 2 provisions)"} {
+		if !strings.Contains(table, expected) {
+			t.Errorf("table missing %q:\n%s", expected, table)
+		}
+	}
+
+	markdown := FormatCoverageMarkdown(report)
+	if !strings.Contains(markdown, "| Jurisdiction | breach notification | tax rate |") || !strings.Contains(markdown, "| EU | ✓ DPR Art 1, DPR Art 2 | ✗ |") {
+		t.Errorf("markdown:\n%s", markdown)
+	}
+
+	csvOutput := FormatCoverageCSV(report)
+	if !strings.Contains(csvOutput, "US-XX,tax rate,found,1,TAX Art 1") {
+		t.Errorf("csv:\n%s", csvOutput)
+	}
+
+	var decoded CoverageReport
+	if err := json.Unmarshal([]byte(FormatCoverageJSON(report)), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(decoded.Rows) != 3 || decoded.Topics[1] != "tax rate" {
+		t.Errorf("decoded = %+v", decoded)
+	}
+}
@@ -0,0 +1,185 @@
+package library
+
+import (
+	"crypto/sha256"
+	"hash/fnv"
+	"sort"
+	"strings"
+)
+
+// DuplicateReason is a signal that two documents are the same law.
+type DuplicateReason string
+
+// Duplicate reasons, strongest first.
+const (
+	DuplicateReasonContent  DuplicateReason = "content"  // identical source text, ignoring case, punctuation, and spacing
+	DuplicateReasonSimilar  DuplicateReason = "similar"  // source texts share most of their wording
+	DuplicateReasonCitation DuplicateReason = "citation" // same short title ("may be cited as") in the same jurisdiction
+	DuplicateReasonTitle    DuplicateReason = "title"    // same name in the same jurisdiction
+)
+
+// DefaultMinSimilarity is the text similarity from which two documents are
+// reported as near-duplicates.
+const DefaultMinSimilarity = 0.9
+
+// shingleSize is the number of words in each shingle compared between
+// source texts.
+const shingleSize = 5
+
+// DuplicateOptions configures FindDuplicates.
+type DuplicateOptions struct {
+	// MinSimilarity is the Jaccard similarity of the source texts' word
+	// shingles from which they are near-duplicates. Defaults to
+	// DefaultMinSimilarity.
+	MinSimilarity float64
+}
+
+// DuplicatePair is a pair of documents that appear to be the same law.
+// DocumentID is the one ingested first, the suggested canonical document.
+type DuplicatePair struct {
+	DocumentID  string            `json:"document_id"`
+	DuplicateID string            `json:"duplicate_id"`
+	Reasons     []DuplicateReason `json:"reasons"`
+	Similarity  float64           `json:"similarity"`
+
+	// Aliased is set when DuplicateID is already an alias of DocumentID.
+	Aliased bool `json:"aliased,omitempty"`
+}
+
+// duplicateProfile is what FindDuplicates compares for each document.
+type duplicateProfile struct {
+	entry       *DocumentEntry
+	contentHash [sha256.Size]byte
+	shingles    map[uint64]struct{}
+	titles      []string
+	citations   []string
+}
+
+// FindDuplicates compares every pair of ready documents by source text,
+// short titles, and names, and returns the pairs that look like the same
+// law, sorted by document ID. Bulk downloads, crawls, and manual adds can
+// each ingest a law under a different ID; alias the duplicate to the
+// canonical document (AddAlias) so queries count it once.
+func (lib *Library) FindDuplicates(opts DuplicateOptions) ([]DuplicatePair, error) {
+	minSimilarity := opts.MinSimilarity
+	if minSimilarity <= 0 {
+		minSimilarity = DefaultMinSimilarity
+	}
+
+	var profiles []*duplicateProfile
+	for _, entry := range lib.ListDocuments() {
+		if entry.Status != StatusReady {
+			continue
+		}
+		sourceText, err := lib.LoadSourceText(entry.ID)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, newDuplicateProfile(entry, sourceText))
+	}
+	// The earlier ingest is the canonical suggestion.
+	sort.SliceStable(profiles, func(i, j int) bool {
+		return profiles[i].entry.IngestedAt.Before(profiles[j].entry.IngestedAt)
+	})
+
+	aliases := lib.Aliases()
+	pairs := make([]DuplicatePair, 0)
+	for i, first := range profiles {
+		for _, second := range profiles[i+1:] {
+			pair, found := compareProfiles(first, second, minSimilarity)
+			if !found {
+				continue
+			}
+			pair.Aliased = aliases[pair.DuplicateID] == pair.DocumentID
+			pairs = append(pairs, pair)
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].DocumentID != pairs[j].DocumentID {
+			return pairs[i].DocumentID < pairs[j].DocumentID
+		}
+		return pairs[i].DuplicateID < pairs[j].DuplicateID
+	})
+	return pairs, nil
+}
+
+func newDuplicateProfile(entry *DocumentEntry, sourceText []byte) *duplicateProfile {
+	words := strings.Fields(nonWordPattern.ReplaceAllString(strings.ToLower(string(sourceText)), " "))
+	profile := &duplicateProfile{
+		entry:       entry,
+		contentHash: sha256.Sum256([]byte(strings.Join(words, " "))),
+		shingles:    make(map[uint64]struct{}),
+	}
+	for start := 0; start == 0 || start+shingleSize <= len(words); start++ {
+		end := min(start+shingleSize, len(words))
+		hash := fnv.New64a()
+		hash.Write([]byte(strings.Join(words[start:end], " ")))
+		profile.shingles[hash.Sum64()] = struct{}{}
+	}
+
+	for _, name := range []string{entry.Name, entry.ShortName, parentheticalTail.ReplaceAllString(entry.FullName, "")} {
+		if normalized := normalizePopularName(name); normalized != "" {
+			profile.titles = append(profile.titles, normalized)
+		}
+	}
+	for _, title := range entry.ShortTitles {
+		if normalized := normalizePopularName(title); normalized != "" {
+			profile.citations = append(profile.citations, normalized)
+		}
+	}
+	return profile
+}
+
+// compareProfiles reports whether two documents look like the same law.
+// Names and short titles only count within a jurisdiction, since states
+// reuse them ("Consumer Privacy Act").
+func compareProfiles(first, second *duplicateProfile, minSimilarity float64) (DuplicatePair, bool) {
+	pair := DuplicatePair{DocumentID: first.entry.ID, DuplicateID: second.entry.ID}
+
+	if first.contentHash == second.contentHash {
+		pair.Reasons = append(pair.Reasons, DuplicateReasonContent)
+		pair.Similarity = 1
+	} else if pair.Similarity = jaccard(first.shingles, second.shingles, minSimilarity); pair.Similarity >= minSimilarity {
+		pair.Reasons = append(pair.Reasons, DuplicateReasonSimilar)
+	}
+
+	sameJurisdiction := first.entry.Jurisdiction == "" || second.entry.Jurisdiction == "" ||
+		first.entry.Jurisdiction == second.entry.Jurisdiction
+	if sameJurisdiction && sharesAny(first.citations, second.citations) {
+		pair.Reasons = append(pair.Reasons, DuplicateReasonCitation)
+	}
+	if sameJurisdiction && sharesAny(first.titles, second.titles) {
+		pair.Reasons = append(pair.Reasons, DuplicateReasonTitle)
+	}
+	return pair, len(pair.Reasons) > 0
+}
+
+// jaccard returns the Jaccard similarity of two shingle sets, or 0 without
+// comparing them when their sizes alone put it below minSimilarity.
+func jaccard(first, second map[uint64]struct{}, minSimilarity float64) float64 {
+	if len(first) > len(second) {
+		first, second = second, first
+	}
+	if len(second) == 0 || float64(len(first))/float64(len(second)) < minSimilarity {
+		return 0
+	}
+	shared := 0
+	for shingle := range first {
+		if _, found := second[shingle]; found {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(first)+len(second)-shared)
+}
+
+func sharesAny(first, second []string) bool {
+	for _, value := range first {
+		for _, other := range second {
+			if value == other {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package library
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	lib, err := Init(filepath.Join(t.TempDir(), "lib"), "")
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	reflowed := strings.ReplaceAll(mergeTestDocumentA, "\n\n", "\n \n")
+	amended := mergeTestDocumentB + "\nSection 2\nPenalties\n\n(a) Violations are punishable by a fine.\n"
+	for _, document := range []struct {
+		id, text, name, jurisdiction string
+	}{
+		{"doc-a", mergeTestDocumentA, "State Consumer Privacy Act", "US-VA"},
+		{"doc-b", mergeTestDocumentB, "State Data Protection Act", "US-VA"},
+		{"uscode-doc-a", reflowed, "", "US-VA"},
+		{"doc-b-2024", amended, "State Data Protection Act", "US-VA"},
+		{"other-b", amended, "State Data Protection Act", "US-CO"},
+	} {
+		if _, err := lib.AddDocument(document.id, []byte(document.text), AddOptions{
+			Format: "us", Name: document.name, Jurisdiction: document.jurisdiction,
+		}); err != nil {
+			t.Fatalf("AddDocument(%s) failed: %v", document.id, err)
+		}
+	}
+
+	pairs, err := lib.FindDuplicates(DuplicateOptions{})
+	if err != nil {
+		t.Fatalf("FindDuplicates failed: %v", err)
+	}
+	reasons := make(map[string][]DuplicateReason)
+	for _, pair := range pairs {
+		reasons[pair.DocumentID+" "+pair.DuplicateID] = pair.Reasons
+	}
+	for key, want := range map[string][]DuplicateReason{
+		"doc-a uscode-doc-a": {DuplicateReasonContent},
+		"doc-b doc-b-2024":   {DuplicateReasonTitle},
+		"doc-b-2024 other-b": {DuplicateReasonContent},
+	} {
+		if !slices.Equal(reasons[key], want) {
+			t.Errorf("%s: reasons = %v, want %v", key, reasons[key], want)
+		}
+	}
+	// The same name in another jurisdiction is a different law.
+	if _, found := reasons["doc-b other-b"]; found {
+		t.Error("documents in different jurisdictions reported by name")
+	}
+	if len(pairs) != 3 {
+		t.Errorf("pairs = %+v, want 3", pairs)
+	}
+
+	if err := lib.AddAlias("uscode-doc-a", "doc-a"); err != nil {
+		t.Fatalf("AddAlias failed: %v", err)
+	}
+	pairs, _ = lib.FindDuplicates(DuplicateOptions{})
+	if !pairs[0].Aliased {
+		t.Errorf("aliased pair not marked: %+v", pairs[0])
+	}
+}
@@ -0,0 +1,316 @@
+package library
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// ExternalDefinitionLink is a reg:usesExternalDefinition edge from a
+// provision or defined term of one document to a definition of another.
+// Via is set when a provision is linked through a local definition that
+// incorporates the external one.
+type ExternalDefinitionLink struct {
+	DocumentID       string `json:"document_id"`
+	Subject          string `json:"subject"`
+	Term             string `json:"term"`
+	TargetDocumentID string `json:"target_document_id"`
+	Target           string `json:"target"`
+	Via              string `json:"via,omitempty"`
+}
+
+// UnresolvedIncorporation is an explicit incorporation whose cited document
+// is not in the library, or does not define the term.
+type UnresolvedIncorporation struct {
+	DocumentID string `json:"document_id"`
+	Subject    string `json:"subject"`
+	Citation   string `json:"citation"`
+	Reason     string `json:"reason"`
+}
+
+// ExternalDefinitionReport summarizes a LinkExternalDefinitions run.
+type ExternalDefinitionReport struct {
+	DryRun     bool                      `json:"dry_run"`
+	Documents  int                       `json:"documents"`
+	Links      []ExternalDefinitionLink  `json:"links"`
+	Unresolved []UnresolvedIncorporation `json:"unresolved"`
+}
+
+var (
+	// incorporationPattern matches a phrase incorporating a definition from
+	// elsewhere and captures the citation that follows it.
+	incorporationPattern = regexp.MustCompile(`(?i)\b(?:as\s+defined\s+(?:in|by|under)|within\s+the\s+meaning\s+of|has\s+the\s+(?:same\s+)?meaning\s+given\s+(?:in|by))\s+([^;:]{1,200})`)
+
+	// citedActPattern matches an EU act cited by number, after an optional
+	// provision such as "Article 4, point (1) of".
+	citedActPattern = regexp.MustCompile(`(?i)^(?:(?:Articles?|Sections?|points?|paragraphs?|Annex)\b[^;:]*?\bof\s+)?(?:the\s+)?(Regulation|Directive|Decision)\s+(?:\((?:EU|EC|EEC|Euratom)\)\s+)?(?:No\s+)?(\d{2,4}/\d+)`)
+
+	// actNumberPattern finds an EU act number in a document's name.
+	actNumberPattern = regexp.MustCompile(`(?i)\b(Regulation|Directive|Decision)\s+(?:\((?:EU|EC|EEC|Euratom)\)\s+)?(?:No\s+)?(\d{2,4}/\d+)`)
+
+	// citedProvisionPrefix strips the provision from a citation of a
+	// document by name, as in "section 3 of the Data Protection Act 2018".
+	citedProvisionPrefix = regexp.MustCompile(`(?i)^(?:(?:Articles?|Sections?|§+|points?|paragraphs?)\b[^;:]*?\bof\s+)?(?:the\s+)?`)
+)
+
+// incorporation is a definition of another document that a text
+// incorporates.
+type incorporation struct {
+	term      string
+	target    *externalDefinitionDocument
+	targetURI string
+}
+
+// externalDefinitionDocument holds a loaded document and its definitions.
+type externalDefinitionDocument struct {
+	entry       *DocumentEntry
+	tripleStore *store.TripleStore
+	terms       map[string]string // normalized term to URI
+	byLength    []string          // normalized terms, longest first
+}
+
+// LinkExternalDefinitions links term usages in each ready document to the
+// definitions of other library documents they explicitly incorporate, as in
+// "personal data as defined in Article 4, point (1) of Regulation (EU)
+// 2016/679". The cited document is found by its EU act number or by a
+// popular name. A reg:usesExternalDefinition edge is written from the
+// provision or definition containing the incorporation, and from every
+// provision using a local definition that incorporates a term of the same
+// name. Existing edges are replaced, so the pass can be rerun after adding
+// documents. With dryRun, nothing is written.
+func (lib *Library) LinkExternalDefinitions(dryRun bool) (*ExternalDefinitionReport, error) {
+	names := lib.PopularNameIndex()
+
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	report := &ExternalDefinitionReport{
+		DryRun:     dryRun,
+		Links:      make([]ExternalDefinitionLink, 0),
+		Unresolved: make([]UnresolvedIncorporation, 0),
+	}
+
+	documents := make(map[string]*externalDefinitionDocument)
+	var order []string
+	acts := make(map[string]string) // "regulation 2016/679" to document ID, from names and titles
+	for _, entry := range lib.manifest.Documents {
+		if entry.Status != StatusReady {
+			continue
+		}
+		tripleStore, err := lib.readTriples(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", entry.ID, err)
+		}
+		documents[entry.ID] = loadExternalDefinitionDocument(entry, tripleStore)
+		order = append(order, entry.ID)
+		actNames := []string{entry.FullName, entry.Name, entry.ShortName}
+		for _, class := range []string{store.ClassRegulation, store.ClassDirective, store.ClassDecision} {
+			for _, documentURI := range sortedSubjects(tripleStore, class) {
+				actNames = append(actNames, tripleStore.GetOne(documentURI, store.PropTitle))
+			}
+		}
+		for _, name := range actNames {
+			for _, match := range actNumberPattern.FindAllStringSubmatch(name, -1) {
+				acts[strings.ToLower(match[1])+" "+match[2]] = entry.ID
+			}
+		}
+	}
+	report.Documents = len(order)
+
+	// resolveDocument returns the library document the text after an
+	// incorporation cites, and the citation itself. The citation is empty
+	// when the text names no document, as in "Article 3 of this Regulation".
+	resolveDocument := func(text string) (string, string) {
+		if match := citedActPattern.FindStringSubmatch(text); match != nil {
+			return acts[strings.ToLower(match[1])+" "+match[2]], match[0]
+		}
+		rest := citedProvisionPrefix.ReplaceAllString(text, "")
+		if end := strings.IndexAny(rest, ",.("); end >= 0 {
+			rest = rest[:end]
+		}
+		if match, ok := names.Resolve(rest); ok {
+			return match.DocumentID, strings.TrimSpace(rest)
+		}
+		return "", ""
+	}
+
+	for _, documentID := range order {
+		document := documents[documentID]
+		tripleStore := document.tripleStore
+		removed := tripleStore.Delete("", store.PropUsesExternalDefinition, "")
+		added := 0
+
+		link := func(subject, term string, target *externalDefinitionDocument, targetURI, via string) {
+			if tripleStore.Exists(subject, store.PropUsesExternalDefinition, targetURI) {
+				return
+			}
+			tripleStore.Add(subject, store.PropUsesExternalDefinition, targetURI)
+			added++
+			report.Links = append(report.Links, ExternalDefinitionLink{
+				DocumentID:       documentID,
+				Subject:          subject,
+				Term:             term,
+				TargetDocumentID: target.entry.ID,
+				Target:           targetURI,
+				Via:              via,
+			})
+		}
+
+		scan := func(subject, text string) []incorporation {
+			var incorporated []incorporation
+			text = strings.Join(strings.Fields(text), " ")
+			for _, match := range incorporationPattern.FindAllStringSubmatchIndex(text, -1) {
+				targetID, citation := resolveDocument(text[match[2]:match[3]])
+				if citation == "" || targetID == documentID {
+					continue
+				}
+				unresolved := UnresolvedIncorporation{DocumentID: documentID, Subject: subject, Citation: citation}
+				target := documents[targetID]
+				if target == nil {
+					unresolved.Reason = "cited document not in library"
+					report.Unresolved = append(report.Unresolved, unresolved)
+					continue
+				}
+				term := target.termBefore(text[:match[0]])
+				if term == "" {
+					unresolved.Reason = "term not defined by " + targetID
+					report.Unresolved = append(report.Unresolved, unresolved)
+					continue
+				}
+				link(subject, term, target, target.terms[term], "")
+				incorporated = append(incorporated, incorporation{term: term, target: target, targetURI: target.terms[term]})
+			}
+			return incorporated
+		}
+
+		// Definitions that incorporate a term of the same name carry the
+		// external definition to every provision using them
+		carried := make(map[string]incorporation) // by local term URI
+		for _, termURI := range sortedSubjects(tripleStore, store.ClassDefinedTerm) {
+			local := normalizeDefinedTerm(tripleStore.GetOne(termURI, store.PropTerm))
+			for _, incorporated := range scan(termURI, tripleStore.GetOne(termURI, store.PropDefinition)) {
+				if incorporated.term == local {
+					carried[termURI] = incorporated
+				}
+			}
+		}
+		for _, articleURI := range sortedSubjects(tripleStore, store.ClassArticle) {
+			scan(articleURI, tripleStore.GetOne(articleURI, store.PropText))
+			for _, usage := range tripleStore.Find(articleURI, store.PropUsesTerm, "") {
+				if incorporated, ok := carried[usage.Object]; ok {
+					link(articleURI, incorporated.term, incorporated.target, incorporated.targetURI, usage.Object)
+				}
+			}
+		}
+
+		if !dryRun && (removed > 0 || added > 0) {
+			if err := lib.writeTriples(document.entry.StorageHash, tripleStore, document.entry.StorageFormat); err != nil {
+				return nil, fmt.Errorf("%s: %w", documentID, err)
+			}
+		}
+	}
+	return report, nil
+}
+
+// loadExternalDefinitionDocument indexes the defined terms of a document.
+func loadExternalDefinitionDocument(entry *DocumentEntry, tripleStore *store.TripleStore) *externalDefinitionDocument {
+	document := &externalDefinitionDocument{entry: entry, tripleStore: tripleStore, terms: make(map[string]string)}
+	for _, termURI := range sortedSubjects(tripleStore, store.ClassDefinedTerm) {
+		normalized := normalizeDefinedTerm(tripleStore.GetOne(termURI, store.PropTerm))
+		if _, seen := document.terms[normalized]; normalized != "" && !seen {
+			document.terms[normalized] = termURI
+			document.byLength = append(document.byLength, normalized)
+		}
+	}
+	sort.SliceStable(document.byLength, func(i, j int) bool {
+		return len(document.byLength[i]) > len(document.byLength[j])
+	})
+	return document
+}
+
+// termBefore returns the longest defined term, or its plural, that ends
+// the text before an incorporation, ignoring closing quotes.
+func (document *externalDefinitionDocument) termBefore(text string) string {
+	text = strings.TrimRight(strings.ToLower(text), " ,'’\"”")
+	for _, term := range document.byLength {
+		for _, form := range []string{term, term + "s"} {
+			if !strings.HasSuffix(text, form) {
+				continue
+			}
+			if before := strings.TrimSuffix(text, form); before == "" || !isWordByte(before[len(before)-1]) {
+				return term
+			}
+		}
+	}
+	return ""
+}
+
+// sortedSubjects returns the subjects of class in tripleStore, sorted.
+func sortedSubjects(tripleStore *store.TripleStore, class string) []string {
+	var subjects []string
+	for _, triple := range tripleStore.Find("", store.RDFType, class) {
+		subjects = append(subjects, triple.Subject)
+	}
+	sort.Strings(subjects)
+	return subjects
+}
+
+func normalizeDefinedTerm(term string) string {
+	return strings.Join(strings.Fields(strings.ToLower(term)), " ")
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b == '-' || b >= 0x80
+}
+
+// FormatExternalDefinitionsTable formats an external definition report for
+// terminal output.
+func FormatExternalDefinitionsTable(report *ExternalDefinitionReport) string {
+	var builder strings.Builder
+
+	if report.DryRun {
+		builder.WriteString("Dry run: no changes written.\n")
+	}
+	builder.WriteString(fmt.Sprintf("Documents: %d | Links: %d | Unresolved: %d\n",
+		report.Documents, len(report.Links), len(report.Unresolved)))
+
+	if len(report.Links) > 0 {
+		builder.WriteString("\nLinks:\n")
+	}
+	for _, link := range report.Links {
+		via := ""
+		if link.Via != "" {
+			via = " (via " + localName(link.Via) + ")"
+		}
+		builder.WriteString(fmt.Sprintf("  %-14s %-28s %q -> %s%s\n",
+			link.DocumentID, localName(link.Subject), link.Term, link.TargetDocumentID, via))
+	}
+
+	if len(report.Unresolved) > 0 {
+		builder.WriteString("\nUnresolved:\n")
+	}
+	for _, unresolved := range report.Unresolved {
+		builder.WriteString(fmt.Sprintf("  %-14s %-28s %s: %s\n",
+			unresolved.DocumentID, localName(unresolved.Subject), unresolved.Reason, unresolved.Citation))
+	}
+	return builder.String()
+}
+
+// FormatExternalDefinitionsJSON formats an external definition report as
+// indented JSON.
+func FormatExternalDefinitionsJSON(report *ExternalDefinitionReport) string {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(data)
+}
+
+// localName returns the part of a URI after its last slash.
+func localName(uri string) string {
+	return uri[strings.LastIndex(uri, "/")+1:]
+}
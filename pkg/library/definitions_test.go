@@ -0,0 +1,167 @@
+package library
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+const definitionsTestBase = `REGULATION (EU) 2099/1 OF THE EUROPEAN PARLIAMENT AND OF THE COUNCIL
+
+on the protection of personal data
+
+CHAPTER I
+General provisions
+
+Article 1
+Subject matter
+
+This Regulation lays down rules on the processing of personal data.
+
+Article 2
+Definitions
+
+For the purposes of this Regulation:
+
+(1) 'personal data' means any information relating to an identified or identifiable natural person;
+
+(2) 'profiling' means any form of automated processing of personal data to evaluate personal aspects;
+`
+
+const definitionsTestIncorporating = `REGULATION (EU) 2099/2 OF THE EUROPEAN PARLIAMENT AND OF THE COUNCIL
+
+on automated systems
+
+CHAPTER I
+General provisions
+
+Article 1
+Definitions
+
+For the purposes of this Regulation:
+
+(1) 'personal data' means personal data as defined in Article 2, point (1) of Regulation (EU) 2099/1;
+
+(2) 'standard' means a standard as defined in Article 2 of Regulation (EU) No 1025/2012;
+
+Article 2
+Data governance
+
+Providers shall process personal data only where profiling as defined in
+Article 2, point (2) of Regulation (EU) 2099/1 is excluded, and shall apply
+the rules in Article 1 of this Regulation.
+`
+
+func setupDefinitionsTestLibrary(t *testing.T) *Library {
+	t.Helper()
+	lib, err := Init(filepath.Join(t.TempDir(), "lib"), "")
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if _, err := lib.AddDocument("eu-base", []byte(definitionsTestBase), AddOptions{Format: "eu", Jurisdiction: "EU"}); err != nil {
+		t.Fatalf("AddDocument (eu-base) failed: %v", err)
+	}
+	if _, err := lib.AddDocument("eu-systems", []byte(definitionsTestIncorporating), AddOptions{Format: "eu", Jurisdiction: "EU"}); err != nil {
+		t.Fatalf("AddDocument (eu-systems) failed: %v", err)
+	}
+	return lib
+}
+
+func TestLinkExternalDefinitions(t *testing.T) {
+	lib := setupDefinitionsTestLibrary(t)
+
+	dryRun, err := lib.LinkExternalDefinitions(true)
+	if err != nil {
+		t.Fatalf("LinkExternalDefinitions (dry run) failed: %v", err)
+	}
+	if len(dryRun.Links) == 0 {
+		t.Fatalf("expected links in dry run: %+v", dryRun)
+	}
+	systems, err := lib.LoadTripleStore("eu-systems")
+	if err != nil {
+		t.Fatalf("LoadTripleStore failed: %v", err)
+	}
+	if edges := systems.Find("", store.PropUsesExternalDefinition, ""); len(edges) != 0 {
+		t.Fatalf("dry run wrote %d edges", len(edges))
+	}
+
+	report, err := lib.LinkExternalDefinitions(false)
+	if err != nil {
+		t.Fatalf("LinkExternalDefinitions failed: %v", err)
+	}
+	base, err := lib.LoadTripleStore("eu-base")
+	if err != nil {
+		t.Fatalf("LoadTripleStore failed: %v", err)
+	}
+	systems, err = lib.LoadTripleStore("eu-systems")
+	if err != nil {
+		t.Fatalf("LoadTripleStore failed: %v", err)
+	}
+
+	termURI := func(tripleStore *store.TripleStore, term string) string {
+		for _, triple := range tripleStore.Find("", store.PropTerm, term) {
+			return triple.Subject
+		}
+		t.Fatalf("no definition of %q", term)
+		return ""
+	}
+	articleURI := func(tripleStore *store.TripleStore, suffix string) string {
+		for _, triple := range tripleStore.Find("", store.RDFType, store.ClassArticle) {
+			if strings.HasSuffix(triple.Subject, suffix) {
+				return triple.Subject
+			}
+		}
+		t.Fatalf("no article %s", suffix)
+		return ""
+	}
+
+	personalData := termURI(base, "personal data")
+	profiling := termURI(base, "profiling")
+	governance := articleURI(systems, ":Art2")
+	tests := []struct {
+		name, subject, target string
+	}{
+		{"incorporating definition", termURI(systems, "personal data"), personalData},
+		{"provision using the incorporating definition", governance, personalData},
+		{"provision incorporating a definition", governance, profiling},
+	}
+	for _, tt := range tests {
+		if !systems.Exists(tt.subject, store.PropUsesExternalDefinition, tt.target) {
+			t.Errorf("%s: missing %s -> %s", tt.name, tt.subject, tt.target)
+		}
+	}
+	if edges := base.Find("", store.PropUsesExternalDefinition, ""); len(edges) != 0 {
+		t.Errorf("base document links to itself: %v", edges)
+	}
+
+	var via bool
+	for _, link := range report.Links {
+		if link.Subject == governance && link.Target == personalData && link.Via != "" {
+			via = true
+		}
+	}
+	if !via {
+		t.Errorf("expected the governance article linked via the local definition: %+v", report.Links)
+	}
+	if len(report.Unresolved) == 0 || !strings.Contains(report.Unresolved[0].Citation, "1025/2012") {
+		t.Errorf("expected the standards regulation to be unresolved: %+v", report.Unresolved)
+	}
+
+	rerun, err := lib.LinkExternalDefinitions(false)
+	if err != nil {
+		t.Fatalf("LinkExternalDefinitions (rerun) failed: %v", err)
+	}
+	systems, err = lib.LoadTripleStore("eu-systems")
+	if err != nil {
+		t.Fatalf("LoadTripleStore failed: %v", err)
+	}
+	if len(rerun.Links) != len(report.Links) || len(systems.Find("", store.PropUsesExternalDefinition, "")) != len(report.Links) {
+		t.Errorf("rerun changed the links: %d, want %d", len(rerun.Links), len(report.Links))
+	}
+
+	if table := FormatExternalDefinitionsTable(report); !strings.Contains(table, "Links: 4") {
+		t.Errorf("unexpected table:\n%s", table)
+	}
+}
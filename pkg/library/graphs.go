@@ -0,0 +1,70 @@
+package library
+
+import (
+	"fmt"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// DocumentGraphURI returns the IRI of the named graph a document's triples
+// are exported in. It is the document's scoped base URI, derived even for
+// documents ingested under the shared library base URI, so that every
+// document gets a distinct graph.
+func (lib *Library) DocumentGraphURI(documentID string) string {
+	lib.mu.RLock()
+	defer lib.mu.RUnlock()
+
+	jurisdiction := ""
+	if entry := lib.findDocumentUnsafe(documentID); entry != nil {
+		jurisdiction = entry.Jurisdiction
+	}
+	return DocumentBaseURI(lib.manifest.BaseURI, jurisdiction, documentID)
+}
+
+// LoadNamedGraphs loads each document into its own named graph, described
+// by its identifier, title, and source, for export to quad stores. With no
+// IDs, all ready documents are loaded.
+func (lib *Library) LoadNamedGraphs(documentIDs ...string) ([]store.NamedGraph, error) {
+	if len(documentIDs) == 0 {
+		documentIDs = lib.ReadyDocumentIDs()
+	}
+
+	graphs := make([]store.NamedGraph, 0, len(documentIDs))
+	for _, documentID := range documentIDs {
+		tripleStore, err := lib.LoadTripleStore(documentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", documentID, err)
+		}
+		entry := lib.GetDocument(documentID)
+
+		metadata := map[string]string{
+			store.PrefixDC + "identifier": entry.ID,
+		}
+		if title := documentTitle(entry); title != "" {
+			metadata[store.PrefixDC+"title"] = title
+		}
+		if entry.SourceInfo != "" {
+			metadata[store.PrefixDC+"source"] = entry.SourceInfo
+		}
+		if entry.Jurisdiction != "" {
+			metadata[store.PrefixDC+"coverage"] = entry.Jurisdiction
+		}
+
+		graphs = append(graphs, store.NamedGraph{
+			Name:     lib.DocumentGraphURI(documentID),
+			Store:    tripleStore,
+			Metadata: metadata,
+		})
+	}
+	return graphs, nil
+}
+
+// documentTitle returns the most descriptive name recorded for a document.
+func documentTitle(entry *DocumentEntry) string {
+	for _, title := range []string{entry.FullName, entry.Name, entry.ShortName} {
+		if title != "" {
+			return title
+		}
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+package library
+
+import (
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func TestLoadNamedGraphs(t *testing.T) {
+	// Both documents share the library base URI, but still get distinct
+	// graphs.
+	lib := setupMergeTestLibrary(t)
+
+	graphs, err := lib.LoadNamedGraphs()
+	if err != nil {
+		t.Fatalf("LoadNamedGraphs failed: %v", err)
+	}
+	if len(graphs) != 2 {
+		t.Fatalf("expected 2 graphs, got %d", len(graphs))
+	}
+
+	expectedNames := []string{lib.BaseURI() + "doc-a/", lib.BaseURI() + "doc-b/"}
+	for i, graph := range graphs {
+		if graph.Name != expectedNames[i] {
+			t.Errorf("graph %d name: got %q, want %q", i, graph.Name, expectedNames[i])
+		}
+		if graph.Store == nil || graph.Store.Count() == 0 {
+			t.Errorf("graph %s has no triples", graph.Name)
+		}
+	}
+	if got := graphs[0].Metadata[store.PrefixDC+"identifier"]; got != "doc-a" {
+		t.Errorf("dc:identifier: got %q, want doc-a", got)
+	}
+
+	selected, err := lib.LoadNamedGraphs("doc-b")
+	if err != nil {
+		t.Fatalf("LoadNamedGraphs(doc-b) failed: %v", err)
+	}
+	if len(selected) != 1 || selected[0].Name != expectedNames[1] {
+		t.Errorf("expected only doc-b, got %+v", selected)
+	}
+
+	if _, err := lib.LoadNamedGraphs("missing"); err == nil {
+		t.Error("expected an error for a missing document")
+	}
+}
@@ -0,0 +1,237 @@
+package library
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+)
+
+const healthFileName = "health.json"
+
+// Health summary status values, from best to worst.
+const (
+	HealthPassing = "passing"
+	HealthWarning = "warning"
+	HealthFailing = "failing"
+	HealthUnknown = "unknown"
+)
+
+// ValidationRecord captures the outcome of the most recent recorded
+// validation run against a document.
+type ValidationRecord struct {
+	Source      string    `json:"source"`
+	Score       float64   `json:"score"`
+	Threshold   float64   `json:"threshold"`
+	Status      string    `json:"status"` // PASS, WARN, or FAIL
+	ValidatedAt time.Time `json:"validated_at"`
+}
+
+// LinkCheckRecord captures the outcome of the most recent recorded external
+// link check.
+type LinkCheckRecord struct {
+	Source     string    `json:"source"`
+	TotalLinks int       `json:"total_links"`
+	Broken     int       `json:"broken"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// maxHealthHistory is the number of validation and link check results kept
+// in a library's history.
+const maxHealthHistory = 100
+
+// HealthRecord holds the recorded validation and link check results for a
+// library. It is stored alongside the manifest in health.json.
+type HealthRecord struct {
+	LastValidation *ValidationRecord `json:"last_validation,omitempty"`
+	LastLinkCheck  *LinkCheckRecord  `json:"last_link_check,omitempty"`
+
+	// Validations and LinkChecks are the most recent recorded results,
+	// oldest first.
+	Validations []ValidationRecord `json:"validations,omitempty"`
+	LinkChecks  []LinkCheckRecord  `json:"link_checks,omitempty"`
+
+	// Conflicts caches the merge conflict count of the ready documents.
+	Conflicts *ConflictCheck `json:"conflicts,omitempty"`
+}
+
+// ConflictCheck is the URI conflict count found by merging the ready
+// documents, kept until the documents change.
+type ConflictCheck struct {
+	// Fingerprint identifies the ready documents' entries the count was
+	// computed for.
+	Fingerprint string    `json:"fingerprint"`
+	Count       int       `json:"count"`
+	CheckedAt   time.Time `json:"checked_at"`
+}
+
+// HealthSummary is a compact health overview of a library suitable for
+// status badges and dashboard tiles. Fields that have never been recorded
+// are null.
+type HealthSummary struct {
+	Status           string     `json:"status"`
+	Documents        int        `json:"documents"`
+	FailedDocuments  int        `json:"failed_documents"`
+	ValidationScore  *float64   `json:"validation_score"`
+	ValidationStatus string     `json:"validation_status,omitempty"`
+	ValidatedAt      *time.Time `json:"validated_at,omitempty"`
+	BrokenLinks      *int       `json:"broken_links"`
+	LinksCheckedAt   *time.Time `json:"links_checked_at,omitempty"`
+	OpenConflicts    int        `json:"open_conflicts"`
+	GeneratedAt      time.Time  `json:"generated_at"`
+}
+
+// LoadHealth reads the recorded health results. A library with no recorded
+// results returns an empty record.
+func (lib *Library) LoadHealth() (*HealthRecord, error) {
+	data, err := lib.backend.Read(healthFileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &HealthRecord{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read health record: %w", err)
+	}
+
+	var record HealthRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to parse health record: %w", err)
+	}
+	return &record, nil
+}
+
+// RecordValidation stores validation as the library's most recent
+// validation result.
+func (lib *Library) RecordValidation(validation ValidationRecord) error {
+	return lib.updateHealth(func(record *HealthRecord) {
+		record.LastValidation = &validation
+		record.Validations = appendHistory(record.Validations, validation)
+	})
+}
+
+// RecordLinkCheck stores linkCheck as the library's most recent link check
+// result.
+func (lib *Library) RecordLinkCheck(linkCheck LinkCheckRecord) error {
+	return lib.updateHealth(func(record *HealthRecord) {
+		record.LastLinkCheck = &linkCheck
+		record.LinkChecks = appendHistory(record.LinkChecks, linkCheck)
+	})
+}
+
+// appendHistory appends result, dropping the oldest results beyond
+// maxHealthHistory.
+func appendHistory[T any](history []T, result T) []T {
+	history = append(history, result)
+	if len(history) > maxHealthHistory {
+		history = history[len(history)-maxHealthHistory:]
+	}
+	return history
+}
+
+func (lib *Library) updateHealth(update func(*HealthRecord)) error {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	record, err := lib.LoadHealth()
+	if err != nil {
+		return err
+	}
+	update(record)
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal health record: %w", err)
+	}
+	if err := lib.backend.Write(healthFileName, data); err != nil {
+		return fmt.Errorf("failed to write health record: %w", err)
+	}
+	return nil
+}
+
+// HealthSummary combines document counts, the recorded validation and link
+// check results, and the URI conflicts found by merging all ready documents
+// into a single summary. The conflict count is cached in health.json, so
+// the documents are only merged again after one of them changes.
+func (lib *Library) HealthSummary() (*HealthSummary, error) {
+	record, err := lib.LoadHealth()
+	if err != nil {
+		return nil, err
+	}
+
+	libraryStats := lib.Stats()
+	summary := &HealthSummary{
+		Documents:       libraryStats.TotalDocuments,
+		FailedDocuments: libraryStats.ByStatus[string(StatusFailed)],
+		GeneratedAt:     time.Now().UTC(),
+	}
+
+	if validation := record.LastValidation; validation != nil {
+		score := validation.Score
+		validatedAt := validation.ValidatedAt
+		summary.ValidationScore = &score
+		summary.ValidationStatus = validation.Status
+		summary.ValidatedAt = &validatedAt
+	}
+	if linkCheck := record.LastLinkCheck; linkCheck != nil {
+		broken := linkCheck.Broken
+		checkedAt := linkCheck.CheckedAt
+		summary.BrokenLinks = &broken
+		summary.LinksCheckedAt = &checkedAt
+	}
+
+	if readyIDs := lib.ReadyDocumentIDs(); len(readyIDs) > 1 {
+		fingerprint := lib.ReadyFingerprint()
+		if cached := record.Conflicts; cached != nil && cached.Fingerprint == fingerprint {
+			summary.OpenConflicts = cached.Count
+		} else {
+			_, report, err := lib.MergeTripleStores(MergeOptions{}, readyIDs...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check merge conflicts: %w", err)
+			}
+			summary.OpenConflicts = len(report.Conflicts)
+			// A library that cannot be written to is merged every time.
+			lib.updateHealth(func(record *HealthRecord) {
+				record.Conflicts = &ConflictCheck{Fingerprint: fingerprint, Count: summary.OpenConflicts, CheckedAt: time.Now().UTC()}
+			})
+		}
+	}
+
+	summary.Status = summary.overallStatus()
+	return summary, nil
+}
+
+// ReadyFingerprint hashes the entries of the ready documents, which change
+// whenever a document is added, updated, removed, or edited. A
+// ConflictCheck with another fingerprint is out of date.
+func (lib *Library) ReadyFingerprint() string {
+	hash := sha256.New()
+	for _, entry := range lib.ListDocuments() {
+		if entry.Status != StatusReady {
+			continue
+		}
+		data, _ := json.Marshal(entry)
+		hash.Write(data)
+		hash.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// overallStatus grades the summary: failing on a failed validation or
+// failed ingestion, warning on a validation warning, broken links, or open
+// conflicts, unknown for an empty library, and passing otherwise.
+func (summary *HealthSummary) overallStatus() string {
+	switch {
+	case summary.ValidationStatus == "FAIL" || summary.FailedDocuments > 0:
+		return HealthFailing
+	case summary.ValidationStatus == "WARN" ||
+		(summary.BrokenLinks != nil && *summary.BrokenLinks > 0) ||
+		summary.OpenConflicts > 0:
+		return HealthWarning
+	case summary.Documents == 0:
+		return HealthUnknown
+	default:
+		return HealthPassing
+	}
+}
@@ -0,0 +1,119 @@
+package library
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHealthSummaryEmptyLibrary(t *testing.T) {
+	lib, err := Init(filepath.Join(t.TempDir(), "lib"), "")
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	summary, err := lib.HealthSummary()
+	if err != nil {
+		t.Fatalf("HealthSummary failed: %v", err)
+	}
+	if summary.Status != HealthUnknown {
+		t.Errorf("status = %s, want %s", summary.Status, HealthUnknown)
+	}
+	if summary.ValidationScore != nil || summary.BrokenLinks != nil {
+		t.Errorf("expected unrecorded score and links, got %+v", summary)
+	}
+}
+
+func TestHealthSummaryRecordedResults(t *testing.T) {
+	lib := setupMergeTestLibrary(t)
+
+	if err := lib.RecordValidation(ValidationRecord{Source: "a.txt", Score: 0.92, Threshold: 0.8, Status: "PASS", ValidatedAt: time.Now()}); err != nil {
+		t.Fatalf("RecordValidation failed: %v", err)
+	}
+	if err := lib.RecordLinkCheck(LinkCheckRecord{Source: "a.txt", TotalLinks: 10, Broken: 2, CheckedAt: time.Now()}); err != nil {
+		t.Fatalf("RecordLinkCheck failed: %v", err)
+	}
+
+	// Recorded results survive reopening and each record keeps the other
+	reopened, err := Open(lib.Path())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	summary, err := reopened.HealthSummary()
+	if err != nil {
+		t.Fatalf("HealthSummary failed: %v", err)
+	}
+
+	if summary.Documents != 2 {
+		t.Errorf("documents = %d, want 2", summary.Documents)
+	}
+	if summary.ValidationScore == nil || *summary.ValidationScore != 0.92 || summary.ValidationStatus != "PASS" {
+		t.Errorf("validation = %v %s, want 0.92 PASS", summary.ValidationScore, summary.ValidationStatus)
+	}
+	if summary.BrokenLinks == nil || *summary.BrokenLinks != 2 {
+		t.Errorf("broken links = %v, want 2", summary.BrokenLinks)
+	}
+	if summary.OpenConflicts == 0 {
+		t.Error("expected open conflicts between documents that both mint Art1")
+	}
+	if summary.Status != HealthWarning {
+		t.Errorf("status = %s, want %s", summary.Status, HealthWarning)
+	}
+
+	if err := reopened.RecordValidation(ValidationRecord{Score: 0.5, Status: "FAIL", ValidatedAt: time.Now()}); err != nil {
+		t.Fatalf("RecordValidation failed: %v", err)
+	}
+	summary, err = reopened.HealthSummary()
+	if err != nil {
+		t.Fatalf("HealthSummary failed: %v", err)
+	}
+	if summary.Status != HealthFailing {
+		t.Errorf("status after failed validation = %s, want %s", summary.Status, HealthFailing)
+	}
+}
+
+func TestHealthSummaryCachesConflicts(t *testing.T) {
+	lib := setupMergeTestLibrary(t)
+
+	first, err := lib.HealthSummary()
+	if err != nil {
+		t.Fatalf("HealthSummary failed: %v", err)
+	}
+	record, _ := lib.LoadHealth()
+	if record.Conflicts == nil || record.Conflicts.Count != first.OpenConflicts {
+		t.Fatalf("conflict count not cached: %+v", record.Conflicts)
+	}
+
+	// A cached count is used while the documents are unchanged...
+	if err := lib.updateHealth(func(record *HealthRecord) { record.Conflicts.Count = 99 }); err != nil {
+		t.Fatal(err)
+	}
+	if summary, _ := lib.HealthSummary(); summary.OpenConflicts != 99 {
+		t.Errorf("open conflicts = %d, want the cached 99", summary.OpenConflicts)
+	}
+
+	// ...and recomputed once one of them changes.
+	status := LegalStatusInForce
+	if _, err := lib.SetMetadata("doc-a", MetadataUpdate{LegalStatus: &status}); err != nil {
+		t.Fatal(err)
+	}
+	if summary, _ := lib.HealthSummary(); summary.OpenConflicts != first.OpenConflicts {
+		t.Errorf("open conflicts after a change = %d, want %d", summary.OpenConflicts, first.OpenConflicts)
+	}
+}
+
+func TestHealthHistory(t *testing.T) {
+	lib := setupMergeTestLibrary(t)
+	for i := 0; i < maxHealthHistory+5; i++ {
+		if err := lib.RecordValidation(ValidationRecord{Score: float64(i), Status: "PASS", ValidatedAt: time.Now()}); err != nil {
+			t.Fatalf("RecordValidation failed: %v", err)
+		}
+	}
+	record, err := lib.LoadHealth()
+	if err != nil {
+		t.Fatalf("LoadHealth failed: %v", err)
+	}
+	if len(record.Validations) != maxHealthHistory || record.Validations[0].Score != 5 || record.LastValidation.Score != maxHealthHistory+4 {
+		t.Errorf("history has %d results starting at %v", len(record.Validations), record.Validations[0].Score)
+	}
+}
@@ -1,13 +1,16 @@
 package library
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/coolbeans/regula/pkg/extract"
 	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/telemetry"
 )
 
 const defaultBaseURI = "https://regula.dev/regulations/"
@@ -16,6 +19,22 @@ const defaultBaseURI = "https://regula.dev/regulations/"
 // populated TripleStore with extraction statistics. An optional formatHint
 // (e.g., "us", "eu", "uk") bypasses automatic format detection.
 func IngestFromText(sourceText []byte, documentID string, baseURI string, formatHint ...string) (*IngestResult, error) {
+	return IngestFromTextWithContext(context.Background(), sourceText, documentID, baseURI, formatHint...)
+}
+
+// IngestFromTextWithContext is IngestFromText with the parse and build
+// stages timed as telemetry spans under ctx.
+func IngestFromTextWithContext(ctx context.Context, sourceText []byte, documentID string, baseURI string, formatHint ...string) (*IngestResult, error) {
+	format := ""
+	if len(formatHint) > 0 {
+		format = formatHint[0]
+	}
+	return ingestFromText(ctx, sourceText, documentID, baseURI, format, nil, nil)
+}
+
+// ingestFromText is IngestFromText with recurrence annotations applied to
+// the extracted obligations and manual mappings applied to the resolver.
+func ingestFromText(ctx context.Context, sourceText []byte, documentID string, baseURI string, format string, recurrence []extract.RecurrenceAnnotation, mappings []extract.ReferenceMapping) (result *IngestResult, err error) {
 	if len(sourceText) == 0 {
 		return nil, fmt.Errorf("source text is empty")
 	}
@@ -28,17 +47,27 @@ func IngestFromText(sourceText []byte, documentID string, baseURI string, format
 
 	regID := strings.ToUpper(documentID)
 
+	ctx = telemetry.With(ctx, slog.String(telemetry.KeyDocumentID, documentID))
+	ctx, ingestSpan := telemetry.Start(ctx, "ingest", slog.Int("source_bytes", len(sourceText)))
+	defer func() {
+		if err != nil {
+			ingestSpan.RecordError(err)
+		} else {
+			ingestSpan.SetAttributes(slog.Int("triples", result.Stats.TotalTriples))
+		}
+		ingestSpan.End()
+	}()
+
 	// Step 1: Parse document structure
-	parser := extract.NewParser()
-	// Apply format hint if provided, bypassing auto-detection
-	if len(formatHint) > 0 && formatHint[0] != "" {
-		parser.SetFormatHint(extract.DocumentFormat(formatHint[0]))
-	}
-	reader := strings.NewReader(string(sourceText))
-	doc, err := parser.Parse(reader)
+	_, parseSpan := telemetry.Start(ctx, "parse")
+	doc, err := parseSource(sourceText, format)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse document: %w", err)
+		parseSpan.RecordError(err)
+		parseSpan.End()
+		return nil, err
 	}
+	parseSpan.SetAttributes(slog.String("type", string(doc.Type)), slog.Int("articles", len(doc.AllArticles())))
+	parseSpan.End()
 
 	// Step 2: Extract definitions
 	defExtractor := extract.NewDefinitionExtractor()
@@ -48,20 +77,39 @@ func IngestFromText(sourceText []byte, documentID string, baseURI string, format
 
 	// Step 4: Extract rights and obligations
 	semExtractor := extract.NewSemanticExtractor()
+	semExtractor.SetRecurrenceAnnotations(recurrence)
 
 	// Step 5: Resolve references
 	resolver := extract.NewReferenceResolver(baseURI, regID)
 	resolver.IndexDocument(doc)
+	resolver.SetManualMappings(mappings)
 
-	// Step 6: Build complete knowledge graph
+	// Step 6: Extract and build complete knowledge graph
+	_, buildSpan := telemetry.Start(ctx, "build")
 	tripleStore := store.NewTripleStore()
 	builder := store.NewGraphBuilder(tripleStore, baseURI)
-	buildStats, err := builder.BuildComplete(doc, defExtractor, refExtractor, resolver, semExtractor)
+	buildStats, err := builder.BuildCompleteWithContext(ctx, doc, defExtractor, refExtractor, resolver, semExtractor)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build graph: %w", err)
+		err = fmt.Errorf("failed to build graph: %w", err)
+		buildSpan.RecordError(err)
+		buildSpan.End()
+		return nil, err
 	}
+	buildSpan.SetAttributes(slog.Int("triples", buildStats.TotalTriples), slog.Int("definitions", buildStats.Definitions), slog.Int("references", buildStats.References))
+	buildSpan.End()
 
-	documentStats := &DocumentStats{
+	return &IngestResult{
+		TripleStore: tripleStore,
+		Stats:       NewDocumentStats(buildStats, len(sourceText)),
+		DocumentID:  documentID,
+		RegID:       regID,
+		ShortTitles: ExtractShortTitles(sourceText),
+	}, nil
+}
+
+// NewDocumentStats converts graph build statistics into document stats.
+func NewDocumentStats(buildStats *store.BuildStats, sourceBytes int) *DocumentStats {
+	return &DocumentStats{
 		TotalTriples: buildStats.TotalTriples,
 		Articles:     buildStats.Articles,
 		Chapters:     buildStats.Chapters,
@@ -72,15 +120,22 @@ func IngestFromText(sourceText []byte, documentID string, baseURI string, format
 		Rights:       buildStats.Rights,
 		Obligations:  buildStats.Obligations,
 		TermUsages:   buildStats.TermUsages,
-		SourceBytes:  len(sourceText),
+		SourceBytes:  sourceBytes,
 	}
+}
 
-	return &IngestResult{
-		TripleStore: tripleStore,
-		Stats:       documentStats,
-		DocumentID:  documentID,
-		RegID:       regID,
-	}, nil
+// parseSource parses source text into a document. A format hint, if
+// provided, bypasses automatic format detection.
+func parseSource(sourceText []byte, format string) (*extract.Document, error) {
+	parser := extract.NewParser()
+	if format != "" {
+		parser.SetFormatHint(extract.DocumentFormat(format))
+	}
+	doc, err := parser.Parse(strings.NewReader(string(sourceText)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+	return doc, nil
 }
 
 // IngestFromFile reads a file from disk and runs the ingestion pipeline.
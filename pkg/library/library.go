@@ -1,11 +1,14 @@
 package library
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"sync"
 	"time"
@@ -19,38 +22,54 @@ const (
 	sourceFileName   = "source.txt"
 	triplesFileName  = "triples.json"
 	metadataFileName = "metadata.json"
-	manifestVersion  = "1.0.0"
+	manifestVersion  = "1.1.0"
+
+	binaryTriplesFileName = "triples.bin"
 )
 
+// DefaultStorageFormat is the triple storage format of new libraries.
+const DefaultStorageFormat = StorageBinary
+
 // Library manages a persistent collection of ingested legislation documents.
 type Library struct {
 	mu       sync.RWMutex
 	path     string
+	backend  Backend
 	manifest *LibraryManifest
 }
 
-// Init creates a new library at the given path with default settings.
+// Init creates a new library at the given path with default settings. The
+// path is a local directory, or an s3:// or gs:// URL (see OpenBackend).
 func Init(libraryPath string, baseURI string) (*Library, error) {
 	if baseURI == "" {
 		baseURI = defaultBaseURI
 	}
 
+	backend, err := OpenBackend(libraryPath)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create directory structure
-	documentsPath := filepath.Join(libraryPath, documentsDir)
-	if err := os.MkdirAll(documentsPath, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create library directory: %w", err)
+	if !IsRemotePath(libraryPath) {
+		documentsPath := filepath.Join(libraryPath, documentsDir)
+		if err := os.MkdirAll(documentsPath, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create library directory: %w", err)
+		}
 	}
 
 	manifest := &LibraryManifest{
-		Version:   manifestVersion,
-		BaseURI:   baseURI,
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC(),
-		Documents: []*DocumentEntry{},
+		Version:       manifestVersion,
+		BaseURI:       baseURI,
+		CreatedAt:     time.Now().UTC(),
+		UpdatedAt:     time.Now().UTC(),
+		Documents:     []*DocumentEntry{},
+		StorageFormat: DefaultStorageFormat,
 	}
 
 	lib := &Library{
 		path:     libraryPath,
+		backend:  backend,
 		manifest: manifest,
 	}
 
@@ -61,10 +80,15 @@ func Init(libraryPath string, baseURI string) (*Library, error) {
 	return lib, nil
 }
 
-// Open loads an existing library from disk.
+// Open loads an existing library from a local directory or, for s3:// and
+// gs:// URLs, from object storage. Document graphs are fetched when first
+// loaded.
 func Open(libraryPath string) (*Library, error) {
-	manifestPath := filepath.Join(libraryPath, manifestFileName)
-	data, err := os.ReadFile(manifestPath)
+	backend, err := OpenBackend(libraryPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := backend.Read(manifestFileName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read library manifest: %w", err)
 	}
@@ -76,33 +100,100 @@ func Open(libraryPath string) (*Library, error) {
 
 	return &Library{
 		path:     libraryPath,
+		backend:  backend,
 		manifest: &manifest,
 	}, nil
 }
 
 // AddDocument ingests source text and stores it in the library.
 func (lib *Library) AddDocument(documentID string, sourceText []byte, opts AddOptions) (*DocumentEntry, error) {
+	return lib.AddDocumentWithContext(context.Background(), documentID, sourceText, opts)
+}
+
+// AddDocumentWithContext is AddDocument with the ingestion stages timed as
+// telemetry spans under ctx.
+func (lib *Library) AddDocumentWithContext(ctx context.Context, documentID string, sourceText []byte, opts AddOptions) (*DocumentEntry, error) {
+	return lib.CommitDocument(lib.PrepareDocument(ctx, documentID, sourceText, opts))
+}
+
+// PreparedDocument is a document ingested by PrepareDocument and not yet
+// committed to the library.
+type PreparedDocument struct {
+	documentID string
+	sourceText []byte
+	opts       AddOptions
+	baseURI    string
+	existing   *DocumentEntry
+	result     *IngestResult
+	err        error
+}
+
+// DocumentID returns the ID the document will be stored under.
+func (prepared *PreparedDocument) DocumentID() string {
+	return prepared.documentID
+}
+
+// PrepareDocument runs the ingestion pipeline for a document without
+// changing the library. It does not hold the library lock while ingesting,
+// so several documents can be prepared concurrently; CommitDocument then
+// stores them one at a time. Ingestion errors, including panics in the
+// pipeline, are kept in the result and reported by CommitDocument.
+func (lib *Library) PrepareDocument(ctx context.Context, documentID string, sourceText []byte, opts AddOptions) (prepared *PreparedDocument) {
+	prepared = &PreparedDocument{documentID: documentID, sourceText: sourceText, opts: opts}
+	if documentID == "" {
+		prepared.err = fmt.Errorf("document ID is required")
+		return prepared
+	}
+
+	lib.mu.RLock()
+	prepared.existing = lib.findDocumentUnsafe(documentID)
+	prepared.baseURI = opts.BaseURI
+	if prepared.baseURI == "" {
+		prepared.baseURI = DocumentBaseURI(lib.manifest.BaseURI, opts.Jurisdiction, documentID)
+	}
+	lib.mu.RUnlock()
+
+	if prepared.existing != nil && !opts.Force {
+		return prepared // idempotent: CommitDocument returns the existing entry
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			prepared.result = nil
+			prepared.err = fmt.Errorf("ingestion panicked: %v", recovered)
+		}
+	}()
+
+	// Run ingestion pipeline with format hint from options
+	prepared.result, prepared.err = ingestFromText(ctx, sourceText, documentID, prepared.baseURI, opts.Format, opts.RecurrenceAnnotations, nil)
+	return prepared
+}
+
+// CommitDocument stores a prepared document and records it in the
+// manifest. A document whose ingestion failed is recorded with failed
+// status and its error returned.
+func (lib *Library) CommitDocument(prepared *PreparedDocument) (*DocumentEntry, error) {
 	lib.mu.Lock()
 	defer lib.mu.Unlock()
 
+	documentID := prepared.documentID
 	if documentID == "" {
-		return nil, fmt.Errorf("document ID is required")
+		return nil, prepared.err
 	}
 
 	// Check for existing document
 	existing := lib.findDocumentUnsafe(documentID)
-	if existing != nil && !opts.Force {
+	if existing != nil && !prepared.opts.Force {
 		return existing, nil // idempotent: return existing entry
 	}
-
-	baseURI := opts.BaseURI
-	if baseURI == "" {
-		baseURI = lib.manifest.BaseURI
+	if prepared.result == nil && prepared.err == nil {
+		// Prepared as already present, but removed since.
+		prepared.err = fmt.Errorf("document %s was removed during ingestion", documentID)
 	}
 
-	// Run ingestion pipeline with format hint from options
-	result, err := IngestFromText(sourceText, documentID, baseURI, opts.Format)
-	if err != nil {
+	opts := prepared.opts
+	baseURI := prepared.baseURI
+	if err := prepared.err; err != nil {
 		// Record failure
 		entry := &DocumentEntry{
 			ID:          documentID,
@@ -121,6 +212,8 @@ func (lib *Library) AddDocument(documentID string, sourceText []byte, opts AddOp
 		}
 		return nil, fmt.Errorf("ingestion failed for %s: %w", documentID, err)
 	}
+	result := prepared.result
+	sourceText := prepared.sourceText
 
 	storageHash := hashDocumentID(documentID)
 
@@ -130,12 +223,9 @@ func (lib *Library) AddDocument(documentID string, sourceText []byte, opts AddOp
 	}
 
 	// Persist serialized triples
-	triplesData, err := SerializeTripleStore(result.TripleStore)
-	if err != nil {
-		return nil, fmt.Errorf("failed to serialize triples: %w", err)
-	}
-	if err := lib.writeDocumentFile(storageHash, triplesFileName, triplesData); err != nil {
-		return nil, fmt.Errorf("failed to save triples: %w", err)
+	storageFormat := lib.storageFormatUnsafe()
+	if err := lib.writeTriples(storageHash, result.TripleStore, storageFormat); err != nil {
+		return nil, err
 	}
 
 	// Persist metadata
@@ -148,19 +238,22 @@ func (lib *Library) AddDocument(documentID string, sourceText []byte, opts AddOp
 	}
 
 	entry := &DocumentEntry{
-		ID:           documentID,
-		Name:         opts.Name,
-		ShortName:    opts.ShortName,
-		FullName:     opts.FullName,
-		Jurisdiction: opts.Jurisdiction,
-		Format:       opts.Format,
-		Tags:         opts.Tags,
-		Status:       StatusReady,
-		IngestedAt:   time.Now().UTC(),
-		UpdatedAt:    time.Now().UTC(),
-		SourceInfo:   opts.SourceInfo,
-		Stats:        result.Stats,
-		StorageHash:  storageHash,
+		ID:            documentID,
+		Name:          opts.Name,
+		ShortName:     opts.ShortName,
+		FullName:      opts.FullName,
+		Jurisdiction:  opts.Jurisdiction,
+		Format:        opts.Format,
+		BaseURI:       baseURI,
+		Tags:          opts.Tags,
+		Status:        StatusReady,
+		IngestedAt:    time.Now().UTC(),
+		UpdatedAt:     time.Now().UTC(),
+		SourceInfo:    opts.SourceInfo,
+		ShortTitles:   result.ShortTitles,
+		Stats:         result.Stats,
+		StorageHash:   storageHash,
+		StorageFormat: storageFormat,
 	}
 
 	lib.upsertEntry(entry)
@@ -183,13 +276,16 @@ func (lib *Library) RemoveDocument(documentID string) error {
 	}
 
 	// Remove files
-	documentPath := filepath.Join(lib.path, documentsDir, entry.StorageHash)
-	if err := os.RemoveAll(documentPath); err != nil {
+	if err := lib.backend.DeletePrefix(path.Join(documentsDir, entry.StorageHash)); err != nil {
 		return fmt.Errorf("failed to remove document files: %w", err)
 	}
 
-	// Remove from manifest
+	// Remove from manifest, collections, and aliases
 	lib.removeEntry(documentID)
+	lib.removeAliasesOfUnsafe(documentID)
+	for _, collection := range lib.manifest.Collections {
+		collection.Documents = slices.DeleteFunc(collection.Documents, func(id string) bool { return id == documentID })
+	}
 
 	if err := lib.saveManifest(); err != nil {
 		return fmt.Errorf("failed to save manifest: %w", err)
@@ -198,11 +294,12 @@ func (lib *Library) RemoveDocument(documentID string) error {
 	return nil
 }
 
-// GetDocument returns the entry for a specific document.
+// GetDocument returns the entry for a specific document, or for the
+// canonical document when documentID is an alias.
 func (lib *Library) GetDocument(documentID string) *DocumentEntry {
 	lib.mu.RLock()
 	defer lib.mu.RUnlock()
-	return lib.findDocumentUnsafe(documentID)
+	return lib.lookupDocumentUnsafe(documentID)
 }
 
 // ListDocuments returns all document entries, sorted by ID.
@@ -220,12 +317,14 @@ func (lib *Library) ListDocuments() []*DocumentEntry {
 	return result
 }
 
-// LoadTripleStore loads and deserializes a single document's triple store.
+// LoadTripleStore loads and deserializes a single document's triple store,
+// with the entry's metadata (jurisdiction, tags, effective date, legal
+// status) added to the document node.
 func (lib *Library) LoadTripleStore(documentID string) (*store.TripleStore, error) {
 	lib.mu.RLock()
 	defer lib.mu.RUnlock()
 
-	entry := lib.findDocumentUnsafe(documentID)
+	entry := lib.lookupDocumentUnsafe(documentID)
 	if entry == nil {
 		return nil, fmt.Errorf("document not found: %s", documentID)
 	}
@@ -233,41 +332,42 @@ func (lib *Library) LoadTripleStore(documentID string) (*store.TripleStore, erro
 		return nil, fmt.Errorf("document %s is not ready (status: %s)", documentID, entry.Status)
 	}
 
-	data, err := lib.readDocumentFile(entry.StorageHash, triplesFileName)
+	tripleStore, err := lib.readTriples(entry)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read triples for %s: %w", documentID, err)
+		return nil, err
 	}
-
-	return DeserializeTripleStore(data)
+	addMetadataTriples(tripleStore, entry)
+	return tripleStore, nil
 }
 
-// LoadMergedTripleStore loads and merges triple stores for the specified documents.
+// LoadMergedTripleStore loads and merges triple stores for the specified
+// documents. Use MergeTripleStores to inspect conflicts between them.
 func (lib *Library) LoadMergedTripleStore(documentIDs ...string) (*store.TripleStore, error) {
-	merged := store.NewTripleStore()
-
-	for _, documentID := range documentIDs {
-		tripleStore, err := lib.LoadTripleStore(documentID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load %s: %w", documentID, err)
-		}
-		merged.MergeFrom(tripleStore)
-	}
-
-	return merged, nil
+	merged, _, err := lib.MergeTripleStores(MergeOptions{}, documentIDs...)
+	return merged, err
 }
 
 // LoadAllTripleStores loads and merges all ready documents into a single store.
 func (lib *Library) LoadAllTripleStores() (*store.TripleStore, error) {
+	return lib.LoadMergedTripleStore(lib.ReadyDocumentIDs()...)
+}
+
+// ReadyDocumentIDs returns the IDs of all documents with ready status,
+// leaving out duplicates that are aliases of another document.
+func (lib *Library) ReadyDocumentIDs() []string {
 	lib.mu.RLock()
+	defer lib.mu.RUnlock()
+
 	readyIDs := make([]string, 0)
 	for _, entry := range lib.manifest.Documents {
+		if _, aliased := lib.manifest.Aliases[entry.ID]; aliased {
+			continue
+		}
 		if entry.Status == StatusReady {
 			readyIDs = append(readyIDs, entry.ID)
 		}
 	}
-	lib.mu.RUnlock()
-
-	return lib.LoadMergedTripleStore(readyIDs...)
+	return readyIDs
 }
 
 // LoadSourceText returns the original source text for a document.
@@ -275,7 +375,7 @@ func (lib *Library) LoadSourceText(documentID string) ([]byte, error) {
 	lib.mu.RLock()
 	defer lib.mu.RUnlock()
 
-	entry := lib.findDocumentUnsafe(documentID)
+	entry := lib.lookupDocumentUnsafe(documentID)
 	if entry == nil {
 		return nil, fmt.Errorf("document not found: %s", documentID)
 	}
@@ -366,28 +466,64 @@ func (lib *Library) removeEntry(documentID string) {
 }
 
 func (lib *Library) saveManifest() error {
-	manifestPath := filepath.Join(lib.path, manifestFileName)
 	data, err := json.MarshalIndent(lib.manifest, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal manifest: %w", err)
 	}
-	return os.WriteFile(manifestPath, data, 0644)
+	return lib.backend.Write(manifestFileName, data)
 }
 
+// documentDir returns the directory of a document's files in a local
+// library.
 func (lib *Library) documentDir(storageHash string) string {
 	return filepath.Join(lib.path, documentsDir, storageHash)
 }
 
 func (lib *Library) writeDocumentFile(storageHash string, fileName string, data []byte) error {
-	dirPath := lib.documentDir(storageHash)
-	if err := os.MkdirAll(dirPath, 0755); err != nil {
-		return err
-	}
-	return os.WriteFile(filepath.Join(dirPath, fileName), data, 0644)
+	return lib.backend.Write(documentKey(storageHash, fileName), data)
 }
 
 func (lib *Library) readDocumentFile(storageHash string, fileName string) ([]byte, error) {
-	return os.ReadFile(filepath.Join(lib.documentDir(storageHash), fileName))
+	return lib.backend.Read(documentKey(storageHash, fileName))
+}
+
+// storageFormatUnsafe returns the format new triples are written in.
+func (lib *Library) storageFormatUnsafe() StorageFormat {
+	if lib.manifest.StorageFormat == "" {
+		return StorageJSON
+	}
+	return lib.manifest.StorageFormat
+}
+
+// readTriples loads a document's triples from the file its storage format
+// names.
+func (lib *Library) readTriples(entry *DocumentEntry) (*store.TripleStore, error) {
+	data, err := lib.readDocumentFile(entry.StorageHash, entry.StorageFormat.FileName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read triples for %s: %w", entry.ID, err)
+	}
+	return DeserializeTripleStore(data)
+}
+
+// writeTriples stores a document's triples in format, removing a copy left
+// in another format.
+func (lib *Library) writeTriples(storageHash string, tripleStore *store.TripleStore, format StorageFormat) error {
+	data, err := encodeTriples(tripleStore, format)
+	if err != nil {
+		return fmt.Errorf("failed to serialize triples: %w", err)
+	}
+	if err := lib.writeDocumentFile(storageHash, format.FileName(), data); err != nil {
+		return fmt.Errorf("failed to save triples: %w", err)
+	}
+	for _, fileName := range []string{triplesFileName, binaryTriplesFileName} {
+		if fileName == format.FileName() {
+			continue
+		}
+		if err := lib.backend.Delete(documentKey(storageHash, fileName)); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", fileName, err)
+		}
+	}
+	return nil
 }
 
 func hashDocumentID(documentID string) string {
@@ -0,0 +1,207 @@
+package library
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// MergeConflictType classifies a conflict found while merging documents.
+type MergeConflictType string
+
+const (
+	// MergeConflictURICollision indicates two documents minted the same
+	// node URI (e.g., both have ".../Regulation:Art1").
+	MergeConflictURICollision MergeConflictType = "uri_collision"
+
+	// MergeConflictFunctionalValue indicates documents assert different
+	// values for a single-valued predicate such as reg:title or reg:text.
+	MergeConflictFunctionalValue MergeConflictType = "functional_value"
+)
+
+// MergeOptions controls how document graphs are combined.
+type MergeOptions struct {
+	// NamespaceDocuments rewrites the locally minted URIs of documents
+	// ingested under the shared library base URI to "<base>/<documentID>/..."
+	// so that nodes from different documents can never collide. Documents
+	// with their own DocumentBaseURI are already scoped and left unchanged.
+	// References between documents are not rewritten and stay within the
+	// referencing document's namespace.
+	NamespaceDocuments bool
+
+	// Replacements are graphs to merge in place of the stored graphs of
+	// the documents they are keyed by, such as a draft bill's proposed
+	// versions of the documents it amends.
+	Replacements map[string]*store.TripleStore
+}
+
+// MergeConflict describes a single conflict in a merged graph.
+type MergeConflict struct {
+	Type        MergeConflictType `json:"type"`
+	Subject     string            `json:"subject"`
+	Predicate   string            `json:"predicate,omitempty"`
+	DocumentIDs []string          `json:"document_ids"`
+	Values      []string          `json:"values,omitempty"`
+}
+
+// MergeReport lists the conflicts detected while merging documents.
+type MergeReport struct {
+	DocumentIDs         []string        `json:"document_ids"`
+	Namespaced          bool            `json:"namespaced"`
+	TotalTriples        int             `json:"total_triples"`
+	DuplicateTriples    int             `json:"duplicate_triples"`
+	URICollisions       int             `json:"uri_collisions"`
+	FunctionalConflicts int             `json:"functional_conflicts"`
+	Conflicts           []MergeConflict `json:"conflicts"`
+}
+
+// HasConflicts reports whether any conflict was detected.
+func (report *MergeReport) HasConflicts() bool {
+	return len(report.Conflicts) > 0
+}
+
+// MergeTripleStores loads the specified documents and merges them into a
+// single store via a store.MergeEngine, detecting URI collisions and
+// conflicting functional values between documents. Aliases load their
+// canonical document, once however many of its IDs are given.
+func (lib *Library) MergeTripleStores(opts MergeOptions, documentIDs ...string) (*store.TripleStore, *MergeReport, error) {
+	documentIDs = lib.resolveDocumentIDs(documentIDs)
+	engine := store.NewMergeEngine(nil)
+	report := &MergeReport{DocumentIDs: documentIDs, Namespaced: opts.NamespaceDocuments}
+
+	// subject -> documents that typed it
+	typedBy := make(map[string][]string)
+
+	for _, documentID := range documentIDs {
+		tripleStore, replaced := opts.Replacements[documentID]
+		if !replaced {
+			var err error
+			if tripleStore, err = lib.LoadTripleStore(documentID); err != nil {
+				return nil, nil, fmt.Errorf("failed to load %s: %w", documentID, err)
+			}
+		}
+		if opts.NamespaceDocuments && lib.DocumentBaseURI(documentID) == lib.BaseURI() {
+			tripleStore = NamespaceTripleStore(tripleStore, lib.BaseURI(), documentID)
+		}
+
+		for _, triple := range tripleStore.Find("", store.RDFType, "") {
+			if !lib.isLocalURI(triple.Subject) {
+				continue
+			}
+			if documents := typedBy[triple.Subject]; len(documents) == 0 || documents[len(documents)-1] != documentID {
+				typedBy[triple.Subject] = append(documents, documentID)
+			}
+		}
+
+		engine.MergeSource(documentID, tripleStore)
+	}
+
+	for subject, documents := range typedBy {
+		if len(documents) < 2 {
+			continue
+		}
+		report.Conflicts = append(report.Conflicts, MergeConflict{
+			Type:        MergeConflictURICollision,
+			Subject:     subject,
+			DocumentIDs: documents,
+		})
+		report.URICollisions++
+	}
+
+	for _, conflict := range engine.Conflicts() {
+		values := make([]string, 0, len(conflict.Values))
+		for _, value := range conflict.Values {
+			values = append(values, value.Object)
+		}
+		report.Conflicts = append(report.Conflicts, MergeConflict{
+			Type:        MergeConflictFunctionalValue,
+			Subject:     conflict.Subject,
+			Predicate:   conflict.Predicate,
+			DocumentIDs: conflict.Sources(),
+			Values:      values,
+		})
+		report.FunctionalConflicts++
+	}
+
+	sort.Slice(report.Conflicts, func(i, j int) bool {
+		if report.Conflicts[i].Subject != report.Conflicts[j].Subject {
+			return report.Conflicts[i].Subject < report.Conflicts[j].Subject
+		}
+		if report.Conflicts[i].Type != report.Conflicts[j].Type {
+			return report.Conflicts[i].Type > report.Conflicts[j].Type
+		}
+		return report.Conflicts[i].Predicate < report.Conflicts[j].Predicate
+	})
+
+	report.TotalTriples = engine.Store().Count()
+	report.DuplicateTriples = engine.DuplicateCount()
+	return engine.Store(), report, nil
+}
+
+// NamespaceTripleStore returns a copy of tripleStore with every URI under
+// baseURI moved into a per-document namespace:
+// "https://regula.dev/regulations/GDPR:Art1" becomes
+// "https://regula.dev/regulations/eu-gdpr/GDPR:Art1".
+func NamespaceTripleStore(tripleStore *store.TripleStore, baseURI string, documentID string) *store.TripleStore {
+	return RebaseTripleStore(tripleStore, baseURI, baseURI+documentID+"/")
+}
+
+// isLocalURI reports whether uri was minted by this library rather than
+// pointing at an external resource.
+func (lib *Library) isLocalURI(uri string) bool {
+	baseURI := lib.BaseURI()
+	return baseURI != "" && strings.HasPrefix(uri, baseURI)
+}
+
+// FormatMergeReportTable formats a merge conflict report for terminal output.
+func FormatMergeReportTable(report *MergeReport) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("Merge of %d documents: %d triples (%d duplicates)",
+		len(report.DocumentIDs), report.TotalTriples, report.DuplicateTriples))
+	if report.Namespaced {
+		builder.WriteString(" (namespaced)")
+	}
+	builder.WriteString("\n")
+	builder.WriteString(fmt.Sprintf("URI collisions: %d | Functional value conflicts: %d\n\n",
+		report.URICollisions, report.FunctionalConflicts))
+
+	if !report.HasConflicts() {
+		builder.WriteString("No conflicts detected.\n")
+		return builder.String()
+	}
+
+	for _, conflict := range report.Conflicts {
+		switch conflict.Type {
+		case MergeConflictURICollision:
+			builder.WriteString(fmt.Sprintf("  COLLISION  %s\n", conflict.Subject))
+			builder.WriteString(fmt.Sprintf("             minted by: %s\n", strings.Join(conflict.DocumentIDs, ", ")))
+		case MergeConflictFunctionalValue:
+			builder.WriteString(fmt.Sprintf("  CONFLICT   %s %s\n", conflict.Subject, conflict.Predicate))
+			builder.WriteString(fmt.Sprintf("             documents: %s\n", strings.Join(conflict.DocumentIDs, ", ")))
+			for _, value := range conflict.Values {
+				builder.WriteString(fmt.Sprintf("             - %s\n", truncateConflictValue(value, 80)))
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// FormatMergeReportJSON formats a merge conflict report as indented JSON.
+func FormatMergeReportJSON(report *MergeReport) string {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(data)
+}
+
+func truncateConflictValue(value string, maxLength int) string {
+	value = strings.Join(strings.Fields(value), " ")
+	return textutil.Truncate(value, maxLength)
+}
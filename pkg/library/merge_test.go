@@ -0,0 +1,133 @@
+package library
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+const mergeTestDocumentA = `STATE CONSUMER PRIVACY ACT
+
+CHAPTER 1
+General Provisions
+
+Article 1
+Scope
+
+Section 1
+Scope
+
+(a) This Act applies to controllers established in the State.
+
+Section 2
+Definitions
+
+(a) 'Consumer' means a natural person who is a resident of the State.
+`
+
+const mergeTestDocumentB = `STATE DATA PROTECTION ACT
+
+CHAPTER 1
+General Provisions
+
+Article 1
+Purpose
+
+Section 1
+Purpose
+
+(a) This Act protects the personal data of residents.
+`
+
+// setupMergeTestLibrary ingests both documents under the shared library
+// base URI, as libraries did before per-document base URIs.
+func setupMergeTestLibrary(t *testing.T) *Library {
+	t.Helper()
+	lib, err := Init(filepath.Join(t.TempDir(), "lib"), "")
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	sharedBase := AddOptions{Format: "us", BaseURI: lib.BaseURI()}
+	if _, err := lib.AddDocument("doc-a", []byte(mergeTestDocumentA), sharedBase); err != nil {
+		t.Fatalf("AddDocument (doc-a) failed: %v", err)
+	}
+	if _, err := lib.AddDocument("doc-b", []byte(mergeTestDocumentB), sharedBase); err != nil {
+		t.Fatalf("AddDocument (doc-b) failed: %v", err)
+	}
+	return lib
+}
+
+func TestMergeTripleStoresDetectsConflicts(t *testing.T) {
+	lib := setupMergeTestLibrary(t)
+
+	_, report, err := lib.MergeTripleStores(MergeOptions{}, "doc-a", "doc-b")
+	if err != nil {
+		t.Fatalf("MergeTripleStores failed: %v", err)
+	}
+
+	if report.URICollisions == 0 {
+		t.Fatal("expected URI collisions between documents that both mint Art1")
+	}
+
+	var titleConflict *MergeConflict
+	for index := range report.Conflicts {
+		conflict := &report.Conflicts[index]
+		if conflict.Type == MergeConflictFunctionalValue && conflict.Predicate == store.PropTitle &&
+			strings.HasSuffix(conflict.Subject, ":Art1") {
+			titleConflict = conflict
+		}
+	}
+	if titleConflict == nil {
+		t.Fatalf("expected a reg:title conflict on Art1, got %+v", report.Conflicts)
+	}
+	if len(titleConflict.DocumentIDs) != 2 || len(titleConflict.Values) != 2 {
+		t.Errorf("expected two documents and two values, got %+v", titleConflict)
+	}
+}
+
+func TestMergeTripleStoresNamespaced(t *testing.T) {
+	lib := setupMergeTestLibrary(t)
+
+	plain, _, err := lib.MergeTripleStores(MergeOptions{}, "doc-a", "doc-b")
+	if err != nil {
+		t.Fatalf("MergeTripleStores failed: %v", err)
+	}
+	merged, report, err := lib.MergeTripleStores(MergeOptions{NamespaceDocuments: true}, "doc-a", "doc-b")
+	if err != nil {
+		t.Fatalf("MergeTripleStores (namespaced) failed: %v", err)
+	}
+
+	if report.HasConflicts() {
+		t.Errorf("expected no conflicts with namespacing, got %+v", report.Conflicts)
+	}
+	if merged.Count() <= plain.Count() {
+		t.Errorf("expected namespaced merge to keep colliding nodes apart (%d <= %d)", merged.Count(), plain.Count())
+	}
+
+	articles := merged.Find("", store.RDFType, store.ClassArticle)
+	if len(articles) != 3 {
+		t.Errorf("expected 3 distinct articles, got %d", len(articles))
+	}
+	for _, triple := range articles {
+		if !strings.Contains(triple.Subject, "/doc-a/") && !strings.Contains(triple.Subject, "/doc-b/") {
+			t.Errorf("article URI %q is not namespaced", triple.Subject)
+		}
+	}
+}
+
+func TestNamespaceTripleStore(t *testing.T) {
+	tripleStore := store.NewTripleStore()
+	tripleStore.Add("https://regula.dev/regulations/GDPR:Art1", store.PropReferences, "https://regula.dev/regulations/GDPR:Art2")
+	tripleStore.Add("https://regula.dev/regulations/GDPR:Art1", store.PropExternalRef, "http://data.europa.eu/eli/dir/1995/46/oj")
+
+	namespaced := NamespaceTripleStore(tripleStore, "https://regula.dev/regulations/", "eu-gdpr")
+
+	if !namespaced.Exists("https://regula.dev/regulations/eu-gdpr/GDPR:Art1", store.PropReferences, "https://regula.dev/regulations/eu-gdpr/GDPR:Art2") {
+		t.Error("expected local subject and object to be namespaced")
+	}
+	if !namespaced.Exists("https://regula.dev/regulations/eu-gdpr/GDPR:Art1", store.PropExternalRef, "http://data.europa.eu/eli/dir/1995/46/oj") {
+		t.Error("expected external URI to be left unchanged")
+	}
+}
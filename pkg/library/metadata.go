@@ -0,0 +1,178 @@
+package library
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// LegalStatus is whether a document is in force.
+type LegalStatus string
+
+const (
+	// LegalStatusInForce marks a document that currently applies.
+	LegalStatusInForce LegalStatus = "in-force"
+
+	// LegalStatusNotYetInForce marks an adopted document that does not
+	// apply yet.
+	LegalStatusNotYetInForce LegalStatus = "not-yet-in-force"
+
+	// LegalStatusProposed marks a draft or proposal.
+	LegalStatusProposed LegalStatus = "proposed"
+
+	// LegalStatusRepealed marks a document repealed by another.
+	LegalStatusRepealed LegalStatus = "repealed"
+
+	// LegalStatusExpired marks a document that ceased to apply on its own
+	// terms, such as a sunset clause.
+	LegalStatusExpired LegalStatus = "expired"
+)
+
+// LegalStatuses lists the valid legal statuses.
+var LegalStatuses = []LegalStatus{
+	LegalStatusInForce,
+	LegalStatusNotYetInForce,
+	LegalStatusProposed,
+	LegalStatusRepealed,
+	LegalStatusExpired,
+}
+
+// effectiveDateLayout is the format of DocumentEntry.EffectiveDate.
+const effectiveDateLayout = "2006-01-02"
+
+// MetadataUpdate describes changes to a document's metadata. Nil fields are
+// left unchanged; a pointer to an empty string clears the field.
+type MetadataUpdate struct {
+	Name          *string
+	Jurisdiction  *string
+	EffectiveDate *string
+	LegalStatus   *LegalStatus
+
+	// AddTags are added to the document's tags unless already present.
+	AddTags []string
+
+	// RemoveTags are removed from the document's tags.
+	RemoveTags []string
+}
+
+// IsEmpty reports whether the update changes nothing.
+func (update MetadataUpdate) IsEmpty() bool {
+	return update.Name == nil && update.Jurisdiction == nil && update.EffectiveDate == nil &&
+		update.LegalStatus == nil && len(update.AddTags) == 0 && len(update.RemoveTags) == 0
+}
+
+// Validate checks the effective date (YYYY-MM-DD) and the legal status.
+func (update MetadataUpdate) Validate() error {
+	if update.EffectiveDate != nil && *update.EffectiveDate != "" {
+		if _, err := time.Parse(effectiveDateLayout, *update.EffectiveDate); err != nil {
+			return fmt.Errorf("invalid effective date %q: want YYYY-MM-DD", *update.EffectiveDate)
+		}
+	}
+	if update.LegalStatus != nil && *update.LegalStatus != "" && !slices.Contains(LegalStatuses, *update.LegalStatus) {
+		valid := make([]string, len(LegalStatuses))
+		for i, status := range LegalStatuses {
+			valid[i] = string(status)
+		}
+		return fmt.Errorf("invalid legal status %q: want one of %s", *update.LegalStatus, strings.Join(valid, ", "))
+	}
+	for _, tag := range append(append([]string{}, update.AddTags...), update.RemoveTags...) {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("empty tag")
+		}
+	}
+	return nil
+}
+
+// SetMetadata applies update to a document's entry and saves the manifest.
+// The stored triples are not rewritten: LoadTripleStore adds the metadata
+// to the document node whenever the document is loaded.
+func (lib *Library) SetMetadata(documentID string, update MetadataUpdate) (*DocumentEntry, error) {
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	entry := lib.findDocumentUnsafe(documentID)
+	if entry == nil {
+		return nil, fmt.Errorf("document not found: %s", documentID)
+	}
+
+	updated := *entry
+	if update.Name != nil {
+		updated.Name = *update.Name
+		updated.ShortName = *update.Name
+	}
+	if update.Jurisdiction != nil {
+		updated.Jurisdiction = *update.Jurisdiction
+	}
+	if update.EffectiveDate != nil {
+		updated.EffectiveDate = *update.EffectiveDate
+	}
+	if update.LegalStatus != nil {
+		updated.LegalStatus = *update.LegalStatus
+	}
+	tags := make([]string, 0, len(entry.Tags)+len(update.AddTags))
+	for _, tag := range entry.Tags {
+		if !slices.Contains(update.RemoveTags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	for _, tag := range update.AddTags {
+		if !slices.Contains(tags, tag) && !slices.Contains(update.RemoveTags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	updated.Tags = tags
+
+	lib.upsertEntry(&updated)
+	if err := lib.saveManifest(); err != nil {
+		return nil, fmt.Errorf("failed to save manifest: %w", err)
+	}
+	return &updated, nil
+}
+
+// DocumentsWithTag returns the entries tagged with tag, sorted by ID.
+func (lib *Library) DocumentsWithTag(tag string) []*DocumentEntry {
+	var tagged []*DocumentEntry
+	for _, entry := range lib.ListDocuments() {
+		if slices.Contains(entry.Tags, tag) {
+			tagged = append(tagged, entry)
+		}
+	}
+	return tagged
+}
+
+// documentClasses are the types of the node a document's graph is rooted
+// at.
+var documentClasses = []string{store.ClassRegulation, store.ClassDirective, store.ClassDecision}
+
+// addMetadataTriples describes the document node of tripleStore with the
+// entry's jurisdiction, tags, effective date, and legal status.
+func addMetadataTriples(tripleStore *store.TripleStore, entry *DocumentEntry) {
+	var documentURIs []string
+	for _, class := range documentClasses {
+		for _, triple := range tripleStore.Find("", store.RDFType, class) {
+			documentURIs = append(documentURIs, triple.Subject)
+		}
+	}
+
+	for _, documentURI := range documentURIs {
+		if entry.Jurisdiction != "" {
+			tripleStore.Add(documentURI, store.PropJurisdiction, entry.Jurisdiction)
+		}
+		for _, tag := range entry.Tags {
+			tripleStore.Add(documentURI, store.PropTag, tag)
+		}
+		if entry.EffectiveDate != "" {
+			tripleStore.Add(documentURI, store.PropEffectiveDate, entry.EffectiveDate)
+		}
+		if entry.LegalStatus != "" {
+			tripleStore.Add(documentURI, store.PropLegalStatus, string(entry.LegalStatus))
+		}
+	}
+}
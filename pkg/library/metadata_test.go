@@ -0,0 +1,74 @@
+package library
+
+import (
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func TestSetMetadata(t *testing.T) {
+	lib := setupMergeTestLibrary(t)
+
+	effectiveDate, status := "2018-05-25", LegalStatusInForce
+	entry, err := lib.SetMetadata("doc-a", MetadataUpdate{
+		EffectiveDate: &effectiveDate,
+		LegalStatus:   &status,
+		AddTags:       []string{"privacy", "eu"},
+	})
+	if err != nil {
+		t.Fatalf("SetMetadata failed: %v", err)
+	}
+	if entry.EffectiveDate != effectiveDate || entry.LegalStatus != LegalStatusInForce || len(entry.Tags) != 2 {
+		t.Errorf("entry = %+v", entry)
+	}
+
+	jurisdiction := "US-VA"
+	if _, err := lib.SetMetadata("doc-a", MetadataUpdate{Jurisdiction: &jurisdiction, AddTags: []string{"privacy"}, RemoveTags: []string{"eu"}}); err != nil {
+		t.Fatalf("SetMetadata failed: %v", err)
+	}
+
+	reopened, err := Open(lib.Path())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	saved := reopened.GetDocument("doc-a")
+	if saved.Jurisdiction != "US-VA" || len(saved.Tags) != 1 || saved.Tags[0] != "privacy" || saved.EffectiveDate != effectiveDate {
+		t.Errorf("saved entry = %+v", saved)
+	}
+	if tagged := reopened.DocumentsWithTag("privacy"); len(tagged) != 1 || tagged[0].ID != "doc-a" {
+		t.Errorf("DocumentsWithTag = %v", tagged)
+	}
+
+	tripleStore, err := reopened.LoadTripleStore("doc-a")
+	if err != nil {
+		t.Fatalf("LoadTripleStore failed: %v", err)
+	}
+	for predicate, want := range map[string]string{
+		store.PropTag:           "privacy",
+		store.PropJurisdiction:  "US-VA",
+		store.PropEffectiveDate: effectiveDate,
+		store.PropLegalStatus:   string(LegalStatusInForce),
+	} {
+		if triples := tripleStore.Find("", predicate, want); len(triples) == 0 {
+			t.Errorf("no %s %q triple on the document node", predicate, want)
+		}
+	}
+}
+
+func TestSetMetadataInvalid(t *testing.T) {
+	lib := setupMergeTestLibrary(t)
+
+	badDate, badStatus := "25/05/2018", LegalStatus("pending")
+	for name, update := range map[string]MetadataUpdate{
+		"date":   {EffectiveDate: &badDate},
+		"status": {LegalStatus: &badStatus},
+		"tag":    {AddTags: []string{" "}},
+	} {
+		if _, err := lib.SetMetadata("doc-a", update); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := lib.SetMetadata("missing", MetadataUpdate{AddTags: []string{"x"}}); err == nil {
+		t.Error("expected an error for a missing document")
+	}
+}
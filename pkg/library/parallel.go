@@ -0,0 +1,55 @@
+package library
+
+import "sync"
+
+// RunOrdered calls work for indexes 0 through count-1 on up to workers
+// goroutines and hands each result to commit, serially and in index order,
+// so that whatever commit records (a manifest, a report) comes out the same
+// regardless of which work finishes first. With workers <= 1 everything
+// runs on the calling goroutine.
+//
+// Workers stay at most 2*workers indexes ahead of commit, which bounds the
+// number of finished results held in memory while an early index is slow.
+func RunOrdered[T any](count int, workers int, work func(index int) T, commit func(index int, result T)) {
+	if workers > count {
+		workers = count
+	}
+	if workers <= 1 {
+		for index := 0; index < count; index++ {
+			commit(index, work(index))
+		}
+		return
+	}
+
+	results := make([]chan T, count)
+	for index := range results {
+		results[index] = make(chan T, 1)
+	}
+	window := make(chan struct{}, 2*workers)
+	pending := make(chan int)
+
+	var workerGroup sync.WaitGroup
+	for workerIndex := 0; workerIndex < workers; workerIndex++ {
+		workerGroup.Add(1)
+		go func() {
+			defer workerGroup.Done()
+			for index := range pending {
+				results[index] <- work(index)
+			}
+		}()
+	}
+
+	go func() {
+		for index := 0; index < count; index++ {
+			window <- struct{}{}
+			pending <- index
+		}
+		close(pending)
+	}()
+
+	for index := 0; index < count; index++ {
+		commit(index, <-results[index])
+		<-window
+	}
+	workerGroup.Wait()
+}
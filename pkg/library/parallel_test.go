@@ -0,0 +1,56 @@
+package library
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunOrdered_CommitsInIndexOrder(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 16} {
+		var running, maxRunning atomic.Int32
+		var committed []int
+
+		RunOrdered(20, workers,
+			func(index int) int {
+				current := running.Add(1)
+				defer running.Add(-1)
+				for {
+					seen := maxRunning.Load()
+					if current <= seen || maxRunning.CompareAndSwap(seen, current) {
+						break
+					}
+				}
+				// Later indexes finish first.
+				time.Sleep(time.Duration(20-index) * 100 * time.Microsecond)
+				return index * index
+			},
+			func(index int, result int) {
+				if result != index*index {
+					t.Errorf("workers=%d: index %d got result %d", workers, index, result)
+				}
+				committed = append(committed, index)
+			})
+
+		if len(committed) != 20 {
+			t.Fatalf("workers=%d: committed %d results, want 20", workers, len(committed))
+		}
+		for i, index := range committed {
+			if index != i {
+				t.Fatalf("workers=%d: commit order %v", workers, committed)
+			}
+		}
+		if limit := int32(max(workers, 1)); maxRunning.Load() > limit {
+			t.Errorf("workers=%d: %d work calls ran at once", workers, maxRunning.Load())
+		}
+	}
+}
+
+func TestRunOrdered_Empty(t *testing.T) {
+	RunOrdered(0, 4, func(int) int {
+		t.Fatal("work called for empty range")
+		return 0
+	}, func(int, int) {
+		t.Fatal("commit called for empty range")
+	})
+}
@@ -0,0 +1,251 @@
+package library
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// Popular-name origins record where an index entry came from.
+const (
+	PopularNameOriginShortTitle = "short_title" // "may be cited as" clause in the source text
+	PopularNameOriginShortName  = "short_name"  // DocumentEntry.ShortName
+	PopularNameOriginFullName   = "full_name"   // DocumentEntry.FullName
+	PopularNameOriginAcronym    = "acronym"     // derived from a short title
+)
+
+// PopularName is a single entry in the popular-name index.
+type PopularName struct {
+	Name       string `json:"name"`
+	DocumentID string `json:"document_id"`
+	Origin     string `json:"origin"`
+}
+
+// PopularNameMatch is the result of resolving a reference such as
+// "COPPA §1303" against the index: the matched name, the document it
+// belongs to, and the unconsumed provision part of the reference.
+type PopularNameMatch struct {
+	PopularName
+	Remainder string `json:"remainder,omitempty"`
+}
+
+// PopularNameIndex maps normalized short titles, popular names, and their
+// acronyms to library document IDs.
+type PopularNameIndex struct {
+	entries map[string][]PopularName
+}
+
+var (
+	shortTitlePattern = regexp.MustCompile(`(?i)(?:may\s+be\s+cited\s+as|shall\s+be\s+known\s+as)\s+(?:the\s+)?["\x{201c}]?([A-Z][^"\x{201d}\n.;]*?(?:Act|Code|Law|Regulations?|Rules?)(?:\s+(?:of\s+)?\d{4})?)["\x{201d}]?\s*[.;,]`)
+	yearSuffixPattern = regexp.MustCompile(`(?i)\s+(?:of\s+)?\d{4}$`)
+	parentheticalTail = regexp.MustCompile(`\s*\([^)]*\)\s*$`)
+	nonWordPattern    = regexp.MustCompile(`[^a-z0-9]+`)
+	provisionPrefix   = regexp.MustCompile(`(?i)^(?:§+|sections?|secs?\.?|articles?|arts?\.?)\s*`)
+)
+
+// acronymStopWords are skipped when deriving an acronym from a short title.
+var acronymStopWords = map[string]bool{
+	"of": true, "and": true, "the": true, "for": true, "to": true,
+	"on": true, "in": true, "a": true, "an": true, "etc": true,
+}
+
+// ExtractShortTitles finds short titles declared in the source text via
+// "may be cited as" clauses, e.g. "This title may be cited as the
+// Children's Online Privacy Protection Act of 1998."
+func ExtractShortTitles(sourceText []byte) []string {
+	var titles []string
+	seen := make(map[string]bool)
+
+	for _, match := range shortTitlePattern.FindAllSubmatch(sourceText, -1) {
+		title := strings.Join(strings.Fields(string(match[1])), " ")
+		if title == "" || seen[title] {
+			continue
+		}
+		seen[title] = true
+		titles = append(titles, title)
+	}
+
+	return titles
+}
+
+// PopularNameAcronym derives an acronym from a short title by taking the
+// initial of each significant word, ignoring a trailing year
+// ("Children's Online Privacy Protection Act of 1998" -> "COPPA").
+// Returns "" when fewer than three letters would result.
+func PopularNameAcronym(title string) string {
+	title = yearSuffixPattern.ReplaceAllString(strings.TrimSpace(title), "")
+
+	var acronym strings.Builder
+	for _, word := range strings.Fields(title) {
+		if acronymStopWords[strings.ToLower(word)] {
+			continue
+		}
+		first := []rune(word)[0]
+		if !unicode.IsLetter(first) {
+			continue
+		}
+		acronym.WriteRune(unicode.ToUpper(first))
+	}
+
+	if acronym.Len() < 3 {
+		return ""
+	}
+	return acronym.String()
+}
+
+// NewPopularNameIndex creates an empty popular-name index.
+func NewPopularNameIndex() *PopularNameIndex {
+	return &PopularNameIndex{entries: make(map[string][]PopularName)}
+}
+
+// Add registers a name for a document. Names ending in a year are also
+// registered without it, so "Data Protection Act 2018" matches
+// "Data Protection Act".
+func (idx *PopularNameIndex) Add(name string, documentID string, origin string) {
+	name = strings.TrimSpace(name)
+	if name == "" || documentID == "" {
+		return
+	}
+
+	idx.addKey(normalizePopularName(name), PopularName{Name: name, DocumentID: documentID, Origin: origin})
+
+	if withoutYear := yearSuffixPattern.ReplaceAllString(name, ""); withoutYear != name {
+		idx.addKey(normalizePopularName(withoutYear), PopularName{Name: name, DocumentID: documentID, Origin: origin})
+	}
+}
+
+// AddShortTitle registers a short title together with its derived acronym.
+func (idx *PopularNameIndex) AddShortTitle(title string, documentID string) {
+	idx.Add(title, documentID, PopularNameOriginShortTitle)
+	if acronym := PopularNameAcronym(title); acronym != "" {
+		idx.Add(acronym, documentID, PopularNameOriginAcronym)
+	}
+}
+
+func (idx *PopularNameIndex) addKey(key string, entry PopularName) {
+	if key == "" {
+		return
+	}
+	for _, existing := range idx.entries[key] {
+		if existing.DocumentID == entry.DocumentID {
+			return
+		}
+	}
+	idx.entries[key] = append(idx.entries[key], entry)
+}
+
+// Lookup returns all entries whose name matches exactly after normalization.
+func (idx *PopularNameIndex) Lookup(name string) []PopularName {
+	return idx.entries[normalizePopularName(name)]
+}
+
+// Resolve matches the longest popular-name prefix of a reference such as
+// "COPPA §1303" or "Children's Online Privacy Protection Act section 1303".
+// Ambiguous names (mapped to more than one document) do not resolve.
+func (idx *PopularNameIndex) Resolve(reference string) (*PopularNameMatch, bool) {
+	words := strings.Fields(reference)
+
+	for wordCount := len(words); wordCount > 0; wordCount-- {
+		candidate := strings.Join(words[:wordCount], " ")
+		matches := idx.Lookup(candidate)
+		if len(matches) != 1 {
+			continue
+		}
+		return &PopularNameMatch{
+			PopularName: matches[0],
+			Remainder:   strings.Join(words[wordCount:], " "),
+		}, true
+	}
+
+	return nil, false
+}
+
+// Entries returns every indexed name, sorted by name then document ID.
+func (idx *PopularNameIndex) Entries() []PopularName {
+	seen := make(map[PopularName]bool)
+	var result []PopularName
+	for _, entries := range idx.entries {
+		for _, entry := range entries {
+			if !seen[entry] {
+				seen[entry] = true
+				result = append(result, entry)
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].DocumentID < result[j].DocumentID
+	})
+	return result
+}
+
+// Len returns the number of distinct normalized keys in the index.
+func (idx *PopularNameIndex) Len() int {
+	return len(idx.entries)
+}
+
+// ProvisionURI finds the article or section in tripleStore numbered by the
+// match remainder ("§1303", "Section 6502", "Art. 17"). Title-qualified
+// section numbers such as "15.6502" match a bare "6502" when no exact
+// match exists. Returns "" when the remainder is empty or no such provision
+// exists.
+func (match *PopularNameMatch) ProvisionURI(tripleStore *store.TripleStore) string {
+	number := strings.TrimSpace(provisionPrefix.ReplaceAllString(strings.TrimSpace(match.Remainder), ""))
+	if number == "" {
+		return ""
+	}
+
+	var qualified []string
+	for _, triple := range tripleStore.Find("", store.RDFType, store.ClassArticle) {
+		if strings.HasSuffix(triple.Subject, ":Art"+number) {
+			return triple.Subject
+		}
+		if strings.HasSuffix(triple.Subject, "."+number) {
+			qualified = append(qualified, triple.Subject)
+		}
+	}
+	if len(qualified) == 0 {
+		return ""
+	}
+
+	sort.Strings(qualified)
+	return qualified[0]
+}
+
+// PopularNameIndex builds an index over all ready documents from their
+// short names, full names (minus any trailing citation), and the short
+// titles found during ingest. Duplicates aliased to another document are
+// left out.
+func (lib *Library) PopularNameIndex() *PopularNameIndex {
+	lib.mu.RLock()
+	defer lib.mu.RUnlock()
+
+	index := NewPopularNameIndex()
+	for _, entry := range lib.manifest.Documents {
+		if _, aliased := lib.manifest.Aliases[entry.ID]; aliased || entry.Status != StatusReady {
+			continue
+		}
+		index.Add(entry.ShortName, entry.ID, PopularNameOriginShortName)
+		index.Add(parentheticalTail.ReplaceAllString(entry.FullName, ""), entry.ID, PopularNameOriginFullName)
+		for _, title := range entry.ShortTitles {
+			index.AddShortTitle(title, entry.ID)
+		}
+	}
+
+	return index
+}
+
+// normalizePopularName lowercases a name and collapses punctuation and
+// whitespace, so "Children’s Online Privacy Protection Act" and
+// "childrens online privacy protection act" share a key.
+func normalizePopularName(name string) string {
+	name = strings.ToLower(name)
+	name = strings.NewReplacer("'", "", "’", "").Replace(name)
+	return strings.Trim(nonWordPattern.ReplaceAllString(name, " "), " ")
+}
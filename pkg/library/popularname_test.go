@@ -0,0 +1,145 @@
+package library
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractShortTitles(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "US title with year",
+			text:     "(a) This title may be cited as the Children's Online Privacy Protection Act of 1998.",
+			expected: []string{"Children's Online Privacy Protection Act of 1998"},
+		},
+		{
+			name:     "quoted state act",
+			text:     `This part 13 shall be known and may be cited as the "Colorado Privacy Act".`,
+			expected: []string{"Colorado Privacy Act"},
+		},
+		{
+			name:     "UK act",
+			text:     "This Act may be cited as the Data Protection Act 2018.",
+			expected: []string{"Data Protection Act 2018"},
+		},
+		{
+			name:     "no short title",
+			text:     "The controller shall implement appropriate measures.",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ExtractShortTitles([]byte(tc.text))
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("ExtractShortTitles() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestPopularNameAcronym(t *testing.T) {
+	tests := map[string]string{
+		"Children's Online Privacy Protection Act of 1998": "COPPA",
+		"California Consumer Privacy Act of 2018":          "CCPA",
+		"Data Protection Act 2018":                         "DPA",
+		"Privacy Act 1988":                                 "",
+	}
+
+	for title, expected := range tests {
+		if got := PopularNameAcronym(title); got != expected {
+			t.Errorf("PopularNameAcronym(%q) = %q, want %q", title, got, expected)
+		}
+	}
+}
+
+func TestPopularNameIndexResolve(t *testing.T) {
+	index := NewPopularNameIndex()
+	index.AddShortTitle("Children's Online Privacy Protection Act of 1998", "us-coppa")
+	index.AddShortTitle("Colorado Privacy Act", "us-co-cpa")
+	index.Add("CPA", "ca-cpa", PopularNameOriginShortName)
+
+	tests := []struct {
+		reference  string
+		documentID string
+		remainder  string
+		resolved   bool
+	}{
+		{"COPPA §1303", "us-coppa", "§1303", true},
+		{"coppa", "us-coppa", "", true},
+		{"Children’s Online Privacy Protection Act section 6502", "us-coppa", "section 6502", true},
+		{"Children's Online Privacy Protection Act of 1998", "us-coppa", "", true},
+		{"Colorado Privacy Act 6-1-1303", "us-co-cpa", "6-1-1303", true},
+		{"CPA §1", "", "", false}, // ambiguous acronym
+		{"Unknown Act §5", "", "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.reference, func(t *testing.T) {
+			match, ok := index.Resolve(tc.reference)
+			if ok != tc.resolved {
+				t.Fatalf("Resolve(%q) resolved = %v, want %v", tc.reference, ok, tc.resolved)
+			}
+			if !ok {
+				return
+			}
+			if match.DocumentID != tc.documentID || match.Remainder != tc.remainder {
+				t.Errorf("Resolve(%q) = (%s, %q), want (%s, %q)",
+					tc.reference, match.DocumentID, match.Remainder, tc.documentID, tc.remainder)
+			}
+		})
+	}
+}
+
+func TestLibraryPopularNameIndex(t *testing.T) {
+	lib, err := Init(filepath.Join(t.TempDir(), "lib"), "")
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	sourceText, err := os.ReadFile(filepath.Join("..", "..", "testdata", "us-coppa.txt"))
+	if err != nil {
+		t.Skipf("COPPA test data not available: %v", err)
+	}
+
+	entry, err := lib.AddDocument("us-coppa", sourceText, AddOptions{
+		FullName: "Children's Online Privacy Protection Act (15 U.S.C. 6501-6506)",
+		Format:   "us",
+	})
+	if err != nil {
+		t.Fatalf("AddDocument failed: %v", err)
+	}
+	if len(entry.ShortTitles) != 1 || !strings.HasPrefix(entry.ShortTitles[0], "Children's Online Privacy Protection Act") {
+		t.Fatalf("expected COPPA short title to be indexed, got %v", entry.ShortTitles)
+	}
+
+	// Short titles persist through the manifest.
+	reopened, err := Open(lib.Path())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	match, ok := reopened.PopularNameIndex().Resolve("COPPA §6502")
+	if !ok {
+		t.Fatal("expected COPPA to resolve via the popular-name index")
+	}
+	if match.DocumentID != "us-coppa" {
+		t.Errorf("DocumentID = %q, want us-coppa", match.DocumentID)
+	}
+
+	tripleStore, err := reopened.LoadTripleStore("us-coppa")
+	if err != nil {
+		t.Fatalf("LoadTripleStore failed: %v", err)
+	}
+	if uri := match.ProvisionURI(tripleStore); !strings.HasSuffix(uri, ":Art15.6502") {
+		t.Errorf("ProvisionURI() = %q, want suffix :Art15.6502", uri)
+	}
+}
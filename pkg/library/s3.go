@@ -0,0 +1,466 @@
+package library
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// S3EndpointEnv sets the endpoint of an S3-compatible service, such as
+	// MinIO or Cloudflare R2. AWS_ENDPOINT_URL_S3 and AWS_ENDPOINT_URL are
+	// also honored.
+	S3EndpointEnv = "REGULA_S3_ENDPOINT"
+
+	// LibraryCacheEnv sets the directory remote library files are cached
+	// in; "off" disables the cache.
+	LibraryCacheEnv = "REGULA_LIBRARY_CACHE"
+)
+
+// gcsEndpoint is the S3-compatible (XML API) endpoint of Google Cloud
+// Storage, used with HMAC keys.
+const gcsEndpoint = "https://storage.googleapis.com"
+
+// RemoteOptions configure a library stored in an S3-compatible bucket.
+type RemoteOptions struct {
+	Bucket string
+	Prefix string
+
+	// Endpoint is the service URL. Empty uses AWS S3 in Region with
+	// virtual-hosted addressing; other endpoints use path-style
+	// addressing.
+	Endpoint string
+	Region   string
+
+	// Credentials sign requests with AWS Signature Version 4. Without
+	// them requests are unsigned, which works for public buckets.
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// CacheDir holds local copies of remote files, revalidated with their
+	// ETag before use. Empty disables caching.
+	CacheDir string
+
+	// Client sends the requests; nil uses http.DefaultClient.
+	Client *http.Client
+}
+
+// RemoteOptionsFromEnv returns the options for an s3:// or gs:// library
+// location from the standard AWS environment variables (AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN, AWS_REGION), $REGULA_S3_ENDPOINT,
+// and $REGULA_LIBRARY_CACHE. gs:// locations use Google Cloud Storage's
+// S3-compatible endpoint, with HMAC keys given as the AWS key variables.
+func RemoteOptionsFromEnv(libraryPath string) (RemoteOptions, error) {
+	bucket, prefix, err := splitRemotePath(libraryPath)
+	if err != nil {
+		return RemoteOptions{}, err
+	}
+	options := RemoteOptions{
+		Bucket:          bucket,
+		Prefix:          prefix,
+		Endpoint:        firstEnv(S3EndpointEnv, "AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL"),
+		Region:          firstEnv("AWS_REGION", "AWS_DEFAULT_REGION"),
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if strings.HasPrefix(libraryPath, gcsScheme) {
+		options.Endpoint, options.Region = gcsEndpoint, "auto"
+	}
+	if options.Region == "" {
+		options.Region = "us-east-1"
+	}
+
+	switch cacheDir := os.Getenv(LibraryCacheEnv); cacheDir {
+	case "off":
+	case "":
+		if userCacheDir, err := os.UserCacheDir(); err == nil {
+			scheme := libraryPath[:strings.Index(libraryPath, "://")]
+			options.CacheDir = filepath.Join(userCacheDir, "regula", "libraries", scheme, bucket, filepath.FromSlash(prefix))
+		}
+	default:
+		options.CacheDir = filepath.Join(cacheDir, bucket, filepath.FromSlash(prefix))
+	}
+	return options, nil
+}
+
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// s3Backend stores files in an S3-compatible bucket, keeping local copies
+// so unchanged files are not downloaded again.
+type s3Backend struct {
+	options  RemoteOptions
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+func newS3Backend(options RemoteOptions) (*s3Backend, error) {
+	endpoint := options.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", options.Bucket, options.Region)
+	}
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || endpointURL.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", endpoint)
+	}
+	client := options.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &s3Backend{options: options, endpoint: endpointURL, client: client, now: time.Now}, nil
+}
+
+func (backend *s3Backend) Read(key string) ([]byte, error) {
+	cached, etag := backend.cached(key)
+	header := http.Header{}
+	if etag != "" {
+		header.Set("If-None-Match", etag)
+	}
+
+	response, err := backend.do(http.MethodGet, backend.objectPath(key), nil, nil, header)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusNotModified && cached != nil:
+		return cached, nil
+	case response.StatusCode == http.StatusNotFound:
+		backend.uncache(key)
+		return nil, fmt.Errorf("%s: %w", backend.location(backend.objectKey(key)), fs.ErrNotExist)
+	case response.StatusCode != http.StatusOK:
+		return nil, backend.responseError(http.MethodGet, backend.objectKey(key), response)
+	}
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", backend.location(backend.objectKey(key)), err)
+	}
+	backend.cache(key, data, response.Header.Get("ETag"))
+	return data, nil
+}
+
+func (backend *s3Backend) Write(key string, data []byte) error {
+	response, err := backend.do(http.MethodPut, backend.objectPath(key), nil, data, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return backend.responseError(http.MethodPut, backend.objectKey(key), response)
+	}
+	backend.cache(key, data, response.Header.Get("ETag"))
+	return nil
+}
+
+func (backend *s3Backend) Delete(key string) error {
+	backend.uncache(key)
+	return backend.deleteObject(backend.objectKey(key))
+}
+
+func (backend *s3Backend) DeletePrefix(prefix string) error {
+	objectKeys, err := backend.list(backend.objectKey(prefix) + "/")
+	if err != nil {
+		return err
+	}
+	for _, objectKey := range objectKeys {
+		backend.uncache(strings.TrimPrefix(objectKey, backend.options.Prefix+"/"))
+		if err := backend.deleteObject(objectKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (backend *s3Backend) deleteObject(objectKey string) error {
+	response, err := backend.do(http.MethodDelete, backend.bucketPath()+"/"+objectKey, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	}
+	return backend.responseError(http.MethodDelete, objectKey, response)
+}
+
+// listBucketResult is the response to ListObjectsV2.
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// list returns the object keys starting with objectPrefix.
+func (backend *s3Backend) list(objectPrefix string) ([]string, error) {
+	var keys []string
+	query := url.Values{"list-type": {"2"}, "prefix": {objectPrefix}}
+	for {
+		response, err := backend.do(http.MethodGet, backend.bucketPath(), query, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if response.StatusCode != http.StatusOK {
+			err := backend.responseError("LIST", objectPrefix, response)
+			response.Body.Close()
+			return nil, err
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(response.Body).Decode(&result)
+		response.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse object listing: %w", err)
+		}
+		for _, content := range result.Contents {
+			keys = append(keys, content.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// objectKey returns the bucket key of a library key.
+func (backend *s3Backend) objectKey(key string) string {
+	return path.Join(backend.options.Prefix, key)
+}
+
+// bucketPath returns the URL path of the bucket: empty with virtual-hosted
+// addressing, /bucket with path-style addressing.
+func (backend *s3Backend) bucketPath() string {
+	if backend.options.Endpoint == "" {
+		return ""
+	}
+	return "/" + backend.options.Bucket
+}
+
+func (backend *s3Backend) objectPath(key string) string {
+	return backend.bucketPath() + "/" + backend.objectKey(key)
+}
+
+// location names an object in errors.
+func (backend *s3Backend) location(objectKey string) string {
+	return backend.options.Bucket + "/" + objectKey
+}
+
+// do sends a signed request for urlPath.
+func (backend *s3Backend) do(method, urlPath string, query url.Values, body []byte, header http.Header) (*http.Response, error) {
+	requestURL := *backend.endpoint
+	requestURL.Path = strings.TrimSuffix(requestURL.Path, "/") + urlPath
+	if requestURL.Path == "" {
+		requestURL.Path = "/"
+	}
+	requestURL.RawPath = awsURIEncode(requestURL.Path, false)
+	requestURL.RawQuery = canonicalQuery(query)
+
+	request, err := http.NewRequest(method, requestURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		request.Header[name] = values
+	}
+	backend.sign(request, body)
+
+	response, err := backend.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", backend.endpoint.Host, err)
+	}
+	return response, nil
+}
+
+// sign adds AWS Signature Version 4 headers to request.
+func (backend *s3Backend) sign(request *http.Request, body []byte) {
+	payloadHash := sha256.Sum256(body)
+	payloadHex := hex.EncodeToString(payloadHash[:])
+	request.Header.Set("X-Amz-Content-Sha256", payloadHex)
+	if backend.options.AccessKeyID == "" || backend.options.SecretAccessKey == "" {
+		return
+	}
+
+	now := backend.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	request.Header.Set("X-Amz-Date", amzDate)
+	if backend.options.SessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", backend.options.SessionToken)
+	}
+
+	headers := map[string]string{"host": request.URL.Host}
+	for name, values := range request.Header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, headers[name])
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHex,
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, backend.options.Region)
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := signingKey(backend.options.SecretAccessKey, date, backend.options.Region, "s3")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		backend.options.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// signingKey derives the Signature Version 4 key for a day, region, and
+// service.
+func signingKey(secret, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// awsURIEncode percent-encodes everything but unreserved characters, and
+// slashes unless encodeSlash is set, as Signature Version 4 requires.
+func awsURIEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '_', b == '.', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+// canonicalQuery encodes query sorted by name, as Signature Version 4
+// requires.
+func canonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var pairs []string
+	for _, name := range names {
+		for _, value := range query[name] {
+			pairs = append(pairs, awsURIEncode(name, true)+"="+awsURIEncode(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// s3Error is the error document of an S3 response.
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (backend *s3Backend) responseError(method, objectKey string, response *http.Response) error {
+	var s3Err s3Error
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+	if xml.Unmarshal(body, &s3Err) == nil && s3Err.Code != "" {
+		return fmt.Errorf("%s %s: %s: %s", method, backend.location(objectKey), s3Err.Code, s3Err.Message)
+	}
+	return fmt.Errorf("%s %s: %s", method, backend.location(objectKey), response.Status)
+}
+
+// cached returns the cached copy of key and its ETag, or nil.
+func (backend *s3Backend) cached(key string) ([]byte, string) {
+	if backend.options.CacheDir == "" {
+		return nil, ""
+	}
+	etag, err := os.ReadFile(backend.cacheFile("etags", key))
+	if err != nil {
+		return nil, ""
+	}
+	data, err := os.ReadFile(backend.cacheFile("objects", key))
+	if err != nil {
+		return nil, ""
+	}
+	return data, string(etag)
+}
+
+// cache stores a copy of key. Failures only cost a later download, so they
+// are ignored.
+func (backend *s3Backend) cache(key string, data []byte, etag string) {
+	if backend.options.CacheDir == "" {
+		return
+	}
+	if etag == "" {
+		backend.uncache(key)
+		return
+	}
+	objectFile, etagFile := backend.cacheFile("objects", key), backend.cacheFile("etags", key)
+	for _, file := range []string{objectFile, etagFile} {
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return
+		}
+	}
+	if err := os.WriteFile(objectFile, data, 0644); err != nil {
+		return
+	}
+	os.WriteFile(etagFile, []byte(etag), 0644)
+}
+
+func (backend *s3Backend) uncache(key string) {
+	if backend.options.CacheDir == "" {
+		return
+	}
+	for _, kind := range []string{"etags", "objects"} {
+		if err := os.Remove(backend.cacheFile(kind, key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return
+		}
+	}
+}
+
+func (backend *s3Backend) cacheFile(kind, key string) string {
+	return filepath.Join(backend.options.CacheDir, kind, filepath.FromSlash(key))
+}
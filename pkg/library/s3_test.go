@@ -0,0 +1,213 @@
+package library
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeS3 is an in-memory S3-compatible service with path-style addressing.
+type fakeS3 struct {
+	mu        sync.Mutex
+	objects   map[string][]byte
+	downloads map[string]int
+	unsigned  int
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	fake := &fakeS3{objects: make(map[string][]byte), downloads: make(map[string]int)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (fake *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=") {
+		fake.unsigned++
+	}
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != "regula" {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "<Error><Code>NoSuchBucket</Code><Message>no such bucket</Message></Error>")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		var keys []string
+		for objectKey := range fake.objects {
+			if strings.HasPrefix(objectKey, r.URL.Query().Get("prefix")) {
+				keys = append(keys, objectKey)
+			}
+		}
+		sort.Strings(keys)
+		fmt.Fprint(w, "<ListBucketResult>")
+		for _, objectKey := range keys {
+			fmt.Fprint(w, "<Contents><Key>")
+			xml.EscapeText(w, []byte(objectKey))
+			fmt.Fprint(w, "</Key></Contents>")
+		}
+		fmt.Fprint(w, "<IsTruncated>false</IsTruncated></ListBucketResult>")
+	case r.Method == http.MethodGet:
+		data, found := fake.objects[key]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		etag := fakeETag(data)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fake.downloads[key]++
+		w.Header().Set("ETag", etag)
+		w.Write(data)
+	case r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		fake.objects[key] = data
+		w.Header().Set("ETag", fakeETag(data))
+	case r.Method == http.MethodDelete:
+		delete(fake.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// keys returns the stored object keys.
+func (fake *fakeS3) keys() []string {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	keys := make([]string, 0, len(fake.objects))
+	for objectKey := range fake.objects {
+		keys = append(keys, objectKey)
+	}
+	return keys
+}
+
+func fakeETag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+func TestRemoteLibrary(t *testing.T) {
+	fake, server := newFakeS3(t)
+	t.Setenv(S3EndpointEnv, server.URL)
+	t.Setenv(LibraryCacheEnv, t.TempDir())
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	lib, err := Init("s3://regula/libraries/main", "")
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if _, err := lib.AddDocument("doc-a", []byte(mergeTestDocumentA), AddOptions{Format: "us"}); err != nil {
+		t.Fatalf("AddDocument failed: %v", err)
+	}
+	if !slices.Contains(fake.keys(), "libraries/main/library.json") {
+		t.Fatal("manifest not written below the prefix")
+	}
+
+	reopened, err := Open("s3://regula/libraries/main")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	entry := reopened.GetDocument("doc-a")
+	if entry == nil || entry.Status != StatusReady {
+		t.Fatalf("entry = %+v", entry)
+	}
+	triplesKey := "libraries/main/" + documentKey(entry.StorageHash, entry.StorageFormat.FileName())
+	for i := 0; i < 2; i++ {
+		tripleStore, err := reopened.LoadTripleStore("doc-a")
+		if err != nil {
+			t.Fatalf("LoadTripleStore failed: %v", err)
+		}
+		if tripleStore.Count() == 0 {
+			t.Fatal("no triples loaded")
+		}
+	}
+	fake.mu.Lock()
+	downloads, unsigned := fake.downloads[triplesKey], fake.unsigned
+	fake.mu.Unlock()
+	if downloads != 0 {
+		t.Errorf("triples downloaded %d time(s), want 0: written through the cache and revalidated", downloads)
+	}
+	if unsigned != 0 {
+		t.Errorf("%d request(s) sent without a signature", unsigned)
+	}
+
+	if err := reopened.RemoveDocument("doc-a"); err != nil {
+		t.Fatalf("RemoveDocument failed: %v", err)
+	}
+	for _, objectKey := range fake.keys() {
+		if strings.Contains(objectKey, documentsDir+"/") {
+			t.Errorf("%s left after RemoveDocument", objectKey)
+		}
+	}
+
+	if _, err := Open("s3://regula/libraries/missing"); err == nil {
+		t.Error("Open of a missing remote library succeeded")
+	}
+}
+
+func TestS3BackendErrors(t *testing.T) {
+	_, server := newFakeS3(t)
+	backend, err := newS3Backend(RemoteOptions{Bucket: "regula", Endpoint: server.URL, Region: "us-east-1"})
+	if err != nil {
+		t.Fatalf("newS3Backend failed: %v", err)
+	}
+	if _, err := backend.Read("missing.json"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Read(missing) = %v, want fs.ErrNotExist", err)
+	}
+
+	other, _ := newS3Backend(RemoteOptions{Bucket: "other", Endpoint: server.URL, Region: "us-east-1"})
+	if err := other.Write("library.json", []byte("{}")); err == nil || !strings.Contains(err.Error(), "NoSuchBucket") {
+		t.Errorf("Write to a missing bucket = %v, want NoSuchBucket", err)
+	}
+}
+
+func TestSigningKey(t *testing.T) {
+	// The key derivation example from the AWS Signature Version 4
+	// documentation.
+	key := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	if got, want := hex.EncodeToString(key), "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d"; got != want {
+		t.Errorf("signingKey = %s, want %s", got, want)
+	}
+}
+
+func TestRemoteOptionsFromEnv(t *testing.T) {
+	t.Setenv(S3EndpointEnv, "")
+	t.Setenv("AWS_ENDPOINT_URL_S3", "")
+	t.Setenv("AWS_ENDPOINT_URL", "")
+	t.Setenv("AWS_REGION", "eu-west-1")
+	t.Setenv(LibraryCacheEnv, "off")
+
+	options, err := RemoteOptionsFromEnv("s3://regula-prod/team/library/")
+	if err != nil {
+		t.Fatalf("RemoteOptionsFromEnv failed: %v", err)
+	}
+	if options.Bucket != "regula-prod" || options.Prefix != "team/library" || options.Region != "eu-west-1" || options.CacheDir != "" {
+		t.Errorf("options = %+v", options)
+	}
+
+	options, _ = RemoteOptionsFromEnv("gs://regula-gcs/library")
+	if options.Endpoint != gcsEndpoint || options.Region != "auto" {
+		t.Errorf("gs:// options = %+v", options)
+	}
+
+	if _, err := RemoteOptionsFromEnv("s3:///library"); err == nil {
+		t.Error("expected an error for a location without a bucket")
+	}
+}
@@ -1,10 +1,12 @@
 package library
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // DefaultCorpusEntries returns the hardcoded list of all known testdata documents.
@@ -31,129 +33,164 @@ func DefaultCorpusEntries() []CorpusEntry {
 	}
 }
 
+// SeedOptions configures how seeding ingests documents.
+type SeedOptions struct {
+	// Workers is the number of documents ingested concurrently. Values
+	// below 2 ingest serially. Documents are committed to the library in
+	// input order whatever the worker count, so the manifest and report
+	// are the same for any setting.
+	Workers int
+
+	// Progress, if set, is called once per document as it is committed,
+	// serially and in input order.
+	Progress func(SeedProgress)
+}
+
+// SeedProgress reports seeding progress after each document.
+type SeedProgress struct {
+	Completed int
+	Total     int
+	Entry     SeedEntryState
+	Elapsed   time.Duration
+}
+
+// seedItem is one document to seed: its ID, source file, and options.
+type seedItem struct {
+	documentID string
+	sourcePath string
+	opts       AddOptions
+}
+
 // SeedFromCorpus ingests all entries from the provided corpus list, resolving
 // source paths relative to testdataDir.
 func SeedFromCorpus(lib *Library, testdataDir string, entries []CorpusEntry) (*SeedReport, error) {
-	seedReport := &SeedReport{
-		TotalAttempted: len(entries),
-		Entries:        make([]SeedEntryState, 0, len(entries)),
-	}
-
-	for _, corpusEntry := range entries {
-		// Check if already ingested
-		if existing := lib.GetDocument(corpusEntry.ID); existing != nil && existing.Status == StatusReady {
-			seedReport.Skipped++
-			seedReport.Entries = append(seedReport.Entries, SeedEntryState{
-				ID:     corpusEntry.ID,
-				Status: "skipped",
-			})
-			continue
-		}
-
-		sourcePath := filepath.Join(testdataDir, corpusEntry.SourcePath)
-		sourceText, err := os.ReadFile(sourcePath)
-		if err != nil {
-			seedReport.Failed++
-			seedReport.Entries = append(seedReport.Entries, SeedEntryState{
-				ID:     corpusEntry.ID,
-				Status: "failed",
-				Error:  fmt.Sprintf("failed to read source: %v", err),
-			})
-			continue
-		}
+	return SeedFromCorpusWithOptions(context.Background(), lib, testdataDir, entries, SeedOptions{})
+}
 
-		opts := AddOptions{
-			Name:         corpusEntry.ShortName,
-			ShortName:    corpusEntry.ShortName,
-			FullName:     corpusEntry.FullName,
-			Jurisdiction: corpusEntry.Jurisdiction,
-			Format:       corpusEntry.Format,
-			SourceInfo:   corpusEntry.SourceInfo,
-			Force:        true,
+// SeedFromCorpusWithOptions is SeedFromCorpus with parallel ingestion and
+// progress reporting. Once ctx is done, the remaining entries are marked
+// failed without being ingested.
+func SeedFromCorpusWithOptions(ctx context.Context, lib *Library, testdataDir string, entries []CorpusEntry, seedOptions SeedOptions) (*SeedReport, error) {
+	items := make([]seedItem, len(entries))
+	for i, corpusEntry := range entries {
+		items[i] = seedItem{
+			documentID: corpusEntry.ID,
+			sourcePath: filepath.Join(testdataDir, corpusEntry.SourcePath),
+			opts: AddOptions{
+				Name:         corpusEntry.ShortName,
+				ShortName:    corpusEntry.ShortName,
+				FullName:     corpusEntry.FullName,
+				Jurisdiction: corpusEntry.Jurisdiction,
+				Format:       corpusEntry.Format,
+				SourceInfo:   corpusEntry.SourceInfo,
+				Force:        true,
+			},
 		}
-
-		_, err = lib.AddDocument(corpusEntry.ID, sourceText, opts)
-		if err != nil {
-			seedReport.Failed++
-			seedReport.Entries = append(seedReport.Entries, SeedEntryState{
-				ID:     corpusEntry.ID,
-				Status: "failed",
-				Error:  err.Error(),
-			})
-			continue
-		}
-
-		seedReport.Succeeded++
-		seedReport.Entries = append(seedReport.Entries, SeedEntryState{
-			ID:     corpusEntry.ID,
-			Status: "ingested",
-		})
 	}
-
-	return seedReport, nil
+	return seedItems(ctx, lib, items, seedOptions, "failed to read source: "), nil
 }
 
 // SeedFromDirectory scans a directory for .txt files and ingests each one.
 func SeedFromDirectory(lib *Library, dirPath string) (*SeedReport, error) {
+	return SeedFromDirectoryWithOptions(context.Background(), lib, dirPath, SeedOptions{})
+}
+
+// SeedFromDirectoryWithOptions is SeedFromDirectory with parallel
+// ingestion and progress reporting. Once ctx is done, the remaining files
+// are marked failed without being ingested.
+func SeedFromDirectoryWithOptions(ctx context.Context, lib *Library, dirPath string, seedOptions SeedOptions) (*SeedReport, error) {
 	matches, err := filepath.Glob(filepath.Join(dirPath, "*.txt"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to glob directory: %w", err)
 	}
 
+	items := make([]seedItem, len(matches))
+	for i, sourcePath := range matches {
+		documentID := DeriveDocumentID(sourcePath)
+		items[i] = seedItem{
+			documentID: documentID,
+			sourcePath: sourcePath,
+			opts: AddOptions{
+				Name:      documentID,
+				ShortName: documentID,
+				Force:     true,
+			},
+		}
+	}
+	return seedItems(ctx, lib, items, seedOptions, ""), nil
+}
+
+// seedItems ingests items that are not already ready in the library.
+// Reading and ingesting run on the worker goroutines; committing runs in
+// input order. readErrorPrefix is prepended to source read errors.
+func seedItems(ctx context.Context, lib *Library, items []seedItem, seedOptions SeedOptions, readErrorPrefix string) *SeedReport {
 	seedReport := &SeedReport{
-		TotalAttempted: len(matches),
-		Entries:        make([]SeedEntryState, 0, len(matches)),
+		TotalAttempted: len(items),
+		Entries:        make([]SeedEntryState, 0, len(items)),
 	}
+	startTime := time.Now()
 
-	for _, sourcePath := range matches {
-		documentID := DeriveDocumentID(sourcePath)
+	prepare := func(index int) *seedResult {
+		item := items[index]
+		if err := ctx.Err(); err != nil {
+			return &seedResult{state: SeedEntryState{ID: item.documentID, Status: "failed", Error: err.Error()}}
+		}
 
 		// Check if already ingested
-		if existing := lib.GetDocument(documentID); existing != nil && existing.Status == StatusReady {
-			seedReport.Skipped++
-			seedReport.Entries = append(seedReport.Entries, SeedEntryState{
-				ID:     documentID,
-				Status: "skipped",
-			})
-			continue
+		if existing := lib.GetDocument(item.documentID); existing != nil && existing.Status == StatusReady {
+			return &seedResult{state: SeedEntryState{ID: item.documentID, Status: "skipped"}}
 		}
 
-		sourceText, err := os.ReadFile(sourcePath)
+		sourceText, err := os.ReadFile(item.sourcePath)
 		if err != nil {
-			seedReport.Failed++
-			seedReport.Entries = append(seedReport.Entries, SeedEntryState{
-				ID:     documentID,
+			return &seedResult{state: SeedEntryState{
+				ID:     item.documentID,
 				Status: "failed",
-				Error:  err.Error(),
-			})
-			continue
+				Error:  readErrorPrefix + err.Error(),
+			}}
 		}
+		return &seedResult{prepared: lib.PrepareDocument(ctx, item.documentID, sourceText, item.opts)}
+	}
 
-		opts := AddOptions{
-			Name:      documentID,
-			ShortName: documentID,
-			Force:     true,
+	commit := func(index int, result *seedResult) {
+		state := result.state
+		if result.prepared != nil {
+			state = SeedEntryState{ID: result.prepared.DocumentID(), Status: "ingested"}
+			if _, err := lib.CommitDocument(result.prepared); err != nil {
+				state.Status = "failed"
+				state.Error = err.Error()
+			}
 		}
 
-		_, err = lib.AddDocument(documentID, sourceText, opts)
-		if err != nil {
+		switch state.Status {
+		case "ingested":
+			seedReport.Succeeded++
+		case "skipped":
+			seedReport.Skipped++
+		case "failed":
 			seedReport.Failed++
-			seedReport.Entries = append(seedReport.Entries, SeedEntryState{
-				ID:     documentID,
-				Status: "failed",
-				Error:  err.Error(),
+		}
+		seedReport.Entries = append(seedReport.Entries, state)
+
+		if seedOptions.Progress != nil {
+			seedOptions.Progress(SeedProgress{
+				Completed: len(seedReport.Entries),
+				Total:     len(items),
+				Entry:     state,
+				Elapsed:   time.Since(startTime),
 			})
-			continue
 		}
-
-		seedReport.Succeeded++
-		seedReport.Entries = append(seedReport.Entries, SeedEntryState{
-			ID:     documentID,
-			Status: "ingested",
-		})
 	}
 
-	return seedReport, nil
+	RunOrdered(len(items), seedOptions.Workers, prepare, commit)
+	return seedReport
+}
+
+// seedResult is a document read and ingested by a seeding worker, or the
+// final state of one that needed no ingestion.
+type seedResult struct {
+	prepared *PreparedDocument
+	state    SeedEntryState
 }
 
 // LoadCorpusManifest reads the corpus manifest.json and returns entries.
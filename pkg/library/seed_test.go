@@ -1,7 +1,9 @@
 package library
 
 import (
+	"context"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -132,3 +134,90 @@ func TestDefaultCorpusEntries(t *testing.T) {
 		}
 	}
 }
+
+func TestSeedFromCorpusWithOptionsParallelMatchesSerial(t *testing.T) {
+	testdataDir := filepath.Join("..", "..", "testdata")
+	entries := []CorpusEntry{
+		{ID: "us-va-vcdpa", Jurisdiction: "US-VA", ShortName: "VCDPA", Format: "us", SourcePath: "vcdpa.txt"},
+		{ID: "missing", Jurisdiction: "EU", ShortName: "Missing", Format: "eu", SourcePath: "nonexistent.txt"},
+		{ID: "us-tx-tdpsa", Jurisdiction: "US-TX", ShortName: "TDPSA", Format: "us", SourcePath: "tdpsa.txt"},
+		{ID: "us-co-cpa", Jurisdiction: "US-CO", ShortName: "CPA", Format: "us", SourcePath: "cpa.txt"},
+	}
+
+	seed := func(workers int) (*Library, *SeedReport, []SeedProgress) {
+		lib, err := Init(filepath.Join(t.TempDir(), "lib"), "")
+		if err != nil {
+			t.Fatalf("Init failed: %v", err)
+		}
+		var progress []SeedProgress
+		seedReport, err := SeedFromCorpusWithOptions(context.Background(), lib, testdataDir, entries, SeedOptions{
+			Workers:  workers,
+			Progress: func(p SeedProgress) { progress = append(progress, p) },
+		})
+		if err != nil {
+			t.Fatalf("SeedFromCorpusWithOptions(workers=%d) failed: %v", workers, err)
+		}
+		return lib, seedReport, progress
+	}
+
+	serialLib, serialReport, _ := seed(1)
+	parallelLib, parallelReport, progress := seed(4)
+
+	if parallelReport.Succeeded != 3 || parallelReport.Failed != 1 {
+		t.Errorf("expected 3 succeeded and 1 failed, got %d and %d", parallelReport.Succeeded, parallelReport.Failed)
+	}
+	if !reflect.DeepEqual(parallelReport, serialReport) {
+		t.Errorf("parallel report differs from serial:\n got %+v\nwant %+v", parallelReport, serialReport)
+	}
+
+	if len(progress) != len(entries) {
+		t.Fatalf("expected %d progress reports, got %d", len(entries), len(progress))
+	}
+	for i, p := range progress {
+		if p.Completed != i+1 || p.Total != len(entries) || p.Entry.ID != entries[i].ID {
+			t.Errorf("progress %d: got %d/%d %s", i, p.Completed, p.Total, p.Entry.ID)
+		}
+	}
+
+	serialDocs, parallelDocs := serialLib.ListDocuments(), parallelLib.ListDocuments()
+	if len(parallelDocs) != len(serialDocs) {
+		t.Fatalf("expected %d documents, got %d", len(serialDocs), len(parallelDocs))
+	}
+	for i := range serialDocs {
+		if parallelDocs[i].ID != serialDocs[i].ID {
+			t.Errorf("document %d: got %s, want %s", i, parallelDocs[i].ID, serialDocs[i].ID)
+			continue
+		}
+		if parallelDocs[i].Status != serialDocs[i].Status {
+			t.Errorf("%s: status %s, want %s", serialDocs[i].ID, parallelDocs[i].Status, serialDocs[i].Status)
+		}
+		if !reflect.DeepEqual(parallelDocs[i].Stats, serialDocs[i].Stats) {
+			t.Errorf("%s: stats %+v, want %+v", serialDocs[i].ID, parallelDocs[i].Stats, serialDocs[i].Stats)
+		}
+	}
+}
+
+func TestSeedFromCorpusWithOptionsCancelled(t *testing.T) {
+	lib, err := Init(filepath.Join(t.TempDir(), "lib"), "")
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	entries := []CorpusEntry{
+		{ID: "us-va-vcdpa", Jurisdiction: "US-VA", ShortName: "VCDPA", Format: "us", SourcePath: "vcdpa.txt"},
+		{ID: "us-co-cpa", Jurisdiction: "US-CO", ShortName: "CPA", Format: "us", SourcePath: "cpa.txt"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	seedReport, err := SeedFromCorpusWithOptions(ctx, lib, filepath.Join("..", "..", "testdata"), entries, SeedOptions{Workers: 2})
+	if err != nil {
+		t.Fatalf("SeedFromCorpusWithOptions failed: %v", err)
+	}
+
+	if seedReport.Failed != len(entries) || seedReport.Succeeded != 0 {
+		t.Errorf("expected %d failed and 0 succeeded, got %d and %d", len(entries), seedReport.Failed, seedReport.Succeeded)
+	}
+	if documents := lib.ListDocuments(); len(documents) != 0 {
+		t.Errorf("expected no documents after a cancelled seed, got %d", len(documents))
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/coolbeans/regula/pkg/store"
 )
 
-// SerializeTripleStore converts all triples in a TripleStore to a JSON byte slice.
+// SerializeTripleStore converts all triples in a TripleStore to a JSON byte
+// slice, with the quality scores of triples scored below
+// store.DefaultQuality.
 func SerializeTripleStore(tripleStore *store.TripleStore) ([]byte, error) {
 	if tripleStore == nil {
 		return nil, fmt.Errorf("triple store is nil")
@@ -17,16 +19,23 @@ func SerializeTripleStore(tripleStore *store.TripleStore) ([]byte, error) {
 	serialized := make([]SerializedTriple, len(allTriples))
 	for i, triple := range allTriples {
 		serialized[i] = FromStoreTriple(triple)
+		if quality := tripleStore.Quality(triple); quality < store.DefaultQuality {
+			serialized[i].Quality = &quality
+		}
 	}
 
 	return json.Marshal(serialized)
 }
 
 // DeserializeTripleStore creates a new TripleStore and populates it from a JSON byte slice.
+// Data in the binary triple format is decoded with DecodeTripleStoreBinary.
 func DeserializeTripleStore(data []byte) (*store.TripleStore, error) {
 	if len(data) == 0 {
 		return nil, fmt.Errorf("empty data")
 	}
+	if IsBinaryTripleData(data) {
+		return DecodeTripleStoreBinary(data)
+	}
 
 	var serialized []SerializedTriple
 	if err := json.Unmarshal(data, &serialized); err != nil {
@@ -42,6 +51,11 @@ func DeserializeTripleStore(data []byte) (*store.TripleStore, error) {
 	if err := tripleStore.BulkAdd(storeTriples); err != nil {
 		return nil, fmt.Errorf("failed to bulk add triples: %w", err)
 	}
+	for i, serializedTriple := range serialized {
+		if serializedTriple.Quality != nil {
+			tripleStore.SetQuality(storeTriples[i], *serializedTriple.Quality)
+		}
+	}
 
 	return tripleStore, nil
 }
@@ -43,6 +43,30 @@ func TestSerializeDeserializeRoundTrip(t *testing.T) {
 	}
 }
 
+func TestSerializeKeepsQuality(t *testing.T) {
+	original := store.NewTripleStore()
+	original.Add("http://example.org/art1", "rdf:type", "reg:Article")
+	original.Add("http://example.org/art2", "reg:references", "http://example.org/art1")
+	reference := store.NewTriple("http://example.org/art2", "reg:references", "http://example.org/art1")
+	original.SetQuality(reference, 0.6)
+
+	data, err := SerializeTripleStore(original)
+	if err != nil {
+		t.Fatalf("SerializeTripleStore failed: %v", err)
+	}
+	restored, err := DeserializeTripleStore(data)
+	if err != nil {
+		t.Fatalf("DeserializeTripleStore failed: %v", err)
+	}
+
+	if quality := restored.Quality(reference); quality != 0.6 {
+		t.Errorf("reference quality %g, want 0.6", quality)
+	}
+	if quality := restored.Quality(store.NewTriple("http://example.org/art1", "rdf:type", "reg:Article")); quality != store.DefaultQuality {
+		t.Errorf("unscored triple quality %g, want %g", quality, store.DefaultQuality)
+	}
+}
+
 func TestSerializeEmptyStore(t *testing.T) {
 	emptyStore := store.NewTripleStore()
 
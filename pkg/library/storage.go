@@ -0,0 +1,214 @@
+package library
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// StorageFormat selects how a document's triples are stored on disk.
+type StorageFormat string
+
+const (
+	// StorageJSON stores triples as a JSON array in triples.json. Libraries
+	// created before the binary format use it for every document.
+	StorageJSON StorageFormat = "json"
+
+	// StorageBinary stores triples in the compact binary format in
+	// triples.bin.
+	StorageBinary StorageFormat = "binary"
+
+	// StorageBinaryGzip stores triples in the binary format compressed
+	// with gzip, trading load time for a smaller file.
+	StorageBinaryGzip StorageFormat = "binary+gzip"
+)
+
+// ParseStorageFormat validates a storage format name.
+func ParseStorageFormat(name string) (StorageFormat, error) {
+	switch format := StorageFormat(name); format {
+	case StorageJSON, StorageBinary, StorageBinaryGzip:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown storage format %q (use %s, %s, or %s)", name, StorageJSON, StorageBinary, StorageBinaryGzip)
+}
+
+// FileName returns the name of the document file triples are stored in.
+// The empty format, used by entries written before formats were recorded,
+// is JSON.
+func (format StorageFormat) FileName() string {
+	if format == StorageJSON || format == "" {
+		return triplesFileName
+	}
+	return binaryTriplesFileName
+}
+
+// encodeTriples encodes tripleStore in format.
+func encodeTriples(tripleStore *store.TripleStore, format StorageFormat) ([]byte, error) {
+	switch format {
+	case StorageJSON, "":
+		return SerializeTripleStore(tripleStore)
+	case StorageBinary:
+		return EncodeTripleStoreBinary(tripleStore, false)
+	case StorageBinaryGzip:
+		return EncodeTripleStoreBinary(tripleStore, true)
+	}
+	return nil, fmt.Errorf("unknown storage format %q", format)
+}
+
+// StorageMigration records the conversion of a single document's triples.
+type StorageMigration struct {
+	DocumentID  string        `json:"document_id"`
+	FromFormat  StorageFormat `json:"from_format"`
+	ToFormat    StorageFormat `json:"to_format"`
+	Triples     int           `json:"triples"`
+	BytesBefore int64         `json:"bytes_before"`
+	BytesAfter  int64         `json:"bytes_after"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// StorageMigrationReport summarizes a MigrateStorageFormat run.
+type StorageMigrationReport struct {
+	DryRun     bool               `json:"dry_run"`
+	Format     StorageFormat      `json:"format"`
+	Migrated   int                `json:"migrated"`
+	Skipped    int                `json:"skipped"`
+	Failed     int                `json:"failed"`
+	Migrations []StorageMigration `json:"migrations"`
+}
+
+// MigrateStorageFormat rewrites the triples of every ready document not
+// already stored in format, and makes format the library's format for new
+// documents. A document that fails to convert keeps its old file. With
+// dryRun, documents are converted in memory to report sizes but nothing is
+// written.
+func (lib *Library) MigrateStorageFormat(format StorageFormat, dryRun bool) (*StorageMigrationReport, error) {
+	if _, err := ParseStorageFormat(string(format)); err != nil {
+		return nil, err
+	}
+
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	report := &StorageMigrationReport{DryRun: dryRun, Format: format, Migrations: make([]StorageMigration, 0)}
+	changed := lib.manifest.StorageFormat != format
+
+	for _, entry := range lib.manifest.Documents {
+		current := entry.StorageFormat
+		if current == "" {
+			current = StorageJSON
+		}
+		if current == format || entry.Status != StatusReady {
+			report.Skipped++
+			continue
+		}
+
+		migration := StorageMigration{DocumentID: entry.ID, FromFormat: current, ToFormat: format}
+		if err := lib.migrateDocumentStorageUnsafe(entry, &migration, dryRun); err != nil {
+			migration.Error = err.Error()
+			report.Failed++
+			report.Migrations = append(report.Migrations, migration)
+			continue
+		}
+
+		changed = true
+		report.Migrated++
+		report.Migrations = append(report.Migrations, migration)
+	}
+
+	if !dryRun && changed {
+		lib.manifest.StorageFormat = format
+		lib.manifest.Version = manifestVersion
+		if err := lib.saveManifest(); err != nil {
+			return nil, fmt.Errorf("failed to save manifest: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// migrateDocumentStorageUnsafe converts one document's triples to
+// migration.ToFormat, filling in the migration's counts.
+func (lib *Library) migrateDocumentStorageUnsafe(entry *DocumentEntry, migration *StorageMigration, dryRun bool) error {
+	before, err := lib.readDocumentFile(entry.StorageHash, entry.StorageFormat.FileName())
+	if err != nil {
+		return fmt.Errorf("failed to read triples: %w", err)
+	}
+	tripleStore, err := DeserializeTripleStore(before)
+	if err != nil {
+		return err
+	}
+	after, err := encodeTriples(tripleStore, migration.ToFormat)
+	if err != nil {
+		return err
+	}
+
+	migration.Triples = tripleStore.Count()
+	migration.BytesBefore = int64(len(before))
+	migration.BytesAfter = int64(len(after))
+	if dryRun {
+		return nil
+	}
+
+	if err := lib.writeTriples(entry.StorageHash, tripleStore, migration.ToFormat); err != nil {
+		return err
+	}
+	entry.StorageFormat = migration.ToFormat
+	return nil
+}
+
+// FormatStorageMigrationTable formats a storage migration report for
+// terminal output.
+func FormatStorageMigrationTable(report *StorageMigrationReport) string {
+	var builder strings.Builder
+
+	if report.DryRun {
+		builder.WriteString("Dry run: no changes written.\n")
+	}
+	builder.WriteString(fmt.Sprintf("Migrated: %d | Skipped: %d | Failed: %d\n",
+		report.Migrated, report.Skipped, report.Failed))
+
+	if len(report.Migrations) == 0 {
+		builder.WriteString(fmt.Sprintf("\nAll documents already use %s storage.\n", report.Format))
+		return builder.String()
+	}
+
+	var totalBefore, totalAfter int64
+	builder.WriteString("\n")
+	for _, migration := range report.Migrations {
+		if migration.Error != "" {
+			builder.WriteString(fmt.Sprintf("  FAILED  %s: %s\n", migration.DocumentID, migration.Error))
+			continue
+		}
+		totalBefore += migration.BytesBefore
+		totalAfter += migration.BytesAfter
+		builder.WriteString(fmt.Sprintf("  %-30s %s -> %s  %d triples  %s -> %s\n",
+			migration.DocumentID, migration.FromFormat, migration.ToFormat, migration.Triples,
+			formatByteSize(migration.BytesBefore), formatByteSize(migration.BytesAfter)))
+	}
+	if totalBefore > 0 {
+		builder.WriteString(fmt.Sprintf("\nTotal: %s -> %s (%.0f%%)\n",
+			formatByteSize(totalBefore), formatByteSize(totalAfter), 100*float64(totalAfter)/float64(totalBefore)))
+	}
+	return builder.String()
+}
+
+// FormatStorageMigrationJSON formats a storage migration report as indented
+// JSON.
+func FormatStorageMigrationJSON(report *StorageMigrationReport) string {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(data)
+}
+
+func formatByteSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", size)
+}
@@ -0,0 +1,131 @@
+package library
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewLibraryStoresBinaryTriples(t *testing.T) {
+	lib := setupMergeTestLibrary(t)
+
+	entry := lib.GetDocument("doc-a")
+	if entry.StorageFormat != DefaultStorageFormat {
+		t.Fatalf("storage format = %q, want %q", entry.StorageFormat, DefaultStorageFormat)
+	}
+	documentDir := lib.documentDir(entry.StorageHash)
+	if _, err := os.Stat(filepath.Join(documentDir, binaryTriplesFileName)); err != nil {
+		t.Errorf("expected %s: %v", binaryTriplesFileName, err)
+	}
+	if _, err := os.Stat(filepath.Join(documentDir, triplesFileName)); !os.IsNotExist(err) {
+		t.Errorf("expected no %s, got %v", triplesFileName, err)
+	}
+
+	tripleStore, err := lib.LoadTripleStore("doc-a")
+	if err != nil {
+		t.Fatalf("LoadTripleStore failed: %v", err)
+	}
+	if tripleStore.Count() != entry.Stats.TotalTriples {
+		t.Errorf("loaded %d triples, want %d", tripleStore.Count(), entry.Stats.TotalTriples)
+	}
+}
+
+func TestMigrateStorageFormat(t *testing.T) {
+	lib := setupMergeTestLibrary(t)
+	want, err := lib.LoadAllTripleStores()
+	if err != nil {
+		t.Fatalf("LoadAllTripleStores failed: %v", err)
+	}
+
+	// Convert to JSON, as a library written before the binary format.
+	report, err := lib.MigrateStorageFormat(StorageJSON, false)
+	if err != nil {
+		t.Fatalf("MigrateStorageFormat(json) failed: %v", err)
+	}
+	if report.Migrated != 2 || report.Failed != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	for _, entry := range lib.ListDocuments() {
+		entry.StorageFormat = ""
+	}
+	lib.manifest.StorageFormat = ""
+	if err := lib.saveManifest(); err != nil {
+		t.Fatal(err)
+	}
+
+	legacy, err := Open(lib.Path())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if got, err := legacy.LoadAllTripleStores(); err != nil || got.Count() != want.Count() {
+		t.Fatalf("legacy library loaded %v triples (err %v), want %d", got, err, want.Count())
+	}
+
+	dryRun, err := legacy.MigrateStorageFormat(StorageBinaryGzip, true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if dryRun.Migrated != 2 || dryRun.Migrations[0].BytesAfter >= dryRun.Migrations[0].BytesBefore {
+		t.Fatalf("unexpected dry run report: %+v", dryRun)
+	}
+	if entry := legacy.GetDocument("doc-a"); entry.StorageFormat != "" {
+		t.Fatalf("dry run changed storage format to %q", entry.StorageFormat)
+	}
+
+	report, err = legacy.MigrateStorageFormat(StorageBinaryGzip, false)
+	if err != nil || report.Migrated != 2 {
+		t.Fatalf("MigrateStorageFormat(binary+gzip) = %+v, %v", report, err)
+	}
+
+	reopened, err := Open(lib.Path())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	got, err := reopened.LoadAllTripleStores()
+	if err != nil {
+		t.Fatalf("LoadAllTripleStores after migration failed: %v", err)
+	}
+	if got.Count() != want.Count() {
+		t.Errorf("migrated library has %d triples, want %d", got.Count(), want.Count())
+	}
+	for _, triple := range want.All() {
+		if !got.Exists(triple.Subject, triple.Predicate, triple.Object) {
+			t.Fatalf("migration lost triple %v", triple)
+		}
+	}
+
+	// New documents use the migrated format, and a second run is a no-op.
+	if _, err := reopened.AddDocument("doc-c", []byte(mergeTestDocumentA), AddOptions{Format: "us"}); err != nil {
+		t.Fatalf("AddDocument failed: %v", err)
+	}
+	if format := reopened.GetDocument("doc-c").StorageFormat; format != StorageBinaryGzip {
+		t.Errorf("new document stored as %q, want %q", format, StorageBinaryGzip)
+	}
+	again, err := reopened.MigrateStorageFormat(StorageBinaryGzip, false)
+	if err != nil || again.Migrated != 0 || again.Skipped != 3 {
+		t.Errorf("second migration = %+v, %v", again, err)
+	}
+}
+
+func TestMigrateStorageFormatKeepsUnreadableDocument(t *testing.T) {
+	lib := setupMergeTestLibrary(t)
+	entry := lib.GetDocument("doc-b")
+	triplesPath := filepath.Join(lib.documentDir(entry.StorageHash), entry.StorageFormat.FileName())
+	if err := os.WriteFile(triplesPath, []byte("RGTB\x01\x00garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := lib.MigrateStorageFormat(StorageJSON, false)
+	if err != nil {
+		t.Fatalf("MigrateStorageFormat failed: %v", err)
+	}
+	if report.Migrated != 1 || report.Failed != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if format := lib.GetDocument("doc-b").StorageFormat; format != StorageBinary {
+		t.Errorf("failed document's format changed to %q", format)
+	}
+	if _, err := os.Stat(triplesPath); err != nil {
+		t.Errorf("failed document's triples were removed: %v", err)
+	}
+}
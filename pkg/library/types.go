@@ -3,6 +3,7 @@ package library
 import (
 	"time"
 
+	"github.com/coolbeans/regula/pkg/extract"
 	"github.com/coolbeans/regula/pkg/store"
 )
 
@@ -27,6 +28,17 @@ type LibraryManifest struct {
 	CreatedAt time.Time        `json:"created_at"`
 	UpdatedAt time.Time        `json:"updated_at"`
 	Documents []*DocumentEntry `json:"documents"`
+
+	// StorageFormat is the format new and updated documents' triples are
+	// written in. Libraries created before it was recorded use JSON.
+	StorageFormat StorageFormat `json:"storage_format,omitempty"`
+
+	// Collections are named groups of documents, sorted by name.
+	Collections []*Collection `json:"collections,omitempty"`
+
+	// Aliases map alternative document IDs to the canonical document they
+	// stand for; see AddAlias.
+	Aliases map[string]string `json:"aliases,omitempty"`
 }
 
 // DocumentEntry represents a single legislation document stored in the library.
@@ -37,13 +49,18 @@ type DocumentEntry struct {
 	FullName     string           `json:"full_name"`
 	Jurisdiction string           `json:"jurisdiction"`
 	Format       string           `json:"format"`
+	BaseURI      string           `json:"base_uri,omitempty"`
 	Tags         []string         `json:"tags,omitempty"`
+	EffectiveDate string          `json:"effective_date,omitempty"`
+	LegalStatus  LegalStatus      `json:"legal_status,omitempty"`
 	Status       DocumentStatus   `json:"status"`
 	IngestedAt   time.Time        `json:"ingested_at"`
 	UpdatedAt    time.Time        `json:"updated_at"`
 	SourceInfo   string           `json:"source_info,omitempty"`
+	ShortTitles  []string         `json:"short_titles,omitempty"`
 	Stats        *DocumentStats   `json:"stats,omitempty"`
 	StorageHash  string           `json:"storage_hash"`
+	StorageFormat StorageFormat   `json:"storage_format,omitempty"`
 	Error        string           `json:"error,omitempty"`
 }
 
@@ -63,10 +80,12 @@ type DocumentStats struct {
 }
 
 // SerializedTriple is a JSON-serializable representation of an RDF triple.
+// Quality is set only for triples scored below store.DefaultQuality.
 type SerializedTriple struct {
-	Subject   string `json:"subject"`
-	Predicate string `json:"predicate"`
-	Object    string `json:"object"`
+	Subject   string   `json:"subject"`
+	Predicate string   `json:"predicate"`
+	Object    string   `json:"object"`
+	Quality   *float64 `json:"quality,omitempty"`
 }
 
 // ToStoreTriple converts a SerializedTriple to a store.Triple.
@@ -92,8 +111,11 @@ type AddOptions struct {
 	Format       string
 	Tags         []string
 	SourceInfo   string
-	BaseURI      string
-	Force        bool // overwrite existing document with same ID
+	BaseURI      string // overrides the document's derived DocumentBaseURI
+	Force        bool   // overwrite existing document with same ID
+
+	// RecurrenceAnnotations override obligation recurrence extracted from the text.
+	RecurrenceAnnotations []extract.RecurrenceAnnotation
 }
 
 // LibraryStats aggregates statistics across all documents in the library.
@@ -142,4 +164,5 @@ type IngestResult struct {
 	Stats       *DocumentStats
 	DocumentID  string
 	RegID       string
+	ShortTitles []string
 }
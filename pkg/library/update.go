@@ -0,0 +1,521 @@
+package library
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// UpdateMode records how UpdateDocument applied an edited source.
+type UpdateMode string
+
+const (
+	// UpdateUnchanged means the new source is identical to the cached one.
+	UpdateUnchanged UpdateMode = "unchanged"
+
+	// UpdateIncremental means only the triples of changed articles were replaced.
+	UpdateIncremental UpdateMode = "incremental"
+
+	// UpdateFull means the whole document was re-ingested.
+	UpdateFull UpdateMode = "full"
+)
+
+// UpdateOptions configures how an edited document is applied to the library.
+type UpdateOptions struct {
+	Format string // parser format hint; defaults to the format the document was added with
+	DryRun bool   // report the changes without writing them
+	Full   bool   // re-ingest the whole document instead of only changed articles
+
+	// RecurrenceAnnotations override obligation recurrence extracted from the text.
+	RecurrenceAnnotations []extract.RecurrenceAnnotation
+
+	// ReferenceMappings pin references the resolver cannot handle to a target.
+	ReferenceMappings []extract.ReferenceMapping
+
+	// ReferenceExtractor extracts the references of changed articles; nil
+	// uses the built-in reference patterns.
+	ReferenceExtractor *extract.ReferenceExtractor
+}
+
+// referenceExtractor returns the reference extractor for an update.
+func (opts UpdateOptions) referenceExtractor() *extract.ReferenceExtractor {
+	if opts.ReferenceExtractor != nil {
+		return opts.ReferenceExtractor
+	}
+	return extract.NewReferenceExtractor()
+}
+
+// UpdateReport summarizes the changes UpdateDocument made to a document's graph.
+type UpdateReport struct {
+	DocumentID       string     `json:"document_id"`
+	Mode             UpdateMode `json:"mode"`
+	Reason           string     `json:"reason,omitempty"`
+	DryRun           bool       `json:"dry_run"`
+	AddedArticles    []string   `json:"added_articles,omitempty"`
+	RemovedArticles  []string   `json:"removed_articles,omitempty"`
+	ModifiedArticles []string   `json:"modified_articles,omitempty"`
+	RelinkedArticles []string   `json:"relinked_articles,omitempty"`
+	TriplesAdded     int        `json:"triples_added"`
+	TriplesRemoved   int        `json:"triples_removed"`
+	TotalTriples     int        `json:"total_triples"`
+}
+
+// UpdateDocument applies an edited source to a stored document. The new
+// source is diffed against the cached one article by article; only added,
+// removed, and modified articles are re-extracted, together with unchanged
+// articles whose cross-references now resolve differently, and their
+// triples are replaced in the stored graph. Edits outside the articles
+// (title, preamble, chapter structure) or to the definitions change triples
+// throughout the document, so they fall back to a full re-ingest.
+func (lib *Library) UpdateDocument(documentID string, sourceText []byte, opts UpdateOptions) (*UpdateReport, error) {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+
+	entry := lib.findDocumentUnsafe(documentID)
+	if entry == nil {
+		return nil, fmt.Errorf("document not found: %s", documentID)
+	}
+	if entry.Status != StatusReady {
+		return nil, fmt.Errorf("document %s is not ready (status: %s)", documentID, entry.Status)
+	}
+	if len(sourceText) == 0 {
+		return nil, fmt.Errorf("source text is empty")
+	}
+
+	cachedSource, err := lib.readDocumentFile(entry.StorageHash, sourceFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached source for %s: %w", documentID, err)
+	}
+	tripleStore, err := lib.readTriples(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(cachedSource, sourceText) && !opts.Full {
+		return &UpdateReport{
+			DocumentID:   documentID,
+			Mode:         UpdateUnchanged,
+			DryRun:       opts.DryRun,
+			TotalTriples: tripleStore.Count(),
+		}, nil
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = entry.Format
+	}
+	baseURI := entry.BaseURI
+	if baseURI == "" {
+		baseURI = lib.manifest.BaseURI
+	}
+
+	oldDoc, err := parseSource(cachedSource, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cached source for %s: %w", documentID, err)
+	}
+	newDoc, err := parseSource(sourceText, format)
+	if err != nil {
+		return nil, err
+	}
+
+	report, stats, err := ApplyEdit(tripleStore, oldDoc, newDoc, strings.ToUpper(documentID), baseURI, entry.Stats, opts)
+	if err != nil {
+		return nil, err
+	}
+	report.DocumentID = documentID
+	report.DryRun = opts.DryRun
+
+	if report.Mode == UpdateFull {
+		result, err := ingestFromText(context.Background(), sourceText, documentID, baseURI, format, opts.RecurrenceAnnotations, opts.ReferenceMappings)
+		if err != nil {
+			return nil, fmt.Errorf("ingestion failed for %s: %w", documentID, err)
+		}
+		report.TriplesRemoved, report.TriplesAdded = replaceTriples(tripleStore, tripleStore.All(), result.TripleStore.All())
+		stats = result.Stats
+	}
+	stats.SourceBytes = len(sourceText)
+	stats.TotalTriples = tripleStore.Count()
+	report.TotalTriples = stats.TotalTriples
+
+	if opts.DryRun {
+		return report, nil
+	}
+
+	if err := lib.writeDocumentFile(entry.StorageHash, sourceFileName, sourceText); err != nil {
+		return nil, fmt.Errorf("failed to save source: %w", err)
+	}
+	if err := lib.writeTriples(entry.StorageHash, tripleStore, entry.StorageFormat); err != nil {
+		return nil, err
+	}
+	metadataBytes, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	if err := lib.writeDocumentFile(entry.StorageHash, metadataFileName, metadataBytes); err != nil {
+		return nil, fmt.Errorf("failed to save metadata: %w", err)
+	}
+
+	entry.Stats = stats
+	entry.ShortTitles = ExtractShortTitles(sourceText)
+	entry.UpdatedAt = time.Now().UTC()
+	if err := lib.saveManifest(); err != nil {
+		return nil, fmt.Errorf("failed to save manifest: %w", err)
+	}
+
+	return report, nil
+}
+
+// ApplyEdit updates tripleStore, a graph built from oldDoc, to match newDoc
+// by re-extracting only the articles that changed. It is the in-memory core
+// of UpdateDocument, for callers that keep their own graph, such as a watch
+// loop during authoring. regID must match the regulation ID the graph was
+// resolved with, and stats are the graph's current stats.
+//
+// When the edit cannot be applied incrementally (see UpdateDocument) or
+// opts.Full is set, the report's Mode is UpdateFull with the Reason, and
+// tripleStore is left untouched for the caller to rebuild.
+func ApplyEdit(tripleStore *store.TripleStore, oldDoc, newDoc *extract.Document, regID string, baseURI string, stats *DocumentStats, opts UpdateOptions) (*UpdateReport, *DocumentStats, error) {
+	diff := diffDocumentArticles(oldDoc, newDoc)
+	report := &UpdateReport{
+		AddedArticles:    diff.added,
+		RemovedArticles:  diff.removed,
+		ModifiedArticles: diff.modified,
+	}
+
+	if opts.Full {
+		report.Reason = "full re-ingest requested"
+	} else {
+		report.Reason = incrementalBlocker(oldDoc, newDoc)
+	}
+	if report.Reason != "" {
+		report.Mode = UpdateFull
+		report.TotalTriples = tripleStore.Count()
+		return report, stats, nil
+	}
+
+	report.Mode = UpdateIncremental
+	updated, err := applyArticleUpdate(tripleStore, report, oldDoc, newDoc, diff, regID, baseURI, stats, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	updated.TotalTriples = tripleStore.Count()
+	report.TotalTriples = updated.TotalTriples
+	return report, updated, nil
+}
+
+// applyArticleUpdate re-extracts the dirty articles of an edit and replaces
+// their triples in tripleStore, returning the adjusted document stats.
+func applyArticleUpdate(tripleStore *store.TripleStore, report *UpdateReport, oldDoc, newDoc *extract.Document, diff *articleDiff, regID string, baseURI string, previous *DocumentStats, opts UpdateOptions) (*DocumentStats, error) {
+	refExtractor := opts.referenceExtractor()
+
+	oldResolver := extract.NewReferenceResolver(baseURI, regID)
+	oldResolver.IndexDocument(oldDoc)
+	oldResolver.SetManualMappings(opts.ReferenceMappings)
+	newResolver := extract.NewReferenceResolver(baseURI, regID)
+	newResolver.IndexDocument(newDoc)
+	newResolver.SetManualMappings(opts.ReferenceMappings)
+
+	// Adding or removing articles changes what references resolve to, so
+	// unchanged articles whose references now resolve differently are dirty too
+	dirty := make(map[string]bool)
+	for _, key := range diff.added {
+		dirty[key] = true
+	}
+	for _, key := range diff.removed {
+		dirty[key] = true
+	}
+	for _, key := range diff.modified {
+		dirty[key] = true
+	}
+	if len(diff.added) > 0 || len(diff.removed) > 0 {
+		for _, article := range newDoc.AllArticles() {
+			key := articleKey(article)
+			if dirty[key] {
+				continue
+			}
+			for _, ref := range refExtractor.ExtractFromArticle(article) {
+				if !reflect.DeepEqual(oldResolver.Resolve(ref), newResolver.Resolve(ref)) {
+					dirty[key] = true
+					report.RelinkedArticles = append(report.RelinkedArticles, key)
+					break
+				}
+			}
+		}
+	}
+
+	oldRegion, oldStats, err := buildArticleRegion(oldDoc, dirty, baseURI, oldResolver, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebuild previous articles: %w", err)
+	}
+	newRegion, newStats, err := buildArticleRegion(newDoc, dirty, baseURI, newResolver, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebuild changed articles: %w", err)
+	}
+	report.TriplesRemoved, report.TriplesAdded = replaceTriples(tripleStore, oldRegion.All(), newRegion.All())
+
+	stats := &DocumentStats{}
+	if previous != nil {
+		*stats = *previous
+	}
+	stats.Articles += newStats.Articles - oldStats.Articles
+	stats.References += newStats.References - oldStats.References
+	stats.Rights += newStats.Rights - oldStats.Rights
+	stats.Obligations += newStats.Obligations - oldStats.Obligations
+	stats.TermUsages += newStats.TermUsages - oldStats.TermUsages
+	return stats, nil
+}
+
+// buildArticleRegion builds the triples of the dirty articles of doc into a
+// scratch store.
+func buildArticleRegion(doc *extract.Document, dirty map[string]bool, baseURI string, resolver *extract.ReferenceResolver, opts UpdateOptions) (*store.TripleStore, *store.BuildStats, error) {
+	var articles []*extract.Article
+	for _, article := range doc.AllArticles() {
+		if dirty[articleKey(article)] {
+			articles = append(articles, article)
+		}
+	}
+
+	semExtractor := extract.NewSemanticExtractor()
+	semExtractor.SetRecurrenceAnnotations(opts.RecurrenceAnnotations)
+
+	regionStore := store.NewTripleStore()
+	builder := store.NewGraphBuilder(regionStore, baseURI)
+	definitions := extract.NewDefinitionExtractor().ExtractDefinitions(doc)
+	stats, err := builder.BuildArticles(doc, articles, definitions, opts.referenceExtractor(), resolver, semExtractor)
+	if err != nil {
+		return nil, nil, err
+	}
+	return regionStore, stats, nil
+}
+
+// replaceTriples removes the outgoing triples that are not also incoming
+// and adds the incoming triples that are missing, returning the number of
+// triples removed and added.
+func replaceTriples(tripleStore *store.TripleStore, outgoing []store.Triple, incoming []store.Triple) (int, int) {
+	keep := make(map[store.Triple]bool, len(incoming))
+	for _, triple := range incoming {
+		keep[triple] = true
+	}
+
+	removed := 0
+	for _, triple := range outgoing {
+		if !keep[triple] && tripleStore.DeleteTriple(triple) {
+			removed++
+		}
+	}
+	added := 0
+	for _, triple := range incoming {
+		if !tripleStore.Exists(triple.Subject, triple.Predicate, triple.Object) {
+			tripleStore.AddTriple(triple)
+			added++
+		}
+	}
+	return removed, added
+}
+
+// articleDiff lists article keys added, removed, and modified between two
+// versions of a document, in document order.
+type articleDiff struct {
+	added    []string
+	removed  []string
+	modified []string
+}
+
+// diffDocumentArticles compares the articles of two versions of a document.
+// An article is modified when its title, text, paragraphs, or parent
+// chapter or section changed.
+func diffDocumentArticles(oldDoc, newDoc *extract.Document) *articleDiff {
+	oldFingerprints := articleFingerprints(oldDoc)
+	newFingerprints := articleFingerprints(newDoc)
+
+	diff := &articleDiff{}
+	for _, article := range newDoc.AllArticles() {
+		key := articleKey(article)
+		oldFingerprint, existed := oldFingerprints[key]
+		switch {
+		case !existed:
+			diff.added = append(diff.added, key)
+		case oldFingerprint != newFingerprints[key]:
+			diff.modified = append(diff.modified, key)
+		}
+	}
+	for _, article := range oldDoc.AllArticles() {
+		key := articleKey(article)
+		if _, exists := newFingerprints[key]; !exists {
+			diff.removed = append(diff.removed, key)
+		}
+	}
+	return diff
+}
+
+// articleFingerprints maps each article key to a serialization of the
+// article and its parent.
+func articleFingerprints(doc *extract.Document) map[string]string {
+	fingerprints := make(map[string]string)
+	add := func(parent string, article *extract.Article) {
+		data, _ := json.Marshal(article)
+		fingerprints[articleKey(article)] = parent + "\n" + string(data)
+	}
+	for _, chapter := range doc.Chapters {
+		for _, section := range chapter.Sections {
+			for _, article := range section.Articles {
+				add(fmt.Sprintf("%s/%d/%s", chapter.Number, section.Number, section.SectionID), article)
+			}
+		}
+		for _, article := range chapter.Articles {
+			add(chapter.Number, article)
+		}
+	}
+	return fingerprints
+}
+
+// articleKey identifies an article within a document.
+func articleKey(article *extract.Article) string {
+	if article.SectionID != "" {
+		return article.SectionID
+	}
+	return fmt.Sprintf("%d", article.Number)
+}
+
+// incrementalBlocker returns why an edit cannot be applied article by
+// article, or "" when it can.
+func incrementalBlocker(oldDoc, newDoc *extract.Document) string {
+	if documentSkeleton(oldDoc) != documentSkeleton(newDoc) {
+		return "document title, identifier, preamble, or chapter structure changed"
+	}
+	if !uniqueArticleNumbers(oldDoc) || !uniqueArticleNumbers(newDoc) {
+		return "article numbers are not unique"
+	}
+	if definitionsFingerprint(oldDoc) != definitionsFingerprint(newDoc) {
+		return "definitions changed"
+	}
+	return ""
+}
+
+// definitionsFingerprint serializes the parts of a document's definitions
+// that are built into the graph. Cross-references between definitions are
+// not built and are collected in no particular order, so they are left out.
+func definitionsFingerprint(doc *extract.Document) string {
+	var builder strings.Builder
+	for _, definition := range extract.NewDefinitionExtractor().ExtractDefinitions(doc) {
+		builtDefinition := *definition
+		builtDefinition.References = nil
+		data, _ := json.Marshal(builtDefinition)
+		builder.Write(data)
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// documentSkeleton serializes the document-level content that is built
+// outside of articles.
+func documentSkeleton(doc *extract.Document) string {
+	type sectionSkeleton struct {
+		Number    int
+		SectionID string
+		Title     string
+	}
+	type chapterSkeleton struct {
+		Number   string
+		Title    string
+		Sections []sectionSkeleton
+	}
+	skeleton := struct {
+		Title       string
+		Type        extract.DocumentType
+		Identifier  string
+		Preamble    *extract.Preamble
+		Definitions []*extract.Definition
+		Chapters    []chapterSkeleton
+	}{
+		Title:       doc.Title,
+		Type:        doc.Type,
+		Identifier:  doc.Identifier,
+		Preamble:    doc.Preamble,
+		Definitions: doc.Definitions,
+	}
+	for _, chapter := range doc.Chapters {
+		chapterEntry := chapterSkeleton{Number: chapter.Number, Title: chapter.Title}
+		for _, section := range chapter.Sections {
+			chapterEntry.Sections = append(chapterEntry.Sections, sectionSkeleton{
+				Number:    section.Number,
+				SectionID: section.SectionID,
+				Title:     section.Title,
+			})
+		}
+		skeleton.Chapters = append(skeleton.Chapters, chapterEntry)
+	}
+	data, _ := json.Marshal(skeleton)
+	return string(data)
+}
+
+// uniqueArticleNumbers reports whether no two articles share a number.
+// Paragraph, reference, and annotation URIs are keyed by article number, so
+// articles sharing one cannot be rebuilt separately.
+func uniqueArticleNumbers(doc *extract.Document) bool {
+	seen := make(map[int]bool)
+	for _, article := range doc.AllArticles() {
+		if seen[article.Number] {
+			return false
+		}
+		seen[article.Number] = true
+	}
+	return true
+}
+
+// FormatUpdateReportTable formats an update report for terminal output.
+func FormatUpdateReportTable(report *UpdateReport) string {
+	var builder strings.Builder
+
+	if report.DryRun {
+		builder.WriteString("Dry run: no changes written.\n")
+	}
+	builder.WriteString(fmt.Sprintf("Document: %s\n", report.DocumentID))
+	builder.WriteString(fmt.Sprintf("Mode: %s", report.Mode))
+	if report.Reason != "" {
+		builder.WriteString(fmt.Sprintf(" (%s)", report.Reason))
+	}
+	builder.WriteString("\n")
+
+	if report.Mode == UpdateUnchanged {
+		builder.WriteString("\nSource unchanged; graph not modified.\n")
+		return builder.String()
+	}
+
+	builder.WriteString("\n")
+	for _, row := range []struct {
+		label string
+		keys  []string
+	}{
+		{"Added articles", report.AddedArticles},
+		{"Removed articles", report.RemovedArticles},
+		{"Modified articles", report.ModifiedArticles},
+		{"Relinked articles", report.RelinkedArticles},
+	} {
+		builder.WriteString(fmt.Sprintf("  %-18s %d", row.label+":", len(row.keys)))
+		if len(row.keys) > 0 {
+			builder.WriteString("  " + truncateConflictValue(strings.Join(row.keys, ", "), 60))
+		}
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString(fmt.Sprintf("\nTriples: +%d / -%d (total %d)\n",
+		report.TriplesAdded, report.TriplesRemoved, report.TotalTriples))
+	return builder.String()
+}
+
+// FormatUpdateReportJSON formats an update report as indented JSON.
+func FormatUpdateReportJSON(report *UpdateReport) string {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(data)
+}
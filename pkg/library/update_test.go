@@ -0,0 +1,250 @@
+package library
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// addGDPR adds the GDPR test data to a fresh library.
+func addGDPR(t *testing.T) (*Library, string) {
+	t.Helper()
+	sourceText, err := os.ReadFile(filepath.Join("..", "..", "testdata", "gdpr.txt"))
+	if err != nil {
+		t.Skipf("GDPR test data not available: %v", err)
+	}
+
+	lib, err := Init(filepath.Join(t.TempDir(), "lib"), "")
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if _, err := lib.AddDocument("eu-gdpr", sourceText, AddOptions{Jurisdiction: "EU"}); err != nil {
+		t.Fatalf("AddDocument failed: %v", err)
+	}
+	return lib, string(sourceText)
+}
+
+// removeArticle cuts the heading and body of an article out of GDPR text.
+func removeArticle(t *testing.T, sourceText string, number string, next string) string {
+	t.Helper()
+	start := strings.Index(sourceText, "\nArticle "+number+"\n")
+	end := strings.Index(sourceText, "\nArticle "+next+"\n")
+	if start < 0 || end < start {
+		t.Fatalf("Article %s not found in test data", number)
+	}
+	return sourceText[:start] + sourceText[end:]
+}
+
+// assertMatchesFullIngest checks that the updated graph equals a fresh
+// ingest of the edited source.
+func assertMatchesFullIngest(t *testing.T, lib *Library, editedSource string) {
+	t.Helper()
+	// Compare the stored triples, without the metadata LoadTripleStore adds.
+	updated, err := lib.readTriples(lib.GetDocument("eu-gdpr"))
+	if err != nil {
+		t.Fatalf("readTriples failed: %v", err)
+	}
+	fresh, err := IngestFromText([]byte(editedSource), "eu-gdpr", lib.DocumentBaseURI("eu-gdpr"))
+	if err != nil {
+		t.Fatalf("IngestFromText failed: %v", err)
+	}
+
+	missing := tripleDifference(fresh.TripleStore, updated)
+	extra := tripleDifference(updated, fresh.TripleStore)
+	if len(missing) > 0 || len(extra) > 0 {
+		t.Errorf("incremental graph differs from full ingest: %d missing (e.g. %v), %d extra (e.g. %v)",
+			len(missing), firstTriples(missing), len(extra), firstTriples(extra))
+	}
+	if entry := lib.GetDocument("eu-gdpr"); entry.Stats.TotalTriples != fresh.Stats.TotalTriples ||
+		entry.Stats.Articles != fresh.Stats.Articles || entry.Stats.References != fresh.Stats.References {
+		t.Errorf("stats = %+v, want %+v", entry.Stats, fresh.Stats)
+	}
+}
+
+func tripleDifference(from, without *store.TripleStore) []string {
+	var difference []string
+	for _, triple := range from.All() {
+		if !without.Exists(triple.Subject, triple.Predicate, triple.Object) {
+			difference = append(difference, triple.Subject+" "+triple.Predicate+" "+triple.Object)
+		}
+	}
+	sort.Strings(difference)
+	return difference
+}
+
+func firstTriples(triples []string) []string {
+	if len(triples) > 3 {
+		return triples[:3]
+	}
+	return triples
+}
+
+func TestUpdateDocument_Unchanged(t *testing.T) {
+	lib, sourceText := addGDPR(t)
+
+	report, err := lib.UpdateDocument("eu-gdpr", []byte(sourceText), UpdateOptions{})
+	if err != nil {
+		t.Fatalf("UpdateDocument failed: %v", err)
+	}
+	if report.Mode != UpdateUnchanged || report.TriplesAdded != 0 || report.TriplesRemoved != 0 {
+		t.Errorf("report = %+v, want unchanged", report)
+	}
+}
+
+func TestUpdateDocument_ModifiedArticle(t *testing.T) {
+	lib, sourceText := addGDPR(t)
+	edited := strings.Replace(sourceText,
+		"have the right to obtain from the controller\nthe erasure of personal data",
+		"have the right to obtain from the controller\nwithout charge the erasure of personal data", 1)
+	if edited == sourceText {
+		t.Fatal("edit did not apply")
+	}
+
+	report, err := lib.UpdateDocument("eu-gdpr", []byte(edited), UpdateOptions{})
+	if err != nil {
+		t.Fatalf("UpdateDocument failed: %v", err)
+	}
+	if report.Mode != UpdateIncremental {
+		t.Fatalf("mode = %s (%s), want incremental", report.Mode, report.Reason)
+	}
+	if len(report.ModifiedArticles) != 1 || report.ModifiedArticles[0] != "17" {
+		t.Errorf("modified articles = %v, want [17]", report.ModifiedArticles)
+	}
+	if report.TriplesAdded == 0 || report.TriplesRemoved == 0 {
+		t.Errorf("expected triples replaced, got +%d/-%d", report.TriplesAdded, report.TriplesRemoved)
+	}
+	assertMatchesFullIngest(t, lib, edited)
+
+	source, err := lib.LoadSourceText("eu-gdpr")
+	if err != nil || string(source) != edited {
+		t.Error("cached source was not updated")
+	}
+}
+
+func TestUpdateDocument_RemovedArticle(t *testing.T) {
+	lib, sourceText := addGDPR(t)
+	edited := removeArticle(t, sourceText, "16", "17")
+
+	report, err := lib.UpdateDocument("eu-gdpr", []byte(edited), UpdateOptions{})
+	if err != nil {
+		t.Fatalf("UpdateDocument failed: %v", err)
+	}
+	if report.Mode != UpdateIncremental {
+		t.Fatalf("mode = %s (%s), want incremental", report.Mode, report.Reason)
+	}
+	if len(report.RemovedArticles) != 1 || report.RemovedArticles[0] != "16" {
+		t.Errorf("removed articles = %v, want [16]", report.RemovedArticles)
+	}
+	if len(report.RelinkedArticles) == 0 {
+		t.Error("expected articles citing Article 16 to be relinked")
+	}
+	assertMatchesFullIngest(t, lib, edited)
+
+	// Restoring the article adds it back
+	report, err = lib.UpdateDocument("eu-gdpr", []byte(sourceText), UpdateOptions{})
+	if err != nil {
+		t.Fatalf("UpdateDocument failed: %v", err)
+	}
+	if len(report.AddedArticles) != 1 || report.AddedArticles[0] != "16" {
+		t.Errorf("added articles = %v, want [16]", report.AddedArticles)
+	}
+	assertMatchesFullIngest(t, lib, sourceText)
+}
+
+func TestUpdateDocument_DefinitionsFallBackToFull(t *testing.T) {
+	lib, sourceText := addGDPR(t)
+	edited := strings.Replace(sourceText, "‘personal data’ means any information", "‘personal data’ means all information", 1)
+	if edited == sourceText {
+		t.Fatal("edit did not apply")
+	}
+
+	report, err := lib.UpdateDocument("eu-gdpr", []byte(edited), UpdateOptions{})
+	if err != nil {
+		t.Fatalf("UpdateDocument failed: %v", err)
+	}
+	if report.Mode != UpdateFull || report.Reason != "definitions changed" {
+		t.Errorf("mode = %s (%s), want full because definitions changed", report.Mode, report.Reason)
+	}
+	assertMatchesFullIngest(t, lib, edited)
+}
+
+func TestUpdateDocument_DryRun(t *testing.T) {
+	lib, sourceText := addGDPR(t)
+	before, _ := lib.LoadTripleStore("eu-gdpr")
+	edited := removeArticle(t, sourceText, "16", "17")
+
+	report, err := lib.UpdateDocument("eu-gdpr", []byte(edited), UpdateOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("UpdateDocument failed: %v", err)
+	}
+	if report.TriplesRemoved == 0 {
+		t.Error("dry run should report removed triples")
+	}
+
+	after, _ := lib.LoadTripleStore("eu-gdpr")
+	if after.Count() != before.Count() {
+		t.Errorf("dry run changed the stored graph: %d -> %d triples", before.Count(), after.Count())
+	}
+	if source, _ := lib.LoadSourceText("eu-gdpr"); string(source) != sourceText {
+		t.Error("dry run changed the cached source")
+	}
+}
+
+func TestUpdateDocument_NotFound(t *testing.T) {
+	lib, err := Init(filepath.Join(t.TempDir(), "lib"), "")
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if _, err := lib.UpdateDocument("missing", []byte("text"), UpdateOptions{}); err == nil {
+		t.Error("expected error for missing document")
+	}
+}
+
+func TestApplyEdit_InMemory(t *testing.T) {
+	sourceText, err := os.ReadFile(filepath.Join("..", "..", "testdata", "gdpr.txt"))
+	if err != nil {
+		t.Skipf("GDPR test data not available: %v", err)
+	}
+	baseURI := "https://regula.dev/regulations/"
+	original, err := IngestFromText(sourceText, "gdpr", baseURI)
+	if err != nil {
+		t.Fatalf("IngestFromText failed: %v", err)
+	}
+	edited := removeArticle(t, string(sourceText), "16", "17")
+
+	oldDoc, _ := parseSource(sourceText, "")
+	newDoc, _ := parseSource([]byte(edited), "")
+	report, stats, err := ApplyEdit(original.TripleStore, oldDoc, newDoc, original.RegID, baseURI, original.Stats, UpdateOptions{})
+	if err != nil {
+		t.Fatalf("ApplyEdit failed: %v", err)
+	}
+	if report.Mode != UpdateIncremental || len(report.RemovedArticles) != 1 {
+		t.Fatalf("report = %+v, want one removed article", report)
+	}
+
+	fresh, err := IngestFromText([]byte(edited), "gdpr", baseURI)
+	if err != nil {
+		t.Fatalf("IngestFromText failed: %v", err)
+	}
+	if missing, extra := tripleDifference(fresh.TripleStore, original.TripleStore), tripleDifference(original.TripleStore, fresh.TripleStore); len(missing) > 0 || len(extra) > 0 {
+		t.Errorf("edited graph differs from full ingest: %d missing, %d extra", len(missing), len(extra))
+	}
+	if stats.Articles != fresh.Stats.Articles || stats.TotalTriples != fresh.Stats.TotalTriples {
+		t.Errorf("stats = %+v, want %+v", stats, fresh.Stats)
+	}
+
+	defsEdited := strings.Replace(edited, "‘personal data’ means any information", "‘personal data’ means all information", 1)
+	defsDoc, _ := parseSource([]byte(defsEdited), "")
+	before := original.TripleStore.Count()
+	report, _, err = ApplyEdit(original.TripleStore, newDoc, defsDoc, original.RegID, baseURI, stats, UpdateOptions{})
+	if err != nil {
+		t.Fatalf("ApplyEdit failed: %v", err)
+	}
+	if report.Mode != UpdateFull || original.TripleStore.Count() != before {
+		t.Errorf("mode = %s, triples %d -> %d; want full fallback with the store untouched", report.Mode, before, original.TripleStore.Count())
+	}
+}
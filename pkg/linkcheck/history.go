@@ -0,0 +1,147 @@
+package linkcheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DefaultHistoryTTL is how long a valid link's last result is trusted before
+// an incremental run checks it again.
+const DefaultHistoryTTL = 24 * time.Hour
+
+// History is the persisted outcome of earlier link checks, keyed by URI. It
+// lets an incremental run re-check only links that are stale or were broken,
+// and compare the new results with the previous ones.
+type History struct {
+	UpdatedAt time.Time              `json:"updated_at"`
+	Links     map[string]*LinkResult `json:"links"`
+}
+
+// LinkDiff lists the links whose state changed since the previous run.
+type LinkDiff struct {
+	// NewlyBroken were valid in the previous run and are broken now.
+	NewlyBroken []*LinkResult `json:"newly_broken"`
+
+	// NewlyFixed were broken in the previous run and are valid now.
+	NewlyFixed []*LinkResult `json:"newly_fixed"`
+
+	// StillBroken counts links broken in both runs.
+	StillBroken int `json:"still_broken"`
+}
+
+// NewHistory creates an empty history.
+func NewHistory() *History {
+	return &History{Links: make(map[string]*LinkResult)}
+}
+
+// LoadHistory reads a history file. A missing file is an empty history.
+func LoadHistory(historyPath string) (*History, error) {
+	data, err := os.ReadFile(historyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewHistory(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read link history %s: %w", historyPath, err)
+	}
+
+	history := NewHistory()
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, fmt.Errorf("failed to parse link history %s: %w", historyPath, err)
+	}
+	if history.Links == nil {
+		history.Links = make(map[string]*LinkResult)
+	}
+	return history, nil
+}
+
+// Save writes the history to historyPath, creating its directory.
+func (history *History) Save(historyPath string) error {
+	if err := os.MkdirAll(filepath.Dir(historyPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create link history directory: %w", err)
+	}
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal link history: %w", err)
+	}
+	if err := os.WriteFile(historyPath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write link history %s: %w", historyPath, err)
+	}
+	return nil
+}
+
+// Due splits links into those that need checking — never checked, broken
+// last time, or last checked more than ttl before now — and the previous
+// results of the rest, which can be reused as they are.
+func (history *History) Due(links []LinkInput, ttl time.Duration, now time.Time) ([]LinkInput, []*LinkResult) {
+	due := make([]LinkInput, 0, len(links))
+	reused := make([]*LinkResult, 0)
+	for _, link := range links {
+		previous, found := history.Links[link.URI]
+		if !found || !previous.IsSuccess() || now.Sub(previous.CheckedAt) > ttl {
+			due = append(due, link)
+			continue
+		}
+		reusedResult := *previous
+		if link.SourceContext != "" {
+			reusedResult.SourceContext = link.SourceContext
+		}
+		reused = append(reused, &reusedResult)
+	}
+	return due, reused
+}
+
+// Diff compares the checked results of a report with the history. Links
+// without a previous result, and skipped links, are not compared.
+func (history *History) Diff(report *ValidationReport) *LinkDiff {
+	diff := &LinkDiff{
+		NewlyBroken: make([]*LinkResult, 0),
+		NewlyFixed:  make([]*LinkResult, 0),
+	}
+	for _, result := range report.Results {
+		previous, found := history.Links[result.URI]
+		if !found || result.Status == StatusSkipped || previous.Status == StatusSkipped {
+			continue
+		}
+		switch {
+		case previous.IsSuccess() && !result.IsSuccess():
+			diff.NewlyBroken = append(diff.NewlyBroken, result)
+		case !previous.IsSuccess() && result.IsSuccess():
+			diff.NewlyFixed = append(diff.NewlyFixed, result)
+		case !previous.IsSuccess() && !result.IsSuccess():
+			diff.StillBroken++
+		}
+	}
+	return diff
+}
+
+// Record stores the results of a report. Skipped links keep their previous
+// result, so an interrupted run does not forget what was already known.
+func (history *History) Record(report *ValidationReport) {
+	for _, result := range report.Results {
+		if result.Status == StatusSkipped {
+			continue
+		}
+		history.Links[result.URI] = result
+	}
+	history.UpdatedAt = time.Now()
+}
+
+// AddReused adds results carried over from the history to the report and
+// counts them in ReusedLinks.
+func (validationReport *ValidationReport) AddReused(results []*LinkResult) {
+	for _, result := range results {
+		validationReport.AddResult(result)
+		validationReport.ReusedLinks++
+	}
+	sort.Slice(validationReport.Results, func(i, j int) bool {
+		return validationReport.Results[i].URI < validationReport.Results[j].URI
+	})
+	sort.Slice(validationReport.BrokenLinks, func(i, j int) bool {
+		return validationReport.BrokenLinks[i].URI < validationReport.BrokenLinks[j].URI
+	})
+}
@@ -32,6 +32,7 @@ func NewRateLimitedHTTPClient(underlying HTTPClient, requestInterval time.Durati
 }
 
 // Do executes an HTTP request, waiting for the rate limiter before sending.
+// The wait ends early if the request's context is done.
 func (rateLimitedClient *RateLimitedHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	rateLimitedClient.mu.Lock()
 
@@ -41,7 +42,13 @@ func (rateLimitedClient *RateLimitedHTTPClient) Do(req *http.Request) (*http.Res
 		if elapsed < rateLimitedClient.requestInterval {
 			waitTime := rateLimitedClient.requestInterval - elapsed
 			rateLimitedClient.mu.Unlock()
-			time.Sleep(waitTime)
+			timer := time.NewTimer(waitTime)
+			select {
+			case <-timer.C:
+			case <-req.Context().Done():
+				timer.Stop()
+				return nil, req.Context().Err()
+			}
 			rateLimitedClient.mu.Lock()
 		}
 	}
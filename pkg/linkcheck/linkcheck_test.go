@@ -377,6 +377,37 @@ func TestBatchValidator_Caching(t *testing.T) {
 	}
 }
 
+func TestBatchValidator_HTTPCacheRevalidation(t *testing.T) {
+	var notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := DefaultBatchConfig()
+	config.DefaultRateLimit = 10 * time.Millisecond
+	config.HTTPCacheDir = t.TempDir()
+	uri := server.URL + "/eli/reg/2016/679"
+
+	// Separate validators share nothing but the on-disk cache, as
+	// successive validate runs do.
+	NewBatchValidator(config).ValidateURIStrings([]string{uri})
+	report := NewBatchValidator(config).ValidateURIStrings([]string{uri})
+
+	if report.ValidLinks != 1 {
+		t.Errorf("ValidLinks = %d, want 1 from the revalidated entry", report.ValidLinks)
+	}
+	if atomic.LoadInt32(&notModified) != 1 {
+		t.Errorf("not-modified responses = %d, want 1", notModified)
+	}
+}
+
 func TestBatchValidator_ProgressCallback(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -415,6 +446,39 @@ func TestBatchValidator_ProgressCallback(t *testing.T) {
 	}
 }
 
+func TestBatchValidator_ResultCallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := DefaultBatchConfig()
+	config.DefaultRateLimit = 10 * time.Millisecond
+
+	validator := NewBatchValidator(config)
+
+	seen := make(map[string]bool)
+	validator.SetResultCallback(func(result *LinkResult) {
+		seen[result.URI] = true
+	})
+
+	uris := []string{
+		server.URL + "/1",
+		server.URL + "/2",
+	}
+
+	report := validator.ValidateURIStrings(uris)
+
+	if len(seen) != 2 || report.TotalLinks != 2 {
+		t.Errorf("callback saw %d results, report has %d; want 2 and 2", len(seen), report.TotalLinks)
+	}
+	for _, uri := range uris {
+		if !seen[uri] {
+			t.Errorf("callback did not receive %s", uri)
+		}
+	}
+}
+
 func TestBatchValidator_SkipDomain(t *testing.T) {
 	requestCount := int32(0)
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -487,6 +551,9 @@ func TestBatchValidator_Cancellation(t *testing.T) {
 	if report.TotalLinks > 3 {
 		t.Errorf("TotalLinks = %d, want <= 3", report.TotalLinks)
 	}
+	if !report.Interrupted {
+		t.Error("Interrupted = false, want true")
+	}
 }
 
 func TestBatchValidator_DomainRateLimiting(t *testing.T) {
@@ -641,3 +708,104 @@ func TestDomainRateLimiter_GetClient(t *testing.T) {
 		t.Error("Expected client for unknown domain")
 	}
 }
+
+// --- History tests ---
+
+func TestHistory_Due(t *testing.T) {
+	now := time.Now()
+	history := NewHistory()
+	history.Links["https://a.example/fresh"] = &LinkResult{URI: "https://a.example/fresh", Status: StatusValid, CheckedAt: now.Add(-time.Hour)}
+	history.Links["https://a.example/stale"] = &LinkResult{URI: "https://a.example/stale", Status: StatusValid, CheckedAt: now.Add(-48 * time.Hour)}
+	history.Links["https://a.example/broken"] = &LinkResult{URI: "https://a.example/broken", Status: StatusInvalid, CheckedAt: now.Add(-time.Hour)}
+
+	due, reused := history.Due([]LinkInput{
+		{URI: "https://a.example/fresh", SourceContext: "Article 3"},
+		{URI: "https://a.example/stale"},
+		{URI: "https://a.example/broken"},
+		{URI: "https://a.example/new"},
+	}, DefaultHistoryTTL, now)
+
+	if len(due) != 3 {
+		t.Errorf("due = %+v, want stale, broken, and new links", due)
+	}
+	if len(reused) != 1 || reused[0].URI != "https://a.example/fresh" || reused[0].SourceContext != "Article 3" {
+		t.Errorf("reused = %+v, want the fresh link with its current context", reused)
+	}
+}
+
+func TestHistory_DiffAndRecord(t *testing.T) {
+	history := NewHistory()
+	history.Links["https://a.example/was-valid"] = &LinkResult{URI: "https://a.example/was-valid", Status: StatusValid}
+	history.Links["https://a.example/was-broken"] = &LinkResult{URI: "https://a.example/was-broken", Status: StatusInvalid, StatusCode: 404}
+	history.Links["https://a.example/still-broken"] = &LinkResult{URI: "https://a.example/still-broken", Status: StatusTimeout}
+	history.Links["https://a.example/interrupted"] = &LinkResult{URI: "https://a.example/interrupted", Status: StatusValid}
+
+	report := NewValidationReport()
+	report.AddResult(&LinkResult{URI: "https://a.example/was-valid", Status: StatusInvalid, StatusCode: 410})
+	report.AddResult(&LinkResult{URI: "https://a.example/was-broken", Status: StatusValid})
+	report.AddResult(&LinkResult{URI: "https://a.example/still-broken", Status: StatusError})
+	report.AddResult(&LinkResult{URI: "https://a.example/interrupted", Status: StatusSkipped})
+	report.AddResult(&LinkResult{URI: "https://a.example/new", Status: StatusInvalid})
+
+	diff := history.Diff(report)
+	if len(diff.NewlyBroken) != 1 || diff.NewlyBroken[0].URI != "https://a.example/was-valid" {
+		t.Errorf("newly broken = %+v", diff.NewlyBroken)
+	}
+	if len(diff.NewlyFixed) != 1 || diff.NewlyFixed[0].URI != "https://a.example/was-broken" {
+		t.Errorf("newly fixed = %+v", diff.NewlyFixed)
+	}
+	if diff.StillBroken != 1 {
+		t.Errorf("still broken = %d, want 1", diff.StillBroken)
+	}
+
+	history.Record(report)
+	if history.Links["https://a.example/interrupted"].Status != StatusValid {
+		t.Error("a skipped result replaced the previous one")
+	}
+	if history.Links["https://a.example/new"] == nil {
+		t.Error("new link was not recorded")
+	}
+}
+
+func TestHistory_SaveLoad(t *testing.T) {
+	historyPath := t.TempDir() + "/lib/link-history.json"
+
+	history, err := LoadHistory(historyPath)
+	if err != nil || len(history.Links) != 0 {
+		t.Fatalf("LoadHistory(missing) = %+v, %v, want an empty history", history, err)
+	}
+
+	report := NewValidationReport()
+	report.AddResult(&LinkResult{URI: "https://a.example/doc", Status: StatusValid, CheckedAt: time.Now()})
+	history.Record(report)
+	if err := history.Save(historyPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadHistory(historyPath)
+	if err != nil {
+		t.Fatalf("LoadHistory failed: %v", err)
+	}
+	if result := loaded.Links["https://a.example/doc"]; result == nil || result.Status != StatusValid {
+		t.Errorf("loaded links = %+v", loaded.Links)
+	}
+}
+
+func TestValidationReport_DiffOutput(t *testing.T) {
+	report := NewValidationReport()
+	report.AddReused([]*LinkResult{{URI: "https://a.example/reused", Status: StatusValid}})
+	report.Diff = &LinkDiff{
+		NewlyBroken: []*LinkResult{{URI: "https://a.example/gone", Status: StatusInvalid, StatusCode: 404}},
+		NewlyFixed:  []*LinkResult{{URI: "https://a.example/back", Status: StatusValid}},
+	}
+
+	text := report.String()
+	for _, want := range []string{"Reused:        1", "1 newly broken, 1 newly fixed", "+ broken: https://a.example/gone (HTTP 404)", "- fixed:  https://a.example/back"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("String() missing %q:\n%s", want, text)
+		}
+	}
+	if markdown := report.ToMarkdown(); !strings.Contains(markdown, "## Changes Since Last Run") {
+		t.Errorf("ToMarkdown() missing changes section:\n%s", markdown)
+	}
+}
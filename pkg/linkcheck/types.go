@@ -82,6 +82,11 @@ type BatchConfig struct {
 
 	// FollowRedirects determines whether to follow HTTP redirects.
 	FollowRedirects bool `json:"follow_redirects"`
+
+	// HTTPCacheDir is the persistent HTTP cache directory (see pkg/httpcache).
+	// Links checked in earlier runs are revalidated with conditional
+	// requests. Empty disables the persistent cache.
+	HTTPCacheDir string `json:"http_cache_dir,omitempty"`
 }
 
 // DefaultBatchConfig returns a BatchConfig with sensible defaults.
@@ -123,6 +128,9 @@ func (batchConfig *BatchConfig) GetDomainConfig(domain string) *DomainConfig {
 // ProgressCallback is called to report validation progress.
 type ProgressCallback func(progress *ValidationProgress)
 
+// ResultCallback is called with each link result as it completes.
+type ResultCallback func(result *LinkResult)
+
 // ValidationProgress reports the current state of batch validation.
 type ValidationProgress struct {
 	TotalLinks     int       `json:"total_links"`
@@ -165,6 +173,18 @@ type ValidationReport struct {
 
 	// Broken links (convenience accessor)
 	BrokenLinks []*LinkResult `json:"broken_links"`
+
+	// Interrupted is set when validation was cancelled before every link
+	// was checked.
+	Interrupted bool `json:"interrupted,omitempty"`
+
+	// ReusedLinks counts results carried over from the link history
+	// instead of being checked again.
+	ReusedLinks int `json:"reused_links,omitempty"`
+
+	// Diff compares the checked links with the previous run, when a
+	// history was used.
+	Diff *LinkDiff `json:"diff,omitempty"`
 }
 
 // DomainStats holds statistics for a specific domain.
@@ -279,7 +299,13 @@ func (validationReport *ValidationReport) ToMarkdown() string {
 	markdownBuilder.WriteString(fmt.Sprintf("- **Error Links**: %d\n", validationReport.ErrorLinks))
 	markdownBuilder.WriteString(fmt.Sprintf("- **Skipped Links**: %d\n", validationReport.SkippedLinks))
 	markdownBuilder.WriteString(fmt.Sprintf("- **Success Rate**: %.1f%%\n", validationReport.SuccessRate()))
+	if validationReport.ReusedLinks > 0 {
+		markdownBuilder.WriteString(fmt.Sprintf("- **Reused From History**: %d\n", validationReport.ReusedLinks))
+	}
 	markdownBuilder.WriteString(fmt.Sprintf("- **Duration**: %dms\n\n", validationReport.DurationMs))
+	if validationReport.Interrupted {
+		markdownBuilder.WriteString("> Validation was interrupted; not every link was checked.\n\n")
+	}
 
 	// Domain breakdown
 	if len(validationReport.DomainStats) > 0 {
@@ -303,6 +329,23 @@ func (validationReport *ValidationReport) ToMarkdown() string {
 		markdownBuilder.WriteString("\n")
 	}
 
+	// Changes since the previous run
+	if diff := validationReport.Diff; diff != nil {
+		markdownBuilder.WriteString("## Changes Since Last Run\n\n")
+		markdownBuilder.WriteString(fmt.Sprintf("- **Newly Broken**: %d\n", len(diff.NewlyBroken)))
+		markdownBuilder.WriteString(fmt.Sprintf("- **Newly Fixed**: %d\n", len(diff.NewlyFixed)))
+		markdownBuilder.WriteString(fmt.Sprintf("- **Still Broken**: %d\n\n", diff.StillBroken))
+		for _, linkResult := range diff.NewlyBroken {
+			markdownBuilder.WriteString(fmt.Sprintf("- broken: %s (%s)\n", linkResult.URI, linkErrorString(linkResult)))
+		}
+		for _, linkResult := range diff.NewlyFixed {
+			markdownBuilder.WriteString(fmt.Sprintf("- fixed: %s\n", linkResult.URI))
+		}
+		if len(diff.NewlyBroken)+len(diff.NewlyFixed) > 0 {
+			markdownBuilder.WriteString("\n")
+		}
+	}
+
 	// Broken links
 	if len(validationReport.BrokenLinks) > 0 {
 		markdownBuilder.WriteString("## Broken Links\n\n")
@@ -340,7 +383,13 @@ func (validationReport *ValidationReport) String() string {
 	summaryBuilder.WriteString(fmt.Sprintf("Error:         %d\n", validationReport.ErrorLinks))
 	summaryBuilder.WriteString(fmt.Sprintf("Skipped:       %d\n", validationReport.SkippedLinks))
 	summaryBuilder.WriteString(fmt.Sprintf("Success rate:  %.1f%%\n", validationReport.SuccessRate()))
+	if validationReport.ReusedLinks > 0 {
+		summaryBuilder.WriteString(fmt.Sprintf("Reused:        %d (from link history)\n", validationReport.ReusedLinks))
+	}
 	summaryBuilder.WriteString(fmt.Sprintf("Duration:      %dms\n", validationReport.DurationMs))
+	if validationReport.Interrupted {
+		summaryBuilder.WriteString("Interrupted:   not every link was checked\n")
+	}
 
 	if len(validationReport.BrokenLinks) > 0 {
 		summaryBuilder.WriteString(fmt.Sprintf("\nBroken links (%d):\n", len(validationReport.BrokenLinks)))
@@ -353,9 +402,31 @@ func (validationReport *ValidationReport) String() string {
 		}
 	}
 
+	if diff := validationReport.Diff; diff != nil {
+		summaryBuilder.WriteString(fmt.Sprintf("\nSince last run: %d newly broken, %d newly fixed, %d still broken\n",
+			len(diff.NewlyBroken), len(diff.NewlyFixed), diff.StillBroken))
+		for _, linkResult := range diff.NewlyBroken {
+			summaryBuilder.WriteString(fmt.Sprintf("  + broken: %s (%s)\n", linkResult.URI, linkErrorString(linkResult)))
+		}
+		for _, linkResult := range diff.NewlyFixed {
+			summaryBuilder.WriteString(fmt.Sprintf("  - fixed:  %s\n", linkResult.URI))
+		}
+	}
+
 	return summaryBuilder.String()
 }
 
+// linkErrorString describes why a link is broken.
+func linkErrorString(linkResult *LinkResult) string {
+	if linkResult.Error == "" && linkResult.StatusCode > 0 {
+		return fmt.Sprintf("HTTP %d", linkResult.StatusCode)
+	}
+	if linkResult.Error == "" {
+		return string(linkResult.Status)
+	}
+	return linkResult.Error
+}
+
 // ExtractDomain extracts the domain from a URI.
 func ExtractDomain(uri string) string {
 	parsedURL, err := url.Parse(uri)
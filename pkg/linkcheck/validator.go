@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"sync"
 	"time"
+
+	"github.com/coolbeans/regula/pkg/httpcache"
 )
 
 // BatchValidator validates multiple URIs with per-domain rate limiting.
@@ -15,6 +17,7 @@ type BatchValidator struct {
 	domainLimiters  *DomainRateLimiter
 	httpClient      HTTPClient
 	progressCb      ProgressCallback
+	resultCb        ResultCallback
 	mu              sync.Mutex
 }
 
@@ -37,6 +40,11 @@ func NewBatchValidator(config *BatchConfig) *BatchValidator {
 			return nil
 		},
 	}
+	if config.HTTPCacheDir != "" {
+		if cache, err := httpcache.Open(config.HTTPCacheDir); err == nil {
+			baseClient = cache.Client(baseClient)
+		}
+	}
 
 	return &BatchValidator{
 		config:         config,
@@ -53,6 +61,14 @@ func (batchValidator *BatchValidator) SetProgressCallback(callback ProgressCallb
 	batchValidator.mu.Unlock()
 }
 
+// SetResultCallback sets a callback function to receive each link result
+// as soon as it is checked. Results arrive in completion order.
+func (batchValidator *BatchValidator) SetResultCallback(callback ResultCallback) {
+	batchValidator.mu.Lock()
+	batchValidator.resultCb = callback
+	batchValidator.mu.Unlock()
+}
+
 // ValidateLinks validates multiple links and returns a comprehensive report.
 func (batchValidator *BatchValidator) ValidateLinks(links []LinkInput) *ValidationReport {
 	return batchValidator.ValidateLinksWithContext(context.Background(), links)
@@ -144,10 +160,17 @@ func (batchValidator *BatchValidator) ValidateLinksWithContext(ctx context.Conte
 	}()
 
 	// Collect results
+	batchValidator.mu.Lock()
+	resultCb := batchValidator.resultCb
+	batchValidator.mu.Unlock()
 	for result := range resultChan {
 		report.AddResult(result)
+		if resultCb != nil {
+			resultCb(result)
+		}
 	}
 
+	report.Interrupted = ctx.Err() != nil
 	report.Finalize()
 	return report
 }
@@ -201,6 +224,13 @@ func (batchValidator *BatchValidator) validateSingleLink(ctx context.Context, li
 
 		lastResult = batchValidator.doValidation(ctx, link, domain, domainConfig)
 
+		// A cancelled check says nothing about the link, so don't cache it
+		if ctx.Err() != nil {
+			lastResult.Status = StatusError
+			lastResult.Error = "cancelled"
+			return lastResult
+		}
+
 		// Don't retry on success or definitive failures
 		if lastResult.Status == StatusValid ||
 			lastResult.Status == StatusRedirect ||
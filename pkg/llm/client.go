@@ -0,0 +1,140 @@
+// Package llm provides a minimal client for OpenAI-compatible chat
+// completion endpoints, used by the optional LLM extraction fallback.
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the default time allowed for one completion request.
+const DefaultTimeout = 60 * time.Second
+
+// DefaultAPIKeyEnv is the environment variable the CLI reads the API key from.
+const DefaultAPIKeyEnv = "REGULA_LLM_API_KEY"
+
+// HTTPClient is an interface matching the Do method of *http.Client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Config holds configuration for a Client.
+type Config struct {
+	// Endpoint is the chat completions URL, e.g.
+	// "https://api.openai.com/v1/chat/completions" or
+	// "http://localhost:11434/v1/chat/completions".
+	Endpoint string
+
+	// Model is the model name sent with each request.
+	Model string
+
+	// APIKey is sent as a bearer token when set.
+	APIKey string
+
+	// Timeout bounds each request. Default: 60 seconds.
+	Timeout time.Duration
+
+	// HTTPClient is the underlying HTTP client. If nil, http.DefaultClient
+	// is used.
+	HTTPClient HTTPClient
+}
+
+// Client sends prompts to a chat completions endpoint.
+type Client struct {
+	config     Config
+	httpClient HTTPClient
+}
+
+// NewClient creates a client. Returns an error if the endpoint or model
+// is missing.
+func NewClient(config Config) (*Client, error) {
+	if strings.TrimSpace(config.Endpoint) == "" {
+		return nil, fmt.Errorf("llm endpoint is required")
+	}
+	if strings.TrimSpace(config.Model) == "" {
+		return nil, fmt.Errorf("llm model is required")
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{config: config, httpClient: httpClient}, nil
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Complete sends prompt as a single user message at temperature 0 and
+// returns the content of the first choice.
+func (client *Client) Complete(ctx context.Context, prompt string) (string, error) {
+	body, err := json.Marshal(chatRequest{
+		Model:    client.config.Model,
+		Messages: []chatMessage{{Role: "user", Content: prompt}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, client.config.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if client.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+client.config.APIKey)
+	}
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("llm request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read llm response: %w", err)
+	}
+	var decoded chatResponse
+	decodeErr := json.Unmarshal(data, &decoded)
+	if resp.StatusCode >= 400 {
+		if decodeErr == nil && decoded.Error != nil && decoded.Error.Message != "" {
+			return "", fmt.Errorf("llm endpoint returned %d: %s", resp.StatusCode, decoded.Error.Message)
+		}
+		return "", fmt.Errorf("llm endpoint returned %d", resp.StatusCode)
+	}
+	if decodeErr != nil {
+		return "", fmt.Errorf("failed to parse llm response: %w", decodeErr)
+	}
+	if len(decoded.Choices) == 0 {
+		return "", fmt.Errorf("llm response has no choices")
+	}
+	return decoded.Choices[0].Message.Content, nil
+}
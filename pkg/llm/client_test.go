@@ -0,0 +1,62 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewClient_Validation(t *testing.T) {
+	if _, err := NewClient(Config{Model: "m"}); err == nil {
+		t.Error("expected an error without an endpoint")
+	}
+	if _, err := NewClient(Config{Endpoint: "http://localhost"}); err == nil {
+		t.Error("expected an error without a model")
+	}
+}
+
+func TestClient_Complete(t *testing.T) {
+	var received chatRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "{\"items\": []}"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Endpoint: server.URL, Model: "test-model", APIKey: "secret"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	reply, err := client.Complete(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if reply != `{"items": []}` {
+		t.Errorf("reply = %q", reply)
+	}
+	if received.Model != "test-model" || len(received.Messages) != 1 || received.Messages[0].Content != "hello" {
+		t.Errorf("request = %+v", received)
+	}
+}
+
+func TestClient_CompleteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": {"message": "invalid api key"}}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(Config{Endpoint: server.URL, Model: "test-model"})
+	_, err := client.Complete(context.Background(), "hello")
+	if err == nil || !strings.Contains(err.Error(), "invalid api key") {
+		t.Errorf("err = %v, want the endpoint's error message", err)
+	}
+}
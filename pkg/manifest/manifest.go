@@ -0,0 +1,405 @@
+// Package manifest reads regula.yaml, the project manifest declaring the
+// documents a project tracks and the pipeline run over them: ingesting the
+// sources into a library, validating them, and exporting the graphs.
+//
+//	name: privacy-compliance
+//	library: .regula
+//	sources:
+//	  - id: eu-gdpr
+//	    path: regulations/gdpr.txt
+//	    jurisdiction: EU
+//	    format: eu
+//	    sha256: 6f1c...e2
+//	validate:
+//	  - source: eu-gdpr
+//	    profile: GDPR
+//	    threshold: 0.85
+//	    report: reports/gdpr-validation.html
+//	exports:
+//	  - source: eu-gdpr
+//	    format: turtle
+//	    output: reports/gdpr.ttl
+//
+// Plan turns the manifest into the regula command lines that run it, so a
+// pipeline behaves exactly like the same commands typed by hand. Relative
+// paths are resolved against the manifest's directory.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the manifest file name looked for in the working directory.
+const FileName = "regula.yaml"
+
+// Manifest is a project's regula.yaml.
+type Manifest struct {
+	// Name identifies the project in run summaries.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+
+	// Library is the library the sources are added to. Empty skips the
+	// ingest stage; validation and export read the source files directly.
+	Library string `yaml:"library,omitempty" json:"library,omitempty"`
+
+	// BaseURI is the base URI of the generated graphs.
+	BaseURI string `yaml:"base_uri,omitempty" json:"base_uri,omitempty"`
+
+	// Ingest holds options applied to every source.
+	Ingest IngestOptions `yaml:"ingest,omitempty" json:"ingest,omitempty"`
+
+	// Sources are the documents of the project.
+	Sources []Source `yaml:"sources" json:"sources"`
+
+	// Validations lists the validations run after ingest.
+	Validations []Validation `yaml:"validate,omitempty" json:"validate,omitempty"`
+
+	// Exports lists the exports written after validation.
+	Exports []Export `yaml:"exports,omitempty" json:"exports,omitempty"`
+
+	// Dir is the directory relative paths are resolved against.
+	Dir string `yaml:"-" json:"-"`
+}
+
+// IngestOptions are the ingest settings shared by all sources.
+type IngestOptions struct {
+	// Format is the parser format hint (eu, us, uk, generic) for sources
+	// that set none.
+	Format string `yaml:"format,omitempty" json:"format,omitempty"`
+
+	// Tags are added to every source's tags.
+	Tags []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+
+	// Args are extra flags passed to every 'library add'.
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+}
+
+// Source is one document of the project.
+type Source struct {
+	// ID is the library document identifier, also used to refer to the
+	// source from validations and exports.
+	ID string `yaml:"id" json:"id"`
+
+	// Path is the document file.
+	Path string `yaml:"path" json:"path"`
+
+	// Name is the human-readable name.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+
+	// Jurisdiction is the jurisdiction code (e.g. EU, US-CA, GB).
+	Jurisdiction string `yaml:"jurisdiction,omitempty" json:"jurisdiction,omitempty"`
+
+	// Format is the parser format hint; Ingest.Format if empty.
+	Format string `yaml:"format,omitempty" json:"format,omitempty"`
+
+	// Tags categorize the document in the library.
+	Tags []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+
+	// SHA256 pins the file's content. A run fails before doing anything
+	// when the file no longer matches.
+	SHA256 string `yaml:"sha256,omitempty" json:"sha256,omitempty"`
+}
+
+// Validation is one 'regula validate' run.
+type Validation struct {
+	// Source is the ID of the source to validate; every source if empty.
+	Source string `yaml:"source,omitempty" json:"source,omitempty"`
+
+	// Profile is the validation profile (GDPR, CCPA, Generic);
+	// auto-detected if empty.
+	Profile string `yaml:"profile,omitempty" json:"profile,omitempty"`
+
+	// Check is what to check (all, references, gates, links).
+	Check string `yaml:"check,omitempty" json:"check,omitempty"`
+
+	// Threshold is the pass/fail threshold (0.0-1.0).
+	Threshold float64 `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+
+	// Report is the file the report is saved to. With several sources
+	// the source ID is added before the extension.
+	Report string `yaml:"report,omitempty" json:"report,omitempty"`
+
+	// Args are extra flags passed to 'regula validate'.
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+}
+
+// Export is one 'regula export' run.
+type Export struct {
+	// Source is the ID of the source to export; every source if empty.
+	Source string `yaml:"source,omitempty" json:"source,omitempty"`
+
+	// Format is the export format (turtle, jsonld, json, ...).
+	Format string `yaml:"format" json:"format"`
+
+	// Output is the file written. With several sources the source ID is
+	// added before the extension.
+	Output string `yaml:"output" json:"output"`
+
+	// Args are extra flags passed to 'regula export'.
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+}
+
+// Load reads and validates the manifest at path.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	manifest.Dir = filepath.Dir(path)
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	return &manifest, nil
+}
+
+// Validate checks that sources have unique IDs and paths and that
+// validations and exports refer to declared sources.
+func (m *Manifest) Validate() error {
+	if len(m.Sources) == 0 {
+		return fmt.Errorf("no sources declared")
+	}
+	seen := make(map[string]bool)
+	for i, source := range m.Sources {
+		if source.ID == "" {
+			return fmt.Errorf("source %d has no id", i+1)
+		}
+		if seen[source.ID] {
+			return fmt.Errorf("duplicate source id %q", source.ID)
+		}
+		seen[source.ID] = true
+		if source.Path == "" {
+			return fmt.Errorf("source %q has no path", source.ID)
+		}
+	}
+	for i, validation := range m.Validations {
+		if validation.Source != "" && !seen[validation.Source] {
+			return fmt.Errorf("validation %d refers to unknown source %q", i+1, validation.Source)
+		}
+		if validation.Threshold < 0 || validation.Threshold > 1 {
+			return fmt.Errorf("validation %d threshold %.2f is outside 0.0-1.0", i+1, validation.Threshold)
+		}
+	}
+	for i, export := range m.Exports {
+		if export.Source != "" && !seen[export.Source] {
+			return fmt.Errorf("export %d refers to unknown source %q", i+1, export.Source)
+		}
+		if export.Format == "" || export.Output == "" {
+			return fmt.Errorf("export %d needs a format and an output", i+1)
+		}
+	}
+	return nil
+}
+
+// VerifySources checks that every source file exists and matches its
+// pinned SHA-256 digest.
+func (m *Manifest) VerifySources() error {
+	for _, source := range m.Sources {
+		path := m.resolve(source.Path)
+		digest, err := fileDigest(path)
+		if err != nil {
+			return fmt.Errorf("source %q: %w", source.ID, err)
+		}
+		if source.SHA256 != "" && !strings.EqualFold(source.SHA256, digest) {
+			return fmt.Errorf("source %q: %s has sha256 %s, manifest pins %s", source.ID, path, digest, source.SHA256)
+		}
+	}
+	return nil
+}
+
+// Step is one command of a planned run.
+type Step struct {
+	// Stage is ingest, validate, or export.
+	Stage string `json:"stage"`
+
+	// Name describes the step, such as "validate eu-gdpr".
+	Name string `json:"name"`
+
+	// Command is the regula command line, without the program name.
+	Command []string `json:"command"`
+
+	// Outputs are the files the step writes, whose directories must exist.
+	Outputs []string `json:"outputs,omitempty"`
+}
+
+// PlanOptions describe the library a run starts from.
+type PlanOptions struct {
+	// LibraryExists is set when the manifest's library has been created.
+	LibraryExists bool
+
+	// LibraryDocuments are the IDs of the documents already in it.
+	LibraryDocuments []string
+
+	// Prune removes library documents the manifest does not declare, so
+	// the library holds exactly the declared sources.
+	Prune bool
+}
+
+// Plan returns the steps that run the manifest: creating the library if
+// it does not exist, pruning undeclared documents if asked to, adding each
+// source with --force, then the validations and the exports in the order
+// declared.
+func (m *Manifest) Plan(options PlanOptions) []Step {
+	var steps []Step
+
+	if m.Library != "" {
+		library := m.resolve(m.Library)
+		if !options.LibraryExists {
+			command := []string{"library", "init", "--path", library}
+			if m.BaseURI != "" {
+				command = append(command, "--base-uri", m.BaseURI)
+			}
+			steps = append(steps, Step{Stage: "ingest", Name: "init library", Command: command})
+		}
+		if options.Prune {
+			for _, documentID := range options.LibraryDocuments {
+				if len(m.sourcesFor(documentID)) == 0 {
+					steps = append(steps, Step{
+						Stage:   "ingest",
+						Name:    "remove " + documentID,
+						Command: []string{"library", "remove", documentID, "--path", library},
+					})
+				}
+			}
+		}
+		for _, source := range m.Sources {
+			command := []string{"library", "add", "--path", library, "--source", m.resolve(source.Path), "--id", source.ID, "--force"}
+			if source.Name != "" {
+				command = append(command, "--name", source.Name)
+			}
+			if source.Jurisdiction != "" {
+				command = append(command, "--jurisdiction", source.Jurisdiction)
+			}
+			if format := firstNonEmpty(source.Format, m.Ingest.Format); format != "" {
+				command = append(command, "--format", format)
+			}
+			if tags := append(append([]string{}, m.Ingest.Tags...), source.Tags...); len(tags) > 0 {
+				command = append(command, "--tags", strings.Join(tags, ","))
+			}
+			command = append(command, m.Ingest.Args...)
+			steps = append(steps, Step{Stage: "ingest", Name: "add " + source.ID, Command: command})
+		}
+	}
+
+	for _, validation := range m.Validations {
+		sources := m.sourcesFor(validation.Source)
+		for _, source := range sources {
+			command := []string{"validate", "--source", m.resolve(source.Path)}
+			if validation.Profile != "" {
+				command = append(command, "--profile", validation.Profile)
+			}
+			if validation.Check != "" {
+				command = append(command, "--check", validation.Check)
+			}
+			if validation.Threshold > 0 {
+				command = append(command, "--threshold", strconv.FormatFloat(validation.Threshold, 'f', -1, 64))
+			}
+			if m.BaseURI != "" {
+				command = append(command, "--base-uri", m.BaseURI)
+			}
+			var outputs []string
+			if validation.Report != "" {
+				report := m.resolve(outputPath(validation.Report, source.ID, len(sources) > 1))
+				command = append(command, "--report", report)
+				outputs = append(outputs, report)
+			}
+			if m.Library != "" {
+				command = append(command, "--record", "--path", m.resolve(m.Library))
+			}
+			command = append(command, validation.Args...)
+			steps = append(steps, Step{Stage: "validate", Name: "validate " + source.ID, Command: command, Outputs: outputs})
+		}
+	}
+
+	for _, export := range m.Exports {
+		sources := m.sourcesFor(export.Source)
+		for _, source := range sources {
+			output := m.resolve(outputPath(export.Output, source.ID, len(sources) > 1))
+			command := []string{"export", "--source", m.resolve(source.Path), "--format", export.Format, "--output", output}
+			command = append(command, export.Args...)
+			steps = append(steps, Step{
+				Stage:   "export",
+				Name:    fmt.Sprintf("export %s (%s)", source.ID, export.Format),
+				Command: command,
+				Outputs: []string{output},
+			})
+		}
+	}
+	return steps
+}
+
+// LibraryPath returns the resolved library path, or "" when the manifest
+// declares no library.
+func (m *Manifest) LibraryPath() string {
+	if m.Library == "" {
+		return ""
+	}
+	return m.resolve(m.Library)
+}
+
+// sourcesFor returns the source with id, or every source when id is empty.
+// It returns nil when no source has id.
+func (m *Manifest) sourcesFor(id string) []Source {
+	if id == "" {
+		return m.Sources
+	}
+	for _, source := range m.Sources {
+		if source.ID == id {
+			return []Source{source}
+		}
+	}
+	return nil
+}
+
+// resolve makes path relative to the manifest's directory.
+func (m *Manifest) resolve(path string) string {
+	if filepath.IsAbs(path) || m.Dir == "" {
+		return path
+	}
+	return filepath.Join(m.Dir, path)
+}
+
+// outputPath adds the source ID before the extension when one declared
+// output is written for several sources.
+func outputPath(path, sourceID string, perSource bool) string {
+	if !perSource {
+		return path
+	}
+	extension := filepath.Ext(path)
+	return strings.TrimSuffix(path, extension) + "-" + sourceID + extension
+}
+
+// fileDigest returns the hex SHA-256 digest of the file at path.
+func fileDigest(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
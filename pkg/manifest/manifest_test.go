@@ -0,0 +1,127 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeManifest(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	return path
+}
+
+func TestPlan(t *testing.T) {
+	path := writeManifest(t, `name: privacy
+library: .regula
+base_uri: https://example.org/regs/
+ingest:
+  format: eu
+  tags: [privacy]
+sources:
+  - id: eu-gdpr
+    path: regulations/gdpr.txt
+    jurisdiction: EU
+  - id: us-ca-ccpa
+    path: regulations/ccpa.txt
+    format: us
+validate:
+  - source: eu-gdpr
+    profile: GDPR
+    threshold: 0.85
+  - report: reports/validation.json
+exports:
+  - format: turtle
+    output: graphs/graph.ttl
+    args: [--eli]
+`)
+	project, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	dir := filepath.Dir(path)
+
+	steps := project.Plan(PlanOptions{})
+	var names []string
+	for _, step := range steps {
+		names = append(names, step.Name)
+	}
+	want := "init library, add eu-gdpr, add us-ca-ccpa, validate eu-gdpr, validate eu-gdpr, validate us-ca-ccpa, export eu-gdpr (turtle), export us-ca-ccpa (turtle)"
+	if got := strings.Join(names, ", "); got != want {
+		t.Fatalf("steps = %s\nwant    %s", got, want)
+	}
+
+	addGDPR := strings.Join(steps[1].Command, " ")
+	for _, want := range []string{"--source " + filepath.Join(dir, "regulations/gdpr.txt"), "--format eu", "--tags privacy", "--force"} {
+		if !strings.Contains(addGDPR, want) {
+			t.Errorf("add command %q lacks %q", addGDPR, want)
+		}
+	}
+	if addCCPA := strings.Join(steps[2].Command, " "); !strings.Contains(addCCPA, "--format us") {
+		t.Errorf("source format did not win over the ingest default: %q", addCCPA)
+	}
+	validateGDPR := strings.Join(steps[3].Command, " ")
+	for _, want := range []string{"--profile GDPR", "--threshold 0.85", "--base-uri https://example.org/regs/", "--record --path " + filepath.Join(dir, ".regula")} {
+		if !strings.Contains(validateGDPR, want) {
+			t.Errorf("validate command %q lacks %q", validateGDPR, want)
+		}
+	}
+	if got := steps[5].Outputs; len(got) != 1 || got[0] != filepath.Join(dir, "reports/validation-us-ca-ccpa.json") {
+		t.Errorf("per-source report outputs = %v", got)
+	}
+	if export := strings.Join(steps[6].Command, " "); !strings.HasSuffix(export, filepath.Join(dir, "graphs/graph-eu-gdpr.ttl")+" --eli") {
+		t.Errorf("export command = %q", export)
+	}
+
+	existing := project.Plan(PlanOptions{LibraryExists: true, LibraryDocuments: []string{"eu-gdpr", "old-doc"}, Prune: true})
+	if existing[0].Name != "remove old-doc" || existing[1].Name != "add eu-gdpr" {
+		t.Errorf("plan for an existing library starts with %q, %q", existing[0].Name, existing[1].Name)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"no sources":         "name: empty\nsources: []\n",
+		"duplicate id":       "sources:\n  - {id: a, path: a.txt}\n  - {id: a, path: b.txt}\n",
+		"missing path":       "sources:\n  - {id: a}\n",
+		"unknown validate":   "sources:\n  - {id: a, path: a.txt}\nvalidate:\n  - source: b\n",
+		"bad threshold":      "sources:\n  - {id: a, path: a.txt}\nvalidate:\n  - threshold: 85\n",
+		"export sans output": "sources:\n  - {id: a, path: a.txt}\nexports:\n  - format: turtle\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Load(writeManifest(t, content)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestVerifySources(t *testing.T) {
+	path := writeManifest(t, `sources:
+  - id: doc
+    path: doc.txt
+    sha256: 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824
+`)
+	project, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := project.VerifySources(); err == nil {
+		t.Error("expected an error for a missing source file")
+	}
+
+	sourcePath := filepath.Join(filepath.Dir(path), "doc.txt")
+	os.WriteFile(sourcePath, []byte("hello"), 0644)
+	if err := project.VerifySources(); err != nil {
+		t.Errorf("VerifySources with the pinned content: %v", err)
+	}
+	os.WriteFile(sourcePath, []byte("hello, world"), 0644)
+	if err := project.VerifySources(); err == nil || !strings.Contains(err.Error(), "manifest pins") {
+		t.Errorf("VerifySources with changed content = %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+// Package ndjson writes newline-delimited JSON: one compact JSON value per
+// line, written as soon as it is encoded, so that large outputs can be piped
+// into jq or log processors without buffering the whole result.
+package ndjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// RecordField is the key EncodeRecord adds to name the kind of a record
+// when one stream mixes several kinds (for example gates and a summary).
+const RecordField = "record"
+
+// flusher is implemented by buffered writers such as bufio.Writer.
+type flusher interface {
+	Flush() error
+}
+
+// Encoder writes one JSON value per line.
+type Encoder struct {
+	writer io.Writer
+	buffer bytes.Buffer
+}
+
+// NewEncoder creates an encoder writing to w. When w is buffered it is
+// flushed after every line.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{writer: w}
+}
+
+// Encode writes value as a single line.
+func (e *Encoder) Encode(value any) error {
+	e.buffer.Reset()
+	encoder := json.NewEncoder(&e.buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode record: %w", err)
+	}
+	return e.writeLine(e.buffer.Bytes())
+}
+
+// EncodeRecord writes value, which must encode as a JSON object, with a
+// leading "record" field set to kind.
+func (e *Encoder) EncodeRecord(kind string, value any) error {
+	e.buffer.Reset()
+	encoder := json.NewEncoder(&e.buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode %s record: %w", kind, err)
+	}
+
+	object := bytes.TrimSpace(e.buffer.Bytes())
+	if len(object) < 2 || object[0] != '{' {
+		return fmt.Errorf("failed to encode %s record: not a JSON object", kind)
+	}
+	kindJSON, err := json.Marshal(kind)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s record: %w", kind, err)
+	}
+
+	line := append([]byte(`{"`+RecordField+`":`), kindJSON...)
+	if len(bytes.TrimSpace(object[1:])) > 1 {
+		line = append(line, ',')
+	}
+	line = append(line, object[1:]...)
+	return e.writeLine(append(line, '\n'))
+}
+
+// writeLine writes one line in a single call.
+func (e *Encoder) writeLine(line []byte) error {
+	if _, err := e.writer.Write(line); err != nil {
+		return fmt.Errorf("failed to write record: %w", err)
+	}
+	if f, ok := e.writer.(flusher); ok {
+		if err := f.Flush(); err != nil {
+			return fmt.Errorf("failed to flush record: %w", err)
+		}
+	}
+	return nil
+}
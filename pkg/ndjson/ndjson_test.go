@@ -0,0 +1,72 @@
+package ndjson
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	var output bytes.Buffer
+	encoder := NewEncoder(&output)
+
+	for _, value := range []map[string]string{{"article": "GDPR:Art17"}, {"article": "<Art 18> & more"}} {
+		if err := encoder.Encode(value); err != nil {
+			t.Fatalf("Encode failed: %v", err)
+		}
+	}
+
+	expected := "{\"article\":\"GDPR:Art17\"}\n{\"article\":\"<Art 18> & more\"}\n"
+	if output.String() != expected {
+		t.Errorf("output = %q, want %q", output.String(), expected)
+	}
+}
+
+func TestEncoder_EncodeRecord(t *testing.T) {
+	var output bytes.Buffer
+	encoder := NewEncoder(&output)
+
+	type gate struct {
+		Gate   string `json:"gate"`
+		Passed bool   `json:"passed"`
+	}
+	if err := encoder.EncodeRecord("gate", gate{Gate: "V0", Passed: true}); err != nil {
+		t.Fatalf("EncodeRecord failed: %v", err)
+	}
+	if err := encoder.EncodeRecord("summary", struct{}{}); err != nil {
+		t.Fatalf("EncodeRecord failed: %v", err)
+	}
+	if err := encoder.EncodeRecord("count", 3); err == nil {
+		t.Error("expected error for a non-object record")
+	}
+
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), output.String())
+	}
+	if lines[0] != `{"record":"gate","gate":"V0","passed":true}` {
+		t.Errorf("line 1 = %s", lines[0])
+	}
+	if lines[1] != `{"record":"summary"}` {
+		t.Errorf("line 2 = %s", lines[1])
+	}
+	for _, line := range lines {
+		if !json.Valid([]byte(line)) {
+			t.Errorf("invalid JSON line: %s", line)
+		}
+	}
+}
+
+func TestEncoder_FlushesBufferedWriter(t *testing.T) {
+	var output bytes.Buffer
+	encoder := NewEncoder(bufio.NewWriter(&output))
+
+	if err := encoder.Encode(map[string]int{"n": 1}); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if output.String() != "{\"n\":1}\n" {
+		t.Errorf("record not flushed: %q", output.String())
+	}
+}
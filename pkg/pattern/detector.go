@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // FormatMatch represents a detected format match with confidence score.
@@ -64,10 +66,7 @@ func (m *FormatMatch) DebugString() string {
 }
 
 func truncatePattern(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
+	return textutil.Truncate(s, maxLen)
 }
 
 // DetectorOptions configures the format detector behavior.
@@ -130,6 +130,12 @@ type ReferencePattern struct {
 	compiled *regexp.Regexp
 }
 
+// Regexp returns the compiled regex for this internal reference pattern.
+// Returns nil if the pattern has not been compiled.
+func (rp *ReferencePattern) Regexp() *regexp.Regexp {
+	return rp.compiled
+}
+
 // ExternalReferencePattern defines a pattern for external references.
 type ExternalReferencePattern struct {
 	Pattern     string         `yaml:"pattern" json:"pattern"`
@@ -141,6 +147,12 @@ type ExternalReferencePattern struct {
 	compiled *regexp.Regexp
 }
 
+// Regexp returns the compiled regex for this external reference pattern.
+// Returns nil if the pattern has not been compiled.
+func (ep *ExternalReferencePattern) Regexp() *regexp.Regexp {
+	return ep.compiled
+}
+
 // CompiledPattern holds all compiled regex patterns for efficient matching.
 type CompiledPattern struct {
 	RequiredIndicators  []*regexp.Regexp
@@ -211,21 +211,54 @@ func (r *DefaultRegistry) LoadDirectory(dir string) error {
 
 // LoadFile loads a single pattern file.
 func (r *DefaultRegistry) LoadFile(path string) error {
+	pattern, err := ParseFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := r.Register(pattern); err != nil {
+		return fmt.Errorf("registering pattern: %w", err)
+	}
+
+	return nil
+}
+
+// ParseFile reads a YAML pattern file without registering it. The returned
+// pattern is validated and compiled.
+func ParseFile(path string) (*FormatPattern, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("reading file: %w", err)
+		return nil, fmt.Errorf("reading file: %w", err)
 	}
 
 	var pattern FormatPattern
 	if err := yaml.Unmarshal(data, &pattern); err != nil {
-		return fmt.Errorf("parsing YAML: %w", err)
+		return nil, fmt.Errorf("parsing YAML: %w", err)
 	}
 
-	if err := r.Register(&pattern); err != nil {
-		return fmt.Errorf("registering pattern: %w", err)
+	if err := pattern.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	if err := pattern.Compile(); err != nil {
+		return nil, fmt.Errorf("compiling pattern %q: %w", pattern.FormatID, err)
 	}
 
-	return nil
+	return &pattern, nil
+}
+
+// Replace registers a pattern, overwriting any existing pattern with the same
+// format ID regardless of version. It is intended for interactive pattern
+// development where edits are made without bumping the version.
+func (r *DefaultRegistry) Replace(pattern *FormatPattern) error {
+	if pattern == nil {
+		return fmt.Errorf("pattern cannot be nil")
+	}
+
+	r.mu.Lock()
+	delete(r.patterns, pattern.FormatID)
+	r.mu.Unlock()
+
+	return r.Register(pattern)
 }
 
 // Reload reloads all patterns from the configured directory.
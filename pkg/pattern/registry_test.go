@@ -321,6 +321,57 @@ structure:
 	}
 }
 
+func TestRegistryReplaceSameVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	patternFile := filepath.Join(tmpDir, "test-format.yaml")
+
+	yamlContent := `
+name: "Test YAML Pattern"
+format_id: "test-yaml"
+version: "1.0.0"
+jurisdiction: "TEST"
+detection:
+  required_indicators:
+    - pattern: "\\bTEST\\b"
+      weight: 10
+structure:
+  hierarchy:
+    - type: "section"
+      pattern: "^Section\\s+(\\d+)"
+      number_group: 1
+`
+
+	if err := os.WriteFile(patternFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	registry := NewRegistry()
+	if err := registry.LoadFile(patternFile); err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+
+	edited, err := ParseFile(patternFile)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	if !edited.IsCompiled() {
+		t.Error("ParseFile() should return a compiled pattern")
+	}
+	edited.Name = "Edited Pattern"
+
+	if err := registry.Register(edited); err == nil {
+		t.Error("Register() should reject same version")
+	}
+	if err := registry.Replace(edited); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+
+	p, _ := registry.Get("test-yaml")
+	if p.Name != "Edited Pattern" {
+		t.Errorf("Name = %q, want %q", p.Name, "Edited Pattern")
+	}
+}
+
 func TestRegistryLoadDirectory(t *testing.T) {
 	tmpDir := t.TempDir()
 
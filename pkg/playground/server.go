@@ -0,0 +1,454 @@
+package playground
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/analysis"
+	"github.com/coolbeans/regula/pkg/query"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/usage"
+)
+
+//go:embed web/index.html
+var indexHTML []byte
+
+// Defaults for graph requests that omit depth or limit.
+const (
+	DefaultGraphDepth = 2
+	DefaultGraphLimit = 300
+	MaxGraphLimit     = 5000
+)
+
+// Server serves the playground web UI and its JSON API:
+//
+//	GET  /               the single-page UI
+//	GET  /api/info       graph label and triple count
+//	GET  /api/templates  the template registry
+//	POST /api/query      run a SPARQL query or a template
+//	GET  /api/graph      relationship subgraph around ?focus= to ?depth=
+//	GET  /api/related    related-provision suggestions for ?provision=
+type Server struct {
+	tripleStore *store.TripleStore
+	executor    *query.Executor
+	label       string
+	mux         *http.ServeMux
+	usage       *usage.Tracker
+
+	// The relationship graph is exported once, on first use.
+	graphOnce sync.Once
+	graph     *store.GraphExport
+
+	// Related-provision suggestions are indexed once, on first use.
+	relatedOnce sync.Once
+	related     *analysis.RelatedAnalyzer
+}
+
+// NewServer creates a playground server over tripleStore. The label names
+// the loaded graph in the UI.
+func NewServer(tripleStore *store.TripleStore, label string) *Server {
+	server := &Server{
+		tripleStore: tripleStore,
+		executor:    query.NewExecutor(tripleStore),
+		label:       label,
+		mux:         http.NewServeMux(),
+	}
+	server.mux.HandleFunc("/", server.handleIndex)
+	server.mux.HandleFunc("/api/info", server.handleInfo)
+	server.mux.HandleFunc("/api/templates", server.handleTemplates)
+	server.mux.HandleFunc("/api/query", server.handleQuery)
+	server.mux.HandleFunc("/api/graph", server.handleGraph)
+	server.mux.HandleFunc("/api/related", server.handleRelated)
+	return server
+}
+
+// SetUsageTracker records the template and provisions behind each query
+// and graph request.
+func (s *Server) SetUsageTracker(tracker *usage.Tracker) {
+	s.usage = tracker
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(indexHTML)
+}
+
+func (s *Server) handleInfo(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"label":   s.label,
+		"triples": s.tripleStore.Count(),
+	})
+}
+
+// TemplateInfo is the JSON form of a PlaygroundTemplate.
+type TemplateInfo struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Category    string          `json:"category"`
+	Query       string          `json:"query"`
+	Parameters  []ParameterInfo `json:"parameters,omitempty"`
+}
+
+// ParameterInfo is the JSON form of a TemplateParameter.
+type ParameterInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+}
+
+// TemplateInfos returns every registered template, sorted by name.
+func TemplateInfos() []TemplateInfo {
+	templates := make([]TemplateInfo, 0, len(templateRegistry))
+	for _, name := range TemplateNames() {
+		template := templateRegistry[name]
+		info := TemplateInfo{
+			Name:        template.Name,
+			Description: template.Description,
+			Category:    template.Category,
+			Query:       template.Query,
+		}
+		for _, parameter := range template.Parameters {
+			info.Parameters = append(info.Parameters, ParameterInfo{
+				Name:        parameter.Name,
+				Description: parameter.Description,
+				Required:    parameter.Required,
+			})
+		}
+		templates = append(templates, info)
+	}
+	return templates
+}
+
+func (s *Server) handleTemplates(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, TemplateInfos())
+}
+
+// QueryRequest is the body of POST /api/query. Either Query or Template is
+// set; Parameters are substituted into the template.
+type QueryRequest struct {
+	Query      string            `json:"query"`
+	Template   string            `json:"template"`
+	Parameters map[string]string `json:"parameters"`
+}
+
+// QueryResponse is the result of POST /api/query. SELECT queries fill
+// Variables and Rows; CONSTRUCT and DESCRIBE queries fill Triples.
+type QueryResponse struct {
+	Type      string              `json:"type"`
+	Query     string              `json:"query"`
+	Variables []string            `json:"variables,omitempty"`
+	Rows      []map[string]string `json:"rows,omitempty"`
+	Triples   []tripleJSON        `json:"triples,omitempty"`
+	Count     int                 `json:"count"`
+	ElapsedMs float64             `json:"elapsed_ms"`
+}
+
+type tripleJSON struct {
+	Subject   string `json:"subject"`
+	Predicate string `json:"predicate"`
+	Object    string `json:"object"`
+}
+
+func (s *Server) handleQuery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("use POST"))
+		return
+	}
+
+	var request QueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	queryStr := request.Query
+	if request.Template != "" {
+		template, exists := Get(request.Template)
+		if !exists {
+			writeError(w, http.StatusNotFound, fmt.Errorf("unknown template: %s", request.Template))
+			return
+		}
+		rendered, err := RenderQuery(template, request.Parameters)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		queryStr = rendered
+	}
+	if strings.TrimSpace(queryStr) == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("query is empty"))
+		return
+	}
+
+	response, err := s.runQuery(r.Context(), queryStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if s.usage != nil {
+		s.usage.Record(request.Template, usage.ProvisionURIs(s.tripleStore, responseValues(response)))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// responseValues lists every value in a query response.
+func responseValues(response *QueryResponse) []string {
+	var values []string
+	for _, row := range response.Rows {
+		for _, value := range row {
+			values = append(values, value)
+		}
+	}
+	for _, triple := range response.Triples {
+		values = append(values, triple.Subject, triple.Object)
+	}
+	return values
+}
+
+// runQuery parses and executes queryStr against the server's store. The
+// executor's own timeout bounds long-running queries.
+func (s *Server) runQuery(ctx context.Context, queryStr string) (*QueryResponse, error) {
+	parsedQuery, err := query.ParseQuery(queryStr)
+	if err != nil {
+		return nil, fmt.Errorf("query parse error: %w", err)
+	}
+
+	startTime := time.Now()
+	response := &QueryResponse{Query: queryStr}
+	switch parsedQuery.Type {
+	case query.ConstructQueryType, query.DescribeQueryType:
+		var result *query.ConstructResult
+		if parsedQuery.Type == query.ConstructQueryType {
+			response.Type = "construct"
+			result, err = s.executor.ExecuteConstructWithContext(ctx, parsedQuery)
+		} else {
+			response.Type = "describe"
+			result, err = s.executor.ExecuteDescribeWithContext(ctx, parsedQuery)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("query error: %w", err)
+		}
+		response.Triples = make([]tripleJSON, len(result.Triples))
+		for i, triple := range result.Triples {
+			response.Triples[i] = tripleJSON{triple.Subject, triple.Predicate, triple.Object}
+		}
+		response.Count = result.Count
+	default:
+		result, err := s.executor.ExecuteWithContext(ctx, parsedQuery)
+		if err != nil {
+			return nil, fmt.Errorf("query error: %w", err)
+		}
+		response.Type = "select"
+		response.Variables = result.Variables
+		response.Rows = result.Bindings
+		response.Count = result.Count
+	}
+	response.ElapsedMs = float64(time.Since(startTime).Microseconds()) / 1000
+	return response, nil
+}
+
+// GraphResponse is the result of GET /api/graph: a relationship subgraph in
+// the ExportGraph JSON shape. Truncated is set when the node limit cut the
+// subgraph short.
+type GraphResponse struct {
+	*store.GraphExport
+	Focus     string `json:"focus,omitempty"`
+	Depth     int    `json:"depth"`
+	Truncated bool   `json:"truncated"`
+}
+
+func (s *Server) handleGraph(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	depth, err := intParam(params.Get("depth"), DefaultGraphDepth)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid depth: %w", err))
+		return
+	}
+	limit, err := intParam(params.Get("limit"), DefaultGraphLimit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit: %w", err))
+		return
+	}
+	if limit <= 0 || limit > MaxGraphLimit {
+		limit = MaxGraphLimit
+	}
+
+	focus := params.Get("focus")
+	graph := s.relationshipGraph()
+	if focus != "" {
+		focusURI := resolveFocus(graph, focus)
+		if focusURI == "" {
+			writeError(w, http.StatusNotFound, fmt.Errorf("no node matches %q", focus))
+			return
+		}
+		focus = focusURI
+		if s.usage != nil {
+			s.usage.Record("", usage.ProvisionURIs(s.tripleStore, []string{focus}))
+		}
+	}
+
+	subgraph, truncated := Subgraph(graph, focus, depth, limit)
+	writeJSON(w, http.StatusOK, GraphResponse{
+		GraphExport: subgraph,
+		Focus:       focus,
+		Depth:       depth,
+		Truncated:   truncated,
+	})
+}
+
+func (s *Server) relationshipGraph() *store.GraphExport {
+	s.graphOnce.Do(func() {
+		s.graph = store.ExportRelationshipSubgraph(s.tripleStore)
+	})
+	return s.graph
+}
+
+// handleRelated serves the provisions suggested as related to ?provision=
+// (a URI or short ID such as Art17) by shared terms, co-citation, and text
+// similarity, in the analysis.RelatedResult JSON shape.
+func (s *Server) handleRelated(w http.ResponseWriter, r *http.Request) {
+	provision := r.URL.Query().Get("provision")
+	if provision == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("provision is required"))
+		return
+	}
+	analyzer := s.relatedAnalyzer()
+	provisionURI := analyzer.Resolve(provision)
+	if provisionURI == "" {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no article matches %q", provision))
+		return
+	}
+	if s.usage != nil {
+		s.usage.Record("", usage.ProvisionURIs(s.tripleStore, []string{provisionURI}))
+	}
+	writeJSON(w, http.StatusOK, analyzer.Suggest(provisionURI))
+}
+
+func (s *Server) relatedAnalyzer() *analysis.RelatedAnalyzer {
+	s.relatedOnce.Do(func() {
+		s.related = analysis.NewRelatedAnalyzer(s.tripleStore, analysis.DefaultRelatedOptions())
+	})
+	return s.related
+}
+
+// resolveFocus finds the node for focus: an exact node ID, a compact URI
+// (GDPR:Art17), or a short ID (Art17) ending a node ID.
+func resolveFocus(graph *store.GraphExport, focus string) string {
+	var suffixMatch string
+	for _, node := range graph.Nodes {
+		if node.ID == focus || query.CompactURI(node.ID) == focus {
+			return node.ID
+		}
+		if suffixMatch == "" && (strings.HasSuffix(node.ID, "/"+focus) || strings.HasSuffix(node.ID, ":"+focus)) {
+			suffixMatch = node.ID
+		}
+	}
+	return suffixMatch
+}
+
+// Subgraph returns the part of graph within depth hops of focus, following
+// edges in both directions, with at most limit nodes. With no focus it
+// starts from the most connected nodes. The second result reports whether
+// the limit was reached before the traversal finished.
+func Subgraph(graph *store.GraphExport, focus string, depth, limit int) (*store.GraphExport, bool) {
+	adjacency := make(map[string][]string)
+	for _, edge := range graph.Edges {
+		adjacency[edge.Source] = append(adjacency[edge.Source], edge.Target)
+		adjacency[edge.Target] = append(adjacency[edge.Target], edge.Source)
+	}
+
+	var frontier []string
+	if focus != "" {
+		frontier = []string{focus}
+	} else {
+		frontier = make([]string, 0, len(adjacency))
+		for id := range adjacency {
+			frontier = append(frontier, id)
+		}
+		sort.Slice(frontier, func(i, j int) bool {
+			if len(adjacency[frontier[i]]) != len(adjacency[frontier[j]]) {
+				return len(adjacency[frontier[i]]) > len(adjacency[frontier[j]])
+			}
+			return frontier[i] < frontier[j]
+		})
+		depth = 0
+	}
+
+	included := make(map[string]bool)
+	truncated := false
+	for level := 0; level <= depth && len(frontier) > 0 && !truncated; level++ {
+		var next []string
+		for _, id := range frontier {
+			if included[id] {
+				continue
+			}
+			if len(included) >= limit {
+				truncated = true
+				break
+			}
+			included[id] = true
+			next = append(next, adjacency[id]...)
+		}
+		frontier = next
+	}
+
+	subgraph := &store.GraphExport{
+		Nodes: make([]store.GraphNode, 0, len(included)),
+		Edges: make([]store.GraphEdge, 0),
+		Stats: store.GraphStats{
+			NodesByType: make(map[string]int),
+			EdgesByType: make(map[string]int),
+		},
+	}
+	for _, node := range graph.Nodes {
+		if included[node.ID] {
+			subgraph.Nodes = append(subgraph.Nodes, node)
+			subgraph.Stats.NodesByType[node.Type]++
+		}
+	}
+	sort.Slice(subgraph.Nodes, func(i, j int) bool { return subgraph.Nodes[i].ID < subgraph.Nodes[j].ID })
+	for _, edge := range graph.Edges {
+		if included[edge.Source] && included[edge.Target] {
+			subgraph.Edges = append(subgraph.Edges, edge)
+			subgraph.Stats.EdgesByType[edge.Type]++
+		}
+	}
+	subgraph.Stats.TotalNodes = len(subgraph.Nodes)
+	subgraph.Stats.TotalEdges = len(subgraph.Edges)
+	return subgraph, truncated
+}
+
+func intParam(value string, defaultValue int) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
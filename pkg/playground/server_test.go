@@ -0,0 +1,207 @@
+package playground
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/analysis"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/usage"
+)
+
+// newServerTestStore builds a chain Art1 -> Art2 -> Art3 -> Art4 of
+// references under one regulation.
+func newServerTestStore() *store.TripleStore {
+	ts := store.NewTripleStore()
+	base := "https://regula.dev/regulations/TEST:"
+	for _, article := range []string{"Art1", "Art2", "Art3", "Art4"} {
+		ts.Add(base+article, store.RDFType, store.ClassArticle)
+		ts.Add(base+article, store.PropTitle, "Title "+article)
+	}
+	ts.Add(base+"Art1", store.PropReferences, base+"Art2")
+	ts.Add(base+"Art2", store.PropReferences, base+"Art3")
+	ts.Add(base+"Art3", store.PropReferences, base+"Art4")
+	return ts
+}
+
+func serve(t *testing.T, server *Server, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestServer_Index(t *testing.T) {
+	server := NewServer(newServerTestStore(), "test")
+
+	response := serve(t, server, http.MethodGet, "/", "")
+	if response.Code != http.StatusOK || !strings.Contains(response.Body.String(), "Regula Playground") {
+		t.Errorf("GET / = %d %q", response.Code, response.Body.String()[:50])
+	}
+	if response := serve(t, server, http.MethodGet, "/missing", ""); response.Code != http.StatusNotFound {
+		t.Errorf("GET /missing = %d, want 404", response.Code)
+	}
+}
+
+func TestServer_Templates(t *testing.T) {
+	server := NewServer(newServerTestStore(), "test")
+
+	response := serve(t, server, http.MethodGet, "/api/templates", "")
+	var templates []TemplateInfo
+	if err := json.Unmarshal(response.Body.Bytes(), &templates); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(templates) != len(TemplateNames()) {
+		t.Fatalf("got %d templates, want %d", len(templates), len(TemplateNames()))
+	}
+	for _, template := range templates {
+		if template.Name == "cross-ref-density" && len(template.Parameters) == 0 {
+			t.Error("cross-ref-density is missing its title parameter")
+		}
+	}
+}
+
+func TestServer_Query(t *testing.T) {
+	server := NewServer(newServerTestStore(), "test")
+
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		wantType   string
+		wantCount  int
+	}{
+		{"select", http.MethodPost, `{"query": "SELECT ?a WHERE { ?a rdf:type reg:Article }"}`, http.StatusOK, "select", 4},
+		{"construct", http.MethodPost, `{"query": "CONSTRUCT { ?a reg:title ?t } WHERE { ?a reg:title ?t }"}`, http.StatusOK, "construct", 4},
+		{"template", http.MethodPost, `{"template": "cross-ref-density"}`, http.StatusOK, "select", 3},
+		{"unknown template", http.MethodPost, `{"template": "nope"}`, http.StatusNotFound, "", 0},
+		{"parse error", http.MethodPost, `{"query": "SELECT WHERE"}`, http.StatusBadRequest, "", 0},
+		{"empty", http.MethodPost, `{}`, http.StatusBadRequest, "", 0},
+		{"get", http.MethodGet, "", http.StatusMethodNotAllowed, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := serve(t, server, tt.method, "/api/query", tt.body)
+			if response.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", response.Code, tt.wantStatus, response.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				var body map[string]string
+				if err := json.Unmarshal(response.Body.Bytes(), &body); err != nil || body["error"] == "" {
+					t.Errorf("expected an error body, got %s", response.Body.String())
+				}
+				return
+			}
+			var result QueryResponse
+			if err := json.Unmarshal(response.Body.Bytes(), &result); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if result.Type != tt.wantType || result.Count != tt.wantCount {
+				t.Errorf("got type %s count %d, want %s %d", result.Type, result.Count, tt.wantType, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestServer_Graph(t *testing.T) {
+	server := NewServer(newServerTestStore(), "test")
+
+	decode := func(response *httptest.ResponseRecorder) GraphResponse {
+		t.Helper()
+		if response.Code != http.StatusOK {
+			t.Fatalf("status = %d: %s", response.Code, response.Body.String())
+		}
+		var graph GraphResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &graph); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		return graph
+	}
+
+	graph := decode(serve(t, server, http.MethodGet, "/api/graph?focus=TEST:Art2&depth=1", ""))
+	if graph.Focus != "https://regula.dev/regulations/TEST:Art2" {
+		t.Errorf("focus = %q", graph.Focus)
+	}
+	if graph.Stats.TotalNodes != 3 || graph.Stats.TotalEdges != 2 || graph.Truncated {
+		t.Errorf("depth 1 around Art2: %d nodes, %d edges, truncated %v", graph.Stats.TotalNodes, graph.Stats.TotalEdges, graph.Truncated)
+	}
+
+	graph = decode(serve(t, server, http.MethodGet, "/api/graph?focus=Art1&depth=3", ""))
+	if graph.Stats.TotalNodes != 4 {
+		t.Errorf("depth 3 around Art1: %d nodes, want 4", graph.Stats.TotalNodes)
+	}
+
+	graph = decode(serve(t, server, http.MethodGet, "/api/graph?limit=2", ""))
+	if graph.Stats.TotalNodes != 2 || !graph.Truncated {
+		t.Errorf("limit 2: %d nodes, truncated %v", graph.Stats.TotalNodes, graph.Truncated)
+	}
+
+	if response := serve(t, server, http.MethodGet, "/api/graph?focus=Art9", ""); response.Code != http.StatusNotFound {
+		t.Errorf("unknown focus status = %d, want 404", response.Code)
+	}
+	if response := serve(t, server, http.MethodGet, "/api/graph?depth=x", ""); response.Code != http.StatusBadRequest {
+		t.Errorf("bad depth status = %d, want 400", response.Code)
+	}
+}
+
+func TestServer_UsageTracking(t *testing.T) {
+	server := NewServer(newServerTestStore(), "test")
+	tracker, err := usage.Open(filepath.Join(t.TempDir(), usage.FileName))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	server.SetUsageTracker(tracker)
+
+	serve(t, server, http.MethodPost, "/api/query", `{"template": "cross-ref-density"}`)
+	serve(t, server, http.MethodGet, "/api/graph?focus=Art2", "")
+	serve(t, server, http.MethodPost, "/api/query", `{"query": "SELECT WHERE"}`)
+
+	stats := tracker.Snapshot()
+	if stats.Requests != 2 {
+		t.Errorf("Requests = %d, want 2 (failed queries are not counted)", stats.Requests)
+	}
+	if stats.Templates["cross-ref-density"] != 1 {
+		t.Errorf("templates = %v", stats.Templates)
+	}
+	art2 := stats.Provisions["https://regula.dev/regulations/TEST:Art2"]
+	if art2 == nil || art2.Count != 2 {
+		t.Errorf("Art2 usage = %+v, want count 2", art2)
+	}
+}
+
+func TestServer_Related(t *testing.T) {
+	ts := newServerTestStore()
+	base := "https://regula.dev/regulations/TEST:"
+	ts.Add(base+"Art1", store.PropText, "The controller shall erase personal data without undue delay.")
+	ts.Add(base+"Art4", store.PropText, "The controller shall erase personal data on request.")
+	server := NewServer(ts, "test")
+
+	response := serve(t, server, http.MethodGet, "/api/related?provision=Art1", "")
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", response.Code, response.Body.String())
+	}
+	var result analysis.RelatedResult
+	if err := json.Unmarshal(response.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.TargetURI != base+"Art1" {
+		t.Errorf("target = %q", result.TargetURI)
+	}
+	if len(result.Suggestions) == 0 || result.Suggestions[0].URI != base+"Art4" {
+		t.Errorf("expected Art4 as the top suggestion, got %+v", result.Suggestions)
+	}
+
+	if response := serve(t, server, http.MethodGet, "/api/related?provision=Art9", ""); response.Code != http.StatusNotFound {
+		t.Errorf("unknown provision status = %d, want 404", response.Code)
+	}
+	if response := serve(t, server, http.MethodGet, "/api/related", ""); response.Code != http.StatusBadRequest {
+		t.Errorf("missing provision status = %d, want 400", response.Code)
+	}
+}
@@ -1,5 +1,6 @@
 // Package playground provides pre-built SPARQL analysis query templates
-// for exploring USC and other ingested legislation data in the library.
+// for exploring USC and other ingested legislation data in the library, and
+// a local web server that exposes them with a query editor and graph view.
 package playground
 
 import (
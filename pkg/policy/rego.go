@@ -0,0 +1,253 @@
+// Package policy generates policy-as-code skeletons from the obligations of
+// a knowledge graph. Each obligation becomes an Open Policy Agent (Rego)
+// rule carrying its conditions, actor, and citation as metadata, as a
+// starting point for machine-enforceable compliance policies.
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// maxConditionLength bounds a condition clause quoted in rule metadata.
+const maxConditionLength = 160
+
+// conditionPattern finds clauses that make an obligation conditional, such
+// as "where processing is based on consent,". Only clauses that end at
+// punctuation are taken, so a clause cut off by the context window is
+// skipped.
+var conditionPattern = regexp.MustCompile(`(?i)\b((?:where|if|unless|provided\s+that|in\s+the\s+(?:case|event)\s+of|when)\s+[^.;:,()]{8,})[.;:,]`)
+
+// identifierPattern matches runs of characters not allowed in Rego
+// identifiers and package path segments.
+var identifierPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Rule is an obligation of the graph described for a policy rule.
+type Rule struct {
+	Name           string   `json:"name"`
+	URI            string   `json:"uri"`
+	Citation       string   `json:"citation"`
+	ArticleTitle   string   `json:"article_title,omitempty"`
+	ObligationType string   `json:"obligation_type"`
+	Prohibition    bool     `json:"prohibition,omitempty"`
+	Actors         []string `json:"actors,omitempty"`
+	Conditions     []string `json:"conditions,omitempty"`
+	Deadlines      []string `json:"deadlines,omitempty"`
+	Recurrence     string   `json:"recurrence,omitempty"`
+	Text           string   `json:"text,omitempty"`
+	article        int
+}
+
+// CollectRules describes every obligation node in the store, ordered by
+// article. Rule names are unique Rego identifiers such as
+// "gdpr_art33_breach_notification".
+func CollectRules(tripleStore *store.TripleStore) []*Rule {
+	var rules []*Rule
+	for _, triple := range tripleStore.Find("", store.RDFType, store.ClassObligation) {
+		uri := triple.Subject
+		articleURI := tripleStore.GetOne(uri, store.PropPartOf)
+		label := tripleStore.GetOne(tripleStore.GetOne(uri, store.PropBelongsTo), store.RDFSLabel)
+		number := tripleStore.GetOne(articleURI, store.PropNumber)
+		obligationType := tripleStore.GetOne(uri, store.PropObligationType)
+		texts := objects(tripleStore, uri, store.PropText)
+		contexts := objects(tripleStore, uri, store.PropContext)
+
+		rule := &Rule{
+			URI:            uri,
+			Citation:       strings.TrimSpace(fmt.Sprintf("%s Art. %s", label, number)),
+			ArticleTitle:   tripleStore.GetOne(articleURI, store.PropTitle),
+			ObligationType: obligationType,
+			Prohibition:    tripleStore.GetOne(uri, store.PropIsProhibition) == "true",
+			Actors:         objects(tripleStore, uri, store.PropDutyBearer),
+			Conditions:     findConditions(contexts),
+			Deadlines:      extract.FindDeadlines(strings.Join(append(texts, contexts...), "\n")),
+			Recurrence:     tripleStore.GetOne(uri, store.PropRecurrence),
+			Text:           strings.Join(strings.Fields(strings.Join(texts, "; ")), " "),
+			article:        textutil.ArticleOrder(number),
+		}
+		rule.Name = ruleName(label, number, obligationType)
+		rules = append(rules, rule)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].article != rules[j].article {
+			return rules[i].article < rules[j].article
+		}
+		return rules[i].Name < rules[j].Name
+	})
+
+	seen := make(map[string]int)
+	for _, rule := range rules {
+		seen[rule.Name]++
+		if count := seen[rule.Name]; count > 1 {
+			rule.Name = fmt.Sprintf("%s_%d", rule.Name, count)
+		}
+	}
+	return rules
+}
+
+// objects returns the sorted objects of a subject's predicate. An obligation
+// node gathers the text, context, and duty bearer of every annotation that
+// produced it.
+func objects(tripleStore *store.TripleStore, subject, predicate string) []string {
+	var values []string
+	for _, triple := range tripleStore.Find(subject, predicate, "") {
+		values = append(values, triple.Object)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// findConditions returns the distinct condition clauses of the given
+// contexts. The ellipsis marking a truncated context is dropped so it does
+// not end a clause.
+func findConditions(contexts []string) []string {
+	var conditions []string
+	seen := make(map[string]bool)
+	for _, context := range contexts {
+		context = strings.TrimSuffix(context, "...")
+		for _, match := range conditionPattern.FindAllStringSubmatch(context, -1) {
+			condition := textutil.Truncate(strings.Join(strings.Fields(match[1]), " "), maxConditionLength)
+			key := strings.ToLower(condition)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions
+}
+
+// ruleName builds a Rego identifier from a document label, article number,
+// and obligation type.
+func ruleName(label, number, obligationType string) string {
+	kind := strings.TrimSuffix(textutil.Humanize(obligationType), " obligation")
+	if kind == "obligation" || kind == "" {
+		kind = "duty"
+	}
+	return identifier(fmt.Sprintf("%s art%s %s", label, number, kind))
+}
+
+// identifier lowercases s and joins its alphanumeric runs with underscores,
+// prefixing an underscore when it would start with a digit.
+func identifier(s string) string {
+	name := strings.Trim(identifierPattern.ReplaceAllString(strings.ToLower(s), "_"), "_")
+	if name == "" {
+		return "_"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// PackageName returns a Rego package path for a document label, such as
+// "regula.gdpr".
+func PackageName(label string) string {
+	if segment := identifier(label); segment != "_" {
+		return "regula." + segment
+	}
+	return "regula"
+}
+
+// FormatRego renders rules as a Rego module. Each obligation becomes a
+// "violation" rule that fires when a duty bearer has not met it (or, for
+// prohibitions, has done what is prohibited), with the rule's details in an
+// OPA METADATA annotation. Conditions are left as comments for the policy
+// author to translate into input checks.
+//
+// The generated rules expect an input document such as:
+//
+//	{"actor": "Controller", "satisfied": {"gdpr_art33_breach_notification": true}}
+func FormatRego(packageName, source string, rules []*Rule) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Policy skeleton generated by regula")
+	if source != "" {
+		sb.WriteString(" from " + source)
+	}
+	sb.WriteString(".\n")
+	sb.WriteString("# Each rule reports a violation of one extracted obligation. Review the\n")
+	sb.WriteString("# conditions noted in each rule and replace them with input checks\n")
+	sb.WriteString("# before enforcing.\n")
+	sb.WriteString("package " + packageName + "\n\n")
+	sb.WriteString("import rego.v1\n")
+
+	for _, rule := range rules {
+		sb.WriteString("\n# METADATA\n")
+		title := rule.Citation
+		if rule.ArticleTitle != "" {
+			title += " - " + rule.ArticleTitle
+		}
+		sb.WriteString("# title: " + yamlString(title) + "\n")
+		sb.WriteString("# description: " + yamlString(textutil.Humanize(rule.ObligationType)) + "\n")
+		sb.WriteString("# custom:\n")
+		sb.WriteString("#   rule: " + rule.Name + "\n")
+		sb.WriteString("#   citation: " + yamlString(rule.Citation) + "\n")
+		sb.WriteString("#   obligation_type: " + rule.ObligationType + "\n")
+		writeYAMLList(&sb, "actors", rule.Actors)
+		sb.WriteString(fmt.Sprintf("#   prohibition: %t\n", rule.Prohibition))
+		writeYAMLList(&sb, "conditions", rule.Conditions)
+		writeYAMLList(&sb, "deadlines", rule.Deadlines)
+		if rule.Recurrence != "" {
+			sb.WriteString("#   recurrence: " + yamlString(rule.Recurrence) + "\n")
+		}
+		if rule.Text != "" {
+			sb.WriteString("#   text: " + yamlString(rule.Text) + "\n")
+		}
+		sb.WriteString("#   source: " + yamlString(rule.URI) + "\n")
+
+		sb.WriteString("violation contains msg if {\n")
+		switch len(rule.Actors) {
+		case 0:
+		case 1:
+			sb.WriteString("\tinput.actor == " + strconv.Quote(rule.Actors[0]) + "\n")
+		default:
+			quoted := make([]string, len(rule.Actors))
+			for i, actor := range rule.Actors {
+				quoted[i] = strconv.Quote(actor)
+			}
+			sb.WriteString("\tinput.actor in {" + strings.Join(quoted, ", ") + "}\n")
+		}
+		for _, condition := range rule.Conditions {
+			sb.WriteString("\t# TODO: " + condition + "\n")
+		}
+		if rule.Prohibition {
+			sb.WriteString("\tinput.performed[" + strconv.Quote(rule.Name) + "]\n")
+		} else {
+			sb.WriteString("\tnot input.satisfied[" + strconv.Quote(rule.Name) + "]\n")
+		}
+		verb := "not met"
+		if rule.Prohibition {
+			verb = "prohibited"
+		}
+		message := fmt.Sprintf("%s: %s %s", rule.Citation, strings.ToLower(textutil.Humanize(rule.ObligationType)), verb)
+		sb.WriteString("\tmsg := " + strconv.Quote(message) + "\n")
+		sb.WriteString("}\n")
+	}
+	return sb.String()
+}
+
+// writeYAMLList writes a metadata list, or nothing when it is empty.
+func writeYAMLList(sb *strings.Builder, key string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	sb.WriteString("#   " + key + ":\n")
+	for _, value := range values {
+		sb.WriteString("#     - " + yamlString(value) + "\n")
+	}
+}
+
+// yamlString quotes a metadata value as a YAML double-quoted scalar.
+func yamlString(s string) string {
+	return strconv.Quote(s)
+}
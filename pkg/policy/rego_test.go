@@ -0,0 +1,99 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func regoTestStore() *store.TripleStore {
+	ts := store.NewTripleStore()
+	ts.Add("reg:TEST", store.RDFSLabel, "TEST")
+
+	ts.Add("reg:TEST:Art33", store.PropNumber, "33")
+	ts.Add("reg:TEST:Art33", store.PropTitle, "Notification of a breach")
+	breach := "reg:TEST:Obligation:33:BreachNotificationObligation"
+	ts.Add(breach, store.RDFType, store.ClassObligation)
+	ts.Add(breach, store.PropObligationType, "BreachNotificationObligation")
+	ts.Add(breach, store.PropText, "shall notify")
+	ts.Add(breach, store.PropContext, "In the case of a personal data breach, the controller shall notify without undue delay...")
+	ts.Add(breach, store.PropContext, "...the processor shall notify the controller where feasible, and in any event if the breach is ongo...")
+	ts.Add(breach, store.PropDutyBearer, "Controller")
+	ts.Add(breach, store.PropDutyBearer, "Processor")
+	ts.Add(breach, store.PropPartOf, "reg:TEST:Art33")
+	ts.Add(breach, store.PropBelongsTo, "reg:TEST")
+
+	ts.Add("reg:TEST:Art9", store.PropNumber, "9")
+	ts.Add("reg:TEST:Art9", store.PropTitle, "Special categories")
+	prohibition := "reg:TEST:Obligation:9:Obligation"
+	ts.Add(prohibition, store.RDFType, store.ClassObligation)
+	ts.Add(prohibition, store.PropObligationType, "Obligation")
+	ts.Add(prohibition, store.PropText, "shall be prohibited")
+	ts.Add(prohibition, store.PropIsProhibition, "true")
+	ts.Add(prohibition, store.PropPartOf, "reg:TEST:Art9")
+	ts.Add(prohibition, store.PropBelongsTo, "reg:TEST")
+	return ts
+}
+
+func TestCollectRules(t *testing.T) {
+	rules := CollectRules(regoTestStore())
+	if len(rules) != 2 {
+		t.Fatalf("rules = %d, want 2", len(rules))
+	}
+
+	prohibition, breach := rules[0], rules[1]
+	if prohibition.Name != "test_art9_duty" || !prohibition.Prohibition {
+		t.Errorf("first rule = %s (prohibition %t), want test_art9_duty ordered by article", prohibition.Name, prohibition.Prohibition)
+	}
+	if breach.Name != "test_art33_breach_notification" || breach.Citation != "TEST Art. 33" {
+		t.Errorf("breach rule = %s cited as %q", breach.Name, breach.Citation)
+	}
+	if strings.Join(breach.Actors, ",") != "Controller,Processor" {
+		t.Errorf("Actors = %v", breach.Actors)
+	}
+	// The clause cut off by the context window is skipped.
+	if strings.Join(breach.Conditions, "|") != "where feasible|In the case of a personal data breach" {
+		t.Errorf("Conditions = %q", breach.Conditions)
+	}
+	if len(breach.Deadlines) != 1 || breach.Deadlines[0] != "without undue delay" {
+		t.Errorf("Deadlines = %v", breach.Deadlines)
+	}
+}
+
+func TestFormatRego(t *testing.T) {
+	output := FormatRego(PackageName("TEST"), "test.txt", CollectRules(regoTestStore()))
+
+	for _, want := range []string{
+		"package regula.test\n",
+		"import rego.v1\n",
+		"# METADATA\n# title: \"TEST Art. 33 - Notification of a breach\"\n",
+		"#   rule: test_art33_breach_notification\n",
+		"#   conditions:\n#     - \"where feasible\"\n",
+		"\tinput.actor in {\"Controller\", \"Processor\"}\n\t# TODO: where feasible\n",
+		"\tnot input.satisfied[\"test_art33_breach_notification\"]\n",
+		"\tinput.performed[\"test_art9_duty\"]\n",
+		"msg := \"TEST Art. 9: obligation prohibited\"",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Count(output, "violation contains msg if {") != 2 {
+		t.Errorf("expected 2 rules:\n%s", output)
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	tests := map[string]string{
+		"GDPR":       "regula.gdpr",
+		"UK-DPA2018": "regula.uk_dpa2018",
+		"2018 Act":   "regula._2018_act",
+		"":           "regula",
+	}
+	for label, want := range tests {
+		if got := PackageName(label); got != want {
+			t.Errorf("PackageName(%q) = %q, want %q", label, got, want)
+		}
+	}
+}
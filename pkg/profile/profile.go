@@ -0,0 +1,212 @@
+// Package profile turns the telemetry spans of an ingest run into a
+// profile: a tree of pipeline stages with their time and allocations, plus
+// per-pattern statistics from the reference extractor. A profile is written
+// as JSON, as a table, or as folded stacks for flamegraph tools
+// (flamegraph.pl, speedscope, inferno).
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/telemetry"
+)
+
+// Stage is one timed pipeline stage and the stages nested in it.
+type Stage struct {
+	Name       string         `json:"name"`
+	DurationMs float64        `json:"duration_ms"`
+	SelfMs     float64        `json:"self_ms"`
+	AllocBytes uint64         `json:"alloc_bytes,omitempty"`
+	Mallocs    uint64         `json:"mallocs,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	Children   []*Stage       `json:"children,omitempty"`
+
+	start time.Time
+}
+
+// Profile is the profile of one run.
+type Profile struct {
+	Source   string                `json:"source,omitempty"`
+	TotalMs  float64               `json:"total_ms"`
+	Stages   []*Stage              `json:"stages"`
+	Patterns []extract.PatternStat `json:"patterns,omitempty"`
+}
+
+// Recorder is a telemetry.Exporter that keeps every span for Build. It
+// forwards spans to next, if set, so profiling can run alongside another
+// exporter such as a trace file.
+type Recorder struct {
+	mu    sync.Mutex
+	spans []telemetry.SpanData
+	next  telemetry.Exporter
+}
+
+// NewRecorder returns a recorder forwarding to next, which may be nil.
+func NewRecorder(next telemetry.Exporter) *Recorder {
+	return &Recorder{next: next}
+}
+
+// Next returns the exporter spans are forwarded to.
+func (r *Recorder) Next() telemetry.Exporter {
+	return r.next
+}
+
+// ExportSpan implements telemetry.Exporter.
+func (r *Recorder) ExportSpan(span telemetry.SpanData) {
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+	if r.next != nil {
+		r.next.ExportSpan(span)
+	}
+}
+
+// Build assembles the recorded spans into a stage tree. Spans whose parent
+// was not recorded become top-level stages.
+func (r *Recorder) Build() *Profile {
+	r.mu.Lock()
+	spans := append([]telemetry.SpanData(nil), r.spans...)
+	r.mu.Unlock()
+
+	stages := make(map[string]*Stage, len(spans))
+	for _, span := range spans {
+		stages[span.SpanID] = &Stage{
+			Name:       span.Name,
+			DurationMs: span.DurationMs,
+			SelfMs:     span.DurationMs,
+			AllocBytes: span.AllocBytes,
+			Mallocs:    span.Mallocs,
+			Attributes: span.Attributes,
+			Error:      span.Error,
+			start:      span.Start,
+		}
+	}
+
+	profile := &Profile{Stages: []*Stage{}}
+	for _, span := range spans {
+		stage := stages[span.SpanID]
+		if parent, ok := stages[span.ParentID]; ok {
+			parent.Children = append(parent.Children, stage)
+			parent.SelfMs -= stage.DurationMs
+			continue
+		}
+		profile.Stages = append(profile.Stages, stage)
+		profile.TotalMs += stage.DurationMs
+	}
+	for _, stage := range stages {
+		sortByStart(stage.Children)
+		if stage.SelfMs < 0 {
+			stage.SelfMs = 0
+		}
+	}
+	sortByStart(profile.Stages)
+	return profile
+}
+
+func sortByStart(stages []*Stage) {
+	sort.SliceStable(stages, func(i, j int) bool {
+		return stages[i].start.Before(stages[j].start)
+	})
+}
+
+// WriteJSON writes the profile as indented JSON.
+func (p *Profile) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(p)
+}
+
+// WriteFolded writes the profile in the folded stack format read by
+// flamegraph tools: one "parent;child self-time" line per stage, with time
+// in microseconds. Reference patterns appear under a synthetic
+// "reference_patterns" root, since they run inside several stages.
+func (p *Profile) WriteFolded(w io.Writer) error {
+	var lines []string
+	var walk func(prefix string, stages []*Stage)
+	walk = func(prefix string, stages []*Stage) {
+		for _, stage := range stages {
+			path := stage.Name
+			if prefix != "" {
+				path = prefix + ";" + stage.Name
+			}
+			if micros := int64(stage.SelfMs * 1000); micros > 0 {
+				lines = append(lines, fmt.Sprintf("%s %d", path, micros))
+			}
+			walk(path, stage.Children)
+		}
+	}
+	walk("", p.Stages)
+	for _, pattern := range p.Patterns {
+		if micros := pattern.Duration.Microseconds(); micros > 0 {
+			lines = append(lines, fmt.Sprintf("reference_patterns;%s %d", pattern.Name, micros))
+		}
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTable writes the stage tree and the slowest patterns as text.
+// patternLimit caps the patterns listed; zero lists them all.
+func (p *Profile) WriteTable(w io.Writer, patternLimit int) {
+	fmt.Fprintf(w, "%-36s %10s %10s %12s\n", "Stage", "Time", "Self", "Allocated")
+	fmt.Fprintln(w, strings.Repeat("-", 71))
+	var walk func(depth int, stages []*Stage)
+	walk = func(depth int, stages []*Stage) {
+		for _, stage := range stages {
+			name := strings.Repeat("  ", depth) + stage.Name
+			fmt.Fprintf(w, "%-36s %10s %10s %12s\n", name, formatMs(stage.DurationMs), formatMs(stage.SelfMs), formatBytes(stage.AllocBytes))
+			walk(depth+1, stage.Children)
+		}
+	}
+	walk(0, p.Stages)
+
+	if len(p.Patterns) == 0 {
+		return
+	}
+	patterns := p.Patterns
+	if patternLimit > 0 && len(patterns) > patternLimit {
+		patterns = patterns[:patternLimit]
+	}
+	fmt.Fprintf(w, "\n%-36s %10s %10s %10s\n", "Reference pattern", "Time", "Calls", "Matches")
+	fmt.Fprintln(w, strings.Repeat("-", 69))
+	for _, pattern := range patterns {
+		fmt.Fprintf(w, "%-36s %10s %10d %10d\n", pattern.Name, formatMs(float64(pattern.Duration.Microseconds())/1000), pattern.Calls, pattern.Matches)
+	}
+	if len(patterns) < len(p.Patterns) {
+		fmt.Fprintf(w, "(%d more patterns)\n", len(p.Patterns)-len(patterns))
+	}
+}
+
+func formatMs(ms float64) string {
+	if ms >= 1000 {
+		return fmt.Sprintf("%.2fs", ms/1000)
+	}
+	return fmt.Sprintf("%.1fms", ms)
+}
+
+func formatBytes(n uint64) string {
+	switch {
+	case n == 0:
+		return "-"
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GiB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
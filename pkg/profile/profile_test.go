@@ -0,0 +1,84 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/telemetry"
+)
+
+func recordedProfile() *Profile {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	recorder := NewRecorder(nil)
+	// Spans end children first, as they do in a real run.
+	recorder.ExportSpan(telemetry.SpanData{SpanID: "b", ParentID: "a", Name: "build", Start: start.Add(40 * time.Millisecond), DurationMs: 50, AllocBytes: 2048})
+	recorder.ExportSpan(telemetry.SpanData{SpanID: "p", ParentID: "a", Name: "parse", Start: start, DurationMs: 30})
+	recorder.ExportSpan(telemetry.SpanData{SpanID: "a", Name: "ingest", Start: start, DurationMs: 100})
+
+	profile := recorder.Build()
+	profile.Patterns = []extract.PatternStat{{Name: "article", Calls: 4, Matches: 9, Duration: 3 * time.Millisecond}}
+	return profile
+}
+
+func TestRecorder_BuildsStageTree(t *testing.T) {
+	profile := recordedProfile()
+
+	if len(profile.Stages) != 1 || profile.TotalMs != 100 {
+		t.Fatalf("stages = %+v, total %v", profile.Stages, profile.TotalMs)
+	}
+	root := profile.Stages[0]
+	if root.Name != "ingest" || root.SelfMs != 20 {
+		t.Errorf("root = %s self %v, want ingest self 20", root.Name, root.SelfMs)
+	}
+	if len(root.Children) != 2 || root.Children[0].Name != "parse" || root.Children[1].Name != "build" {
+		t.Errorf("children out of start order: %+v", root.Children)
+	}
+}
+
+func TestRecorder_ForwardsSpans(t *testing.T) {
+	var buf bytes.Buffer
+	recorder := NewRecorder(telemetry.NewJSONExporter(&buf))
+	recorder.ExportSpan(telemetry.SpanData{SpanID: "a", Name: "query"})
+	if !strings.Contains(buf.String(), `"name":"query"`) {
+		t.Errorf("span not forwarded: %q", buf.String())
+	}
+}
+
+func TestProfile_WriteFolded(t *testing.T) {
+	var buf bytes.Buffer
+	if err := recordedProfile().WriteFolded(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "ingest 20000\ningest;parse 30000\ningest;build 50000\nreference_patterns;article 3000\n"
+	if buf.String() != want {
+		t.Errorf("folded =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestProfile_WriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := recordedProfile().WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded Profile
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded.Stages[0].Children[1].AllocBytes != 2048 || decoded.Patterns[0].Matches != 9 {
+		t.Errorf("decoded = %+v", decoded)
+	}
+}
+
+func TestProfile_WriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	recordedProfile().WriteTable(&buf, 0)
+	for _, want := range []string{"ingest", "  parse", "2.0 KiB", "Reference pattern", "article"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("table missing %q:\n%s", want, buf.String())
+		}
+	}
+}
@@ -6,13 +6,17 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/coolbeans/regula/pkg/ndjson"
 	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/telemetry"
 )
 
 // Executor executes SPARQL queries against a triple store.
@@ -20,6 +24,7 @@ type Executor struct {
 	store          *store.TripleStore
 	planner        *QueryPlanner
 	enablePlanning bool
+	explain        bool
 	timeout        time.Duration
 }
 
@@ -33,6 +38,14 @@ func WithPlanning(enabled bool) ExecutorOption {
 	}
 }
 
+// WithExplain records a QueryPlan of how each query's WHERE clause is
+// evaluated and attaches it to the result.
+func WithExplain(enabled bool) ExecutorOption {
+	return func(e *Executor) {
+		e.explain = enabled
+	}
+}
+
 // WithTimeout sets the query execution timeout.
 func WithTimeout(d time.Duration) ExecutorOption {
 	return func(e *Executor) {
@@ -56,6 +69,12 @@ func NewExecutor(tripleStore *store.TripleStore, opts ...ExecutorOption) *Execut
 	return e
 }
 
+// Explains reports whether the executor records query plans; see
+// WithExplain.
+func (e *Executor) Explains() bool {
+	return e.explain
+}
+
 // RefreshStats updates the query planner with current store statistics.
 func (e *Executor) RefreshStats() {
 	e.planner = NewQueryPlanner(e.store.Stats())
@@ -67,6 +86,7 @@ type QueryResult struct {
 	Bindings  []map[string]string // Variable bindings for each result row
 	Count     int                 // Number of result rows
 	Metrics   QueryMetrics        // Execution metrics
+	Plan      *QueryPlan          // Evaluation plan, set by executors created WithExplain
 }
 
 // ConstructResult represents the result of a CONSTRUCT query execution.
@@ -74,6 +94,7 @@ type ConstructResult struct {
 	Triples []ConstructedTriple // Constructed triples
 	Count   int                 // Number of triples
 	Metrics QueryMetrics        // Execution metrics
+	Plan    *QueryPlan          // Evaluation plan, set by executors created WithExplain
 }
 
 // ConstructedTriple represents a triple produced by a CONSTRUCT query.
@@ -110,17 +131,23 @@ func (e *Executor) ExecuteWithContext(ctx context.Context, query *Query) (*Query
 		defer cancel()
 	}
 
+	ctx, span := telemetry.Start(ctx, "query", slog.String("query_type", string(query.Type)))
+
 	if query.Type == SelectQueryType {
 		result, err := e.executeSelect(ctx, query.Select, &metrics)
 		if err != nil {
+			endQuerySpan(span, &metrics, 0, err)
 			return nil, err
 		}
 		metrics.TotalTime = time.Since(startTime)
 		result.Metrics = metrics
+		endQuerySpan(span, &metrics, result.Count, nil)
 		return result, nil
 	}
 
-	return nil, fmt.Errorf("unsupported query type for Execute: %s (use ExecuteConstruct for CONSTRUCT queries)", query.Type)
+	err := fmt.Errorf("unsupported query type for Execute: %s (use ExecuteConstruct for CONSTRUCT queries)", query.Type)
+	endQuerySpan(span, &metrics, 0, err)
+	return nil, err
 }
 
 // ExecuteConstruct executes a parsed CONSTRUCT query.
@@ -140,19 +167,40 @@ func (e *Executor) ExecuteConstructWithContext(ctx context.Context, query *Query
 		defer cancel()
 	}
 
+	ctx, span := telemetry.Start(ctx, "query", slog.String("query_type", string(query.Type)))
+
 	if query.Type != ConstructQueryType {
-		return nil, fmt.Errorf("expected CONSTRUCT query, got: %s", query.Type)
+		err := fmt.Errorf("expected CONSTRUCT query, got: %s", query.Type)
+		endQuerySpan(span, &metrics, 0, err)
+		return nil, err
 	}
 
 	result, err := e.executeConstruct(ctx, query.Construct, &metrics)
 	if err != nil {
+		endQuerySpan(span, &metrics, 0, err)
 		return nil, err
 	}
 	metrics.TotalTime = time.Since(startTime)
 	result.Metrics = metrics
+	endQuerySpan(span, &metrics, result.Count, nil)
 	return result, nil
 }
 
+// endQuerySpan ends a query span with the planning and execution times
+// and the result size, or the error that stopped the query.
+func endQuerySpan(span *telemetry.Span, metrics *QueryMetrics, size int, err error) {
+	if err != nil {
+		span.RecordError(err)
+	} else {
+		span.SetAttributes(
+			slog.Int("results", size),
+			slog.Float64("plan_ms", float64(metrics.PlanTime.Microseconds())/1000),
+			slog.Float64("execute_ms", float64(metrics.ExecuteTime.Microseconds())/1000),
+		)
+	}
+	span.End()
+}
+
 // ExecuteString parses and executes a SPARQL SELECT query string.
 func (e *Executor) ExecuteString(queryStr string) (*QueryResult, error) {
 	return e.ExecuteStringWithContext(context.Background(), queryStr)
@@ -193,16 +241,22 @@ func (e *Executor) ExecuteDescribeWithContext(ctx context.Context, query *Query)
 		defer cancel()
 	}
 
+	ctx, span := telemetry.Start(ctx, "query", slog.String("query_type", string(query.Type)))
+
 	if query.Type != DescribeQueryType {
-		return nil, fmt.Errorf("expected DESCRIBE query, got: %s", query.Type)
+		err := fmt.Errorf("expected DESCRIBE query, got: %s", query.Type)
+		endQuerySpan(span, &metrics, 0, err)
+		return nil, err
 	}
 
 	result, err := e.executeDescribe(ctx, query.Describe, &metrics)
 	if err != nil {
+		endQuerySpan(span, &metrics, 0, err)
 		return nil, err
 	}
 	metrics.TotalTime = time.Since(startTime)
 	result.Metrics = metrics
+	endQuerySpan(span, &metrics, result.Count, nil)
 	return result, nil
 }
 
@@ -266,37 +320,23 @@ func (e *Executor) executeSelect(ctx context.Context, query *SelectQuery, metric
 
 	executeStart := time.Now()
 
-	// Start with a single empty binding
-	bindings := []map[string]string{{}}
-
 	// Process each triple pattern (in optimized order)
-	for _, pattern := range optimizedQuery.Where {
-		// Check for cancellation
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-
-		bindings = e.matchPattern(pattern, bindings)
-		if len(bindings) == 0 {
-			break // No matches, short-circuit
-		}
-	}
-
-	// Process OPTIONAL patterns
-	for _, optPatterns := range query.Optional {
-		bindings = e.processOptional(ctx, optPatterns, bindings)
+	plan := e.newPlan(query.Where, optimizedQuery.Where)
+	bindings, err := e.matchPatterns(ctx, optimizedQuery.Where, plan)
+	if err != nil {
+		return nil, err
 	}
 
-	// Apply filters
-	for _, filter := range query.Filters {
-		bindings = e.applyFilter(filter, bindings)
-	}
+	// Process OPTIONAL patterns and filters
+	bindings = e.applyOptionalAndFilters(ctx, query.Optional, query.Filters, bindings, plan)
 
 	// Branch: aggregate queries take a separate execution path
 	if query.HasAggregates() {
-		return e.executeAggregateSelect(ctx, query, bindings, metrics, executeStart)
+		result, err := e.executeAggregateSelect(ctx, query, bindings, metrics, executeStart)
+		if result != nil {
+			result.Plan = plan
+		}
+		return result, err
 	}
 
 	// Apply ORDER BY before DISTINCT (to get consistent ordering)
@@ -330,6 +370,7 @@ func (e *Executor) executeSelect(ctx context.Context, query *SelectQuery, metric
 	result := &QueryResult{
 		Bindings: bindings,
 		Count:    len(bindings),
+		Plan:     plan,
 	}
 
 	// Determine variables to return
@@ -720,33 +761,15 @@ func (e *Executor) executeConstruct(ctx context.Context, query *ConstructQuery,
 
 	executeStart := time.Now()
 
-	// Start with a single empty binding
-	bindings := []map[string]string{{}}
-
 	// Process each triple pattern in WHERE clause
-	for _, pattern := range query.Where {
-		// Check for cancellation
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-
-		bindings = e.matchPattern(pattern, bindings)
-		if len(bindings) == 0 {
-			break // No matches, short-circuit
-		}
+	plan := e.newPlan(query.Where, query.Where)
+	bindings, err := e.matchPatterns(ctx, query.Where, plan)
+	if err != nil {
+		return nil, err
 	}
 
-	// Process OPTIONAL patterns
-	for _, optPatterns := range query.Optional {
-		bindings = e.processOptional(ctx, optPatterns, bindings)
-	}
-
-	// Apply filters
-	for _, filter := range query.Filters {
-		bindings = e.applyFilter(filter, bindings)
-	}
+	// Process OPTIONAL patterns and filters
+	bindings = e.applyOptionalAndFilters(ctx, query.Optional, query.Filters, bindings, plan)
 
 	// Construct triples from template using bindings
 	seen := make(map[string]bool)
@@ -783,11 +806,14 @@ func (e *Executor) executeConstruct(ctx context.Context, query *ConstructQuery,
 	return &ConstructResult{
 		Triples: triples,
 		Count:   len(triples),
+		Plan:    plan,
 	}, nil
 }
 
 // executeDescribe executes a DESCRIBE query by collecting all triples where
-// target resources appear as subject or object (bidirectional).
+// target resources appear as subject or object (bidirectional). With a
+// DEPTH above 1, outgoing edges are followed to that depth and the outgoing
+// triples of every node reached are included; see describeClosure.
 func (e *Executor) executeDescribe(ctx context.Context, query *DescribeQuery, metrics *QueryMetrics) (*ConstructResult, error) {
 	planStart := time.Now()
 	metrics.PlanTime = time.Since(planStart)
@@ -796,6 +822,7 @@ func (e *Executor) executeDescribe(ctx context.Context, query *DescribeQuery, me
 	executeStart := time.Now()
 
 	var targetURIs []string
+	var plan *QueryPlan
 
 	if len(query.Where) == 0 {
 		// Direct URI form: DESCRIBE <uri> or DESCRIBE prefix:name
@@ -807,30 +834,14 @@ func (e *Executor) executeDescribe(ctx context.Context, query *DescribeQuery, me
 		}
 	} else {
 		// Variable form: DESCRIBE ?var WHERE { ... }
-		bindings := []map[string]string{{}}
-
-		for _, pattern := range query.Where {
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			default:
-			}
-
-			bindings = e.matchPattern(pattern, bindings)
-			if len(bindings) == 0 {
-				break
-			}
-		}
-
-		// Process OPTIONAL patterns
-		for _, optPatterns := range query.Optional {
-			bindings = e.processOptional(ctx, optPatterns, bindings)
+		plan = e.newPlan(query.Where, query.Where)
+		bindings, err := e.matchPatterns(ctx, query.Where, plan)
+		if err != nil {
+			return nil, err
 		}
 
-		// Apply filters
-		for _, filter := range query.Filters {
-			bindings = e.applyFilter(filter, bindings)
-		}
+		// Process OPTIONAL patterns and filters
+		bindings = e.applyOptionalAndFilters(ctx, query.Optional, query.Filters, bindings, plan)
 
 		// Extract unique URIs from variable bindings
 		seenURIs := make(map[string]bool)
@@ -887,15 +898,93 @@ func (e *Executor) executeDescribe(ctx context.Context, query *DescribeQuery, me
 		}
 	}
 
+	if query.Depth > 1 {
+		closure, err := e.describeClosure(ctx, targetURIs, query.Depth, seen)
+		if err != nil {
+			return nil, err
+		}
+		triples = append(triples, closure...)
+	}
+
 	metrics.ExecuteTime = time.Since(executeStart)
 	metrics.ResultCount = len(triples)
 
 	return &ConstructResult{
 		Triples: triples,
 		Count:   len(triples),
+		Plan:    plan,
 	}, nil
 }
 
+// describeClosure follows outgoing edges from the described resources,
+// breadth first, and returns the outgoing triples of each node reached
+// within depth hops, so describing an article inlines its rights,
+// obligations, and referenced definitions. Blank nodes ("_:b0") are
+// followed without counting toward the depth, so a blank node's
+// description is always complete. rdf:type edges are not followed: a
+// resource's class is not part of its description. seen holds the keys of
+// triples already returned and is updated.
+func (e *Executor) describeClosure(ctx context.Context, targetURIs []string, depth int, seen map[string]bool) ([]ConstructedTriple, error) {
+	type describeNode struct {
+		uri   string
+		level int
+	}
+
+	visited := make(map[string]bool, len(targetURIs))
+	queue := make([]describeNode, 0, len(targetURIs))
+	for _, uri := range targetURIs {
+		if !visited[uri] {
+			visited[uri] = true
+			queue = append(queue, describeNode{uri: uri, level: 1})
+		}
+	}
+
+	var triples []ConstructedTriple
+	for len(queue) > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, edge := range e.store.Find(node.uri, "", "") {
+			next := edge.Object
+			if visited[next] || edge.Predicate == store.RDFType {
+				continue
+			}
+			level := node.level + 1
+			if strings.HasPrefix(next, "_:") {
+				level = node.level
+			} else if level > depth {
+				continue
+			}
+
+			outgoing := e.store.Find(next, "", "")
+			if len(outgoing) == 0 {
+				// A literal, or a resource with no description.
+				continue
+			}
+			visited[next] = true
+			for _, triple := range outgoing {
+				tripleKey := triple.Subject + "|" + triple.Predicate + "|" + triple.Object
+				if !seen[tripleKey] {
+					seen[tripleKey] = true
+					triples = append(triples, ConstructedTriple{
+						Subject:   triple.Subject,
+						Predicate: triple.Predicate,
+						Object:    triple.Object,
+					})
+				}
+			}
+			queue = append(queue, describeNode{uri: next, level: level})
+		}
+	}
+	return triples, nil
+}
+
 // resolveResourceURI resolves a resource identifier to a plain URI string.
 func resolveResourceURI(resource string) string {
 	if IsURI(resource) {
@@ -927,9 +1016,72 @@ func (e *Executor) substituteVariable(term string, binding map[string]string) st
 	return term
 }
 
-// matchPattern matches a triple pattern against the store.
-func (e *Executor) matchPattern(pattern TriplePattern, currentBindings []map[string]string) []map[string]string {
+// matchPatterns joins the triple patterns in order, starting from a single
+// empty binding and stopping at the first pattern that leaves none. Each
+// join step is recorded in plan when it is not nil.
+func (e *Executor) matchPatterns(ctx context.Context, patterns []TriplePattern, plan *QueryPlan) ([]map[string]string, error) {
+	bindings := []map[string]string{{}}
+
+	for i, pattern := range patterns {
+		// Check for cancellation
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		stepStart := time.Now()
+		input := len(bindings)
+		var matches int
+		bindings, matches = e.matchPattern(pattern, bindings)
+		if plan != nil {
+			step := &plan.Steps[i]
+			step.Lookups = input
+			step.Matches = matches
+			step.Input = input
+			step.Output = len(bindings)
+			step.Duration = time.Since(stepStart)
+			step.Skipped = false
+		}
+		if len(bindings) == 0 {
+			break // No matches, short-circuit
+		}
+	}
+
+	return bindings, nil
+}
+
+// applyOptionalAndFilters processes OPTIONAL groups, then FILTER clauses,
+// recording each in plan when it is not nil.
+func (e *Executor) applyOptionalAndFilters(ctx context.Context, optional [][]TriplePattern, filters []Filter, bindings []map[string]string, plan *QueryPlan) []map[string]string {
+	for _, optPatterns := range optional {
+		stepStart := time.Now()
+		input := len(bindings)
+		bindings = e.processOptional(ctx, optPatterns, bindings)
+		if plan != nil {
+			texts := make([]string, len(optPatterns))
+			for i, pattern := range optPatterns {
+				texts[i] = fmt.Sprintf("%s %s %s", pattern.Subject, pattern.Predicate, pattern.Object)
+			}
+			plan.record(PlanStepOptional, "{ "+strings.Join(texts, " . ")+" }", input, len(bindings), stepStart)
+		}
+	}
+
+	for _, filter := range filters {
+		stepStart := time.Now()
+		input := len(bindings)
+		bindings = e.applyFilter(filter, bindings)
+		plan.record(PlanStepFilter, filter.Expression, input, len(bindings), stepStart)
+	}
+
+	return bindings
+}
+
+// matchPattern matches a triple pattern against the store, returning the
+// extended bindings and the number of triples the lookups found.
+func (e *Executor) matchPattern(pattern TriplePattern, currentBindings []map[string]string) ([]map[string]string, int) {
 	var newBindings []map[string]string
+	matches := 0
 
 	for _, binding := range currentBindings {
 		// Resolve pattern with current bindings
@@ -939,6 +1091,7 @@ func (e *Executor) matchPattern(pattern TriplePattern, currentBindings []map[str
 
 		// Query triple store
 		triples := e.store.Find(subject, predicate, object)
+		matches += len(triples)
 
 		// Create new bindings for each matching triple
 		for _, triple := range triples {
@@ -985,7 +1138,7 @@ func (e *Executor) matchPattern(pattern TriplePattern, currentBindings []map[str
 		}
 	}
 
-	return newBindings
+	return newBindings, matches
 }
 
 // processOptional processes OPTIONAL patterns (left outer join).
@@ -1001,7 +1154,7 @@ func (e *Executor) processOptional(ctx context.Context, patterns []TriplePattern
 				return currentBindings // Return original on cancellation
 			default:
 			}
-			optBindings = e.matchPattern(pattern, optBindings)
+			optBindings, _ = e.matchPattern(pattern, optBindings)
 		}
 
 		if len(optBindings) > 0 {
@@ -1223,52 +1376,38 @@ const (
 	FormatCSV      OutputFormat = "csv"
 	FormatTurtle   OutputFormat = "turtle"
 	FormatNTriples OutputFormat = "ntriples"
+	FormatNDJSON   OutputFormat = "ndjson"
+	FormatSRJ      OutputFormat = "srj"  // W3C SPARQL Query Results JSON
+	FormatSRX      OutputFormat = "srx"  // W3C SPARQL Query Results XML
+	FormatXLSX     OutputFormat = "xlsx" // Excel workbook (binary)
 )
 
-// Common URI prefixes that can be compacted in output.
-var commonURIPrefixes = []struct {
-	prefix string
-	short  string
-}{
-	{"https://regula.dev/regulations/", ""},
-	{"https://regula.dev/ontology#", "reg:"},
-	{"http://www.w3.org/1999/02/22-rdf-syntax-ns#", "rdf:"},
-	{"http://www.w3.org/2000/01/rdf-schema#", "rdfs:"},
-	{"http://purl.org/dc/terms/", "dc:"},
-	{"http://data.europa.eu/eli/ontology#", "eli:"},
-}
-
-// CompactURI shortens a full URI to a more readable compact form.
+// CompactURI shortens a full URI using the default prefixes.
 // For example: "https://regula.dev/regulations/GDPR:Art17" -> "GDPR:Art17"
 func CompactURI(uri string) string {
-	for _, p := range commonURIPrefixes {
-		if strings.HasPrefix(uri, p.prefix) {
-			return p.short + strings.TrimPrefix(uri, p.prefix)
-		}
-	}
-	return uri
+	return defaultPrefixes.Compact(uri)
 }
 
 // CompactBindings applies CompactURI to all values in the bindings.
 func CompactBindings(bindings []map[string]string) []map[string]string {
-	result := make([]map[string]string, len(bindings))
-	for i, binding := range bindings {
-		newBinding := make(map[string]string)
-		for k, v := range binding {
-			newBinding[k] = CompactURI(v)
-		}
-		result[i] = newBinding
-	}
-	return result
+	return defaultPrefixes.CompactBindings(bindings)
 }
 
-// WithCompactURIs returns a copy of the QueryResult with compacted URIs.
+// WithCompactURIs returns a copy of the QueryResult with URIs compacted
+// using the default prefixes.
 func (r *QueryResult) WithCompactURIs() *QueryResult {
+	return r.WithPrefixes(defaultPrefixes)
+}
+
+// WithPrefixes returns a copy of the QueryResult with URIs compacted using
+// prefixes.
+func (r *QueryResult) WithPrefixes(prefixes PrefixMap) *QueryResult {
 	return &QueryResult{
 		Variables: r.Variables,
-		Bindings:  CompactBindings(r.Bindings),
+		Bindings:  prefixes.CompactBindings(r.Bindings),
 		Count:     r.Count,
 		Metrics:   r.Metrics,
+		Plan:      r.Plan,
 	}
 }
 
@@ -1281,6 +1420,17 @@ func (r *QueryResult) Format(format OutputFormat) (string, error) {
 		return r.FormatCSV()
 	case FormatTable:
 		return r.FormatTable(), nil
+	case FormatNDJSON:
+		var sb strings.Builder
+		err := r.WriteNDJSON(&sb)
+		return sb.String(), err
+	case FormatSRJ:
+		return r.FormatSPARQLJSON()
+	case FormatSRX:
+		return r.FormatSPARQLXML()
+	case FormatXLSX:
+		data, err := r.ToXLSX()
+		return string(data), err
 	default:
 		return "", fmt.Errorf("unsupported format: %s", format)
 	}
@@ -1390,6 +1540,18 @@ func (r *QueryResult) FormatCSV() (string, error) {
 	return sb.String(), nil
 }
 
+// WriteNDJSON writes each binding to w as one JSON object per line,
+// without buffering the whole result.
+func (r *QueryResult) WriteNDJSON(w io.Writer) error {
+	encoder := ndjson.NewEncoder(w)
+	for _, binding := range r.Bindings {
+		if err := encoder.Encode(binding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Format formats the CONSTRUCT result in the specified format.
 func (r *ConstructResult) Format(format OutputFormat) (string, error) {
 	switch format {
@@ -1399,9 +1561,31 @@ func (r *ConstructResult) Format(format OutputFormat) (string, error) {
 		return r.FormatNTriples(), nil
 	case FormatJSON:
 		return r.FormatJSON()
+	case FormatNDJSON:
+		var sb strings.Builder
+		err := r.WriteNDJSON(&sb)
+		return sb.String(), err
 	default:
-		return "", fmt.Errorf("unsupported format for CONSTRUCT results: %s (use turtle, ntriples, or json)", format)
+		return "", fmt.Errorf("unsupported format for CONSTRUCT results: %s (use turtle, ntriples, json, or ndjson)", format)
+	}
+}
+
+// WriteNDJSON writes each constructed triple to w as one JSON object per
+// line with subject, predicate, and object fields.
+func (r *ConstructResult) WriteNDJSON(w io.Writer) error {
+	type jsonTriple struct {
+		Subject   string `json:"subject"`
+		Predicate string `json:"predicate"`
+		Object    string `json:"object"`
+	}
+
+	encoder := ndjson.NewEncoder(w)
+	for _, t := range r.Triples {
+		if err := encoder.Encode(jsonTriple{Subject: t.Subject, Predicate: t.Predicate, Object: t.Object}); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 // FormatTurtle formats the constructed triples in Turtle format.
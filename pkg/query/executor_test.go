@@ -512,6 +512,30 @@ func TestQueryResult_FormatCSV(t *testing.T) {
 	}
 }
 
+func TestQueryResult_FormatNDJSON(t *testing.T) {
+	result := &QueryResult{
+		Variables: []string{"article", "title"},
+		Bindings: []map[string]string{
+			{"article": "Art17", "title": "Right to erasure"},
+			{"article": "Art18", "title": "Right to restriction"},
+		},
+		Count: 2,
+	}
+
+	ndjsonOut, err := result.Format(FormatNDJSON)
+	if err != nil {
+		t.Fatalf("Format(ndjson) error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(ndjsonOut), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("NDJSON should have one line per binding, got %d", len(lines))
+	}
+	if lines[0] != `{"article":"Art17","title":"Right to erasure"}` {
+		t.Errorf("first line = %s", lines[0])
+	}
+}
+
 func TestQueryPlanner_OptimizeQuery(t *testing.T) {
 	// Create stats that make certain patterns more selective
 	stats := store.IndexStats{
@@ -846,6 +870,27 @@ func TestConstructResult_FormatJSON(t *testing.T) {
 	}
 }
 
+func TestConstructResult_FormatNDJSON(t *testing.T) {
+	result := &ConstructResult{
+		Triples: []ConstructedTriple{
+			{Subject: "Art17", Predicate: "title", Object: "Right to erasure"},
+			{Subject: "Art17", Predicate: "rdf:type", Object: "reg:Article"},
+		},
+		Count: 2,
+	}
+
+	ndjsonOut, err := result.Format(FormatNDJSON)
+	if err != nil {
+		t.Fatalf("Format(ndjson) error = %v", err)
+	}
+
+	expected := `{"subject":"Art17","predicate":"title","object":"Right to erasure"}` + "\n" +
+		`{"subject":"Art17","predicate":"rdf:type","object":"reg:Article"}` + "\n"
+	if ndjsonOut != expected {
+		t.Errorf("NDJSON = %q, want %q", ndjsonOut, expected)
+	}
+}
+
 func TestConstructResult_FormatEmpty(t *testing.T) {
 	result := &ConstructResult{
 		Triples: []ConstructedTriple{},
@@ -1389,3 +1434,98 @@ func TestQueryResult_WithCompactURIs(t *testing.T) {
 		t.Errorf("Expected count to be preserved, got %d", compacted.Count)
 	}
 }
+
+func TestExecutor_DescribeDepth(t *testing.T) {
+	ts := setupTestStore()
+	executor := NewExecutor(ts)
+
+	hasSubject := func(result *ConstructResult, subject string) bool {
+		for _, triple := range result.Triples {
+			if triple.Subject == subject {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Depth 1: only Art17's own triples (and triples pointing at it).
+	result, err := executor.ExecuteDescribeString(`DESCRIBE GDPR:Art17`)
+	if err != nil {
+		t.Fatalf("ExecuteDescribeString() error = %v", err)
+	}
+	if hasSubject(result, "GDPR:Art6") || hasSubject(result, "GDPR:ChapterIII") {
+		t.Error("Depth 1 should not describe neighbouring resources")
+	}
+
+	// Depth 2: Art17 references Art6 and is part of Chapter III.
+	result, err = executor.ExecuteDescribeString(`DESCRIBE GDPR:Art17 DEPTH 2`)
+	if err != nil {
+		t.Fatalf("ExecuteDescribeString() error = %v", err)
+	}
+	if !hasSubject(result, "GDPR:Art6") {
+		t.Error("Depth 2 should describe referenced GDPR:Art6")
+	}
+	if !hasSubject(result, "GDPR:ChapterIII") {
+		t.Error("Depth 2 should describe GDPR:ChapterIII")
+	}
+	if hasSubject(result, "GDPR:ChapterII") {
+		t.Error("Depth 2 should not reach GDPR:ChapterII (three edges away)")
+	}
+	if hasSubject(result, "reg:Article") {
+		t.Error("rdf:type edges should not be followed")
+	}
+
+	// Depth 3: Art6 is part of Chapter II.
+	result, err = executor.ExecuteDescribeString(`DESCRIBE GDPR:Art17 DEPTH 3`)
+	if err != nil {
+		t.Fatalf("ExecuteDescribeString() error = %v", err)
+	}
+	if !hasSubject(result, "GDPR:ChapterII") {
+		t.Error("Depth 3 should describe GDPR:ChapterII")
+	}
+
+	seen := make(map[string]bool)
+	for _, triple := range result.Triples {
+		key := triple.Subject + "|" + triple.Predicate + "|" + triple.Object
+		if seen[key] {
+			t.Errorf("Duplicate triple %s", key)
+		}
+		seen[key] = true
+	}
+}
+
+func TestExecutor_DescribeDepthBlankNodes(t *testing.T) {
+	ts := store.NewTripleStore()
+	ts.Add("GDPR:Art17", "reg:grants", "GDPR:Right:17")
+	ts.Add("GDPR:Right:17", "reg:rightType", "RightToErasure")
+	ts.Add("GDPR:Right:17", "reg:condition", "_:c1")
+	ts.Add("_:c1", "reg:text", "the data are no longer necessary")
+	ts.Add("_:c1", "reg:next", "_:c2")
+	ts.Add("_:c2", "reg:text", "consent is withdrawn")
+	executor := NewExecutor(ts)
+
+	result, err := executor.ExecuteDescribeString(`DESCRIBE GDPR:Art17 DEPTH 2`)
+	if err != nil {
+		t.Fatalf("ExecuteDescribeString() error = %v", err)
+	}
+
+	// Blank nodes are closed over regardless of depth.
+	want := map[string]bool{
+		"GDPR:Art17|reg:grants|GDPR:Right:17":            false,
+		"GDPR:Right:17|reg:condition|_:c1":               false,
+		"_:c1|reg:next|_:c2":                             false,
+		"_:c2|reg:text|consent is withdrawn":             false,
+		"_:c1|reg:text|the data are no longer necessary": false,
+	}
+	for _, triple := range result.Triples {
+		key := triple.Subject + "|" + triple.Predicate + "|" + triple.Object
+		if _, ok := want[key]; ok {
+			want[key] = true
+		}
+	}
+	for key, found := range want {
+		if !found {
+			t.Errorf("Missing triple %s", key)
+		}
+	}
+}
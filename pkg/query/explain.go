@@ -0,0 +1,178 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Plan step kinds.
+const (
+	PlanStepPattern  = "pattern"
+	PlanStepOptional = "optional"
+	PlanStepFilter   = "filter"
+)
+
+// Store indexes a pattern lookup can use; IndexScan reads every triple.
+const (
+	IndexSPO  = "SPO"
+	IndexPOS  = "POS"
+	IndexOSP  = "OSP"
+	IndexScan = "scan"
+)
+
+// QueryPlan records how an executor evaluated a query's WHERE clause: the
+// order the triple patterns were joined in, the index each lookup used, and
+// the bindings and time of each step. Executors created WithExplain attach
+// one to every result.
+type QueryPlan struct {
+	Reordered    bool       `json:"reordered"`     // The planner changed the written pattern order
+	TotalTriples int        `json:"total_triples"` // Triples in the store the estimates come from
+	Steps        []PlanStep `json:"steps"`
+}
+
+// PlanStep is one step of a QueryPlan: a triple pattern joined with the
+// bindings so far, an OPTIONAL group, or a FILTER.
+type PlanStep struct {
+	Kind     string `json:"kind"`
+	Text     string `json:"text"`
+	Position int    `json:"position,omitempty"` // 1-based position of the pattern as written
+	Index    string `json:"index,omitempty"`
+	// Estimated is the planner's estimate of the triples matching the
+	// pattern alone, from the store's index statistics.
+	Estimated float64       `json:"estimated,omitempty"`
+	Lookups   int           `json:"lookups"` // Index lookups, one per input binding
+	Matches   int           `json:"matches"` // Triples the lookups returned
+	Input     int           `json:"input"`   // Bindings before the step
+	Output    int           `json:"output"`  // Bindings after the step
+	Duration  time.Duration `json:"duration"`
+	// Skipped is set for patterns not evaluated because an earlier step
+	// left no bindings.
+	Skipped bool `json:"skipped,omitempty"`
+}
+
+// newPlan returns the plan for joining ordered, the written patterns in
+// evaluation order, or nil when the executor does not explain queries. Each
+// pattern starts out skipped until matchPatterns evaluates it.
+func (e *Executor) newPlan(written, ordered []TriplePattern) *QueryPlan {
+	if !e.explain {
+		return nil
+	}
+
+	plan := &QueryPlan{TotalTriples: e.planner.stats.TotalTriples}
+	used := make([]bool, len(written))
+	bound := make(map[string]bool)
+	for i, pattern := range ordered {
+		position := 0
+		for j, candidate := range written {
+			if !used[j] && candidate == pattern {
+				used[j] = true
+				position = j + 1
+				break
+			}
+		}
+		if position != i+1 {
+			plan.Reordered = true
+		}
+
+		plan.Steps = append(plan.Steps, PlanStep{
+			Kind:      PlanStepPattern,
+			Text:      fmt.Sprintf("%s %s %s", pattern.Subject, pattern.Predicate, pattern.Object),
+			Position:  position,
+			Index:     patternIndex(pattern, bound),
+			Estimated: e.planner.estimateSelectivity(pattern),
+			Skipped:   true,
+		})
+		for _, term := range []string{pattern.Subject, pattern.Predicate, pattern.Object} {
+			if IsVariable(term) {
+				bound[StripVariable(term)] = true
+			}
+		}
+	}
+	return plan
+}
+
+// patternIndex returns the index the store uses to look up a pattern, given
+// the variables bound by earlier patterns. It follows TripleStore.Find: a
+// known subject uses SPO, else a known predicate POS, else a known object
+// OSP.
+func patternIndex(pattern TriplePattern, bound map[string]bool) string {
+	known := func(term string) bool {
+		return !IsVariable(term) || bound[StripVariable(term)]
+	}
+	switch {
+	case known(pattern.Subject):
+		return IndexSPO
+	case known(pattern.Predicate):
+		return IndexPOS
+	case known(pattern.Object):
+		return IndexOSP
+	default:
+		return IndexScan
+	}
+}
+
+// record appends an OPTIONAL or FILTER step. It does nothing on a nil plan.
+func (p *QueryPlan) record(kind, text string, input, output int, start time.Time) {
+	if p == nil {
+		return
+	}
+	p.Steps = append(p.Steps, PlanStep{
+		Kind:     kind,
+		Text:     text,
+		Input:    input,
+		Output:   output,
+		Duration: time.Since(start),
+	})
+}
+
+// Format renders the plan as a table, one row per step in evaluation
+// order.
+func (p *QueryPlan) Format() string {
+	var sb strings.Builder
+
+	patterns := 0
+	for _, step := range p.Steps {
+		if step.Kind == PlanStepPattern {
+			patterns++
+		}
+	}
+	order := "in written order"
+	if p.Reordered {
+		order = "reordered by estimated selectivity"
+	}
+	fmt.Fprintf(&sb, "Query plan: %d pattern(s) %s (%d triples in store)\n\n", patterns, order, p.TotalTriples)
+
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STEP\tKIND\tPATTERN\tINDEX\tEST.\tLOOKUPS\tMATCHES\tBINDINGS\tTIME")
+	for i, step := range p.Steps {
+		text := step.Text
+		if step.Position > 0 {
+			text = fmt.Sprintf("#%d %s", step.Position, text)
+		}
+		if step.Kind != PlanStepPattern {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t-\t-\t-\t-\t%d -> %d\t%v\n",
+				i+1, step.Kind, text, step.Input, step.Output, step.Duration)
+			continue
+		}
+		if step.Skipped {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t-\t-\tskipped\t-\n",
+				i+1, step.Kind, text, step.Index, formatEstimate(step.Estimated))
+			continue
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%d\t%d\t%d -> %d\t%v\n",
+			i+1, step.Kind, text, step.Index, formatEstimate(step.Estimated),
+			step.Lookups, step.Matches, step.Input, step.Output, step.Duration)
+	}
+	tw.Flush()
+
+	return sb.String()
+}
+
+func formatEstimate(estimate float64) string {
+	if estimate < 1 {
+		return "<1"
+	}
+	return fmt.Sprintf("%.0f", estimate)
+}
@@ -0,0 +1,154 @@
+package query
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExecutor_ExplainSelect(t *testing.T) {
+	ts := setupTestStore()
+	executor := NewExecutor(ts, WithExplain(true))
+
+	// The title pattern is written first but is less selective than the
+	// lookup of a single article's title.
+	result, err := executor.ExecuteString(`SELECT ?title ?chapter WHERE {
+		?article reg:partOf ?chapter .
+		GDPR:Art17 reg:title ?title .
+		FILTER(CONTAINS(?chapter, "III"))
+	}`)
+	if err != nil {
+		t.Fatalf("ExecuteString() error = %v", err)
+	}
+
+	plan := result.Plan
+	if plan == nil {
+		t.Fatal("Plan is nil for an executor created WithExplain")
+	}
+	if !plan.Reordered {
+		t.Error("Reordered = false, want true")
+	}
+	if plan.TotalTriples != ts.Count() {
+		t.Errorf("TotalTriples = %d, want %d", plan.TotalTriples, ts.Count())
+	}
+	if len(plan.Steps) != 3 {
+		t.Fatalf("len(Steps) = %d, want 3: %+v", len(plan.Steps), plan.Steps)
+	}
+
+	first, second, filter := plan.Steps[0], plan.Steps[1], plan.Steps[2]
+	if first.Position != 2 || first.Index != IndexSPO || first.Output != 1 {
+		t.Errorf("first step = %+v, want written #2 via SPO with 1 binding", first)
+	}
+	if second.Position != 1 || second.Index != IndexPOS || second.Lookups != 1 || second.Matches != 3 || second.Output != 3 {
+		t.Errorf("second step = %+v, want written #1 via POS, 1 lookup, 3 matches", second)
+	}
+	if filter.Kind != PlanStepFilter || filter.Input != 3 || filter.Output != 1 {
+		t.Errorf("filter step = %+v, want 3 -> 1", filter)
+	}
+	if result.Count != 1 {
+		t.Errorf("Count = %d, want 1", result.Count)
+	}
+
+	if compact := result.WithCompactURIs(); compact.Plan != plan {
+		t.Error("WithCompactURIs() dropped the plan")
+	}
+
+	formatted := plan.Format()
+	for _, want := range []string{"reordered by estimated selectivity", "#2 GDPR:Art17 reg:title ?title", "POS", "3 -> 1"} {
+		if !strings.Contains(formatted, want) {
+			t.Errorf("Format() missing %q:\n%s", want, formatted)
+		}
+	}
+}
+
+func TestExecutor_ExplainSkippedPatterns(t *testing.T) {
+	ts := setupTestStore()
+	executor := NewExecutor(ts, WithExplain(true), WithPlanning(false))
+
+	result, err := executor.ExecuteString(`SELECT ?a WHERE {
+		?a reg:missing ?x .
+		?a reg:title ?title .
+	}`)
+	if err != nil {
+		t.Fatalf("ExecuteString() error = %v", err)
+	}
+
+	plan := result.Plan
+	if plan.Reordered {
+		t.Error("Reordered = true with planning disabled")
+	}
+	if len(plan.Steps) != 2 {
+		t.Fatalf("len(Steps) = %d, want 2", len(plan.Steps))
+	}
+	if plan.Steps[0].Skipped || plan.Steps[0].Output != 0 {
+		t.Errorf("first step = %+v, want evaluated with no bindings", plan.Steps[0])
+	}
+	if !plan.Steps[1].Skipped {
+		t.Error("second step should be skipped after an empty join")
+	}
+	if plan.Steps[1].Index != IndexSPO {
+		t.Errorf("second step index = %s, want SPO (?a bound by the first pattern)", plan.Steps[1].Index)
+	}
+	if !strings.Contains(plan.Format(), "skipped") {
+		t.Errorf("Format() should mark skipped steps:\n%s", plan.Format())
+	}
+}
+
+func TestExecutor_ExplainConstructAndDescribe(t *testing.T) {
+	executor := NewExecutor(setupTestStore(), WithExplain(true))
+
+	construct, err := executor.ExecuteConstructString(`CONSTRUCT { ?a reg:hasTitle ?t } WHERE {
+		?a rdf:type reg:Article .
+		?a reg:title ?t .
+		OPTIONAL { ?a reg:references ?ref }
+	}`)
+	if err != nil {
+		t.Fatalf("ExecuteConstructString() error = %v", err)
+	}
+	if construct.Plan == nil || len(construct.Plan.Steps) != 3 {
+		t.Fatalf("CONSTRUCT plan = %+v, want 2 patterns and an OPTIONAL", construct.Plan)
+	}
+	if construct.Plan.Steps[2].Kind != PlanStepOptional {
+		t.Errorf("last step kind = %s, want optional", construct.Plan.Steps[2].Kind)
+	}
+
+	describe, err := executor.ExecuteDescribeString(`DESCRIBE GDPR:Art17`)
+	if err != nil {
+		t.Fatalf("ExecuteDescribeString() error = %v", err)
+	}
+	if describe.Plan != nil {
+		t.Error("DESCRIBE of a named resource should have no plan")
+	}
+}
+
+func TestExecutor_NoPlanByDefault(t *testing.T) {
+	executor := NewExecutor(setupTestStore())
+
+	result, err := executor.ExecuteString(`SELECT ?a WHERE { ?a rdf:type reg:Article }`)
+	if err != nil {
+		t.Fatalf("ExecuteString() error = %v", err)
+	}
+	if result.Plan != nil {
+		t.Error("Plan should be nil unless the executor is created WithExplain")
+	}
+}
+
+func TestPatternIndex(t *testing.T) {
+	tests := []struct {
+		pattern TriplePattern
+		bound   map[string]bool
+		want    string
+	}{
+		{TriplePattern{"GDPR:Art17", "?p", "?o"}, nil, IndexSPO},
+		{TriplePattern{"?s", "reg:title", "?o"}, nil, IndexPOS},
+		{TriplePattern{"?s", "?p", "GDPR:Art6"}, nil, IndexOSP},
+		{TriplePattern{"?s", "?p", "?o"}, nil, IndexScan},
+		{TriplePattern{"?s", "?p", "?o"}, map[string]bool{"s": true}, IndexSPO},
+		{TriplePattern{"?s", "?p", "?o"}, map[string]bool{"o": true}, IndexOSP},
+	}
+
+	for _, tt := range tests {
+		if got := patternIndex(tt.pattern, tt.bound); got != tt.want {
+			t.Errorf("patternIndex(%v, %v) = %s, want %s", tt.pattern, tt.bound, got, tt.want)
+		}
+	}
+}
@@ -298,6 +298,17 @@ func parseDescribeQuery(queryStr string) (*DescribeQuery, error) {
 	// Remove PREFIX declarations for easier parsing
 	queryStr = prefixRegex.ReplaceAllString(queryStr, "")
 
+	// Extract a trailing DEPTH clause: DESCRIBE <uri> DEPTH 2
+	depthRegex := regexp.MustCompile(`(?i)\s+DEPTH\s+(\d+)\s*$`)
+	if depthMatch := depthRegex.FindStringSubmatch(queryStr); depthMatch != nil {
+		depth, err := strconv.Atoi(depthMatch[1])
+		if err != nil || depth < 1 {
+			return nil, fmt.Errorf("invalid DEPTH %s: must be a positive integer", depthMatch[1])
+		}
+		describeQuery.Depth = depth
+		queryStr = queryStr[:len(queryStr)-len(depthMatch[0])]
+	}
+
 	// Detect query form by checking for WHERE clause
 	upperQuery := strings.ToUpper(queryStr)
 	whereIdx := strings.Index(upperQuery, "WHERE")
@@ -1065,6 +1076,9 @@ func (q *DescribeQuery) Validate() []error {
 	if len(q.Resources) == 0 {
 		errors = append(errors, fmt.Errorf("DESCRIBE query has no resources"))
 	}
+	if q.Depth < 0 {
+		errors = append(errors, fmt.Errorf("DESCRIBE DEPTH must be positive, got %d", q.Depth))
+	}
 
 	// If WHERE clause exists, verify resource variables are bound
 	if len(q.Where) > 0 {
@@ -1160,5 +1174,9 @@ func (q *DescribeQuery) String() string {
 		sb.WriteString("}")
 	}
 
+	if q.Depth > 1 {
+		sb.WriteString(fmt.Sprintf(" DEPTH %d", q.Depth))
+	}
+
 	return sb.String()
 }
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -1351,3 +1352,38 @@ func TestDescribeQuery_ExpandPrefixesWithWhere(t *testing.T) {
 		t.Errorf("Where Predicate = %s, want <http://example.org/title>", query.Describe.Where[0].Predicate)
 	}
 }
+
+func TestParseDescribeQuery_Depth(t *testing.T) {
+	tests := []struct {
+		query     string
+		wantDepth int
+		wantErr   bool
+	}{
+		{`DESCRIBE GDPR:Art17`, 0, false},
+		{`DESCRIBE GDPR:Art17 DEPTH 2`, 2, false},
+		{`describe <http://example.org/Art17> depth 3`, 3, false},
+		{`DESCRIBE ?article WHERE { ?article rdf:type reg:Article . } DEPTH 2`, 2, false},
+		{`DESCRIBE GDPR:Art17 DEPTH 0`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := ParseQuery(tt.query)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ParseQuery() expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseQuery() error = %v", err)
+			}
+			if query.Describe.Depth != tt.wantDepth {
+				t.Errorf("Depth = %d, want %d", query.Describe.Depth, tt.wantDepth)
+			}
+			if tt.wantDepth > 1 && !strings.Contains(query.String(), fmt.Sprintf("DEPTH %d", tt.wantDepth)) {
+				t.Errorf("String() = %q, want DEPTH %d", query.String(), tt.wantDepth)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package query
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+	"gopkg.in/yaml.v3"
+)
+
+// PrefixesFile is the name of a library's prefix declarations file.
+const PrefixesFile = "prefixes.yaml"
+
+var prefixNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// PrefixMap maps prefix names to namespaces, for compacting URIs in
+// results to CURIEs such as reg:title, and for expanding CURIEs in
+// queries. The empty name compacts a namespace to the bare local name, as
+// regula's regulation namespace yields GDPR:Art17.
+type PrefixMap map[string]string
+
+// defaultPrefixes are the prefixes results are compacted with by default.
+var defaultPrefixes = PrefixMap{
+	"":     "https://regula.dev/regulations/",
+	"reg":  "https://regula.dev/ontology#",
+	"rdf":  "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	"rdfs": "http://www.w3.org/2000/01/rdf-schema#",
+	"dc":   "http://purl.org/dc/terms/",
+	"eli":  "http://data.europa.eu/eli/ontology#",
+}
+
+// DefaultPrefixes returns a copy of the prefixes results are compacted
+// with by default.
+func DefaultPrefixes() PrefixMap {
+	return defaultPrefixes.With(nil)
+}
+
+// LoadPrefixes reads prefix declarations from a YAML file that maps names
+// to namespaces:
+//
+//	uksi: http://www.legislation.gov.uk/uksi/
+//	acme: https://law.acme.example/regulations/
+//
+// A missing file declares no prefixes. Names must be identifiers and may
+// not redefine the default prefixes, whose terms the store keeps in
+// compact form.
+func LoadPrefixes(path string) (PrefixMap, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return PrefixMap{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prefixes: %w", err)
+	}
+
+	var prefixes PrefixMap
+	if err := yaml.Unmarshal(data, &prefixes); err != nil {
+		return nil, fmt.Errorf("failed to parse prefixes %s: %w", path, err)
+	}
+	for name, namespace := range prefixes {
+		if !prefixNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("%s: invalid prefix name %q", path, name)
+		}
+		if _, ok := defaultPrefixes[name]; ok {
+			return nil, fmt.Errorf("%s: prefix %q is built in and cannot be redefined", path, name)
+		}
+		if !strings.Contains(namespace, "://") {
+			return nil, fmt.Errorf("%s: prefix %s: namespace %q is not an absolute URI", path, name, namespace)
+		}
+	}
+	if prefixes == nil {
+		prefixes = PrefixMap{}
+	}
+	return prefixes, nil
+}
+
+// ResourcePrefixes maps the prefix of each compact resource URI in ts,
+// such as GDPR in GDPR:Art17, to its namespace. The store keeps predicates
+// in compact form but resources as full URIs, so these prefixes let
+// queries name resources the way results show them.
+func ResourcePrefixes(ts *store.TripleStore) PrefixMap {
+	prefixes := make(PrefixMap)
+	if ts == nil {
+		return prefixes
+	}
+	for _, subject := range ts.Subjects() {
+		compact := CompactURI(subject)
+		separator := strings.Index(compact, ":")
+		if compact == subject || separator <= 0 {
+			continue
+		}
+		name := compact[:separator]
+		if _, ok := prefixes[name]; !ok {
+			prefixes[name] = subject[:len(subject)-len(compact)+separator+1]
+		}
+	}
+	return prefixes
+}
+
+// With returns a copy of the map with the prefixes of other added, taking
+// precedence over its own.
+func (p PrefixMap) With(other PrefixMap) PrefixMap {
+	merged := make(PrefixMap, len(p)+len(other))
+	for name, namespace := range p {
+		merged[name] = namespace
+	}
+	for name, namespace := range other {
+		merged[name] = namespace
+	}
+	return merged
+}
+
+// Compact returns uri as a CURIE using the longest matching namespace, or
+// uri unchanged when no namespace matches.
+func (p PrefixMap) Compact(uri string) string {
+	bestName, bestNamespace := "", ""
+	for name, namespace := range p {
+		if namespace == "" || !strings.HasPrefix(uri, namespace) || len(uri) == len(namespace) {
+			continue
+		}
+		if len(namespace) > len(bestNamespace) || (len(namespace) == len(bestNamespace) && name < bestName) {
+			bestName, bestNamespace = name, namespace
+		}
+	}
+	if bestNamespace == "" {
+		return uri
+	}
+	local := uri[len(bestNamespace):]
+	if bestName == "" {
+		return local
+	}
+	return bestName + ":" + local
+}
+
+// CompactBindings applies Compact to all values in the bindings.
+func (p PrefixMap) CompactBindings(bindings []map[string]string) []map[string]string {
+	result := make([]map[string]string, len(bindings))
+	for i, binding := range bindings {
+		compacted := make(map[string]string, len(binding))
+		for k, v := range binding {
+			compacted[k] = p.Compact(v)
+		}
+		result[i] = compacted
+	}
+	return result
+}
+
+// Names returns the prefix names in sorted order.
+func (p PrefixMap) Names() []string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Prefixes returns the prefixes the query declares with PREFIX.
+func (q *Query) Prefixes() PrefixMap {
+	switch {
+	case q.Select != nil:
+		return PrefixMap(q.Select.Prefixes)
+	case q.Construct != nil:
+		return PrefixMap(q.Construct.Prefixes)
+	case q.Describe != nil:
+		return PrefixMap(q.Describe.Prefixes)
+	}
+	return nil
+}
+
+// ExpandPrefixes declares prefixes on the query, except those the query
+// declares itself, and expands the CURIEs in its patterns. Terms in the
+// namespaces of the default named prefixes are left in, or returned to,
+// compact form, as the store keeps them so.
+func (q *Query) ExpandPrefixes(prefixes PrefixMap) {
+	declare := func(declared map[string]string) {
+		for name, namespace := range prefixes {
+			if _, ok := declared[name]; !ok && name != "" {
+				declared[name] = namespace
+			}
+		}
+	}
+
+	switch {
+	case q.Select != nil && q.Select.Prefixes != nil:
+		declare(q.Select.Prefixes)
+		q.Select.ExpandPrefixes()
+		compactStoredTerms(q.Select.Where, q.Select.Optional...)
+	case q.Construct != nil && q.Construct.Prefixes != nil:
+		declare(q.Construct.Prefixes)
+		q.Construct.ExpandPrefixes()
+		compactStoredTerms(q.Construct.Template)
+		compactStoredTerms(q.Construct.Where, q.Construct.Optional...)
+	case q.Describe != nil && q.Describe.Prefixes != nil:
+		declare(q.Describe.Prefixes)
+		q.Describe.ExpandPrefixes()
+		for i := range q.Describe.Resources {
+			q.Describe.Resources[i] = storedTerm(q.Describe.Resources[i])
+		}
+		compactStoredTerms(q.Describe.Where, q.Describe.Optional...)
+	}
+}
+
+// compactStoredTerms applies storedTerm to every term of the patterns.
+func compactStoredTerms(patterns []TriplePattern, groups ...[]TriplePattern) {
+	for _, group := range append(groups, patterns) {
+		for i := range group {
+			group[i].Subject = storedTerm(group[i].Subject)
+			group[i].Predicate = storedTerm(group[i].Predicate)
+			group[i].Object = storedTerm(group[i].Object)
+		}
+	}
+}
+
+// storedTerm returns the compact form of a bracketed URI in the namespace
+// of a default named prefix, such as reg:title for
+// <https://regula.dev/ontology#title>, and other terms unchanged.
+func storedTerm(term string) string {
+	if len(term) < 2 || term[0] != '<' || term[len(term)-1] != '>' {
+		return term
+	}
+	uri := term[1 : len(term)-1]
+	for name, namespace := range defaultPrefixes {
+		if name != "" && strings.HasPrefix(uri, namespace) && len(uri) > len(namespace) {
+			return name + ":" + uri[len(namespace):]
+		}
+	}
+	return term
+}
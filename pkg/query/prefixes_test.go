@@ -0,0 +1,116 @@
+package query
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func TestPrefixMap_Compact(t *testing.T) {
+	prefixes := DefaultPrefixes().With(PrefixMap{
+		"acme":    "https://law.acme.example/",
+		"acmeReg": "https://law.acme.example/regs/",
+	})
+
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{"https://regula.dev/regulations/GDPR:Art17", "GDPR:Art17"},
+		{"https://regula.dev/ontology#title", "reg:title"},
+		{"https://law.acme.example/guidance/1", "acme:guidance/1"},
+		{"https://law.acme.example/regs/Reg1", "acmeReg:Reg1"},
+		{"https://law.acme.example/", "https://law.acme.example/"},
+		{"https://example.org/other", "https://example.org/other"},
+		{"Right to erasure", "Right to erasure"},
+	}
+
+	for _, tt := range tests {
+		if got := prefixes.Compact(tt.uri); got != tt.want {
+			t.Errorf("Compact(%q) = %q, want %q", tt.uri, got, tt.want)
+		}
+	}
+
+	if got := CompactURI("https://law.acme.example/regs/Reg1"); got != "https://law.acme.example/regs/Reg1" {
+		t.Errorf("CompactURI should only use the default prefixes, got %q", got)
+	}
+}
+
+func TestLoadPrefixes(t *testing.T) {
+	dir := t.TempDir()
+
+	prefixes, err := LoadPrefixes(filepath.Join(dir, PrefixesFile))
+	if err != nil || len(prefixes) != 0 {
+		t.Fatalf("LoadPrefixes(missing) = %v, %v; want no prefixes", prefixes, err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"valid", "uksi: http://www.legislation.gov.uk/uksi/\nacme: https://law.acme.example/\n", ""},
+		{"empty", "", ""},
+		{"built in", "reg: https://example.org/ontology#\n", "built in"},
+		{"bad name", "1st: https://example.org/\n", "invalid prefix name"},
+		{"relative", "acme: law/acme\n", "not an absolute URI"},
+		{"not a map", "- acme\n", "failed to parse"},
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(dir, tt.name+".yaml")
+		if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		prefixes, err := LoadPrefixes(path)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: LoadPrefixes() error = %v", tt.name, err)
+			} else if prefixes == nil {
+				t.Errorf("%s: LoadPrefixes() returned a nil map", tt.name)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: LoadPrefixes() error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestResourcePrefixes(t *testing.T) {
+	ts := store.NewTripleStore()
+	ts.Add("https://regula.dev/regulations/GDPR:Art17", "reg:title", "Right to erasure")
+	ts.Add("https://example.org/Thing", "reg:title", "Thing")
+
+	prefixes := ResourcePrefixes(ts)
+	if prefixes["GDPR"] != "https://regula.dev/regulations/GDPR:" {
+		t.Errorf("prefixes = %v", prefixes)
+	}
+	if len(prefixes) != 1 {
+		t.Errorf("only compactable resources should declare prefixes, got %v", prefixes)
+	}
+}
+
+func TestQuery_ExpandPrefixes(t *testing.T) {
+	q, err := ParseQuery(`PREFIX reg: <https://regula.dev/ontology#>
+		PREFIX ex: <https://example.org/>
+		SELECT ?t WHERE { GDPR:Art17 reg:title ?t . ex:Thing <http://purl.org/dc/terms/title> ?t }`)
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+	q.ExpandPrefixes(PrefixMap{"GDPR": "https://regula.dev/regulations/GDPR:", "ex": "https://ignored.example/"})
+
+	where := q.Select.Where
+	if where[0].Subject != "<https://regula.dev/regulations/GDPR:Art17>" {
+		t.Errorf("resource subject = %s", where[0].Subject)
+	}
+	if where[0].Predicate != "reg:title" || where[1].Predicate != "dc:title" {
+		t.Errorf("ontology predicates should stay compact, got %s and %s", where[0].Predicate, where[1].Predicate)
+	}
+	if where[1].Subject != "<https://example.org/Thing>" {
+		t.Errorf("the query's own PREFIX should take precedence, got %s", where[1].Subject)
+	}
+}
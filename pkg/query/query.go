@@ -98,6 +98,7 @@ type DescribeQuery struct {
 	Optional  [][]TriplePattern // OPTIONAL clause patterns
 	Filters   []Filter          // FILTER clauses
 	Prefixes  map[string]string // Prefix declarations
+	Depth     int               // DEPTH n: outgoing edges to follow (0 or 1 describes directly attached triples)
 }
 
 // TriplePattern represents a triple pattern in a WHERE clause.
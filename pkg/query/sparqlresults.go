@@ -0,0 +1,144 @@
+package query
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+)
+
+// sparqlResultsNamespace is the XML namespace of SPARQL Query Results XML.
+const sparqlResultsNamespace = "http://www.w3.org/2005/sparql-results#"
+
+// Kinds of RDF term in SPARQL results.
+const (
+	termURI     = "uri"
+	termLiteral = "literal"
+	termBNode   = "bnode"
+)
+
+// IsSPARQLResults reports whether the format is a W3C SPARQL query results
+// format. These carry full URIs, so results must not be compacted first.
+func (f OutputFormat) IsSPARQLResults() bool {
+	return f == FormatSRJ || f == FormatSRX
+}
+
+// sparqlTerm is an RDF term bound to a variable in SPARQL results JSON.
+type sparqlTerm struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// resultTerm classifies a bound value as an IRI, literal, or blank node.
+// Absolute URIs and ontology terms, which the store keeps in compact form
+// (reg:title), are IRIs; ontology terms are expanded so clients receive
+// absolute IRIs. Blank node labels drop the _: prefix. Everything else is
+// a plain literal, so results should be formatted before URIs are
+// compacted.
+func resultTerm(value string) sparqlTerm {
+	switch {
+	case strings.HasPrefix(value, "_:"):
+		return sparqlTerm{Type: termBNode, Value: value[2:]}
+	case strings.Contains(value, "://") || strings.HasPrefix(value, "urn:"):
+		return sparqlTerm{Type: termURI, Value: value}
+	}
+	if name, local, ok := strings.Cut(value, ":"); ok && name != "" && local != "" && !strings.ContainsAny(value, " \t\n") {
+		if namespace, ok := defaultPrefixes[name]; ok {
+			return sparqlTerm{Type: termURI, Value: namespace + local}
+		}
+	}
+	return sparqlTerm{Type: termLiteral, Value: value}
+}
+
+// FormatSPARQLJSON formats the result in the W3C SPARQL 1.1 Query Results
+// JSON format. Unbound variables are omitted from a solution.
+func (r *QueryResult) FormatSPARQLJSON() (string, error) {
+	type jsonResults struct {
+		Head struct {
+			Vars []string `json:"vars"`
+		} `json:"head"`
+		Results struct {
+			Bindings []map[string]sparqlTerm `json:"bindings"`
+		} `json:"results"`
+	}
+
+	var results jsonResults
+	results.Head.Vars = r.Variables
+	if results.Head.Vars == nil {
+		results.Head.Vars = []string{}
+	}
+	results.Results.Bindings = make([]map[string]sparqlTerm, 0, len(r.Bindings))
+	for _, binding := range r.Bindings {
+		solution := make(map[string]sparqlTerm, len(binding))
+		for _, variable := range r.Variables {
+			if value, ok := binding[variable]; ok && value != "" {
+				solution[variable] = resultTerm(value)
+			}
+		}
+		results.Results.Bindings = append(results.Results.Bindings, solution)
+	}
+
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// FormatSPARQLXML formats the result in the W3C SPARQL Query Results XML
+// format. Unbound variables are omitted from a result.
+func (r *QueryResult) FormatSPARQLXML() (string, error) {
+	type xmlVariable struct {
+		Name string `xml:"name,attr"`
+	}
+	type xmlBinding struct {
+		Name    string  `xml:"name,attr"`
+		URI     *string `xml:"uri"`
+		Literal *string `xml:"literal"`
+		BNode   *string `xml:"bnode"`
+	}
+	type xmlResult struct {
+		Bindings []xmlBinding `xml:"binding"`
+	}
+	type xmlResults struct {
+		XMLName   xml.Name `xml:"sparql"`
+		Namespace string   `xml:"xmlns,attr"`
+		Head      struct {
+			Variables []xmlVariable `xml:"variable"`
+		} `xml:"head"`
+		Results struct {
+			Results []xmlResult `xml:"result"`
+		} `xml:"results"`
+	}
+
+	results := xmlResults{Namespace: sparqlResultsNamespace}
+	for _, variable := range r.Variables {
+		results.Head.Variables = append(results.Head.Variables, xmlVariable{Name: variable})
+	}
+	for _, binding := range r.Bindings {
+		var result xmlResult
+		for _, variable := range r.Variables {
+			value, ok := binding[variable]
+			if !ok || value == "" {
+				continue
+			}
+			term := resultTerm(value)
+			bound := xmlBinding{Name: variable}
+			switch term.Type {
+			case termURI:
+				bound.URI = &term.Value
+			case termBNode:
+				bound.BNode = &term.Value
+			default:
+				bound.Literal = &term.Value
+			}
+			result.Bindings = append(result.Bindings, bound)
+		}
+		results.Results.Results = append(results.Results.Results, result)
+	}
+
+	data, err := xml.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(data) + "\n", nil
+}
@@ -0,0 +1,98 @@
+package query
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func sparqlResultsFixture() *QueryResult {
+	return &QueryResult{
+		Variables: []string{"article", "title", "type", "node"},
+		Bindings: []map[string]string{
+			{
+				"article": "https://regula.dev/regulations/GDPR:Art17",
+				"title":   `Right to "erasure" <forgotten>`,
+				"type":    "reg:Article",
+				"node":    "_:b0",
+			},
+			{"article": "urn:lex:eu:regulation:2016-04-27;679", "title": "GDPR:Art17"},
+		},
+		Count: 2,
+	}
+}
+
+func TestQueryResult_FormatSPARQLJSON(t *testing.T) {
+	output, err := sparqlResultsFixture().Format(FormatSRJ)
+	if err != nil {
+		t.Fatalf("Format(srj) error = %v", err)
+	}
+
+	var parsed struct {
+		Head struct {
+			Vars []string `json:"vars"`
+		} `json:"head"`
+		Results struct {
+			Bindings []map[string]sparqlTerm `json:"bindings"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+	}
+	if strings.Join(parsed.Head.Vars, ",") != "article,title,type,node" {
+		t.Errorf("head.vars = %v", parsed.Head.Vars)
+	}
+	if len(parsed.Results.Bindings) != 2 {
+		t.Fatalf("len(bindings) = %d, want 2", len(parsed.Results.Bindings))
+	}
+
+	first, second := parsed.Results.Bindings[0], parsed.Results.Bindings[1]
+	want := map[string]sparqlTerm{
+		"article": {termURI, "https://regula.dev/regulations/GDPR:Art17"},
+		"title":   {termLiteral, `Right to "erasure" <forgotten>`},
+		"type":    {termURI, "https://regula.dev/ontology#Article"},
+		"node":    {termBNode, "b0"},
+	}
+	for variable, term := range want {
+		if first[variable] != term {
+			t.Errorf("%s = %+v, want %+v", variable, first[variable], term)
+		}
+	}
+	if second["article"].Type != termURI || second["title"].Type != termLiteral {
+		t.Errorf("second solution = %+v", second)
+	}
+	if _, ok := second["type"]; ok {
+		t.Error("unbound variables should be omitted")
+	}
+}
+
+func TestQueryResult_FormatSPARQLXML(t *testing.T) {
+	output, err := sparqlResultsFixture().Format(FormatSRX)
+	if err != nil {
+		t.Fatalf("Format(srx) error = %v", err)
+	}
+	if err := xml.Unmarshal([]byte(output), new(struct{})); err != nil {
+		t.Fatalf("output is not well-formed XML: %v\n%s", err, output)
+	}
+	for _, want := range []string{
+		`<sparql xmlns="http://www.w3.org/2005/sparql-results#">`,
+		`<variable name="article"></variable>`,
+		`<uri>https://regula.dev/regulations/GDPR:Art17</uri>`,
+		`<literal>Right to &#34;erasure&#34; &lt;forgotten&gt;</literal>`,
+		`<uri>https://regula.dev/ontology#Article</uri>`,
+		`<bnode>b0</bnode>`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Count(output, "<binding ") != 6 {
+		t.Errorf("expected 6 bindings, unbound variables omitted:\n%s", output)
+	}
+
+	empty, err := (&QueryResult{Variables: []string{"a"}}).FormatSPARQLXML()
+	if err != nil || !strings.Contains(empty, "<results></results>") {
+		t.Errorf("empty result should have an empty results element: err=%v\n%s", err, empty)
+	}
+}
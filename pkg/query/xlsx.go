@@ -0,0 +1,36 @@
+package query
+
+import (
+	"strconv"
+
+	"github.com/coolbeans/regula/pkg/xlsx"
+)
+
+// ToXLSX renders the result as an Excel workbook with a single Results
+// sheet, one column per variable. Values that are plain numbers, and read
+// back unchanged, are written as numbers so they can be summed and sorted;
+// everything else, including identifiers such as 017, is written as text.
+func (r *QueryResult) ToXLSX() ([]byte, error) {
+	workbook := xlsx.NewWorkbook()
+	sheet := workbook.AddSheet("Results", r.Variables...)
+	for _, binding := range r.Bindings {
+		row := make([]any, len(r.Variables))
+		for i, variable := range r.Variables {
+			row[i] = cellValue(binding[variable])
+		}
+		sheet.AddRow(row...)
+	}
+	return workbook.Bytes()
+}
+
+// cellValue returns value as a number when it is one in canonical form,
+// and as a string otherwise. Empty values are nil, leaving the cell blank.
+func cellValue(value string) any {
+	if value == "" {
+		return nil
+	}
+	if number, err := strconv.ParseFloat(value, 64); err == nil && strconv.FormatFloat(number, 'f', -1, 64) == value {
+		return number
+	}
+	return value
+}
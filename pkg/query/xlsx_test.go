@@ -0,0 +1,69 @@
+package query
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestQueryResult_ToXLSX(t *testing.T) {
+	result := &QueryResult{
+		Variables: []string{"article", "title", "refs"},
+		Bindings: []map[string]string{
+			{"article": "GDPR:Art17", "title": "Right to erasure", "refs": "12"},
+			{"article": "GDPR:Art18", "refs": "017"},
+		},
+		Count: 2,
+	}
+
+	data, err := result.ToXLSX()
+	if err != nil {
+		t.Fatalf("ToXLSX() error = %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("not an .xlsx file: %v", err)
+	}
+	reader, err := archive.Open("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	content, _ := io.ReadAll(reader)
+	sheet := string(content)
+
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr" s="1"><is><t xml:space="preserve">article</t></is></c>`,
+		`<c r="C2"><v>12</v></c>`,
+		`<c r="C3" t="inlineStr"><is><t xml:space="preserve">017</t></is></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %s:\n%s", want, sheet)
+		}
+	}
+	if strings.Contains(sheet, `r="B3"`) {
+		t.Errorf("unbound value should leave the cell empty:\n%s", sheet)
+	}
+}
+
+func TestCellValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  any
+	}{
+		{"42", 42.0},
+		{"0.5", 0.5},
+		{"-3", -3.0},
+		{"017", "017"},
+		{"1e5", "1e5"},
+		{"GDPR:Art17", "GDPR:Art17"},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := cellValue(tt.value); got != tt.want {
+			t.Errorf("cellValue(%q) = %#v, want %#v", tt.value, got, tt.want)
+		}
+	}
+}
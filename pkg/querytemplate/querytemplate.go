@@ -0,0 +1,274 @@
+// Package querytemplate loads saved, parameterized SPARQL query templates
+// from YAML files, so a team can share named queries by committing them
+// next to its library.
+package querytemplate
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DirName is the directory below a library where saved templates live.
+const DirName = "templates"
+
+// Parameter types. A typed parameter's value is validated and normalized
+// before it is substituted.
+const (
+	// TypeString substitutes the value as given.
+	TypeString = "string"
+	// TypeArticle takes an article number in any common form ("17",
+	// "Art. 17", "Article 17", "1798.100") and substitutes the form used
+	// in article URIs, such as "Art17".
+	TypeArticle = "article"
+	// TypeTerm takes a defined term and substitutes its normalized form:
+	// lowercase with single spaces, as in reg:normalizedTerm.
+	TypeTerm = "term"
+	// TypeJurisdiction takes a jurisdiction code such as "EU" or "US-CA"
+	// and substitutes the lowercase form used in document base URIs.
+	TypeJurisdiction = "jurisdiction"
+	// TypeInteger takes a non-negative integer, such as a LIMIT.
+	TypeInteger = "integer"
+)
+
+var parameterTypes = map[string]bool{
+	"": true, TypeString: true, TypeArticle: true, TypeTerm: true, TypeJurisdiction: true, TypeInteger: true,
+}
+
+var (
+	articlePrefixPattern = regexp.MustCompile(`(?i)^(article|art\.?)\s*`)
+	articleNumberPattern = regexp.MustCompile(`^[0-9]+[A-Za-z]*(\.[0-9]+[A-Za-z]*)*$`)
+	jurisdictionPattern  = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,3})*$`)
+)
+
+// placeholderPattern matches a parameter reference such as {{actor}}.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+
+var parameterNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// Parameter describes a named parameter a template accepts.
+type Parameter struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Type is one of the Type constants; empty means TypeString.
+	Type     string `yaml:"type,omitempty" json:"type,omitempty"`
+	Default  string `yaml:"default,omitempty" json:"default,omitempty"`
+	Required bool   `yaml:"required,omitempty" json:"required,omitempty"`
+}
+
+// Normalize validates a value for the parameter's type and returns the
+// form to substitute.
+func (p Parameter) Normalize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch p.Type {
+	case "", TypeString:
+		return value, nil
+	case TypeArticle:
+		number := strings.TrimSpace(articlePrefixPattern.ReplaceAllString(value, ""))
+		if !articleNumberPattern.MatchString(number) {
+			return "", fmt.Errorf("invalid article %q: expected a number such as 17, Art. 17, or 1798.100", value)
+		}
+		return "Art" + number, nil
+	case TypeTerm:
+		term := strings.ToLower(strings.Join(strings.Fields(value), " "))
+		if term == "" {
+			return "", fmt.Errorf("empty term")
+		}
+		return term, nil
+	case TypeJurisdiction:
+		if !jurisdictionPattern.MatchString(value) {
+			return "", fmt.Errorf("invalid jurisdiction %q: expected a code such as EU or US-CA", value)
+		}
+		return strings.ToLower(value), nil
+	case TypeInteger:
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 0 {
+			return "", fmt.Errorf("invalid integer %q", value)
+		}
+		return strconv.Itoa(number), nil
+	default:
+		return "", fmt.Errorf("unknown parameter type %q", p.Type)
+	}
+}
+
+// Template is a saved query. The query refers to parameters as {{name}}.
+//
+//	description: Obligations borne by an actor
+//	parameters:
+//	  - name: actor
+//	    description: Duty bearer, such as controller or processor
+//	    type: string
+//	    default: controller
+//	query: |
+//	  SELECT ?article ?obligation WHERE {
+//	    ?obligation reg:dutyBearer ?bearer .
+//	    ?obligation reg:partOf ?article .
+//	    FILTER(REGEX(?bearer, "(?i)^{{actor}}$"))
+//	  }
+type Template struct {
+	// Name is the file's path below the templates directory without its
+	// extension, such as "my-team/dpia-triggers".
+	Name        string      `yaml:"-" json:"name"`
+	Description string      `yaml:"description" json:"description"`
+	Parameters  []Parameter `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Query       string      `yaml:"query" json:"query"`
+	// Path is the file the template was loaded from.
+	Path string `yaml:"-" json:"path,omitempty"`
+}
+
+// Parse parses a template's YAML content and validates it.
+func Parse(name string, data []byte) (*Template, error) {
+	var template Template
+	if err := yaml.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	template.Name = name
+
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Validate checks that the template has a query, that parameter names are
+// unique identifiers with known types and valid defaults, and that every
+// placeholder in the query is a declared parameter.
+func (t *Template) Validate() error {
+	if strings.TrimSpace(t.Query) == "" {
+		return fmt.Errorf("template %s has no query", t.Name)
+	}
+
+	declared := make(map[string]bool)
+	for _, parameter := range t.Parameters {
+		if !parameterNamePattern.MatchString(parameter.Name) {
+			return fmt.Errorf("template %s: invalid parameter name %q", t.Name, parameter.Name)
+		}
+		if declared[parameter.Name] {
+			return fmt.Errorf("template %s: duplicate parameter %q", t.Name, parameter.Name)
+		}
+		declared[parameter.Name] = true
+
+		if !parameterTypes[parameter.Type] {
+			return fmt.Errorf("template %s: parameter %s has unknown type %q", t.Name, parameter.Name, parameter.Type)
+		}
+		if parameter.Default != "" {
+			if _, err := parameter.Normalize(parameter.Default); err != nil {
+				return fmt.Errorf("template %s: parameter %s: invalid default: %w", t.Name, parameter.Name, err)
+			}
+		}
+	}
+	for _, match := range placeholderPattern.FindAllStringSubmatch(t.Query, -1) {
+		if !declared[match[1]] {
+			return fmt.Errorf("template %s: query uses undeclared parameter %q", t.Name, match[1])
+		}
+	}
+	return nil
+}
+
+// LoadDir loads the *.yaml and *.yml templates in dir and its
+// subdirectories, sorted by name. A missing directory has no templates.
+func LoadDir(dir string) ([]*Template, error) {
+	var templates []*Template
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		extension := filepath.Ext(path)
+		if entry.IsDir() || (extension != ".yaml" && extension != ".yml") {
+			return nil
+		}
+
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read template: %w", err)
+		}
+		template, err := Parse(filepath.ToSlash(strings.TrimSuffix(relative, extension)), data)
+		if err != nil {
+			return err
+		}
+		template.Path = path
+		templates = append(templates, template)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	for i := 1; i < len(templates); i++ {
+		if templates[i].Name == templates[i-1].Name {
+			return nil, fmt.Errorf("template %s is defined by both %s and %s",
+				templates[i].Name, templates[i-1].Path, templates[i].Path)
+		}
+	}
+	return templates, nil
+}
+
+// Render substitutes parameter values into the query. Parameters not in
+// values take their defaults, and typed values are normalized; a required
+// parameter with neither a value nor a default is an error, as are an
+// invalid value and a value for an undeclared parameter. Double quotes and
+// backslashes in values are escaped, so a value cannot end the string
+// literal it is placed in.
+func (t *Template) Render(values map[string]string) (string, error) {
+	resolved := make(map[string]string, len(t.Parameters))
+	for _, parameter := range t.Parameters {
+		value, ok := values[parameter.Name]
+		if !ok || value == "" {
+			value = parameter.Default
+		}
+		if value == "" {
+			if parameter.Required {
+				return "", fmt.Errorf("template %s: required parameter %s not provided: %s", t.Name, parameter.Name, parameter.Description)
+			}
+			resolved[parameter.Name] = ""
+			continue
+		}
+		normalized, err := parameter.Normalize(value)
+		if err != nil {
+			return "", fmt.Errorf("template %s: parameter %s: %w", t.Name, parameter.Name, err)
+		}
+		resolved[parameter.Name] = normalized
+	}
+	for name := range values {
+		if _, ok := resolved[name]; !ok {
+			return "", fmt.Errorf("template %s has no parameter %q", t.Name, name)
+		}
+	}
+
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return placeholderPattern.ReplaceAllStringFunc(t.Query, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		return escaper.Replace(resolved[name])
+	}), nil
+}
+
+// ParseValues parses name=value pairs, as given to --param.
+func ParseValues(pairs []string) (map[string]string, error) {
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid parameter %q: expected name=value", pair)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,213 @@
+package querytemplate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleTemplate = `description: Obligations borne by an actor
+parameters:
+  - name: actor
+    description: Duty bearer
+    default: controller
+  - name: article
+    description: Article URI
+    required: true
+query: |
+  SELECT ?obligation WHERE {
+    ?obligation reg:dutyBearer "{{actor}}" .
+    ?obligation reg:partOf <{{ article }}> .
+  }
+`
+
+func TestParse(t *testing.T) {
+	template, err := Parse("team/by-actor", []byte(sampleTemplate))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if template.Name != "team/by-actor" || template.Description != "Obligations borne by an actor" {
+		t.Errorf("template = %+v", template)
+	}
+	if len(template.Parameters) != 2 || template.Parameters[0].Default != "controller" || !template.Parameters[1].Required {
+		t.Errorf("parameters = %+v", template.Parameters)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"no query":            "description: empty\n",
+		"undeclared":          "query: SELECT ?a WHERE { ?a reg:title \"{{title}}\" }\n",
+		"duplicate parameter": "parameters: [{name: a}, {name: a}]\nquery: SELECT ?a WHERE { ?a ?b ?c }\n",
+		"invalid name":        "parameters: [{name: \"a b\"}]\nquery: SELECT ?a WHERE { ?a ?b ?c }\n",
+		"not YAML":            "query: [\n",
+	}
+	for name, content := range tests {
+		if _, err := Parse(name, []byte(content)); err == nil {
+			t.Errorf("%s: Parse() expected error", name)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	template, err := Parse("by-actor", []byte(sampleTemplate))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	query, err := template.Render(map[string]string{"article": "https://regula.dev/regulations/GDPR:Art28"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(query, `reg:dutyBearer "controller"`) || !strings.Contains(query, "<https://regula.dev/regulations/GDPR:Art28>") {
+		t.Errorf("Render() with defaults = %q", query)
+	}
+
+	query, err = template.Render(map[string]string{"actor": `pro"cessor`, "article": "x"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(query, `"pro\"cessor"`) {
+		t.Errorf("Render() should escape quotes: %q", query)
+	}
+
+	if _, err := template.Render(nil); err == nil || !strings.Contains(err.Error(), "required parameter article") {
+		t.Errorf("Render() without a required parameter: err = %v", err)
+	}
+	if _, err := template.Render(map[string]string{"article": "x", "who": "y"}); err == nil {
+		t.Error("Render() expected error for an undeclared parameter")
+	}
+}
+
+func TestParameter_Normalize(t *testing.T) {
+	tests := []struct {
+		parameterType string
+		value         string
+		want          string
+		wantErr       bool
+	}{
+		{TypeString, " As Given ", "As Given", false},
+		{"", "x", "x", false},
+		{TypeArticle, "17", "Art17", false},
+		{TypeArticle, "Art. 17", "Art17", false},
+		{TypeArticle, "article 6", "Art6", false},
+		{TypeArticle, "Art17", "Art17", false},
+		{TypeArticle, "1798.100", "Art1798.100", false},
+		{TypeArticle, "6A", "Art6A", false},
+		{TypeArticle, "seventeen", "", true},
+		{TypeArticle, "17; DROP", "", true},
+		{TypeTerm, "  Personal   Data ", "personal data", false},
+		{TypeTerm, "   ", "", true},
+		{TypeJurisdiction, "US-CA", "us-ca", false},
+		{TypeJurisdiction, "eu", "eu", false},
+		{TypeJurisdiction, "California", "", true},
+		{TypeInteger, "25", "25", false},
+		{TypeInteger, "-1", "", true},
+		{"date", "2024-01-01", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := Parameter{Name: "p", Type: tt.parameterType}.Normalize(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Normalize(%s, %q) error = %v, wantErr %v", tt.parameterType, tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Normalize(%s, %q) = %q, want %q", tt.parameterType, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRender_Typed(t *testing.T) {
+	template := &Template{
+		Name: "refs",
+		Parameters: []Parameter{
+			{Name: "article", Type: TypeArticle, Default: "17"},
+			{Name: "limit", Type: TypeInteger},
+		},
+		Query: `SELECT ?a WHERE { ?a reg:references ?t . FILTER(STRENDS(?t, ":{{article}}")) } LIMIT {{limit}}`,
+	}
+	if err := template.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	query, err := template.Render(map[string]string{"article": "Article 6", "limit": "5"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(query, `":Art6"`) || !strings.HasSuffix(query, "LIMIT 5") {
+		t.Errorf("Render() = %q", query)
+	}
+
+	if _, err := template.Render(map[string]string{"limit": "many"}); err == nil || !strings.Contains(err.Error(), "parameter limit") {
+		t.Errorf("Render() with an invalid integer: err = %v", err)
+	}
+}
+
+func TestValidate_Types(t *testing.T) {
+	unknown := &Template{Name: "t", Query: "SELECT ?a WHERE { ?a ?b ?c }", Parameters: []Parameter{{Name: "a", Type: "date"}}}
+	if err := unknown.Validate(); err == nil || !strings.Contains(err.Error(), "unknown type") {
+		t.Errorf("Validate() with an unknown type: err = %v", err)
+	}
+
+	badDefault := &Template{Name: "t", Query: "SELECT ?a WHERE { ?a ?b ?c }", Parameters: []Parameter{{Name: "a", Type: TypeArticle, Default: "first"}}}
+	if err := badDefault.Validate(); err == nil || !strings.Contains(err.Error(), "invalid default") {
+		t.Errorf("Validate() with an invalid default: err = %v", err)
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "team", "by-actor.yaml"), sampleTemplate)
+	writeFile(t, filepath.Join(dir, "articles.yml"), "description: Articles\nquery: SELECT ?a WHERE { ?a rdf:type reg:Article }\n")
+	writeFile(t, filepath.Join(dir, "README.md"), "not a template")
+
+	templates, err := LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir() error = %v", err)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("len(templates) = %d, want 2", len(templates))
+	}
+	if templates[0].Name != "articles" || templates[1].Name != "team/by-actor" {
+		t.Errorf("names = %s, %s", templates[0].Name, templates[1].Name)
+	}
+	if templates[1].Path != filepath.Join(dir, "team", "by-actor.yaml") {
+		t.Errorf("Path = %s", templates[1].Path)
+	}
+
+	// The same name from .yaml and .yml is ambiguous.
+	writeFile(t, filepath.Join(dir, "articles.yaml"), "query: SELECT ?a WHERE { ?a ?b ?c }\n")
+	if _, err := LoadDir(dir); err == nil || !strings.Contains(err.Error(), "defined by both") {
+		t.Errorf("LoadDir() with a duplicate name: err = %v", err)
+	}
+
+	templates, err = LoadDir(filepath.Join(dir, "missing"))
+	if err != nil || len(templates) != 0 {
+		t.Errorf("LoadDir() of a missing directory = %v, %v", templates, err)
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	values, err := ParseValues([]string{"actor=processor", "filter=a=b", "empty="})
+	if err != nil {
+		t.Fatalf("ParseValues() error = %v", err)
+	}
+	if values["actor"] != "processor" || values["filter"] != "a=b" || values["empty"] != "" {
+		t.Errorf("values = %v", values)
+	}
+	if _, err := ParseValues([]string{"actor"}); err == nil {
+		t.Error("ParseValues() expected error without =")
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,404 @@
+// Package regula is the stable Go API for embedding regula: ingest
+// regulation text into a knowledge graph, then query it with SPARQL,
+// analyze the impact of changing a provision, and match compliance
+// scenarios to the provisions that apply.
+//
+//	graph, err := regula.Ingest(file, regula.Options{DocumentID: "gdpr"})
+//	if err != nil {
+//		return err
+//	}
+//	result, err := graph.Query("SELECT ?a WHERE { ?a rdf:type reg:Article }")
+//	impact, err := graph.Impact("Art17", regula.ImpactOptions{Depth: 2})
+//	matches, err := graph.Match("access_request")
+//
+// # Stability
+//
+// The exported API of this package is stable: within a major version,
+// identifiers are not removed or renamed, function signatures do not
+// change, and struct fields are only added, never removed or retyped.
+// Result types are plain data owned by this package and do not expose
+// internal types. The one exception is Graph.Store, an escape hatch to the
+// underlying triple store, whose type is governed by pkg/store and may
+// change between minor versions.
+//
+// Everything else under pkg/ is internal to regula in practice and may
+// change without notice.
+package regula
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/analysis"
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/query"
+	"github.com/coolbeans/regula/pkg/simulate"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// DefaultBaseURI is the base URI used for graph nodes when Options.BaseURI
+// is empty.
+const DefaultBaseURI = "https://regula.dev/regulations/"
+
+// DefaultImpactDepth is the transitive depth used when ImpactOptions.Depth
+// is zero.
+const DefaultImpactDepth = 2
+
+// Errors returned by Graph methods, for use with errors.Is.
+var (
+	// ErrInvalidQuery is returned for SPARQL queries that fail to parse or
+	// execute.
+	ErrInvalidQuery = errors.New("invalid query")
+	// ErrProvisionNotFound is returned when an impact target is not in the
+	// graph.
+	ErrProvisionNotFound = errors.New("provision not found")
+	// ErrUnknownScenario is returned when a scenario name is not one of
+	// Scenarios.
+	ErrUnknownScenario = errors.New("unknown scenario")
+	// ErrInvalidDirection is returned for an ImpactOptions.Direction other
+	// than the Direction constants.
+	ErrInvalidDirection = errors.New("invalid direction")
+)
+
+// Options configures ingestion.
+type Options struct {
+	// DocumentID names the document; its upper-cased form prefixes node
+	// URIs (e.g. "gdpr" gives .../GDPR:Art17). Required for Ingest;
+	// IngestFile defaults it to the file name without extension.
+	DocumentID string
+
+	// Format is an optional format hint ("eu", "us", "uk", ...). When
+	// empty the format is detected from the text.
+	Format string
+
+	// BaseURI is the base URI for graph nodes. Defaults to DefaultBaseURI.
+	BaseURI string
+}
+
+// Stats summarizes an ingested graph.
+type Stats struct {
+	Triples     int `json:"triples"`
+	Articles    int `json:"articles"`
+	Chapters    int `json:"chapters"`
+	Definitions int `json:"definitions"`
+	References  int `json:"references"`
+	Rights      int `json:"rights"`
+	Obligations int `json:"obligations"`
+}
+
+// Graph is an ingested regulation. A Graph is safe for concurrent reads.
+type Graph struct {
+	documentID  string
+	baseURI     string
+	stats       Stats
+	store       *store.TripleStore
+	executor    *query.Executor
+	document    *extract.Document
+	annotations []*extract.SemanticAnnotation
+}
+
+// Ingest parses regulation text from r and builds its knowledge graph:
+// document structure, definitions, resolved cross-references, and rights
+// and obligations.
+func Ingest(r io.Reader, opts Options) (*Graph, error) {
+	if opts.DocumentID == "" {
+		return nil, fmt.Errorf("document ID is required")
+	}
+	baseURI := opts.BaseURI
+	if baseURI == "" {
+		baseURI = DefaultBaseURI
+	}
+
+	parser := extract.NewParser()
+	if opts.Format != "" {
+		parser.SetFormatHint(extract.DocumentFormat(opts.Format))
+	}
+	doc, err := parser.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+
+	semExtractor := extract.NewSemanticExtractor()
+	resolver := extract.NewReferenceResolver(baseURI, strings.ToUpper(opts.DocumentID))
+	resolver.IndexDocument(doc)
+
+	tripleStore := store.NewTripleStore()
+	builder := store.NewGraphBuilder(tripleStore, baseURI)
+	buildStats, err := builder.BuildComplete(doc, extract.NewDefinitionExtractor(), extract.NewReferenceExtractor(), resolver, semExtractor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build graph: %w", err)
+	}
+
+	return &Graph{
+		documentID: opts.DocumentID,
+		baseURI:    baseURI,
+		stats: Stats{
+			Triples:     buildStats.TotalTriples,
+			Articles:    buildStats.Articles,
+			Chapters:    buildStats.Chapters,
+			Definitions: buildStats.Definitions,
+			References:  buildStats.References,
+			Rights:      buildStats.Rights,
+			Obligations: buildStats.Obligations,
+		},
+		store:       tripleStore,
+		executor:    query.NewExecutor(tripleStore),
+		document:    doc,
+		annotations: semExtractor.ExtractFromDocument(doc),
+	}, nil
+}
+
+// IngestFile ingests the regulation text in the file at path.
+func IngestFile(path string, opts Options) (*Graph, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open source: %w", err)
+	}
+	defer file.Close()
+
+	if opts.DocumentID == "" {
+		opts.DocumentID = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return Ingest(file, opts)
+}
+
+// DocumentID returns the ID the graph was ingested under.
+func (g *Graph) DocumentID() string {
+	return g.documentID
+}
+
+// Stats returns the graph's ingestion statistics.
+func (g *Graph) Stats() Stats {
+	return g.stats
+}
+
+// Store returns the underlying triple store. Its API is not covered by
+// this package's stability guarantee.
+func (g *Graph) Store() *store.TripleStore {
+	return g.store
+}
+
+// Triple is an RDF triple.
+type Triple struct {
+	Subject   string `json:"subject"`
+	Predicate string `json:"predicate"`
+	Object    string `json:"object"`
+}
+
+// QueryResult is the result of a SPARQL query. SELECT queries fill
+// Variables and Rows; CONSTRUCT and DESCRIBE queries fill Triples.
+type QueryResult struct {
+	// Type is "select", "construct", or "describe".
+	Type      string              `json:"type"`
+	Variables []string            `json:"variables,omitempty"`
+	Rows      []map[string]string `json:"rows,omitempty"`
+	Triples   []Triple            `json:"triples,omitempty"`
+	Count     int                 `json:"count"`
+	Duration  time.Duration       `json:"duration"`
+}
+
+// Query runs a SPARQL SELECT, CONSTRUCT, or DESCRIBE query.
+func (g *Graph) Query(sparql string) (*QueryResult, error) {
+	return g.QueryContext(context.Background(), sparql)
+}
+
+// QueryContext runs a SPARQL query, stopping early if ctx is canceled.
+func (g *Graph) QueryContext(ctx context.Context, sparql string) (*QueryResult, error) {
+	if strings.TrimSpace(sparql) == "" {
+		return nil, fmt.Errorf("%w: query is empty", ErrInvalidQuery)
+	}
+	parsedQuery, err := query.ParseQuery(sparql)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+	}
+
+	startTime := time.Now()
+	result := &QueryResult{}
+	switch parsedQuery.Type {
+	case query.ConstructQueryType, query.DescribeQueryType:
+		var constructResult *query.ConstructResult
+		if parsedQuery.Type == query.ConstructQueryType {
+			result.Type = "construct"
+			constructResult, err = g.executor.ExecuteConstructWithContext(ctx, parsedQuery)
+		} else {
+			result.Type = "describe"
+			constructResult, err = g.executor.ExecuteDescribeWithContext(ctx, parsedQuery)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+		}
+		result.Triples = make([]Triple, len(constructResult.Triples))
+		for i, triple := range constructResult.Triples {
+			result.Triples[i] = Triple{Subject: triple.Subject, Predicate: triple.Predicate, Object: triple.Object}
+		}
+		result.Count = constructResult.Count
+	default:
+		selectResult, err := g.executor.ExecuteWithContext(ctx, parsedQuery)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+		}
+		result.Type = "select"
+		result.Variables = selectResult.Variables
+		result.Rows = selectResult.Bindings
+		result.Count = selectResult.Count
+	}
+	result.Duration = time.Since(startTime)
+	return result, nil
+}
+
+// Impact directions.
+const (
+	DirectionIncoming = "incoming"
+	DirectionOutgoing = "outgoing"
+	DirectionBoth     = "both"
+)
+
+// ImpactOptions configures impact analysis.
+type ImpactOptions struct {
+	// Depth is the maximum transitive depth. Defaults to
+	// DefaultImpactDepth.
+	Depth int
+
+	// Direction is DirectionIncoming (provisions that reference the
+	// target), DirectionOutgoing (provisions the target references), or
+	// DirectionBoth, the default.
+	Direction string
+}
+
+// AffectedProvision is a provision reached by impact analysis.
+type AffectedProvision struct {
+	URI   string `json:"uri"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Depth int    `json:"depth"`
+	// Impact is "direct" or "transitive".
+	Impact string `json:"impact"`
+	// Direction is "incoming" or "outgoing".
+	Direction string `json:"direction"`
+}
+
+// ImpactResult lists the provisions affected by a change to a target.
+type ImpactResult struct {
+	TargetURI       string              `json:"target_uri"`
+	TargetLabel     string              `json:"target_label"`
+	Affected        []AffectedProvision `json:"affected"`
+	TotalAffected   int                 `json:"total_affected"`
+	MaxDepthReached int                 `json:"max_depth_reached"`
+}
+
+// Impact finds the provisions affected by a change to provision, given as
+// a full URI or a short ID such as "Art17" or "GDPR:Art17".
+func (g *Graph) Impact(provision string, opts ImpactOptions) (*ImpactResult, error) {
+	if provision == "" {
+		return nil, fmt.Errorf("%w: provision is empty", ErrProvisionNotFound)
+	}
+	depth := opts.Depth
+	if depth <= 0 {
+		depth = DefaultImpactDepth
+	}
+	var direction analysis.ImpactDirection
+	switch opts.Direction {
+	case "", DirectionBoth:
+		direction = analysis.DirectionBoth
+	case DirectionIncoming:
+		direction = analysis.DirectionIncoming
+	case DirectionOutgoing:
+		direction = analysis.DirectionOutgoing
+	default:
+		return nil, fmt.Errorf("%w: %s (use incoming, outgoing, or both)", ErrInvalidDirection, opts.Direction)
+	}
+
+	analyzer := analysis.NewImpactAnalyzer(g.store, g.baseURI)
+	provisionURI := analyzer.ResolveShortID(provision)
+	if len(g.store.Find(provisionURI, "", "")) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrProvisionNotFound, provision)
+	}
+	analysisResult := analyzer.Analyze(provisionURI, depth, direction)
+
+	result := &ImpactResult{
+		TargetURI:   analysisResult.TargetURI,
+		TargetLabel: analysisResult.TargetLabel,
+	}
+	for _, nodes := range [][]*analysis.ImpactNode{analysisResult.DirectIncoming, analysisResult.DirectOutgoing, analysisResult.TransitiveNodes} {
+		for _, node := range nodes {
+			result.Affected = append(result.Affected, AffectedProvision{
+				URI:       node.URI,
+				Label:     node.Label,
+				Type:      node.Type,
+				Depth:     node.Depth,
+				Impact:    string(node.Impact),
+				Direction: node.Direction,
+			})
+		}
+	}
+	if analysisResult.Summary != nil {
+		result.TotalAffected = analysisResult.Summary.TotalAffected
+		result.MaxDepthReached = analysisResult.Summary.MaxDepthReached
+	}
+	return result, nil
+}
+
+// Scenarios returns the names of the predefined scenarios Match accepts.
+func Scenarios() []string {
+	names := make([]string, 0, len(simulate.PredefinedScenarios))
+	for name := range simulate.PredefinedScenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MatchedProvision is a provision that applies to a scenario.
+type MatchedProvision struct {
+	URI   string `json:"uri"`
+	Title string `json:"title"`
+	// Relevance is "DIRECT", "TRIGGERED", or "RELATED".
+	Relevance string   `json:"relevance"`
+	Score     float64  `json:"score"`
+	Reasons   []string `json:"reasons"`
+}
+
+// MatchResult lists the provisions that apply to a scenario.
+type MatchResult struct {
+	Scenario       string             `json:"scenario"`
+	Matches        []MatchedProvision `json:"matches"`
+	DirectCount    int                `json:"direct_count"`
+	TriggeredCount int                `json:"triggered_count"`
+	RelatedCount   int                `json:"related_count"`
+}
+
+// Match finds the provisions that apply to a predefined scenario (see
+// Scenarios).
+func (g *Graph) Match(scenarioName string) (*MatchResult, error) {
+	scenario, ok := simulate.PredefinedScenarios[scenarioName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownScenario, scenarioName)
+	}
+
+	matcher := simulate.NewProvisionMatcher(g.store, g.baseURI, g.annotations, g.document)
+	matchResult := matcher.Match(scenario)
+
+	result := &MatchResult{Scenario: scenarioName}
+	for _, match := range matchResult.AllMatches {
+		result.Matches = append(result.Matches, MatchedProvision{
+			URI:       match.URI,
+			Title:     match.Title,
+			Relevance: string(match.Relevance),
+			Score:     match.Score,
+			Reasons:   match.MatchReasons,
+		})
+	}
+	if matchResult.Summary != nil {
+		result.DirectCount = matchResult.Summary.DirectCount
+		result.TriggeredCount = matchResult.Summary.TriggeredCount
+		result.RelatedCount = matchResult.Summary.RelatedCount
+	}
+	return result, nil
+}
@@ -0,0 +1,129 @@
+package regula
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func ingestGDPR(t *testing.T) *Graph {
+	t.Helper()
+	path := filepath.Join("..", "..", "testdata", "gdpr.txt")
+	if _, err := os.Stat(path); err != nil {
+		t.Skipf("GDPR test data not available: %v", err)
+	}
+	graph, err := IngestFile(path, Options{})
+	if err != nil {
+		t.Fatalf("IngestFile failed: %v", err)
+	}
+	return graph
+}
+
+func TestIngestFile(t *testing.T) {
+	graph := ingestGDPR(t)
+
+	if graph.DocumentID() != "gdpr" {
+		t.Errorf("DocumentID = %q, want gdpr", graph.DocumentID())
+	}
+	stats := graph.Stats()
+	if stats.Articles != 99 || stats.Triples == 0 || stats.Definitions == 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if graph.Store().Count() != stats.Triples {
+		t.Errorf("store has %d triples, stats report %d", graph.Store().Count(), stats.Triples)
+	}
+}
+
+func TestIngest_RequiresDocumentID(t *testing.T) {
+	if _, err := Ingest(strings.NewReader("Article 1\nScope"), Options{}); err == nil {
+		t.Error("expected error for missing document ID")
+	}
+}
+
+func TestGraph_Query(t *testing.T) {
+	graph := ingestGDPR(t)
+
+	selectResult, err := graph.Query("SELECT ?a WHERE { ?a rdf:type reg:Article }")
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if selectResult.Type != "select" || selectResult.Count != 99 || len(selectResult.Rows) != 99 {
+		t.Errorf("select: type %s count %d rows %d, want select 99 99", selectResult.Type, selectResult.Count, len(selectResult.Rows))
+	}
+	if len(selectResult.Variables) != 1 || selectResult.Rows[0]["a"] == "" {
+		t.Errorf("select rows not keyed by variable: %v %v", selectResult.Variables, selectResult.Rows[0])
+	}
+
+	constructResult, err := graph.Query("CONSTRUCT { ?a reg:title ?t } WHERE { ?a rdf:type reg:Article . ?a reg:title ?t }")
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if constructResult.Type != "construct" || len(constructResult.Triples) == 0 {
+		t.Errorf("construct: type %s with %d triples", constructResult.Type, len(constructResult.Triples))
+	}
+
+	if _, err := graph.Query("SELEKT nothing"); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("malformed query error = %v, want ErrInvalidQuery", err)
+	}
+}
+
+func TestGraph_Impact(t *testing.T) {
+	graph := ingestGDPR(t)
+
+	result, err := graph.Impact("Art17", ImpactOptions{Depth: 1})
+	if err != nil {
+		t.Fatalf("Impact failed: %v", err)
+	}
+	if !strings.HasSuffix(result.TargetURI, "GDPR:Art17") {
+		t.Errorf("TargetURI = %s, want suffix GDPR:Art17", result.TargetURI)
+	}
+	if result.TotalAffected == 0 || result.TotalAffected != len(result.Affected) {
+		t.Errorf("TotalAffected = %d with %d affected", result.TotalAffected, len(result.Affected))
+	}
+	for _, provision := range result.Affected {
+		if provision.Depth != 1 || provision.Impact != "direct" {
+			t.Errorf("depth 1 analysis returned %+v", provision)
+		}
+	}
+
+	if _, err := graph.Impact("Art999", ImpactOptions{}); !errors.Is(err, ErrProvisionNotFound) {
+		t.Errorf("missing provision error = %v, want ErrProvisionNotFound", err)
+	}
+	if _, err := graph.Impact("Art17", ImpactOptions{Direction: "sideways"}); !errors.Is(err, ErrInvalidDirection) {
+		t.Errorf("bad direction error = %v, want ErrInvalidDirection", err)
+	}
+}
+
+func TestGraph_Match(t *testing.T) {
+	graph := ingestGDPR(t)
+
+	result, err := graph.Match("access_request")
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+	if len(result.Matches) == 0 || result.DirectCount == 0 {
+		t.Errorf("access_request matched %d provisions (%d direct)", len(result.Matches), result.DirectCount)
+	}
+
+	if _, err := graph.Match("no_such_scenario"); !errors.Is(err, ErrUnknownScenario) {
+		t.Errorf("unknown scenario error = %v, want ErrUnknownScenario", err)
+	}
+}
+
+func TestScenarios(t *testing.T) {
+	names := Scenarios()
+	found := false
+	for i, name := range names {
+		if i > 0 && names[i-1] > name {
+			t.Errorf("Scenarios not sorted: %v", names)
+		}
+		if name == "access_request" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Scenarios missing access_request: %v", names)
+	}
+}
@@ -0,0 +1,123 @@
+package repl
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/query"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// sparqlKeywords are completed case-insensitively and inserted in upper case.
+var sparqlKeywords = []string{
+	"SELECT", "CONSTRUCT", "DESCRIBE", "WHERE", "FILTER", "OPTIONAL",
+	"DISTINCT", "ORDER", "BY", "GROUP", "HAVING", "LIMIT", "OFFSET",
+	"PREFIX", "COUNT", "REGEX", "CONTAINS", "ASC", "DESC",
+}
+
+// Completer offers prefix completion of SPARQL keywords, meta-commands, and
+// the predicates, classes, and resources in a graph, in the compact form
+// the query parser accepts (reg:title, GDPR:Art17).
+type Completer struct {
+	words []string
+}
+
+// NewCompleter indexes the URIs in ts. Extra words, such as meta-commands
+// and template names, are completed as given.
+func NewCompleter(ts *store.TripleStore, extra ...string) *Completer {
+	seen := make(map[string]bool)
+	var words []string
+	add := func(word string) {
+		if word != "" && !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+
+	for _, word := range extra {
+		add(word)
+	}
+	if ts != nil {
+		for _, predicate := range ts.Predicates() {
+			add(compactForCompletion(predicate))
+		}
+		for _, triple := range ts.Find("", store.RDFType, "") {
+			add(compactForCompletion(triple.Object))
+		}
+		for _, subject := range ts.Subjects() {
+			add(compactForCompletion(subject))
+		}
+	}
+
+	sort.Strings(words)
+	return &Completer{words: words}
+}
+
+// compactForCompletion returns the compact form of uri, or "" when it is a
+// full URI with no known prefix and so cannot be typed in compact form.
+// Predicates and classes are already stored compact (reg:title).
+func compactForCompletion(uri string) string {
+	compact := query.CompactURI(uri)
+	if compact == uri && (strings.Contains(uri, "://") || !strings.Contains(uri, ":")) {
+		return ""
+	}
+	if strings.ContainsAny(compact, " \t\n") {
+		return ""
+	}
+	return compact
+}
+
+// Complete returns the start offset of the word ending at cursor in line
+// and the words it could be completed to.
+func (c *Completer) Complete(line string, cursor int) (int, []string) {
+	if cursor > len(line) {
+		cursor = len(line)
+	}
+	start := cursor
+	for start > 0 && !isWordBoundary(line[start-1]) {
+		start--
+	}
+	prefix := line[start:cursor]
+	if prefix == "" {
+		return start, nil
+	}
+
+	var candidates []string
+	upper := strings.ToUpper(prefix)
+	for _, keyword := range sparqlKeywords {
+		if strings.HasPrefix(keyword, upper) && keyword != upper {
+			candidates = append(candidates, keyword)
+		}
+	}
+
+	index := sort.SearchStrings(c.words, prefix)
+	for ; index < len(c.words) && strings.HasPrefix(c.words[index], prefix); index++ {
+		if c.words[index] != prefix {
+			candidates = append(candidates, c.words[index])
+		}
+	}
+	return start, candidates
+}
+
+// WordCount returns the number of indexed words.
+func (c *Completer) WordCount() int {
+	return len(c.words)
+}
+
+func isWordBoundary(b byte) bool {
+	return strings.IndexByte(" \t\n{}(),;<>\"", b) >= 0
+}
+
+// commonPrefix returns the longest prefix shared by all candidates.
+func commonPrefix(candidates []string) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+	prefix := candidates[0]
+	for _, candidate := range candidates[1:] {
+		for !strings.HasPrefix(candidate, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
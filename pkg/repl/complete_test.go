@@ -0,0 +1,97 @@
+package repl
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompleter_Complete(t *testing.T) {
+	completer := NewCompleter(buildTestStore(), `\describe`, `\describe-all`)
+
+	testCases := []struct {
+		line      string
+		wantStart int
+		want      []string
+	}{
+		{"SELECT ?t WHERE { ?a reg:ti", 21, []string{"reg:title"}},
+		{"DESCRIBE TEST:Art1", 9, []string{"TEST:Art17"}},
+		{"sel", 0, []string{"SELECT"}},
+		{`\desc`, 0, []string{`\describe`, `\describe-all`}},
+		{"?a rdf:type reg:Art", 12, []string{"reg:Article"}},
+		{"SELECT ", 7, nil},
+	}
+	for _, testCase := range testCases {
+		start, candidates := completer.Complete(testCase.line, len(testCase.line))
+		if start != testCase.wantStart || strings.Join(candidates, ",") != strings.Join(testCase.want, ",") {
+			t.Errorf("Complete(%q) = %d %v, want %d %v", testCase.line, start, candidates, testCase.wantStart, testCase.want)
+		}
+	}
+}
+
+func TestLineEditor_CompleteInsertsCommonPrefix(t *testing.T) {
+	editor := &LineEditor{completer: NewCompleter(buildTestStore()), history: NewHistory(0), out: &strings.Builder{}}
+
+	state := &editState{buffer: []rune("?a reg:re"), cursor: 9}
+	editor.complete(state)
+	if got := string(state.buffer); got != "?a reg:references " {
+		t.Errorf("buffer = %q, want unique completion with a trailing space", got)
+	}
+
+	state = &editState{buffer: []rune("DESCRIBE TEST:A"), cursor: 15}
+	editor.complete(state)
+	if got := string(state.buffer); got != "DESCRIBE TEST:Art" {
+		t.Errorf("buffer = %q, want common prefix TEST:Art", got)
+	}
+
+	state = &editState{buffer: []rune("sel ?a"), cursor: 3}
+	editor.complete(state)
+	if got := string(state.buffer); got != "SELECT  ?a" || state.cursor != 7 {
+		t.Errorf("buffer = %q (cursor %d), want keyword replaced in upper case", got, state.cursor)
+	}
+}
+
+func TestLineEditor_Recall(t *testing.T) {
+	history := NewHistory(0)
+	history.Add("SELECT ?a WHERE {\n}")
+	history.Add(`\timing`)
+	editor := &LineEditor{history: history}
+
+	state := &editState{buffer: []rune("draft"), cursor: 5, historyIndex: history.Len()}
+	editor.recall(state, -1)
+	editor.recall(state, -1)
+	if got := string(state.buffer); got != "SELECT ?a WHERE { }" {
+		t.Errorf("recalled %q, want multi-line entry on one line", got)
+	}
+	editor.recall(state, -1)
+	editor.recall(state, 1)
+	editor.recall(state, 1)
+	if got := string(state.buffer); got != "draft" {
+		t.Errorf("buffer = %q, want the line being edited restored", got)
+	}
+}
+
+func TestHistory_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "history")
+	history, err := LoadHistory(path, 2)
+	if err != nil {
+		t.Fatalf("LoadHistory failed: %v", err)
+	}
+	history.Add("first")
+	history.Add("SELECT ?a WHERE {\n  ?a reg:title \"C:\\\\path\"\n}")
+	history.Add("SELECT ?a WHERE {\n  ?a reg:title \"C:\\\\path\"\n}")
+	history.Add("   ")
+	history.Add(`\quit`)
+	if err := history.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded, err := LoadHistory(path, 10)
+	if err != nil {
+		t.Fatalf("LoadHistory failed: %v", err)
+	}
+	entries := reloaded.Entries()
+	if len(entries) != 2 || entries[0] != "SELECT ?a WHERE {\n  ?a reg:title \"C:\\\\path\"\n}" || entries[1] != `\quit` {
+		t.Errorf("entries = %q", entries)
+	}
+}
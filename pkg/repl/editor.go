@@ -0,0 +1,324 @@
+package repl
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrInterrupted is returned by ReadLine when the user presses Ctrl+C.
+var ErrInterrupted = errors.New("interrupted")
+
+// Key codes handled by the line editor in raw mode.
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyBackspace = 8
+	keyTab       = 9
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// LineEditor reads lines with Emacs-style editing, history recall, and tab
+// completion when attached to a terminal, and plain lines otherwise.
+type LineEditor struct {
+	reader      *bufio.Reader
+	out         io.Writer
+	fd          int
+	interactive bool
+	history     *History
+	completer   *Completer
+}
+
+// NewLineEditor creates a line editor reading from in. Editing is enabled
+// only when in is a terminal; history and completer may be nil.
+func NewLineEditor(in io.Reader, out io.Writer, history *History, completer *Completer) *LineEditor {
+	editor := &LineEditor{
+		reader:    bufio.NewReader(in),
+		out:       out,
+		fd:        -1,
+		history:   history,
+		completer: completer,
+	}
+	if file, ok := in.(*os.File); ok && isTerminal(int(file.Fd())) {
+		editor.fd = int(file.Fd())
+		editor.interactive = true
+	}
+	if editor.history == nil {
+		editor.history = NewHistory(DefaultHistorySize)
+	}
+	return editor
+}
+
+// Interactive reports whether the editor is attached to a terminal.
+func (e *LineEditor) Interactive() bool {
+	return e.interactive
+}
+
+// SetCompleter replaces the completer, for example after a new graph is
+// loaded.
+func (e *LineEditor) SetCompleter(completer *Completer) {
+	e.completer = completer
+}
+
+// ReadLine prints prompt and returns the next line without its newline.
+// It returns io.EOF at end of input or on Ctrl+D at an empty line, and
+// ErrInterrupted on Ctrl+C.
+func (e *LineEditor) ReadLine(prompt string) (string, error) {
+	if !e.interactive {
+		return e.readPlainLine()
+	}
+
+	restore, err := enableRawMode(e.fd)
+	if err != nil {
+		fmt.Fprint(e.out, prompt)
+		return e.readPlainLine()
+	}
+	defer restore()
+
+	state := &editState{prompt: prompt, historyIndex: e.history.Len()}
+	e.render(state)
+	for {
+		r, _, err := e.reader.ReadRune()
+		if err != nil {
+			fmt.Fprint(e.out, "\r\n")
+			return "", err
+		}
+
+		switch r {
+		case keyEnter, '\n':
+			fmt.Fprint(e.out, "\r\n")
+			return string(state.buffer), nil
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", ErrInterrupted
+		case keyCtrlD:
+			if len(state.buffer) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			state.deleteForward()
+		case keyTab:
+			e.complete(state)
+		case keyBackspace, keyDelete:
+			state.deleteBackward()
+		case keyCtrlA:
+			state.cursor = 0
+		case keyCtrlE:
+			state.cursor = len(state.buffer)
+		case keyCtrlB:
+			state.moveLeft()
+		case keyCtrlF:
+			state.moveRight()
+		case keyCtrlK:
+			state.buffer = state.buffer[:state.cursor]
+		case keyCtrlU:
+			state.buffer = state.buffer[state.cursor:]
+			state.cursor = 0
+		case keyCtrlW:
+			state.deleteWord()
+		case keyCtrlL:
+			fmt.Fprint(e.out, "\x1b[H\x1b[2J")
+		case keyCtrlP:
+			e.recall(state, -1)
+		case keyCtrlN:
+			e.recall(state, 1)
+		case keyEscape:
+			e.handleEscape(state)
+		default:
+			if r >= ' ' {
+				state.insert([]rune{r})
+			}
+		}
+		e.render(state)
+	}
+}
+
+// readPlainLine reads a line without editing, for pipes and scripts.
+func (e *LineEditor) readPlainLine() (string, error) {
+	line, err := e.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// handleEscape interprets arrow, Home, End, and Delete key sequences.
+func (e *LineEditor) handleEscape(state *editState) {
+	introducer, _, err := e.reader.ReadRune()
+	if err != nil || (introducer != '[' && introducer != 'O') {
+		return
+	}
+
+	var params []rune
+	for {
+		r, _, err := e.reader.ReadRune()
+		if err != nil {
+			return
+		}
+		if r >= '0' && r <= '9' || r == ';' {
+			params = append(params, r)
+			continue
+		}
+
+		switch r {
+		case 'A':
+			e.recall(state, -1)
+		case 'B':
+			e.recall(state, 1)
+		case 'C':
+			state.moveRight()
+		case 'D':
+			state.moveLeft()
+		case 'H':
+			state.cursor = 0
+		case 'F':
+			state.cursor = len(state.buffer)
+		case '~':
+			switch string(params) {
+			case "1", "7":
+				state.cursor = 0
+			case "4", "8":
+				state.cursor = len(state.buffer)
+			case "3":
+				state.deleteForward()
+			}
+		}
+		return
+	}
+}
+
+// recall replaces the buffer with an older (direction -1) or newer (+1)
+// history entry, keeping the line being edited as the newest.
+func (e *LineEditor) recall(state *editState, direction int) {
+	entries := e.history.Entries()
+	next := state.historyIndex + direction
+	if next < 0 || next > len(entries) {
+		return
+	}
+	if state.historyIndex == len(entries) {
+		state.pending = append([]rune(nil), state.buffer...)
+	}
+	state.historyIndex = next
+
+	if next == len(entries) {
+		state.buffer = append([]rune(nil), state.pending...)
+	} else {
+		state.buffer = []rune(strings.ReplaceAll(entries[next], "\n", " "))
+	}
+	state.cursor = len(state.buffer)
+}
+
+// complete extends the word at the cursor to the longest common prefix of
+// its completions, and lists them when that adds nothing.
+func (e *LineEditor) complete(state *editState) {
+	if e.completer == nil {
+		return
+	}
+	line := string(state.buffer)
+	cursor := len(string(state.buffer[:state.cursor]))
+	start, candidates := e.completer.Complete(line, cursor)
+	if len(candidates) == 0 {
+		return
+	}
+
+	word := line[start:cursor]
+	completion := commonPrefix(candidates)
+	if len(candidates) == 1 {
+		completion += " "
+	}
+	if len(completion) > len(word) && strings.EqualFold(completion[:len(word)], word) {
+		// Replace the whole word, since keywords are completed in upper case.
+		wordStart := state.cursor - len([]rune(word))
+		state.buffer = append(state.buffer[:wordStart], state.buffer[state.cursor:]...)
+		state.cursor = wordStart
+		state.insert([]rune(completion))
+		return
+	}
+
+	fmt.Fprint(e.out, "\r\n")
+	const maxListed = 50
+	for i, candidate := range candidates {
+		if i == maxListed {
+			fmt.Fprintf(e.out, "... and %d more\r\n", len(candidates)-maxListed)
+			break
+		}
+		fmt.Fprintf(e.out, "%s\r\n", candidate)
+	}
+}
+
+// render redraws the prompt and buffer and positions the cursor.
+func (e *LineEditor) render(state *editState) {
+	fmt.Fprintf(e.out, "\r%s%s\x1b[K", state.prompt, string(state.buffer))
+	if back := len(state.buffer) - state.cursor; back > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", back)
+	}
+}
+
+// editState is the line being edited.
+type editState struct {
+	prompt       string
+	buffer       []rune
+	cursor       int
+	historyIndex int
+	pending      []rune
+}
+
+func (s *editState) insert(runes []rune) {
+	tail := append(append([]rune(nil), runes...), s.buffer[s.cursor:]...)
+	s.buffer = append(s.buffer[:s.cursor], tail...)
+	s.cursor += len(runes)
+}
+
+func (s *editState) deleteBackward() {
+	if s.cursor == 0 {
+		return
+	}
+	s.buffer = append(s.buffer[:s.cursor-1], s.buffer[s.cursor:]...)
+	s.cursor--
+}
+
+func (s *editState) deleteForward() {
+	if s.cursor < len(s.buffer) {
+		s.buffer = append(s.buffer[:s.cursor], s.buffer[s.cursor+1:]...)
+	}
+}
+
+// deleteWord deletes from the cursor back to the start of the previous word.
+func (s *editState) deleteWord() {
+	start := s.cursor
+	for start > 0 && s.buffer[start-1] == ' ' {
+		start--
+	}
+	for start > 0 && s.buffer[start-1] != ' ' {
+		start--
+	}
+	s.buffer = append(s.buffer[:start], s.buffer[s.cursor:]...)
+	s.cursor = start
+}
+
+func (s *editState) moveLeft() {
+	if s.cursor > 0 {
+		s.cursor--
+	}
+}
+
+func (s *editState) moveRight() {
+	if s.cursor < len(s.buffer) {
+		s.cursor++
+	}
+}
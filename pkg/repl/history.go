@@ -0,0 +1,124 @@
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultHistorySize is the number of entries kept in the history file.
+const DefaultHistorySize = 1000
+
+// History holds previously entered queries and meta-commands. Multi-line
+// queries are stored as a single entry with newlines escaped, so they can
+// be recalled and re-run as a whole.
+type History struct {
+	entries []string
+	maxSize int
+	path    string
+}
+
+// NewHistory creates an empty history that keeps up to maxSize entries.
+func NewHistory(maxSize int) *History {
+	if maxSize <= 0 {
+		maxSize = DefaultHistorySize
+	}
+	return &History{maxSize: maxSize}
+}
+
+// LoadHistory reads the history file at path. A missing file yields an
+// empty history that will be created on Save.
+func LoadHistory(path string, maxSize int) (*History, error) {
+	history := NewHistory(maxSize)
+	history.path = path
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			history.Add(unescapeHistoryEntry(line))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return history, nil
+}
+
+// Add appends an entry, skipping blanks and immediate repeats.
+func (h *History) Add(entry string) {
+	entry = strings.TrimSpace(entry)
+	if entry == "" {
+		return
+	}
+	if len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry {
+		return
+	}
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > h.maxSize {
+		h.entries = h.entries[len(h.entries)-h.maxSize:]
+	}
+}
+
+// Entries returns the history, oldest first.
+func (h *History) Entries() []string {
+	return h.entries
+}
+
+// Len returns the number of entries.
+func (h *History) Len() int {
+	return len(h.entries)
+}
+
+// Save writes the history to the file it was loaded from. It does nothing
+// for a history without a path.
+func (h *History) Save() error {
+	if h.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	var sb strings.Builder
+	for _, entry := range h.entries {
+		sb.WriteString(escapeHistoryEntry(entry))
+		sb.WriteString("\n")
+	}
+	if err := os.WriteFile(h.path, []byte(sb.String()), 0600); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
+
+func escapeHistoryEntry(entry string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(entry)
+}
+
+func unescapeHistoryEntry(line string) string {
+	var sb strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' && i+1 < len(line) {
+			i++
+			if line[i] == 'n' {
+				sb.WriteByte('\n')
+			} else {
+				sb.WriteByte(line[i])
+			}
+			continue
+		}
+		sb.WriteByte(line[i])
+	}
+	return sb.String()
+}
@@ -0,0 +1,388 @@
+// Package repl implements the interactive SPARQL shell behind "regula repl":
+// line editing with persistent history, prefix completion of graph URIs,
+// multi-line query entry, and backslash meta-commands.
+package repl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/query"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// Prompts shown for the first and continuation lines of a query.
+const (
+	Prompt             = "regula> "
+	ContinuationPrompt = "   ...> "
+)
+
+// errQuit signals that the user asked to leave the shell.
+var errQuit = errors.New("quit")
+
+// Template is a named query that can be run with \template.
+type Template struct {
+	Name        string
+	Description string
+	Query       string
+}
+
+// Config configures a shell session.
+type Config struct {
+	// Store is the graph to query.
+	Store *store.TripleStore
+
+	// Label describes the graph in the banner, e.g. a source file name.
+	Label string
+
+	// Templates are the queries available to \templates and \template.
+	Templates []Template
+
+	// Format is the initial output format (default table).
+	Format query.OutputFormat
+
+	// Prefixes are custom prefixes, such as a library's prefixes.yaml, that
+	// queries may use and SELECT results are compacted with.
+	Prefixes query.PrefixMap
+
+	// FullURIs disables compacting URIs in SELECT results.
+	FullURIs bool
+
+	// Timing prints execution time after each query.
+	Timing bool
+
+	// History records entered lines; nil keeps history in memory only.
+	History *History
+}
+
+// Session is an interactive shell over one graph.
+type Session struct {
+	config    Config
+	executor  *query.Executor
+	completer *Completer
+	templates map[string]Template
+	prefixes  query.PrefixMap
+	history   *History
+}
+
+// metaCommands are completed at the start of a line.
+var metaCommands = []string{
+	`\help`, `\templates`, `\template`, `\describe`, `\format`, `\timing`, `\uris`, `\quit`,
+}
+
+// NewSession creates a shell over config.Store.
+func NewSession(config Config) *Session {
+	if config.Format == "" {
+		config.Format = query.FormatTable
+	}
+	if config.History == nil {
+		config.History = NewHistory(DefaultHistorySize)
+	}
+
+	templates := make(map[string]Template, len(config.Templates))
+	extra := append([]string(nil), metaCommands...)
+	for _, template := range config.Templates {
+		templates[template.Name] = template
+		extra = append(extra, template.Name)
+	}
+
+	return &Session{
+		config:    config,
+		executor:  query.NewExecutor(config.Store),
+		completer: NewCompleter(config.Store, extra...),
+		templates: templates,
+		prefixes:  query.ResourcePrefixes(config.Store).With(config.Prefixes),
+		history:   config.History,
+	}
+}
+
+// Run reads queries and meta-commands from in until \quit or end of input,
+// writing results to out. Prompts and the banner are only shown when in is
+// a terminal, so a script can be piped through the shell. The history is
+// saved on exit.
+func (s *Session) Run(in io.Reader, out io.Writer) error {
+	editor := NewLineEditor(in, out, s.history, s.completer)
+	if editor.Interactive() {
+		fmt.Fprintf(out, "Regula SPARQL shell: %s (%d triples)\n", s.config.Label, s.config.Store.Count())
+		fmt.Fprintf(out, "End queries with a closing brace or ';'. Type \\help for commands, Tab to complete.\n\n")
+	}
+
+	var pending []string
+	for {
+		prompt := Prompt
+		if len(pending) > 0 {
+			prompt = ContinuationPrompt
+		}
+		if !editor.Interactive() {
+			prompt = ""
+		}
+
+		line, err := editor.ReadLine(prompt)
+		if errors.Is(err, ErrInterrupted) {
+			pending = nil
+			continue
+		}
+		if err == io.EOF {
+			if len(pending) > 0 {
+				s.runInput(strings.Join(pending, "\n"), out)
+			}
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+
+		if len(pending) == 0 && strings.TrimSpace(line) == "" {
+			continue
+		}
+		pending = append(pending, line)
+		input := strings.Join(pending, "\n")
+		if !isMetaCommand(input) && !QueryComplete(input) {
+			continue
+		}
+		pending = nil
+
+		if errors.Is(s.runInput(input, out), errQuit) {
+			break
+		}
+	}
+
+	return s.history.Save()
+}
+
+// runInput records input in the history and executes it, printing errors.
+func (s *Session) runInput(input string, out io.Writer) error {
+	s.history.Add(input)
+	err := s.Execute(input, out)
+	if err != nil && !errors.Is(err, errQuit) {
+		fmt.Fprintf(out, "Error: %v\n", err)
+	}
+	return err
+}
+
+// Execute runs one complete query or meta-command.
+func (s *Session) Execute(input string, out io.Writer) error {
+	input = strings.TrimSpace(input)
+	if isMetaCommand(input) {
+		return s.executeMeta(input, out)
+	}
+	return s.executeQuery(strings.TrimSuffix(input, ";"), out)
+}
+
+func isMetaCommand(input string) bool {
+	return strings.HasPrefix(strings.TrimSpace(input), `\`)
+}
+
+// QueryComplete reports whether input is a complete query: its braces are
+// balanced, or it ends with ';'. Braces inside string literals and <URIs>
+// are ignored. A query without braces, such as DESCRIBE GDPR:Art17, is
+// complete at the end of its line.
+func QueryComplete(input string) bool {
+	trimmed := strings.TrimSpace(input)
+	if strings.HasSuffix(trimmed, ";") {
+		return true
+	}
+
+	depth := 0
+	var quote byte
+	inURI := false
+	for i := 0; i < len(trimmed); i++ {
+		c := trimmed[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case inURI:
+			inURI = c != '>'
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '<' && i+1 < len(trimmed) && !strings.ContainsRune(" =", rune(trimmed[i+1])):
+			inURI = true
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+		}
+	}
+	return depth <= 0 && quote == 0
+}
+
+// executeQuery parses and runs a SPARQL query.
+func (s *Session) executeQuery(queryStr string, out io.Writer) error {
+	parsedQuery, err := query.ParseQuery(queryStr)
+	if err != nil {
+		return fmt.Errorf("query parse error: %w", err)
+	}
+	compact := query.DefaultPrefixes().With(s.config.Prefixes).With(parsedQuery.Prefixes())
+	parsedQuery.ExpandPrefixes(s.prefixes)
+
+	startTime := time.Now()
+	var output string
+	var count int
+	switch parsedQuery.Type {
+	case query.ConstructQueryType, query.DescribeQueryType:
+		var result *query.ConstructResult
+		if parsedQuery.Type == query.ConstructQueryType {
+			result, err = s.executor.ExecuteConstruct(parsedQuery)
+		} else {
+			result, err = s.executor.ExecuteDescribe(parsedQuery)
+		}
+		if err != nil {
+			return fmt.Errorf("query error: %w", err)
+		}
+		if output, err = result.Format(s.graphFormat()); err != nil {
+			return fmt.Errorf("format error: %w", err)
+		}
+		count = result.Count
+	default:
+		result, err := s.executor.Execute(parsedQuery)
+		if err != nil {
+			return fmt.Errorf("query error: %w", err)
+		}
+		if !s.config.FullURIs && !s.selectFormat().IsSPARQLResults() {
+			result = result.WithPrefixes(compact)
+		}
+		if output, err = result.Format(s.selectFormat()); err != nil {
+			return fmt.Errorf("format error: %w", err)
+		}
+		count = result.Count
+	}
+
+	fmt.Fprint(out, output)
+	if !strings.HasSuffix(output, "\n") {
+		fmt.Fprintln(out)
+	}
+	if s.config.Timing {
+		fmt.Fprintf(out, "(%d results in %v)\n", count, time.Since(startTime).Round(time.Microsecond))
+	}
+	return nil
+}
+
+// selectFormat returns the format for SELECT results, falling back to a
+// table when a graph format is selected.
+func (s *Session) selectFormat() query.OutputFormat {
+	switch s.config.Format {
+	case query.FormatTurtle, query.FormatNTriples:
+		return query.FormatTable
+	}
+	return s.config.Format
+}
+
+// graphFormat returns the format for CONSTRUCT and DESCRIBE results,
+// falling back to Turtle when a tabular format is selected.
+func (s *Session) graphFormat() query.OutputFormat {
+	switch s.config.Format {
+	case query.FormatTable, query.FormatCSV, query.FormatSRJ, query.FormatSRX:
+		return query.FormatTurtle
+	}
+	return s.config.Format
+}
+
+// executeMeta runs a backslash command.
+func (s *Session) executeMeta(input string, out io.Writer) error {
+	fields := strings.Fields(input)
+	command, args := fields[0], fields[1:]
+
+	switch command {
+	case `\q`, `\quit`, `\exit`:
+		return errQuit
+
+	case `\h`, `\?`, `\help`:
+		printHelp(out)
+
+	case `\templates`:
+		names := make([]string, 0, len(s.templates))
+		for name := range s.templates {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(out, "  %-20s %s\n", name, s.templates[name].Description)
+		}
+
+	case `\t`, `\template`:
+		if len(args) != 1 {
+			return fmt.Errorf(`usage: \template <name> (see \templates)`)
+		}
+		template, ok := s.templates[args[0]]
+		if !ok {
+			return fmt.Errorf("unknown template: %s", args[0])
+		}
+		fmt.Fprintf(out, "%s\n\n", template.Query)
+		return s.executeQuery(template.Query, out)
+
+	case `\d`, `\describe`:
+		if len(args) < 1 || len(args) > 2 {
+			return fmt.Errorf(`usage: \describe <uri> [depth]`)
+		}
+		resource := args[0]
+		if strings.HasPrefix(resource, "http://") || strings.HasPrefix(resource, "https://") {
+			resource = "<" + resource + ">"
+		}
+		queryStr := "DESCRIBE " + resource
+		if len(args) == 2 {
+			queryStr += " DEPTH " + args[1]
+		}
+		return s.executeQuery(queryStr, out)
+
+	case `\f`, `\format`:
+		if len(args) == 0 {
+			fmt.Fprintf(out, "Output format: %s\n", s.config.Format)
+			return nil
+		}
+		format := query.OutputFormat(strings.ToLower(args[0]))
+		switch format {
+		case query.FormatTable, query.FormatJSON, query.FormatCSV, query.FormatSRJ, query.FormatSRX,
+			query.FormatTurtle, query.FormatNTriples:
+			s.config.Format = format
+			fmt.Fprintf(out, "Output format: %s\n", format)
+		default:
+			return fmt.Errorf("unknown format %q (table, json, csv, srj, srx, turtle, ntriples)", args[0])
+		}
+
+	case `\timing`:
+		s.config.Timing = !s.config.Timing
+		fmt.Fprintf(out, "Timing is %s.\n", onOff(s.config.Timing))
+
+	case `\uris`:
+		s.config.FullURIs = !s.config.FullURIs
+		fmt.Fprintf(out, "Full URIs are %s.\n", onOff(s.config.FullURIs))
+
+	default:
+		return fmt.Errorf(`unknown command %s (type \help for commands)`, command)
+	}
+	return nil
+}
+
+func printHelp(out io.Writer) {
+	fmt.Fprint(out, `Enter a SPARQL query; it runs once its braces are balanced or it ends with ';'.
+
+Meta-commands:
+  \templates             List query templates
+  \template <name>       Run a query template (\t)
+  \describe <uri> [n]    Describe a resource to depth n, e.g. \describe GDPR:Art17 2 (\d)
+  \format [name]         Show or set the output format: table, json, csv,
+                         srj, srx, turtle, ntriples (\f)
+  \timing                Toggle query timing
+  \uris                  Toggle full URIs in SELECT results
+  \help                  Show this help (\?)
+  \quit                  Leave the shell (\q, Ctrl+D)
+
+Keys: Tab completes keywords, reg: predicates, and resource URIs; Up/Down
+recall history; Ctrl+C discards the current query.
+`)
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
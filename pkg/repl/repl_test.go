@@ -0,0 +1,192 @@
+package repl
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/query"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+const testBase = "https://regula.dev/regulations/"
+
+func buildTestStore() *store.TripleStore {
+	ts := store.NewTripleStore()
+	for _, article := range []string{"Art1", "Art2", "Art17"} {
+		ts.Add(testBase+"TEST:"+article, store.RDFType, store.ClassArticle)
+		ts.Add(testBase+"TEST:"+article, store.PropTitle, "Title of "+article)
+	}
+	ts.Add(testBase+"TEST:Art2", store.PropReferences, testBase+"TEST:Art17")
+	return ts
+}
+
+func newTestSession(t *testing.T) *Session {
+	t.Helper()
+	return NewSession(Config{
+		Store: buildTestStore(),
+		Label: "test",
+		Templates: []Template{
+			{Name: "articles", Description: "List articles", Query: "SELECT ?a WHERE { ?a rdf:type reg:Article }"},
+		},
+	})
+}
+
+func TestQueryComplete(t *testing.T) {
+	testCases := []struct {
+		input    string
+		complete bool
+	}{
+		{"SELECT ?a WHERE {", false},
+		{"SELECT ?a WHERE {\n  ?a rdf:type reg:Article .", false},
+		{"SELECT ?a WHERE {\n  ?a rdf:type reg:Article .\n} LIMIT 5", true},
+		{"DESCRIBE TEST:Art17", true},
+		{"SELECT ?a WHERE { ?a reg:title \"}\"", false},
+		{"SELECT ?a WHERE { ?a reg:title \"{\" }", true},
+		{"SELECT ?a WHERE { <https://x/{y}> reg:title ?a", false},
+		{"SELECT ?a WHERE { ?a reg:number ?n FILTER(?n < 5) }", true},
+		{"SELECT ?a WHERE {\n  ?a rdf:type reg:Article ;", true},
+	}
+	for _, testCase := range testCases {
+		if got := QueryComplete(testCase.input); got != testCase.complete {
+			t.Errorf("QueryComplete(%q) = %v, want %v", testCase.input, got, testCase.complete)
+		}
+	}
+}
+
+func TestSession_RunScript(t *testing.T) {
+	session := newTestSession(t)
+	script := strings.Join([]string{
+		"SELECT ?title WHERE {",
+		"  TEST:Art17 reg:title ?title .",
+		"}",
+		`\format json`,
+		`SELECT ?a WHERE { ?a reg:references TEST:Art17 }`,
+		`\bogus`,
+		`\quit`,
+		"SELECT ?never WHERE { ?never rdf:type reg:Article }",
+	}, "\n")
+
+	var out bytes.Buffer
+	if err := session.Run(strings.NewReader(script), &out); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	output := out.String()
+
+	if !strings.Contains(output, "Title of Art17") {
+		t.Errorf("multi-line query with a compact subject did not run:\n%s", output)
+	}
+	if !strings.Contains(output, `"TEST:Art2"`) {
+		t.Errorf("expected JSON output after \\format json:\n%s", output)
+	}
+	if !strings.Contains(output, `Error: unknown command \bogus`) {
+		t.Errorf("expected error for unknown meta-command:\n%s", output)
+	}
+	if strings.Contains(output, "never") {
+		t.Errorf("input after \\quit was executed:\n%s", output)
+	}
+
+	entries := session.history.Entries()
+	if len(entries) != 5 || entries[0] != "SELECT ?title WHERE {\n  TEST:Art17 reg:title ?title .\n}" {
+		t.Errorf("history = %q", entries)
+	}
+}
+
+func TestSession_MetaCommands(t *testing.T) {
+	session := newTestSession(t)
+
+	var out bytes.Buffer
+	if err := session.Execute(`\templates`, &out); err != nil || !strings.Contains(out.String(), "List articles") {
+		t.Errorf("\\templates: err=%v output=%q", err, out.String())
+	}
+
+	out.Reset()
+	if err := session.Execute(`\template articles`, &out); err != nil || !strings.Contains(out.String(), "TEST:Art17") {
+		t.Errorf("\\template articles: err=%v output=%q", err, out.String())
+	}
+	if err := session.Execute(`\template missing`, &out); err == nil {
+		t.Error("expected error for unknown template")
+	}
+
+	out.Reset()
+	if err := session.Execute(`\describe TEST:Art2`, &out); err != nil {
+		t.Fatalf("\\describe failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Title of Art2") {
+		t.Errorf("\\describe output = %q", out.String())
+	}
+
+	out.Reset()
+	if err := session.Execute(`\describe `+testBase+"TEST:Art1", &out); err != nil || !strings.Contains(out.String(), "Title of Art1") {
+		t.Errorf("\\describe with a full URI: err=%v output=%q", err, out.String())
+	}
+
+	out.Reset()
+	if err := session.Execute(`\describe TEST:Art2 2`, &out); err != nil || !strings.Contains(out.String(), "Title of Art17") {
+		t.Errorf("\\describe with a depth: err=%v output=%q", err, out.String())
+	}
+	if err := session.Execute(`\describe TEST:Art2 0`, &out); err == nil {
+		t.Error("expected error for depth 0")
+	}
+
+	if err := session.Execute(`\format xml`, &out); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if err := session.Execute(`\format ntriples`, &out); err != nil || session.config.Format != query.FormatNTriples {
+		t.Errorf("\\format ntriples: err=%v format=%s", err, session.config.Format)
+	}
+
+	out.Reset()
+	if err := session.Execute("SELECT ?a WHERE { ?a reg:references ?b }", &out); err != nil || !strings.Contains(out.String(), "TEST:Art2") {
+		t.Errorf("SELECT with a graph format should fall back to a table: err=%v output=%q", err, out.String())
+	}
+
+	if err := session.Execute(`\quit`, &out); err != errQuit {
+		t.Errorf("\\quit returned %v", err)
+	}
+}
+
+func TestSession_CustomPrefixes(t *testing.T) {
+	ts := buildTestStore()
+	ts.Add("https://law.acme.example/regs/Reg1", store.PropTitle, "Acme regulation")
+	session := NewSession(Config{
+		Store:    ts,
+		Prefixes: query.PrefixMap{"acme": "https://law.acme.example/regs/"},
+	})
+
+	var out bytes.Buffer
+	if err := session.Execute(`SELECT ?title WHERE { acme:Reg1 reg:title ?title }`, &out); err != nil || !strings.Contains(out.String(), "Acme regulation") {
+		t.Errorf("query with a custom prefix: err=%v output=%q", err, out.String())
+	}
+
+	out.Reset()
+	if err := session.Execute(`SELECT ?a WHERE { ?a reg:title "Acme regulation" }`, &out); err != nil || !strings.Contains(out.String(), "acme:Reg1") {
+		t.Errorf("results should be compacted with custom prefixes: err=%v output=%q", err, out.String())
+	}
+
+	out.Reset()
+	if err := session.Execute(`PREFIX law: <https://law.acme.example/> SELECT ?a WHERE { ?a reg:title "Acme regulation" }`, &out); err != nil || !strings.Contains(out.String(), "acme:Reg1") {
+		t.Errorf("the longest matching namespace should win: err=%v output=%q", err, out.String())
+	}
+}
+
+func TestSession_SavesHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	history, err := LoadHistory(path, 10)
+	if err != nil {
+		t.Fatalf("LoadHistory failed: %v", err)
+	}
+	session := NewSession(Config{Store: buildTestStore(), History: history})
+	if err := session.Run(strings.NewReader("\\timing\n"), &bytes.Buffer{}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	reloaded, err := LoadHistory(path, 10)
+	if err != nil {
+		t.Fatalf("LoadHistory failed: %v", err)
+	}
+	if entries := reloaded.Entries(); len(entries) != 1 || entries[0] != `\timing` {
+		t.Errorf("reloaded history = %q", entries)
+	}
+}
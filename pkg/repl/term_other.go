@@ -0,0 +1,15 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package repl
+
+import "errors"
+
+// isTerminal reports false on platforms without termios support, so input
+// is read line by line without editing.
+func isTerminal(fd int) bool {
+	return false
+}
+
+func enableRawMode(fd int) (func(), error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
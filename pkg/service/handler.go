@@ -0,0 +1,154 @@
+package service
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MethodPrefix is the path prefix of the service methods, matching the
+// gRPC method names "/regula.v1.Regula/<Method>".
+const MethodPrefix = "/regula.v1.Regula/"
+
+// NewHandler serves the regula.v1.Regula service over the Connect protocol
+// (https://connectrpc.com/docs/protocol) with the JSON codec, so Connect
+// clients generated from proto/regula/v1/regula.proto can call it. Each
+// method is a unary POST to its gRPC method path, e.g.
+// /regula.v1.Regula/Query, with the JSON-encoded request message as the
+// body. Errors are Connect error bodies, {"code": ..., "message": ...},
+// with the HTTP status Connect assigns to the code.
+func NewHandler(s *Service) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("POST "+MethodPrefix+"Ingest", unary(s.Ingest))
+	mux.Handle("POST "+MethodPrefix+"Query", unary(s.Query))
+	mux.Handle("POST "+MethodPrefix+"Impact", unary(s.Impact))
+	mux.Handle("POST "+MethodPrefix+"Match", unary(s.Match))
+	return mux
+}
+
+// connectProtocolVersion is the only Connect-Protocol-Version header value
+// the handler accepts.
+const connectProtocolVersion = "1"
+
+// unary adapts a service method to a Connect unary handler that decodes the
+// request message from the body and encodes the response message.
+func unary[Req, Resp any](method func(context.Context, *Req) (*Resp, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+			w.Header().Set("Accept-Post", "application/json")
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		if version := r.Header.Get("Connect-Protocol-Version"); version != "" && version != connectProtocolVersion {
+			writeError(w, errorf(CodeInvalidArgument, "unsupported Connect-Protocol-Version %q", version))
+			return
+		}
+
+		ctx := r.Context()
+		if timeout := r.Header.Get("Connect-Timeout-Ms"); timeout != "" {
+			milliseconds, err := strconv.ParseInt(timeout, 10, 64)
+			if err != nil || milliseconds < 0 {
+				writeError(w, errorf(CodeInvalidArgument, "invalid Connect-Timeout-Ms %q", timeout))
+				return
+			}
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, time.Duration(milliseconds)*time.Millisecond)
+			defer cancel()
+		}
+
+		body := io.Reader(r.Body)
+		switch encoding := r.Header.Get("Content-Encoding"); encoding {
+		case "", "identity":
+		case "gzip":
+			gzipReader, err := gzip.NewReader(r.Body)
+			if err != nil {
+				writeError(w, errorf(CodeInvalidArgument, "invalid gzip body: %v", err))
+				return
+			}
+			defer gzipReader.Close()
+			body = gzipReader
+		default:
+			w.Header().Set("Accept-Encoding", "gzip")
+			writeError(w, errorf(CodeUnimplemented, "unsupported Content-Encoding %q", encoding))
+			return
+		}
+
+		request := new(Req)
+		if err := decodeMessage(body, request); err != nil {
+			writeError(w, errorf(CodeInvalidArgument, "invalid request body: %v", err))
+			return
+		}
+		response, err := method(ctx, request)
+		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				err = errorf(CodeDeadlineExceeded, "%v", err)
+			}
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+}
+
+// decodeMessage decodes a JSON request message into request. Proto3 JSON
+// writers name fields in lowerCamelCase (documentId) and readers accept the
+// proto field names (document_id) too, so both are accepted. Request
+// messages are flat, so only top-level keys are renamed.
+func decodeMessage(body io.Reader, request any) error {
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(body).Decode(&fields); err != nil {
+		return err
+	}
+	renamed := make(map[string]json.RawMessage, len(fields))
+	for name, value := range fields {
+		renamed[protoFieldName(name)] = value
+	}
+	data, err := json.Marshal(renamed)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, request)
+}
+
+// protoFieldName converts a lowerCamelCase JSON name to its proto field
+// name, e.g. "graphId" to "graph_id".
+func protoFieldName(jsonName string) string {
+	var builder strings.Builder
+	for _, char := range jsonName {
+		if unicode.IsUpper(char) {
+			builder.WriteByte('_')
+			char = unicode.ToLower(char)
+		}
+		builder.WriteRune(char)
+	}
+	return builder.String()
+}
+
+// connectStatus maps each Code to the HTTP status of a Connect error
+// response.
+var connectStatus = map[Code]int{
+	CodeInvalidArgument:  http.StatusBadRequest,
+	CodeNotFound:         http.StatusNotFound,
+	CodeDeadlineExceeded: http.StatusGatewayTimeout,
+	CodeUnimplemented:    http.StatusNotImplemented,
+	CodeInternal:         http.StatusInternalServerError,
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	code := ErrorCode(err)
+	status, ok := connectStatus[code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"code": string(code), "message": err.Error()})
+}
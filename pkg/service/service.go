@@ -0,0 +1,233 @@
+// Package service implements the Regula RPC service defined in
+// proto/regula/v1/regula.proto on top of the pkg/regula API: ingesting
+// regulation text, SPARQL queries, impact analysis, and scenario matching
+// over in-memory graphs. Request and response types mirror the proto
+// messages field for field; NewHandler serves them over the Connect
+// protocol.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/coolbeans/regula/pkg/regula"
+	"github.com/coolbeans/regula/pkg/usage"
+)
+
+// Code classifies service errors. The values are the Connect names of the
+// gRPC status codes, so transports can map them directly.
+type Code string
+
+const (
+	CodeInvalidArgument  Code = "invalid_argument"
+	CodeNotFound         Code = "not_found"
+	CodeDeadlineExceeded Code = "deadline_exceeded"
+	CodeUnimplemented    Code = "unimplemented"
+	CodeInternal         Code = "internal"
+)
+
+// Error is a service error with a transport-independent code.
+type Error struct {
+	Code    Code
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func errorf(code Code, format string, args ...any) error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// ErrorCode returns the code of a service error, or CodeInternal for any
+// other error.
+func ErrorCode(err error) Code {
+	var serviceErr *Error
+	if errors.As(err, &serviceErr) {
+		return serviceErr.Code
+	}
+	return CodeInternal
+}
+
+// Service holds ingested graphs, keyed by document ID. It is safe for
+// concurrent use.
+type Service struct {
+	mu     sync.RWMutex
+	graphs map[string]*regula.Graph
+	usage  *usage.Tracker
+}
+
+// New creates a service with no graphs.
+func New() *Service {
+	return &Service{graphs: make(map[string]*regula.Graph)}
+}
+
+// SetUsageTracker records the provisions each query, impact, and match
+// request consults. Query results are credited to the provisions they
+// name; match requests count as uses of the "match:<scenario>" template.
+func (s *Service) SetUsageTracker(tracker *usage.Tracker) {
+	s.usage = tracker
+}
+
+// Ingest parses the request text and builds its knowledge graph. The graph
+// ID is the document ID; ingesting the same ID again replaces the graph.
+func (s *Service) Ingest(ctx context.Context, request *IngestRequest) (*IngestResponse, error) {
+	if request.DocumentID == "" {
+		return nil, errorf(CodeInvalidArgument, "document_id is required")
+	}
+	if strings.TrimSpace(request.Text) == "" {
+		return nil, errorf(CodeInvalidArgument, "text is empty")
+	}
+
+	graph, err := regula.Ingest(strings.NewReader(request.Text), regula.Options{
+		DocumentID: request.DocumentID,
+		Format:     request.Format,
+		BaseURI:    request.BaseURI,
+	})
+	if err != nil {
+		return nil, errorf(CodeInvalidArgument, "%v", err)
+	}
+
+	s.mu.Lock()
+	s.graphs[request.DocumentID] = graph
+	s.mu.Unlock()
+
+	stats := graph.Stats()
+	return &IngestResponse{
+		GraphID:     request.DocumentID,
+		Triples:     stats.Triples,
+		Articles:    stats.Articles,
+		Definitions: stats.Definitions,
+		References:  stats.References,
+		Rights:      stats.Rights,
+		Obligations: stats.Obligations,
+	}, nil
+}
+
+// Query runs a SPARQL SELECT, CONSTRUCT, or DESCRIBE query against a graph.
+func (s *Service) Query(ctx context.Context, request *QueryRequest) (*QueryResponse, error) {
+	graph, err := s.graph(request.GraphID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := graph.QueryContext(ctx, request.Query)
+	if err != nil {
+		return nil, classify(err)
+	}
+
+	response := &QueryResponse{
+		Type:       result.Type,
+		Variables:  result.Variables,
+		Count:      result.Count,
+		DurationMs: result.Duration.Milliseconds(),
+	}
+	if result.Type == "select" {
+		response.Rows = make([]Binding, len(result.Rows))
+		for i, row := range result.Rows {
+			response.Rows[i] = Binding{Values: row}
+		}
+	}
+	for _, triple := range result.Triples {
+		response.Triples = append(response.Triples, Triple(triple))
+	}
+	if s.usage != nil {
+		var values []string
+		for _, row := range result.Rows {
+			for _, value := range row {
+				values = append(values, value)
+			}
+		}
+		for _, triple := range result.Triples {
+			values = append(values, triple.Subject, triple.Object)
+		}
+		s.usage.Record("", usage.ProvisionURIs(graph.Store(), values))
+	}
+	return response, nil
+}
+
+// Impact finds the provisions affected by a change to the requested
+// provision, which may be a full URI or a short ID such as "Art17".
+func (s *Service) Impact(ctx context.Context, request *ImpactRequest) (*ImpactResponse, error) {
+	graph, err := s.graph(request.GraphID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Provision == "" {
+		return nil, errorf(CodeInvalidArgument, "provision is required")
+	}
+	result, err := graph.Impact(request.Provision, regula.ImpactOptions{Depth: request.Depth, Direction: request.Direction})
+	if err != nil {
+		return nil, classify(err)
+	}
+
+	response := &ImpactResponse{
+		TargetURI:       result.TargetURI,
+		TargetLabel:     result.TargetLabel,
+		TotalAffected:   result.TotalAffected,
+		MaxDepthReached: result.MaxDepthReached,
+	}
+	for _, provision := range result.Affected {
+		response.Affected = append(response.Affected, ImpactedProvision(provision))
+	}
+	if s.usage != nil {
+		s.usage.Record("", []string{result.TargetURI})
+	}
+	return response, nil
+}
+
+// Match finds the provisions that apply to a predefined scenario.
+func (s *Service) Match(ctx context.Context, request *MatchRequest) (*MatchResponse, error) {
+	graph, err := s.graph(request.GraphID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := graph.Match(request.Scenario)
+	if err != nil {
+		return nil, classify(err)
+	}
+
+	response := &MatchResponse{
+		Scenario:       result.Scenario,
+		DirectCount:    result.DirectCount,
+		TriggeredCount: result.TriggeredCount,
+		RelatedCount:   result.RelatedCount,
+	}
+	var matched []string
+	for _, match := range result.Matches {
+		response.Matches = append(response.Matches, MatchedProvision(match))
+		matched = append(matched, match.URI)
+	}
+	if s.usage != nil {
+		s.usage.Record("match:"+result.Scenario, usage.ProvisionURIs(graph.Store(), matched))
+	}
+	return response, nil
+}
+
+func (s *Service) graph(graphID string) (*regula.Graph, error) {
+	if graphID == "" {
+		return nil, errorf(CodeInvalidArgument, "graph_id is required")
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	graph, ok := s.graphs[graphID]
+	if !ok {
+		return nil, errorf(CodeNotFound, "graph not found: %s (ingest it first)", graphID)
+	}
+	return graph, nil
+}
+
+// classify maps a regula API error to a service error code.
+func classify(err error) error {
+	switch {
+	case errors.Is(err, regula.ErrInvalidQuery), errors.Is(err, regula.ErrInvalidDirection):
+		return &Error{Code: CodeInvalidArgument, Message: err.Error()}
+	case errors.Is(err, regula.ErrProvisionNotFound), errors.Is(err, regula.ErrUnknownScenario):
+		return &Error{Code: CodeNotFound, Message: err.Error()}
+	default:
+		return err
+	}
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/usage"
+)
+
+func newGDPRService(t *testing.T) *Service {
+	t.Helper()
+	sourceText, err := os.ReadFile(filepath.Join("..", "..", "testdata", "gdpr.txt"))
+	if err != nil {
+		t.Skipf("GDPR test data not available: %v", err)
+	}
+	svc := New()
+	response, err := svc.Ingest(context.Background(), &IngestRequest{DocumentID: "gdpr", Text: string(sourceText)})
+	if err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if response.GraphID != "gdpr" || response.Triples == 0 || response.Articles != 99 {
+		t.Fatalf("unexpected ingest response: %+v", response)
+	}
+	return svc
+}
+
+func TestService_Operations(t *testing.T) {
+	svc := newGDPRService(t)
+	ctx := context.Background()
+
+	selectResponse, err := svc.Query(ctx, &QueryRequest{GraphID: "gdpr", Query: "SELECT ?a WHERE { ?a rdf:type reg:Article }"})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if selectResponse.Type != "select" || selectResponse.Count != 99 || len(selectResponse.Rows) != 99 {
+		t.Errorf("select: type %s count %d rows %d, want select 99 99", selectResponse.Type, selectResponse.Count, len(selectResponse.Rows))
+	}
+
+	constructResponse, err := svc.Query(ctx, &QueryRequest{GraphID: "gdpr", Query: "CONSTRUCT { ?a reg:title ?t } WHERE { ?a rdf:type reg:Article . ?a reg:title ?t }"})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if constructResponse.Type != "construct" || len(constructResponse.Triples) == 0 {
+		t.Errorf("construct: type %s with %d triples", constructResponse.Type, len(constructResponse.Triples))
+	}
+
+	impactResponse, err := svc.Impact(ctx, &ImpactRequest{GraphID: "gdpr", Provision: "Art17", Depth: 1})
+	if err != nil {
+		t.Fatalf("Impact failed: %v", err)
+	}
+	if !strings.HasSuffix(impactResponse.TargetURI, "GDPR:Art17") || len(impactResponse.Affected) == 0 {
+		t.Errorf("impact: target %s with %d affected", impactResponse.TargetURI, len(impactResponse.Affected))
+	}
+
+	matchResponse, err := svc.Match(ctx, &MatchRequest{GraphID: "gdpr", Scenario: "access_request"})
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+	if matchResponse.DirectCount == 0 || len(matchResponse.Matches) == 0 {
+		t.Errorf("match: %d direct of %d matches", matchResponse.DirectCount, len(matchResponse.Matches))
+	}
+}
+
+func TestService_UsageTracking(t *testing.T) {
+	svc := newGDPRService(t)
+	tracker, err := usage.Open(filepath.Join(t.TempDir(), usage.FileName))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	svc.SetUsageTracker(tracker)
+	ctx := context.Background()
+
+	if _, err := svc.Query(ctx, &QueryRequest{GraphID: "gdpr", Query: "SELECT ?t WHERE { <https://regula.dev/regulations/GDPR:Art17> reg:title ?t }"}); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if _, err := svc.Query(ctx, &QueryRequest{GraphID: "gdpr", Query: "SELECT ?a WHERE { ?a rdf:type reg:Article . ?a reg:title ?t . FILTER(CONTAINS(?t, \"erasure\")) }"}); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if _, err := svc.Impact(ctx, &ImpactRequest{GraphID: "gdpr", Provision: "Art17", Depth: 1}); err != nil {
+		t.Fatalf("Impact failed: %v", err)
+	}
+	if _, err := svc.Match(ctx, &MatchRequest{GraphID: "gdpr", Scenario: "access_request"}); err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	stats := tracker.Snapshot()
+	if stats.Requests != 4 {
+		t.Errorf("Requests = %d, want 4", stats.Requests)
+	}
+	if stats.Templates["match:access_request"] != 1 {
+		t.Errorf("templates = %v, want match:access_request", stats.Templates)
+	}
+	art17 := stats.Provisions["https://regula.dev/regulations/GDPR:Art17"]
+	if art17 == nil || art17.Count < 2 || art17.Document != "GDPR" {
+		t.Errorf("Art17 usage = %+v, want at least 2 accesses in GDPR", art17)
+	}
+}
+
+func TestService_Errors(t *testing.T) {
+	svc := newGDPRService(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+		want Code
+	}{
+		{"ingest without ID", func() error {
+			_, err := svc.Ingest(ctx, &IngestRequest{Text: "Article 1"})
+			return err
+		}, CodeInvalidArgument},
+		{"unknown graph", func() error {
+			_, err := svc.Query(ctx, &QueryRequest{GraphID: "ccpa", Query: "SELECT ?a WHERE { ?a ?p ?o }"})
+			return err
+		}, CodeNotFound},
+		{"bad query", func() error {
+			_, err := svc.Query(ctx, &QueryRequest{GraphID: "gdpr", Query: "SELECT WHERE"})
+			return err
+		}, CodeInvalidArgument},
+		{"unknown provision", func() error {
+			_, err := svc.Impact(ctx, &ImpactRequest{GraphID: "gdpr", Provision: "Art999"})
+			return err
+		}, CodeNotFound},
+		{"bad direction", func() error {
+			_, err := svc.Impact(ctx, &ImpactRequest{GraphID: "gdpr", Provision: "Art17", Direction: "sideways"})
+			return err
+		}, CodeInvalidArgument},
+		{"unknown scenario", func() error {
+			_, err := svc.Match(ctx, &MatchRequest{GraphID: "gdpr", Scenario: "nope"})
+			return err
+		}, CodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if code := ErrorCode(err); code != tt.want {
+				t.Errorf("code = %s, want %s (%v)", code, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler := NewHandler(newGDPRService(t))
+
+	post := func(method, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, MethodPrefix+method, strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	response := post("Query", `{"graph_id": "gdpr", "query": "SELECT ?a WHERE { ?a rdf:type reg:Article } LIMIT 3"}`)
+	if response.Code != http.StatusOK {
+		t.Fatalf("Query status = %d: %s", response.Code, response.Body.String())
+	}
+	var queryResponse QueryResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &queryResponse); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if queryResponse.Count != 3 {
+		t.Errorf("count = %d, want 3", queryResponse.Count)
+	}
+
+	if response := post("Match", `{"graph_id": "missing", "scenario": "access_request"}`); response.Code != http.StatusNotFound ||
+		!strings.Contains(response.Body.String(), `"code":"not_found"`) {
+		t.Errorf("missing graph = %d %s, want 404 not_found", response.Code, response.Body.String())
+	}
+	if response := post("Impact", `not json`); response.Code != http.StatusBadRequest {
+		t.Errorf("invalid body status = %d, want 400", response.Code)
+	}
+	if response := post("Delete", `{}`); response.Code != http.StatusNotFound {
+		t.Errorf("unknown method status = %d, want 404", response.Code)
+	}
+}
+
+func TestHandlerConnectProtocol(t *testing.T) {
+	handler := NewHandler(newGDPRService(t))
+
+	serve := func(request *http.Request) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// Connect clients send proto3 JSON names and the protocol version header.
+	request := httptest.NewRequest(http.MethodPost, MethodPrefix+"Impact",
+		strings.NewReader(`{"graphId": "gdpr", "provision": "Art17", "depth": 1}`))
+	request.Header.Set("Content-Type", "application/json; charset=utf-8")
+	request.Header.Set("Connect-Protocol-Version", "1")
+	request.Header.Set("Connect-Timeout-Ms", "10000")
+	response := serve(request)
+	if response.Code != http.StatusOK {
+		t.Fatalf("Impact status = %d: %s", response.Code, response.Body.String())
+	}
+	var impactResponse ImpactResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &impactResponse); err != nil || impactResponse.TargetURI == "" {
+		t.Errorf("Impact response = %s (%v), want the target provision", response.Body.String(), err)
+	}
+
+	// Errors are Connect error bodies.
+	request = httptest.NewRequest(http.MethodPost, MethodPrefix+"Query", strings.NewReader(`{"graphId": "missing", "query": "SELECT ?a WHERE { ?a ?b ?c }"}`))
+	request.Header.Set("Content-Type", "application/json")
+	response = serve(request)
+	var connectError struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(response.Body.Bytes(), &connectError); err != nil ||
+		response.Code != http.StatusNotFound || connectError.Code != "not_found" || connectError.Message == "" {
+		t.Errorf("missing graph = %d %s, want a not_found error", response.Code, response.Body.String())
+	}
+
+	// The binary protobuf codec is not served.
+	request = httptest.NewRequest(http.MethodPost, MethodPrefix+"Query", strings.NewReader(""))
+	request.Header.Set("Content-Type", "application/proto")
+	if response := serve(request); response.Code != http.StatusUnsupportedMediaType || response.Header().Get("Accept-Post") != "application/json" {
+		t.Errorf("proto codec status = %d, want 415", response.Code)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, MethodPrefix+"Query", strings.NewReader("{}"))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Connect-Protocol-Version", "2")
+	if response := serve(request); response.Code != http.StatusBadRequest {
+		t.Errorf("unknown protocol version status = %d, want 400", response.Code)
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+// IngestRequest mirrors regula.v1.IngestRequest.
+type IngestRequest struct {
+	DocumentID string `json:"document_id"`
+	Text       string `json:"text"`
+	Format     string `json:"format,omitempty"`
+	BaseURI    string `json:"base_uri,omitempty"`
+}
+
+// IngestResponse mirrors regula.v1.IngestResponse.
+type IngestResponse struct {
+	GraphID     string `json:"graph_id"`
+	Triples     int    `json:"triples"`
+	Articles    int    `json:"articles"`
+	Definitions int    `json:"definitions"`
+	References  int    `json:"references"`
+	Rights      int    `json:"rights"`
+	Obligations int    `json:"obligations"`
+}
+
+// QueryRequest mirrors regula.v1.QueryRequest.
+type QueryRequest struct {
+	GraphID string `json:"graph_id"`
+	Query   string `json:"query"`
+}
+
+// Triple mirrors regula.v1.Triple.
+type Triple struct {
+	Subject   string `json:"subject"`
+	Predicate string `json:"predicate"`
+	Object    string `json:"object"`
+}
+
+// Binding mirrors regula.v1.Binding: one SELECT result row.
+type Binding struct {
+	Values map[string]string `json:"values"`
+}
+
+// QueryResponse mirrors regula.v1.QueryResponse.
+type QueryResponse struct {
+	Type       string    `json:"type"`
+	Variables  []string  `json:"variables,omitempty"`
+	Rows       []Binding `json:"rows,omitempty"`
+	Triples    []Triple  `json:"triples,omitempty"`
+	Count      int       `json:"count"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// ImpactRequest mirrors regula.v1.ImpactRequest.
+type ImpactRequest struct {
+	GraphID   string `json:"graph_id"`
+	Provision string `json:"provision"`
+	Depth     int    `json:"depth,omitempty"`
+	Direction string `json:"direction,omitempty"`
+}
+
+// ImpactedProvision mirrors regula.v1.ImpactedProvision.
+type ImpactedProvision struct {
+	URI       string `json:"uri"`
+	Label     string `json:"label"`
+	Type      string `json:"type"`
+	Depth     int    `json:"depth"`
+	Impact    string `json:"impact"`
+	Direction string `json:"direction"`
+}
+
+// ImpactResponse mirrors regula.v1.ImpactResponse.
+type ImpactResponse struct {
+	TargetURI       string              `json:"target_uri"`
+	TargetLabel     string              `json:"target_label"`
+	Affected        []ImpactedProvision `json:"affected"`
+	TotalAffected   int                 `json:"total_affected"`
+	MaxDepthReached int                 `json:"max_depth_reached"`
+}
+
+// MatchRequest mirrors regula.v1.MatchRequest.
+type MatchRequest struct {
+	GraphID  string `json:"graph_id"`
+	Scenario string `json:"scenario"`
+}
+
+// MatchedProvision mirrors regula.v1.MatchedProvision.
+type MatchedProvision struct {
+	URI       string   `json:"uri"`
+	Title     string   `json:"title"`
+	Relevance string   `json:"relevance"`
+	Score     float64  `json:"score"`
+	Reasons   []string `json:"reasons"`
+}
+
+// MatchResponse mirrors regula.v1.MatchResponse.
+type MatchResponse struct {
+	Scenario       string             `json:"scenario"`
+	Matches        []MatchedProvision `json:"matches"`
+	DirectCount    int                `json:"direct_count"`
+	TriggeredCount int                `json:"triggered_count"`
+	RelatedCount   int                `json:"related_count"`
+}
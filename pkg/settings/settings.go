@@ -0,0 +1,258 @@
+// Package settings supplies default flag values from configuration files so
+// options repeated on every command line (--base-uri, --path, rate limits)
+// can be written down once.
+//
+// Settings are read from the user file ~/.regula/config.yaml and the
+// project file .regula/config.yaml, the library config that also holds the
+// language, daemon, and http sections:
+//
+//	defaults:
+//	  base-uri: https://regula.dev/regulations/
+//	  crawl.rate-limit: 2s
+//	  bulk.download.concurrency: 2
+//	profile: work
+//	profiles:
+//	  work:
+//	    path: /srv/regula/library
+//	    http-cache: /srv/regula/http-cache
+//
+// A key names a flag, optionally prefixed with the dotted path of the
+// command it applies to. A value applies to every command that has the
+// flag unless a more specific key overrides it. Later sources win:
+//
+//  1. user defaults
+//  2. project defaults
+//  3. the selected profile (user, then project)
+//  4. environment variables: REGULA_BASE_URI for --base-uri
+//  5. flags given on the command line
+package settings
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the user and project configuration files.
+const FileName = "config.yaml"
+
+const (
+	// EnvPrefix starts the environment variables that override settings.
+	EnvPrefix = "REGULA_"
+
+	// ProfileEnv selects a profile when --config-profile is not given.
+	ProfileEnv = "REGULA_PROFILE"
+
+	// HomeEnv overrides the directory holding the user configuration file.
+	HomeEnv = "REGULA_CONFIG_HOME"
+)
+
+// ProjectDir is the directory holding the project configuration file.
+const ProjectDir = ".regula"
+
+// File is the settings part of a configuration file. Other sections of the
+// file are left to the packages that own them.
+type File struct {
+	// Defaults maps setting keys to values.
+	Defaults map[string]any `yaml:"defaults,omitempty"`
+
+	// Profile is the profile used when none is selected.
+	Profile string `yaml:"profile,omitempty"`
+
+	// Profiles holds named sets of settings chosen with --config-profile.
+	Profiles map[string]map[string]any `yaml:"profiles,omitempty"`
+}
+
+// Layer is one source of settings.
+type Layer struct {
+	// Source describes where the values came from, such as
+	// "project (.regula/config.yaml)".
+	Source string
+
+	// Values maps setting keys to values.
+	Values map[string]string
+}
+
+// Settings are the layered settings for one invocation.
+type Settings struct {
+	// Profile is the selected profile, empty when none is.
+	Profile string
+
+	// Layers are ordered from lowest to highest precedence. The
+	// environment is consulted after them.
+	Layers []Layer
+}
+
+// UserPath returns the user configuration file: config.yaml in
+// $REGULA_CONFIG_HOME, or in ~/.regula.
+func UserPath() string {
+	if dir := os.Getenv(HomeEnv); dir != "" {
+		return filepath.Join(dir, FileName)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".regula", FileName)
+}
+
+// ProjectPath returns the project configuration file.
+func ProjectPath() string {
+	return filepath.Join(ProjectDir, FileName)
+}
+
+// Load reads the user and project configuration files and selects profile,
+// or $REGULA_PROFILE, or the profile named in the project or user file.
+// Missing files contribute nothing; naming a profile that neither file
+// defines is an error.
+func Load(profile string) (*Settings, error) {
+	userPath, projectPath := UserPath(), ProjectPath()
+	userFile, err := ReadFile(userPath)
+	if err != nil {
+		return nil, err
+	}
+	projectFile, err := ReadFile(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile == "" {
+		profile = os.Getenv(ProfileEnv)
+	}
+	if profile == "" {
+		profile = projectFile.Profile
+	}
+	if profile == "" {
+		profile = userFile.Profile
+	}
+
+	settings := &Settings{Profile: profile}
+	settings.add(fmt.Sprintf("user (%s)", userPath), userFile.Defaults)
+	settings.add(fmt.Sprintf("project (%s)", projectPath), projectFile.Defaults)
+	if profile != "" {
+		userProfile, inUser := userFile.Profiles[profile]
+		projectProfile, inProject := projectFile.Profiles[profile]
+		if !inUser && !inProject {
+			return nil, fmt.Errorf("unknown profile %q: not defined in %s or %s", profile, userPath, projectPath)
+		}
+		settings.add(fmt.Sprintf("profile %s (%s)", profile, userPath), userProfile)
+		settings.add(fmt.Sprintf("profile %s (%s)", profile, projectPath), projectProfile)
+	}
+	return settings, nil
+}
+
+// ReadFile reads the settings part of a configuration file. A missing file
+// yields an empty File.
+func ReadFile(path string) (*File, error) {
+	file := &File{}
+	if path == "" {
+		return file, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// add appends a layer unless values is empty.
+func (s *Settings) add(source string, values map[string]any) {
+	if len(values) == 0 {
+		return
+	}
+	layer := Layer{Source: source, Values: make(map[string]string, len(values))}
+	for key, value := range values {
+		layer.Values[key] = stringValue(value)
+	}
+	s.Layers = append(s.Layers, layer)
+}
+
+// Lookup returns the value of flag for the command at commandPath (such as
+// "bulk download") and where it came from. Within a layer a key scoped to
+// the command, or to one of its parents, wins over the bare flag name.
+func (s *Settings) Lookup(commandPath, flag string) (value, source string, found bool) {
+	if value := os.Getenv(EnvName(flag)); value != "" {
+		return value, "env " + EnvName(flag), true
+	}
+	keys := scopedKeys(commandPath, flag)
+	for i := len(s.Layers) - 1; i >= 0; i-- {
+		for _, key := range keys {
+			if value, found := s.Layers[i].Values[key]; found {
+				return value, s.Layers[i].Source, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// Keys returns every key set in any layer, sorted.
+func (s *Settings) Keys() []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, layer := range s.Layers {
+		for key := range layer.Values {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// EnvName returns the environment variable overriding flag: REGULA_ and the
+// flag name upper-cased with dashes as underscores.
+func EnvName(flag string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// SplitKey separates a key into its command path and flag name:
+// "bulk.download.concurrency" is the concurrency flag of "bulk download".
+func SplitKey(key string) (commandPath, flag string) {
+	index := strings.LastIndex(key, ".")
+	if index < 0 {
+		return "", key
+	}
+	return strings.ReplaceAll(key[:index], ".", " "), key[index+1:]
+}
+
+// scopedKeys lists the keys that can set flag for the command, most
+// specific first.
+func scopedKeys(commandPath, flag string) []string {
+	parts := strings.Fields(commandPath)
+	keys := make([]string, 0, len(parts)+1)
+	for i := len(parts); i > 0; i-- {
+		keys = append(keys, strings.Join(parts[:i], ".")+"."+flag)
+	}
+	return append(keys, flag)
+}
+
+// stringValue renders a YAML value as a flag value. Lists become
+// comma-separated values for slice flags.
+func stringValue(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case []any:
+		items := make([]string, len(typed))
+		for i, item := range typed {
+			items[i] = stringValue(item)
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(typed)
+	}
+}
@@ -0,0 +1,163 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupFiles points the user configuration at a temporary directory and
+// runs the test from another one holding the project configuration.
+func setupFiles(t *testing.T, user, project string) {
+	t.Helper()
+	userDir, projectDir := t.TempDir(), t.TempDir()
+	t.Setenv(HomeEnv, userDir)
+	t.Setenv(ProfileEnv, "")
+	t.Chdir(projectDir)
+	for path, content := range map[string]string{UserPath(): user, ProjectPath(): project} {
+		if content == "" {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	setupFiles(t, `defaults:
+  base-uri: https://user.example/
+  rate-limit: 5s
+profiles:
+  work:
+    rate-limit: 3s
+`, `language: de
+defaults:
+  rate-limit: 2s
+  crawl.rate-limit: 1s
+  allowed-domains: [eur-lex.europa.eu, www.legislation.gov.uk]
+profiles:
+  work:
+    path: /srv/regula
+`)
+
+	settings, err := Load("")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	for _, tc := range []struct {
+		command, flag, want string
+	}{
+		{"ingest", "base-uri", "https://user.example/"},
+		{"bulk download", "rate-limit", "2s"},
+		{"crawl", "rate-limit", "1s"},
+		{"crawl", "allowed-domains", "eur-lex.europa.eu,www.legislation.gov.uk"},
+	} {
+		if got, _, _ := settings.Lookup(tc.command, tc.flag); got != tc.want {
+			t.Errorf("Lookup(%q, %q) = %q, want %q", tc.command, tc.flag, got, tc.want)
+		}
+	}
+	if _, _, found := settings.Lookup("ingest", "path"); found {
+		t.Error("profile value used without selecting the profile")
+	}
+
+	work, err := Load("work")
+	if err != nil {
+		t.Fatalf("Load(work) failed: %v", err)
+	}
+	if got, source, _ := work.Lookup("bulk download", "rate-limit"); got != "3s" || !strings.HasPrefix(source, "profile work") {
+		t.Errorf("profile rate-limit = %q from %q, want 3s from the profile", got, source)
+	}
+	if got, _, _ := work.Lookup("status", "path"); got != "/srv/regula" {
+		t.Errorf("profile path = %q", got)
+	}
+
+	t.Setenv("REGULA_RATE_LIMIT", "500ms")
+	if got, source, _ := work.Lookup("crawl", "rate-limit"); got != "500ms" || source != "env REGULA_RATE_LIMIT" {
+		t.Errorf("env rate-limit = %q from %q", got, source)
+	}
+}
+
+func TestLoadProfileSelection(t *testing.T) {
+	setupFiles(t, "profile: home\nprofiles:\n  home:\n    path: ~/regula\n", "profiles:\n  ci:\n    path: build/library\n")
+
+	settings, err := Load("")
+	if err != nil || settings.Profile != "home" {
+		t.Fatalf("Load() profile = %v, %v, want the user file's profile", settings, err)
+	}
+
+	t.Setenv(ProfileEnv, "ci")
+	settings, err = Load("")
+	if err != nil || settings.Profile != "ci" {
+		t.Fatalf("Load() profile = %v, %v, want $%s", settings, err, ProfileEnv)
+	}
+
+	if _, err := Load("missing"); err == nil || !strings.Contains(err.Error(), "unknown profile") {
+		t.Errorf("Load(missing) error = %v, want unknown profile", err)
+	}
+}
+
+func TestSetAndUnset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".regula", FileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	original := "# library settings\nlanguage: fr\ndaemon:\n  interval: 1h\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Set(path, "", "base-uri", "https://example.org/regs/"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := Set(path, "", "base-uri", "https://example.org/laws/"); err != nil {
+		t.Fatalf("Set (overwrite) failed: %v", err)
+	}
+	if err := Set(path, "work", "crawl.max-depth", "3"); err != nil {
+		t.Fatalf("Set (profile) failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{"# library settings", "language: fr", "interval: 1h"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Set dropped %q from the file:\n%s", want, data)
+		}
+	}
+	file, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if file.Defaults["base-uri"] != "https://example.org/laws/" || len(file.Defaults) != 1 {
+		t.Errorf("defaults = %v", file.Defaults)
+	}
+	if file.Profiles["work"]["crawl.max-depth"] != 3 {
+		t.Errorf("profiles = %v", file.Profiles)
+	}
+
+	if err := Unset(path, "", "base-uri"); err != nil {
+		t.Fatalf("Unset failed: %v", err)
+	}
+	if err := Unset(path, "other", "path"); err != nil {
+		t.Fatalf("Unset of a missing profile failed: %v", err)
+	}
+	file, _ = ReadFile(path)
+	if _, found := file.Defaults["base-uri"]; found {
+		t.Errorf("base-uri still set: %v", file.Defaults)
+	}
+}
+
+func TestSplitKey(t *testing.T) {
+	if command, flag := SplitKey("bulk.download.concurrency"); command != "bulk download" || flag != "concurrency" {
+		t.Errorf("SplitKey = %q, %q", command, flag)
+	}
+	if command, flag := SplitKey("base-uri"); command != "" || flag != "base-uri" {
+		t.Errorf("SplitKey = %q, %q", command, flag)
+	}
+	if EnvName("base-uri") != "REGULA_BASE_URI" {
+		t.Errorf("EnvName = %q", EnvName("base-uri"))
+	}
+}
@@ -0,0 +1,122 @@
+package settings
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Set writes key = value to the defaults section of the configuration file
+// at path, or to the named profile when profile is not empty. The file's
+// other sections and comments are kept.
+func Set(path, profile, key, value string) error {
+	return edit(path, func(root *yaml.Node) error {
+		section, err := section(root, profile, true)
+		if err != nil {
+			return err
+		}
+		for i := 0; i+1 < len(section.Content); i += 2 {
+			if section.Content[i].Value == key {
+				section.Content[i+1] = scalar(value)
+				return nil
+			}
+		}
+		section.Content = append(section.Content, scalar(key), scalar(value))
+		return nil
+	})
+}
+
+// Unset removes key from the defaults section of the configuration file at
+// path, or from the named profile. Removing a key that is not set is not an
+// error.
+func Unset(path, profile, key string) error {
+	return edit(path, func(root *yaml.Node) error {
+		section, err := section(root, profile, false)
+		if err != nil || section == nil {
+			return err
+		}
+		for i := 0; i+1 < len(section.Content); i += 2 {
+			if section.Content[i].Value == key {
+				section.Content = append(section.Content[:i], section.Content[i+2:]...)
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+// edit applies change to the document at path, creating the file and its
+// directory if needed.
+func edit(path string, change func(root *yaml.Node) error) error {
+	var document yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if document.Kind == 0 {
+		document = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s is not a YAML mapping", path)
+	}
+	if err := change(root); err != nil {
+		return fmt.Errorf("failed to update %s: %w", path, err)
+	}
+
+	output, err := yaml.Marshal(&document)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	return os.WriteFile(path, output, 0644)
+}
+
+// section returns the defaults mapping, or the mapping of the named
+// profile, adding it when create is set. It returns nil when the section
+// does not exist and create is not set.
+func section(root *yaml.Node, profile string, create bool) (*yaml.Node, error) {
+	if profile == "" {
+		return child(root, "defaults", create)
+	}
+	profiles, err := child(root, "profiles", create)
+	if err != nil || profiles == nil {
+		return nil, err
+	}
+	return child(profiles, profile, create)
+}
+
+// child returns the mapping stored under key in node.
+func child(node *yaml.Node, key string, create bool) (*yaml.Node, error) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != key {
+			continue
+		}
+		value := node.Content[i+1]
+		if value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+			value.Kind, value.Tag, value.Value = yaml.MappingNode, "", ""
+		}
+		if value.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%q is not a mapping", key)
+		}
+		return value, nil
+	}
+	if !create {
+		return nil, nil
+	}
+	value := &yaml.Node{Kind: yaml.MappingNode}
+	node.Content = append(node.Content, scalar(key), value)
+	return value, nil
+}
+
+// scalar returns a plain scalar node.
+func scalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+}
@@ -0,0 +1,350 @@
+package simulate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"gopkg.in/yaml.v3"
+)
+
+// ScenarioExpectations declares the outcome a scenario must produce, turning
+// it into a regulation unit test that can run in CI against an ingested
+// corpus.
+type ScenarioExpectations struct {
+	// MustMatch lists provisions that must be matched.
+	MustMatch []ProvisionExpectation `json:"must_match,omitempty" yaml:"must_match,omitempty"`
+
+	// RequiredObligations lists obligation types the matched provisions
+	// must impose.
+	RequiredObligations []extract.ObligationType `json:"required_obligations,omitempty" yaml:"required_obligations,omitempty"`
+
+	// RequiredRights lists right types the matched provisions must grant.
+	RequiredRights []extract.RightType `json:"required_rights,omitempty" yaml:"required_rights,omitempty"`
+
+	// ForbiddenMatches lists provisions that must not be matched.
+	ForbiddenMatches []ProvisionExpectation `json:"forbidden_matches,omitempty" yaml:"forbidden_matches,omitempty"`
+}
+
+// ProvisionExpectation names a provision such as "Art17" or "Article 7(3)",
+// optionally with a relevance level. For must_match the provision must be
+// matched at that relevance or stronger; for forbidden_matches it must not
+// be. Without a relevance any match counts.
+//
+// In YAML and JSON an expectation is either a plain string or a mapping:
+//
+//	must_match:
+//	  - Art17
+//	  - {provision: Art7, relevance: direct}
+type ProvisionExpectation struct {
+	Provision string         `json:"provision" yaml:"provision"`
+	Relevance RelevanceScore `json:"relevance,omitempty" yaml:"relevance,omitempty"`
+}
+
+// UnmarshalYAML accepts either a provision string or a mapping.
+func (p *ProvisionExpectation) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		p.Provision = node.Value
+		return nil
+	}
+	type plain ProvisionExpectation
+	if err := node.Decode((*plain)(p)); err != nil {
+		return err
+	}
+	p.Relevance = RelevanceScore(strings.ToUpper(string(p.Relevance)))
+	return nil
+}
+
+// UnmarshalJSON accepts either a provision string or an object.
+func (p *ProvisionExpectation) UnmarshalJSON(data []byte) error {
+	var provision string
+	if err := json.Unmarshal(data, &provision); err == nil {
+		p.Provision = provision
+		return nil
+	}
+	type plain ProvisionExpectation
+	if err := json.Unmarshal(data, (*plain)(p)); err != nil {
+		return err
+	}
+	p.Relevance = RelevanceScore(strings.ToUpper(string(p.Relevance)))
+	return nil
+}
+
+// String returns the expectation as written in a scenario.
+func (p ProvisionExpectation) String() string {
+	if p.Relevance == "" {
+		return p.Provision
+	}
+	return fmt.Sprintf("%s (%s)", p.Provision, strings.ToLower(string(p.Relevance)))
+}
+
+// IsEmpty reports whether no expectations are declared.
+func (e *ScenarioExpectations) IsEmpty() bool {
+	return e == nil || len(e.MustMatch)+len(e.RequiredObligations)+len(e.RequiredRights)+len(e.ForbiddenMatches) == 0
+}
+
+// AssertionKind identifies the type of expectation an assertion checks.
+type AssertionKind string
+
+const (
+	AssertMustMatch          AssertionKind = "must_match"
+	AssertRequiredObligation AssertionKind = "required_obligation"
+	AssertRequiredRight      AssertionKind = "required_right"
+	AssertForbiddenMatch     AssertionKind = "forbidden_match"
+)
+
+// AssertionResult is the outcome of checking a single expectation.
+type AssertionResult struct {
+	Kind   AssertionKind `json:"kind"`
+	Target string        `json:"target"`
+	Passed bool          `json:"passed"`
+	Detail string        `json:"detail"`
+}
+
+// AssertionReport collects the assertion results for one scenario.
+type AssertionReport struct {
+	ScenarioID   string             `json:"scenario_id"`
+	ScenarioName string             `json:"scenario_name"`
+	Document     string             `json:"document,omitempty"`
+	Results      []*AssertionResult `json:"results"`
+	Passed       int                `json:"passed"`
+	Failed       int                `json:"failed"`
+}
+
+// OK reports whether every assertion passed.
+func (r *AssertionReport) OK() bool {
+	return r.Failed == 0
+}
+
+// relevanceRank orders relevance levels so that DIRECT is the strongest.
+var relevanceRank = map[RelevanceScore]int{
+	RelevanceRelated:   1,
+	RelevanceTriggered: 2,
+	RelevanceDirect:    3,
+}
+
+// Assert checks the match result against the scenario's expectations.
+func (r *MatchResult) Assert(expect *ScenarioExpectations) *AssertionReport {
+	report := &AssertionReport{
+		Results: make([]*AssertionResult, 0),
+	}
+	if r.Scenario != nil {
+		report.ScenarioID = r.Scenario.ID
+		report.ScenarioName = r.Scenario.Name
+		report.Document = r.Scenario.Document
+	}
+	if expect == nil {
+		return report
+	}
+
+	for _, expectation := range expect.MustMatch {
+		report.add(r.assertProvision(AssertMustMatch, expectation))
+	}
+	for _, obligationType := range expect.RequiredObligations {
+		report.add(r.assertObligation(obligationType))
+	}
+	for _, rightType := range expect.RequiredRights {
+		report.add(r.assertRight(rightType))
+	}
+	for _, expectation := range expect.ForbiddenMatches {
+		report.add(r.assertProvision(AssertForbiddenMatch, expectation))
+	}
+
+	return report
+}
+
+func (r *AssertionReport) add(result *AssertionResult) {
+	r.Results = append(r.Results, result)
+	if result.Passed {
+		r.Passed++
+	} else {
+		r.Failed++
+	}
+}
+
+// assertProvision checks a must_match or forbidden_match expectation.
+func (r *MatchResult) assertProvision(kind AssertionKind, expectation ProvisionExpectation) *AssertionResult {
+	result := &AssertionResult{Kind: kind, Target: expectation.String()}
+
+	articleNum, ok := parseProvisionNumber(expectation.Provision)
+	if !ok {
+		result.Detail = fmt.Sprintf("cannot parse provision %q", expectation.Provision)
+		return result
+	}
+	if expectation.Relevance != "" && relevanceRank[expectation.Relevance] == 0 {
+		result.Detail = fmt.Sprintf("unknown relevance %q", expectation.Relevance)
+		return result
+	}
+
+	var match *MatchedProvision
+	for _, candidate := range r.AllMatches {
+		if candidate.ArticleNum == articleNum {
+			match = candidate
+			break
+		}
+	}
+	satisfies := match != nil && relevanceRank[match.Relevance] >= relevanceRank[expectation.Relevance]
+
+	switch kind {
+	case AssertMustMatch:
+		result.Passed = satisfies
+		switch {
+		case match == nil:
+			result.Detail = fmt.Sprintf("Art %d not matched", articleNum)
+		case !satisfies:
+			result.Detail = fmt.Sprintf("Art %d matched as %s, want %s", articleNum, match.Relevance, expectation.Relevance)
+		default:
+			result.Detail = fmt.Sprintf("Art %d matched as %s (score: %.2f)", articleNum, match.Relevance, match.Score)
+		}
+	case AssertForbiddenMatch:
+		result.Passed = !satisfies
+		switch {
+		case match == nil:
+			result.Detail = fmt.Sprintf("Art %d not matched", articleNum)
+		case satisfies:
+			result.Detail = fmt.Sprintf("Art %d matched as %s: %s", articleNum, match.Relevance, strings.Join(match.MatchReasons, "; "))
+		default:
+			result.Detail = fmt.Sprintf("Art %d matched only as %s", articleNum, match.Relevance)
+		}
+	}
+	return result
+}
+
+// assertObligation checks that some matched provision imposes obligationType.
+func (r *MatchResult) assertObligation(obligationType extract.ObligationType) *AssertionResult {
+	result := &AssertionResult{Kind: AssertRequiredObligation, Target: string(obligationType)}
+
+	var articles []int
+	for _, match := range r.AllMatches {
+		for _, obligation := range match.Obligations {
+			if obligation.ObligationType == obligationType {
+				articles = appendUnique(articles, match.ArticleNum)
+			}
+		}
+	}
+
+	result.Passed = len(articles) > 0
+	result.Detail = describeArticles("imposed by", "not imposed by any matched provision", articles)
+	return result
+}
+
+// assertRight checks that some matched provision grants rightType.
+func (r *MatchResult) assertRight(rightType extract.RightType) *AssertionResult {
+	result := &AssertionResult{Kind: AssertRequiredRight, Target: string(rightType)}
+
+	var articles []int
+	for _, match := range r.AllMatches {
+		for _, right := range match.Rights {
+			if right.RightType == rightType {
+				articles = appendUnique(articles, match.ArticleNum)
+			}
+		}
+	}
+
+	result.Passed = len(articles) > 0
+	result.Detail = describeArticles("granted by", "not granted by any matched provision", articles)
+	return result
+}
+
+// describeArticles lists articles as "<prefix> Art 6, Art 7", or returns
+// missing when there are none.
+func describeArticles(prefix string, missing string, articles []int) string {
+	if len(articles) == 0 {
+		return missing
+	}
+	sort.Ints(articles)
+	labels := make([]string, len(articles))
+	for i, articleNum := range articles {
+		labels[i] = fmt.Sprintf("Art %d", articleNum)
+	}
+	return prefix + " " + strings.Join(labels, ", ")
+}
+
+// parseProvisionNumber extracts the article number from a provision
+// reference such as "Art17", "Article 7(3)", or "17".
+func parseProvisionNumber(provision string) (int, bool) {
+	start := strings.IndexAny(provision, "0123456789")
+	if start < 0 {
+		return 0, false
+	}
+	end := start
+	for end < len(provision) && provision[end] >= '0' && provision[end] <= '9' {
+		end++
+	}
+	num, err := strconv.Atoi(provision[start:end])
+	if err != nil {
+		return 0, false
+	}
+	return num, true
+}
+
+// SuiteResult aggregates assertion reports for a compliance test suite.
+type SuiteResult struct {
+	Reports          []*AssertionReport `json:"reports"`
+	ScenariosPassed  int                `json:"scenarios_passed"`
+	ScenariosFailed  int                `json:"scenarios_failed"`
+	AssertionsPassed int                `json:"assertions_passed"`
+	AssertionsFailed int                `json:"assertions_failed"`
+}
+
+// NewSuiteResult tallies the given assertion reports.
+func NewSuiteResult(reports []*AssertionReport) *SuiteResult {
+	suite := &SuiteResult{Reports: reports}
+	for _, report := range reports {
+		suite.AssertionsPassed += report.Passed
+		suite.AssertionsFailed += report.Failed
+		if report.OK() {
+			suite.ScenariosPassed++
+		} else {
+			suite.ScenariosFailed++
+		}
+	}
+	return suite
+}
+
+// OK reports whether every scenario in the suite passed.
+func (s *SuiteResult) OK() bool {
+	return s.ScenariosFailed == 0
+}
+
+// ToJSON serializes the suite result to JSON.
+func (s *SuiteResult) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// String returns a human-readable test report.
+func (s *SuiteResult) String() string {
+	var sb strings.Builder
+
+	for _, report := range s.Reports {
+		status := "PASS"
+		if !report.OK() {
+			status = "FAIL"
+		}
+		sb.WriteString(fmt.Sprintf("%s  %s", status, report.ScenarioName))
+		if report.Document != "" {
+			sb.WriteString(fmt.Sprintf(" [%s]", report.Document))
+		}
+		sb.WriteString("\n")
+
+		if len(report.Results) == 0 {
+			sb.WriteString("      (no expectations declared)\n")
+		}
+		for _, result := range report.Results {
+			mark := "ok  "
+			if !result.Passed {
+				mark = "FAIL"
+			}
+			sb.WriteString(fmt.Sprintf("  %s %s %s: %s\n", mark, result.Kind, result.Target, result.Detail))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%d scenarios: %d passed, %d failed (%d/%d assertions passed)\n",
+		len(s.Reports), s.ScenariosPassed, s.ScenariosFailed,
+		s.AssertionsPassed, s.AssertionsPassed+s.AssertionsFailed))
+
+	return sb.String()
+}
@@ -0,0 +1,209 @@
+package simulate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func TestScenarioFromYAML_Expectations(t *testing.T) {
+	data := []byte(`
+name: Erasure request
+document: eu-gdpr
+actions:
+  - type: request_erasure
+    actor: data_subject
+expect:
+  must_match:
+    - Art17
+    - {provision: Art12, relevance: triggered}
+  required_rights: [RightToErasure]
+  required_obligations: [ResponseObligation]
+  forbidden_matches: [Art99]
+`)
+	path := filepath.Join(t.TempDir(), "erasure.yaml")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scenario, err := LoadScenarioFile(path)
+	if err != nil {
+		t.Fatalf("LoadScenarioFile failed: %v", err)
+	}
+	if scenario.ID != "erasure_request" || scenario.Document != "eu-gdpr" {
+		t.Errorf("scenario = %s (%s), want erasure_request (eu-gdpr)", scenario.ID, scenario.Document)
+	}
+	if len(scenario.Actions) != 1 || scenario.Actions[0].ID != "action_1" || len(scenario.Actions[0].Keywords) == 0 {
+		t.Errorf("action defaults not filled in: %+v", scenario.Actions)
+	}
+
+	expect := scenario.Expect
+	if expect == nil || len(expect.MustMatch) != 2 {
+		t.Fatalf("expectations = %+v, want 2 must_match entries", expect)
+	}
+	if expect.MustMatch[0].Provision != "Art17" || expect.MustMatch[0].Relevance != "" {
+		t.Errorf("scalar expectation = %+v", expect.MustMatch[0])
+	}
+	if expect.MustMatch[1].Provision != "Art12" || expect.MustMatch[1].Relevance != RelevanceTriggered {
+		t.Errorf("mapping expectation = %+v", expect.MustMatch[1])
+	}
+	if len(expect.RequiredRights) != 1 || expect.RequiredRights[0] != extract.RightErasure {
+		t.Errorf("required rights = %v", expect.RequiredRights)
+	}
+	if len(expect.ForbiddenMatches) != 1 || expect.ForbiddenMatches[0].Provision != "Art99" {
+		t.Errorf("forbidden matches = %v", expect.ForbiddenMatches)
+	}
+}
+
+func TestScenarioFromJSON_Expectations(t *testing.T) {
+	scenario, err := ScenarioFromJSON([]byte(`{"name": "Access", "expect": {"must_match": ["Art15", {"provision": "Art12", "relevance": "direct"}]}}`))
+	if err != nil {
+		t.Fatalf("ScenarioFromJSON failed: %v", err)
+	}
+	if len(scenario.Expect.MustMatch) != 2 || scenario.Expect.MustMatch[1].Relevance != RelevanceDirect {
+		t.Errorf("must_match = %+v", scenario.Expect.MustMatch)
+	}
+}
+
+func TestLoadScenarioFile_RequiresName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "unnamed.yaml")
+	if err := os.WriteFile(path, []byte("actions: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadScenarioFile(path); err == nil {
+		t.Error("expected error for scenario without a name")
+	}
+}
+
+func TestLoadScenarioSuite_Directory(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"b.yaml":    "name: Second\n",
+		"a.yml":     "name: First\n",
+		"c.json":    `{"name": "Third"}`,
+		"notes.txt": "not a scenario",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios, err := LoadScenarioSuite([]string{dir})
+	if err != nil {
+		t.Fatalf("LoadScenarioSuite failed: %v", err)
+	}
+	if len(scenarios) != 3 || scenarios[0].Name != "First" || scenarios[2].Name != "Third" {
+		names := make([]string, len(scenarios))
+		for i, scenario := range scenarios {
+			names[i] = scenario.Name
+		}
+		t.Errorf("scenarios = %v, want [First Second Third]", names)
+	}
+}
+
+func TestMatchResultAssert(t *testing.T) {
+	result := &MatchResult{
+		Scenario: &Scenario{ID: "test", Name: "Test"},
+		AllMatches: []*MatchedProvision{
+			{
+				ArticleNum:  17,
+				Relevance:   RelevanceDirect,
+				Rights:      []*extract.SemanticAnnotation{{RightType: extract.RightErasure}},
+				Obligations: []*extract.SemanticAnnotation{{ObligationType: extract.ObligationRespond}},
+			},
+			{ArticleNum: 12, Relevance: RelevanceTriggered},
+			{ArticleNum: 30, Relevance: RelevanceRelated},
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		expect *ScenarioExpectations
+		passed bool
+	}{
+		{"must match any relevance", &ScenarioExpectations{MustMatch: []ProvisionExpectation{{Provision: "Art30"}}}, true},
+		{"must match article form", &ScenarioExpectations{MustMatch: []ProvisionExpectation{{Provision: "Article 17(1)"}}}, true},
+		{"must match stronger relevance", &ScenarioExpectations{MustMatch: []ProvisionExpectation{{Provision: "Art17", Relevance: RelevanceTriggered}}}, true},
+		{"must match too weak", &ScenarioExpectations{MustMatch: []ProvisionExpectation{{Provision: "Art12", Relevance: RelevanceDirect}}}, false},
+		{"must match missing", &ScenarioExpectations{MustMatch: []ProvisionExpectation{{Provision: "Art5"}}}, false},
+		{"must match unparseable", &ScenarioExpectations{MustMatch: []ProvisionExpectation{{Provision: "Recital"}}}, false},
+		{"unknown relevance", &ScenarioExpectations{MustMatch: []ProvisionExpectation{{Provision: "Art17", Relevance: "STRONG"}}}, false},
+		{"required right", &ScenarioExpectations{RequiredRights: []extract.RightType{extract.RightErasure}}, true},
+		{"missing right", &ScenarioExpectations{RequiredRights: []extract.RightType{extract.RightAccess}}, false},
+		{"required obligation", &ScenarioExpectations{RequiredObligations: []extract.ObligationType{extract.ObligationRespond}}, true},
+		{"missing obligation", &ScenarioExpectations{RequiredObligations: []extract.ObligationType{extract.ObligationNotifyBreach}}, false},
+		{"forbidden absent", &ScenarioExpectations{ForbiddenMatches: []ProvisionExpectation{{Provision: "Art99"}}}, true},
+		{"forbidden present", &ScenarioExpectations{ForbiddenMatches: []ProvisionExpectation{{Provision: "Art30"}}}, false},
+		{"forbidden only when direct", &ScenarioExpectations{ForbiddenMatches: []ProvisionExpectation{{Provision: "Art30", Relevance: RelevanceDirect}}}, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			report := result.Assert(testCase.expect)
+			if len(report.Results) != 1 {
+				t.Fatalf("got %d results, want 1", len(report.Results))
+			}
+			if report.OK() != testCase.passed {
+				t.Errorf("passed = %v, want %v (%s)", report.OK(), testCase.passed, report.Results[0].Detail)
+			}
+		})
+	}
+}
+
+func TestSuiteResult(t *testing.T) {
+	suite := NewSuiteResult([]*AssertionReport{
+		{ScenarioName: "Passing", Passed: 2},
+		{ScenarioName: "Failing", Passed: 1, Failed: 1},
+	})
+	if suite.OK() || suite.ScenariosPassed != 1 || suite.ScenariosFailed != 1 {
+		t.Errorf("suite = %+v, want 1 passed and 1 failed scenario", suite)
+	}
+	if suite.AssertionsPassed != 3 || suite.AssertionsFailed != 1 {
+		t.Errorf("assertions = %d passed, %d failed; want 3, 1", suite.AssertionsPassed, suite.AssertionsFailed)
+	}
+}
+
+func TestGDPRScenarioSuite(t *testing.T) {
+	file, err := os.Open("../../testdata/gdpr.txt")
+	if err != nil {
+		t.Skipf("Skipping GDPR test: %v", err)
+	}
+	defer file.Close()
+
+	doc, err := extract.NewParser().Parse(file)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	baseURI := "https://regula.dev/regulations/"
+	ts := store.NewTripleStore()
+	builder := store.NewGraphBuilder(ts, baseURI)
+	semExtractor := extract.NewSemanticExtractor()
+	resolver := extract.NewReferenceResolver(baseURI, "GDPR")
+	resolver.IndexDocument(doc)
+	if _, err := builder.BuildComplete(doc, extract.NewDefinitionExtractor(), extract.NewReferenceExtractor(), resolver, semExtractor); err != nil {
+		t.Fatalf("Failed to build graph: %v", err)
+	}
+	matcher := NewProvisionMatcher(ts, baseURI, semExtractor.ExtractFromDocument(doc), doc)
+
+	scenarios, err := LoadScenarioSuite([]string{"../../testdata/scenarios"})
+	if err != nil {
+		t.Fatalf("LoadScenarioSuite failed: %v", err)
+	}
+	if len(scenarios) == 0 {
+		t.Fatal("no scenarios in testdata/scenarios")
+	}
+
+	for _, scenario := range scenarios {
+		report := matcher.Match(scenario).Assert(scenario.Expect)
+		for _, result := range report.Results {
+			if !result.Passed {
+				t.Errorf("%s: %s %s failed: %s", scenario.Name, result.Kind, result.Target, result.Detail)
+			}
+		}
+	}
+}
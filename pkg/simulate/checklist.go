@@ -0,0 +1,215 @@
+package simulate
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// maxChecklistTextLength bounds the provision text quoted in a checklist item.
+const maxChecklistTextLength = 200
+
+// ChecklistItem is one obligation to act on, with who must act, by when,
+// and where the duty is stated.
+type ChecklistItem struct {
+	Document       string                 `json:"document"`
+	Citation       string                 `json:"citation"`
+	ArticleNum     int                    `json:"article_num"`
+	ArticleTitle   string                 `json:"article_title,omitempty"`
+	ObligationType extract.ObligationType `json:"obligation_type"`
+	Prohibition    bool                   `json:"prohibition,omitempty"`
+	Actor          string                 `json:"actor,omitempty"`
+	Deadlines      []string               `json:"deadlines,omitempty"`
+	Text           string                 `json:"text,omitempty"`
+}
+
+// Checklist consolidates the obligations a scenario triggers across one or
+// more documents.
+type Checklist struct {
+	Scenario  string           `json:"scenario"`
+	Documents []string         `json:"documents"`
+	Items     []*ChecklistItem `json:"items"`
+}
+
+// NewChecklist creates an empty checklist for a scenario.
+func NewChecklist(scenario *Scenario) *Checklist {
+	return &Checklist{
+		Scenario:  scenario.Name,
+		Documents: make([]string, 0),
+		Items:     make([]*ChecklistItem, 0),
+	}
+}
+
+// Add appends the obligations of a document's matched provisions. Each
+// article contributes one item per obligation type; deadlines are read
+// from the paragraphs that state the obligation. label prefixes citations,
+// as in "GDPR Art. 33".
+func (c *Checklist) Add(documentID, label string, matcher *ProvisionMatcher, result *MatchResult) {
+	c.Documents = append(c.Documents, documentID)
+
+	articles := make(map[int]*extract.Article)
+	if matcher.doc != nil {
+		for _, article := range matcher.doc.AllArticles() {
+			articles[article.Number] = article
+		}
+	}
+
+	items := make(map[string]*ChecklistItem)
+	var ordered []*ChecklistItem
+	for _, match := range result.AllMatches {
+		for _, ann := range match.Obligations {
+			key := fmt.Sprintf("%d|%s", ann.ArticleNum, ann.ObligationType)
+			item, ok := items[key]
+			if !ok {
+				item = &ChecklistItem{
+					Document:       documentID,
+					Citation:       strings.TrimSpace(fmt.Sprintf("%s Art. %d", label, ann.ArticleNum)),
+					ArticleNum:     ann.ArticleNum,
+					ArticleTitle:   match.Title,
+					ObligationType: ann.ObligationType,
+				}
+				if article := articles[ann.ArticleNum]; article != nil && article.Title != "" {
+					item.ArticleTitle = article.Title
+				}
+				items[key] = item
+				ordered = append(ordered, item)
+			}
+			if ann.Type == extract.SemanticProhibition {
+				item.Prohibition = true
+			}
+			if item.Actor == "" && ann.DutyBearer != extract.EntityUnspecified {
+				item.Actor = string(ann.DutyBearer)
+			}
+			text := annotationText(articles[ann.ArticleNum], ann.ParagraphNum)
+			if item.Text == "" {
+				quoted := ann.MatchedText
+				if ann.ParagraphNum > 0 && text != "" {
+					quoted = text
+				}
+				item.Text = textutil.Truncate(strings.Join(strings.Fields(quoted), " "), maxChecklistTextLength)
+			}
+			for _, deadline := range extract.FindDeadlines(text) {
+				item.Deadlines = appendUnique(item.Deadlines, deadline)
+			}
+		}
+	}
+
+	for _, item := range ordered {
+		sort.Strings(item.Deadlines)
+	}
+	c.Items = append(c.Items, ordered...)
+	sort.SliceStable(c.Items, func(i, j int) bool {
+		a, b := c.Items[i], c.Items[j]
+		if a.ObligationType != b.ObligationType {
+			return a.ObligationType < b.ObligationType
+		}
+		if a.Document != b.Document {
+			return a.Document < b.Document
+		}
+		return a.ArticleNum < b.ArticleNum
+	})
+}
+
+// annotationText returns the text of the paragraph an annotation was found
+// in, with its points, or the whole article when the paragraph is unknown.
+func annotationText(article *extract.Article, paragraphNum int) string {
+	if article == nil {
+		return ""
+	}
+	var parts []string
+	for _, para := range article.Paragraphs {
+		if paragraphNum > 0 && para.Number != paragraphNum {
+			continue
+		}
+		parts = append(parts, para.Text)
+		for _, point := range para.Points {
+			parts = append(parts, point.Text)
+		}
+	}
+	if len(parts) == 0 {
+		parts = append(parts, article.Text)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// ToMarkdown renders the checklist as Markdown task lists, grouped by
+// obligation type so duties shared across documents sit together.
+func (c *Checklist) ToMarkdown() string {
+	var sb strings.Builder
+
+	sb.WriteString("# Compliance Checklist: " + c.Scenario + "\n\n")
+	sb.WriteString(fmt.Sprintf("Documents: %s  \n", strings.Join(c.Documents, ", ")))
+	sb.WriteString(fmt.Sprintf("Items: %d\n", len(c.Items)))
+	if len(c.Items) == 0 {
+		sb.WriteString("\nNo obligations matched this scenario.\n")
+		return sb.String()
+	}
+
+	var group extract.ObligationType
+	for i, item := range c.Items {
+		if i == 0 || item.ObligationType != group {
+			group = item.ObligationType
+			sb.WriteString("\n## " + textutil.Humanize(string(group)) + "\n\n")
+		}
+		sb.WriteString(fmt.Sprintf("- [ ] **%s**", item.Citation))
+		if item.ArticleTitle != "" {
+			sb.WriteString(" " + item.ArticleTitle)
+		}
+		sb.WriteString("\n")
+		actor := "unspecified"
+		if item.Actor != "" {
+			actor = textutil.Humanize(item.Actor)
+		}
+		sb.WriteString(fmt.Sprintf("  - Responsible: %s\n", actor))
+		if len(item.Deadlines) > 0 {
+			sb.WriteString(fmt.Sprintf("  - Deadline: %s\n", strings.Join(item.Deadlines, "; ")))
+		}
+		if item.Prohibition {
+			sb.WriteString("  - Prohibition: must not be done\n")
+		}
+		if item.Text != "" {
+			sb.WriteString(fmt.Sprintf("  - Provision: “%s”\n", item.Text))
+		}
+	}
+
+	return sb.String()
+}
+
+// ToCSV renders the checklist with one row per item.
+func (c *Checklist) ToCSV() (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	header := []string{"done", "obligation", "actor", "deadline", "citation", "document", "article_title", "prohibition", "text"}
+	if err := writer.Write(header); err != nil {
+		return "", err
+	}
+	for _, item := range c.Items {
+		row := []string{
+			"",
+			string(item.ObligationType),
+			item.Actor,
+			strings.Join(item.Deadlines, "; "),
+			item.Citation,
+			item.Document,
+			item.ArticleTitle,
+			fmt.Sprintf("%t", item.Prohibition),
+			item.Text,
+		}
+		if err := writer.Write(row); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	return buf.String(), writer.Error()
+}
+
+// ToJSON serializes the checklist to JSON.
+func (c *Checklist) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
@@ -0,0 +1,95 @@
+package simulate
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+func checklistTestMatcher(articleNum int, title, text string, annotations ...*extract.SemanticAnnotation) *ProvisionMatcher {
+	doc := &extract.Document{Chapters: []*extract.Chapter{{
+		Articles: []*extract.Article{{
+			Number:     articleNum,
+			Title:      title,
+			Paragraphs: []*extract.Paragraph{{Number: 1, Text: text}},
+		}},
+	}}}
+	return NewProvisionMatcher(store.NewTripleStore(), "https://regula.dev/regulations/", annotations, doc)
+}
+
+func TestChecklist_Add(t *testing.T) {
+	scenario := DataBreachScenario()
+	gdpr := checklistTestMatcher(33, "Notification of a personal data breach",
+		"The controller shall without undue delay and, where feasible, not later than 72 hours after having become aware of it, notify the breach.",
+		&extract.SemanticAnnotation{Type: extract.SemanticObligation, ArticleNum: 33, ParagraphNum: 1,
+			ObligationType: extract.ObligationNotifyBreach, DutyBearer: extract.EntityController, MatchedText: "notify the breach", Confidence: 0.9},
+		&extract.SemanticAnnotation{Type: extract.SemanticObligation, ArticleNum: 33, ParagraphNum: 1,
+			ObligationType: extract.ObligationNotifyBreach, DutyBearer: extract.EntityUnspecified, MatchedText: "shall notify", Confidence: 0.8})
+	ccpa := checklistTestMatcher(150, "Security breaches",
+		"A business shall implement reasonable security procedures.",
+		&extract.SemanticAnnotation{Type: extract.SemanticObligation, ArticleNum: 150, ParagraphNum: 1,
+			ObligationType: extract.ObligationSecure, DutyBearer: extract.EntityBusiness, MatchedText: "shall implement", Confidence: 0.8})
+
+	checklist := NewChecklist(scenario)
+	checklist.Add("eu-gdpr", "GDPR", gdpr, gdpr.Match(scenario))
+	checklist.Add("us-ca-ccpa", "CCPA", ccpa, ccpa.Match(scenario))
+
+	if len(checklist.Items) != 2 {
+		t.Fatalf("Items = %d, want 2 (one per article and obligation type)", len(checklist.Items))
+	}
+	breach := checklist.Items[0]
+	if breach.Citation != "GDPR Art. 33" || breach.Actor != "Controller" {
+		t.Errorf("breach item = %+v", breach)
+	}
+	if strings.Join(breach.Deadlines, ";") != "not later than 72 hours;without undue delay" {
+		t.Errorf("Deadlines = %v", breach.Deadlines)
+	}
+	if !strings.HasPrefix(breach.Text, "The controller shall without undue delay") {
+		t.Errorf("Text should quote the paragraph, got %q", breach.Text)
+	}
+	if checklist.Items[1].Citation != "CCPA Art. 150" || checklist.Items[1].ArticleTitle != "Security breaches" {
+		t.Errorf("security item = %+v", checklist.Items[1])
+	}
+
+	markdown := checklist.ToMarkdown()
+	for _, want := range []string{
+		"Documents: eu-gdpr, us-ca-ccpa",
+		"## breach notification obligation",
+		"- [ ] **GDPR Art. 33** Notification of a personal data breach",
+		"  - Responsible: controller",
+		"  - Deadline: not later than 72 hours; without undue delay",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown missing %q:\n%s", want, markdown)
+		}
+	}
+
+	csvOutput, err := checklist.ToCSV()
+	if err != nil {
+		t.Fatalf("ToCSV: %v", err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(csvOutput)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(rows) != 3 || rows[0][1] != "obligation" || rows[2][4] != "CCPA Art. 150" {
+		t.Errorf("unexpected CSV rows: %q", rows)
+	}
+}
+
+func TestChecklist_Empty(t *testing.T) {
+	scenario := DataBreachScenario()
+	matcher := checklistTestMatcher(1, "Scope", "This Act applies to data.")
+	checklist := NewChecklist(scenario)
+	checklist.Add("doc", "DOC", matcher, matcher.Match(scenario))
+
+	if len(checklist.Items) != 0 {
+		t.Errorf("Items = %d, want 0", len(checklist.Items))
+	}
+	if !strings.Contains(checklist.ToMarkdown(), "No obligations matched this scenario.") {
+		t.Error("expected an empty-checklist note")
+	}
+}
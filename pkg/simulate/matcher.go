@@ -8,6 +8,7 @@ import (
 
 	"github.com/coolbeans/regula/pkg/extract"
 	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // RelevanceScore indicates how directly a provision applies to a scenario.
@@ -508,10 +509,7 @@ func (r *MatchResult) FormatTable() string {
 	sb.WriteString("+----------+---------+------+--------------------------------------------------+\n")
 
 	for _, match := range r.AllMatches {
-		title := match.Title
-		if len(title) > 48 {
-			title = title[:45] + "..."
-		}
+		title := textutil.Truncate(match.Title, 48)
 		sb.WriteString(fmt.Sprintf("| Art %-4d | %-7s | %.2f | %-48s |\n",
 			match.ArticleNum, match.Relevance, match.Score, title))
 	}
@@ -4,9 +4,12 @@ package simulate
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/coolbeans/regula/pkg/extract"
+	"gopkg.in/yaml.v3"
 )
 
 // ActionType represents the type of action in a scenario.
@@ -30,32 +33,34 @@ const (
 
 // Scenario represents a compliance scenario to evaluate.
 type Scenario struct {
-	ID          string                 `json:"id"`
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	Entities    []ScenarioEntity       `json:"entities"`
-	Actions     []ScenarioAction       `json:"actions"`
-	Context     map[string]interface{} `json:"context,omitempty"`
-	Keywords    []string               `json:"keywords,omitempty"`
+	ID          string                 `json:"id" yaml:"id"`
+	Name        string                 `json:"name" yaml:"name"`
+	Description string                 `json:"description" yaml:"description"`
+	Document    string                 `json:"document,omitempty" yaml:"document,omitempty"` // Library document ID
+	Entities    []ScenarioEntity       `json:"entities" yaml:"entities"`
+	Actions     []ScenarioAction       `json:"actions" yaml:"actions"`
+	Context     map[string]interface{} `json:"context,omitempty" yaml:"context,omitempty"`
+	Keywords    []string               `json:"keywords,omitempty" yaml:"keywords,omitempty"`
+	Expect      *ScenarioExpectations  `json:"expect,omitempty" yaml:"expect,omitempty"`
 }
 
 // ScenarioEntity represents an entity involved in the scenario.
 type ScenarioEntity struct {
-	ID         string           `json:"id"`
-	Type       extract.EntityType `json:"type"`
-	Name       string           `json:"name,omitempty"`
-	Attributes map[string]string `json:"attributes,omitempty"`
+	ID         string             `json:"id" yaml:"id"`
+	Type       extract.EntityType `json:"type" yaml:"type"`
+	Name       string             `json:"name,omitempty" yaml:"name,omitempty"`
+	Attributes map[string]string  `json:"attributes,omitempty" yaml:"attributes,omitempty"`
 }
 
 // ScenarioAction represents an action in the scenario.
 type ScenarioAction struct {
-	ID          string     `json:"id"`
-	Type        ActionType `json:"type"`
-	Actor       string     `json:"actor"`      // Entity ID
-	Target      string     `json:"target,omitempty"` // Entity ID or data type
-	Description string     `json:"description,omitempty"`
-	Triggers    []string   `json:"triggers,omitempty"` // Action IDs triggered by this
-	Keywords    []string   `json:"keywords,omitempty"`
+	ID          string     `json:"id" yaml:"id"`
+	Type        ActionType `json:"type" yaml:"type"`
+	Actor       string     `json:"actor" yaml:"actor"`                       // Entity ID
+	Target      string     `json:"target,omitempty" yaml:"target,omitempty"` // Entity ID or data type
+	Description string     `json:"description,omitempty" yaml:"description,omitempty"`
+	Triggers    []string   `json:"triggers,omitempty" yaml:"triggers,omitempty"` // Action IDs triggered by this
+	Keywords    []string   `json:"keywords,omitempty" yaml:"keywords,omitempty"`
 }
 
 // NewScenario creates a new scenario with the given name.
@@ -157,6 +162,103 @@ func ScenarioFromJSON(data []byte) (*Scenario, error) {
 	return &scenario, nil
 }
 
+// ScenarioFromYAML parses a scenario from YAML.
+func ScenarioFromYAML(data []byte) (*Scenario, error) {
+	var scenario Scenario
+	if err := yaml.Unmarshal(data, &scenario); err != nil {
+		return nil, err
+	}
+	return &scenario, nil
+}
+
+// LoadScenarioFile reads a scenario definition from a YAML or JSON file
+// (chosen by extension) and fills in the IDs and action keywords that
+// hand-written scenarios usually leave out.
+func LoadScenarioFile(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+
+	var scenario *Scenario
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		scenario, err = ScenarioFromJSON(data)
+	} else {
+		scenario, err = ScenarioFromYAML(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse scenario %s: %w", path, err)
+	}
+	if scenario.Name == "" {
+		return nil, fmt.Errorf("scenario %s has no name", path)
+	}
+
+	scenario.normalize()
+	return scenario, nil
+}
+
+// LoadScenarioSuite loads every scenario named by paths. A directory
+// contributes each .yaml, .yml, and .json file it contains, in name order,
+// so a folder of scenarios can be run as one compliance test suite.
+func LoadScenarioSuite(paths []string) ([]*Scenario, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read scenario path: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read scenario directory: %w", err)
+		}
+		for _, entry := range entries {
+			switch strings.ToLower(filepath.Ext(entry.Name())) {
+			case ".yaml", ".yml", ".json":
+				if !entry.IsDir() {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+	}
+
+	scenarios := make([]*Scenario, 0, len(files))
+	for _, file := range files {
+		scenario, err := LoadScenarioFile(file)
+		if err != nil {
+			return nil, err
+		}
+		scenarios = append(scenarios, scenario)
+	}
+	return scenarios, nil
+}
+
+// normalize fills in defaults for a scenario read from a file.
+func (s *Scenario) normalize() {
+	if s.ID == "" {
+		s.ID = generateID(s.Name)
+	}
+	for i := range s.Keywords {
+		s.Keywords[i] = strings.ToLower(s.Keywords[i])
+	}
+	for i := range s.Entities {
+		if s.Entities[i].ID == "" {
+			s.Entities[i].ID = generateID(s.Entities[i].Name)
+		}
+	}
+	for i := range s.Actions {
+		action := &s.Actions[i]
+		if action.ID == "" {
+			action.ID = fmt.Sprintf("action_%d", i+1)
+		}
+		action.Keywords = uniqueStrings(append(extractKeywords(action.Type, action.Description), action.Keywords...))
+	}
+}
+
 // generateID creates a simple ID from a name.
 func generateID(name string) string {
 	id := strings.ToLower(name)
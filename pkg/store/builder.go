@@ -1,6 +1,7 @@
 package store
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -9,9 +10,11 @@ import (
 
 // GraphBuilder converts extracted regulatory documents into RDF triples.
 type GraphBuilder struct {
-	store   *TripleStore
-	baseURI string
-	regID   string
+	store        *TripleStore
+	baseURI      string
+	regID        string
+	contributors []BuildContributor
+	llmExtractor *extract.LLMExtractor
 }
 
 // BuildStats contains statistics about the graph building process.
@@ -34,6 +37,17 @@ type BuildStats struct {
 	Rights            int `json:"rights"`
 	Obligations       int `json:"obligations"`
 	TermUsages        int `json:"term_usages"`
+	Abbreviations     int `json:"abbreviations,omitempty"`
+	Annexes           int `json:"annexes,omitempty"`
+	AnnexTriples      int `json:"annex_triples,omitempty"`
+	Notes             int `json:"notes,omitempty"`
+	NoteTriples       int `json:"note_triples,omitempty"`
+
+	// ContributedTriples counts the triples added by each BuildContributor.
+	ContributedTriples map[string]int `json:"contributed_triples,omitempty"`
+
+	// LLM is the outcome of the LLM extraction fallback, when one is set.
+	LLM *extract.LLMResult `json:"llm,omitempty"`
 }
 
 // NewGraphBuilder creates a new GraphBuilder with the given store and base URI.
@@ -47,6 +61,13 @@ func NewGraphBuilder(store *TripleStore, baseURI string) *GraphBuilder {
 	}
 }
 
+// SetLLMExtractor enables the LLM extraction fallback in BuildComplete. The
+// definitions, rights, and obligations it adds are marked with
+// reg:extractionMethod "llm" and scored with the model's confidence.
+func (b *GraphBuilder) SetLLMExtractor(llmExtractor *extract.LLMExtractor) {
+	b.llmExtractor = llmExtractor
+}
+
 // SetRegulationID overrides the auto-derived regulation ID with a specific value.
 // This is useful for documents whose identifier does not follow EU regulation
 // patterns (e.g., US House Rules, US Code titles).
@@ -78,6 +99,10 @@ func (b *GraphBuilder) Build(doc *extract.Document) (*BuildStats, error) {
 		b.buildChapter(chapter, stats)
 	}
 
+	for _, annex := range doc.Annexes {
+		b.buildAnnex(annex, stats)
+	}
+
 	// Build definitions from extracted definitions
 	for _, def := range doc.Definitions {
 		b.buildDefinition(def, stats)
@@ -113,6 +138,10 @@ func (b *GraphBuilder) BuildWithExtractors(
 		b.buildChapter(chapter, stats)
 	}
 
+	for _, annex := range doc.Annexes {
+		b.buildAnnex(annex, stats)
+	}
+
 	// Build rich definitions if extractor provided
 	if defExtractor != nil {
 		definitions := defExtractor.ExtractDefinitions(doc)
@@ -172,6 +201,14 @@ func (b *GraphBuilder) sectionURIStr(chapterNum string, sectionID string) string
 	return b.baseURI + b.regID + ":Chapter" + chapterNum + ":Section" + sectionID
 }
 
+// sectionNodeURI returns the URI of a section, preferring its alphanumeric ID.
+func (b *GraphBuilder) sectionNodeURI(chapterNum string, section *extract.Section) string {
+	if section.SectionID != "" {
+		return b.sectionURIStr(chapterNum, section.SectionID)
+	}
+	return b.sectionURI(chapterNum, section.Number)
+}
+
 func (b *GraphBuilder) articleURI(number int) string {
 	return b.baseURI + b.regID + ":Art" + itoa(number)
 }
@@ -188,6 +225,10 @@ func (b *GraphBuilder) pointURI(articleNum, paraNum int, letter string) string {
 	return b.baseURI + b.regID + ":Art" + itoa(articleNum) + "(" + itoa(paraNum) + ")(" + letter + ")"
 }
 
+func (b *GraphBuilder) annexURI(number string) string {
+	return b.baseURI + b.regID + ":Annex" + number
+}
+
 func (b *GraphBuilder) recitalURI(number int) string {
 	return b.baseURI + b.regID + ":Recital" + itoa(number)
 }
@@ -201,6 +242,10 @@ func (b *GraphBuilder) definitionURI(normalizedTerm string) string {
 	return b.baseURI + b.regID + ":Term:" + safeTerm
 }
 
+func (b *GraphBuilder) abbreviationURI(shortForm string) string {
+	return b.baseURI + b.regID + ":Abbr:" + b.normalizeTerm(shortForm)
+}
+
 func (b *GraphBuilder) referenceURI(sourceArticle int, offset int) string {
 	return b.baseURI + b.regID + ":Ref:Art" + itoa(sourceArticle) + ":" + itoa(offset)
 }
@@ -315,15 +360,48 @@ func (b *GraphBuilder) buildChapter(chapter *extract.Chapter, stats *BuildStats)
 	}
 }
 
+func (b *GraphBuilder) buildAnnex(annex *extract.Annex, stats *BuildStats) {
+	uri := b.annexURI(annex.Number)
+	regURI := b.regulationURI()
+
+	b.store.Add(uri, RDFType, ClassAnnex)
+	b.store.Add(uri, PropNumber, annex.Number)
+	b.store.Add(uri, PropAnnexKind, string(annex.Kind))
+	if annex.Title != "" {
+		b.store.Add(uri, PropTitle, annex.Title)
+		stats.AnnexTriples++
+	}
+	if annex.Text != "" {
+		b.store.Add(uri, PropText, annex.Text)
+		stats.AnnexTriples++
+	}
+
+	// Hierarchy
+	b.store.Add(uri, PropPartOf, regURI)
+	b.store.Add(uri, PropBelongsTo, regURI)
+	b.store.Add(regURI, PropHasAnnex, uri)
+	b.store.Add(regURI, PropContains, uri)
+
+	stats.Annexes++
+	stats.AnnexTriples += 7 // type, number, annexKind, partOf, belongsTo, hasAnnex, contains
+
+	// Table rows keep their cells in column order.
+	for i, row := range annex.Rows {
+		rowURI := uri + ":Row" + itoa(i+1)
+		b.store.Add(rowURI, RDFType, ClassAnnexRow)
+		b.store.Add(rowURI, PropNumber, itoa(i+1))
+		b.store.Add(rowURI, PropCells, strings.Join(row, " | "))
+		b.store.Add(rowURI, PropPartOf, uri)
+		b.store.Add(uri, PropHasRow, rowURI)
+		stats.AnnexTriples += 5
+	}
+}
+
 func (b *GraphBuilder) buildSection(section *extract.Section, chapterNum string, chapterURI string, stats *BuildStats) {
-	var uri string
-	var numberValue string
+	uri := b.sectionNodeURI(chapterNum, section)
+	numberValue := itoa(section.Number)
 	if section.SectionID != "" {
-		uri = b.sectionURIStr(chapterNum, section.SectionID)
 		numberValue = section.SectionID
-	} else {
-		uri = b.sectionURI(chapterNum, section.Number)
-		numberValue = itoa(section.Number)
 	}
 	regURI := b.regulationURI()
 
@@ -391,6 +469,33 @@ func (b *GraphBuilder) buildArticle(article *extract.Article, parentURI string,
 	for _, para := range article.Paragraphs {
 		b.buildParagraph(para, article.Number, uri, stats)
 	}
+
+	for i, note := range article.Notes {
+		b.buildNote(note, uri+":Note:"+itoa(i+1), uri, stats)
+	}
+}
+
+func (b *GraphBuilder) buildNote(note *extract.EditorialNote, uri string, provisionURI string, stats *BuildStats) {
+	b.store.Add(uri, RDFType, ClassEditorialNote)
+	b.store.Add(uri, PropNoteKind, string(note.Kind))
+	b.store.Add(uri, PropText, note.Text)
+	b.store.Add(uri, PropPartOf, provisionURI)
+	b.store.Add(provisionURI, PropHasNote, uri)
+	stats.NoteTriples += 5 // type, noteKind, text, partOf, hasNote
+
+	if note.Heading != "" {
+		b.store.Add(uri, PropTitle, note.Heading)
+		stats.NoteTriples++
+	}
+	if note.Number != "" {
+		b.store.Add(uri, PropNumber, note.Number)
+		stats.NoteTriples++
+	}
+	if note.Year != 0 {
+		b.store.Add(uri, PropAmendmentYear, itoa(note.Year))
+		stats.NoteTriples++
+	}
+	stats.Notes++
 }
 
 func (b *GraphBuilder) buildParagraph(para *extract.Paragraph, articleNum int, articleURI string, stats *BuildStats) {
@@ -430,6 +535,28 @@ func (b *GraphBuilder) buildPoint(point *extract.Point, articleNum, paraNum int,
 	b.store.Add(uri, PropBelongsTo, regURI)
 	b.store.Add(paraURI, PropHasPoint, uri)
 	b.store.Add(paraURI, PropContains, uri)
+
+	// Build sub-points
+	for _, subPoint := range point.SubPoints {
+		b.buildSubPoint(subPoint, uri)
+	}
+}
+
+func (b *GraphBuilder) buildSubPoint(subPoint *extract.SubPoint, pointURI string) {
+	uri := pointURI + "(" + subPoint.Numeral + ")"
+	regURI := b.regulationURI()
+
+	b.store.Add(uri, RDFType, ClassSubPoint)
+	b.store.Add(uri, PropNumber, subPoint.Numeral)
+	if subPoint.Text != "" {
+		b.store.Add(uri, PropText, subPoint.Text)
+	}
+
+	// Hierarchy
+	b.store.Add(uri, PropPartOf, pointURI)
+	b.store.Add(uri, PropBelongsTo, regURI)
+	b.store.Add(pointURI, PropHasSubPoint, uri)
+	b.store.Add(pointURI, PropContains, uri)
 }
 
 func (b *GraphBuilder) buildDefinition(def *extract.Definition, stats *BuildStats) {
@@ -472,6 +599,10 @@ func (b *GraphBuilder) buildDefinedTerm(def *extract.DefinedTerm, stats *BuildSt
 	if def.Scope != "" {
 		b.store.Add(uri, PropScope, def.Scope)
 	}
+	if def.ExtractionMethod != "" {
+		b.store.Add(uri, PropExtractionMethod, def.ExtractionMethod)
+		b.store.Add(uri, PropConfidence, fmt.Sprintf("%.2f", def.Confidence))
+	}
 
 	// Links
 	b.store.Add(uri, PropDefinedIn, articleURI)
@@ -497,6 +628,10 @@ func (b *GraphBuilder) buildDefinedTerm(def *extract.DefinedTerm, stats *BuildSt
 		stats.DefinitionTriples++
 	}
 	stats.DefinitionTriples += len(def.SubPoints) * 5
+	if def.ExtractionMethod != "" {
+		b.scoreTriples(def.Confidence, append(b.store.Find(uri, "", ""), NewTriple(articleURI, PropDefines, uri))...)
+		stats.DefinitionTriples += 2
+	}
 }
 
 func (b *GraphBuilder) buildReference(ref *extract.Reference, stats *BuildStats) {
@@ -545,7 +680,7 @@ func (b *GraphBuilder) buildReference(ref *extract.Reference, stats *BuildStats)
 		// External reference - store as literal
 		b.store.Add(uri, PropExternalRef, ref.Identifier)
 		if ref.ExternalDoc != "" {
-			b.store.Add(uri, "reg:externalDocType", ref.ExternalDoc)
+			b.store.Add(uri, PropExternalDocType, ref.ExternalDoc)
 		}
 	}
 
@@ -603,6 +738,10 @@ func (b *GraphBuilder) BuildWithResolver(
 		b.buildChapter(chapter, stats)
 	}
 
+	for _, annex := range doc.Annexes {
+		b.buildAnnex(annex, stats)
+	}
+
 	// Build rich definitions if extractor provided
 	if defExtractor != nil {
 		definitions := defExtractor.ExtractDefinitions(doc)
@@ -671,6 +810,7 @@ func (b *GraphBuilder) buildResolvedReference(res *extract.ResolvedReference, st
 		if res.Status == extract.ResolutionResolved || res.Status == extract.ResolutionPartial {
 			b.store.Add(sourceURI, PropReferences, res.TargetURI)
 			b.store.Add(res.TargetURI, PropReferencedBy, sourceURI)
+			b.addArticleReference(sourceURI, res.TargetURI)
 		}
 	}
 
@@ -679,6 +819,7 @@ func (b *GraphBuilder) buildResolvedReference(res *extract.ResolvedReference, st
 		b.store.Add(uri, PropResolvedTarget, targetURI)
 		b.store.Add(sourceURI, PropReferences, targetURI)
 		b.store.Add(targetURI, PropReferencedBy, sourceURI)
+		b.addArticleReference(sourceURI, targetURI)
 	}
 
 	// Record alternative targets for ambiguous refs
@@ -690,7 +831,7 @@ func (b *GraphBuilder) buildResolvedReference(res *extract.ResolvedReference, st
 	if res.Status == extract.ResolutionExternal {
 		b.store.Add(uri, PropExternalRef, ref.Identifier)
 		if ref.ExternalDoc != "" {
-			b.store.Add(uri, "reg:externalDocType", ref.ExternalDoc)
+			b.store.Add(uri, PropExternalDocType, ref.ExternalDoc)
 		}
 	}
 
@@ -716,10 +857,60 @@ func (b *GraphBuilder) buildResolvedReference(res *extract.ResolvedReference, st
 		}
 	}
 
+	// Triples that rest on the resolution carry its confidence
+	confidence := float64(res.Confidence)
+	for _, targetURI := range append([]string{res.TargetURI}, res.TargetURIs...) {
+		if targetURI == "" {
+			continue
+		}
+		b.scoreTriples(confidence,
+			NewTriple(uri, PropResolvedTarget, targetURI),
+			NewTriple(sourceURI, PropReferences, targetURI),
+			NewTriple(targetURI, PropReferencedBy, sourceURI))
+	}
+	for _, altURI := range res.AlternativeURIs {
+		b.scoreTriples(confidence, NewTriple(uri, PropAlternativeTarget, altURI))
+	}
+
 	stats.References++
 	stats.ReferenceTriples += 10 // base triples plus resolution metadata
 }
 
+// addArticleReference links sourceURI to the article containing a
+// paragraph, point, or sub-point target, so that article-level queries still
+// see references that resolve below the article.
+func (b *GraphBuilder) addArticleReference(sourceURI, targetURI string) {
+	articleURI := containingArticleURI(targetURI)
+	if articleURI == "" {
+		return
+	}
+	b.store.Add(sourceURI, PropReferences, articleURI)
+	b.store.Add(articleURI, PropReferencedBy, sourceURI)
+}
+
+// containingArticleURI returns the article URI of a paragraph, point, or
+// sub-point URI such as "...GDPR:Art6(1)(a)", or "" for any other URI.
+func containingArticleURI(uri string) string {
+	open := strings.Index(uri, "(")
+	if open < 0 {
+		return ""
+	}
+	colon := strings.LastIndex(uri[:open], ":")
+	if colon < 0 || !strings.HasPrefix(uri[colon:open], ":Art") {
+		return ""
+	}
+	return uri[:open]
+}
+
+// scoreTriples records score as the quality of each triple. A triple
+// already scored keeps the higher score, since any one confident
+// extraction supports it.
+func (b *GraphBuilder) scoreTriples(score float64, triples ...Triple) {
+	for _, triple := range triples {
+		b.store.raiseQuality(triple, score)
+	}
+}
+
 // buildSemanticAnnotation builds triples for a semantic annotation (right or obligation).
 func (b *GraphBuilder) buildSemanticAnnotation(ann *extract.SemanticAnnotation, stats *BuildStats) {
 	articleURI := b.articleURI(ann.ArticleNum)
@@ -731,9 +922,9 @@ func (b *GraphBuilder) buildSemanticAnnotation(ann *extract.SemanticAnnotation,
 		rightURI := fmt.Sprintf("%s%s:Right:%d:%s", b.baseURI, b.regID, ann.ArticleNum, ann.RightType)
 
 		b.store.Add(rightURI, RDFType, ClassRight)
-		b.store.Add(rightURI, "reg:rightType", string(ann.RightType))
+		b.store.Add(rightURI, PropRightType, string(ann.RightType))
 		b.store.Add(rightURI, PropText, ann.MatchedText)
-		b.store.Add(rightURI, "reg:confidence", fmt.Sprintf("%.2f", ann.Confidence))
+		b.store.Add(rightURI, PropConfidence, fmt.Sprintf("%.2f", ann.Confidence))
 		b.store.Add(rightURI, PropPartOf, articleURI)
 		b.store.Add(rightURI, PropBelongsTo, regURI)
 
@@ -742,14 +933,20 @@ func (b *GraphBuilder) buildSemanticAnnotation(ann *extract.SemanticAnnotation,
 
 		// Beneficiary
 		if ann.Beneficiary != extract.EntityUnspecified {
-			b.store.Add(rightURI, "reg:beneficiary", string(ann.Beneficiary))
+			b.store.Add(rightURI, PropBeneficiary, string(ann.Beneficiary))
 		}
 
 		// Context
 		if ann.Context != "" {
-			b.store.Add(rightURI, "reg:context", ann.Context)
+			b.store.Add(rightURI, PropContext, ann.Context)
+		}
+
+		if ann.ExtractionMethod != "" {
+			b.store.Add(rightURI, PropExtractionMethod, ann.ExtractionMethod)
 		}
 
+		b.scoreTriples(ann.Confidence, append(b.store.Find(rightURI, "", ""), NewTriple(articleURI, PropGrantsRight, rightURI))...)
+
 		stats.Rights++
 		stats.SemanticTriples += 8
 
@@ -758,9 +955,9 @@ func (b *GraphBuilder) buildSemanticAnnotation(ann *extract.SemanticAnnotation,
 		obligURI := fmt.Sprintf("%s%s:Obligation:%d:%s", b.baseURI, b.regID, ann.ArticleNum, ann.ObligationType)
 
 		b.store.Add(obligURI, RDFType, ClassObligation)
-		b.store.Add(obligURI, "reg:obligationType", string(ann.ObligationType))
+		b.store.Add(obligURI, PropObligationType, string(ann.ObligationType))
 		b.store.Add(obligURI, PropText, ann.MatchedText)
-		b.store.Add(obligURI, "reg:confidence", fmt.Sprintf("%.2f", ann.Confidence))
+		b.store.Add(obligURI, PropConfidence, fmt.Sprintf("%.2f", ann.Confidence))
 		b.store.Add(obligURI, PropPartOf, articleURI)
 		b.store.Add(obligURI, PropBelongsTo, regURI)
 
@@ -769,19 +966,31 @@ func (b *GraphBuilder) buildSemanticAnnotation(ann *extract.SemanticAnnotation,
 
 		// Duty bearer
 		if ann.DutyBearer != extract.EntityUnspecified {
-			b.store.Add(obligURI, "reg:dutyBearer", string(ann.DutyBearer))
+			b.store.Add(obligURI, PropDutyBearer, string(ann.DutyBearer))
 		}
 
 		// Mark if prohibition
 		if ann.Type == extract.SemanticProhibition {
-			b.store.Add(obligURI, "reg:isProhibition", "true")
+			b.store.Add(obligURI, PropIsProhibition, "true")
+		}
+
+		// Recurrence (the first paragraph to state one wins)
+		if ann.Recurrence != nil && len(b.store.Find(obligURI, PropRecurrence, "")) == 0 {
+			b.store.Add(obligURI, PropRecurrence, ann.Recurrence.Rule())
+			b.store.Add(obligURI, PropRecurrenceSource, ann.Recurrence.Source)
 		}
 
 		// Context
 		if ann.Context != "" {
-			b.store.Add(obligURI, "reg:context", ann.Context)
+			b.store.Add(obligURI, PropContext, ann.Context)
+		}
+
+		if ann.ExtractionMethod != "" {
+			b.store.Add(obligURI, PropExtractionMethod, ann.ExtractionMethod)
 		}
 
+		b.scoreTriples(ann.Confidence, append(b.store.Find(obligURI, "", ""), NewTriple(articleURI, PropImposesObligation, obligURI))...)
+
 		stats.Obligations++
 		stats.SemanticTriples += 9
 	}
@@ -814,6 +1023,10 @@ func (b *GraphBuilder) BuildWithSemantics(
 		b.buildChapter(chapter, stats)
 	}
 
+	for _, annex := range doc.Annexes {
+		b.buildAnnex(annex, stats)
+	}
+
 	// Build definitions
 	if defExtractor != nil {
 		definitions := defExtractor.ExtractDefinitions(doc)
@@ -856,22 +1069,77 @@ func (b *GraphBuilder) buildTermUsage(usage *extract.TermUsage, stats *BuildStat
 
 	// Also add a more specific usage node for detailed tracking
 	usageURI := fmt.Sprintf("%s%s:TermUsage:%d:%s", b.baseURI, b.regID, usage.ArticleNum, b.normalizeTerm(usage.NormalizedTerm))
-	b.store.Add(usageURI, RDFType, "reg:TermUsage")
-	b.store.Add(usageURI, "reg:usesTermRef", termURI)
-	b.store.Add(usageURI, "reg:inArticle", articleURI)
-	b.store.Add(usageURI, "reg:matchCount", itoa(usage.Count))
+	b.store.Add(usageURI, RDFType, ClassTermUsage)
+	b.store.Add(usageURI, PropUsesTermRef, termURI)
+	b.store.Add(usageURI, PropInArticle, articleURI)
+	b.store.Add(usageURI, PropMatchCount, itoa(usage.Count))
 	b.store.Add(usageURI, PropPartOf, articleURI)
 
 	stats.TermUsageTriples += 6
 }
 
-// BuildComplete builds the complete relationship graph with all extractors.
+// buildAbbreviation builds an abbreviation node in the provision that
+// introduces it, linked to the defined term it stands for, if any.
+func (b *GraphBuilder) buildAbbreviation(abbreviation *extract.Abbreviation, defined map[string]bool, stats *BuildStats) {
+	uri := b.abbreviationURI(abbreviation.ShortForm)
+	b.store.Add(uri, RDFType, ClassAbbreviation)
+	b.store.Add(uri, PropShortForm, abbreviation.ShortForm)
+	b.store.Add(uri, PropExpansion, abbreviation.Expansion)
+	b.store.Add(uri, PropBelongsTo, b.regulationURI())
+	stats.DefinitionTriples += 4
+
+	switch {
+	case abbreviation.ArticleNum > 0:
+		b.store.Add(uri, PropDefinedIn, b.articleURI(abbreviation.ArticleNum))
+		stats.DefinitionTriples++
+	case abbreviation.RecitalNum > 0:
+		b.store.Add(uri, PropDefinedIn, b.recitalURI(abbreviation.RecitalNum))
+		stats.DefinitionTriples++
+	}
+
+	if expansion := strings.Join(strings.Fields(strings.ToLower(abbreviation.Expansion)), " "); defined[expansion] {
+		b.store.Add(uri, PropAbbreviates, b.definitionURI(expansion))
+		stats.DefinitionTriples++
+	}
+	stats.Abbreviations++
+}
+
+// buildDefinitionDependency links a defined term to the terms its
+// definition uses, and records the quoted terms it uses undefined.
+func (b *GraphBuilder) buildDefinitionDependency(dependency *extract.DefinitionDependency, stats *BuildStats) {
+	termURI := b.definitionURI(dependency.NormalizedTerm)
+	for _, used := range dependency.Uses {
+		b.store.Add(termURI, PropDefinitionUses, b.definitionURI(used))
+	}
+	for _, undefined := range dependency.Undefined {
+		b.store.Add(termURI, PropUsesUndefinedTerm, undefined)
+	}
+
+	stats.DefinitionTriples += len(dependency.Uses) + len(dependency.Undefined)
+}
+
+// BuildComplete builds the complete relationship graph with all extractors,
+// then runs any contributors added with AddContributor.
 func (b *GraphBuilder) BuildComplete(
 	doc *extract.Document,
 	defExtractor *extract.DefinitionExtractor,
 	refExtractor *extract.ReferenceExtractor,
 	resolver *extract.ReferenceResolver,
 	semExtractor *extract.SemanticExtractor,
+) (*BuildStats, error) {
+	return b.BuildCompleteWithContext(context.Background(), doc, defExtractor, refExtractor, resolver, semExtractor)
+}
+
+// BuildCompleteWithContext is BuildComplete with a context for the LLM
+// extractor's requests; once ctx is done, they are abandoned and the graph
+// holds only what the patterns found.
+func (b *GraphBuilder) BuildCompleteWithContext(
+	ctx context.Context,
+	doc *extract.Document,
+	defExtractor *extract.DefinitionExtractor,
+	refExtractor *extract.ReferenceExtractor,
+	resolver *extract.ReferenceResolver,
+	semExtractor *extract.SemanticExtractor,
 ) (*BuildStats, error) {
 	if doc == nil {
 		return nil, fmt.Errorf("document is nil")
@@ -893,6 +1161,10 @@ func (b *GraphBuilder) BuildComplete(
 		b.buildChapter(chapter, stats)
 	}
 
+	for _, annex := range doc.Annexes {
+		b.buildAnnex(annex, stats)
+	}
+
 	// Build definitions
 	var definitions []*extract.DefinedTerm
 	if defExtractor != nil {
@@ -907,6 +1179,7 @@ func (b *GraphBuilder) BuildComplete(
 	}
 
 	// Build references with resolution if resolver provided
+	var resolved []*extract.ResolvedReference
 	if refExtractor != nil {
 		refs := refExtractor.ExtractFromDocument(doc)
 
@@ -915,7 +1188,7 @@ func (b *GraphBuilder) BuildComplete(
 			resolver.IndexDocument(doc)
 
 			// Resolve and build each reference
-			resolved := resolver.ResolveAll(refs)
+			resolved = resolver.ResolveAll(refs)
 			for _, res := range resolved {
 				b.buildResolvedReference(res, stats)
 			}
@@ -928,21 +1201,162 @@ func (b *GraphBuilder) BuildComplete(
 	}
 
 	// Build semantic annotations
+	var annotations []*extract.SemanticAnnotation
 	if semExtractor != nil {
-		annotations := semExtractor.ExtractFromDocument(doc)
+		annotations = semExtractor.ExtractFromDocument(doc)
 		for _, ann := range annotations {
 			b.buildSemanticAnnotation(ann, stats)
 		}
 	}
 
+	// Let the LLM fallback fill in what the patterns missed
+	if b.llmExtractor != nil {
+		llmResult := b.llmExtractor.Extract(ctx, doc, definitions, annotations)
+		for _, def := range llmResult.Definitions {
+			b.buildDefinedTerm(def, stats)
+		}
+		for _, ann := range llmResult.Annotations {
+			b.buildSemanticAnnotation(ann, stats)
+		}
+		definitions = append(definitions, llmResult.Definitions...)
+		annotations = append(annotations, llmResult.Annotations...)
+		stats.LLM = llmResult
+	}
+
+	// Build abbreviations, linked to the terms they stand for
+	abbreviations := extract.NewAbbreviationExtractor().ExtractFromDocument(doc)
+	defined := make(map[string]bool, len(definitions))
+	for _, def := range definitions {
+		defined[def.NormalizedTerm] = true
+	}
+	for _, abbreviation := range abbreviations {
+		b.buildAbbreviation(abbreviation, defined, stats)
+	}
+
 	// Build term usage edges
 	if len(definitions) > 0 {
 		usageExtractor := extract.NewTermUsageExtractor(definitions)
+		usageExtractor.SetAbbreviations(abbreviations)
 		usages := usageExtractor.ExtractFromDocument(doc)
 		for _, usage := range usages {
 			b.buildTermUsage(usage, stats)
 		}
 		stats.TermUsages = len(usages)
+
+		for _, dependency := range extract.ExtractDefinitionDependencies(definitions) {
+			b.buildDefinitionDependency(dependency, stats)
+		}
+	}
+
+	// Let contributors add their own vocabularies
+	build := &BuildContext{
+		Document:    doc,
+		Articles:    doc.AllArticles(),
+		Definitions: definitions,
+		References:  resolved,
+		Annotations: annotations,
+	}
+	if err := b.runContributors(build, stats); err != nil {
+		return nil, err
+	}
+
+	stats.TotalTriples = b.store.Count()
+	return stats, nil
+}
+
+// BuildArticles builds only the triples produced by the given articles of
+// doc: their structure, resolved references, rights and obligations, and
+// term usages. Document-level triples (regulation, preamble, chapters,
+// sections, definitions) are not built. Definitions and the resolver index
+// come from the whole document, so each article yields the same triples as
+// in BuildComplete; this lets an edited document be rebuilt article by
+// article. Contributors run with BuildContext.Partial set.
+func (b *GraphBuilder) BuildArticles(
+	doc *extract.Document,
+	articles []*extract.Article,
+	definitions []*extract.DefinedTerm,
+	refExtractor *extract.ReferenceExtractor,
+	resolver *extract.ReferenceResolver,
+	semExtractor *extract.SemanticExtractor,
+) (*BuildStats, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("document is nil")
+	}
+
+	stats := &BuildStats{}
+	b.regID = b.extractRegID(doc.Identifier)
+
+	selected := make(map[*extract.Article]bool, len(articles))
+	for _, article := range articles {
+		selected[article] = true
+	}
+
+	// Build selected articles under their parents, in document order
+	region := &extract.Chapter{}
+	for _, chapter := range doc.Chapters {
+		chapterURI := b.chapterURI(chapter.Number)
+		for _, section := range chapter.Sections {
+			sectionURI := b.sectionNodeURI(chapter.Number, section)
+			for _, article := range section.Articles {
+				if selected[article] {
+					b.buildArticle(article, sectionURI, stats)
+					region.Articles = append(region.Articles, article)
+				}
+			}
+		}
+		for _, article := range chapter.Articles {
+			if selected[article] {
+				b.buildArticle(article, chapterURI, stats)
+				region.Articles = append(region.Articles, article)
+			}
+		}
+	}
+	regionDoc := &extract.Document{Identifier: doc.Identifier, Chapters: []*extract.Chapter{region}}
+
+	var resolved []*extract.ResolvedReference
+	if refExtractor != nil {
+		refs := refExtractor.ExtractFromDocument(regionDoc)
+		if resolver != nil {
+			resolver.IndexDocument(doc)
+			resolved = resolver.ResolveAll(refs)
+			for _, res := range resolved {
+				b.buildResolvedReference(res, stats)
+			}
+		} else {
+			for _, ref := range refs {
+				b.buildReference(ref, stats)
+			}
+		}
+	}
+
+	var annotations []*extract.SemanticAnnotation
+	if semExtractor != nil {
+		annotations = semExtractor.ExtractFromDocument(regionDoc)
+		for _, ann := range annotations {
+			b.buildSemanticAnnotation(ann, stats)
+		}
+	}
+
+	if len(definitions) > 0 {
+		usageExtractor := extract.NewTermUsageExtractor(definitions)
+		usageExtractor.SetAbbreviations(extract.NewAbbreviationExtractor().ExtractFromDocument(doc))
+		usages := usageExtractor.ExtractFromDocument(regionDoc)
+		for _, usage := range usages {
+			b.buildTermUsage(usage, stats)
+		}
+		stats.TermUsages = len(usages)
+	}
+
+	build := &BuildContext{
+		Document:    doc,
+		Articles:    region.Articles,
+		Partial:     true,
+		Definitions: definitions,
+		References:  resolved,
+		Annotations: annotations,
+	}
+	if err := b.runContributors(build, stats); err != nil {
+		return nil, err
 	}
 
 	stats.TotalTriples = b.store.Count()
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -210,6 +211,106 @@ func TestGraphBuilder_Build_WithSections(t *testing.T) {
 	}
 }
 
+func TestGraphBuilder_Build_WithAnnexes(t *testing.T) {
+	store := NewTripleStore()
+	builder := NewGraphBuilder(store, "https://test.org/")
+
+	doc := &extract.Document{
+		Title:      "Test Regulation",
+		Type:       extract.DocumentTypeRegulation,
+		Identifier: "(EU) 2024/001",
+		Annexes: []*extract.Annex{
+			{
+				Kind:   extract.AnnexKindAnnex,
+				Number: "II",
+				Title:  "High-risk systems",
+				Text:   "Area | System\nBiometrics | Remote identification",
+				Rows:   [][]string{{"Area", "System"}, {"Biometrics", "Remote identification"}},
+			},
+		},
+	}
+
+	stats, err := builder.Build(doc)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if stats.Annexes != 1 {
+		t.Errorf("Expected 1 annex, got %d", stats.Annexes)
+	}
+
+	annexURI := builder.GetRegulationURI() + ":AnnexII"
+	if !store.Exists(annexURI, RDFType, ClassAnnex) {
+		t.Fatalf("Expected %s to be a reg:Annex", annexURI)
+	}
+	if title := store.GetOne(annexURI, PropTitle); title != "High-risk systems" {
+		t.Errorf("Annex title = %q", title)
+	}
+	if !store.Exists(builder.GetRegulationURI(), PropHasAnnex, annexURI) {
+		t.Error("Expected regulation to link to its annex")
+	}
+
+	rows := store.Find(annexURI, PropHasRow, "")
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	if cells := store.GetOne(annexURI+":Row2", PropCells); cells != "Biometrics | Remote identification" {
+		t.Errorf("Row 2 cells = %q", cells)
+	}
+}
+
+func TestGraphBuilder_Build_WithNotes(t *testing.T) {
+	store := NewTripleStore()
+	builder := NewGraphBuilder(store, "https://test.org/")
+
+	doc := &extract.Document{
+		Title:      "Test Act",
+		Type:       extract.DocumentTypeRegulation,
+		Identifier: "(EU) 2024/001",
+		Chapters: []*extract.Chapter{
+			{
+				Number: "1",
+				Articles: []*extract.Article{
+					{
+						Number: 6502,
+						Text:   "The Commission shall promulgate regulations.",
+						Notes: []*extract.EditorialNote{
+							{Kind: extract.NoteKindSourceCredit, Text: "(Pub. L. 105–277, §1303.)"},
+							{Kind: extract.NoteKindAmendment, Heading: "Amendments", Year: 2010, Text: "Pub. L. 111–203 substituted \"Bureau\"."},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	stats, err := builder.Build(doc)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if stats.Notes != 2 {
+		t.Errorf("Expected 2 notes, got %d", stats.Notes)
+	}
+
+	articleURI := builder.GetRegulationURI() + ":Art6502"
+	if text := store.GetOne(articleURI, PropText); text != "The Commission shall promulgate regulations." {
+		t.Errorf("Article text = %q", text)
+	}
+	if notes := store.Find(articleURI, PropHasNote, ""); len(notes) != 2 {
+		t.Fatalf("Expected 2 hasNote triples, got %d", len(notes))
+	}
+
+	amendmentURI := articleURI + ":Note:2"
+	if !store.Exists(amendmentURI, RDFType, ClassEditorialNote) {
+		t.Fatalf("Expected %s to be a reg:EditorialNote", amendmentURI)
+	}
+	if kind := store.GetOne(amendmentURI, PropNoteKind); kind != "amendment" {
+		t.Errorf("Note kind = %q, want amendment", kind)
+	}
+	if year := store.GetOne(amendmentURI, PropAmendmentYear); year != "2010" {
+		t.Errorf("Amendment year = %q, want 2010", year)
+	}
+}
+
 func TestGraphBuilder_Build_WithParagraphsAndPoints(t *testing.T) {
 	store := NewTripleStore()
 	builder := NewGraphBuilder(store, "https://test.org/")
@@ -232,7 +333,13 @@ func TestGraphBuilder_Build_WithParagraphsAndPoints(t *testing.T) {
 								Text:   "Processing shall be lawful only if...",
 								Points: []*extract.Point{
 									{Letter: "a", Text: "the data subject has given consent"},
-									{Letter: "b", Text: "processing is necessary for contract"},
+									{
+										Letter: "b",
+										Text:   "processing is necessary for contract",
+										SubPoints: []*extract.SubPoint{
+											{Numeral: "i", Text: "to which the data subject is party"},
+										},
+									},
 								},
 							},
 							{
@@ -262,6 +369,46 @@ func TestGraphBuilder_Build_WithParagraphsAndPoints(t *testing.T) {
 	if len(points) != 2 {
 		t.Errorf("Expected 2 point triples, got %d", len(points))
 	}
+
+	// Verify sub-points are addressable below their point
+	pointURI := builder.GetRegulationURI() + ":Art6(1)(b)"
+	subPointURI := pointURI + "(i)"
+	if !store.Exists(subPointURI, RDFType, ClassSubPoint) {
+		t.Fatalf("Expected %s to be a reg:SubPoint", subPointURI)
+	}
+	if !store.Exists(pointURI, PropHasSubPoint, subPointURI) {
+		t.Error("Expected point to link to its sub-point")
+	}
+}
+
+func TestGraphBuilder_PointReferenceAlsoReferencesArticle(t *testing.T) {
+	store := NewTripleStore()
+	builder := NewGraphBuilder(store, "https://test.org/")
+	builder.SetRegulationID("TEST")
+
+	source := builder.articleURI(7)
+	target := builder.pointURI(6, 1, "a")
+	builder.buildResolvedReference(&extract.ResolvedReference{
+		Original: &extract.Reference{
+			Type:          extract.ReferenceTypeInternal,
+			Target:        extract.TargetArticle,
+			RawText:       "Article 6(1)(a)",
+			SourceArticle: 7,
+		},
+		Status:     extract.ResolutionResolved,
+		Confidence: extract.ConfidenceHigh,
+		TargetURI:  target,
+	}, &BuildStats{})
+
+	if !store.Exists(source, PropReferences, target) {
+		t.Error("Expected a reference to the point")
+	}
+	if !store.Exists(source, PropReferences, builder.articleURI(6)) {
+		t.Error("Expected a reference to the article containing the point")
+	}
+	if got := containingArticleURI(builder.chapterURI("II")); got != "" {
+		t.Errorf("containingArticleURI(chapter) = %q, want empty", got)
+	}
 }
 
 func TestGraphBuilder_Build_NilDocument(t *testing.T) {
@@ -510,6 +657,50 @@ func TestBuildGDPRGraph_WithExtractors(t *testing.T) {
 	}
 }
 
+func TestBuildGDPRGraph_RecurrenceAnnotations(t *testing.T) {
+	doc := loadGDPRDocument(t)
+
+	annotations, err := extract.ParseRecurrenceAnnotations([]byte(`
+recurrence:
+  - article: 59
+    rule: FREQ=YEARLY;BYMONTH=3;BYMONTHDAY=31
+    note: Annual activity report
+`))
+	if err != nil {
+		t.Fatalf("ParseRecurrenceAnnotations failed: %v", err)
+	}
+
+	baseURI := "https://regula.dev/regulations/"
+	store := NewTripleStore()
+	builder := NewGraphBuilder(store, baseURI)
+	resolver := extract.NewReferenceResolver(baseURI, "GDPR")
+	resolver.IndexDocument(doc)
+	semExtractor := extract.NewSemanticExtractor()
+	semExtractor.SetRecurrenceAnnotations(annotations)
+
+	if _, err := builder.BuildComplete(doc, extract.NewDefinitionExtractor(), extract.NewReferenceExtractor(), resolver, semExtractor); err != nil {
+		t.Fatalf("BuildComplete failed: %v", err)
+	}
+
+	recurring := store.Find("", PropRecurrence, "")
+	if len(recurring) == 0 {
+		t.Fatal("Expected a reg:recurrence triple for Article 59")
+	}
+	for _, triple := range recurring {
+		if store.GetOne(triple.Subject, PropPartOf) != baseURI+"GDPR:Art59" {
+			continue
+		}
+		if triple.Object != "FREQ=YEARLY;BYMONTH=3;BYMONTHDAY=31" {
+			t.Errorf("Expected annotated rule, got %q", triple.Object)
+		}
+		if source := store.GetOne(triple.Subject, PropRecurrenceSource); source != extract.RecurrenceSourceAnnotation {
+			t.Errorf("Expected recurrence source annotation, got %q", source)
+		}
+		return
+	}
+	t.Errorf("No recurring obligation is part of Article 59: %v", recurring)
+}
+
 func TestBuildGDPRGraph_Queries(t *testing.T) {
 	doc := loadGDPRDocument(t)
 
@@ -771,3 +962,118 @@ func TestBuildGDPRGraph_VerifyHierarchy(t *testing.T) {
 		}
 	}
 }
+
+// cannedCompleter returns the same model reply for every prompt.
+type cannedCompleter string
+
+func (c cannedCompleter) Complete(ctx context.Context, prompt string) (string, error) {
+	return string(c), nil
+}
+
+func TestGraphBuilder_LLMFallback(t *testing.T) {
+	doc := &extract.Document{
+		Title:      "Records Act",
+		Identifier: "(EU) 2024/1",
+		Chapters: []*extract.Chapter{{
+			Number: "I",
+			Articles: []*extract.Article{{
+				Number: 1,
+				Title:  "Duties",
+				Paragraphs: []*extract.Paragraph{
+					{Number: 1, Text: "A custodian is bound to retain archives, and a keeper means a custodian of archives."},
+				},
+			}},
+		}},
+	}
+	reply := cannedCompleter(`{"items": [
+		{"kind": "definition", "term": "keeper", "text": "a keeper means a custodian of archives", "confidence": 0.9},
+		{"kind": "obligation", "category": "RecordKeepingObligation", "text": "is bound to retain archives", "confidence": 0.7}
+	]}`)
+
+	store := NewTripleStore()
+	builder := NewGraphBuilder(store, "https://example.org/")
+	builder.SetLLMExtractor(extract.NewLLMExtractor(reply, extract.LLMExtractorOptions{}))
+	stats, err := builder.BuildComplete(doc, extract.NewDefinitionExtractor(), extract.NewReferenceExtractor(), nil, extract.NewSemanticExtractor())
+	if err != nil {
+		t.Fatalf("BuildComplete failed: %v", err)
+	}
+	if stats.LLM == nil || stats.LLM.Paragraphs != 1 {
+		t.Fatalf("expected one paragraph sent to the LLM, got %+v", stats.LLM)
+	}
+
+	marked := store.Find("", PropExtractionMethod, extract.ExtractionMethodLLM)
+	if len(marked) != 2 {
+		t.Fatalf("expected 2 LLM-extracted nodes, got %d", len(marked))
+	}
+	for _, triple := range marked {
+		nodeType := store.GetOne(triple.Subject, RDFType)
+		if nodeType != ClassDefinedTerm && nodeType != ClassObligation {
+			t.Errorf("unexpected LLM node type %s", nodeType)
+		}
+	}
+}
+
+func TestBuildComplete_DefinitionDependencies(t *testing.T) {
+	doc := loadGDPRDocument(t)
+	store := NewTripleStore()
+	builder := NewGraphBuilder(store, "https://regula.dev/regulations/")
+	if _, err := builder.BuildComplete(doc, extract.NewDefinitionExtractor(), nil, nil, nil); err != nil {
+		t.Fatalf("BuildComplete failed: %v", err)
+	}
+
+	processor := builder.definitionURI("processor")
+	if len(store.Find(processor, PropDefinitionUses, builder.definitionURI("controller"))) != 1 {
+		t.Errorf("expected processor to use controller: %v", store.Find(processor, PropDefinitionUses, ""))
+	}
+	if len(store.Find(builder.definitionURI("personal data breach"), PropDefinitionUses, builder.definitionURI("personal data"))) != 1 {
+		t.Error("expected personal data breach to use personal data")
+	}
+	if undefined := store.GetOne(builder.definitionURI("personal data"), PropUsesUndefinedTerm); undefined != "data subject" {
+		t.Errorf("undefined term = %q, want %q", undefined, "data subject")
+	}
+}
+
+func TestBuildComplete_Abbreviations(t *testing.T) {
+	doc := loadGDPRDocument(t)
+	store := NewTripleStore()
+	builder := NewGraphBuilder(store, "https://regula.dev/regulations/")
+	stats, err := builder.BuildComplete(doc, extract.NewDefinitionExtractor(), nil, nil, nil)
+	if err != nil {
+		t.Fatalf("BuildComplete failed: %v", err)
+	}
+
+	board := builder.abbreviationURI("Board")
+	if expansion := store.GetOne(board, PropExpansion); expansion != "European Data Protection Board" {
+		t.Errorf("Board expansion = %q", expansion)
+	}
+	if definedIn := store.GetOne(board, PropDefinedIn); definedIn != builder.articleURI(68) {
+		t.Errorf("Board defined in %q, want Article 68", definedIn)
+	}
+	if stats.Abbreviations != len(store.Find("", RDFType, ClassAbbreviation)) {
+		t.Errorf("stats report %d abbreviations", stats.Abbreviations)
+	}
+}
+
+func TestGraphBuilder_BuildAbbreviation_LinksDefinedTerm(t *testing.T) {
+	store := NewTripleStore()
+	builder := NewGraphBuilder(store, "https://test.org/")
+	builder.regID = "CCPA"
+	stats := &BuildStats{}
+
+	defined := map[string]bool{"personal information": true}
+	builder.buildAbbreviation(&extract.Abbreviation{ShortForm: "PI", Expansion: "Personal Information", ArticleNum: 2}, defined, stats)
+	builder.buildAbbreviation(&extract.Abbreviation{ShortForm: "TFEU", Expansion: "Treaty on the Functioning of the European Union", RecitalNum: 1}, defined, stats)
+
+	if abbreviates := store.GetOne(builder.abbreviationURI("PI"), PropAbbreviates); abbreviates != builder.definitionURI("personal information") {
+		t.Errorf("PI abbreviates %q", abbreviates)
+	}
+	if len(store.Find(builder.abbreviationURI("TFEU"), PropAbbreviates, "")) != 0 {
+		t.Error("TFEU should not abbreviate a defined term")
+	}
+	if definedIn := store.GetOne(builder.abbreviationURI("TFEU"), PropDefinedIn); definedIn != builder.recitalURI(1) {
+		t.Errorf("TFEU defined in %q, want Recital 1", definedIn)
+	}
+	if stats.Abbreviations != 2 {
+		t.Errorf("Abbreviations = %d, want 2", stats.Abbreviations)
+	}
+}
@@ -0,0 +1,159 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/extract"
+)
+
+// BuildContributor adds triples from an additional extractor (penalties,
+// actors, deadlines, annotations, ...) while a document is being built.
+// Each contributor owns a namespace: every predicate it emits must be in
+// that namespace, except rdf:type, rdfs:label, and rdfs:comment. Contributed
+// triples may use any subject, so they can attach to the builder's article
+// and regulation nodes or to nodes of the contributor's own.
+type BuildContributor interface {
+	// Name returns the contributor name used in statistics (e.g., "penalties").
+	Name() string
+
+	// Namespace returns the prefix and namespace URI of the contributor's
+	// vocabulary. Predicates may be written either as prefixed names
+	// ("pen:maxFine") or as full URIs.
+	Namespace() PrefixMapping
+
+	// Contribute returns the triples to add for the document or articles
+	// being built. It must not modify build.Store itself.
+	Contribute(build *BuildContext) ([]Triple, error)
+}
+
+// BuildContext is what a BuildContributor sees of a build in progress. The
+// core structure, definitions, references, and semantic annotations have
+// already been added to Store when contributors run.
+type BuildContext struct {
+	// Document is the parsed document.
+	Document *extract.Document
+
+	// Articles are the articles being built: all of them for BuildComplete,
+	// the selected ones for BuildArticles. Contributors should only emit
+	// article-level triples for these, so incremental rebuilds stay exact.
+	Articles []*extract.Article
+
+	// Partial is true for BuildArticles, where document-level triples are
+	// not rebuilt and contributors should skip theirs.
+	Partial bool
+
+	// Definitions, References, and Annotations are the extraction results
+	// the core build used, when the corresponding extractor was given.
+	Definitions []*extract.DefinedTerm
+	References  []*extract.ResolvedReference
+	Annotations []*extract.SemanticAnnotation
+
+	// Store is the graph built so far.
+	Store *TripleStore
+
+	builder *GraphBuilder
+}
+
+// RegulationURI returns the URI of the regulation node.
+func (build *BuildContext) RegulationURI() string {
+	return build.builder.regulationURI()
+}
+
+// ArticleURI returns the URI of an article node.
+func (build *BuildContext) ArticleURI(article *extract.Article) string {
+	if article.SectionID != "" {
+		return build.builder.articleURIStr(article.SectionID)
+	}
+	return build.builder.articleURI(article.Number)
+}
+
+// NodeURI returns a URI under the builder's base URI for a node the
+// contributor introduces, e.g. NodeURI("Penalty", "Art83-4").
+func (build *BuildContext) NodeURI(kind string, id string) string {
+	return fmt.Sprintf("%s%s:%s:%s", build.builder.baseURI, build.builder.regID, kind, id)
+}
+
+// AddContributor registers a contributor to run at the end of BuildComplete
+// and BuildArticles. Contributors run in the order they were added.
+// Returns an error if the contributor is nil, has an empty name or
+// namespace, or reuses the name or prefix of one already added.
+func (b *GraphBuilder) AddContributor(contributor BuildContributor) error {
+	if contributor == nil {
+		return fmt.Errorf("build contributor cannot be nil")
+	}
+	contributorName := contributor.Name()
+	if contributorName == "" {
+		return fmt.Errorf("build contributor name cannot be empty")
+	}
+	namespace := contributor.Namespace()
+	if namespace.Prefix == "" || namespace.Namespace == "" {
+		return fmt.Errorf("build contributor %q must declare a namespace prefix and URI", contributorName)
+	}
+	if _, reserved := knownNamespaces[namespace.Prefix]; reserved {
+		return fmt.Errorf("build contributor %q cannot use the reserved prefix %q", contributorName, namespace.Prefix)
+	}
+	for _, existing := range b.contributors {
+		if existing.Name() == contributorName {
+			return fmt.Errorf("build contributor %q already added", contributorName)
+		}
+		if existing.Namespace().Prefix == namespace.Prefix {
+			return fmt.Errorf("build contributor %q reuses prefix %q of %q", contributorName, namespace.Prefix, existing.Name())
+		}
+	}
+	b.contributors = append(b.contributors, contributor)
+	return nil
+}
+
+// Namespaces returns the namespaces of the added contributors, for
+// declaring their prefixes when serializing (see WithPrefix).
+func (b *GraphBuilder) Namespaces() []PrefixMapping {
+	namespaces := make([]PrefixMapping, 0, len(b.contributors))
+	for _, contributor := range b.contributors {
+		namespaces = append(namespaces, contributor.Namespace())
+	}
+	return namespaces
+}
+
+// runContributors adds the triples of every contributor to the store and
+// records them in stats. A contributor error, or a predicate outside the
+// contributor's namespace, stops the build.
+func (b *GraphBuilder) runContributors(build *BuildContext, stats *BuildStats) error {
+	if len(b.contributors) == 0 {
+		return nil
+	}
+	build.Store = b.store
+	build.builder = b
+
+	for _, contributor := range b.contributors {
+		triples, err := contributor.Contribute(build)
+		if err != nil {
+			return fmt.Errorf("build contributor %q failed: %w", contributor.Name(), err)
+		}
+		namespace := contributor.Namespace()
+		for _, triple := range triples {
+			if !inContributorNamespace(triple.Predicate, namespace) {
+				return fmt.Errorf("build contributor %q emitted predicate %s outside its namespace %s", contributor.Name(), triple.Predicate, namespace.Namespace)
+			}
+		}
+		if stats.ContributedTriples == nil {
+			stats.ContributedTriples = make(map[string]int)
+		}
+		for _, triple := range triples {
+			if b.store.Exists(triple.Subject, triple.Predicate, triple.Object) {
+				continue
+			}
+			b.store.AddTriple(triple)
+			stats.ContributedTriples[contributor.Name()]++
+		}
+	}
+	return nil
+}
+
+func inContributorNamespace(predicate string, namespace PrefixMapping) bool {
+	switch predicate {
+	case RDFType, RDFSLabel, RDFSComment:
+		return true
+	}
+	return strings.HasPrefix(predicate, namespace.Prefix+":") || strings.HasPrefix(predicate, namespace.Namespace)
+}
@@ -0,0 +1,161 @@
+package store
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/extract"
+)
+
+// deadlineContributor tags articles whose text sets a time limit, as a
+// stand-in for a real deadline extractor.
+type deadlineContributor struct {
+	name      string
+	prefix    string
+	predicate string
+	err       error
+}
+
+var withinDaysPattern = regexp.MustCompile(`within (\w+) (days|months)`)
+
+func (c deadlineContributor) Name() string {
+	if c.name == "" {
+		return "deadlines"
+	}
+	return c.name
+}
+
+func (c deadlineContributor) Namespace() PrefixMapping {
+	prefix := c.prefix
+	if prefix == "" {
+		prefix = "dl"
+	}
+	return PrefixMapping{Prefix: prefix, Namespace: "https://example.org/deadlines#"}
+}
+
+func (c deadlineContributor) Contribute(build *BuildContext) ([]Triple, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	predicate := c.predicate
+	if predicate == "" {
+		predicate = "dl:period"
+	}
+	var triples []Triple
+	for _, article := range build.Articles {
+		match := withinDaysPattern.FindStringSubmatch(article.Text)
+		if match == nil {
+			continue
+		}
+		node := build.NodeURI("Deadline", itoa(article.Number))
+		triples = append(triples,
+			NewTriple(node, RDFType, "dl:Deadline"),
+			NewTriple(node, predicate, match[1]+" "+match[2]),
+			NewTriple(build.ArticleURI(article), "dl:hasDeadline", node),
+		)
+	}
+	return triples, nil
+}
+
+func TestGraphBuilder_AddContributor(t *testing.T) {
+	builder := NewGraphBuilder(NewTripleStore(), "https://example.org/")
+
+	if err := builder.AddContributor(nil); err == nil {
+		t.Error("expected error for nil contributor")
+	}
+	if err := builder.AddContributor(deadlineContributor{prefix: "reg"}); err == nil {
+		t.Error("expected error for reserved prefix reg")
+	}
+	if err := builder.AddContributor(deadlineContributor{}); err != nil {
+		t.Fatalf("AddContributor: %v", err)
+	}
+	if err := builder.AddContributor(deadlineContributor{}); err == nil {
+		t.Error("expected error for duplicate contributor name")
+	}
+	if err := builder.AddContributor(deadlineContributor{name: "other"}); err == nil {
+		t.Error("expected error for duplicate prefix")
+	}
+
+	namespaces := builder.Namespaces()
+	if len(namespaces) != 1 || namespaces[0].Prefix != "dl" {
+		t.Errorf("Namespaces() = %v", namespaces)
+	}
+}
+
+func TestGraphBuilder_ContributorsRunInBuildComplete(t *testing.T) {
+	doc := loadGDPRDocument(t)
+	tripleStore := NewTripleStore()
+	builder := NewGraphBuilder(tripleStore, "https://regula.dev/regulations/")
+	if err := builder.AddContributor(deadlineContributor{}); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := builder.BuildComplete(doc, extract.NewDefinitionExtractor(), extract.NewReferenceExtractor(),
+		extract.NewReferenceResolver("https://regula.dev/regulations/", "GDPR"), extract.NewSemanticExtractor())
+	if err != nil {
+		t.Fatalf("BuildComplete: %v", err)
+	}
+
+	contributed := stats.ContributedTriples["deadlines"]
+	if contributed == 0 {
+		t.Fatal("deadline contributor added no triples")
+	}
+	if stats.TotalTriples != tripleStore.Count() {
+		t.Errorf("TotalTriples = %d, store has %d", stats.TotalTriples, tripleStore.Count())
+	}
+
+	deadlines := tripleStore.Find("", "dl:hasDeadline", "")
+	if len(deadlines) == 0 || contributed != 3*len(deadlines) {
+		t.Errorf("%d deadlines, %d contributed triples", len(deadlines), contributed)
+	}
+	for _, triple := range deadlines {
+		if len(tripleStore.Find(triple.Subject, RDFType, ClassArticle)) != 1 {
+			t.Errorf("deadline attached to non-article %s", triple.Subject)
+		}
+	}
+}
+
+func TestGraphBuilder_ContributorsRunInBuildArticles(t *testing.T) {
+	doc := loadGDPRDocument(t)
+	builder := NewGraphBuilder(NewTripleStore(), "https://regula.dev/regulations/")
+	var seen *BuildContext
+	builder.AddContributor(recordingContributor{seen: &seen})
+
+	article := doc.GetArticle(17)
+	if _, err := builder.BuildArticles(doc, []*extract.Article{article}, nil, nil, nil, nil); err != nil {
+		t.Fatalf("BuildArticles: %v", err)
+	}
+	if seen == nil || !seen.Partial || len(seen.Articles) != 1 || seen.Articles[0] != article {
+		t.Errorf("contributor saw %+v", seen)
+	}
+}
+
+type recordingContributor struct {
+	seen **BuildContext
+}
+
+func (c recordingContributor) Name() string { return "recording" }
+func (c recordingContributor) Namespace() PrefixMapping {
+	return PrefixMapping{Prefix: "rec", Namespace: "https://example.org/rec#"}
+}
+func (c recordingContributor) Contribute(build *BuildContext) ([]Triple, error) {
+	*c.seen = build
+	return nil, nil
+}
+
+func TestGraphBuilder_ContributorErrors(t *testing.T) {
+	doc := loadGDPRDocument(t)
+
+	failing := NewGraphBuilder(NewTripleStore(), "https://regula.dev/regulations/")
+	failing.AddContributor(deadlineContributor{err: errors.New("model unavailable")})
+	if _, err := failing.BuildComplete(doc, nil, nil, nil, nil); err == nil {
+		t.Error("expected contributor error to fail the build")
+	}
+
+	trespassing := NewGraphBuilder(NewTripleStore(), "https://regula.dev/regulations/")
+	trespassing.AddContributor(deadlineContributor{predicate: PropTitle})
+	if _, err := trespassing.BuildComplete(doc, nil, nil, nil, nil); err == nil {
+		t.Error("expected error for a predicate outside the contributor namespace")
+	}
+}
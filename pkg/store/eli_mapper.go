@@ -21,6 +21,7 @@ var eliClassMapping = map[string]string{
 	ClassArticle:    ELIClassLegalResourceSubdivision,
 	ClassParagraph:  ELIClassLegalResourceSubdivision,
 	ClassPoint:      ELIClassLegalResourceSubdivision,
+	ClassSubPoint:   ELIClassLegalResourceSubdivision,
 	ClassPreamble:   ELIClassLegalResourceSubdivision,
 	ClassRecital:    ELIClassLegalResourceSubdivision,
 }
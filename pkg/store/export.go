@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // GraphNode represents a node in the graph visualization.
@@ -37,6 +39,21 @@ type GraphStats struct {
 	EdgesByType  map[string]int `json:"edges_by_type"`
 }
 
+// NodeTypeColors are the fill colors of node types in diagrams drawn
+// without Graphviz, the hex equivalents of the colors ToDOT uses.
+var NodeTypeColors = map[string]string{
+	"Article":     "#add8e6",
+	"Chapter":     "#90ee90",
+	"Section":     "#ffffe0",
+	"DefinedTerm": "#ffb6c1",
+	"Right":       "#f08080",
+	"Obligation":  "#ffa07a",
+	"Reference":   "#d3d3d3",
+	"Regulation":  "#ffd700",
+	"Preamble":    "#e6e6fa",
+	"Recital":     "#e6e6fa",
+}
+
 // ExportGraph exports the triple store as a graph structure for visualization.
 func ExportGraph(store *TripleStore) *GraphExport {
 	export := &GraphExport{
@@ -226,6 +243,12 @@ func isRelationshipPredicate(predicate string) bool {
 		PropDefines,
 		PropDefinedIn,
 		PropUsesTerm,
+		PropDefinitionUses,
+		PropAbbreviates,
+		PropUsesExternalDefinition,
+		PropRelatedTo,
+		PropSuggestionFor,
+		PropSuggestedProvision,
 		PropGrantsRight,
 		PropImposesObligation,
 		PropAmends,
@@ -335,10 +358,7 @@ func (g *GraphExport) ToDOT() string {
 			color = "white"
 		}
 		// Escape label for DOT
-		label := strings.ReplaceAll(node.Label, "\"", "\\\"")
-		if len(label) > 30 {
-			label = label[:30] + "..."
-		}
+		label := strings.ReplaceAll(textutil.Truncate(node.Label, 33), "\"", "\\\"")
 		sb.WriteString(fmt.Sprintf("  \"%s\" [label=\"%s\" style=filled fillcolor=%s];\n",
 			node.ID, label, color))
 	}
@@ -354,6 +374,7 @@ func (g *GraphExport) ToDOT() string {
 		"defines":          "green",
 		"definedIn":        "green",
 		"usesTerm":         "purple",
+		"definitionUses":   "purple",
 		"grantsRight":      "orange",
 		"imposesObligation": "brown",
 	}
@@ -0,0 +1,425 @@
+package store
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/coolbeans/regula/pkg/extract"
+)
+
+// IdentifierScheme mints official identifiers (ELI, ECLI, USLM, ...) for
+// nodes in a regulation graph. Implementations inspect the graph and return
+// the identifier triples to add; they must not modify the store themselves.
+type IdentifierScheme interface {
+	// Name returns the scheme name used in statistics (e.g., "eli-uk").
+	Name() string
+
+	// Mint returns identifier triples for the documents, subdivisions, and
+	// references in the store that the scheme recognizes.
+	Mint(tripleStore *TripleStore, documentType extract.DocumentType) []Triple
+}
+
+// IdentifierEnrichmentStats tracks the identifiers added by each scheme.
+type IdentifierEnrichmentStats struct {
+	SchemeTriples map[string]int `json:"scheme_triples"`
+	TotalTriples  int            `json:"total_triples"`
+}
+
+// IdentifierSchemeRegistry manages a collection of identifier schemes.
+// Thread-safe for concurrent use.
+type IdentifierSchemeRegistry struct {
+	mu      sync.RWMutex
+	schemes map[string]IdentifierScheme
+}
+
+// NewIdentifierSchemeRegistry creates an empty identifier scheme registry.
+func NewIdentifierSchemeRegistry() *IdentifierSchemeRegistry {
+	return &IdentifierSchemeRegistry{
+		schemes: make(map[string]IdentifierScheme),
+	}
+}
+
+// DefaultIdentifierSchemeRegistry returns a registry with the built-in
+// schemes: ELI for EU and UK legislation, ECLI for cited case law, and USLM
+// for US Code, CFR, and Public Law references.
+func DefaultIdentifierSchemeRegistry() *IdentifierSchemeRegistry {
+	registry := NewIdentifierSchemeRegistry()
+	registry.Register(EUELIScheme{})
+	registry.Register(UKELIScheme{})
+	registry.Register(ECLIScheme{})
+	registry.Register(USLMScheme{})
+	return registry
+}
+
+// Register adds a scheme to the registry.
+// Returns an error if the scheme is nil, has an empty name, or a scheme
+// with the same name is already registered.
+func (r *IdentifierSchemeRegistry) Register(scheme IdentifierScheme) error {
+	if scheme == nil {
+		return fmt.Errorf("identifier scheme cannot be nil")
+	}
+	schemeName := scheme.Name()
+	if schemeName == "" {
+		return fmt.Errorf("identifier scheme name cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.schemes[schemeName]; exists {
+		return fmt.Errorf("identifier scheme %q already registered", schemeName)
+	}
+	r.schemes[schemeName] = scheme
+	return nil
+}
+
+// Unregister removes a scheme by name.
+// Returns an error if the scheme is not found.
+func (r *IdentifierSchemeRegistry) Unregister(schemeName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.schemes[schemeName]; !exists {
+		return fmt.Errorf("identifier scheme %q not found", schemeName)
+	}
+	delete(r.schemes, schemeName)
+	return nil
+}
+
+// Get returns a scheme by name.
+func (r *IdentifierSchemeRegistry) Get(schemeName string) (IdentifierScheme, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	scheme, ok := r.schemes[schemeName]
+	return scheme, ok
+}
+
+// List returns all registered scheme names in sorted order.
+func (r *IdentifierSchemeRegistry) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	schemeNames := make([]string, 0, len(r.schemes))
+	for schemeName := range r.schemes {
+		schemeNames = append(schemeNames, schemeName)
+	}
+	sort.Strings(schemeNames)
+	return schemeNames
+}
+
+// Count returns the number of registered schemes.
+func (r *IdentifierSchemeRegistry) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.schemes)
+}
+
+// EnrichWithIdentifiers runs every scheme in the registry, in name order,
+// and adds the minted identifier triples to the store. A nil registry uses
+// DefaultIdentifierSchemeRegistry.
+//
+// Like EnrichWithELI, this is additive and idempotent: only triples not
+// already in the store are added and counted.
+func EnrichWithIdentifiers(tripleStore *TripleStore, documentType extract.DocumentType, registry *IdentifierSchemeRegistry) *IdentifierEnrichmentStats {
+	if registry == nil {
+		registry = DefaultIdentifierSchemeRegistry()
+	}
+
+	enrichmentStats := &IdentifierEnrichmentStats{SchemeTriples: make(map[string]int)}
+	for _, schemeName := range registry.List() {
+		scheme, ok := registry.Get(schemeName)
+		if !ok {
+			continue
+		}
+		for _, triple := range scheme.Mint(tripleStore, documentType) {
+			if tripleStore.Exists(triple.Subject, triple.Predicate, triple.Object) {
+				continue
+			}
+			tripleStore.AddTriple(triple)
+			enrichmentStats.SchemeTriples[schemeName]++
+			enrichmentStats.TotalTriples++
+		}
+	}
+	return enrichmentStats
+}
+
+// documentNodes returns the top-level document nodes in the store, sorted.
+func documentNodes(tripleStore *TripleStore) []string {
+	var documentURIs []string
+	for _, documentClass := range []string{ClassRegulation, ClassDirective, ClassDecision} {
+		for _, triple := range tripleStore.Find("", RDFType, documentClass) {
+			documentURIs = append(documentURIs, triple.Subject)
+		}
+	}
+	sort.Strings(documentURIs)
+	return documentURIs
+}
+
+// documentArticles returns the article nodes of a document with their
+// numbers.
+func documentArticles(tripleStore *TripleStore, documentURI string) map[string]string {
+	articleNumbers := make(map[string]string)
+	for _, triple := range tripleStore.Find("", PropBelongsTo, documentURI) {
+		if !tripleStore.Exists(triple.Subject, RDFType, ClassArticle) {
+			continue
+		}
+		if number := tripleStore.GetOne(triple.Subject, PropNumber); number != "" {
+			articleNumbers[triple.Subject] = number
+		}
+	}
+	return articleNumbers
+}
+
+// externalReferences returns reference nodes mapped to their external
+// identifier, optionally restricted to the given external document types.
+func externalReferences(tripleStore *TripleStore, documentTypes ...string) map[string]string {
+	references := make(map[string]string)
+	for _, triple := range tripleStore.Find("", PropExternalRef, "") {
+		if len(documentTypes) > 0 {
+			externalDocType := strings.ToLower(tripleStore.GetOne(triple.Subject, PropExternalDocType))
+			matched := false
+			for _, documentType := range documentTypes {
+				if externalDocType == strings.ToLower(documentType) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		references[triple.Subject] = triple.Object
+	}
+	return references
+}
+
+// --- EU ELI ---
+
+// ELIBaseEU is the base of European Legislation Identifiers for EU acts.
+const ELIBaseEU = "http://data.europa.eu/eli/"
+
+// euActPattern matches EU act numbers such as "(EU) 2016/679",
+// "Regulation (EC) No 45/2001", "Directive 95/46/EC", or "Decision 2010/87/EU".
+var euActPattern = regexp.MustCompile(`(?i)(?:\((?:EU|EC|EEC|Euratom)\)\s*)?(No\.?\s*)?\b(\d{1,4})/(\d{1,4})\b`)
+
+// EUELIScheme mints ELI URIs for EU regulations, directives, and decisions:
+// the document and its articles, and external references to other EU acts.
+type EUELIScheme struct{}
+
+// Name returns the scheme name.
+func (EUELIScheme) Name() string { return "eli-eu" }
+
+// Mint returns reg:eli triples for EU acts in the store.
+func (EUELIScheme) Mint(tripleStore *TripleStore, documentType extract.DocumentType) []Triple {
+	var minted []Triple
+
+	if IsEUDocumentType(documentType) {
+		for _, documentURI := range documentNodes(tripleStore) {
+			identifier := tripleStore.GetOne(documentURI, PropIdentifier)
+			if isUKLegislationIdentifier(identifier) {
+				continue
+			}
+			workPath, ok := euELIWorkPath(eliTypeForDocumentType(documentType), identifier)
+			if !ok {
+				continue
+			}
+			minted = append(minted, NewTriple(documentURI, PropELI, ELIBaseEU+workPath+"/oj"))
+			for articleURI, articleNumber := range documentArticles(tripleStore, documentURI) {
+				minted = append(minted, NewTriple(articleURI, PropELI, ELIBaseEU+workPath+"/art_"+articleNumber+"/oj"))
+			}
+		}
+	}
+
+	for referenceURI, identifier := range externalReferences(tripleStore, "Regulation", "Directive", "Decision") {
+		eliType := eliTypeForDocumentType(extract.DocumentType(strings.ToLower(tripleStore.GetOne(referenceURI, PropExternalDocType))))
+		if workPath, ok := euELIWorkPath(eliType, identifier); ok {
+			minted = append(minted, NewTriple(referenceURI, PropELI, ELIBaseEU+workPath+"/oj"))
+		}
+	}
+	return minted
+}
+
+// eliTypeForDocumentType returns the ELI type segment ("reg", "dir", "dec").
+func eliTypeForDocumentType(documentType extract.DocumentType) string {
+	switch documentType {
+	case extract.DocumentTypeDirective:
+		return "dir"
+	case extract.DocumentTypeDecision:
+		return "dec"
+	default:
+		return "reg"
+	}
+}
+
+// euELIWorkPath returns the "{type}/{year}/{number}" path of an EU act.
+// Acts numbered before 2015 with "No" put the number first
+// ("No 45/2001"); others put the year first ("2016/679", "95/46").
+// Two-digit years are in the 1900s.
+func euELIWorkPath(eliType string, identifier string) (string, bool) {
+	match := euActPattern.FindStringSubmatch(identifier)
+	if match == nil {
+		return "", false
+	}
+	year, number := match[2], match[3]
+	if match[1] != "" {
+		year, number = number, year
+	}
+	if len(year) == 2 {
+		year = "19" + year
+	}
+	if len(year) != 4 {
+		return "", false
+	}
+	return fmt.Sprintf("%s/%s/%s", eliType, year, strings.TrimLeft(number, "0")), true
+}
+
+// --- UK ELI ---
+
+// ELIBaseUK is the base of identifier URIs on legislation.gov.uk, which
+// follow the ELI template {type}/{year}/{number}.
+const ELIBaseUK = "http://www.legislation.gov.uk/id/"
+
+var (
+	ukActIdentifierPattern = regexp.MustCompile(`^(\d{4})\s+c\.\s*(\d+)$`)
+	ukSIIdentifierPattern  = regexp.MustCompile(`^S\.I\.\s+(\d{4})/(\d+)$`)
+)
+
+// UKELIScheme mints legislation.gov.uk ELI URIs for UK Public General Acts
+// ("2018 c. 12") and Statutory Instruments ("S.I. 2019/419") and their
+// sections or regulations.
+type UKELIScheme struct{}
+
+// Name returns the scheme name.
+func (UKELIScheme) Name() string { return "eli-uk" }
+
+// Mint returns reg:eli triples for UK legislation in the store.
+func (UKELIScheme) Mint(tripleStore *TripleStore, documentType extract.DocumentType) []Triple {
+	var minted []Triple
+	for _, documentURI := range documentNodes(tripleStore) {
+		identifier := strings.TrimSpace(tripleStore.GetOne(documentURI, PropIdentifier))
+
+		var workPath, subdivision string
+		if match := ukActIdentifierPattern.FindStringSubmatch(identifier); match != nil {
+			workPath, subdivision = "ukpga/"+match[1]+"/"+match[2], "section"
+		} else if match := ukSIIdentifierPattern.FindStringSubmatch(identifier); match != nil {
+			workPath, subdivision = "uksi/"+match[1]+"/"+match[2], "regulation"
+		} else {
+			continue
+		}
+
+		minted = append(minted, NewTriple(documentURI, PropELI, ELIBaseUK+workPath))
+		for articleURI, articleNumber := range documentArticles(tripleStore, documentURI) {
+			minted = append(minted, NewTriple(articleURI, PropELI, ELIBaseUK+workPath+"/"+subdivision+"/"+articleNumber))
+		}
+	}
+	return minted
+}
+
+// isUKLegislationIdentifier reports whether identifier is a UK Act chapter
+// or SI number.
+func isUKLegislationIdentifier(identifier string) bool {
+	identifier = strings.TrimSpace(identifier)
+	return ukActIdentifierPattern.MatchString(identifier) || ukSIIdentifierPattern.MatchString(identifier)
+}
+
+// --- ECLI ---
+
+// ECLIResolverBase resolves European Case Law Identifiers on the e-Justice portal.
+const ECLIResolverBase = "https://e-justice.europa.eu/ecli/"
+
+// ecliPattern matches an ECLI: country, court, year, and ordinal number.
+var ecliPattern = regexp.MustCompile(`\bECLI:[A-Z]{2}:[A-Z0-9]{1,7}:\d{4}:[A-Za-z0-9.]{1,25}[A-Za-z0-9]`)
+
+// ECLIScheme finds European Case Law Identifiers cited in provision text or
+// external references and links them to the citing node.
+type ECLIScheme struct{}
+
+// Name returns the scheme name.
+func (ECLIScheme) Name() string { return "ecli" }
+
+// Mint returns reg:ecli triples for each ECLI cited in the store.
+func (ECLIScheme) Mint(tripleStore *TripleStore, documentType extract.DocumentType) []Triple {
+	var minted []Triple
+	for _, predicate := range []string{PropText, PropExternalRef} {
+		for _, triple := range tripleStore.Find("", predicate, "") {
+			for _, ecli := range ecliPattern.FindAllString(triple.Object, -1) {
+				minted = append(minted, NewTriple(triple.Subject, PropECLI, ECLIResolverBase+ecli))
+			}
+		}
+	}
+	return minted
+}
+
+// --- USLM ---
+
+var (
+	uscCitationPattern        = regexp.MustCompile(`(?i)\b(\d+)\s+U\.?\s?S\.?\s?C\.?\s*(?:(?:§+|Sec\.|Section)\s*)?(\d+[a-z]*(?:-\d+[a-z]*)?)`)
+	cfrCitationPattern        = regexp.MustCompile(`(?i)\b(\d+)\s+C\.?\s?F\.?\s?R\.?\s*(?:Part\s+|§+\s*)?(\d+)(?:\.(\d+))?`)
+	publicLawCitationPattern  = regexp.MustCompile(`(?i)\bPub(?:lic)?\.?\s*L(?:aw)?\.?\s*(?:No\.?\s*)?(\d+)-(\d+)`)
+	uslmIdentifierSourceTypes = []string{"USC", "CFR", "PublicLaw"}
+)
+
+// USLMScheme mints USLM identifiers for US Code sections, CFR parts and
+// sections, and Public Laws, both for US documents whose identifier is such
+// a citation and for external references to them.
+type USLMScheme struct{}
+
+// Name returns the scheme name.
+func (USLMScheme) Name() string { return "uslm" }
+
+// Mint returns reg:uslmIdentifier triples for US citations in the store.
+func (USLMScheme) Mint(tripleStore *TripleStore, documentType extract.DocumentType) []Triple {
+	var minted []Triple
+	for _, documentURI := range documentNodes(tripleStore) {
+		if identifier, ok := USLMIdentifier(tripleStore.GetOne(documentURI, PropIdentifier)); ok {
+			minted = append(minted, NewTriple(documentURI, PropUSLMIdentifier, identifier))
+		}
+	}
+	for referenceURI, citation := range externalReferences(tripleStore, uslmIdentifierSourceTypes...) {
+		if identifier, ok := USLMIdentifier(citation); ok {
+			minted = append(minted, NewTriple(referenceURI, PropUSLMIdentifier, identifier))
+		}
+	}
+	return minted
+}
+
+// USLMIdentifier converts a US citation to its USLM identifier:
+// "15 U.S.C. § 6501" becomes "/us/usc/t15/s6501", "45 C.F.R. 164.502"
+// becomes "/us/cfr/t45/s164.502", "34 C.F.R. Part 99" becomes
+// "/us/cfr/t34/pt99", and "Pub. L. 106-102" becomes "/us/pl/106/102".
+// A US Code section range ("6501-6506") cites its first section; hyphenated
+// section numbers such as "300aa-11" are kept.
+func USLMIdentifier(citation string) (string, bool) {
+	if match := uscCitationPattern.FindStringSubmatch(citation); match != nil {
+		section := match[2]
+		if first, last, isRange := strings.Cut(section, "-"); isRange && isDigits(first) && isDigits(last) {
+			section = first
+		}
+		return fmt.Sprintf("/us/usc/t%s/s%s", match[1], section), true
+	}
+	if match := cfrCitationPattern.FindStringSubmatch(citation); match != nil {
+		if match[3] != "" {
+			return fmt.Sprintf("/us/cfr/t%s/s%s.%s", match[1], match[2], match[3]), true
+		}
+		return fmt.Sprintf("/us/cfr/t%s/pt%s", match[1], match[2]), true
+	}
+	if match := publicLawCitationPattern.FindStringSubmatch(citation); match != nil {
+		return fmt.Sprintf("/us/pl/%s/%s", match[1], match[2]), true
+	}
+	return "", false
+}
+
+// isDigits reports whether value is a non-empty run of ASCII digits.
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return true
+}
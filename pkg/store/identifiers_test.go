@@ -0,0 +1,229 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/extract"
+)
+
+type stubIdentifierScheme struct {
+	name string
+}
+
+func (scheme stubIdentifierScheme) Name() string { return scheme.name }
+
+func (scheme stubIdentifierScheme) Mint(tripleStore *TripleStore, documentType extract.DocumentType) []Triple {
+	return []Triple{NewTriple("https://example.org/doc", PropIdentifier, scheme.name)}
+}
+
+func TestIdentifierSchemeRegistry(t *testing.T) {
+	registry := NewIdentifierSchemeRegistry()
+
+	if err := registry.Register(nil); err == nil {
+		t.Error("expected error registering nil scheme")
+	}
+	if err := registry.Register(stubIdentifierScheme{}); err == nil {
+		t.Error("expected error registering scheme without a name")
+	}
+	if err := registry.Register(stubIdentifierScheme{name: "zeta"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := registry.Register(stubIdentifierScheme{name: "alpha"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := registry.Register(stubIdentifierScheme{name: "alpha"}); err == nil {
+		t.Error("expected error registering duplicate scheme")
+	}
+
+	names := registry.List()
+	if len(names) != 2 || names[0] != "alpha" || names[1] != "zeta" {
+		t.Errorf("List() = %v, want [alpha zeta]", names)
+	}
+	if _, ok := registry.Get("alpha"); !ok {
+		t.Error("Get(alpha) not found")
+	}
+
+	if err := registry.Unregister("alpha"); err != nil {
+		t.Fatalf("Unregister failed: %v", err)
+	}
+	if err := registry.Unregister("alpha"); err == nil {
+		t.Error("expected error unregistering missing scheme")
+	}
+	if registry.Count() != 1 {
+		t.Errorf("Count() = %d, want 1", registry.Count())
+	}
+}
+
+func TestDefaultIdentifierSchemeRegistry(t *testing.T) {
+	registry := DefaultIdentifierSchemeRegistry()
+	for _, schemeName := range []string{"ecli", "eli-eu", "eli-uk", "uslm"} {
+		if _, ok := registry.Get(schemeName); !ok {
+			t.Errorf("default registry missing scheme %q", schemeName)
+		}
+	}
+}
+
+func TestEnrichWithIdentifiers_CustomScheme(t *testing.T) {
+	registry := NewIdentifierSchemeRegistry()
+	if err := registry.Register(stubIdentifierScheme{name: "custom"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tripleStore := NewTripleStore()
+	stats := EnrichWithIdentifiers(tripleStore, extract.DocumentTypeUnknown, registry)
+	if stats.TotalTriples != 1 || stats.SchemeTriples["custom"] != 1 {
+		t.Errorf("stats = %+v, want 1 custom triple", stats)
+	}
+	if !tripleStore.Exists("https://example.org/doc", PropIdentifier, "custom") {
+		t.Error("custom scheme triple not added")
+	}
+}
+
+func TestEUELIWorkPath(t *testing.T) {
+	testCases := []struct {
+		eliType    string
+		identifier string
+		expected   string
+		ok         bool
+	}{
+		{"reg", "(EU) 2016/679", "reg/2016/679", true},
+		{"dir", "Directive 95/46", "dir/1995/46", true},
+		{"reg", "Regulation (EC) No 45/2001", "reg/2001/45", true},
+		{"dec", "Decision 2010/087", "dec/2010/87", true},
+		{"reg", "no citation here", "", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.identifier, func(t *testing.T) {
+			workPath, ok := euELIWorkPath(testCase.eliType, testCase.identifier)
+			if ok != testCase.ok || workPath != testCase.expected {
+				t.Errorf("euELIWorkPath(%q, %q) = %q, %v; want %q, %v",
+					testCase.eliType, testCase.identifier, workPath, ok, testCase.expected, testCase.ok)
+			}
+		})
+	}
+}
+
+func TestEnrichWithIdentifiers_EUDocument(t *testing.T) {
+	tripleStore := NewTripleStore()
+	documentURI := "https://example.org/GDPR"
+	articleURI := documentURI + ":Art17"
+	tripleStore.Add(documentURI, RDFType, ClassRegulation)
+	tripleStore.Add(documentURI, PropIdentifier, "(EU) 2016/679")
+	tripleStore.Add(articleURI, RDFType, ClassArticle)
+	tripleStore.Add(articleURI, PropBelongsTo, documentURI)
+	tripleStore.Add(articleURI, PropNumber, "17")
+
+	stats := EnrichWithIdentifiers(tripleStore, extract.DocumentTypeRegulation, nil)
+	if stats.SchemeTriples["eli-eu"] != 2 {
+		t.Errorf("eli-eu triples = %d, want 2", stats.SchemeTriples["eli-eu"])
+	}
+	if !tripleStore.Exists(documentURI, PropELI, ELIBaseEU+"reg/2016/679/oj") {
+		t.Error("missing document ELI")
+	}
+	if !tripleStore.Exists(articleURI, PropELI, ELIBaseEU+"reg/2016/679/art_17/oj") {
+		t.Error("missing article ELI")
+	}
+
+	again := EnrichWithIdentifiers(tripleStore, extract.DocumentTypeRegulation, nil)
+	if again.TotalTriples != 0 {
+		t.Errorf("second enrichment added %d triples, want 0", again.TotalTriples)
+	}
+}
+
+func TestEnrichWithIdentifiers_UKLegislation(t *testing.T) {
+	testCases := []struct {
+		name            string
+		identifier      string
+		expectedWork    string
+		expectedArticle string
+	}{
+		{"public general act", "2018 c. 12", "ukpga/2018/12", "ukpga/2018/12/section/3"},
+		{"statutory instrument", "S.I. 2019/419", "uksi/2019/419", "uksi/2019/419/regulation/3"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tripleStore := NewTripleStore()
+			documentURI := "https://example.org/UK"
+			articleURI := documentURI + ":Art3"
+			tripleStore.Add(documentURI, RDFType, ClassRegulation)
+			tripleStore.Add(documentURI, PropIdentifier, testCase.identifier)
+			tripleStore.Add(articleURI, RDFType, ClassArticle)
+			tripleStore.Add(articleURI, PropBelongsTo, documentURI)
+			tripleStore.Add(articleURI, PropNumber, "3")
+
+			stats := EnrichWithIdentifiers(tripleStore, extract.DocumentTypeRegulation, nil)
+			if stats.SchemeTriples["eli-eu"] != 0 {
+				t.Errorf("EU scheme minted %d triples for UK legislation", stats.SchemeTriples["eli-eu"])
+			}
+			if !tripleStore.Exists(documentURI, PropELI, ELIBaseUK+testCase.expectedWork) {
+				t.Errorf("missing document ELI %s", testCase.expectedWork)
+			}
+			if !tripleStore.Exists(articleURI, PropELI, ELIBaseUK+testCase.expectedArticle) {
+				t.Errorf("missing subdivision ELI %s", testCase.expectedArticle)
+			}
+		})
+	}
+}
+
+func TestEnrichWithIdentifiers_ECLI(t *testing.T) {
+	tripleStore := NewTripleStore()
+	articleURI := "https://example.org/doc:Art1"
+	tripleStore.Add(articleURI, PropText, "As held in ECLI:EU:C:2014:317 and ECLI:NL:HR:2019:1234.")
+
+	EnrichWithIdentifiers(tripleStore, extract.DocumentTypeUnknown, nil)
+
+	for _, ecli := range []string{"ECLI:EU:C:2014:317", "ECLI:NL:HR:2019:1234"} {
+		if !tripleStore.Exists(articleURI, PropECLI, ECLIResolverBase+ecli) {
+			t.Errorf("missing ECLI %s", ecli)
+		}
+	}
+}
+
+func TestUSLMIdentifier(t *testing.T) {
+	testCases := []struct {
+		citation string
+		expected string
+		ok       bool
+	}{
+		{"15 U.S.C. § 6501", "/us/usc/t15/s6501", true},
+		{"15 U.S.C. §§ 6501-6506", "/us/usc/t15/s6501", true},
+		{"42 USC 300aa-11", "/us/usc/t42/s300aa-11", true},
+		{"20 U.S.C. Sec. 1232g", "/us/usc/t20/s1232g", true},
+		{"20 U.S.C. § 1232g", "/us/usc/t20/s1232g", true},
+		{"45 C.F.R. 164.502", "/us/cfr/t45/s164.502", true},
+		{"34 C.F.R. Part 99", "/us/cfr/t34/pt99", true},
+		{"Pub. L. 106-102", "/us/pl/106/102", true},
+		{"Public Law 104-191", "/us/pl/104/191", true},
+		{"Article 6 GDPR", "", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.citation, func(t *testing.T) {
+			identifier, ok := USLMIdentifier(testCase.citation)
+			if ok != testCase.ok || identifier != testCase.expected {
+				t.Errorf("USLMIdentifier(%q) = %q, %v; want %q, %v",
+					testCase.citation, identifier, ok, testCase.expected, testCase.ok)
+			}
+		})
+	}
+}
+
+func TestEnrichWithIdentifiers_USLMExternalReference(t *testing.T) {
+	tripleStore := NewTripleStore()
+	referenceURI := "https://example.org/ref:usc"
+	tripleStore.Add(referenceURI, PropExternalRef, "20 U.S.C. § 1232g")
+	tripleStore.Add(referenceURI, PropExternalDocType, "USC")
+	otherURI := "https://example.org/ref:other"
+	tripleStore.Add(otherURI, PropExternalRef, "20 U.S.C. § 1232g")
+	tripleStore.Add(otherURI, PropExternalDocType, "Statute")
+
+	stats := EnrichWithIdentifiers(tripleStore, extract.DocumentTypeStatute, nil)
+	if stats.SchemeTriples["uslm"] != 1 {
+		t.Errorf("uslm triples = %d, want 1", stats.SchemeTriples["uslm"])
+	}
+	if !tripleStore.Exists(referenceURI, PropUSLMIdentifier, "/us/usc/t20/s1232g") {
+		t.Error("missing USLM identifier on USC reference")
+	}
+}
@@ -389,6 +389,12 @@ func (serializer *JSONLDSerializer) isRelationshipPredicate(predicate string) bo
 		PropDefines,
 		PropDefinedIn,
 		PropUsesTerm,
+		PropDefinitionUses,
+		PropAbbreviates,
+		PropUsesExternalDefinition,
+		PropRelatedTo,
+		PropSuggestionFor,
+		PropSuggestedProvision,
 		PropGrantsRight,
 		PropImposesObligation,
 		PropAmends,
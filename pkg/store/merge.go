@@ -0,0 +1,252 @@
+package store
+
+import (
+	"sort"
+	"sync"
+)
+
+// DefaultFunctionalPredicates are the predicates expected to carry exactly
+// one value per subject. Two sources asserting different objects for one of
+// these is reported as a conflict.
+var DefaultFunctionalPredicates = []string{
+	PropTitle,
+	PropText,
+	PropNumber,
+	PropIdentifier,
+	PropPartOf,
+	PropBelongsTo,
+	PropDate,
+	PropVersion,
+}
+
+// MergeEngine merges triple stores from multiple sources into a single
+// target store. Duplicate triples are stored once, every triple remembers
+// which sources asserted it, and conflicting values for functional
+// predicates are reported.
+type MergeEngine struct {
+	target     *TripleStore
+	functional map[string]bool
+	provenance map[Triple][]string
+	sources    []string
+	duplicates int
+	mu         sync.RWMutex
+}
+
+// MergeResult summarizes a single MergeSource call.
+type MergeResult struct {
+	SourceID   string `json:"source_id"`
+	Triples    int    `json:"triples"`
+	Added      int    `json:"added"`
+	Duplicates int    `json:"duplicates"`
+}
+
+// ConflictingValue is one of the competing objects in a MergeConflict,
+// with the sources that asserted it.
+type ConflictingValue struct {
+	Object  string   `json:"object"`
+	Sources []string `json:"sources"`
+}
+
+// MergeConflict records sources asserting different objects for the same
+// subject and functional predicate.
+type MergeConflict struct {
+	Subject   string             `json:"subject"`
+	Predicate string             `json:"predicate"`
+	Values    []ConflictingValue `json:"values"`
+}
+
+// Sources returns every source involved in the conflict, sorted.
+func (conflict MergeConflict) Sources() []string {
+	seen := make(map[string]bool)
+	var sources []string
+	for _, value := range conflict.Values {
+		for _, source := range value.Sources {
+			if !seen[source] {
+				seen[source] = true
+				sources = append(sources, source)
+			}
+		}
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// NewMergeEngine creates a MergeEngine writing into target (a new store
+// when nil) that checks DefaultFunctionalPredicates for conflicts.
+func NewMergeEngine(target *TripleStore) *MergeEngine {
+	if target == nil {
+		target = NewTripleStore()
+	}
+	engine := &MergeEngine{
+		target:     target,
+		functional: make(map[string]bool),
+		provenance: make(map[Triple][]string),
+	}
+	engine.SetFunctionalPredicates(DefaultFunctionalPredicates...)
+	return engine
+}
+
+// SetFunctionalPredicates replaces the predicates checked for conflicts.
+func (engine *MergeEngine) SetFunctionalPredicates(predicates ...string) {
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+
+	engine.functional = make(map[string]bool, len(predicates))
+	for _, predicate := range predicates {
+		engine.functional[predicate] = true
+	}
+}
+
+// MergeSource adds all triples from source, attributing them to sourceID.
+// A triple already asserted by another source is not added again; the
+// source is appended to its provenance and counted as a duplicate. Quality
+// scores are copied, and a triple scored by several sources keeps the
+// lowest score.
+func (engine *MergeEngine) MergeSource(sourceID string, source *TripleStore) MergeResult {
+	result := engine.MergeTriples(sourceID, source.All())
+	for triple, score := range source.qualityScores() {
+		if score < engine.target.Quality(triple) {
+			engine.target.SetQuality(triple, score)
+		}
+	}
+	return result
+}
+
+// MergeTriples adds triples attributed to sourceID. See MergeSource.
+func (engine *MergeEngine) MergeTriples(sourceID string, triples []Triple) MergeResult {
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+
+	result := MergeResult{SourceID: sourceID, Triples: len(triples)}
+	if !containsString(engine.sources, sourceID) {
+		engine.sources = append(engine.sources, sourceID)
+	}
+
+	newTriples := make([]Triple, 0, len(triples))
+	for _, triple := range triples {
+		sources, seen := engine.provenance[triple]
+		if containsString(sources, sourceID) {
+			continue
+		}
+		engine.provenance[triple] = append(sources, sourceID)
+		if seen {
+			result.Duplicates++
+			continue
+		}
+		newTriples = append(newTriples, triple)
+	}
+
+	previousCount := engine.target.Count()
+	_ = engine.target.BulkAdd(newTriples)
+	result.Added = engine.target.Count() - previousCount
+	engine.duplicates += result.Duplicates
+
+	return result
+}
+
+// Store returns the merged target store.
+func (engine *MergeEngine) Store() *TripleStore {
+	return engine.target
+}
+
+// SourceIDs returns the sources merged so far, in merge order.
+func (engine *MergeEngine) SourceIDs() []string {
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	return append([]string(nil), engine.sources...)
+}
+
+// Provenance returns the sources that asserted a triple, in merge order.
+func (engine *MergeEngine) Provenance(subject, predicate, object string) []string {
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	return append([]string(nil), engine.provenance[Triple{Subject: subject, Predicate: predicate, Object: object}]...)
+}
+
+// TriplesFromSource returns every triple asserted by sourceID, including
+// ones first contributed by another source.
+func (engine *MergeEngine) TriplesFromSource(sourceID string) []Triple {
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+
+	var triples []Triple
+	for triple, sources := range engine.provenance {
+		if containsString(sources, sourceID) {
+			triples = append(triples, triple)
+		}
+	}
+	return triples
+}
+
+// DuplicateCount returns how many incoming triples were already present
+// from another source.
+func (engine *MergeEngine) DuplicateCount() int {
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	return engine.duplicates
+}
+
+// Conflicts returns functional-predicate conflicts between sources, sorted
+// by subject and predicate. Multiple values asserted by a single source
+// are not a merge conflict and are ignored.
+func (engine *MergeEngine) Conflicts() []MergeConflict {
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+
+	type subjectPredicate struct{ subject, predicate string }
+	valuesByKey := make(map[subjectPredicate]map[string][]string)
+
+	for triple, sources := range engine.provenance {
+		if !engine.functional[triple.Predicate] {
+			continue
+		}
+		key := subjectPredicate{triple.Subject, triple.Predicate}
+		if valuesByKey[key] == nil {
+			valuesByKey[key] = make(map[string][]string)
+		}
+		valuesByKey[key][triple.Object] = sources
+	}
+
+	var conflicts []MergeConflict
+	for key, sourcesByObject := range valuesByKey {
+		if len(sourcesByObject) < 2 {
+			continue
+		}
+
+		contributing := make(map[string]bool)
+		conflict := MergeConflict{Subject: key.subject, Predicate: key.predicate}
+		for object, sources := range sourcesByObject {
+			sortedSources := append([]string(nil), sources...)
+			sort.Strings(sortedSources)
+			conflict.Values = append(conflict.Values, ConflictingValue{Object: object, Sources: sortedSources})
+			for _, source := range sources {
+				contributing[source] = true
+			}
+		}
+		if len(contributing) < 2 {
+			continue
+		}
+
+		sort.Slice(conflict.Values, func(i, j int) bool {
+			return conflict.Values[i].Object < conflict.Values[j].Object
+		})
+		conflicts = append(conflicts, conflict)
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Subject != conflicts[j].Subject {
+			return conflicts[i].Subject < conflicts[j].Subject
+		}
+		return conflicts[i].Predicate < conflicts[j].Predicate
+	})
+	return conflicts
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
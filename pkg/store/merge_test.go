@@ -0,0 +1,147 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeEngine_DeduplicatesWithProvenance(t *testing.T) {
+	first := NewTripleStore()
+	first.Add("GDPR:Art17", RDFType, ClassArticle)
+	first.Add("GDPR:Art17", PropTitle, "Right to erasure")
+	first.Add("GDPR:Art17", PropReferences, "GDPR:Art6")
+
+	second := NewTripleStore()
+	second.Add("GDPR:Art17", RDFType, ClassArticle)
+	second.Add("GDPR:Art17", PropTitle, "Right to erasure")
+	second.Add("GDPR:Art17", PropReferences, "GDPR:Art9")
+
+	engine := NewMergeEngine(nil)
+	firstResult := engine.MergeSource("fetch:gdpr", first)
+	secondResult := engine.MergeSource("crawl:gdpr", second)
+
+	if firstResult.Added != 3 || firstResult.Duplicates != 0 {
+		t.Errorf("first merge = %+v, want 3 added, 0 duplicates", firstResult)
+	}
+	if secondResult.Added != 1 || secondResult.Duplicates != 2 {
+		t.Errorf("second merge = %+v, want 1 added, 2 duplicates", secondResult)
+	}
+	if engine.Store().Count() != 4 {
+		t.Errorf("expected 4 merged triples, got %d", engine.Store().Count())
+	}
+	if engine.DuplicateCount() != 2 {
+		t.Errorf("DuplicateCount() = %d, want 2", engine.DuplicateCount())
+	}
+
+	if got := engine.Provenance("GDPR:Art17", PropTitle, "Right to erasure"); !reflect.DeepEqual(got, []string{"fetch:gdpr", "crawl:gdpr"}) {
+		t.Errorf("Provenance(title) = %v", got)
+	}
+	if got := engine.Provenance("GDPR:Art17", PropReferences, "GDPR:Art9"); !reflect.DeepEqual(got, []string{"crawl:gdpr"}) {
+		t.Errorf("Provenance(Art9 reference) = %v", got)
+	}
+	if got := len(engine.TriplesFromSource("crawl:gdpr")); got != 3 {
+		t.Errorf("TriplesFromSource(crawl:gdpr) returned %d triples, want 3", got)
+	}
+
+	// Non-functional predicates with different objects are not conflicts.
+	if conflicts := engine.Conflicts(); len(conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %+v", conflicts)
+	}
+}
+
+func TestMergeEngine_KeepsLowestQuality(t *testing.T) {
+	reference := NewTriple("GDPR:Art17", PropReferences, "GDPR:Art6")
+
+	first := NewTripleStore()
+	first.Add(reference.Subject, reference.Predicate, reference.Object)
+	first.SetQuality(reference, 0.9)
+
+	second := NewTripleStore()
+	second.Add(reference.Subject, reference.Predicate, reference.Object)
+	second.SetQuality(reference, 0.6)
+
+	engine := NewMergeEngine(nil)
+	engine.MergeSource("first", first)
+	if quality := engine.Store().Quality(reference); quality != 0.9 {
+		t.Errorf("quality after first merge = %g, want 0.9", quality)
+	}
+	engine.MergeSource("second", second)
+	if quality := engine.Store().Quality(reference); quality != 0.6 {
+		t.Errorf("quality after second merge = %g, want 0.6", quality)
+	}
+}
+
+func TestMergeEngine_RemergeSameSourceIsNoop(t *testing.T) {
+	source := NewTripleStore()
+	source.Add("GDPR:Art1", PropTitle, "Subject-matter and objectives")
+
+	engine := NewMergeEngine(nil)
+	engine.MergeSource("doc", source)
+	result := engine.MergeSource("doc", source)
+
+	if result.Added != 0 || result.Duplicates != 0 {
+		t.Errorf("re-merge = %+v, want nothing added or duplicated", result)
+	}
+	if got := engine.Provenance("GDPR:Art1", PropTitle, "Subject-matter and objectives"); len(got) != 1 {
+		t.Errorf("expected a single provenance entry, got %v", got)
+	}
+}
+
+func TestMergeEngine_Conflicts(t *testing.T) {
+	engine := NewMergeEngine(nil)
+	engine.MergeTriples("eu-gdpr", []Triple{
+		NewTriple("Regulation:Art1", PropTitle, "Subject-matter and objectives"),
+		NewTriple("Regulation:Art1", PropNumber, "1"),
+	})
+	engine.MergeTriples("us-ccpa", []Triple{
+		NewTriple("Regulation:Art1", PropTitle, "Title"),
+		NewTriple("Regulation:Art1", PropNumber, "1"),
+	})
+	engine.MergeTriples("uk-dpa", []Triple{
+		NewTriple("Regulation:Art1", PropTitle, "Title"),
+	})
+
+	conflicts := engine.Conflicts()
+	if len(conflicts) != 1 {
+		t.Fatalf("expected 1 conflict, got %+v", conflicts)
+	}
+
+	expected := MergeConflict{
+		Subject:   "Regulation:Art1",
+		Predicate: PropTitle,
+		Values: []ConflictingValue{
+			{Object: "Subject-matter and objectives", Sources: []string{"eu-gdpr"}},
+			{Object: "Title", Sources: []string{"uk-dpa", "us-ccpa"}},
+		},
+	}
+	if !reflect.DeepEqual(conflicts[0], expected) {
+		t.Errorf("conflict = %+v, want %+v", conflicts[0], expected)
+	}
+	if got := conflicts[0].Sources(); !reflect.DeepEqual(got, []string{"eu-gdpr", "uk-dpa", "us-ccpa"}) {
+		t.Errorf("Sources() = %v", got)
+	}
+}
+
+func TestMergeEngine_SingleSourceMultipleValuesIsNotConflict(t *testing.T) {
+	engine := NewMergeEngine(nil)
+	engine.MergeTriples("doc", []Triple{
+		NewTriple("Regulation:Art1", PropText, "first paragraph"),
+		NewTriple("Regulation:Art1", PropText, "second paragraph"),
+	})
+
+	if conflicts := engine.Conflicts(); len(conflicts) != 0 {
+		t.Errorf("expected no conflicts within a single source, got %+v", conflicts)
+	}
+}
+
+func TestMergeEngine_CustomFunctionalPredicates(t *testing.T) {
+	engine := NewMergeEngine(nil)
+	engine.SetFunctionalPredicates(PropReferences)
+	engine.MergeTriples("a", []Triple{NewTriple("X", PropReferences, "Y"), NewTriple("X", PropTitle, "A")})
+	engine.MergeTriples("b", []Triple{NewTriple("X", PropReferences, "Z"), NewTriple("X", PropTitle, "B")})
+
+	conflicts := engine.Conflicts()
+	if len(conflicts) != 1 || conflicts[0].Predicate != PropReferences {
+		t.Errorf("expected only a reg:references conflict, got %+v", conflicts)
+	}
+}
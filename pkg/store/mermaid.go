@@ -0,0 +1,70 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// mermaidLabelLength is the longest node label written to a Mermaid
+// diagram; longer labels are truncated so diagrams stay readable.
+const mermaidLabelLength = 40
+
+// MermaidLabel returns text as the content of a quoted Mermaid node or edge
+// label: truncated, on one line, and with the characters Mermaid would read
+// as markup written as entity codes.
+func MermaidLabel(text string) string {
+	text = textutil.Truncate(strings.Join(strings.Fields(text), " "), mermaidLabelLength)
+	return strings.NewReplacer(
+		"#", "#35;",
+		`"`, "#quot;",
+		"<", "#lt;",
+		">", "#gt;",
+	).Replace(text)
+}
+
+// ToMermaid exports the graph as a Mermaid flowchart, which GitHub and
+// most Markdown renderers draw from a ```mermaid code block. Nodes are
+// colored by type as in ToDOT. Nodes and edges are written in a fixed
+// order, so exporting the same graph twice gives the same diagram.
+func (g *GraphExport) ToMermaid() string {
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+
+	nodeIDs := make(map[string]string, len(g.Nodes))
+	classMembers := make(map[string][]string)
+	declare := func(uri, label, nodeType string) string {
+		if id, ok := nodeIDs[uri]; ok {
+			return id
+		}
+		id := fmt.Sprintf("n%d", len(nodeIDs))
+		nodeIDs[uri] = id
+		sb.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", id, MermaidLabel(label)))
+		if _, ok := NodeTypeColors[nodeType]; ok {
+			classMembers[nodeType] = append(classMembers[nodeType], id)
+		}
+		return id
+	}
+
+	for _, node := range g.sortedNodes() {
+		declare(node.ID, node.Label, node.Type)
+	}
+	for _, edge := range g.sortedEdges() {
+		source := declare(edge.Source, extractLabel(edge.Source), "")
+		target := declare(edge.Target, extractLabel(edge.Target), "")
+		sb.WriteString(fmt.Sprintf("  %s -->|%s| %s\n", source, MermaidLabel(edge.Label), target))
+	}
+
+	nodeTypes := make([]string, 0, len(classMembers))
+	for nodeType := range classMembers {
+		nodeTypes = append(nodeTypes, nodeType)
+	}
+	sort.Strings(nodeTypes)
+	for _, nodeType := range nodeTypes {
+		sb.WriteString(fmt.Sprintf("  classDef %s fill:%s,stroke:#333\n", nodeType, NodeTypeColors[nodeType]))
+		sb.WriteString(fmt.Sprintf("  class %s %s\n", strings.Join(classMembers[nodeType], ","), nodeType))
+	}
+	return sb.String()
+}
@@ -0,0 +1,78 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestGraphExport_ToMermaid(t *testing.T) {
+	output := testNeo4jGraph().ToMermaid()
+
+	if !strings.HasPrefix(output, "flowchart LR\n") {
+		t.Errorf("Expected a flowchart, got:\n%s", output)
+	}
+	for _, expected := range []string{
+		`["Right to #quot;erasure#quot;"]`,
+		`["personal data"]`,
+		"-->|references|",
+		"-->|usesTerm|",
+		"classDef Article fill:#add8e6,stroke:#333",
+		"classDef DefinedTerm fill:#ffb6c1,stroke:#333",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected Mermaid to contain %q, got:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "https://") {
+		t.Errorf("Expected URIs to be replaced by node IDs, got:\n%s", output)
+	}
+}
+
+func TestGraphExport_ToMermaidDeterministic(t *testing.T) {
+	ts := NewTripleStore()
+	base := "https://regula.dev/regulations/TEST:"
+	for i := 1; i <= 20; i++ {
+		article := fmt.Sprintf("%sArt%d", base, i)
+		ts.Add(article, RDFType, ClassArticle)
+		ts.Add(article, PropTitle, fmt.Sprintf("Article %d", i))
+		ts.Add(article, PropReferences, fmt.Sprintf("%sArt%d", base, i%20+1))
+	}
+
+	first := ExportRelationshipSubgraph(ts).ToMermaid()
+	second := ExportRelationshipSubgraph(ts).ToMermaid()
+	if first != second {
+		t.Errorf("Expected identical exports, got:\n%s\nand:\n%s", first, second)
+	}
+
+	graph := testNeo4jGraph()
+	reversed := *graph
+	reversed.Nodes = make([]GraphNode, len(graph.Nodes))
+	for i, node := range graph.Nodes {
+		reversed.Nodes[len(graph.Nodes)-1-i] = node
+	}
+	reversed.Edges = make([]GraphEdge, len(graph.Edges))
+	for i, edge := range graph.Edges {
+		reversed.Edges[len(graph.Edges)-1-i] = edge
+	}
+	if graph.ToMermaid() != reversed.ToMermaid() {
+		t.Errorf("Expected node and edge order not to change the diagram")
+	}
+}
+
+func TestMermaidLabel(t *testing.T) {
+	tests := []struct {
+		input, expected string
+	}{
+		{"Right to erasure", "Right to erasure"},
+		{`Right to "erasure"`, "Right to #quot;erasure#quot;"},
+		{"a <b> #1", "a #lt;b#gt; #35;1"},
+		{"multi\nline   text", "multi line text"},
+		{strings.Repeat("x", 50), strings.Repeat("x", 37) + "..."},
+	}
+	for _, tt := range tests {
+		if got := MermaidLabel(tt.input); got != tt.expected {
+			t.Errorf("MermaidLabel(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package store
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Neo4jResourceLabel is the label every exported node carries, alongside its
+// type label (Article, DefinedTerm, Right, ...). Nodes are keyed by their
+// URI in the "uri" property.
+const Neo4jResourceLabel = "Resource"
+
+// ToCypher exports the graph as a Cypher script for Neo4j: a uniqueness
+// constraint on node URIs, one MERGE per node with its type label and
+// properties, then one MERGE per relationship, typed after the predicate
+// (reg:usesTerm becomes USES_TERM). Statements are idempotent, so the
+// script can be re-run against the same database.
+func (g *GraphExport) ToCypher() string {
+	var sb strings.Builder
+
+	sb.WriteString("// Regulation graph for Neo4j. Load with: cypher-shell -f <file>\n")
+	sb.WriteString(fmt.Sprintf("CREATE CONSTRAINT regula_resource_uri IF NOT EXISTS FOR (n:%s) REQUIRE n.uri IS UNIQUE;\n\n",
+		Neo4jResourceLabel))
+
+	for _, node := range g.sortedNodes() {
+		sb.WriteString(fmt.Sprintf("MERGE (n:%s {uri: %s})", Neo4jResourceLabel, cypherString(node.ID)))
+		if label := neo4jLabel(node.Type); label != "" {
+			sb.WriteString(" SET n:" + label)
+			sb.WriteString(", n += ")
+		} else {
+			sb.WriteString(" SET n += ")
+		}
+
+		properties := []string{"label: " + cypherString(node.Label)}
+		for _, key := range sortedKeys(node.Metadata) {
+			if key == "label" || key == "uri" {
+				continue
+			}
+			properties = append(properties, cypherIdentifier(key)+": "+cypherString(node.Metadata[key]))
+		}
+		sb.WriteString("{" + strings.Join(properties, ", ") + "};\n")
+	}
+
+	if len(g.Edges) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, edge := range g.sortedEdges() {
+		sb.WriteString(fmt.Sprintf("MATCH (a:%s {uri: %s}), (b:%s {uri: %s}) MERGE (a)-[:%s]->(b);\n",
+			Neo4jResourceLabel, cypherString(edge.Source),
+			Neo4jResourceLabel, cypherString(edge.Target),
+			neo4jRelationshipType(edge.Label)))
+	}
+
+	return sb.String()
+}
+
+// ToNeo4jCSV exports the graph as node and relationship CSV files in the
+// header format of "neo4j-admin database import": nodes keyed by URI with
+// ";"-separated labels and one column per property, and relationships
+// typed as in ToCypher.
+func (g *GraphExport) ToNeo4jCSV() (nodesCSV string, relationshipsCSV string, err error) {
+	nodes := g.sortedNodes()
+
+	propertySet := make(map[string]bool)
+	for _, node := range nodes {
+		for key := range node.Metadata {
+			if key != "label" && key != "uri" {
+				propertySet[key] = true
+			}
+		}
+	}
+	properties := sortedKeys(propertySet)
+
+	var nodeBuffer bytes.Buffer
+	nodeWriter := csv.NewWriter(&nodeBuffer)
+	header := append([]string{"uri:ID", ":LABEL", "label"}, properties...)
+	if err := nodeWriter.Write(header); err != nil {
+		return "", "", fmt.Errorf("failed to write node header: %w", err)
+	}
+	for _, node := range nodes {
+		labels := Neo4jResourceLabel
+		if label := neo4jLabel(node.Type); label != "" {
+			labels += ";" + label
+		}
+		record := []string{node.ID, labels, node.Label}
+		for _, key := range properties {
+			record = append(record, node.Metadata[key])
+		}
+		if err := nodeWriter.Write(record); err != nil {
+			return "", "", fmt.Errorf("failed to write node %s: %w", node.ID, err)
+		}
+	}
+	nodeWriter.Flush()
+	if err := nodeWriter.Error(); err != nil {
+		return "", "", fmt.Errorf("failed to write nodes: %w", err)
+	}
+
+	var relationshipBuffer bytes.Buffer
+	relationshipWriter := csv.NewWriter(&relationshipBuffer)
+	if err := relationshipWriter.Write([]string{":START_ID", ":END_ID", ":TYPE"}); err != nil {
+		return "", "", fmt.Errorf("failed to write relationship header: %w", err)
+	}
+	for _, edge := range g.sortedEdges() {
+		if err := relationshipWriter.Write([]string{edge.Source, edge.Target, neo4jRelationshipType(edge.Label)}); err != nil {
+			return "", "", fmt.Errorf("failed to write relationship: %w", err)
+		}
+	}
+	relationshipWriter.Flush()
+	if err := relationshipWriter.Error(); err != nil {
+		return "", "", fmt.Errorf("failed to write relationships: %w", err)
+	}
+
+	return nodeBuffer.String(), relationshipBuffer.String(), nil
+}
+
+// sortedNodes returns the nodes ordered by ID, so exports are stable.
+func (g *GraphExport) sortedNodes() []GraphNode {
+	nodes := make([]GraphNode, len(g.Nodes))
+	copy(nodes, g.Nodes)
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes
+}
+
+// sortedEdges returns the edges ordered by source, type, and target.
+func (g *GraphExport) sortedEdges() []GraphEdge {
+	edges := make([]GraphEdge, len(g.Edges))
+	copy(edges, g.Edges)
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Source != edges[j].Source {
+			return edges[i].Source < edges[j].Source
+		}
+		if edges[i].Type != edges[j].Type {
+			return edges[i].Type < edges[j].Type
+		}
+		return edges[i].Target < edges[j].Target
+	})
+	return edges
+}
+
+// neo4jLabel converts a node type to a label, or "" for untyped nodes.
+func neo4jLabel(nodeType string) string {
+	var builder strings.Builder
+	for _, r := range nodeType {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+		}
+	}
+	label := builder.String()
+	if label == "" || label == "Node" || label == Neo4jResourceLabel || unicode.IsDigit(rune(label[0])) {
+		return ""
+	}
+	return label
+}
+
+// neo4jRelationshipType converts a predicate label to the upper snake case
+// Neo4j uses for relationship types: "usesTerm" becomes "USES_TERM" and
+// "is_part_of" becomes "IS_PART_OF".
+func neo4jRelationshipType(predicateLabel string) string {
+	var builder strings.Builder
+	var previous rune
+	for _, r := range predicateLabel {
+		switch {
+		case unicode.IsUpper(r):
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) {
+				builder.WriteRune('_')
+			}
+			builder.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			builder.WriteRune(unicode.ToUpper(r))
+		default:
+			if previous != '_' && builder.Len() > 0 {
+				builder.WriteRune('_')
+			}
+			r = '_'
+		}
+		previous = r
+	}
+	relationshipType := strings.Trim(builder.String(), "_")
+	if relationshipType == "" {
+		return "RELATED"
+	}
+	return relationshipType
+}
+
+// cypherString quotes value as a Cypher string literal.
+func cypherString(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// cypherIdentifier returns key as a Cypher property name, backtick-quoted
+// unless it is a plain identifier.
+func cypherIdentifier(key string) string {
+	for i, r := range key {
+		if !(r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return "`" + strings.ReplaceAll(key, "`", "``") + "`"
+		}
+	}
+	return key
+}
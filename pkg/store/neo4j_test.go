@@ -0,0 +1,105 @@
+package store
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func testNeo4jGraph() *GraphExport {
+	tripleStore := NewTripleStore()
+	tripleStore.Add("https://regula.dev/regulations/GDPR:Art17", RDFType, ClassArticle)
+	tripleStore.Add("https://regula.dev/regulations/GDPR:Art17", PropTitle, `Right to "erasure"`)
+	tripleStore.Add("https://regula.dev/regulations/GDPR:Art17", PropReferences, "https://regula.dev/regulations/GDPR:Art6")
+	tripleStore.Add("https://regula.dev/regulations/GDPR:Art17", PropUsesTerm, "https://regula.dev/regulations/GDPR:Term:personal_data")
+	tripleStore.Add("https://regula.dev/regulations/GDPR:Art6", RDFType, ClassArticle)
+	tripleStore.Add("https://regula.dev/regulations/GDPR:Term:personal_data", RDFType, ClassDefinedTerm)
+	tripleStore.Add("https://regula.dev/regulations/GDPR:Term:personal_data", PropTerm, "personal data")
+	return ExportRelationshipSubgraph(tripleStore)
+}
+
+func TestGraphExport_ToCypher(t *testing.T) {
+	cypher := testNeo4jGraph().ToCypher()
+
+	for _, expected := range []string{
+		"CREATE CONSTRAINT regula_resource_uri IF NOT EXISTS FOR (n:Resource) REQUIRE n.uri IS UNIQUE;",
+		`MERGE (n:Resource {uri: "https://regula.dev/regulations/GDPR:Art17"}) SET n:Article, n += {label: "Right to \"erasure\"", title: "Right to \"erasure\""};`,
+		`MERGE (n:Resource {uri: "https://regula.dev/regulations/GDPR:Term:personal_data"}) SET n:DefinedTerm, n += {label: "personal data", term: "personal data"};`,
+		`MATCH (a:Resource {uri: "https://regula.dev/regulations/GDPR:Art17"}), (b:Resource {uri: "https://regula.dev/regulations/GDPR:Art6"}) MERGE (a)-[:REFERENCES]->(b);`,
+		`MERGE (a)-[:USES_TERM]->(b);`,
+	} {
+		if !strings.Contains(cypher, expected) {
+			t.Errorf("Expected Cypher to contain %q, got:\n%s", expected, cypher)
+		}
+	}
+
+	if strings.Index(cypher, "MERGE (n:") > strings.Index(cypher, "MATCH (a:") {
+		t.Error("Expected nodes to be created before relationships")
+	}
+	if testNeo4jGraph().ToCypher() != cypher {
+		t.Error("Cypher output differs between runs")
+	}
+}
+
+func TestGraphExport_ToNeo4jCSV(t *testing.T) {
+	nodesCSV, relationshipsCSV, err := testNeo4jGraph().ToNeo4jCSV()
+	if err != nil {
+		t.Fatalf("ToNeo4jCSV failed: %v", err)
+	}
+
+	nodes, err := csv.NewReader(strings.NewReader(nodesCSV)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid nodes CSV: %v", err)
+	}
+	if got := strings.Join(nodes[0], ","); got != "uri:ID,:LABEL,label,term,title" {
+		t.Errorf("node header: got %q", got)
+	}
+	if len(nodes) != 4 {
+		t.Fatalf("expected 3 nodes and a header, got %d rows", len(nodes))
+	}
+	if nodes[1][0] != "https://regula.dev/regulations/GDPR:Art17" || nodes[1][1] != "Resource;Article" || nodes[1][4] != `Right to "erasure"` {
+		t.Errorf("first node row: %q", nodes[1])
+	}
+
+	relationships, err := csv.NewReader(strings.NewReader(relationshipsCSV)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid relationships CSV: %v", err)
+	}
+	if got := strings.Join(relationships[0], ","); got != ":START_ID,:END_ID,:TYPE" {
+		t.Errorf("relationship header: got %q", got)
+	}
+	if len(relationships) != 3 || relationships[1][2] != "REFERENCES" || relationships[2][2] != "USES_TERM" {
+		t.Errorf("relationship rows: %q", relationships)
+	}
+}
+
+func TestNeo4jRelationshipType(t *testing.T) {
+	cases := map[string]string{
+		"usesTerm":          "USES_TERM",
+		"references":        "REFERENCES",
+		"imposesObligation": "IMPOSES_OBLIGATION",
+		"is_part_of":        "IS_PART_OF",
+		"hasPart2":          "HAS_PART2",
+		"":                  "RELATED",
+	}
+	for predicateLabel, expected := range cases {
+		if got := neo4jRelationshipType(predicateLabel); got != expected {
+			t.Errorf("neo4jRelationshipType(%q) = %q, want %q", predicateLabel, got, expected)
+		}
+	}
+}
+
+func TestNeo4jLabel(t *testing.T) {
+	cases := map[string]string{
+		"Article":     "Article",
+		"DefinedTerm": "DefinedTerm",
+		"Node":        "",
+		"eli:Act":     "eliAct",
+		"":            "",
+	}
+	for nodeType, expected := range cases {
+		if got := neo4jLabel(nodeType); got != expected {
+			t.Errorf("neo4jLabel(%q) = %q, want %q", nodeType, got, expected)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetJurisdiction records jurisdiction on every node that does not already
+// carry one, for the network formats' jurisdiction attribute.
+func (g *GraphExport) SetJurisdiction(jurisdiction string) {
+	if jurisdiction == "" {
+		return
+	}
+	for i := range g.Nodes {
+		if g.Nodes[i].Metadata == nil {
+			g.Nodes[i].Metadata = make(map[string]string)
+		}
+		if g.Nodes[i].Metadata["jurisdiction"] == "" {
+			g.Nodes[i].Metadata["jurisdiction"] = jurisdiction
+		}
+	}
+}
+
+// networkNodeAttributes are the node attributes written by ToGraphML and
+// ToGEXF, and networkEdgeAttributes the edge attributes. Edges carry the
+// relationship label ("references", "contains") and the full predicate.
+var (
+	networkNodeAttributes = []string{"type", "title", "jurisdiction"}
+	networkEdgeAttributes = []string{"relationship", "predicate"}
+)
+
+// networkNodeAttribute returns the value of a node attribute.
+func networkNodeAttribute(node GraphNode, attribute string) string {
+	if attribute == "type" {
+		return node.Type
+	}
+	return node.Metadata[attribute]
+}
+
+// networkEdgeAttribute returns the value of an edge attribute.
+func networkEdgeAttribute(edge GraphEdge, attribute string) string {
+	if attribute == "relationship" {
+		return edge.Label
+	}
+	return edge.Type
+}
+
+// ToGraphML exports the graph as GraphML for Cytoscape, Gephi, and other
+// network analysis tools. Nodes are identified by URI.
+func (g *GraphExport) ToGraphML() string {
+	var sb strings.Builder
+
+	sb.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	sb.WriteString("<graphml xmlns=\"http://graphml.graphdrawing.org/xmlns\"\n")
+	sb.WriteString("    xmlns:xsi=\"http://www.w3.org/2001/XMLSchema-instance\"\n")
+	sb.WriteString("    xsi:schemaLocation=\"http://graphml.graphdrawing.org/xmlns http://graphml.graphdrawing.org/xmlns/1.0/graphml.xsd\">\n")
+
+	sb.WriteString("  <key id=\"label\" for=\"node\" attr.name=\"label\" attr.type=\"string\"/>\n")
+	for _, attribute := range networkNodeAttributes {
+		sb.WriteString(fmt.Sprintf("  <key id=\"%s\" for=\"node\" attr.name=\"%s\" attr.type=\"string\"/>\n", attribute, attribute))
+	}
+	for _, attribute := range networkEdgeAttributes {
+		sb.WriteString(fmt.Sprintf("  <key id=\"%s\" for=\"edge\" attr.name=\"%s\" attr.type=\"string\"/>\n", attribute, attribute))
+	}
+
+	sb.WriteString("  <graph id=\"regula\" edgedefault=\"directed\">\n")
+	for _, node := range g.sortedNodes() {
+		sb.WriteString(fmt.Sprintf("    <node id=\"%s\">\n", escapeXMLAttribute(node.ID)))
+		sb.WriteString(fmt.Sprintf("      <data key=\"label\">%s</data>\n", escapeXMLText(node.Label)))
+		for _, attribute := range networkNodeAttributes {
+			if value := networkNodeAttribute(node, attribute); value != "" {
+				sb.WriteString(fmt.Sprintf("      <data key=\"%s\">%s</data>\n", attribute, escapeXMLText(value)))
+			}
+		}
+		sb.WriteString("    </node>\n")
+	}
+	for i, edge := range g.sortedEdges() {
+		sb.WriteString(fmt.Sprintf("    <edge id=\"e%d\" source=\"%s\" target=\"%s\">\n",
+			i, escapeXMLAttribute(edge.Source), escapeXMLAttribute(edge.Target)))
+		for _, attribute := range networkEdgeAttributes {
+			sb.WriteString(fmt.Sprintf("      <data key=\"%s\">%s</data>\n", attribute, escapeXMLText(networkEdgeAttribute(edge, attribute))))
+		}
+		sb.WriteString("    </edge>\n")
+	}
+	sb.WriteString("  </graph>\n")
+	sb.WriteString("</graphml>\n")
+
+	return sb.String()
+}
+
+// ToGEXF exports the graph as GEXF 1.3, Gephi's native format. Nodes are
+// identified by URI, and edges are labeled with their relationship.
+func (g *GraphExport) ToGEXF() string {
+	var sb strings.Builder
+
+	sb.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	sb.WriteString("<gexf xmlns=\"http://gexf.net/1.3\" version=\"1.3\">\n")
+	sb.WriteString("  <meta>\n")
+	sb.WriteString("    <creator>regula</creator>\n")
+	sb.WriteString("    <description>Regulation relationship graph</description>\n")
+	sb.WriteString("  </meta>\n")
+	sb.WriteString("  <graph defaultedgetype=\"directed\" mode=\"static\">\n")
+
+	sb.WriteString("    <attributes class=\"node\">\n")
+	for _, attribute := range networkNodeAttributes {
+		sb.WriteString(fmt.Sprintf("      <attribute id=\"%s\" title=\"%s\" type=\"string\"/>\n", attribute, attribute))
+	}
+	sb.WriteString("    </attributes>\n")
+	sb.WriteString("    <attributes class=\"edge\">\n")
+	for _, attribute := range networkEdgeAttributes {
+		sb.WriteString(fmt.Sprintf("      <attribute id=\"%s\" title=\"%s\" type=\"string\"/>\n", attribute, attribute))
+	}
+	sb.WriteString("    </attributes>\n")
+
+	sb.WriteString("    <nodes>\n")
+	for _, node := range g.sortedNodes() {
+		sb.WriteString(fmt.Sprintf("      <node id=\"%s\" label=\"%s\">\n", escapeXMLAttribute(node.ID), escapeXMLAttribute(node.Label)))
+		sb.WriteString("        <attvalues>\n")
+		for _, attribute := range networkNodeAttributes {
+			if value := networkNodeAttribute(node, attribute); value != "" {
+				sb.WriteString(fmt.Sprintf("          <attvalue for=\"%s\" value=\"%s\"/>\n", attribute, escapeXMLAttribute(value)))
+			}
+		}
+		sb.WriteString("        </attvalues>\n")
+		sb.WriteString("      </node>\n")
+	}
+	sb.WriteString("    </nodes>\n")
+
+	sb.WriteString("    <edges>\n")
+	for i, edge := range g.sortedEdges() {
+		sb.WriteString(fmt.Sprintf("      <edge id=\"%d\" source=\"%s\" target=\"%s\" label=\"%s\">\n",
+			i, escapeXMLAttribute(edge.Source), escapeXMLAttribute(edge.Target), escapeXMLAttribute(edge.Label)))
+		sb.WriteString("        <attvalues>\n")
+		for _, attribute := range networkEdgeAttributes {
+			sb.WriteString(fmt.Sprintf("          <attvalue for=\"%s\" value=\"%s\"/>\n", attribute, escapeXMLAttribute(networkEdgeAttribute(edge, attribute))))
+		}
+		sb.WriteString("        </attvalues>\n")
+		sb.WriteString("      </edge>\n")
+	}
+	sb.WriteString("    </edges>\n")
+
+	sb.WriteString("  </graph>\n")
+	sb.WriteString("</gexf>\n")
+
+	return sb.String()
+}
@@ -0,0 +1,120 @@
+package store
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestGraphExport_SetJurisdiction(t *testing.T) {
+	export := testNeo4jGraph()
+	export.Nodes[0].Metadata["jurisdiction"] = "UK"
+	export.SetJurisdiction("EU")
+
+	for i, node := range export.Nodes {
+		expected := "EU"
+		if i == 0 {
+			expected = "UK"
+		}
+		if got := node.Metadata["jurisdiction"]; got != expected {
+			t.Errorf("node %s jurisdiction: got %q, want %q", node.ID, got, expected)
+		}
+	}
+}
+
+func TestGraphExport_ToGraphML(t *testing.T) {
+	export := testNeo4jGraph()
+	export.SetJurisdiction("EU")
+	output := export.ToGraphML()
+
+	var document struct {
+		Keys  []struct{ ID, For string } `xml:"key"`
+		Graph struct {
+			EdgeDefault string `xml:"edgedefault,attr"`
+			Nodes       []struct {
+				ID   string `xml:"id,attr"`
+				Data []struct {
+					Key   string `xml:"key,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"data"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal([]byte(output), &document); err != nil {
+		t.Fatalf("GraphML is not valid XML: %v\n%s", err, output)
+	}
+
+	if len(document.Keys) != 6 {
+		t.Errorf("expected 6 attribute keys, got %d", len(document.Keys))
+	}
+	if document.Graph.EdgeDefault != "directed" {
+		t.Errorf("edgedefault: got %q", document.Graph.EdgeDefault)
+	}
+	if len(document.Graph.Nodes) != 3 || len(document.Graph.Edges) != 2 {
+		t.Fatalf("expected 3 nodes and 2 edges, got %d and %d", len(document.Graph.Nodes), len(document.Graph.Edges))
+	}
+
+	article := document.Graph.Nodes[0]
+	values := make(map[string]string)
+	for _, data := range article.Data {
+		values[data.Key] = data.Value
+	}
+	if article.ID != "https://regula.dev/regulations/GDPR:Art17" || values["type"] != "Article" ||
+		values["title"] != `Right to "erasure"` || values["jurisdiction"] != "EU" {
+		t.Errorf("article node: id %q, data %v", article.ID, values)
+	}
+	if !strings.Contains(output, `<data key="relationship">usesTerm</data>`) {
+		t.Errorf("expected a usesTerm edge, got:\n%s", output)
+	}
+}
+
+func TestGraphExport_ToGEXF(t *testing.T) {
+	export := testNeo4jGraph()
+	export.SetJurisdiction("EU")
+	output := export.ToGEXF()
+
+	var document struct {
+		Version string `xml:"version,attr"`
+		Graph   struct {
+			Nodes []struct {
+				ID        string `xml:"id,attr"`
+				Label     string `xml:"label,attr"`
+				AttValues []struct {
+					For   string `xml:"for,attr"`
+					Value string `xml:"value,attr"`
+				} `xml:"attvalues>attvalue"`
+			} `xml:"nodes>node"`
+			Edges []struct {
+				Label string `xml:"label,attr"`
+			} `xml:"edges>edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal([]byte(output), &document); err != nil {
+		t.Fatalf("GEXF is not valid XML: %v\n%s", err, output)
+	}
+
+	if document.Version != "1.3" {
+		t.Errorf("version: got %q", document.Version)
+	}
+	if len(document.Graph.Nodes) != 3 || len(document.Graph.Edges) != 2 {
+		t.Fatalf("expected 3 nodes and 2 edges, got %d and %d", len(document.Graph.Nodes), len(document.Graph.Edges))
+	}
+	if label := document.Graph.Nodes[0].Label; label != `Right to "erasure"` {
+		t.Errorf("node label: got %q", label)
+	}
+	if document.Graph.Edges[0].Label != "references" || document.Graph.Edges[1].Label != "usesTerm" {
+		t.Errorf("edge labels: %+v", document.Graph.Edges)
+	}
+
+	attributes := make(map[string]string)
+	for _, value := range document.Graph.Nodes[2].AttValues {
+		attributes[value.For] = value.Value
+	}
+	if attributes["type"] != "DefinedTerm" || attributes["jurisdiction"] != "EU" {
+		t.Errorf("term node attributes: %v", attributes)
+	}
+}
@@ -0,0 +1,284 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// plantUMLLabelLength is the longest element label written to a PlantUML
+// diagram; longer labels are truncated so diagrams stay readable.
+const plantUMLLabelLength = 60
+
+// defaultPlantUMLMaxReferences is the number of reference edges drawn when
+// no limit is configured.
+const defaultPlantUMLMaxReferences = 40
+
+// plantUMLStructureTypes are the classes drawn in the hierarchy, with the
+// word used to label them.
+var plantUMLStructureTypes = map[string]string{
+	ClassChapter: "Chapter",
+	ClassSection: "Section",
+	ClassArticle: "Article",
+}
+
+// PlantUMLSerializer renders the chapter/section/article hierarchy of a
+// TripleStore as a PlantUML component diagram. Chapters and sections are
+// drawn as packages containing their articles, and the most-cited
+// references between provisions are drawn as dotted arrows.
+type PlantUMLSerializer struct {
+	includeReferences bool
+	maxReferences     int
+}
+
+// PlantUMLOption is a functional option for configuring the PlantUMLSerializer.
+type PlantUMLOption func(*PlantUMLSerializer)
+
+// NewPlantUMLSerializer creates a PlantUMLSerializer that draws up to 40
+// reference edges.
+func NewPlantUMLSerializer(options ...PlantUMLOption) *PlantUMLSerializer {
+	serializer := &PlantUMLSerializer{
+		includeReferences: true,
+		maxReferences:     defaultPlantUMLMaxReferences,
+	}
+
+	for _, option := range options {
+		option(serializer)
+	}
+
+	return serializer
+}
+
+// WithoutPlantUMLReferences draws the hierarchy only.
+func WithoutPlantUMLReferences() PlantUMLOption {
+	return func(serializer *PlantUMLSerializer) {
+		serializer.includeReferences = false
+	}
+}
+
+// WithPlantUMLMaxReferences limits the diagram to the n reference edges
+// whose targets are cited most often. Zero or less draws every edge.
+func WithPlantUMLMaxReferences(n int) PlantUMLOption {
+	return func(serializer *PlantUMLSerializer) {
+		serializer.maxReferences = n
+	}
+}
+
+// plantUMLElement is a node of the hierarchy being drawn.
+type plantUMLElement struct {
+	uri      string
+	order    int
+	label    string
+	isLeaf   bool
+	children []*plantUMLElement
+}
+
+// Serialize renders the hierarchy in the store as a PlantUML document.
+func (serializer *PlantUMLSerializer) Serialize(store *TripleStore) string {
+	elements := make(map[string]*plantUMLElement)
+	for class, word := range plantUMLStructureTypes {
+		for _, triple := range store.Find("", RDFType, class) {
+			elements[triple.Subject] = &plantUMLElement{
+				uri:    triple.Subject,
+				label:  plantUMLElementLabel(store, triple.Subject, word),
+				isLeaf: class == ClassArticle,
+			}
+		}
+	}
+
+	// Top-level elements are grouped by the document that contains them.
+	documents := make(map[string][]*plantUMLElement)
+	for uri, element := range elements {
+		parent := store.GetOne(uri, PropPartOf)
+		if parentElement, ok := elements[parent]; ok {
+			parentElement.children = append(parentElement.children, element)
+		} else {
+			documents[parent] = append(documents[parent], element)
+		}
+	}
+
+	documentURIs := make([]string, 0, len(documents))
+	for uri := range documents {
+		documentURIs = append(documentURIs, uri)
+	}
+	sort.Strings(documentURIs)
+
+	var builder strings.Builder
+	builder.WriteString("@startuml\n")
+	builder.WriteString("skinparam packageStyle folder\n")
+	builder.WriteString("skinparam shadowing false\n")
+
+	aliasCount := 0
+	var writeElement func(element *plantUMLElement, indent string)
+	writeElement = func(element *plantUMLElement, indent string) {
+		aliasCount++
+		element.order = aliasCount
+		if element.isLeaf {
+			builder.WriteString(fmt.Sprintf("%srectangle \"%s\" as E%d\n", indent, element.label, element.order))
+			return
+		}
+		builder.WriteString(fmt.Sprintf("%spackage \"%s\" as E%d {\n", indent, element.label, element.order))
+		for _, child := range sortPlantUMLElements(store, element.children) {
+			writeElement(child, indent+"  ")
+		}
+		builder.WriteString(indent + "}\n")
+	}
+
+	for _, documentURI := range documentURIs {
+		indent := ""
+		if documentURI != "" {
+			aliasCount++
+			builder.WriteString(fmt.Sprintf("package \"%s\" as E%d {\n", plantUMLDocumentLabel(store, documentURI), aliasCount))
+			indent = "  "
+		}
+		for _, element := range sortPlantUMLElements(store, documents[documentURI]) {
+			writeElement(element, indent)
+		}
+		if documentURI != "" {
+			builder.WriteString("}\n")
+		}
+	}
+
+	if serializer.includeReferences {
+		for _, edge := range serializer.keyReferences(store, elements) {
+			builder.WriteString(fmt.Sprintf("E%d ..> E%d : references\n", elements[edge[0]].order, elements[edge[1]].order))
+		}
+	}
+
+	builder.WriteString("@enduml\n")
+	return builder.String()
+}
+
+// keyReferences returns the reference edges between drawn elements, lifted
+// from paragraphs and points to their enclosing article, keeping the edges
+// whose targets are cited most often. Edges are ordered by source and
+// target so the output is stable.
+func (serializer *PlantUMLSerializer) keyReferences(store *TripleStore, elements map[string]*plantUMLElement) [][2]string {
+	enclosing := func(uri string) string {
+		for depth := 0; uri != "" && depth < 8; depth++ {
+			if _, ok := elements[uri]; ok {
+				return uri
+			}
+			uri = store.GetOne(uri, PropPartOf)
+		}
+		return ""
+	}
+
+	seen := make(map[[2]string]bool)
+	citations := make(map[string]int)
+	var edges [][2]string
+	for _, triple := range store.Find("", PropReferences, "") {
+		source, target := enclosing(triple.Subject), enclosing(triple.Object)
+		if source == "" || target == "" || source == target {
+			continue
+		}
+		edge := [2]string{source, target}
+		if seen[edge] {
+			continue
+		}
+		seen[edge] = true
+		citations[target]++
+		edges = append(edges, edge)
+	}
+
+	if serializer.maxReferences > 0 && len(edges) > serializer.maxReferences {
+		sort.Slice(edges, func(i, j int) bool {
+			if citations[edges[i][1]] != citations[edges[j][1]] {
+				return citations[edges[i][1]] > citations[edges[j][1]]
+			}
+			return lessPlantUMLEdge(elements, edges[i], edges[j])
+		})
+		edges = edges[:serializer.maxReferences]
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		return lessPlantUMLEdge(elements, edges[i], edges[j])
+	})
+	return edges
+}
+
+// lessPlantUMLEdge orders edges by the position of their source and target
+// in the diagram.
+func lessPlantUMLEdge(elements map[string]*plantUMLElement, a, b [2]string) bool {
+	for k := range a {
+		if orderA, orderB := elements[a[k]].order, elements[b[k]].order; orderA != orderB {
+			return orderA < orderB
+		}
+	}
+	return false
+}
+
+// sortPlantUMLElements orders siblings by their number, reading arabic and
+// roman numerals by value, then by URI.
+func sortPlantUMLElements(store *TripleStore, elements []*plantUMLElement) []*plantUMLElement {
+	sort.Slice(elements, func(i, j int) bool {
+		numberI := structureNumberValue(store.GetOne(elements[i].uri, PropNumber))
+		numberJ := structureNumberValue(store.GetOne(elements[j].uri, PropNumber))
+		if numberI != numberJ {
+			return numberI < numberJ
+		}
+		return elements[i].uri < elements[j].uri
+	})
+	return elements
+}
+
+// structureNumberValue returns the value of a chapter, section, or article
+// number written in arabic or roman numerals, reading an inserted article
+// such as "5a" as 5, or zero when it is neither.
+func structureNumberValue(number string) int {
+	digits := strings.TrimRightFunc(number, unicode.IsLetter)
+	if value, err := strconv.Atoi(digits); err == nil {
+		return value
+	}
+	romanValues := map[rune]int{'I': 1, 'V': 5, 'X': 10, 'L': 50, 'C': 100, 'D': 500, 'M': 1000}
+	total, previous := 0, 0
+	runes := []rune(strings.ToUpper(number))
+	for i := len(runes) - 1; i >= 0; i-- {
+		value, ok := romanValues[runes[i]]
+		if !ok {
+			return 0
+		}
+		if value < previous {
+			total -= value
+		} else {
+			total += value
+			previous = value
+		}
+	}
+	return total
+}
+
+// plantUMLElementLabel labels a chapter, section, or article with its type
+// and number, followed by its title when it has one.
+func plantUMLElementLabel(store *TripleStore, uri, word string) string {
+	label := word
+	if number := store.GetOne(uri, PropNumber); number != "" {
+		label += " " + number
+	} else {
+		label = extractLabel(uri)
+	}
+	if title := store.GetOne(uri, PropTitle); title != "" {
+		label += ": " + title
+	}
+	return plantUMLLabel(label)
+}
+
+// plantUMLDocumentLabel labels the document containing the hierarchy.
+func plantUMLDocumentLabel(store *TripleStore, uri string) string {
+	if title := store.GetOne(uri, PropTitle); title != "" {
+		return plantUMLLabel(title)
+	}
+	return plantUMLLabel(extractLabel(uri))
+}
+
+// plantUMLLabel returns text as the content of a quoted PlantUML name:
+// truncated, on one line, and without double quotes, which PlantUML
+// cannot escape.
+func plantUMLLabel(text string) string {
+	text = textutil.Truncate(strings.Join(strings.Fields(text), " "), plantUMLLabelLength)
+	return strings.ReplaceAll(text, `"`, "'")
+}
@@ -0,0 +1,102 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+// testPlantUMLStore builds a regulation with a chapter holding a section
+// and an article, and references from a paragraph and an article.
+func testPlantUMLStore() *TripleStore {
+	ts := NewTripleStore()
+	reg := "https://regula.dev/regulations/TEST"
+	ts.Add(reg, RDFType, ClassRegulation)
+	ts.Add(reg, PropTitle, `The "Test" Regulation`)
+	for _, chapter := range []struct{ uri, number, title string }{
+		{reg + ":ChapterX", "X", "Final provisions"},
+		{reg + ":ChapterII", "II", "Principles"},
+	} {
+		ts.Add(chapter.uri, RDFType, ClassChapter)
+		ts.Add(chapter.uri, PropNumber, chapter.number)
+		ts.Add(chapter.uri, PropTitle, chapter.title)
+		ts.Add(chapter.uri, PropPartOf, reg)
+	}
+	section := reg + ":ChapterII:Section1"
+	ts.Add(section, RDFType, ClassSection)
+	ts.Add(section, PropNumber, "1")
+	ts.Add(section, PropPartOf, reg+":ChapterII")
+	for _, article := range []struct{ uri, number, parent string }{
+		{reg + ":Art10", "10", section},
+		{reg + ":Art9", "9", section},
+		{reg + ":Art5a", "5a", reg + ":ChapterII"},
+		{reg + ":Art99", "99", reg + ":ChapterX"},
+	} {
+		ts.Add(article.uri, RDFType, ClassArticle)
+		ts.Add(article.uri, PropNumber, article.number)
+		ts.Add(article.uri, PropPartOf, article.parent)
+	}
+	ts.Add(reg+":Art10:Para1", PropPartOf, reg+":Art10")
+	ts.Add(reg+":Art10:Para1", PropReferences, reg+":Art9")
+	ts.Add(reg+":Art10", PropReferences, reg+":Art9")
+	ts.Add(reg+":Art99", PropReferences, reg+":Art5a")
+	ts.Add(reg+":Art99", PropReferences, reg+":Art99")
+	return ts
+}
+
+func TestPlantUMLSerializer_Serialize(t *testing.T) {
+	output := NewPlantUMLSerializer().Serialize(testPlantUMLStore())
+
+	expected := `@startuml
+skinparam packageStyle folder
+skinparam shadowing false
+package "The 'Test' Regulation" as E1 {
+  package "Chapter II: Principles" as E2 {
+    package "Section 1" as E3 {
+      rectangle "Article 9" as E4
+      rectangle "Article 10" as E5
+    }
+    rectangle "Article 5a" as E6
+  }
+  package "Chapter X: Final provisions" as E7 {
+    rectangle "Article 99" as E8
+  }
+}
+E5 ..> E4 : references
+E8 ..> E6 : references
+@enduml
+`
+	if output != expected {
+		t.Errorf("Serialize() =\n%s\nwant:\n%s", output, expected)
+	}
+}
+
+func TestPlantUMLSerializer_Options(t *testing.T) {
+	output := NewPlantUMLSerializer(WithoutPlantUMLReferences()).Serialize(testPlantUMLStore())
+	if strings.Contains(output, "..>") {
+		t.Errorf("Expected no reference edges, got:\n%s", output)
+	}
+
+	output = NewPlantUMLSerializer(WithPlantUMLMaxReferences(1)).Serialize(testPlantUMLStore())
+	if got := strings.Count(output, "..>"); got != 1 {
+		t.Errorf("Expected 1 reference edge, got %d:\n%s", got, output)
+	}
+}
+
+func TestStructureNumberValue(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"17", 17},
+		{"5a", 5},
+		{"IV", 4},
+		{"XIV", 14},
+		{"", 0},
+		{"Annex", 0},
+	}
+	for _, tt := range tests {
+		if got := structureNumberValue(tt.input); got != tt.expected {
+			t.Errorf("structureNumberValue(%q) = %d, want %d", tt.input, got, tt.expected)
+		}
+	}
+}
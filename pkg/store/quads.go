@@ -0,0 +1,142 @@
+package store
+
+import (
+	"sort"
+	"strings"
+)
+
+// NamedGraph is a store whose triples are exported in a named graph, so
+// that a quad store loading them keeps track of where each triple came
+// from. Metadata is exported as triples about the graph itself, in the
+// default graph, e.g. the document ID and title of a library document.
+type NamedGraph struct {
+	// Name is the graph's IRI.
+	Name  string
+	Store *TripleStore
+
+	// Metadata maps predicates to objects describing the graph.
+	Metadata map[string]string
+}
+
+// SerializeNQuads converts named graphs to W3C N-Quads: one statement per
+// line, with full IRIs and the graph IRI as the fourth term. Graph
+// metadata is written to the default graph first. Output is sorted, so the
+// same graphs always serialize identically.
+func SerializeNQuads(graphs []NamedGraph) string {
+	var builder strings.Builder
+
+	var metadata []string
+	for _, graph := range graphs {
+		for _, predicate := range sortedKeys(graph.Metadata) {
+			metadata = append(metadata, nQuadsStatement(graph.Name, predicate, graph.Metadata[predicate], ""))
+		}
+	}
+	sort.Strings(metadata)
+	for _, line := range metadata {
+		builder.WriteString(line)
+	}
+
+	for _, graph := range graphs {
+		if graph.Store == nil {
+			continue
+		}
+		lines := make([]string, 0, graph.Store.Count())
+		for _, triple := range graph.Store.All() {
+			lines = append(lines, nQuadsStatement(triple.Subject, triple.Predicate, triple.Object, graph.Name))
+		}
+		sort.Strings(lines)
+		for _, line := range lines {
+			builder.WriteString(line)
+		}
+	}
+
+	return builder.String()
+}
+
+// nQuadsStatement formats one N-Quads line; an empty graph is the default
+// graph.
+func nQuadsStatement(subject, predicate, object, graph string) string {
+	var builder strings.Builder
+	builder.WriteString(nQuadsResource(subject))
+	builder.WriteString(" ")
+	builder.WriteString(nQuadsResource(predicate))
+	builder.WriteString(" ")
+	builder.WriteString(nQuadsObject(object))
+	if graph != "" {
+		builder.WriteString(" ")
+		builder.WriteString(nQuadsResource(graph))
+	}
+	builder.WriteString(" .\n")
+	return builder.String()
+}
+
+// nQuadsResource formats a subject, predicate, or graph as a full IRI,
+// expanding known prefixed names such as "reg:Article".
+func nQuadsResource(value string) string {
+	if strings.HasPrefix(value, "_:") {
+		return value
+	}
+	return "<" + escapeIRI(ExpandPrefixedName(value)) + ">"
+}
+
+// nQuadsObject formats an object as an IRI or a single-line literal.
+func nQuadsObject(value string) string {
+	if isFullURI(value) || strings.HasPrefix(value, "_:") {
+		return nQuadsResource(value)
+	}
+	if isPrefixedName(value) {
+		if expanded := ExpandPrefixedName(value); expanded != value {
+			return "<" + escapeIRI(expanded) + ">"
+		}
+	}
+	return `"` + escapeLiteralString(value) + `"`
+}
+
+// SerializeTriG converts named graphs to W3C TriG: the serializer's prefix
+// declarations, graph metadata in the default graph, then each graph's
+// triples in Turtle syntax inside a "<graph> { ... }" block.
+func (serializer *TurtleSerializer) SerializeTriG(graphs []NamedGraph) string {
+	var builder strings.Builder
+
+	serializer.writePrefixDeclarations(&builder)
+
+	for _, graph := range graphs {
+		if len(graph.Metadata) == 0 {
+			continue
+		}
+		predicateObjects := make(map[string][]string, len(graph.Metadata))
+		for predicate, object := range graph.Metadata {
+			predicateObjects[predicate] = []string{object}
+		}
+		serializer.writeSubjectGroup(&builder, graph.Name, predicateObjects)
+		builder.WriteString("\n")
+	}
+
+	for graphIndex, graph := range graphs {
+		if graphIndex > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(serializer.formatResource(graph.Name))
+		builder.WriteString(" {\n")
+
+		if graph.Store != nil {
+			subjectGroups := serializer.groupTriplesBySubject(graph.Store)
+			for subjectIndex, subject := range sortedKeys(subjectGroups) {
+				if subjectIndex > 0 {
+					builder.WriteString("\n")
+				}
+				var group strings.Builder
+				serializer.writeSubjectGroup(&group, subject, subjectGroups[subject])
+				for _, line := range strings.SplitAfter(group.String(), "\n") {
+					if line != "" {
+						builder.WriteString("    " + line)
+					}
+				}
+			}
+		}
+
+		builder.WriteString("}\n")
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func testNamedGraphs() []NamedGraph {
+	gdpr := NewTripleStore()
+	gdpr.Add("https://regula.dev/regulations/GDPR:Art17", RDFType, ClassArticle)
+	gdpr.Add("https://regula.dev/regulations/GDPR:Art17", PropTitle, "Right to erasure\n(\"right to be forgotten\")")
+
+	dpa := NewTripleStore()
+	dpa.Add("https://regula.dev/regulations/DPA:s1", RDFType, ClassSection)
+
+	return []NamedGraph{
+		{
+			Name:     "https://regula.dev/regulations/eu/eu-gdpr/",
+			Store:    gdpr,
+			Metadata: map[string]string{PrefixDC + "identifier": "eu-gdpr"},
+		},
+		{
+			Name:  "https://regula.dev/regulations/gb/uk-dpa2018/",
+			Store: dpa,
+		},
+	}
+}
+
+func TestSerializeNQuads(t *testing.T) {
+	output := SerializeNQuads(testNamedGraphs())
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+
+	expected := []string{
+		`<https://regula.dev/regulations/eu/eu-gdpr/> <http://purl.org/dc/terms/identifier> "eu-gdpr" .`,
+		`<https://regula.dev/regulations/GDPR:Art17> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <https://regula.dev/ontology#Article> <https://regula.dev/regulations/eu/eu-gdpr/> .`,
+		`<https://regula.dev/regulations/GDPR:Art17> <https://regula.dev/ontology#title> "Right to erasure\n(\"right to be forgotten\")" <https://regula.dev/regulations/eu/eu-gdpr/> .`,
+		`<https://regula.dev/regulations/DPA:s1> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <https://regula.dev/ontology#Section> <https://regula.dev/regulations/gb/uk-dpa2018/> .`,
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, got %d:\n%s", len(expected), len(lines), output)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("Line %d:\n got: %s\nwant: %s", i, lines[i], expected[i])
+		}
+	}
+}
+
+func TestSerializeNQuads_Deterministic(t *testing.T) {
+	first := SerializeNQuads(testNamedGraphs())
+	for i := 0; i < 5; i++ {
+		if SerializeNQuads(testNamedGraphs()) != first {
+			t.Fatal("N-Quads output differs between runs")
+		}
+	}
+}
+
+func TestSerializeNQuads_Empty(t *testing.T) {
+	if output := SerializeNQuads(nil); output != "" {
+		t.Errorf("Expected empty output, got %q", output)
+	}
+}
+
+func TestSerializeTriG(t *testing.T) {
+	output := NewTurtleSerializer().SerializeTriG(testNamedGraphs())
+
+	for _, expected := range []string{
+		"@prefix reg: <https://regula.dev/ontology#> .",
+		"<https://regula.dev/regulations/eu/eu-gdpr/> dc:identifier \"eu-gdpr\" .",
+		"<https://regula.dev/regulations/eu/eu-gdpr/> {\n    <https://regula.dev/regulations/GDPR:Art17> a reg:Article ;\n",
+		`        reg:title """Right to erasure\n(\"right to be forgotten\")""" .`,
+		"<https://regula.dev/regulations/gb/uk-dpa2018/> {\n    <https://regula.dev/regulations/DPA:s1> a reg:Section .\n}\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected TriG output to contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	if strings.Count(output, "{\n") != 2 || strings.Count(output, "\n}\n") != 2 {
+		t.Errorf("Expected two graph blocks, got:\n%s", output)
+	}
+	if metadata := strings.Index(output, "dc:identifier"); metadata > strings.Index(output, "{") {
+		t.Error("Expected graph metadata in the default graph before the graph blocks")
+	}
+}
@@ -0,0 +1,147 @@
+package store
+
+// Quality scores say how far a triple can be trusted, from 0.0 to 1.0.
+// Triples taken directly from the document structure (articles, titles,
+// chapters) carry no recorded score and count as DefaultQuality. Triples
+// that depend on a heuristic, such as a resolved cross-reference or an
+// extracted obligation, are scored by the graph builder from the
+// extractor's confidence, and validation gates can lower scores further.
+//
+// Filtering a store with FilterByQuality gives a high-precision view of the
+// graph; the unfiltered store is the high-recall view.
+
+// DefaultQuality is the quality of a triple without a recorded score.
+const DefaultQuality = 1.0
+
+// SetQuality records the quality score of a triple in the store, clamped to
+// [0, 1]. Scores for triples not in the store are ignored.
+func (ts *TripleStore) SetQuality(triple Triple, score float64) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if !ts.existsUnsafe(triple.Subject, triple.Predicate, triple.Object) {
+		return
+	}
+	ts.quality[triple] = clampQuality(score)
+}
+
+// raiseQuality records score for a triple in the store unless a higher
+// score is already recorded.
+func (ts *TripleStore) raiseQuality(triple Triple, score float64) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if !ts.existsUnsafe(triple.Subject, triple.Predicate, triple.Object) {
+		return
+	}
+	if existing, ok := ts.quality[triple]; ok && existing >= score {
+		return
+	}
+	ts.quality[triple] = clampQuality(score)
+}
+
+// Quality returns the quality score of a triple, DefaultQuality if none
+// was recorded.
+func (ts *TripleStore) Quality(triple Triple) float64 {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	if score, ok := ts.quality[triple]; ok {
+		return score
+	}
+	return DefaultQuality
+}
+
+// ScaleQuality multiplies the quality of every triple matching pattern by
+// factor and returns the number of triples matched. Use empty strings in
+// the pattern as wildcards.
+func (ts *TripleStore) ScaleQuality(pattern TriplePattern, factor float64) int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	matches := ts.findUnsafe(pattern.Subject, pattern.Predicate, pattern.Object)
+	for _, triple := range matches {
+		score, ok := ts.quality[triple]
+		if !ok {
+			score = DefaultQuality
+		}
+		ts.quality[triple] = clampQuality(score * factor)
+	}
+	return len(matches)
+}
+
+// ScaleScoredQuality multiplies every recorded score by factor, leaving
+// triples without a score at DefaultQuality. It returns the number of
+// scores changed.
+func (ts *TripleStore) ScaleScoredQuality(factor float64) int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	for triple, score := range ts.quality {
+		ts.quality[triple] = clampQuality(score * factor)
+	}
+	return len(ts.quality)
+}
+
+// CountBelowQuality returns the number of triples scored below minQuality.
+func (ts *TripleStore) CountBelowQuality(minQuality float64) int {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	count := 0
+	for _, score := range ts.quality {
+		if score < minQuality {
+			count++
+		}
+	}
+	return count
+}
+
+// FilterByQuality returns a new store holding the triples whose quality is
+// at least minQuality, with their scores.
+func (ts *TripleStore) FilterByQuality(minQuality float64) *TripleStore {
+	return ts.Filter(func(triple Triple) bool {
+		return ts.Quality(triple) >= minQuality
+	})
+}
+
+// Filter returns a new store holding the triples for which keep returns
+// true, with their quality scores.
+func (ts *TripleStore) Filter(keep func(Triple) bool) *TripleStore {
+	filtered := NewTripleStore()
+	var kept []Triple
+	for _, triple := range ts.All() {
+		if keep(triple) {
+			kept = append(kept, triple)
+		}
+	}
+	_ = filtered.BulkAdd(kept)
+	for triple, score := range ts.qualityScores() {
+		if filtered.Exists(triple.Subject, triple.Predicate, triple.Object) {
+			filtered.quality[triple] = score
+		}
+	}
+	return filtered
+}
+
+// qualityScores returns a copy of the recorded scores.
+func (ts *TripleStore) qualityScores() map[Triple]float64 {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	scores := make(map[Triple]float64, len(ts.quality))
+	for triple, score := range ts.quality {
+		scores[triple] = score
+	}
+	return scores
+}
+
+func clampQuality(score float64) float64 {
+	switch {
+	case score < 0:
+		return 0
+	case score > 1:
+		return 1
+	}
+	return score
+}
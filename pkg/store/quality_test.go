@@ -0,0 +1,81 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/extract"
+)
+
+func TestTripleStore_Quality(t *testing.T) {
+	ts := NewTripleStore()
+	scored := NewTriple("ex:Art1", PropReferences, "ex:Art2")
+	plain := NewTriple("ex:Art1", RDFType, ClassArticle)
+	ts.AddTriple(scored)
+	ts.AddTriple(plain)
+
+	ts.SetQuality(scored, 0.5)
+	ts.SetQuality(NewTriple("ex:missing", RDFType, ClassArticle), 0.1)
+	if got := ts.Quality(scored); got != 0.5 {
+		t.Errorf("Quality(scored) = %v, want 0.5", got)
+	}
+	if got := ts.Quality(plain); got != DefaultQuality {
+		t.Errorf("Quality(plain) = %v, want %v", got, DefaultQuality)
+	}
+	if got := ts.CountBelowQuality(0.75); got != 1 {
+		t.Errorf("CountBelowQuality(0.75) = %d, want 1", got)
+	}
+
+	filtered := ts.FilterByQuality(0.75)
+	if filtered.Count() != 1 || !filtered.Exists(plain.Subject, plain.Predicate, plain.Object) {
+		t.Errorf("FilterByQuality(0.75) kept %v", filtered.All())
+	}
+	if ts.FilterByQuality(0.5).Quality(scored) != 0.5 {
+		t.Error("FilterByQuality dropped the score of a kept triple")
+	}
+
+	if n := ts.ScaleQuality(NewTriplePattern("ex:Art1", "", ""), 0.5); n != 2 {
+		t.Errorf("ScaleQuality matched %d triples, want 2", n)
+	}
+	if ts.Quality(scored) != 0.25 || ts.Quality(plain) != 0.5 {
+		t.Errorf("after ScaleQuality: %v, %v", ts.Quality(scored), ts.Quality(plain))
+	}
+
+	ts.DeleteTriple(scored)
+	ts.AddTriple(scored)
+	if ts.Quality(scored) != DefaultQuality {
+		t.Error("score survived deleting the triple")
+	}
+}
+
+func TestGraphBuilder_ScoresDerivedTriples(t *testing.T) {
+	doc := loadGDPRDocument(t)
+	tripleStore := NewTripleStore()
+	builder := NewGraphBuilder(tripleStore, "https://regula.dev/regulations/")
+	if _, err := builder.BuildComplete(doc, extract.NewDefinitionExtractor(), extract.NewReferenceExtractor(),
+		extract.NewReferenceResolver("https://regula.dev/regulations/", "GDPR"), extract.NewSemanticExtractor()); err != nil {
+		t.Fatalf("BuildComplete: %v", err)
+	}
+
+	for _, triple := range tripleStore.Find("", RDFType, ClassArticle) {
+		if tripleStore.Quality(triple) != DefaultQuality {
+			t.Fatalf("structural triple %v scored %v", triple, tripleStore.Quality(triple))
+		}
+	}
+
+	// Every reference edge is scored with its best resolution confidence.
+	var below int
+	for _, triple := range tripleStore.Find("", PropReferences, "") {
+		if tripleStore.Quality(triple) < float64(extract.ConfidenceHigh) {
+			below++
+		}
+	}
+	if below == 0 {
+		t.Error("no reference edge scored below high confidence")
+	}
+
+	for _, triple := range tripleStore.Find("", PropGrantsRight, "") {
+		if tripleStore.Quality(triple) == DefaultQuality && tripleStore.GetOne(triple.Object, PropConfidence) != "1.00" {
+			t.Errorf("right link %v not scored from confidence %s", triple, tripleStore.GetOne(triple.Object, PropConfidence))
+		}
+	}
+}
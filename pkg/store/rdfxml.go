@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // RDFXMLSerializer converts a TripleStore into W3C-compliant RDF/XML format.
@@ -252,7 +254,9 @@ func isURIObject(value string) bool {
 }
 
 // escapeXMLText escapes characters that are special in XML text content.
+// Invalid UTF-8 and control characters, which XML 1.0 forbids, are sanitized.
 func escapeXMLText(text string) string {
+	text = textutil.Sanitize(text)
 	var builder strings.Builder
 	builder.Grow(len(text) + len(text)/8)
 
@@ -274,6 +278,7 @@ func escapeXMLText(text string) string {
 
 // escapeXMLAttribute escapes characters that are special in XML attribute values.
 func escapeXMLAttribute(text string) string {
+	text = textutil.Sanitize(text)
 	var builder strings.Builder
 	builder.Grow(len(text) + len(text)/8)
 
@@ -319,6 +319,9 @@ func TestEscapeXMLText(t *testing.T) {
 		{"multiple specials", "a & b < c > d", "a &amp; b &lt; c &gt; d"},
 		{"empty", "", ""},
 		{"quotes not escaped", `He said "hello"`, `He said "hello"`},
+		{"non-Latin preserved", "Règlement – Γενικός – 規則", "Règlement – Γενικός – 規則"},
+		{"control characters dropped", "page\x0cbreak\x00", "pagebreak"},
+		{"invalid UTF-8 replaced", "caf\xe9", "caf\ufffd"},
 	}
 
 	for _, testCase := range testCases {
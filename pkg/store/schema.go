@@ -23,6 +23,9 @@ const (
 
 	// NamespaceFRBR is the Functional Requirements for Bibliographic Records namespace.
 	NamespaceFRBR = "http://purl.org/vocab/frbr/core#"
+
+	// NamespaceOWL is the Web Ontology Language namespace.
+	NamespaceOWL = "http://www.w3.org/2002/07/owl#"
 )
 
 // Namespace prefixes for compact URI representation.
@@ -34,6 +37,7 @@ const (
 	PrefixDC   = "dc:"
 	PrefixELI  = "eli:"
 	PrefixFRBR = "frbr:"
+	PrefixOWL  = "owl:"
 )
 
 // ELI Classes - European Legislation Identifier types.
@@ -134,6 +138,16 @@ const (
 	// ClassSubPoint represents a sub-point within a point.
 	ClassSubPoint = "reg:SubPoint"
 
+	// ClassAnnex represents an annex (EU) or schedule (UK) to a regulation.
+	ClassAnnex = "reg:Annex"
+
+	// ClassAnnexRow represents a row of a table within an annex.
+	ClassAnnexRow = "reg:AnnexRow"
+
+	// ClassEditorialNote represents a source credit, editorial or statutory
+	// note, amendment entry, or footnote printed with a provision.
+	ClassEditorialNote = "reg:EditorialNote"
+
 	// ClassRecital represents a preamble recital.
 	ClassRecital = "reg:Recital"
 
@@ -143,6 +157,10 @@ const (
 	// ClassDefinedTerm represents a defined term from Article 4 or similar.
 	ClassDefinedTerm = "reg:DefinedTerm"
 
+	// ClassAbbreviation represents a short form a document introduces, such
+	// as "TFEU" or the ‘Board’.
+	ClassAbbreviation = "reg:Abbreviation"
+
 	// ClassReference represents a cross-reference.
 	ClassReference = "reg:Reference"
 
@@ -151,6 +169,10 @@ const (
 
 	// ClassRight represents a right granted by a provision.
 	ClassRight = "reg:Right"
+
+	// ClassReviewObligation represents a mandated review of, or report on,
+	// a regulation due by a date.
+	ClassReviewObligation = "reg:ReviewObligation"
 )
 
 // Metadata Properties - Basic descriptive predicates.
@@ -206,6 +228,31 @@ const (
 	// PropHasPoint links paragraph to its points.
 	PropHasPoint = "reg:hasPoint"
 
+	// PropHasSubPoint links point to its sub-points.
+	PropHasSubPoint = "reg:hasSubPoint"
+
+	// PropHasAnnex links regulation to its annexes and schedules.
+	PropHasAnnex = "reg:hasAnnex"
+
+	// PropHasRow links an annex to the rows of its tables.
+	PropHasRow = "reg:hasRow"
+
+	// PropAnnexKind distinguishes an "annex" from a "schedule".
+	PropAnnexKind = "reg:annexKind"
+
+	// PropCells holds the cells of an annex row, in column order, joined by " | ".
+	PropCells = "reg:cells"
+
+	// PropHasNote links a provision to its editorial notes.
+	PropHasNote = "reg:hasNote"
+
+	// PropNoteKind is the kind of an editorial note: "source_credit",
+	// "editorial", "statutory", "amendment", or "footnote".
+	PropNoteKind = "reg:noteKind"
+
+	// PropAmendmentYear is the year of an amendment note entry.
+	PropAmendmentYear = "reg:amendmentYear"
+
 	// PropHasRecital links preamble to its recitals.
 	PropHasRecital = "reg:hasRecital"
 )
@@ -234,6 +281,33 @@ const (
 
 	// PropRefersToPoint specifically references a point.
 	PropRefersToPoint = "reg:refersToPoint"
+
+	// PropExternalDocType is the kind of external document referenced (e.g., "directive").
+	PropExternalDocType = "reg:externalDocType"
+)
+
+// Related Provision Properties - Computed suggestions of connected provisions.
+const (
+	// PropRelatedTo suggests a provision related to this one by shared terms,
+	// co-citation, or text similarity, whether or not either cites the other.
+	// Example: <GDPR:Art17> reg:relatedTo <GDPR:Art21>
+	PropRelatedTo = "reg:relatedTo"
+
+	// ClassRelatedSuggestion records the evidence behind a reg:relatedTo suggestion.
+	ClassRelatedSuggestion = "reg:RelatedSuggestion"
+
+	// PropSuggestionFor links a suggestion to the provision it was computed for.
+	PropSuggestionFor = "reg:suggestionFor"
+
+	// PropSuggestedProvision links a suggestion to the related provision.
+	PropSuggestedProvision = "reg:suggestedProvision"
+
+	// PropSimilarityScore is the combined relatedness score (0.0-1.0).
+	PropSimilarityScore = "reg:similarityScore"
+
+	// PropSuggestionBasis names a signal behind a suggestion.
+	// Values: "shared-terms", "co-citation", "text-similarity"
+	PropSuggestionBasis = "reg:suggestionBasis"
 )
 
 // Definition Properties - Term definitions.
@@ -260,6 +334,43 @@ const (
 
 	// PropUsesTerm indicates a provision uses a defined term.
 	PropUsesTerm = "reg:usesTerm"
+
+	// PropDefinitionUses indicates a definition is written in another
+	// defined term.
+	// Example: <GDPR:Term:processor> reg:definitionUses <GDPR:Term:controller>
+	PropDefinitionUses = "reg:definitionUses"
+
+	// PropUsesUndefinedTerm is a term a definition quotes that no
+	// definition defines.
+	PropUsesUndefinedTerm = "reg:usesUndefinedTerm"
+
+	// PropUsesExternalDefinition links a provision or defined term to the
+	// definition of another document it incorporates, as in "personal data
+	// as defined in Article 4, point (1) of Regulation (EU) 2016/679".
+	// Example: <AIAct:Art10> reg:usesExternalDefinition <GDPR:Term:personal_data>
+	PropUsesExternalDefinition = "reg:usesExternalDefinition"
+
+	// PropShortForm is the text of an abbreviation.
+	PropShortForm = "reg:shortForm"
+
+	// PropExpansion is the name an abbreviation stands for.
+	PropExpansion = "reg:expansion"
+
+	// PropAbbreviates links an abbreviation to the defined term it stands for.
+	// Example: <CCPA:Abbr:pi> reg:abbreviates <CCPA:Term:personal_information>
+	PropAbbreviates = "reg:abbreviates"
+
+	// ClassTermUsage represents the use of a defined term within an article.
+	ClassTermUsage = "reg:TermUsage"
+
+	// PropUsesTermRef links a term usage to the defined term.
+	PropUsesTermRef = "reg:usesTermRef"
+
+	// PropInArticle links a term usage to the article it occurs in.
+	PropInArticle = "reg:inArticle"
+
+	// PropMatchCount is the number of times a term occurs in the article.
+	PropMatchCount = "reg:matchCount"
 )
 
 // Amendment Properties - Document evolution.
@@ -322,6 +433,28 @@ const (
 
 	// PropSubjectTo indicates being subject to conditions.
 	PropSubjectTo = "reg:subjectTo"
+
+	// PropRecurrence is an RRULE-style recurrence of an obligation.
+	// Example: <GDPR:Obligation:30:RecordKeepingObligation> reg:recurrence "FREQ=YEARLY"
+	PropRecurrence = "reg:recurrence"
+
+	// PropRecurrenceSource records where a recurrence came from ("text" or "annotation").
+	PropRecurrenceSource = "reg:recurrenceSource"
+
+	// PropRightType is the specific type of a right (e.g., "RightToErasure").
+	PropRightType = "reg:rightType"
+
+	// PropObligationType is the specific type of an obligation (e.g., "RecordKeepingObligation").
+	PropObligationType = "reg:obligationType"
+
+	// PropIsProhibition marks an obligation phrased as a prohibition ("shall not").
+	PropIsProhibition = "reg:isProhibition"
+
+	// PropConfidence is the extraction confidence of a right or obligation (0.00-1.00).
+	PropConfidence = "reg:confidence"
+
+	// PropContext is the text surrounding an extracted right or obligation.
+	PropContext = "reg:context"
 )
 
 // Entity Properties - Data subjects, controllers, etc.
@@ -356,6 +489,9 @@ const (
 	// PropDeadline indicates a deadline for compliance.
 	PropDeadline = "reg:deadline"
 
+	// PropDueDate is the date a review obligation is first due.
+	PropDueDate = "reg:dueDate"
+
 	// PropTimeLimit indicates a time limit (e.g., "within 1 month").
 	PropTimeLimit = "reg:timeLimit"
 
@@ -376,6 +512,14 @@ const (
 
 	// PropSupersededDate is when an entity was superseded by a newer version.
 	PropSupersededDate = "reg:supersededDate"
+
+	// PropInForceFrom is the first day a provision is in force, as computed
+	// from entry-into-force and application clauses and validity triples.
+	PropInForceFrom = "reg:inForceFrom"
+
+	// PropInForceUntil is the day a provision ceases to be in force, as
+	// computed from sunset clauses, expiry dates, and repeals.
+	PropInForceUntil = "reg:inForceUntil"
 )
 
 // Version Properties - Document and entity versioning.
@@ -456,6 +600,10 @@ const (
 
 	// PropExtractedAt is the extraction timestamp.
 	PropExtractedAt = "reg:extractedAt"
+
+	// PropExtractionMethod marks a node extracted by something other than
+	// the pattern extractors. Values: "llm"
+	PropExtractionMethod = "reg:extractionMethod"
 )
 
 // Resolution Properties - Reference resolution tracking.
@@ -521,6 +669,21 @@ const (
 	PropExternalDocURI = "reg:externalDocURI"
 )
 
+// Standard Identifier Properties - Official identifiers minted by identifier schemes.
+const (
+	// PropELI links a legal resource or subdivision to its European Legislation Identifier URI.
+	// Example: http://data.europa.eu/eli/reg/2016/679/oj
+	PropELI = "reg:eli"
+
+	// PropECLI links a provision to the European Case Law Identifier of case law it cites.
+	// Example: https://e-justice.europa.eu/ecli/ECLI:EU:C:2014:317
+	PropECLI = "reg:ecli"
+
+	// PropUSLMIdentifier is the United States Legislative Markup identifier of a US legal resource.
+	// Example: /us/usc/t15/s6501
+	PropUSLMIdentifier = "reg:uslmIdentifier"
+)
+
 // Crawl Provenance Properties - Tracking legislation discovery via crawling.
 const (
 	// ClassCrawledDocument represents a document discovered and ingested by the crawler.
@@ -589,6 +752,45 @@ const (
 	ClassDeliberationProcess = "reg:DeliberationProcess"
 )
 
+// Text Evolution - How provision text changes across meetings.
+const (
+	// ClassProposedText represents a provision text as first proposed.
+	ClassProposedText = "reg:ProposedText"
+
+	// ClassAmendedText represents a provision text after an amendment.
+	ClassAmendedText = "reg:AmendedText"
+
+	// ClassAdoptedText represents a provision text as adopted.
+	ClassAdoptedText = "reg:AdoptedText"
+
+	// PropEventType is the kind of text evolution event (proposed, amended, adopted, rejected).
+	PropEventType = "reg:eventType"
+
+	// PropEventDate is when a text evolution event was recorded.
+	PropEventDate = "reg:eventDate"
+
+	// PropProposedAt links a text version to the meeting where it was proposed.
+	PropProposedAt = "reg:proposedAt"
+
+	// PropAmendedAt links a text version to the meeting where it was amended.
+	PropAmendedAt = "reg:amendedAt"
+
+	// PropAdoptedAt links a text version to the meeting where it was adopted.
+	PropAdoptedAt = "reg:adoptedAt"
+
+	// PropRejectedAt links a text version to the meeting where it was rejected.
+	PropRejectedAt = "reg:rejectedAt"
+
+	// PropHasVote links a text version to the vote that decided it.
+	PropHasVote = "reg:hasVote"
+
+	// PropDiscusses links an agenda item to a provision it discusses.
+	PropDiscusses = "reg:discusses"
+
+	// PropRoleName is the name of a stakeholder role (e.g., "Chair").
+	PropRoleName = "reg:roleName"
+)
+
 // Deliberation Meeting Properties - Temporal anchors and meeting structure.
 const (
 	// PropMeetingDate is the date of a meeting.
@@ -829,6 +1031,19 @@ const (
 	PropSourceClause = "reg:sourceClause"
 )
 
+// Library Metadata Properties - Document metadata curated in a library.
+const (
+	// PropJurisdiction is the jurisdiction code of a document (e.g., "EU", "US-CA").
+	PropJurisdiction = "reg:jurisdiction"
+
+	// PropTag is a library tag of a document; a document may have several.
+	// Example: <GDPR> reg:tag "privacy"
+	PropTag = "reg:tag"
+
+	// PropLegalStatus is whether a document is in force, repealed, and so on.
+	PropLegalStatus = "reg:legalStatus"
+)
+
 // URIBuilder helps construct URIs for regulatory entities.
 type URIBuilder struct {
 	BaseURI string
@@ -40,6 +40,9 @@ type TripleStore struct {
 	predicateCounts map[string]int
 	subjectCounts   map[string]int
 	objectCounts    map[string]int
+
+	// Quality scores for triples that have one (see quality.go)
+	quality map[Triple]float64
 }
 
 // NewTripleStore creates a new in-memory triple store with all indexes initialized.
@@ -52,6 +55,7 @@ func NewTripleStore() *TripleStore {
 		predicateCounts: make(map[string]int),
 		subjectCounts:   make(map[string]int),
 		objectCounts:    make(map[string]int),
+		quality:         make(map[Triple]float64),
 	}
 }
 
@@ -122,41 +126,54 @@ func (ts *TripleStore) BulkAdd(triples []Triple) error {
 			continue // Skip invalid triples
 		}
 
-		// Check if triple already exists
-		if ts.existsUnsafe(triple.Subject, triple.Predicate, triple.Object) {
-			continue
-		}
-
 		subject := triple.Subject
 		predicate := triple.Predicate
 		object := triple.Object
 
-		// Add to SPO index
-		if ts.spo[subject] == nil {
-			ts.spo[subject] = make(map[string]map[string]bool)
+		// Look each index level up once; loading a stored graph spends most
+		// of its time here.
+		spoPredicates := ts.spo[subject]
+		if spoPredicates == nil {
+			spoPredicates = make(map[string]map[string]bool)
+			ts.spo[subject] = spoPredicates
 		}
-		if ts.spo[subject][predicate] == nil {
-			ts.spo[subject][predicate] = make(map[string]bool)
+		spoObjects := spoPredicates[predicate]
+		if spoObjects == nil {
+			spoObjects = make(map[string]bool)
+			spoPredicates[predicate] = spoObjects
 		}
-		ts.spo[subject][predicate][object] = true
+
+		// Skip triples that already exist
+		if spoObjects[object] {
+			continue
+		}
+		spoObjects[object] = true
 
 		// Add to POS index
-		if ts.pos[predicate] == nil {
-			ts.pos[predicate] = make(map[string]map[string]bool)
+		posObjects := ts.pos[predicate]
+		if posObjects == nil {
+			posObjects = make(map[string]map[string]bool)
+			ts.pos[predicate] = posObjects
 		}
-		if ts.pos[predicate][object] == nil {
-			ts.pos[predicate][object] = make(map[string]bool)
+		posSubjects := posObjects[object]
+		if posSubjects == nil {
+			posSubjects = make(map[string]bool)
+			posObjects[object] = posSubjects
 		}
-		ts.pos[predicate][object][subject] = true
+		posSubjects[subject] = true
 
 		// Add to OSP index
-		if ts.osp[object] == nil {
-			ts.osp[object] = make(map[string]map[string]bool)
+		ospSubjects := ts.osp[object]
+		if ospSubjects == nil {
+			ospSubjects = make(map[string]map[string]bool)
+			ts.osp[object] = ospSubjects
 		}
-		if ts.osp[object][subject] == nil {
-			ts.osp[object][subject] = make(map[string]bool)
+		ospPredicates := ospSubjects[subject]
+		if ospPredicates == nil {
+			ospPredicates = make(map[string]bool)
+			ospSubjects[subject] = ospPredicates
 		}
-		ts.osp[object][subject][predicate] = true
+		ospPredicates[predicate] = true
 
 		// Update statistics
 		ts.predicateCounts[predicate]++
@@ -170,10 +187,16 @@ func (ts *TripleStore) BulkAdd(triples []Triple) error {
 
 // MergeFrom copies all triples from the source store into this store.
 // Returns the number of new triples added (duplicates are skipped via idempotent Add).
+// Quality scores are copied too; a triple scored in both stores keeps the lower score.
 func (ts *TripleStore) MergeFrom(source *TripleStore) int {
 	sourceTriples := source.All()
 	previousCount := ts.Count()
 	_ = ts.BulkAdd(sourceTriples)
+	for triple, score := range source.qualityScores() {
+		if score < ts.Quality(triple) {
+			ts.SetQuality(triple, score)
+		}
+	}
 	return ts.Count() - previousCount
 }
 
@@ -269,6 +292,7 @@ func (ts *TripleStore) Clear() {
 	ts.predicateCounts = make(map[string]int)
 	ts.subjectCounts = make(map[string]int)
 	ts.objectCounts = make(map[string]int)
+	ts.quality = make(map[Triple]float64)
 }
 
 // Count returns the total number of triples in the store.
@@ -461,6 +485,7 @@ func (ts *TripleStore) deleteTripleUnsafe(subject, predicate, object string) {
 	if !ts.existsUnsafe(subject, predicate, object) {
 		return
 	}
+	delete(ts.quality, Triple{Subject: subject, Predicate: predicate, Object: object})
 
 	// Remove from SPO index
 	if pMap, ok := ts.spo[subject]; ok {
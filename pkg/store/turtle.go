@@ -311,6 +311,10 @@ func escapeLiteralString(value string) string {
 			builder.WriteString(`\r`)
 		case '\t':
 			builder.WriteString(`\t`)
+		case '\b':
+			builder.WriteString(`\b`)
+		case '\f':
+			builder.WriteString(`\f`)
 		default:
 			builder.WriteRune(char)
 		}
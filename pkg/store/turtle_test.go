@@ -74,6 +74,8 @@ func TestEscapeLiteralString(t *testing.T) {
 		{"empty", "", ""},
 		{"unicode", "Recht auf Löschung", "Recht auf Löschung"},
 		{"single_quotes", "'term' means something", "'term' means something"},
+		{"form_feed", "page\fbreak", `page\fbreak`},
+		{"non_latin", "Γενικός Κανονισμός 規則", "Γενικός Κανονισμός 規則"},
 	}
 
 	for _, testCase := range testCases {
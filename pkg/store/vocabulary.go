@@ -0,0 +1,564 @@
+package store
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// schemaSource is this package's schema.go. The vocabulary is read from its
+// constant declarations so that declaring a constant is all it takes to add
+// a class or predicate to the ontology.
+//
+//go:embed schema.go
+var schemaSource string
+
+// TermKind distinguishes classes from properties.
+type TermKind string
+
+const (
+	TermClass    TermKind = "class"
+	TermProperty TermKind = "property"
+)
+
+// VocabularyTerm is a class or property declared in the schema.
+type VocabularyTerm struct {
+	// Name is the prefixed name, e.g. "reg:partOf".
+	Name     string   `json:"name"`
+	Kind     TermKind `json:"kind"`
+	Label    string   `json:"label"`
+	Comment  string   `json:"comment,omitempty"`
+	Group    string   `json:"group,omitempty"`
+	Constant string   `json:"constant"`
+}
+
+// URI returns the full URI of the term.
+func (term VocabularyTerm) URI() string {
+	return ExpandPrefixedName(term.Name)
+}
+
+// Vocabulary is the set of terms declared in the schema.
+type Vocabulary struct {
+	Terms []VocabularyTerm
+	index map[string]int
+}
+
+var (
+	defaultVocabulary     *Vocabulary
+	defaultVocabularyOnce sync.Once
+)
+
+// DefaultVocabulary returns the vocabulary declared in schema.go.
+func DefaultVocabulary() *Vocabulary {
+	defaultVocabularyOnce.Do(func() {
+		vocabulary, err := parseVocabulary(schemaSource)
+		if err != nil {
+			// schema.go is compiled into this package, so it always parses.
+			panic(fmt.Sprintf("failed to parse schema vocabulary: %v", err))
+		}
+		defaultVocabulary = vocabulary
+	})
+	return defaultVocabulary
+}
+
+// knownNamespaces maps the prefixes used by the schema to their namespaces.
+var knownNamespaces = map[string]string{
+	"reg":  NamespaceReg,
+	"rdf":  NamespaceRDF,
+	"rdfs": NamespaceRDFS,
+	"xsd":  NamespaceXSD,
+	"dc":   NamespaceDC,
+	"eli":  NamespaceELI,
+	"frbr": NamespaceFRBR,
+	"owl":  NamespaceOWL,
+}
+
+// ExpandPrefixedName expands a prefixed name with a known prefix to a full
+// URI. Other values are returned unchanged.
+func ExpandPrefixedName(name string) string {
+	prefix, localName, found := strings.Cut(name, ":")
+	if !found {
+		return name
+	}
+	if namespace, ok := knownNamespaces[prefix]; ok {
+		return namespace + localName
+	}
+	return name
+}
+
+// CompactURI returns the prefixed name of a full URI in a known namespace.
+// Other values are returned unchanged.
+func CompactURI(uri string) string {
+	for prefix, namespace := range knownNamespaces {
+		if strings.HasPrefix(uri, namespace) && len(uri) > len(namespace) {
+			return prefix + ":" + strings.TrimPrefix(uri, namespace)
+		}
+	}
+	return uri
+}
+
+var termNamePattern = regexp.MustCompile(`^[a-z]+:[A-Za-z_][A-Za-z0-9_]*$`)
+
+// parseVocabulary extracts terms from the string constants of a Go source
+// file. Each const block's doc comment ("Classes - Types of ...") names the
+// group; each constant's doc comment, when it starts with the constant name,
+// becomes the term comment.
+func parseVocabulary(source string) (*Vocabulary, error) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "schema.go", source, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	vocabulary := &Vocabulary{index: make(map[string]int)}
+	for _, declaration := range file.Decls {
+		genDecl, ok := declaration.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		group := groupName(genDecl.Doc)
+
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for index, name := range valueSpec.Names {
+				if index >= len(valueSpec.Values) {
+					continue
+				}
+				literal, ok := valueSpec.Values[index].(*ast.BasicLit)
+				if !ok || literal.Kind != token.STRING {
+					continue
+				}
+				value, err := strconv.Unquote(literal.Value)
+				if err != nil || !termNamePattern.MatchString(value) {
+					continue
+				}
+				if _, exists := vocabulary.index[value]; exists {
+					continue
+				}
+
+				localName := value[strings.Index(value, ":")+1:]
+				kind := TermProperty
+				if unicode.IsUpper(rune(localName[0])) {
+					kind = TermClass
+				}
+
+				vocabulary.index[value] = len(vocabulary.Terms)
+				vocabulary.Terms = append(vocabulary.Terms, VocabularyTerm{
+					Name:     value,
+					Kind:     kind,
+					Label:    labelFromLocalName(localName),
+					Comment:  termComment(name.Name, valueSpec.Doc),
+					Group:    group,
+					Constant: name.Name,
+				})
+			}
+		}
+	}
+	return vocabulary, nil
+}
+
+// groupName returns the heading of a const block comment: "Classes" for
+// "Classes - Types of regulatory entities.".
+func groupName(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	heading, _, _ := strings.Cut(strings.TrimSpace(doc.Text()), "\n")
+	heading, _, _ = strings.Cut(heading, " - ")
+	return strings.TrimSuffix(heading, ".")
+}
+
+// termComment turns "PropPartOf indicates hierarchical containment." into
+// "Indicates hierarchical containment." and "PropTitle is the title." into
+// "The title.", dropping example lines. Comments
+// that do not describe the constant itself are ignored.
+func termComment(constant string, doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	text, found := strings.CutPrefix(strings.TrimSpace(doc.Text()), constant+" ")
+	if !found {
+		return ""
+	}
+	text = strings.TrimPrefix(text, "is ")
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "Example:") || strings.HasPrefix(line, "Values:") {
+			break
+		}
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	comment := strings.Join(lines, " ")
+	if comment == "" {
+		return ""
+	}
+	return strings.ToUpper(comment[:1]) + comment[1:]
+}
+
+// labelFromLocalName splits a camel-case local name into words:
+// "partOf" becomes "part of", "DefinedTerm" becomes "Defined Term", and
+// "externalDocURI" becomes "external doc URI".
+func labelFromLocalName(localName string) string {
+	var builder strings.Builder
+	runes := []rune(localName)
+	for index, r := range runes {
+		if r == '_' {
+			builder.WriteRune(' ')
+			continue
+		}
+		if index > 0 && unicode.IsUpper(r) && !unicode.IsUpper(runes[index-1]) && runes[index-1] != '_' {
+			builder.WriteRune(' ')
+			acronym := index+1 < len(runes) && unicode.IsUpper(runes[index+1])
+			if unicode.IsLower(runes[0]) && !acronym {
+				r = unicode.ToLower(r)
+			}
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// Lookup returns the term with the given prefixed name or full URI.
+func (vocabulary *Vocabulary) Lookup(name string) (VocabularyTerm, bool) {
+	index, ok := vocabulary.index[CompactURI(name)]
+	if !ok {
+		return VocabularyTerm{}, false
+	}
+	return vocabulary.Terms[index], true
+}
+
+// IsDeclared reports whether name is a declared term.
+func (vocabulary *Vocabulary) IsDeclared(name string) bool {
+	_, ok := vocabulary.Lookup(name)
+	return ok
+}
+
+// Filter returns the terms of the given kind in the given namespace prefix.
+// Empty arguments match everything.
+func (vocabulary *Vocabulary) Filter(kind TermKind, prefix string) []VocabularyTerm {
+	var terms []VocabularyTerm
+	for _, term := range vocabulary.Terms {
+		if kind != "" && term.Kind != kind {
+			continue
+		}
+		if prefix != "" && !strings.HasPrefix(term.Name, strings.TrimSuffix(prefix, ":")+":") {
+			continue
+		}
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// TermUsageCount is how often a vocabulary term occurs in a graph: as a
+// predicate for properties, as an rdf:type object for classes.
+type TermUsageCount struct {
+	VocabularyTerm
+	Count int `json:"count"`
+}
+
+// Usage counts how often each declared term occurs in tripleStore.
+func (vocabulary *Vocabulary) Usage(tripleStore *TripleStore) []TermUsageCount {
+	counts := make(map[string]int)
+	for _, triple := range tripleStore.All() {
+		counts[CompactURI(triple.Predicate)]++
+		if triple.Predicate == RDFType || triple.Predicate == NamespaceRDF+"type" {
+			counts[CompactURI(triple.Object)]++
+		}
+	}
+
+	usage := make([]TermUsageCount, 0, len(vocabulary.Terms))
+	for _, term := range vocabulary.Terms {
+		usage = append(usage, TermUsageCount{VocabularyTerm: term, Count: counts[term.Name]})
+	}
+	return usage
+}
+
+// UndeclaredTerm is a predicate or class used in a graph but not declared in
+// the vocabulary.
+type UndeclaredTerm struct {
+	Name           string `json:"name"`
+	Count          int    `json:"count"`
+	ExampleSubject string `json:"example_subject"`
+}
+
+// VocabularyReport is the result of checking a graph against the vocabulary.
+type VocabularyReport struct {
+	Conforms           bool `json:"conforms"`
+	Triples            int  `json:"triples"`
+	PredicatesUsed     int  `json:"predicates_used"`
+	DeclaredPredicates int  `json:"declared_predicates"`
+
+	// UndeclaredPredicates are predicates in the reg: namespace, or in no
+	// known namespace, that the vocabulary does not declare.
+	UndeclaredPredicates []UndeclaredTerm `json:"undeclared_predicates"`
+
+	// UndeclaredClasses are reg: classes used with rdf:type but not declared.
+	UndeclaredClasses []UndeclaredTerm `json:"undeclared_classes"`
+
+	// ExternalPredicates are undeclared predicates from well-known external
+	// vocabularies (rdf, rdfs, dc, eli, ...). They do not fail validation.
+	ExternalPredicates []UndeclaredTerm `json:"external_predicates,omitempty"`
+}
+
+// Validate checks that tripleStore only uses declared predicates and classes.
+func (vocabulary *Vocabulary) Validate(tripleStore *TripleStore) *VocabularyReport {
+	report := &VocabularyReport{
+		UndeclaredPredicates: make([]UndeclaredTerm, 0),
+		UndeclaredClasses:    make([]UndeclaredTerm, 0),
+	}
+	predicates := make(map[string]*UndeclaredTerm)
+	classes := make(map[string]*UndeclaredTerm)
+
+	record := func(terms map[string]*UndeclaredTerm, name string, subject string) {
+		if entry, ok := terms[name]; ok {
+			entry.Count++
+			return
+		}
+		terms[name] = &UndeclaredTerm{Name: name, Count: 1, ExampleSubject: subject}
+	}
+
+	for _, triple := range tripleStore.All() {
+		report.Triples++
+		record(predicates, CompactURI(triple.Predicate), triple.Subject)
+
+		if triple.Predicate == RDFType || triple.Predicate == NamespaceRDF+"type" {
+			class := CompactURI(triple.Object)
+			if strings.HasPrefix(class, PrefixReg) && !vocabulary.IsDeclared(class) {
+				record(classes, class, triple.Subject)
+			}
+		}
+	}
+
+	report.PredicatesUsed = len(predicates)
+	for name, entry := range predicates {
+		switch {
+		case vocabulary.IsDeclared(name):
+			report.DeclaredPredicates++
+		case isExternalVocabularyTerm(name):
+			report.ExternalPredicates = append(report.ExternalPredicates, *entry)
+		default:
+			report.UndeclaredPredicates = append(report.UndeclaredPredicates, *entry)
+		}
+	}
+	for _, entry := range classes {
+		report.UndeclaredClasses = append(report.UndeclaredClasses, *entry)
+	}
+
+	sortUndeclaredTerms(report.UndeclaredPredicates)
+	sortUndeclaredTerms(report.UndeclaredClasses)
+	sortUndeclaredTerms(report.ExternalPredicates)
+	report.Conforms = len(report.UndeclaredPredicates) == 0 && len(report.UndeclaredClasses) == 0
+	return report
+}
+
+// isKnownPrefixedName reports whether value is a prefixed name in a known
+// namespace, e.g. "reg:Article".
+func isKnownPrefixedName(value string) bool {
+	prefix, localName, found := strings.Cut(value, ":")
+	if !found || localName == "" || strings.ContainsAny(localName, " \t\n") {
+		return false
+	}
+	_, known := knownNamespaces[prefix]
+	return known
+}
+
+// isExternalVocabularyTerm reports whether name is a prefixed name in a known
+// namespace other than reg:.
+func isExternalVocabularyTerm(name string) bool {
+	prefix, _, found := strings.Cut(name, ":")
+	if !found || prefix == "reg" {
+		return false
+	}
+	_, known := knownNamespaces[prefix]
+	return known
+}
+
+func sortUndeclaredTerms(terms []UndeclaredTerm) {
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Count != terms[j].Count {
+			return terms[i].Count > terms[j].Count
+		}
+		return terms[i].Name < terms[j].Name
+	})
+}
+
+// OntologyURI is the URI of the regula ontology itself.
+var OntologyURI = strings.TrimSuffix(NamespaceReg, "#")
+
+// Ontology describes the reg: vocabulary as OWL in a triple store, ready for
+// any of the RDF serializers. When usageGraph is non-nil, properties whose
+// objects in it are all URIs become owl:ObjectProperty and the rest
+// owl:DatatypeProperty; otherwise properties are typed rdf:Property.
+func (vocabulary *Vocabulary) Ontology(usageGraph *TripleStore) *TripleStore {
+	ontology := NewTripleStore()
+	ontology.Add(OntologyURI, RDFType, NamespaceOWL+"Ontology")
+	ontology.Add(OntologyURI, RDFSLabel, "Regula regulation ontology")
+	ontology.Add(OntologyURI, RDFSComment, "Classes and properties of regula regulatory knowledge graphs.")
+
+	propertyKinds := make(map[string]string)
+	if usageGraph != nil {
+		propertyKinds = inferPropertyKinds(usageGraph)
+	}
+
+	for _, term := range vocabulary.Filter("", "reg") {
+		uri := term.URI()
+		switch term.Kind {
+		case TermClass:
+			ontology.Add(uri, RDFType, NamespaceOWL+"Class")
+			if superClass := superClassOf(term.Name); superClass != "" {
+				ontology.Add(uri, RDFSSubClassOf, ExpandPrefixedName(superClass))
+			}
+		default:
+			propertyType, ok := propertyKinds[term.Name]
+			if !ok {
+				propertyType = NamespaceRDF + "Property"
+			}
+			ontology.Add(uri, RDFType, propertyType)
+		}
+		ontology.Add(uri, RDFSLabel, term.Label)
+		if term.Comment != "" {
+			ontology.Add(uri, RDFSComment, term.Comment)
+		}
+		ontology.Add(uri, NamespaceRDFS+"isDefinedBy", OntologyURI)
+	}
+	return ontology
+}
+
+// superClassOf places right and obligation types under reg:Right and
+// reg:Obligation.
+func superClassOf(name string) string {
+	localName := strings.TrimPrefix(name, PrefixReg)
+	switch {
+	case name == ClassRight || name == ClassObligation:
+		return ""
+	case strings.HasPrefix(localName, "Right"):
+		return ClassRight
+	case strings.HasSuffix(localName, "Obligation") && localName != "LegalObligation":
+		return ClassObligation
+	}
+	return ""
+}
+
+// inferPropertyKinds classifies each predicate used in tripleStore as an
+// object property (every object is a URI) or a datatype property.
+func inferPropertyKinds(tripleStore *TripleStore) map[string]string {
+	literalObjects := make(map[string]bool)
+	seen := make(map[string]bool)
+	for _, triple := range tripleStore.All() {
+		predicate := CompactURI(triple.Predicate)
+		seen[predicate] = true
+		if !isFullURI(triple.Object) && !isKnownPrefixedName(triple.Object) {
+			literalObjects[predicate] = true
+		}
+	}
+
+	kinds := make(map[string]string, len(seen))
+	for predicate := range seen {
+		if literalObjects[predicate] {
+			kinds[predicate] = NamespaceOWL + "DatatypeProperty"
+		} else {
+			kinds[predicate] = NamespaceOWL + "ObjectProperty"
+		}
+	}
+	return kinds
+}
+
+// FormatOntologyTurtle serializes the ontology as Turtle with an owl: prefix.
+func FormatOntologyTurtle(ontology *TripleStore) string {
+	return NewTurtleSerializer(WithPrefix("owl", NamespaceOWL)).Serialize(ontology)
+}
+
+// FormatVocabularyTable formats term usage for terminal output.
+func FormatVocabularyTable(usage []TermUsageCount, showCounts bool) string {
+	var builder strings.Builder
+
+	classes, properties := 0, 0
+	for _, entry := range usage {
+		if entry.Kind == TermClass {
+			classes++
+		} else {
+			properties++
+		}
+	}
+	builder.WriteString(fmt.Sprintf("Vocabulary: %d classes, %d properties\n", classes, properties))
+
+	currentGroup := "\x00"
+	for _, entry := range usage {
+		if entry.Group != currentGroup {
+			currentGroup = entry.Group
+			group := currentGroup
+			if group == "" {
+				group = "Other"
+			}
+			builder.WriteString(fmt.Sprintf("\n%s\n", group))
+		}
+		kind := "P"
+		if entry.Kind == TermClass {
+			kind = "C"
+		}
+		if showCounts {
+			builder.WriteString(fmt.Sprintf("  %s %-32s %7d  %s\n", kind, entry.Name, entry.Count, entry.Comment))
+		} else {
+			builder.WriteString(fmt.Sprintf("  %s %-32s %s\n", kind, entry.Name, entry.Comment))
+		}
+	}
+	return builder.String()
+}
+
+// FormatVocabularyJSON formats term usage as indented JSON.
+func FormatVocabularyJSON(usage []TermUsageCount) string {
+	data, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(data)
+}
+
+// FormatVocabularyReportTable formats a vocabulary validation report for
+// terminal output.
+func FormatVocabularyReportTable(report *VocabularyReport) string {
+	var builder strings.Builder
+
+	status := "PASS"
+	if !report.Conforms {
+		status = "FAIL"
+	}
+	builder.WriteString(fmt.Sprintf("Vocabulary validation: %s\n", status))
+	builder.WriteString(fmt.Sprintf("  Triples: %d | Predicates used: %d | Declared: %d | External: %d | Undeclared: %d\n",
+		report.Triples, report.PredicatesUsed, report.DeclaredPredicates,
+		len(report.ExternalPredicates), len(report.UndeclaredPredicates)))
+
+	writeTerms := func(title string, terms []UndeclaredTerm) {
+		if len(terms) == 0 {
+			return
+		}
+		builder.WriteString(fmt.Sprintf("\n%s:\n", title))
+		for _, term := range terms {
+			builder.WriteString(fmt.Sprintf("  %-32s %7d  e.g. %s\n", term.Name, term.Count, term.ExampleSubject))
+		}
+	}
+	writeTerms("Undeclared predicates", report.UndeclaredPredicates)
+	writeTerms("Undeclared classes", report.UndeclaredClasses)
+	writeTerms("External predicates (not checked)", report.ExternalPredicates)
+	return builder.String()
+}
+
+// FormatVocabularyReportJSON formats a vocabulary validation report as
+// indented JSON.
+func FormatVocabularyReportJSON(report *VocabularyReport) string {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(data)
+}
@@ -0,0 +1,163 @@
+package store
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/extract"
+)
+
+func TestDefaultVocabulary(t *testing.T) {
+	vocabulary := DefaultVocabulary()
+
+	article, ok := vocabulary.Lookup(ClassArticle)
+	if !ok {
+		t.Fatal("reg:Article is not declared")
+	}
+	if article.Kind != TermClass || article.Constant != "ClassArticle" || article.Group != "Classes" {
+		t.Errorf("unexpected term: %+v", article)
+	}
+	if article.Comment != "Represents an article (main provision unit)." {
+		t.Errorf("Comment: got %q", article.Comment)
+	}
+
+	partOf, ok := vocabulary.Lookup(NamespaceReg + "partOf")
+	if !ok {
+		t.Fatal("full URI lookup failed for reg:partOf")
+	}
+	if partOf.Kind != TermProperty || partOf.Label != "part of" {
+		t.Errorf("unexpected term: %+v", partOf)
+	}
+	if strings.Contains(partOf.Comment, "Example") {
+		t.Errorf("example line was not dropped: %q", partOf.Comment)
+	}
+
+	title, _ := vocabulary.Lookup(PropTitle)
+	if title.Comment != "The title of a provision or document." {
+		t.Errorf("Comment: got %q", title.Comment)
+	}
+
+	// Namespace and prefix constants are not terms.
+	for _, name := range []string{NamespaceReg, PrefixReg, "reg:"} {
+		if vocabulary.IsDeclared(name) {
+			t.Errorf("%q should not be a term", name)
+		}
+	}
+	if len(vocabulary.Filter(TermClass, "eli")) != 3 {
+		t.Errorf("expected 3 ELI classes, got %v", vocabulary.Filter(TermClass, "eli"))
+	}
+}
+
+func TestLabelFromLocalName(t *testing.T) {
+	tests := map[string]string{
+		"partOf":         "part of",
+		"DefinedTerm":    "Defined Term",
+		"externalDocURI": "external doc URI",
+		"id_local":       "id local",
+		"text":           "text",
+	}
+	for localName, want := range tests {
+		if got := labelFromLocalName(localName); got != want {
+			t.Errorf("labelFromLocalName(%q) = %q, want %q", localName, got, want)
+		}
+	}
+}
+
+func TestVocabulary_Validate(t *testing.T) {
+	tripleStore := NewTripleStore()
+	article := "https://regula.dev/regulations/T:Art1"
+	tripleStore.Add(article, RDFType, ClassArticle)
+	tripleStore.Add(article, PropTitle, "Scope")
+	tripleStore.Add(article, NamespaceReg+"number", "1")
+	tripleStore.Add(article, "reg:titel", "Scope")
+	tripleStore.Add(article, "reg:titel", "Scope (amended)")
+	tripleStore.Add(article, "dc:title", "Scope")
+	tripleStore.Add(article, "https://example.org/custom", "x")
+	tripleStore.Add(article, RDFType, "reg:Artikel")
+
+	report := DefaultVocabulary().Validate(tripleStore)
+
+	if report.Conforms {
+		t.Fatal("expected report not to conform")
+	}
+	if report.DeclaredPredicates != 3 {
+		t.Errorf("DeclaredPredicates: got %d, want 3 (rdf:type, reg:title, reg:number)", report.DeclaredPredicates)
+	}
+	if len(report.UndeclaredPredicates) != 2 || report.UndeclaredPredicates[0].Name != "reg:titel" || report.UndeclaredPredicates[0].Count != 2 {
+		t.Errorf("unexpected undeclared predicates: %+v", report.UndeclaredPredicates)
+	}
+	if len(report.ExternalPredicates) != 1 || report.ExternalPredicates[0].Name != "dc:title" {
+		t.Errorf("unexpected external predicates: %+v", report.ExternalPredicates)
+	}
+	if len(report.UndeclaredClasses) != 1 || report.UndeclaredClasses[0].Name != "reg:Artikel" {
+		t.Errorf("unexpected undeclared classes: %+v", report.UndeclaredClasses)
+	}
+	if !strings.Contains(FormatVocabularyReportTable(report), "Vocabulary validation: FAIL") {
+		t.Error("table does not report failure")
+	}
+}
+
+func TestVocabulary_GDPRGraphConforms(t *testing.T) {
+	doc := loadGDPRDocument(t)
+
+	baseURI := "https://regula.dev/regulations/"
+	tripleStore := NewTripleStore()
+	builder := NewGraphBuilder(tripleStore, baseURI)
+	resolver := extract.NewReferenceResolver(baseURI, "GDPR")
+	resolver.IndexDocument(doc)
+	if _, err := builder.BuildComplete(doc, extract.NewDefinitionExtractor(), extract.NewReferenceExtractor(), resolver, extract.NewSemanticExtractor()); err != nil {
+		t.Fatalf("BuildComplete failed: %v", err)
+	}
+
+	vocabulary := DefaultVocabulary()
+	report := vocabulary.Validate(tripleStore)
+	if !report.Conforms {
+		t.Errorf("GDPR graph uses undeclared terms: %+v %+v", report.UndeclaredPredicates, report.UndeclaredClasses)
+	}
+
+	for _, entry := range vocabulary.Usage(tripleStore) {
+		if entry.Name == ClassArticle && entry.Count < 99 {
+			t.Errorf("reg:Article usage: got %d, want at least 99", entry.Count)
+		}
+	}
+}
+
+func TestVocabulary_Ontology(t *testing.T) {
+	usageGraph := NewTripleStore()
+	usageGraph.Add("https://regula.dev/regulations/T:Art1", PropPartOf, "https://regula.dev/regulations/T:ChapterI")
+	usageGraph.Add("https://regula.dev/regulations/T:Art1", PropTitle, "Scope")
+
+	ontology := DefaultVocabulary().Ontology(usageGraph)
+
+	expectType := func(name string, want string) {
+		t.Helper()
+		if got := ontology.GetOne(ExpandPrefixedName(name), RDFType); got != want {
+			t.Errorf("%s type: got %q, want %q", name, got, want)
+		}
+	}
+	expectType(ClassArticle, NamespaceOWL+"Class")
+	expectType(PropPartOf, NamespaceOWL+"ObjectProperty")
+	expectType(PropTitle, NamespaceOWL+"DatatypeProperty")
+	expectType(PropDeadline, NamespaceRDF+"Property")
+
+	if got := ontology.GetOne(ExpandPrefixedName(RightErasure), RDFSSubClassOf); got != ExpandPrefixedName(ClassRight) {
+		t.Errorf("reg:RightToErasure subClassOf: got %q", got)
+	}
+	if got := ontology.GetOne(ExpandPrefixedName(LegalBasisLegalObligation), RDFSSubClassOf); got != "" {
+		t.Errorf("reg:LegalObligation is a legal basis, not an obligation type: got %q", got)
+	}
+	if len(ontology.Find(ExpandPrefixedName(ELIPropTitle), "", "")) != 0 {
+		t.Error("ontology should only define reg: terms")
+	}
+
+	turtle := FormatOntologyTurtle(ontology)
+	for _, expected := range []string{
+		"@prefix owl: <http://www.w3.org/2002/07/owl#> .",
+		"<https://regula.dev/ontology> a owl:Ontology",
+		"reg:Article a owl:Class",
+	} {
+		if !strings.Contains(turtle, expected) {
+			t.Errorf("Turtle output missing %q", expected)
+		}
+	}
+}
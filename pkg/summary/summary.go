@@ -0,0 +1,524 @@
+// Package summary builds per-chapter and per-article summaries of a
+// regulation from its knowledge graph: the obligations imposed, rights
+// granted, actors bound, deadlines, and penalties of each provision.
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/textutil"
+)
+
+// maxQuoteLength bounds the provision text quoted for obligations, rights,
+// and penalties.
+const maxQuoteLength = 240
+
+// Summary is a structured summary of one regulation.
+type Summary struct {
+	Regulation string            `json:"regulation"`
+	Label      string            `json:"label,omitempty"`
+	Title      string            `json:"title,omitempty"`
+	Chapters   []*ChapterSummary `json:"chapters"`
+	Totals     Totals            `json:"totals"`
+}
+
+// Totals counts the summarized items of a regulation.
+type Totals struct {
+	Articles    int `json:"articles"`
+	Obligations int `json:"obligations"`
+	Rights      int `json:"rights"`
+	Actors      int `json:"actors"`
+	Deadlines   int `json:"deadlines"`
+	Penalties   int `json:"penalties"`
+}
+
+// ChapterSummary summarizes the articles of one chapter. Overview is only
+// set by Polish.
+type ChapterSummary struct {
+	URI      string            `json:"uri,omitempty"`
+	Number   string            `json:"number,omitempty"`
+	Title    string            `json:"title,omitempty"`
+	Overview string            `json:"overview,omitempty"`
+	Articles []*ArticleSummary `json:"articles"`
+}
+
+// ArticleSummary lists what one article imposes, grants, and sets.
+type ArticleSummary struct {
+	URI         string      `json:"uri"`
+	Number      string      `json:"number"`
+	Title       string      `json:"title,omitempty"`
+	Obligations []Provision `json:"obligations,omitempty"`
+	Rights      []Provision `json:"rights,omitempty"`
+	Actors      []string    `json:"actors,omitempty"`
+	Deadlines   []string    `json:"deadlines,omitempty"`
+	Penalties   []string    `json:"penalties,omitempty"`
+}
+
+// Provision is an obligation or right node of the graph.
+type Provision struct {
+	Type        string `json:"type"`
+	Party       string `json:"party,omitempty"`
+	Prohibition bool   `json:"prohibition,omitempty"`
+	Recurrence  string `json:"recurrence,omitempty"`
+	Text        string `json:"text,omitempty"`
+}
+
+// IsEmpty reports whether nothing was found for the article.
+func (article *ArticleSummary) IsEmpty() bool {
+	return len(article.Obligations) == 0 && len(article.Rights) == 0 &&
+		len(article.Deadlines) == 0 && len(article.Penalties) == 0
+}
+
+// penaltyPattern finds sentences about sanctions. A penalty sentence must
+// also state an amount or lay down a rule (penaltyRulePattern), so passing
+// mentions such as "the execution of criminal penalties" are left out.
+var (
+	penaltyPattern     = regexp.MustCompile(`(?i)\b(penalt(?:y|ies)|imprisonment|fines?)\b`)
+	penaltyRulePattern = regexp.MustCompile(`(?i)(\d[\d\s,.]*\s*(?:EUR|euros?|USD|dollars|GBP|pounds)\b|[€$£]\s?\d|\d\s?%|\b(?:shall|must|liable)\b)`)
+	sentencePattern    = regexp.MustCompile(`[.;:]\s+`)
+)
+
+// Summarize summarizes the regulation at regulationURI. An empty URI
+// selects the first regulation in the store.
+func Summarize(tripleStore *store.TripleStore, regulationURI string) (*Summary, error) {
+	if regulationURI == "" {
+		regulationURI = firstRegulation(tripleStore)
+		if regulationURI == "" {
+			return nil, fmt.Errorf("no regulation found in the graph")
+		}
+	}
+
+	summary := &Summary{
+		Regulation: regulationURI,
+		Label:      tripleStore.GetOne(regulationURI, store.RDFSLabel),
+		Title:      tripleStore.GetOne(regulationURI, store.PropTitle),
+		Chapters:   make([]*ChapterSummary, 0),
+	}
+
+	chapters := make(map[string]*ChapterSummary)
+	firstArticle := make(map[*ChapterSummary]int)
+	actors := make(map[string]bool)
+	for _, triple := range tripleStore.Find("", store.RDFType, store.ClassArticle) {
+		if tripleStore.GetOne(triple.Subject, store.PropBelongsTo) != regulationURI {
+			continue
+		}
+		article := summarizeArticle(tripleStore, triple.Subject)
+		summary.Totals.Articles++
+		summary.Totals.Obligations += len(article.Obligations)
+		summary.Totals.Rights += len(article.Rights)
+		summary.Totals.Deadlines += len(article.Deadlines)
+		summary.Totals.Penalties += len(article.Penalties)
+		for _, actor := range article.Actors {
+			actors[actor] = true
+		}
+
+		chapterURI := enclosingChapter(tripleStore, triple.Subject)
+		chapter, ok := chapters[chapterURI]
+		if !ok {
+			chapter = &ChapterSummary{URI: chapterURI, Articles: make([]*ArticleSummary, 0)}
+			if chapterURI != "" {
+				chapter.Number = tripleStore.GetOne(chapterURI, store.PropNumber)
+				chapter.Title = tripleStore.GetOne(chapterURI, store.PropTitle)
+			}
+			chapters[chapterURI] = chapter
+			summary.Chapters = append(summary.Chapters, chapter)
+		}
+		chapter.Articles = append(chapter.Articles, article)
+
+		if order := textutil.ArticleOrder(article.Number); !ok || order < firstArticle[chapter] {
+			firstArticle[chapter] = order
+		}
+	}
+	summary.Totals.Actors = len(actors)
+
+	for _, chapter := range summary.Chapters {
+		sort.SliceStable(chapter.Articles, func(i, j int) bool {
+			return lessArticle(chapter.Articles[i].Number, chapter.Articles[j].Number)
+		})
+	}
+	sort.SliceStable(summary.Chapters, func(i, j int) bool {
+		return firstArticle[summary.Chapters[i]] < firstArticle[summary.Chapters[j]]
+	})
+
+	return summary, nil
+}
+
+// summarizeArticle collects the obligations, rights, deadlines, and
+// penalties of one article node.
+func summarizeArticle(tripleStore *store.TripleStore, articleURI string) *ArticleSummary {
+	article := &ArticleSummary{
+		URI:    articleURI,
+		Number: tripleStore.GetOne(articleURI, store.PropNumber),
+		Title:  tripleStore.GetOne(articleURI, store.PropTitle),
+	}
+	actors := make(map[string]bool)
+
+	for _, triple := range tripleStore.Find(articleURI, store.PropImposesObligation, "") {
+		obligation := Provision{
+			Type:        tripleStore.GetOne(triple.Object, store.PropObligationType),
+			Party:       tripleStore.GetOne(triple.Object, store.PropDutyBearer),
+			Prohibition: tripleStore.GetOne(triple.Object, store.PropIsProhibition) == "true",
+			Text:        quote(tripleStore.GetOne(triple.Object, store.PropText)),
+		}
+		if rule := tripleStore.GetOne(triple.Object, store.PropRecurrence); rule != "" {
+			if recurrence, err := extract.ParseRecurrenceRule(rule); err == nil {
+				obligation.Recurrence = recurrence.Describe()
+			}
+		}
+		article.Obligations = append(article.Obligations, obligation)
+		if obligation.Party != "" {
+			actors[obligation.Party] = true
+		}
+	}
+	for _, triple := range tripleStore.Find(articleURI, store.PropGrantsRight, "") {
+		right := Provision{
+			Type:  tripleStore.GetOne(triple.Object, store.PropRightType),
+			Party: tripleStore.GetOne(triple.Object, store.PropBeneficiary),
+			Text:  quote(tripleStore.GetOne(triple.Object, store.PropText)),
+		}
+		article.Rights = append(article.Rights, right)
+		if right.Party != "" {
+			actors[right.Party] = true
+		}
+	}
+	sortProvisions(article.Obligations)
+	sortProvisions(article.Rights)
+	article.Actors = sortedSet(actors)
+
+	text := articleText(tripleStore, articleURI)
+	article.Deadlines = extract.FindDeadlines(text)
+
+	seen := make(map[string]bool)
+	for _, sentence := range sentencePattern.Split(text, -1) {
+		sentence = strings.TrimSpace(sentence)
+		if !penaltyPattern.MatchString(sentence) || !penaltyRulePattern.MatchString(sentence) || seen[sentence] {
+			continue
+		}
+		seen[sentence] = true
+		article.Penalties = append(article.Penalties, quote(sentence))
+	}
+
+	return article
+}
+
+// articleText joins the text of an article, its paragraphs, and their
+// points, with whitespace collapsed.
+func articleText(tripleStore *store.TripleStore, articleURI string) string {
+	parts := []string{tripleStore.GetOne(articleURI, store.PropText)}
+	paragraphs := tripleStore.Find(articleURI, store.PropHasParagraph, "")
+	sort.Slice(paragraphs, func(i, j int) bool {
+		return textutil.ArticleOrder(tripleStore.GetOne(paragraphs[i].Object, store.PropNumber)) <
+			textutil.ArticleOrder(tripleStore.GetOne(paragraphs[j].Object, store.PropNumber))
+	})
+	for _, paragraph := range paragraphs {
+		parts = append(parts, tripleStore.GetOne(paragraph.Object, store.PropText))
+		for _, point := range tripleStore.Find(paragraph.Object, store.PropHasPoint, "") {
+			parts = append(parts, tripleStore.GetOne(point.Object, store.PropText))
+		}
+	}
+	return strings.Join(strings.Fields(strings.Join(parts, "\n")), " ")
+}
+
+// enclosingChapter walks reg:partOf up from an article to its chapter, or
+// returns "" if the article is not in one.
+func enclosingChapter(tripleStore *store.TripleStore, uri string) string {
+	for depth := 0; depth < 8 && uri != ""; depth++ {
+		uri = tripleStore.GetOne(uri, store.PropPartOf)
+		if uri != "" && tripleStore.Exists(uri, store.RDFType, store.ClassChapter) {
+			return uri
+		}
+	}
+	return ""
+}
+
+func firstRegulation(tripleStore *store.TripleStore) string {
+	var regulations []string
+	for _, class := range []string{store.ClassRegulation, store.ClassDirective, store.ClassDecision} {
+		for _, triple := range tripleStore.Find("", store.RDFType, class) {
+			regulations = append(regulations, triple.Subject)
+		}
+	}
+	if len(regulations) == 0 {
+		return ""
+	}
+	sort.Strings(regulations)
+	return regulations[0]
+}
+
+func lessArticle(a, b string) bool {
+	if orderA, orderB := textutil.ArticleOrder(a), textutil.ArticleOrder(b); orderA != orderB {
+		return orderA < orderB
+	}
+	return a < b
+}
+
+func sortProvisions(provisions []Provision) {
+	sort.SliceStable(provisions, func(i, j int) bool {
+		return provisions[i].Type < provisions[j].Type
+	})
+}
+
+func sortedSet(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+func quote(text string) string {
+	return textutil.Truncate(strings.Join(strings.Fields(text), " "), maxQuoteLength)
+}
+
+// Heading returns the chapter's display heading, such as "Chapter III:
+// Rights of the data subject".
+func (chapter *ChapterSummary) Heading() string {
+	if chapter.Number == "" {
+		return "Articles outside a chapter"
+	}
+	heading := "Chapter " + chapter.Number
+	if chapter.Title != "" {
+		heading += ": " + chapter.Title
+	}
+	return heading
+}
+
+// Heading returns the article's display heading, such as "Article 17:
+// Right to erasure".
+func (article *ArticleSummary) Heading() string {
+	heading := "Article " + article.Number
+	if article.Title != "" {
+		heading += ": " + article.Title
+	}
+	return heading
+}
+
+func (provision Provision) label() string {
+	label := textutil.Humanize(provision.Type)
+	if provision.Prohibition {
+		label += " (prohibition)"
+	}
+	if provision.Party != "" {
+		label += " — " + textutil.Humanize(provision.Party)
+	}
+	if provision.Recurrence != "" {
+		label += ", " + provision.Recurrence
+	}
+	return label
+}
+
+// ToMarkdown renders the summary as Markdown. Articles with nothing to
+// report are counted but not listed.
+func (summary *Summary) ToMarkdown() string {
+	var sb strings.Builder
+
+	sb.WriteString("# Summary: " + summary.displayTitle() + "\n\n")
+	sb.WriteString(fmt.Sprintf("%d articles, %d obligations, %d rights, %d actors, %d deadlines, %d penalties\n",
+		summary.Totals.Articles, summary.Totals.Obligations, summary.Totals.Rights,
+		summary.Totals.Actors, summary.Totals.Deadlines, summary.Totals.Penalties))
+
+	for _, chapter := range summary.Chapters {
+		sb.WriteString("\n## " + chapter.Heading() + "\n\n")
+		if chapter.Overview != "" {
+			sb.WriteString("> " + chapter.Overview + "\n>\n> _Overview generated by a language model._\n\n")
+		}
+		listed := 0
+		for _, article := range chapter.Articles {
+			if article.IsEmpty() {
+				continue
+			}
+			listed++
+			sb.WriteString("### " + article.Heading() + "\n\n")
+			writeMarkdownList(&sb, "Obligations", provisionLabels(article.Obligations))
+			writeMarkdownList(&sb, "Rights", provisionLabels(article.Rights))
+			writeMarkdownList(&sb, "Actors", humanizeAll(article.Actors))
+			writeMarkdownList(&sb, "Deadlines", article.Deadlines)
+			writeMarkdownList(&sb, "Penalties", article.Penalties)
+		}
+		if skipped := len(chapter.Articles) - listed; skipped > 0 {
+			sb.WriteString(fmt.Sprintf("_%d of %d articles have no extracted obligations, rights, deadlines, or penalties._\n",
+				skipped, len(chapter.Articles)))
+		}
+	}
+
+	return sb.String()
+}
+
+func writeMarkdownList(sb *strings.Builder, label string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	sb.WriteString("**" + label + ":**\n\n")
+	for _, item := range items {
+		sb.WriteString("- " + item + "\n")
+	}
+	sb.WriteString("\n")
+}
+
+// ToHTML renders the summary as a self-contained HTML page.
+func (summary *Summary) ToHTML() string {
+	var sb strings.Builder
+	title := html.EscapeString("Summary: " + summary.displayTitle())
+
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"UTF-8\">\n")
+	sb.WriteString("<title>" + title + "</title>\n")
+	sb.WriteString("<style>\nbody { font-family: sans-serif; max-width: 960px; margin: 2em auto; line-height: 1.5; }\n")
+	sb.WriteString("h2 { border-bottom: 1px solid #ccc; }\n.overview { background: #f5f5f5; padding: 0.5em 1em; }\n")
+	sb.WriteString(".muted { color: #666; font-style: italic; }\n</style>\n</head>\n<body>\n")
+	sb.WriteString("<h1>" + title + "</h1>\n")
+	sb.WriteString(fmt.Sprintf("<p>%d articles, %d obligations, %d rights, %d actors, %d deadlines, %d penalties</p>\n",
+		summary.Totals.Articles, summary.Totals.Obligations, summary.Totals.Rights,
+		summary.Totals.Actors, summary.Totals.Deadlines, summary.Totals.Penalties))
+
+	for _, chapter := range summary.Chapters {
+		sb.WriteString("<h2>" + html.EscapeString(chapter.Heading()) + "</h2>\n")
+		if chapter.Overview != "" {
+			sb.WriteString("<div class=\"overview\"><p>" + html.EscapeString(chapter.Overview) + "</p>")
+			sb.WriteString("<p class=\"muted\">Overview generated by a language model.</p></div>\n")
+		}
+		listed := 0
+		for _, article := range chapter.Articles {
+			if article.IsEmpty() {
+				continue
+			}
+			listed++
+			sb.WriteString("<h3>" + html.EscapeString(article.Heading()) + "</h3>\n")
+			writeHTMLList(&sb, "Obligations", provisionLabels(article.Obligations))
+			writeHTMLList(&sb, "Rights", provisionLabels(article.Rights))
+			writeHTMLList(&sb, "Actors", humanizeAll(article.Actors))
+			writeHTMLList(&sb, "Deadlines", article.Deadlines)
+			writeHTMLList(&sb, "Penalties", article.Penalties)
+		}
+		if skipped := len(chapter.Articles) - listed; skipped > 0 {
+			sb.WriteString(fmt.Sprintf("<p class=\"muted\">%d of %d articles have no extracted obligations, rights, deadlines, or penalties.</p>\n",
+				skipped, len(chapter.Articles)))
+		}
+	}
+
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
+
+func writeHTMLList(sb *strings.Builder, label string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	sb.WriteString("<p><strong>" + label + ":</strong></p>\n<ul>\n")
+	for _, item := range items {
+		sb.WriteString("<li>" + html.EscapeString(item) + "</li>\n")
+	}
+	sb.WriteString("</ul>\n")
+}
+
+// ToJSON renders the summary as indented JSON.
+func (summary *Summary) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(summary, "", "  ")
+}
+
+func (summary *Summary) displayTitle() string {
+	if summary.Label != "" && summary.Title != "" {
+		return summary.Label + " — " + summary.Title
+	}
+	if summary.Title != "" {
+		return summary.Title
+	}
+	if summary.Label != "" {
+		return summary.Label
+	}
+	return summary.Regulation
+}
+
+func provisionLabels(provisions []Provision) []string {
+	labels := make([]string, len(provisions))
+	for i, provision := range provisions {
+		labels[i] = provision.label()
+		if provision.Text != "" {
+			labels[i] += ": “" + provision.Text + "”"
+		}
+	}
+	return labels
+}
+
+func humanizeAll(values []string) []string {
+	humanized := make([]string, len(values))
+	for i, value := range values {
+		humanized[i] = textutil.Humanize(value)
+	}
+	return humanized
+}
+
+// Completer sends a prompt to a language model and returns its reply.
+type Completer interface {
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// Polish asks the model for a short prose overview of each chapter, based
+// only on the facts already in the summary, and stores it in the chapter's
+// Overview. The graph-derived lists are left as they are. Chapters with
+// nothing to summarize are skipped; failed requests are returned as errors
+// and leave the chapter without an overview.
+func (summary *Summary) Polish(ctx context.Context, client Completer) []error {
+	var errs []error
+	for _, chapter := range summary.Chapters {
+		facts := chapterFacts(chapter)
+		if facts == "" {
+			continue
+		}
+		reply, err := client.Complete(ctx, polishPrompt(summary.displayTitle(), chapter.Heading(), facts))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", chapter.Heading(), err))
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		chapter.Overview = strings.Join(strings.Fields(reply), " ")
+	}
+	return errs
+}
+
+// chapterFacts lists the chapter's extracted facts, one line per item.
+func chapterFacts(chapter *ChapterSummary) string {
+	var sb strings.Builder
+	for _, article := range chapter.Articles {
+		if article.IsEmpty() {
+			continue
+		}
+		sb.WriteString(article.Heading() + "\n")
+		for _, label := range provisionLabels(article.Obligations) {
+			sb.WriteString("- obligation: " + label + "\n")
+		}
+		for _, label := range provisionLabels(article.Rights) {
+			sb.WriteString("- right: " + label + "\n")
+		}
+		for _, deadline := range article.Deadlines {
+			sb.WriteString("- deadline: " + deadline + "\n")
+		}
+		for _, penalty := range article.Penalties {
+			sb.WriteString("- penalty: " + penalty + "\n")
+		}
+	}
+	return sb.String()
+}
+
+func polishPrompt(regulation, chapter, facts string) string {
+	var sb strings.Builder
+	sb.WriteString("Write a plain-language overview, of at most three sentences, of " + chapter + " of " + regulation + ".\n")
+	sb.WriteString("Use only the facts listed below. Do not add obligations, rights, deadlines, or penalties that are not listed.\n")
+	sb.WriteString("Reply with the overview text only.\n\n")
+	sb.WriteString("Facts:\n")
+	sb.WriteString(facts)
+	return sb.String()
+}
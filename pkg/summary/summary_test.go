@@ -0,0 +1,182 @@
+package summary
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+const testBase = "https://regula.dev/regulations/"
+
+func buildSummaryTestStore() *store.TripleStore {
+	tripleStore := store.NewTripleStore()
+	regulation := testBase + "GDPR"
+	tripleStore.Add(regulation, store.RDFType, store.ClassRegulation)
+	tripleStore.Add(regulation, store.RDFSLabel, "GDPR")
+	tripleStore.Add(regulation, store.PropTitle, "General Data Protection Regulation")
+
+	addChapter := func(number, title string) string {
+		uri := testBase + "GDPR:Chapter" + number
+		tripleStore.Add(uri, store.RDFType, store.ClassChapter)
+		tripleStore.Add(uri, store.PropNumber, number)
+		tripleStore.Add(uri, store.PropTitle, title)
+		tripleStore.Add(uri, store.PropPartOf, regulation)
+		return uri
+	}
+	addArticle := func(number int, title, parent string, paragraphs ...string) string {
+		uri := fmt.Sprintf("%sGDPR:Art%d", testBase, number)
+		tripleStore.Add(uri, store.RDFType, store.ClassArticle)
+		tripleStore.Add(uri, store.PropNumber, fmt.Sprint(number))
+		tripleStore.Add(uri, store.PropTitle, title)
+		tripleStore.Add(uri, store.PropPartOf, parent)
+		tripleStore.Add(uri, store.PropBelongsTo, regulation)
+		for i, text := range paragraphs {
+			paragraph := fmt.Sprintf("%s:Para%d", uri, i+1)
+			tripleStore.Add(paragraph, store.PropNumber, fmt.Sprint(i+1))
+			tripleStore.Add(paragraph, store.PropText, text)
+			tripleStore.Add(uri, store.PropHasParagraph, paragraph)
+		}
+		return uri
+	}
+
+	rights := addChapter("III", "Rights of the data subject")
+	remedies := addChapter("VIII", "Remedies, liability and penalties")
+	section := testBase + "GDPR:ChapterIII:Section3"
+	tripleStore.Add(section, store.RDFType, store.ClassSection)
+	tripleStore.Add(section, store.PropPartOf, rights)
+
+	art17 := addArticle(17, "Right to erasure", section,
+		"The data subject shall have the right to obtain erasure without undue delay.")
+	erasure := testBase + "GDPR:Right:17:RightToErasure"
+	tripleStore.Add(art17, store.PropGrantsRight, erasure)
+	tripleStore.Add(erasure, store.PropRightType, "RightToErasure")
+	tripleStore.Add(erasure, store.PropBeneficiary, "DataSubject")
+	tripleStore.Add(erasure, store.PropText, "right to obtain erasure")
+
+	art12 := addArticle(12, "Transparent information", rights,
+		"The controller shall provide information within one month of receipt of the request.")
+	inform := testBase + "GDPR:Obligation:12:TransparencyObligation"
+	tripleStore.Add(art12, store.PropImposesObligation, inform)
+	tripleStore.Add(inform, store.PropObligationType, "TransparencyObligation")
+	tripleStore.Add(inform, store.PropDutyBearer, "Controller")
+	tripleStore.Add(inform, store.PropRecurrence, "FREQ=YEARLY")
+
+	addArticle(13, "Information to be provided", rights, "Where personal data are collected, information is provided.")
+	addArticle(83, "General conditions for imposing administrative fines", remedies,
+		"Infringements shall be subject to administrative fines up to 20 000 000 EUR. Supervisory authorities may impose an administrative fine pursuant to this Article.")
+
+	return tripleStore
+}
+
+func TestSummarize(t *testing.T) {
+	summary, err := Summarize(buildSummaryTestStore(), "")
+	if err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+
+	want := Totals{Articles: 4, Obligations: 1, Rights: 1, Actors: 2, Deadlines: 2, Penalties: 1}
+	if summary.Totals != want {
+		t.Errorf("Totals = %+v, want %+v", summary.Totals, want)
+	}
+	if len(summary.Chapters) != 2 || summary.Chapters[0].Number != "III" || summary.Chapters[1].Number != "VIII" {
+		t.Fatalf("unexpected chapters: %+v", summary.Chapters)
+	}
+
+	rights := summary.Chapters[0]
+	if len(rights.Articles) != 3 || rights.Articles[0].Number != "12" || rights.Articles[2].Number != "17" {
+		t.Fatalf("articles not ordered within chapter: %+v", rights.Articles)
+	}
+	art12 := rights.Articles[0]
+	if len(art12.Obligations) != 1 || art12.Obligations[0].Recurrence == "" {
+		t.Errorf("expected a recurring obligation in Article 12: %+v", art12.Obligations)
+	}
+	if len(art12.Deadlines) != 1 || art12.Deadlines[0] != "within one month" {
+		t.Errorf("Article 12 deadlines = %v", art12.Deadlines)
+	}
+	art17 := rights.Articles[2]
+	if len(art17.Actors) != 1 || art17.Actors[0] != "DataSubject" {
+		t.Errorf("Article 17 actors = %v", art17.Actors)
+	}
+	if !rights.Articles[1].IsEmpty() {
+		t.Errorf("Article 13 should have nothing to report: %+v", rights.Articles[1])
+	}
+
+	penalties := summary.Chapters[1].Articles[0].Penalties
+	if len(penalties) != 1 || !strings.Contains(penalties[0], "20 000 000 EUR") {
+		t.Errorf("Article 83 penalties = %v", penalties)
+	}
+}
+
+func TestSummarize_NoRegulation(t *testing.T) {
+	if _, err := Summarize(store.NewTripleStore(), ""); err == nil {
+		t.Error("expected an error for an empty graph")
+	}
+}
+
+func TestSummary_Render(t *testing.T) {
+	summary, err := Summarize(buildSummaryTestStore(), "")
+	if err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+
+	markdown := summary.ToMarkdown()
+	for _, want := range []string{
+		"# Summary: GDPR — General Data Protection Regulation",
+		"## Chapter III: Rights of the data subject",
+		"### Article 17: Right to erasure",
+		"- right to erasure — data subject: “right to obtain erasure”",
+		"_1 of 3 articles have no extracted",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown missing %q:\n%s", want, markdown)
+		}
+	}
+	if strings.Contains(markdown, "Article 13") {
+		t.Error("markdown should not list articles with nothing to report")
+	}
+
+	page := summary.ToHTML()
+	if !strings.Contains(page, "<h3>Article 83: General conditions for imposing administrative fines</h3>") {
+		t.Errorf("html missing article heading:\n%s", page)
+	}
+}
+
+type fakeCompleter struct {
+	prompts []string
+}
+
+func (f *fakeCompleter) Complete(ctx context.Context, prompt string) (string, error) {
+	f.prompts = append(f.prompts, prompt)
+	if strings.Contains(prompt, "Chapter VIII") {
+		return "", fmt.Errorf("rate limited")
+	}
+	return "  Data subjects may have\ntheir data erased.  ", nil
+}
+
+func TestSummary_Polish(t *testing.T) {
+	summary, err := Summarize(buildSummaryTestStore(), "")
+	if err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+
+	client := &fakeCompleter{}
+	errs := summary.Polish(context.Background(), client)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "Chapter VIII") {
+		t.Errorf("errs = %v, want one error for Chapter VIII", errs)
+	}
+	if len(client.prompts) != 2 || !strings.Contains(client.prompts[0], "- right: right to erasure") {
+		t.Errorf("unexpected prompts: %q", client.prompts)
+	}
+	if summary.Chapters[0].Overview != "Data subjects may have their data erased." {
+		t.Errorf("Overview = %q", summary.Chapters[0].Overview)
+	}
+	if summary.Chapters[1].Overview != "" {
+		t.Errorf("failed chapter should have no overview, got %q", summary.Chapters[1].Overview)
+	}
+	if !strings.Contains(summary.ToMarkdown(), "_Overview generated by a language model._") {
+		t.Error("markdown should mark the generated overview")
+	}
+}
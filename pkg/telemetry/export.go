@@ -0,0 +1,48 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// JSONExporter writes each finished span as one JSON object per line.
+type JSONExporter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+// NewJSONExporter returns an exporter writing to w.
+func NewJSONExporter(w io.Writer) *JSONExporter {
+	return &JSONExporter{encoder: json.NewEncoder(w)}
+}
+
+// CreateJSONExporter creates (or truncates) the file at path and returns an
+// exporter writing to it. Close the exporter to close the file.
+func CreateJSONExporter(path string) (*JSONExporter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace file: %w", err)
+	}
+	exporter := NewJSONExporter(file)
+	exporter.closer = file
+	return exporter, nil
+}
+
+// ExportSpan implements Exporter.
+func (e *JSONExporter) ExportSpan(span SpanData) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.encoder.Encode(span)
+}
+
+// Close closes the underlying file, if the exporter opened one.
+func (e *JSONExporter) Close() error {
+	if e.closer == nil {
+		return nil
+	}
+	return e.closer.Close()
+}
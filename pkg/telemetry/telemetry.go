@@ -0,0 +1,237 @@
+// Package telemetry records how long pipeline stages take. A Span times one
+// stage (parse, extract, build, query) and, when it ends, logs a structured
+// record through log/slog and hands the finished span to the registered
+// Exporter, if any.
+//
+// Attributes attached to a context with With, such as the document ID, are
+// added to every span and log record started from it, so a bulk ingest
+// can be broken down per document and per stage.
+//
+// Spans carry trace and span IDs in the OpenTelemetry format and nest
+// through the context, so an Exporter can forward them to an OpenTelemetry
+// collector. JSONExporter writes them as JSON lines for offline analysis.
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Attribute keys shared across packages.
+const (
+	KeyStage      = "stage"
+	KeyDocumentID = "document_id"
+	KeyDurationMs = "duration_ms"
+	KeyError      = "error"
+)
+
+// SpanData is a finished span.
+type SpanData struct {
+	TraceID    string         `json:"trace_id"`
+	SpanID     string         `json:"span_id"`
+	ParentID   string         `json:"parent_span_id,omitempty"`
+	Name       string         `json:"name"`
+	Start      time.Time      `json:"start"`
+	End        time.Time      `json:"end"`
+	DurationMs float64        `json:"duration_ms"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	Error      string         `json:"error,omitempty"`
+
+	// AllocBytes and Mallocs are the heap bytes and objects allocated
+	// while the span was open, across all goroutines. They are recorded
+	// only while memory tracking is on (see SetMemoryTracking).
+	AllocBytes uint64 `json:"alloc_bytes,omitempty"`
+	Mallocs    uint64 `json:"mallocs,omitempty"`
+}
+
+// Exporter receives spans as they end. Implementations must be safe for
+// concurrent use.
+type Exporter interface {
+	ExportSpan(span SpanData)
+}
+
+var (
+	exporterMu sync.RWMutex
+	exporter   Exporter
+)
+
+// SetExporter registers the exporter that receives finished spans and
+// returns the previous one. A nil exporter disables export; spans are then
+// only logged.
+func SetExporter(e Exporter) Exporter {
+	exporterMu.Lock()
+	defer exporterMu.Unlock()
+	previous := exporter
+	exporter = e
+	return previous
+}
+
+func currentExporter() Exporter {
+	exporterMu.RLock()
+	defer exporterMu.RUnlock()
+	return exporter
+}
+
+var memoryTracking atomic.Bool
+
+// SetMemoryTracking turns allocation accounting for spans on or off. It
+// reads runtime memory statistics at the start and end of every span, which
+// briefly stops the world, so it is meant for profiling runs only.
+func SetMemoryTracking(enabled bool) {
+	memoryTracking.Store(enabled)
+}
+
+func readAllocations() (allocBytes, mallocs uint64) {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	return memStats.TotalAlloc, memStats.Mallocs
+}
+
+type attrsKey struct{}
+type spanKey struct{}
+
+// With returns a context whose spans and log records carry attrs. An
+// attribute replaces one already on ctx with the same key.
+func With(ctx context.Context, attrs ...slog.Attr) context.Context {
+	inherited := Attrs(ctx)
+	combined := make([]slog.Attr, 0, len(inherited)+len(attrs))
+	for _, attr := range inherited {
+		if !hasKey(attrs, attr.Key) {
+			combined = append(combined, attr)
+		}
+	}
+	combined = append(combined, attrs...)
+	return context.WithValue(ctx, attrsKey{}, combined)
+}
+
+func hasKey(attrs []slog.Attr, key string) bool {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Attrs returns the attributes attached to ctx with With.
+func Attrs(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// Span times one pipeline stage. End it exactly once.
+type Span struct {
+	ctx      context.Context
+	traceID  string
+	spanID   string
+	parentID string
+	name     string
+	start    time.Time
+	attrs    []slog.Attr
+	err      error
+
+	trackMemory bool
+	allocStart  uint64
+	mallocStart uint64
+}
+
+// Start begins a span named stage, a child of the span in ctx if there is
+// one. The returned context carries the span for nested stages.
+func Start(ctx context.Context, stage string, attrs ...slog.Attr) (context.Context, *Span) {
+	span := &Span{
+		spanID: newID(8),
+		name:   stage,
+		start:  time.Now(),
+		attrs:  attrs,
+	}
+	if parent, ok := ctx.Value(spanKey{}).(*Span); ok {
+		span.traceID = parent.traceID
+		span.parentID = parent.spanID
+	} else {
+		span.traceID = newID(16)
+	}
+	span.ctx = context.WithValue(ctx, spanKey{}, span)
+	if memoryTracking.Load() {
+		span.trackMemory = true
+		span.allocStart, span.mallocStart = readAllocations()
+		span.start = time.Now()
+	}
+	return span.ctx, span
+}
+
+// SetAttributes adds attributes to the span, such as result counts known
+// only once the stage finishes.
+func (span *Span) SetAttributes(attrs ...slog.Attr) {
+	span.attrs = append(span.attrs, attrs...)
+}
+
+// RecordError marks the span as failed.
+func (span *Span) RecordError(err error) {
+	span.err = err
+}
+
+// End finishes the span, logs it at debug level (warn if it failed), and
+// exports it. It returns the span's duration.
+func (span *Span) End() time.Duration {
+	end := time.Now()
+	duration := end.Sub(span.start)
+	var allocBytes, mallocs uint64
+	if span.trackMemory {
+		allocEnd, mallocEnd := readAllocations()
+		allocBytes, mallocs = allocEnd-span.allocStart, mallocEnd-span.mallocStart
+	}
+	durationMs := float64(duration.Microseconds()) / 1000
+
+	inherited := Attrs(span.ctx)
+	logAttrs := make([]slog.Attr, 0, len(inherited)+len(span.attrs)+3)
+	logAttrs = append(logAttrs, slog.String(KeyStage, span.name))
+	logAttrs = append(logAttrs, inherited...)
+	logAttrs = append(logAttrs, span.attrs...)
+	logAttrs = append(logAttrs, slog.Float64(KeyDurationMs, durationMs))
+
+	level, message := slog.LevelDebug, "stage finished"
+	if span.err != nil {
+		level, message = slog.LevelWarn, "stage failed"
+		logAttrs = append(logAttrs, slog.String(KeyError, span.err.Error()))
+	}
+	slog.Default().LogAttrs(span.ctx, level, message, logAttrs...)
+
+	if exporter := currentExporter(); exporter != nil {
+		data := SpanData{
+			TraceID:    span.traceID,
+			SpanID:     span.spanID,
+			ParentID:   span.parentID,
+			Name:       span.name,
+			Start:      span.start,
+			End:        end,
+			DurationMs: durationMs,
+			AllocBytes: allocBytes,
+			Mallocs:    mallocs,
+		}
+		if len(inherited)+len(span.attrs) > 0 {
+			data.Attributes = make(map[string]any, len(inherited)+len(span.attrs))
+			for _, attr := range logAttrs[1 : 1+len(inherited)+len(span.attrs)] {
+				data.Attributes[attr.Key] = attr.Value.Resolve().Any()
+			}
+		}
+		if span.err != nil {
+			data.Error = span.err.Error()
+		}
+		exporter.ExportSpan(data)
+	}
+	return duration
+}
+
+// newID returns n random bytes as hex: 16 for trace IDs and 8 for span IDs,
+// as in OpenTelemetry.
+func newID(n int) string {
+	buf := make([]byte, n)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
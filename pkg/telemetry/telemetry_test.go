@@ -0,0 +1,158 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type recordingExporter struct {
+	mu    sync.Mutex
+	spans []SpanData
+}
+
+func (r *recordingExporter) ExportSpan(span SpanData) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, span)
+}
+
+// capture routes spans and debug logs to buffers for the test.
+func capture(t *testing.T) (*recordingExporter, *bytes.Buffer) {
+	t.Helper()
+	var logs bytes.Buffer
+	previousLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	recorder := &recordingExporter{}
+	previousExporter := SetExporter(recorder)
+	t.Cleanup(func() {
+		slog.SetDefault(previousLogger)
+		SetExporter(previousExporter)
+	})
+	return recorder, &logs
+}
+
+func TestSpan_NestsUnderParent(t *testing.T) {
+	recorder, _ := capture(t)
+
+	ctx, parent := Start(context.Background(), "ingest")
+	_, child := Start(ctx, "parse")
+	child.End()
+	parent.End()
+
+	if len(recorder.spans) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(recorder.spans))
+	}
+	parse, ingest := recorder.spans[0], recorder.spans[1]
+	if parse.Name != "parse" || ingest.Name != "ingest" {
+		t.Fatalf("spans = %q, %q", parse.Name, ingest.Name)
+	}
+	if parse.TraceID != ingest.TraceID || parse.ParentID != ingest.SpanID {
+		t.Errorf("parse span %+v is not a child of %+v", parse, ingest)
+	}
+	if ingest.ParentID != "" {
+		t.Errorf("root span has parent %q", ingest.ParentID)
+	}
+	if len(ingest.TraceID) != 32 || len(ingest.SpanID) != 16 {
+		t.Errorf("IDs %q/%q are not OpenTelemetry-sized", ingest.TraceID, ingest.SpanID)
+	}
+}
+
+func TestSpan_LogsContextAttributes(t *testing.T) {
+	recorder, logs := capture(t)
+
+	ctx := With(context.Background(), slog.String(KeyDocumentID, "gdpr"))
+	_, span := Start(ctx, "build", slog.Int("triples", 42))
+	span.End()
+
+	var record map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &record); err != nil {
+		t.Fatalf("log is not JSON: %v\n%s", err, logs)
+	}
+	if record["msg"] != "stage finished" || record["level"] != "DEBUG" {
+		t.Errorf("record = %v", record)
+	}
+	if record[KeyStage] != "build" || record[KeyDocumentID] != "gdpr" || record["triples"] != float64(42) {
+		t.Errorf("record attributes = %v", record)
+	}
+	if _, ok := record[KeyDurationMs]; !ok {
+		t.Errorf("record has no duration: %v", record)
+	}
+
+	exported := recorder.spans[0].Attributes
+	if exported[KeyDocumentID] != "gdpr" || exported["triples"] != int64(42) {
+		t.Errorf("exported attributes = %v", exported)
+	}
+}
+
+func TestSpan_RecordErrorLogsWarning(t *testing.T) {
+	recorder, logs := capture(t)
+
+	_, span := Start(context.Background(), "parse")
+	span.RecordError(errors.New("no articles"))
+	span.End()
+
+	if !strings.Contains(logs.String(), `"level":"WARN"`) || !strings.Contains(logs.String(), `"error":"no articles"`) {
+		t.Errorf("log = %s", logs)
+	}
+	if recorder.spans[0].Error != "no articles" {
+		t.Errorf("exported error = %q", recorder.spans[0].Error)
+	}
+}
+
+func TestWith_ReplacesKey(t *testing.T) {
+	ctx := With(context.Background(), slog.String(KeyDocumentID, "a"), slog.String("source", "cfr"))
+	ctx = With(ctx, slog.String(KeyDocumentID, "b"))
+
+	attrs := Attrs(ctx)
+	if len(attrs) != 2 {
+		t.Fatalf("attrs = %v", attrs)
+	}
+	for _, attr := range attrs {
+		if attr.Key == KeyDocumentID && attr.Value.String() != "b" {
+			t.Errorf("%s = %s, want b", attr.Key, attr.Value)
+		}
+	}
+}
+
+func TestJSONExporter(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := NewJSONExporter(&buf)
+	exporter.ExportSpan(SpanData{TraceID: "t", SpanID: "s", Name: "query", DurationMs: 1.5})
+	exporter.ExportSpan(SpanData{TraceID: "t", SpanID: "u", ParentID: "s", Name: "plan"})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("wrote %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+	var span SpanData
+	if err := json.Unmarshal([]byte(lines[1]), &span); err != nil {
+		t.Fatal(err)
+	}
+	if span.Name != "plan" || span.ParentID != "s" {
+		t.Errorf("decoded span = %+v", span)
+	}
+}
+
+func TestSetMemoryTracking(t *testing.T) {
+	recorder, _ := capture(t)
+	SetMemoryTracking(true)
+	defer SetMemoryTracking(false)
+
+	_, span := Start(context.Background(), "allocate")
+	buffers := make([][]byte, 0, 64)
+	for i := 0; i < 64; i++ {
+		buffers = append(buffers, make([]byte, 4096))
+	}
+	span.End()
+	_ = buffers
+
+	if recorder.spans[0].AllocBytes < 64*4096 || recorder.spans[0].Mallocs == 0 {
+		t.Errorf("allocations = %d bytes, %d objects", recorder.spans[0].AllocBytes, recorder.spans[0].Mallocs)
+	}
+}
@@ -0,0 +1,49 @@
+package textutil
+
+import (
+	"sort"
+	"strconv"
+)
+
+// ArticleOrder returns the leading number of an article or section number
+// such as "17" or "1798.100", for ordering. Numbers without a leading digit
+// sort after every numbered one.
+func ArticleOrder(number string) int {
+	end := 0
+	for end < len(number) && number[end] >= '0' && number[end] <= '9' {
+		end++
+	}
+	value, err := strconv.Atoi(number[:end])
+	if err != nil {
+		return 1 << 30
+	}
+	return value
+}
+
+// RomanToInt converts a Roman numeral to an integer.
+func RomanToInt(s string) int {
+	values := map[rune]int{
+		'I': 1, 'V': 5, 'X': 10, 'L': 50,
+		'C': 100, 'D': 500, 'M': 1000,
+	}
+
+	result := 0
+	prev := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		val := values[rune(s[i])]
+		if val < prev {
+			result -= val
+		} else {
+			result += val
+		}
+		prev = val
+	}
+	return result
+}
+
+// SortRomanNumerals sorts Roman numerals in numeric order.
+func SortRomanNumerals(numerals []string) {
+	sort.Slice(numerals, func(i, j int) bool {
+		return RomanToInt(numerals[i]) < RomanToInt(numerals[j])
+	})
+}
@@ -0,0 +1,62 @@
+package textutil
+
+import "testing"
+
+func TestArticleOrder(t *testing.T) {
+	testCases := []struct {
+		number   string
+		expected int
+	}{
+		{"17", 17},
+		{"1798.100", 1798},
+		{"6a", 6},
+		{"IV", 1 << 30},
+		{"", 1 << 30},
+	}
+
+	for _, testCase := range testCases {
+		if got := ArticleOrder(testCase.number); got != testCase.expected {
+			t.Errorf("ArticleOrder(%q) = %d, want %d", testCase.number, got, testCase.expected)
+		}
+	}
+}
+
+func TestRomanToInt(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"I", 1},
+		{"II", 2},
+		{"III", 3},
+		{"IV", 4},
+		{"V", 5},
+		{"IX", 9},
+		{"X", 10},
+		{"XI", 11},
+		{"XVIII", 18},
+		{"XIX", 19},
+		{"XX", 20},
+		{"XXI", 21},
+		{"XXIX", 29},
+	}
+
+	for _, tc := range tests {
+		result := RomanToInt(tc.input)
+		if result != tc.expected {
+			t.Errorf("RomanToInt(%q): expected %d, got %d", tc.input, tc.expected, result)
+		}
+	}
+}
+
+func TestSortRomanNumerals(t *testing.T) {
+	numerals := []string{"X", "I", "XX", "V", "III", "IX", "XVIII"}
+	SortRomanNumerals(numerals)
+
+	expected := []string{"I", "III", "V", "IX", "X", "XVIII", "XX"}
+	for i, exp := range expected {
+		if numerals[i] != exp {
+			t.Errorf("Position %d: expected %q, got %q", i, exp, numerals[i])
+		}
+	}
+}
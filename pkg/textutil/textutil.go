@@ -0,0 +1,178 @@
+// Package textutil provides Unicode-safe helpers for measuring, truncating,
+// and slicing regulation text. Titles and provisions in accented Latin,
+// Greek, Cyrillic, CJK, and right-to-left scripts are multi-byte in UTF-8,
+// so byte slicing can cut a character in half; these helpers count runes
+// and only cut on character boundaries. It also orders provision numbers:
+// numeric article and section numbers and Roman numerals.
+//
+// Extraction offsets (Reference.TextOffset, TermUsage.TextOffset) remain
+// byte offsets into the source text, matching Go string indexing.
+package textutil
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Ellipsis is appended to truncated text.
+const Ellipsis = "..."
+
+// zeroWidthJoiner joins adjacent characters into one glyph, as in emoji
+// sequences and some Indic and Arabic ligatures.
+const zeroWidthJoiner = '\u200d'
+
+// RuneLen returns the number of characters (runes) in text.
+func RuneLen(text string) int {
+	return utf8.RuneCountInString(text)
+}
+
+// Truncate shortens text to at most maxRunes characters, replacing the tail
+// with "..." when it is cut. The cut never splits a character or separates
+// a base character from its combining marks. When maxRunes is too small to
+// fit the ellipsis, the text is cut without one.
+func Truncate(text string, maxRunes int) string {
+	if RuneLen(text) <= maxRunes {
+		return text
+	}
+	if maxRunes < len(Ellipsis) {
+		return TruncateRunes(text, maxRunes)
+	}
+	return TruncateRunes(text, maxRunes-len(Ellipsis)) + Ellipsis
+}
+
+// TruncateRunes returns at most the first maxRunes characters of text,
+// without an ellipsis. Like Truncate, it backs off rather than splitting a
+// combining sequence.
+func TruncateRunes(text string, maxRunes int) string {
+	if maxRunes <= 0 {
+		return ""
+	}
+	cut := byteOffset(text, maxRunes)
+	for cut > 0 && cut < len(text) {
+		next, _ := utf8.DecodeRuneInString(text[cut:])
+		previous, size := utf8.DecodeLastRuneInString(text[:cut])
+		if !isCombining(next) && previous != zeroWidthJoiner {
+			break
+		}
+		cut -= size
+	}
+	return text[:cut]
+}
+
+// isCombining reports whether char attaches to the preceding character:
+// a combining mark, variation selector, or zero-width joiner.
+func isCombining(char rune) bool {
+	return unicode.In(char, unicode.Mn, unicode.Me) || char == zeroWidthJoiner
+}
+
+// byteOffset returns the byte offset of the character at runeOffset,
+// clamped to the length of text.
+func byteOffset(text string, runeOffset int) int {
+	if runeOffset <= 0 {
+		return 0
+	}
+	count := 0
+	for index := range text {
+		if count == runeOffset {
+			return index
+		}
+		count++
+	}
+	return len(text)
+}
+
+// runeStart moves byteIndex back to the start of the character containing
+// it, so text[:runeStart(text, i)] never ends mid-character.
+func runeStart(text string, byteIndex int) int {
+	if byteIndex <= 0 {
+		return 0
+	}
+	if byteIndex >= len(text) {
+		return len(text)
+	}
+	for byteIndex > 0 && !utf8.RuneStart(text[byteIndex]) {
+		byteIndex--
+	}
+	return byteIndex
+}
+
+// ContextBounds returns byte bounds of the window around text[start:end]
+// extended by up to contextRunes characters on each side. The bounds fall
+// on character boundaries and are clamped to the text.
+func ContextBounds(text string, start, end, contextRunes int) (int, int) {
+	start = runeStart(text, start)
+	end = runeStart(text, end)
+	if end < start {
+		end = start
+	}
+
+	contextStart := start
+	for i := 0; i < contextRunes && contextStart > 0; i++ {
+		_, size := utf8.DecodeLastRuneInString(text[:contextStart])
+		contextStart -= size
+	}
+	contextEnd := end
+	for i := 0; i < contextRunes && contextEnd < len(text); i++ {
+		_, size := utf8.DecodeRuneInString(text[contextEnd:])
+		contextEnd += size
+	}
+	return contextStart, contextEnd
+}
+
+// Sanitize replaces invalid UTF-8 with U+FFFD and removes control
+// characters other than tab, newline, and carriage return, which are not
+// allowed in XML and garble terminal output.
+func Sanitize(text string) string {
+	text = strings.ToValidUTF8(text, "\ufffd")
+	if strings.IndexFunc(text, isDisallowedControl) < 0 {
+		return text
+	}
+	return strings.Map(func(char rune) rune {
+		if isDisallowedControl(char) {
+			return -1
+		}
+		return char
+	}, text)
+}
+
+func isDisallowedControl(char rune) bool {
+	return unicode.IsControl(char) && char != '\t' && char != '\n' && char != '\r'
+}
+
+// containsRTL reports whether text contains right-to-left script, such as
+// Arabic or Hebrew.
+func containsRTL(text string) bool {
+	for _, char := range text {
+		if unicode.In(char, unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsolateBidi wraps text containing right-to-left script in Unicode
+// first-strong isolate marks (U+2068 ... U+2069) so that, when embedded in
+// left-to-right output such as a table row, it cannot reorder the
+// surrounding punctuation, ellipsis, or column separators. Other text is
+// returned unchanged.
+func IsolateBidi(text string) string {
+	if !containsRTL(text) {
+		return text
+	}
+	return "\u2068" + text + "\u2069"
+}
+
+// Humanize turns a CamelCase identifier such as "DataSubject" or
+// "RightToErasure" into lowercase words: "data subject", "right to erasure".
+func Humanize(identifier string) string {
+	var builder strings.Builder
+	runes := []rune(identifier)
+	for i, char := range runes {
+		if i > 0 && unicode.IsUpper(char) && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			builder.WriteRune(' ')
+		}
+		builder.WriteRune(unicode.ToLower(char))
+	}
+	return builder.String()
+}
@@ -0,0 +1,143 @@
+package textutil
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		maxRunes int
+		expected string
+	}{
+		{"short ASCII unchanged", "GDPR", 10, "GDPR"},
+		{"ASCII truncated", "General Data Protection", 10, "General..."},
+		{"accented Latin", "Règlement général sur la protection", 12, "Règlement..."},
+		{"German umlauts", "Datenschutz-Grundverordnung über", 30, "Datenschutz-Grundverordnung..."},
+		{"Greek", "Γενικός Κανονισμός", 10, "Γενικός..."},
+		{"Cyrillic at limit", "Регламент", 9, "Регламент"},
+		{"CJK", "一般データ保護規則", 8, "一般データ..."},
+		{"Arabic", "اللائحة العامة لحماية البيانات", 10, "اللائحة..."},
+		{"Hebrew", "תקנת הגנת המידע הכללית", 7, "תקנת..."},
+		{"only ellipsis", "Ελλάδα", 3, "..."},
+		{"no room for ellipsis", "Ελλάδα", 2, "Ελ"},
+		{"zero", "Ελλάδα", 0, ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result := Truncate(testCase.text, testCase.maxRunes)
+			if result != testCase.expected {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", testCase.text, testCase.maxRunes, result, testCase.expected)
+			}
+			if !utf8.ValidString(result) {
+				t.Errorf("Truncate(%q, %d) produced invalid UTF-8", testCase.text, testCase.maxRunes)
+			}
+			if RuneLen(result) > testCase.maxRunes && testCase.maxRunes >= 0 {
+				t.Errorf("Truncate(%q, %d) has %d runes", testCase.text, testCase.maxRunes, RuneLen(result))
+			}
+		})
+	}
+}
+
+func TestTruncateRunes_CombiningSequences(t *testing.T) {
+	// "é" as e + U+0301 must not lose its accent
+	decomposed := "cafe\u0301 noir"
+	if result := TruncateRunes(decomposed, 4); result != "caf" {
+		t.Errorf("TruncateRunes split combining sequence: %q", result)
+	}
+	if result := TruncateRunes(decomposed, 5); result != "cafe\u0301" {
+		t.Errorf("TruncateRunes(5) = %q, want full combining sequence", result)
+	}
+
+	// Emoji joined with a zero-width joiner stay together
+	joined := "ab\U0001F469\u200d\U0001F4BB"
+	if result := TruncateRunes(joined, 4); result != "ab" {
+		t.Errorf("TruncateRunes split joiner sequence: %q", result)
+	}
+}
+
+func TestOffsets(t *testing.T) {
+	text := "Artículo 5 — λόγος"
+
+	offset := byteOffset(text, 9)
+	if text[offset:offset+1] != "5" {
+		t.Errorf("byteOffset(9) = %d, points at %q", offset, text[offset:])
+	}
+
+	// An offset inside "í" snaps back to its start
+	accentStart := byteOffset(text, 3)
+	if start := runeStart(text, accentStart+1); start != accentStart {
+		t.Errorf("runeStart inside multi-byte character = %d, want %d", start, accentStart)
+	}
+
+	if byteOffset(text, 1000) != len(text) {
+		t.Error("offset past the end should clamp")
+	}
+}
+
+func TestContextBounds(t *testing.T) {
+	text := "Ωμέγα Article 17 Ωμέγα"
+	start := byteOffset(text, 6)
+	end := byteOffset(text, 16)
+
+	contextStart, contextEnd := ContextBounds(text, start, end, 3)
+	context := text[contextStart:contextEnd]
+	if context != "γα Article 17 Ωμ" {
+		t.Errorf("ContextBounds context = %q", context)
+	}
+
+	contextStart, contextEnd = ContextBounds(text, start, end, 100)
+	if contextStart != 0 || contextEnd != len(text) {
+		t.Errorf("ContextBounds should clamp, got [%d:%d]", contextStart, contextEnd)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"clean text unchanged", "Überblick\tend\n", "Überblick\tend\n"},
+		{"control characters removed", "form\x0cfeed\x00", "formfeed"},
+		{"invalid UTF-8 replaced", "caf\xe9", "caf\ufffd"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if result := Sanitize(testCase.text); result != testCase.expected {
+				t.Errorf("Sanitize(%q) = %q, want %q", testCase.text, result, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestIsolateBidi(t *testing.T) {
+	if result := IsolateBidi("Article 5"); result != "Article 5" {
+		t.Errorf("IsolateBidi changed left-to-right text: %q", result)
+	}
+	if !containsRTL("المادة 5") || !containsRTL("סעיף 5") {
+		t.Error("containsRTL missed Arabic or Hebrew")
+	}
+	if result := IsolateBidi("المادة 5"); result != "\u2068المادة 5\u2069" {
+		t.Errorf("IsolateBidi(Arabic) = %q", result)
+	}
+}
+
+func TestHumanize(t *testing.T) {
+	tests := map[string]string{
+		"DataSubject":                   "data subject",
+		"RightToErasure":                "right to erasure",
+		"RightAgainstAutomatedDecision": "right against automated decision",
+		"DPOAppointment":                "dpo appointment",
+		"Obligation":                    "obligation",
+	}
+	for input, want := range tests {
+		if got := Humanize(input); got != want {
+			t.Errorf("Humanize(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,384 @@
+// Package usage tracks which provisions and query templates are consulted
+// in server mode, and reports the most-consulted provisions per document
+// ("hot spots") so compliance teams can see where attention concentrates.
+//
+// A Tracker counts accesses in memory and persists them as JSON, by default
+// usage.json in the library directory. Servers record each answered request;
+// `regula usage hotspots` reads the file back.
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// FileName is the usage file name within a library directory.
+const FileName = "usage.json"
+
+// MaxProvisionsPerRequest bounds how many provisions one request may
+// credit. Listing queries that return more (e.g. every article) say little
+// about what was consulted, so they count toward templates but not
+// provisions.
+const MaxProvisionsPerRequest = 50
+
+// provisionClasses are the node types that count as provisions.
+var provisionClasses = map[string]bool{
+	store.ClassChapter:   true,
+	store.ClassSection:   true,
+	store.ClassArticle:   true,
+	store.ClassParagraph: true,
+	store.ClassPoint:     true,
+	store.ClassSubPoint:  true,
+	store.ClassRecital:   true,
+}
+
+// ProvisionUsage counts accesses to one provision.
+type ProvisionUsage struct {
+	Document     string    `json:"document"`
+	Count        int       `json:"count"`
+	LastAccessed time.Time `json:"last_accessed"`
+}
+
+// Stats is the persisted usage record.
+type Stats struct {
+	Since      time.Time                  `json:"since"`
+	UpdatedAt  time.Time                  `json:"updated_at"`
+	Requests   int                        `json:"requests"`
+	Templates  map[string]int             `json:"templates"`
+	Provisions map[string]*ProvisionUsage `json:"provisions"`
+}
+
+// Tracker records provision and template accesses. It is safe for
+// concurrent use.
+type Tracker struct {
+	path string
+
+	mu    sync.Mutex
+	stats *Stats
+	dirty bool
+}
+
+// Open loads the usage file at path, or starts an empty record if it does
+// not exist yet.
+func Open(path string) (*Tracker, error) {
+	stats, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Tracker{path: path, stats: stats}, nil
+}
+
+// Load reads a usage file. A missing file returns empty stats.
+func Load(path string) (*Stats, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return newStats(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read usage file: %w", err)
+	}
+
+	stats := newStats()
+	if err := json.Unmarshal(data, stats); err != nil {
+		return nil, fmt.Errorf("failed to parse usage file %s: %w", path, err)
+	}
+	if stats.Templates == nil {
+		stats.Templates = make(map[string]int)
+	}
+	if stats.Provisions == nil {
+		stats.Provisions = make(map[string]*ProvisionUsage)
+	}
+	return stats, nil
+}
+
+func newStats() *Stats {
+	now := time.Now().UTC()
+	return &Stats{
+		Since:      now,
+		UpdatedAt:  now,
+		Templates:  make(map[string]int),
+		Provisions: make(map[string]*ProvisionUsage),
+	}
+}
+
+// Record counts one answered request: the template it used, if any, and
+// the provisions it consulted. Duplicate provisions are counted once, and
+// requests with more than MaxProvisionsPerRequest provisions credit none.
+func (tracker *Tracker) Record(template string, provisionURIs []string) {
+	unique := dedupe(provisionURIs)
+	now := time.Now().UTC()
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	tracker.stats.Requests++
+	tracker.stats.UpdatedAt = now
+	tracker.dirty = true
+	if template != "" {
+		tracker.stats.Templates[template]++
+	}
+	if len(unique) > MaxProvisionsPerRequest {
+		return
+	}
+	for _, uri := range unique {
+		provision := tracker.stats.Provisions[uri]
+		if provision == nil {
+			provision = &ProvisionUsage{Document: DocumentOf(uri)}
+			tracker.stats.Provisions[uri] = provision
+		}
+		provision.Count++
+		provision.LastAccessed = now
+	}
+}
+
+// Snapshot returns a copy of the current stats.
+func (tracker *Tracker) Snapshot() *Stats {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	snapshot := *tracker.stats
+	snapshot.Templates = make(map[string]int, len(tracker.stats.Templates))
+	for name, count := range tracker.stats.Templates {
+		snapshot.Templates[name] = count
+	}
+	snapshot.Provisions = make(map[string]*ProvisionUsage, len(tracker.stats.Provisions))
+	for uri, provision := range tracker.stats.Provisions {
+		provisionCopy := *provision
+		snapshot.Provisions[uri] = &provisionCopy
+	}
+	return &snapshot
+}
+
+// Flush writes the usage file if anything was recorded since the last
+// flush.
+func (tracker *Tracker) Flush() error {
+	tracker.mu.Lock()
+	if !tracker.dirty {
+		tracker.mu.Unlock()
+		return nil
+	}
+	data, err := json.MarshalIndent(tracker.stats, "", "  ")
+	tracker.dirty = false
+	tracker.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(tracker.path), 0755); err != nil {
+		return fmt.Errorf("failed to create usage directory: %w", err)
+	}
+	// Write then rename, so a crash mid-write never leaves a torn file
+	tempPath := tracker.path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write usage file: %w", err)
+	}
+	if err := os.Rename(tempPath, tracker.path); err != nil {
+		return fmt.Errorf("failed to write usage file: %w", err)
+	}
+	return nil
+}
+
+// Run flushes every interval until ctx is done, then flushes once more.
+func (tracker *Tracker) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := tracker.Flush(); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return tracker.Flush()
+		}
+	}
+}
+
+// ProvisionURIs returns the distinct values that name provision nodes in
+// tripleStore, in first-seen order. Servers pass every URI in a response.
+func ProvisionURIs(tripleStore *store.TripleStore, values []string) []string {
+	var provisions []string
+	for _, value := range dedupe(values) {
+		for _, class := range tripleStore.Find(value, store.RDFType, "") {
+			if provisionClasses[class.Object] {
+				provisions = append(provisions, value)
+				break
+			}
+		}
+	}
+	return provisions
+}
+
+// DocumentOf returns the document prefix of a provision URI, e.g. "GDPR"
+// for https://regula.dev/regulations/GDPR:Art17.
+func DocumentOf(uri string) string {
+	localName := uri[strings.LastIndex(uri, "/")+1:]
+	if colon := strings.Index(localName, ":"); colon >= 0 {
+		return localName[:colon]
+	}
+	return localName
+}
+
+// ShortID returns the provision part of a URI, e.g. "Art17" for
+// https://regula.dev/regulations/GDPR:Art17.
+func ShortID(uri string) string {
+	localName := uri[strings.LastIndex(uri, "/")+1:]
+	if colon := strings.Index(localName, ":"); colon >= 0 {
+		return localName[colon+1:]
+	}
+	return localName
+}
+
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		unique = append(unique, value)
+	}
+	return unique
+}
+
+// Hotspot is one provision in a hot-spot ranking.
+type Hotspot struct {
+	URI          string    `json:"uri"`
+	Provision    string    `json:"provision"`
+	Count        int       `json:"count"`
+	Share        float64   `json:"share"`
+	LastAccessed time.Time `json:"last_accessed"`
+}
+
+// DocumentHotspots ranks the most-consulted provisions of one document.
+// Share is a provision's fraction of the document's provision accesses.
+type DocumentHotspots struct {
+	Document   string    `json:"document"`
+	Accesses   int       `json:"accesses"`
+	Provisions []Hotspot `json:"provisions"`
+}
+
+// TemplateUsage counts uses of one query template.
+type TemplateUsage struct {
+	Template string `json:"template"`
+	Count    int    `json:"count"`
+}
+
+// HotspotReport is the output of `regula usage hotspots`.
+type HotspotReport struct {
+	Since     time.Time          `json:"since"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	Requests  int                `json:"requests"`
+	Documents []DocumentHotspots `json:"documents"`
+	Templates []TemplateUsage    `json:"templates"`
+}
+
+// Hotspots ranks provisions per document by access count, keeping the top
+// limit of each (all when limit <= 0). A non-empty document restricts the
+// report to that document, matched case-insensitively. Documents are
+// ordered by total accesses.
+func Hotspots(stats *Stats, document string, limit int) *HotspotReport {
+	report := &HotspotReport{
+		Since:     stats.Since,
+		UpdatedAt: stats.UpdatedAt,
+		Requests:  stats.Requests,
+		Documents: []DocumentHotspots{},
+		Templates: []TemplateUsage{},
+	}
+
+	byDocument := make(map[string]*DocumentHotspots)
+	for uri, provision := range stats.Provisions {
+		if document != "" && !strings.EqualFold(provision.Document, document) {
+			continue
+		}
+		entry := byDocument[provision.Document]
+		if entry == nil {
+			entry = &DocumentHotspots{Document: provision.Document}
+			byDocument[provision.Document] = entry
+		}
+		entry.Accesses += provision.Count
+		entry.Provisions = append(entry.Provisions, Hotspot{
+			URI:          uri,
+			Provision:    ShortID(uri),
+			Count:        provision.Count,
+			LastAccessed: provision.LastAccessed,
+		})
+	}
+
+	for _, entry := range byDocument {
+		sort.Slice(entry.Provisions, func(i, j int) bool {
+			if entry.Provisions[i].Count != entry.Provisions[j].Count {
+				return entry.Provisions[i].Count > entry.Provisions[j].Count
+			}
+			return entry.Provisions[i].URI < entry.Provisions[j].URI
+		})
+		for i := range entry.Provisions {
+			entry.Provisions[i].Share = float64(entry.Provisions[i].Count) / float64(entry.Accesses)
+		}
+		if limit > 0 && len(entry.Provisions) > limit {
+			entry.Provisions = entry.Provisions[:limit]
+		}
+		report.Documents = append(report.Documents, *entry)
+	}
+	sort.Slice(report.Documents, func(i, j int) bool {
+		if report.Documents[i].Accesses != report.Documents[j].Accesses {
+			return report.Documents[i].Accesses > report.Documents[j].Accesses
+		}
+		return report.Documents[i].Document < report.Documents[j].Document
+	})
+
+	for name, count := range stats.Templates {
+		report.Templates = append(report.Templates, TemplateUsage{Template: name, Count: count})
+	}
+	sort.Slice(report.Templates, func(i, j int) bool {
+		if report.Templates[i].Count != report.Templates[j].Count {
+			return report.Templates[i].Count > report.Templates[j].Count
+		}
+		return report.Templates[i].Template < report.Templates[j].Template
+	})
+	return report
+}
+
+// FormatHotspotsTable formats a hot-spot report for terminal output.
+func FormatHotspotsTable(report *HotspotReport) string {
+	var builder strings.Builder
+
+	builder.WriteString("\nProvision Hot Spots\n")
+	builder.WriteString(strings.Repeat("═", 72) + "\n")
+	builder.WriteString(fmt.Sprintf("  %d request(s) since %s\n",
+		report.Requests, report.Since.Format("2006-01-02 15:04")))
+
+	if len(report.Documents) == 0 {
+		builder.WriteString("\n  No provision accesses recorded.\n")
+	}
+	for _, document := range report.Documents {
+		builder.WriteString(fmt.Sprintf("\n  %s (%d accesses)\n", document.Document, document.Accesses))
+		builder.WriteString(fmt.Sprintf("  %-4s  %-30s  %8s  %6s  %s\n", "RANK", "PROVISION", "COUNT", "SHARE", "LAST ACCESSED"))
+		builder.WriteString("  " + strings.Repeat("─", 70) + "\n")
+		for rank, hotspot := range document.Provisions {
+			builder.WriteString(fmt.Sprintf("  %-4d  %-30s  %8d  %5.1f%%  %s\n",
+				rank+1, hotspot.Provision, hotspot.Count, hotspot.Share*100,
+				hotspot.LastAccessed.Format("2006-01-02 15:04")))
+		}
+	}
+
+	if len(report.Templates) > 0 {
+		builder.WriteString("\n  Templates\n")
+		builder.WriteString("  " + strings.Repeat("─", 70) + "\n")
+		for _, template := range report.Templates {
+			builder.WriteString(fmt.Sprintf("  %-40s  %8d\n", template.Template, template.Count))
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,187 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+const testBase = "https://regula.dev/regulations/"
+
+func TestTracker_RecordAndFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	tracker, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	tracker.Record("rights-list", []string{testBase + "GDPR:Art17", testBase + "GDPR:Art17", testBase + "GDPR:Art15"})
+	tracker.Record("", []string{testBase + "GDPR:Art17"})
+	tracker.Record("rights-list", nil)
+
+	if err := tracker.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	stats, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if stats.Requests != 3 {
+		t.Errorf("Requests = %d, want 3", stats.Requests)
+	}
+	if stats.Templates["rights-list"] != 2 {
+		t.Errorf("rights-list count = %d, want 2", stats.Templates["rights-list"])
+	}
+	if got := stats.Provisions[testBase+"GDPR:Art17"]; got == nil || got.Count != 2 || got.Document != "GDPR" {
+		t.Errorf("Art17 usage = %+v, want count 2 in GDPR", got)
+	}
+
+	// Reopening continues from the saved counts
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	reopened.Record("", []string{testBase + "GDPR:Art17"})
+	if got := reopened.Snapshot().Provisions[testBase+"GDPR:Art17"].Count; got != 3 {
+		t.Errorf("Art17 count after reopen = %d, want 3", got)
+	}
+}
+
+func TestTracker_SkipsBroadResults(t *testing.T) {
+	tracker, err := Open(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	var provisions []string
+	for i := 1; i <= MaxProvisionsPerRequest+1; i++ {
+		provisions = append(provisions, fmt.Sprintf("%sGDPR:Art%d", testBase, i))
+	}
+	tracker.Record("all-articles", provisions)
+
+	stats := tracker.Snapshot()
+	if len(stats.Provisions) != 0 {
+		t.Errorf("recorded %d provisions for a broad result, want 0", len(stats.Provisions))
+	}
+	if stats.Templates["all-articles"] != 1 {
+		t.Errorf("template count = %d, want 1", stats.Templates["all-articles"])
+	}
+}
+
+func TestTracker_FlushOnlyWhenDirty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	tracker, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := tracker.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("clean flush wrote %s", path)
+	}
+}
+
+func TestTracker_RunFlushesOnCancel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	tracker, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	tracker.Record("", []string{testBase + "GDPR:Art5"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := tracker.Run(ctx, time.Hour); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	stats, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if stats.Provisions[testBase+"GDPR:Art5"] == nil {
+		t.Error("final flush did not persist Art5")
+	}
+}
+
+func TestProvisionURIs(t *testing.T) {
+	tripleStore := store.NewTripleStore()
+	tripleStore.Add(testBase+"GDPR:Art17", store.RDFType, store.ClassArticle)
+	tripleStore.Add(testBase+"GDPR:Recital65", store.RDFType, store.ClassRecital)
+	tripleStore.Add(testBase+"GDPR:Term:personal_data", store.RDFType, "reg:DefinedTerm")
+
+	got := ProvisionURIs(tripleStore, []string{
+		testBase + "GDPR:Art17",
+		"Right to erasure",
+		testBase + "GDPR:Term:personal_data",
+		testBase + "GDPR:Recital65",
+		testBase + "GDPR:Art17",
+	})
+	want := []string{testBase + "GDPR:Art17", testBase + "GDPR:Recital65"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ProvisionURIs = %v, want %v", got, want)
+	}
+}
+
+func TestDocumentOfAndShortID(t *testing.T) {
+	tests := []struct {
+		uri, document, shortID string
+	}{
+		{testBase + "GDPR:Art17", "GDPR", "Art17"},
+		{testBase + "GDPR:ChapterIV:Section1", "GDPR", "ChapterIV:Section1"},
+		{"CCPA:1798.100", "CCPA", "1798.100"},
+	}
+	for _, tt := range tests {
+		if got := DocumentOf(tt.uri); got != tt.document {
+			t.Errorf("DocumentOf(%q) = %q, want %q", tt.uri, got, tt.document)
+		}
+		if got := ShortID(tt.uri); got != tt.shortID {
+			t.Errorf("ShortID(%q) = %q, want %q", tt.uri, got, tt.shortID)
+		}
+	}
+}
+
+func TestHotspots(t *testing.T) {
+	tracker, err := Open(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		tracker.Record("rights-list", []string{testBase + "GDPR:Art17"})
+	}
+	tracker.Record("", []string{testBase + "GDPR:Art15"})
+	tracker.Record("", []string{testBase + "GDPR:Art6"})
+	tracker.Record("", []string{testBase + "CCPA:1798.100"})
+
+	report := Hotspots(tracker.Snapshot(), "", 2)
+	if len(report.Documents) != 2 || report.Documents[0].Document != "GDPR" {
+		t.Fatalf("documents = %+v, want GDPR first of 2", report.Documents)
+	}
+	gdpr := report.Documents[0]
+	if gdpr.Accesses != 5 || len(gdpr.Provisions) != 2 {
+		t.Fatalf("GDPR = %d accesses, %d provisions; want 5 and 2", gdpr.Accesses, len(gdpr.Provisions))
+	}
+	if top := gdpr.Provisions[0]; top.Provision != "Art17" || top.Count != 3 || top.Share != 0.6 {
+		t.Errorf("top hotspot = %+v, want Art17 x3 at 0.6", top)
+	}
+	if report.Templates[0].Template != "rights-list" || report.Templates[0].Count != 3 {
+		t.Errorf("templates = %+v", report.Templates)
+	}
+
+	filtered := Hotspots(tracker.Snapshot(), "ccpa", 0)
+	if len(filtered.Documents) != 1 || filtered.Documents[0].Document != "CCPA" {
+		t.Errorf("filtered documents = %+v, want only CCPA", filtered.Documents)
+	}
+
+	table := FormatHotspotsTable(report)
+	for _, want := range []string{"Provision Hot Spots", "GDPR (5 accesses)", "Art17", "60.0%", "rights-list"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("table missing %q:\n%s", want, table)
+		}
+	}
+}
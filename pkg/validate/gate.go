@@ -8,6 +8,7 @@ import (
 
 	"github.com/coolbeans/regula/pkg/extract"
 	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/validate/shapes"
 )
 
 // ValidationGate represents a validation checkpoint in the ingestion pipeline.
@@ -76,6 +77,9 @@ type ValidationConfig struct {
 
 	// FailOnWarn causes the pipeline to halt on any warning.
 	FailOnWarn bool
+
+	// Shapes, when set, is validated against the triple store by V3.
+	Shapes *shapes.ShapeSet
 }
 
 // DefaultValidationConfig returns a config with no overrides and default behavior.
@@ -99,6 +103,9 @@ type GateResult struct {
 	Duration   time.Duration      `json:"duration"`
 	Skipped    bool               `json:"skipped,omitempty"`
 	SkipReason string             `json:"skip_reason,omitempty"`
+
+	// ShapeViolations lists shape constraint failures (V3 only).
+	ShapeViolations []shapes.Violation `json:"shape_violations,omitempty"`
 }
 
 // GateWarning represents a non-fatal issue detected by a gate.
@@ -190,8 +197,9 @@ func (gateReport *GateReport) String() string {
 
 // GatePipeline executes validation gates in sequence and collects results.
 type GatePipeline struct {
-	gates  []ValidationGate
-	config *ValidationConfig
+	gates    []ValidationGate
+	config   *ValidationConfig
+	onResult func(*GateResult)
 }
 
 // NewGatePipeline creates a pipeline with the given configuration.
@@ -210,6 +218,12 @@ func (gatePipeline *GatePipeline) RegisterGate(gate ValidationGate) {
 	gatePipeline.gates = append(gatePipeline.gates, gate)
 }
 
+// SetResultCallback sets a function called with each gate result, including
+// skipped gates, as soon as the gate finishes.
+func (gatePipeline *GatePipeline) SetResultCallback(callback func(*GateResult)) {
+	gatePipeline.onResult = callback
+}
+
 // RegisterDefaultGates registers the four standard gates (V0-V3).
 func (gatePipeline *GatePipeline) RegisterDefaultGates() {
 	gatePipeline.RegisterGate(NewSchemaGate())
@@ -240,11 +254,13 @@ func (gatePipeline *GatePipeline) Run(ctx *ValidationContext) *GateReport {
 			}
 			gateReport.Results = append(gateReport.Results, skipResult)
 			gateReport.GatesSkipped++
+			gatePipeline.notify(skipResult)
 			continue
 		}
 
 		gateResult := gate.Run(ctx)
 		gateReport.Results = append(gateReport.Results, gateResult)
+		gatePipeline.notify(gateResult)
 
 		if gateResult.Passed {
 			gateReport.GatesPassed++
@@ -282,6 +298,13 @@ func (gatePipeline *GatePipeline) Run(ctx *ValidationContext) *GateReport {
 	return gateReport
 }
 
+// notify passes a finished gate result to the result callback, if any.
+func (gatePipeline *GatePipeline) notify(gateResult *GateResult) {
+	if gatePipeline.onResult != nil {
+		gatePipeline.onResult(gateResult)
+	}
+}
+
 // RunGate executes a single named gate. Useful for running individual checkpoints
 // at specific pipeline stages rather than all gates at once.
 // Returns nil if the gate is not found or is configured to be skipped.
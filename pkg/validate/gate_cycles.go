@@ -0,0 +1,98 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coolbeans/regula/pkg/analysis/metrics"
+)
+
+// maxCycleWarnings caps the cycles listed per kind in a gate result.
+const maxCycleWarnings = 10
+
+// CycleGate validates that provisions do not depend on each other in
+// circles, either by citing one another or through terms defined in terms of
+// each other. It is optional: register it after the default gates and run it
+// once the triple store is built.
+type CycleGate struct{}
+
+// NewCycleGate creates a new cycle detection gate.
+func NewCycleGate() *CycleGate {
+	return &CycleGate{}
+}
+
+// Name returns "cycles".
+func (cycleGate *CycleGate) Name() string { return "cycles" }
+
+// Thresholds returns the default thresholds for cycle detection metrics.
+// Mutual references between a handful of provisions are common in
+// legislation, so reference cycles are tolerated more than circular
+// definitions.
+func (cycleGate *CycleGate) Thresholds() map[string]float64 {
+	return map[string]float64{
+		"reference_acyclicity":  0.80,
+		"definition_acyclicity": 0.90,
+	}
+}
+
+// Run measures the fraction of provisions and defined terms outside any
+// cycle, and lists the cycles found as warnings.
+func (cycleGate *CycleGate) Run(ctx *ValidationContext) *GateResult {
+	startTime := time.Now()
+
+	gateResult := &GateResult{
+		Gate:     cycleGate.Name(),
+		Metrics:  make(map[string]float64),
+		Warnings: make([]GateWarning, 0),
+		Errors:   make([]GateError, 0),
+	}
+
+	if ctx.TripleStore == nil {
+		gateResult.Skipped = true
+		gateResult.SkipReason = "no triple store available"
+		gateResult.Passed = true
+		gateResult.Duration = time.Since(startTime)
+		return gateResult
+	}
+
+	report := metrics.FindCycles(ctx.TripleStore)
+	gateResult.Metrics["reference_acyclicity"] = acyclicity(report.ProvisionsInCycles, report.Provisions)
+	gateResult.Metrics["definition_acyclicity"] = acyclicity(report.TermsInCycles, report.Terms)
+
+	evaluateMetrics(gateResult, ctx.Config, cycleGate)
+	gateResult.Warnings = append(gateResult.Warnings, cycleWarnings("reference_acyclicity", report.ReferenceCycles)...)
+	gateResult.Warnings = append(gateResult.Warnings, cycleWarnings("definition_acyclicity", report.DefinitionCycles)...)
+	gateResult.Duration = time.Since(startTime)
+	return gateResult
+}
+
+// acyclicity returns the fraction of total nodes not in a cycle.
+func acyclicity(inCycles, total int) float64 {
+	if total == 0 {
+		return 1.0
+	}
+	return 1.0 - float64(inCycles)/float64(total)
+}
+
+// cycleWarnings lists up to maxCycleWarnings cycles as warnings, noting how
+// many more were found.
+func cycleWarnings(metric string, cycles []metrics.Cycle) []GateWarning {
+	warnings := make([]GateWarning, 0)
+	for i, cycle := range cycles {
+		if i == maxCycleWarnings {
+			warnings = append(warnings, GateWarning{
+				Metric:  metric,
+				Message: fmt.Sprintf("%d more %s cycle(s) not shown", len(cycles)-maxCycleWarnings, cycle.Kind),
+				Value:   float64(len(cycles) - maxCycleWarnings),
+			})
+			break
+		}
+		warnings = append(warnings, GateWarning{
+			Metric:  metric,
+			Message: fmt.Sprintf("%s cycle of %d: %s", cycle.Kind, len(cycle.Members), strings.Join(cycle.PathLabels, " → ")),
+			Value:   float64(len(cycle.Members)),
+		})
+	}
+	return warnings
+}
@@ -5,11 +5,13 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/coolbeans/regula/pkg/extract"
 	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/validate/shapes"
 )
 
 // --- Interface compliance (compile-time) ---
@@ -18,6 +20,7 @@ var _ ValidationGate = (*SchemaGate)(nil)
 var _ ValidationGate = (*StructureGate)(nil)
 var _ ValidationGate = (*CoverageGate)(nil)
 var _ ValidationGate = (*QualityGate)(nil)
+var _ ValidationGate = (*CycleGate)(nil)
 
 // --- Helper: build a minimal document with articles ---
 
@@ -405,6 +408,61 @@ func TestQualityGate_LowResolution(t *testing.T) {
 	}
 }
 
+func TestQualityGate_ShapeConformance(t *testing.T) {
+	gate := NewQualityGate()
+
+	tripleStore := store.NewTripleStore()
+	tripleStore.Add("https://regula.dev/regulations/T:Art1", store.RDFType, store.ClassArticle)
+	tripleStore.Add("https://regula.dev/regulations/T:Art1", store.PropNumber, "1")
+	tripleStore.Add("https://regula.dev/regulations/T:Art2", store.RDFType, store.ClassArticle)
+
+	config := DefaultValidationConfig()
+	config.Shapes = shapes.DefaultShapeSet()
+
+	result := gate.Run(&ValidationContext{
+		Document:    buildTestDocument(2, true),
+		TripleStore: tripleStore,
+		Config:      config,
+	})
+
+	if result.Metrics["shape_conformance"] != 0.5 {
+		t.Errorf("shape_conformance: got %.2f, want 0.5", result.Metrics["shape_conformance"])
+	}
+	if len(result.ShapeViolations) == 0 {
+		t.Fatal("expected shape violations on the result")
+	}
+
+	hasShapeError, hasShapeWarning := false, false
+	for _, gateError := range result.Errors {
+		if gateError.Metric == "shape_conformance" {
+			hasShapeError = true
+		}
+	}
+	for _, warning := range result.Warnings {
+		if warning.Metric == "shape_conformance" && strings.Contains(warning.Message, "ArticleShape") {
+			hasShapeWarning = true
+		}
+	}
+	if !hasShapeError {
+		t.Errorf("expected shape_conformance below threshold error, got %v", result.Errors)
+	}
+	if !hasShapeWarning {
+		t.Errorf("expected grouped ArticleShape warnings, got %v", result.Warnings)
+	}
+}
+
+func TestQualityGate_NoShapesConfigured(t *testing.T) {
+	gate := NewQualityGate()
+	result := gate.Run(&ValidationContext{
+		Document:    buildTestDocument(2, true),
+		TripleStore: store.NewTripleStore(),
+		Config:      DefaultValidationConfig(),
+	})
+	if _, exists := result.Metrics["shape_conformance"]; exists {
+		t.Error("shape_conformance should not be reported without shapes")
+	}
+}
+
 func TestQualityGate_NoResolvedRefs(t *testing.T) {
 	gate := NewQualityGate()
 	ctx := &ValidationContext{
@@ -421,6 +479,83 @@ func TestQualityGate_NoResolvedRefs(t *testing.T) {
 	}
 }
 
+// --- CycleGate Tests ---
+
+func buildCycleStore(articles []string, references [][2]string) *store.TripleStore {
+	tripleStore := store.NewTripleStore()
+	for _, article := range articles {
+		tripleStore.Add("https://regula.dev/regulations/TEST:"+article, store.RDFType, store.ClassArticle)
+	}
+	for _, reference := range references {
+		tripleStore.Add("https://regula.dev/regulations/TEST:"+reference[0], store.PropReferences,
+			"https://regula.dev/regulations/TEST:"+reference[1])
+	}
+	return tripleStore
+}
+
+func TestCycleGate_NoCycles(t *testing.T) {
+	gate := NewCycleGate()
+	ctx := &ValidationContext{
+		TripleStore: buildCycleStore([]string{"Art1", "Art2", "Art3"}, [][2]string{{"Art2", "Art1"}, {"Art3", "Art2"}}),
+		Config:      DefaultValidationConfig(),
+	}
+
+	result := gate.Run(ctx)
+
+	if !result.Passed || len(result.Warnings) != 0 {
+		t.Errorf("expected a clean pass, got passed=%v warnings=%v", result.Passed, result.Warnings)
+	}
+	if result.Metrics["reference_acyclicity"] != 1.0 || result.Metrics["definition_acyclicity"] != 1.0 {
+		t.Errorf("metrics: %v", result.Metrics)
+	}
+}
+
+func TestCycleGate_ReferenceCycle(t *testing.T) {
+	gate := NewCycleGate()
+	ctx := &ValidationContext{
+		TripleStore: buildCycleStore([]string{"Art1", "Art2", "Art3", "Art4"}, [][2]string{{"Art1", "Art2"}, {"Art2", "Art1"}}),
+		Config:      DefaultValidationConfig(),
+	}
+
+	result := gate.Run(ctx)
+
+	if result.Metrics["reference_acyclicity"] != 0.5 {
+		t.Errorf("reference_acyclicity: got %.2f, want 0.50", result.Metrics["reference_acyclicity"])
+	}
+	if result.Passed {
+		t.Error("expected failure with half the provisions in a cycle")
+	}
+	found := false
+	for _, warning := range result.Warnings {
+		if strings.Contains(warning.Message, "TEST:Art1 → TEST:Art2 → TEST:Art1") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the cycle to be listed, got %v", result.Warnings)
+	}
+}
+
+func TestCycleGate_NoTripleStore(t *testing.T) {
+	result := NewCycleGate().Run(&ValidationContext{Config: DefaultValidationConfig()})
+	if !result.Skipped || !result.Passed {
+		t.Errorf("expected a skipped pass without a triple store, got %+v", result)
+	}
+}
+
+func TestCycleGate_NotRegisteredByDefault(t *testing.T) {
+	pipeline := NewGatePipeline(DefaultValidationConfig())
+	pipeline.RegisterDefaultGates()
+	if result := pipeline.RunGate("cycles", &ValidationContext{}); result != nil {
+		t.Errorf("cycle gate should be opt-in, got %+v", result)
+	}
+
+	pipeline.RegisterGate(NewCycleGate())
+	if result := pipeline.RunGate("cycles", &ValidationContext{TripleStore: store.NewTripleStore()}); result == nil || !result.Passed {
+		t.Errorf("expected registered cycle gate to run, got %+v", result)
+	}
+}
+
 // --- GatePipeline Tests ---
 
 func TestGatePipeline_AllGatesPass(t *testing.T) {
@@ -519,6 +654,36 @@ func TestGatePipeline_SkipGates(t *testing.T) {
 	}
 }
 
+func TestGatePipeline_ResultCallback(t *testing.T) {
+	config := &ValidationConfig{
+		Thresholds: make(map[string]float64),
+		SkipGates:  []string{"V2"},
+	}
+	pipeline := NewGatePipeline(config)
+	pipeline.RegisterDefaultGates()
+
+	var streamed []string
+	pipeline.SetResultCallback(func(gateResult *GateResult) {
+		streamed = append(streamed, gateResult.Gate)
+	})
+
+	report := pipeline.Run(&ValidationContext{
+		SourcePath: "/path/to/file.txt",
+		SourceSize: 50000,
+		Document:   buildTestDocument(10, true),
+		Config:     config,
+	})
+
+	if len(streamed) != len(report.Results) {
+		t.Fatalf("callback received %d results, report has %d", len(streamed), len(report.Results))
+	}
+	for i, gateResult := range report.Results {
+		if streamed[i] != gateResult.Gate {
+			t.Errorf("result %d: streamed %s, report has %s", i, streamed[i], gateResult.Gate)
+		}
+	}
+}
+
 func TestGatePipeline_StrictModeHalts(t *testing.T) {
 	config := &ValidationConfig{
 		Thresholds: make(map[string]float64),
@@ -1,9 +1,12 @@
 package validate
 
 import (
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/validate/shapes"
 )
 
 // QualityGate (V3) validates resolution quality and overall graph confidence.
@@ -24,10 +27,13 @@ func (qualityGate *QualityGate) Thresholds() map[string]float64 {
 		"resolution_rate":    0.80,
 		"confidence_average": 0.70,
 		"graph_connectivity": 0.50,
+		"shape_conformance":  0.90,
 	}
 }
 
 // Run validates reference resolution rate, confidence levels, and graph connectivity.
+// When the config carries a shape set, the triple store is also validated
+// against it and shape_conformance is reported.
 func (qualityGate *QualityGate) Run(ctx *ValidationContext) *GateResult {
 	startTime := time.Now()
 
@@ -105,7 +111,56 @@ func (qualityGate *QualityGate) Run(ctx *ValidationContext) *GateResult {
 		gateResult.Metrics["graph_connectivity"] = 0.0
 	}
 
+	// shape_conformance: fraction of shape focus nodes without violations.
+	var shapeReport *shapes.Report
+	if ctx.Config != nil && ctx.Config.Shapes != nil && ctx.TripleStore != nil {
+		shapeReport = ctx.Config.Shapes.Validate(ctx.TripleStore)
+		gateResult.Metrics["shape_conformance"] = shapeReport.Conformance()
+		gateResult.ShapeViolations = shapeReport.Violations
+	}
+
 	evaluateMetrics(gateResult, ctx.Config, qualityGate)
+	if shapeReport != nil {
+		gateResult.Warnings = append(gateResult.Warnings, summarizeShapeViolations(shapeReport.Violations)...)
+	}
 	gateResult.Duration = time.Since(startTime)
 	return gateResult
 }
+
+// summarizeShapeViolations groups shape violations and warnings by shape,
+// path, and constraint so a missing title on 40 articles is reported once.
+func summarizeShapeViolations(violations []shapes.Violation) []GateWarning {
+	type violationGroup struct {
+		example shapes.Violation
+		count   int
+	}
+	groups := make(map[string]*violationGroup)
+	for _, violation := range violations {
+		if violation.Severity == shapes.SeverityInfo {
+			continue
+		}
+		key := violation.Shape + "|" + violation.Path + "|" + violation.Constraint
+		if groups[key] == nil {
+			groups[key] = &violationGroup{example: violation}
+		}
+		groups[key].count++
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	warnings := make([]GateWarning, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		warnings = append(warnings, GateWarning{
+			Metric: "shape_conformance",
+			Message: fmt.Sprintf("%s %s: %s on %d node(s), e.g. %s",
+				group.example.Shape, group.example.Severity, group.example.Message, group.count, group.example.FocusNode),
+			Value: float64(group.count),
+		})
+	}
+	return warnings
+}
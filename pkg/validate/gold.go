@@ -0,0 +1,344 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/extract"
+)
+
+// Extractors scored against a gold standard.
+const (
+	GoldDefinitions = "definitions"
+	GoldReferences  = "references"
+	GoldRights      = "rights"
+	GoldObligations = "obligations"
+)
+
+// GoldStandard is a hand-labeled file of what extraction should find in a
+// document:
+//
+//	{
+//	  "document": "GDPR",
+//	  "articles": [4, 7, 17],
+//	  "definitions": [{"term": "personal data", "article": 4}],
+//	  "references": [{"article": 17, "text": "Article 21(2)", "target": "article"}],
+//	  "rights": [{"article": 17, "type": "RightToErasure"}],
+//	  "obligations": [{"article": 7, "type": "ConsentObligation"}]
+//	}
+//
+// When Articles is set only those articles are labeled, and extraction from
+// other articles is not scored.
+type GoldStandard struct {
+	Document    string           `json:"document,omitempty"`
+	Articles    []int            `json:"articles,omitempty"`
+	Definitions []GoldDefinition `json:"definitions,omitempty"`
+	References  []GoldReference  `json:"references,omitempty"`
+	Rights      []GoldSemantic   `json:"rights,omitempty"`
+	Obligations []GoldSemantic   `json:"obligations,omitempty"`
+}
+
+// GoldDefinition is a labeled defined term.
+type GoldDefinition struct {
+	Term    string `json:"term"`
+	Article int    `json:"article,omitempty"`
+}
+
+// GoldReference is a labeled reference, by its source article and raw
+// text. Target, when set, is the reference target used to score by type.
+type GoldReference struct {
+	Article int    `json:"article"`
+	Text    string `json:"text"`
+	Target  string `json:"target,omitempty"`
+}
+
+// GoldSemantic is a labeled right or obligation of an article, by its
+// right or obligation type.
+type GoldSemantic struct {
+	Article int    `json:"article"`
+	Type    string `json:"type"`
+}
+
+// GoldScore counts the matches of one extractor, or one type within it,
+// against the gold standard.
+type GoldScore struct {
+	Extractor      string  `json:"extractor"`
+	Type           string  `json:"type,omitempty"`
+	TruePositives  int     `json:"true_positives"`
+	FalsePositives int     `json:"false_positives"`
+	FalseNegatives int     `json:"false_negatives"`
+	Precision      float64 `json:"precision"`
+	Recall         float64 `json:"recall"`
+	F1             float64 `json:"f1"`
+}
+
+// compute sets precision, recall, and F1 from the counts. Precision is 1
+// when nothing was extracted and recall is 1 when nothing was labeled.
+func (s *GoldScore) compute() {
+	s.Precision, s.Recall = 1, 1
+	if found := s.TruePositives + s.FalsePositives; found > 0 {
+		s.Precision = float64(s.TruePositives) / float64(found)
+	}
+	if labeled := s.TruePositives + s.FalseNegatives; labeled > 0 {
+		s.Recall = float64(s.TruePositives) / float64(labeled)
+	}
+	s.F1 = 0
+	if s.Precision+s.Recall > 0 {
+		s.F1 = 2 * s.Precision * s.Recall / (s.Precision + s.Recall)
+	}
+}
+
+// GoldMismatch is a labeled item extraction missed, or an extracted item
+// the gold standard does not contain.
+type GoldMismatch struct {
+	Extractor string `json:"extractor"`
+	Type      string `json:"type,omitempty"`
+	Article   int    `json:"article,omitempty"`
+	Text      string `json:"text"`
+
+	// Kind is "missed" or "spurious".
+	Kind string `json:"kind"`
+}
+
+// GoldReport scores extraction against a gold standard, per extractor and
+// per type within each extractor.
+type GoldReport struct {
+	Document   string         `json:"document,omitempty"`
+	Extractors []GoldScore    `json:"extractors"`
+	Types      []GoldScore    `json:"types"`
+	Mismatches []GoldMismatch `json:"mismatches,omitempty"`
+}
+
+// LoadGoldStandard reads a gold standard JSON file.
+func LoadGoldStandard(path string) (*GoldStandard, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gold standard: %w", err)
+	}
+	gold := &GoldStandard{}
+	if err := json.Unmarshal(data, gold); err != nil {
+		return nil, fmt.Errorf("%s: failed to parse gold standard: %w", path, err)
+	}
+	for index, reference := range gold.References {
+		if reference.Text == "" || reference.Article == 0 {
+			return nil, fmt.Errorf("%s: reference %d: article and text are required", path, index+1)
+		}
+	}
+	return gold, nil
+}
+
+// goldItem is one labeled or extracted item, keyed for matching.
+type goldItem struct {
+	key     string
+	typ     string
+	article int
+	text    string
+}
+
+// EvaluateGold scores extracted definitions, references, and rights and
+// obligations against a gold standard. Definitions match by normalized
+// term, references by source article and raw text, and rights and
+// obligations by article and type. Whitespace in reference text is
+// normalized, since raw text keeps line breaks. Repeated rights or
+// obligations of one type in an article count once.
+func EvaluateGold(gold *GoldStandard, definitions []*extract.DefinedTerm, references []*extract.Reference, annotations []*extract.SemanticAnnotation) *GoldReport {
+	report := &GoldReport{Document: gold.Document}
+	inScope := func(article int) bool { return true }
+	if len(gold.Articles) > 0 {
+		scope := make(map[int]bool, len(gold.Articles))
+		for _, article := range gold.Articles {
+			scope[article] = true
+		}
+		inScope = func(article int) bool { return scope[article] }
+	}
+
+	// Definitions
+	var labeled, found []goldItem
+	for _, definition := range gold.Definitions {
+		term := strings.Join(strings.Fields(strings.ToLower(definition.Term)), " ")
+		labeled = append(labeled, goldItem{key: term, article: definition.Article, text: definition.Term})
+	}
+	for _, definition := range definitions {
+		if inScope(definition.ArticleRef) {
+			found = append(found, goldItem{key: definition.NormalizedTerm, article: definition.ArticleRef, text: definition.Term})
+		}
+	}
+	report.score(GoldDefinitions, labeled, found, false)
+
+	// References
+	labeled, found = nil, nil
+	for _, reference := range gold.References {
+		labeled = append(labeled, goldItem{key: referenceKey(reference.Article, reference.Text), typ: reference.Target, article: reference.Article, text: reference.Text})
+	}
+	for _, reference := range references {
+		if inScope(reference.SourceArticle) {
+			found = append(found, goldItem{key: referenceKey(reference.SourceArticle, reference.RawText), typ: string(reference.Target), article: reference.SourceArticle, text: reference.RawText})
+		}
+	}
+	report.score(GoldReferences, labeled, found, false)
+
+	// Rights and obligations
+	var labeledRights, labeledObligations, foundRights, foundObligations []goldItem
+	for _, right := range gold.Rights {
+		labeledRights = append(labeledRights, goldItem{key: fmt.Sprintf("%d|%s", right.Article, right.Type), typ: right.Type, article: right.Article, text: right.Type})
+	}
+	for _, obligation := range gold.Obligations {
+		labeledObligations = append(labeledObligations, goldItem{key: fmt.Sprintf("%d|%s", obligation.Article, obligation.Type), typ: obligation.Type, article: obligation.Article, text: obligation.Type})
+	}
+	for _, annotation := range annotations {
+		if !inScope(annotation.ArticleNum) {
+			continue
+		}
+		switch annotation.Type {
+		case extract.SemanticRight:
+			typ := string(annotation.RightType)
+			foundRights = append(foundRights, goldItem{key: fmt.Sprintf("%d|%s", annotation.ArticleNum, typ), typ: typ, article: annotation.ArticleNum, text: typ})
+		case extract.SemanticObligation:
+			typ := string(annotation.ObligationType)
+			foundObligations = append(foundObligations, goldItem{key: fmt.Sprintf("%d|%s", annotation.ArticleNum, typ), typ: typ, article: annotation.ArticleNum, text: typ})
+		}
+	}
+	report.score(GoldRights, labeledRights, foundRights, true)
+	report.score(GoldObligations, labeledObligations, foundObligations, true)
+
+	return report
+}
+
+// score matches the found items of one extractor against the labeled ones
+// and adds the extractor's scores to the report. When dedupe is set,
+// repeated keys count once.
+func (r *GoldReport) score(extractor string, labeled, found []goldItem, dedupe bool) {
+	if dedupe {
+		labeled, found = uniqueGoldItems(labeled), uniqueGoldItems(found)
+	}
+
+	total := GoldScore{Extractor: extractor}
+	types := make(map[string]*GoldScore)
+	typeScore := func(typ string) *GoldScore {
+		if types[typ] == nil {
+			types[typ] = &GoldScore{Extractor: extractor, Type: typ}
+		}
+		return types[typ]
+	}
+
+	// matched counts the labeled items of each key found so far; found
+	// items beyond that count are spurious.
+	available := make(map[string][]goldItem)
+	for _, item := range found {
+		available[item.key] = append(available[item.key], item)
+	}
+	matched := make(map[string]int)
+	for _, item := range labeled {
+		if matches := available[item.key]; matched[item.key] < len(matches) {
+			typ := item.typ
+			if typ == "" {
+				typ = matches[matched[item.key]].typ
+			}
+			matched[item.key]++
+			total.TruePositives++
+			typeScore(typ).TruePositives++
+			continue
+		}
+		total.FalseNegatives++
+		typeScore(item.typ).FalseNegatives++
+		r.Mismatches = append(r.Mismatches, GoldMismatch{Extractor: extractor, Type: item.typ, Article: item.article, Text: item.text, Kind: "missed"})
+	}
+	for _, item := range found {
+		if matched[item.key] > 0 {
+			matched[item.key]--
+			continue
+		}
+		total.FalsePositives++
+		typeScore(item.typ).FalsePositives++
+		r.Mismatches = append(r.Mismatches, GoldMismatch{Extractor: extractor, Type: item.typ, Article: item.article, Text: item.text, Kind: "spurious"})
+	}
+
+	total.compute()
+	r.Extractors = append(r.Extractors, total)
+
+	var typeScores []GoldScore
+	for typ, s := range types {
+		if typ == "" && extractor == GoldDefinitions {
+			continue
+		}
+		s.compute()
+		typeScores = append(typeScores, *s)
+	}
+	sort.Slice(typeScores, func(i, j int) bool { return typeScores[i].Type < typeScores[j].Type })
+	r.Types = append(r.Types, typeScores...)
+}
+
+// referenceKey identifies a reference by source article and raw text.
+func referenceKey(article int, text string) string {
+	return fmt.Sprintf("%d|%s", article, strings.Join(strings.Fields(text), " "))
+}
+
+// uniqueGoldItems drops items whose key was already seen.
+func uniqueGoldItems(items []goldItem) []goldItem {
+	seen := make(map[string]bool, len(items))
+	var unique []goldItem
+	for _, item := range items {
+		if !seen[item.key] {
+			seen[item.key] = true
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
+
+// ToJSON serializes the report to JSON.
+func (r *GoldReport) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// String returns a human-readable summary of the report.
+func (r *GoldReport) String() string {
+	var sb strings.Builder
+
+	title := "Gold Standard Evaluation"
+	if r.Document != "" {
+		title += " (" + r.Document + ")"
+	}
+	sb.WriteString(title + "\n")
+	sb.WriteString(strings.Repeat("=", 50) + "\n")
+
+	writeScore := func(name string, s GoldScore) {
+		sb.WriteString(fmt.Sprintf("  %-46s %4d %4d %4d %8.1f%% %7.1f%% %6.1f%%\n",
+			name, s.TruePositives, s.FalsePositives, s.FalseNegatives, s.Precision*100, s.Recall*100, s.F1*100))
+	}
+	sb.WriteString(fmt.Sprintf("  %-46s %4s %4s %4s %9s %8s %7s\n", "Extractor", "TP", "FP", "FN", "Precision", "Recall", "F1"))
+	for _, s := range r.Extractors {
+		writeScore(s.Extractor, s)
+	}
+
+	if len(r.Types) > 0 {
+		sb.WriteString("\nBy type:\n")
+		for _, s := range r.Types {
+			typ := s.Type
+			if typ == "" {
+				typ = "(untyped)"
+			}
+			writeScore(s.Extractor+"/"+typ, s)
+		}
+	}
+
+	if len(r.Mismatches) > 0 {
+		sb.WriteString(fmt.Sprintf("\nMismatches (%d):\n", len(r.Mismatches)))
+		for i, mismatch := range r.Mismatches {
+			if i == 20 {
+				sb.WriteString(fmt.Sprintf("  ... and %d more\n", len(r.Mismatches)-20))
+				break
+			}
+			location := ""
+			if mismatch.Article != 0 {
+				location = fmt.Sprintf("Art %d: ", mismatch.Article)
+			}
+			sb.WriteString(fmt.Sprintf("  %s %s %s%q\n", mismatch.Kind, mismatch.Extractor, location, mismatch.Text))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,135 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/extract"
+)
+
+func findGoldScore(scores []GoldScore, extractor, typ string) *GoldScore {
+	for i := range scores {
+		if scores[i].Extractor == extractor && scores[i].Type == typ {
+			return &scores[i]
+		}
+	}
+	return nil
+}
+
+func TestEvaluateGold(t *testing.T) {
+	gold := &GoldStandard{
+		Document: "Test",
+		Articles: []int{4, 17},
+		Definitions: []GoldDefinition{
+			{Term: "Personal  Data", Article: 4},
+			{Term: "processing", Article: 4},
+		},
+		References: []GoldReference{
+			{Article: 17, Text: "Article 21(2)", Target: "article"},
+			{Article: 17, Text: "paragraph 1", Target: "paragraph"},
+		},
+		Rights:      []GoldSemantic{{Article: 17, Type: string(extract.RightErasure)}},
+		Obligations: []GoldSemantic{{Article: 17, Type: string(extract.ObligationNotifySubject)}},
+	}
+	definitions := []*extract.DefinedTerm{
+		{Term: "personal data", NormalizedTerm: "personal data", ArticleRef: 4},
+		{Term: "controller", NormalizedTerm: "controller", ArticleRef: 4},
+		// Outside the labeled articles
+		{Term: "enterprise", NormalizedTerm: "enterprise", ArticleRef: 30},
+	}
+	references := []*extract.Reference{
+		{SourceArticle: 17, RawText: "Article\n21(2)", Target: extract.TargetArticle},
+		{SourceArticle: 17, RawText: "Article 6(1)", Target: extract.TargetArticle},
+		{SourceArticle: 30, RawText: "Article 5", Target: extract.TargetArticle},
+	}
+	annotations := []*extract.SemanticAnnotation{
+		{Type: extract.SemanticRight, ArticleNum: 17, RightType: extract.RightErasure},
+		{Type: extract.SemanticRight, ArticleNum: 17, RightType: extract.RightErasure},
+		{Type: extract.SemanticObligation, ArticleNum: 17, ObligationType: extract.ObligationNotifySubject},
+		{Type: extract.SemanticObligation, ArticleNum: 4, ObligationType: extract.ObligationConsent},
+	}
+
+	report := EvaluateGold(gold, definitions, references, annotations)
+
+	tests := []struct {
+		extractor, typ string
+		tp, fp, fn     int
+	}{
+		{GoldDefinitions, "", 1, 1, 1},
+		{GoldReferences, "", 1, 1, 1},
+		{GoldReferences, "article", 1, 1, 0},
+		{GoldReferences, "paragraph", 0, 0, 1},
+		{GoldRights, "", 1, 0, 0},
+		{GoldObligations, "", 1, 1, 0},
+	}
+	for _, tt := range tests {
+		scores := report.Extractors
+		if tt.typ != "" {
+			scores = report.Types
+		}
+		score := findGoldScore(scores, tt.extractor, tt.typ)
+		if score == nil {
+			t.Errorf("%s/%s: no score", tt.extractor, tt.typ)
+			continue
+		}
+		if score.TruePositives != tt.tp || score.FalsePositives != tt.fp || score.FalseNegatives != tt.fn {
+			t.Errorf("%s/%s: TP=%d FP=%d FN=%d, want %d %d %d", tt.extractor, tt.typ,
+				score.TruePositives, score.FalsePositives, score.FalseNegatives, tt.tp, tt.fp, tt.fn)
+		}
+	}
+
+	definitionScore := findGoldScore(report.Extractors, GoldDefinitions, "")
+	if definitionScore.Precision != 0.5 || definitionScore.Recall != 0.5 || definitionScore.F1 != 0.5 {
+		t.Errorf("definition score = %+v, want 0.5 precision, recall and F1", definitionScore)
+	}
+	if rights := findGoldScore(report.Extractors, GoldRights, ""); rights.F1 != 1 {
+		t.Errorf("rights F1 = %v, want 1 with duplicate annotations counted once", rights.F1)
+	}
+
+	var missed bool
+	for _, mismatch := range report.Mismatches {
+		if mismatch.Kind == "missed" && mismatch.Extractor == GoldReferences && mismatch.Text == "paragraph 1" {
+			missed = true
+		}
+		if mismatch.Article == 30 {
+			t.Errorf("article outside the labeled scope reported: %+v", mismatch)
+		}
+	}
+	if !missed {
+		t.Errorf("mismatches = %+v, want the missed paragraph reference", report.Mismatches)
+	}
+
+	output := report.String()
+	if !strings.Contains(output, "Gold Standard Evaluation (Test)") || !strings.Contains(output, "references/paragraph") {
+		t.Errorf("unexpected report:\n%s", output)
+	}
+}
+
+func TestEvaluateGold_Empty(t *testing.T) {
+	report := EvaluateGold(&GoldStandard{}, nil, nil, nil)
+	for _, score := range report.Extractors {
+		if score.Precision != 1 || score.Recall != 1 {
+			t.Errorf("%s: empty score = %+v, want precision and recall of 1", score.Extractor, score)
+		}
+	}
+}
+
+func TestLoadGoldStandard(t *testing.T) {
+	gold, err := LoadGoldStandard(filepath.Join("..", "..", "testdata", "gdpr-gold.json"))
+	if err != nil {
+		t.Fatalf("LoadGoldStandard: %v", err)
+	}
+	if gold.Document != "GDPR" || len(gold.Definitions) == 0 || len(gold.References) == 0 {
+		t.Errorf("gold standard = %+v", gold)
+	}
+
+	path := filepath.Join(t.TempDir(), "gold.json")
+	if err := os.WriteFile(path, []byte(`{"references": [{"article": 17}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadGoldStandard(path); err == nil || !strings.Contains(err.Error(), "article and text are required") {
+		t.Errorf("error = %v, want a missing text error", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/textutil"
 )
 
 // ProfileGenerator analyzes documents and extraction results to suggest
@@ -399,10 +400,7 @@ func (generator *ProfileGenerator) suggestProfileName(doc *extract.Document) str
 
 	if doc.Title != "" {
 		// Truncate long titles
-		title := doc.Title
-		if len(title) > 50 {
-			title = title[:50] + "..."
-		}
+		title := textutil.Truncate(doc.Title, 53)
 		return title
 	}
 
@@ -0,0 +1,49 @@
+package validate
+
+import (
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/validate/shapes"
+)
+
+// Quality factors applied to the triples of a focus node that fails a
+// shape constraint.
+const (
+	ShapeViolationQuality = 0.5
+	ShapeWarningQuality   = 0.8
+)
+
+// ApplyGateQuality lowers the quality scores of triples in tripleStore
+// according to gate outcomes. A failed gate scales every scored triple,
+// those derived from extraction heuristics, by the gate's score: its
+// metrics put the extraction as a whole in doubt, while the triples read
+// directly from the document structure are unaffected. Shape violations
+// reported by V3 scale every triple of the offending focus node. Skipped
+// and nil results are ignored.
+func ApplyGateQuality(tripleStore *store.TripleStore, results ...*GateResult) {
+	for _, gateResult := range results {
+		if gateResult == nil || gateResult.Skipped {
+			continue
+		}
+		if !gateResult.Passed {
+			tripleStore.ScaleScoredQuality(gateResult.Score)
+		}
+
+		// A node with several violations is penalized once per severity.
+		penalized := make(map[string]bool)
+		for _, violation := range gateResult.ShapeViolations {
+			factor := ShapeWarningQuality
+			switch violation.Severity {
+			case shapes.SeverityViolation:
+				factor = ShapeViolationQuality
+			case shapes.SeverityInfo:
+				continue
+			}
+			key := violation.FocusNode + "|" + string(violation.Severity)
+			if penalized[key] {
+				continue
+			}
+			penalized[key] = true
+			tripleStore.ScaleQuality(store.NewTriplePattern(violation.FocusNode, "", ""), factor)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/store"
+	"github.com/coolbeans/regula/pkg/validate/shapes"
+)
+
+func TestApplyGateQuality(t *testing.T) {
+	tripleStore := store.NewTripleStore()
+	reference := store.NewTriple("ex:Art1", store.PropReferences, "ex:Art2")
+	title := store.NewTriple("ex:Art1", store.PropTitle, "Scope")
+	orphanType := store.NewTriple("ex:Art3", store.RDFType, store.ClassArticle)
+	for _, triple := range []store.Triple{reference, title, orphanType} {
+		tripleStore.AddTriple(triple)
+	}
+	tripleStore.SetQuality(reference, 0.8)
+
+	ApplyGateQuality(tripleStore,
+		nil,
+		&GateResult{Gate: "V2", Passed: false, Score: 0.5},
+		&GateResult{Gate: "V1", Passed: false, Score: 0.1, Skipped: true},
+		&GateResult{Gate: "V3", Passed: true, Score: 0.9, ShapeViolations: []shapes.Violation{
+			{FocusNode: "ex:Art3", Severity: shapes.SeverityViolation},
+			{FocusNode: "ex:Art3", Severity: shapes.SeverityViolation},
+			{FocusNode: "ex:Art1", Severity: shapes.SeverityInfo},
+		}},
+	)
+
+	if got := tripleStore.Quality(reference); got != 0.4 {
+		t.Errorf("reference quality = %v, want 0.4 (0.8 scaled by failed V2)", got)
+	}
+	if got := tripleStore.Quality(title); got != store.DefaultQuality {
+		t.Errorf("structural triple quality = %v, want %v", got, store.DefaultQuality)
+	}
+	if got := tripleStore.Quality(orphanType); got != ShapeViolationQuality {
+		t.Errorf("violating node quality = %v, want %v", got, ShapeViolationQuality)
+	}
+}
@@ -4,26 +4,36 @@ import (
 	"fmt"
 	"html"
 	"strings"
+
+	"github.com/coolbeans/regula/pkg/i18n"
 )
 
 // ToHTML generates a self-contained HTML validation report with inline CSS.
 func (validationResult *ValidationResult) ToHTML() string {
+	return validationResult.ToHTMLLocalized(nil)
+}
+
+// ToHTMLLocalized generates the HTML validation report in the translator's
+// language, setting the document's lang attribute to match. A nil translator
+// renders English.
+func (validationResult *ValidationResult) ToHTMLLocalized(translator *i18n.Translator) string {
 	var htmlBuilder strings.Builder
 
 	statusColor := statusToHTMLColor(validationResult.Status)
 	statusLabel := string(validationResult.Status)
+	reportTitle := html.EscapeString(translator.T("Validation Report"))
 
-	htmlBuilder.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n")
+	htmlBuilder.WriteString(fmt.Sprintf("<!DOCTYPE html>\n<html lang=\"%s\">\n<head>\n", translator.Language()))
 	htmlBuilder.WriteString("<meta charset=\"UTF-8\">\n")
 	htmlBuilder.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\">\n")
-	htmlBuilder.WriteString("<title>Validation Report</title>\n")
+	htmlBuilder.WriteString("<title>" + reportTitle + "</title>\n")
 	htmlBuilder.WriteString(validationHTMLStyles())
 	htmlBuilder.WriteString("</head>\n<body>\n")
 
 	// Header
 	htmlBuilder.WriteString("<div class=\"container\">\n")
 	htmlBuilder.WriteString("<div class=\"header\">\n")
-	htmlBuilder.WriteString("<h1>Validation Report</h1>\n")
+	htmlBuilder.WriteString("<h1>" + reportTitle + "</h1>\n")
 
 	if validationResult.ProfileName != "" {
 		htmlBuilder.WriteString(fmt.Sprintf("<span class=\"badge\">%s</span>\n",
@@ -36,20 +46,20 @@ func (validationResult *ValidationResult) ToHTML() string {
 
 	// Overall Score Bar
 	htmlBuilder.WriteString("<div class=\"score-section\">\n")
-	htmlBuilder.WriteString("<h2>Overall Score</h2>\n")
+	htmlBuilder.WriteString("<h2>" + html.EscapeString(translator.T("Overall Score")) + "</h2>\n")
 	htmlBuilder.WriteString(fmt.Sprintf("<div class=\"score-value\">%.1f%%</div>\n", validationResult.OverallScore*100))
 	htmlBuilder.WriteString("<div class=\"score-bar-container\">\n")
 	htmlBuilder.WriteString(fmt.Sprintf("<div class=\"score-bar\" style=\"width:%.1f%%;background-color:%s\"></div>\n",
 		validationResult.OverallScore*100, statusColor))
 	htmlBuilder.WriteString("</div>\n")
-	htmlBuilder.WriteString(fmt.Sprintf("<div class=\"threshold-label\">Threshold: %.1f%%</div>\n",
-		validationResult.Threshold*100))
+	htmlBuilder.WriteString(fmt.Sprintf("<div class=\"threshold-label\">%s: %.1f%%</div>\n",
+		html.EscapeString(translator.T("Threshold")), validationResult.Threshold*100))
 	htmlBuilder.WriteString("</div>\n\n")
 
 	// Component Scores
 	if validationResult.ComponentScores != nil {
 		htmlBuilder.WriteString("<div class=\"section\">\n")
-		htmlBuilder.WriteString("<h2>Component Scores</h2>\n")
+		htmlBuilder.WriteString("<h2>" + html.EscapeString(translator.T("Component Scores")) + "</h2>\n")
 
 		componentEntries := []struct {
 			name   string
@@ -66,7 +76,7 @@ func (validationResult *ValidationResult) ToHTML() string {
 		for _, componentEntry := range componentEntries {
 			barColor := scoreToHTMLColor(componentEntry.score)
 			htmlBuilder.WriteString("<div class=\"component-row\">\n")
-			htmlBuilder.WriteString(fmt.Sprintf("<span class=\"component-name\">%s</span>\n", componentEntry.name))
+			htmlBuilder.WriteString(fmt.Sprintf("<span class=\"component-name\">%s</span>\n", html.EscapeString(translator.T(componentEntry.name))))
 			htmlBuilder.WriteString(fmt.Sprintf("<span class=\"component-weight\">(%.0f%%)</span>\n", componentEntry.weight*100))
 			htmlBuilder.WriteString("<div class=\"component-bar-container\">\n")
 			htmlBuilder.WriteString(fmt.Sprintf("<div class=\"component-bar\" style=\"width:%.1f%%;background-color:%s\"></div>\n",
@@ -82,22 +92,22 @@ func (validationResult *ValidationResult) ToHTML() string {
 	// Reference Resolution
 	if validationResult.References != nil {
 		htmlBuilder.WriteString("<details class=\"section\" open>\n")
-		htmlBuilder.WriteString("<summary><h2>Reference Resolution</h2></summary>\n")
+		htmlBuilder.WriteString("<summary><h2>" + html.EscapeString(translator.T("Reference Resolution")) + "</h2></summary>\n")
 		htmlBuilder.WriteString("<table>\n")
-		htmlBuilder.WriteString("<tr><th>Metric</th><th>Value</th></tr>\n")
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Total References</td><td>%d</td></tr>\n", validationResult.References.TotalReferences))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Resolved</td><td>%d</td></tr>\n", validationResult.References.Resolved))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Partial</td><td>%d</td></tr>\n", validationResult.References.Partial))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Ambiguous</td><td>%d</td></tr>\n", validationResult.References.Ambiguous))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Not Found</td><td>%d</td></tr>\n", validationResult.References.NotFound))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>External</td><td>%d</td></tr>\n", validationResult.References.External))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Resolution Rate</td><td>%.1f%%</td></tr>\n", validationResult.References.ResolutionRate*100))
+		htmlBuilder.WriteString(htmlTableHeader(translator, "Metric", "Value"))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Total References")), validationResult.References.TotalReferences))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Resolved")), validationResult.References.Resolved))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Partial")), validationResult.References.Partial))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Ambiguous")), validationResult.References.Ambiguous))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Not Found")), validationResult.References.NotFound))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("External")), validationResult.References.External))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%.1f%%</td></tr>\n", html.EscapeString(translator.T("Resolution Rate")), validationResult.References.ResolutionRate*100))
 		htmlBuilder.WriteString("</table>\n")
 
 		if len(validationResult.References.UnresolvedExamples) > 0 {
-			htmlBuilder.WriteString("<h3>Unresolved Examples</h3>\n")
+			htmlBuilder.WriteString("<h3>" + html.EscapeString(translator.T("Unresolved Examples")) + "</h3>\n")
 			htmlBuilder.WriteString("<table>\n")
-			htmlBuilder.WriteString("<tr><th>Article</th><th>Reference</th><th>Reason</th></tr>\n")
+			htmlBuilder.WriteString(htmlTableHeader(translator, "Article", "Reference", "Reason"))
 			for _, example := range validationResult.References.UnresolvedExamples {
 				htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Art %d</td><td>%s</td><td>%s</td></tr>\n",
 					example.SourceArticle,
@@ -113,21 +123,21 @@ func (validationResult *ValidationResult) ToHTML() string {
 	// Graph Connectivity
 	if validationResult.Connectivity != nil {
 		htmlBuilder.WriteString("<details class=\"section\" open>\n")
-		htmlBuilder.WriteString("<summary><h2>Graph Connectivity</h2></summary>\n")
+		htmlBuilder.WriteString("<summary><h2>" + html.EscapeString(translator.T("Graph Connectivity")) + "</h2></summary>\n")
 		htmlBuilder.WriteString("<table>\n")
-		htmlBuilder.WriteString("<tr><th>Metric</th><th>Value</th></tr>\n")
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Total Provisions</td><td>%d</td></tr>\n", validationResult.Connectivity.TotalProvisions))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Connected</td><td>%d</td></tr>\n", validationResult.Connectivity.ConnectedCount))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Orphans</td><td>%d</td></tr>\n", validationResult.Connectivity.OrphanCount))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Connectivity Rate</td><td>%.1f%%</td></tr>\n", validationResult.Connectivity.ConnectivityRate*100))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Avg Incoming Refs</td><td>%.1f</td></tr>\n", validationResult.Connectivity.AvgIncomingRefs))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Avg Outgoing Refs</td><td>%.1f</td></tr>\n", validationResult.Connectivity.AvgOutgoingRefs))
+		htmlBuilder.WriteString(htmlTableHeader(translator, "Metric", "Value"))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Total Provisions")), validationResult.Connectivity.TotalProvisions))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Connected")), validationResult.Connectivity.ConnectedCount))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Orphans")), validationResult.Connectivity.OrphanCount))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%.1f%%</td></tr>\n", html.EscapeString(translator.T("Connectivity Rate")), validationResult.Connectivity.ConnectivityRate*100))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%.1f</td></tr>\n", html.EscapeString(translator.T("Avg Incoming Refs")), validationResult.Connectivity.AvgIncomingRefs))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%.1f</td></tr>\n", html.EscapeString(translator.T("Avg Outgoing Refs")), validationResult.Connectivity.AvgOutgoingRefs))
 		htmlBuilder.WriteString("</table>\n")
 
 		if len(validationResult.Connectivity.MostReferenced) > 0 {
-			htmlBuilder.WriteString("<h3>Most Referenced Articles</h3>\n")
+			htmlBuilder.WriteString("<h3>" + html.EscapeString(translator.T("Most Referenced Articles")) + "</h3>\n")
 			htmlBuilder.WriteString("<table>\n")
-			htmlBuilder.WriteString("<tr><th>Article</th><th>References</th></tr>\n")
+			htmlBuilder.WriteString(htmlTableHeader(translator, "Article", "References"))
 			for _, articleRefCount := range validationResult.Connectivity.MostReferenced {
 				htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Art %d</td><td>%d</td></tr>\n",
 					articleRefCount.ArticleNum, articleRefCount.Count))
@@ -141,19 +151,19 @@ func (validationResult *ValidationResult) ToHTML() string {
 	// Definition Coverage
 	if validationResult.Definitions != nil {
 		htmlBuilder.WriteString("<details class=\"section\" open>\n")
-		htmlBuilder.WriteString("<summary><h2>Definition Coverage</h2></summary>\n")
+		htmlBuilder.WriteString("<summary><h2>" + html.EscapeString(translator.T("Definition Coverage")) + "</h2></summary>\n")
 		htmlBuilder.WriteString("<table>\n")
-		htmlBuilder.WriteString("<tr><th>Metric</th><th>Value</th></tr>\n")
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Total Definitions</td><td>%d</td></tr>\n", validationResult.Definitions.TotalDefinitions))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Used Definitions</td><td>%d</td></tr>\n", validationResult.Definitions.UsedDefinitions))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Unused Definitions</td><td>%d</td></tr>\n", validationResult.Definitions.UnusedDefinitions))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Usage Rate</td><td>%.1f%%</td></tr>\n", validationResult.Definitions.UsageRate*100))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Total Usages</td><td>%d</td></tr>\n", validationResult.Definitions.TotalUsages))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Articles Using Terms</td><td>%d</td></tr>\n", validationResult.Definitions.ArticlesWithTerms))
+		htmlBuilder.WriteString(htmlTableHeader(translator, "Metric", "Value"))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Total Definitions")), validationResult.Definitions.TotalDefinitions))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Used Definitions")), validationResult.Definitions.UsedDefinitions))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Unused Definitions")), validationResult.Definitions.UnusedDefinitions))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%.1f%%</td></tr>\n", html.EscapeString(translator.T("Usage Rate")), validationResult.Definitions.UsageRate*100))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Total Usages")), validationResult.Definitions.TotalUsages))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Articles Using Terms")), validationResult.Definitions.ArticlesWithTerms))
 		htmlBuilder.WriteString("</table>\n")
 
 		if len(validationResult.Definitions.UnusedTerms) > 0 {
-			htmlBuilder.WriteString("<h3>Unused Terms</h3>\n<ul>\n")
+			htmlBuilder.WriteString("<h3>" + html.EscapeString(translator.T("Unused Terms")) + "</h3>\n<ul>\n")
 			for _, term := range validationResult.Definitions.UnusedTerms {
 				htmlBuilder.WriteString(fmt.Sprintf("<li>%s</li>\n", html.EscapeString(term)))
 			}
@@ -166,26 +176,26 @@ func (validationResult *ValidationResult) ToHTML() string {
 	// Semantic Extraction
 	if validationResult.Semantics != nil {
 		htmlBuilder.WriteString("<details class=\"section\" open>\n")
-		htmlBuilder.WriteString("<summary><h2>Semantic Extraction</h2></summary>\n")
+		htmlBuilder.WriteString("<summary><h2>" + html.EscapeString(translator.T("Semantic Extraction")) + "</h2></summary>\n")
 		htmlBuilder.WriteString("<table>\n")
-		htmlBuilder.WriteString("<tr><th>Metric</th><th>Value</th></tr>\n")
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Rights Found</td><td>%d</td></tr>\n", validationResult.Semantics.RightsCount))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Obligations Found</td><td>%d</td></tr>\n", validationResult.Semantics.ObligationsCount))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Articles with Rights</td><td>%d</td></tr>\n", validationResult.Semantics.ArticlesWithRights))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Articles with Obligations</td><td>%d</td></tr>\n", validationResult.Semantics.ArticlesWithOblig))
+		htmlBuilder.WriteString(htmlTableHeader(translator, "Metric", "Value"))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Rights Found")), validationResult.Semantics.RightsCount))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Obligations Found")), validationResult.Semantics.ObligationsCount))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Articles with Rights")), validationResult.Semantics.ArticlesWithRights))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Articles with Obligations")), validationResult.Semantics.ArticlesWithOblig))
 
 		regulationLabel := validationResult.Semantics.RegulationType
 		if regulationLabel == "" {
-			regulationLabel = "known"
+			regulationLabel = translator.T("known")
 		}
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Known %s Rights</td><td>%d/%d</td></tr>\n",
-			html.EscapeString(regulationLabel),
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d/%d</td></tr>\n",
+			html.EscapeString(translator.Sprintf("Known %s Rights", regulationLabel)),
 			validationResult.Semantics.KnownRightsFound,
 			validationResult.Semantics.KnownRightsTotal))
 		htmlBuilder.WriteString("</table>\n")
 
 		if len(validationResult.Semantics.MissingRights) > 0 {
-			htmlBuilder.WriteString("<h3>Missing Rights</h3>\n<ul>\n")
+			htmlBuilder.WriteString("<h3>" + html.EscapeString(translator.T("Missing Rights")) + "</h3>\n<ul>\n")
 			for _, right := range validationResult.Semantics.MissingRights {
 				htmlBuilder.WriteString(fmt.Sprintf("<li>%s</li>\n", html.EscapeString(right)))
 			}
@@ -198,19 +208,19 @@ func (validationResult *ValidationResult) ToHTML() string {
 	// Structure Quality
 	if validationResult.Structure != nil {
 		htmlBuilder.WriteString("<details class=\"section\" open>\n")
-		htmlBuilder.WriteString("<summary><h2>Structure Quality</h2></summary>\n")
+		htmlBuilder.WriteString("<summary><h2>" + html.EscapeString(translator.T("Structure Quality")) + "</h2></summary>\n")
 		htmlBuilder.WriteString("<table>\n")
-		htmlBuilder.WriteString("<tr><th>Metric</th><th>Value</th></tr>\n")
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Articles</td><td>%d</td></tr>\n", validationResult.Structure.TotalArticles))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Chapters</td><td>%d</td></tr>\n", validationResult.Structure.TotalChapters))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Sections</td><td>%d</td></tr>\n", validationResult.Structure.TotalSections))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Recitals</td><td>%d</td></tr>\n", validationResult.Structure.TotalRecitals))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Content Rate</td><td>%.1f%%</td></tr>\n", validationResult.Structure.ContentRate*100))
-		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Structure Score</td><td>%.1f%%</td></tr>\n", validationResult.Structure.StructureScore*100))
+		htmlBuilder.WriteString(htmlTableHeader(translator, "Metric", "Value"))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Articles")), validationResult.Structure.TotalArticles))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Chapters")), validationResult.Structure.TotalChapters))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Sections")), validationResult.Structure.TotalSections))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td></tr>\n", html.EscapeString(translator.T("Recitals")), validationResult.Structure.TotalRecitals))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%.1f%%</td></tr>\n", html.EscapeString(translator.T("Content Rate")), validationResult.Structure.ContentRate*100))
+		htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%.1f%%</td></tr>\n", html.EscapeString(translator.T("Structure Score")), validationResult.Structure.StructureScore*100))
 
 		if validationResult.Structure.ExpectedArticles > 0 {
-			htmlBuilder.WriteString(fmt.Sprintf("<tr><td>Expected Articles</td><td>%d (%.1f%% complete)</td></tr>\n",
-				validationResult.Structure.ExpectedArticles, validationResult.Structure.ArticleCompleteness*100))
+			htmlBuilder.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td></tr>\n", html.EscapeString(translator.T("Expected Articles")),
+				html.EscapeString(translator.Sprintf("%d (%.1f%% complete)", validationResult.Structure.ExpectedArticles, validationResult.Structure.ArticleCompleteness*100))))
 		}
 
 		htmlBuilder.WriteString("</table>\n")
@@ -220,7 +230,7 @@ func (validationResult *ValidationResult) ToHTML() string {
 	// Issues
 	if len(validationResult.Issues) > 0 {
 		htmlBuilder.WriteString("<div class=\"section\">\n")
-		htmlBuilder.WriteString("<h2>Issues</h2>\n")
+		htmlBuilder.WriteString("<h2>" + html.EscapeString(translator.T("Issues")) + "</h2>\n")
 		for _, issue := range validationResult.Issues {
 			alertClass := "alert-error"
 			if issue.Severity == "warning" {
@@ -234,7 +244,7 @@ func (validationResult *ValidationResult) ToHTML() string {
 				html.EscapeString(issue.Category),
 				html.EscapeString(issue.Message)))
 			if issue.Count > 0 {
-				htmlBuilder.WriteString(fmt.Sprintf(" (count: %d)", issue.Count))
+				htmlBuilder.WriteString(" " + html.EscapeString(translator.Sprintf("(count: %d)", issue.Count)))
 			}
 			htmlBuilder.WriteString("\n</div>\n")
 		}
@@ -244,7 +254,7 @@ func (validationResult *ValidationResult) ToHTML() string {
 	// Warnings
 	if len(validationResult.Warnings) > 0 {
 		htmlBuilder.WriteString("<div class=\"section\">\n")
-		htmlBuilder.WriteString("<h2>Warnings</h2>\n")
+		htmlBuilder.WriteString("<h2>" + html.EscapeString(translator.T("Warnings")) + "</h2>\n")
 		for _, warning := range validationResult.Warnings {
 			htmlBuilder.WriteString("<div class=\"alert alert-warning\">\n")
 			htmlBuilder.WriteString(fmt.Sprintf("<strong>[%s]:</strong> %s\n",
@@ -449,3 +459,14 @@ func scoreToHTMLColor(score float64) string {
 	}
 	return "#f44336"
 }
+
+// htmlTableHeader returns a translated table header row.
+func htmlTableHeader(translator *i18n.Translator, columns ...string) string {
+	var headerBuilder strings.Builder
+	headerBuilder.WriteString("<tr>")
+	for _, column := range columns {
+		headerBuilder.WriteString("<th>" + html.EscapeString(translator.T(column)) + "</th>")
+	}
+	headerBuilder.WriteString("</tr>\n")
+	return headerBuilder.String()
+}
@@ -3,49 +3,56 @@ package validate
 import (
 	"fmt"
 	"strings"
+
+	"github.com/coolbeans/regula/pkg/i18n"
 )
 
 // ToMarkdown generates a Markdown-formatted validation report suitable for
 // GitHub/GitLab rendering, PR comments, and documentation.
 func (validationResult *ValidationResult) ToMarkdown() string {
+	return validationResult.ToMarkdownLocalized(nil)
+}
+
+// ToMarkdownLocalized generates the Markdown validation report with headings
+// and labels translated by translator. Status badges, issue categories, and
+// extracted values are left as-is. A nil translator renders English.
+func (validationResult *ValidationResult) ToMarkdownLocalized(translator *i18n.Translator) string {
 	var markdownBuilder strings.Builder
 
 	// Header with status badge
 	statusBadge := statusToMarkdownBadge(validationResult.Status)
-	markdownBuilder.WriteString(fmt.Sprintf("# Validation Report %s\n\n", statusBadge))
+	markdownBuilder.WriteString(fmt.Sprintf("# %s %s\n\n", translator.T("Validation Report"), statusBadge))
 
 	// Summary table
-	markdownBuilder.WriteString("## Summary\n\n")
-	markdownBuilder.WriteString("| Metric | Value |\n")
-	markdownBuilder.WriteString("|--------|-------|\n")
-	markdownBuilder.WriteString(fmt.Sprintf("| **Overall Score** | %.1f%% |\n", validationResult.OverallScore*100))
-	markdownBuilder.WriteString(fmt.Sprintf("| **Threshold** | %.1f%% |\n", validationResult.Threshold*100))
-	markdownBuilder.WriteString(fmt.Sprintf("| **Status** | %s %s |\n", statusBadge, validationResult.Status))
+	markdownBuilder.WriteString("## " + translator.T("Summary") + "\n\n")
+	markdownBuilder.WriteString(translator.MarkdownTableHeader("Metric", "Value"))
+	markdownBuilder.WriteString(fmt.Sprintf("| **%s** | %.1f%% |\n", translator.T("Overall Score"), validationResult.OverallScore*100))
+	markdownBuilder.WriteString(fmt.Sprintf("| **%s** | %.1f%% |\n", translator.T("Threshold"), validationResult.Threshold*100))
+	markdownBuilder.WriteString(fmt.Sprintf("| **%s** | %s %s |\n", translator.T("Status"), statusBadge, validationResult.Status))
 
 	if validationResult.ProfileName != "" {
-		markdownBuilder.WriteString(fmt.Sprintf("| **Profile** | %s |\n", validationResult.ProfileName))
+		markdownBuilder.WriteString(fmt.Sprintf("| **%s** | %s |\n", translator.T("Profile"), validationResult.ProfileName))
 	}
 
 	markdownBuilder.WriteString("\n")
 
 	// Component Scores
 	if validationResult.ComponentScores != nil {
-		markdownBuilder.WriteString("## Component Scores\n\n")
-		markdownBuilder.WriteString("| Component | Score | Weight |\n")
-		markdownBuilder.WriteString("|-----------|-------|--------|\n")
-		markdownBuilder.WriteString(fmt.Sprintf("| References | %.1f%% | %.0f%% |\n",
+		markdownBuilder.WriteString("## " + translator.T("Component Scores") + "\n\n")
+		markdownBuilder.WriteString(translator.MarkdownTableHeader("Component", "Score", "Weight"))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %.1f%% | %.0f%% |\n", translator.T("References"),
 			validationResult.ComponentScores.ReferenceScore*100,
 			validationResult.ComponentScores.ReferenceWeight*100))
-		markdownBuilder.WriteString(fmt.Sprintf("| Connectivity | %.1f%% | %.0f%% |\n",
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %.1f%% | %.0f%% |\n", translator.T("Connectivity"),
 			validationResult.ComponentScores.ConnectivityScore*100,
 			validationResult.ComponentScores.ConnectivityWeight*100))
-		markdownBuilder.WriteString(fmt.Sprintf("| Definitions | %.1f%% | %.0f%% |\n",
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %.1f%% | %.0f%% |\n", translator.T("Definitions"),
 			validationResult.ComponentScores.DefinitionScore*100,
 			validationResult.ComponentScores.DefinitionWeight*100))
-		markdownBuilder.WriteString(fmt.Sprintf("| Semantics | %.1f%% | %.0f%% |\n",
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %.1f%% | %.0f%% |\n", translator.T("Semantics"),
 			validationResult.ComponentScores.SemanticScore*100,
 			validationResult.ComponentScores.SemanticWeight*100))
-		markdownBuilder.WriteString(fmt.Sprintf("| Structure | %.1f%% | %.0f%% |\n",
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %.1f%% | %.0f%% |\n", translator.T("Structure"),
 			validationResult.ComponentScores.StructureScore*100,
 			validationResult.ComponentScores.StructureWeight*100))
 		markdownBuilder.WriteString("\n")
@@ -53,30 +60,28 @@ func (validationResult *ValidationResult) ToMarkdown() string {
 
 	// Reference Resolution
 	if validationResult.References != nil {
-		markdownBuilder.WriteString("## Reference Resolution\n\n")
-		markdownBuilder.WriteString("| Metric | Value |\n")
-		markdownBuilder.WriteString("|--------|-------|\n")
-		markdownBuilder.WriteString(fmt.Sprintf("| Total References | %d |\n", validationResult.References.TotalReferences))
-		markdownBuilder.WriteString(fmt.Sprintf("| Resolved | %d |\n", validationResult.References.Resolved))
-		markdownBuilder.WriteString(fmt.Sprintf("| Partial | %d |\n", validationResult.References.Partial))
-		markdownBuilder.WriteString(fmt.Sprintf("| Ambiguous | %d |\n", validationResult.References.Ambiguous))
-		markdownBuilder.WriteString(fmt.Sprintf("| Not Found | %d |\n", validationResult.References.NotFound))
-		markdownBuilder.WriteString(fmt.Sprintf("| External | %d |\n", validationResult.References.External))
-		markdownBuilder.WriteString(fmt.Sprintf("| Range Refs | %d |\n", validationResult.References.RangeRefs))
-		markdownBuilder.WriteString(fmt.Sprintf("| Resolution Rate | %.1f%% |\n", validationResult.References.ResolutionRate*100))
+		markdownBuilder.WriteString("## " + translator.T("Reference Resolution") + "\n\n")
+		markdownBuilder.WriteString(translator.MarkdownTableHeader("Metric", "Value"))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Total References"), validationResult.References.TotalReferences))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Resolved"), validationResult.References.Resolved))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Partial"), validationResult.References.Partial))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Ambiguous"), validationResult.References.Ambiguous))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Not Found"), validationResult.References.NotFound))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("External"), validationResult.References.External))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Range Refs"), validationResult.References.RangeRefs))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %.1f%% |\n", translator.T("Resolution Rate"), validationResult.References.ResolutionRate*100))
 		markdownBuilder.WriteString("\n")
 
 		// Confidence breakdown
-		markdownBuilder.WriteString("**Confidence Distribution:**\n\n")
-		markdownBuilder.WriteString(fmt.Sprintf("- High: %d\n", validationResult.References.HighConfidence))
-		markdownBuilder.WriteString(fmt.Sprintf("- Medium: %d\n", validationResult.References.MediumConfidence))
-		markdownBuilder.WriteString(fmt.Sprintf("- Low: %d\n", validationResult.References.LowConfidence))
+		markdownBuilder.WriteString("**" + translator.T("Confidence Distribution") + ":**\n\n")
+		markdownBuilder.WriteString(fmt.Sprintf("- %s: %d\n", translator.T("High"), validationResult.References.HighConfidence))
+		markdownBuilder.WriteString(fmt.Sprintf("- %s: %d\n", translator.T("Medium"), validationResult.References.MediumConfidence))
+		markdownBuilder.WriteString(fmt.Sprintf("- %s: %d\n", translator.T("Low"), validationResult.References.LowConfidence))
 		markdownBuilder.WriteString("\n")
 
 		if len(validationResult.References.UnresolvedExamples) > 0 {
-			markdownBuilder.WriteString("**Unresolved Examples:**\n\n")
-			markdownBuilder.WriteString("| Article | Reference | Reason |\n")
-			markdownBuilder.WriteString("|---------|-----------|--------|\n")
+			markdownBuilder.WriteString("**" + translator.T("Unresolved Examples") + ":**\n\n")
+			markdownBuilder.WriteString(translator.MarkdownTableHeader("Article", "Reference", "Reason"))
 			for _, example := range validationResult.References.UnresolvedExamples {
 				markdownBuilder.WriteString(fmt.Sprintf("| Art %d | %s | %s |\n",
 					example.SourceArticle, escapeMarkdownTableCell(example.RawText), example.Reason))
@@ -85,9 +90,8 @@ func (validationResult *ValidationResult) ToMarkdown() string {
 		}
 
 		if len(validationResult.References.AmbiguousExamples) > 0 {
-			markdownBuilder.WriteString("**Ambiguous Examples:**\n\n")
-			markdownBuilder.WriteString("| Article | Reference | Reason |\n")
-			markdownBuilder.WriteString("|---------|-----------|--------|\n")
+			markdownBuilder.WriteString("**" + translator.T("Ambiguous Examples") + ":**\n\n")
+			markdownBuilder.WriteString(translator.MarkdownTableHeader("Article", "Reference", "Reason"))
 			for _, example := range validationResult.References.AmbiguousExamples {
 				markdownBuilder.WriteString(fmt.Sprintf("| Art %d | %s | %s |\n",
 					example.SourceArticle, escapeMarkdownTableCell(example.RawText), example.Reason))
@@ -98,15 +102,14 @@ func (validationResult *ValidationResult) ToMarkdown() string {
 
 	// Graph Connectivity
 	if validationResult.Connectivity != nil {
-		markdownBuilder.WriteString("## Graph Connectivity\n\n")
-		markdownBuilder.WriteString("| Metric | Value |\n")
-		markdownBuilder.WriteString("|--------|-------|\n")
-		markdownBuilder.WriteString(fmt.Sprintf("| Total Provisions | %d |\n", validationResult.Connectivity.TotalProvisions))
-		markdownBuilder.WriteString(fmt.Sprintf("| Connected | %d |\n", validationResult.Connectivity.ConnectedCount))
-		markdownBuilder.WriteString(fmt.Sprintf("| Orphans | %d |\n", validationResult.Connectivity.OrphanCount))
-		markdownBuilder.WriteString(fmt.Sprintf("| Connectivity Rate | %.1f%% |\n", validationResult.Connectivity.ConnectivityRate*100))
-		markdownBuilder.WriteString(fmt.Sprintf("| Avg Incoming Refs | %.1f |\n", validationResult.Connectivity.AvgIncomingRefs))
-		markdownBuilder.WriteString(fmt.Sprintf("| Avg Outgoing Refs | %.1f |\n", validationResult.Connectivity.AvgOutgoingRefs))
+		markdownBuilder.WriteString("## " + translator.T("Graph Connectivity") + "\n\n")
+		markdownBuilder.WriteString(translator.MarkdownTableHeader("Metric", "Value"))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Total Provisions"), validationResult.Connectivity.TotalProvisions))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Connected"), validationResult.Connectivity.ConnectedCount))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Orphans"), validationResult.Connectivity.OrphanCount))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %.1f%% |\n", translator.T("Connectivity Rate"), validationResult.Connectivity.ConnectivityRate*100))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %.1f |\n", translator.T("Avg Incoming Refs"), validationResult.Connectivity.AvgIncomingRefs))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %.1f |\n", translator.T("Avg Outgoing Refs"), validationResult.Connectivity.AvgOutgoingRefs))
 		markdownBuilder.WriteString("\n")
 
 		if len(validationResult.Connectivity.OrphanArticles) > 0 {
@@ -114,13 +117,12 @@ func (validationResult *ValidationResult) ToMarkdown() string {
 			for i, articleNum := range validationResult.Connectivity.OrphanArticles {
 				articleStrings[i] = fmt.Sprintf("%d", articleNum)
 			}
-			markdownBuilder.WriteString(fmt.Sprintf("**Orphan Articles:** %s\n\n", strings.Join(articleStrings, ", ")))
+			markdownBuilder.WriteString(fmt.Sprintf("**%s:** %s\n\n", translator.T("Orphan Articles"), strings.Join(articleStrings, ", ")))
 		}
 
 		if len(validationResult.Connectivity.MostReferenced) > 0 {
-			markdownBuilder.WriteString("**Most Referenced Articles:**\n\n")
-			markdownBuilder.WriteString("| Article | References |\n")
-			markdownBuilder.WriteString("|---------|------------|\n")
+			markdownBuilder.WriteString("**" + translator.T("Most Referenced Articles") + ":**\n\n")
+			markdownBuilder.WriteString(translator.MarkdownTableHeader("Article", "References"))
 			for _, articleRefCount := range validationResult.Connectivity.MostReferenced {
 				markdownBuilder.WriteString(fmt.Sprintf("| Art %d | %d |\n",
 					articleRefCount.ArticleNum, articleRefCount.Count))
@@ -131,19 +133,18 @@ func (validationResult *ValidationResult) ToMarkdown() string {
 
 	// Definition Coverage
 	if validationResult.Definitions != nil {
-		markdownBuilder.WriteString("## Definition Coverage\n\n")
-		markdownBuilder.WriteString("| Metric | Value |\n")
-		markdownBuilder.WriteString("|--------|-------|\n")
-		markdownBuilder.WriteString(fmt.Sprintf("| Total Definitions | %d |\n", validationResult.Definitions.TotalDefinitions))
-		markdownBuilder.WriteString(fmt.Sprintf("| Used Definitions | %d |\n", validationResult.Definitions.UsedDefinitions))
-		markdownBuilder.WriteString(fmt.Sprintf("| Unused Definitions | %d |\n", validationResult.Definitions.UnusedDefinitions))
-		markdownBuilder.WriteString(fmt.Sprintf("| Usage Rate | %.1f%% |\n", validationResult.Definitions.UsageRate*100))
-		markdownBuilder.WriteString(fmt.Sprintf("| Total Usages | %d |\n", validationResult.Definitions.TotalUsages))
-		markdownBuilder.WriteString(fmt.Sprintf("| Articles Using Terms | %d |\n", validationResult.Definitions.ArticlesWithTerms))
+		markdownBuilder.WriteString("## " + translator.T("Definition Coverage") + "\n\n")
+		markdownBuilder.WriteString(translator.MarkdownTableHeader("Metric", "Value"))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Total Definitions"), validationResult.Definitions.TotalDefinitions))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Used Definitions"), validationResult.Definitions.UsedDefinitions))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Unused Definitions"), validationResult.Definitions.UnusedDefinitions))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %.1f%% |\n", translator.T("Usage Rate"), validationResult.Definitions.UsageRate*100))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Total Usages"), validationResult.Definitions.TotalUsages))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Articles Using Terms"), validationResult.Definitions.ArticlesWithTerms))
 		markdownBuilder.WriteString("\n")
 
 		if len(validationResult.Definitions.UnusedTerms) > 0 {
-			markdownBuilder.WriteString("**Unused Terms:**\n\n")
+			markdownBuilder.WriteString("**" + translator.T("Unused Terms") + ":**\n\n")
 			for _, term := range validationResult.Definitions.UnusedTerms {
 				markdownBuilder.WriteString(fmt.Sprintf("- %s\n", term))
 			}
@@ -151,9 +152,8 @@ func (validationResult *ValidationResult) ToMarkdown() string {
 		}
 
 		if len(validationResult.Definitions.MostUsedTerms) > 0 {
-			markdownBuilder.WriteString("**Most Used Terms:**\n\n")
-			markdownBuilder.WriteString("| Term | Usages | Articles |\n")
-			markdownBuilder.WriteString("|------|--------|----------|\n")
+			markdownBuilder.WriteString("**" + translator.T("Most Used Terms") + ":**\n\n")
+			markdownBuilder.WriteString(translator.MarkdownTableHeader("Term", "Usages", "Articles"))
 			for _, termUsageCount := range validationResult.Definitions.MostUsedTerms {
 				markdownBuilder.WriteString(fmt.Sprintf("| %s | %d | %d |\n",
 					termUsageCount.Term, termUsageCount.UsageCount, termUsageCount.ArticleCount))
@@ -164,24 +164,23 @@ func (validationResult *ValidationResult) ToMarkdown() string {
 
 	// Semantic Extraction
 	if validationResult.Semantics != nil {
-		markdownBuilder.WriteString("## Semantic Extraction\n\n")
-		markdownBuilder.WriteString("| Metric | Value |\n")
-		markdownBuilder.WriteString("|--------|-------|\n")
-		markdownBuilder.WriteString(fmt.Sprintf("| Rights Found | %d |\n", validationResult.Semantics.RightsCount))
-		markdownBuilder.WriteString(fmt.Sprintf("| Obligations Found | %d |\n", validationResult.Semantics.ObligationsCount))
-		markdownBuilder.WriteString(fmt.Sprintf("| Articles with Rights | %d |\n", validationResult.Semantics.ArticlesWithRights))
-		markdownBuilder.WriteString(fmt.Sprintf("| Articles with Obligations | %d |\n", validationResult.Semantics.ArticlesWithOblig))
+		markdownBuilder.WriteString("## " + translator.T("Semantic Extraction") + "\n\n")
+		markdownBuilder.WriteString(translator.MarkdownTableHeader("Metric", "Value"))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Rights Found"), validationResult.Semantics.RightsCount))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Obligations Found"), validationResult.Semantics.ObligationsCount))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Articles with Rights"), validationResult.Semantics.ArticlesWithRights))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Articles with Obligations"), validationResult.Semantics.ArticlesWithOblig))
 
 		regulationLabel := validationResult.Semantics.RegulationType
 		if regulationLabel == "" {
-			regulationLabel = "known"
+			regulationLabel = translator.T("known")
 		}
-		markdownBuilder.WriteString(fmt.Sprintf("| Known %s Rights | %d/%d |\n",
-			regulationLabel, validationResult.Semantics.KnownRightsFound, validationResult.Semantics.KnownRightsTotal))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d/%d |\n",
+			translator.Sprintf("Known %s Rights", regulationLabel), validationResult.Semantics.KnownRightsFound, validationResult.Semantics.KnownRightsTotal))
 		markdownBuilder.WriteString("\n")
 
 		if len(validationResult.Semantics.MissingRights) > 0 {
-			markdownBuilder.WriteString("**Missing Rights:**\n\n")
+			markdownBuilder.WriteString("**" + translator.T("Missing Rights") + ":**\n\n")
 			for _, right := range validationResult.Semantics.MissingRights {
 				markdownBuilder.WriteString(fmt.Sprintf("- %s\n", right))
 			}
@@ -189,35 +188,34 @@ func (validationResult *ValidationResult) ToMarkdown() string {
 		}
 
 		if len(validationResult.Semantics.RightTypes) > 0 {
-			markdownBuilder.WriteString(fmt.Sprintf("**Right Types:** %s\n\n",
+			markdownBuilder.WriteString(fmt.Sprintf("**%s:** %s\n\n", translator.T("Right Types"),
 				strings.Join(validationResult.Semantics.RightTypes, ", ")))
 		}
 
 		if len(validationResult.Semantics.ObligationTypes) > 0 {
-			markdownBuilder.WriteString(fmt.Sprintf("**Obligation Types:** %s\n\n",
+			markdownBuilder.WriteString(fmt.Sprintf("**%s:** %s\n\n", translator.T("Obligation Types"),
 				strings.Join(validationResult.Semantics.ObligationTypes, ", ")))
 		}
 	}
 
 	// Structure Quality
 	if validationResult.Structure != nil {
-		markdownBuilder.WriteString("## Structure Quality\n\n")
-		markdownBuilder.WriteString("| Metric | Value |\n")
-		markdownBuilder.WriteString("|--------|-------|\n")
-		markdownBuilder.WriteString(fmt.Sprintf("| Articles | %d |\n", validationResult.Structure.TotalArticles))
-		markdownBuilder.WriteString(fmt.Sprintf("| Chapters | %d |\n", validationResult.Structure.TotalChapters))
-		markdownBuilder.WriteString(fmt.Sprintf("| Sections | %d |\n", validationResult.Structure.TotalSections))
-		markdownBuilder.WriteString(fmt.Sprintf("| Recitals | %d |\n", validationResult.Structure.TotalRecitals))
-		markdownBuilder.WriteString(fmt.Sprintf("| Content Rate | %.1f%% |\n", validationResult.Structure.ContentRate*100))
-		markdownBuilder.WriteString(fmt.Sprintf("| Structure Score | %.1f%% |\n", validationResult.Structure.StructureScore*100))
+		markdownBuilder.WriteString("## " + translator.T("Structure Quality") + "\n\n")
+		markdownBuilder.WriteString(translator.MarkdownTableHeader("Metric", "Value"))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Articles"), validationResult.Structure.TotalArticles))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Chapters"), validationResult.Structure.TotalChapters))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Sections"), validationResult.Structure.TotalSections))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %d |\n", translator.T("Recitals"), validationResult.Structure.TotalRecitals))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %.1f%% |\n", translator.T("Content Rate"), validationResult.Structure.ContentRate*100))
+		markdownBuilder.WriteString(fmt.Sprintf("| %s | %.1f%% |\n", translator.T("Structure Score"), validationResult.Structure.StructureScore*100))
 
 		if validationResult.Structure.ExpectedArticles > 0 {
-			markdownBuilder.WriteString(fmt.Sprintf("| Expected Articles | %d (%.1f%% complete) |\n",
-				validationResult.Structure.ExpectedArticles, validationResult.Structure.ArticleCompleteness*100))
+			markdownBuilder.WriteString(fmt.Sprintf("| %s | %s |\n", translator.T("Expected Articles"),
+				translator.Sprintf("%d (%.1f%% complete)", validationResult.Structure.ExpectedArticles, validationResult.Structure.ArticleCompleteness*100)))
 		}
 		if validationResult.Structure.ExpectedChapters > 0 {
-			markdownBuilder.WriteString(fmt.Sprintf("| Expected Chapters | %d (%.1f%% complete) |\n",
-				validationResult.Structure.ExpectedChapters, validationResult.Structure.ChapterCompleteness*100))
+			markdownBuilder.WriteString(fmt.Sprintf("| %s | %s |\n", translator.T("Expected Chapters"),
+				translator.Sprintf("%d (%.1f%% complete)", validationResult.Structure.ExpectedChapters, validationResult.Structure.ChapterCompleteness*100)))
 		}
 
 		markdownBuilder.WriteString("\n")
@@ -225,9 +223,8 @@ func (validationResult *ValidationResult) ToMarkdown() string {
 
 	// Issues
 	if len(validationResult.Issues) > 0 {
-		markdownBuilder.WriteString("## Issues\n\n")
-		markdownBuilder.WriteString("| Severity | Category | Message | Count |\n")
-		markdownBuilder.WriteString("|----------|----------|---------|-------|\n")
+		markdownBuilder.WriteString("## " + translator.T("Issues") + "\n\n")
+		markdownBuilder.WriteString(translator.MarkdownTableHeader("Severity", "Category", "Message", "Count"))
 		for _, issue := range validationResult.Issues {
 			countStr := ""
 			if issue.Count > 0 {
@@ -241,9 +238,8 @@ func (validationResult *ValidationResult) ToMarkdown() string {
 
 	// Warnings
 	if len(validationResult.Warnings) > 0 {
-		markdownBuilder.WriteString("## Warnings\n\n")
-		markdownBuilder.WriteString("| Category | Message |\n")
-		markdownBuilder.WriteString("|----------|---------|\n")
+		markdownBuilder.WriteString("## " + translator.T("Warnings") + "\n\n")
+		markdownBuilder.WriteString(translator.MarkdownTableHeader("Category", "Message"))
 		for _, warning := range validationResult.Warnings {
 			markdownBuilder.WriteString(fmt.Sprintf("| %s | %s |\n",
 				warning.Category, escapeMarkdownTableCell(warning.Message)))
@@ -1,9 +1,14 @@
 package validate
 
 import (
+	"archive/zip"
+	"bytes"
+	"io"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/coolbeans/regula/pkg/i18n"
 )
 
 // buildTestValidationResult creates a ValidationResult with all components populated for testing.
@@ -633,3 +638,100 @@ func TestScoreToHTMLColor(t *testing.T) {
 		}
 	}
 }
+
+func TestValidationResult_Localized(t *testing.T) {
+	validationResult := buildTestValidationResult(StatusPass, 0.876)
+	translator, err := i18n.New("es")
+	if err != nil {
+		t.Fatalf("i18n.New failed: %v", err)
+	}
+
+	outputs := map[string]struct {
+		output   string
+		expected []string
+	}{
+		"text": {validationResult.StringLocalized(translator), []string{
+			"Informe de validación\n=====================\n", "Resolución de referencias:", "Umbral: 80.0%"}},
+		"markdown": {validationResult.ToMarkdownLocalized(translator), []string{
+			"# Informe de validación `PASS`", "| Métrica | Valor |\n|---------|-------|", "| **Puntuación global** | 87.6% |"}},
+		"html": {validationResult.ToHTMLLocalized(translator), []string{
+			`<html lang="es">`, "<title>Informe de validación</title>", "<th>Métrica</th>"}},
+	}
+
+	for format, rendered := range outputs {
+		for _, expected := range rendered.expected {
+			if !strings.Contains(rendered.output, expected) {
+				t.Errorf("%s output missing %q", format, expected)
+			}
+		}
+	}
+
+	if validationResult.StringLocalized(nil) != validationResult.String() {
+		t.Error("StringLocalized(nil) should match String()")
+	}
+}
+
+// readXLSXPart returns a part of an .xlsx file, such as xl/workbook.xml.
+func readXLSXPart(t *testing.T, data []byte, name string) string {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("not an .xlsx file: %v", err)
+	}
+	reader, err := archive.Open(name)
+	if err != nil {
+		t.Fatalf("missing part %s: %v", name, err)
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestValidationResult_ToXLSX(t *testing.T) {
+	data, err := buildTestValidationResult(StatusPass, 0.876).ToXLSX()
+	if err != nil {
+		t.Fatalf("ToXLSX() error = %v", err)
+	}
+
+	workbook := readXLSXPart(t, data, "xl/workbook.xml")
+	for _, sheet := range []string{"Summary", "Component Scores", "Metrics", "Issues", "Unresolved References", "Term Usage"} {
+		if !strings.Contains(workbook, `name="`+sheet+`"`) {
+			t.Errorf("workbook missing sheet %q: %s", sheet, workbook)
+		}
+	}
+	summary := readXLSXPart(t, data, "xl/worksheets/sheet1.xml")
+	if !strings.Contains(summary, "<v>0.876</v>") || !strings.Contains(summary, "GDPR") {
+		t.Errorf("summary sheet missing the score or profile: %s", summary)
+	}
+	unresolved := readXLSXPart(t, data, "xl/worksheets/sheet5.xml")
+	if !strings.Contains(unresolved, "Article 99(2)") || !strings.Contains(unresolved, "ambiguous") {
+		t.Errorf("unresolved references sheet: %s", unresolved)
+	}
+}
+
+func TestGateReport_ToXLSX(t *testing.T) {
+	gateReport := &GateReport{
+		Results: []*GateResult{
+			{Gate: "V0", Passed: true, Score: 1, Metrics: map[string]float64{"parse_rate": 1}},
+			{Gate: "V1", Score: 0.4, Errors: []GateError{{Metric: "articles", Message: "too few articles", Value: 2}}},
+		},
+		TotalScore:  0.7,
+		GatesPassed: 1,
+		GatesFailed: 1,
+	}
+	data, err := gateReport.ToXLSX()
+	if err != nil {
+		t.Fatalf("ToXLSX() error = %v", err)
+	}
+	gates := readXLSXPart(t, data, "xl/worksheets/sheet2.xml")
+	if !strings.Contains(gates, "FAIL") || !strings.Contains(gates, "V1") {
+		t.Errorf("gates sheet: %s", gates)
+	}
+	findings := readXLSXPart(t, data, "xl/worksheets/sheet4.xml")
+	if !strings.Contains(findings, "too few articles") {
+		t.Errorf("findings sheet: %s", findings)
+	}
+}
@@ -0,0 +1,170 @@
+package validate
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/xlsx"
+)
+
+// ToXLSX generates the validation report as an Excel workbook with a sheet
+// per section: the summary, component scores, metrics, issues, unresolved
+// references, and term usage.
+func (validationResult *ValidationResult) ToXLSX() ([]byte, error) {
+	workbook := xlsx.NewWorkbook()
+
+	summary := workbook.AddSheet("Summary", "Metric", "Value")
+	summary.AddRow("Status", string(validationResult.Status))
+	summary.AddRow("Overall Score", xlsx.Percent(validationResult.OverallScore))
+	summary.AddRow("Threshold", xlsx.Percent(validationResult.Threshold))
+	if validationResult.ProfileName != "" {
+		summary.AddRow("Profile", validationResult.ProfileName)
+	}
+
+	if scores := validationResult.ComponentScores; scores != nil {
+		components := workbook.AddSheet("Component Scores", "Component", "Score", "Weight")
+		components.AddRow("References", xlsx.Percent(scores.ReferenceScore), xlsx.Percent(scores.ReferenceWeight))
+		components.AddRow("Connectivity", xlsx.Percent(scores.ConnectivityScore), xlsx.Percent(scores.ConnectivityWeight))
+		components.AddRow("Definitions", xlsx.Percent(scores.DefinitionScore), xlsx.Percent(scores.DefinitionWeight))
+		components.AddRow("Semantics", xlsx.Percent(scores.SemanticScore), xlsx.Percent(scores.SemanticWeight))
+		components.AddRow("Structure", xlsx.Percent(scores.StructureScore), xlsx.Percent(scores.StructureWeight))
+	}
+
+	metrics := workbook.AddSheet("Metrics", "Component", "Metric", "Value")
+	if references := validationResult.References; references != nil {
+		metrics.AddRow("References", "Total references", references.TotalReferences)
+		metrics.AddRow("References", "Resolved", references.Resolved)
+		metrics.AddRow("References", "Partial", references.Partial)
+		metrics.AddRow("References", "Ambiguous", references.Ambiguous)
+		metrics.AddRow("References", "Not found", references.NotFound)
+		metrics.AddRow("References", "External", references.External)
+		metrics.AddRow("References", "Range references", references.RangeRefs)
+		metrics.AddRow("References", "Resolution rate", xlsx.Percent(references.ResolutionRate))
+		metrics.AddRow("References", "High confidence", references.HighConfidence)
+		metrics.AddRow("References", "Medium confidence", references.MediumConfidence)
+		metrics.AddRow("References", "Low confidence", references.LowConfidence)
+	}
+	if connectivity := validationResult.Connectivity; connectivity != nil {
+		metrics.AddRow("Connectivity", "Total provisions", connectivity.TotalProvisions)
+		metrics.AddRow("Connectivity", "Connected", connectivity.ConnectedCount)
+		metrics.AddRow("Connectivity", "Orphans", connectivity.OrphanCount)
+		metrics.AddRow("Connectivity", "Connectivity rate", xlsx.Percent(connectivity.ConnectivityRate))
+		metrics.AddRow("Connectivity", "Average incoming references", connectivity.AvgIncomingRefs)
+		metrics.AddRow("Connectivity", "Average outgoing references", connectivity.AvgOutgoingRefs)
+	}
+	if definitions := validationResult.Definitions; definitions != nil {
+		metrics.AddRow("Definitions", "Total definitions", definitions.TotalDefinitions)
+		metrics.AddRow("Definitions", "Used", definitions.UsedDefinitions)
+		metrics.AddRow("Definitions", "Unused", definitions.UnusedDefinitions)
+		metrics.AddRow("Definitions", "Usage rate", xlsx.Percent(definitions.UsageRate))
+		metrics.AddRow("Definitions", "Total usages", definitions.TotalUsages)
+		metrics.AddRow("Definitions", "Articles with terms", definitions.ArticlesWithTerms)
+	}
+	if semantics := validationResult.Semantics; semantics != nil {
+		metrics.AddRow("Semantics", "Rights", semantics.RightsCount)
+		metrics.AddRow("Semantics", "Obligations", semantics.ObligationsCount)
+		metrics.AddRow("Semantics", "Articles with rights", semantics.ArticlesWithRights)
+		metrics.AddRow("Semantics", "Articles with obligations", semantics.ArticlesWithOblig)
+		if semantics.KnownRightsTotal > 0 {
+			metrics.AddRow("Semantics", "Known rights found", semantics.KnownRightsFound)
+			metrics.AddRow("Semantics", "Known rights total", semantics.KnownRightsTotal)
+			metrics.AddRow("Semantics", "Missing rights", strings.Join(semantics.MissingRights, ", "))
+		}
+	}
+	if structure := validationResult.Structure; structure != nil {
+		metrics.AddRow("Structure", "Articles", structure.TotalArticles)
+		metrics.AddRow("Structure", "Chapters", structure.TotalChapters)
+		metrics.AddRow("Structure", "Sections", structure.TotalSections)
+		metrics.AddRow("Structure", "Recitals", structure.TotalRecitals)
+		metrics.AddRow("Structure", "Article completeness", xlsx.Percent(structure.ArticleCompleteness))
+		metrics.AddRow("Structure", "Definition completeness", xlsx.Percent(structure.DefinitionCompleteness))
+		metrics.AddRow("Structure", "Chapter completeness", xlsx.Percent(structure.ChapterCompleteness))
+		metrics.AddRow("Structure", "Content rate", xlsx.Percent(structure.ContentRate))
+	}
+
+	issues := workbook.AddSheet("Issues", "Severity", "Category", "Message", "Count", "Examples")
+	for _, issue := range append(append([]ValidationIssue(nil), validationResult.Issues...), validationResult.Warnings...) {
+		issues.AddRow(issue.Severity, issue.Category, issue.Message, issue.Count, strings.Join(issue.Examples, "; "))
+	}
+
+	if references := validationResult.References; references != nil {
+		unresolved := workbook.AddSheet("Unresolved References", "Kind", "Source Article", "Reference", "Reason")
+		for _, example := range references.UnresolvedExamples {
+			unresolved.AddRow("unresolved", example.SourceArticle, example.RawText, example.Reason)
+		}
+		for _, example := range references.AmbiguousExamples {
+			unresolved.AddRow("ambiguous", example.SourceArticle, example.RawText, example.Reason)
+		}
+	}
+
+	if definitions := validationResult.Definitions; definitions != nil {
+		terms := workbook.AddSheet("Term Usage", "Term", "Usages", "Articles")
+		for _, term := range definitions.MostUsedTerms {
+			terms.AddRow(term.Term, term.UsageCount, term.ArticleCount)
+		}
+		for _, term := range definitions.UnusedTerms {
+			terms.AddRow(term, 0, 0)
+		}
+	}
+
+	return workbook.Bytes()
+}
+
+// ToXLSX generates the gate report as an Excel workbook with sheets for the
+// summary, each gate's status, its metrics, its warnings and errors, and
+// shape violations.
+func (gateReport *GateReport) ToXLSX() ([]byte, error) {
+	workbook := xlsx.NewWorkbook()
+
+	summary := workbook.AddSheet("Summary", "Metric", "Value")
+	overallStatus := "PASS"
+	if !gateReport.OverallPass {
+		overallStatus = "FAIL"
+	}
+	summary.AddRow("Status", overallStatus)
+	summary.AddRow("Overall Score", xlsx.Percent(gateReport.TotalScore))
+	summary.AddRow("Gates Passed", gateReport.GatesPassed)
+	summary.AddRow("Gates Failed", gateReport.GatesFailed)
+	summary.AddRow("Gates Skipped", gateReport.GatesSkipped)
+	summary.AddRow("Duration", gateReport.Duration.String())
+	if gateReport.HaltedAt != "" {
+		summary.AddRow("Halted At", gateReport.HaltedAt)
+	}
+
+	gates := workbook.AddSheet("Gates", "Gate", "Status", "Score", "Duration", "Skip Reason")
+	metrics := workbook.AddSheet("Metrics", "Gate", "Metric", "Value")
+	findings := workbook.AddSheet("Findings", "Gate", "Severity", "Metric", "Message", "Value")
+	violations := workbook.AddSheet("Shape Violations", "Gate", "Focus Node", "Shape", "Path", "Constraint", "Severity", "Message", "Value")
+	for _, gateResult := range gateReport.Results {
+		statusLabel := "PASS"
+		if gateResult.Skipped {
+			statusLabel = "SKIP"
+		} else if !gateResult.Passed {
+			statusLabel = "FAIL"
+		}
+		gates.AddRow(gateResult.Gate, statusLabel, xlsx.Percent(gateResult.Score), gateResult.Duration.String(), gateResult.SkipReason)
+
+		metricNames := make([]string, 0, len(gateResult.Metrics))
+		for metricName := range gateResult.Metrics {
+			metricNames = append(metricNames, metricName)
+		}
+		sort.Strings(metricNames)
+		for _, metricName := range metricNames {
+			metrics.AddRow(gateResult.Gate, metricName, xlsx.Percent(gateResult.Metrics[metricName]))
+		}
+
+		for _, gateError := range gateResult.Errors {
+			findings.AddRow(gateResult.Gate, "error", gateError.Metric, gateError.Message, gateError.Value)
+		}
+		for _, gateWarning := range gateResult.Warnings {
+			findings.AddRow(gateResult.Gate, "warning", gateWarning.Metric, gateWarning.Message, gateWarning.Value)
+		}
+
+		for _, violation := range gateResult.ShapeViolations {
+			violations.AddRow(gateResult.Gate, violation.FocusNode, violation.Shape, violation.Path,
+				violation.Constraint, string(violation.Severity), violation.Message, violation.Value)
+		}
+	}
+
+	return workbook.Bytes()
+}
@@ -0,0 +1,480 @@
+package shapes
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+//go:embed default.yaml
+var defaultShapesYAML []byte
+
+// namespaceSHACL is the W3C SHACL vocabulary namespace.
+const namespaceSHACL = "http://www.w3.org/ns/shacl#"
+
+// DefaultShapeSet returns the built-in shapes for the regulation ontology.
+func DefaultShapeSet() *ShapeSet {
+	shapeSet, err := ParseYAML(defaultShapesYAML)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in shapes: %v", err))
+	}
+	return shapeSet
+}
+
+// LoadShapes reads a shapes file. Files ending in .ttl are parsed as SHACL
+// Turtle; everything else as YAML.
+func LoadShapes(path string) (*ShapeSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shapes file: %w", err)
+	}
+
+	var shapeSet *ShapeSet
+	if strings.EqualFold(filepath.Ext(path), ".ttl") {
+		shapeSet, err = ParseTurtle(data)
+	} else {
+		shapeSet, err = ParseYAML(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return shapeSet, nil
+}
+
+// ParseYAML parses a YAML shapes file:
+//
+//	shapes:
+//	  - name: ArticleShape
+//	    target_class: reg:Article
+//	    properties:
+//	      - path: reg:title
+//	        min_count: 1
+//	        max_count: 1
+func ParseYAML(data []byte) (*ShapeSet, error) {
+	shapeSet := &ShapeSet{}
+	if err := yaml.Unmarshal(data, shapeSet); err != nil {
+		return nil, fmt.Errorf("failed to parse shapes: %w", err)
+	}
+	if err := shapeSet.Compile(); err != nil {
+		return nil, err
+	}
+	return shapeSet, nil
+}
+
+// ParseTurtle parses the subset of SHACL Turtle used for node shapes:
+// sh:NodeShape subjects with sh:targetClass, sh:severity, and sh:property
+// blank nodes carrying sh:path, sh:minCount, sh:maxCount, sh:minLength,
+// sh:pattern, sh:nodeKind, sh:class, sh:severity, and sh:message.
+// IRIs in the regulation ontology namespaces are compacted to the prefixed
+// form used by the triple store (e.g., reg:title).
+func ParseTurtle(data []byte) (*ShapeSet, error) {
+	tokens, err := tokenizeTurtle(string(data))
+	if err != nil {
+		return nil, err
+	}
+	parser := &turtleParser{
+		tokens:   tokens,
+		prefixes: make(map[string]string),
+		graph:    store.NewTripleStore(),
+	}
+	if err := parser.parse(); err != nil {
+		return nil, err
+	}
+
+	graph := parser.graph
+	shapeSet := &ShapeSet{}
+	for _, shapeTriple := range graph.Find("", store.RDFType, "sh:NodeShape") {
+		shapeNode := shapeTriple.Subject
+		shape := Shape{
+			Name:        localName(shapeNode),
+			TargetClass: graph.GetOne(shapeNode, "sh:targetClass"),
+			Severity:    shaclSeverity(graph.GetOne(shapeNode, "sh:severity")),
+		}
+
+		for _, propertyTriple := range graph.Find(shapeNode, "sh:property", "") {
+			propertyNode := propertyTriple.Object
+			property := PropertyConstraint{
+				Path:     graph.GetOne(propertyNode, "sh:path"),
+				Pattern:  graph.GetOne(propertyNode, "sh:pattern"),
+				Class:    graph.GetOne(propertyNode, "sh:class"),
+				Message:  graph.GetOne(propertyNode, "sh:message"),
+				Severity: shaclSeverity(graph.GetOne(propertyNode, "sh:severity")),
+			}
+			for predicate, target := range map[string]*int{
+				"sh:minCount":  &property.MinCount,
+				"sh:maxCount":  &property.MaxCount,
+				"sh:minLength": &property.MinLength,
+			} {
+				if value := graph.GetOne(propertyNode, predicate); value != "" {
+					parsed, err := strconv.Atoi(value)
+					if err != nil {
+						return nil, fmt.Errorf("shape %s: %s must be an integer, got %q", shape.Name, predicate, value)
+					}
+					*target = parsed
+				}
+			}
+			switch graph.GetOne(propertyNode, "sh:nodeKind") {
+			case "sh:IRI":
+				property.NodeKind = NodeKindIRI
+			case "sh:Literal":
+				property.NodeKind = NodeKindLiteral
+			}
+			shape.Properties = append(shape.Properties, property)
+		}
+
+		shapeSet.Shapes = append(shapeSet.Shapes, shape)
+	}
+
+	// Find returns subjects in map order; keep shapes in a stable order
+	sort.Slice(shapeSet.Shapes, func(i, j int) bool {
+		return shapeSet.Shapes[i].Name < shapeSet.Shapes[j].Name
+	})
+
+	if err := shapeSet.Compile(); err != nil {
+		return nil, err
+	}
+	return shapeSet, nil
+}
+
+func shaclSeverity(value string) Severity {
+	switch value {
+	case "sh:Warning":
+		return SeverityWarning
+	case "sh:Info":
+		return SeverityInfo
+	case "sh:Violation":
+		return SeverityViolation
+	}
+	return ""
+}
+
+// localName returns the fragment, last path segment, or local part of a
+// shape node identifier.
+func localName(node string) string {
+	for _, separator := range []string{"#", "/", ":"} {
+		if index := strings.LastIndex(node, separator); index >= 0 && index < len(node)-1 {
+			node = node[index+1:]
+		}
+	}
+	return node
+}
+
+// compactNamespaces maps full namespace IRIs to the prefixes used in the
+// triple store.
+var compactNamespaces = []struct {
+	namespace string
+	prefix    string
+}{
+	{store.NamespaceReg, store.PrefixReg},
+	{store.NamespaceRDF, store.PrefixRDF},
+	{store.NamespaceRDFS, store.PrefixRDFS},
+	{store.NamespaceXSD, store.PrefixXSD},
+	{store.NamespaceDC, store.PrefixDC},
+	{store.NamespaceELI, store.PrefixELI},
+	{store.NamespaceFRBR, store.PrefixFRBR},
+	{namespaceSHACL, "sh:"},
+}
+
+// turtleToken is a lexical token of the Turtle subset.
+type turtleToken struct {
+	kind  string // "iri", "pname", "literal", "number", "punct", "a", "prefix"
+	value string
+	line  int
+}
+
+type turtleParser struct {
+	tokens     []turtleToken
+	position   int
+	prefixes   map[string]string
+	graph      *store.TripleStore
+	blankCount int
+}
+
+func (parser *turtleParser) peek() *turtleToken {
+	if parser.position >= len(parser.tokens) {
+		return nil
+	}
+	return &parser.tokens[parser.position]
+}
+
+func (parser *turtleParser) next() (*turtleToken, error) {
+	token := parser.peek()
+	if token == nil {
+		return nil, fmt.Errorf("unexpected end of Turtle input")
+	}
+	parser.position++
+	return token, nil
+}
+
+func (parser *turtleParser) expectPunct(value string) error {
+	token, err := parser.next()
+	if err != nil {
+		return err
+	}
+	if token.kind != "punct" || token.value != value {
+		return fmt.Errorf("line %d: expected %q, got %q", token.line, value, token.value)
+	}
+	return nil
+}
+
+func (parser *turtleParser) parse() error {
+	for parser.peek() != nil {
+		token := parser.peek()
+		if token.kind == "prefix" {
+			if err := parser.parsePrefix(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		subject, err := parser.parseTerm()
+		if err != nil {
+			return err
+		}
+		if err := parser.parsePredicateObjectList(subject, "."); err != nil {
+			return err
+		}
+		if err := parser.expectPunct("."); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (parser *turtleParser) parsePrefix() error {
+	directive, _ := parser.next()
+	nameToken, err := parser.next()
+	if err != nil {
+		return err
+	}
+	if nameToken.kind != "pname" || !strings.HasSuffix(nameToken.value, ":") {
+		return fmt.Errorf("line %d: invalid prefix name %q", nameToken.line, nameToken.value)
+	}
+	iriToken, err := parser.next()
+	if err != nil {
+		return err
+	}
+	if iriToken.kind != "iri" {
+		return fmt.Errorf("line %d: expected IRI for prefix %s", iriToken.line, nameToken.value)
+	}
+	parser.prefixes[strings.TrimSuffix(nameToken.value, ":")] = iriToken.value
+
+	// "@prefix" requires a terminating '.', SPARQL-style "PREFIX" does not
+	if directive.value == "@prefix" {
+		return parser.expectPunct(".")
+	}
+	return nil
+}
+
+// parsePredicateObjectList parses "pred obj, obj ; pred obj" until the
+// terminator ('.' or ']') is next.
+func (parser *turtleParser) parsePredicateObjectList(subject string, terminator string) error {
+	for {
+		token := parser.peek()
+		if token == nil {
+			return fmt.Errorf("unexpected end of Turtle input")
+		}
+		if token.kind == "punct" && token.value == terminator {
+			return nil
+		}
+
+		predicate, err := parser.parseTerm()
+		if err != nil {
+			return err
+		}
+		for {
+			object, err := parser.parseTerm()
+			if err != nil {
+				return err
+			}
+			parser.graph.Add(subject, predicate, object)
+
+			if next := parser.peek(); next != nil && next.kind == "punct" && next.value == "," {
+				parser.position++
+				continue
+			}
+			break
+		}
+
+		next := parser.peek()
+		if next != nil && next.kind == "punct" && next.value == ";" {
+			parser.position++
+			continue
+		}
+		return nil
+	}
+}
+
+func (parser *turtleParser) parseTerm() (string, error) {
+	token, err := parser.next()
+	if err != nil {
+		return "", err
+	}
+
+	switch token.kind {
+	case "a":
+		return store.RDFType, nil
+	case "iri":
+		return compactIRI(token.value), nil
+	case "pname":
+		prefix, local, _ := strings.Cut(token.value, ":")
+		namespace, known := parser.prefixes[prefix]
+		if !known {
+			return "", fmt.Errorf("line %d: undeclared prefix %q", token.line, prefix)
+		}
+		return compactIRI(namespace + local), nil
+	case "literal", "number":
+		return token.value, nil
+	case "punct":
+		if token.value == "[" {
+			parser.blankCount++
+			blankNode := fmt.Sprintf("_:b%d", parser.blankCount)
+			if err := parser.parsePredicateObjectList(blankNode, "]"); err != nil {
+				return "", err
+			}
+			if err := parser.expectPunct("]"); err != nil {
+				return "", err
+			}
+			return blankNode, nil
+		}
+	}
+	return "", fmt.Errorf("line %d: unexpected %q", token.line, token.value)
+}
+
+func compactIRI(iri string) string {
+	for _, mapping := range compactNamespaces {
+		if strings.HasPrefix(iri, mapping.namespace) {
+			return mapping.prefix + strings.TrimPrefix(iri, mapping.namespace)
+		}
+	}
+	return iri
+}
+
+// tokenizeTurtle splits Turtle source into tokens, dropping comments,
+// literal datatypes, and language tags.
+func tokenizeTurtle(source string) ([]turtleToken, error) {
+	var tokens []turtleToken
+	line := 1
+	runes := []rune(source)
+
+	for index := 0; index < len(runes); {
+		current := runes[index]
+		switch {
+		case current == '\n':
+			line++
+			index++
+		case current == ' ' || current == '\t' || current == '\r':
+			index++
+		case current == '#':
+			for index < len(runes) && runes[index] != '\n' {
+				index++
+			}
+		case current == '<':
+			end := index + 1
+			for end < len(runes) && runes[end] != '>' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("line %d: unterminated IRI", line)
+			}
+			tokens = append(tokens, turtleToken{kind: "iri", value: string(runes[index+1 : end]), line: line})
+			index = end + 1
+		case current == '"':
+			var literal strings.Builder
+			end := index + 1
+			for end < len(runes) && runes[end] != '"' {
+				if runes[end] == '\\' && end+1 < len(runes) {
+					end++
+					switch runes[end] {
+					case 'n':
+						literal.WriteRune('\n')
+					case 't':
+						literal.WriteRune('\t')
+					default:
+						literal.WriteRune(runes[end])
+					}
+				} else {
+					if runes[end] == '\n' {
+						line++
+					}
+					literal.WriteRune(runes[end])
+				}
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("line %d: unterminated string literal", line)
+			}
+			index = end + 1
+			// Skip a language tag or datatype suffix
+			if index < len(runes) && runes[index] == '@' {
+				for index < len(runes) && !isTurtleDelimiter(runes[index]) {
+					index++
+				}
+			} else if index+1 < len(runes) && runes[index] == '^' && runes[index+1] == '^' {
+				index += 2
+				for index < len(runes) && !isTurtleDelimiter(runes[index]) {
+					index++
+				}
+			}
+			tokens = append(tokens, turtleToken{kind: "literal", value: literal.String(), line: line})
+		case strings.ContainsRune("[];,", current):
+			tokens = append(tokens, turtleToken{kind: "punct", value: string(current), line: line})
+			index++
+		case current == '.':
+			tokens = append(tokens, turtleToken{kind: "punct", value: ".", line: line})
+			index++
+		default:
+			end := index
+			for end < len(runes) && !isTurtleDelimiter(runes[end]) {
+				end++
+			}
+			word := string(runes[index:end])
+			// A trailing '.' ends the statement rather than the name
+			if strings.HasSuffix(word, ".") && len(word) > 1 {
+				word = strings.TrimSuffix(word, ".")
+				end--
+			}
+			index = end
+
+			switch {
+			case word == "a":
+				tokens = append(tokens, turtleToken{kind: "a", value: word, line: line})
+			case word == "@prefix" || strings.EqualFold(word, "PREFIX"):
+				tokens = append(tokens, turtleToken{kind: "prefix", value: strings.ToLower(word), line: line})
+			case isTurtleNumber(word):
+				tokens = append(tokens, turtleToken{kind: "number", value: word, line: line})
+			case strings.Contains(word, ":"):
+				tokens = append(tokens, turtleToken{kind: "pname", value: word, line: line})
+			default:
+				return nil, fmt.Errorf("line %d: unexpected token %q", line, word)
+			}
+		}
+	}
+
+	return tokens, nil
+}
+
+func isTurtleDelimiter(character rune) bool {
+	return character == ' ' || character == '\t' || character == '\n' || character == '\r' ||
+		strings.ContainsRune("[];,<\"#", character)
+}
+
+func isTurtleNumber(word string) bool {
+	if word == "" {
+		return false
+	}
+	for _, character := range word {
+		if character < '0' || character > '9' {
+			return false
+		}
+	}
+	return true
+}
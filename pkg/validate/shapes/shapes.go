@@ -0,0 +1,262 @@
+// Package shapes validates regulation graphs against declarative,
+// SHACL-style shape constraints (e.g., every reg:Article must have exactly
+// one reg:title).
+package shapes
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+// Severity is the severity of a shape violation, mirroring sh:severity.
+type Severity string
+
+const (
+	SeverityViolation Severity = "violation"
+	SeverityWarning   Severity = "warning"
+	SeverityInfo      Severity = "info"
+)
+
+// Node kinds accepted by PropertyConstraint.NodeKind.
+const (
+	NodeKindIRI     = "iri"
+	NodeKindLiteral = "literal"
+)
+
+// Shape is a node shape: a set of property constraints that every
+// instance of TargetClass must satisfy.
+type Shape struct {
+	Name        string               `yaml:"name" json:"name"`
+	TargetClass string               `yaml:"target_class" json:"target_class"`
+	Severity    Severity             `yaml:"severity,omitempty" json:"severity,omitempty"`
+	Properties  []PropertyConstraint `yaml:"properties" json:"properties"`
+}
+
+// PropertyConstraint constrains the values of one predicate (sh:path) on
+// each focus node. Zero values disable the corresponding check.
+type PropertyConstraint struct {
+	Path      string   `yaml:"path" json:"path"`
+	MinCount  int      `yaml:"min_count,omitempty" json:"min_count,omitempty"`
+	MaxCount  int      `yaml:"max_count,omitempty" json:"max_count,omitempty"`
+	MinLength int      `yaml:"min_length,omitempty" json:"min_length,omitempty"`
+	Pattern   string   `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	NodeKind  string   `yaml:"node_kind,omitempty" json:"node_kind,omitempty"`
+	Class     string   `yaml:"class,omitempty" json:"class,omitempty"`
+	Severity  Severity `yaml:"severity,omitempty" json:"severity,omitempty"`
+	Message   string   `yaml:"message,omitempty" json:"message,omitempty"`
+
+	compiledPattern *regexp.Regexp
+}
+
+// ShapeSet is a collection of shapes loaded from a shapes file.
+type ShapeSet struct {
+	Shapes []Shape `yaml:"shapes" json:"shapes"`
+}
+
+// Violation is a single constraint failure on a focus node.
+type Violation struct {
+	FocusNode  string   `json:"focus_node"`
+	Shape      string   `json:"shape"`
+	Path       string   `json:"path"`
+	Constraint string   `json:"constraint"`
+	Severity   Severity `json:"severity"`
+	Message    string   `json:"message"`
+	Value      string   `json:"value,omitempty"`
+}
+
+// Report is the result of validating a graph against a ShapeSet.
+type Report struct {
+	Conforms           bool        `json:"conforms"`
+	FocusNodes         int         `json:"focus_nodes"`
+	NonConformingNodes int         `json:"non_conforming_nodes"`
+	Violations         []Violation `json:"violations"`
+}
+
+// Conformance returns the fraction of focus nodes without any violation of
+// severity "violation" (1.0 when there are no focus nodes).
+func (report *Report) Conformance() float64 {
+	if report.FocusNodes == 0 {
+		return 1.0
+	}
+	return float64(report.FocusNodes-report.NonConformingNodes) / float64(report.FocusNodes)
+}
+
+// CountBySeverity returns the number of violations with the given severity.
+func (report *Report) CountBySeverity(severity Severity) int {
+	count := 0
+	for _, violation := range report.Violations {
+		if violation.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// Compile validates the shape definitions and prepares patterns. It is
+// called by the loaders; shapes built in code must call it before Validate.
+func (shapeSet *ShapeSet) Compile() error {
+	for shapeIndex := range shapeSet.Shapes {
+		shape := &shapeSet.Shapes[shapeIndex]
+		if shape.Name == "" {
+			shape.Name = fmt.Sprintf("Shape%d", shapeIndex+1)
+		}
+		if shape.TargetClass == "" {
+			return fmt.Errorf("shape %s: target_class is required", shape.Name)
+		}
+		if shape.Severity == "" {
+			shape.Severity = SeverityViolation
+		}
+		if err := validateSeverity(shape.Severity); err != nil {
+			return fmt.Errorf("shape %s: %w", shape.Name, err)
+		}
+
+		for propertyIndex := range shape.Properties {
+			property := &shape.Properties[propertyIndex]
+			if property.Path == "" {
+				return fmt.Errorf("shape %s: property %d: path is required", shape.Name, propertyIndex+1)
+			}
+			if property.MaxCount > 0 && property.MinCount > property.MaxCount {
+				return fmt.Errorf("shape %s: %s: min_count %d exceeds max_count %d",
+					shape.Name, property.Path, property.MinCount, property.MaxCount)
+			}
+			if property.Severity != "" {
+				if err := validateSeverity(property.Severity); err != nil {
+					return fmt.Errorf("shape %s: %s: %w", shape.Name, property.Path, err)
+				}
+			}
+			property.NodeKind = strings.ToLower(property.NodeKind)
+			if property.NodeKind != "" && property.NodeKind != NodeKindIRI && property.NodeKind != NodeKindLiteral {
+				return fmt.Errorf("shape %s: %s: unknown node_kind %q", shape.Name, property.Path, property.NodeKind)
+			}
+			if property.Pattern != "" {
+				compiled, err := regexp.Compile(property.Pattern)
+				if err != nil {
+					return fmt.Errorf("shape %s: %s: invalid pattern: %w", shape.Name, property.Path, err)
+				}
+				property.compiledPattern = compiled
+			}
+		}
+	}
+	return nil
+}
+
+func validateSeverity(severity Severity) error {
+	switch severity {
+	case SeverityViolation, SeverityWarning, SeverityInfo:
+		return nil
+	}
+	return fmt.Errorf("unknown severity %q", severity)
+}
+
+// Validate checks every instance of each shape's target class in the triple
+// store against the shape's property constraints.
+func (shapeSet *ShapeSet) Validate(tripleStore *store.TripleStore) *Report {
+	report := &Report{Violations: make([]Violation, 0)}
+	nonConforming := make(map[string]bool)
+	focusNodes := make(map[string]bool)
+
+	for _, shape := range shapeSet.Shapes {
+		for _, typeTriple := range tripleStore.Find("", store.RDFType, shape.TargetClass) {
+			focusNode := typeTriple.Subject
+			focusNodes[focusNode] = true
+
+			for _, property := range shape.Properties {
+				for _, violation := range checkProperty(tripleStore, shape, property, focusNode) {
+					report.Violations = append(report.Violations, violation)
+					if violation.Severity == SeverityViolation {
+						nonConforming[focusNode] = true
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(report.Violations, func(i, j int) bool {
+		if report.Violations[i].Shape != report.Violations[j].Shape {
+			return report.Violations[i].Shape < report.Violations[j].Shape
+		}
+		return report.Violations[i].FocusNode < report.Violations[j].FocusNode
+	})
+
+	report.FocusNodes = len(focusNodes)
+	report.NonConformingNodes = len(nonConforming)
+	report.Conforms = report.NonConformingNodes == 0
+	return report
+}
+
+// checkProperty evaluates a single property constraint on a focus node.
+func checkProperty(tripleStore *store.TripleStore, shape Shape, property PropertyConstraint, focusNode string) []Violation {
+	severity := shape.Severity
+	if property.Severity != "" {
+		severity = property.Severity
+	}
+
+	newViolation := func(constraint string, defaultMessage string, value string) Violation {
+		message := property.Message
+		if message == "" {
+			message = defaultMessage
+		}
+		return Violation{
+			FocusNode:  focusNode,
+			Shape:      shape.Name,
+			Path:       property.Path,
+			Constraint: constraint,
+			Severity:   severity,
+			Message:    message,
+			Value:      value,
+		}
+	}
+
+	values := tripleStore.Find(focusNode, property.Path, "")
+	var violations []Violation
+
+	if property.MinCount > 0 && len(values) < property.MinCount {
+		violations = append(violations, newViolation("min_count",
+			fmt.Sprintf("expected at least %d %s, found %d", property.MinCount, property.Path, len(values)), ""))
+	}
+	if property.MaxCount > 0 && len(values) > property.MaxCount {
+		violations = append(violations, newViolation("max_count",
+			fmt.Sprintf("expected at most %d %s, found %d", property.MaxCount, property.Path, len(values)), ""))
+	}
+
+	for _, valueTriple := range values {
+		value := valueTriple.Object
+		isIRI := isIRIValue(tripleStore, value)
+
+		if property.NodeKind == NodeKindIRI && !isIRI {
+			violations = append(violations, newViolation("node_kind",
+				fmt.Sprintf("%s value must be an IRI", property.Path), value))
+		}
+		if property.NodeKind == NodeKindLiteral && isIRI {
+			violations = append(violations, newViolation("node_kind",
+				fmt.Sprintf("%s value must be a literal", property.Path), value))
+		}
+		if property.MinLength > 0 && len(strings.TrimSpace(value)) < property.MinLength {
+			violations = append(violations, newViolation("min_length",
+				fmt.Sprintf("%s value shorter than %d characters", property.Path, property.MinLength), value))
+		}
+		if property.compiledPattern != nil && !property.compiledPattern.MatchString(value) {
+			violations = append(violations, newViolation("pattern",
+				fmt.Sprintf("%s value does not match %s", property.Path, property.Pattern), value))
+		}
+		if property.Class != "" && !tripleStore.Exists(value, store.RDFType, property.Class) {
+			violations = append(violations, newViolation("class",
+				fmt.Sprintf("%s value is not a %s", property.Path, property.Class), value))
+		}
+	}
+
+	return violations
+}
+
+// isIRIValue reports whether an object is a resource rather than a literal:
+// a full IRI, or a node that is itself described in the store.
+func isIRIValue(tripleStore *store.TripleStore, value string) bool {
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") || strings.HasPrefix(value, "urn:") {
+		return true
+	}
+	return len(tripleStore.Find(value, "", "")) > 0
+}
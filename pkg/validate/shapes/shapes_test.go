@@ -0,0 +1,279 @@
+package shapes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/store"
+)
+
+const testBase = "https://regula.dev/regulations/"
+
+func buildShapeTestStore() *store.TripleStore {
+	tripleStore := store.NewTripleStore()
+
+	// Art1 conforms.
+	tripleStore.Add(testBase+"T:Art1", store.RDFType, store.ClassArticle)
+	tripleStore.Add(testBase+"T:Art1", store.PropNumber, "1")
+	tripleStore.Add(testBase+"T:Art1", store.PropTitle, "Scope")
+
+	// Art2 has no number and two titles.
+	tripleStore.Add(testBase+"T:Art2", store.RDFType, store.ClassArticle)
+	tripleStore.Add(testBase+"T:Art2", store.PropTitle, "Definitions")
+	tripleStore.Add(testBase+"T:Art2", store.PropTitle, "Definitions (amended)")
+
+	// Term without a definition.
+	tripleStore.Add(testBase+"T:Term:data", store.RDFType, store.ClassDefinedTerm)
+	tripleStore.Add(testBase+"T:Term:data", store.PropTerm, "data")
+
+	return tripleStore
+}
+
+func TestParseYAML(t *testing.T) {
+	shapeSet, err := ParseYAML([]byte(`
+shapes:
+  - name: ArticleShape
+    target_class: reg:Article
+    properties:
+      - path: reg:number
+        min_count: 1
+        pattern: "^[0-9]+$"
+      - path: reg:title
+        max_count: 1
+        severity: warning
+`))
+	if err != nil {
+		t.Fatalf("ParseYAML failed: %v", err)
+	}
+	if len(shapeSet.Shapes) != 1 || len(shapeSet.Shapes[0].Properties) != 2 {
+		t.Fatalf("unexpected shapes: %+v", shapeSet.Shapes)
+	}
+	if shapeSet.Shapes[0].Severity != SeverityViolation {
+		t.Errorf("default severity: got %q, want violation", shapeSet.Shapes[0].Severity)
+	}
+	if shapeSet.Shapes[0].Properties[0].compiledPattern == nil {
+		t.Error("pattern was not compiled")
+	}
+}
+
+func TestParseYAML_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"missing target": "shapes:\n  - name: A\n",
+		"bad severity":   "shapes:\n  - name: A\n    target_class: reg:Article\n    severity: fatal\n",
+		"bad node kind":  "shapes:\n  - target_class: reg:Article\n    properties:\n      - path: reg:title\n        node_kind: blank\n",
+		"min over max":   "shapes:\n  - target_class: reg:Article\n    properties:\n      - path: reg:title\n        min_count: 2\n        max_count: 1\n",
+		"bad pattern":    "shapes:\n  - target_class: reg:Article\n    properties:\n      - path: reg:title\n        pattern: \"(\"\n",
+	}
+	for name, input := range cases {
+		if _, err := ParseYAML([]byte(input)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestParseTurtle(t *testing.T) {
+	shapeSet, err := ParseTurtle([]byte(`
+@prefix sh: <http://www.w3.org/ns/shacl#> .
+@prefix reg: <https://regula.dev/ontology#> .
+@prefix ex: <https://example.org/shapes#> .
+
+# Articles need a number and a title.
+ex:ArticleShape a sh:NodeShape ;
+    sh:targetClass reg:Article ;
+    sh:property [
+        sh:path reg:number ;
+        sh:minCount 1 ;
+        sh:maxCount 1
+    ] , [
+        sh:path <https://regula.dev/ontology#title> ;
+        sh:minCount 1 ;
+        sh:severity sh:Warning ;
+        sh:message "Article has no title"
+    ] .
+
+ex:TermShape a sh:NodeShape ;
+    sh:targetClass reg:DefinedTerm ;
+    sh:property [ sh:path reg:definedIn ; sh:nodeKind sh:IRI ] .
+`))
+	if err != nil {
+		t.Fatalf("ParseTurtle failed: %v", err)
+	}
+	if len(shapeSet.Shapes) != 2 {
+		t.Fatalf("got %d shapes, want 2", len(shapeSet.Shapes))
+	}
+
+	article := shapeSet.Shapes[0]
+	if article.Name != "ArticleShape" || article.TargetClass != "reg:Article" {
+		t.Errorf("unexpected article shape: %+v", article)
+	}
+	if len(article.Properties) != 2 {
+		t.Fatalf("got %d article properties, want 2", len(article.Properties))
+	}
+	var title *PropertyConstraint
+	for i := range article.Properties {
+		if article.Properties[i].Path == "reg:title" {
+			title = &article.Properties[i]
+		}
+	}
+	if title == nil {
+		t.Fatal("full IRI path was not compacted to reg:title")
+	}
+	if title.Severity != SeverityWarning || title.Message != "Article has no title" || title.MinCount != 1 {
+		t.Errorf("unexpected title constraint: %+v", *title)
+	}
+
+	term := shapeSet.Shapes[1]
+	if term.Properties[0].NodeKind != NodeKindIRI {
+		t.Errorf("node kind: got %q, want iri", term.Properties[0].NodeKind)
+	}
+}
+
+func TestParseTurtle_Errors(t *testing.T) {
+	inputs := []string{
+		`@prefix sh: <http://www.w3.org/ns/shacl#> . ex:A a sh:NodeShape`,
+		`@prefix sh: <http://www.w3.org/ns/shacl#> . <a> a sh:NodeShape ; sh:targetClass "unterminated .`,
+		`@prefix sh: <http://www.w3.org/ns/shacl#> . <a> a sh:NodeShape ; sh:targetClass <C> ; sh:property [ sh:path <p> ; sh:minCount "x" ] .`,
+	}
+	for _, input := range inputs {
+		if _, err := ParseTurtle([]byte(input)); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	shapeSet := DefaultShapeSet()
+	report := shapeSet.Validate(buildShapeTestStore())
+
+	if report.Conforms {
+		t.Fatal("expected report not to conform")
+	}
+	if report.FocusNodes != 3 {
+		t.Errorf("FocusNodes: got %d, want 3", report.FocusNodes)
+	}
+	// Art2 (missing number) and the term (missing definition).
+	if report.NonConformingNodes != 2 {
+		t.Errorf("NonConformingNodes: got %d, want 2", report.NonConformingNodes)
+	}
+
+	found := make(map[string]bool)
+	for _, violation := range report.Violations {
+		found[violation.Shape+" "+violation.Path+" "+violation.Constraint] = true
+	}
+	for _, expected := range []string{
+		"ArticleShape reg:number min_count",
+		"ArticleShape reg:title max_count",
+		"DefinedTermShape reg:definition min_count",
+	} {
+		if !found[expected] {
+			t.Errorf("missing violation %q in %v", expected, found)
+		}
+	}
+
+	if report.CountBySeverity(SeverityWarning) == 0 {
+		t.Error("expected warning-severity violations")
+	}
+	if conformance := report.Conformance(); conformance < 0.33 || conformance > 0.34 {
+		t.Errorf("Conformance: got %.3f, want 1/3", conformance)
+	}
+}
+
+func TestValidate_ValueConstraints(t *testing.T) {
+	shapeSet, err := ParseYAML([]byte(`
+shapes:
+  - name: ArticleShape
+    target_class: reg:Article
+    properties:
+      - path: reg:number
+        pattern: "^[0-9]+$"
+      - path: reg:title
+        min_length: 3
+        node_kind: literal
+      - path: reg:partOf
+        class: reg:Chapter
+`))
+	if err != nil {
+		t.Fatalf("ParseYAML failed: %v", err)
+	}
+
+	tripleStore := store.NewTripleStore()
+	article := testBase + "T:Art1"
+	tripleStore.Add(article, store.RDFType, store.ClassArticle)
+	tripleStore.Add(article, store.PropNumber, "1a")
+	tripleStore.Add(article, store.PropTitle, "X")
+	tripleStore.Add(article, store.PropPartOf, testBase+"T:Section1")
+	tripleStore.Add(testBase+"T:Section1", store.RDFType, store.ClassSection)
+
+	report := shapeSet.Validate(tripleStore)
+	constraints := make(map[string]bool)
+	for _, violation := range report.Violations {
+		constraints[violation.Constraint] = true
+	}
+	for _, expected := range []string{"pattern", "min_length", "class"} {
+		if !constraints[expected] {
+			t.Errorf("missing %s violation, got %v", expected, report.Violations)
+		}
+	}
+	if constraints["node_kind"] {
+		t.Error("literal title should satisfy node_kind literal")
+	}
+}
+
+func TestLoadShapes(t *testing.T) {
+	tempDir := t.TempDir()
+	yamlPath := filepath.Join(tempDir, "shapes.yaml")
+	if err := os.WriteFile(yamlPath, []byte("shapes:\n  - target_class: reg:Article\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	shapeSet, err := LoadShapes(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadShapes failed: %v", err)
+	}
+	if shapeSet.Shapes[0].Name != "Shape1" {
+		t.Errorf("default name: got %q", shapeSet.Shapes[0].Name)
+	}
+
+	if _, err := LoadShapes(filepath.Join(tempDir, "missing.ttl")); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	badPath := filepath.Join(tempDir, "bad.ttl")
+	if err := os.WriteFile(badPath, []byte("<a> <b>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadShapes(badPath); err == nil || !strings.Contains(err.Error(), "bad.ttl") {
+		t.Errorf("expected error naming the file, got %v", err)
+	}
+}
+
+func TestDefaultShapeSet_GDPR(t *testing.T) {
+	file, err := os.Open("../../../testdata/gdpr.txt")
+	if err != nil {
+		t.Skipf("GDPR testdata not available: %v", err)
+	}
+	defer file.Close()
+
+	document, err := extract.NewParser().Parse(file)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tripleStore := store.NewTripleStore()
+	builder := store.NewGraphBuilder(tripleStore, testBase)
+	resolver := extract.NewReferenceResolver(testBase, "GDPR")
+	resolver.IndexDocument(document)
+	if _, err := builder.BuildComplete(document, extract.NewDefinitionExtractor(), extract.NewReferenceExtractor(), resolver, extract.NewSemanticExtractor()); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	report := DefaultShapeSet().Validate(tripleStore)
+	if report.FocusNodes < 99 {
+		t.Errorf("FocusNodes: got %d, want at least the 99 GDPR articles", report.FocusNodes)
+	}
+	if report.Conformance() < 0.95 {
+		t.Errorf("GDPR conformance: got %.3f, want >= 0.95; violations: %v", report.Conformance(), report.Violations)
+	}
+}
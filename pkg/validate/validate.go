@@ -4,10 +4,13 @@ package validate
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 
 	"github.com/coolbeans/regula/pkg/extract"
+	"github.com/coolbeans/regula/pkg/i18n"
+	"github.com/coolbeans/regula/pkg/ndjson"
 	"github.com/coolbeans/regula/pkg/store"
 )
 
@@ -105,6 +108,7 @@ type ReferenceValidation struct {
 	NotFound        int     `json:"not_found"`
 	External        int     `json:"external"`
 	RangeRefs       int     `json:"range_refs"`
+	SelfRefs        int     `json:"self_refs"` // "this Article"
 
 	ResolutionRate   float64 `json:"resolution_rate"`
 	HighConfidence   int     `json:"high_confidence"`
@@ -490,6 +494,8 @@ func (v *Validator) validateReferences(resolved []*extract.ResolvedReference) *R
 			val.External++
 		case extract.ResolutionRangeRef:
 			val.RangeRefs++
+		case extract.ResolutionSelfRef:
+			val.SelfRefs++
 		}
 
 		// Count confidence levels
@@ -506,7 +512,7 @@ func (v *Validator) validateReferences(resolved []*extract.ResolvedReference) *R
 	// Calculate resolution rate (excluding external refs)
 	internalRefs := val.TotalReferences - val.External
 	if internalRefs > 0 {
-		successfulResolutions := val.Resolved + val.Partial + val.RangeRefs
+		successfulResolutions := val.Resolved + val.Partial + val.RangeRefs + val.SelfRefs
 		val.ResolutionRate = float64(successfulResolutions) / float64(internalRefs)
 	}
 
@@ -1113,33 +1119,84 @@ func (r *ValidationResult) ToJSON() ([]byte, error) {
 	return json.MarshalIndent(r, "", "  ")
 }
 
+// WriteNDJSON writes the result to w as newline-delimited JSON: one
+// "component" record per validated component, then one "issue" or
+// "warning" record each, then a "summary" record.
+func (r *ValidationResult) WriteNDJSON(w io.Writer) error {
+	encoder := ndjson.NewEncoder(w)
+	components := []struct {
+		name  string
+		value any
+	}{
+		{"references", r.References},
+		{"connectivity", r.Connectivity},
+		{"definitions", r.Definitions},
+		{"semantics", r.Semantics},
+		{"structure", r.Structure},
+	}
+	for _, component := range components {
+		if err := encoder.EncodeRecord("component", struct {
+			Component string `json:"component"`
+			Metrics   any    `json:"metrics"`
+		}{component.name, component.value}); err != nil {
+			return err
+		}
+	}
+	for _, issue := range r.Issues {
+		if err := encoder.EncodeRecord("issue", issue); err != nil {
+			return err
+		}
+	}
+	for _, warning := range r.Warnings {
+		if err := encoder.EncodeRecord("warning", warning); err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeRecord("summary", struct {
+		Status          ValidationStatus `json:"status"`
+		Threshold       float64          `json:"threshold"`
+		OverallScore    float64          `json:"overall_score"`
+		ProfileName     string           `json:"profile_name"`
+		ComponentScores *ComponentScores `json:"component_scores,omitempty"`
+		IssueCount      int              `json:"issue_count"`
+		WarningCount    int              `json:"warning_count"`
+	}{r.Status, r.Threshold, r.OverallScore, r.ProfileName, r.ComponentScores, len(r.Issues), len(r.Warnings)})
+}
+
 // String returns a human-readable validation report.
 func (r *ValidationResult) String() string {
+	return r.StringLocalized(nil)
+}
+
+// StringLocalized returns the human-readable report with headings and labels
+// translated by translator. A nil translator renders English.
+func (r *ValidationResult) StringLocalized(translator *i18n.Translator) string {
 	var sb strings.Builder
 
-	sb.WriteString("Validation Report\n")
-	sb.WriteString("=================\n")
+	title := translator.T("Validation Report")
+	sb.WriteString(title + "\n")
+	sb.WriteString(strings.Repeat("=", len([]rune(title))) + "\n")
 
 	// Profile used
 	if r.ProfileName != "" {
-		sb.WriteString(fmt.Sprintf("Profile: %s\n", r.ProfileName))
+		sb.WriteString(fmt.Sprintf("%s: %s\n", translator.T("Profile"), r.ProfileName))
 	}
 	sb.WriteString("\n")
 
 	// Reference Resolution
 	if r.References != nil {
-		sb.WriteString("Reference Resolution:\n")
-		sb.WriteString(fmt.Sprintf("  Total references: %d\n", r.References.TotalReferences))
-		sb.WriteString(fmt.Sprintf("  Resolved: %d (%.1f%%)\n",
-			r.References.Resolved+r.References.Partial+r.References.RangeRefs,
+		sb.WriteString(translator.T("Reference Resolution") + ":\n")
+		sb.WriteString(fmt.Sprintf("  %s: %d\n", translator.T("Total references"), r.References.TotalReferences))
+		sb.WriteString(fmt.Sprintf("  %s: %d (%.1f%%)\n", translator.T("Resolved"),
+			r.References.Resolved+r.References.Partial+r.References.RangeRefs+r.References.SelfRefs,
 			r.References.ResolutionRate*100))
-		sb.WriteString(fmt.Sprintf("  Unresolved: %d\n", r.References.NotFound))
-		sb.WriteString(fmt.Sprintf("    - External: %d\n", r.References.External))
-		sb.WriteString(fmt.Sprintf("    - Ambiguous: %d\n", r.References.Ambiguous))
-		sb.WriteString(fmt.Sprintf("    - Not found: %d\n", r.References.NotFound))
+		sb.WriteString(fmt.Sprintf("  %s: %d\n", translator.T("Unresolved"), r.References.NotFound))
+		sb.WriteString(fmt.Sprintf("    - %s: %d\n", translator.T("External"), r.References.External))
+		sb.WriteString(fmt.Sprintf("    - %s: %d\n", translator.T("Ambiguous"), r.References.Ambiguous))
+		sb.WriteString(fmt.Sprintf("    - %s: %d\n", translator.T("Not found"), r.References.NotFound))
 
 		if len(r.References.UnresolvedExamples) > 0 {
-			sb.WriteString("  Examples:\n")
+			sb.WriteString("  " + translator.T("Examples") + ":\n")
 			for _, ex := range r.References.UnresolvedExamples {
 				sb.WriteString(fmt.Sprintf("    - Art %d: %q (%s)\n", ex.SourceArticle, ex.RawText, ex.Reason))
 			}
@@ -1149,24 +1206,24 @@ func (r *ValidationResult) String() string {
 
 	// Graph Connectivity
 	if r.Connectivity != nil {
-		sb.WriteString("Graph Connectivity:\n")
-		sb.WriteString(fmt.Sprintf("  Total provisions: %d\n", r.Connectivity.TotalProvisions))
-		sb.WriteString(fmt.Sprintf("  Connected: %d (%.1f%%)\n",
+		sb.WriteString(translator.T("Graph Connectivity") + ":\n")
+		sb.WriteString(fmt.Sprintf("  %s: %d\n", translator.T("Total provisions"), r.Connectivity.TotalProvisions))
+		sb.WriteString(fmt.Sprintf("  %s: %d (%.1f%%)\n", translator.T("Connected"),
 			r.Connectivity.ConnectedCount, r.Connectivity.ConnectivityRate*100))
-		sb.WriteString(fmt.Sprintf("  Orphans: %d\n", r.Connectivity.OrphanCount))
+		sb.WriteString(fmt.Sprintf("  %s: %d\n", translator.T("Orphans"), r.Connectivity.OrphanCount))
 
 		if len(r.Connectivity.OrphanArticles) > 0 && len(r.Connectivity.OrphanArticles) <= 10 {
 			articles := make([]string, len(r.Connectivity.OrphanArticles))
 			for i, a := range r.Connectivity.OrphanArticles {
 				articles[i] = fmt.Sprintf("%d", a)
 			}
-			sb.WriteString(fmt.Sprintf("    Articles: %s\n", strings.Join(articles, ", ")))
+			sb.WriteString(fmt.Sprintf("    %s: %s\n", translator.T("Articles"), strings.Join(articles, ", ")))
 		}
 
 		if len(r.Connectivity.MostReferenced) > 0 {
-			sb.WriteString("  Most referenced:\n")
+			sb.WriteString("  " + translator.T("Most referenced") + ":\n")
 			for _, arc := range r.Connectivity.MostReferenced {
-				sb.WriteString(fmt.Sprintf("    - Article %d: %d references\n", arc.ArticleNum, arc.Count))
+				sb.WriteString("    - " + translator.Sprintf("Article %d: %d references", arc.ArticleNum, arc.Count) + "\n")
 			}
 		}
 		sb.WriteString("\n")
@@ -1174,15 +1231,15 @@ func (r *ValidationResult) String() string {
 
 	// Definition Coverage
 	if r.Definitions != nil {
-		sb.WriteString("Definition Coverage:\n")
-		sb.WriteString(fmt.Sprintf("  Defined terms: %d\n", r.Definitions.TotalDefinitions))
-		sb.WriteString(fmt.Sprintf("  Terms with usage links: %d (%.1f%%)\n",
+		sb.WriteString(translator.T("Definition Coverage") + ":\n")
+		sb.WriteString(fmt.Sprintf("  %s: %d\n", translator.T("Defined terms"), r.Definitions.TotalDefinitions))
+		sb.WriteString(fmt.Sprintf("  %s: %d (%.1f%%)\n", translator.T("Terms with usage links"),
 			r.Definitions.UsedDefinitions, r.Definitions.UsageRate*100))
-		sb.WriteString(fmt.Sprintf("  Total term usages: %d\n", r.Definitions.TotalUsages))
-		sb.WriteString(fmt.Sprintf("  Articles using terms: %d\n", r.Definitions.ArticlesWithTerms))
+		sb.WriteString(fmt.Sprintf("  %s: %d\n", translator.T("Total term usages"), r.Definitions.TotalUsages))
+		sb.WriteString(fmt.Sprintf("  %s: %d\n", translator.T("Articles using terms"), r.Definitions.ArticlesWithTerms))
 
 		if len(r.Definitions.UnusedTerms) > 0 {
-			sb.WriteString("  Unused terms:\n")
+			sb.WriteString("  " + translator.T("Unused terms") + ":\n")
 			for _, term := range r.Definitions.UnusedTerms {
 				sb.WriteString(fmt.Sprintf("    - %s\n", term))
 			}
@@ -1192,20 +1249,20 @@ func (r *ValidationResult) String() string {
 
 	// Semantic Extraction
 	if r.Semantics != nil {
-		sb.WriteString("Semantic Extraction:\n")
-		sb.WriteString(fmt.Sprintf("  Rights found: %d (in %d articles)\n",
-			r.Semantics.RightsCount, r.Semantics.ArticlesWithRights))
-		sb.WriteString(fmt.Sprintf("  Obligations found: %d (in %d articles)\n",
-			r.Semantics.ObligationsCount, r.Semantics.ArticlesWithOblig))
+		sb.WriteString(translator.T("Semantic Extraction") + ":\n")
+		sb.WriteString(fmt.Sprintf("  %s: %d %s\n", translator.T("Rights found"),
+			r.Semantics.RightsCount, translator.Sprintf("(in %d articles)", r.Semantics.ArticlesWithRights)))
+		sb.WriteString(fmt.Sprintf("  %s: %d %s\n", translator.T("Obligations found"),
+			r.Semantics.ObligationsCount, translator.Sprintf("(in %d articles)", r.Semantics.ArticlesWithOblig)))
 		regulationLabel := r.Semantics.RegulationType
 		if regulationLabel == "" {
-			regulationLabel = "known"
+			regulationLabel = translator.T("known")
 		}
-		sb.WriteString(fmt.Sprintf("  Known %s rights: %d/%d\n",
-			regulationLabel, r.Semantics.KnownRightsFound, r.Semantics.KnownRightsTotal))
+		sb.WriteString(fmt.Sprintf("  %s: %d/%d\n", translator.Sprintf("Known %s rights", regulationLabel),
+			r.Semantics.KnownRightsFound, r.Semantics.KnownRightsTotal))
 
 		if len(r.Semantics.MissingRights) > 0 {
-			sb.WriteString("  Missing rights:\n")
+			sb.WriteString("  " + translator.T("Missing rights") + ":\n")
 			for _, right := range r.Semantics.MissingRights {
 				sb.WriteString(fmt.Sprintf("    - %s\n", right))
 			}
@@ -1215,43 +1272,43 @@ func (r *ValidationResult) String() string {
 
 	// Structure Quality
 	if r.Structure != nil {
-		sb.WriteString("Structure Quality:\n")
-		sb.WriteString(fmt.Sprintf("  Articles: %d", r.Structure.TotalArticles))
+		sb.WriteString(translator.T("Structure Quality") + ":\n")
+		sb.WriteString(fmt.Sprintf("  %s: %d", translator.T("Articles"), r.Structure.TotalArticles))
 		if r.Structure.ExpectedArticles > 0 {
-			sb.WriteString(fmt.Sprintf(" (expected: %d, %.1f%%)",
+			sb.WriteString(fmt.Sprintf(" (%s: %d, %.1f%%)", translator.T("expected"),
 				r.Structure.ExpectedArticles, r.Structure.ArticleCompleteness*100))
 		}
 		sb.WriteString("\n")
-		sb.WriteString(fmt.Sprintf("  Chapters: %d", r.Structure.TotalChapters))
+		sb.WriteString(fmt.Sprintf("  %s: %d", translator.T("Chapters"), r.Structure.TotalChapters))
 		if r.Structure.ExpectedChapters > 0 {
-			sb.WriteString(fmt.Sprintf(" (expected: %d, %.1f%%)",
+			sb.WriteString(fmt.Sprintf(" (%s: %d, %.1f%%)", translator.T("expected"),
 				r.Structure.ExpectedChapters, r.Structure.ChapterCompleteness*100))
 		}
 		sb.WriteString("\n")
-		sb.WriteString(fmt.Sprintf("  Content quality: %.1f%% articles with content\n", r.Structure.ContentRate*100))
-		sb.WriteString(fmt.Sprintf("  Structure score: %.1f%%\n", r.Structure.StructureScore*100))
+		sb.WriteString(fmt.Sprintf("  %s: %s\n", translator.T("Content quality"),
+			translator.Sprintf("%.1f%% articles with content", r.Structure.ContentRate*100)))
+		sb.WriteString(fmt.Sprintf("  %s: %.1f%%\n", translator.T("Structure score"), r.Structure.StructureScore*100))
 		sb.WriteString("\n")
 	}
 
 	// Component Scores (when available)
 	if r.ComponentScores != nil {
-		sb.WriteString("Component Scores:\n")
-		sb.WriteString(fmt.Sprintf("  References:    %.1f%% (weight: %.0f%%)\n",
-			r.ComponentScores.ReferenceScore*100, r.ComponentScores.ReferenceWeight*100))
-		sb.WriteString(fmt.Sprintf("  Connectivity:  %.1f%% (weight: %.0f%%)\n",
-			r.ComponentScores.ConnectivityScore*100, r.ComponentScores.ConnectivityWeight*100))
-		sb.WriteString(fmt.Sprintf("  Definitions:   %.1f%% (weight: %.0f%%)\n",
-			r.ComponentScores.DefinitionScore*100, r.ComponentScores.DefinitionWeight*100))
-		sb.WriteString(fmt.Sprintf("  Semantics:     %.1f%% (weight: %.0f%%)\n",
-			r.ComponentScores.SemanticScore*100, r.ComponentScores.SemanticWeight*100))
-		sb.WriteString(fmt.Sprintf("  Structure:     %.1f%% (weight: %.0f%%)\n",
-			r.ComponentScores.StructureScore*100, r.ComponentScores.StructureWeight*100))
+		sb.WriteString(translator.T("Component Scores") + ":\n")
+		componentLine := func(name string, score, weight float64) {
+			sb.WriteString(fmt.Sprintf("  %-14s %.1f%% (%s: %.0f%%)\n",
+				translator.T(name)+":", score*100, translator.T("weight"), weight*100))
+		}
+		componentLine("References", r.ComponentScores.ReferenceScore, r.ComponentScores.ReferenceWeight)
+		componentLine("Connectivity", r.ComponentScores.ConnectivityScore, r.ComponentScores.ConnectivityWeight)
+		componentLine("Definitions", r.ComponentScores.DefinitionScore, r.ComponentScores.DefinitionWeight)
+		componentLine("Semantics", r.ComponentScores.SemanticScore, r.ComponentScores.SemanticWeight)
+		componentLine("Structure", r.ComponentScores.StructureScore, r.ComponentScores.StructureWeight)
 		sb.WriteString("\n")
 	}
 
 	// Warnings
 	if len(r.Warnings) > 0 {
-		sb.WriteString("Warnings:\n")
+		sb.WriteString(translator.T("Warnings") + ":\n")
 		for _, w := range r.Warnings {
 			sb.WriteString(fmt.Sprintf("  [%s] %s\n", w.Category, w.Message))
 		}
@@ -1259,9 +1316,9 @@ func (r *ValidationResult) String() string {
 	}
 
 	// Overall Status
-	sb.WriteString(fmt.Sprintf("Overall Score: %.1f%%\n", r.OverallScore*100))
-	sb.WriteString(fmt.Sprintf("Threshold: %.1f%%\n", r.Threshold*100))
-	sb.WriteString(fmt.Sprintf("Status: %s\n", r.Status))
+	sb.WriteString(fmt.Sprintf("%s: %.1f%%\n", translator.T("Overall Score"), r.OverallScore*100))
+	sb.WriteString(fmt.Sprintf("%s: %.1f%%\n", translator.T("Threshold"), r.Threshold*100))
+	sb.WriteString(fmt.Sprintf("%s: %s\n", translator.T("Status"), r.Status))
 
 	return sb.String()
 }
@@ -1,7 +1,10 @@
 package validate
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/coolbeans/regula/pkg/extract"
@@ -351,6 +354,43 @@ func TestValidationJSON(t *testing.T) {
 	}
 }
 
+func TestValidationNDJSON(t *testing.T) {
+	result := &ValidationResult{
+		Status:       StatusFail,
+		Threshold:    0.80,
+		OverallScore: 0.62,
+		References:   &ReferenceValidation{TotalReferences: 10, Resolved: 6, ResolutionRate: 0.60},
+		Issues:       []ValidationIssue{{Category: "references", Severity: "error", Message: "low resolution"}},
+		Warnings:     []ValidationIssue{{Category: "definitions", Severity: "warning", Message: "unused terms"}},
+	}
+
+	var output bytes.Buffer
+	if err := result.WriteNDJSON(&output); err != nil {
+		t.Fatalf("WriteNDJSON failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	// Five components, one issue, one warning, and the summary.
+	if len(lines) != 8 {
+		t.Fatalf("got %d lines, want 8:\n%s", len(lines), output.String())
+	}
+
+	var kinds []string
+	for _, line := range lines {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid JSON line %s: %v", line, err)
+		}
+		kinds = append(kinds, record["record"].(string))
+	}
+	if strings.Join(kinds[5:], ",") != "issue,warning,summary" {
+		t.Errorf("record kinds = %v", kinds)
+	}
+	if !strings.Contains(lines[7], `"status":"FAIL"`) || !strings.Contains(lines[7], `"issue_count":1`) {
+		t.Errorf("summary = %s", lines[7])
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
 }
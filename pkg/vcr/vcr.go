@@ -0,0 +1,325 @@
+// Package vcr records HTTP interactions to cassette files and replays them,
+// so network-dependent commands (fetch, crawl, link checks, bulk downloads)
+// can run deterministically in tests and offline demos.
+//
+// A Recorder is an http.RoundTripper. In ModeRecord it forwards requests to
+// the real transport and collects each interaction, writing the cassette
+// when it is closed; in ModeReplay it answers requests from the cassette
+// without touching the network. Install sets it as http.DefaultTransport,
+// which every client in regula that does not inject its own transport uses.
+//
+// Secrets are redacted before an interaction is stored: the values of query
+// parameters such as key and token, URL passwords, and credential and
+// cookie headers. Replay redacts request URLs the same way, so a cassette
+// matches whichever key the replaying run uses.
+package vcr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Mode selects whether a Recorder records or replays.
+type Mode string
+
+const (
+	// ModeRecord performs real requests and records them.
+	ModeRecord Mode = "record"
+	// ModeReplay serves requests from the cassette only.
+	ModeReplay Mode = "replay"
+)
+
+const cassetteVersion = 1
+
+// Cassette is the on-disk list of recorded interactions.
+type Cassette struct {
+	Version      int            `json:"version"`
+	RecordedAt   time.Time      `json:"recorded_at"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest identifies a request by method and URL.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+// RecordedResponse is a response with its body stored as text when it is
+// valid UTF-8, or base64 otherwise (archives, PDFs).
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records to or replays from a
+// cassette file. It is safe for concurrent use.
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+	cassette  *Cassette
+
+	mu sync.Mutex
+	// replayed counts how many interactions for each request key have been
+	// served, so repeated requests replay in recorded order.
+	replayed map[string]int
+}
+
+// New creates a Recorder for the cassette at path. In ModeReplay the
+// cassette must exist; in ModeRecord any existing cassette is replaced, and
+// the recording is written by Close.
+// transport performs real requests when recording; nil uses
+// http.DefaultTransport as it is when New is called.
+func New(mode Mode, path string, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	recorder := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: transport,
+		replayed:  make(map[string]int),
+	}
+
+	switch mode {
+	case ModeRecord:
+		recorder.cassette = &Cassette{Version: cassetteVersion, RecordedAt: time.Now().UTC(), Interactions: []*Interaction{}}
+		if err := recorder.save(); err != nil {
+			return nil, err
+		}
+	case ModeReplay:
+		cassette, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		recorder.cassette = cassette
+	default:
+		return nil, fmt.Errorf("unknown vcr mode: %s (use record or replay)", mode)
+	}
+	return recorder, nil
+}
+
+// Load reads a cassette file.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	return &cassette, nil
+}
+
+// Install creates a Recorder and sets it as http.DefaultTransport. The
+// returned function restores the previous transport and closes the
+// recorder, writing the cassette when recording.
+func Install(mode Mode, path string) (*Recorder, func() error, error) {
+	previous := http.DefaultTransport
+	recorder, err := New(mode, path, previous)
+	if err != nil {
+		return nil, nil, err
+	}
+	http.DefaultTransport = recorder
+	return recorder, func() error {
+		http.DefaultTransport = previous
+		return recorder.Close()
+	}, nil
+}
+
+// Close writes the recorded interactions to the cassette. It does nothing
+// in ModeReplay.
+func (recorder *Recorder) Close() error {
+	if recorder.mode != ModeRecord {
+		return nil
+	}
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return recorder.save()
+}
+
+// Len returns the number of interactions in the cassette.
+func (recorder *Recorder) Len() int {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return len(recorder.cassette.Interactions)
+}
+
+// RoundTrip records or replays a single request.
+func (recorder *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	if recorder.mode == ModeReplay {
+		return recorder.replay(request)
+	}
+	return recorder.record(request)
+}
+
+func (recorder *Recorder) record(request *http.Request) (*http.Response, error) {
+	response, err := recorder.transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body for recording: %w", err)
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded := RecordedResponse{StatusCode: response.StatusCode, Header: redactHeader(response.Header)}
+	if utf8.Valid(body) {
+		recorded.Body = string(body)
+	} else {
+		recorded.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.cassette.Interactions = append(recorder.cassette.Interactions, &Interaction{
+		Request:  RecordedRequest{Method: request.Method, URL: redactURL(request.URL)},
+		Response: recorded,
+	})
+	return response, nil
+}
+
+// replay serves the next recorded interaction for the request's method and
+// URL. Once every recording of a request has been served, the last one is
+// served again, so retries and repeated runs still get an answer.
+func (recorder *Recorder) replay(request *http.Request) (*http.Response, error) {
+	key := request.Method + " " + redactURL(request.URL)
+
+	recorder.mu.Lock()
+	var matches []*Interaction
+	for _, interaction := range recorder.cassette.Interactions {
+		if interaction.Request.Method+" "+interaction.Request.URL == key {
+			matches = append(matches, interaction)
+		}
+	}
+	if len(matches) == 0 {
+		recorder.mu.Unlock()
+		return nil, fmt.Errorf("vcr: no recorded interaction for %s in %s", key, recorder.path)
+	}
+	index := recorder.replayed[key]
+	if index >= len(matches) {
+		index = len(matches) - 1
+	}
+	recorder.replayed[key]++
+	interaction := matches[index]
+	recorder.mu.Unlock()
+
+	if request.Body != nil {
+		request.Body.Close()
+	}
+
+	body := []byte(interaction.Response.Body)
+	if interaction.Response.BodyBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(interaction.Response.BodyBase64)
+		if err != nil {
+			return nil, fmt.Errorf("vcr: invalid recorded body for %s: %w", key, err)
+		}
+		body = decoded
+	}
+
+	header := interaction.Response.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}, nil
+}
+
+// save writes the cassette. The caller must hold mu or own the recorder
+// exclusively.
+func (recorder *Recorder) save() error {
+	if dir := filepath.Dir(recorder.path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create cassette directory: %w", err)
+		}
+	}
+	data, err := json.MarshalIndent(recorder.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+	if err := os.WriteFile(recorder.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// redacted replaces secret values in recorded URLs and headers.
+const redacted = "REDACTED"
+
+// secretQueryParams are the query parameters, compared case-insensitively,
+// whose values are redacted from recorded URLs.
+var secretQueryParams = map[string]bool{
+	"key": true, "api_key": true, "apikey": true, "api-key": true,
+	"token": true, "access_token": true, "auth": true, "password": true,
+	"secret": true, "client_secret": true, "signature": true, "sig": true,
+}
+
+// secretHeaders are the response headers whose values are redacted.
+var secretHeaders = []string{"Set-Cookie", "Authorization", "Proxy-Authorization", "Cookie", "X-Api-Key"}
+
+// redactURL returns the URL with the values of secret query parameters
+// and any password replaced. A URL without secrets is returned unchanged,
+// so its parameter order is kept.
+func redactURL(requestURL *url.URL) string {
+	redactedURL := *requestURL
+	if password, set := requestURL.User.Password(); set && password != "" {
+		redactedURL.User = url.UserPassword(requestURL.User.Username(), redacted)
+	}
+	query := requestURL.Query()
+	changed := false
+	for name, values := range query {
+		if !secretQueryParams[strings.ToLower(name)] {
+			continue
+		}
+		for i := range values {
+			values[i] = redacted
+		}
+		changed = true
+	}
+	if changed {
+		redactedURL.RawQuery = query.Encode()
+	}
+	return redactedURL.String()
+}
+
+// redactHeader returns a copy of header with secret values replaced.
+func redactHeader(header http.Header) http.Header {
+	redactedHeader := header.Clone()
+	for _, name := range secretHeaders {
+		if values := redactedHeader.Values(name); len(values) > 0 {
+			redactedHeader[http.CanonicalHeaderKey(name)] = []string{redacted}
+		}
+	}
+	return redactedHeader
+}
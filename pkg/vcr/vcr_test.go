@@ -0,0 +1,183 @@
+package vcr
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coolbeans/regula/pkg/crawler"
+)
+
+// newCountingServer serves a text page at /text, binary data at /binary,
+// and a body that changes with each request at /counter.
+func newCountingServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	counter := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/text":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html><body><p>Article 1 Subject matter</p></body></html>"))
+		case "/binary":
+			w.Write([]byte{0x50, 0x4b, 0x03, 0x04, 0xff, 0xfe})
+		case "/counter":
+			counter++
+			w.Write([]byte(strings.Repeat("x", counter)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+	response, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("reading %s failed: %v", url, err)
+	}
+	return response.StatusCode, string(body)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	server := newCountingServer(t)
+	cassettePath := filepath.Join(t.TempDir(), "cassettes", "session.json")
+
+	recorder, err := New(ModeRecord, cassettePath, nil)
+	if err != nil {
+		t.Fatalf("New(record) failed: %v", err)
+	}
+	recordClient := &http.Client{Transport: recorder}
+	paths := []string{"/text", "/binary", "/counter", "/counter", "/missing"}
+	recorded := make([]string, len(paths))
+	for i, path := range paths {
+		_, recorded[i] = get(t, recordClient, server.URL+path)
+	}
+	if recorder.Len() != len(paths) {
+		t.Fatalf("recorded %d interactions, want %d", recorder.Len(), len(paths))
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// Replay must not reach the server
+	server.Close()
+
+	replayer, err := New(ModeReplay, cassettePath, nil)
+	if err != nil {
+		t.Fatalf("New(replay) failed: %v", err)
+	}
+	replayClient := &http.Client{Transport: replayer}
+	for i, path := range paths {
+		status, body := get(t, replayClient, server.URL+path)
+		if body != recorded[i] {
+			t.Errorf("replayed %s = %q, want %q", path, body, recorded[i])
+		}
+		if path == "/missing" && status != http.StatusNotFound {
+			t.Errorf("replayed /missing status = %d, want 404", status)
+		}
+	}
+
+	// Exhausted recordings repeat the last one
+	if _, body := get(t, replayClient, server.URL+"/counter"); body != "xx" {
+		t.Errorf("repeated /counter = %q, want the last recording", body)
+	}
+
+	if _, err := replayClient.Get(server.URL + "/never"); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("unrecorded request error = %v", err)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New(ModeReplay, filepath.Join(t.TempDir(), "absent.json"), nil); err == nil {
+		t.Error("expected an error replaying a missing cassette")
+	}
+	if _, err := New("rewind", filepath.Join(t.TempDir(), "c.json"), nil); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
+
+func TestInstallCoversDefaultClients(t *testing.T) {
+	server := newCountingServer(t)
+	cassettePath := filepath.Join(t.TempDir(), "crawl.json")
+	fetcher := crawler.NewContentFetcher(crawler.CrawlConfig{})
+
+	_, restore, err := Install(ModeRecord, cassettePath)
+	if err != nil {
+		t.Fatalf("Install(record) failed: %v", err)
+	}
+	live, err := fetcher.Fetch(server.URL + "/text")
+	if closeErr := restore(); closeErr != nil {
+		t.Fatalf("saving the cassette failed: %v", closeErr)
+	}
+	if err != nil {
+		t.Fatalf("live fetch failed: %v", err)
+	}
+	server.Close()
+
+	_, restore, err = Install(ModeReplay, cassettePath)
+	if err != nil {
+		t.Fatalf("Install(replay) failed: %v", err)
+	}
+	defer restore()
+	replayed, err := crawler.NewContentFetcher(crawler.CrawlConfig{}).Fetch(server.URL + "/text")
+	if err != nil {
+		t.Fatalf("replayed fetch failed: %v", err)
+	}
+	if string(replayed.PlainText) != string(live.PlainText) || !strings.Contains(string(replayed.PlainText), "Article 1") {
+		t.Errorf("replayed text %q, want %q", replayed.PlainText, live.PlainText)
+	}
+}
+
+func TestRecordRedactsSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "session-secret"})
+		w.Write([]byte("law text"))
+	}))
+	defer server.Close()
+	cassettePath := filepath.Join(t.TempDir(), "secrets.json")
+
+	recorder, err := New(ModeRecord, cassettePath, nil)
+	if err != nil {
+		t.Fatalf("New(record) failed: %v", err)
+	}
+	get(t, &http.Client{Transport: recorder}, server.URL+"/law?full=true&key=api-secret")
+
+	// Nothing beyond the empty cassette is written until Close.
+	if cassette, err := Load(cassettePath); err != nil || len(cassette.Interactions) != 0 {
+		t.Fatalf("cassette before Close = %+v, %v; want it empty", cassette, err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	data, err := os.ReadFile(cassettePath)
+	if err != nil {
+		t.Fatalf("reading the cassette failed: %v", err)
+	}
+	for _, secret := range []string{"api-secret", "session-secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+
+	// Replay matches whichever key the replaying run sends.
+	server.Close()
+	replayer, err := New(ModeReplay, cassettePath, nil)
+	if err != nil {
+		t.Fatalf("New(replay) failed: %v", err)
+	}
+	if _, body := get(t, &http.Client{Transport: replayer}, server.URL+"/law?full=true&key=other-key"); body != "law text" {
+		t.Errorf("replayed body = %q, want the recorded law text", body)
+	}
+}
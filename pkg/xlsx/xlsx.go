@@ -0,0 +1,394 @@
+// Package xlsx writes Office Open XML spreadsheets (.xlsx) for reports:
+// one sheet per section, each with a bold, shaded header row that stays
+// frozen while scrolling and columns sized to their content.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Sheet name and cell limits imposed by spreadsheet applications.
+const (
+	maxSheetNameLength = 31
+	maxCellLength      = 32767
+	maxColumnWidth     = 80
+	minColumnWidth     = 8
+)
+
+// Cell styles, indexes into the cellXfs of styles.xml.
+const (
+	styleDefault = 0
+	styleHeader  = 1
+	stylePercent = 2
+)
+
+// Percent is a fraction displayed as a percentage, such as 0.853 as 85.3%.
+type Percent float64
+
+// Workbook is a spreadsheet of one or more sheets.
+type Workbook struct {
+	sheets []*Sheet
+}
+
+// Sheet is a table with a header row.
+type Sheet struct {
+	name   string
+	header []string
+	rows   [][]any
+}
+
+// NewWorkbook creates an empty workbook.
+func NewWorkbook() *Workbook {
+	return &Workbook{}
+}
+
+// AddSheet adds a sheet with the given header row. Characters a sheet name
+// may not contain are replaced, long names are shortened, and a name
+// already in use gets a numeric suffix.
+func (w *Workbook) AddSheet(name string, header ...string) *Sheet {
+	sheet := &Sheet{name: w.uniqueName(name), header: header}
+	w.sheets = append(w.sheets, sheet)
+	return sheet
+}
+
+// AddRow appends a row. Strings are written as text; integers, floats, and
+// Percent as numbers; bools as booleans; times in RFC 3339; nil as an
+// empty cell; and other values as formatted by fmt.
+func (s *Sheet) AddRow(values ...any) {
+	s.rows = append(s.rows, values)
+}
+
+// Len returns the number of rows below the header.
+func (s *Sheet) Len() int {
+	return len(s.rows)
+}
+
+func (w *Workbook) uniqueName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) || r < ' ' {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	name = strings.Trim(name, "'")
+	if name == "" {
+		name = fmt.Sprintf("Sheet%d", len(w.sheets)+1)
+	}
+	name = truncateRunes(name, maxSheetNameLength)
+
+	candidate := name
+	for suffix := 2; w.hasSheet(candidate); suffix++ {
+		tail := fmt.Sprintf(" (%d)", suffix)
+		candidate = truncateRunes(name, maxSheetNameLength-len(tail)) + tail
+	}
+	return candidate
+}
+
+func (w *Workbook) hasSheet(name string) bool {
+	for _, sheet := range w.sheets {
+		if strings.EqualFold(sheet.name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Bytes returns the workbook as an .xlsx file.
+func (w *Workbook) Bytes() ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := w.Write(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Write writes the workbook to out as an .xlsx file. A workbook without
+// sheets is written with one empty sheet, as spreadsheets need one.
+func (w *Workbook) Write(out io.Writer) error {
+	sheets := w.sheets
+	if len(sheets) == 0 {
+		sheets = []*Sheet{{name: "Sheet1"}}
+	}
+
+	archive := zip.NewWriter(out)
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes(len(sheets))},
+		{"_rels/.rels", rootRelationships},
+		{"xl/workbook.xml", workbookXML(sheets)},
+		{"xl/_rels/workbook.xml.rels", workbookRelationships(len(sheets))},
+		{"xl/styles.xml", stylesXML},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, struct {
+			name    string
+			content string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml()})
+	}
+
+	for _, part := range parts {
+		writer, err := archive.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(writer, part.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+	return archive.Close()
+}
+
+// xml renders the sheet's worksheet part.
+func (s *Sheet) xml() string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	if len(s.header) > 0 {
+		sb.WriteString(`<sheetViews><sheetView workbookViewId="0">`)
+		sb.WriteString(`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`)
+		sb.WriteString(`<selection pane="bottomLeft"/>`)
+		sb.WriteString(`</sheetView></sheetViews>`)
+	}
+
+	if widths := s.columnWidths(); len(widths) > 0 {
+		sb.WriteString("<cols>")
+		for i, width := range widths {
+			fmt.Fprintf(&sb, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width)
+		}
+		sb.WriteString("</cols>")
+	}
+
+	sb.WriteString("<sheetData>")
+	rowNumber := 0
+	if len(s.header) > 0 {
+		rowNumber++
+		fmt.Fprintf(&sb, `<row r="%d">`, rowNumber)
+		for column, name := range s.header {
+			writeCell(&sb, cellReference(column, rowNumber), name, styleHeader)
+		}
+		sb.WriteString("</row>")
+	}
+	for _, row := range s.rows {
+		rowNumber++
+		fmt.Fprintf(&sb, `<row r="%d">`, rowNumber)
+		for column, value := range row {
+			writeCell(&sb, cellReference(column, rowNumber), value, styleDefault)
+		}
+		sb.WriteString("</row>")
+	}
+	sb.WriteString("</sheetData></worksheet>")
+	return sb.String()
+}
+
+// columnWidths sizes each column to its longest value, in characters.
+func (s *Sheet) columnWidths() []int {
+	var widths []int
+	measure := func(column int, text string) {
+		for len(widths) <= column {
+			widths = append(widths, minColumnWidth)
+		}
+		longest := 0
+		for _, line := range strings.Split(text, "\n") {
+			longest = max(longest, utf8.RuneCountInString(line))
+		}
+		widths[column] = min(max(widths[column], longest+2), maxColumnWidth)
+	}
+	for column, name := range s.header {
+		measure(column, name)
+	}
+	for _, row := range s.rows {
+		for column, value := range row {
+			measure(column, cellText(value))
+		}
+	}
+	return widths
+}
+
+// writeCell writes one <c> element; empty values are omitted.
+func writeCell(sb *strings.Builder, reference string, value any, style int) {
+	var number string
+	switch v := value.(type) {
+	case nil:
+		return
+	case int:
+		number = strconv.Itoa(v)
+	case int64:
+		number = strconv.FormatInt(v, 10)
+	case int32:
+		number = strconv.FormatInt(int64(v), 10)
+	case uint:
+		number = strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		number = strconv.FormatUint(v, 10)
+	case float64:
+		number = formatFloat(v)
+	case float32:
+		number = formatFloat(float64(v))
+	case Percent:
+		number = formatFloat(float64(v))
+		style = stylePercent
+	case bool:
+		fmt.Fprintf(sb, `<c r="%s" t="b"`, reference)
+		writeStyle(sb, style)
+		if v {
+			sb.WriteString("><v>1</v></c>")
+		} else {
+			sb.WriteString("><v>0</v></c>")
+		}
+		return
+	}
+
+	if number != "" {
+		fmt.Fprintf(sb, `<c r="%s"`, reference)
+		writeStyle(sb, style)
+		fmt.Fprintf(sb, "><v>%s</v></c>", number)
+		return
+	}
+
+	text := cellText(value)
+	if text == "" {
+		return
+	}
+	fmt.Fprintf(sb, `<c r="%s" t="inlineStr"`, reference)
+	writeStyle(sb, style)
+	sb.WriteString(`><is><t xml:space="preserve">`)
+	xml.EscapeText(sb, []byte(text))
+	sb.WriteString("</t></is></c>")
+}
+
+func writeStyle(sb *strings.Builder, style int) {
+	if style != styleDefault {
+		fmt.Fprintf(sb, ` s="%d"`, style)
+	}
+}
+
+// formatFloat formats a number cell value; NaN and infinities, which a
+// number cell cannot hold, yield "".
+func formatFloat(value float64) string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// cellText returns the text of a value written as a string cell.
+func cellText(value any) string {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		text = v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		text = v.Format(time.RFC3339)
+	case Percent:
+		text = fmt.Sprintf("%.1f%%", float64(v)*100)
+	default:
+		text = fmt.Sprint(v)
+	}
+	return truncateRunes(text, maxCellLength)
+}
+
+// cellReference returns the A1 reference of a zero-based column and a
+// one-based row.
+func cellReference(column, row int) string {
+	var letters []byte
+	for column++; column > 0; column = (column - 1) / 26 {
+		letters = append([]byte{byte('A' + (column-1)%26)}, letters...)
+	}
+	return string(letters) + strconv.Itoa(row)
+}
+
+func truncateRunes(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	return string([]rune(text)[:limit])
+}
+
+func contentTypes(sheetCount int) string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	sb.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	sb.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	sb.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	sb.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&sb, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	sb.WriteString(`</Types>`)
+	return sb.String()
+}
+
+const rootRelationships = xml.Header +
+	`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+func workbookXML(sheets []*Sheet) string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, sheet := range sheets {
+		sb.WriteString(`<sheet name="`)
+		xml.EscapeText(&sb, []byte(sheet.name))
+		fmt.Fprintf(&sb, `" sheetId="%d" r:id="rId%d"/>`, i+1, i+1)
+	}
+	sb.WriteString(`</sheets></workbook>`)
+	return sb.String()
+}
+
+func workbookRelationships(sheetCount int) string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&sb, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&sb, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, sheetCount+1)
+	sb.WriteString(`</Relationships>`)
+	return sb.String()
+}
+
+// stylesXML defines the default style, the header style (bold on a light
+// blue fill with a bottom border), and a one-decimal percentage format.
+const stylesXML = xml.Header +
+	`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="0.0%"/></numFmts>` +
+	`<fonts count="2">` +
+	`<font><sz val="11"/><name val="Calibri"/></font>` +
+	`<font><b/><sz val="11"/><name val="Calibri"/></font>` +
+	`</fonts>` +
+	`<fills count="3">` +
+	`<fill><patternFill patternType="none"/></fill>` +
+	`<fill><patternFill patternType="gray125"/></fill>` +
+	`<fill><patternFill patternType="solid"><fgColor rgb="FFD9E1F2"/><bgColor indexed="64"/></patternFill></fill>` +
+	`</fills>` +
+	`<borders count="2">` +
+	`<border><left/><right/><top/><bottom/><diagonal/></border>` +
+	`<border><left/><right/><top/><bottom style="thin"><color auto="1"/></bottom><diagonal/></border>` +
+	`</borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="2" borderId="1" xfId="0" applyFont="1" applyFill="1" applyBorder="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
@@ -0,0 +1,146 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readParts unzips a workbook into its parts, checking each is well-formed
+// XML.
+func readParts(t *testing.T, workbook *Workbook) map[string]string {
+	t.Helper()
+	data, err := workbook.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("workbook is not a zip archive: %v", err)
+	}
+
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoder := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s is not well-formed XML: %v", file.Name, err)
+			}
+		}
+		parts[file.Name] = string(content)
+	}
+	return parts
+}
+
+func TestWorkbook_Write(t *testing.T) {
+	workbook := NewWorkbook()
+	summary := workbook.AddSheet("Summary", "Metric", "Value")
+	summary.AddRow("Overall score", Percent(0.853))
+	summary.AddRow("Articles", 99)
+	summary.AddRow("Passed", true)
+	results := workbook.AddSheet("Results", "article", "title", "ingested")
+	results.AddRow("GDPR:Art17", `Right to "erasure" <& forgotten>`, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	results.AddRow("GDPR:Art18", nil, time.Time{})
+
+	parts := readParts(t, workbook)
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels",
+		"xl/styles.xml", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("workbook missing part %s", name)
+		}
+	}
+	if !strings.Contains(parts["xl/workbook.xml"], `<sheet name="Summary" sheetId="1" r:id="rId1"/>`) {
+		t.Errorf("workbook.xml = %s", parts["xl/workbook.xml"])
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`,
+		`<c r="A1" t="inlineStr" s="1"><is><t xml:space="preserve">Metric</t></is></c>`,
+		`<c r="B2" s="2"><v>0.853</v></c>`,
+		`<c r="B3"><v>99</v></c>`,
+		`<c r="B4" t="b"><v>1</v></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet1 missing %s:\n%s", want, sheet)
+		}
+	}
+
+	sheet = parts["xl/worksheets/sheet2.xml"]
+	if !strings.Contains(sheet, "Right to &#34;erasure&#34; &lt;&amp; forgotten&gt;") {
+		t.Errorf("text not escaped:\n%s", sheet)
+	}
+	if !strings.Contains(sheet, "2026-01-02T03:04:05Z") {
+		t.Errorf("time not written in RFC 3339:\n%s", sheet)
+	}
+	if strings.Contains(sheet, `r="B3"`) || strings.Contains(sheet, `r="C3"`) {
+		t.Errorf("empty values should be omitted:\n%s", sheet)
+	}
+}
+
+func TestWorkbook_SheetNames(t *testing.T) {
+	workbook := NewWorkbook()
+	names := []string{
+		workbook.AddSheet("Results").name,
+		workbook.AddSheet("results").name,
+		workbook.AddSheet("a/b: [c]?").name,
+		workbook.AddSheet(strings.Repeat("x", 40)).name,
+		workbook.AddSheet(strings.Repeat("x", 40)).name,
+		workbook.AddSheet("").name,
+	}
+	want := []string{"Results", "results (2)", "a-b- -c--", strings.Repeat("x", 31), strings.Repeat("x", 27) + " (2)", "Sheet6"}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("sheet %d name = %q, want %q", i, names[i], want[i])
+		}
+	}
+}
+
+func TestWorkbook_Empty(t *testing.T) {
+	parts := readParts(t, NewWorkbook())
+	if _, ok := parts["xl/worksheets/sheet1.xml"]; !ok {
+		t.Error("an empty workbook should still have one sheet")
+	}
+}
+
+func TestCellReference(t *testing.T) {
+	tests := []struct {
+		column, row int
+		want        string
+	}{
+		{0, 1, "A1"},
+		{25, 2, "Z2"},
+		{26, 3, "AA3"},
+		{701, 4, "ZZ4"},
+		{702, 5, "AAA5"},
+	}
+	for _, tt := range tests {
+		if got := cellReference(tt.column, tt.row); got != tt.want {
+			t.Errorf("cellReference(%d, %d) = %s, want %s", tt.column, tt.row, got, tt.want)
+		}
+	}
+}
+
+func TestWriteCell_NonFinite(t *testing.T) {
+	var sb strings.Builder
+	writeCell(&sb, "A1", math.NaN(), styleDefault)
+	if !strings.Contains(sb.String(), `t="inlineStr"`) {
+		t.Errorf("NaN should be written as text, got %s", sb.String())
+	}
+}